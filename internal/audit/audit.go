@@ -0,0 +1,92 @@
+// Package audit gathers what the audit log records about a request while it is being served.
+// The audit middleware attaches a Trail to the context of each request, and the layers serving it
+// note who made the request, the operation it runs and, when access is refused, why.
+// Outside an audited request, such as in the CLI or background jobs, every function is a no-op.
+package audit
+
+import (
+	"context"
+	"sync"
+)
+
+// Trail collects the audit details of a single request.
+type Trail struct {
+	mu        sync.Mutex
+	actor     string
+	operation string
+	filePath  string
+	denial    string
+}
+
+// Details is a snapshot of the details collected by a Trail.
+// Denial is empty unless access was refused while serving the request.
+type Details struct {
+	Actor     string
+	Operation string
+	FilePath  string
+	Denial    string
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying trail.
+func NewContext(ctx context.Context, trail *Trail) context.Context {
+	return context.WithValue(ctx, contextKey{}, trail)
+}
+
+func fromContext(ctx context.Context) *Trail {
+	trail, _ := ctx.Value(contextKey{}).(*Trail)
+	return trail
+}
+
+// SetActor records who made the request, such as "tenant:acme" or "admin".
+func SetActor(ctx context.Context, actor string) {
+	if trail := fromContext(ctx); trail != nil {
+		trail.mu.Lock()
+		defer trail.mu.Unlock()
+		trail.actor = actor
+	}
+}
+
+// Describe records the operation the request runs and the file it reads, if any.
+func Describe(ctx context.Context, operation, filePath string) {
+	if trail := fromContext(ctx); trail != nil {
+		trail.mu.Lock()
+		defer trail.mu.Unlock()
+		trail.operation = operation
+		trail.filePath = filePath
+	}
+}
+
+// Deny records that access was refused while serving the request, such as a rejected API key
+// or a file path outside the caller's reach. Only the first reason is kept.
+func Deny(ctx context.Context, reason string) {
+	if trail := fromContext(ctx); trail != nil {
+		trail.mu.Lock()
+		defer trail.mu.Unlock()
+		if trail.denial == "" {
+			trail.denial = reason
+		}
+	}
+}
+
+// DetailsFromContext returns the details collected so far by the trail carried by ctx,
+// or no details without one.
+func DetailsFromContext(ctx context.Context) Details {
+	if trail := fromContext(ctx); trail != nil {
+		return trail.Details()
+	}
+	return Details{}
+}
+
+// Details returns the details collected so far.
+func (t *Trail) Details() Details {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Details{
+		Actor:     t.actor,
+		Operation: t.operation,
+		FilePath:  t.filePath,
+		Denial:    t.denial,
+	}
+}
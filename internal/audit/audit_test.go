@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrail(t *testing.T) {
+	trail := &Trail{}
+	ctx := NewContext(context.Background(), trail)
+
+	SetActor(ctx, "tenant:acme")
+	Describe(ctx, "sum", "testdata/matrix1.csv")
+	Deny(ctx, "path traversal not allowed")
+	Deny(ctx, "file not found")
+
+	assert.Equal(t, Details{
+		Actor:     "tenant:acme",
+		Operation: "sum",
+		FilePath:  "testdata/matrix1.csv",
+		Denial:    "path traversal not allowed",
+	}, trail.Details())
+	assert.Equal(t, trail.Details(), DetailsFromContext(ctx))
+}
+
+func TestWithoutTrail(t *testing.T) {
+	// Contexts of unaudited work are left alone
+	assert.NotPanics(t, func() {
+		SetActor(context.Background(), "admin")
+		Describe(context.Background(), "sum", "")
+		Deny(context.Background(), "denied")
+	})
+	assert.Equal(t, Details{}, DetailsFromContext(context.Background()))
+}
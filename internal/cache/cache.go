@@ -0,0 +1,201 @@
+// Package cache provides a bounded in-memory cache, evicting its least recently used entries once full and
+// expiring entries a fixed time after they were put, so repeated work can be skipped without holding stale
+// values or unbounded memory.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Config bounds a cache. Entries are evicted least recently used first once MaxEntries are held, and expire
+// TTL after they were put, however often they are used. A zero TTL keeps entries until they are evicted.
+// Now returns the current time; it defaults to time.Now and tests replace it to expire entries.
+type Config struct {
+	MaxEntries int
+	TTL        time.Duration
+	Now        func() time.Time
+}
+
+// Stats reports the lookups of a cache by outcome and the entries it holds.
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// HitRatio returns the share of lookups that were hits, or 0 before any lookup.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// entry is a cached value, with the time it expires at.
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// Cache is an LRU cache of values of type V by keys of type K, safe for concurrent use.
+// A nil cache holds nothing: Get always misses, without counting the lookup, and Put does nothing.
+type Cache[K comparable, V any] struct {
+	config Config
+
+	mu sync.Mutex
+	// order holds the entries from the most to the least recently used
+	order   *list.List
+	entries map[K]*list.Element
+	hits    uint64
+	misses  uint64
+}
+
+// New creates a cache bounded by config, or returns nil when config.MaxEntries is not positive,
+// which disables caching.
+func New[K comparable, V any](config Config) *Cache[K, V] {
+	if config.MaxEntries <= 0 {
+		return nil
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Cache[K, V]{
+		config:  config,
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value cached for key, if it has not expired, and marks it as the most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var zero V
+	if c == nil {
+		return zero, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if ok && c.expired(element.Value.(*entry[K, V])) {
+		c.remove(element)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return zero, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*entry[K, V]).value, true
+}
+
+// Put caches value for key, replacing any value cached for it, and evicts the least recently used entries
+// beyond the size bound.
+func (c *Cache[K, V]) Put(key K, value V) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &entry[K, V]{key: key, value: value}
+	if c.config.TTL > 0 {
+		e.expires = c.config.Now().Add(c.config.TTL)
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value = e
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(e)
+	for c.order.Len() > c.config.MaxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// Remove forgets the value cached for key, if any.
+func (c *Cache[K, V]) Remove(key K) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// RemoveFunc forgets the values cached for the keys match reports true for, and returns how many it forgot.
+func (c *Cache[K, V]) RemoveFunc(match func(key K) bool) int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, element := range c.entries {
+		if match(key) {
+			c.remove(element)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Clear forgets every cached value and returns how many it forgot. Lookups counted so far are kept.
+func (c *Cache[K, V]) Clear() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := c.order.Len()
+	c.order.Init()
+	clear(c.entries)
+	return removed
+}
+
+// Len returns the number of entries held, including expired entries not looked up since they expired.
+func (c *Cache[K, V]) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the lookups of the cache by outcome and the entries it holds.
+func (c *Cache[K, V]) Stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Hits: c.hits, Misses: c.misses, Entries: c.order.Len()}
+}
+
+// expired reports whether e has expired. The caller holds the lock.
+func (c *Cache[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expires.IsZero() && !c.config.Now().Before(e.expires)
+}
+
+// remove forgets the entry held by element. The caller holds the lock.
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
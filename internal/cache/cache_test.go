@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := New[string, int](Config{MaxEntries: 2, TTL: time.Minute, Now: func() time.Time { return now }})
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Put("a", 1)
+	got, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+
+	// Putting a key again replaces its value
+	c.Put("a", 2)
+	got, _ = c.Get("a")
+	assert.Equal(t, 2, got)
+
+	// The least recently used entry is evicted first
+	c.Put("b", 3)
+	c.Get("a")
+	c.Put("c", 4)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+
+	c.Remove("c")
+	_, ok = c.Get("c")
+	assert.False(t, ok)
+
+	// Entries expire TTL after they were put, however often they are used
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+
+	stats := c.Stats()
+	assert.Equal(t, Stats{Hits: 4, Misses: 4, Entries: 0}, stats)
+	assert.Equal(t, 0.5, stats.HitRatio())
+}
+
+func TestCache_NoTTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := New[string, int](Config{MaxEntries: 1, Now: func() time.Time { return now }})
+
+	c.Put("a", 1)
+	now = now.Add(24 * time.Hour)
+	got, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+}
+
+func TestCache_RemoveFunc(t *testing.T) {
+	c := New[string, int](Config{MaxEntries: 4})
+	c.Put("a1", 1)
+	c.Put("a2", 2)
+	c.Put("b1", 3)
+
+	removed := c.RemoveFunc(func(key string) bool { return key[0] == 'a' })
+
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, 1, c.Len())
+	_, ok := c.Get("b1")
+	assert.True(t, ok)
+
+	assert.Equal(t, 1, c.Clear())
+	assert.Equal(t, 0, c.Len())
+	_, ok = c.Get("b1")
+	assert.False(t, ok)
+
+	// A cleared cache keeps caching
+	c.Put("a1", 1)
+	got, ok := c.Get("a1")
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+}
+
+func TestCache_Disabled(t *testing.T) {
+	c := New[string, int](Config{MaxEntries: 0, TTL: time.Minute})
+	assert.Nil(t, c)
+
+	// A nil cache holds nothing, without counting lookups
+	c.Put("a", 1)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	c.Remove("a")
+	assert.Equal(t, 0, c.RemoveFunc(func(string) bool { return true }))
+	assert.Equal(t, 0, c.Clear())
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, Stats{}, c.Stats())
+	assert.Equal(t, 0.0, c.Stats().HitRatio())
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	c := New[int, int](Config{MaxEntries: 16, TTL: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 100 {
+				c.Put(j%32, i)
+				c.Get(j % 32)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, 16, c.Len())
+	assert.Equal(t, uint64(800), c.Stats().Hits+c.Stats().Misses)
+}
@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+const (
+	// defaultBenchRequests is the number of requests sent for each operation unless --requests is given.
+	defaultBenchRequests = 1000
+
+	// defaultBenchConcurrency is the number of requests in flight unless --concurrency is given.
+	defaultBenchConcurrency = 4
+
+	// benchValueRange bounds the absolute value of the generated matrix values.
+	benchValueRange = 1000
+
+	// benchRequestTimeout bounds each request sent to a server.
+	benchRequestTimeout = 30 * time.Second
+)
+
+// benchOptions holds the flags of the bench subcommand.
+type benchOptions struct {
+	url         string
+	apiKey      string
+	size        string
+	requests    int
+	concurrency int
+	seed        uint64
+}
+
+// benchTarget runs one operation on the benchmarked matrix.
+type benchTarget func(ctx context.Context, operation string) error
+
+// benchReport summarizes the requests sent for one operation.
+type benchReport struct {
+	operation string
+	requests  int
+	errors    int
+	firstErr  error
+	elapsed   time.Duration
+	latencies []time.Duration // sorted, successful and failed requests alike
+}
+
+func newBenchCommand(matrixDomain domain.MatrixDomainInterface) *cobra.Command {
+	options := &benchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "bench [operation...]",
+		Short: "Measure the throughput and latency of matrix operations",
+		Long: "Run matrix operations repeatedly on a generated matrix and report their throughput and latency percentiles.\n" +
+			"Operations run in process through the domain, or against a running server when --url is given, in which case\n" +
+			"the matrix is POSTed as JSON to /matrix/<operation>. Every operation is measured when none is named.\n" +
+			"The command fails when any request fails, so it can guard against regressions in scripts.",
+		Example: "  " + commandName + " bench\n" +
+			"  " + commandName + " bench sum multiply --size 5x5 --requests 10000\n" +
+			"  " + commandName + " bench transpose inverse --url http://localhost:8080 --concurrency 16",
+		Args: usageArgs(cobra.ArbitraryArgs),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeOperations(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return bench(cmd.Context(), matrixDomain, args, options, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&options.url, "url", "", "base URL of a running server; operations run in process when empty")
+	cmd.Flags().StringVar(&options.apiKey, "api-key", "", "API key sent to the server when tenants are configured")
+	cmd.Flags().StringVar(&options.size, "size", fmt.Sprintf("%dx%d", matrixlib.MaxRows, matrixlib.MaxCols),
+		"dimensions of the generated matrix, as ROWSxCOLS")
+	cmd.Flags().IntVarP(&options.requests, "requests", "n", defaultBenchRequests, "number of requests per operation")
+	cmd.Flags().IntVarP(&options.concurrency, "concurrency", "c", defaultBenchConcurrency, "number of requests in flight")
+	cmd.Flags().Uint64Var(&options.seed, "seed", 1, "seed of the generated matrix values")
+	for _, flag := range []string{"url", "api-key", "size", "requests", "concurrency", "seed"} {
+		mustRegisterFlagCompletion(cmd, flag, cobra.NoFileCompletions)
+	}
+	return cmd
+}
+
+// bench measures each operation in turn and writes a report table to w.
+func bench(ctx context.Context, matrixDomain domain.MatrixDomainInterface, operations []string,
+	options *benchOptions, w io.Writer) error {
+	rows, cols, err := parseSize(options.size)
+	if err != nil {
+		return err
+	}
+	if len(operations) == 0 {
+		operations = operationNames()
+		// Only square matrices have an inverse, so it is measured on others only when asked for
+		if rows != cols {
+			operations = slices.DeleteFunc(operations, func(operation string) bool {
+				return operation == string(matrixlib.Inverse)
+			})
+		}
+	}
+	for _, operation := range operations {
+		if err := matrixlib.ValidateOperation(operation); err != nil {
+			return err
+		}
+	}
+	if options.requests < 1 || options.concurrency < 1 {
+		return fmt.Errorf("%w: --requests and --concurrency must be positive", apperrors.ErrInvalidInput)
+	}
+
+	matrix := generateMatrix(rows, cols, options.seed)
+
+	target := domainTarget(matrixDomain, matrix)
+	if options.url != "" {
+		target, err = serverTarget(options.url, options.apiKey, matrix)
+		if err != nil {
+			return err
+		}
+	}
+
+	reports := make([]*benchReport, 0, len(operations))
+	for _, operation := range operations {
+		report, err := runBench(ctx, target, operation, options.requests, options.concurrency)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	if err := writeBenchReports(w, rows, cols, reports); err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		if report.errors > 0 {
+			return fmt.Errorf("%d of %d %s requests failed, first error: %w",
+				report.errors, report.requests, report.operation, report.firstErr)
+		}
+	}
+	return nil
+}
+
+// parseSize parses matrix dimensions given as ROWSxCOLS, which must be within the matrix limits.
+func parseSize(size string) (int, int, error) {
+	rows, cols, err := parseDimensions(size)
+	if err != nil {
+		return 0, 0, err
+	}
+	if rows < 1 || rows > matrixlib.MaxRows || cols < 1 || cols > matrixlib.MaxCols {
+		return 0, 0, fmt.Errorf("%w: invalid size %q: must be between 1x1 and %dx%d",
+			apperrors.ErrInvalidInput, size, matrixlib.MaxRows, matrixlib.MaxCols)
+	}
+	return rows, cols, nil
+}
+
+// parseDimensions parses matrix dimensions given as ROWSxCOLS, without checking them against the matrix limits.
+func parseDimensions(size string) (int, int, error) {
+	rowsStr, colsStr, ok := strings.Cut(strings.ToLower(size), "x")
+	rows, rowsErr := strconv.Atoi(rowsStr)
+	cols, colsErr := strconv.Atoi(colsStr)
+	if !ok || rowsErr != nil || colsErr != nil {
+		return 0, 0, fmt.Errorf("%w: invalid size %q: expected ROWSxCOLS", apperrors.ErrInvalidInput, size)
+	}
+	return rows, cols, nil
+}
+
+// generateMatrix builds a rows x cols matrix of pseudo-random values, reproducible for a given seed.
+func generateMatrix(rows, cols int, seed uint64) *entity.Matrix[int64] {
+	random := rand.New(rand.NewPCG(seed, seed))
+	data := make([][]int64, rows)
+	for i := range data {
+		data[i] = make([]int64, cols)
+		for j := range data[i] {
+			data[i][j] = random.Int64N(2*benchValueRange+1) - benchValueRange
+		}
+	}
+	return &entity.Matrix[int64]{Data: data}
+}
+
+// domainTarget runs operations in process, through the same domain as the compute command.
+func domainTarget(matrixDomain domain.MatrixDomainInterface, matrix *entity.Matrix[int64]) benchTarget {
+	return func(ctx context.Context, operation string) error {
+		_, err := matrixDomain.ProcessMatrixData(ctx, operation, matrix, nil)
+		return err
+	}
+}
+
+// serverTarget POSTs the matrix to a running server, authenticating with apiKey when it is set.
+func serverTarget(baseURL string, apiKey string, matrix *entity.Matrix[int64]) (benchTarget, error) {
+	body, err := json.Marshal(map[string][][]int64{"rows": matrix.Data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode matrix: %w", err)
+	}
+
+	client := &http.Client{Timeout: benchRequestTimeout}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return func(ctx context.Context, operation string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/matrix/"+operation, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("%w: invalid server URL: %v", apperrors.ErrInvalidInput, err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("%w: request failed: %v", apperrors.ErrServiceUnavailable, err)
+		}
+		defer resp.Body.Close()
+
+		// Read the whole response, so the measured latency covers the transfer of the result
+		if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+			return fmt.Errorf("%w: failed to read response: %v", apperrors.ErrServiceUnavailable, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("server responded with %s", resp.Status)
+		}
+		return nil
+	}, nil
+}
+
+// runBench sends requests for operation to target from concurrency workers and measures each one.
+func runBench(ctx context.Context, target benchTarget, operation string, requests int, concurrency int) (*benchReport, error) {
+	report := &benchReport{
+		operation: operation,
+		requests:  requests,
+		latencies: make([]time.Duration, requests),
+	}
+
+	var (
+		next     atomic.Int64
+		failures atomic.Int64
+		errOnce  sync.Once
+		wg       sync.WaitGroup
+	)
+	start := time.Now()
+	for range min(concurrency, requests) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= requests || ctx.Err() != nil {
+					return
+				}
+
+				began := time.Now()
+				err := target(ctx, operation)
+				report.latencies[i] = time.Since(began)
+				if err != nil {
+					failures.Add(1)
+					errOnce.Do(func() { report.firstErr = err })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	report.elapsed = time.Since(start)
+
+	// An interrupted run reports nothing, as its figures would be misleading
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report.errors = int(failures.Load())
+	slices.Sort(report.latencies)
+	return report, nil
+}
+
+// percentile returns the nearest-rank percentile p, between 0 and 100, of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(float64(len(sorted))*p/100)) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// writeBenchReports writes one row per operation with its throughput and latency percentiles.
+func writeBenchReports(w io.Writer, rows, cols int, reports []*benchReport) error {
+	fmt.Fprintf(w, "matrix %dx%d\n", rows, cols)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "operation\trequests\terrors\treq/s\tp50\tp90\tp99\tmax\t")
+	for _, report := range reports {
+		fmt.Fprintf(table, "%s\t%d\t%d\t%.0f\t%s\t%s\t%s\t%s\t\n",
+			report.operation,
+			report.requests,
+			report.errors,
+			float64(report.requests)/report.elapsed.Seconds(),
+			percentile(report.latencies, 50),
+			percentile(report.latencies, 90),
+			percentile(report.latencies, 99),
+			percentile(report.latencies, 100))
+	}
+	return table.Flush()
+}
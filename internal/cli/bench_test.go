@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestBenchCommand(t *testing.T) {
+	t.Run("measures the named operations in process", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrixData", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "1"}, nil).Times(20)
+		mockDomain.On("ProcessMatrixData", mock.Anything, "invert", mock.Anything, (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "1"}, nil).Times(20)
+
+		got, err := runCommand(t, mockDomain, "", "bench", "sum", "invert", "--size", "3x2", "-n", "20", "-c", "3")
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(got), "\n")
+		assert.Len(t, lines, 4)
+		assert.Equal(t, "matrix 3x2", lines[0])
+		assert.Equal(t, []string{"operation", "requests", "errors", "req/s", "p50", "p90", "p99", "max"}, strings.Fields(lines[1]))
+		assert.Equal(t, []string{"sum", "20", "0"}, strings.Fields(lines[2])[:3])
+		assert.Equal(t, []string{"invert", "20", "0"}, strings.Fields(lines[3])[:3])
+
+		matrix := mockDomain.Calls[0].Arguments.Get(2).(*entity.Matrix[int64])
+		assert.Len(t, matrix.Data, 3)
+		assert.Len(t, matrix.Data[0], 2)
+	})
+
+	t.Run("failed requests fail the command after the report", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrixData", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Return(nil, apperrors.ErrUnprocessableEntity)
+
+		got, err := runCommand(t, mockDomain, "", "bench", "sum", "-n", "5")
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.ErrorContains(t, err, "5 of 5 sum requests failed")
+		assert.Equal(t, []string{"sum", "5", "5"}, strings.Fields(strings.Split(got, "\n")[2])[:3])
+	})
+
+	invalid := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown operation", args: []string{"bench", "divide"}},
+		{name: "invalid size", args: []string{"bench", "--size", "ten"}},
+		{name: "size over the matrix limits", args: []string{"bench", "--size", "11x1"}},
+		{name: "no requests", args: []string{"bench", "--requests", "0"}},
+		{name: "no concurrency", args: []string{"bench", "--concurrency", "0"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", tt.args...)
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		})
+	}
+}
+
+func TestBench_Server(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+
+		var body struct {
+			Rows [][]int64 `json:"rows"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Len(t, body.Rows, 2)
+
+		if r.URL.Path == "/matrix/multiply" {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "/matrix/sum", r.URL.Path)
+		_, _ = w.Write([]byte("42"))
+	}))
+	defer server.Close()
+
+	options := &benchOptions{url: server.URL + "/", apiKey: "secret", size: "2x2", requests: 10, concurrency: 2}
+
+	var out strings.Builder
+	assert.NoError(t, bench(context.Background(), nil, []string{"sum"}, options, &out))
+	assert.Equal(t, int64(10), requests.Load())
+
+	err := bench(context.Background(), nil, []string{"multiply"}, options, &out)
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+}
+
+func TestRunBench(t *testing.T) {
+	t.Run("records every request", func(t *testing.T) {
+		var calls atomic.Int64
+		failure := errors.New("failure")
+		target := func(_ context.Context, _ string) error {
+			if calls.Add(1)%4 == 0 {
+				return failure
+			}
+			return nil
+		}
+
+		report, err := runBench(context.Background(), target, "sum", 40, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(40), calls.Load())
+		assert.Equal(t, 40, report.requests)
+		assert.Equal(t, 10, report.errors)
+		assert.ErrorIs(t, report.firstErr, failure)
+		assert.Len(t, report.latencies, 40)
+		assert.IsNonDecreasing(t, report.latencies)
+	})
+
+	t.Run("interrupted runs report nothing", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		report, err := runBench(ctx, func(context.Context, string) error { return nil }, "sum", 10, 2)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, report)
+	})
+}
+
+func TestParseSize(t *testing.T) {
+	rows, cols, err := parseSize("3X7")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rows)
+	assert.Equal(t, 7, cols)
+
+	for _, size := range []string{"", "3", "3x", "x3", "0x3", "3x0", "-1x3", "3x11", "axb"} {
+		_, _, err := parseSize(size)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput, size)
+	}
+}
+
+func TestGenerateMatrix(t *testing.T) {
+	matrix := generateMatrix(4, 5, 7)
+
+	assert.Len(t, matrix.Data, 4)
+	for _, row := range matrix.Data {
+		assert.Len(t, row, 5)
+		for _, val := range row {
+			assert.LessOrEqual(t, val, int64(benchValueRange))
+			assert.GreaterOrEqual(t, val, int64(-benchValueRange))
+		}
+	}
+
+	assert.Equal(t, matrix, generateMatrix(4, 5, 7))
+	assert.NotEqual(t, matrix, generateMatrix(4, 5, 8))
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(sorted, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(sorted, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(sorted, 100))
+	assert.Equal(t, time.Millisecond, percentile(sorted, 0))
+	assert.Equal(t, 5*time.Millisecond, percentile([]time.Duration{5 * time.Millisecond}, 90))
+	assert.Zero(t, percentile(nil, 50))
+}
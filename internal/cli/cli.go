@@ -0,0 +1,115 @@
+// Package cli implements the league-matrix command line interface, which either serves the
+// matrix operations over HTTP or runs them directly on local files.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// commandName is the name of the binary as shown in usage messages.
+const commandName = "league-matrix"
+
+// Execute runs the command line with the given arguments and returns the process exit code,
+// which is derived from the error sentinels with apperrors.GetExitCode.
+// Cancelling ctx stops a running server gracefully and aborts a running computation;
+// the serve command also stops gracefully on SIGINT and SIGTERM.
+func Execute(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	// The local commands read local files and standard input alone, so they run with the default settings;
+	// serve configures its own domains once its settings are parsed
+	root := NewRootCommand(newMatrixDomain(config.Default, nil))
+	root.SetArgs(args)
+	root.SetIn(stdin)
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+
+	err := root.ExecuteContext(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", commandName, err)
+	}
+	return apperrors.GetExitCode(err)
+}
+
+// NewRootCommand creates the league-matrix command with all of its subcommands.
+// matrixDomain runs the operations requested by the compute, repl and bench subcommands.
+func NewRootCommand(matrixDomain domain.MatrixDomainInterface) *cobra.Command {
+	root := &cobra.Command{
+		Use:   commandName,
+		Short: "Run matrix operations on CSV files, locally or as an HTTP server",
+		// Errors are reported once by Execute, without repeating the usage for failures unrelated to it
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		// The completion subcommand is defined explicitly for the supported shells
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
+	}
+	// Invalid flags are usage errors, like invalid arguments
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return fmt.Errorf("%w: %v", apperrors.ErrInvalidInput, err)
+	})
+
+	root.AddCommand(
+		newServeCommand(),
+		newComputeCommand(matrixDomain),
+		newREPLCommand(matrixDomain),
+		newBenchCommand(matrixDomain),
+		newGenFixturesCommand(domain.NewFixtureDomain()),
+		newCompletionCommand(),
+	)
+	return root
+}
+
+// newMatrixDomain creates the matrix domain configured by cfg, streaming aggregate operations under its stream
+// limits and remembering results within the bounds of its memo settings. Matrix files given as s3:// paths are
+// read from the object storage of cfg with the access keys of secretDomain, or rejected without secretDomain,
+// and files given as URLs are downloaded from the remote file hosts of cfg alone.
+func newMatrixDomain(cfg config.Config, secretDomain domain.SecretDomainInterface) domain.MatrixDomainInterface {
+	return domain.NewMatrixDomain(domain.StreamLimits{
+		MaxRows:     cfg.StreamMaxRows,
+		MaxCols:     cfg.StreamMaxCols,
+		MaxFileSize: cfg.StreamMaxFileSize,
+	}, domain.MemoConfig{
+		MaxEntries: cfg.MemoMaxEntries,
+		TTL:        cfg.MemoTTL,
+	}, domain.FileSourcesConfig{
+		S3Endpoint:   cfg.S3Endpoint,
+		S3Region:     cfg.S3Region,
+		SecretDomain: secretDomain,
+		URLHosts:     cfg.RemoteFileHosts,
+	})
+}
+
+// newSecretDomain creates the secret domain reading the secrets from the store selected by the SECRETS_PROVIDER
+// environment variable, as described by domain.SecretsConfig.
+func newSecretDomain() (domain.SecretDomainInterface, error) {
+	secretDomain, err := domain.NewSecretDomain(domain.SecretsConfig{
+		Provider:        os.Getenv("SECRETS_PROVIDER"),
+		Dir:             os.Getenv("SECRETS_DIR"),
+		VaultAddress:    os.Getenv("VAULT_ADDR"),
+		VaultToken:      os.Getenv("VAULT_TOKEN"),
+		VaultTokenFile:  os.Getenv("VAULT_TOKEN_FILE"),
+		VaultPath:       os.Getenv("VAULT_SECRET_PATH"),
+		RefreshInterval: os.Getenv("SECRETS_REFRESH_INTERVAL"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure secrets: %w", err)
+	}
+	return secretDomain, nil
+}
+
+// usageArgs wraps a positional argument validator so the errors it reports are classified as invalid input.
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return fmt.Errorf("%w: %v", apperrors.ErrInvalidInput, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// dataDir is the directory the HTTP API serves matrix files from; its CSV files are offered as completions.
+const dataDir = "testdata"
+
+// completionShells lists the shells a completion script can be generated for.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+func newCompletionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion <" + strings.Join(completionShells, "|") + ">",
+		Short: "Generate a shell completion script",
+		Long: "Generate a completion script for bash, zsh or fish and write it to standard output.\n" +
+			"Besides commands and flags, it completes operation names, output formats and the CSV files in " + dataDir + "/.",
+		Example: "  source <(" + commandName + " completion bash)\n" +
+			"  " + commandName + " completion zsh > \"${fpath[1]}/_" + commandName + "\"\n" +
+			"  " + commandName + " completion fish > ~/.config/fish/completions/" + commandName + ".fish",
+		ValidArgs: completionShells,
+		Args:      usageArgs(cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, w := cmd.Root(), cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(w, true)
+			case "zsh":
+				return root.GenZshCompletion(w)
+			case "fish":
+				return root.GenFishCompletion(w, true)
+			default:
+				return fmt.Errorf("%w: unsupported shell: %s", apperrors.ErrInvalidInput, args[0])
+			}
+		},
+	}
+}
+
+// completeOperations completes operation names from the operations registry.
+func completeOperations(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return operationNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFormats completes the output formats supported by the codecs.
+func completeFormats(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return codec.Formats(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeMatrixFiles completes the CSV files in the data directory that start with toComplete.
+// When none match, such as for a path outside the data directory, the shell falls back to completing CSV files itself.
+func completeMatrixFiles(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var files []string
+	// Unreadable entries are skipped: completion is best effort
+	_ = filepath.WalkDir(dataDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(path), ".csv") {
+			// Paths are completed in the slash-separated form the API accepts
+			path = filepath.ToSlash(path)
+			if strings.HasPrefix(path, toComplete) {
+				files = append(files, path)
+			}
+		}
+		return nil
+	})
+
+	if len(files) == 0 {
+		return []string{"csv"}, cobra.ShellCompDirectiveFilterFileExt
+	}
+	return files, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDirs lets the shell complete directories.
+func completeDirs(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveFilterDirs
+}
+
+// mustRegisterFlagCompletion registers the completion function of a flag defined by the same command,
+// so a failure is a programming error.
+func mustRegisterFlagCompletion(cmd *cobra.Command, flag string,
+	complete func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)) {
+	if err := cmd.RegisterFlagCompletionFunc(flag, complete); err != nil {
+		panic(fmt.Sprintf("failed to register completion for --%s: %v", flag, err))
+	}
+}
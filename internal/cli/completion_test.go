@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{shell: "bash", want: "# bash completion V2 for league-matrix"},
+		{shell: "zsh", want: "#compdef league-matrix"},
+		{shell: "fish", want: "# fish completion for league-matrix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			got, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "completion", tt.shell)
+
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(got, tt.want), got)
+		})
+	}
+
+	t.Run("unsupported shell", func(t *testing.T) {
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "completion", "powershell")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("shell is required", func(t *testing.T) {
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "completion")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
+
+func TestDynamicCompletion(t *testing.T) {
+	// Complete from a working directory holding a data directory with CSV and other files
+	t.Chdir(t.TempDir())
+	assert.NoError(t, os.MkdirAll(filepath.Join(dataDir, "uploads"), 0o755))
+	for _, name := range []string{"matrix1.csv", "matrix2.csv", "notes.txt", "uploads/upload.csv"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dataDir, name), []byte("1\n"), 0o600))
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "operations",
+			args: []string{"compute", ""},
+			want: []string{"echo", "flatten", "inverse", "invert", "max", "mean", "median", "min", "multiply", "sum", "transpose", ":4"},
+		},
+		{
+			name: "matrix files",
+			args: []string{"compute", "sum", ""},
+			want: []string{"testdata/matrix1.csv", "testdata/matrix2.csv", "testdata/uploads/upload.csv", ":4"},
+		},
+		{
+			name: "matrix files matching the prefix",
+			args: []string{"compute", "sum", "testdata/u"},
+			want: []string{"testdata/uploads/upload.csv", ":4"},
+		},
+		{
+			name: "files outside the data directory",
+			args: []string{"compute", "sum", "/tmp/"},
+			want: []string{"csv", ":8"},
+		},
+		{
+			name: "file flag",
+			args: []string{"compute", "sum", "--file", "testdata/matrix1"},
+			want: []string{"testdata/matrix1.csv", ":4"},
+		},
+		{
+			name: "formats",
+			args: []string{"compute", "sum", "--format", ""},
+			want: []string{"cbor", "csv", "json", "msgpack", "ndjson", "protobuf", "text", ":4"},
+		},
+		{
+			name: "repl file",
+			args: []string{"repl", "testdata/matrix2"},
+			want: []string{"testdata/matrix2.csv", ":4"},
+		},
+		{
+			name: "no arguments after the file",
+			args: []string{"compute", "sum", "testdata/matrix1.csv", ""},
+			want: []string{":4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", append([]string{"__complete"}, tt.args...)...)
+
+			assert.NoError(t, err)
+			// The completions are written one per line, followed by the shell directive
+			assert.Equal(t, tt.want, strings.Split(strings.TrimSpace(got), "\n"))
+		})
+	}
+}
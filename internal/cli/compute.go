@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// computeOptions holds the flags of the compute subcommand.
+type computeOptions struct {
+	filePath  string
+	format    string
+	lenient   bool
+	header    bool
+	rowLabels bool
+}
+
+// stdinPath is the file name that makes compute read the matrix from standard input.
+const stdinPath = "-"
+
+func newComputeCommand(matrixDomain domain.MatrixDomainInterface) *cobra.Command {
+	options := &computeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "compute <operation> [file]",
+		Short: "Run an operation on a CSV matrix and print the result",
+		Long: "Run an operation on a CSV matrix and print the result to standard output.\n" +
+			"The matrix is read from a local file, given as an argument or with --file, or from standard input when the file is \"-\".\n" +
+			"It is validated like files served by the HTTP API. The exit status tells usage errors (64), invalid matrices (65)\n" +
+			"and missing files (66) apart. With --lenient, whitespace around values is trimmed and blank rows are skipped,\n" +
+			"as found in CSV files exported by spreadsheets. With --header, the first row is read as column labels, and with\n" +
+			"--row-labels, the first value of every row as its label. The csv, json, msgpack and cbor formats report the labels\n" +
+			"with results that keep the rows or columns of the matrix.",
+		Example: "  " + commandName + " compute sum --file testdata/matrix1.csv\n" +
+			"  " + commandName + " compute inverse matrix.csv --format json\n" +
+			"  cat matrix.csv | " + commandName + " compute sum -\n" +
+			"  " + commandName + " compute sum export.csv --lenient\n" +
+			"  " + commandName + " compute echo scores.csv --header --format json",
+		Args: usageArgs(cobra.RangeArgs(1, 2)),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			switch len(args) {
+			case 0:
+				return completeOperations(cmd, args, toComplete)
+			case 1:
+				return completeMatrixFiles(cmd, args, toComplete)
+			default:
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 2 {
+				if options.filePath != "" {
+					return fmt.Errorf("%w: the file is given both as an argument and with --file", apperrors.ErrInvalidInput)
+				}
+				options.filePath = args[1]
+			}
+			return compute(cmd.Context(), matrixDomain, args[0], options, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.filePath, "file", "f", "", `CSV file holding the matrix, or "-" for standard input`)
+	cmd.Flags().StringVar(&options.format, "format", "text",
+		"output format ("+strings.Join(codec.Formats(), ", ")+")")
+	cmd.Flags().BoolVar(&options.lenient, "lenient", false, "trim whitespace around values and skip blank rows")
+	cmd.Flags().BoolVar(&options.header, "header", false, "read the first row as column labels")
+	cmd.Flags().BoolVar(&options.rowLabels, "row-labels", false, "read the first value of every row as its label")
+	mustRegisterFlagCompletion(cmd, "file", completeMatrixFiles)
+	mustRegisterFlagCompletion(cmd, "format", completeFormats)
+	return cmd
+}
+
+// compute runs an operation on the matrix named in options, read from stdin for "-", and writes the encoded result to w.
+func compute(ctx context.Context, matrixDomain domain.MatrixDomainInterface, operation string,
+	options *computeOptions, stdin io.Reader, w io.Writer) error {
+	// Reject an unknown format before doing any work
+	outputCodec, err := codec.ForFormat(options.format)
+	if err != nil {
+		return err
+	}
+
+	var input io.Reader
+	switch options.filePath {
+	case "":
+		return fmt.Errorf("%w: a matrix file is required (use %q to read from standard input)", apperrors.ErrInvalidInput, stdinPath)
+	case stdinPath:
+		input = stdin
+	default:
+		file, err := openMatrixFile(options.filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	ctx = parsing.NewContext(ctx, parsing.Options{
+		Lenient:   options.lenient,
+		Header:    options.header,
+		RowLabels: options.rowLabels,
+	})
+	result, err := matrixDomain.ProcessMatrixReader(ctx, operation, input, nil)
+	if err != nil {
+		return err
+	}
+
+	body, err := outputCodec.EncodeResult(operation, result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	// Terminate text output so the shell prompt starts on a new line; binary formats are written as is
+	if isTextual(outputCodec) && !bytes.HasSuffix(body, []byte("\n")) {
+		body = append(body, '\n')
+	}
+
+	_, err = w.Write(body)
+	return err
+}
+
+// openMatrixFile opens a local matrix file, classifying a file that cannot be opened as not found.
+func openMatrixFile(path string) (*os.File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open file: %v", apperrors.ErrNotFound, err)
+	}
+	return file, nil
+}
+
+// isTextual reports whether a codec produces human-readable output.
+func isTextual(c codec.Codec) bool {
+	contentType := c.ContentType()
+	return strings.HasPrefix(contentType, "text/") || strings.HasSuffix(contentType, "json")
+}
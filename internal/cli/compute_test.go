@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// runCommand executes the root command with args and stdin and returns what it wrote to stdout.
+func runCommand(t *testing.T, matrixDomain *mocks.MockMatrixDomainInterface, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout bytes.Buffer
+	root := NewRootCommand(matrixDomain)
+	root.SetArgs(args)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&stdout)
+	root.SetErr(&bytes.Buffer{})
+
+	err := root.ExecuteContext(context.Background())
+	return stdout.String(), err
+}
+
+func writeMatrixFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "matrix.csv")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestComputeCommand(t *testing.T) {
+	invertResult := &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}}
+
+	tests := []struct {
+		name      string
+		operation string
+		format    []string
+		result    *entity.Result
+		want      string
+	}{
+		{name: "scalar result", operation: "sum", result: &entity.Result{Scalar: "10"}, want: "10\n"},
+		{name: "matrix result", operation: "invert", result: invertResult, want: "1,3\n2,4\n"},
+		{name: "csv output", operation: "invert", format: []string{"--format", "csv"}, result: invertResult, want: "1,3\n2,4\n"},
+		{
+			name: "json output", operation: "invert", format: []string{"--format", "json"}, result: invertResult,
+			want: `{"operation":"invert","matrix":[[1,3],[2,4]]}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeMatrixFile(t, "1,2\n3,4\n")
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			mockDomain.On("ProcessMatrixReader", mock.Anything, tt.operation, mock.Anything, (*entity.Selection)(nil)).
+				Return(tt.result, nil)
+
+			got, err := runCommand(t, mockDomain, "", append([]string{"compute", tt.operation, "--file", path}, tt.format...)...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("domain errors are returned", func(t *testing.T) {
+		path := writeMatrixFile(t, "1,a\n")
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrixReader", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Return(nil, apperrors.ErrUnprocessableEntity)
+
+		got, err := runCommand(t, mockDomain, "", "compute", "sum", "-f", path)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Empty(t, got)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		got, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "",
+			"compute", "sum", "--file", filepath.Join(t.TempDir(), "missing.csv"))
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Empty(t, got)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		path := writeMatrixFile(t, "1,2\n")
+
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "compute", "sum", "--file", path, "--format", "xml")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("file as an argument", func(t *testing.T) {
+		path := writeMatrixFile(t, "1,2\n3,4\n")
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrixReader", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "10"}, nil)
+
+		got, err := runCommand(t, mockDomain, "", "compute", "sum", path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "10\n", got)
+	})
+
+	t.Run("matrix from stdin", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.EXPECT().ProcessMatrixReader(mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			RunAndReturn(func(_ context.Context, _ string, r io.Reader, _ *entity.Selection) (*entity.Result, error) {
+				data, err := io.ReadAll(r)
+				assert.NoError(t, err)
+				assert.Equal(t, "1,2\n3,4\n", string(data))
+				return &entity.Result{Scalar: "10"}, nil
+			})
+
+		got, err := runCommand(t, mockDomain, "1,2\n3,4\n", "compute", "sum", "-")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "10\n", got)
+	})
+
+	t.Run("parsing options", func(t *testing.T) {
+		for _, tt := range []struct {
+			args        []string
+			wantOptions parsing.Options
+		}{
+			{args: []string{"compute", "sum", "-"}},
+			{args: []string{"compute", "sum", "-", "--lenient"}, wantOptions: parsing.Options{Lenient: true}},
+			{args: []string{"compute", "sum", "-", "--header"}, wantOptions: parsing.Options{Header: true}},
+			{args: []string{"compute", "sum", "-", "--row-labels"}, wantOptions: parsing.Options{RowLabels: true}},
+		} {
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			mockDomain.EXPECT().ProcessMatrixReader(mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+				RunAndReturn(func(ctx context.Context, _ string, _ io.Reader, _ *entity.Selection) (*entity.Result, error) {
+					assert.Equal(t, tt.wantOptions, parsing.FromContext(ctx))
+					return &entity.Result{Scalar: "10"}, nil
+				})
+
+			_, err := runCommand(t, mockDomain, " 1, 2\n", tt.args...)
+
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("file is required", func(t *testing.T) {
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "compute", "sum")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("file given twice", func(t *testing.T) {
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "compute", "sum", "a.csv", "--file", "b.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("operation is required", func(t *testing.T) {
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "compute", "--file", "matrix.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "compute", "sum", "--rows", "1")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+	}{
+		{name: "success", args: []string{"compute", "sum", "-"}, stdin: "1,2\n3,4\n", wantCode: 0, wantStdout: "10\n"},
+		{name: "unknown operation", args: []string{"compute", "divide", "-"}, stdin: "1,2\n", wantCode: apperrors.ExitUsage},
+		{name: "invalid matrix", args: []string{"compute", "sum", "-"}, stdin: "1,a\n", wantCode: apperrors.ExitDataErr},
+		{name: "input too large", args: []string{"compute", "sum", "-"}, stdin: strings.Repeat("1,", 1024), wantCode: apperrors.ExitDataErr},
+		{name: "missing file", args: []string{"compute", "sum", "missing.csv"}, wantCode: apperrors.ExitNoInput},
+		{name: "unknown flag", args: []string{"compute", "sum", "--bogus"}, wantCode: apperrors.ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := Execute(context.Background(), tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			if tt.wantCode != 0 {
+				assert.True(t, strings.HasPrefix(stderr.String(), "league-matrix: "), stderr.String())
+			} else {
+				assert.Empty(t, stderr.String())
+			}
+		})
+	}
+
+	t.Run("cancelled command exits like an interrupt", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		code := Execute(ctx, []string{"compute", "sum", "-"}, strings.NewReader("1,2\n"), &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.Equal(t, apperrors.ExitInterrupted, code)
+	})
+
+	t.Run("server settings leave the other commands alone", func(t *testing.T) {
+		// The settings and secrets of serve are only read by serve, so they cannot fail another command
+		t.Setenv("STREAM_MAX_ROWS", "many")
+		t.Setenv("MEMO_TTL", "forever")
+		t.Setenv("SECRETS_PROVIDER", "unknown")
+
+		for _, args := range [][]string{{"compute", "sum", "-"}, {"completion", "bash"}, {"--help"}} {
+			var stderr bytes.Buffer
+			code := Execute(context.Background(), args, strings.NewReader("1,2\n"), &bytes.Buffer{}, &stderr)
+
+			assert.Equal(t, 0, code, args)
+			assert.Empty(t, stderr.String(), args)
+		}
+	})
+}
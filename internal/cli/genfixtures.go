@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+const (
+	// defaultFixturesDir is the directory fixtures are written into unless --dir is given.
+	defaultFixturesDir = "testdata/fixtures"
+
+	// allDefects is the --defect value that selects every defect.
+	allDefects = "all"
+)
+
+// genFixturesOptions holds the flags of the genfixtures subcommand.
+type genFixturesOptions struct {
+	dir     string
+	sizes   []string
+	min     int64
+	max     int64
+	defects []string
+	seed    uint64
+}
+
+func newGenFixturesCommand(fixtureDomain domain.FixtureDomainInterface) *cobra.Command {
+	options := &genFixturesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "genfixtures",
+		Short: "Generate test matrices, valid or with deliberate defects",
+		Long: "Generate CSV test matrices into a directory for tests and demos: a valid matrix of each --size, and for each\n" +
+			"--defect a matrix of each size the service must reject. The ragged defect shortens a row, bad-cell replaces a value\n" +
+			"with one that is not a number and oversize adds rows past the row limit. Files are named after their defect and\n" +
+			"dimensions, such as matrix-3x3.csv or ragged-3x3.csv, and the same seed always produces the same files.\n" +
+			"Existing files are never overwritten. Sizes beyond the matrix limits are allowed for streamed operations.",
+		Example: "  " + commandName + " genfixtures\n" +
+			"  " + commandName + " genfixtures --size 2x2,5x5 --min 0 --max 9 --defect all\n" +
+			"  " + commandName + " genfixtures --dir /tmp/fixtures --size 1000x10 --defect ragged,bad-cell --seed 42",
+		Args:              usageArgs(cobra.NoArgs),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return genFixtures(cmd.Context(), fixtureDomain, options, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.dir, "dir", "d", defaultFixturesDir, "directory the fixtures are written into")
+	cmd.Flags().StringSliceVar(&options.sizes, "size", []string{"3x3", fmt.Sprintf("%dx%d", matrixlib.MaxRows, matrixlib.MaxCols)},
+		"dimensions of the generated matrices, as ROWSxCOLS")
+	cmd.Flags().Int64Var(&options.min, "min", -benchValueRange, "smallest generated value")
+	cmd.Flags().Int64Var(&options.max, "max", benchValueRange, "largest generated value")
+	cmd.Flags().StringSliceVar(&options.defects, "defect", nil,
+		"defects to generate matrices with ("+strings.Join(defectNames(), ", ")+", or "+allDefects+")")
+	cmd.Flags().Uint64Var(&options.seed, "seed", 1, "seed of the generated values")
+	mustRegisterFlagCompletion(cmd, "dir", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	})
+	mustRegisterFlagCompletion(cmd, "defect", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return append(defectNames(), allDefects), cobra.ShellCompDirectiveNoFileComp
+	})
+	for _, flag := range []string{"size", "min", "max", "seed"} {
+		mustRegisterFlagCompletion(cmd, flag, cobra.NoFileCompletions)
+	}
+	return cmd
+}
+
+// genFixtures generates the fixtures described by options and lists the files written to w.
+func genFixtures(ctx context.Context, fixtureDomain domain.FixtureDomainInterface, options *genFixturesOptions,
+	w io.Writer) error {
+	spec := &entity.FixtureSpec{
+		Dir:  options.dir,
+		Min:  options.min,
+		Max:  options.max,
+		Seed: options.seed,
+	}
+	for _, size := range options.sizes {
+		rows, cols, err := parseDimensions(size)
+		if err != nil {
+			return err
+		}
+		spec.Sizes = append(spec.Sizes, entity.FixtureSize{Rows: rows, Cols: cols})
+	}
+	for _, defect := range options.defects {
+		if defect == allDefects {
+			spec.Defects = slices.Clone(entity.FixtureDefects)
+			break
+		}
+		spec.Defects = append(spec.Defects, entity.FixtureDefect(defect))
+	}
+
+	fixtures, err := fixtureDomain.GenerateFixtures(ctx, spec)
+	// The fixtures written before a failure are listed too, so they can be found and removed
+	if writeErr := writeFixtures(w, fixtures); err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+// writeFixtures writes one row per fixture with its path, dimensions and defect.
+func writeFixtures(w io.Writer, fixtures []*entity.Fixture) error {
+	if len(fixtures) == 0 {
+		return nil
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "file\tsize\tdefect")
+	for _, fixture := range fixtures {
+		defect := string(fixture.Defect)
+		if defect == "" {
+			defect = "-"
+		}
+		fmt.Fprintf(table, "%s\t%dx%d\t%s\n", fixture.Path, fixture.Rows, fixture.Cols, defect)
+	}
+	return table.Flush()
+}
+
+// defectNames returns the names of the defects fixtures can be generated with.
+func defectNames() []string {
+	names := make([]string, 0, len(entity.FixtureDefects))
+	for _, defect := range entity.FixtureDefects {
+		names = append(names, string(defect))
+	}
+	return names
+}
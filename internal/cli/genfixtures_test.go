@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestGenFixturesCommand(t *testing.T) {
+	t.Run("writes the fixtures and lists them", func(t *testing.T) {
+		dir := t.TempDir()
+
+		got, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "",
+			"genfixtures", "--dir", dir, "--size", "2x3", "--min", "0", "--max", "9", "--defect", "all")
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(got), "\n")
+		if assert.Len(t, lines, 5) {
+			assert.Equal(t, []string{"file", "size", "defect"}, strings.Fields(lines[0]))
+			assert.Equal(t, []string{filepath.Join(dir, "matrix-2x3.csv"), "2x3", "-"}, strings.Fields(lines[1]))
+			assert.Equal(t, []string{filepath.Join(dir, "ragged-2x3.csv"), "2x3", "ragged"}, strings.Fields(lines[2]))
+			assert.Equal(t, []string{filepath.Join(dir, "bad-cell-2x3.csv"), "2x3", "bad-cell"}, strings.Fields(lines[3]))
+			assert.Equal(t, []string{filepath.Join(dir, "oversize-11x3.csv"), "11x3", "oversize"}, strings.Fields(lines[4]))
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, "matrix-2x3.csv"))
+		assert.NoError(t, err)
+		assert.Regexp(t, `^([0-9],[0-9],[0-9]\n){2}$`, string(content))
+	})
+
+	t.Run("sizes beyond the matrix limits", func(t *testing.T) {
+		dir := t.TempDir()
+
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "genfixtures", "--dir", dir, "--size", "50x20")
+
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "matrix-50x20.csv"))
+	})
+
+	t.Run("lists the fixtures written before a failure", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "ragged-2x2.csv"), nil, 0o600))
+
+		got, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "",
+			"genfixtures", "--dir", dir, "--size", "2x2", "--defect", "ragged")
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Contains(t, got, "matrix-2x2.csv")
+	})
+
+	invalid := []struct {
+		name string
+		args []string
+	}{
+		{name: "malformed size", args: []string{"--size", "3by3"}},
+		{name: "unknown defect", args: []string{"--defect", "typo"}},
+		{name: "inverted range", args: []string{"--min", "10", "--max", "1"}},
+		{name: "unexpected argument", args: []string{"extra"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"genfixtures", "--dir", t.TempDir()}, tt.args...)
+
+			_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", args...)
+
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		})
+	}
+}
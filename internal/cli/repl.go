@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// replPrompt is written before each command read by the REPL.
+const replPrompt = "> "
+
+func newREPLCommand(matrixDomain domain.MatrixDomainInterface) *cobra.Command {
+	return &cobra.Command{
+		Use:   "repl [file]",
+		Short: "Explore a CSV matrix interactively",
+		Long: "Load a CSV matrix and run successive operations on it interactively.\n" +
+			"Operations that produce a matrix replace the current matrix, so they can be chained;\n" +
+			"\"undo\" goes back to the previous one. Type \"help\" at the prompt for every command.",
+		Example: "  " + commandName + " repl testdata/matrix1.csv",
+		Args:    usageArgs(cobra.MaximumNArgs(1)),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completeMatrixFiles(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return runREPL(cmd.Context(), matrixDomain, path, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+}
+
+// replSession holds the state of an interactive session.
+// history stacks every matrix the session went through; the last one is the current matrix.
+type replSession struct {
+	matrixDomain domain.MatrixDomainInterface
+	out          io.Writer
+	history      []*entity.Matrix[int64]
+}
+
+// runREPL loads the matrix in path, if any, then reads commands from in until "quit" or the end of the input.
+// Failed commands are reported on errOut without ending the session, but a matrix that cannot be loaded
+// at startup is returned as an error.
+func runREPL(ctx context.Context, matrixDomain domain.MatrixDomainInterface, path string,
+	in io.Reader, out io.Writer, errOut io.Writer) error {
+	session := &replSession{matrixDomain: matrixDomain, out: out}
+	if path != "" {
+		if err := session.load(ctx, path); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(out, `Type "help" for the available commands.`)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, replPrompt)
+		if !scanner.Scan() {
+			break
+		}
+
+		quit, err := session.run(ctx, scanner.Text())
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			fmt.Fprintf(errOut, "error: %v\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+
+	// End the prompt line left open by the end of the input
+	fmt.Fprintln(out)
+	return scanner.Err()
+}
+
+// run executes a single command line and reports whether the session should end.
+func (s *replSession) run(ctx context.Context, line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	command, args := fields[0], fields[1:]
+	switch command {
+	case "quit", "exit":
+		return true, nil
+	case "help":
+		s.help()
+		return false, nil
+	case "load":
+		if len(args) != 1 {
+			return false, fmt.Errorf("%w: usage: load <file>", apperrors.ErrInvalidInput)
+		}
+		return false, s.load(ctx, args[0])
+	}
+
+	if len(args) > 0 {
+		return false, fmt.Errorf("%w: %s takes no arguments", apperrors.ErrInvalidInput, command)
+	}
+
+	switch command {
+	case "show":
+		return false, s.show()
+	case "undo":
+		return false, s.undo()
+	default:
+		return false, s.apply(ctx, command)
+	}
+}
+
+// load reads and validates the matrix in path and makes it the current matrix.
+func (s *replSession) load(ctx context.Context, path string) error {
+	file, err := openMatrixFile(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Echoing the matrix validates it like any other operation input and returns it unchanged
+	result, err := s.matrixDomain.ProcessMatrixReader(ctx, string(matrixlib.Echo), file, nil)
+	if err != nil {
+		return err
+	}
+
+	s.history = append(s.history, result.Matrix)
+	fmt.Fprintf(s.out, "loaded %s (%s)\n", path, dimensions(result.Matrix))
+	return nil
+}
+
+// apply runs an operation on the current matrix and prints its result.
+// A matrix result becomes the current matrix, while scalar results leave it unchanged.
+func (s *replSession) apply(ctx context.Context, operation string) error {
+	current, err := s.current()
+	if err != nil {
+		return err
+	}
+
+	result, err := s.matrixDomain.ProcessMatrixData(ctx, operation, current, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(s.out, result.String())
+	if result.Matrix != nil {
+		s.history = append(s.history, result.Matrix)
+	}
+	return nil
+}
+
+// show prints the current matrix.
+func (s *replSession) show() error {
+	current, err := s.current()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(s.out, (&entity.Result{Matrix: current}).String())
+	return nil
+}
+
+// undo goes back to the matrix that was current before the last operation or load.
+func (s *replSession) undo() error {
+	if len(s.history) < 2 {
+		return fmt.Errorf("%w: nothing to undo", apperrors.ErrInvalidInput)
+	}
+
+	s.history = s.history[:len(s.history)-1]
+	return s.show()
+}
+
+func (s *replSession) current() (*entity.Matrix[int64], error) {
+	if len(s.history) == 0 {
+		return nil, fmt.Errorf("%w: no matrix loaded (use load <file>)", apperrors.ErrInvalidInput)
+	}
+	return s.history[len(s.history)-1], nil
+}
+
+func (s *replSession) help() {
+	fmt.Fprintf(s.out, `Commands:
+  <operation>   run an operation on the current matrix (%s);
+                a matrix result becomes the current matrix
+  show          print the current matrix
+  undo          go back to the previous matrix
+  load <file>   load a CSV file as the current matrix
+  help          print this help
+  quit, exit    end the session
+`, strings.Join(operationNames(), ", "))
+}
+
+// operationNames returns the names of the supported operations in alphabetical order.
+func operationNames() []string {
+	return matrixlib.Operations()
+}
+
+// dimensions describes the size of a matrix, such as "3x3".
+func dimensions(matrix *entity.Matrix[int64]) string {
+	return fmt.Sprintf("%dx%d", matrix.Rows(), matrix.Cols())
+}
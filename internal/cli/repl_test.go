@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestRunREPL(t *testing.T) {
+	loaded := &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+	inverted := &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}
+
+	// newMockDomain expects the matrix file to be loaded through the echo operation
+	newMockDomain := func(t *testing.T) *mocks.MockMatrixDomainInterface {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrixReader", mock.Anything, "echo", mock.Anything, (*entity.Selection)(nil)).
+			Return(&entity.Result{Matrix: loaded}, nil)
+		return mockDomain
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		setupMock  func(*mocks.MockMatrixDomainInterface)
+		wantStdout []string
+		wantStderr string
+	}{
+		{
+			name:  "matrix results are chained",
+			input: "invert\nsum\n",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrixData", mock.Anything, "invert", loaded, (*entity.Selection)(nil)).
+					Return(&entity.Result{Matrix: inverted}, nil)
+				m.On("ProcessMatrixData", mock.Anything, "sum", inverted, (*entity.Selection)(nil)).
+					Return(&entity.Result{Scalar: "10"}, nil)
+			},
+			wantStdout: []string{"loaded ", "(2x2)", "> 1,3\n2,4\n", "> 10\n"},
+		},
+		{
+			name:  "scalar results keep the current matrix",
+			input: "sum\nshow\n",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrixData", mock.Anything, "sum", loaded, (*entity.Selection)(nil)).
+					Return(&entity.Result{Scalar: "10"}, nil)
+			},
+			wantStdout: []string{"> 10\n", "> 1,2\n3,4\n"},
+		},
+		{
+			name:  "undo restores the previous matrix",
+			input: "invert\nundo\n",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrixData", mock.Anything, "invert", loaded, (*entity.Selection)(nil)).
+					Return(&entity.Result{Matrix: inverted}, nil)
+			},
+			wantStdout: []string{"> 1,3\n2,4\n", "> 1,2\n3,4\n"},
+		},
+		{
+			name:  "errors do not end the session",
+			input: "divide\nshow\n",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrixData", mock.Anything, "divide", loaded, (*entity.Selection)(nil)).
+					Return(nil, apperrors.ErrInvalidInput)
+			},
+			wantStdout: []string{"> 1,2\n3,4\n"},
+			wantStderr: "error: invalid input\n",
+		},
+		{
+			name:       "nothing to undo",
+			input:      "undo\n",
+			wantStderr: "error: invalid input: nothing to undo\n",
+		},
+		{
+			name:       "missing file",
+			input:      "load " + filepath.Join(t.TempDir(), "missing.csv") + "\n",
+			wantStderr: "error: not found: failed to open file",
+		},
+		{
+			name:       "commands without arguments",
+			input:      "show all\n",
+			wantStderr: "error: invalid input: show takes no arguments\n",
+		},
+		{
+			name:       "help lists the operations",
+			input:      "help\n",
+			wantStdout: []string{"echo, flatten, inverse, invert, max, mean, median, min, multiply, sum, transpose"},
+		},
+		{
+			name:       "quit ends the session",
+			input:      "quit\nsum\n",
+			wantStdout: []string{"> "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeMatrixFile(t, "1,2\n3,4\n")
+			mockDomain := newMockDomain(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockDomain)
+			}
+			var stdout, stderr bytes.Buffer
+
+			err := runREPL(context.Background(), mockDomain, path, strings.NewReader(tt.input), &stdout, &stderr)
+
+			assert.NoError(t, err)
+			for _, want := range tt.wantStdout {
+				assert.Contains(t, stdout.String(), want)
+			}
+			if tt.wantStderr == "" {
+				assert.Empty(t, stderr.String())
+			} else {
+				assert.True(t, strings.HasPrefix(stderr.String(), tt.wantStderr), stderr.String())
+			}
+		})
+	}
+
+	t.Run("operations need a matrix", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := runREPL(context.Background(), mocks.NewMockMatrixDomainInterface(t), "", strings.NewReader("sum\n"), &stdout, &stderr)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "error: invalid input: no matrix loaded (use load <file>)\n", stderr.String())
+	})
+
+	t.Run("invalid startup matrix", func(t *testing.T) {
+		path := writeMatrixFile(t, "1,a\n")
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrixReader", mock.Anything, "echo", mock.Anything, (*entity.Selection)(nil)).
+			Return(nil, apperrors.ErrUnprocessableEntity)
+
+		err := runREPL(context.Background(), mockDomain, path, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	})
+
+	t.Run("cancelled session", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := runREPL(ctx, mocks.NewMockMatrixDomainInterface(t), "", strings.NewReader("help\n"), &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestREPLCommand(t *testing.T) {
+	t.Run("too many arguments", func(t *testing.T) {
+		_, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "", "repl", "a.csv", "b.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("reads commands from stdin", func(t *testing.T) {
+		got, err := runCommand(t, mocks.NewMockMatrixDomainInterface(t), "help\n", "repl")
+
+		assert.NoError(t, err)
+		assert.Contains(t, got, "Commands:")
+	})
+}
@@ -0,0 +1,425 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/grpcserver"
+	"github.com/matsuboshi/league-matrix-app/internal/handler"
+	"github.com/matsuboshi/league-matrix-app/internal/logging"
+	"github.com/matsuboshi/league-matrix-app/internal/middleware"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+)
+
+const (
+	// schedulerStopTimeout bounds waiting for the scheduled runs in progress to submit their jobs.
+	schedulerStopTimeout = 5 * time.Second
+
+	// jobWorkersStopTimeout bounds waiting for the job workers to run the queued jobs.
+	jobWorkersStopTimeout = 20 * time.Second
+
+	// retentionStopTimeout bounds waiting for a cleanup of expired and deleted matrix files to finish.
+	retentionStopTimeout = 5 * time.Second
+
+	// uploadStopTimeout bounds waiting for a sweep of abandoned uploads to finish.
+	uploadStopTimeout = 5 * time.Second
+
+	// telemetryStopTimeout bounds exporting the last log records and metrics to the OpenTelemetry collector.
+	telemetryStopTimeout = 5 * time.Second
+)
+
+func newServeCommand() *cobra.Command {
+	cfg := config.Default
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP and gRPC servers",
+		Long: "Start the HTTP server exposing the matrix operations, jobs and schedules, and the gRPC server exposing\n" +
+			"the matrix operations, until SIGINT or SIGTERM shuts them down gracefully. Every setting is a flag that\n" +
+			"can also be set with the environment variable named in its description. Secrets such as ADMIN_TOKEN\n" +
+			"are read from the store selected by SECRETS_PROVIDER, and logs and metrics are exported to the\n" +
+			"OpenTelemetry collector set by the standard OTEL_* variables; see the README for both.",
+		Args:              usageArgs(cobra.NoArgs),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// Listen for SIGINT (Ctrl+C) and SIGTERM (Docker/K8s stop). Only the server traps them:
+			// other commands are stopped right away, even while blocked reading standard input.
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			if err := cfg.LoadEnv(cmd.Flags(), os.Getenv); err != nil {
+				return fmt.Errorf("failed to configure server: %w", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("failed to configure server: %w", err)
+			}
+			// Background jobs and scheduled runs read matrix files outside any request, so the limits apply
+			// process-wide
+			config.SetLimits(cfg.Limits)
+
+			return serve(ctx, cfg)
+		},
+	}
+
+	cfg.Bind(cmd.Flags())
+	for _, flag := range []string{"port", "grpc-port", "read-timeout", "write-timeout", "shutdown-timeout",
+		"max-file-size", "max-matrix-dims", "request-timeout", "operation-timeout", "memory-limit", "lenient-parsing",
+		"debug-profile", "s3-endpoint", "s3-region", "retention-default-ttl", "retention-restore-window",
+		"retention-sweep-interval", "stream-max-rows", "stream-max-cols", "stream-max-file-size", "memo-max-entries",
+		"memo-ttl", "remote-file-hosts", "drain-delay"} {
+		mustRegisterFlagCompletion(cmd, flag, cobra.NoFileCompletions)
+	}
+	mustRegisterFlagCompletion(cmd, "allowed-data-dir", completeDirs)
+	mustRegisterFlagCompletion(cmd, "export-dir", completeDirs)
+	return cmd
+}
+
+// serve runs the HTTP and gRPC servers configured by cfg until ctx is cancelled, then shuts them down gracefully.
+// Before shutting down, the servers drain for the drain delay of cfg: they keep serving, but the HTTP server
+// reports itself unhealthy.
+func serve(ctx context.Context, cfg config.Config) error {
+	metricsDomain := domain.NewMetricsDomain()
+	telemetryDomain, err := newTelemetryDomain(metricsDomain)
+	if err != nil {
+		return fmt.Errorf("failed to configure telemetry: %w", err)
+	}
+	logHandler := slog.Handler(slog.NewTextHandler(os.Stderr, nil))
+	if telemetryDomain.ExportsLogs() {
+		logHandler = telemetryDomain.LogHandler(logHandler)
+	}
+	// Records logged while serving a request carry its identifiers, exported records included
+	slog.SetDefault(slog.New(logging.NewHandler(logHandler)))
+
+	shutdownDomain := domain.NewShutdownDomain()
+	drainDomain := domain.NewDrainDomain()
+	mux, grpcServer, err := newServeMux(cfg, shutdownDomain, drainDomain, metricsDomain)
+	// Telemetry is stopped last, so the logs of the other hooks are exported too
+	shutdownDomain.Register("telemetry", telemetryStopTimeout, telemetryDomain.Shutdown)
+	if err != nil {
+		// Stop the subsystems started before the failure
+		return errors.Join(err, shutdownDomain.Shutdown(context.Background()))
+	}
+
+	// Configure HTTP server with timeouts
+	server := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,  // Maximum time to read request headers (prevents slow header attacks)
+		ReadTimeout:       cfg.ReadTimeout,  // Maximum duration for reading the entire request
+		WriteTimeout:      cfg.WriteTimeout, // Maximum duration before timing out writes
+		IdleTimeout:       60 * time.Second, // Maximum time to wait for next request with keep-alive
+	}
+
+	slog.Info("starting HTTP server",
+		"port", cfg.Port,
+		"address", "http://localhost:"+cfg.Port,
+		"read_timeout", server.ReadTimeout,
+		"write_timeout", server.WriteTimeout,
+		"data_dir", cfg.Limits.DataDir)
+
+	// The gRPC port is bound before serving, so a port that cannot be used fails the start like the HTTP port
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return errors.Join(fmt.Errorf("gRPC server failed to start on port %s: %w", cfg.GRPCPort, err),
+			shutdownDomain.Shutdown(context.Background()))
+	}
+	slog.Info("starting gRPC server", "port", cfg.GRPCPort)
+
+	// Start servers in goroutines
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	grpcErr := make(chan error, 1)
+	go func() {
+		grpcErr <- grpcServer.Serve(grpcListener)
+	}()
+	grpcServer.SetServing(true)
+
+	// Block until a server fails or a shutdown signal cancels the context
+	select {
+	case err := <-serverErr:
+		grpcServer.Stop()
+		return errors.Join(fmt.Errorf("server failed to start on port %s: %w", cfg.Port, err),
+			shutdownDomain.Shutdown(context.Background()))
+	case err := <-grpcErr:
+		_ = server.Close()
+		return errors.Join(fmt.Errorf("gRPC server failed on port %s: %w", cfg.GRPCPort, err),
+			shutdownDomain.Shutdown(context.Background()))
+	case <-ctx.Done():
+		slog.Info("shutdown signal received")
+	}
+
+	// Load balancers notice the failing health checks and stop routing new traffic meanwhile
+	drainDomain.StartDrain()
+	grpcServer.SetServing(false)
+	if cfg.DrainDelay > 0 {
+		slog.Info("draining server before shutdown", "drain_delay", cfg.DrainDelay)
+		time.Sleep(cfg.DrainDelay)
+	}
+
+	// Create context with timeout for shutdown
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Attempt graceful shutdown; both servers wait for the requests in flight at the same time
+	slog.Info("gracefully shutting down server", "timeout", cfg.ShutdownTimeout)
+	grpcStopped := make(chan error, 1)
+	go func() {
+		grpcStopped <- stopGRPCServer(shutdownCtx, grpcServer.Server)
+	}()
+	err = server.Shutdown(shutdownCtx)
+	if err != nil {
+		err = fmt.Errorf("server shutdown failed: %w", err)
+	} else if serveErr := <-serverErr; !errors.Is(serveErr, http.ErrServerClosed) {
+		err = serveErr
+	}
+	if err = errors.Join(err, <-grpcStopped); err != nil {
+		// The subsystems are stopped anyway, even though requests may still be running
+		return errors.Join(err, shutdownDomain.Shutdown(shutdownCtx))
+	}
+
+	// No request is running anymore, so the subsystems can be stopped in the time left
+	if err := shutdownDomain.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	slog.Info("server stopped gracefully")
+	return nil
+}
+
+// stopGRPCServer stops server gracefully, waiting for the calls in flight, or cancels them once ctx is done.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		<-stopped
+		return fmt.Errorf("gRPC server shutdown failed: %w", ctx.Err())
+	}
+}
+
+// newServeMux creates the handlers of the server configured by cfg and registers them on their routes, and the
+// gRPC server sharing their domain layer.
+// Every route but the health check, the metrics, the dashboard assets and the signed URLs requires an API key when tenants
+// are configured and is recorded in the audit log; the /admin/ routes require the admin token instead.
+// Browsers must echo a CSRF token on state-changing requests that carry no API key.
+// Subsystems running in the background register their cleanup on shutdownDomain, to run once the server has stopped.
+// While drainDomain reports the server as draining, the health check fails; /drain reports the drain state.
+// Every request is measured in metricsDomain, exposed to Prometheus on /metrics, and scoped so the records logged while serving it carry its identifiers.
+// When cfg enables debug profiles, clients may ask for the timing breakdown of their requests.
+func newServeMux(cfg config.Config, shutdownDomain domain.ShutdownDomainInterface, drainDomain domain.DrainDomainInterface,
+	metricsDomain domain.MetricsDomainInterface) (http.Handler, *grpcserver.Server, error) {
+	secretDomain, err := newSecretDomain()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matrixDomain := newMatrixDomain(cfg, secretDomain)
+	metricsDomain.Register(matrixDomain.MemoMetrics)
+	metricsDomain.Register(matrixDomain.CacheMetrics)
+	reportHandler := handler.NewReportHandler(domain.NewReportDomain())
+	aggregateHandler := handler.NewAggregateHandler(domain.NewAggregateDomain(matrixDomain))
+	batchHandler := handler.NewBatchHandler(domain.NewBatchDomain(matrixDomain))
+	webSocketHandler := handler.NewWebSocketHandler(matrixDomain)
+
+	exportDomain := domain.NewExportDomain(domain.ExportConfig{
+		Dir:        cfg.ExportDir,
+		S3Endpoint: cfg.S3Endpoint,
+		S3Region:   cfg.S3Region,
+	}, secretDomain)
+	retentionDomain, err := domain.NewRetentionDomain(domain.RetentionConfig{
+		DefaultTTL:    cfg.RetentionDefaultTTL,
+		RestoreWindow: cfg.RetentionRestoreWindow,
+		SweepInterval: cfg.RetentionSweepInterval,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load retention state: %w", err)
+	}
+	shutdownDomain.Register("retention", retentionStopTimeout, retentionDomain.Stop)
+	retentionHandler := handler.NewRetentionHandler(retentionDomain)
+	versionHandler := handler.NewVersionHandler(domain.NewVersionDomain())
+	catalogHandler := handler.NewCatalogHandler(domain.NewCatalogDomain())
+	tagHandler := handler.NewTagHandler(domain.NewTagDomain())
+	uploadDomain := domain.NewUploadDomain(retentionDomain)
+	shutdownDomain.Register("uploads", uploadStopTimeout, uploadDomain.Stop)
+	uploadHandler := handler.NewUploadHandler(uploadDomain)
+
+	historyDomain := domain.NewHistoryDomain()
+	matrixHandler := handler.NewMatrixHandler(matrixDomain, exportDomain, historyDomain, retentionDomain)
+	historyHandler := handler.NewHistoryHandler(historyDomain)
+
+	tenantDomain, err := domain.NewTenantDomain(cfg.TenantsFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tenants: %w", err)
+	}
+	tenantHandler := handler.NewTenantHandler(tenantDomain)
+	signedURLHandler := handler.NewSignedURLHandler(domain.NewSignedURLDomain(matrixDomain, secretDomain, tenantDomain))
+
+	jobDomain, err := domain.NewJobDomain(matrixDomain, secretDomain, tenantDomain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize job store: %w", err)
+	}
+	jobHandler := handler.NewJobHandler(jobDomain)
+
+	scheduleDomain, err := domain.NewScheduleDomain(jobDomain, cfg.SchedulesFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load schedules: %w", err)
+	}
+	scheduleHandler := handler.NewScheduleHandler(scheduleDomain)
+
+	// The scheduler submits jobs, so it is stopped before the job workers
+	shutdownDomain.Register("scheduler", schedulerStopTimeout, scheduleDomain.Stop)
+	shutdownDomain.Register("job_workers", jobWorkersStopTimeout, jobDomain.Stop)
+
+	memoryGuardDomain := domain.NewMemoryGuardDomain(cfg.MemoryLimit)
+	memoryGuardHandler := handler.NewMemoryGuardHandler(memoryGuardDomain)
+
+	backpressureDomain := domain.NewBackpressureDomain(jobDomain.QueueStatus)
+	metricsDomain.Register(backpressureDomain.Metrics)
+	backpressureHandler := handler.NewBackpressureHandler(backpressureDomain)
+	// guard sheds the requests running operations under memory pressure and while the workers are saturated
+	guard := func(h http.HandlerFunc) http.Handler {
+		return memoryGuardHandler.Guard(backpressureHandler.ShedOperations(h))
+	}
+
+	parsingHandler := handler.NewParsingHandler(parsing.Options{Lenient: cfg.LenientParsing})
+	qosHandler := handler.NewQoSHandler()
+
+	timeoutConfig := domain.TimeoutConfig{
+		Request:   cfg.RequestTimeout,
+		Operation: cfg.OperationTimeout,
+	}
+	timeoutHandler := handler.NewTimeoutHandler(timeoutConfig)
+	limit := func(h http.HandlerFunc) http.Handler { return timeoutHandler.Limit(h) }
+	limitOperation := timeoutHandler.LimitOperation
+
+	// Routes running operations are shed under memory pressure and overload and given the longer operation timeout;
+	// cheap lookups keep being served, under the request timeout
+	api := http.NewServeMux()
+	api.Handle("/", limit(matrixHandler.ListMatrixOperations))
+	api.Handle("POST /{$}", limitOperation(guard(matrixHandler.ProcessForm)))
+	api.Handle("/matrix", limit(matrixHandler.ListMatrixOperations))
+	api.Handle("/matrix/", limitOperation(guard(matrixHandler.ProcessMatrix)))
+	api.Handle("/matrix/report", limitOperation(http.HandlerFunc(reportHandler.ReportMatrix)))
+	api.Handle("/matrix/aggregate", limitOperation(guard(aggregateHandler.Aggregate)))
+	api.Handle("/matrix/batch", limitOperation(guard(batchHandler.ProcessBatch)))
+	api.Handle("/matrix/split", limitOperation(guard(matrixHandler.SplitMatrix)))
+	api.Handle("/batch/", limitOperation(guard(matrixHandler.ProcessArchive)))
+	api.Handle("/results/recent", limit(historyHandler.ListRecentResults))
+	api.Handle("DELETE /files", limit(retentionHandler.DeleteFile))
+	api.Handle("PUT /files", limitOperation(http.HandlerFunc(versionHandler.UpdateFile)))
+	api.Handle("/files/restore", limit(retentionHandler.RestoreFile))
+	api.Handle("/files/trash", limit(retentionHandler.ListDeletedFiles))
+	api.Handle("/files/retention", limit(retentionHandler.SetRetention))
+	api.Handle("/files/versions", limit(versionHandler.ListVersions))
+	api.Handle("/files/tags", limit(tagHandler.HandleTags))
+	api.Handle("/matrices", limit(catalogHandler.SearchMatrices))
+	api.Handle("/matrices/export", limitOperation(http.HandlerFunc(catalogHandler.ExportMatrices)))
+	api.Handle("/share", limit(signedURLHandler.CreateSignedURL))
+	api.Handle("/uploads", limit(uploadHandler.CreateUpload))
+	// Completing an upload validates the matrix uploaded
+	api.Handle("/uploads/", limitOperation(http.HandlerFunc(uploadHandler.HandleUpload)))
+	// WebSocket connections outlive any request timeout; each message is given its own
+	api.HandleFunc("/ws", webSocketHandler.ServeWebSocket)
+	api.Handle("/jobs", timeoutHandler.Limit(memoryGuardHandler.Guard(backpressureHandler.ShedJobs(http.HandlerFunc(jobHandler.CreateJob)))))
+	api.Handle("/jobs/", limit(jobHandler.HandleJob))
+	api.Handle("/schedules", limit(scheduleHandler.HandleSchedules))
+	api.Handle("/schedules/", limit(scheduleHandler.HandleSchedule))
+
+	auditDomain, err := domain.NewAuditDomain(cfg.AuditLogFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	auditHandler := handler.NewAuditHandler(auditDomain)
+	dashboardHandler := handler.NewDashboardHandler(domain.NewDashboardDomain(matrixDomain, jobDomain, auditDomain))
+	api.Handle("/ui/api/summary", limit(dashboardHandler.GetSummary))
+	adminHandler := handler.NewAdminHandler(secretDomain)
+	operationHandler := handler.NewOperationHandler(domain.NewOperationRegistryDomain())
+
+	healthDomain := domain.NewHealthDomain()
+	healthDomain.Register("data_directory", matrixDomain.CheckHealth)
+	healthDomain.Register("job_store", jobDomain.CheckHealth)
+	healthDomain.Register("secrets", secretDomain.CheckHealth)
+	healthHandler := handler.NewHealthHandler(healthDomain, drainDomain)
+	drainHandler := handler.NewDrainHandler(drainDomain)
+
+	admin := http.NewServeMux()
+	admin.Handle("/admin/audit", limit(auditHandler.ListAuditEntries))
+	admin.Handle("/admin/usage", limit(tenantHandler.ListUsage))
+	admin.Handle("/admin/operations", limit(operationHandler.HandleOperations))
+	admin.Handle("/admin/operations/", limit(operationHandler.HandleOperation))
+	cacheHandler := handler.NewCacheHandler(matrixDomain)
+	admin.Handle("/admin/cache/flush", limit(cacheHandler.FlushCache))
+	admin.Handle("/admin/cache/invalidate", limit(cacheHandler.InvalidateCache))
+
+	// Requests are checked against the OpenAPI document once authenticated, so unknown parameters and operations
+	// are rejected before any handler reads them
+	openAPIHandler := handler.NewOpenAPIHandler(domain.NewMatrixOperationsDomain())
+	audited := http.NewServeMux()
+	// The class of service depends on the tenant, so requests are classified once it is known
+	audited.Handle("/", tenantHandler.RequireTenant(qosHandler.Classify(openAPIHandler.Validate(parsingHandler.Parse(api)))))
+	audited.Handle("/admin/", adminHandler.RequireAdmin(openAPIHandler.Validate(admin)))
+	// Browsers load the dashboard page without an API key; the page sends one with its API calls
+	audited.Handle("/ui/", limit(dashboardHandler.ServeAssets))
+	// Signed URLs stand in for the API key of the tenant that shared them
+	audited.Handle("/shared/", signedURLHandler.RequireSignature(qosHandler.Classify(
+		parsingHandler.Parse(limitOperation(guard(matrixHandler.ProcessMatrix))))))
+	audited.Handle("/ui/api/", tenantHandler.RequireTenant(qosHandler.Classify(openAPIHandler.Validate(parsingHandler.Parse(api)))))
+
+	// Health checks, drain status requests and metrics scrapes come from orchestrators and monitoring systems
+	// that hold no API key, and are left out of the audit log and of the requests in flight, like the OpenAPI
+	// document, which describes how to get one
+	metricsHandler := handler.NewMetricsHandler(metricsDomain)
+	mux := http.NewServeMux()
+	mux.Handle("/health", limit(healthHandler.HealthCheck))
+	mux.Handle("/drain", limit(drainHandler.DrainStatus))
+	mux.Handle("/metrics", limit(metricsHandler.ServeMetrics))
+	mux.Handle("/openapi.json", limit(openAPIHandler.ServeDocument))
+	mux.Handle("/", drainHandler.Track(auditHandler.Audit(handler.NewCSRFHandler().Protect(audited))))
+
+	profileHandler := handler.NewProfileHandler(cfg.DebugProfile)
+	// The gRPC server shares the audit log, metrics, timeouts and load shedding of the HTTP server
+	grpcServer := grpcserver.NewServer(matrixDomain, tenantDomain, auditDomain, metricsDomain, memoryGuardDomain,
+		backpressureDomain, timeoutConfig)
+	return middleware.RequestID(handler.NewLoggingHandler().Scope(
+		profileHandler.Profile(metricsHandler.Record(mux)))), grpcServer, nil
+}
+
+// newTelemetryDomain configures the OpenTelemetry exporters from the standard OTEL_* environment variables.
+func newTelemetryDomain(metricsDomain domain.MetricsDomainInterface) (domain.TelemetryDomainInterface, error) {
+	return domain.NewTelemetryDomain(domain.TelemetryConfig{
+		SDKDisabled:          os.Getenv("OTEL_SDK_DISABLED"),
+		Endpoint:             os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		LogsEndpoint:         os.Getenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"),
+		MetricsEndpoint:      os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"),
+		Headers:              os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"),
+		Protocol:             os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"),
+		Timeout:              os.Getenv("OTEL_EXPORTER_OTLP_TIMEOUT"),
+		LogsExporter:         os.Getenv("OTEL_LOGS_EXPORTER"),
+		MetricsExporter:      os.Getenv("OTEL_METRICS_EXPORTER"),
+		MetricExportInterval: os.Getenv("OTEL_METRIC_EXPORT_INTERVAL"),
+		ServiceName:          os.Getenv("OTEL_SERVICE_NAME"),
+		ResourceAttributes:   os.Getenv("OTEL_RESOURCE_ATTRIBUTES"),
+	}, metricsDomain)
+}
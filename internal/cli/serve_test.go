@@ -0,0 +1,919 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestNewServeMux(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "health", method: http.MethodGet, path: "/health", wantStatus: http.StatusOK},
+		{name: "detailed health", method: http.MethodGet, path: "/health?details=true", wantStatus: http.StatusOK},
+		{name: "drain status", method: http.MethodGet, path: "/drain", wantStatus: http.StatusOK},
+		{name: "operations", method: http.MethodGet, path: "/matrix", wantStatus: http.StatusOK},
+		{name: "validation report", method: http.MethodGet, path: "/matrix/report?file=testdata/matrix3.csv", wantStatus: http.StatusOK},
+		{name: "validation report of a missing file", method: http.MethodGet, path: "/matrix/report?file=testdata/missing.csv", wantStatus: http.StatusNotFound},
+		{name: "directory aggregate", method: http.MethodGet, path: "/matrix/aggregate?dir=testdata&op=sum", wantStatus: http.StatusOK},
+		{name: "directory aggregate outside testdata", method: http.MethodGet, path: "/matrix/aggregate?dir=/etc&op=sum", wantStatus: http.StatusBadRequest},
+		{name: "concat", method: http.MethodGet, path: "/matrix/concat?file=testdata/matrix1.csv&file2=testdata/matrix1.csv", wantStatus: http.StatusOK},
+		{name: "concat of matrices that do not line up", method: http.MethodGet,
+			path: "/matrix/concat?file=testdata/matrix1.csv&file2=testdata/matrix1.csv&axis=vertical", wantStatus: http.StatusUnprocessableEntity},
+		{name: "matmul of matrices that do not line up", method: http.MethodGet,
+			path: "/matrix/matmul?file=testdata/matrix1.csv&file2=testdata/matrix1.csv", wantStatus: http.StatusUnprocessableEntity},
+		{name: "split", method: http.MethodGet, path: "/matrix/split?file=testdata/matrix1.csv&rows=3", wantStatus: http.StatusOK},
+		{name: "split into unequal tiles", method: http.MethodGet, path: "/matrix/split?file=testdata/matrix1.csv&rows=2", wantStatus: http.StatusUnprocessableEntity},
+		{name: "landing form without a form", method: http.MethodPost, path: "/", wantStatus: http.StatusBadRequest},
+		{name: "post to an unknown path", method: http.MethodPost, path: "/unknown", wantStatus: http.StatusMethodNotAllowed},
+		{name: "schedules", method: http.MethodGet, path: "/schedules", wantStatus: http.StatusOK},
+		{name: "unknown job", method: http.MethodGet, path: "/jobs/missing", wantStatus: http.StatusNotFound},
+		{name: "dashboard", method: http.MethodGet, path: "/ui/", wantStatus: http.StatusOK},
+		{name: "dashboard without a trailing slash", method: http.MethodGet, path: "/ui", wantStatus: http.StatusTemporaryRedirect},
+		{name: "dashboard summary", method: http.MethodGet, path: "/ui/api/summary", wantStatus: http.StatusOK},
+		{name: "OpenAPI document", method: http.MethodGet, path: "/openapi.json", wantStatus: http.StatusOK},
+		{name: "unknown query parameter", method: http.MethodGet, path: "/matrix/sum?file=testdata/matrix1.csv&fromat=json", wantStatus: http.StatusBadRequest},
+		{name: "unknown operation", method: http.MethodGet, path: "/matrix/sqrt?file=testdata/matrix1.csv", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			// Every response carries the ID of its request, whatever its outcome
+			assert.Regexp(t, "^[0-9a-f]{32}$", w.Header().Get("X-Request-ID"))
+		})
+	}
+}
+
+func TestNewServeMux_Batch(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/matrix/batch", strings.NewReader(`{"items":[
+		{"operation":"sum","file":"testdata/matrix1.csv"},
+		{"operation":"flatten","file":"testdata/matrix1.csv"},
+		{"operation":"sum","file":"testdata/missing.csv"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Succeeded int
+		Failed    int
+		Items     []struct {
+			Operation string
+			Status    int
+			Result    string
+		}
+	}
+	if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response)) {
+		assert.Equal(t, 2, response.Succeeded)
+		assert.Equal(t, 1, response.Failed)
+		if assert.Len(t, response.Items, 3) {
+			assert.Equal(t, "flatten", response.Items[1].Operation)
+			assert.Equal(t, http.StatusNotFound, response.Items[2].Status)
+		}
+	}
+
+	// The batch route is not mistaken for an operation named batch
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/batch", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestNewServeMux_Drain(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	drainDomain := domain.NewDrainDomain()
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), drainDomain, domain.NewMetricsDomain())
+	assert.NoError(t, err)
+	drainDomain.StartDrain()
+
+	// Load balancers see the server as unhealthy while requests keep being served
+	for path, wantStatus := range map[string]int{
+		"/health": http.StatusServiceUnavailable,
+		"/drain":  http.StatusServiceUnavailable,
+		"/matrix": http.StatusOK,
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, wantStatus, w.Code, path)
+	}
+}
+
+func TestNewServeMux_Metrics(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	metricsDomain := domain.NewMetricsDomain()
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), metricsDomain)
+	assert.NoError(t, err)
+
+	for _, path := range []string{"/health", "/matrix", "/jobs/missing", "/matrix/sum?file=testdata/matrix1.csv"} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	metrics := make(map[string]*entity.Metric)
+	for _, metric := range metricsDomain.Snapshot().Metrics {
+		metrics[metric.Name] = metric
+	}
+	routes := make(map[string]string)
+	for _, point := range metrics["http.server.request.duration"].Points {
+		routes[point.Attributes["http.route"]] = point.Attributes["http.response.status_code"]
+	}
+	assert.Equal(t, map[string]string{
+		"/health": "200", "/matrix": "200", "/jobs/{id}": "404", "/matrix/{operation}": "200",
+	}, routes)
+
+	// The matrix domain describes the operation of the request, which the middleware measures
+	if assert.Len(t, metrics["matrix.operation.duration"].Points, 1) {
+		assert.Equal(t, "sum", metrics["matrix.operation.duration"].Points[0].Attributes["matrix.operation"])
+	}
+	if assert.Len(t, metrics["matrix.size"].Points, 1) {
+		assert.Equal(t, uint64(1), metrics["matrix.size"].Points[0].Count)
+	}
+
+	// The matrix domain reports the metrics of its memo and file cache
+	assert.Contains(t, metrics, "matrix.memo.lookups")
+	assert.Contains(t, metrics, "matrix.memo.entries")
+	assert.Contains(t, metrics, "matrix.cache.lookups")
+	assert.Contains(t, metrics, "matrix.cache.hit_ratio")
+
+	// The backpressure domain reports the work queued and the requests rejected under overload
+	assert.Contains(t, metrics, "backpressure.queue.size")
+	assert.Contains(t, metrics, "backpressure.rejections")
+
+	// Prometheus scrapes the same metrics from /metrics
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `matrix_operation_duration_seconds_count{matrix_operation="sum"} 1`)
+	assert.Contains(t, w.Body.String(), `http_server_errors_total{http_response_status_class="4xx",http_route="/jobs/{id}"} 1`)
+}
+
+func TestNewServeMux_Tenants(t *testing.T) {
+	tenantsFile := filepath.Join(t.TempDir(), "tenants.json")
+	assert.NoError(t, os.WriteFile(tenantsFile, []byte(`[{"id": "acme", "api_keys": ["acme-key"]}]`), 0o600))
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	cfg := config.Default
+	cfg.TenantsFile = tenantsFile
+
+	mux, _, err := newServeMux(cfg, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		path       string
+		apiKey     string
+		wantStatus int
+	}{
+		{name: "health needs no API key", path: "/health", wantStatus: http.StatusOK},
+		{name: "metrics need no API key", path: "/metrics", wantStatus: http.StatusOK},
+		{name: "missing API key", path: "/matrix", wantStatus: http.StatusUnauthorized},
+		{name: "unknown API key", path: "/matrix", apiKey: "globex-key", wantStatus: http.StatusUnauthorized},
+		{name: "valid API key", path: "/matrix", apiKey: "acme-key", wantStatus: http.StatusOK},
+		{name: "dashboard page needs no API key", path: "/ui/", wantStatus: http.StatusOK},
+		{name: "dashboard summary without an API key", path: "/ui/api/summary", wantStatus: http.StatusUnauthorized},
+		{name: "dashboard summary with an API key", path: "/ui/api/summary", apiKey: "acme-key", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestNewServeMux_QoS(t *testing.T) {
+	tenantsFile := filepath.Join(t.TempDir(), "tenants.json")
+	assert.NoError(t, os.WriteFile(tenantsFile, []byte(`[
+		{"id": "acme", "api_keys": ["acme-key"]},
+		{"id": "globex", "api_keys": ["globex-key"], "qos_class": "batch"}
+	]`), 0o600))
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	cfg := config.Default
+	cfg.TenantsFile = tenantsFile
+
+	mux, _, err := newServeMux(cfg, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		header     string
+		wantStatus int
+		wantClass  string
+	}{
+		{name: "interactive tenant", apiKey: "acme-key", wantStatus: http.StatusOK, wantClass: "interactive"},
+		{name: "batch on request", apiKey: "acme-key", header: "batch", wantStatus: http.StatusOK, wantClass: "batch"},
+		{name: "batch tenant", apiKey: "globex-key", header: "interactive", wantStatus: http.StatusOK, wantClass: "batch"},
+		{name: "invalid class", apiKey: "acme-key", header: "realtime", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/matrix", nil)
+			req.Header.Set("X-API-Key", tt.apiKey)
+			if tt.header != "" {
+				req.Header.Set("X-QoS-Class", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantClass, w.Header().Get("X-QoS-Class"))
+		})
+	}
+}
+
+func TestNewServeMux_Audit(t *testing.T) {
+	dir := t.TempDir()
+	tenantsFile := filepath.Join(dir, "tenants.json")
+	assert.NoError(t, os.WriteFile(tenantsFile, []byte(`[{"id": "acme", "api_keys": ["acme-key"]}]`), 0o600))
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "admin-token")
+	cfg := config.Default
+	cfg.TenantsFile = tenantsFile
+	cfg.AuditLogFile = filepath.Join(dir, "audit.log")
+
+	mux, _, err := newServeMux(cfg, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	serve := func(path string, header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/health", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("/matrix", "X-API-Key", "unknown").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("/matrix/sum?file=testdata/matrix1.csv", "X-API-Key", "acme-key").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("/admin/audit", "X-API-Key", "acme-key").Code)
+
+	w := serve("/admin/audit", "Authorization", "Bearer admin-token")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var got struct {
+		Entries []struct {
+			Event     string `json:"event"`
+			Actor     string `json:"actor"`
+			Path      string `json:"path"`
+			Operation string `json:"operation"`
+			File      string `json:"file"`
+			Status    int    `json:"status"`
+		} `json:"entries"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	// The health check is not audited, and the listing itself is recorded once it has been served
+	if assert.Len(t, got.Entries, 3) {
+		assert.Equal(t, "denied", got.Entries[0].Event)
+		assert.Equal(t, "/admin/audit", got.Entries[0].Path)
+
+		assert.Equal(t, "denied", got.Entries[1].Event)
+		assert.Equal(t, "tenant:acme", got.Entries[1].Actor)
+		assert.Equal(t, "sum", got.Entries[1].Operation)
+		assert.Equal(t, "testdata/matrix1.csv", got.Entries[1].File)
+		assert.Equal(t, http.StatusBadRequest, got.Entries[1].Status)
+
+		assert.Equal(t, "denied", got.Entries[2].Event)
+		assert.Equal(t, http.StatusUnauthorized, got.Entries[2].Status)
+	}
+}
+
+func TestNewServeMux_CSRF(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	// A page load issues the token that later requests from the browser must echo
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ui/", nil))
+	cookies := w.Result().Cookies()
+	if !assert.Len(t, cookies, 1) {
+		return
+	}
+
+	post := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", strings.NewReader(`{"rows":[[1,2],[3,4]]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Origin", "http://localhost:8080")
+		req.AddCookie(cookies[0])
+		if token != "" {
+			req.Header.Set("X-CSRF-Token", token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, post(""))
+	assert.Equal(t, http.StatusOK, post(cookies[0].Value))
+}
+
+func TestServe(t *testing.T) {
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := serve(ctx, serveConfig("0"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports an invalid telemetry configuration", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/xml")
+
+		err := serve(context.Background(), serveConfig("0"))
+
+		assert.ErrorContains(t, err, "failed to configure telemetry")
+	})
+
+	t.Run("reports a port that cannot be used", func(t *testing.T) {
+		err := serve(context.Background(), serveConfig("not-a-port"))
+
+		assert.ErrorContains(t, err, "server failed to start")
+	})
+
+	t.Run("reports a gRPC port that cannot be used", func(t *testing.T) {
+		cfg := serveConfig("0")
+		cfg.GRPCPort = "not-a-port"
+
+		err := serve(context.Background(), cfg)
+
+		assert.ErrorContains(t, err, "gRPC server failed to start")
+	})
+}
+
+func TestServeCommand_Config(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		wantStderr string
+	}{
+		{
+			name:       "invalid environment variable",
+			env:        map[string]string{"MAX_MATRIX_DIMS": "large"},
+			args:       []string{"serve"},
+			wantStderr: "failed to configure server: invalid MAX_MATRIX_DIMS",
+		},
+		{
+			name:       "invalid flag",
+			args:       []string{"serve", "--read-timeout", "-1s"},
+			wantStderr: "invalid argument \"-1s\" for \"--read-timeout\"",
+		},
+		{
+			name:       "invalid memory limit",
+			args:       []string{"serve", "--memory-limit", "lots"},
+			wantStderr: "invalid argument \"lots\" for \"--memory-limit\"",
+		},
+		{
+			name:       "invalid stream limit",
+			env:        map[string]string{"STREAM_MAX_ROWS": "abc"},
+			args:       []string{"serve"},
+			wantStderr: "failed to configure server: invalid STREAM_MAX_ROWS",
+		},
+		{
+			name:       "invalid memo TTL",
+			env:        map[string]string{"MEMO_TTL": "forever"},
+			args:       []string{"serve"},
+			wantStderr: "failed to configure server: invalid MEMO_TTL",
+		},
+		{
+			name:       "invalid remote file host",
+			args:       []string{"serve", "--remote-file-hosts", "https://example.com"},
+			wantStderr: "invalid argument \"https://example.com\" for \"--remote-file-hosts\"",
+		},
+		{
+			name:       "negative drain delay",
+			env:        map[string]string{"DRAIN_DELAY": "-5s"},
+			args:       []string{"serve"},
+			wantStderr: "failed to configure server: invalid DRAIN_DELAY",
+		},
+		{
+			name:       "operation timeout outlasting the write timeout",
+			env:        map[string]string{"OPERATION_TIMEOUT": "1m"},
+			args:       []string{"serve"},
+			wantStderr: "failed to configure server: invalid input: request and operation timeouts must be shorter",
+		},
+		{
+			name:       "invalid flag overriding a valid environment variable",
+			env:        map[string]string{"PORT": "8081"},
+			args:       []string{"serve", "-p", "http"},
+			wantStderr: "invalid port \"http\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			var stderr bytes.Buffer
+
+			code := Execute(context.Background(), tt.args, strings.NewReader(""), &bytes.Buffer{}, &stderr)
+
+			assert.Equal(t, apperrors.ExitUsage, code)
+			assert.Contains(t, stderr.String(), tt.wantStderr)
+		})
+	}
+}
+
+// serveConfig returns the default server configuration listening on port, with the gRPC server on any free port,
+// shutting down without draining.
+func serveConfig(port string) config.Config {
+	cfg := config.Default
+	cfg.Port = port
+	cfg.GRPCPort = "0"
+	cfg.DrainDelay = 0
+	return cfg
+}
+
+func TestNewServeMux_LenientParsing(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	t.Chdir(t.TempDir())
+	assert.NoError(t, os.MkdirAll("testdata", 0o755))
+	// As exported by a spreadsheet: a byte order mark, padded values and a row of empty cells
+	assert.NoError(t, os.WriteFile("testdata/export.csv", []byte("\ufeff 1 , 2\r\n3 , 4\r\n,\r\n"), 0o644))
+
+	tests := []struct {
+		name       string
+		lenient    bool
+		query      string
+		wantStatus int
+	}{
+		{name: "strict by default", wantStatus: http.StatusUnprocessableEntity},
+		{name: "lenient on request", query: "&lenient=true", wantStatus: http.StatusOK},
+		{name: "lenient by default", lenient: true, wantStatus: http.StatusOK},
+		{name: "strict on request", lenient: true, query: "&lenient=false", wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid parameter", query: "&lenient=maybe", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default
+			cfg.LenientParsing = tt.lenient
+			mux, _, err := newServeMux(cfg, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/export.csv"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "10", w.Body.String())
+			}
+		})
+	}
+
+	t.Run("header row", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile("testdata/scores.csv", []byte("home,away\n1,2\n3,4\n"), 0o644))
+		mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/echo?file=testdata/scores.csv&header=true&format=json", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, []any{"home", "away"}, body["column_labels"])
+		assert.Equal(t, []any{[]any{1.0, 2.0}, []any{3.0, 4.0}}, body["matrix"])
+		assert.Equal(t, map[string]any{"rows": 2.0, "cols": 2.0}, body["output"])
+	})
+
+	t.Run("header row and row labels", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile("testdata/standings.csv", []byte("team,won,lost\nlions,3,1\ntigers,2,2\n"), 0o644))
+		mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+			"/matrix/invert?file=testdata/standings.csv&header=true&row_labels=true&format=csv", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, ",lions,tigers\nwon,3,2\nlost,1,2\n", w.Body.String())
+	})
+}
+
+func TestNewServeMux_MemoryLimit(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	t.Run("operations are shed above the limit", func(t *testing.T) {
+		// Any running process uses more than a kibibyte, so the server is always under memory pressure
+		cfg := config.Default
+		cfg.MemoryLimit = 1 << 10
+
+		mux, _, err := newServeMux(cfg, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "5", w.Header().Get("Retry-After"))
+
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestNewServeMux_Operations(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "admin-token")
+
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	// The registry is shared by the whole process, so echo is enabled again whatever happens
+	t.Cleanup(func() { serve(http.MethodPatch, "/admin/operations/echo", "admin-token", `{"enabled":true}`) })
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/admin/operations", "", "").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/admin/operations", "admin-token", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/admin/operations?enabled=true", "admin-token", "").Code)
+
+	w := serve(http.MethodPatch, "/admin/operations/echo", "admin-token", `{"enabled":false}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"echo","description":"Returns the matrix unchanged.","built_in":true,"enabled":false}`, w.Body.String())
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/matrix/echo?file=testdata/matrix1.csv", "", "").Code)
+	assert.NotContains(t, serve(http.MethodGet, "/matrix", "", "").Body.String(), "/matrix/echo")
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodPatch, "/admin/operations/echo", "admin-token", `{"enabled":true}`).Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/matrix/echo?file=testdata/matrix1.csv", "", "").Code)
+}
+
+func TestNewServeMux_Timeouts(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	t.Run("routes are given their own timeout", func(t *testing.T) {
+		// Lookups expire before they start, while operations keep the default timeout
+		cfg := config.Default
+		cfg.RequestTimeout = time.Nanosecond
+
+		mux, _, err := newServeMux(cfg, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices", nil))
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestNewServeMux_RecentResults(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	for _, url := range []string{"/matrix/sum?file=testdata/matrix1.csv", "/matrix/invert?file=testdata/matrix1.csv&rows=1"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/results/recent?limit=1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var got struct {
+		Results []struct {
+			Operation string `json:"operation"`
+			File      string `json:"file"`
+			Checksum  string `json:"checksum"`
+			Result    string `json:"result"`
+		} `json:"results"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	if assert.Len(t, got.Results, 1) {
+		assert.Equal(t, "invert", got.Results[0].Operation)
+		assert.Equal(t, "testdata/matrix1.csv", got.Results[0].File)
+		assert.Regexp(t, "^sha256:[0-9a-f]{64}$", got.Results[0].Checksum)
+		assert.NotEmpty(t, got.Results[0].Result)
+	}
+}
+
+func TestNewServeMux_Export(t *testing.T) {
+	exportDir := t.TempDir()
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	t.Setenv("S3_ACCESS_KEY_ID", "")
+	cfg := config.Default
+	cfg.ExportDir = exportDir
+
+	mux, _, err := newServeMux(cfg, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&export=daily/sum.csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "daily/sum.csv", w.Header().Get("Matrix-Export"))
+
+	data, err := os.ReadFile(filepath.Join(exportDir, "daily", "sum.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "378\n", string(data))
+
+	// Object storage targets need access keys
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&export=s3://reports/sum.csv", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNewServeMux_DeleteAndRestore(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	assert.NoError(t, os.MkdirAll("testdata", 0o755))
+	assert.NoError(t, os.WriteFile("testdata/out.csv", []byte("1,2\n3,4\n"), 0o644))
+
+	shutdownDomain := domain.NewShutdownDomain()
+	mux, _, err := newServeMux(config.Default, shutdownDomain, domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+	t.Cleanup(func() { shutdownDomain.Shutdown(context.Background()) })
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/files?file=testdata/out.csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/out.csv", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/trash", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"file":"testdata/out.csv"`)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/files/restore?file=testdata/out.csv", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/out.csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "10", w.Body.String())
+}
+
+func TestNewServeMux_Versions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	assert.NoError(t, os.MkdirAll("testdata", 0o755))
+	assert.NoError(t, os.WriteFile("testdata/league.csv", []byte("1,2\n3,4\n"), 0o644))
+
+	shutdownDomain := domain.NewShutdownDomain()
+	mux, _, err := newServeMux(config.Default, shutdownDomain, domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+	t.Cleanup(func() { shutdownDomain.Shutdown(context.Background()) })
+
+	update := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/files?file=testdata/league.csv", strings.NewReader(`{"rows":[[5,6],[7,8]]}`))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/versions?file=testdata/league.csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	assert.Equal(t, http.StatusPreconditionRequired, update("").Code)
+	w = update(etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "testdata/league.csv@v2", w.Header().Get("Matrix-File"))
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	// A second writer holding the same ETag would overwrite the update
+	assert.Equal(t, http.StatusPreconditionFailed, update(etag).Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/versions?file=testdata/league.csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ref":"testdata/league.csv@v1"`)
+
+	for ref, want := range map[string]string{
+		"testdata/league.csv":    "26",
+		"testdata/league.csv@v2": "26",
+		"testdata/league.csv@v1": "10",
+	} {
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file="+ref, nil))
+		assert.Equal(t, http.StatusOK, w.Code, ref)
+		assert.Equal(t, want, w.Body.String(), ref)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/league.csv@v3", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNewServeMux_SignedURL(t *testing.T) {
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	t.Setenv("SIGNED_URL_KEY", "s3cret")
+
+	mux, _, err := newServeMux(config.Default, domain.NewShutdownDomain(), domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/share?file=testdata/matrix1.csv&operation=sum&ttl=1h", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var got struct {
+		URL string `json:"url"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.True(t, strings.HasPrefix(got.URL, "http://example.com/shared/sum?"), got.URL)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, got.URL, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "378", w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+
+	// The signature covers the operation
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, strings.Replace(got.URL, "/shared/sum", "/shared/invert", 1), nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestNewServeMux_Matrices(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	assert.NoError(t, os.MkdirAll("testdata", 0o755))
+	assert.NoError(t, os.WriteFile("testdata/league-2026.csv", []byte("1,2\n3,4\n5,6\n"), 0o644))
+
+	shutdownDomain := domain.NewShutdownDomain()
+	mux, _, err := newServeMux(config.Default, shutdownDomain, domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+	t.Cleanup(func() { shutdownDomain.Shutdown(context.Background()) })
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices?name=LEAGUE&min_rows=3", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Body.String(), `"file":"testdata/league-2026.csv","name":"league-2026.csv","rows":3,"cols":2`)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices?sort=owner", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNewServeMux_Tags(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	assert.NoError(t, os.MkdirAll("testdata", 0o755))
+	assert.NoError(t, os.WriteFile("testdata/league-2026.csv", []byte("1,2\n3,4\n"), 0o644))
+	assert.NoError(t, os.WriteFile("testdata/league-2025.csv", []byte("1,2\n3,4\n"), 0o644))
+
+	shutdownDomain := domain.NewShutdownDomain()
+	mux, _, err := newServeMux(config.Default, shutdownDomain, domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+	t.Cleanup(func() { shutdownDomain.Shutdown(context.Background()) })
+
+	req := httptest.NewRequest(http.MethodPut, "/files/tags?file=testdata/league-2026.csv",
+		strings.NewReader(`{"tags":{"season":"2026"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/tags?file=testdata/league-2026.csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"file":"testdata/league-2026.csv","tags":{"season":"2026"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices?tag=season:2026", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Body.String(), `"file":"testdata/league-2026.csv"`)
+	assert.Contains(t, w.Body.String(), `"tags":{"season":"2026"}`)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/tags?file=testdata/missing.csv", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNewServeMux_MatricesExport(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("JOB_DATABASE_URL", "")
+	t.Setenv("WEBHOOK_SECRET", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	assert.NoError(t, os.MkdirAll("testdata", 0o755))
+	assert.NoError(t, os.WriteFile("testdata/league-2026.csv", []byte("1,2\n3,4\n5,6\n"), 0o644))
+
+	shutdownDomain := domain.NewShutdownDomain()
+	mux, _, err := newServeMux(config.Default, shutdownDomain, domain.NewDrainDomain(), domain.NewMetricsDomain())
+	assert.NoError(t, err)
+	t.Cleanup(func() { shutdownDomain.Shutdown(context.Background()) })
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices/export?name=league-2026", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if assert.NoError(t, err) && assert.Len(t, archive.File, 2) {
+		assert.Equal(t, "testdata/league-2026.csv", archive.File[0].Name)
+		assert.Equal(t, "manifest.json", archive.File[1].Name)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices/export?sort=owner", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,58 @@
+package codec
+
+import (
+	"bufio"
+	"io"
+	"sync"
+
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// maxPooledLine bounds the capacity of the row buffers kept for reuse,
+// so encoding one unusually wide matrix does not pin its buffer in memory.
+const maxPooledLine = 16 << 10
+
+// rowWriter buffers the output of row-oriented codecs and holds a scratch buffer rows are rendered into.
+// Writers are pooled, so encoding a result allocates neither the write buffer nor the row buffer.
+type rowWriter struct {
+	*bufio.Writer
+	line []byte
+}
+
+var rowWriterPool = sync.Pool{
+	New: func() any {
+		return &rowWriter{
+			Writer: bufio.NewWriter(nil),
+			line:   make([]byte, 0, 64),
+		}
+	},
+}
+
+// newRowWriter takes a writer from the pool and points it at w.
+// Callers must flush it and then hand it back with release.
+func newRowWriter(w io.Writer) *rowWriter {
+	rw := rowWriterPool.Get().(*rowWriter)
+	rw.Reset(w)
+	rw.line = rw.line[:0]
+	return rw
+}
+
+// release returns the writer to the pool. It must not be used afterwards.
+func (rw *rowWriter) release() {
+	// Drop the reference to the destination, so the pool does not keep it alive
+	rw.Reset(nil)
+	if cap(rw.line) <= maxPooledLine {
+		rowWriterPool.Put(rw)
+	}
+}
+
+// appendRow appends the values of row, held at scale, to b as comma-separated numbers.
+func appendRow(b []byte, row []int64, scale int) []byte {
+	for j, val := range row {
+		if j > 0 {
+			b = append(b, ',')
+		}
+		b = matrixlib.AppendValue(b, val, scale)
+	}
+	return b
+}
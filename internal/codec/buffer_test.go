@@ -0,0 +1,58 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+func TestAppendRow(t *testing.T) {
+	assert.Equal(t, "row:1,-2,30", string(appendRow([]byte("row:"), []int64{1, -2, 30}, 0)))
+	assert.Equal(t, "row:0.01,-0.2,3", string(appendRow([]byte("row:"), []int64{1, -20, 300}, 2)))
+	assert.Equal(t, "", string(appendRow(nil, nil, 0)))
+}
+
+func TestRowWriter(t *testing.T) {
+	var first, second bytes.Buffer
+
+	writer := newRowWriter(&first)
+	writer.line = append(writer.line, "left over"...)
+	_, err := writer.WriteString("first")
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Flush())
+	writer.release()
+
+	// A writer taken from the pool starts empty and writes to its new destination only
+	writer = newRowWriter(&second)
+	assert.Empty(t, writer.line)
+	_, err = writer.WriteString("second")
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Flush())
+	writer.release()
+
+	assert.Equal(t, "first", first.String())
+	assert.Equal(t, "second", second.String())
+}
+
+func TestStreamingCodec_WriteResultAllocations(t *testing.T) {
+	data := make([][]int64, 10)
+	for i := range data {
+		data[i] = []int64{1, -2, 300, 4000, 50000, 600000, 7000000, 8, 9, 10}
+	}
+	result := &entity.Result{Matrix: &entity.Matrix[int64]{Data: data}}
+
+	// Pooled writers leave repeated text and CSV encodings of a matrix without allocations
+	for _, format := range []string{"text", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			streaming := formats[format].(StreamingCodec)
+			allocs := testing.AllocsPerRun(100, func() {
+				_ = streaming.WriteResult(io.Discard, "echo", result)
+			})
+			assert.Zero(t, allocs)
+		})
+	}
+}
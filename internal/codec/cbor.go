@@ -0,0 +1,39 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const cborContentType = "application/cbor"
+
+// cborCodec encodes results and decodes request matrices as CBOR maps (RFC 8949).
+type cborCodec struct{}
+
+func (c *cborCodec) ContentType() string {
+	return cborContentType
+}
+
+func (c *cborCodec) FileExtension() string {
+	return "cbor"
+}
+
+func (c *cborCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	data, err := cbor.Marshal(newResultPayload(operation, result))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cbor result: %w", err)
+	}
+	return data, nil
+}
+
+func (c *cborCodec) DecodeMatrix(data []byte) (*entity.Matrix[int64], error) {
+	payload := &matrixPayload{}
+	if err := cbor.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode cbor matrix: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+	return &entity.Matrix[int64]{Data: payload.Rows, Scale: payload.Scale}, nil
+}
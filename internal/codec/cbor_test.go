@@ -0,0 +1,73 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestCborCodec_EncodeResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		result    *entity.Result
+		want      resultPayload
+	}{
+		{
+			name:      "matrix result",
+			operation: "flatten",
+			result:    &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2, 3, 4}}}},
+			want:      resultPayload{Operation: "flatten", Matrix: [][]int64{{1, 2, 3, 4}}},
+		},
+		{
+			name:      "scalar result",
+			operation: "multiply",
+			result:    &entity.Result{Scalar: "24"},
+			want:      resultPayload{Operation: "multiply", Scalar: "24"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := (&cborCodec{}).EncodeResult(tt.operation, tt.result)
+			assert.NoError(t, err)
+
+			var got resultPayload
+			assert.NoError(t, cbor.Unmarshal(data, &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCborCodec_DecodeMatrix(t *testing.T) {
+	t.Run("decodes rows map", func(t *testing.T) {
+		data, err := cbor.Marshal(map[string]any{"rows": [][]int64{{1, 2}, {-3, 4}}})
+		assert.NoError(t, err)
+
+		got, err := (&cborCodec{}).DecodeMatrix(data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {-3, 4}}}, got)
+	})
+
+	t.Run("rejects non-integer cells", func(t *testing.T) {
+		data, err := cbor.Marshal(map[string]any{"rows": [][]string{{"a", "b"}}})
+		assert.NoError(t, err)
+
+		got, err := (&cborCodec{}).DecodeMatrix(data)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+
+	t.Run("rejects malformed payload", func(t *testing.T) {
+		got, err := (&cborCodec{}).DecodeMatrix([]byte{0xff})
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,143 @@
+package codec
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Codec defines the contract for translating matrices and operation results to and from a wire format.
+// Each implementation handles a single media type used for content negotiation on the HTTP API.
+type Codec interface {
+	// ContentType returns the media type written in the Content-Type header of encoded responses.
+	ContentType() string
+
+	// FileExtension returns the extension, without the leading dot, used when results are downloaded as files.
+	FileExtension() string
+
+	// EncodeResult serializes the result of the given operation.
+	EncodeResult(operation string, result *entity.Result) ([]byte, error)
+
+	// DecodeMatrix deserializes a matrix sent in a request body.
+	// Codecs that cannot carry request matrices return an ErrUnsupportedMediaType error.
+	DecodeMatrix(data []byte) (*entity.Matrix[int64], error)
+}
+
+// StreamingCodec is implemented by row-oriented codecs that can write a result incrementally.
+// Rows are encoded straight into w instead of being collected into a single buffer first.
+type StreamingCodec interface {
+	Codec
+
+	// WriteResult encodes the result of the given operation into w.
+	WriteResult(w io.Writer, operation string, result *entity.Result) error
+}
+
+// defaultCodec is used when the client does not express a preference or accepts any media type.
+var defaultCodec Codec = &textCodec{}
+
+// formats holds every supported codec keyed by the short name accepted in the format query parameter.
+var formats = map[string]Codec{
+	"text":     defaultCodec,
+	"csv":      &csvCodec{},
+	"json":     &jsonCodec{},
+	"ndjson":   &ndjsonCodec{},
+	"protobuf": &protobufCodec{},
+	"msgpack":  &msgpackCodec{},
+	"cbor":     &cborCodec{},
+}
+
+// codecs holds every supported codec keyed by its media type.
+var codecs = func() map[string]Codec {
+	byMediaType := make(map[string]Codec, len(formats))
+	for _, c := range formats {
+		byMediaType[c.ContentType()] = c
+	}
+	return byMediaType
+}()
+
+// Negotiate selects the codec for a response based on the request's Accept header.
+// Media ranges are ranked by their quality value; wildcards, missing headers and
+// unsupported media types fall back to the plain text codec.
+func Negotiate(accept string) Codec {
+	type candidate struct {
+		mediaType string
+		quality   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{mediaType: mediaType, quality: quality})
+	}
+
+	// Stable sort keeps the client's ordering for media ranges with equal quality
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if c.mediaType == "*/*" || c.mediaType == "text/*" {
+			return defaultCodec
+		}
+		if selected, ok := codecs[c.mediaType]; ok {
+			return selected
+		}
+	}
+
+	return defaultCodec
+}
+
+// ForContentType returns the codec able to decode a request body with the given Content-Type header.
+// It returns an ErrUnsupportedMediaType error when no codec handles the media type.
+func ForContentType(contentType string) (Codec, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid content type: %q", apperrors.ErrUnsupportedMediaType, contentType)
+	}
+
+	selected, ok := codecs[mediaType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", apperrors.ErrUnsupportedMediaType, mediaType)
+	}
+	return selected, nil
+}
+
+// ForFormat returns the codec registered under a short format name such as "json" or "csv".
+// It lets clients choose the response format with a query parameter instead of the Accept header,
+// and returns an ErrInvalidInput error for unknown names.
+func ForFormat(format string) (Codec, error) {
+	selected, ok := formats[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported format: %q", apperrors.ErrInvalidInput, format)
+	}
+	return selected, nil
+}
+
+// Formats returns the short names accepted by ForFormat, sorted alphabetically.
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
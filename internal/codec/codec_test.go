@@ -0,0 +1,266 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+	}{
+		{
+			name:            "empty accept header falls back to text",
+			accept:          "",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "wildcard falls back to text",
+			accept:          "*/*",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "explicit text",
+			accept:          "text/plain",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "explicit protobuf",
+			accept:          "application/x-protobuf",
+			wantContentType: "application/x-protobuf",
+		},
+		{
+			name:            "explicit msgpack",
+			accept:          "application/msgpack",
+			wantContentType: "application/msgpack",
+		},
+		{
+			name:            "explicit cbor",
+			accept:          "application/cbor",
+			wantContentType: "application/cbor",
+		},
+		{
+			name:            "explicit json",
+			accept:          "application/json",
+			wantContentType: "application/json",
+		},
+		{
+			name:            "explicit csv",
+			accept:          "text/csv",
+			wantContentType: "text/csv",
+		},
+		{
+			name:            "protobuf preferred by quality value",
+			accept:          "text/plain;q=0.5, application/x-protobuf;q=0.9",
+			wantContentType: "application/x-protobuf",
+		},
+		{
+			name:            "first listed wins on equal quality",
+			accept:          "text/plain, application/x-protobuf",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "zero quality is excluded",
+			accept:          "application/x-protobuf;q=0",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "unsupported media type falls back to text",
+			accept:          "application/xml",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "malformed header falls back to text",
+			accept:          ";;;",
+			wantContentType: "text/plain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Negotiate(tt.accept)
+			assert.Equal(t, tt.wantContentType, got.ContentType())
+		})
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentType     string
+		wantContentType string
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name:            "protobuf",
+			contentType:     "application/x-protobuf",
+			wantContentType: "application/x-protobuf",
+		},
+		{
+			name:            "msgpack",
+			contentType:     "application/msgpack",
+			wantContentType: "application/msgpack",
+		},
+		{
+			name:            "cbor",
+			contentType:     "application/cbor",
+			wantContentType: "application/cbor",
+		},
+		{
+			name:            "media type parameters are ignored",
+			contentType:     "text/plain; charset=utf-8",
+			wantContentType: "text/plain",
+		},
+		{
+			name:        "unsupported media type",
+			contentType: "application/xml",
+			wantErr:     true,
+			errType:     apperrors.ErrUnsupportedMediaType,
+		},
+		{
+			name:        "missing content type",
+			contentType: "",
+			wantErr:     true,
+			errType:     apperrors.ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ForContentType(tt.contentType)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantContentType, got.ContentType())
+			}
+		})
+	}
+}
+
+func TestCodec_FileExtension(t *testing.T) {
+	want := map[string]string{
+		"text":     "csv",
+		"csv":      "csv",
+		"json":     "json",
+		"ndjson":   "ndjson",
+		"protobuf": "binpb",
+		"msgpack":  "msgpack",
+		"cbor":     "cbor",
+	}
+
+	for format, c := range formats {
+		assert.Equal(t, want[format], c.FileExtension(), format)
+	}
+}
+
+func TestForFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		format          string
+		wantContentType string
+		wantErr         bool
+	}{
+		{name: "text", format: "text", wantContentType: "text/plain"},
+		{name: "csv", format: "csv", wantContentType: "text/csv"},
+		{name: "json", format: "json", wantContentType: "application/json"},
+		{name: "ndjson", format: "ndjson", wantContentType: "application/x-ndjson"},
+		{name: "binary formats", format: "protobuf", wantContentType: "application/x-protobuf"},
+		{name: "names are case insensitive", format: "JSON", wantContentType: "application/json"},
+		{name: "unknown format", format: "xml", wantErr: true},
+		{name: "media types are not format names", format: "application/json", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ForFormat(tt.format)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantContentType, got.ContentType())
+			}
+		})
+	}
+}
+
+func TestFormats(t *testing.T) {
+	got := Formats()
+
+	assert.Equal(t, []string{"cbor", "csv", "json", "msgpack", "ndjson", "protobuf", "text"}, got)
+	for _, format := range got {
+		_, err := ForFormat(format)
+		assert.NoError(t, err, format)
+	}
+}
+
+func TestStreamingCodec_WriteResult(t *testing.T) {
+	data := make([][]int64, 2000)
+	for i := range data {
+		data[i] = []int64{int64(i), int64(-i), 1 << 40}
+	}
+	result := &entity.Result{Matrix: &entity.Matrix[int64]{Data: data}}
+
+	for _, format := range []string{"text", "csv", "ndjson"} {
+		t.Run(format, func(t *testing.T) {
+			streaming, ok := formats[format].(StreamingCodec)
+			assert.True(t, ok)
+
+			want, err := streaming.EncodeResult("echo", result)
+			assert.NoError(t, err)
+
+			writer := &countingWriter{}
+			assert.NoError(t, streaming.WriteResult(writer, "echo", result))
+
+			assert.Equal(t, want, writer.buf.Bytes())
+			// Large results reach the writer in several chunks rather than one final write
+			assert.Greater(t, writer.writes, 1)
+		})
+	}
+}
+
+// countingWriter records how many writes it receives.
+type countingWriter struct {
+	buf    bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.buf.Write(p)
+}
+
+func BenchmarkCodec_EncodeResult(b *testing.B) {
+	data := make([][]int64, 10)
+	for i := range data {
+		data[i] = []int64{1, -2, 300, 4000, 50000, 600000, 7000000, 8, 9, 10}
+	}
+	result := &entity.Result{Matrix: &entity.Matrix[int64]{Data: data}}
+
+	for _, format := range Formats() {
+		b.Run(format, func(b *testing.B) {
+			c := formats[format]
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := c.EncodeResult("echo", result); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+const csvContentType = "text/csv"
+
+// csvCodec renders results as RFC 4180 CSV, one record per matrix row.
+// Unlike the text format every record, including the last one, is terminated by a newline.
+// Labelled results start with a header row of column labels and their records with the row label,
+// so the output reads back with the same header and row label options.
+type csvCodec struct{}
+
+func (c *csvCodec) ContentType() string {
+	return csvContentType
+}
+
+func (c *csvCodec) FileExtension() string {
+	return "csv"
+}
+
+func (c *csvCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.WriteResult(&buf, operation, result); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *csvCodec) WriteResult(w io.Writer, _ string, result *entity.Result) error {
+	if result == nil || result.Matrix == nil {
+		// csv.Writer buffers internally and quotes the scalar if it ever needs to
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{result.String()}); err != nil {
+			return fmt.Errorf("failed to write csv result: %w", err)
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to write csv result: %w", err)
+		}
+		return nil
+	}
+
+	if result.ColumnLabels != nil || result.RowLabels != nil {
+		return writeLabelledCSV(w, result)
+	}
+
+	// Numbers never need quoting, so matrix rows are rendered directly instead of through csv.Writer,
+	// which would take a string per value
+	writer := newRowWriter(w)
+	defer writer.release()
+	for _, row := range result.Matrix.Data {
+		writer.line = append(appendRow(writer.line[:0], row, result.Matrix.Scale), '\n')
+		if _, err := writer.Write(writer.line); err != nil {
+			return fmt.Errorf("failed to write csv result: %w", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write csv result: %w", err)
+	}
+	return nil
+}
+
+// writeLabelledCSV writes a matrix result along with its labels, which may need quoting, through csv.Writer.
+// Labelled results come from the operations on small matrices only, so the slower path is of no concern.
+func writeLabelledCSV(w io.Writer, result *entity.Result) error {
+	writer := csv.NewWriter(w)
+	if result.ColumnLabels != nil {
+		header := result.ColumnLabels
+		if result.RowLabels != nil {
+			// The corner cell sits above the row labels
+			header = append([]string{""}, header...)
+		}
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("failed to write csv result: %w", err)
+		}
+	}
+
+	for i, row := range result.Matrix.Data {
+		record := make([]string, 0, len(row)+1)
+		if result.RowLabels != nil {
+			record = append(record, result.RowLabels[i])
+		}
+		for _, val := range row {
+			record = append(record, string(matrixlib.AppendValue(nil, val, result.Matrix.Scale)))
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv result: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv result: %w", err)
+	}
+	return nil
+}
+
+func (c *csvCodec) DecodeMatrix(_ []byte) (*entity.Matrix[int64], error) {
+	return nil, fmt.Errorf("%w: %s request bodies are not supported", apperrors.ErrUnsupportedMediaType, csvContentType)
+}
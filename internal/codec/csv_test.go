@@ -0,0 +1,83 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestCSVCodec_EncodeResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *entity.Result
+		want   string
+	}{
+		{
+			name:   "matrix result",
+			result: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, -2, 3}, {4, 5, 6}}}},
+			want:   "1,-2,3\n4,5,6\n",
+		},
+		{
+			name:   "decimal matrix result",
+			result: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{125, -2000}, {5, 0}}, Scale: 3}},
+			want:   "0.125,-2\n0.005,0\n",
+		},
+		{
+			name: "labelled decimal matrix result",
+			result: &entity.Result{
+				Matrix:    &entity.Matrix[int64]{Data: [][]int64{{15, 2}}, Scale: 1},
+				RowLabels: []string{"lions"},
+			},
+			want: "lions,1.5,0.2\n",
+		},
+		{
+			name: "labelled matrix result",
+			result: &entity.Result{
+				Matrix:       &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}},
+				ColumnLabels: []string{"home", "away, goals"},
+				RowLabels:    []string{"lions", "tigers"},
+			},
+			want: ",home,\"away, goals\"\nlions,1,2\ntigers,3,4\n",
+		},
+		{
+			name: "column labels only",
+			result: &entity.Result{
+				Matrix:       &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+				ColumnLabels: []string{"home", "away"},
+			},
+			want: "home,away\n1,2\n",
+		},
+		{
+			name: "row labels only",
+			result: &entity.Result{
+				Matrix:    &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+				RowLabels: []string{"lions"},
+			},
+			want: "lions,1,2\n",
+		},
+		{
+			name:   "scalar result",
+			result: &entity.Result{Scalar: "45"},
+			want:   "45\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&csvCodec{}).EncodeResult("echo", tt.result)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestCSVCodec_DecodeMatrix(t *testing.T) {
+	got, err := (&csvCodec{}).DecodeMatrix([]byte("1,2\n"))
+
+	assert.ErrorIs(t, err, apperrors.ErrUnsupportedMediaType)
+	assert.Nil(t, got)
+}
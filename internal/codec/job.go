@@ -0,0 +1,63 @@
+package codec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// jobPayload describes the state of a job. Timestamps are omitted until the job reaches them,
+// and tags for jobs whose input file carried none.
+type jobPayload struct {
+	ID         string            `json:"id"`
+	Operation  string            `json:"operation"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Status     entity.JobStatus  `json:"status"`
+	CreatedAt  time.Time         `json:"created_at"`
+	StartedAt  *time.Time        `json:"started_at,omitempty"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+	Error      *jobError         `json:"error,omitempty"`
+}
+
+// jobError reports why a job failed, with the status code a synchronous request would have returned.
+type jobError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// EncodeJob serializes the state of a job as a JSON object. The same document is returned by the
+// job status endpoint and sent to the job's webhook when it finishes.
+func EncodeJob(job *entity.Job) ([]byte, error) {
+	payload := &jobPayload{
+		ID:        job.ID,
+		Operation: job.Operation,
+		Tags:      job.Tags,
+		Status:    job.Status,
+		CreatedAt: job.CreatedAt,
+	}
+	if !job.StartedAt.IsZero() {
+		payload.StartedAt = &job.StartedAt
+	}
+	if !job.FinishedAt.IsZero() {
+		payload.FinishedAt = &job.FinishedAt
+	}
+	if job.Status == entity.JobFailed {
+		statusCode, message := JobErrorStatus(job.Err)
+		payload.Error = &jobError{Status: statusCode, Message: message}
+	}
+
+	return json.Marshal(payload)
+}
+
+// JobErrorStatus maps the error of a failed job to the status code and message a synchronous request would have returned.
+func JobErrorStatus(err error) (int, string) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout, "job timeout"
+	}
+	return apperrors.GetHTTPStatusCode(err), err.Error()
+}
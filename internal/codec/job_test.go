@@ -0,0 +1,78 @@
+package codec
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestEncodeJob(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	startedAt := createdAt.Add(time.Second)
+	finishedAt := createdAt.Add(2 * time.Second)
+
+	tests := []struct {
+		name string
+		job  *entity.Job
+		want string
+	}{
+		{
+			name: "queued job",
+			job:  &entity.Job{ID: "abc", Operation: "sum", Status: entity.JobQueued, CreatedAt: createdAt},
+			want: `{"id":"abc","operation":"sum","status":"queued","created_at":"2026-01-02T03:04:05Z"}`,
+		},
+		{
+			name: "tagged job",
+			job: &entity.Job{
+				ID: "abc", Operation: "sum", Status: entity.JobQueued, CreatedAt: createdAt,
+				Tags: map[string]string{"season": "2026"},
+			},
+			want: `{"id":"abc","operation":"sum","tags":{"season":"2026"},"status":"queued","created_at":"2026-01-02T03:04:05Z"}`,
+		},
+		{
+			name: "succeeded job",
+			job: &entity.Job{
+				ID: "abc", Operation: "sum", Status: entity.JobSucceeded, Result: &entity.Result{Scalar: "10"},
+				CreatedAt: createdAt, StartedAt: startedAt, FinishedAt: finishedAt,
+			},
+			want: `{"id":"abc","operation":"sum","status":"succeeded","created_at":"2026-01-02T03:04:05Z",
+				"started_at":"2026-01-02T03:04:06Z","finished_at":"2026-01-02T03:04:07Z"}`,
+		},
+		{
+			name: "failed job",
+			job: &entity.Job{
+				ID: "abc", Operation: "sum", Status: entity.JobFailed,
+				Err:       fmt.Errorf("%w: invalid matrix", apperrors.ErrUnprocessableEntity),
+				CreatedAt: createdAt, StartedAt: startedAt, FinishedAt: finishedAt,
+			},
+			want: `{"id":"abc","operation":"sum","status":"failed","created_at":"2026-01-02T03:04:05Z",
+				"started_at":"2026-01-02T03:04:06Z","finished_at":"2026-01-02T03:04:07Z",
+				"error":{"status":422,"message":"unprocessable entity: invalid matrix"}}`,
+		},
+		{
+			name: "timed out job",
+			job: &entity.Job{
+				ID: "abc", Operation: "sum", Status: entity.JobFailed, Err: context.DeadlineExceeded,
+				CreatedAt: createdAt, StartedAt: startedAt, FinishedAt: finishedAt,
+			},
+			want: `{"id":"abc","operation":"sum","status":"failed","created_at":"2026-01-02T03:04:05Z",
+				"started_at":"2026-01-02T03:04:06Z","finished_at":"2026-01-02T03:04:07Z",
+				"error":{"status":504,"message":"job timeout"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeJob(tt.job)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
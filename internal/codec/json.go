@@ -0,0 +1,91 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+const jsonContentType = "application/json"
+
+// jsonCodec encodes results and decodes request matrices as JSON objects.
+// Scalars are encoded as strings because sums and products can exceed the range of JSON numbers.
+// Matrix values are JSON numbers, decimals included, written with their decimal point in place.
+type jsonCodec struct{}
+
+// jsonMatrixPayload is the JSON representation of a request matrix.
+type jsonMatrixPayload struct {
+	Rows [][]json.Number `json:"rows"`
+}
+
+// jsonResultPayload is the JSON representation of an operation result: a resultPayload whose matrix
+// values are decimal JSON numbers rather than integers scaled by a power of ten.
+type jsonResultPayload struct {
+	*resultPayload
+	Matrix [][]json.Number `json:"matrix,omitempty"`
+}
+
+func (c *jsonCodec) ContentType() string {
+	return jsonContentType
+}
+
+func (c *jsonCodec) FileExtension() string {
+	return "json"
+}
+
+func (c *jsonCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	payload := &jsonResultPayload{resultPayload: newResultPayload(operation, result)}
+	if result != nil && result.Matrix != nil {
+		payload.Matrix = MatrixToJSON(result.Matrix)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json result: %w", err)
+	}
+	return data, nil
+}
+
+func (c *jsonCodec) DecodeMatrix(data []byte) (*entity.Matrix[int64], error) {
+	payload := &jsonMatrixPayload{}
+	if err := json.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode json matrix: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+	return MatrixFromJSON(payload.Rows)
+}
+
+// MatrixToJSON converts the values of a Matrix entity into JSON numbers, written with their decimal point
+// in place, so decimals are encoded exactly.
+func MatrixToJSON(matrix *entity.Matrix[int64]) [][]json.Number {
+	rows := make([][]json.Number, matrix.Rows())
+	for i, row := range matrix.Data {
+		rows[i] = make([]json.Number, len(row))
+		for j, val := range row {
+			rows[i][j] = json.Number(matrixlib.AppendValue(nil, val, matrix.Scale))
+		}
+	}
+	return rows
+}
+
+// MatrixFromJSON converts rows of JSON numbers, integers or decimals, into a Matrix entity.
+// Values are converted like CSV values, but dimensions are left to be validated with the rest of the matrix.
+func MatrixFromJSON(rows [][]json.Number) (*entity.Matrix[int64], error) {
+	matrix := &entity.Matrix[int64]{}
+	unbounded := matrixlib.Limits{MaxRows: math.MaxInt, MaxCols: math.MaxInt}
+
+	var record []string
+	for _, row := range rows {
+		record = record[:0]
+		for _, val := range row {
+			record = append(record, string(val))
+		}
+		if err := matrixlib.AppendRowWithin(matrix, record, unbounded); err != nil {
+			return nil, err
+		}
+	}
+	return matrix, nil
+}
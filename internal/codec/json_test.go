@@ -0,0 +1,102 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestJSONCodec_EncodeResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		result    *entity.Result
+		want      string
+	}{
+		{
+			name:      "matrix result",
+			operation: "invert",
+			result:    &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}},
+			want:      `{"operation":"invert","matrix":[[1,3],[2,4]]}`,
+		},
+		{
+			name:      "matrix result with column labels",
+			operation: "echo",
+			result: &entity.Result{
+				Matrix:       &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+				ColumnLabels: []string{"home", "away"},
+			},
+			want: `{"operation":"echo","column_labels":["home","away"],"matrix":[[1,2]]}`,
+		},
+		{
+			name:      "matrix result with row labels",
+			operation: "invert",
+			result: &entity.Result{
+				Matrix:    &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+				RowLabels: []string{"home"},
+			},
+			want: `{"operation":"invert","row_labels":["home"],"matrix":[[1,2]]}`,
+		},
+		{
+			name:      "decimal matrix result",
+			operation: "echo",
+			result:    &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{150, -25}, {1, 0}}, Scale: 2}},
+			want:      `{"operation":"echo","matrix":[[1.5,-0.25],[0.01,0]]}`,
+		},
+		{
+			name:      "scalar result is a string",
+			operation: "multiply",
+			result:    &entity.Result{Scalar: "123456789012345678901234567890"},
+			want:      `{"operation":"multiply","scalar":"123456789012345678901234567890"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&jsonCodec{}).EncodeResult(tt.operation, tt.result)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestJSONCodec_DecodeMatrix(t *testing.T) {
+	t.Run("decodes rows object", func(t *testing.T) {
+		got, err := (&jsonCodec{}).DecodeMatrix([]byte(`{"rows": [[1, 2], [-3, 4]]}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {-3, 4}}}, got)
+	})
+
+	t.Run("decodes decimal cells", func(t *testing.T) {
+		got, err := (&jsonCodec{}).DecodeMatrix([]byte(`{"rows": [[1.5, 2], [0.25, 1e1]]}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.Matrix[int64]{Data: [][]int64{{150, 200}, {25, 1000}}, Scale: 2}, got)
+	})
+
+	t.Run("rejects non-numeric cells", func(t *testing.T) {
+		got, err := (&jsonCodec{}).DecodeMatrix([]byte(`{"rows": [["a", 2]]}`))
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+
+	t.Run("rejects cells out of range", func(t *testing.T) {
+		got, err := (&jsonCodec{}).DecodeMatrix([]byte(`{"rows": [[9223372036854775808]]}`))
+
+		assert.ErrorIs(t, err, apperrors.ErrOverflow)
+		assert.Nil(t, got)
+	})
+
+	t.Run("rejects malformed payload", func(t *testing.T) {
+		got, err := (&jsonCodec{}).DecodeMatrix([]byte(`{"rows": `))
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+}
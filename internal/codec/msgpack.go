@@ -0,0 +1,39 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const msgpackContentType = "application/msgpack"
+
+// msgpackCodec encodes results and decodes request matrices as MessagePack maps.
+type msgpackCodec struct{}
+
+func (c *msgpackCodec) ContentType() string {
+	return msgpackContentType
+}
+
+func (c *msgpackCodec) FileExtension() string {
+	return "msgpack"
+}
+
+func (c *msgpackCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	data, err := msgpack.Marshal(newResultPayload(operation, result))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal msgpack result: %w", err)
+	}
+	return data, nil
+}
+
+func (c *msgpackCodec) DecodeMatrix(data []byte) (*entity.Matrix[int64], error) {
+	payload := &matrixPayload{}
+	if err := msgpack.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode msgpack matrix: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+	return &entity.Matrix[int64]{Data: payload.Rows, Scale: payload.Scale}, nil
+}
@@ -0,0 +1,89 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestMsgpackCodec_EncodeResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		result    *entity.Result
+		want      resultPayload
+	}{
+		{
+			name:      "matrix result",
+			operation: "invert",
+			result:    &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}},
+			want:      resultPayload{Operation: "invert", Matrix: [][]int64{{1, 3}, {2, 4}}},
+		},
+		{
+			name:      "decimal matrix result",
+			operation: "echo",
+			result:    &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{15, -2}}, Scale: 1}},
+			want:      resultPayload{Operation: "echo", Matrix: [][]int64{{15, -2}}, Scale: 1},
+		},
+		{
+			name:      "scalar result",
+			operation: "sum",
+			result:    &entity.Result{Scalar: "10"},
+			want:      resultPayload{Operation: "sum", Scalar: "10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := (&msgpackCodec{}).EncodeResult(tt.operation, tt.result)
+			assert.NoError(t, err)
+
+			var got resultPayload
+			assert.NoError(t, msgpack.Unmarshal(data, &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMsgpackCodec_DecodeMatrix(t *testing.T) {
+	t.Run("decodes rows map", func(t *testing.T) {
+		data, err := msgpack.Marshal(map[string]any{"rows": [][]int64{{1, 2}, {-3, 4}}})
+		assert.NoError(t, err)
+
+		got, err := (&msgpackCodec{}).DecodeMatrix(data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {-3, 4}}}, got)
+	})
+
+	t.Run("decodes scaled decimal rows", func(t *testing.T) {
+		data, err := msgpack.Marshal(map[string]any{"rows": [][]int64{{15, 2}}, "scale": 1})
+		assert.NoError(t, err)
+
+		got, err := (&msgpackCodec{}).DecodeMatrix(data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.5,0.2", got.String())
+	})
+
+	t.Run("rejects non-integer cells", func(t *testing.T) {
+		data, err := msgpack.Marshal(map[string]any{"rows": [][]string{{"a", "b"}}})
+		assert.NoError(t, err)
+
+		got, err := (&msgpackCodec{}).DecodeMatrix(data)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+
+	t.Run("rejects malformed payload", func(t *testing.T) {
+		got, err := (&msgpackCodec{}).DecodeMatrix([]byte{0xc1})
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,64 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// ndjsonCodec renders results as newline-delimited JSON so they can be processed line by line.
+// Matrix results produce one JSON array per row; scalar results produce a single JSON string.
+type ndjsonCodec struct{}
+
+func (c *ndjsonCodec) ContentType() string {
+	return ndjsonContentType
+}
+
+func (c *ndjsonCodec) FileExtension() string {
+	return "ndjson"
+}
+
+func (c *ndjsonCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.WriteResult(&buf, operation, result); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *ndjsonCodec) WriteResult(w io.Writer, _ string, result *entity.Result) error {
+	writer := newRowWriter(w)
+	defer writer.release()
+	// Encoder terminates every value with a newline
+	encoder := json.NewEncoder(writer)
+
+	if result == nil || result.Matrix == nil {
+		if err := encoder.Encode(result.String()); err != nil {
+			return fmt.Errorf("failed to marshal ndjson result: %w", err)
+		}
+	} else {
+		// Rows are rendered directly as JSON arrays, so decimals keep their decimal point in place
+		for _, row := range result.Matrix.Data {
+			writer.line = append(writer.line[:0], '[')
+			writer.line = append(appendRow(writer.line, row, result.Matrix.Scale), ']', '\n')
+			if _, err := writer.Write(writer.line); err != nil {
+				return fmt.Errorf("failed to write ndjson result: %w", err)
+			}
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write ndjson result: %w", err)
+	}
+	return nil
+}
+
+func (c *ndjsonCodec) DecodeMatrix(_ []byte) (*entity.Matrix[int64], error) {
+	return nil, fmt.Errorf("%w: %s request bodies are not supported", apperrors.ErrUnsupportedMediaType, ndjsonContentType)
+}
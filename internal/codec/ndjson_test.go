@@ -0,0 +1,50 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestNDJSONCodec_EncodeResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *entity.Result
+		want   string
+	}{
+		{
+			name:   "one line per matrix row",
+			result: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}},
+			want:   "[1,2,3]\n[4,5,6]\n",
+		},
+		{
+			name:   "decimal rows keep their decimal point",
+			result: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{15, -2}, {5, 30}}, Scale: 1}},
+			want:   "[1.5,-0.2]\n[0.5,3]\n",
+		},
+		{
+			name:   "scalar result is a single string line",
+			result: &entity.Result{Scalar: "45"},
+			want:   "\"45\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&ndjsonCodec{}).EncodeResult("echo", tt.result)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestNDJSONCodec_DecodeMatrix(t *testing.T) {
+	got, err := (&ndjsonCodec{}).DecodeMatrix([]byte("[1,2]\n"))
+
+	assert.ErrorIs(t, err, apperrors.ErrUnsupportedMediaType)
+	assert.Nil(t, got)
+}
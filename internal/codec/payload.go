@@ -0,0 +1,38 @@
+package codec
+
+import "github.com/matsuboshi/league-matrix-app/internal/entity"
+
+// matrixPayload is the schemaless representation of a request matrix shared by
+// the binary self-describing codecs (msgpack, cbor). It mirrors the protobuf Matrix message:
+// decimal values are integers scaled by 10^Scale.
+type matrixPayload struct {
+	Rows  [][]int64 `msgpack:"rows" cbor:"rows"`
+	Scale int       `msgpack:"scale,omitempty" cbor:"scale,omitempty"`
+}
+
+// resultPayload is the schemaless representation of an operation result shared by
+// the self-describing codecs. It mirrors the protobuf OperationResult message:
+// exactly one of Matrix or Scalar is set, and decimal values are integers scaled by 10^Scale.
+// Column and row labels, which the protobuf message lacks, accompany a matrix read with labels.
+// The json codec writes decimal values as they are instead, so Scale is left out of JSON.
+type resultPayload struct {
+	Operation    string    `json:"operation" msgpack:"operation" cbor:"operation"`
+	ColumnLabels []string  `json:"column_labels,omitempty" msgpack:"column_labels,omitempty" cbor:"column_labels,omitempty"`
+	RowLabels    []string  `json:"row_labels,omitempty" msgpack:"row_labels,omitempty" cbor:"row_labels,omitempty"`
+	Matrix       [][]int64 `json:"matrix,omitempty" msgpack:"matrix,omitempty" cbor:"matrix,omitempty"`
+	Scale        int       `json:"-" msgpack:"scale,omitempty" cbor:"scale,omitempty"`
+	Scalar       string    `json:"scalar,omitempty" msgpack:"scalar,omitempty" cbor:"scalar,omitempty"`
+}
+
+func newResultPayload(operation string, result *entity.Result) *resultPayload {
+	payload := &resultPayload{Operation: operation}
+	if result != nil && result.Matrix != nil {
+		payload.Matrix = result.Matrix.Data
+		payload.Scale = result.Matrix.Scale
+		payload.ColumnLabels = result.ColumnLabels
+		payload.RowLabels = result.RowLabels
+	} else {
+		payload.Scalar = result.String()
+	}
+	return payload
+}
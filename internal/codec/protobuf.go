@@ -0,0 +1,65 @@
+package codec
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/pb"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const protobufContentType = "application/x-protobuf"
+
+// protobufCodec encodes results as pb.OperationResult and decodes request matrices from pb.Matrix messages.
+type protobufCodec struct{}
+
+func (c *protobufCodec) ContentType() string {
+	return protobufContentType
+}
+
+func (c *protobufCodec) FileExtension() string {
+	return "binpb"
+}
+
+func (c *protobufCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	message := &pb.OperationResult{Operation: operation}
+	if result != nil && result.Matrix != nil {
+		message.Value = &pb.OperationResult_Matrix{Matrix: MatrixToProto(result.Matrix)}
+	} else {
+		message.Value = &pb.OperationResult_Scalar{Scalar: result.String()}
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal protobuf result: %w", err)
+	}
+	return data, nil
+}
+
+func (c *protobufCodec) DecodeMatrix(data []byte) (*entity.Matrix[int64], error) {
+	message := &pb.Matrix{}
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode protobuf matrix: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+	return MatrixFromProto(message), nil
+}
+
+// MatrixToProto converts a Matrix entity into its protobuf representation.
+func MatrixToProto(matrix *entity.Matrix[int64]) *pb.Matrix {
+	message := &pb.Matrix{Rows: make([]*pb.Row, matrix.Rows()), Scale: int32(matrix.Scale)}
+	for i, row := range matrix.Data {
+		message.Rows[i] = &pb.Row{Values: row}
+	}
+	return message
+}
+
+// MatrixFromProto converts a protobuf matrix into a Matrix entity.
+func MatrixFromProto(message *pb.Matrix) *entity.Matrix[int64] {
+	matrix := &entity.Matrix[int64]{Data: make([][]int64, len(message.GetRows())), Scale: int(message.GetScale())}
+	for i, row := range message.GetRows() {
+		matrix.Data[i] = row.GetValues()
+	}
+	return matrix
+}
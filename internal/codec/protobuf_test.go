@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/pb"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestProtobufCodec_EncodeResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		operation  string
+		result     *entity.Result
+		wantMatrix [][]int64
+		wantScalar string
+	}{
+		{
+			name:       "matrix result",
+			operation:  "echo",
+			result:     &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}},
+			wantMatrix: [][]int64{{1, 2}, {3, 4}},
+		},
+		{
+			name:       "scalar result beyond int64",
+			operation:  "multiply",
+			result:     &entity.Result{Scalar: "1000000000000000000000000000000"},
+			wantScalar: "1000000000000000000000000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &protobufCodec{}
+
+			data, err := c.EncodeResult(tt.operation, tt.result)
+			assert.NoError(t, err)
+
+			got := &pb.OperationResult{}
+			assert.NoError(t, proto.Unmarshal(data, got))
+			assert.Equal(t, tt.operation, got.GetOperation())
+			assert.Equal(t, tt.wantScalar, got.GetScalar())
+			if tt.wantMatrix != nil {
+				assert.Equal(t, tt.wantMatrix, MatrixFromProto(got.GetMatrix()).Data)
+			} else {
+				assert.Nil(t, got.GetMatrix())
+			}
+		})
+	}
+}
+
+func TestProtobufCodec_DecodeMatrix(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		matrix := &entity.Matrix[int64]{Data: [][]int64{{1, -2, 3}, {4, 5, 1000000}}}
+		data, err := proto.Marshal(MatrixToProto(matrix))
+		assert.NoError(t, err)
+
+		got, err := (&protobufCodec{}).DecodeMatrix(data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, matrix, got)
+	})
+
+	t.Run("decimal round trip", func(t *testing.T) {
+		matrix := &entity.Matrix[int64]{Data: [][]int64{{15, -2}}, Scale: 1}
+		data, err := proto.Marshal(MatrixToProto(matrix))
+		assert.NoError(t, err)
+
+		got, err := (&protobufCodec{}).DecodeMatrix(data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, matrix, got)
+		assert.Equal(t, "1.5,-0.2", got.String())
+	})
+
+	t.Run("malformed payload", func(t *testing.T) {
+		got, err := (&protobufCodec{}).DecodeMatrix([]byte{0xff, 0xff, 0xff})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+}
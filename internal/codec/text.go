@@ -0,0 +1,62 @@
+package codec
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const textContentType = "text/plain"
+
+// textCodec renders results in the plain text format historically returned by the API.
+type textCodec struct{}
+
+func (c *textCodec) ContentType() string {
+	return textContentType
+}
+
+// Matrix results in the text format are valid CSV, so downloads can be opened directly in spreadsheets.
+func (c *textCodec) FileExtension() string {
+	return "csv"
+}
+
+func (c *textCodec) EncodeResult(_ string, result *entity.Result) ([]byte, error) {
+	if result == nil || result.Matrix == nil {
+		return []byte(result.String()), nil
+	}
+
+	// Render straight into the returned slice, avoiding the copies of an intermediate buffer
+	return result.Matrix.AppendText(nil)
+}
+
+func (c *textCodec) WriteResult(w io.Writer, _ string, result *entity.Result) error {
+	if result == nil || result.Matrix == nil {
+		_, err := io.WriteString(w, result.String())
+		return err
+	}
+
+	writer := newRowWriter(w)
+	defer writer.release()
+	for i, row := range result.Matrix.Data {
+		writer.line = writer.line[:0]
+		// Rows are separated, not terminated, by newlines
+		if i > 0 {
+			writer.line = append(writer.line, '\n')
+		}
+		writer.line = appendRow(writer.line, row, result.Matrix.Scale)
+		if _, err := writer.Write(writer.line); err != nil {
+			return fmt.Errorf("failed to write text result: %w", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write text result: %w", err)
+	}
+	return nil
+}
+
+func (c *textCodec) DecodeMatrix(_ []byte) (*entity.Matrix[int64], error) {
+	return nil, fmt.Errorf("%w: %s request bodies are not supported", apperrors.ErrUnsupportedMediaType, textContentType)
+}
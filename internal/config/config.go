@@ -0,0 +1,460 @@
+// Package config holds the settings of the HTTP server: where it and the gRPC server listen, how long it waits
+// on connections and requests, the limits of the matrix files it reads and streams, the results it remembers,
+// the hosts it downloads from, and the files and stores its subsystems keep their state in. Every setting is read from an environment variable and can be overridden with a command
+// line flag. Secrets are not settings: they are read from the secret store.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// Config holds the settings of the HTTP server.
+type Config struct {
+	// Port is the port the server listens on.
+	Port string
+
+	// GRPCPort is the port the gRPC server listens on, next to the HTTP server.
+	GRPCPort string
+
+	// ReadTimeout bounds reading a whole request, body included.
+	ReadTimeout time.Duration
+
+	// WriteTimeout bounds writing a response, after which the server cuts the connection.
+	WriteTimeout time.Duration
+
+	// ShutdownTimeout bounds how long in-flight requests may take to complete once shutdown starts,
+	// together with the shutdown hooks that run afterwards.
+	ShutdownTimeout time.Duration
+
+	// Limits bound the matrix files the server reads and the matrices it processes.
+	Limits Limits
+
+	// RequestTimeout bounds the requests looking up state, and OperationTimeout the requests running matrix
+	// operations. Both must be shorter than WriteTimeout, so a response reports the timeout.
+	RequestTimeout   time.Duration
+	OperationTimeout time.Duration
+
+	// MemoryLimit is the memory use, in bytes, above which requests running operations are shed.
+	// 0 falls back to the soft memory limit of the runtime set with GOMEMLIMIT.
+	MemoryLimit int64
+
+	// LenientParsing makes matrix files be parsed leniently unless a request asks otherwise.
+	LenientParsing bool
+
+	// DebugProfile lets clients ask for the timing breakdown of their requests.
+	DebugProfile bool
+
+	// TenantsFile is the JSON file listing the tenants and their API keys; empty serves without API keys.
+	TenantsFile string
+
+	// SchedulesFile is the JSON file of the schedules loaded on start; empty starts without schedules.
+	SchedulesFile string
+
+	// AuditLogFile is the file the audit log is appended to; empty keeps it in memory.
+	AuditLogFile string
+
+	// ExportDir is the directory results are exported to with a relative export target.
+	ExportDir string
+
+	// S3Endpoint and S3Region locate the object storage of s3:// export targets and matrix files.
+	S3Endpoint string
+	S3Region   string
+
+	// RetentionDefaultTTL is how long stored matrix files are kept unless given a time to live; 0 keeps them.
+	RetentionDefaultTTL time.Duration
+
+	// RetentionRestoreWindow is how long deleted matrix files can be restored.
+	RetentionRestoreWindow time.Duration
+
+	// RetentionSweepInterval is how often expired and deleted matrix files are cleaned up.
+	RetentionSweepInterval time.Duration
+
+	// StreamMaxRows, StreamMaxCols and StreamMaxFileSize bound the matrices aggregate operations read as a stream
+	// of rows, far beyond Limits.
+	StreamMaxRows     int
+	StreamMaxCols     int
+	StreamMaxFileSize int64
+
+	// MemoMaxEntries is the number of results remembered, 0 disabling the memo, and MemoTTL how long each is kept.
+	MemoMaxEntries int
+	MemoTTL        time.Duration
+
+	// RemoteFileHosts lists the hosts matrix files given as URLs may be downloaded from; empty allows none.
+	RemoteFileHosts []string
+
+	// DrainDelay is how long the server keeps serving once shutdown starts, reporting itself unhealthy so load
+	// balancers stop routing new traffic before the listener is closed.
+	DrainDelay time.Duration
+}
+
+// Default is the configuration used unless configured otherwise.
+var Default = Config{
+	Port:            "8080",
+	GRPCPort:        "9090",
+	ReadTimeout:     7 * time.Second,
+	WriteTimeout:    30 * time.Second,
+	ShutdownTimeout: 30 * time.Second,
+	Limits:          DefaultLimits,
+
+	RequestTimeout:         5 * time.Second,
+	OperationTimeout:       25 * time.Second,
+	RetentionRestoreWindow: 7 * 24 * time.Hour,
+	RetentionSweepInterval: 10 * time.Minute,
+
+	StreamMaxRows:     1_000_000,
+	StreamMaxCols:     10_000,
+	StreamMaxFileSize: 64 << 20, // 64MiB
+	MemoMaxEntries:    1024,
+	MemoTTL:           10 * time.Minute,
+	DrainDelay:        5 * time.Second,
+}
+
+// setting is a setting of Config, named by a command line flag, with an optional shorthand,
+// and an environment variable.
+type setting struct {
+	flag      string
+	shorthand string
+	env       string
+	usage     string
+	value     pflag.Value
+}
+
+// settings returns the settings of c, each updating c when set.
+func (c *Config) settings() []setting {
+	return []setting{
+		{flag: "port", shorthand: "p", env: "PORT", usage: "port to listen on", value: (*portValue)(&c.Port)},
+		{flag: "grpc-port", env: "GRPC_PORT", usage: "port the gRPC server listens on", value: (*portValue)(&c.GRPCPort)},
+		{flag: "read-timeout", env: "READ_TIMEOUT", usage: "how long to wait for a whole request",
+			value: (*durationValue)(&c.ReadTimeout)},
+		{flag: "write-timeout", env: "WRITE_TIMEOUT", usage: "how long to wait for a response to be written",
+			value: (*durationValue)(&c.WriteTimeout)},
+		{flag: "shutdown-timeout", env: "SHUTDOWN_TIMEOUT", usage: "how long to wait for in-flight requests on shutdown",
+			value: (*durationValue)(&c.ShutdownTimeout)},
+		{flag: "max-file-size", env: "MAX_FILE_SIZE", usage: "largest matrix file read, with an optional KiB or MiB suffix",
+			value: (*byteSizeValue)(&c.Limits.MaxFileSize)},
+		{flag: "max-matrix-dims", env: "MAX_MATRIX_DIMS", usage: "largest matrix processed, as rows x columns",
+			value: &dimsValue{rows: &c.Limits.MaxRows, cols: &c.Limits.MaxCols}},
+		{flag: "allowed-data-dir", env: "ALLOWED_DATA_DIR", usage: "directory matrix files are read from",
+			value: (*dirValue)(&c.Limits.DataDir)},
+		{flag: "request-timeout", env: "REQUEST_TIMEOUT", usage: "how long a request looking up state may take",
+			value: (*durationValue)(&c.RequestTimeout)},
+		{flag: "operation-timeout", env: "OPERATION_TIMEOUT", usage: "how long a request running an operation may take",
+			value: (*durationValue)(&c.OperationTimeout)},
+		{flag: "memory-limit", env: "MEMORY_LIMIT", usage: "memory use above which operations are shed, with an optional MiB or GiB suffix",
+			value: (*byteSizeValue)(&c.MemoryLimit)},
+		{flag: "lenient-parsing", env: "LENIENT_PARSING", usage: "parse matrix files leniently by default",
+			value: (*boolValue)(&c.LenientParsing)},
+		{flag: "debug-profile", env: "DEBUG_PROFILE", usage: "let clients ask for the timing breakdown of their requests",
+			value: (*boolValue)(&c.DebugProfile)},
+		{flag: "tenants-file", env: "TENANTS_FILE", usage: "JSON file listing the tenants and their API keys",
+			value: (*stringValue)(&c.TenantsFile)},
+		{flag: "schedules-file", env: "SCHEDULES_FILE", usage: "JSON file of the schedules loaded on start",
+			value: (*stringValue)(&c.SchedulesFile)},
+		{flag: "audit-log-file", env: "AUDIT_LOG_FILE", usage: "file the audit log is appended to",
+			value: (*stringValue)(&c.AuditLogFile)},
+		{flag: "export-dir", env: "EXPORT_DIR", usage: "directory results are exported to",
+			value: (*stringValue)(&c.ExportDir)},
+		{flag: "s3-endpoint", env: "S3_ENDPOINT", usage: "endpoint of the object storage of s3:// paths",
+			value: (*stringValue)(&c.S3Endpoint)},
+		{flag: "s3-region", env: "S3_REGION", usage: "region of the object storage of s3:// paths",
+			value: (*stringValue)(&c.S3Region)},
+		{flag: "retention-default-ttl", env: "RETENTION_DEFAULT_TTL",
+			usage: "how long stored matrix files are kept without a ttl, or 0 to keep them",
+			value: (*ageValue)(&c.RetentionDefaultTTL)},
+		{flag: "retention-restore-window", env: "RETENTION_RESTORE_WINDOW", usage: "how long deleted matrix files can be restored",
+			value: (*ageValue)(&c.RetentionRestoreWindow)},
+		{flag: "retention-sweep-interval", env: "RETENTION_SWEEP_INTERVAL",
+			usage: "how often expired and deleted matrix files are cleaned up", value: (*durationValue)(&c.RetentionSweepInterval)},
+		{flag: "stream-max-rows", env: "STREAM_MAX_ROWS", usage: "rows of a streamed matrix",
+			value: (*countValue)(&c.StreamMaxRows)},
+		{flag: "stream-max-cols", env: "STREAM_MAX_COLS", usage: "columns of a streamed matrix",
+			value: (*countValue)(&c.StreamMaxCols)},
+		{flag: "stream-max-file-size", env: "STREAM_MAX_FILE_SIZE",
+			usage: "largest streamed matrix file, with an optional MiB or GiB suffix", value: (*byteSizeValue)(&c.StreamMaxFileSize)},
+		{flag: "memo-max-entries", env: "MEMO_MAX_ENTRIES", usage: "results remembered, or 0 to disable the memo",
+			value: (*capacityValue)(&c.MemoMaxEntries)},
+		{flag: "memo-ttl", env: "MEMO_TTL", usage: "how long a result is remembered",
+			value: (*durationValue)(&c.MemoTTL)},
+		{flag: "remote-file-hosts", env: "REMOTE_FILE_HOSTS", usage: "comma-separated hosts matrix files may be downloaded from",
+			value: (*hostsValue)(&c.RemoteFileHosts)},
+		{flag: "drain-delay", env: "DRAIN_DELAY", usage: "how long to keep serving with /health failing before shutting down",
+			value: (*ageValue)(&c.DrainDelay)},
+	}
+}
+
+// Bind registers a flag on fs for every setting of c, defaulting to its current value.
+func (c *Config) Bind(fs *pflag.FlagSet) {
+	for _, s := range c.settings() {
+		flag := fs.VarPF(s.value, s.flag, s.shorthand, fmt.Sprintf("%s (env %s)", s.usage, s.env))
+		if _, ok := s.value.(*boolValue); ok {
+			// --lenient-parsing alone turns the setting on
+			flag.NoOptDefVal = "true"
+		}
+	}
+}
+
+// LoadEnv sets the settings of c from the environment variables read with getenv, skipping the settings
+// whose flags were given on the command line of fs, so flags take precedence. fs may be nil.
+// Empty variables keep the current value.
+func (c *Config) LoadEnv(fs *pflag.FlagSet, getenv func(string) string) error {
+	for _, s := range c.settings() {
+		if fs != nil && fs.Changed(s.flag) {
+			continue
+		}
+		value := getenv(s.env)
+		if value == "" {
+			continue
+		}
+		if err := s.value.Set(value); err != nil {
+			return fmt.Errorf("invalid %s: %w", s.env, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the settings of c against each other, once they are all set, along with the bounds of the
+// settings a Config built in code could get wrong.
+func (c *Config) Validate() error {
+	if max(c.RequestTimeout, c.OperationTimeout) >= c.WriteTimeout {
+		return fmt.Errorf("%w: request and operation timeouts must be shorter than the %s write timeout",
+			apperrors.ErrInvalidInput, c.WriteTimeout)
+	}
+	if c.StreamMaxRows <= 0 || c.StreamMaxCols <= 0 || c.StreamMaxFileSize <= 0 {
+		return fmt.Errorf("%w: stream limits must be positive", apperrors.ErrInvalidInput)
+	}
+	if c.MemoMaxEntries < 0 || c.MemoTTL <= 0 {
+		return fmt.Errorf("%w: the memo size must not be negative and its TTL must be positive", apperrors.ErrInvalidInput)
+	}
+	if c.DrainDelay < 0 {
+		return fmt.Errorf("%w: the drain delay must not be negative", apperrors.ErrInvalidInput)
+	}
+	for _, host := range c.RemoteFileHosts {
+		if _, err := ParseHosts(host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// portValue is a port number, kept as a string as net/http expects.
+type portValue string
+
+func (v *portValue) Set(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > 65535 {
+		return fmt.Errorf("%w: invalid port %q: expected a number between 0 and 65535", apperrors.ErrInvalidInput, value)
+	}
+	*v = portValue(value)
+	return nil
+}
+
+func (v *portValue) String() string { return string(*v) }
+
+func (v *portValue) Type() string { return "port" }
+
+// durationValue is a positive duration.
+type durationValue time.Duration
+
+func (v *durationValue) Set(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("%w: invalid duration %q: expected a positive duration such as 30s",
+			apperrors.ErrInvalidInput, value)
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+func (v *durationValue) Type() string { return "duration" }
+
+// ageValue is a duration that may be 0, such as a time to live where 0 keeps things forever.
+type ageValue time.Duration
+
+func (v *ageValue) Set(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fmt.Errorf("%w: invalid duration %q: expected a duration such as 720h, or 0",
+			apperrors.ErrInvalidInput, value)
+	}
+	*v = ageValue(d)
+	return nil
+}
+
+func (v *ageValue) String() string { return time.Duration(*v).String() }
+
+func (v *ageValue) Type() string { return "duration" }
+
+// byteSizeValue is a positive size in bytes, parsed with ParseByteSize.
+type byteSizeValue int64
+
+func (v *byteSizeValue) Set(value string) error {
+	size, err := ParseByteSize(value)
+	if err != nil {
+		return err
+	}
+	*v = byteSizeValue(size)
+	return nil
+}
+
+func (v *byteSizeValue) String() string { return strconv.FormatInt(int64(*v), 10) }
+
+func (v *byteSizeValue) Type() string { return "size" }
+
+// countValue is a positive number of things, such as rows.
+type countValue int
+
+func (v *countValue) Set(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("%w: invalid number %q: expected a positive number", apperrors.ErrInvalidInput, value)
+	}
+	*v = countValue(n)
+	return nil
+}
+
+func (v *countValue) String() string { return strconv.Itoa(int(*v)) }
+
+func (v *countValue) Type() string { return "int" }
+
+// capacityValue is a number of things that may be 0, such as the size of a cache where 0 disables it.
+type capacityValue int
+
+func (v *capacityValue) Set(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return fmt.Errorf("%w: invalid number %q: expected a number, or 0 to disable", apperrors.ErrInvalidInput, value)
+	}
+	*v = capacityValue(n)
+	return nil
+}
+
+func (v *capacityValue) String() string { return strconv.Itoa(int(*v)) }
+
+func (v *capacityValue) Type() string { return "int" }
+
+// dimsValue is a number of rows by a number of columns, written as 10x10.
+type dimsValue struct {
+	rows *int
+	cols *int
+}
+
+func (v *dimsValue) Set(value string) error {
+	limits, err := ParseDims(value)
+	if err != nil {
+		return err
+	}
+	*v.rows, *v.cols = limits.MaxRows, limits.MaxCols
+	return nil
+}
+
+func (v *dimsValue) String() string {
+	if v.rows == nil || v.cols == nil {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", *v.rows, *v.cols)
+}
+
+func (v *dimsValue) Type() string { return "dims" }
+
+// ParseDims parses matrix dimensions written as rows by columns, such as 10x10, into dimension limits.
+func ParseDims(value string) (matrixlib.Limits, error) {
+	rows, cols, found := strings.Cut(value, "x")
+	r, rowsErr := strconv.Atoi(rows)
+	c, colsErr := strconv.Atoi(cols)
+	if !found || rowsErr != nil || colsErr != nil || r <= 0 || c <= 0 {
+		return matrixlib.Limits{}, fmt.Errorf("%w: invalid dimensions %q: expected rows x columns such as 10x10",
+			apperrors.ErrInvalidInput, value)
+	}
+	return matrixlib.Limits{MaxRows: r, MaxCols: c}, nil
+}
+
+// dirValue is a directory, cleaned of trailing slashes.
+type dirValue string
+
+func (v *dirValue) Set(value string) error {
+	dir := strings.TrimRight(value, "/")
+	if dir == "" || strings.Contains(dir, "..") {
+		return fmt.Errorf("%w: invalid directory %q: expected a directory without ..", apperrors.ErrInvalidInput, value)
+	}
+	*v = dirValue(dir)
+	return nil
+}
+
+func (v *dirValue) String() string { return string(*v) }
+
+func (v *dirValue) Type() string { return "dir" }
+
+// hostsValue is a list of hosts, parsed with ParseHosts.
+type hostsValue []string
+
+func (v *hostsValue) Set(value string) error {
+	hosts, err := ParseHosts(value)
+	if err != nil {
+		return err
+	}
+	*v = hosts
+	return nil
+}
+
+func (v *hostsValue) String() string { return strings.Join(*v, ",") }
+
+func (v *hostsValue) Type() string { return "hosts" }
+
+// ParseHosts parses a comma-separated list of hosts, lowercased: example.com stands for the host on any port,
+// and example.com:8443 for that port alone. An empty value lists no host.
+func ParseHosts(value string) ([]string, error) {
+	var hosts []string
+	for _, host := range strings.Split(value, ",") {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" {
+			continue
+		}
+		// A host must parse back to itself as the authority of a URL, without a scheme, path or credentials
+		u, err := url.Parse("https://" + host)
+		if err != nil || u.Host != host || u.Hostname() == "" || u.User != nil {
+			return nil, fmt.Errorf("%w: invalid host %q: expected a host such as example.com or example.com:8443",
+				apperrors.ErrInvalidInput, host)
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// boolValue is a setting turned on with true and off with false.
+type boolValue bool
+
+func (v *boolValue) Set(value string) error {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid setting %q: expected true or false", apperrors.ErrInvalidInput, value)
+	}
+	*v = boolValue(enabled)
+	return nil
+}
+
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+func (v *boolValue) Type() string { return "bool" }
+
+// stringValue is a setting taken as given, such as a file path or an address.
+type stringValue string
+
+func (v *stringValue) Set(value string) error {
+	*v = stringValue(value)
+	return nil
+}
+
+func (v *stringValue) String() string { return string(*v) }
+
+func (v *stringValue) Type() string { return "string" }
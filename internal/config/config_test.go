@@ -0,0 +1,258 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+func TestConfig_LoadEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		args    []string
+		want    func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: func(*Config) {},
+		},
+		{
+			name: "environment variables",
+			env: map[string]string{
+				"PORT":             "9090",
+				"GRPC_PORT":        "9091",
+				"READ_TIMEOUT":     "10s",
+				"WRITE_TIMEOUT":    "1m",
+				"SHUTDOWN_TIMEOUT": "45s",
+				"MAX_FILE_SIZE":    "64KiB",
+				"MAX_MATRIX_DIMS":  "100x20",
+				"ALLOWED_DATA_DIR": "data/",
+
+				"REQUEST_TIMEOUT":          "2s",
+				"OPERATION_TIMEOUT":        "20s",
+				"MEMORY_LIMIT":             "512MiB",
+				"LENIENT_PARSING":          "true",
+				"DEBUG_PROFILE":            "1",
+				"TENANTS_FILE":             "tenants.json",
+				"SCHEDULES_FILE":           "schedules.json",
+				"AUDIT_LOG_FILE":           "audit.log",
+				"EXPORT_DIR":               "exports",
+				"S3_ENDPOINT":              "http://localhost:9000",
+				"S3_REGION":                "eu-west-1",
+				"RETENTION_DEFAULT_TTL":    "720h",
+				"RETENTION_RESTORE_WINDOW": "0",
+				"RETENTION_SWEEP_INTERVAL": "1m",
+				"STREAM_MAX_ROWS":          "5000",
+				"STREAM_MAX_COLS":          "20",
+				"STREAM_MAX_FILE_SIZE":     "2MiB",
+				"MEMO_MAX_ENTRIES":         "0",
+				"MEMO_TTL":                 "30s",
+				"REMOTE_FILE_HOSTS":        " Example.com, data.example.org:8443",
+				"DRAIN_DELAY":              "0",
+			},
+			want: func(cfg *Config) {
+				cfg.Port = "9090"
+				cfg.GRPCPort = "9091"
+				cfg.ReadTimeout = 10 * time.Second
+				cfg.WriteTimeout = time.Minute
+				cfg.ShutdownTimeout = 45 * time.Second
+				cfg.Limits = Limits{DataDir: "data", MaxFileSize: 64 << 10, MaxRows: 100, MaxCols: 20}
+				cfg.RequestTimeout = 2 * time.Second
+				cfg.OperationTimeout = 20 * time.Second
+				cfg.MemoryLimit = 512 << 20
+				cfg.LenientParsing = true
+				cfg.DebugProfile = true
+				cfg.TenantsFile = "tenants.json"
+				cfg.SchedulesFile = "schedules.json"
+				cfg.AuditLogFile = "audit.log"
+				cfg.ExportDir = "exports"
+				cfg.S3Endpoint = "http://localhost:9000"
+				cfg.S3Region = "eu-west-1"
+				cfg.RetentionDefaultTTL = 720 * time.Hour
+				cfg.RetentionRestoreWindow = 0
+				cfg.RetentionSweepInterval = time.Minute
+				cfg.StreamMaxRows, cfg.StreamMaxCols, cfg.StreamMaxFileSize = 5000, 20, 2<<20
+				cfg.MemoMaxEntries = 0
+				cfg.MemoTTL = 30 * time.Second
+				cfg.RemoteFileHosts = []string{"example.com", "data.example.org:8443"}
+				cfg.DrainDelay = 0
+			},
+		},
+		{
+			name: "flags take precedence over environment variables",
+			env:  map[string]string{"PORT": "9090", "MAX_MATRIX_DIMS": "100x20"},
+			args: []string{"-p", "9191", "--allowed-data-dir", "data"},
+			want: func(cfg *Config) {
+				cfg.Port = "9191"
+				cfg.Limits.MaxRows, cfg.Limits.MaxCols = 100, 20
+				cfg.Limits.DataDir = "data"
+			},
+		},
+		{
+			name: "boolean flags without a value",
+			env:  map[string]string{"LENIENT_PARSING": "false"},
+			args: []string{"--lenient-parsing"},
+			want: func(cfg *Config) { cfg.LenientParsing = true },
+		},
+		{
+			name: "empty environment variables keep the defaults",
+			env:  map[string]string{"PORT": "", "MAX_FILE_SIZE": ""},
+			want: func(*Config) {},
+		},
+		{name: "invalid port", env: map[string]string{"PORT": "http"}, wantErr: "invalid PORT"},
+		{name: "port out of range", env: map[string]string{"PORT": "65536"}, wantErr: "invalid PORT"},
+		{name: "invalid gRPC port", env: map[string]string{"GRPC_PORT": "grpc"}, wantErr: "invalid GRPC_PORT"},
+		{name: "invalid timeout", env: map[string]string{"READ_TIMEOUT": "7"}, wantErr: "invalid READ_TIMEOUT"},
+		{name: "negative timeout", env: map[string]string{"WRITE_TIMEOUT": "-1s"}, wantErr: "invalid WRITE_TIMEOUT"},
+		{name: "invalid file size", env: map[string]string{"MAX_FILE_SIZE": "1GB"}, wantErr: "invalid MAX_FILE_SIZE"},
+		{name: "invalid dimensions", env: map[string]string{"MAX_MATRIX_DIMS": "10"}, wantErr: "invalid MAX_MATRIX_DIMS"},
+		{name: "data directory outside", env: map[string]string{"ALLOWED_DATA_DIR": "../data"},
+			wantErr: "invalid ALLOWED_DATA_DIR"},
+		{name: "invalid memory limit", env: map[string]string{"MEMORY_LIMIT": "512MB"}, wantErr: "invalid MEMORY_LIMIT"},
+		{name: "invalid boolean", env: map[string]string{"LENIENT_PARSING": "sometimes"}, wantErr: "invalid LENIENT_PARSING"},
+		{name: "zero operation timeout", env: map[string]string{"OPERATION_TIMEOUT": "0"}, wantErr: "invalid OPERATION_TIMEOUT"},
+		{name: "negative time to live", env: map[string]string{"RETENTION_DEFAULT_TTL": "-1h"},
+			wantErr: "invalid RETENTION_DEFAULT_TTL"},
+		{name: "zero sweep interval", env: map[string]string{"RETENTION_SWEEP_INTERVAL": "0"},
+			wantErr: "invalid RETENTION_SWEEP_INTERVAL"},
+		{name: "zero stream limit", env: map[string]string{"STREAM_MAX_ROWS": "0"}, wantErr: "invalid STREAM_MAX_ROWS"},
+		{name: "invalid stream limit", env: map[string]string{"STREAM_MAX_COLS": "many"}, wantErr: "invalid STREAM_MAX_COLS"},
+		{name: "invalid stream file size", env: map[string]string{"STREAM_MAX_FILE_SIZE": "2MB"},
+			wantErr: "invalid STREAM_MAX_FILE_SIZE"},
+		{name: "negative memo size", env: map[string]string{"MEMO_MAX_ENTRIES": "-1"}, wantErr: "invalid MEMO_MAX_ENTRIES"},
+		{name: "invalid memo TTL", env: map[string]string{"MEMO_TTL": "10"}, wantErr: "invalid MEMO_TTL"},
+		{name: "invalid remote file host", env: map[string]string{"REMOTE_FILE_HOSTS": "example.com/data"},
+			wantErr: "invalid REMOTE_FILE_HOSTS"},
+		{name: "negative drain delay", env: map[string]string{"DRAIN_DELAY": "-1s"}, wantErr: "invalid DRAIN_DELAY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default
+			fs := pflag.NewFlagSet("serve", pflag.ContinueOnError)
+			cfg.Bind(fs)
+			assert.NoError(t, fs.Parse(tt.args))
+
+			err := cfg.LoadEnv(fs, func(key string) string { return tt.env[key] })
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			want := Default
+			tt.want(&want)
+			assert.Equal(t, want, cfg)
+		})
+	}
+
+	t.Run("without flags", func(t *testing.T) {
+		cfg := Default
+
+		err := cfg.LoadEnv(nil, func(key string) string { return map[string]string{"PORT": "9090"}[key] })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "9090", cfg.Port)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Default.Validate())
+
+	tests := []struct {
+		name    string
+		set     func(cfg *Config)
+		wantErr string
+	}{
+		{name: "request timeout", set: func(cfg *Config) { cfg.RequestTimeout = cfg.WriteTimeout },
+			wantErr: "timeouts must be shorter than"},
+		{name: "operation timeout", set: func(cfg *Config) { cfg.OperationTimeout = time.Minute },
+			wantErr: "timeouts must be shorter than"},
+		{name: "write timeout", set: func(cfg *Config) { cfg.WriteTimeout = 10 * time.Second },
+			wantErr: "timeouts must be shorter than"},
+		{name: "stream limit", set: func(cfg *Config) { cfg.StreamMaxCols = 0 }, wantErr: "stream limits must be positive"},
+		{name: "memo size", set: func(cfg *Config) { cfg.MemoMaxEntries = -1 }, wantErr: "memo size must not be negative"},
+		{name: "memo TTL", set: func(cfg *Config) { cfg.MemoTTL = 0 }, wantErr: "TTL must be positive"},
+		{name: "drain delay", set: func(cfg *Config) { cfg.DrainDelay = -time.Second }, wantErr: "drain delay must not be negative"},
+		{name: "remote file host", set: func(cfg *Config) { cfg.RemoteFileHosts = []string{"user@example.com"} },
+			wantErr: "invalid host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default
+			tt.set(&cfg)
+
+			err := cfg.Validate()
+
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Bind(t *testing.T) {
+	cfg := Default
+	fs := pflag.NewFlagSet("serve", pflag.ContinueOnError)
+	cfg.Bind(fs)
+
+	assert.Equal(t, "8080", fs.Lookup("port").DefValue)
+	assert.Equal(t, "9090", fs.Lookup("grpc-port").DefValue)
+	assert.Equal(t, "10x10", fs.Lookup("max-matrix-dims").DefValue)
+	assert.Contains(t, fs.Lookup("allowed-data-dir").Usage, "(env ALLOWED_DATA_DIR)")
+
+	assert.Error(t, fs.Parse([]string{"--max-file-size", "0"}))
+}
+
+func TestParseHosts(t *testing.T) {
+	got, err := ParseHosts("")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = ParseHosts(" Example.com, data.example.org:8443,,127.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "data.example.org:8443", "127.0.0.1"}, got)
+
+	for _, hosts := range []string{"https://example.com", "example.com/data", "user@example.com", ":8443", "example.com:port"} {
+		_, err := ParseHosts(hosts)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput, hosts)
+	}
+}
+
+func TestParseDims(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    matrixlib.Limits
+		wantErr bool
+	}{
+		{value: "10x10", want: matrixlib.Limits{MaxRows: 10, MaxCols: 10}},
+		{value: "1000x3", want: matrixlib.Limits{MaxRows: 1000, MaxCols: 3}},
+		{value: "", wantErr: true},
+		{value: "10", wantErr: true},
+		{value: "10X10", wantErr: true},
+		{value: "0x10", wantErr: true},
+		{value: "10x-1", wantErr: true},
+		{value: "x10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDims(tt.value)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"sync/atomic"
+
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// Limits bound the matrix files the server reads and the matrices it processes. Tenants may lower them,
+// never raise them.
+type Limits struct {
+	// DataDir is the directory matrix files are read from; file paths must lie inside it.
+	// The data directories of tenants and the uploads live inside it too.
+	DataDir string
+
+	// MaxFileSize caps the size in bytes of a matrix file, and of a matrix read from any other source.
+	MaxFileSize int64
+
+	// MaxRows and MaxCols cap the dimensions of a matrix, other than those streamed through aggregate operations.
+	MaxRows int
+	MaxCols int
+}
+
+// DefaultLimits are the limits used unless configured otherwise: 1KiB files of up to 10x10 matrices in testdata/.
+var DefaultLimits = Limits{
+	DataDir: "testdata",
+	// The largest 10x10 matrix of 7-digit numbers takes about 800 bytes
+	MaxFileSize: 1024,
+	MaxRows:     matrixlib.MaxRows,
+	MaxCols:     matrixlib.MaxCols,
+}
+
+// Matrix returns the dimension limits of the matrix engine.
+func (l Limits) Matrix() matrixlib.Limits {
+	return matrixlib.Limits{MaxRows: l.MaxRows, MaxCols: l.MaxCols}
+}
+
+// current holds the limits set with SetLimits.
+var current atomic.Pointer[Limits]
+
+// CurrentLimits returns the limits enforced by the process: the ones set with SetLimits, or DefaultLimits.
+func CurrentLimits() Limits {
+	if limits := current.Load(); limits != nil {
+		return *limits
+	}
+	return DefaultLimits
+}
+
+// SetLimits makes limits the limits enforced by the process, wherever matrix files are read or matrices validated,
+// including the work done outside any request. The server sets them once at startup, before serving.
+func SetLimits(limits Limits) {
+	current.Store(&limits)
+}
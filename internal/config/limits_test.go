@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+func TestCurrentLimits(t *testing.T) {
+	t.Cleanup(func() { SetLimits(DefaultLimits) })
+
+	assert.Equal(t, DefaultLimits, CurrentLimits())
+
+	limits := Limits{DataDir: "data", MaxFileSize: 4096, MaxRows: 50, MaxCols: 5}
+	SetLimits(limits)
+	assert.Equal(t, limits, CurrentLimits())
+
+	// Changing the limits set leaves the process-wide ones untouched
+	limits.MaxRows = 1
+	assert.Equal(t, 50, CurrentLimits().MaxRows)
+}
+
+func TestLimits_Matrix(t *testing.T) {
+	limits := Limits{MaxRows: 50, MaxCols: 5}
+
+	assert.Equal(t, matrixlib.Limits{MaxRows: 50, MaxCols: 5}, limits.Matrix())
+	assert.Equal(t, matrixlib.DefaultLimits, DefaultLimits.Matrix())
+}
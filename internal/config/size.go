@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// byteUnits maps the suffixes accepted in sizes, as in GOMEMLIMIT, to their size in bytes.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{suffix: "TiB", size: 1 << 40},
+	{suffix: "GiB", size: 1 << 30},
+	{suffix: "MiB", size: 1 << 20},
+	{suffix: "KiB", size: 1 << 10},
+	{suffix: "B", size: 1},
+}
+
+// ParseByteSize parses a size in bytes with an optional KiB, MiB, GiB or TiB suffix.
+func ParseByteSize(value string) (int64, error) {
+	number, size := value, int64(1)
+	for _, unit := range byteUnits {
+		if trimmed, ok := strings.CutSuffix(value, unit.suffix); ok {
+			number, size = trimmed, unit.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/size {
+		return 0, fmt.Errorf("%w: invalid size %q: expected a positive size such as 512MiB",
+			apperrors.ErrInvalidInput, value)
+	}
+	return n * size, nil
+}
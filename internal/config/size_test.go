@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "1048576", want: 1 << 20},
+		{value: "1048576B", want: 1 << 20},
+		{value: "64KiB", want: 64 << 10},
+		{value: "512MiB", want: 512 << 20},
+		{value: "2GiB", want: 2 << 30},
+		{value: "1TiB", want: 1 << 40},
+		{value: "", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "-1GiB", wantErr: true},
+		{value: "1.5GiB", wantErr: true},
+		{value: "1GB", wantErr: true},
+		{value: "GiB", wantErr: true},
+		{value: "9999999999TiB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseByteSize(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
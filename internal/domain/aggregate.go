@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+const (
+	// maxAggregateFiles bounds the number of files listed in a single aggregate.
+	maxAggregateFiles = 100
+
+	// aggregateWorkers bounds the number of files of an aggregate processed at the same time.
+	aggregateWorkers = 8
+)
+
+// AggregateDomainInterface defines the business logic contract for running an operation on several matrix files
+// at once and combining their results.
+type AggregateDomainInterface interface {
+	// AggregateDirectory runs operation on every matrix file directly inside dir that the caller may read,
+	// reported in the order ListFiles returns them. Each file is processed independently, so invalid files are
+	// recorded on their own result; the results of aggregate operations are combined into a total.
+	// An error is returned only when the operation or directory is invalid or the files cannot be listed.
+	AggregateDirectory(ctx context.Context, operation string, dir string) (*entity.Aggregate, error)
+
+	// AggregateFiles runs operation on each of the listed matrix files, at most 100, and combines their results
+	// like AggregateDirectory. Files are processed concurrently and reported in the order they are listed;
+	// each file path is validated on its own, so an invalid path only fails its own result.
+	AggregateFiles(ctx context.Context, operation string, files []string) (*entity.Aggregate, error)
+}
+
+type aggregateDomain struct {
+	matrixDomain MatrixDomainInterface
+}
+
+// NewAggregateDomain creates a new instance of AggregateDomainInterface with its dependencies.
+// It initializes the domain service with the matrix domain the files are listed and processed with.
+func NewAggregateDomain(matrixDomain MatrixDomainInterface) AggregateDomainInterface {
+	return &aggregateDomain{
+		matrixDomain: matrixDomain,
+	}
+}
+
+func (d *aggregateDomain) AggregateDirectory(ctx context.Context, operation string, dir string) (*entity.Aggregate, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateAggregateOperation(operation); err != nil {
+		return nil, err
+	}
+
+	dir, err := cleanDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := d.matrixDomain.ListFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []string
+	for _, file := range files {
+		if path.Dir(file) == dir {
+			matching = append(matching, file)
+		}
+	}
+	return d.aggregate(ctx, operation, matching)
+}
+
+func (d *aggregateDomain) AggregateFiles(ctx context.Context, operation string, files []string) (*entity.Aggregate, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateAggregateOperation(operation); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: file parameter is required", apperrors.ErrInvalidInput)
+	}
+	if len(files) > maxAggregateFiles {
+		return nil, fmt.Errorf("%w: too many files: %d (maximum: %d)", apperrors.ErrInvalidInput, len(files), maxAggregateFiles)
+	}
+
+	return d.aggregate(ctx, operation, files)
+}
+
+// aggregate runs operation on files, a few at a time, and combines their results.
+func (d *aggregateDomain) aggregate(ctx context.Context, operation string, files []string) (*entity.Aggregate, error) {
+	results := make([]*entity.FileResult, len(files))
+	workers := make(chan struct{}, aggregateWorkers)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		workers <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-workers }()
+
+			result := &entity.FileResult{File: file}
+			result.Result, result.Err = d.matrixDomain.ProcessMatrix(ctx, operation, file, nil)
+			if result.Err != nil && ctx.Err() == nil {
+				slog.WarnContext(ctx, "aggregate file failed",
+					"operation", operation,
+					"file", file,
+					"error", result.Err)
+			}
+			results[i] = result
+		})
+	}
+	wg.Wait()
+
+	// A cancelled request aborts the whole aggregate rather than failing the remaining files one by one
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	total, err := combineResults(operation, results)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Aggregate{Operation: operation, Files: results, Total: total}, nil
+}
+
+// validateAggregateOperation rejects an unknown operation once instead of reporting it for every file.
+func validateAggregateOperation(operation string) error {
+	if operation == "" {
+		return fmt.Errorf("%w: op parameter is required", apperrors.ErrInvalidInput)
+	}
+	return matrixlib.ValidateOperation(operation)
+}
+
+// cleanDir validates a directory of matrix files, which follows the rules of file paths, and returns it
+// without a trailing slash.
+func cleanDir(dir string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("%w: dir parameter is required", apperrors.ErrInvalidInput)
+	}
+	if strings.Contains(dir, "..") {
+		return "", fmt.Errorf("%w: path traversal not allowed", apperrors.ErrInvalidInput)
+	}
+
+	dir = strings.TrimSuffix(dir, "/")
+	if dataDir := config.CurrentLimits().DataDir; dir != dataDir && !strings.HasPrefix(dir, dataDir+"/") {
+		return "", fmt.Errorf("%w: only directories in %s/ are allowed", apperrors.ErrInvalidInput, dataDir)
+	}
+	return dir, nil
+}
+
+// combineResults combines the scalar results of the files that succeeded into the result of operation
+// over all of their values: the sum of the sums, the product of the products, the smallest of the minimums or
+// the largest of the maximums. Results of decimal matrices are combined exactly. It returns an empty total for
+// operations producing matrices, for the mean and median, which cannot be combined from the results of each
+// file, and when no file succeeded.
+func combineResults(operation string, files []*entity.FileResult) (string, error) {
+	var total *big.Rat
+	switch matrixlib.Operation(operation) {
+	case matrixlib.Sum, matrixlib.Min, matrixlib.Max:
+		total = new(big.Rat)
+	case matrixlib.Multiply:
+		total = big.NewRat(1, 1)
+	default:
+		return "", nil
+	}
+
+	succeeded := 0
+	for _, file := range files {
+		if file.Err != nil {
+			continue
+		}
+		value, ok := new(big.Rat).SetString(file.Result.Scalar)
+		if !ok {
+			return "", fmt.Errorf("invalid %s result of %s: %q", operation, file.File, file.Result.Scalar)
+		}
+		switch matrixlib.Operation(operation) {
+		case matrixlib.Sum:
+			total.Add(total, value)
+		case matrixlib.Multiply:
+			total.Mul(total, value)
+		case matrixlib.Min:
+			if succeeded == 0 || value.Cmp(total) < 0 {
+				total = value
+			}
+		case matrixlib.Max:
+			if succeeded == 0 || value.Cmp(total) > 0 {
+				total = value
+			}
+		}
+		succeeded++
+	}
+
+	if succeeded == 0 {
+		return "", nil
+	}
+	return decimalString(total), nil
+}
+
+// decimalString writes n, a sum, product or extreme of decimals, as a decimal without trailing zeros. Its
+// denominator only has the prime factors 2 and 5, so it takes as many decimal places as the larger of their powers.
+func decimalString(n *big.Rat) string {
+	if n.IsInt() {
+		return n.Num().String()
+	}
+
+	places := 0
+	for _, factor := range []int64{2, 5} {
+		power := 0
+		denom, remainder := new(big.Int).Set(n.Denom()), new(big.Int)
+		for divisor := big.NewInt(factor); ; power++ {
+			quotient, _ := new(big.Int).QuoRem(denom, divisor, remainder)
+			if remainder.Sign() != 0 {
+				break
+			}
+			denom = quotient
+		}
+		places = max(places, power)
+	}
+	return strings.TrimRight(n.FloatString(places), "0")
+}
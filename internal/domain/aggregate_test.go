@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestAggregateDomain_AggregateDirectory(t *testing.T) {
+	files := []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv", "testdata/sub/c.csv", "testdata2/d.csv"}
+
+	tests := []struct {
+		name      string
+		operation string
+		dir       string
+		results   map[string]*entity.Result
+		wantFiles []string
+		wantTotal string
+	}{
+		{
+			name: "sum of the sums", operation: "sum", dir: "testdata",
+			results:   map[string]*entity.Result{"testdata/a.csv": {Scalar: "10"}, "testdata/b.csv": {Scalar: "9223372036854775807"}},
+			wantFiles: []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv"},
+			wantTotal: "9223372036854775817",
+		},
+		{
+			name: "product of the products", operation: "multiply", dir: "testdata/",
+			results:   map[string]*entity.Result{"testdata/a.csv": {Scalar: "6"}, "testdata/b.csv": {Scalar: "-7"}},
+			wantFiles: []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv"},
+			wantTotal: "-42",
+		},
+		{
+			name: "sum of decimal sums", operation: "sum", dir: "testdata",
+			results:   map[string]*entity.Result{"testdata/a.csv": {Scalar: "1.25"}, "testdata/b.csv": {Scalar: "0.75"}},
+			wantFiles: []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv"},
+			wantTotal: "2",
+		},
+		{
+			name: "product of decimal products", operation: "multiply", dir: "testdata",
+			results:   map[string]*entity.Result{"testdata/a.csv": {Scalar: "0.5"}, "testdata/b.csv": {Scalar: "-0.25"}},
+			wantFiles: []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv"},
+			wantTotal: "-0.125",
+		},
+		{
+			name: "smallest of the minimums", operation: "min", dir: "testdata",
+			results:   map[string]*entity.Result{"testdata/a.csv": {Scalar: "-1.5"}, "testdata/b.csv": {Scalar: "3"}},
+			wantFiles: []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv"},
+			wantTotal: "-1.5",
+		},
+		{
+			name: "largest of the maximums", operation: "max", dir: "testdata",
+			results:   map[string]*entity.Result{"testdata/a.csv": {Scalar: "-1.5"}, "testdata/b.csv": {Scalar: "3"}},
+			wantFiles: []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv"},
+			wantTotal: "3",
+		},
+		{
+			name: "means have no total", operation: "mean", dir: "testdata",
+			results:   map[string]*entity.Result{"testdata/a.csv": {Scalar: "2.5"}, "testdata/b.csv": {Scalar: "10/3"}},
+			wantFiles: []string{"testdata/a.csv", "testdata/b.csv", "testdata/bad.csv"},
+		},
+		{
+			name: "matrix results have no total", operation: "echo", dir: "testdata/sub",
+			results:   map[string]*entity.Result{"testdata/sub/c.csv": {Matrix: &entity.Matrix[int64]{Data: [][]int64{{1}}}}},
+			wantFiles: []string{"testdata/sub/c.csv"},
+		},
+		{
+			name: "empty directory", operation: "sum", dir: "testdata/empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+			mockMatrix.On("ListFiles", mock.Anything).Return(files, nil)
+			for _, file := range tt.wantFiles {
+				if result, ok := tt.results[file]; ok {
+					mockMatrix.On("ProcessMatrix", mock.Anything, tt.operation, file, (*entity.Selection)(nil)).Return(result, nil)
+				} else {
+					mockMatrix.On("ProcessMatrix", mock.Anything, tt.operation, file, (*entity.Selection)(nil)).
+						Return(nil, apperrors.ErrUnprocessableEntity)
+				}
+			}
+
+			domain := &aggregateDomain{matrixDomain: mockMatrix}
+			got, err := domain.AggregateDirectory(context.Background(), tt.operation, tt.dir)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.operation, got.Operation)
+			assert.Equal(t, tt.wantTotal, got.Total)
+			var gotFiles []string
+			for _, file := range got.Files {
+				gotFiles = append(gotFiles, file.File)
+				_, ok := tt.results[file.File]
+				assert.Equal(t, !ok, file.Err != nil, file.File)
+			}
+			assert.Equal(t, tt.wantFiles, gotFiles)
+		})
+	}
+}
+
+func TestAggregateDomain_AggregateDirectory_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		dir       string
+		wantErr   error
+	}{
+		{name: "missing operation", dir: "testdata", wantErr: apperrors.ErrInvalidInput},
+		{name: "invalid operation", operation: "divide", dir: "testdata", wantErr: apperrors.ErrInvalidInput},
+		{name: "missing directory", operation: "sum", wantErr: apperrors.ErrInvalidInput},
+		{name: "path traversal", operation: "sum", dir: "testdata/../etc", wantErr: apperrors.ErrInvalidInput},
+		{name: "directory outside testdata", operation: "sum", dir: "/etc", wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := &aggregateDomain{matrixDomain: mocks.NewMockMatrixDomainInterface(t)}
+			_, err := domain.AggregateDirectory(context.Background(), tt.operation, tt.dir)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("unlistable files", func(t *testing.T) {
+		mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+		mockMatrix.On("ListFiles", mock.Anything).Return(nil, apperrors.ErrServiceUnavailable)
+
+		domain := &aggregateDomain{matrixDomain: mockMatrix}
+		_, err := domain.AggregateDirectory(context.Background(), "sum", "testdata")
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		domain := &aggregateDomain{matrixDomain: mocks.NewMockMatrixDomainInterface(t)}
+		_, err := domain.AggregateDirectory(ctx, "sum", "testdata")
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestAggregateDomain_AggregateFiles(t *testing.T) {
+	t.Run("results in the listed order", func(t *testing.T) {
+		files := make([]string, 20)
+		mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+		for i := range files {
+			files[i] = fmt.Sprintf("testdata/matrix%d.csv", i)
+			result := &entity.Result{Scalar: strconv.Itoa(i)}
+			mockMatrix.On("ProcessMatrix", mock.Anything, "sum", files[i], (*entity.Selection)(nil)).Return(result, nil)
+		}
+		// The same file may be listed twice, counting twice in the total
+		files = append(files, "testdata/matrix19.csv", "../secrets.csv")
+		mockMatrix.On("ProcessMatrix", mock.Anything, "sum", "../secrets.csv", (*entity.Selection)(nil)).
+			Return(nil, apperrors.ErrInvalidInput)
+
+		domain := &aggregateDomain{matrixDomain: mockMatrix}
+		got, err := domain.AggregateFiles(context.Background(), "sum", files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "209", got.Total)
+		if assert.Len(t, got.Files, len(files)) {
+			for i, file := range got.Files {
+				assert.Equal(t, files[i], file.File)
+			}
+			assert.ErrorIs(t, got.Files[len(files)-1].Err, apperrors.ErrInvalidInput)
+		}
+	})
+
+	t.Run("files are processed concurrently", func(t *testing.T) {
+		// Every file waits for the other one, so a sequential aggregate would never finish
+		started := make(chan struct{})
+		wait := func(mock.Arguments) {
+			select {
+			case started <- struct{}{}:
+			case <-started:
+			}
+		}
+		mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+		mockMatrix.On("ProcessMatrix", mock.Anything, "multiply", mock.Anything, (*entity.Selection)(nil)).
+			Run(wait).Return(&entity.Result{Scalar: "3"}, nil)
+
+		domain := &aggregateDomain{matrixDomain: mockMatrix}
+		got, err := domain.AggregateFiles(context.Background(), "multiply", []string{"testdata/a.csv", "testdata/b.csv"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "9", got.Total)
+	})
+
+	errorTests := []struct {
+		name    string
+		files   []string
+		wantErr error
+	}{
+		{name: "no files", wantErr: apperrors.ErrInvalidInput},
+		{name: "too many files", files: make([]string, maxAggregateFiles+1), wantErr: apperrors.ErrInvalidInput},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := &aggregateDomain{matrixDomain: mocks.NewMockMatrixDomainInterface(t)}
+			_, err := domain.AggregateFiles(context.Background(), "sum", tt.files)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("cancelled request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+		mockMatrix.On("ProcessMatrix", mock.Anything, "sum", "testdata/a.csv", (*entity.Selection)(nil)).
+			Run(func(mock.Arguments) { cancel() }).Return(nil, context.Canceled)
+
+		domain := &aggregateDomain{matrixDomain: mockMatrix}
+		_, err := domain.AggregateFiles(ctx, "sum", []string{"testdata/a.csv"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestAggregateDomain_AggregateDirectory_Samples(t *testing.T) {
+	// The sample matrices holding valid sums add up whatever order they are listed in
+	got, err := NewAggregateDomain(NewMatrixDomain(DefaultStreamLimits, MemoConfig{}, FileSourcesConfig{})).
+		AggregateDirectory(context.Background(), "sum", "testdata")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, got.Files)
+	assert.NotEmpty(t, got.Total)
+}
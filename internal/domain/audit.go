@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// defaultAuditLimit is the number of entries returned when a query sets no limit.
+	defaultAuditLimit = 100
+
+	// maxAuditLimit caps the number of entries a single query may return.
+	maxAuditLimit = 1000
+)
+
+// AuditDomainInterface defines the business logic contract for the audit log, which records every
+// API request and every denied access attempt for later review by operators.
+type AuditDomainInterface interface {
+	// Record adds an entry to the audit log, assigning its ID. Entries naming a file are recorded with the tags
+	// the file carries at the time, unless they carry tags already.
+	Record(ctx context.Context, entry *entity.AuditEntry) error
+
+	// ListEntries returns the entries matching filter, newest first.
+	// Without a limit, at most 100 entries are returned; limits above 1000 are rejected with ErrInvalidInput.
+	ListEntries(ctx context.Context, filter *entity.AuditFilter) ([]*entity.AuditEntry, error)
+}
+
+type auditDomain struct {
+	auditRepository repository.AuditRepositoryInterface
+	tagRepository   repository.TagRepositoryInterface
+}
+
+// NewAuditDomain creates a new instance of AuditDomainInterface.
+// It initializes the domain service with an audit log kept in auditFile, one JSON entry per line;
+// when auditFile is empty, the latest entries are kept in memory only. The tags of the files
+// entries name are read from the tag repository.
+func NewAuditDomain(auditFile string) (AuditDomainInterface, error) {
+	auditRepository := repository.NewAuditRepository()
+	if auditFile != "" {
+		var err error
+		auditRepository, err = repository.NewFileAuditRepository(auditFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &auditDomain{
+		auditRepository: auditRepository,
+		tagRepository:   repository.NewTagRepository(),
+	}, nil
+}
+
+func (d *auditDomain) Record(ctx context.Context, entry *entity.AuditEntry) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	id, err := newID("audit entry")
+	if err != nil {
+		return err
+	}
+	entry.ID = id
+	if entry.FilePath != "" && entry.Tags == nil {
+		entry.Tags = fileTags(ctx, d.tagRepository, entry.FilePath)
+	}
+
+	return d.auditRepository.AppendEntry(ctx, &repository.AuditRecord{
+		ID:         entry.ID,
+		Time:       entry.Time,
+		Event:      string(entry.Event),
+		Actor:      entry.Actor,
+		RemoteAddr: entry.RemoteAddr,
+		Method:     entry.Method,
+		Path:       entry.Path,
+		Operation:  entry.Operation,
+		FilePath:   entry.FilePath,
+		Status:     entry.Status,
+		Duration:   entry.Duration,
+		Reason:     entry.Reason,
+		Tags:       entry.Tags,
+	})
+}
+
+func (d *auditDomain) ListEntries(ctx context.Context, filter *entity.AuditFilter) ([]*entity.AuditEntry, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	switch {
+	case limit < 0 || limit > maxAuditLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", apperrors.ErrInvalidInput, maxAuditLimit)
+	case limit == 0:
+		limit = defaultAuditLimit
+	}
+	if filter.Event != "" && filter.Event != entity.AuditRequest && filter.Event != entity.AuditDenied {
+		return nil, fmt.Errorf("%w: unknown audit event: %s", apperrors.ErrInvalidInput, filter.Event)
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return nil, fmt.Errorf("%w: since must be before until", apperrors.ErrInvalidInput)
+	}
+
+	records, err := d.auditRepository.ListEntries(ctx, &repository.AuditQuery{
+		Actor:     filter.Actor,
+		Event:     string(filter.Event),
+		Operation: filter.Operation,
+		Since:     filter.Since,
+		Until:     filter.Until,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*entity.AuditEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, &entity.AuditEntry{
+			ID:         record.ID,
+			Time:       record.Time,
+			Event:      entity.AuditEvent(record.Event),
+			Actor:      record.Actor,
+			RemoteAddr: record.RemoteAddr,
+			Method:     record.Method,
+			Path:       record.Path,
+			Operation:  record.Operation,
+			FilePath:   record.FilePath,
+			Status:     record.Status,
+			Duration:   record.Duration,
+			Reason:     record.Reason,
+			Tags:       record.Tags,
+		})
+	}
+	return entries, nil
+}
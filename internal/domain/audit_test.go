@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestAuditDomain_RecordAndList(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, auditFile := range []string{"", filepath.Join(t.TempDir(), "audit.log")} {
+		domain, err := NewAuditDomain(auditFile)
+		assert.NoError(t, err)
+
+		for i := range 3 {
+			entry := &entity.AuditEntry{
+				Time:      start.Add(time.Duration(i) * time.Minute),
+				Event:     entity.AuditRequest,
+				Actor:     "tenant:acme",
+				Method:    "GET",
+				Path:      "/matrix/sum",
+				Operation: "sum",
+				Status:    200,
+				Duration:  time.Millisecond,
+			}
+			assert.NoError(t, domain.Record(context.Background(), entry))
+			assert.Len(t, entry.ID, 32)
+		}
+		assert.NoError(t, domain.Record(context.Background(), &entity.AuditEntry{
+			Time:   start.Add(time.Hour),
+			Event:  entity.AuditDenied,
+			Method: "GET",
+			Path:   "/matrix/sum",
+			Status: 401,
+			Reason: "unknown API key",
+		}))
+
+		got, err := domain.ListEntries(context.Background(), &entity.AuditFilter{})
+		assert.NoError(t, err)
+		if assert.Len(t, got, 4) {
+			assert.Equal(t, entity.AuditDenied, got[0].Event)
+			assert.Equal(t, "unknown API key", got[0].Reason)
+			assert.Equal(t, time.Millisecond, got[1].Duration)
+		}
+
+		got, err = domain.ListEntries(context.Background(), &entity.AuditFilter{Event: entity.AuditRequest, Limit: 2})
+		assert.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, start.Add(2*time.Minute), got[0].Time)
+		}
+	}
+}
+
+func TestAuditDomain_ListEntries_InvalidFilter(t *testing.T) {
+	domain, err := NewAuditDomain("")
+	assert.NoError(t, err)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter *entity.AuditFilter
+	}{
+		{name: "limit too large", filter: &entity.AuditFilter{Limit: maxAuditLimit + 1}},
+		{name: "negative limit", filter: &entity.AuditFilter{Limit: -1}},
+		{name: "unknown event", filter: &entity.AuditFilter{Event: "login"}},
+		{name: "empty time range", filter: &entity.AuditFilter{Since: start, Until: start}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ListEntries(context.Background(), tt.filter)
+
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestAuditDomain_Record_Tags(t *testing.T) {
+	t.Chdir(t.TempDir())
+	filePath := "testdata/tenants/acme/league.csv"
+	storeMatrixFile(t, filePath)
+	tags := map[string]string{"season": "2026"}
+	assert.NoError(t, repository.NewTagRepository().SetTags(tenant.WithID(context.Background(), "acme"), filePath, tags))
+
+	domain, err := NewAuditDomain("")
+	assert.NoError(t, err)
+
+	// Entries are recorded with the tags of the file, whoever the request came from
+	for _, entry := range []*entity.AuditEntry{
+		{Event: entity.AuditRequest, Actor: "tenant:acme", FilePath: filePath, Status: 200},
+		{Event: entity.AuditDenied, FilePath: filePath + "@v1", Status: 401},
+		{Event: entity.AuditRequest, FilePath: "../league.csv", Status: 400},
+	} {
+		assert.NoError(t, domain.Record(context.Background(), entry))
+	}
+
+	got, err := domain.ListEntries(context.Background(), &entity.AuditFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 3) {
+		assert.Nil(t, got[0].Tags)
+		assert.Equal(t, tags, got[1].Tags)
+		assert.Equal(t, tags, got[2].Tags)
+	}
+}
+
+func TestNewAuditDomain_UnwritableFile(t *testing.T) {
+	domain, err := NewAuditDomain(filepath.Join(t.TempDir(), "missing", "audit.log"))
+
+	assert.Error(t, err)
+	assert.Nil(t, domain)
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// operationsPerWorker is the number of operation requests admitted per worker of the execution pool:
+	// one running and the others waiting for it, few enough to be served well before they time out.
+	operationsPerWorker = 8
+
+	// minRetryAfter and maxRetryAfter bound the time clients rejected under overload are told to wait,
+	// so they neither retry in a tight loop nor give up on a queue that drains quickly.
+	minRetryAfter = time.Second
+	maxRetryAfter = time.Minute
+
+	// defaultServiceTime stands for the time per item of a queue before any item was measured.
+	defaultServiceTime = time.Second
+
+	// Resources reported in the overload metrics.
+	operationsResource = "operations"
+	jobsResource       = "jobs"
+)
+
+// BackpressureDomainInterface defines the business logic contract for rejecting work up front while the workers
+// it would wait for are saturated, rather than letting it pile up until it times out.
+// Rejections come with the time after which the client should retry, estimated from the work ahead of it.
+type BackpressureDomainInterface interface {
+	// AdmitOperation reserves a place for an operation request among those the execution pool can serve in time,
+	// and returns the function releasing it once the request is served. While every place is taken it returns
+	// ErrServiceUnavailable, with the time after which the client should retry.
+	AdmitOperation(ctx context.Context) (release func(), retryAfter time.Duration, err error)
+
+	// AdmitJob checks whether the job queue has room for a new job. While it is full it returns
+	// ErrServiceUnavailable, with the time after which the client should retry.
+	AdmitJob(ctx context.Context) (retryAfter time.Duration, err error)
+
+	// Metrics returns the overload metrics: the work queued for each resource, its capacity,
+	// and the requests rejected since the service started.
+	Metrics() []*entity.Metric
+}
+
+type backpressureDomain struct {
+	pool *executionPool
+	// capacity is the number of operation requests admitted at once
+	capacity int
+	// jobQueue reports the status of the job queue
+	jobQueue func() *entity.QueueStatus
+
+	operations atomic.Int64
+
+	mu       sync.Mutex
+	rejected map[string]uint64
+}
+
+// NewBackpressureDomain creates a new instance of BackpressureDomainInterface with its dependencies.
+// It initializes the domain service with the execution pool shared by the process, admitting
+// a few operation requests per worker, and with jobQueue reporting the status of the job queue.
+func NewBackpressureDomain(jobQueue func() *entity.QueueStatus) BackpressureDomainInterface {
+	pool := sharedExecutionPool()
+	return newBackpressureDomain(pool, pool.workers*operationsPerWorker, jobQueue)
+}
+
+// newBackpressureDomain builds a backpressure domain admitting capacity operation requests to pool at once.
+func newBackpressureDomain(pool *executionPool, capacity int, jobQueue func() *entity.QueueStatus) *backpressureDomain {
+	return &backpressureDomain{
+		pool:     pool,
+		capacity: capacity,
+		jobQueue: jobQueue,
+		rejected: make(map[string]uint64),
+	}
+}
+
+func (d *backpressureDomain) AdmitOperation(ctx context.Context) (func(), time.Duration, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if admitted := d.operations.Add(1); admitted > int64(d.capacity) {
+		d.operations.Add(-1)
+		d.reject(operationsResource)
+		// Every operation admitted is ahead of the rejected one
+		retryAfter := estimateRetryAfter(int(admitted-1), d.pool.workers, d.pool.serviceTime.get())
+		return nil, retryAfter, fmt.Errorf("%w: too many operations in progress, retry in %s",
+			apperrors.ErrServiceUnavailable, retryAfter)
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { d.operations.Add(-1) }) }, 0, nil
+}
+
+func (d *backpressureDomain) AdmitJob(ctx context.Context) (time.Duration, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	status := d.jobQueue()
+	if status.Queued < status.Capacity {
+		return 0, nil
+	}
+
+	d.reject(jobsResource)
+	// The running jobs are ahead of the queued ones as well
+	retryAfter := estimateRetryAfter(status.Queued+status.Workers, status.Workers, status.ServiceTime)
+	return retryAfter, fmt.Errorf("%w: job queue is full, retry in %s", apperrors.ErrServiceUnavailable, retryAfter)
+}
+
+func (d *backpressureDomain) Metrics() []*entity.Metric {
+	jobs := d.jobQueue()
+
+	d.mu.Lock()
+	rejectedOperations, rejectedJobs := d.rejected[operationsResource], d.rejected[jobsResource]
+	d.mu.Unlock()
+
+	return []*entity.Metric{
+		{
+			Name:        "backpressure.queue.size",
+			Description: "Count of operation requests admitted and of jobs waiting for a worker.",
+			Unit:        "{request}",
+			Kind:        entity.MetricGauge,
+			Points: []*entity.MetricPoint{
+				{Attributes: map[string]string{"backpressure.resource": operationsResource}, Value: float64(d.operations.Load())},
+				{Attributes: map[string]string{"backpressure.resource": jobsResource}, Value: float64(jobs.Queued)},
+			},
+		},
+		{
+			Name:        "backpressure.queue.capacity",
+			Description: "Count of operation requests and of jobs admitted before new ones are rejected.",
+			Unit:        "{request}",
+			Kind:        entity.MetricGauge,
+			Points: []*entity.MetricPoint{
+				{Attributes: map[string]string{"backpressure.resource": operationsResource}, Value: float64(d.capacity)},
+				{Attributes: map[string]string{"backpressure.resource": jobsResource}, Value: float64(jobs.Capacity)},
+			},
+		},
+		{
+			Name:        "backpressure.rejections",
+			Description: "Count of requests rejected with 503 Service Unavailable because their queue was full.",
+			Unit:        "{request}",
+			Kind:        entity.MetricCounter,
+			Points: []*entity.MetricPoint{
+				{Attributes: map[string]string{"backpressure.resource": operationsResource}, Value: float64(rejectedOperations)},
+				{Attributes: map[string]string{"backpressure.resource": jobsResource}, Value: float64(rejectedJobs)},
+			},
+		},
+	}
+}
+
+// reject counts a request rejected for resource.
+func (d *backpressureDomain) reject(resource string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.rejected[resource]++
+}
+
+// estimateRetryAfter estimates how long workers take to get through ahead items, taking serviceTime each,
+// rounded up to whole seconds and bounded by minRetryAfter and maxRetryAfter.
+func estimateRetryAfter(ahead, workers int, serviceTime time.Duration) time.Duration {
+	if serviceTime <= 0 {
+		serviceTime = defaultServiceTime
+	}
+	workers = max(workers, 1)
+	// The items ahead are served in rounds of one item per worker
+	rounds := (ahead + workers - 1) / workers
+	estimate := (time.Duration(rounds)*serviceTime + time.Second - 1).Truncate(time.Second)
+
+	return min(max(estimate, minRetryAfter), maxRetryAfter)
+}
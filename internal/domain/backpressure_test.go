@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestBackpressureDomain_AdmitOperation(t *testing.T) {
+	jobQueue := func() *entity.QueueStatus { return &entity.QueueStatus{Capacity: 1} }
+
+	t.Run("admits operations up to the capacity", func(t *testing.T) {
+		pool := newExecutionPool(2)
+		pool.serviceTime.observe(3 * time.Second)
+		d := newBackpressureDomain(pool, 4, jobQueue)
+
+		var releases []func()
+		for range 4 {
+			release, _, err := d.AdmitOperation(context.Background())
+			require.NoError(t, err)
+			releases = append(releases, release)
+		}
+
+		// The 4 operations ahead take two rounds of the 2 workers
+		release, retryAfter, err := d.AdmitOperation(context.Background())
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.Nil(t, release)
+		assert.Equal(t, 6*time.Second, retryAfter)
+
+		// Releasing twice frees a single place
+		releases[0]()
+		releases[0]()
+		release, _, err = d.AdmitOperation(context.Background())
+		require.NoError(t, err)
+		release()
+		_, _, err = d.AdmitOperation(context.Background())
+		assert.NoError(t, err)
+		_, _, err = d.AdmitOperation(context.Background())
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		d := newBackpressureDomain(newExecutionPool(1), 1, jobQueue)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		release, _, err := d.AdmitOperation(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, release)
+	})
+}
+
+func TestBackpressureDomain_AdmitJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         *entity.QueueStatus
+		wantErr        error
+		wantRetryAfter time.Duration
+	}{
+		{name: "room in the queue", status: &entity.QueueStatus{Queued: 9, Capacity: 10, Workers: 2}},
+		{
+			name:           "full queue",
+			status:         &entity.QueueStatus{Queued: 10, Capacity: 10, Workers: 2, ServiceTime: 500 * time.Millisecond},
+			wantErr:        apperrors.ErrServiceUnavailable,
+			wantRetryAfter: 3 * time.Second,
+		},
+		{
+			name:           "full queue of slow jobs",
+			status:         &entity.QueueStatus{Queued: 10, Capacity: 10, Workers: 2, ServiceTime: 20 * time.Second},
+			wantErr:        apperrors.ErrServiceUnavailable,
+			wantRetryAfter: time.Minute,
+		},
+		{
+			name:           "full queue before any job ran",
+			status:         &entity.QueueStatus{Queued: 1, Capacity: 1, Workers: 4},
+			wantErr:        apperrors.ErrServiceUnavailable,
+			wantRetryAfter: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newBackpressureDomain(newExecutionPool(1), 1, func() *entity.QueueStatus { return tt.status })
+
+			retryAfter, err := d.AdmitJob(context.Background())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRetryAfter, retryAfter)
+		})
+	}
+}
+
+func TestBackpressureDomain_Metrics(t *testing.T) {
+	d := newBackpressureDomain(newExecutionPool(1), 1, func() *entity.QueueStatus {
+		return &entity.QueueStatus{Queued: 3, Capacity: 3, Workers: 1}
+	})
+	_, _, err := d.AdmitOperation(context.Background())
+	require.NoError(t, err)
+	_, _, err = d.AdmitOperation(context.Background())
+	require.Error(t, err)
+	_, err = d.AdmitJob(context.Background())
+	require.Error(t, err)
+	_, err = d.AdmitJob(context.Background())
+	require.Error(t, err)
+
+	values := map[string]map[string]float64{}
+	for _, metric := range d.Metrics() {
+		values[metric.Name] = map[string]float64{}
+		for _, point := range metric.Points {
+			values[metric.Name][point.Attributes["backpressure.resource"]] = point.Value
+		}
+	}
+	assert.Equal(t, map[string]map[string]float64{
+		"backpressure.queue.size":     {"operations": 1, "jobs": 3},
+		"backpressure.queue.capacity": {"operations": 1, "jobs": 3},
+		"backpressure.rejections":     {"operations": 1, "jobs": 2},
+	}, values)
+}
+
+func TestEstimateRetryAfter(t *testing.T) {
+	tests := []struct {
+		name        string
+		ahead       int
+		workers     int
+		serviceTime time.Duration
+		want        time.Duration
+	}{
+		{name: "rounds of work", ahead: 10, workers: 4, serviceTime: 2 * time.Second, want: 6 * time.Second},
+		{name: "rounded up to seconds", ahead: 3, workers: 1, serviceTime: 700 * time.Millisecond, want: 3 * time.Second},
+		{name: "at least a second", ahead: 1, workers: 8, serviceTime: time.Millisecond, want: time.Second},
+		{name: "at most a minute", ahead: 100, workers: 1, serviceTime: time.Second, want: time.Minute},
+		{name: "unmeasured service time", ahead: 4, workers: 2, want: 2 * time.Second},
+		{name: "no workers", ahead: 3, serviceTime: time.Second, want: 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, estimateRetryAfter(tt.ahead, tt.workers, tt.serviceTime))
+		})
+	}
+}
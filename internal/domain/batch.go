@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/metrics"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// maxBatchItems bounds the number of items of a single batch request.
+	maxBatchItems = 100
+
+	// batchWorkers bounds the number of items of a batch request processed at the same time.
+	batchWorkers = 8
+
+	// batchOperation names batch requests in the metrics, whatever operations their items run.
+	batchOperation = "batch"
+)
+
+// BatchDomainInterface defines the business logic contract for running several operations, each on its own
+// matrix file, in a single request.
+type BatchDomainInterface interface {
+	// RunBatch runs each of items, at most 100, like ProcessMatrix does, and returns their results in the order
+	// of items. Items are processed concurrently, a few at a time, and each is validated on its own, so an invalid
+	// operation or file only fails its own result. An error is returned only when items is empty or too long,
+	// or when ctx is done before every item completed.
+	RunBatch(ctx context.Context, items []entity.BatchItem) ([]*entity.BatchItemResult, error)
+}
+
+type batchDomain struct {
+	matrixDomain MatrixDomainInterface
+}
+
+// NewBatchDomain creates a new instance of BatchDomainInterface with its dependencies.
+// It initializes the domain service with the matrix domain the items are processed with.
+func NewBatchDomain(matrixDomain MatrixDomainInterface) BatchDomainInterface {
+	return &batchDomain{
+		matrixDomain: matrixDomain,
+	}
+}
+
+func (d *batchDomain) RunBatch(ctx context.Context, items []entity.BatchItem) ([]*entity.BatchItemResult, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: a batch needs at least one item", apperrors.ErrInvalidInput)
+	}
+	if len(items) > maxBatchItems {
+		return nil, fmt.Errorf("%w: too many batch items: %d (maximum: %d)", apperrors.ErrInvalidInput, len(items), maxBatchItems)
+	}
+
+	// Each item writes its own slot, so results need no lock and keep the order of items
+	results := make([]*entity.BatchItemResult, len(items))
+	workers := make(chan struct{}, batchWorkers)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		workers <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-workers }()
+
+			result := &entity.BatchItemResult{Item: item}
+			result.Result, result.Err = d.matrixDomain.ProcessMatrix(ctx, item.Operation, item.File, nil)
+			if result.Err != nil && ctx.Err() == nil {
+				slog.WarnContext(ctx, "batch item failed",
+					"item", i,
+					"operation", item.Operation,
+					"file", item.File,
+					"error", result.Err)
+			}
+			results[i] = result
+		})
+	}
+	wg.Wait()
+	// Items name their own operations as they run, so the request is named after the batch once they are done
+	metrics.SetOperation(ctx, batchOperation)
+
+	// A cancelled request aborts the whole batch rather than failing the remaining items one by one
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/metrics"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestBatchDomain_RunBatch(t *testing.T) {
+	mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+	mockMatrix.On("ProcessMatrix", mock.Anything, "sum", "testdata/a.csv", (*entity.Selection)(nil)).
+		Return(&entity.Result{Scalar: "10"}, nil)
+	mockMatrix.On("ProcessMatrix", mock.Anything, "transpose", "testdata/a.csv", (*entity.Selection)(nil)).
+		Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}}, nil)
+	mockMatrix.On("ProcessMatrix", mock.Anything, "sum", "testdata/bad.csv", (*entity.Selection)(nil)).
+		Return(nil, apperrors.ErrUnprocessableEntity)
+	mockMatrix.On("ProcessMatrix", mock.Anything, "divide", "testdata/a.csv", (*entity.Selection)(nil)).
+		Return(nil, apperrors.ErrInvalidInput)
+
+	items := []entity.BatchItem{
+		{Operation: "sum", File: "testdata/a.csv"},
+		{Operation: "sum", File: "testdata/bad.csv"},
+		{Operation: "transpose", File: "testdata/a.csv"},
+		{Operation: "divide", File: "testdata/a.csv"},
+	}
+	request := metrics.NewRequest()
+	ctx := metrics.NewContext(context.Background(), request)
+
+	got, err := NewBatchDomain(mockMatrix).RunBatch(ctx, items)
+
+	assert.NoError(t, err)
+	if assert.Len(t, got, len(items)) {
+		// Results keep the order of the items, and failing items only fail their own result
+		for i, result := range got {
+			assert.Equal(t, items[i], result.Item)
+		}
+		assert.Equal(t, "10", got[0].Result.String())
+		assert.ErrorIs(t, got[1].Err, apperrors.ErrUnprocessableEntity)
+		assert.Equal(t, "1,3\n2,4", got[2].Result.String())
+		assert.ErrorIs(t, got[3].Err, apperrors.ErrInvalidInput)
+	}
+	assert.Equal(t, batchOperation, request.Operation())
+}
+
+func TestBatchDomain_RunBatch_Concurrency(t *testing.T) {
+	var running, peak atomic.Int64
+	mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+	mockMatrix.On("ProcessMatrix", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+		Run(func(mock.Arguments) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+		}).
+		Return(&entity.Result{Scalar: "1"}, nil)
+
+	items := make([]entity.BatchItem, maxBatchItems)
+	for i := range items {
+		items[i] = entity.BatchItem{Operation: "sum", File: fmt.Sprintf("testdata/%d.csv", i)}
+	}
+
+	got, err := NewBatchDomain(mockMatrix).RunBatch(context.Background(), items)
+
+	assert.NoError(t, err)
+	assert.Len(t, got, maxBatchItems)
+	// Items run concurrently, but never more than the workers at once
+	assert.Greater(t, peak.Load(), int64(1))
+	assert.LessOrEqual(t, peak.Load(), int64(batchWorkers))
+}
+
+func TestBatchDomain_RunBatch_Errors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		items   []entity.BatchItem
+		wantErr error
+	}{
+		{name: "no items", ctx: context.Background(), wantErr: apperrors.ErrInvalidInput},
+		{name: "too many items", ctx: context.Background(), items: make([]entity.BatchItem, maxBatchItems+1), wantErr: apperrors.ErrInvalidInput},
+		{name: "cancelled", ctx: cancelled, items: []entity.BatchItem{{Operation: "sum", File: "testdata/a.csv"}}, wantErr: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBatchDomain(mocks.NewMockMatrixDomainInterface(t)).RunBatch(tt.ctx, tt.items)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, got)
+		})
+	}
+}
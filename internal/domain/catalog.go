@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// defaultCatalogLimit is the number of files returned when a search sets no limit.
+	defaultCatalogLimit = 50
+
+	// maxCatalogLimit caps the number of files a search returns at once, which is as many as the repository lists.
+	maxCatalogLimit = 1000
+)
+
+// catalogSorts compares stored matrix files by each of the fields a search may sort them by, in ascending order.
+var catalogSorts = map[string]func(a, b *entity.StoredMatrix) int{
+	"name":       func(a, b *entity.StoredMatrix) int { return cmp.Compare(a.Name, b.Name) },
+	"created_at": func(a, b *entity.StoredMatrix) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"updated_at": func(a, b *entity.StoredMatrix) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+	"size":       func(a, b *entity.StoredMatrix) int { return cmp.Compare(a.Size, b.Size) },
+	"rows":       func(a, b *entity.StoredMatrix) int { return cmp.Compare(a.Rows, b.Rows) },
+	"cols":       func(a, b *entity.StoredMatrix) int { return cmp.Compare(a.Cols, b.Cols) },
+}
+
+// CatalogDomainInterface defines the business logic contract for finding stored matrix files by their metadata.
+type CatalogDomainInterface interface {
+	// Search returns the page of the matrix files the tenant carried by ctx may read that filter selects,
+	// with their tags, sorted by name unless filter sets another order. It returns ErrInvalidInput for an unknown sort field
+	// or a limit above 1000.
+	Search(ctx context.Context, filter *entity.MatrixFilter) (*entity.MatrixPage, error)
+
+	// Export passes every matrix file Search would find with filter, regardless of its page, to handleFile
+	// one at a time and in the same order, with its raw content. Files removed since they were listed are left out. Exporting stops
+	// at the first error returned by handleFile or when the context is cancelled; an unknown sort field
+	// is rejected with ErrInvalidInput before any file is passed.
+	Export(ctx context.Context, filter *entity.MatrixFilter, handleFile func(matrix *entity.StoredMatrix, content []byte) error) error
+}
+
+type catalogDomain struct {
+	matrixRepository repository.MatrixRepositoryInterface
+	tagRepository    repository.TagRepositoryInterface
+}
+
+// NewCatalogDomain creates a new instance of CatalogDomainInterface with its dependencies.
+// It initializes the domain service with the matrix repository the files are listed and read from,
+// and the tag repository their tags are read from.
+func NewCatalogDomain() CatalogDomainInterface {
+	return &catalogDomain{
+		matrixRepository: repository.NewMatrixRepository(),
+		tagRepository:    repository.NewTagRepository(),
+	}
+}
+
+func (d *catalogDomain) Search(ctx context.Context, filter *entity.MatrixFilter) (*entity.MatrixPage, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	switch {
+	case limit < 0 || limit > maxCatalogLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", apperrors.ErrInvalidInput, maxCatalogLimit)
+	case limit == 0:
+		limit = defaultCatalogLimit
+	}
+
+	matrices, err := d.find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(matrices)
+	start := min(filter.Offset, total)
+	end := start + min(limit, total-start)
+	return &entity.MatrixPage{
+		Matrices: matrices[start:end],
+		Total:    total,
+		Offset:   filter.Offset,
+		Limit:    limit,
+	}, nil
+}
+
+func (d *catalogDomain) Export(ctx context.Context, filter *entity.MatrixFilter, handleFile func(matrix *entity.StoredMatrix, content []byte) error) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	matrices, err := d.find(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range matrices {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// The content is read again rather than kept from the search, so a large export never holds every file
+		content, err := d.matrixRepository.ReadFile(ctx, info.FilePath)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.WarnContext(ctx, "skipping unreadable matrix file",
+				"file_path", info.FilePath,
+				"error", err)
+			continue
+		}
+		info.Size = int64(len(content))
+		if err := handleFile(info, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// find returns every matrix file the tenant carried by ctx may read that filter selects, ignoring its page,
+// sorted as filter sets.
+func (d *catalogDomain) find(ctx context.Context, filter *entity.MatrixFilter) ([]*entity.StoredMatrix, error) {
+	field, descending := strings.CutPrefix(filter.Sort, "-")
+	if field == "" {
+		field = "name"
+	}
+	compare, ok := catalogSorts[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid sort field: %q", apperrors.ErrInvalidInput, filter.Sort)
+	}
+
+	files, err := d.matrixRepository.ListFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matrices := make([]*entity.StoredMatrix, 0, len(files))
+	for _, filePath := range files {
+		// The name and tags are checked first, so files they rule out are never read
+		name := path.Base(filePath)
+		if !strings.Contains(strings.ToLower(name), strings.ToLower(filter.Name)) {
+			continue
+		}
+		tags, err := d.tagRepository.GetTags(ctx, filePath)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to read tags",
+				"file_path", filePath,
+				"error", err)
+		}
+		if !matchesTags(tags, filter.Tags) {
+			continue
+		}
+		info, err := d.describe(ctx, filePath)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// The search is best effort: a file removed or grown too large since it was listed is left out
+			slog.WarnContext(ctx, "skipping unreadable matrix file",
+				"file_path", filePath,
+				"error", err)
+			continue
+		}
+		if matchesDimensions(info, filter) {
+			info.Tags = tags
+			matrices = append(matrices, info)
+		}
+	}
+
+	slices.SortStableFunc(matrices, func(a, b *entity.StoredMatrix) int {
+		order := compare(a, b)
+		if descending {
+			order = -order
+		}
+		return cmp.Or(order, cmp.Compare(a.FilePath, b.FilePath))
+	})
+	return matrices, nil
+}
+
+// describe reads the matrix file at filePath and returns its metadata. A file that is not valid CSV
+// is still described, with no rows or columns.
+func (d *catalogDomain) describe(ctx context.Context, filePath string) (*entity.StoredMatrix, error) {
+	data, err := d.matrixRepository.ReadFile(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := d.matrixRepository.FileModTime(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	// The first version was stored with the file, whatever it has been updated with since
+	createdAt, err := d.matrixRepository.FileModTime(ctx, repository.VersionRef(filePath, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	info := &entity.StoredMatrix{
+		FilePath:  filePath,
+		Name:      path.Base(filePath),
+		Size:      int64(len(data)),
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+	err = d.matrixRepository.StreamContent(ctx, bytes.NewReader(data), func(row []string) error {
+		info.Rows++
+		info.Cols = max(info.Cols, len(row))
+		return nil
+	})
+	if err != nil {
+		info.Rows, info.Cols = 0, 0
+	}
+	return info, nil
+}
+
+// matchesTags reports whether tags holds every tag in want, with any value for those whose value is empty.
+func matchesTags(tags map[string]string, want map[string]string) bool {
+	for key, value := range want {
+		got, ok := tags[key]
+		if !ok || (value != "" && got != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesDimensions reports whether the rows and columns of info are within the bounds set by filter.
+func matchesDimensions(info *entity.StoredMatrix, filter *entity.MatrixFilter) bool {
+	return (filter.MinRows == 0 || info.Rows >= filter.MinRows) &&
+		(filter.MaxRows == 0 || info.Rows <= filter.MaxRows) &&
+		(filter.MinCols == 0 || info.Cols >= filter.MinCols) &&
+		(filter.MaxCols == 0 || info.Cols <= filter.MaxCols)
+}
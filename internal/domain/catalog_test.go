@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestCatalogDomain_Search(t *testing.T) {
+	t.Chdir(t.TempDir())
+	created := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	files := []struct {
+		name    string
+		content string
+		modTime time.Time
+	}{
+		{"alpha.csv", "1,2\n3,4\n", created.Add(3 * time.Hour)},
+		{"beta.csv", "1,2,3\n", created.Add(time.Hour)},
+		{"gamma.csv", "1\n2\n3\n", created.Add(2 * time.Hour)},
+		{"broken.csv", "1,\"2\n", created},
+	}
+	dir := "testdata/tenants/acme"
+	assert.NoError(t, os.MkdirAll(dir, 0o755))
+	for _, file := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file.name), []byte(file.content), 0o644))
+		assert.NoError(t, os.Chtimes(filepath.Join(dir, file.name), file.modTime, file.modTime))
+	}
+	// alpha.csv was first stored before any other file, and updated last
+	storeMatrixFile(t, filepath.Join(dir, ".versions/alpha.csv/v1"))
+	assert.NoError(t, os.Chtimes(filepath.Join(dir, ".versions/alpha.csv/v1"), created.Add(-time.Hour), created.Add(-time.Hour)))
+	ctx := tenant.WithID(context.Background(), "acme")
+	tags := repository.NewTagRepository()
+	assert.NoError(t, tags.SetTags(ctx, filepath.Join(dir, "alpha.csv"), map[string]string{"season": "2026", "league": "premier"}))
+	assert.NoError(t, tags.SetTags(ctx, filepath.Join(dir, "gamma.csv"), map[string]string{"season": "2025"}))
+
+	tests := []struct {
+		name      string
+		filter    *entity.MatrixFilter
+		wantFiles []string
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name:      "sorted by name",
+			filter:    &entity.MatrixFilter{},
+			wantFiles: []string{"alpha.csv", "beta.csv", "broken.csv", "gamma.csv"},
+			wantTotal: 4,
+		},
+		{
+			name:      "name filter ignores case",
+			filter:    &entity.MatrixFilter{Name: "MA"},
+			wantFiles: []string{"gamma.csv"},
+			wantTotal: 1,
+		},
+		{
+			name:      "dimension filters",
+			filter:    &entity.MatrixFilter{MinRows: 2, MaxCols: 1},
+			wantFiles: []string{"gamma.csv"},
+			wantTotal: 1,
+		},
+		{
+			name:      "tag filter",
+			filter:    &entity.MatrixFilter{Tags: map[string]string{"season": "2026"}},
+			wantFiles: []string{"alpha.csv"},
+			wantTotal: 1,
+		},
+		{
+			name:      "tag with any value",
+			filter:    &entity.MatrixFilter{Tags: map[string]string{"season": ""}},
+			wantFiles: []string{"alpha.csv", "gamma.csv"},
+			wantTotal: 2,
+		},
+		{
+			name:      "every tag must match",
+			filter:    &entity.MatrixFilter{Tags: map[string]string{"season": "", "league": "premier"}},
+			wantFiles: []string{"alpha.csv"},
+			wantTotal: 1,
+		},
+		{
+			name:      "sorted by creation",
+			filter:    &entity.MatrixFilter{Sort: "created_at"},
+			wantFiles: []string{"alpha.csv", "broken.csv", "beta.csv", "gamma.csv"},
+			wantTotal: 4,
+		},
+		{
+			name:      "sorted by update, newest first",
+			filter:    &entity.MatrixFilter{Sort: "-updated_at"},
+			wantFiles: []string{"alpha.csv", "gamma.csv", "beta.csv", "broken.csv"},
+			wantTotal: 4,
+		},
+		{
+			name:      "page",
+			filter:    &entity.MatrixFilter{Sort: "-rows", Offset: 1, Limit: 2},
+			wantFiles: []string{"alpha.csv", "beta.csv"},
+			wantTotal: 4,
+		},
+		{
+			name:      "offset past the end",
+			filter:    &entity.MatrixFilter{Offset: 10},
+			wantFiles: []string{},
+			wantTotal: 4,
+		},
+		{name: "unknown sort field", filter: &entity.MatrixFilter{Sort: "owner"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "limit too high", filter: &entity.MatrixFilter{Limit: 1001}, wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := NewCatalogDomain().Search(ctx, tt.filter)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			names := make([]string, 0, len(page.Matrices))
+			for _, matrix := range page.Matrices {
+				names = append(names, matrix.Name)
+			}
+			assert.Equal(t, tt.wantFiles, names)
+			assert.Equal(t, tt.wantTotal, page.Total)
+		})
+	}
+
+	t.Run("metadata", func(t *testing.T) {
+		page, err := NewCatalogDomain().Search(ctx, &entity.MatrixFilter{Name: "alpha"})
+		assert.NoError(t, err)
+		if assert.Len(t, page.Matrices, 1) {
+			got := page.Matrices[0]
+			assert.Equal(t, "testdata/tenants/acme/alpha.csv", got.FilePath)
+			assert.Equal(t, 2, got.Rows)
+			assert.Equal(t, 2, got.Cols)
+			assert.Equal(t, int64(8), got.Size)
+			assert.True(t, created.Add(-time.Hour).Equal(got.CreatedAt))
+			assert.True(t, created.Add(3*time.Hour).Equal(got.UpdatedAt))
+			assert.Equal(t, map[string]string{"season": "2026", "league": "premier"}, got.Tags)
+		}
+		assert.Equal(t, defaultCatalogLimit, page.Limit)
+	})
+
+	t.Run("other tenants", func(t *testing.T) {
+		page, err := NewCatalogDomain().Search(tenant.WithID(context.Background(), "globex"), &entity.MatrixFilter{})
+		assert.NoError(t, err)
+		assert.Empty(t, page.Matrices)
+	})
+}
+
+func TestCatalogDomain_Export(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dir := "testdata/tenants/acme"
+	for _, name := range []string{"alpha.csv", "beta.csv", "gamma.csv"} {
+		storeMatrixFile(t, filepath.Join(dir, name))
+	}
+	ctx := tenant.WithID(context.Background(), "acme")
+	assert.NoError(t, repository.NewTagRepository().SetTags(ctx, filepath.Join(dir, "beta.csv"), map[string]string{"season": "2026"}))
+
+	t.Run("every file matched, regardless of the page", func(t *testing.T) {
+		var names []string
+		err := NewCatalogDomain().Export(ctx, &entity.MatrixFilter{Sort: "-name", Limit: 1}, func(matrix *entity.StoredMatrix, content []byte) error {
+			names = append(names, matrix.Name)
+			assert.Equal(t, "1,2\n3,4\n", string(content))
+			assert.Equal(t, int64(len(content)), matrix.Size)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"gamma.csv", "beta.csv", "alpha.csv"}, names)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		var got []*entity.StoredMatrix
+		err := NewCatalogDomain().Export(ctx, &entity.MatrixFilter{Tags: map[string]string{"season": ""}}, func(matrix *entity.StoredMatrix, _ []byte) error {
+			got = append(got, matrix)
+			return nil
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, "testdata/tenants/acme/beta.csv", got[0].FilePath)
+			assert.Equal(t, map[string]string{"season": "2026"}, got[0].Tags)
+		}
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		calls := 0
+		err := NewCatalogDomain().Export(ctx, &entity.MatrixFilter{}, func(*entity.StoredMatrix, []byte) error {
+			calls++
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		err := NewCatalogDomain().Export(ctx, &entity.MatrixFilter{Sort: "owner"}, func(*entity.StoredMatrix, []byte) error {
+			t.Fatal("no file expected")
+			return nil
+		})
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
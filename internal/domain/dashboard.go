@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+// dashboardRecentJobs is the number of jobs listed on the dashboard.
+const dashboardRecentJobs = 20
+
+// DashboardDomainInterface defines the business logic contract for the web dashboard,
+// which summarizes the caller's workspace on a single page.
+type DashboardDomainInterface interface {
+	// Summary returns the matrix files, operation usage and recent jobs of the tenant carried by ctx.
+	// Usage is counted over the latest 1000 audited requests of the tenant, or of every caller
+	// without multi-tenancy, and lists every supported operation, used or not, in alphabetical order.
+	Summary(ctx context.Context) (*entity.DashboardSummary, error)
+}
+
+type dashboardDomain struct {
+	matrixDomain MatrixDomainInterface
+	jobDomain    JobDomainInterface
+	auditDomain  AuditDomainInterface
+}
+
+// NewDashboardDomain creates a new instance of DashboardDomainInterface with all required dependencies.
+// It initializes the domain service with the matrix, job and audit domains the summary is gathered from.
+func NewDashboardDomain(matrixDomain MatrixDomainInterface, jobDomain JobDomainInterface, auditDomain AuditDomainInterface) DashboardDomainInterface {
+	return &dashboardDomain{
+		matrixDomain: matrixDomain,
+		jobDomain:    jobDomain,
+		auditDomain:  auditDomain,
+	}
+}
+
+func (d *dashboardDomain) Summary(ctx context.Context) (*entity.DashboardSummary, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	files, err := d.matrixDomain.ListFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := d.operationUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := d.jobDomain.ListRecentJobs(ctx, dashboardRecentJobs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.DashboardSummary{
+		Files:      files,
+		Operations: usage,
+		RecentJobs: jobs,
+	}, nil
+}
+
+// operationUsage counts the requests per supported operation in the latest audit log entries.
+// Entries naming an unsupported operation, such as a mistyped URL, are left out.
+func (d *dashboardDomain) operationUsage(ctx context.Context) ([]entity.OperationUsage, error) {
+	filter := &entity.AuditFilter{Event: entity.AuditRequest, Limit: maxAuditLimit}
+	if t, ok := tenant.FromContext(ctx); ok {
+		// Matches the actor the audit middleware records for tenants
+		filter.Actor = "tenant:" + t.ID
+	}
+
+	entries, err := d.auditDomain.ListEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := d.matrixDomain.ListOperations()
+	usage := make([]entity.OperationUsage, len(operations))
+	index := make(map[string]int, len(operations))
+	for i, operation := range operations {
+		usage[i].Operation = operation
+		index[operation] = i
+	}
+
+	for _, entry := range entries {
+		i, ok := index[entry.Operation]
+		if !ok {
+			continue
+		}
+		usage[i].Requests++
+		// Client and server errors both count as failures
+		if entry.Status >= 400 {
+			usage[i].Failures++
+		}
+	}
+	return usage, nil
+}
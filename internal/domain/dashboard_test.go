@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestDashboardDomain_Summary(t *testing.T) {
+	jobs := []*entity.Job{{ID: "job", Operation: "sum", Status: entity.JobSucceeded, CreatedAt: time.Now()}}
+	entries := []*entity.AuditEntry{
+		{Operation: "sum", Status: 200},
+		{Operation: "sum", Status: 422},
+		{Operation: "echo", Status: 200},
+		{Operation: "typo", Status: 400},
+		{Path: "/jobs", Status: 202},
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantActor string
+	}{
+		{name: "without a tenant", ctx: context.Background(), wantActor: ""},
+		{name: "tenant", ctx: tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"}), wantActor: "tenant:acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+			mockJobs := mocks.NewMockJobDomainInterface(t)
+			mockAudit := mocks.NewMockAuditDomainInterface(t)
+			mockMatrix.On("ListFiles", tt.ctx).Return([]string{"testdata/matrix1.csv"}, nil)
+			mockMatrix.On("ListOperations").Return([]string{"echo", "invert", "sum"})
+			mockJobs.On("ListRecentJobs", tt.ctx, dashboardRecentJobs).Return(jobs, nil)
+			mockAudit.On("ListEntries", tt.ctx, &entity.AuditFilter{
+				Actor: tt.wantActor,
+				Event: entity.AuditRequest,
+				Limit: maxAuditLimit,
+			}).Return(entries, nil)
+
+			got, err := NewDashboardDomain(mockMatrix, mockJobs, mockAudit).Summary(tt.ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, &entity.DashboardSummary{
+				Files: []string{"testdata/matrix1.csv"},
+				Operations: []entity.OperationUsage{
+					{Operation: "echo", Requests: 1},
+					{Operation: "invert"},
+					{Operation: "sum", Requests: 2, Failures: 1},
+				},
+				RecentJobs: jobs,
+			}, got)
+		})
+	}
+
+	t.Run("audit failure", func(t *testing.T) {
+		mockMatrix := mocks.NewMockMatrixDomainInterface(t)
+		mockAudit := mocks.NewMockAuditDomainInterface(t)
+		mockMatrix.On("ListFiles", mock.Anything).Return([]string{}, nil)
+		mockAudit.On("ListEntries", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: audit log unavailable", apperrors.ErrServiceUnavailable))
+
+		got, err := NewDashboardDomain(mockMatrix, mocks.NewMockJobDomainInterface(t), mockAudit).Summary(context.Background())
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.Nil(t, got)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		got, err := NewDashboardDomain(mocks.NewMockMatrixDomainInterface(t), mocks.NewMockJobDomainInterface(t),
+			mocks.NewMockAuditDomainInterface(t)).Summary(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// DrainDomainInterface defines the business logic contract for draining the server before it shuts down.
+// It keeps the drain state and counts the requests in flight, so both can be reported to load balancers.
+type DrainDomainInterface interface {
+	// StartDrain marks the server as draining. Calling it again keeps the time draining started.
+	StartDrain()
+
+	// Draining reports whether the server is draining.
+	Draining() bool
+
+	// TrackRequest counts a request as in flight until the returned function is called.
+	TrackRequest() func()
+
+	// Status reports the drain state and the number of requests in flight.
+	Status() *entity.DrainStatus
+}
+
+type drainDomain struct {
+	// now returns the current time; tests replace it to control the drain start
+	now func() time.Time
+
+	mu       sync.Mutex
+	since    time.Time
+	inFlight int
+}
+
+// NewDrainDomain creates a new instance of DrainDomainInterface.
+// It initializes the domain service as serving, with no request in flight.
+func NewDrainDomain() DrainDomainInterface {
+	return &drainDomain{
+		now: time.Now,
+	}
+}
+
+func (d *drainDomain) StartDrain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.since.IsZero() {
+		return
+	}
+	d.since = d.now()
+
+	slog.Info("draining server", "in_flight_requests", d.inFlight)
+}
+
+func (d *drainDomain) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return !d.since.IsZero()
+}
+
+func (d *drainDomain) TrackRequest() func() {
+	d.mu.Lock()
+	d.inFlight++
+	d.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			d.mu.Lock()
+			d.inFlight--
+			d.mu.Unlock()
+		})
+	}
+}
+
+func (d *drainDomain) Status() *entity.DrainStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return &entity.DrainStatus{
+		Draining:         !d.since.IsZero(),
+		Since:            d.since,
+		InFlightRequests: d.inFlight,
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+func TestDrainDomain(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewDrainDomain().(*drainDomain)
+	d.now = func() time.Time { return now }
+
+	first := d.TrackRequest()
+	second := d.TrackRequest()
+	assert.False(t, d.Draining())
+	assert.Equal(t, &entity.DrainStatus{InFlightRequests: 2}, d.Status())
+
+	d.StartDrain()
+	first()
+	// Finishing a request twice only counts once
+	first()
+	assert.True(t, d.Draining())
+	assert.Equal(t, &entity.DrainStatus{Draining: true, Since: now, InFlightRequests: 1}, d.Status())
+
+	// Draining again keeps the original start
+	now = now.Add(time.Minute)
+	d.StartDrain()
+	second()
+	assert.Equal(t, &entity.DrainStatus{Draining: true, Since: now.Add(-time.Minute)}, d.Status())
+}
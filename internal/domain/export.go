@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// bucketNamePattern matches the names S3 accepts for buckets, of export targets and of matrix files alike.
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// ExportConfig selects the storage targets results can be exported to, given as strings such as environment variables.
+// Dir is the local directory relative export paths are written below; without it, only object storage targets
+// are accepted. S3Endpoint and S3Region locate the object storage s3:// targets are written to, as described by
+// repository.S3Config, with the access keys kept in the S3AccessKeyIDSecret and S3SecretAccessKeySecret secrets.
+type ExportConfig struct {
+	Dir        string
+	S3Endpoint string
+	S3Region   string
+}
+
+// ExportDomainInterface defines the business logic contract for exporting operation results to storage targets
+// outside the matrix files, in addition to returning them.
+type ExportDomainInterface interface {
+	// ExportResult writes result as a CSV file to target: s3://bucket/key for object storage, or a relative path
+	// written below the export directory. Matrix results are written row by row, while scalar results are written
+	// as a single-cell matrix; an existing file or object is replaced. The exports of a tenant are written below
+	// a directory, or key prefix, named after its ID.
+	ExportResult(ctx context.Context, target string, result *entity.Result) error
+}
+
+type exportDomain struct {
+	// dirRepository writes relative export paths; nil when no export directory is configured
+	dirRepository repository.ExportRepositoryInterface
+	s3Repository  repository.ExportRepositoryInterface
+	secretDomain  SecretDomainInterface
+}
+
+// NewExportDomain creates a new instance of ExportDomainInterface with its dependencies.
+// It initializes the domain service with the storage targets described by config and the secret domain
+// the object storage access keys are read from on every export, so rotated keys take effect.
+func NewExportDomain(config ExportConfig, secretDomain SecretDomainInterface) ExportDomainInterface {
+	d := &exportDomain{secretDomain: secretDomain}
+	if config.Dir != "" {
+		d.dirRepository = repository.NewDirExportRepository(config.Dir)
+	}
+	d.s3Repository = repository.NewS3ExportRepository(repository.S3Config{
+		Endpoint:    config.S3Endpoint,
+		Region:      config.S3Region,
+		Credentials: d.s3Credentials,
+	})
+	return d
+}
+
+func (d *exportDomain) ExportResult(ctx context.Context, target string, result *entity.Result) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if result == nil {
+		return fmt.Errorf("%w: no result to export", apperrors.ErrUnprocessableEntity)
+	}
+
+	exportRepository, filePath, err := d.resolveTarget(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	err = exportRepository.WriteFile(ctx, filePath, resultContent(result))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to export result",
+			"target", target,
+			"error", err)
+		return err
+	}
+	return nil
+}
+
+// resolveTarget returns the repository an export target is written with and the path of the file within it.
+// Tenants export below their own prefix, so they cannot replace each other's exports.
+func (d *exportDomain) resolveTarget(ctx context.Context, target string) (repository.ExportRepositoryInterface, string, error) {
+	var prefix string
+	if id := tenant.ID(ctx); id != "" {
+		prefix = id + "/"
+	}
+
+	if target == "" {
+		return nil, "", fmt.Errorf("%w: export target is required", apperrors.ErrInvalidInput)
+	}
+
+	scheme, location, ok := strings.Cut(target, "://")
+	if !ok {
+		if d.dirRepository == nil {
+			return nil, "", fmt.Errorf("%w: exports to local paths are not configured", apperrors.ErrInvalidInput)
+		}
+		if err := validateExportPath(target); err != nil {
+			return nil, "", err
+		}
+		return d.dirRepository, prefix + target, nil
+	}
+
+	if scheme != repository.S3Scheme {
+		return nil, "", fmt.Errorf("%w: unsupported export target scheme %q: expected s3:// or a relative path",
+			apperrors.ErrInvalidInput, scheme)
+	}
+	bucket, key, _ := strings.Cut(location, "/")
+	if !bucketNamePattern.MatchString(bucket) {
+		return nil, "", fmt.Errorf("%w: invalid bucket name: %q", apperrors.ErrInvalidInput, bucket)
+	}
+	if err := validateExportPath(key); err != nil {
+		return nil, "", err
+	}
+	return d.s3Repository, bucket + "/" + prefix + key, nil
+}
+
+// validateExportPath checks that filePath is a clean relative path, so exports stay within their target.
+func validateExportPath(filePath string) error {
+	if filePath == "" || strings.HasSuffix(filePath, "/") {
+		return fmt.Errorf("%w: export target must name a file", apperrors.ErrInvalidInput)
+	}
+	if strings.Contains(filePath, "..") {
+		return fmt.Errorf("%w: path traversal not allowed", apperrors.ErrInvalidInput)
+	}
+	if path.IsAbs(filePath) || strings.Contains(filePath, `\`) || path.Clean(filePath) != filePath {
+		return fmt.Errorf("%w: export target must be a clean relative path: %q", apperrors.ErrInvalidInput, filePath)
+	}
+	return nil
+}
+
+// s3Credentials reads the object storage access keys from the secrets.
+func (d *exportDomain) s3Credentials(ctx context.Context) (repository.S3Credentials, error) {
+	return readS3Credentials(ctx, d.secretDomain, "exports to object storage")
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestExportDomain_ExportResult(t *testing.T) {
+	matrixResult := &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}}
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		target      string
+		wantS3      bool
+		wantPath    string
+		wantContent [][]string
+		errType     error
+	}{
+		{name: "local path", ctx: context.Background(), target: "daily/echo.csv",
+			wantPath: "daily/echo.csv", wantContent: [][]string{{"1", "2"}, {"3", "4"}}},
+		{name: "object storage", ctx: context.Background(), target: "s3://results/daily/echo.csv", wantS3: true,
+			wantPath: "results/daily/echo.csv", wantContent: [][]string{{"1", "2"}, {"3", "4"}}},
+		{name: "local path of a tenant", ctx: acme, target: "echo.csv",
+			wantPath: "acme/echo.csv", wantContent: [][]string{{"1", "2"}, {"3", "4"}}},
+		{name: "object storage of a tenant", ctx: acme, target: "s3://results/echo.csv", wantS3: true,
+			wantPath: "results/acme/echo.csv", wantContent: [][]string{{"1", "2"}, {"3", "4"}}},
+		{name: "path traversal", ctx: context.Background(), target: "../echo.csv", errType: apperrors.ErrInvalidInput},
+		{name: "absolute path", ctx: context.Background(), target: "/etc/echo.csv", errType: apperrors.ErrInvalidInput},
+		{name: "unclean path", ctx: context.Background(), target: "daily//echo.csv", errType: apperrors.ErrInvalidInput},
+		{name: "directory", ctx: context.Background(), target: "daily/", errType: apperrors.ErrInvalidInput},
+		{name: "unsupported scheme", ctx: context.Background(), target: "gs://results/echo.csv", errType: apperrors.ErrInvalidInput},
+		{name: "invalid bucket", ctx: context.Background(), target: "s3://Results/echo.csv", errType: apperrors.ErrInvalidInput},
+		{name: "missing key", ctx: context.Background(), target: "s3://results", errType: apperrors.ErrInvalidInput},
+		{name: "missing target", ctx: context.Background(), target: "", errType: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dirRepository := mocks.NewMockExportRepositoryInterface(t)
+			s3Repository := mocks.NewMockExportRepositoryInterface(t)
+			if tt.errType == nil {
+				repo := dirRepository
+				if tt.wantS3 {
+					repo = s3Repository
+				}
+				repo.On("WriteFile", mock.Anything, tt.wantPath, &repository.MatrixFileContent{Content: tt.wantContent}).Return(nil)
+			}
+
+			domain := &exportDomain{dirRepository: dirRepository, s3Repository: s3Repository}
+			err := domain.ExportResult(tt.ctx, tt.target, matrixResult)
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExportDomain_ExportResult_Errors(t *testing.T) {
+	t.Run("local paths without an export directory", func(t *testing.T) {
+		domain := &exportDomain{s3Repository: mocks.NewMockExportRepositoryInterface(t)}
+		err := domain.ExportResult(context.Background(), "echo.csv", &entity.Result{Scalar: "10"})
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("no result", func(t *testing.T) {
+		domain := &exportDomain{}
+		err := domain.ExportResult(context.Background(), "echo.csv", nil)
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	})
+
+	t.Run("failed write", func(t *testing.T) {
+		s3Repository := mocks.NewMockExportRepositoryInterface(t)
+		s3Repository.On("WriteFile", mock.Anything, "results/sum.csv", &repository.MatrixFileContent{Content: [][]string{{"10"}}}).
+			Return(apperrors.ErrServiceUnavailable)
+
+		domain := &exportDomain{s3Repository: s3Repository}
+		err := domain.ExportResult(context.Background(), "s3://results/sum.csv", &entity.Result{Scalar: "10"})
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := (&exportDomain{}).ExportResult(ctx, "echo.csv", &entity.Result{Scalar: "10"})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestNewExportDomain(t *testing.T) {
+	t.Run("local directory", func(t *testing.T) {
+		dir := t.TempDir()
+		domain := NewExportDomain(ExportConfig{Dir: dir}, mocks.NewMockSecretDomainInterface(t))
+
+		err := domain.ExportResult(context.Background(), "daily/sum.csv", &entity.Result{Scalar: "45"})
+		assert.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(dir, "daily", "sum.csv"))
+		assert.NoError(t, err)
+		assert.Equal(t, "45\n", string(data))
+	})
+
+	t.Run("object storage with the secret access keys", func(t *testing.T) {
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+		}))
+		defer server.Close()
+
+		secrets := mocks.NewMockSecretDomainInterface(t)
+		secrets.On("Secret", mock.Anything, S3AccessKeyIDSecret).Return("AKIDEXAMPLE", nil)
+		secrets.On("Secret", mock.Anything, S3SecretAccessKeySecret).Return("secret", nil)
+
+		domain := NewExportDomain(ExportConfig{S3Endpoint: server.URL, S3Region: "eu-west-1"}, secrets)
+		err := domain.ExportResult(context.Background(), "s3://results/sum.csv", &entity.Result{Scalar: "45"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, authorization, "Credential=AKIDEXAMPLE/")
+	})
+
+	t.Run("object storage without access keys", func(t *testing.T) {
+		secrets := mocks.NewMockSecretDomainInterface(t)
+		secrets.On("Secret", mock.Anything, mock.Anything).Return("", nil)
+
+		domain := NewExportDomain(ExportConfig{}, secrets)
+		err := domain.ExportResult(context.Background(), "s3://results/sum.csv", &entity.Result{Scalar: "45"})
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
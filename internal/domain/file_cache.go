@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/matsuboshi/league-matrix-app/internal/cache"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+// fileKey identifies a matrix file as seen by a tenant, whose paths resolve in its own data directory.
+type fileKey struct {
+	tenantID string
+	path     string
+}
+
+// fileVersion is the hash of the content of a matrix file, as of the modification time and size it was read at.
+type fileVersion struct {
+	stat repository.FileStat
+	hash string
+}
+
+// fileResultKey identifies a result by the hash of the content of the file the operation ran on,
+// by the selection and parsing options the content was read with and by the precision it was rounded to.
+type fileResultKey struct {
+	hash      string
+	operation string
+	selection string
+	options   parsing.Options
+	precision int
+}
+
+// fileRef is a matrix file to look up in the file cache, as of the stat taken before it is read.
+type fileRef struct {
+	key  fileKey
+	stat repository.FileStat
+}
+
+// fileCache remembers the results of operations on matrix files by the hash of their content, so repeating
+// an operation on an unchanged file returns without reading and parsing it again. A file is unchanged while
+// its modification time and size are those it was hashed at. A nil cache remembers nothing.
+type fileCache struct {
+	versions *cache.Cache[fileKey, fileVersion]
+	results  *cache.Cache[fileResultKey, *entity.Result]
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+}
+
+// newFileCache creates a file cache bounded by config, or nil when config disables it.
+func newFileCache(config MemoConfig) *fileCache {
+	if config.MaxEntries <= 0 {
+		return nil
+	}
+	return &fileCache{
+		versions: cache.New[fileKey, fileVersion](config.cacheConfig()),
+		results:  cache.New[fileResultKey, *entity.Result](config.cacheConfig()),
+	}
+}
+
+// ref returns the reference of the matrix file at filePath with stat, or nil when the cache is disabled
+// or stat cannot tell changes apart because the modification time or size is unknown.
+func (c *fileCache) ref(ctx context.Context, filePath string, stat repository.FileStat) *fileRef {
+	if c == nil || stat.ModTime.IsZero() || stat.Size < 0 {
+		return nil
+	}
+	return &fileRef{key: fileKey{tenantID: tenant.ID(ctx), path: filePath}, stat: stat}
+}
+
+// get returns a copy of the result remembered for running operation on the file of ref, as long as the file
+// has not changed since it was hashed. A changed file is forgotten, with all its results.
+func (c *fileCache) get(ctx context.Context, ref *fileRef, operation string, selection *entity.Selection) (*entity.Result, bool) {
+	if c == nil || ref == nil {
+		return nil, false
+	}
+
+	version, ok := c.versions.Get(ref.key)
+	if ok && (!version.stat.ModTime.Equal(ref.stat.ModTime) || version.stat.Size != ref.stat.Size) {
+		slog.DebugContext(ctx, "cached file changed",
+			"file_path", ref.key.path)
+		c.versions.Remove(ref.key)
+		ok = false
+	}
+	var result *entity.Result
+	if ok {
+		result, ok = c.results.Get(newFileResultKey(ctx, version.hash, operation, selection))
+	}
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	cached := copyResult(result)
+	cached.Cached = true
+	return cached, true
+}
+
+// put remembers a copy of result for running operation on the file of ref, whose content hashed to hash.
+func (c *fileCache) put(ctx context.Context, ref *fileRef, hash string, operation string, selection *entity.Selection,
+	result *entity.Result) {
+	if c == nil || ref == nil {
+		return
+	}
+
+	c.versions.Put(ref.key, fileVersion{stat: ref.stat, hash: hash})
+	c.results.Put(newFileResultKey(ctx, hash, operation, selection), copyResult(result))
+}
+
+// flush forgets every file and remembered result and returns how many results it forgot.
+func (c *fileCache) flush() int {
+	if c == nil {
+		return 0
+	}
+	c.versions.Clear()
+	return c.results.Clear()
+}
+
+// invalidate forgets the matrix file at filePath, as seen by the tenant carried by ctx, with the results of every
+// operation on its content, and returns how many results it forgot.
+func (c *fileCache) invalidate(ctx context.Context, filePath string) int {
+	if c == nil {
+		return 0
+	}
+
+	key := fileKey{tenantID: tenant.ID(ctx), path: filePath}
+	version, ok := c.versions.Get(key)
+	if !ok {
+		return 0
+	}
+	c.versions.Remove(key)
+	return c.results.RemoveFunc(func(key fileResultKey) bool {
+		return key.hash == version.hash
+	})
+}
+
+// metrics returns the lookups of the cache by outcome, the results it holds and its hit ratio.
+// A disabled cache reports no metrics.
+func (c *fileCache) metrics() []*entity.Metric {
+	if c == nil {
+		return nil
+	}
+	return cacheMetrics("matrix.cache", "file operation results", cache.Stats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: c.results.Len(),
+	})
+}
+
+// newFileResultKey returns the key of the result of running operation on the selection of the content
+// hashed to hash, read with the parsing options and rounded to the precision carried by ctx.
+func newFileResultKey(ctx context.Context, hash string, operation string, selection *entity.Selection) fileResultKey {
+	key := fileResultKey{
+		hash:      hash,
+		operation: operation,
+		options:   parsing.FromContext(ctx),
+		precision: precisionFromContext(ctx),
+	}
+	if !selection.IsEmpty() {
+		key.selection = fmt.Sprintf("%v;%v", selection.Rows, selection.Cols)
+	}
+	return key
+}
+
+// hashStream wraps stream to hash the raw records it produces, before they are parsed. The returned function
+// gives the hex-encoded SHA-256 of the records read once stream has completed.
+func hashStream(stream rowStream) (rowStream, func() string) {
+	hash := sha256.New()
+	var buf []byte
+	hashed := func(ctx context.Context, handleRow repository.RowHandler) error {
+		return stream(ctx, func(row []string) error {
+			// Each field is length-prefixed, so records split differently never hash alike
+			buf = buf[:0]
+			for _, field := range row {
+				buf = fmt.Appendf(buf, "%d:%s,", len(field), field)
+			}
+			buf = append(buf, '\n')
+			hash.Write(buf)
+			return handleRow(row)
+		})
+	}
+	return hashed, func() string {
+		return hex.EncodeToString(hash.Sum(nil))
+	}
+}
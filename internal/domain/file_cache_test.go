@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+func TestMatrixDomain_ProcessMatrix_FileCache(t *testing.T) {
+	content := []byte("1,2\n3,4\n")
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var downloads atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			downloads.Add(1)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		http.ServeContent(w, r, "league.csv", modTime, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	d := NewMatrixDomain(DefaultStreamLimits, DefaultMemoConfig, FileSourcesConfig{URLHosts: []string{"127.0.0.1"}})
+	filePath := server.URL + "/league.csv"
+	process := func(ctx context.Context, operation string, selection *entity.Selection) *entity.Result {
+		t.Helper()
+		got, err := d.ProcessMatrix(ctx, operation, filePath, selection)
+		assert.NoError(t, err)
+		return got
+	}
+
+	got := process(context.Background(), "sum", nil)
+	assert.Equal(t, "10", got.String())
+	assert.False(t, got.Cached)
+	assert.Equal(t, int64(1), downloads.Load())
+
+	// The same operation on the unchanged file is answered without downloading it again
+	got = process(context.Background(), "sum", nil)
+	assert.Equal(t, "10", got.String())
+	assert.True(t, got.Cached)
+	assert.Equal(t, 2, got.Input.Rows)
+	assert.Equal(t, int64(1), downloads.Load())
+
+	// Other operations, selections, parsing options and tenants are cached apart
+	got = process(context.Background(), "transpose", nil)
+	assert.Equal(t, "1,3\n2,4", got.String())
+	assert.False(t, got.Cached)
+	got = process(context.Background(), "sum", &entity.Selection{Rows: []entity.IndexRange{{Start: 0, End: 0}}})
+	assert.Equal(t, "3", got.String())
+	assert.False(t, got.Cached)
+	got = process(parsing.NewContext(context.Background(), parsing.Options{Lenient: true}), "sum", nil)
+	assert.False(t, got.Cached)
+	got = process(tenant.WithID(context.Background(), "acme"), "sum", nil)
+	assert.False(t, got.Cached)
+	assert.Equal(t, int64(5), downloads.Load())
+
+	// A changed modification time invalidates the results of the file
+	modTime = modTime.Add(time.Second)
+	content = []byte("5,6\n7,8\n")
+	got = process(context.Background(), "sum", nil)
+	assert.Equal(t, "26", got.String())
+	assert.False(t, got.Cached)
+	assert.Equal(t, int64(6), downloads.Load())
+	got = process(context.Background(), "sum", nil)
+	assert.True(t, got.Cached)
+
+	// A changed size invalidates them too, even within the same second
+	content = []byte("5,6\n7,80\n")
+	got = process(context.Background(), "sum", nil)
+	assert.Equal(t, "98", got.String())
+	assert.False(t, got.Cached)
+
+	metrics := d.CacheMetrics()
+	assert.Equal(t, []string{"matrix.cache.entries", "matrix.cache.hit_ratio", "matrix.cache.lookups"},
+		[]string{metrics[0].Name, metrics[1].Name, metrics[2].Name})
+	assert.Equal(t, 2.0, metrics[2].Points[0].Value)
+	assert.Equal(t, 7.0, metrics[2].Points[1].Value)
+}
+
+func TestMatrixDomain_InvalidateCache(t *testing.T) {
+	content := []byte("1,2\n3,4\n")
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		http.ServeContent(w, r, "league.csv", modTime, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	d := NewMatrixDomain(DefaultStreamLimits, DefaultMemoConfig, FileSourcesConfig{URLHosts: []string{"127.0.0.1"}})
+	filePath := server.URL + "/league.csv"
+	process := func(ctx context.Context) *entity.Result {
+		t.Helper()
+		got, err := d.ProcessMatrix(ctx, "sum", filePath, nil)
+		assert.NoError(t, err)
+		return got
+	}
+
+	process(context.Background())
+	process(tenant.WithID(context.Background(), "acme"))
+	assert.True(t, process(context.Background()).Cached)
+
+	// The results of the file are forgotten for the tenant invalidating it only
+	assert.Equal(t, 0, d.InvalidateCache(context.Background(), server.URL+"/other.csv"))
+	assert.Equal(t, 1, d.InvalidateCache(context.Background(), filePath))
+	assert.False(t, process(context.Background()).Cached)
+	assert.True(t, process(tenant.WithID(context.Background(), "acme")).Cached)
+
+	// Flushing forgets every result of every tenant
+	assert.Positive(t, d.FlushCache())
+	assert.False(t, process(tenant.WithID(context.Background(), "acme")).Cached)
+}
+
+func TestMatrixDomain_ProcessMatrix_FileCacheDisabled(t *testing.T) {
+	d := NewMatrixDomain(DefaultStreamLimits, MemoConfig{}, FileSourcesConfig{})
+	assert.Nil(t, d.CacheMetrics())
+	assert.Equal(t, 0, d.FlushCache())
+	assert.Equal(t, 0, d.InvalidateCache(context.Background(), "testdata/matrix1.csv"))
+}
+
+func TestFileCache_Ref(t *testing.T) {
+	files := newFileCache(DefaultMemoConfig)
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ref := files.ref(tenant.WithID(context.Background(), "acme"), "testdata/a.csv", repository.FileStat{ModTime: modTime, Size: 8})
+	if assert.NotNil(t, ref) {
+		assert.Equal(t, fileKey{tenantID: "acme", path: "testdata/a.csv"}, ref.key)
+	}
+
+	// Files whose changes cannot be told apart are never cached
+	assert.Nil(t, files.ref(context.Background(), "testdata/a.csv", repository.FileStat{Size: 8}))
+	assert.Nil(t, files.ref(context.Background(), "testdata/a.csv", repository.FileStat{ModTime: modTime, Size: -1}))
+	assert.Nil(t, newFileCache(MemoConfig{}).ref(context.Background(), "testdata/a.csv", repository.FileStat{ModTime: modTime, Size: 8}))
+}
+
+func TestHashStream(t *testing.T) {
+	hashOf := func(rows ...[]string) string {
+		stream, hash := hashStream(func(_ context.Context, handleRow repository.RowHandler) error {
+			for _, row := range rows {
+				if err := handleRow(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		var read int
+		assert.NoError(t, stream(context.Background(), func([]string) error {
+			read++
+			return nil
+		}))
+		// Rows are passed on unchanged
+		assert.Equal(t, len(rows), read)
+		return hash()
+	}
+
+	assert.Equal(t, hashOf([]string{"1", "2"}), hashOf([]string{"1", "2"}))
+	assert.NotEqual(t, hashOf([]string{"1", "2"}), hashOf([]string{"1,2"}))
+	assert.NotEqual(t, hashOf([]string{"1", "2"}), hashOf([]string{"1"}, []string{"2"}))
+	assert.NotEqual(t, hashOf([]string{"1", "2"}), hashOf([]string{" 1", "2"}))
+}
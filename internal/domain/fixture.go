@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// maxFixtureCells bounds the values of a generated matrix, so a mistyped size cannot fill the disk.
+const maxFixtureCells = 1_000_000
+
+// badCells are the values a bad-cell fixture holds in place of a number, one of each kind the validator rejects.
+// The empty value comes first, as it is left out for matrices of a single column.
+var badCells = []string{"", "abc", "12abc", "1.2.3", "0x1F", "1_000", "9223372036854775808"}
+
+// FixtureDomainInterface defines the business logic contract for generating test matrices,
+// valid or with deliberate defects, to exercise the service in tests and demos.
+type FixtureDomainInterface interface {
+	// GenerateFixtures writes the matrices described by spec as CSV files into spec.Dir and returns them
+	// in the order they were written. Files are named after their defect and dimensions, such as
+	// matrix-3x3.csv for a valid matrix and ragged-3x3.csv. Each file only depends on the seed and its name,
+	// so adding sizes or defects leaves the other files unchanged. Existing files are never overwritten:
+	// generating a file that already exists fails with ErrConflict.
+	GenerateFixtures(ctx context.Context, spec *entity.FixtureSpec) ([]*entity.Fixture, error)
+}
+
+type fixtureDomain struct {
+	matrixRepository repository.MatrixRepositoryInterface
+}
+
+// NewFixtureDomain creates a new instance of FixtureDomainInterface with its dependencies.
+// It initializes the domain service with the matrix repository the fixtures are saved through.
+func NewFixtureDomain() FixtureDomainInterface {
+	return &fixtureDomain{
+		matrixRepository: repository.NewMatrixRepository(),
+	}
+}
+
+func (d *fixtureDomain) GenerateFixtures(ctx context.Context, spec *entity.FixtureSpec) ([]*entity.Fixture, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateFixtureSpec(spec); err != nil {
+		return nil, err
+	}
+
+	var fixtures []*entity.Fixture
+	written := make(map[string]bool)
+	for _, size := range spec.Sizes {
+		for _, defect := range slices.Concat([]entity.FixtureDefect{""}, spec.Defects) {
+			fixture := &entity.Fixture{Rows: size.Rows, Cols: size.Cols, Defect: defect}
+			switch defect {
+			case entity.FixtureRagged:
+				// The first row sets the expected length, so a ragged matrix needs a second one
+				fixture.Rows = max(size.Rows, 2)
+			case entity.FixtureOversize:
+				fixture.Rows = max(size.Rows, matrixlib.MaxRows) + 1
+			}
+
+			name := fmt.Sprintf("%s-%dx%d.csv", cmp.Or(string(defect), "matrix"), fixture.Rows, fixture.Cols)
+			// Defects that change the dimensions of different sizes may give the same file
+			if written[name] {
+				continue
+			}
+			written[name] = true
+			fixture.Path = filepath.Join(spec.Dir, name)
+
+			content := generateFixture(fixture, spec, newFixtureRandom(spec.Seed, name))
+			if err := d.matrixRepository.SaveFileContent(ctx, fixture.Path, content); err != nil {
+				return fixtures, fmt.Errorf("failed to write fixture %s: %w", fixture.Path, err)
+			}
+			fixtures = append(fixtures, fixture)
+		}
+	}
+	return fixtures, nil
+}
+
+// validateFixtureSpec checks that spec describes at least one matrix that can be generated.
+func validateFixtureSpec(spec *entity.FixtureSpec) error {
+	if spec.Dir == "" {
+		return fmt.Errorf("%w: fixture directory is required", apperrors.ErrInvalidInput)
+	}
+	if len(spec.Sizes) == 0 {
+		return fmt.Errorf("%w: at least one fixture size is required", apperrors.ErrInvalidInput)
+	}
+	for _, size := range spec.Sizes {
+		if size.Rows < 1 || size.Cols < 1 || size.Rows > maxFixtureCells/size.Cols {
+			return fmt.Errorf("%w: invalid fixture size %dx%d: must hold between 1 and %d values",
+				apperrors.ErrInvalidInput, size.Rows, size.Cols, maxFixtureCells)
+		}
+	}
+	if spec.Min > spec.Max {
+		return fmt.Errorf("%w: invalid value range: minimum %d is greater than maximum %d",
+			apperrors.ErrInvalidInput, spec.Min, spec.Max)
+	}
+	for _, defect := range spec.Defects {
+		if !slices.Contains(entity.FixtureDefects, defect) {
+			return fmt.Errorf("%w: unknown fixture defect %q: expected one of %v",
+				apperrors.ErrInvalidInput, defect, entity.FixtureDefects)
+		}
+	}
+	return nil
+}
+
+// newFixtureRandom returns the random source of the fixture named name, derived from seed.
+func newFixtureRandom(seed uint64, name string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return rand.New(rand.NewPCG(seed, h.Sum64()))
+}
+
+// generateFixture builds the content of fixture, a matrix of random values between the bounds of spec
+// carrying the defect of fixture.
+func generateFixture(fixture *entity.Fixture, spec *entity.FixtureSpec, random *rand.Rand) *repository.MatrixFileContent {
+	content := make([][]string, fixture.Rows)
+	for i := range content {
+		content[i] = make([]string, fixture.Cols)
+		for j := range content[i] {
+			content[i][j] = strconv.FormatInt(randomValue(random, spec.Min, spec.Max), 10)
+		}
+	}
+
+	switch fixture.Defect {
+	case entity.FixtureRagged:
+		// The first row sets the expected length, so a later row is changed
+		i := 1 + random.IntN(fixture.Rows-1)
+		if fixture.Cols > 1 {
+			content[i] = content[i][:fixture.Cols-1]
+		} else {
+			content[i] = append(content[i], strconv.FormatInt(randomValue(random, spec.Min, spec.Max), 10))
+		}
+	case entity.FixtureBadCell:
+		// A row holding a single empty value is a blank line, which CSV readers skip rather than reject
+		candidates := badCells
+		if fixture.Cols == 1 {
+			candidates = badCells[1:]
+		}
+		content[random.IntN(fixture.Rows)][random.IntN(fixture.Cols)] = candidates[random.IntN(len(candidates))]
+	}
+	return &repository.MatrixFileContent{Content: content}
+}
+
+// randomValue returns a value between lo and hi inclusive. The span is computed on unsigned integers,
+// so it does not overflow for the full int64 range.
+func randomValue(random *rand.Rand, lo, hi int64) int64 {
+	span := uint64(hi-lo) + 1
+	if span == 0 {
+		return int64(random.Uint64())
+	}
+	return lo + int64(random.Uint64N(span))
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+func TestFixtureDomain_GenerateFixtures(t *testing.T) {
+	dir := t.TempDir()
+	domain := NewFixtureDomain()
+
+	got, err := domain.GenerateFixtures(context.Background(), &entity.FixtureSpec{
+		Dir:     dir,
+		Sizes:   []entity.FixtureSize{{Rows: 1, Cols: 1}, {Rows: 3, Cols: 4}, {Rows: 2, Cols: 4}},
+		Min:     -5,
+		Max:     5,
+		Defects: entity.FixtureDefects,
+		Seed:    7,
+	})
+
+	assert.NoError(t, err)
+	var names []string
+	for _, fixture := range got {
+		names = append(names, filepath.Base(fixture.Path))
+	}
+	// Oversized matrices of sizes with the same columns are generated once
+	assert.Equal(t, []string{
+		"matrix-1x1.csv", "ragged-2x1.csv", "bad-cell-1x1.csv", "oversize-11x1.csv",
+		"matrix-3x4.csv", "ragged-3x4.csv", "bad-cell-3x4.csv", "oversize-11x4.csv",
+		"matrix-2x4.csv", "ragged-2x4.csv", "bad-cell-2x4.csv",
+	}, names)
+
+	repo := repository.NewMatrixRepository()
+	validator := NewMatrixValidatorDomain()
+	for _, fixture := range got {
+		t.Run(filepath.Base(fixture.Path), func(t *testing.T) {
+			// Ragged rows are already rejected when the file is read
+			content, err := repo.GetFileContent(context.Background(), fixture.Path)
+			if fixture.Defect == entity.FixtureRagged {
+				assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			matrix, err := validator.Validate(context.Background(), content)
+			if fixture.Defect != "" {
+				// Bad cells may hold a value out of range, rejected as an overflow
+				assert.Equal(t, http.StatusUnprocessableEntity, apperrors.GetHTTPStatusCode(err), err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, fixture.Rows, matrix.Rows())
+				assert.Equal(t, fixture.Cols, matrix.Cols())
+				for _, row := range matrix.Data {
+					for _, val := range row {
+						assert.True(t, val >= -5 && val <= 5, "%d is out of range", val)
+					}
+				}
+			}
+		})
+	}
+
+	oversize, err := repo.GetFileContent(context.Background(), filepath.Join(dir, "oversize-11x4.csv"))
+	if assert.NoError(t, err) {
+		assert.Len(t, oversize.Content, matrixlib.MaxRows+1)
+	}
+}
+
+func TestFixtureDomain_GenerateFixtures_Reproducible(t *testing.T) {
+	domain := NewFixtureDomain()
+	generate := func(seed uint64, sizes ...entity.FixtureSize) string {
+		dir := t.TempDir()
+		_, err := domain.GenerateFixtures(context.Background(), &entity.FixtureSpec{
+			Dir: dir, Sizes: sizes, Min: math.MinInt64, Max: math.MaxInt64, Seed: seed,
+		})
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "matrix-3x3.csv"))
+		assert.NoError(t, err)
+		return string(content)
+	}
+
+	first := generate(1, entity.FixtureSize{Rows: 3, Cols: 3})
+	// A file only depends on the seed and its name, whatever else is generated
+	assert.Equal(t, first, generate(1, entity.FixtureSize{Rows: 2, Cols: 2}, entity.FixtureSize{Rows: 3, Cols: 3}))
+	assert.NotEqual(t, first, generate(2, entity.FixtureSize{Rows: 3, Cols: 3}))
+}
+
+func TestFixtureDomain_GenerateFixtures_Errors(t *testing.T) {
+	sizes := []entity.FixtureSize{{Rows: 2, Cols: 2}}
+
+	tests := []struct {
+		name    string
+		spec    *entity.FixtureSpec
+		wantErr string
+	}{
+		{name: "no directory", spec: &entity.FixtureSpec{Sizes: sizes}, wantErr: "fixture directory is required"},
+		{name: "no size", spec: &entity.FixtureSpec{Dir: "fixtures"}, wantErr: "at least one fixture size is required"},
+		{name: "empty size", spec: &entity.FixtureSpec{Dir: "fixtures", Sizes: []entity.FixtureSize{{Rows: 0, Cols: 2}}}, wantErr: "invalid fixture size 0x2"},
+		{name: "too many values", spec: &entity.FixtureSpec{Dir: "fixtures", Sizes: []entity.FixtureSize{{Rows: 1001, Cols: 1000}}}, wantErr: "invalid fixture size 1001x1000"},
+		{name: "inverted range", spec: &entity.FixtureSpec{Dir: "fixtures", Sizes: sizes, Min: 1}, wantErr: "minimum 1 is greater than maximum 0"},
+		{name: "unknown defect", spec: &entity.FixtureSpec{Dir: "fixtures", Sizes: sizes, Defects: []entity.FixtureDefect{"typo"}}, wantErr: `unknown fixture defect "typo"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFixtureDomain().GenerateFixtures(context.Background(), tt.spec)
+
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Empty(t, got)
+		})
+	}
+
+	t.Run("existing files are not overwritten", func(t *testing.T) {
+		dir := t.TempDir()
+		existing := filepath.Join(dir, "matrix-2x2.csv")
+		assert.NoError(t, os.WriteFile(existing, []byte("1,2\n3,4\n"), 0o600))
+
+		got, err := NewFixtureDomain().GenerateFixtures(context.Background(), &entity.FixtureSpec{Dir: dir, Sizes: sizes, Max: 9})
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Empty(t, got)
+		content, _ := os.ReadFile(existing)
+		assert.Equal(t, "1,2\n3,4\n", string(content))
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := NewFixtureDomain().GenerateFixtures(ctx, &entity.FixtureSpec{Dir: t.TempDir(), Sizes: sizes})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRandomValue(t *testing.T) {
+	random := newFixtureRandom(1, "values")
+	for range 1000 {
+		assert.Equal(t, int64(7), randomValue(random, 7, 7))
+		val := randomValue(random, -3, 3)
+		assert.True(t, val >= -3 && val <= 3, strconv.FormatInt(val, 10))
+	}
+	// The full range does not overflow
+	assert.NotPanics(t, func() { randomValue(random, math.MinInt64, math.MaxInt64) })
+}
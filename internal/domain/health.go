@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// healthCheckTimeout bounds each component check, so a hanging backend reports as down instead of
+// holding up the health endpoint past the probe timeout of the orchestrator.
+const healthCheckTimeout = 2 * time.Second
+
+// HealthDomainInterface defines the business logic contract for the detailed health of the service.
+// Components, such as the data directory, the job store or a remote backend, register a check,
+// and every check runs each time the health is reported.
+type HealthDomainInterface interface {
+	// Register adds the check of a component under name. The check reports whether the component works,
+	// returning the reason when it does not. Components are reported in the order they were registered.
+	Register(name string, check func(ctx context.Context) error)
+
+	// Check runs every component check concurrently, each within 2 seconds, and reports the outcome
+	// together with the uptime of the service and the last error of each component.
+	Check(ctx context.Context) (*entity.HealthReport, error)
+}
+
+// healthComponent is a registered component and the last failure of its check.
+type healthComponent struct {
+	name        string
+	check       func(ctx context.Context) error
+	lastError   string
+	lastErrorAt time.Time
+}
+
+type healthDomain struct {
+	startedAt time.Time
+	// timeout bounds each component check
+	timeout time.Duration
+	// now returns the current time; tests replace it to control the uptime
+	now func() time.Time
+
+	mu         sync.Mutex
+	components []*healthComponent
+}
+
+// NewHealthDomain creates a new instance of HealthDomainInterface.
+// It initializes the domain service without components, counting the uptime from now.
+func NewHealthDomain() HealthDomainInterface {
+	return &healthDomain{
+		startedAt: time.Now(),
+		timeout:   healthCheckTimeout,
+		now:       time.Now,
+	}
+}
+
+func (d *healthDomain) Register(name string, check func(ctx context.Context) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.components = append(d.components, &healthComponent{name: name, check: check})
+}
+
+func (d *healthDomain) Check(ctx context.Context) (*entity.HealthReport, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	components := append([]*healthComponent(nil), d.components...)
+	d.mu.Unlock()
+
+	results := make([]*entity.ComponentHealth, len(components))
+	var wg sync.WaitGroup
+	for i, component := range components {
+		wg.Go(func() {
+			results[i] = d.checkComponent(ctx, component)
+		})
+	}
+	wg.Wait()
+
+	// A client that went away leaves no meaningful report
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &entity.HealthReport{
+		Status:     entity.HealthUp,
+		Uptime:     d.now().Sub(d.startedAt),
+		Components: results,
+	}
+	for _, result := range results {
+		if result.Status != entity.HealthUp {
+			report.Status = entity.HealthDown
+		}
+	}
+	return report, nil
+}
+
+// checkComponent runs the check of component within the check timeout and records its failure, if any.
+func (d *healthDomain) checkComponent(ctx context.Context, component *healthComponent) *entity.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	start := d.now()
+	err := component.check(ctx)
+	result := &entity.ComponentHealth{
+		Name:     component.name,
+		Status:   entity.HealthUp,
+		Duration: d.now().Sub(start),
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("health check timed out after %s", d.timeout)
+		}
+		slog.WarnContext(ctx, "health check failed",
+			"component", component.name,
+			"error", err)
+		result.Status = entity.HealthDown
+		result.Error = err.Error()
+		component.lastError = err.Error()
+		component.lastErrorAt = start
+	}
+	result.LastError = component.lastError
+	result.LastErrorAt = component.lastErrorAt
+	return result
+}
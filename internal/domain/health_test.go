@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newTestHealthDomain builds a health domain whose clock only moves when the test moves it.
+func newTestHealthDomain(now *time.Time) *healthDomain {
+	d := NewHealthDomain().(*healthDomain)
+	d.startedAt = *now
+	d.now = func() time.Time { return *now }
+	return d
+}
+
+func TestHealthDomain_Check(t *testing.T) {
+	t.Run("every component up", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		d := newTestHealthDomain(&now)
+		d.Register("data_directory", func(ctx context.Context) error { return nil })
+		d.Register("job_store", func(ctx context.Context) error { return nil })
+		now = now.Add(time.Hour)
+
+		got, err := d.Check(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.HealthReport{
+			Status: entity.HealthUp,
+			Uptime: time.Hour,
+			Components: []*entity.ComponentHealth{
+				{Name: "data_directory", Status: entity.HealthUp},
+				{Name: "job_store", Status: entity.HealthUp},
+			},
+		}, got)
+	})
+
+	t.Run("a component down takes the service down and is remembered", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		d := newTestHealthDomain(&now)
+		var jobStoreErr error = errors.New("connection refused")
+		d.Register("data_directory", func(ctx context.Context) error { return nil })
+		d.Register("job_store", func(ctx context.Context) error { return jobStoreErr })
+		failedAt := now
+
+		got, err := d.Check(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.HealthDown, got.Status)
+		assert.Equal(t, entity.HealthUp, got.Components[0].Status)
+		assert.Equal(t, &entity.ComponentHealth{
+			Name:        "job_store",
+			Status:      entity.HealthDown,
+			Error:       "connection refused",
+			LastError:   "connection refused",
+			LastErrorAt: failedAt,
+		}, got.Components[1])
+
+		// Once the component recovers, its last error is still reported
+		jobStoreErr = nil
+		now = now.Add(time.Minute)
+		got, err = d.Check(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.HealthUp, got.Status)
+		assert.Equal(t, &entity.ComponentHealth{
+			Name:        "job_store",
+			Status:      entity.HealthUp,
+			LastError:   "connection refused",
+			LastErrorAt: failedAt,
+		}, got.Components[1])
+	})
+
+	t.Run("hanging checks time out", func(t *testing.T) {
+		d := NewHealthDomain().(*healthDomain)
+		d.timeout = 10 * time.Millisecond
+		d.Register("remote", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		got, err := d.Check(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.HealthDown, got.Status)
+		assert.Equal(t, "health check timed out after 10ms", got.Components[0].Error)
+	})
+
+	t.Run("without components", func(t *testing.T) {
+		got, err := NewHealthDomain().Check(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.HealthUp, got.Status)
+		assert.Empty(t, got.Components)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		d := NewHealthDomain()
+		d.Register("secrets", func(ctx context.Context) error { return apperrors.ErrServiceUnavailable })
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := d.Check(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
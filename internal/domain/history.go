@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// maxHistoryEntries caps the entries kept for each tenant; the oldest are dropped first.
+	maxHistoryEntries = 100
+
+	// defaultHistoryLimit is the number of entries returned when a query sets no limit.
+	defaultHistoryLimit = 20
+)
+
+// HistoryDomainInterface defines the business logic contract for the history of completed operations,
+// which lets users retrieve results they computed earlier without running the operations again.
+type HistoryDomainInterface interface {
+	// Record adds a completed operation to the history of the tenant of ctx, assigning its ID.
+	// Only the latest 100 entries of each tenant are kept.
+	Record(ctx context.Context, entry *entity.HistoryEntry) error
+
+	// Recent returns the entries of the tenant of ctx matching filter, newest first.
+	// Without a limit, at most 20 entries are returned; limits above 100 are rejected with ErrInvalidInput.
+	Recent(ctx context.Context, filter *entity.HistoryFilter) ([]*entity.HistoryEntry, error)
+}
+
+type historyDomain struct {
+	mu sync.Mutex
+	// entries holds the entries of each tenant from the oldest to the newest, keyed by tenant ID
+	entries map[string][]*entity.HistoryEntry
+}
+
+// NewHistoryDomain creates a new instance of HistoryDomainInterface.
+// It initializes the domain service with an empty history kept in memory, so it is lost when the process exits.
+func NewHistoryDomain() HistoryDomainInterface {
+	return &historyDomain{
+		entries: make(map[string][]*entity.HistoryEntry),
+	}
+}
+
+func (d *historyDomain) Record(ctx context.Context, entry *entity.HistoryEntry) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	id, err := newID("history entry")
+	if err != nil {
+		return err
+	}
+	entry.ID = id
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tenantID := tenant.ID(ctx)
+	entries := d.entries[tenantID]
+	if len(entries) == maxHistoryEntries {
+		entries = slices.Delete(entries, 0, 1)
+	}
+	d.entries[tenantID] = append(entries, entry)
+	return nil
+}
+
+func (d *historyDomain) Recent(ctx context.Context, filter *entity.HistoryFilter) ([]*entity.HistoryEntry, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	switch {
+	case limit < 0 || limit > maxHistoryEntries:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", apperrors.ErrInvalidInput, maxHistoryEntries)
+	case limit == 0:
+		limit = defaultHistoryLimit
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored := d.entries[tenant.ID(ctx)]
+	entries := make([]*entity.HistoryEntry, 0, min(limit, len(stored)))
+	for i := len(stored) - 1; i >= 0 && len(entries) < limit; i-- {
+		entry := stored[i]
+		if (filter.Operation == "" || entry.Operation == filter.Operation) &&
+			(filter.FilePath == "" || entry.FilePath == filter.FilePath) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
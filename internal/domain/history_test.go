@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestHistoryDomain_RecordAndRecent(t *testing.T) {
+	domain := NewHistoryDomain()
+	ctx := context.Background()
+
+	for i, operation := range []string{"sum", "invert", "sum"} {
+		entry := &entity.HistoryEntry{
+			Operation: operation,
+			FilePath:  fmt.Sprintf("testdata/matrix%d.csv", i),
+			Result:    &entity.Result{Scalar: fmt.Sprint(i)},
+		}
+		assert.NoError(t, domain.Record(ctx, entry))
+		assert.Len(t, entry.ID, 32)
+	}
+
+	got, err := domain.Recent(ctx, &entity.HistoryFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 3) {
+		assert.Equal(t, "2", got[0].Result.Scalar)
+		assert.Equal(t, "0", got[2].Result.Scalar)
+	}
+
+	got, err = domain.Recent(ctx, &entity.HistoryFilter{Operation: "sum", Limit: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "testdata/matrix2.csv", got[0].FilePath)
+	}
+
+	got, err = domain.Recent(ctx, &entity.HistoryFilter{FilePath: "testdata/matrix1.csv"})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "invert", got[0].Operation)
+	}
+}
+
+func TestHistoryDomain_Bounded(t *testing.T) {
+	domain := NewHistoryDomain()
+	ctx := context.Background()
+
+	for i := range maxHistoryEntries + 5 {
+		assert.NoError(t, domain.Record(ctx, &entity.HistoryEntry{Operation: "sum", Result: &entity.Result{Scalar: fmt.Sprint(i)}}))
+	}
+
+	got, err := domain.Recent(ctx, &entity.HistoryFilter{Limit: maxHistoryEntries})
+	assert.NoError(t, err)
+	if assert.Len(t, got, maxHistoryEntries) {
+		assert.Equal(t, fmt.Sprint(maxHistoryEntries+4), got[0].Result.Scalar)
+		// The oldest entries were dropped
+		assert.Equal(t, "5", got[maxHistoryEntries-1].Result.Scalar)
+	}
+}
+
+func TestHistoryDomain_TenantIsolation(t *testing.T) {
+	domain := NewHistoryDomain()
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	globex := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex"})
+
+	assert.NoError(t, domain.Record(acme, &entity.HistoryEntry{Operation: "sum"}))
+
+	got, err := domain.Recent(acme, &entity.HistoryFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	for _, ctx := range []context.Context{globex, context.Background()} {
+		got, err = domain.Recent(ctx, &entity.HistoryFilter{})
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	}
+}
+
+func TestHistoryDomain_Recent_InvalidLimit(t *testing.T) {
+	domain := NewHistoryDomain()
+
+	for _, limit := range []int{-1, maxHistoryEntries + 1} {
+		got, err := domain.Recent(context.Background(), &entity.HistoryFilter{Limit: limit})
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Nil(t, got)
+	}
+}
+
+func TestHistoryDomain_CancelledContext(t *testing.T) {
+	domain := NewHistoryDomain()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, domain.Record(ctx, &entity.HistoryEntry{}), context.Canceled)
+	_, err := domain.Recent(ctx, &entity.HistoryFilter{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
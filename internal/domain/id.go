@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// newID generates a random, URL-safe identifier for uploads, jobs and other server-side resources.
+// kind names the resource in the error message.
+func newID(kind string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate %s id: %w", kind, err)
+	}
+	return hex.EncodeToString(buf), nil
+}
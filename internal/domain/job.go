@@ -0,0 +1,678 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// jobWorkers is the number of jobs executed concurrently.
+	jobWorkers = 4
+
+	// jobQueueSize bounds how many jobs may wait for a free worker; further submissions are rejected.
+	jobQueueSize = 100
+
+	// jobTimeout bounds the execution of a single job, which is no longer tied to an HTTP request.
+	jobTimeout = 5 * time.Minute
+
+	// jobStoreTimeout bounds each update of a job's stored state made by a worker.
+	jobStoreTimeout = 5 * time.Second
+
+	// jobRetention is how long finished jobs are kept before they are deleted from the job store.
+	jobRetention = 24 * time.Hour
+
+	// jobSweepInterval is how often finished jobs past their retention are deleted.
+	jobSweepInterval = 10 * time.Minute
+
+	// webhookTimeout bounds notifying a job's callback URL, retries included.
+	webhookTimeout = 2 * time.Minute
+
+	// maxCallbackURLLength bounds the length of a job's callback URL.
+	maxCallbackURLLength = 2048
+
+	// jobFinishedEvent is the webhook event sent when a job finishes.
+	jobFinishedEvent = "job.finished"
+)
+
+// jobErrorKinds are the errors whose identity survives storing a failed job,
+// so a job loaded from the store reports the same status as when it failed.
+var jobErrorKinds = []error{
+	apperrors.ErrInvalidInput,
+	apperrors.ErrNotFound,
+	apperrors.ErrConflict,
+	apperrors.ErrPayloadTooLarge,
+	apperrors.ErrUnsupportedMediaType,
+	apperrors.ErrUnprocessableEntity,
+	apperrors.ErrServiceUnavailable,
+	context.DeadlineExceeded,
+}
+
+// JobDomainInterface defines the business logic contract for matrix operations executed in the background.
+// Jobs are queued and executed by a bounded pool of workers, so slow operations on large matrices
+// do not hold HTTP requests open. Job state is kept in a job repository, which may outlive the process.
+type JobDomainInterface interface {
+	// SubmitJob validates an operation request and enqueues it for background execution.
+	// The input is either a matrix file or a matrix supplied by the caller, but not both.
+	// When callbackURL is set, the job's final state is POSTed to it once the job finishes;
+	// callbacks are rejected with ErrInvalidInput when webhooks are not configured.
+	// The job belongs to the tenant carried by ctx, if any, and runs on its behalf, and records the tags
+	// the input file carries.
+	// It returns the queued job immediately; when the queue is full the job is rejected with
+	// ErrServiceUnavailable so clients can retry later.
+	SubmitJob(ctx context.Context, operation string, filePath string, matrix *entity.Matrix[int64], callbackURL string) (*entity.Job, error)
+
+	// GetJob returns a snapshot of a job's current state, including its result or error once finished.
+	// It returns ErrNotFound for unknown job IDs and for jobs submitted by another tenant.
+	GetJob(ctx context.Context, id string) (*entity.Job, error)
+
+	// ListRecentJobs returns up to limit jobs submitted by the tenant carried by ctx, newest first.
+	ListRecentJobs(ctx context.Context, limit int) ([]*entity.Job, error)
+
+	// CheckHealth reports whether the job store can be reached, for the health endpoint.
+	CheckHealth(ctx context.Context) error
+
+	// QueueStatus reports the jobs waiting for a worker and how long the workers recently took per job,
+	// so clients turned away by a full queue can be told when to retry.
+	QueueStatus() *entity.QueueStatus
+
+	// Stop rejects new jobs with ErrServiceUnavailable, stops deleting finished jobs, and waits until the workers have run the queued jobs
+	// and the pending webhooks have been delivered, then closes the job store. It returns the error of ctx
+	// when it expires first; the jobs still queued are then resumed by the next run when the store is durable.
+	Stop(ctx context.Context) error
+}
+
+type jobDomain struct {
+	matrixDomain     MatrixDomainInterface
+	operationsDomain MatrixOperationsDomainInterface
+	jobRepository    repository.JobRepositoryInterface
+	// tagRepository provides the tags of the input files, which jobs record
+	tagRepository repository.TagRepositoryInterface
+	// webhookRepository is nil when webhooks are not configured
+	webhookRepository repository.WebhookRepositoryInterface
+	// secretDomain provides the current webhook signing key
+	secretDomain SecretDomainInterface
+	// tenantDomain restores the limits of the tenant a job runs for; nil runs jobs without tenant limits
+	tenantDomain TenantDomainInterface
+
+	// mu serializes enqueueing so the capacity check and the send cannot interleave
+	mu    sync.Mutex
+	queue chan *entity.Job
+	// stopped is set once the queue is closed, under mu
+	stopped bool
+
+	// workers tracks the running workers and deliveries the webhooks being delivered
+	workers    sync.WaitGroup
+	deliveries sync.WaitGroup
+	// sweepStop stops the sweeper, which deletes finished jobs past their retention
+	sweepStop chan struct{}
+	sweeper   sync.WaitGroup
+	// workerCount is the number of workers started
+	workerCount int
+	// serviceTime measures how long the workers take per job
+	serviceTime serviceTimer
+}
+
+// NewJobDomain creates a new instance of JobDomainInterface with all required dependencies.
+// It initializes the domain service with the matrix domain that runs the jobs and a job store, which is
+// PostgreSQL when the JOB_DATABASE_URL secret is set and in memory otherwise. Jobs left unfinished by a previous
+// run are resumed or marked as failed before the worker pool starts. Job webhooks are signed with the
+// WEBHOOK_SECRET secret, read again for every delivery so it can be rotated; they are disabled when it is not
+// set at startup. Jobs run under the limits tenantDomain sets for the tenant that submitted them. Finished jobs
+// are deleted from the store jobRetention after they finish.
+func NewJobDomain(matrixDomain MatrixDomainInterface, secretDomain SecretDomainInterface,
+	tenantDomain TenantDomainInterface) (JobDomainInterface, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobStoreTimeout)
+	defer cancel()
+
+	databaseURL, err := secretDomain.Secret(ctx, JobDatabaseURLSecret)
+	if err != nil {
+		return nil, err
+	}
+	jobRepository := repository.NewJobRepository()
+	if databaseURL != "" {
+		jobRepository, err = repository.NewPostgresJobRepository(databaseURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	webhookSecret, err := secretDomain.Secret(ctx, WebhookSecret)
+	if err != nil {
+		return nil, err
+	}
+	var webhookRepository repository.WebhookRepositoryInterface
+	if webhookSecret != "" {
+		webhookRepository = repository.NewWebhookRepository()
+	}
+
+	d := newJobDomain(matrixDomain, NewMatrixOperationsDomain(), jobRepository, webhookRepository, jobQueueSize)
+	d.tenantDomain = tenantDomain
+	d.secretDomain = secretDomain
+
+	if err := d.resume(ctx); err != nil {
+		return nil, err
+	}
+
+	d.start(jobWorkers)
+	d.sweeper.Go(d.runSweeper)
+	return d, nil
+}
+
+// newJobDomain builds a job domain with the given queue capacity. Its workers are started separately.
+// webhookRepository may be nil to disable webhooks.
+func newJobDomain(matrixDomain MatrixDomainInterface, operationsDomain MatrixOperationsDomainInterface,
+	jobRepository repository.JobRepositoryInterface, webhookRepository repository.WebhookRepositoryInterface,
+	queueSize int) *jobDomain {
+	return &jobDomain{
+		matrixDomain:      matrixDomain,
+		operationsDomain:  operationsDomain,
+		jobRepository:     jobRepository,
+		tagRepository:     repository.NewTagRepository(),
+		webhookRepository: webhookRepository,
+		queue:             make(chan *entity.Job, queueSize),
+		sweepStop:         make(chan struct{}),
+	}
+}
+
+// start launches the given number of workers.
+func (d *jobDomain) start(workers int) {
+	d.workerCount += workers
+	for range workers {
+		d.workers.Go(d.work)
+	}
+}
+
+func (d *jobDomain) SubmitJob(ctx context.Context, operation string, filePath string, matrix *entity.Matrix[int64], callbackURL string) (*entity.Job, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	// Reject an unknown operation now rather than after the job has waited in the queue
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	if (filePath == "") == (matrix == nil) {
+		return nil, fmt.Errorf("%w: a job requires either a file or a matrix", apperrors.ErrInvalidInput)
+	}
+
+	if callbackURL != "" {
+		err = d.validateCallbackURL(callbackURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := newID("job")
+	if err != nil {
+		return nil, err
+	}
+
+	job := &entity.Job{
+		ID:          id,
+		Operation:   operation,
+		FilePath:    filePath,
+		Matrix:      matrix,
+		Status:      entity.JobQueued,
+		CreatedAt:   time.Now(),
+		CallbackURL: callbackURL,
+		TenantID:    tenant.ID(ctx),
+	}
+	if filePath != "" {
+		job.Tags = fileTags(ctx, d.tagRepository, filePath)
+	}
+
+	// Copy the job before queueing it, since a worker may start updating it right away
+	queued := *job
+	err = d.enqueue(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "job queued",
+		"job_id", id,
+		"operation", operation,
+		"file", filePath)
+
+	return &queued, nil
+}
+
+func (d *jobDomain) GetJob(ctx context.Context, id string) (*entity.Job, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	record, err := d.jobRepository.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jobs of other tenants are reported like unknown jobs
+	if record.TenantID != tenant.ID(ctx) {
+		return nil, fmt.Errorf("%w: job not found: %s", apperrors.ErrNotFound, id)
+	}
+
+	return fromJobRecord(record), nil
+}
+
+func (d *jobDomain) ListRecentJobs(ctx context.Context, limit int) ([]*entity.Job, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	records, err := d.jobRepository.ListRecentJobs(ctx, tenant.ID(ctx), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*entity.Job, len(records))
+	for i, record := range records {
+		jobs[i] = fromJobRecord(record)
+	}
+	return jobs, nil
+}
+
+func (d *jobDomain) CheckHealth(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return d.jobRepository.CheckHealth(ctx)
+}
+
+func (d *jobDomain) QueueStatus() *entity.QueueStatus {
+	return &entity.QueueStatus{
+		Queued:      len(d.queue),
+		Capacity:    cap(d.queue),
+		Workers:     d.workerCount,
+		ServiceTime: d.serviceTime.get(),
+	}
+}
+
+func (d *jobDomain) Stop(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.stopped {
+		d.stopped = true
+		// The workers exit once they have run the jobs left in the queue
+		close(d.queue)
+		close(d.sweepStop)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.sweeper.Wait()
+		d.workers.Wait()
+		// Only workers start deliveries once the service runs, so none is added after they are done
+		d.deliveries.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("%d jobs still queued when the workers were stopped: %w", len(d.queue), ctx.Err())
+	}
+
+	return d.jobRepository.Close()
+}
+
+// runSweeper deletes the finished jobs past their retention every jobSweepInterval until the domain is stopped.
+func (d *jobDomain) runSweeper() {
+	ticker := time.NewTicker(jobSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.sweepStop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), jobStoreTimeout)
+			err := d.sweep(ctx, time.Now())
+			cancel()
+			if err != nil {
+				slog.Error("failed to delete finished jobs", "error", err)
+			}
+		}
+	}
+}
+
+// sweep deletes the jobs that finished more than jobRetention before now.
+func (d *jobDomain) sweep(ctx context.Context, now time.Time) error {
+	deleted, err := d.jobRepository.DeleteFinishedJobs(ctx, now.Add(-jobRetention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		slog.Info("deleted finished jobs", "count", deleted, "retention", jobRetention)
+	}
+	return nil
+}
+
+// enqueue stores a queued job and hands it to the workers, or rejects it when the queue is full.
+// The job is stored before it is queued so a worker never updates a job that does not exist yet.
+func (d *jobDomain) enqueue(ctx context.Context, job *entity.Job) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return fmt.Errorf("%w: server is shutting down", apperrors.ErrServiceUnavailable)
+	}
+	if len(d.queue) == cap(d.queue) {
+		return fmt.Errorf("%w: job queue is full", apperrors.ErrServiceUnavailable)
+	}
+
+	err := d.jobRepository.SaveJob(ctx, toJobRecord(job))
+	if err != nil {
+		return err
+	}
+
+	// Only enqueue adds to the queue and it holds the lock, so the capacity check guarantees this does not block
+	d.queue <- job
+	return nil
+}
+
+// resume restores the jobs a previous run left unfinished. Queued jobs are queued again, while jobs
+// that were running are marked as failed since how far they got is unknown; clients can resubmit them.
+func (d *jobDomain) resume(ctx context.Context) error {
+	records, err := d.jobRepository.ListUnfinishedJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	resumed := 0
+	for _, record := range records {
+		job := fromJobRecord(record)
+
+		if job.Status == entity.JobQueued {
+			err = d.enqueue(ctx, job)
+			if err == nil {
+				resumed++
+				continue
+			}
+			if !errors.Is(err, apperrors.ErrServiceUnavailable) {
+				return err
+			}
+		}
+
+		job.Status = entity.JobFailed
+		job.Err = fmt.Errorf("%w: job was interrupted by a server restart", apperrors.ErrServiceUnavailable)
+		job.FinishedAt = time.Now()
+		job.Matrix = nil
+		err = d.jobRepository.SaveJob(ctx, toJobRecord(job))
+		if err != nil {
+			return err
+		}
+		d.notify(job)
+	}
+
+	if len(records) > 0 {
+		slog.InfoContext(ctx, "unfinished jobs restored",
+			"resumed", resumed,
+			"failed", len(records)-resumed)
+	}
+	return nil
+}
+
+// work executes queued jobs one at a time until the queue is closed.
+func (d *jobDomain) work() {
+	for job := range d.queue {
+		d.run(job)
+	}
+}
+
+// run executes a single job and records its outcome.
+// The job is owned by this worker once dequeued, so it is updated without locking.
+func (d *jobDomain) run(job *entity.Job) {
+	job.Status = entity.JobRunning
+	job.StartedAt = time.Now()
+	d.save(job)
+
+	// The job runs on behalf of the tenant that submitted it, so it can only read that tenant's files,
+	// and as batch work, so a queue of jobs cannot hold up the interactive requests
+	ctx, cancel := context.WithTimeout(qos.NewContext(d.tenantContext(job.TenantID), qos.Batch), jobTimeout)
+	defer cancel()
+
+	var result *entity.Result
+	var err error
+	if job.Matrix != nil {
+		result, err = d.matrixDomain.ProcessMatrixData(ctx, job.Operation, job.Matrix, nil)
+	} else {
+		result, err = d.matrixDomain.ProcessMatrix(ctx, job.Operation, job.FilePath, nil)
+	}
+
+	job.FinishedAt = time.Now()
+	d.serviceTime.observe(job.FinishedAt.Sub(job.StartedAt))
+	// The input is no longer needed once the job has run
+	job.Matrix = nil
+	if err != nil {
+		job.Status = entity.JobFailed
+		job.Err = err
+
+		slog.Error("job failed",
+			"job_id", job.ID,
+			"operation", job.Operation,
+			"error", err)
+	} else {
+		job.Status = entity.JobSucceeded
+		job.Result = result
+
+		slog.Info("job succeeded",
+			"job_id", job.ID,
+			"operation", job.Operation,
+			"duration", job.FinishedAt.Sub(job.StartedAt))
+	}
+
+	d.save(job)
+	d.notify(job)
+}
+
+// tenantContext returns a context carrying the tenant identified by tenantID with its current limits.
+// A tenant that is no longer configured keeps its ID, so its jobs still only read its own files.
+func (d *jobDomain) tenantContext(tenantID string) context.Context {
+	ctx := context.Background()
+	if tenantID == "" || d.tenantDomain == nil {
+		return tenant.WithID(ctx, tenantID)
+	}
+
+	t, err := d.tenantDomain.GetTenant(ctx, tenantID)
+	if err != nil {
+		slog.Warn("job tenant not found, running without tenant limits",
+			"tenant_id", tenantID,
+			"error", err)
+		return tenant.WithID(ctx, tenantID)
+	}
+	return tenant.NewContext(ctx, t)
+}
+
+// notify delivers the final state of a job to its callback URL, if it has one. Delivery happens in the
+// background so slow receivers do not hold up the worker; a delivery that still fails after its retries
+// is logged and dropped, since the job's state can always be fetched from the status endpoint.
+func (d *jobDomain) notify(job *entity.Job) {
+	if job.CallbackURL == "" || d.webhookRepository == nil {
+		return
+	}
+
+	payload, err := codec.EncodeJob(job)
+	if err != nil {
+		slog.Error("failed to encode job webhook",
+			"job_id", job.ID,
+			"error", err)
+		return
+	}
+
+	d.deliveries.Go(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+		defer cancel()
+
+		secret, err := d.secretDomain.Secret(ctx, WebhookSecret)
+		if err == nil && secret == "" {
+			err = fmt.Errorf("%w: webhook secret is no longer set", apperrors.ErrServiceUnavailable)
+		}
+		if err == nil {
+			// The URL is not logged since it may embed a token for the receiver
+			err = d.webhookRepository.Deliver(ctx, job.CallbackURL, jobFinishedEvent, payload, secret)
+		}
+		if err != nil {
+			slog.Error("failed to deliver job webhook",
+				"job_id", job.ID,
+				"error", err)
+			return
+		}
+
+		slog.Info("job webhook delivered", "job_id", job.ID)
+	})
+}
+
+// validateCallbackURL checks that a callback URL is an absolute HTTP or HTTPS URL and that webhooks are enabled.
+func (d *jobDomain) validateCallbackURL(callbackURL string) error {
+	if d.webhookRepository == nil {
+		return fmt.Errorf("%w: webhooks are not enabled on this server", apperrors.ErrInvalidInput)
+	}
+	if len(callbackURL) > maxCallbackURLLength {
+		return fmt.Errorf("%w: callback URL is longer than %d characters", apperrors.ErrInvalidInput, maxCallbackURLLength)
+	}
+
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: callback URL must be an absolute http or https URL", apperrors.ErrInvalidInput)
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("%w: callback URL must not contain credentials", apperrors.ErrInvalidInput)
+	}
+	return nil
+}
+
+// save stores a job's new state. A failed update is logged rather than failing the job: the job keeps
+// running, and if the store never catches up it is marked as failed on the next restart.
+func (d *jobDomain) save(job *entity.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobStoreTimeout)
+	defer cancel()
+
+	if err := d.jobRepository.SaveJob(ctx, toJobRecord(job)); err != nil {
+		slog.Error("failed to save job state",
+			"job_id", job.ID,
+			"status", job.Status,
+			"error", err)
+	}
+}
+
+// toJobRecord converts a job to its stored form.
+func toJobRecord(job *entity.Job) *repository.JobRecord {
+	record := &repository.JobRecord{
+		ID:          job.ID,
+		Operation:   job.Operation,
+		FilePath:    job.FilePath,
+		Status:      string(job.Status),
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+		CallbackURL: job.CallbackURL,
+		TenantID:    job.TenantID,
+		Tags:        job.Tags,
+	}
+	if job.Matrix != nil {
+		record.Input = job.Matrix.Data
+		record.InputScale = job.Matrix.Scale
+	}
+	if job.Result != nil {
+		record.ResultScalar = job.Result.Scalar
+		if job.Result.Matrix != nil {
+			record.ResultMatrix = job.Result.Matrix.Data
+			record.ResultScale = job.Result.Matrix.Scale
+		}
+		if job.Result.Input != nil {
+			record.InputRows = job.Result.Input.Rows
+			record.InputCols = job.Result.Input.Cols
+			record.InputChecksum = job.Result.Input.Checksum
+		}
+	}
+	if job.Err != nil {
+		record.ErrorMessage = job.Err.Error()
+		for _, kind := range jobErrorKinds {
+			if errors.Is(job.Err, kind) {
+				record.ErrorKind = kind.Error()
+				break
+			}
+		}
+	}
+	return record
+}
+
+// fromJobRecord restores a job from its stored form.
+func fromJobRecord(record *repository.JobRecord) *entity.Job {
+	job := &entity.Job{
+		ID:          record.ID,
+		Operation:   record.Operation,
+		FilePath:    record.FilePath,
+		Status:      entity.JobStatus(record.Status),
+		CreatedAt:   record.CreatedAt,
+		StartedAt:   record.StartedAt,
+		FinishedAt:  record.FinishedAt,
+		CallbackURL: record.CallbackURL,
+		TenantID:    record.TenantID,
+		Tags:        record.Tags,
+	}
+	if record.Input != nil {
+		job.Matrix = &entity.Matrix[int64]{Data: record.Input, Scale: record.InputScale}
+	}
+	if job.Status == entity.JobSucceeded {
+		job.Result = &entity.Result{Scalar: record.ResultScalar}
+		if record.ResultMatrix != nil {
+			job.Result.Matrix = &entity.Matrix[int64]{Data: record.ResultMatrix, Scale: record.ResultScale}
+		}
+		if record.InputChecksum != "" {
+			job.Result.Input = &entity.MatrixInfo{
+				Rows:     record.InputRows,
+				Cols:     record.InputCols,
+				Checksum: record.InputChecksum,
+			}
+		}
+	}
+	if job.Status == entity.JobFailed {
+		job.Err = &storedJobError{message: record.ErrorMessage}
+		for _, kind := range jobErrorKinds {
+			if kind.Error() == record.ErrorKind {
+				job.Err = &storedJobError{message: record.ErrorMessage, kind: kind}
+				break
+			}
+		}
+	}
+	return job
+}
+
+// storedJobError is the error of a failed job restored from the store.
+// It keeps the original message and unwraps to the kind of error it was recorded as.
+type storedJobError struct {
+	message string
+	kind    error
+}
+
+func (e *storedJobError) Error() string {
+	return e.message
+}
+
+func (e *storedJobError) Unwrap() error {
+	return e.kind
+}
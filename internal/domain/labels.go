@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// matrixLabels collects the labels of a matrix read with a header row or a column of row labels,
+// as asked for by the parsing options, and carries them over to the result of an operation.
+type matrixLabels struct {
+	header    bool
+	rowLabels bool
+	cols      []string
+	rows      []string
+}
+
+// newMatrixLabels creates the labels of a matrix read with options.
+func newMatrixLabels(options parsing.Options) *matrixLabels {
+	return &matrixLabels{header: options.Header, rowLabels: options.RowLabels}
+}
+
+// wrap returns stream without the header row and the row labels, which are collected instead of being handled as values.
+func (l *matrixLabels) wrap(stream rowStream) rowStream {
+	if !l.header && !l.rowLabels {
+		return stream
+	}
+	return func(ctx context.Context, handleRow repository.RowHandler) error {
+		return stream(ctx, l.handler(handleRow))
+	}
+}
+
+// strip returns the rows of content without the header row and the row labels, which are collected.
+func (l *matrixLabels) strip(content *repository.MatrixFileContent) *repository.MatrixFileContent {
+	if !l.header && !l.rowLabels {
+		return content
+	}
+
+	stripped := &repository.MatrixFileContent{}
+	handleRow := l.handler(func(row []string) error {
+		stripped.Content = append(stripped.Content, row)
+		return nil
+	})
+	for _, row := range content.Content {
+		_ = handleRow(row)
+	}
+	return stripped
+}
+
+// handler wraps handleRow so the header row and the first value of every row are collected as labels.
+func (l *matrixLabels) handler(handleRow repository.RowHandler) repository.RowHandler {
+	header := l.header
+	return func(row []string) error {
+		if header {
+			header = false
+			// Rows may be reused by the reader, so the labels are copied
+			l.cols = slices.Clone(row)
+			if l.rowLabels && len(l.cols) > 0 {
+				// The first label names the column of row labels rather than a column of values
+				l.cols = l.cols[1:]
+			}
+			return nil
+		}
+		if l.rowLabels && len(row) > 0 {
+			l.rows = append(l.rows, row[0])
+			row = row[1:]
+		}
+		return handleRow(row)
+	}
+}
+
+// check rejects a header row whose number of labels differs from the number of columns of the matrix.
+func (l *matrixLabels) check(cols int) error {
+	if l.header && len(l.cols) != cols {
+		return fmt.Errorf("%w: header row holds %d labels, but rows hold %d values",
+			apperrors.ErrUnprocessableEntity, len(l.cols), cols)
+	}
+	return nil
+}
+
+// apply attaches the labels of the selected rows and columns to the result of operation. Echo keeps
+// the labels where they are, while transpose swaps them and so does inverse, whose rows match the columns of the matrix
+// and columns its rows. The other operations do not keep rows or columns.
+func (l *matrixLabels) apply(result *entity.Result, operation string, selection *entity.Selection) {
+	var rowRanges, colRanges []entity.IndexRange
+	if selection != nil {
+		rowRanges, colRanges = selection.Rows, selection.Cols
+	}
+	rows := selectLabels(l.rows, rowRanges)
+	cols := selectLabels(l.cols, colRanges)
+
+	switch matrixlib.Operation(operation) {
+	case matrixlib.Echo:
+		result.RowLabels, result.ColumnLabels = rows, cols
+	case matrixlib.Transpose, matrixlib.Invert, matrixlib.Inverse:
+		result.RowLabels, result.ColumnLabels = cols, rows
+	}
+}
+
+// selectLabels returns the labels at the indices covered by ranges, which were already checked against the matrix.
+func selectLabels(labels []string, ranges []entity.IndexRange) []string {
+	if labels == nil {
+		return nil
+	}
+
+	indices, err := expandRanges(ranges, len(labels), "label")
+	if err != nil {
+		return nil
+	}
+	selected := make([]string, len(indices))
+	for i, j := range indices {
+		selected[i] = labels[j]
+	}
+	return selected
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestMatrixLabels_Wrap(t *testing.T) {
+	input := [][]string{{"team", "home", "away"}, {"lions", "1", "2"}, {"tigers", "3", "4"}}
+	tests := []struct {
+		name     string
+		options  parsing.Options
+		wantRows [][]string
+		wantCols []string
+		wantRowL []string
+	}{
+		{name: "unlabelled", wantRows: input},
+		{name: "header row", options: parsing.Options{Header: true}, wantRows: input[1:],
+			wantCols: []string{"team", "home", "away"}},
+		{name: "row labels", options: parsing.Options{RowLabels: true},
+			wantRows: [][]string{{"home", "away"}, {"1", "2"}, {"3", "4"}}, wantRowL: []string{"team", "lions", "tigers"}},
+		{name: "header row and row labels", options: parsing.Options{Header: true, RowLabels: true},
+			wantRows: [][]string{{"1", "2"}, {"3", "4"}}, wantCols: []string{"home", "away"}, wantRowL: []string{"lions", "tigers"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The reader reuses its record, as the repository does
+			record := make([]string, 3)
+			stream := func(_ context.Context, handleRow repository.RowHandler) error {
+				for _, row := range input {
+					copy(record, row)
+					if err := handleRow(record); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			labels := newMatrixLabels(tt.options)
+			var rows [][]string
+			err := labels.wrap(stream)(context.Background(), func(row []string) error {
+				rows = append(rows, append([]string(nil), row...))
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRows, rows)
+			assert.Equal(t, tt.wantCols, labels.cols)
+			assert.Equal(t, tt.wantRowL, labels.rows)
+		})
+	}
+}
+
+func TestMatrixLabels_Strip(t *testing.T) {
+	labels := newMatrixLabels(parsing.Options{Header: true, RowLabels: true})
+
+	got := labels.strip(&repository.MatrixFileContent{Content: [][]string{{"", "home"}, {"lions", "1"}}})
+
+	assert.Equal(t, [][]string{{"1"}}, got.Content)
+	assert.Equal(t, []string{"home"}, labels.cols)
+	assert.Equal(t, []string{"lions"}, labels.rows)
+}
+
+func TestMatrixLabels_Check(t *testing.T) {
+	labels := &matrixLabels{header: true, cols: []string{"home", "away"}}
+	assert.NoError(t, labels.check(2))
+	assert.ErrorIs(t, labels.check(3), apperrors.ErrUnprocessableEntity)
+
+	assert.ErrorIs(t, (&matrixLabels{header: true}).check(2), apperrors.ErrUnprocessableEntity)
+	assert.NoError(t, (&matrixLabels{}).check(2))
+}
+
+func TestMatrixLabels_Apply(t *testing.T) {
+	labels := &matrixLabels{cols: []string{"a", "b", "c"}, rows: []string{"x", "y"}}
+	tests := []struct {
+		name      string
+		operation string
+		labels    *matrixLabels
+		selection *entity.Selection
+		wantCols  []string
+		wantRows  []string
+	}{
+		{name: "echo", operation: "echo", labels: labels, wantCols: []string{"a", "b", "c"}, wantRows: []string{"x", "y"}},
+		{name: "invert swaps the labels", operation: "invert", labels: labels,
+			wantCols: []string{"x", "y"}, wantRows: []string{"a", "b", "c"}},
+		{name: "transpose swaps the labels", operation: "transpose", labels: labels,
+			wantCols: []string{"x", "y"}, wantRows: []string{"a", "b", "c"}},
+		{name: "inverse swaps the labels", operation: "inverse", labels: &matrixLabels{cols: []string{"a", "b"}, rows: []string{"x", "y"}},
+			wantCols: []string{"x", "y"}, wantRows: []string{"a", "b"}},
+		{name: "selection", operation: "echo", labels: labels,
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 1, End: 1}}, Cols: []entity.IndexRange{{Start: 2, End: 2}, {Start: 0, End: 0}}},
+			wantCols:  []string{"c", "a"}, wantRows: []string{"y"}},
+		{name: "row labels only", operation: "echo", labels: &matrixLabels{rows: []string{"x"}}, wantRows: []string{"x"}},
+		{name: "operation keeping no rows or columns", operation: "flatten", labels: labels},
+		{name: "unlabelled", operation: "echo", labels: &matrixLabels{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &entity.Result{}
+			tt.labels.apply(result, tt.operation, tt.selection)
+
+			assert.Equal(t, tt.wantCols, result.ColumnLabels)
+			assert.Equal(t, tt.wantRows, result.RowLabels)
+		})
+	}
+}
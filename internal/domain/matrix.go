@@ -1,99 +1,1026 @@
 package domain
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"io"
 	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/i18n"
+	"github.com/matsuboshi/league-matrix-app/internal/metrics"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
 	"github.com/matsuboshi/league-matrix-app/internal/repository"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+const (
+	// listingBaseURL is the address of a server running with the default settings, used in the example URLs
+	// of the operations listing.
+	listingBaseURL = "http://localhost:8080"
+
+	// listingSampleFile is the sample matrix the example URLs of the operations listing run on.
+	listingSampleFile = "testdata/matrix1.csv"
+
+	// concatOperation and matmulOperation name the operations on two matrix files in the metrics.
+	concatOperation = "concat"
+	matmulOperation = "matmul"
 )
 
 // MatrixDomainInterface defines the main business logic contract for matrix processing.
 // It coordinates between repository, validation, and operation layers to process matrix requests.
 type MatrixDomainInterface interface {
-	// ListMatrixOperations returns a formatted string listing all available matrix operations.
-	// It includes a sample URL and all supported operation names.
-	ListMatrixOperations() (string, error)
+	// ListMatrixOperations returns a formatted string listing all available matrix operations in alphabetical order.
+	// It includes a sample URL, then each operation with a one-line description and an example URL,
+	// with the instructional text in the language of localizer.
+	ListMatrixOperations(localizer *i18n.Localizer) (string, error)
+
+	// ListOperations returns the names of the supported operations in alphabetical order.
+	ListOperations() []string
+
+	// DescribeOperations returns the supported operations with their descriptions in alphabetical order.
+	DescribeOperations() []entity.OperationInfo
+
+	// ListFiles returns the paths of the matrix files the caller may read, sorted,
+	// scoped to the tenant carried by ctx like every other file access.
+	ListFiles(ctx context.Context) ([]string, error)
+
+	// CheckHealth reports whether matrix files can be read, for the health endpoint.
+	CheckHealth(ctx context.Context) error
+
+	// FileModTime returns the time the matrix file at filePath was last modified, validating the path
+	// like ProcessMatrix does. It returns the zero time for files that carry none, such as the embedded samples.
+	FileModTime(ctx context.Context, filePath string) (time.Time, error)
 
 	// ProcessMatrix executes a specific matrix operation on a file.
-	// It validates the operation, reads the file, validates the matrix data, and performs the operation.
-	// Returns the result as a formatted string or an error if any step fails.
-	ProcessMatrix(ctx context.Context, operation string, filePath string) (string, error)
+	// It validates the operation, reads the file, validates the matrix data, and performs the operation
+	// on the submatrix described by selection (nil selects the whole matrix).
+	// Returns the operation result or an error if any step fails.
+	ProcessMatrix(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Result, error)
+
+	// ProcessMatrixData executes a specific matrix operation on a matrix supplied by the caller,
+	// such as one decoded from a request body, instead of reading it from a file.
+	// It validates the operation and the matrix dimensions before performing the operation on the selected submatrix.
+	ProcessMatrixData(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Result, error)
+
+	// ProcessMatrixReader executes a specific matrix operation on CSV matrix data read from r,
+	// such as a local file, a pipe given to the command line interface or an uploaded request body.
+	// The data is validated like a matrix file, but no file path restrictions apply.
+	ProcessMatrixReader(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Result, error)
+
+	// ProcessArchive executes a specific matrix operation on every CSV file in a zip archive.
+	// Each file is validated and processed independently and reported in archive order, so invalid
+	// files are recorded on their own result instead of failing the batch.
+	// An error is returned only when the operation is invalid or the archive itself cannot be read.
+	ProcessArchive(ctx context.Context, operation string, archive []byte) ([]*entity.FileResult, error)
+
+	// ConcatMatrices joins the matrix files at filePath and filePath2 along axis: horizontal, the default,
+	// places the columns of the second matrix after those of the first, while vertical places its rows below them.
+	// Both files are validated like for ProcessMatrix, and matrices that do not line up along axis are rejected
+	// as unprocessable. The joined matrix must fit the dimension limits; it is returned like the result of echo
+	// on the submatrix described by selection, without the labels of files read with header or row labels.
+	ConcatMatrices(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Result, error)
+
+	// MultiplyMatrices returns the matrix product of the matrix files at filePath and filePath2, which has the rows
+	// of the first matrix and the columns of the second. Both files are validated like for ProcessMatrix, and matrices
+	// that do not line up, with as many columns in the first as rows in the second, are rejected as unprocessable.
+	// The product is returned like the result of echo on the submatrix described by selection, without the labels
+	// of files read with header or row labels.
+	MultiplyMatrices(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Result, error)
+
+	// PlanMatrix is the dry run of ProcessMatrix: it validates the operation and the file path, reads and
+	// validates the matrix and the selection like ProcessMatrix does, then returns how the operation would run
+	// on the selected submatrix without running it.
+	PlanMatrix(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Plan, error)
+
+	// PlanMatrixData is the dry run of ProcessMatrixData, planning the operation on a matrix supplied by the caller.
+	PlanMatrixData(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Plan, error)
+
+	// PlanMatrixReader is the dry run of ProcessMatrixReader, planning the operation on CSV matrix data read from r.
+	PlanMatrixReader(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Plan, error)
+
+	// PlanConcat is the dry run of ConcatMatrices: it joins and validates the matrices like ConcatMatrices does,
+	// then plans echo on the selected submatrix of the joined matrix.
+	PlanConcat(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Plan, error)
+
+	// PlanMultiplyMatrices is the dry run of MultiplyMatrices: it multiplies the matrices like MultiplyMatrices does,
+	// then plans echo on the selected submatrix of their product.
+	PlanMultiplyMatrices(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Plan, error)
+
+	// SplitMatrix divides the matrix file at filePath into a grid of rows by cols tiles of equal size,
+	// returned row by row. The file is validated like for ProcessMatrix, and matrices whose dimensions
+	// are not multiples of the grid are rejected as unprocessable.
+	SplitMatrix(ctx context.Context, filePath string, rows int, cols int) ([]*entity.Tile, error)
+
+	// MemoMetrics returns the metrics of the memo of operation results: its lookups by outcome, the results
+	// it holds and its hit ratio. It returns no metrics when the memo is disabled.
+	MemoMetrics() []*entity.Metric
+
+	// CacheMetrics returns the metrics of the cache of results of operations on matrix files: its lookups
+	// by outcome, the results it holds and its hit ratio. It returns no metrics when the cache is disabled.
+	CacheMetrics() []*entity.Metric
+
+	// FlushCache forgets every result held by the memo and by the cache of results of operations on matrix
+	// files, so the next operations run again, and returns how many results it forgot.
+	FlushCache() int
+
+	// InvalidateCache forgets the results remembered for key and returns how many it forgot. key is either the
+	// checksum of a matrix, as reported with results, whose memoized results are forgotten, or the path of a
+	// matrix file of the tenant carried by ctx, whose cached results are forgotten.
+	InvalidateCache(ctx context.Context, key string) int
+
+	// SaveResult persists an operation result as a new CSV file so it can be used as input to later requests.
+	// The path is subject to the same validation as input files. Matrix results are written row by row,
+	// while scalar results are written as a single-cell matrix.
+	SaveResult(ctx context.Context, filePath string, result *entity.Result) error
+}
+
+// StreamLimits bounds the matrices that aggregate operations read as a stream of rows.
+// Sum and multiply on a whole matrix file or input stream never hold the matrix in memory,
+// so they accept matrices far beyond the limits of the other operations.
+type StreamLimits struct {
+	MaxRows     int
+	MaxCols     int
+	MaxFileSize int64
+}
+
+// DefaultStreamLimits are the stream limits used unless configured otherwise.
+var DefaultStreamLimits = StreamLimits{
+	MaxRows:     config.Default.StreamMaxRows,
+	MaxCols:     config.Default.StreamMaxCols,
+	MaxFileSize: config.Default.StreamMaxFileSize,
 }
 
 type matrixDomain struct {
-	matrixRepository repository.MatrixRepositoryInterface
-	validatorDomain  MatrixValidatorDomainInterface
-	operationsDomain MatrixOperationsDomainInterface
+	matrixRepository  repository.MatrixRepositoryInterface
+	archiveRepository repository.ArchiveRepositoryInterface
+	validatorDomain   MatrixValidatorDomainInterface
+	operationsDomain  MatrixOperationsDomainInterface
+	// streamLimits bounds streamed aggregate operations; the zero value disables streaming
+	streamLimits StreamLimits
+	// memo remembers the results of operations on assembled matrices; nil disables it
+	memo *resultMemo
+	// files remembers the results of operations on unchanged matrix files; nil disables it
+	files *fileCache
+}
+
+// FileSourcesConfig configures the remote sources matrix files are read from besides the data directory.
+// Files addressed as s3://bucket/key.csv are read from the S3-compatible object storage at S3Endpoint and
+// S3Region, as described by repository.S3Config, with the access keys kept in the S3AccessKeyIDSecret and
+// S3SecretAccessKeySecret secrets of SecretDomain; without SecretDomain, such files are rejected.
+// Files addressed as http:// or https:// URLs are downloaded from the hosts of URLHosts alone, as described
+// by repository.NewURLMatrixRepository; without URLHosts, such files are rejected.
+type FileSourcesConfig struct {
+	S3Endpoint   string
+	S3Region     string
+	SecretDomain SecretDomainInterface
+	URLHosts     []string
 }
 
 // NewMatrixDomain creates a new instance of MatrixDomainInterface with all required dependencies.
-// It initializes the domain service with repository, validator, and operations components.
-func NewMatrixDomain() MatrixDomainInterface {
+// It initializes the domain service with file and archive repositories, validator, and operations components.
+// Aggregate operations on whole matrices are streamed under streamLimits, and the results of the other
+// operations, like those of any operation on an unchanged matrix file, are remembered within the bounds of
+// memoConfig. Matrix files are read from the data directory, or from the remote sources of fileSources for
+// s3:// paths and URLs, retried and guarded by a circuit breaker.
+func NewMatrixDomain(streamLimits StreamLimits, memoConfig MemoConfig, fileSources FileSourcesConfig) MatrixDomainInterface {
+	schemes := make(map[string]repository.MatrixRepositoryInterface)
+	if secretDomain := fileSources.SecretDomain; secretDomain != nil {
+		s3Repository := repository.NewS3MatrixRepository(repository.S3Config{
+			Endpoint: fileSources.S3Endpoint,
+			Region:   fileSources.S3Region,
+			Credentials: func(ctx context.Context) (repository.S3Credentials, error) {
+				return readS3Credentials(ctx, secretDomain, "matrix files in object storage")
+			},
+		})
+		schemes[repository.S3Scheme] = repository.NewBreakerMatrixRepository(
+			repository.NewRetryMatrixRepository(s3Repository, repository.DefaultRetryConfig()),
+			repository.DefaultBreakerConfig())
+	}
+	if len(fileSources.URLHosts) > 0 {
+		urlRepository := repository.NewBreakerMatrixRepository(
+			repository.NewRetryMatrixRepository(repository.NewURLMatrixRepository(fileSources.URLHosts),
+				repository.DefaultRetryConfig()),
+			repository.DefaultBreakerConfig())
+		schemes[repository.HTTPScheme] = urlRepository
+		schemes[repository.HTTPSScheme] = urlRepository
+	}
+
 	return &matrixDomain{
-		matrixRepository: repository.NewMatrixRepository(),
-		validatorDomain:  NewMatrixValidatorDomain(),
-		operationsDomain: NewMatrixOperationsDomain(),
+		matrixRepository:  repository.NewSchemeMatrixRepository(repository.NewMatrixRepository(), schemes),
+		archiveRepository: repository.NewArchiveRepository(),
+		validatorDomain:   NewMatrixValidatorDomain(),
+		operationsDomain:  NewMatrixOperationsDomain(),
+		streamLimits:      streamLimits,
+		memo:              newResultMemo(memoConfig),
+		files:             newFileCache(memoConfig),
+	}
+}
+
+func (d *matrixDomain) ListMatrixOperations(localizer *i18n.Localizer) (string, error) {
+	operations := d.operationsDomain.DescribeOperations()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `
+	%s
+	%s 
+	%s
+
+	%s 
+`, localizer.T("listing.lost"), localizer.T("listing.sample"), exampleURL(matrixlib.Sum), localizer.T("listing.operations"))
+
+	width := 0
+	for _, op := range operations {
+		width = max(width, len(op.Name))
+	}
+	for _, op := range operations {
+		fmt.Fprintf(&sb, "\t%-*s  %s\n\t%*s  %s\n", width, op.Name, op.Description, width, "", exampleURL(op.Name))
+	}
+
+	return sb.String(), nil
+}
+
+// exampleURL returns a URL running operation on the sample matrix, for the operations listing.
+func exampleURL(operation matrixlib.Operation) string {
+	return listingBaseURL + "/matrix/" + string(operation) + "?file=" + listingSampleFile
+}
+
+func (d *matrixDomain) ListOperations() []string {
+	return d.operationsDomain.ListOperations()
+}
+
+func (d *matrixDomain) DescribeOperations() []entity.OperationInfo {
+	return d.operationsDomain.DescribeOperations()
+}
+
+func (d *matrixDomain) ListFiles(ctx context.Context) ([]string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return d.matrixRepository.ListFiles(ctx)
+}
+
+func (d *matrixDomain) CheckHealth(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return d.matrixRepository.CheckHealth(ctx)
+}
+
+func (d *matrixDomain) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	err := d.validatorDomain.ValidateFileRef(ctx, filePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return d.matrixRepository.FileModTime(ctx, filePath)
+}
+
+func (d *matrixDomain) ProcessMatrix(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Result, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	err := d.validatorDomain.ValidateFileRef(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.processRows(ctx, operation, selection, d.fileRef(ctx, filePath), func(ctx context.Context, handleRow repository.RowHandler) error {
+		return d.matrixRepository.StreamFileContent(ctx, filePath, handleRow)
+	})
+}
+
+func (d *matrixDomain) ProcessMatrixData(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Result, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	metrics.SetOperation(ctx, operation)
+
+	err = d.validatorDomain.ValidateMatrix(ctx, matrix)
+	if err != nil {
+		return nil, err
+	}
+	metrics.ObserveMatrix(ctx, matrix.Rows(), matrix.Cols())
+
+	return d.runSelectedOperation(ctx, matrix, operation, selection)
+}
+
+func (d *matrixDomain) ProcessMatrixReader(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Result, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	return d.processRows(ctx, operation, selection, nil, func(ctx context.Context, handleRow repository.RowHandler) error {
+		return d.matrixRepository.StreamContent(ctx, r, handleRow)
+	})
+}
+
+// rowStream reads matrix rows with ctx and passes them to handleRow one at a time.
+type rowStream func(ctx context.Context, handleRow repository.RowHandler) error
+
+// fileRef returns the reference of the matrix file at filePath in the file cache, or nil when the cache
+// is disabled or the file cannot be looked up, in which case it is read as usual.
+func (d *matrixDomain) fileRef(ctx context.Context, filePath string) *fileRef {
+	if d.files == nil {
+		return nil
+	}
+
+	stat, err := d.matrixRepository.StatFile(ctx, filePath)
+	if err != nil {
+		slog.DebugContext(ctx, "matrix file not cacheable",
+			"file_path", filePath,
+			"error", err)
+		return nil
+	}
+	return d.files.ref(ctx, filePath, stat)
+}
+
+// processRows validates the operation, assembles a validated matrix from the rows produced by stream
+// and runs the operation on it. Aggregate operations on the whole matrix are streamed instead.
+// When stream reads the file of ref, a result remembered for its unchanged content is returned without
+// reading it, and the result is remembered otherwise.
+func (d *matrixDomain) processRows(ctx context.Context, operation string, selection *entity.Selection,
+	ref *fileRef, stream rowStream) (*entity.Result, error) {
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	metrics.SetOperation(ctx, operation)
+
+	if result, ok := d.files.get(ctx, ref, operation, selection); ok {
+		slog.DebugContext(ctx, "cached file operation",
+			"operation", operation,
+			"file_path", ref.key.path)
+		metrics.ObserveMatrix(ctx, result.Input.Rows, result.Input.Cols)
+		return result, nil
+	}
+	if ref == nil {
+		return d.processStream(ctx, operation, selection, stream)
+	}
+
+	// The file is hashed as it is read, so its result is remembered by its content
+	stream, hash := hashStream(stream)
+	result, err := d.processStream(ctx, operation, selection, stream)
+	if err != nil {
+		return nil, err
+	}
+	d.files.put(ctx, ref, hash(), operation, selection, result)
+	return result, nil
+}
+
+// processStream assembles a validated matrix from the rows produced by stream and runs the operation on it,
+// or streams aggregate operations on the whole matrix. The operation must already be validated.
+func (d *matrixDomain) processStream(ctx context.Context, operation string, selection *entity.Selection,
+	stream rowStream) (*entity.Result, error) {
+	labels := newMatrixLabels(parsing.FromContext(ctx))
+	stream = labels.wrap(stream)
+
+	if selection.IsEmpty() && d.streamLimits.MaxRows > 0 && matrixlib.Streamable(matrixlib.Operation(operation)) {
+		result, err := d.streamOperation(ctx, operation, stream)
+		if err == nil {
+			err = labels.check(result.Input.Cols)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	validatedMatrix, err := d.assembleMatrix(ctx, labels, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.runSelectedOperation(ctx, validatedMatrix, operation, selection)
+	if err != nil {
+		return nil, err
+	}
+	labels.apply(result, operation, selection)
+	return result, nil
+}
+
+// assembleMatrix builds a validated matrix from the rows produced by stream, wrapped by labels,
+// and checks labels against it.
+func (d *matrixDomain) assembleMatrix(ctx context.Context, labels *matrixLabels, stream rowStream) (*entity.Matrix[int64], error) {
+	// Validate and convert each row as it is read so invalid or oversized input is rejected early
+	matrix := &entity.Matrix[int64]{}
+	err := stream(ctx, func(row []string) error {
+		return d.validatorDomain.ValidateRow(ctx, matrix, row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty input produces no rows, so the assembled matrix is checked as a whole
+	err = d.validatorDomain.ValidateMatrix(ctx, matrix)
+	if err != nil {
+		return nil, err
+	}
+	err = labels.check(matrix.Cols())
+	if err != nil {
+		return nil, err
+	}
+	metrics.ObserveMatrix(ctx, matrix.Rows(), matrix.Cols())
+	return matrix, nil
+}
+
+// streamOperation runs an aggregate operation on the rows produced by stream as they are read,
+// without assembling the matrix, so inputs up to the stream limits run in constant memory.
+func (d *matrixDomain) streamOperation(ctx context.Context, operation string, stream rowStream) (*entity.Result, error) {
+	rows, err := matrixlib.NewStream[int64](matrixlib.Operation(operation), matrixlib.Limits{
+		MaxRows: d.streamLimits.MaxRows,
+		MaxCols: d.streamLimits.MaxCols,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := newMatrixChecksum()
+	err = stream(repository.WithMaxFileSize(ctx, d.streamLimits.MaxFileSize), func(row []string) error {
+		values, err := d.validatorDomain.ValidateStreamRow(ctx, rows, row)
+		if err != nil {
+			return err
+		}
+		checksum.addRow(values, rows.Scale())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stopOperation := profile.Start(ctx, profile.Operation)
+	var result *matrixlib.Result[int64]
+	if precision := precisionFromContext(ctx); precision != noPrecision {
+		result, err = rows.ResultWithPrecision(precision)
+	} else {
+		result, err = rows.Result()
+	}
+	stopOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "streamed operation",
+		"operation", operation,
+		"rows", rows.Rows(),
+		"cols", rows.Cols())
+	metrics.ObserveMatrix(ctx, rows.Rows(), rows.Cols())
+
+	return &entity.Result{
+		Scalar: result.Scalar,
+		Input:  &entity.MatrixInfo{Rows: rows.Rows(), Cols: rows.Cols(), Checksum: checksum.sum()},
+	}, nil
+}
+
+func (d *matrixDomain) ProcessArchive(ctx context.Context, operation string, archive []byte) ([]*entity.FileResult, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	// Reject an unknown operation once instead of reporting it for every file
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	metrics.SetOperation(ctx, operation)
+
+	entries, err := d.archiveRepository.ReadArchive(ctx, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*entity.FileResult, 0, len(entries))
+	for _, entry := range entries {
+		result := &entity.FileResult{File: entry.Name, Err: entry.Err}
+		if result.Err == nil {
+			result.Result, result.Err = d.processArchiveEntry(ctx, operation, entry)
+		}
+
+		// A cancelled request aborts the whole batch rather than failing the remaining files one by one
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if result.Err != nil {
+			slog.WarnContext(ctx, "archive file failed",
+				"operation", operation,
+				"file", entry.Name,
+				"error", result.Err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (d *matrixDomain) processArchiveEntry(ctx context.Context, operation string, entry *repository.ArchiveEntry) (*entity.Result, error) {
+	labels := newMatrixLabels(parsing.FromContext(ctx))
+	matrix, err := d.validatorDomain.Validate(ctx, labels.strip(entry.Content))
+	if err != nil {
+		return nil, err
 	}
+	err = labels.check(matrix.Cols())
+	if err != nil {
+		return nil, err
+	}
+	metrics.ObserveMatrix(ctx, matrix.Rows(), matrix.Cols())
+
+	result, err := d.runOperation(ctx, matrix, operation)
+	if err != nil {
+		return nil, err
+	}
+	labels.apply(result, operation, nil)
+	return result, nil
+}
+
+func (d *matrixDomain) ConcatMatrices(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Result, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	metrics.SetOperation(ctx, concatOperation)
+	joined, err := d.joinMatrices(ctx, filePath, filePath2, axis)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.runSelectedOperation(ctx, joined, string(matrixlib.Echo), selection)
+}
+
+// joinMatrices reads the matrix files at filePath and filePath2 and joins them along axis,
+// holding the joined matrix to the limits of any other matrix.
+func (d *matrixDomain) joinMatrices(ctx context.Context, filePath string, filePath2 string, axis string) (*entity.Matrix[int64], error) {
+	parsedAxis, err := matrixlib.ParseAxis(cmp.Or(axis, string(matrixlib.Horizontal)))
+	if err != nil {
+		return nil, err
+	}
+	if filePath2 == "" {
+		return nil, fmt.Errorf("%w: file2 parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	first, err := d.readMatrix(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	second, err := d.readMatrix(ctx, filePath2)
+	if err != nil {
+		return nil, err
+	}
+
+	joined, err := matrixlib.Concat(first, second, parsedAxis)
+	if err != nil {
+		return nil, err
+	}
+	// The joined matrix is held to the limits of any other matrix, so it can be saved and read back
+	err = d.validatorDomain.ValidateMatrix(ctx, joined)
+	if err != nil {
+		return nil, err
+	}
+	return joined, nil
+}
+
+func (d *matrixDomain) MultiplyMatrices(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Result, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	metrics.SetOperation(ctx, matmulOperation)
+	product, err := d.matrixProduct(ctx, filePath, filePath2)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.runSelectedOperation(ctx, product, string(matrixlib.Echo), selection)
+}
+
+// matrixProduct reads the matrix files at filePath and filePath2 and multiplies them. The product needs no
+// validation against the limits: its rows are those of the first matrix and its columns those of the second.
+func (d *matrixDomain) matrixProduct(ctx context.Context, filePath string, filePath2 string) (*entity.Matrix[int64], error) {
+	if filePath2 == "" {
+		return nil, fmt.Errorf("%w: file2 parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	first, err := d.readMatrix(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	second, err := d.readMatrix(ctx, filePath2)
+	if err != nil {
+		return nil, err
+	}
+
+	defer profile.Start(ctx, profile.Operation)()
+	return matrixlib.MatMul(first, second)
+}
+
+func (d *matrixDomain) PlanMatrix(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Plan, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.validatorDomain.ValidateFileRef(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.planRows(ctx, operation, selection, func(ctx context.Context, handleRow repository.RowHandler) error {
+		return d.matrixRepository.StreamFileContent(ctx, filePath, handleRow)
+	})
 }
 
-func (d *matrixDomain) ListMatrixOperations() (string, error) {
-	allOperations := d.operationsDomain.ListOperations()
+func (d *matrixDomain) PlanMatrixReader(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Plan, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
 
-	operationsStr := `
-	Are you lost?
-	Try using this sample URL: 
-	http://localhost:8080/matrix/sum?file=testdata/matrix1.csv
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
 
-	Other available operations: 
-	`
-	for i, op := range allOperations {
-		if i > 0 {
-			operationsStr += ","
+	return d.planRows(ctx, operation, selection, func(ctx context.Context, handleRow repository.RowHandler) error {
+		return d.matrixRepository.StreamContent(ctx, r, handleRow)
+	})
+}
+
+// planRows is the dry run of processRows, assembling or checking the rows produced by stream
+// like processRows does and planning the operation on them. The operation must already be validated.
+func (d *matrixDomain) planRows(ctx context.Context, operation string, selection *entity.Selection,
+	stream rowStream) (*entity.Plan, error) {
+	labels := newMatrixLabels(parsing.FromContext(ctx))
+	stream = labels.wrap(stream)
+
+	// Matrices that would be streamed are checked as a stream too, as they may be too large to assemble
+	if selection.IsEmpty() && d.streamLimits.MaxRows > 0 && matrixlib.Streamable(matrixlib.Operation(operation)) {
+		input, err := d.checkStream(ctx, stream)
+		if err == nil {
+			err = labels.check(input.Cols)
 		}
-		operationsStr += op
+		if err != nil {
+			return nil, err
+		}
+		return d.plan(ctx, operation, input, true)
 	}
 
-	return operationsStr, nil
+	matrix, err := d.assembleMatrix(ctx, labels, stream)
+	if err != nil {
+		return nil, err
+	}
+	return d.planSelected(ctx, matrix, operation, selection)
 }
 
-func (d *matrixDomain) ProcessMatrix(ctx context.Context, operation string, filePath string) (string, error) {
+func (d *matrixDomain) PlanMatrixData(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Plan, error) {
 	// Check if context is already cancelled
 	if err := ctx.Err(); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if operation == "" {
-		return "", fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.validatorDomain.ValidateMatrix(ctx, matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.planSelected(ctx, matrix, operation, selection)
+}
+
+func (d *matrixDomain) PlanConcat(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Plan, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	joined, err := d.joinMatrices(ctx, filePath, filePath2, axis)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.planSelected(ctx, joined, string(matrixlib.Echo), selection)
+}
+
+func (d *matrixDomain) PlanMultiplyMatrices(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Plan, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	product, err := d.matrixProduct(ctx, filePath, filePath2)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.planSelected(ctx, product, string(matrixlib.Echo), selection)
+}
+
+// checkStream validates the rows produced by stream under the stream limits without running any operation
+// on them, and describes the matrix they make up.
+func (d *matrixDomain) checkStream(ctx context.Context, stream rowStream) (*entity.MatrixInfo, error) {
+	rows := matrixlib.NewCheckStream[int64](matrixlib.Limits{
+		MaxRows: d.streamLimits.MaxRows,
+		MaxCols: d.streamLimits.MaxCols,
+	})
+
+	checksum := newMatrixChecksum()
+	err := stream(repository.WithMaxFileSize(ctx, d.streamLimits.MaxFileSize), func(row []string) error {
+		values, err := d.validatorDomain.ValidateStreamRow(ctx, rows, row)
+		if err != nil {
+			return err
+		}
+		checksum.addRow(values, rows.Scale())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty stream is rejected like it would be when the operation runs
+	_, err = rows.Result()
+	if err != nil {
+		return nil, err
+	}
+	return &entity.MatrixInfo{Rows: rows.Rows(), Cols: rows.Cols(), Checksum: checksum.sum()}, nil
+}
+
+// planSelected narrows a validated matrix down to the selected submatrix and plans the operation on it.
+func (d *matrixDomain) planSelected(ctx context.Context, matrix *entity.Matrix[int64], operation string, selection *entity.Selection) (*entity.Plan, error) {
+	submatrix, err := selectSubmatrix(matrix, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.plan(ctx, operation, describeMatrix(submatrix), false)
+}
+
+// plan works out how operation would run on the matrix described by input.
+func (d *matrixDomain) plan(ctx context.Context, operation string, input *entity.MatrixInfo, streamed bool) (*entity.Plan, error) {
+	operationPlan, err := d.operationsDomain.PlanOperation(ctx, operation, input.Rows, input.Cols)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "planned operation",
+		"operation", operation,
+		"rows", input.Rows,
+		"cols", input.Cols,
+		"cost", operationPlan.Cost)
+
+	return &entity.Plan{Operation: operationPlan, Input: input, Streamed: streamed}, nil
+}
+
+func (d *matrixDomain) SplitMatrix(ctx context.Context, filePath string, rows int, cols int) ([]*entity.Tile, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	matrix, err := d.readMatrix(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	grid, err := matrixlib.Split(matrix, rows, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	tiles := make([]*entity.Tile, 0, rows*cols)
+	for i, band := range grid {
+		for j, tile := range band {
+			tiles = append(tiles, &entity.Tile{
+				Row:    i + 1,
+				Col:    j + 1,
+				Rows:   entity.IndexRange{Start: i * tile.Rows(), End: (i+1)*tile.Rows() - 1},
+				Cols:   entity.IndexRange{Start: j * tile.Cols(), End: (j+1)*tile.Cols() - 1},
+				Matrix: tile,
+			})
+		}
+	}
+	return tiles, nil
+}
+
+// readMatrix reads and validates the matrix file at filePath. The labels of a file read with header
+// or row labels are checked and dropped.
+func (d *matrixDomain) readMatrix(ctx context.Context, filePath string) (*entity.Matrix[int64], error) {
+	err := d.validatorDomain.ValidateFileRef(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := newMatrixLabels(parsing.FromContext(ctx))
+	return d.assembleMatrix(ctx, labels, labels.wrap(func(ctx context.Context, handleRow repository.RowHandler) error {
+		return d.matrixRepository.StreamFileContent(ctx, filePath, handleRow)
+	}))
+}
+
+func (d *matrixDomain) MemoMetrics() []*entity.Metric {
+	return d.memo.metrics()
+}
+
+func (d *matrixDomain) CacheMetrics() []*entity.Metric {
+	return d.files.metrics()
+}
+
+func (d *matrixDomain) FlushCache() int {
+	return d.memo.flush() + d.files.flush()
+}
+
+func (d *matrixDomain) InvalidateCache(ctx context.Context, key string) int {
+	return d.memo.invalidate(key) + d.files.invalidate(ctx, key)
+}
+
+func (d *matrixDomain) SaveResult(ctx context.Context, filePath string, result *entity.Result) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
 	err := d.validatorDomain.ValidateFilePath(ctx, filePath)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if result == nil {
+		return fmt.Errorf("%w: no result to save", apperrors.ErrUnprocessableEntity)
 	}
 
-	err = d.operationsDomain.IsValidOperation(ctx, operation)
+	err = d.matrixRepository.SaveFileContent(ctx, filePath, resultContent(result))
 	if err != nil {
-		return "", err
+		slog.ErrorContext(ctx, "failed to save result",
+			"file_path", filePath,
+			"error", err)
+		return err
+	}
+
+	return nil
+}
+
+// resultContent converts result to the rows of a CSV file: the rows of a matrix result,
+// or a single-cell matrix holding a scalar result.
+func resultContent(result *entity.Result) *repository.MatrixFileContent {
+	content := &repository.MatrixFileContent{}
+	if result.Matrix == nil {
+		content.Content = [][]string{{result.Scalar}}
+		return content
+	}
+
+	content.Content = make([][]string, 0, result.Matrix.Rows())
+	for _, row := range result.Matrix.Data {
+		record := make([]string, len(row))
+		for j, val := range row {
+			record[j] = string(matrixlib.AppendValue(nil, val, result.Matrix.Scale))
+		}
+		content.Content = append(content.Content, record)
 	}
+	return content
+}
 
-	rawData, err := d.matrixRepository.GetFileContent(ctx, filePath)
+// runSelectedOperation narrows a validated matrix down to the selected submatrix and runs the operation on it.
+// A result remembered for the same submatrix and operation is returned without running the operation again.
+func (d *matrixDomain) runSelectedOperation(ctx context.Context, matrix *entity.Matrix[int64], operation string, selection *entity.Selection) (*entity.Result, error) {
+	submatrix, err := selectSubmatrix(matrix, selection)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	input := describeMatrix(submatrix)
+	key := memoKey{checksum: input.Checksum, operation: operation, precision: precisionFromContext(ctx)}
+	if result, ok := d.memo.get(key); ok {
+		slog.DebugContext(ctx, "memoized operation",
+			"operation", operation,
+			"checksum", input.Checksum)
+		return result, nil
 	}
 
-	validatedMatrix, err := d.validatorDomain.Validate(ctx, rawData)
+	result, err := d.runOperation(ctx, submatrix, operation)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	result.Input = input
+	d.memo.put(key, result)
+	return result, nil
+}
+
+// describeMatrix computes the dimensions and checksum reported with a result.
+func describeMatrix(matrix *entity.Matrix[int64]) *entity.MatrixInfo {
+	info := &entity.MatrixInfo{Rows: matrix.Rows(), Cols: matrix.Cols()}
+
+	checksum := newMatrixChecksum()
+	for _, row := range matrix.Data {
+		checksum.addRow(row, matrix.Scale)
 	}
+	info.Checksum = checksum.sum()
+
+	return info
+}
+
+// matrixChecksum computes the SHA-256 of a matrix in canonical CSV form, one row at a time.
+// Decimals are written without trailing zeros, so the checksum does not depend on the scale they are held at.
+type matrixChecksum struct {
+	hash hash.Hash
+	buf  []byte
+}
+
+func newMatrixChecksum() *matrixChecksum {
+	return &matrixChecksum{hash: sha256.New(), buf: make([]byte, 0, 64)}
+}
+
+// addRow hashes the next row of the matrix, whose values are held at scale.
+func (c *matrixChecksum) addRow(row []int64, scale int) {
+	c.buf = c.buf[:0]
+	for j, val := range row {
+		if j > 0 {
+			c.buf = append(c.buf, ',')
+		}
+		c.buf = matrixlib.AppendValue(c.buf, val, scale)
+	}
+	c.buf = append(c.buf, '\n')
+	c.hash.Write(c.buf)
+}
+
+// sum returns the hex-encoded checksum of the rows added so far.
+func (c *matrixChecksum) sum() string {
+	return hex.EncodeToString(c.hash.Sum(nil))
+}
+
+func (d *matrixDomain) runOperation(ctx context.Context, matrix *entity.Matrix[int64], operation string) (*entity.Result, error) {
+	defer profile.Start(ctx, profile.Operation)()
 
-	result, err := d.operationsDomain.RunOperation(ctx, validatedMatrix, operation)
+	result, err := d.operationsDomain.RunOperation(ctx, matrix, operation)
 	if err != nil {
-		slog.Error("operation execution failed",
+		slog.ErrorContext(ctx, "operation execution failed",
 			"operation", operation,
 			"error", err)
-		return "", err
+		return nil, err
 	}
 
 	return result, nil
@@ -3,199 +3,242 @@ package domain
 import (
 	"context"
 	"fmt"
-	"math/big"
-	"strings"
+	"sync"
 
 	"github.com/matsuboshi/league-matrix-app/internal/entity"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
 )
 
-type Operation string
+// Operation is a matrix operation run by MatrixOperationsDomainInterface. The built-in operations of the matrix
+// engine are registered at init time, and RegisterOperation adds others.
+type Operation interface {
+	// Name returns the name the operation is requested by, such as sum.
+	Name() string
 
-const (
-	SumOperation      Operation = "sum"
-	MultiplyOperation Operation = "multiply"
-	EchoOperation     Operation = "echo"
-	InvertOperation   Operation = "invert"
-	FlattenOperation  Operation = "flatten"
-)
+	// Validate checks that the operation can run on matrix, before it waits for a worker of the execution pool.
+	Validate(matrix *entity.Matrix[int64]) error
 
-var matrixOperations = map[Operation]bool{
-	SumOperation:      true,
-	MultiplyOperation: true,
-	EchoOperation:     true,
-	InvertOperation:   true,
-	FlattenOperation:  true,
+	// Run executes the operation on matrix, once validated. Matrix results must not share memory with matrix.
+	Run(matrix *entity.Matrix[int64]) (*entity.Result, error)
 }
 
-// MatrixOperationsDomainInterface defines the contract for performing operations on matrices.
-// It provides methods to list, validate, and execute various matrix transformations and calculations.
-type MatrixOperationsDomainInterface interface {
-	// ListOperations returns a list of all supported matrix operation names.
-	ListOperations() []string
-
-	// IsValidOperation checks if the given operation name is supported.
-	IsValidOperation(ctx context.Context, operation string) error
-
-	// RunOperation executes the specified operation on the given matrix.
-	// Returns the result as a formatted string or an error if the operation fails.
-	RunOperation(ctx context.Context, matrix *entity.Matrix, operation string) (string, error)
+// operationDescriber is implemented by the operations that describe what they return, for the operation listings.
+type operationDescriber interface {
+	Description() string
 }
 
-type matrixOperationsDomain struct{}
+// operations holds the operations run by the matrix operations domain, by name.
+// Whether an operation exists and is enabled is up to the registry of the matrix engine, which lists them all.
+var operations = struct {
+	mu     sync.RWMutex
+	byName map[string]Operation
+}{byName: make(map[string]Operation)}
 
-// NewMatrixOperationsDomain creates a new instance of MatrixOperationsDomainInterface.
-// It returns an operations service that can execute all supported matrix operations.
-func NewMatrixOperationsDomain() MatrixOperationsDomainInterface {
-	return &matrixOperationsDomain{}
+func init() {
+	for _, info := range matrixlib.DefaultRegistry.DescribeAll() {
+		operations.byName[string(info.Name)] = engineOperation(info.Name)
+	}
 }
 
-func (d *matrixOperationsDomain) ListOperations() []string {
-	operations := make([]string, 0, len(matrixOperations))
-	for op := range matrixOperations {
-		operations = append(operations, string(op))
+// RegisterOperation adds an operation to the ones the service runs, from then on until the process exits.
+// It is listed, validated and switched on and off through the operation registry like the built-in operations,
+// with the description returned by its Description() string method, if it has one, but it cannot be a step of
+// a composite operation, streamed or planned by a dry run. It returns ErrConflict when the name is taken
+// and ErrInvalidInput for an invalid name.
+func RegisterOperation(operation Operation) error {
+	var description string
+	if describer, ok := operation.(operationDescriber); ok {
+		description = describer.Description()
 	}
-	return operations
-}
 
-func (d *matrixOperationsDomain) IsValidOperation(ctx context.Context, operation string) error {
-	// Check if context is already cancelled
-	if err := ctx.Err(); err != nil {
+	// The operation is only looked up once registered with the engine, so both happen at once
+	operations.mu.Lock()
+	defer operations.mu.Unlock()
+
+	err := matrixlib.DefaultRegistry.RegisterExternal(matrixlib.Operation(operation.Name()), description)
+	if err != nil {
 		return err
 	}
+	operations.byName[operation.Name()] = operation
+	return nil
+}
+
+// lookupOperation returns the enabled operation with the given name. Operations of the engine without
+// an entry, such as the composite operations registered at runtime, are run by the engine.
+func lookupOperation(name string) (Operation, error) {
+	operations.mu.RLock()
+	defer operations.mu.RUnlock()
 
-	if !matrixOperations[Operation(operation)] {
-		return fmt.Errorf("%w: invalid operation: %s", apperrors.ErrInvalidInput, operation)
+	if err := matrixlib.ValidateOperation(name); err != nil {
+		return nil, err
 	}
+	if operation, ok := operations.byName[name]; ok {
+		return operation, nil
+	}
+	return engineOperation(name), nil
+}
+
+// engineOperation is an operation run by the matrix engine.
+type engineOperation matrixlib.Operation
+
+func (o engineOperation) Name() string {
+	return string(o)
+}
+
+func (o engineOperation) Validate(*entity.Matrix[int64]) error {
+	// The engine validates the matrix as it runs the operation
 	return nil
 }
 
-func (d *matrixOperationsDomain) RunOperation(ctx context.Context, matrix *entity.Matrix, operation string) (string, error) {
-	// Check if context is already cancelled
-	if err := ctx.Err(); err != nil {
-		return "", err
+func (o engineOperation) Run(matrix *entity.Matrix[int64]) (*entity.Result, error) {
+	result, err := matrixlib.Run(matrixlib.Operation(o), matrix)
+	if err != nil {
+		return nil, err
 	}
+	return &entity.Result{Matrix: result.Matrix, Scalar: result.Scalar}, nil
+}
+
+// runWithPrecision executes the operation on matrix like Run, with its result rounded to precision decimal places.
+func (o engineOperation) runWithPrecision(matrix *entity.Matrix[int64], precision int) (*entity.Result, error) {
+	result, err := matrixlib.RunWithPrecision(matrixlib.Operation(o), matrix, precision)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Result{Matrix: result.Matrix, Scalar: result.Scalar}, nil
+}
 
-	chosenOperation := Operation(operation)
+// noPrecision is the precision of a context without one, whose results keep the rounding of the matrix engine.
+const noPrecision = -1
 
-	switch chosenOperation {
-	case SumOperation:
-		return d.sum(matrix)
-	case MultiplyOperation:
-		return d.multiply(matrix)
-	case EchoOperation:
-		return d.echo(matrix)
-	case InvertOperation:
-		return d.invert(matrix)
-	case FlattenOperation:
-		return d.flatten(matrix)
-	default:
-		return "", fmt.Errorf("%w: unsupported operation: %s", apperrors.ErrInvalidInput, operation)
+type precisionContextKey struct{}
+
+// WithPrecision returns a copy of ctx rounding the results of the operations of the matrix engine run with it
+// to precision decimal places: the inverse, and the mean and median, which are otherwise written as a fraction
+// when they have no short decimal. Operations registered with RegisterOperation are left as they are.
+// It fails with ErrInvalidInput when precision is not between 0 and 18.
+func WithPrecision(ctx context.Context, precision int) (context.Context, error) {
+	if err := matrixlib.ValidatePrecision(precision); err != nil {
+		return nil, err
 	}
+	return context.WithValue(ctx, precisionContextKey{}, precision), nil
 }
 
-func (d *matrixOperationsDomain) sum(matrix *entity.Matrix) (string, error) {
-	if matrix == nil || len(matrix.Data) == 0 {
-		return "", fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+// precisionFromContext returns the precision carried by ctx, or noPrecision without one.
+func precisionFromContext(ctx context.Context) int {
+	precision, ok := ctx.Value(precisionContextKey{}).(int)
+	if !ok {
+		return noPrecision
 	}
+	return precision
+}
 
-	// Use big.Int for arbitrary precision to avoid overflow
-	sum := big.NewInt(0)
-	for _, row := range matrix.Data {
-		for _, val := range row {
-			sum.Add(sum, big.NewInt(val))
-		}
+// runOperation executes op on matrix, rounding the results of the operations of the engine to the precision
+// carried by ctx.
+func runOperation(ctx context.Context, op Operation, matrix *entity.Matrix[int64]) (*entity.Result, error) {
+	engine, ok := op.(engineOperation)
+	if precision := precisionFromContext(ctx); ok && precision != noPrecision {
+		return engine.runWithPrecision(matrix, precision)
 	}
+	return op.Run(matrix)
+}
+
+// MatrixOperationsDomainInterface defines the contract for performing operations on matrices.
+// It provides methods to list, validate, and execute various matrix transformations and calculations.
+type MatrixOperationsDomainInterface interface {
+	// ListOperations returns a list of all supported matrix operation names, in alphabetical order.
+	ListOperations() []string
+
+	// DescribeOperations returns every supported operation with its description, in alphabetical order.
+	DescribeOperations() []entity.OperationInfo
 
-	return sum.String(), nil
+	// IsValidOperation checks if the given operation name is supported.
+	IsValidOperation(ctx context.Context, operation string) error
+
+	// RunOperation executes the specified operation on the given matrix.
+	// Operations run on a bounded pool of workers shared by the whole process; when every worker
+	// is busy the call waits for a free one, or until the context is done.
+	// Returns the operation result or an error if the operation fails.
+	RunOperation(ctx context.Context, matrix *entity.Matrix[int64], operation string) (*entity.Result, error)
+
+	// PlanOperation works out how the specified operation would run on a matrix of rows by cols values,
+	// without running it. It fails like IsValidOperation for operations that cannot run.
+	PlanOperation(ctx context.Context, operation string, rows int, cols int) (*entity.OperationPlan, error)
 }
 
-func (d *matrixOperationsDomain) multiply(matrix *entity.Matrix) (string, error) {
-	if matrix == nil || len(matrix.Data) == 0 {
-		return "", fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
-	}
+type matrixOperationsDomain struct {
+	pool *executionPool
+}
 
-	// Use big.Int for arbitrary precision to avoid overflow
-	product := big.NewInt(1)
-	for _, row := range matrix.Data {
-		for _, val := range row {
-			product.Mul(product, big.NewInt(val))
-		}
+// NewMatrixOperationsDomain creates a new instance of MatrixOperationsDomainInterface.
+// It returns an operations service running the registered operations, listed by the registry of the exported
+// matrix engine, which schedules operations on the execution pool shared by the process.
+func NewMatrixOperationsDomain() MatrixOperationsDomainInterface {
+	return &matrixOperationsDomain{
+		pool: sharedExecutionPool(),
 	}
+}
 
-	return product.String(), nil
+func (d *matrixOperationsDomain) ListOperations() []string {
+	return matrixlib.Operations()
 }
 
-func (d *matrixOperationsDomain) echo(matrix *entity.Matrix) (string, error) {
-	if matrix == nil || len(matrix.Data) == 0 {
-		return "", fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
-	}
+func (d *matrixOperationsDomain) DescribeOperations() []entity.OperationInfo {
+	return matrixlib.DescribeOperations()
+}
 
-	var builder strings.Builder
-	for i, row := range matrix.Data {
-		for j, val := range row {
-			if j > 0 {
-				builder.WriteString(",")
-			}
-			builder.WriteString(fmt.Sprintf("%d", val))
-		}
-		if i < len(matrix.Data)-1 {
-			builder.WriteString("\n")
-		}
+func (d *matrixOperationsDomain) IsValidOperation(ctx context.Context, operation string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	return builder.String(), nil
+	return matrixlib.ValidateOperation(operation)
 }
 
-func (d *matrixOperationsDomain) invert(matrix *entity.Matrix) (string, error) {
-	if matrix == nil || len(matrix.Data) == 0 {
-		return "", fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+func (d *matrixOperationsDomain) RunOperation(ctx context.Context, matrix *entity.Matrix[int64], operation string) (*entity.Result, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	rows := len(matrix.Data)
-	cols := len(matrix.Data[0])
-
-	// Transpose the matrix
-	inverted := make([][]int64, cols)
-	for i := range inverted {
-		inverted[i] = make([]int64, rows)
-		for j := range inverted[i] {
-			inverted[i][j] = matrix.Data[j][i]
-		}
+	op, err := lookupOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+	err = op.Validate(matrix)
+	if err != nil {
+		return nil, err
 	}
 
-	var builder strings.Builder
-	for i, row := range inverted {
-		for j, val := range row {
-			if j > 0 {
-				builder.WriteString(",")
-			}
-			builder.WriteString(fmt.Sprintf("%d", val))
-		}
-		if i < len(inverted)-1 {
-			builder.WriteString("\n")
-		}
+	var result *entity.Result
+	poolErr := d.pool.run(ctx, func() {
+		result, err = runOperation(ctx, op, matrix)
+	})
+	if poolErr != nil {
+		return nil, poolErr
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return builder.String(), nil
+	return result, nil
 }
 
-func (d *matrixOperationsDomain) flatten(matrix *entity.Matrix) (string, error) {
-	if matrix == nil || len(matrix.Data) == 0 {
-		return "", fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+func (d *matrixOperationsDomain) PlanOperation(ctx context.Context, operation string, rows int, cols int) (*entity.OperationPlan, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	var builder strings.Builder
-	first := true
-	for _, row := range matrix.Data {
-		for _, val := range row {
-			if !first {
-				builder.WriteString(",")
-			}
-			builder.WriteString(fmt.Sprintf("%d", val))
-			first = false
-		}
+	op, err := lookupOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := op.(engineOperation); !ok {
+		return nil, fmt.Errorf("%w: operation %s cannot be planned without running it",
+			apperrors.ErrUnprocessableEntity, operation)
 	}
 
-	return builder.String(), nil
+	return matrixlib.PlanOperation(matrixlib.Operation(operation), rows, cols)
 }
@@ -2,12 +2,16 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/matsuboshi/league-matrix-app/internal/entity"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
 )
 
 func TestMatrixOperationsDomain_ListOperations(t *testing.T) {
@@ -19,9 +23,24 @@ func TestMatrixOperationsDomain_ListOperations(t *testing.T) {
 	assert.Contains(t, operations, "sum")
 	assert.Contains(t, operations, "multiply")
 	assert.Contains(t, operations, "echo")
+	assert.Contains(t, operations, "transpose")
 	assert.Contains(t, operations, "invert")
+	assert.Contains(t, operations, "inverse")
 	assert.Contains(t, operations, "flatten")
-	assert.Len(t, operations, 5)
+	assert.Len(t, operations, 11)
+}
+
+func TestMatrixOperationsDomain_DescribeOperations(t *testing.T) {
+	domain := NewMatrixOperationsDomain()
+
+	infos := domain.DescribeOperations()
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, string(info.Name))
+		assert.NotEmpty(t, info.Description)
+	}
+	assert.Equal(t, domain.ListOperations(), names)
 }
 
 func TestMatrixOperationsDomain_IsValidOperation(t *testing.T) {
@@ -51,6 +70,16 @@ func TestMatrixOperationsDomain_IsValidOperation(t *testing.T) {
 			operation: "invert",
 			wantErr:   false,
 		},
+		{
+			name:      "valid operation - transpose",
+			operation: "transpose",
+			wantErr:   false,
+		},
+		{
+			name:      "valid operation - inverse",
+			operation: "inverse",
+			wantErr:   false,
+		},
 		{
 			name:      "valid operation - flatten",
 			operation: "flatten",
@@ -94,494 +123,11 @@ func TestMatrixOperationsDomain_IsValidOperation(t *testing.T) {
 	}
 }
 
-func TestMatrixOperationsDomain_Sum(t *testing.T) {
-	tests := []struct {
-		name    string
-		matrix  *entity.Matrix
-		want    string
-		wantErr bool
-		errType error
-	}{
-		{
-			name: "sum of 2x2 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2},
-					{3, 4},
-				},
-			},
-			want:    "10",
-			wantErr: false,
-		},
-		{
-			name: "sum of 3x3 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2, 3},
-					{4, 5, 6},
-					{7, 8, 9},
-				},
-			},
-			want:    "45",
-			wantErr: false,
-		},
-		{
-			name: "sum with negative numbers",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{-1, -2},
-					{-3, -4},
-				},
-			},
-			want:    "-10",
-			wantErr: false,
-		},
-		{
-			name: "sum with large numbers",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1000000, 1000000},
-					{1000000, 1000000},
-				},
-			},
-			want:    "4000000",
-			wantErr: false,
-		},
-		{
-			name: "sum of single element",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{42}},
-			},
-			want:    "42",
-			wantErr: false,
-		},
-		{
-			name:    "empty matrix",
-			matrix:  &entity.Matrix{Data: [][]int64{}},
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-		{
-			name:    "nil matrix",
-			matrix:  nil,
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			domain := &matrixOperationsDomain{}
-
-			got, err := domain.sum(tt.matrix)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errType != nil {
-					assert.ErrorIs(t, err, tt.errType)
-				}
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
-func TestMatrixOperationsDomain_Multiply(t *testing.T) {
-	tests := []struct {
-		name    string
-		matrix  *entity.Matrix
-		want    string
-		wantErr bool
-		errType error
-	}{
-		{
-			name: "multiply 2x2 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{2, 3},
-					{4, 5},
-				},
-			},
-			want:    "120",
-			wantErr: false,
-		},
-		{
-			name: "multiply 3x3 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2, 3},
-					{4, 5, 6},
-					{7, 8, 9},
-				},
-			},
-			want:    "362880",
-			wantErr: false,
-		},
-		{
-			name: "multiply with zero",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 0, 3},
-					{4, 5, 6},
-				},
-			},
-			want:    "0",
-			wantErr: false,
-		},
-		{
-			name: "multiply with negative numbers",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{-2, 3},
-					{4, -5},
-				},
-			},
-			want:    "120",
-			wantErr: false,
-		},
-		{
-			name: "multiply single element",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{7}},
-			},
-			want:    "7",
-			wantErr: false,
-		},
-		{
-			name:    "empty matrix",
-			matrix:  &entity.Matrix{Data: [][]int64{}},
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-		{
-			name:    "nil matrix",
-			matrix:  nil,
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			domain := &matrixOperationsDomain{}
-
-			got, err := domain.multiply(tt.matrix)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errType != nil {
-					assert.ErrorIs(t, err, tt.errType)
-				}
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
-func TestMatrixOperationsDomain_Echo(t *testing.T) {
-	tests := []struct {
-		name    string
-		matrix  *entity.Matrix
-		want    string
-		wantErr bool
-		errType error
-	}{
-		{
-			name: "echo 2x2 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2},
-					{3, 4},
-				},
-			},
-			want:    "1,2\n3,4",
-			wantErr: false,
-		},
-		{
-			name: "echo 3x3 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2, 3},
-					{4, 5, 6},
-					{7, 8, 9},
-				},
-			},
-			want:    "1,2,3\n4,5,6\n7,8,9",
-			wantErr: false,
-		},
-		{
-			name: "echo single element",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{42}},
-			},
-			want:    "42",
-			wantErr: false,
-		},
-		{
-			name: "echo with negative numbers",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{-1, -2},
-					{-3, -4},
-				},
-			},
-			want:    "-1,-2\n-3,-4",
-			wantErr: false,
-		},
-		{
-			name:    "empty matrix",
-			matrix:  &entity.Matrix{Data: [][]int64{}},
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-		{
-			name:    "nil matrix",
-			matrix:  nil,
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			domain := &matrixOperationsDomain{}
-
-			got, err := domain.echo(tt.matrix)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errType != nil {
-					assert.ErrorIs(t, err, tt.errType)
-				}
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
-func TestMatrixOperationsDomain_Invert(t *testing.T) {
-	tests := []struct {
-		name    string
-		matrix  *entity.Matrix
-		want    string
-		wantErr bool
-		errType error
-	}{
-		{
-			name: "invert 2x2 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2},
-					{3, 4},
-				},
-			},
-			want:    "1,3\n2,4",
-			wantErr: false,
-		},
-		{
-			name: "invert 3x3 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2, 3},
-					{4, 5, 6},
-					{7, 8, 9},
-				},
-			},
-			want:    "1,4,7\n2,5,8\n3,6,9",
-			wantErr: false,
-		},
-		{
-			name: "invert rectangular matrix 2x3",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2, 3},
-					{4, 5, 6},
-				},
-			},
-			want:    "1,4\n2,5\n3,6",
-			wantErr: false,
-		},
-		{
-			name: "invert rectangular matrix 3x2",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2},
-					{3, 4},
-					{5, 6},
-				},
-			},
-			want:    "1,3,5\n2,4,6",
-			wantErr: false,
-		},
-		{
-			name: "invert single element",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{42}},
-			},
-			want:    "42",
-			wantErr: false,
-		},
-		{
-			name: "invert single row",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{1, 2, 3, 4}},
-			},
-			want:    "1\n2\n3\n4",
-			wantErr: false,
-		},
-		{
-			name: "invert single column",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{1}, {2}, {3}, {4}},
-			},
-			want:    "1,2,3,4",
-			wantErr: false,
-		},
-		{
-			name:    "empty matrix",
-			matrix:  &entity.Matrix{Data: [][]int64{}},
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-		{
-			name:    "nil matrix",
-			matrix:  nil,
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			domain := &matrixOperationsDomain{}
-
-			got, err := domain.invert(tt.matrix)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errType != nil {
-					assert.ErrorIs(t, err, tt.errType)
-				}
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
-func TestMatrixOperationsDomain_Flatten(t *testing.T) {
-	tests := []struct {
-		name    string
-		matrix  *entity.Matrix
-		want    string
-		wantErr bool
-		errType error
-	}{
-		{
-			name: "flatten 2x2 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2},
-					{3, 4},
-				},
-			},
-			want:    "1,2,3,4",
-			wantErr: false,
-		},
-		{
-			name: "flatten 3x3 matrix",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{1, 2, 3},
-					{4, 5, 6},
-					{7, 8, 9},
-				},
-			},
-			want:    "1,2,3,4,5,6,7,8,9",
-			wantErr: false,
-		},
-		{
-			name: "flatten single element",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{42}},
-			},
-			want:    "42",
-			wantErr: false,
-		},
-		{
-			name: "flatten single row",
-			matrix: &entity.Matrix{
-				Data: [][]int64{{1, 2, 3, 4, 5}},
-			},
-			want:    "1,2,3,4,5",
-			wantErr: false,
-		},
-		{
-			name: "flatten with negative numbers",
-			matrix: &entity.Matrix{
-				Data: [][]int64{
-					{-1, -2},
-					{-3, -4},
-				},
-			},
-			want:    "-1,-2,-3,-4",
-			wantErr: false,
-		},
-		{
-			name:    "empty matrix",
-			matrix:  &entity.Matrix{Data: [][]int64{}},
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-		{
-			name:    "nil matrix",
-			matrix:  nil,
-			want:    "",
-			wantErr: true,
-			errType: apperrors.ErrInvalidInput,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			domain := &matrixOperationsDomain{}
-
-			got, err := domain.flatten(tt.matrix)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errType != nil {
-					assert.ErrorIs(t, err, tt.errType)
-				}
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
 func TestMatrixOperationsDomain_RunOperation(t *testing.T) {
 	tests := []struct {
 		name      string
 		operation string
-		matrix    *entity.Matrix
+		matrix    *entity.Matrix[int64]
 		want      string
 		wantErr   bool
 		errType   error
@@ -589,7 +135,7 @@ func TestMatrixOperationsDomain_RunOperation(t *testing.T) {
 		{
 			name:      "run sum operation",
 			operation: "sum",
-			matrix: &entity.Matrix{
+			matrix: &entity.Matrix[int64]{
 				Data: [][]int64{{1, 2}, {3, 4}},
 			},
 			want:    "10",
@@ -598,7 +144,7 @@ func TestMatrixOperationsDomain_RunOperation(t *testing.T) {
 		{
 			name:      "run multiply operation",
 			operation: "multiply",
-			matrix: &entity.Matrix{
+			matrix: &entity.Matrix[int64]{
 				Data: [][]int64{{2, 3}, {4, 5}},
 			},
 			want:    "120",
@@ -607,7 +153,7 @@ func TestMatrixOperationsDomain_RunOperation(t *testing.T) {
 		{
 			name:      "run echo operation",
 			operation: "echo",
-			matrix: &entity.Matrix{
+			matrix: &entity.Matrix[int64]{
 				Data: [][]int64{{1, 2}, {3, 4}},
 			},
 			want:    "1,2\n3,4",
@@ -616,16 +162,44 @@ func TestMatrixOperationsDomain_RunOperation(t *testing.T) {
 		{
 			name:      "run invert operation",
 			operation: "invert",
-			matrix: &entity.Matrix{
+			matrix: &entity.Matrix[int64]{
+				Data: [][]int64{{1, 2}, {3, 4}},
+			},
+			want:    "1,3\n2,4",
+			wantErr: false,
+		},
+		{
+			name:      "run transpose operation",
+			operation: "transpose",
+			matrix: &entity.Matrix[int64]{
 				Data: [][]int64{{1, 2}, {3, 4}},
 			},
 			want:    "1,3\n2,4",
 			wantErr: false,
 		},
+		{
+			name:      "run inverse operation",
+			operation: "inverse",
+			matrix: &entity.Matrix[int64]{
+				Data: [][]int64{{1, 2}, {3, 4}},
+			},
+			want:    "-2,1\n1.5,-0.5",
+			wantErr: false,
+		},
+		{
+			name:      "inverse of a singular matrix",
+			operation: "inverse",
+			matrix: &entity.Matrix[int64]{
+				Data: [][]int64{{1, 2}, {2, 4}},
+			},
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrUnprocessableEntity,
+		},
 		{
 			name:      "run flatten operation",
 			operation: "flatten",
-			matrix: &entity.Matrix{
+			matrix: &entity.Matrix[int64]{
 				Data: [][]int64{{1, 2}, {3, 4}},
 			},
 			want:    "1,2,3,4",
@@ -634,7 +208,7 @@ func TestMatrixOperationsDomain_RunOperation(t *testing.T) {
 		{
 			name:      "unsupported operation",
 			operation: "unsupported",
-			matrix: &entity.Matrix{
+			matrix: &entity.Matrix[int64]{
 				Data: [][]int64{{1, 2}},
 			},
 			want:    "",
@@ -656,12 +230,50 @@ func TestMatrixOperationsDomain_RunOperation(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.want, got.String())
 			}
 		})
 	}
 }
 
+func TestMatrixOperationsDomain_RunOperation_Precision(t *testing.T) {
+	domain := NewMatrixOperationsDomain()
+	matrix := &entity.Matrix[int64]{Data: [][]int64{{7, -2, 5}}}
+
+	got, err := domain.RunOperation(context.Background(), matrix, "mean")
+	require.NoError(t, err)
+	assert.Equal(t, "10/3", got.Scalar)
+
+	ctx, err := WithPrecision(context.Background(), 2)
+	require.NoError(t, err)
+	got, err = domain.RunOperation(ctx, matrix, "mean")
+	require.NoError(t, err)
+	assert.Equal(t, "3.33", got.Scalar)
+
+	got, err = domain.RunOperation(ctx, &entity.Matrix[int64]{Data: [][]int64{{3}}}, "inverse")
+	require.NoError(t, err)
+	assert.Equal(t, "0.33", got.String())
+
+	_, err = WithPrecision(context.Background(), 19)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestMatrixOperationsDomain_PlanOperation(t *testing.T) {
+	domain := NewMatrixOperationsDomain()
+
+	plan, err := domain.PlanOperation(context.Background(), "flatten", 9, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.OperationPlan{Operation: "flatten", Steps: []matrixlib.Operation{"flatten"}, Rows: 1, Cols: 27, Cost: 27}, plan)
+
+	_, err = domain.PlanOperation(context.Background(), "divide", 9, 3)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = domain.PlanOperation(ctx, "sum", 9, 3)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestMatrixOperationsDomain_ContextCancellation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -705,7 +317,7 @@ func TestMatrixOperationsDomain_ContextCancellation(t *testing.T) {
 			}
 
 			if tt.name == "context cancelled before RunOperation" {
-				matrix := &entity.Matrix{Data: [][]int64{{1, 2}}}
+				matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2}}}
 				_, err := domain.RunOperation(ctx, matrix, tt.operation)
 				if tt.wantErr {
 					assert.Error(t, err)
@@ -715,3 +327,60 @@ func TestMatrixOperationsDomain_ContextCancellation(t *testing.T) {
 		})
 	}
 }
+
+// traceOperation adds up the values of the main diagonal of square matrices.
+type traceOperation struct{}
+
+func (traceOperation) Name() string { return "trace" }
+
+func (traceOperation) Description() string { return "Adds the values of the main diagonal." }
+
+func (traceOperation) Validate(matrix *entity.Matrix[int64]) error {
+	if matrix.Rows() != matrix.Cols() {
+		return fmt.Errorf("%w: trace needs a square matrix", apperrors.ErrUnprocessableEntity)
+	}
+	return nil
+}
+
+func (traceOperation) Run(matrix *entity.Matrix[int64]) (*entity.Result, error) {
+	var trace int64
+	for i := range matrix.Data {
+		trace += matrix.Data[i][i]
+	}
+	return &entity.Result{Scalar: strconv.FormatInt(trace, 10)}, nil
+}
+
+func TestRegisterOperation(t *testing.T) {
+	require.NoError(t, RegisterOperation(traceOperation{}))
+	// The operation cannot be removed, so it is disabled to keep the operations of the other tests unchanged
+	t.Cleanup(func() { require.NoError(t, matrixlib.DefaultRegistry.SetEnabled("trace", false)) })
+	domain := NewMatrixOperationsDomain()
+	ctx := context.Background()
+
+	assert.ErrorIs(t, RegisterOperation(traceOperation{}), apperrors.ErrConflict)
+	assert.Contains(t, domain.ListOperations(), "trace")
+	assert.Contains(t, domain.DescribeOperations(), entity.OperationInfo{
+		Name: "trace", Description: "Adds the values of the main diagonal.", Enabled: true,
+	})
+	assert.NoError(t, domain.IsValidOperation(ctx, "trace"))
+
+	got, err := domain.RunOperation(ctx, &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}, "trace")
+	require.NoError(t, err)
+	assert.Equal(t, "5", got.Scalar)
+
+	_, err = domain.RunOperation(ctx, &entity.Matrix[int64]{Data: [][]int64{{1, 2}}}, "trace")
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+
+	_, err = domain.PlanOperation(ctx, "trace", 2, 2)
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+
+	require.NoError(t, matrixlib.DefaultRegistry.SetEnabled("trace", false))
+	_, err = domain.RunOperation(ctx, &entity.Matrix[int64]{Data: [][]int64{{1}}}, "trace")
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+}
+
+func TestRegisterOperation_BuiltInName(t *testing.T) {
+	err := RegisterOperation(engineOperation(matrixlib.Sum))
+
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+}
@@ -3,35 +3,62 @@ package domain
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/i18n"
 	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
 	"github.com/matsuboshi/league-matrix-app/internal/repository"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
 )
 
 func TestMatrixDomain_ListMatrixOperations(t *testing.T) {
 	tests := []struct {
 		name           string
-		mockOperations []string
-		wantContains   []string
-		wantErr        bool
+		mockOperations []entity.OperationInfo
+		localizer      *i18n.Localizer
+		want           string
 	}{
 		{
-			name:           "successfully list operations",
-			mockOperations: []string{"sum", "multiply", "echo", "invert", "flatten"},
-			wantContains:   []string{"Are you lost?", "http://localhost:8080/matrix/sum?file=testdata/matrix1.csv", "sum", "multiply", "echo", "invert", "flatten"},
-			wantErr:        false,
+			name: "successfully list operations",
+			mockOperations: []entity.OperationInfo{
+				{Name: "echo", Description: "Returns the matrix unchanged."},
+				{Name: "sum", Description: "Adds every value of the matrix."},
+			},
+			want: `
+	Are you lost?
+	Try using this sample URL: 
+	http://localhost:8080/matrix/sum?file=testdata/matrix1.csv
+
+	Other available operations: 
+	echo  Returns the matrix unchanged.
+	      http://localhost:8080/matrix/echo?file=testdata/matrix1.csv
+	sum   Adds every value of the matrix.
+	      http://localhost:8080/matrix/sum?file=testdata/matrix1.csv
+`,
 		},
 		{
-			name:           "list with single operation",
-			mockOperations: []string{"sum"},
-			wantContains:   []string{"Are you lost?", "sum"},
-			wantErr:        false,
+			name:           "localized text",
+			mockOperations: []entity.OperationInfo{{Name: "sum", Description: "Adds every value of the matrix."}},
+			localizer:      i18n.Match("es-MX,es;q=0.9"),
+			want: `
+	¿Estás perdido?
+	Prueba con esta URL de ejemplo: 
+	http://localhost:8080/matrix/sum?file=testdata/matrix1.csv
+
+	Otras operaciones disponibles: 
+	sum  Adds every value of the matrix.
+	     http://localhost:8080/matrix/sum?file=testdata/matrix1.csv
+`,
 		},
 	}
 
@@ -41,7 +68,7 @@ func TestMatrixDomain_ListMatrixOperations(t *testing.T) {
 			mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
 
 			// Setup expectations using testify/mock syntax
-			mockOperations.On("ListOperations").Return(tt.mockOperations)
+			mockOperations.On("DescribeOperations").Return(tt.mockOperations)
 
 			// Create domain with mocked dependencies
 			domain := &matrixDomain{
@@ -49,29 +76,93 @@ func TestMatrixDomain_ListMatrixOperations(t *testing.T) {
 			}
 
 			// Execute
-			got, err := domain.ListMatrixOperations()
+			localizer := tt.localizer
+			if localizer == nil {
+				localizer = i18n.English()
+			}
+			got, err := domain.ListMatrixOperations(localizer)
 
 			// Assert
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				for _, want := range tt.wantContains {
-					assert.Contains(t, got, want)
-				}
-			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func TestMatrixDomain_ListOperations(t *testing.T) {
+	mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+	mockOperations.On("ListOperations").Return([]string{"echo", "sum"})
+
+	domain := &matrixDomain{operationsDomain: mockOperations}
+
+	assert.Equal(t, []string{"echo", "sum"}, domain.ListOperations())
+}
+
+func TestMatrixDomain_DescribeOperations(t *testing.T) {
+	operations := []entity.OperationInfo{{Name: "echo", Description: "Returns the matrix unchanged.", BuiltIn: true, Enabled: true}}
+	mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+	mockOperations.On("DescribeOperations").Return(operations)
+
+	domain := &matrixDomain{operationsDomain: mockOperations}
+
+	assert.Equal(t, operations, domain.DescribeOperations())
+}
+
+func TestMatrixDomain_ListFiles(t *testing.T) {
+	t.Run("lists the repository files", func(t *testing.T) {
+		mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+		mockRepo.On("ListFiles", mock.Anything).Return([]string{"testdata/matrix1.csv"}, nil)
+
+		domain := &matrixDomain{matrixRepository: mockRepo}
+		got, err := domain.ListFiles(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"testdata/matrix1.csv"}, got)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		domain := &matrixDomain{matrixRepository: mocks.NewMockMatrixRepositoryInterface(t)}
+		_, err := domain.ListFiles(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestMatrixDomain_FileModTime(t *testing.T) {
+	t.Run("returns the repository modification time", func(t *testing.T) {
+		modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+		mockRepo.On("FileModTime", mock.Anything, "testdata/matrix1.csv").Return(modTime, nil)
+
+		domain := &matrixDomain{matrixRepository: mockRepo, validatorDomain: NewMatrixValidatorDomain()}
+		got, err := domain.FileModTime(context.Background(), "testdata/matrix1.csv")
+
+		assert.NoError(t, err)
+		assert.Equal(t, modTime, got)
+	})
+
+	t.Run("invalid file path", func(t *testing.T) {
+		domain := &matrixDomain{
+			matrixRepository: mocks.NewMockMatrixRepositoryInterface(t),
+			validatorDomain:  NewMatrixValidatorDomain(),
+		}
+		_, err := domain.FileModTime(context.Background(), "../secrets.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
+
 func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 	tests := []struct {
 		name              string
 		operation         string
 		filePath          string
 		mockFileContent   *repository.MatrixFileContent
-		mockMatrix        *entity.Matrix
-		mockResult        string
+		mockMatrix        *entity.Matrix[int64]
+		mockResult        *entity.Result
 		mockValidateError error
 		mockFileError     error
 		mockOperationErr  error
@@ -90,13 +181,13 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 					{"4", "5", "6"},
 				},
 			},
-			mockMatrix: &entity.Matrix{
+			mockMatrix: &entity.Matrix[int64]{
 				Data: [][]int64{
 					{1, 2, 3},
 					{4, 5, 6},
 				},
 			},
-			mockResult:        "21",
+			mockResult:        &entity.Result{Scalar: "21"},
 			mockValidateError: nil,
 			mockFileError:     nil,
 			mockOperationErr:  nil,
@@ -114,13 +205,13 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 					{"4", "5"},
 				},
 			},
-			mockMatrix: &entity.Matrix{
+			mockMatrix: &entity.Matrix[int64]{
 				Data: [][]int64{
 					{2, 3},
 					{4, 5},
 				},
 			},
-			mockResult: "120",
+			mockResult: &entity.Result{Scalar: "120"},
 			want:       "120",
 			wantErr:    false,
 		},
@@ -134,13 +225,13 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 					{"3", "4"},
 				},
 			},
-			mockMatrix: &entity.Matrix{
+			mockMatrix: &entity.Matrix[int64]{
 				Data: [][]int64{
 					{1, 2},
 					{3, 4},
 				},
 			},
-			mockResult: "1,2\n3,4",
+			mockResult: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}},
 			want:       "1,2\n3,4",
 			wantErr:    false,
 		},
@@ -213,7 +304,7 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 			mockFileContent: &repository.MatrixFileContent{
 				Content: [][]string{},
 			},
-			mockMatrix: &entity.Matrix{
+			mockMatrix: &entity.Matrix[int64]{
 				Data: [][]int64{},
 			},
 			mockValidateError: nil,
@@ -235,7 +326,7 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 
 			// Setup expectations based on test case
 			if tt.operation != "" {
-				mockValidator.On("ValidateFilePath", mock.Anything, tt.filePath).
+				mockValidator.On("ValidateFileRef", mock.Anything, tt.filePath).
 					Return(tt.mockValidateError)
 			}
 
@@ -245,20 +336,19 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 			}
 
 			if tt.mockOperationErr == nil && tt.mockValidateError == nil && tt.operation != "" {
-				mockRepo.On("GetFileContent", mock.Anything, tt.filePath).
-					Return(tt.mockFileContent, tt.mockFileError)
+				mockRepo.EXPECT().StreamFileContent(mock.Anything, tt.filePath, mock.Anything).
+					RunAndReturn(streamRows(tt.mockFileContent, tt.mockFileError))
 			}
 
 			if tt.mockFileError == nil && tt.mockOperationErr == nil && tt.mockValidateError == nil && tt.operation != "" {
-				var validateErr error
-				if tt.mockMatrix == nil {
-					validateErr = apperrors.ErrUnprocessableEntity
-				}
-				mockValidator.On("Validate", mock.Anything, tt.mockFileContent).
-					Return(tt.mockMatrix, validateErr)
+				mockValidator.EXPECT().ValidateRow(mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(appendRows(tt.mockMatrix)).
+					Maybe()
 
 				if tt.mockMatrix != nil {
-					mockOperations.On("RunOperation", mock.Anything, tt.mockMatrix, tt.operation).
+					mockValidator.On("ValidateMatrix", mock.Anything, mock.AnythingOfType("*matrix.Matrix[int64]")).
+						Return(nil)
+					mockOperations.On("RunOperation", mock.Anything, mock.AnythingOfType("*matrix.Matrix[int64]"), tt.operation).
 						Return(tt.mockResult, tt.mockRunOpError)
 				}
 			}
@@ -271,7 +361,7 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 			}
 
 			// Execute
-			got, err := domain.ProcessMatrix(context.Background(), tt.operation, tt.filePath)
+			got, err := domain.ProcessMatrix(context.Background(), tt.operation, tt.filePath, nil)
 
 			// Assert
 			if tt.wantErr {
@@ -281,7 +371,7 @@ func TestMatrixDomain_ProcessMatrix(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.want, got.String())
 			}
 		})
 	}
@@ -323,17 +413,14 @@ func TestMatrixDomain_ProcessMatrix_ContextCancellation(t *testing.T) {
 				mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
 				mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
 
-				mockValidator.On("ValidateFilePath", mock.Anything, "testdata/matrix1.csv").Return(nil)
+				mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix1.csv").Return(nil)
 				mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
-				mockRepo.On("GetFileContent", mock.Anything, "testdata/matrix1.csv").Return(
-					&repository.MatrixFileContent{Content: [][]string{{"1", "2"}}},
-					nil,
-				)
-				mockValidator.On("Validate", mock.Anything, mock.Anything).Return(
-					&entity.Matrix{Data: [][]int64{{1, 2}}},
-					nil,
-				)
-				mockOperations.On("RunOperation", mock.Anything, mock.Anything, "sum").Return("3", nil)
+				mockRepo.EXPECT().StreamFileContent(mock.Anything, "testdata/matrix1.csv", mock.Anything).
+					RunAndReturn(streamRows(&repository.MatrixFileContent{Content: [][]string{{"1", "2"}}}, nil))
+				mockValidator.EXPECT().ValidateRow(mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(appendRows(&entity.Matrix[int64]{Data: [][]int64{{1, 2}}}))
+				mockValidator.On("ValidateMatrix", mock.Anything, mock.Anything).Return(nil)
+				mockOperations.On("RunOperation", mock.Anything, mock.Anything, "sum").Return(&entity.Result{Scalar: "3"}, nil)
 
 				domain := &matrixDomain{
 					matrixRepository: mockRepo,
@@ -341,15 +428,15 @@ func TestMatrixDomain_ProcessMatrix_ContextCancellation(t *testing.T) {
 					operationsDomain: mockOperations,
 				}
 
-				got, err := domain.ProcessMatrix(ctx, "sum", "testdata/matrix1.csv")
+				got, err := domain.ProcessMatrix(ctx, "sum", "testdata/matrix1.csv", nil)
 				assert.NoError(t, err)
-				assert.Equal(t, "3", got)
+				assert.Equal(t, "3", got.String())
 			} else {
 				domain := &matrixDomain{}
-				got, err := domain.ProcessMatrix(ctx, "sum", "testdata/matrix1.csv")
+				got, err := domain.ProcessMatrix(ctx, "sum", "testdata/matrix1.csv", nil)
 
 				assert.Error(t, err)
-				assert.Equal(t, "", got)
+				assert.Nil(t, got)
 				if tt.expectedErr != nil {
 					assert.ErrorIs(t, err, tt.expectedErr)
 				}
@@ -361,14 +448,14 @@ func TestMatrixDomain_ProcessMatrix_ContextCancellation(t *testing.T) {
 func TestMatrixDomain_ProcessMatrix_ErrorPropagation(t *testing.T) {
 	t.Run("error from validator is properly wrapped", func(t *testing.T) {
 		mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
-		mockValidator.On("ValidateFilePath", mock.Anything, "invalid/path").
+		mockValidator.On("ValidateFileRef", mock.Anything, "invalid/path").
 			Return(errors.New("custom validation error"))
 
 		domain := &matrixDomain{
 			validatorDomain: mockValidator,
 		}
 
-		_, err := domain.ProcessMatrix(context.Background(), "sum", "invalid/path")
+		_, err := domain.ProcessMatrix(context.Background(), "sum", "invalid/path", nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "custom validation error")
 	})
@@ -378,10 +465,10 @@ func TestMatrixDomain_ProcessMatrix_ErrorPropagation(t *testing.T) {
 		mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
 		mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
 
-		mockValidator.On("ValidateFilePath", mock.Anything, "testdata/matrix1.csv").Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix1.csv").Return(nil)
 		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
-		mockRepo.On("GetFileContent", mock.Anything, "testdata/matrix1.csv").
-			Return(nil, errors.New("file read error"))
+		mockRepo.On("StreamFileContent", mock.Anything, "testdata/matrix1.csv", mock.Anything).
+			Return(errors.New("file read error"))
 
 		domain := &matrixDomain{
 			matrixRepository: mockRepo,
@@ -389,8 +476,978 @@ func TestMatrixDomain_ProcessMatrix_ErrorPropagation(t *testing.T) {
 			operationsDomain: mockOperations,
 		}
 
-		_, err := domain.ProcessMatrix(context.Background(), "sum", "testdata/matrix1.csv")
+		_, err := domain.ProcessMatrix(context.Background(), "sum", "testdata/matrix1.csv", nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "file read error")
 	})
 }
+
+func TestMatrixDomain_ProcessMatrixData(t *testing.T) {
+	tests := []struct {
+		name             string
+		operation        string
+		matrix           *entity.Matrix[int64]
+		mockOperationErr error
+		mockValidateErr  error
+		mockResult       *entity.Result
+		want             string
+		wantErr          bool
+		expectedError    error
+	}{
+		{
+			name:       "successfully process sum operation",
+			operation:  "sum",
+			matrix:     &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}},
+			mockResult: &entity.Result{Scalar: "10"},
+			want:       "10",
+		},
+		{
+			name:          "fail when operation is empty",
+			operation:     "",
+			matrix:        &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+			wantErr:       true,
+			expectedError: apperrors.ErrInvalidInput,
+		},
+		{
+			name:             "fail when operation is invalid",
+			operation:        "divide",
+			matrix:           &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+			mockOperationErr: apperrors.ErrInvalidInput,
+			wantErr:          true,
+			expectedError:    apperrors.ErrInvalidInput,
+		},
+		{
+			name:            "fail when matrix validation fails",
+			operation:       "sum",
+			matrix:          &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3}}},
+			mockValidateErr: apperrors.ErrUnprocessableEntity,
+			wantErr:         true,
+			expectedError:   apperrors.ErrUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+			mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+
+			if tt.operation != "" {
+				mockOperations.On("IsValidOperation", mock.Anything, tt.operation).
+					Return(tt.mockOperationErr)
+			}
+			if tt.operation != "" && tt.mockOperationErr == nil {
+				mockValidator.On("ValidateMatrix", mock.Anything, tt.matrix).
+					Return(tt.mockValidateErr)
+			}
+			if tt.operation != "" && tt.mockOperationErr == nil && tt.mockValidateErr == nil {
+				mockOperations.On("RunOperation", mock.Anything, tt.matrix, tt.operation).
+					Return(tt.mockResult, nil)
+			}
+
+			domain := &matrixDomain{
+				validatorDomain:  mockValidator,
+				operationsDomain: mockOperations,
+			}
+
+			got, err := domain.ProcessMatrixData(context.Background(), tt.operation, tt.matrix, nil)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.expectedError != nil {
+					assert.ErrorIs(t, err, tt.expectedError)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestMatrixDomain_ProcessMatrix_StreamingAbortsEarly(t *testing.T) {
+	mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+	mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+	mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+
+	rows := [][]string{{"1", "2"}, {"a", "b"}, {"5", "6"}}
+	var streamed int
+
+	mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix2.csv").Return(nil)
+	mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+	mockRepo.EXPECT().StreamFileContent(mock.Anything, "testdata/matrix2.csv", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, handleRow repository.RowHandler) error {
+			for _, row := range rows {
+				streamed++
+				if err := handleRow(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	mockValidator.EXPECT().ValidateRow(mock.Anything, mock.Anything, []string{"1", "2"}).Return(nil)
+	mockValidator.EXPECT().ValidateRow(mock.Anything, mock.Anything, []string{"a", "b"}).
+		Return(apperrors.ErrUnprocessableEntity)
+
+	domain := &matrixDomain{
+		matrixRepository: mockRepo,
+		validatorDomain:  mockValidator,
+		operationsDomain: mockOperations,
+	}
+
+	got, err := domain.ProcessMatrix(context.Background(), "sum", "testdata/matrix2.csv", nil)
+
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	assert.Nil(t, got)
+	assert.Equal(t, 2, streamed, "rows after the invalid one must not be read")
+}
+
+func TestMatrixDomain_ProcessMatrixReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		input     string
+		want      string
+		errType   error
+	}{
+		{name: "sum", operation: "sum", input: "1,2\n3,4\n", want: "10"},
+		{name: "invert", operation: "invert", input: "1,2\n3,4\n", want: "1,3\n2,4"},
+		{name: "missing operation", operation: "", input: "1,2\n3,4\n", errType: apperrors.ErrInvalidInput},
+		{name: "unknown operation", operation: "divide", input: "1,2\n3,4\n", errType: apperrors.ErrInvalidInput},
+		{name: "non-numeric value", operation: "sum", input: "1,a\n3,4\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "empty input", operation: "sum", input: "", errType: apperrors.ErrUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := NewMatrixDomain(DefaultStreamLimits, DefaultMemoConfig, FileSourcesConfig{})
+
+			got, err := domain.ProcessMatrixReader(context.Background(), tt.operation, strings.NewReader(tt.input), nil)
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestMatrixDomain_ProcessMatrixReader_Labels(t *testing.T) {
+	header := parsing.Options{Header: true}
+	tests := []struct {
+		name          string
+		operation     string
+		input         string
+		options       parsing.Options
+		selection     *entity.Selection
+		want          string
+		wantLabels    []string
+		wantRowLabels []string
+		errType       error
+	}{
+		{name: "echo keeps the labels", operation: "echo", input: "home,away\n1,2\n3,4\n", options: header, want: "1,2\n3,4",
+			wantLabels: []string{"home", "away"}},
+		{name: "selected columns", operation: "echo", input: "a,b,c\n1,2,3\n", options: header,
+			selection: &entity.Selection{Cols: []entity.IndexRange{{Start: 2, End: 2}, {Start: 0, End: 0}}},
+			want:      "3,1", wantLabels: []string{"c", "a"}},
+		{name: "invert turns the labels into row labels", operation: "invert", input: "home,away\n1,2\n", options: header, want: "1\n2",
+			wantRowLabels: []string{"home", "away"}},
+		{name: "streamed sum", operation: "sum", input: "home,away\n1,2\n3,4\n", options: header, want: "10"},
+		{name: "labels do not match the columns", operation: "echo", input: "home\n1,2\n", options: header,
+			errType: apperrors.ErrUnprocessableEntity},
+		{name: "streamed labels do not match the columns", operation: "sum", input: "a,b,c\n1,2\n", options: header,
+			errType: apperrors.ErrUnprocessableEntity},
+		{name: "header only", operation: "echo", input: "home,away\n", options: header, errType: apperrors.ErrUnprocessableEntity},
+		{name: "row labels", operation: "echo", input: "lions,1,2\ntigers,3,4\n", options: parsing.Options{RowLabels: true},
+			want: "1,2\n3,4", wantRowLabels: []string{"lions", "tigers"}},
+		{name: "header row and row labels", operation: "invert", input: "team,home,away\nlions,1,2\ntigers,3,4\n",
+			options: parsing.Options{Header: true, RowLabels: true},
+			want:    "1,3\n2,4", wantLabels: []string{"lions", "tigers"}, wantRowLabels: []string{"home", "away"}},
+		{name: "selected rows", operation: "echo", input: "lions,1,2\ntigers,3,4\n", options: parsing.Options{RowLabels: true},
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 1, End: 1}}}, want: "3,4", wantRowLabels: []string{"tigers"}},
+		{name: "streamed sum of labelled rows", operation: "sum", input: "lions,1,2\ntigers,3,4\n",
+			options: parsing.Options{RowLabels: true}, want: "10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := parsing.NewContext(context.Background(), tt.options)
+
+			got, err := NewMatrixDomain(DefaultStreamLimits, DefaultMemoConfig, FileSourcesConfig{}).ProcessMatrixReader(ctx, tt.operation, strings.NewReader(tt.input), tt.selection)
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.wantLabels, got.ColumnLabels)
+			assert.Equal(t, tt.wantRowLabels, got.RowLabels)
+		})
+	}
+}
+
+func TestMatrixDomain_StreamedOperations(t *testing.T) {
+	limits := StreamLimits{MaxRows: 2000, MaxCols: 100, MaxFileSize: 1 << 20}
+
+	// A 2000x100 matrix, far beyond the limits of the materialized operations and the 1KB file limit
+	var input strings.Builder
+	for range 2000 {
+		input.WriteString(strings.Repeat("1,", 99) + "2\n")
+	}
+
+	t.Run("aggregates stream large matrices", func(t *testing.T) {
+		d := NewMatrixDomain(limits, MemoConfig{}, FileSourcesConfig{})
+
+		got, err := d.ProcessMatrixReader(context.Background(), "sum", strings.NewReader(input.String()), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "202000", got.String())
+		assert.Equal(t, 2000, got.Input.Rows)
+		assert.Equal(t, 100, got.Input.Cols)
+	})
+
+	t.Run("other operations keep the engine limits", func(t *testing.T) {
+		d := NewMatrixDomain(limits, MemoConfig{}, FileSourcesConfig{})
+
+		_, err := d.ProcessMatrixReader(context.Background(), "invert", strings.NewReader(input.String()), nil)
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+
+	t.Run("selections keep the engine limits", func(t *testing.T) {
+		d := NewMatrixDomain(limits, MemoConfig{}, FileSourcesConfig{})
+
+		_, err := d.ProcessMatrixReader(context.Background(), "sum", strings.NewReader(input.String()),
+			&entity.Selection{Rows: []entity.IndexRange{{Start: 0, End: 0}}})
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+
+	t.Run("stream limits apply", func(t *testing.T) {
+		d := NewMatrixDomain(StreamLimits{MaxRows: 1000, MaxCols: 100, MaxFileSize: 1 << 20}, MemoConfig{}, FileSourcesConfig{})
+
+		_, err := d.ProcessMatrixReader(context.Background(), "multiply", strings.NewReader(input.String()), nil)
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+
+		d = NewMatrixDomain(StreamLimits{MaxRows: 2000, MaxCols: 100, MaxFileSize: 1024}, MemoConfig{}, FileSourcesConfig{})
+		_, err = d.ProcessMatrixReader(context.Background(), "multiply", strings.NewReader(input.String()), nil)
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+
+	t.Run("streamed results describe their input like materialized ones", func(t *testing.T) {
+		d := NewMatrixDomain(limits, MemoConfig{}, FileSourcesConfig{})
+
+		got, err := d.ProcessMatrixReader(context.Background(), "sum", strings.NewReader("1, 2\n3,4\n"), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}), got.Input)
+	})
+}
+
+func TestMatrixDomain_ProcessMatrix_URL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("1,2\n3,4\n"))
+	}))
+	defer server.Close()
+
+	d := NewMatrixDomain(DefaultStreamLimits, MemoConfig{}, FileSourcesConfig{URLHosts: []string{"127.0.0.1"}})
+	got, err := d.ProcessMatrix(context.Background(), "sum", server.URL+"/league.csv", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", got.String())
+
+	// Without allowed hosts, URLs are not read at all
+	d = NewMatrixDomain(DefaultStreamLimits, MemoConfig{}, FileSourcesConfig{})
+	_, err = d.ProcessMatrix(context.Background(), "sum", server.URL+"/league.csv", nil)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+// streamRows returns a StreamFileContent implementation that feeds the given content to the row handler.
+func streamRows(content *repository.MatrixFileContent, fileErr error) func(context.Context, string, repository.RowHandler) error {
+	return func(_ context.Context, _ string, handleRow repository.RowHandler) error {
+		if fileErr != nil {
+			return fileErr
+		}
+		if content == nil {
+			return nil
+		}
+		for _, row := range content.Content {
+			if err := handleRow(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// appendRows returns a ValidateRow implementation that appends the rows of want to the streamed matrix,
+// or rejects every row when want is nil.
+func appendRows(want *entity.Matrix[int64]) func(context.Context, *entity.Matrix[int64], []string) error {
+	return func(_ context.Context, matrix *entity.Matrix[int64], _ []string) error {
+		if want == nil {
+			return apperrors.ErrUnprocessableEntity
+		}
+		matrix.Data = append(matrix.Data, want.Data[len(matrix.Data)])
+		return nil
+	}
+}
+
+func TestMatrixDomain_ProcessArchive(t *testing.T) {
+	t.Run("each file is processed independently", func(t *testing.T) {
+		mockArchives := mocks.NewMockArchiveRepositoryInterface(t)
+		mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+		mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+
+		valid := &repository.MatrixFileContent{Content: [][]string{{"1", "2"}, {"3", "4"}}}
+		invalid := &repository.MatrixFileContent{Content: [][]string{{"a", "b"}}}
+		matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+		mockArchives.On("ReadArchive", mock.Anything, []byte("zip")).Return([]*repository.ArchiveEntry{
+			{Name: "a.csv", Content: valid},
+			{Name: "b.csv", Content: invalid},
+			{Name: "notes.txt", Err: apperrors.ErrInvalidInput},
+		}, nil)
+		mockValidator.On("Validate", mock.Anything, valid).Return(matrix, nil)
+		mockValidator.On("Validate", mock.Anything, invalid).Return(nil, apperrors.ErrUnprocessableEntity)
+		mockOperations.On("RunOperation", mock.Anything, matrix, "sum").Return(&entity.Result{Scalar: "10"}, nil)
+
+		domain := &matrixDomain{
+			archiveRepository: mockArchives,
+			validatorDomain:   mockValidator,
+			operationsDomain:  mockOperations,
+		}
+
+		got, err := domain.ProcessArchive(context.Background(), "sum", []byte("zip"))
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, "a.csv", got[0].File)
+		assert.NoError(t, got[0].Err)
+		assert.Equal(t, "10", got[0].Result.String())
+		assert.Equal(t, "b.csv", got[1].File)
+		assert.ErrorIs(t, got[1].Err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got[1].Result)
+		assert.Equal(t, "notes.txt", got[2].File)
+		assert.ErrorIs(t, got[2].Err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("header rows are skipped", func(t *testing.T) {
+		mockArchives := mocks.NewMockArchiveRepositoryInterface(t)
+		mockArchives.On("ReadArchive", mock.Anything, []byte("zip")).Return([]*repository.ArchiveEntry{
+			{Name: "a.csv", Content: &repository.MatrixFileContent{Content: [][]string{{"home", "away"}, {"1", "2"}}}},
+			{Name: "b.csv", Content: &repository.MatrixFileContent{Content: [][]string{{"home"}, {"1", "2"}}}},
+		}, nil)
+
+		domain := &matrixDomain{
+			archiveRepository: mockArchives,
+			validatorDomain:   NewMatrixValidatorDomain(),
+			operationsDomain:  NewMatrixOperationsDomain(),
+		}
+		ctx := parsing.NewContext(context.Background(), parsing.Options{Header: true})
+
+		got, err := domain.ProcessArchive(ctx, "echo", []byte("zip"))
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.NoError(t, got[0].Err)
+		assert.Equal(t, "1,2", got[0].Result.String())
+		assert.Equal(t, []string{"home", "away"}, got[0].Result.ColumnLabels)
+		assert.ErrorIs(t, got[1].Err, apperrors.ErrUnprocessableEntity)
+	})
+
+	t.Run("invalid operation fails the batch", func(t *testing.T) {
+		mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "divide").Return(apperrors.ErrInvalidInput)
+
+		domain := &matrixDomain{operationsDomain: mockOperations}
+
+		got, err := domain.ProcessArchive(context.Background(), "divide", []byte("zip"))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+
+	t.Run("unreadable archive fails the batch", func(t *testing.T) {
+		mockArchives := mocks.NewMockArchiveRepositoryInterface(t)
+		mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+		mockArchives.On("ReadArchive", mock.Anything, []byte("not a zip")).
+			Return(nil, apperrors.ErrUnprocessableEntity)
+
+		domain := &matrixDomain{
+			archiveRepository: mockArchives,
+			operationsDomain:  mockOperations,
+		}
+
+		got, err := domain.ProcessArchive(context.Background(), "sum", []byte("not a zip"))
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+	})
+
+	t.Run("empty operation", func(t *testing.T) {
+		domain := &matrixDomain{}
+
+		_, err := domain.ProcessArchive(context.Background(), "", []byte("zip"))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
+
+func TestMatrixDomain_SaveResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		filePath    string
+		result      *entity.Result
+		pathErr     error
+		wantContent *repository.MatrixFileContent
+		saveErr     error
+		wantErr     error
+	}{
+		{
+			name:        "matrix result is saved row by row",
+			filePath:    "testdata/out.csv",
+			result:      &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, -4}, {2, 5}}}},
+			wantContent: &repository.MatrixFileContent{Content: [][]string{{"1", "-4"}, {"2", "5"}}},
+		},
+		{
+			name:        "scalar result is saved as a single cell",
+			filePath:    "testdata/out.csv",
+			result:      &entity.Result{Scalar: "123456789012345678901234567890"},
+			wantContent: &repository.MatrixFileContent{Content: [][]string{{"123456789012345678901234567890"}}},
+		},
+		{
+			name:     "invalid path is rejected",
+			filePath: "../out.csv",
+			result:   &entity.Result{Scalar: "1"},
+			pathErr:  apperrors.ErrInvalidInput,
+			wantErr:  apperrors.ErrInvalidInput,
+		},
+		{
+			name:        "existing file is a conflict",
+			filePath:    "testdata/matrix1.csv",
+			result:      &entity.Result{Scalar: "1"},
+			wantContent: &repository.MatrixFileContent{Content: [][]string{{"1"}}},
+			saveErr:     apperrors.ErrConflict,
+			wantErr:     apperrors.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+			mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+
+			mockValidator.On("ValidateFilePath", mock.Anything, tt.filePath).Return(tt.pathErr)
+			if tt.wantContent != nil {
+				mockRepo.On("SaveFileContent", mock.Anything, tt.filePath, tt.wantContent).Return(tt.saveErr)
+			}
+
+			domain := &matrixDomain{
+				matrixRepository: mockRepo,
+				validatorDomain:  mockValidator,
+			}
+
+			err := domain.SaveResult(context.Background(), tt.filePath, tt.result)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatrixDomain_ProcessMatrixData_Selection(t *testing.T) {
+	mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+	mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+
+	matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}
+	selection := &entity.Selection{Cols: []entity.IndexRange{{Start: 1, End: 2}}}
+
+	mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+	mockValidator.On("ValidateMatrix", mock.Anything, matrix).Return(nil)
+	mockOperations.On("RunOperation", mock.Anything, &entity.Matrix[int64]{Data: [][]int64{{2, 3}, {5, 6}}}, "sum").
+		Return(&entity.Result{Scalar: "16"}, nil)
+
+	domain := &matrixDomain{
+		validatorDomain:  mockValidator,
+		operationsDomain: mockOperations,
+	}
+
+	got, err := domain.ProcessMatrixData(context.Background(), "sum", matrix, selection)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "16", got.String())
+	// The input reported with the result is the selected submatrix
+	assert.Equal(t, 2, got.Input.Rows)
+	assert.Equal(t, 2, got.Input.Cols)
+}
+
+func TestDescribeMatrix(t *testing.T) {
+	got := describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}})
+
+	assert.Equal(t, 2, got.Rows)
+	assert.Equal(t, 2, got.Cols)
+	// sha256 of "1,2\n3,4\n"
+	assert.Equal(t, "96bbd5de61f36b0e10c5771d180998d066192e8986aa34a8cb7c453f62959274", got.Checksum)
+
+	// The same values give the same checksum, different values a different one
+	assert.Equal(t, got.Checksum, describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}).Checksum)
+	assert.NotEqual(t, got.Checksum, describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{2, 1}, {3, 4}}}).Checksum)
+}
+
+func BenchmarkMatrixDomain_ProcessMatrixData(b *testing.B) {
+	d := NewMatrixDomain(DefaultStreamLimits, DefaultMemoConfig, FileSourcesConfig{})
+	matrix := &entity.Matrix[int64]{Data: make([][]int64, 10)}
+	for i := range matrix.Data {
+		matrix.Data[i] = []int64{1, -2, 3, -4, 5, -6, 7, -8, 9, -10}
+	}
+
+	for _, operation := range NewMatrixOperationsDomain().ListOperations() {
+		b.Run(operation, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := d.ProcessMatrixData(context.Background(), operation, matrix, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestMatrixDomain_ProcessMatrixData_Memo(t *testing.T) {
+	mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+	mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+
+	matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}
+	firstCol := &entity.Selection{Cols: []entity.IndexRange{{Start: 0, End: 0}}}
+
+	mockOperations.On("IsValidOperation", mock.Anything, mock.Anything).Return(nil)
+	mockValidator.On("ValidateMatrix", mock.Anything, mock.Anything).Return(nil)
+	// Each operation runs once per submatrix, however often it is asked for
+	mockOperations.On("RunOperation", mock.Anything, matrix, "sum").Return(&entity.Result{Scalar: "21"}, nil).Once()
+	mockOperations.On("RunOperation", mock.Anything, matrix, "echo").Return(&entity.Result{Matrix: matrix}, nil).Once()
+	mockOperations.On("RunOperation", mock.Anything, &entity.Matrix[int64]{Data: [][]int64{{1}, {4}}}, "sum").
+		Return(&entity.Result{Scalar: "5"}, nil).Once()
+
+	domain := &matrixDomain{
+		validatorDomain:  mockValidator,
+		operationsDomain: mockOperations,
+		memo:             newResultMemo(DefaultMemoConfig),
+	}
+
+	for range 2 {
+		got, err := domain.ProcessMatrixData(context.Background(), "sum", matrix, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "21", got.Scalar)
+		assert.Equal(t, describeMatrix(matrix), got.Input)
+
+		got, err = domain.ProcessMatrixData(context.Background(), "sum", matrix, firstCol)
+		assert.NoError(t, err)
+		assert.Equal(t, "5", got.Scalar)
+
+		got, err = domain.ProcessMatrixData(context.Background(), "echo", matrix, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, matrix, got.Matrix)
+		// Callers attach labels to the result they get, which must not leak into later results
+		assert.Nil(t, got.RowLabels)
+		got.RowLabels = []string{"home", "away"}
+	}
+
+	metrics := domain.MemoMetrics()
+	lookups := metrics[len(metrics)-1]
+	assert.Equal(t, "matrix.memo.lookups", lookups.Name)
+	assert.Equal(t, 3.0, lookups.Points[0].Value)
+	assert.Equal(t, 3.0, lookups.Points[1].Value)
+}
+
+func TestMatrixDomain_ConcatMatrices(t *testing.T) {
+	files := map[string]string{
+		"testdata/a.csv":      "1,2\n3,4\n",
+		"testdata/b.csv":      "5\n6\n",
+		"testdata/row.csv":    "7,8\n",
+		"testdata/wide.csv":   strings.Repeat("1,2,3,4,5,6,7,8,9\n", 2),
+		"testdata/header.csv": "home,away\n1,2\n",
+	}
+	tests := []struct {
+		name      string
+		file      string
+		file2     string
+		axis      string
+		options   parsing.Options
+		selection *entity.Selection
+		want      string
+		errType   error
+	}{
+		{name: "horizontal by default", file: "testdata/a.csv", file2: "testdata/b.csv", want: "1,2,5\n3,4,6"},
+		{name: "vertical", file: "testdata/a.csv", file2: "testdata/row.csv", axis: "vertical", want: "1,2\n3,4\n7,8"},
+		{name: "selection of the joined matrix", file: "testdata/a.csv", file2: "testdata/b.csv", axis: "horizontal",
+			selection: &entity.Selection{Cols: []entity.IndexRange{{Start: 1, End: 2}}}, want: "2,5\n4,6"},
+		{name: "labels are dropped", file: "testdata/header.csv", file2: "testdata/header.csv", axis: "vertical",
+			options: parsing.Options{Header: true}, want: "1,2\n1,2"},
+		{name: "rows do not line up", file: "testdata/a.csv", file2: "testdata/row.csv", errType: apperrors.ErrUnprocessableEntity},
+		{name: "columns do not line up", file: "testdata/a.csv", file2: "testdata/b.csv", axis: "vertical",
+			errType: apperrors.ErrUnprocessableEntity},
+		{name: "joined matrix beyond the limits", file: "testdata/wide.csv", file2: "testdata/wide.csv",
+			errType: apperrors.ErrUnprocessableEntity},
+		{name: "invalid axis", file: "testdata/a.csv", file2: "testdata/b.csv", axis: "diagonal", errType: apperrors.ErrInvalidInput},
+		{name: "missing second file", file: "testdata/a.csv", errType: apperrors.ErrInvalidInput},
+		{name: "invalid second file path", file: "testdata/a.csv", file2: "../b.csv", errType: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+			mockRepo.EXPECT().StreamFileContent(mock.Anything, mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, filePath string, handleRow repository.RowHandler) error {
+					return repository.NewMatrixRepository().StreamContent(ctx, strings.NewReader(files[filePath]), handleRow)
+				}).
+				Maybe()
+
+			domain := &matrixDomain{
+				matrixRepository: mockRepo,
+				validatorDomain:  NewMatrixValidatorDomain(),
+				operationsDomain: NewMatrixOperationsDomain(),
+			}
+			ctx := parsing.NewContext(context.Background(), tt.options)
+			got, err := domain.ConcatMatrices(ctx, tt.file, tt.file2, tt.axis, tt.selection)
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Nil(t, got.ColumnLabels)
+			assert.Equal(t, describeMatrix(got.Matrix), got.Input)
+		})
+	}
+}
+
+func TestMatrixDomain_MultiplyMatrices(t *testing.T) {
+	files := map[string]string{
+		"testdata/a.csv":      "1,2\n3,4\n",
+		"testdata/b.csv":      "5\n6\n",
+		"testdata/row.csv":    "7,8\n",
+		"testdata/large.csv":  "9223372036854775807\n",
+		"testdata/header.csv": "home,away\n1,2\n",
+		"testdata/column.csv": "points\n5\n6\n",
+	}
+	tests := []struct {
+		name      string
+		file      string
+		file2     string
+		options   parsing.Options
+		selection *entity.Selection
+		want      string
+		errType   error
+	}{
+		{name: "matrix by column", file: "testdata/a.csv", file2: "testdata/b.csv", want: "17\n39"},
+		{name: "column by row", file: "testdata/b.csv", file2: "testdata/row.csv", want: "35,40\n42,48"},
+		{name: "selection of the product", file: "testdata/a.csv", file2: "testdata/a.csv",
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 1, End: 1}}}, want: "15,22"},
+		{name: "labels are dropped", file: "testdata/header.csv", file2: "testdata/column.csv",
+			options: parsing.Options{Header: true}, want: "17"},
+		{name: "matrices do not line up", file: "testdata/a.csv", file2: "testdata/row.csv", errType: apperrors.ErrUnprocessableEntity},
+		{name: "product beyond int64", file: "testdata/large.csv", file2: "testdata/row.csv", errType: apperrors.ErrOverflow},
+		{name: "missing second file", file: "testdata/a.csv", errType: apperrors.ErrInvalidInput},
+		{name: "invalid second file path", file: "testdata/a.csv", file2: "../b.csv", errType: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+			mockRepo.EXPECT().StreamFileContent(mock.Anything, mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, filePath string, handleRow repository.RowHandler) error {
+					return repository.NewMatrixRepository().StreamContent(ctx, strings.NewReader(files[filePath]), handleRow)
+				}).
+				Maybe()
+			domain := &matrixDomain{
+				matrixRepository: mockRepo,
+				validatorDomain:  NewMatrixValidatorDomain(),
+				operationsDomain: NewMatrixOperationsDomain(),
+			}
+			ctx := parsing.NewContext(context.Background(), tt.options)
+			got, err := domain.MultiplyMatrices(ctx, tt.file, tt.file2, tt.selection)
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Nil(t, got.ColumnLabels)
+			assert.Equal(t, describeMatrix(got.Matrix), got.Input)
+		})
+	}
+}
+
+func TestMatrixDomain_PlanMatrix(t *testing.T) {
+	files := map[string]string{
+		"testdata/a.csv":      "1,2,3\n4,5,6\n",
+		"testdata/b.csv":      "7,8,9\n",
+		"testdata/bad.csv":    "1,x\n",
+		"testdata/empty.csv":  "",
+		"testdata/header.csv": "home,away\n1,2\n",
+		"testdata/large.csv":  strings.Repeat(strings.Repeat("1,", 19)+"2\n", 50),
+	}
+	tests := []struct {
+		name      string
+		operation string
+		file      string
+		options   parsing.Options
+		selection *entity.Selection
+		want      *entity.Plan
+		errType   error
+	}{
+		{
+			name: "matrix result", operation: "invert", file: "testdata/a.csv",
+			want: &entity.Plan{
+				Operation: &entity.OperationPlan{Operation: "invert", Steps: []matrixlib.Operation{"invert"}, Rows: 3, Cols: 2, Cost: 6},
+				Input:     describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}),
+			},
+		},
+		{
+			name: "selection", operation: "flatten", file: "testdata/a.csv",
+			selection: &entity.Selection{Cols: []entity.IndexRange{{Start: 1, End: 2}}},
+			want: &entity.Plan{
+				Operation: &entity.OperationPlan{Operation: "flatten", Steps: []matrixlib.Operation{"flatten"}, Rows: 1, Cols: 4, Cost: 4},
+				Input:     describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{2, 3}, {5, 6}}}),
+			},
+		},
+		{
+			name: "labels are checked and dropped", operation: "echo", file: "testdata/header.csv",
+			options: parsing.Options{Header: true},
+			want: &entity.Plan{
+				Operation: &entity.OperationPlan{Operation: "echo", Steps: []matrixlib.Operation{"echo"}, Rows: 1, Cols: 2, Cost: 2},
+				Input:     describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{1, 2}}}),
+			},
+		},
+		{
+			name: "streamed aggregate beyond the engine limits", operation: "multiply", file: "testdata/large.csv",
+			want: &entity.Plan{
+				Operation: &entity.OperationPlan{Operation: "multiply", Steps: []matrixlib.Operation{"multiply"}, Scalar: true, Cost: 1000},
+				Input:     describeMatrix(largeMatrix(50, 20)),
+				Streamed:  true,
+			},
+		},
+		{name: "other operations keep the engine limits", operation: "echo", file: "testdata/large.csv", errType: apperrors.ErrPayloadTooLarge},
+		{name: "selection out of range", operation: "sum", file: "testdata/b.csv",
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 1, End: 1}}}, errType: apperrors.ErrInvalidInput},
+		{name: "invalid values", operation: "sum", file: "testdata/bad.csv", errType: apperrors.ErrUnprocessableEntity},
+		{name: "empty streamed matrix", operation: "sum", file: "testdata/empty.csv", errType: apperrors.ErrUnprocessableEntity},
+		{name: "unknown operation", operation: "divide", file: "testdata/a.csv", errType: apperrors.ErrInvalidInput},
+		{name: "missing operation", file: "testdata/a.csv", errType: apperrors.ErrInvalidInput},
+		{name: "invalid file path", operation: "sum", file: "../a.csv", errType: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+			mockRepo.EXPECT().StreamFileContent(mock.Anything, mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, filePath string, handleRow repository.RowHandler) error {
+					return repository.NewMatrixRepository().StreamContent(ctx, strings.NewReader(files[filePath]), handleRow)
+				}).
+				Maybe()
+
+			domain := &matrixDomain{
+				matrixRepository: mockRepo,
+				validatorDomain:  NewMatrixValidatorDomain(),
+				operationsDomain: NewMatrixOperationsDomain(),
+				streamLimits:     StreamLimits{MaxRows: 100, MaxCols: 100, MaxFileSize: 1 << 20},
+			}
+			ctx := parsing.NewContext(context.Background(), tt.options)
+			got, err := domain.PlanMatrix(ctx, tt.operation, tt.file, tt.selection)
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("the operation is not run", func(t *testing.T) {
+		mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+		mockRepo.EXPECT().StreamFileContent(mock.Anything, "testdata/a.csv", mock.Anything).
+			RunAndReturn(func(ctx context.Context, _ string, handleRow repository.RowHandler) error {
+				return repository.NewMatrixRepository().StreamContent(ctx, strings.NewReader(files["testdata/a.csv"]), handleRow)
+			})
+		// RunOperation has no expectation, so running the operation fails the test
+		mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "echo").Return(nil)
+		mockOperations.On("PlanOperation", mock.Anything, "echo", 2, 3).
+			Return(&entity.OperationPlan{Operation: "echo", Rows: 2, Cols: 3, Cost: 6}, nil)
+
+		domain := &matrixDomain{
+			matrixRepository: mockRepo,
+			validatorDomain:  NewMatrixValidatorDomain(),
+			operationsDomain: mockOperations,
+		}
+		got, err := domain.PlanMatrix(context.Background(), "echo", "testdata/a.csv", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), got.Operation.Cost)
+		assert.False(t, got.Streamed)
+	})
+}
+
+// largeMatrix returns the matrix of rows rows of cols values, all ones but for a two in the last column.
+func largeMatrix(rows, cols int) *entity.Matrix[int64] {
+	matrix := &entity.Matrix[int64]{}
+	for range rows {
+		row := make([]int64, cols)
+		for j := range row {
+			row[j] = 1
+		}
+		row[cols-1] = 2
+		matrix.Data = append(matrix.Data, row)
+	}
+	return matrix
+}
+
+func TestMatrixDomain_PlanMatrixData(t *testing.T) {
+	domain := &matrixDomain{
+		validatorDomain:  NewMatrixValidatorDomain(),
+		operationsDomain: NewMatrixOperationsDomain(),
+	}
+	matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+	got, err := domain.PlanMatrixData(context.Background(), "sum", matrix, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Plan{
+		Operation: &entity.OperationPlan{Operation: "sum", Steps: []matrixlib.Operation{"sum"}, Scalar: true, Cost: 4},
+		Input:     describeMatrix(matrix),
+	}, got)
+
+	_, err = domain.PlanMatrixData(context.Background(), "sum", &entity.Matrix[int64]{}, nil)
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	_, err = domain.PlanMatrixData(context.Background(), "divide", matrix, nil)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestMatrixDomain_PlanMatrixReader(t *testing.T) {
+	limits := StreamLimits{MaxRows: 2000, MaxCols: 100, MaxFileSize: 1 << 20}
+	d := NewMatrixDomain(limits, MemoConfig{}, FileSourcesConfig{})
+
+	got, err := d.PlanMatrixReader(context.Background(), "invert", strings.NewReader("1,2,3\n4,5,6\n"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Plan{
+		Operation: &entity.OperationPlan{Operation: "invert", Steps: []matrixlib.Operation{"invert"}, Rows: 3, Cols: 2, Cost: 6},
+		Input:     describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}),
+	}, got)
+
+	// Aggregates over matrices beyond the materialized limits are checked as a stream
+	var input strings.Builder
+	for range 2000 {
+		input.WriteString(strings.Repeat("1,", 99) + "2\n")
+	}
+	got, err = d.PlanMatrixReader(context.Background(), "sum", strings.NewReader(input.String()), nil)
+	assert.NoError(t, err)
+	assert.True(t, got.Streamed)
+	assert.Equal(t, int64(200000), got.Operation.Cost)
+
+	_, err = d.PlanMatrixReader(context.Background(), "sum", strings.NewReader("1,x\n"), nil)
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	_, err = d.PlanMatrixReader(context.Background(), "divide", strings.NewReader("1\n"), nil)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestMatrixDomain_PlanConcat(t *testing.T) {
+	files := map[string]string{
+		"testdata/a.csv": "1,2\n3,4\n",
+		"testdata/b.csv": "5\n6\n",
+	}
+	mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+	mockRepo.EXPECT().StreamFileContent(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, filePath string, handleRow repository.RowHandler) error {
+			return repository.NewMatrixRepository().StreamContent(ctx, strings.NewReader(files[filePath]), handleRow)
+		})
+	domain := &matrixDomain{
+		matrixRepository: mockRepo,
+		validatorDomain:  NewMatrixValidatorDomain(),
+		operationsDomain: NewMatrixOperationsDomain(),
+	}
+
+	got, err := domain.PlanConcat(context.Background(), "testdata/a.csv", "testdata/b.csv", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Plan{
+		Operation: &entity.OperationPlan{Operation: "echo", Steps: []matrixlib.Operation{"echo"}, Rows: 2, Cols: 3, Cost: 6},
+		Input:     describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{1, 2, 5}, {3, 4, 6}}}),
+	}, got)
+
+	_, err = domain.PlanConcat(context.Background(), "testdata/a.csv", "testdata/b.csv", "vertical", nil)
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+}
+
+func TestMatrixDomain_PlanMultiplyMatrices(t *testing.T) {
+	files := map[string]string{
+		"testdata/a.csv": "1,2\n3,4\n",
+		"testdata/b.csv": "5\n6\n",
+	}
+	mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+	mockRepo.EXPECT().StreamFileContent(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, filePath string, handleRow repository.RowHandler) error {
+			return repository.NewMatrixRepository().StreamContent(ctx, strings.NewReader(files[filePath]), handleRow)
+		})
+	domain := &matrixDomain{
+		matrixRepository: mockRepo,
+		validatorDomain:  NewMatrixValidatorDomain(),
+		operationsDomain: NewMatrixOperationsDomain(),
+	}
+
+	got, err := domain.PlanMultiplyMatrices(context.Background(), "testdata/a.csv", "testdata/b.csv", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Plan{
+		Operation: &entity.OperationPlan{Operation: "echo", Steps: []matrixlib.Operation{"echo"}, Rows: 2, Cols: 1, Cost: 2},
+		Input:     describeMatrix(&entity.Matrix[int64]{Data: [][]int64{{17}, {39}}}),
+	}, got)
+
+	_, err = domain.PlanMultiplyMatrices(context.Background(), "testdata/b.csv", "testdata/b.csv", nil)
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+}
+
+func TestMatrixDomain_SplitMatrix(t *testing.T) {
+	t.Run("grid of tiles", func(t *testing.T) {
+		got, err := NewMatrixDomain(StreamLimits{}, MemoConfig{}, FileSourcesConfig{}).SplitMatrix(context.Background(), "testdata/matrix1.csv", 3, 1)
+		assert.NoError(t, err)
+
+		assert.Len(t, got, 3)
+		assert.Equal(t, 2, got[1].Row)
+		assert.Equal(t, 1, got[1].Col)
+		assert.Equal(t, entity.IndexRange{Start: 3, End: 5}, got[1].Rows)
+		assert.Equal(t, entity.IndexRange{Start: 0, End: 2}, got[1].Cols)
+		assert.Equal(t, "10,11,12\n13,14,15\n16,17,18", got[1].Matrix.String())
+	})
+
+	tests := []struct {
+		name     string
+		filePath string
+		rows     int
+		cols     int
+		errType  error
+	}{
+		{name: "rows do not divide evenly", filePath: "testdata/matrix1.csv", rows: 2, cols: 1, errType: apperrors.ErrUnprocessableEntity},
+		{name: "invalid grid", filePath: "testdata/matrix1.csv", rows: 0, cols: 1, errType: apperrors.ErrInvalidInput},
+		{name: "invalid matrix", filePath: "testdata/matrix2.csv", rows: 1, cols: 1, errType: apperrors.ErrUnprocessableEntity},
+		{name: "invalid file path", filePath: "../matrix1.csv", rows: 1, cols: 1, errType: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMatrixDomain(StreamLimits{}, MemoConfig{}, FileSourcesConfig{}).SplitMatrix(context.Background(), tt.filePath, tt.rows, tt.cols)
+
+			assert.ErrorIs(t, err, tt.errType)
+			assert.Nil(t, got)
+		})
+	}
+}
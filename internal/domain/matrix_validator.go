@@ -3,27 +3,56 @@ package domain
 import (
 	"context"
 	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
 	"strings"
+	"syscall"
 
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/config"
 	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
 	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
-)
-
-const (
-	maxInputMatrixRows = 10
-	maxInputMatrixCols = 10
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
 )
 
 // MatrixValidatorDomainInterface defines the contract for validating and transforming raw matrix data.
-// It ensures matrix data integrity and converts string data to typed entities.
+// It ensures matrix data integrity and converts string data to typed entities, applying the rules
+// of the exported matrix engine on top of the file path rules of the HTTP API.
 type MatrixValidatorDomainInterface interface {
+	// ValidateFilePath checks the path of a matrix file stored in the data directory, such as one to save a result to:
+	// a .csv file inside the data directory, and inside the data directory of the tenant carried by ctx, if any.
+	// Files of other tenants are reported with ErrNotFound, like missing files. Paths with a scheme, such as s3://bucket/league.csv, name files that can only be read and are rejected.
 	ValidateFilePath(ctx context.Context, filePath string) error
 
+	// ValidateFileRef checks a reference to a matrix file to read: a file path under the rules of ValidateFilePath,
+	// optionally pinned to one of its versions, as in testdata/league.csv@v3, or the address of an object
+	// in object storage, such as s3://bucket/league.csv, or the URL of a remote file, such as
+	// https://example.com/league.csv. Tenants may only read the objects below a prefix named after their ID,
+	// such as s3://bucket/acme/league.csv. The hosts remote files may be read from are checked as they are read.
+	ValidateFileRef(ctx context.Context, ref string) error
+
 	// Validate checks raw matrix file content for consistency and converts it to a typed Matrix entity.
 	// It ensures all rows have equal length and all values are valid integers.
 	// Returns a validated Matrix entity or an error if validation fails.
-	Validate(ctx context.Context, matrix *repository.MatrixFileContent) (*entity.Matrix, error)
+	Validate(ctx context.Context, matrix *repository.MatrixFileContent) (*entity.Matrix[int64], error)
+
+	// ValidateRow checks the next row of a streamed matrix and appends its converted values to matrix.
+	// Dimension limits, including those of the tenant carried by ctx, are enforced as rows arrive,
+	// so oversized input is rejected as soon as a limit is crossed.
+	ValidateRow(ctx context.Context, matrix *entity.Matrix[int64], row []string) error
+
+	// ValidateStreamRow checks the next row of a matrix streamed through an aggregate operation and feeds it
+	// to stream, which enforces its own dimension limits. The limits of the tenant carried by ctx apply as well.
+	// It returns the converted values, which are only valid until the next row is validated.
+	ValidateStreamRow(ctx context.Context, stream *matrixlib.Stream[int64], row []string) ([]int64, error)
+
+	// ValidateMatrix checks an already typed Matrix entity, such as one decoded from a request body.
+	// It enforces the same dimension limits, including the tenant's, and row consistency rules as Validate.
+	ValidateMatrix(ctx context.Context, matrix *entity.Matrix[int64]) error
 }
 
 type matrixValidatorDomain struct{}
@@ -39,23 +68,110 @@ func (d *matrixValidatorDomain) ValidateFilePath(ctx context.Context, filePath s
 	if err := ctx.Err(); err != nil {
 		return err
 	}
+	defer profile.Start(ctx, profile.Path)()
 
 	if filePath == "" {
 		return fmt.Errorf("%w: file parameter is required", apperrors.ErrInvalidInput)
 	}
+	dataDir := config.CurrentLimits().DataDir
+	if scheme, ok := repository.FileScheme(filePath); ok {
+		return fmt.Errorf("%w: only files in %s/ are allowed: %s:// files can only be read",
+			apperrors.ErrInvalidInput, dataDir, scheme)
+	}
 	if strings.Contains(filePath, "..") {
+		audit.Deny(ctx, "path traversal: "+filePath)
 		return fmt.Errorf("%w: path traversal not allowed", apperrors.ErrInvalidInput)
 	}
-	if !strings.HasPrefix(filePath, "testdata/") {
-		return fmt.Errorf("%w: only files in testdata/ are allowed", apperrors.ErrInvalidInput)
+	if !strings.HasPrefix(filePath, dataDir+"/") {
+		audit.Deny(ctx, "path outside "+dataDir+"/: "+filePath)
+		return fmt.Errorf("%w: only files in %s/ are allowed", apperrors.ErrInvalidInput, dataDir)
 	}
 	if !strings.HasSuffix(filePath, ".csv") {
 		return fmt.Errorf("%w: only .csv files are supported", apperrors.ErrInvalidInput)
 	}
+	if !tenant.CanAccess(ctx, filePath) {
+		audit.Deny(ctx, "path outside the tenant's data directory: "+filePath)
+		t, ok := tenant.FromContext(ctx)
+		if !ok {
+			return fmt.Errorf("%w: files in %s/ belong to tenants", apperrors.ErrInvalidInput, tenant.Root())
+		}
+		if tenant.Owner(filePath) != "" {
+			// Files of other tenants are reported like missing files, so a tenant cannot tell which exist
+			return fmt.Errorf("%w: failed to open file: %v", apperrors.ErrNotFound,
+				&fs.PathError{Op: "open", Path: filePath, Err: syscall.ENOENT})
+		}
+		return fmt.Errorf("%w: only files in %s/ are allowed", apperrors.ErrInvalidInput, t.DataDir())
+	}
 	return nil
 }
 
-func (d *matrixValidatorDomain) Validate(ctx context.Context, rawData *repository.MatrixFileContent) (*entity.Matrix, error) {
+func (d *matrixValidatorDomain) ValidateFileRef(ctx context.Context, ref string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if scheme, ok := repository.FileScheme(ref); ok {
+		switch scheme {
+		case repository.S3Scheme:
+			return d.validateObjectRef(ctx, ref)
+		case repository.HTTPScheme, repository.HTTPSScheme:
+			return d.validateURLRef(ctx, ref)
+		default:
+			return fmt.Errorf("%w: unsupported file scheme %q: expected s3://, https:// or a path in %s/",
+				apperrors.ErrInvalidInput, scheme, config.CurrentLimits().DataDir)
+		}
+	}
+
+	filePath, _, err := repository.SplitVersion(ref)
+	if err != nil {
+		return err
+	}
+	return d.ValidateFilePath(ctx, filePath)
+}
+
+// validateObjectRef checks ref, the address of an object in object storage such as s3://bucket/league.csv.
+// Objects are not versioned, so ref is never pinned to a version.
+func (d *matrixValidatorDomain) validateObjectRef(ctx context.Context, ref string) error {
+	defer profile.Start(ctx, profile.Path)()
+
+	bucket, key, _ := repository.SplitS3URI(ref)
+	if !bucketNamePattern.MatchString(bucket) {
+		return fmt.Errorf("%w: invalid bucket name: %q", apperrors.ErrInvalidInput, bucket)
+	}
+	if strings.Contains(key, "..") {
+		audit.Deny(ctx, "path traversal: "+ref)
+		return fmt.Errorf("%w: path traversal not allowed", apperrors.ErrInvalidInput)
+	}
+	if key == "" || path.IsAbs(key) || strings.Contains(key, `\`) || path.Clean(key) != key {
+		return fmt.Errorf("%w: object key must be a clean relative path: %q", apperrors.ErrInvalidInput, key)
+	}
+	if !strings.HasSuffix(key, ".csv") {
+		return fmt.Errorf("%w: only .csv files are supported", apperrors.ErrInvalidInput)
+	}
+	if !tenant.CanAccessObject(ctx, key) {
+		audit.Deny(ctx, "object outside the tenant's prefix: "+ref)
+		return fmt.Errorf("%w: only objects below %s/ are allowed", apperrors.ErrInvalidInput, tenant.ID(ctx))
+	}
+	return nil
+}
+
+// validateURLRef checks ref, the URL of a remote file such as https://example.com/league.csv.
+// Credentials are rejected, so they never end up in logs, histories or shared links.
+func (d *matrixValidatorDomain) validateURLRef(ctx context.Context, ref string) error {
+	defer profile.Start(ctx, profile.Path)()
+
+	u, err := url.Parse(ref)
+	if err != nil || u.Host == "" || u.Hostname() == "" {
+		return fmt.Errorf("%w: invalid URL: %q: expected https://host/path", apperrors.ErrInvalidInput, ref)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: URLs must not hold credentials", apperrors.ErrInvalidInput)
+	}
+	return nil
+}
+
+func (d *matrixValidatorDomain) Validate(ctx context.Context, rawData *repository.MatrixFileContent) (*entity.Matrix[int64], error) {
 	// Check if context is already cancelled
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -65,45 +181,78 @@ func (d *matrixValidatorDomain) Validate(ctx context.Context, rawData *repositor
 		return nil, fmt.Errorf("%w: empty matrix data", apperrors.ErrUnprocessableEntity)
 	}
 
-	rows := len(rawData.Content)
-	cols := len(rawData.Content[0])
+	matrix := &entity.Matrix[int64]{
+		Data: make([][]int64, 0, len(rawData.Content)),
+	}
+	for _, row := range rawData.Content {
+		if err := d.ValidateRow(ctx, matrix, row); err != nil {
+			return nil, err
+		}
+	}
 
-	// Validate maximum dimensions
-	if rows > maxInputMatrixRows {
-		return nil, fmt.Errorf("%w: matrix exceeds maximum row limit: got %d rows, maximum is %d",
-			apperrors.ErrUnprocessableEntity, rows, maxInputMatrixRows)
+	return matrix, nil
+}
+
+func (d *matrixValidatorDomain) ValidateRow(ctx context.Context, matrix *entity.Matrix[int64], row []string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	defer profile.Start(ctx, profile.Validate)()
 
-	if cols > maxInputMatrixCols {
-		return nil, fmt.Errorf("%w: matrix exceeds maximum column limit: got %d columns, maximum is %d",
-			apperrors.ErrUnprocessableEntity, cols, maxInputMatrixCols)
+	// Tenant limits are checked first, as they can only be lower than the service-wide ones
+	limits := tenant.LimitsFromContext(ctx)
+	if limits.MaxRows > 0 && matrix.Rows() >= limits.MaxRows {
+		return fmt.Errorf("%w: matrix exceeds the tenant's row limit: got more than %d rows",
+			apperrors.ErrMatrixTooLarge, limits.MaxRows)
+	}
+	if limits.MaxCols > 0 && len(row) > limits.MaxCols {
+		return fmt.Errorf("%w: matrix exceeds the tenant's column limit: got %d columns, maximum is %d",
+			apperrors.ErrMatrixTooLarge, len(row), limits.MaxCols)
 	}
 
-	// Validate that all rows have the same number of columns
-	for i, row := range rawData.Content {
-		if len(row) != cols {
-			return nil, fmt.Errorf("%w: inconsistent row length at row %d: expected %d columns, got %d",
-				apperrors.ErrUnprocessableEntity, i, cols, len(row))
-		}
+	return matrixlib.AppendRowWithin(matrix, row, config.CurrentLimits().Matrix())
+}
+
+func (d *matrixValidatorDomain) ValidateStreamRow(ctx context.Context, stream *matrixlib.Stream[int64], row []string) ([]int64, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
+	defer profile.Start(ctx, profile.Validate)()
 
-	// Convert string data to int64
-	matrix := &entity.Matrix{
-		Data: make([][]int64, rows),
+	limits := tenant.LimitsFromContext(ctx)
+	if limits.MaxRows > 0 && stream.Rows() >= limits.MaxRows {
+		return nil, fmt.Errorf("%w: matrix exceeds the tenant's row limit: got more than %d rows",
+			apperrors.ErrMatrixTooLarge, limits.MaxRows)
+	}
+	if limits.MaxCols > 0 && len(row) > limits.MaxCols {
+		return nil, fmt.Errorf("%w: matrix exceeds the tenant's column limit: got %d columns, maximum is %d",
+			apperrors.ErrMatrixTooLarge, len(row), limits.MaxCols)
 	}
 
-	for i, row := range rawData.Content {
-		matrix.Data[i] = make([]int64, cols)
-		for j, val := range row {
-			var num int64
-			_, err := fmt.Sscanf(val, "%d", &num)
-			if err != nil {
-				return nil, fmt.Errorf("%w: invalid integer value at row %d, column %d: %v",
-					apperrors.ErrUnprocessableEntity, i, j, err)
-			}
-			matrix.Data[i][j] = num
-		}
+	return stream.AppendRow(row)
+}
+
+func (d *matrixValidatorDomain) ValidateMatrix(ctx context.Context, matrix *entity.Matrix[int64]) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	defer profile.Start(ctx, profile.Validate)()
 
-	return matrix, nil
+	if err := matrixlib.ValidateWithin(matrix, config.CurrentLimits().Matrix()); err != nil {
+		return err
+	}
+
+	limits := tenant.LimitsFromContext(ctx)
+	if rows := matrix.Rows(); limits.MaxRows > 0 && rows > limits.MaxRows {
+		return fmt.Errorf("%w: matrix exceeds the tenant's row limit: got %d rows, maximum is %d",
+			apperrors.ErrMatrixTooLarge, rows, limits.MaxRows)
+	}
+	if cols := matrix.Cols(); limits.MaxCols > 0 && cols > limits.MaxCols {
+		return fmt.Errorf("%w: matrix exceeds the tenant's column limit: got %d columns, maximum is %d",
+			apperrors.ErrMatrixTooLarge, cols, limits.MaxCols)
+	}
+	return nil
 }
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/cache"
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// MemoConfig bounds the results the matrix domain remembers, so repeated operations on unchanged matrices
+// return without running again. Results are evicted least recently used first once MaxEntries are held,
+// and expire TTL after they were computed. The same bounds apply to the cache of results of operations on
+// matrix files, kept apart from the memo. A MaxEntries of zero disables both.
+type MemoConfig struct {
+	MaxEntries int
+	TTL        time.Duration
+}
+
+// DefaultMemoConfig is the memo configuration used unless configured otherwise.
+var DefaultMemoConfig = MemoConfig{
+	MaxEntries: config.Default.MemoMaxEntries,
+	TTL:        config.Default.MemoTTL,
+}
+
+// memoKey identifies a result by the checksum of the matrix the operation ran on and the precision it was
+// rounded to. The checksum covers the selected submatrix only, so the selection is part of the key without
+// being stored.
+type memoKey struct {
+	checksum  string
+	operation string
+	precision int
+}
+
+// resultMemo remembers the results of operations, bounded by its configuration.
+// A nil memo remembers nothing.
+type resultMemo struct {
+	results *cache.Cache[memoKey, *entity.Result]
+}
+
+// newResultMemo creates a memo bounded by config, or nil when config disables it.
+func newResultMemo(config MemoConfig) *resultMemo {
+	if config.MaxEntries <= 0 {
+		return nil
+	}
+	return &resultMemo{results: cache.New[memoKey, *entity.Result](config.cacheConfig())}
+}
+
+// cacheConfig returns the bounds of a cache of results configured by c.
+func (c MemoConfig) cacheConfig() cache.Config {
+	return cache.Config{MaxEntries: c.MaxEntries, TTL: c.TTL}
+}
+
+// get returns a copy of the result remembered for key, if it has not expired.
+func (m *resultMemo) get(key memoKey) (*entity.Result, bool) {
+	if m == nil {
+		return nil, false
+	}
+
+	result, ok := m.results.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return copyResult(result), true
+}
+
+// put remembers a copy of result for key, evicting the least recently used results beyond the size bound.
+func (m *resultMemo) put(key memoKey, result *entity.Result) {
+	if m == nil {
+		return
+	}
+	m.results.Put(key, copyResult(result))
+}
+
+// flush forgets every remembered result and returns how many it forgot.
+func (m *resultMemo) flush() int {
+	if m == nil {
+		return 0
+	}
+	return m.results.Clear()
+}
+
+// invalidate forgets the results of every operation on the matrix with checksum and returns how many it forgot.
+func (m *resultMemo) invalidate(checksum string) int {
+	if m == nil {
+		return 0
+	}
+	return m.results.RemoveFunc(func(key memoKey) bool {
+		return key.checksum == checksum
+	})
+}
+
+// metrics returns the lookups of the memo by outcome, the results it holds and its hit ratio.
+// A disabled memo reports no metrics.
+func (m *resultMemo) metrics() []*entity.Metric {
+	if m == nil {
+		return nil
+	}
+	return cacheMetrics("matrix.memo", "operation results", m.results.Stats())
+}
+
+// cacheMetrics returns the metrics of a cache of results named prefix: the results it holds, its hit ratio
+// and its lookups by outcome. results describes what the cache holds.
+func cacheMetrics(prefix string, results string, stats cache.Stats) []*entity.Metric {
+	return []*entity.Metric{
+		{
+			Name:        prefix + ".entries",
+			Description: "Count of " + results + " held in memory.",
+			Unit:        "{result}",
+			Kind:        entity.MetricGauge,
+			Points:      []*entity.MetricPoint{{Value: float64(stats.Entries)}},
+		},
+		{
+			Name:        prefix + ".hit_ratio",
+			Description: "Share of lookups answered with remembered " + results + ".",
+			Unit:        "1",
+			Kind:        entity.MetricGauge,
+			Points:      []*entity.MetricPoint{{Value: stats.HitRatio()}},
+		},
+		{
+			Name:        prefix + ".lookups",
+			Description: "Count of lookups of remembered " + results + ".",
+			Unit:        "{lookup}",
+			Kind:        entity.MetricCounter,
+			Points: []*entity.MetricPoint{
+				{Attributes: map[string]string{prefix + ".result": "hit"}, Value: float64(stats.Hits)},
+				{Attributes: map[string]string{prefix + ".result": "miss"}, Value: float64(stats.Misses)},
+			},
+		},
+	}
+}
+
+// copyResult returns a shallow copy of result, so callers attaching labels or pages to it leave the
+// remembered result untouched. The matrices of results are never modified, so they are shared.
+func copyResult(result *entity.Result) *entity.Result {
+	copied := *result
+	return &copied
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/cache"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+func TestNewResultMemo_Disabled(t *testing.T) {
+	assert.Nil(t, newResultMemo(MemoConfig{TTL: time.Minute}))
+}
+
+func TestResultMemo(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := MemoConfig{MaxEntries: 2, TTL: time.Minute}.cacheConfig()
+	config.Now = func() time.Time { return now }
+	memo := &resultMemo{results: cache.New[memoKey, *entity.Result](config)}
+
+	sum := memoKey{checksum: "a", operation: "sum"}
+	multiply := memoKey{checksum: "a", operation: "multiply"}
+	other := memoKey{checksum: "b", operation: "sum"}
+
+	_, ok := memo.get(sum)
+	assert.False(t, ok)
+
+	result := &entity.Result{Scalar: "10"}
+	memo.put(sum, result)
+	// The memo keeps its own copy of the result
+	result.Scalar = "11"
+	got, ok := memo.get(sum)
+	assert.True(t, ok)
+	assert.Equal(t, "10", got.Scalar)
+	got.Scalar = "12"
+	got, _ = memo.get(sum)
+	assert.Equal(t, "10", got.Scalar)
+
+	// The least recently used result is evicted first
+	memo.put(multiply, &entity.Result{Scalar: "24"})
+	memo.get(sum)
+	memo.put(other, &entity.Result{Scalar: "3"})
+	_, ok = memo.get(multiply)
+	assert.False(t, ok)
+	_, ok = memo.get(sum)
+	assert.True(t, ok)
+
+	// Results expire TTL after they were computed, however often they are used
+	now = now.Add(time.Minute)
+	_, ok = memo.get(sum)
+	assert.False(t, ok)
+	assert.Equal(t, 1, memo.results.Len())
+
+	metrics := memo.metrics()
+	assert.Equal(t, []string{"matrix.memo.entries", "matrix.memo.hit_ratio", "matrix.memo.lookups"},
+		[]string{metrics[0].Name, metrics[1].Name, metrics[2].Name})
+	assert.Equal(t, 1.0, metrics[0].Points[0].Value)
+	// 4 of the 7 lookups were hits
+	assert.InDelta(t, 4.0/7, metrics[1].Points[0].Value, 1e-9)
+	assert.Equal(t, entity.MetricCounter, metrics[2].Kind)
+	assert.Equal(t, map[string]string{"matrix.memo.result": "hit"}, metrics[2].Points[0].Attributes)
+	assert.Equal(t, 4.0, metrics[2].Points[0].Value)
+	assert.Equal(t, 3.0, metrics[2].Points[1].Value)
+}
+
+func TestResultMemo_Invalidate(t *testing.T) {
+	memo := newResultMemo(DefaultMemoConfig)
+	memo.put(memoKey{checksum: "a", operation: "sum"}, &entity.Result{Scalar: "10"})
+	memo.put(memoKey{checksum: "a", operation: "multiply", precision: 2}, &entity.Result{Scalar: "24"})
+	memo.put(memoKey{checksum: "b", operation: "sum"}, &entity.Result{Scalar: "3"})
+
+	// Every result on the matrix is forgotten, whatever the operation and precision
+	assert.Equal(t, 2, memo.invalidate("a"))
+	_, ok := memo.get(memoKey{checksum: "a", operation: "sum"})
+	assert.False(t, ok)
+	_, ok = memo.get(memoKey{checksum: "b", operation: "sum"})
+	assert.True(t, ok)
+	assert.Equal(t, 0, memo.invalidate("unknown"))
+
+	assert.Equal(t, 1, memo.flush())
+	_, ok = memo.get(memoKey{checksum: "b", operation: "sum"})
+	assert.False(t, ok)
+}
+
+func TestResultMemo_Disabled(t *testing.T) {
+	var memo *resultMemo
+
+	memo.put(memoKey{checksum: "a", operation: "sum"}, &entity.Result{Scalar: "10"})
+	_, ok := memo.get(memoKey{checksum: "a", operation: "sum"})
+
+	assert.False(t, ok)
+	assert.Nil(t, memo.metrics())
+	assert.Equal(t, 0, memo.invalidate("a"))
+	assert.Equal(t, 0, memo.flush())
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// memoryPressureRatio is the share of the memory limit above which new operations are rejected,
+	// leaving headroom for the requests already running.
+	memoryPressureRatio = 0.9
+
+	// memorySampleInterval is how long a memory reading is reused, so busy servers do not read
+	// the runtime metrics on every request.
+	memorySampleInterval = 100 * time.Millisecond
+)
+
+// MemoryGuardDomainInterface defines the business logic contract for shedding load under memory pressure.
+// It compares the memory used by the Go runtime with a limit, so expensive operations are rejected
+// while memory is tight instead of risking the process being killed for running out of memory.
+type MemoryGuardDomainInterface interface {
+	// Enabled reports whether a memory limit is configured. When it is not, every operation is admitted.
+	Enabled() bool
+
+	// Admit checks whether a new operation may start. It returns ErrServiceUnavailable while the memory
+	// used by the runtime is above 90% of the limit, so clients can retry once memory is reclaimed.
+	Admit(ctx context.Context) error
+}
+
+type memoryGuardDomain struct {
+	// limit is the memory limit in bytes; 0 disables the guard
+	limit int64
+	// usage reports the memory currently used by the runtime, in bytes
+	usage func() uint64
+	now   func() time.Time
+
+	// mu guards the cached memory reading and the pressure state
+	mu        sync.Mutex
+	sampledAt time.Time
+	used      uint64
+	shedding  bool
+}
+
+// NewMemoryGuardDomain creates a new instance of MemoryGuardDomainInterface.
+// It initializes the guard with limit, in bytes. When limit is 0, the soft memory limit of the runtime set with
+// GOMEMLIMIT is used instead, and the guard is disabled when neither is set.
+func NewMemoryGuardDomain(limit int64) MemoryGuardDomainInterface {
+	if limit == 0 {
+		// A negative input only reports the current limit, which is MaxInt64 unless GOMEMLIMIT is set
+		if runtimeLimit := debug.SetMemoryLimit(-1); runtimeLimit != math.MaxInt64 {
+			limit = runtimeLimit
+		}
+	}
+
+	return newMemoryGuardDomain(limit, runtimeMemoryUsage, time.Now)
+}
+
+// newMemoryGuardDomain builds a memory guard that reads memory usage with usage and the time with now.
+func newMemoryGuardDomain(limit int64, usage func() uint64, now func() time.Time) *memoryGuardDomain {
+	return &memoryGuardDomain{
+		limit: limit,
+		usage: usage,
+		now:   now,
+	}
+}
+
+func (d *memoryGuardDomain) Enabled() bool {
+	return d.limit > 0
+}
+
+func (d *memoryGuardDomain) Admit(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !d.Enabled() {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if now := d.now(); now.Sub(d.sampledAt) >= memorySampleInterval {
+		d.used = d.usage()
+		d.sampledAt = now
+
+		// Log transitions only, rather than every rejected request
+		shedding := float64(d.used) > float64(d.limit)*memoryPressureRatio
+		if shedding != d.shedding {
+			d.shedding = shedding
+			if shedding {
+				slog.WarnContext(ctx, "memory pressure, rejecting new operations", "memory_used", d.used, "memory_limit", d.limit)
+			} else {
+				slog.InfoContext(ctx, "memory pressure relieved, accepting operations", "memory_used", d.used, "memory_limit", d.limit)
+			}
+		}
+	}
+
+	if d.shedding {
+		return fmt.Errorf("%w: server is low on memory, retry later", apperrors.ErrServiceUnavailable)
+	}
+	return nil
+}
+
+// runtimeMemorySamples are the runtime metrics whose difference is the memory counted against GOMEMLIMIT.
+var runtimeMemorySamples = []string{
+	"/memory/classes/total:bytes",
+	"/memory/classes/heap/released:bytes",
+}
+
+// runtimeMemoryUsage returns the memory mapped by the Go runtime minus the memory returned to the OS,
+// which is the figure the runtime compares with its own soft memory limit.
+func runtimeMemoryUsage() uint64 {
+	samples := make([]metrics.Sample, len(runtimeMemorySamples))
+	for i, name := range runtimeMemorySamples {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+
+	total, released := samples[0].Value.Uint64(), samples[1].Value.Uint64()
+	if released > total {
+		return 0
+	}
+	return total - released
+}
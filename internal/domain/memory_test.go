@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestNewMemoryGuardDomain(t *testing.T) {
+	t.Run("configured limit", func(t *testing.T) {
+		guard := NewMemoryGuardDomain(512 << 20)
+		assert.True(t, guard.Enabled())
+		assert.Equal(t, int64(512<<20), guard.(*memoryGuardDomain).limit)
+	})
+
+	t.Run("admits everything when the limit is far away", func(t *testing.T) {
+		guard := NewMemoryGuardDomain(1 << 40)
+		assert.NoError(t, guard.Admit(context.Background()))
+	})
+}
+
+func TestMemoryGuardDomain_Admit(t *testing.T) {
+	t.Run("disabled guard admits everything", func(t *testing.T) {
+		guard := newMemoryGuardDomain(0, func() uint64 { return 1 << 40 }, time.Now)
+		assert.False(t, guard.Enabled())
+		assert.NoError(t, guard.Admit(context.Background()))
+	})
+
+	t.Run("sheds above the pressure threshold until memory is reclaimed", func(t *testing.T) {
+		now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		used := uint64(800)
+		reads := 0
+		guard := newMemoryGuardDomain(1000, func() uint64 { reads++; return used }, func() time.Time { return now })
+
+		assert.NoError(t, guard.Admit(context.Background()))
+
+		// Readings are reused within the sample interval
+		used = 950
+		assert.NoError(t, guard.Admit(context.Background()))
+		assert.Equal(t, 1, reads)
+
+		now = now.Add(memorySampleInterval)
+		assert.ErrorIs(t, guard.Admit(context.Background()), apperrors.ErrServiceUnavailable)
+		assert.Equal(t, 2, reads)
+
+		used = 900
+		now = now.Add(memorySampleInterval)
+		assert.NoError(t, guard.Admit(context.Background()))
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		guard := newMemoryGuardDomain(1000, func() uint64 { return 0 }, time.Now)
+		assert.ErrorIs(t, guard.Admit(ctx), context.Canceled)
+	})
+}
+
+func TestRuntimeMemoryUsage(t *testing.T) {
+	assert.Positive(t, runtimeMemoryUsage())
+}
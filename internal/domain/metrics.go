@@ -0,0 +1,341 @@
+package domain
+
+import (
+	"cmp"
+	"maps"
+	"net/http"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// requestDurationBounds are the upper bounds, in seconds, of the buckets of the request duration histogram,
+// as recommended by the OpenTelemetry semantic conventions for HTTP servers.
+var requestDurationBounds = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// matrixSizeBounds are the upper bounds, in values, of the buckets of the matrix size histogram.
+var matrixSizeBounds = []float64{1, 10, 100, 1e3, 1e4, 1e5, 1e6}
+
+// knownMethods are the HTTP methods recorded as they are; any other method is recorded as _OTHER,
+// so clients cannot create series at will.
+var knownMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// MetricsDomainInterface defines the business logic contract for the metrics of the service.
+// It accumulates the measurements reported by the HTTP and gRPC servers and collects them with the runtime
+// measurements, so they can be exported.
+type MetricsDomainInterface interface {
+	// RecordRequest counts a served HTTP request and its duration by method, route and status code.
+	// route is the route template, such as /matrix/{operation}, never the raw path.
+	RecordRequest(method, route string, statusCode int, duration time.Duration)
+
+	// RecordCall counts a served gRPC call and its duration by method, such as
+	// /leaguematrix.v1.MatrixService/ProcessMatrix, and status code.
+	RecordCall(method string, statusCode int, duration time.Duration)
+
+	// RecordOperation counts a request that ran operation, with its duration and the number of values
+	// of every matrix it read.
+	RecordOperation(operation string, duration time.Duration, sizes []int)
+
+	// Register adds collect to the sources of metrics, called on every snapshot so subsystems
+	// such as the matrix domain export their own measurements.
+	Register(collect func() []*entity.Metric)
+
+	// Snapshot returns the current value of every metric, sorted by name and attributes.
+	Snapshot() *entity.MetricsSnapshot
+}
+
+// requestKey identifies the series of a request metric.
+type requestKey struct {
+	method     string
+	route      string
+	statusCode int
+}
+
+// callKey identifies the series of a gRPC call metric.
+type callKey struct {
+	method     string
+	statusCode int
+}
+
+// classKey identifies the series of a request counter, which counts requests by status class.
+type classKey struct {
+	method string
+	route  string
+	class  string
+}
+
+// requestStats accumulates the durations of the requests of a series.
+type requestStats struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+// observe adds value to the histogram bounded by bounds.
+func (s *requestStats) observe(bounds []float64, value float64) {
+	if s.buckets == nil {
+		s.buckets = make([]uint64, len(bounds)+1)
+	}
+	s.count++
+	s.sum += value
+	// A value equal to a bound belongs to the bucket that bound closes
+	bucket, _ := slices.BinarySearch(bounds, value)
+	s.buckets[bucket]++
+}
+
+// point returns the histogram as a metric point with attributes.
+func (s *requestStats) point(attributes map[string]string) *entity.MetricPoint {
+	return &entity.MetricPoint{
+		Attributes:   attributes,
+		Count:        s.count,
+		Sum:          s.sum,
+		BucketCounts: slices.Clone(s.buckets),
+	}
+}
+
+// operationStats accumulates the durations of the requests that ran an operation and the sizes of their matrices.
+type operationStats struct {
+	durations requestStats
+	sizes     requestStats
+}
+
+type metricsDomain struct {
+	startedAt time.Time
+	// now returns the current time; tests replace it to control the snapshot times
+	now func() time.Time
+
+	mu         sync.Mutex
+	requests   map[requestKey]*requestStats
+	calls      map[callKey]*requestStats
+	operations map[string]*operationStats
+	collectors []func() []*entity.Metric
+}
+
+// NewMetricsDomain creates a new instance of MetricsDomainInterface.
+// It initializes the domain service without measurements, accumulating from now.
+func NewMetricsDomain() MetricsDomainInterface {
+	return &metricsDomain{
+		startedAt:  time.Now(),
+		now:        time.Now,
+		requests:   make(map[requestKey]*requestStats),
+		calls:      make(map[callKey]*requestStats),
+		operations: make(map[string]*operationStats),
+	}
+}
+
+func (d *metricsDomain) RecordRequest(method, route string, statusCode int, duration time.Duration) {
+	if !slices.Contains(knownMethods, method) {
+		method = "_OTHER"
+	}
+	key := requestKey{method: method, route: route, statusCode: statusCode}
+	seconds := duration.Seconds()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats, ok := d.requests[key]
+	if !ok {
+		stats = &requestStats{}
+		d.requests[key] = stats
+	}
+	stats.observe(requestDurationBounds, seconds)
+}
+
+func (d *metricsDomain) RecordCall(method string, statusCode int, duration time.Duration) {
+	key := callKey{method: method, statusCode: statusCode}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats, ok := d.calls[key]
+	if !ok {
+		stats = &requestStats{}
+		d.calls[key] = stats
+	}
+	stats.observe(requestDurationBounds, duration.Seconds())
+}
+
+func (d *metricsDomain) RecordOperation(operation string, duration time.Duration, sizes []int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats, ok := d.operations[operation]
+	if !ok {
+		stats = &operationStats{}
+		d.operations[operation] = stats
+	}
+	stats.durations.observe(requestDurationBounds, duration.Seconds())
+	for _, size := range sizes {
+		stats.sizes.observe(matrixSizeBounds, float64(size))
+	}
+}
+
+func (d *metricsDomain) Register(collect func() []*entity.Metric) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.collectors = append(d.collectors, collect)
+}
+
+func (d *metricsDomain) Snapshot() *entity.MetricsSnapshot {
+	duration := &entity.Metric{
+		Name:        "http.server.request.duration",
+		Description: "Duration of HTTP server requests.",
+		Unit:        "s",
+		Kind:        entity.MetricHistogram,
+		Bounds:      requestDurationBounds,
+	}
+
+	requests := &entity.Metric{
+		Name:        "http.server.requests",
+		Description: "Count of HTTP server requests, by status class such as 2xx.",
+		Unit:        "{request}",
+		Kind:        entity.MetricCounter,
+	}
+	errors := &entity.Metric{
+		Name:        "http.server.errors",
+		Description: "Count of HTTP server requests answered with a 4xx or 5xx status.",
+		Unit:        "{request}",
+		Kind:        entity.MetricCounter,
+	}
+	callDuration := &entity.Metric{
+		Name:        "rpc.server.call.duration",
+		Description: "Duration of gRPC server calls.",
+		Unit:        "s",
+		Kind:        entity.MetricHistogram,
+		Bounds:      requestDurationBounds,
+	}
+	operationDuration := &entity.Metric{
+		Name:        "matrix.operation.duration",
+		Description: "Duration of the HTTP server requests and gRPC server calls that ran a matrix operation.",
+		Unit:        "s",
+		Kind:        entity.MetricHistogram,
+		Bounds:      requestDurationBounds,
+	}
+	matrixSize := &entity.Metric{
+		Name:        "matrix.size",
+		Description: "Number of values of the matrices read by matrix operations.",
+		Unit:        "{value}",
+		Kind:        entity.MetricHistogram,
+		Bounds:      matrixSizeBounds,
+	}
+
+	d.mu.Lock()
+	keys := make([]requestKey, 0, len(d.requests))
+	for key := range d.requests {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b requestKey) int {
+		return cmp.Or(cmp.Compare(a.route, b.route), cmp.Compare(a.method, b.method), cmp.Compare(a.statusCode, b.statusCode))
+	})
+	requestCounts := make(map[classKey]uint64)
+	// Errors are not counted by method, so their keys leave it empty
+	errorCounts := make(map[classKey]uint64)
+	for _, key := range keys {
+		stats := d.requests[key]
+		duration.Points = append(duration.Points, stats.point(map[string]string{
+			"http.request.method":       key.method,
+			"http.route":                key.route,
+			"http.response.status_code": strconv.Itoa(key.statusCode),
+		}))
+
+		class := statusClass(key.statusCode)
+		requestCounts[classKey{route: key.route, method: key.method, class: class}] += stats.count
+		if key.statusCode >= http.StatusBadRequest {
+			errorCounts[classKey{route: key.route, class: class}] += stats.count
+		}
+	}
+	for _, key := range slices.SortedFunc(maps.Keys(requestCounts), compareClassKeys) {
+		requests.Points = append(requests.Points, &entity.MetricPoint{
+			Attributes: map[string]string{
+				"http.request.method":        key.method,
+				"http.route":                 key.route,
+				"http.response.status_class": key.class,
+			},
+			Value: float64(requestCounts[key]),
+		})
+	}
+	for _, key := range slices.SortedFunc(maps.Keys(errorCounts), compareClassKeys) {
+		errors.Points = append(errors.Points, &entity.MetricPoint{
+			Attributes: map[string]string{
+				"http.route":                 key.route,
+				"http.response.status_class": key.class,
+			},
+			Value: float64(errorCounts[key]),
+		})
+	}
+
+	for _, key := range slices.SortedFunc(maps.Keys(d.calls), func(a, b callKey) int {
+		return cmp.Or(cmp.Compare(a.method, b.method), cmp.Compare(a.statusCode, b.statusCode))
+	}) {
+		callDuration.Points = append(callDuration.Points, d.calls[key].point(map[string]string{
+			"rpc.system":           "grpc",
+			"rpc.method":           key.method,
+			"rpc.grpc.status_code": strconv.Itoa(key.statusCode),
+		}))
+	}
+
+	for _, operation := range slices.Sorted(maps.Keys(d.operations)) {
+		stats := d.operations[operation]
+		attributes := map[string]string{"matrix.operation": operation}
+		operationDuration.Points = append(operationDuration.Points, stats.durations.point(attributes))
+		if stats.sizes.count > 0 {
+			matrixSize.Points = append(matrixSize.Points, stats.sizes.point(attributes))
+		}
+	}
+	collectors := slices.Clone(d.collectors)
+	d.mu.Unlock()
+
+	metrics := []*entity.Metric{
+		{
+			Name:        "go.goroutine.count",
+			Description: "Count of live goroutines.",
+			Unit:        "{goroutine}",
+			Kind:        entity.MetricGauge,
+			Points:      []*entity.MetricPoint{{Value: float64(runtime.NumGoroutine())}},
+		},
+		{
+			Name:        "go.memory.used",
+			Description: "Memory used by the Go runtime, as counted against its memory limit.",
+			Unit:        "By",
+			Kind:        entity.MetricGauge,
+			Points:      []*entity.MetricPoint{{Value: float64(runtimeMemoryUsage())}},
+		},
+		duration,
+		requests,
+		errors,
+		callDuration,
+		operationDuration,
+		matrixSize,
+	}
+	// Collectors take their own locks, so they are called once the requests have been copied
+	for _, collect := range collectors {
+		metrics = append(metrics, collect()...)
+	}
+	slices.SortStableFunc(metrics, func(a, b *entity.Metric) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return &entity.MetricsSnapshot{
+		StartTime: d.startedAt,
+		Time:      d.now(),
+		Metrics:   metrics,
+	}
+}
+
+// statusClass returns the class of statusCode, such as 2xx for 204.
+func statusClass(statusCode int) string {
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+// compareClassKeys orders the series of the request counters like those of the request durations.
+func compareClassKeys(a, b classKey) int {
+	return cmp.Or(cmp.Compare(a.route, b.route), cmp.Compare(a.method, b.method), cmp.Compare(a.class, b.class))
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+func TestMetricsDomain_RecordRequest(t *testing.T) {
+	domain := NewMetricsDomain()
+
+	domain.RecordRequest("GET", "/matrix/{operation}", 200, 3*time.Millisecond)
+	domain.RecordRequest("GET", "/matrix/{operation}", 200, 100*time.Millisecond)
+	domain.RecordRequest("GET", "/matrix/{operation}", 200, 20*time.Second)
+	domain.RecordRequest("GET", "/matrix/{operation}", 400, time.Millisecond)
+	domain.RecordRequest("BREW", "/", 405, time.Millisecond)
+
+	duration := findMetric(t, domain.Snapshot(), "http.server.request.duration")
+	assert.Equal(t, entity.MetricHistogram, duration.Kind)
+	assert.Equal(t, "s", duration.Unit)
+	if !assert.Len(t, duration.Points, 3) {
+		return
+	}
+
+	// Unknown methods are grouped, so clients cannot create series at will
+	assert.Equal(t, map[string]string{
+		"http.request.method":       "_OTHER",
+		"http.route":                "/",
+		"http.response.status_code": "405",
+	}, duration.Points[0].Attributes)
+
+	ok := duration.Points[1]
+	assert.Equal(t, "200", ok.Attributes["http.response.status_code"])
+	assert.Equal(t, uint64(3), ok.Count)
+	assert.InDelta(t, 20.103, ok.Sum, 1e-9)
+	assert.Len(t, ok.BucketCounts, len(duration.Bounds)+1)
+	// 3ms falls in the first bucket, 100ms in the bucket it closes and 20s past the last bound
+	assert.Equal(t, uint64(1), ok.BucketCounts[0])
+	assert.Equal(t, uint64(1), ok.BucketCounts[5])
+	assert.Equal(t, uint64(1), ok.BucketCounts[len(duration.Bounds)])
+
+	assert.Equal(t, "400", duration.Points[2].Attributes["http.response.status_code"])
+	assert.Equal(t, uint64(1), duration.Points[2].Count)
+}
+
+func TestMetricsDomain_Snapshot(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := &metricsDomain{
+		startedAt:  start,
+		now:        func() time.Time { return start.Add(time.Minute) },
+		requests:   make(map[requestKey]*requestStats),
+		calls:      make(map[callKey]*requestStats),
+		operations: make(map[string]*operationStats),
+	}
+
+	snapshot := domain.Snapshot()
+
+	assert.Equal(t, start, snapshot.StartTime)
+	assert.Equal(t, start.Add(time.Minute), snapshot.Time)
+	names := make([]string, 0, len(snapshot.Metrics))
+	for _, metric := range snapshot.Metrics {
+		names = append(names, metric.Name)
+	}
+	assert.Equal(t, []string{
+		"go.goroutine.count", "go.memory.used", "http.server.errors", "http.server.request.duration",
+		"http.server.requests", "matrix.operation.duration", "matrix.size", "rpc.server.call.duration",
+	}, names)
+
+	goroutines := snapshot.Metrics[0]
+	assert.Equal(t, entity.MetricGauge, goroutines.Kind)
+	if assert.Len(t, goroutines.Points, 1) {
+		assert.Positive(t, goroutines.Points[0].Value)
+	}
+	// No request has been served yet
+	for _, metric := range snapshot.Metrics[2:] {
+		assert.Empty(t, metric.Points, metric.Name)
+	}
+
+	// A snapshot is a copy that later requests do not change
+	domain.RecordRequest("GET", "/health", 200, time.Millisecond)
+	domain.RecordCall("/grpc.health.v1.Health/Check", 0, time.Millisecond)
+	domain.RecordOperation("sum", time.Millisecond, []int{4})
+	for _, metric := range snapshot.Metrics[2:] {
+		assert.Empty(t, metric.Points, metric.Name)
+	}
+}
+
+func TestMetricsDomain_RecordCall(t *testing.T) {
+	domain := NewMetricsDomain()
+
+	domain.RecordCall("/leaguematrix.v1.MatrixService/ProcessMatrix", 0, 3*time.Millisecond)
+	domain.RecordCall("/leaguematrix.v1.MatrixService/ProcessMatrix", 0, 2*time.Second)
+	domain.RecordCall("/leaguematrix.v1.MatrixService/ProcessMatrix", 4, time.Millisecond)
+	domain.RecordCall("/grpc.health.v1.Health/Check", 0, time.Millisecond)
+
+	duration := findMetric(t, domain.Snapshot(), "rpc.server.call.duration")
+	assert.Equal(t, entity.MetricHistogram, duration.Kind)
+	assert.Equal(t, "s", duration.Unit)
+	if assert.Len(t, duration.Points, 3) {
+		assert.Equal(t, map[string]string{
+			"rpc.system":           "grpc",
+			"rpc.method":           "/grpc.health.v1.Health/Check",
+			"rpc.grpc.status_code": "0",
+		}, duration.Points[0].Attributes)
+		ok := duration.Points[1]
+		assert.Equal(t, uint64(2), ok.Count)
+		assert.InDelta(t, 2.003, ok.Sum, 1e-9)
+		assert.Equal(t, "4", duration.Points[2].Attributes["rpc.grpc.status_code"])
+	}
+}
+
+func TestMetricsDomain_RequestCounts(t *testing.T) {
+	domain := NewMetricsDomain()
+
+	domain.RecordRequest("GET", "/matrix/{operation}", 200, time.Millisecond)
+	domain.RecordRequest("GET", "/matrix/{operation}", 204, time.Millisecond)
+	domain.RecordRequest("GET", "/matrix/{operation}", 400, time.Millisecond)
+	domain.RecordRequest("POST", "/matrix/{operation}", 422, time.Millisecond)
+	domain.RecordRequest("GET", "/health", 503, time.Millisecond)
+
+	snapshot := domain.Snapshot()
+
+	// Requests are counted by status class, so every status of a class adds to the same series
+	requests := findMetric(t, snapshot, "http.server.requests")
+	assert.Equal(t, entity.MetricCounter, requests.Kind)
+	counts := make(map[string]float64)
+	for _, point := range requests.Points {
+		attributes := point.Attributes
+		counts[attributes["http.request.method"]+" "+attributes["http.route"]+" "+attributes["http.response.status_class"]] = point.Value
+	}
+	assert.Equal(t, map[string]float64{
+		"GET /health 5xx":              1,
+		"GET /matrix/{operation} 2xx":  2,
+		"GET /matrix/{operation} 4xx":  1,
+		"POST /matrix/{operation} 4xx": 1,
+	}, counts)
+
+	// Errors are counted by route and status class, whatever the method
+	errors := findMetric(t, snapshot, "http.server.errors")
+	assert.Equal(t, entity.MetricCounter, errors.Kind)
+	if assert.Len(t, errors.Points, 2) {
+		assert.Equal(t, map[string]string{
+			"http.route":                 "/health",
+			"http.response.status_class": "5xx",
+		}, errors.Points[0].Attributes)
+		assert.Equal(t, float64(1), errors.Points[0].Value)
+		assert.Equal(t, "/matrix/{operation}", errors.Points[1].Attributes["http.route"])
+		assert.Equal(t, float64(2), errors.Points[1].Value)
+	}
+}
+
+func TestMetricsDomain_RecordOperation(t *testing.T) {
+	domain := NewMetricsDomain()
+
+	domain.RecordOperation("sum", 3*time.Millisecond, []int{9})
+	domain.RecordOperation("sum", 2*time.Second, []int{1_000_000})
+	domain.RecordOperation("concat", time.Millisecond, []int{4, 6})
+	// An operation rejected before reading a matrix still counts, without a size
+	domain.RecordOperation("inverse", time.Millisecond, nil)
+
+	snapshot := domain.Snapshot()
+
+	duration := findMetric(t, snapshot, "matrix.operation.duration")
+	assert.Equal(t, entity.MetricHistogram, duration.Kind)
+	assert.Equal(t, "s", duration.Unit)
+	if assert.Len(t, duration.Points, 3) {
+		assert.Equal(t, map[string]string{"matrix.operation": "concat"}, duration.Points[0].Attributes)
+		assert.Equal(t, "inverse", duration.Points[1].Attributes["matrix.operation"])
+		sum := duration.Points[2]
+		assert.Equal(t, uint64(2), sum.Count)
+		assert.InDelta(t, 2.003, sum.Sum, 1e-9)
+	}
+
+	size := findMetric(t, snapshot, "matrix.size")
+	assert.Equal(t, entity.MetricHistogram, size.Kind)
+	if assert.Len(t, size.Points, 2) {
+		concat := size.Points[0]
+		assert.Equal(t, "concat", concat.Attributes["matrix.operation"])
+		assert.Equal(t, uint64(2), concat.Count)
+		assert.Equal(t, float64(10), concat.Sum)
+		// 4 values fall in the bucket closed by 10, as do 6
+		assert.Equal(t, uint64(2), concat.BucketCounts[1])
+
+		sum := size.Points[1]
+		assert.Equal(t, uint64(1), sum.BucketCounts[1])
+		// A million values fall in the bucket closed by the last bound
+		assert.Equal(t, uint64(1), sum.BucketCounts[len(size.Bounds)-1])
+	}
+}
+
+func TestMetricsDomain_Register(t *testing.T) {
+	domain := NewMetricsDomain()
+	domain.Register(func() []*entity.Metric {
+		return []*entity.Metric{{Name: "matrix.memo.entries", Kind: entity.MetricGauge}}
+	})
+	domain.Register(func() []*entity.Metric {
+		return []*entity.Metric{{Name: "app.queue.size", Kind: entity.MetricGauge}}
+	})
+
+	// Registered metrics are collected on every snapshot, sorted with the built-in ones
+	names := make([]string, 0)
+	for _, metric := range domain.Snapshot().Metrics {
+		names = append(names, metric.Name)
+	}
+	assert.Equal(t, []string{
+		"app.queue.size", "go.goroutine.count", "go.memory.used", "http.server.errors", "http.server.request.duration",
+		"http.server.requests", "matrix.memo.entries", "matrix.operation.duration", "matrix.size",
+		"rpc.server.call.duration",
+	}, names)
+}
+
+// findMetric returns the metric of snapshot named name, failing the test without one.
+func findMetric(t *testing.T, snapshot *entity.MetricsSnapshot, name string) *entity.Metric {
+	t.Helper()
+	for _, metric := range snapshot.Metrics {
+		if metric.Name == name {
+			return metric
+		}
+	}
+	t.Fatalf("metric %s not found", name)
+	return nil
+}
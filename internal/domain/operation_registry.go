@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// OperationRegistryDomainInterface defines the business logic contract for managing the matrix operations
+// the service runs while it runs. Changes apply to every request, job and schedule from then on,
+// and last until the process exits.
+type OperationRegistryDomainInterface interface {
+	// ListOperations returns every registered operation, enabled or not, in alphabetical order.
+	ListOperations(ctx context.Context) ([]entity.OperationInfo, error)
+
+	// GetOperation returns the operation with the given name, or ErrNotFound for unknown operations.
+	GetOperation(ctx context.Context, name string) (*entity.OperationInfo, error)
+
+	// RegisterOperation adds a composite operation running steps in turn, each on the matrix returned
+	// by the previous one. It returns ErrConflict when the name is taken and ErrInvalidInput for invalid steps.
+	RegisterOperation(ctx context.Context, name, description string, steps []string) (*entity.OperationInfo, error)
+
+	// SetOperationEnabled enables or disables an operation. Requests for a disabled operation are rejected
+	// with ErrForbidden. It returns ErrNotFound for unknown operations.
+	SetOperationEnabled(ctx context.Context, name string, enabled bool) (*entity.OperationInfo, error)
+}
+
+type operationRegistryDomain struct {
+	registry *matrixlib.Registry
+}
+
+// NewOperationRegistryDomain creates a new instance of OperationRegistryDomainInterface.
+// It initializes the domain service with the registry of the exported matrix engine,
+// which every operation run by the service is looked up in.
+func NewOperationRegistryDomain() OperationRegistryDomainInterface {
+	return &operationRegistryDomain{
+		registry: matrixlib.DefaultRegistry,
+	}
+}
+
+func (d *operationRegistryDomain) ListOperations(ctx context.Context) ([]entity.OperationInfo, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return d.registry.DescribeAll(), nil
+}
+
+func (d *operationRegistryDomain) GetOperation(ctx context.Context, name string) (*entity.OperationInfo, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info, err := d.registry.Describe(matrixlib.Operation(name))
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (d *operationRegistryDomain) RegisterOperation(ctx context.Context, name, description string, steps []string) (*entity.OperationInfo, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	operations := make([]matrixlib.Operation, 0, len(steps))
+	for _, step := range steps {
+		operations = append(operations, matrixlib.Operation(step))
+	}
+	err := d.registry.Register(matrixlib.Operation(name), description, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "operation registered",
+		"operation", name,
+		"steps", steps)
+	return d.GetOperation(ctx, name)
+}
+
+func (d *operationRegistryDomain) SetOperationEnabled(ctx context.Context, name string, enabled bool) (*entity.OperationInfo, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	err := d.registry.SetEnabled(matrixlib.Operation(name), enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "operation availability changed",
+		"operation", name,
+		"enabled", enabled)
+	return d.GetOperation(ctx, name)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+func TestOperationRegistryDomain(t *testing.T) {
+	// A registry of its own keeps the operations of the other tests unchanged
+	d := &operationRegistryDomain{registry: matrixlib.NewRegistry()}
+	ctx := context.Background()
+
+	got, err := d.RegisterOperation(ctx, "column-sums", "Adds every column.", []string{"invert", "sum"})
+	require.NoError(t, err)
+	assert.Equal(t, &entity.OperationInfo{
+		Name:        "column-sums",
+		Description: "Adds every column.",
+		Steps:       []matrixlib.Operation{matrixlib.Invert, matrixlib.Sum},
+		Enabled:     true,
+	}, got)
+
+	_, err = d.RegisterOperation(ctx, "column-sums", "", []string{"sum"})
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	_, err = d.RegisterOperation(ctx, "halve", "", []string{"divide"})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+	got, err = d.SetOperationEnabled(ctx, "sum", false)
+	require.NoError(t, err)
+	assert.False(t, got.Enabled)
+	assert.True(t, got.BuiltIn)
+	_, err = d.SetOperationEnabled(ctx, "divide", false)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	operations, err := d.ListOperations(ctx)
+	require.NoError(t, err)
+	names := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		names = append(names, string(operation.Name))
+	}
+	assert.Equal(t, []string{"column-sums", "echo", "flatten", "inverse", "invert", "max", "mean", "median", "min", "multiply", "sum", "transpose"}, names)
+
+	_, err = d.GetOperation(ctx, "divide")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := d.ListOperations(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = d.RegisterOperation(ctx, "row", "", []string{"flatten"})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestNewOperationRegistryDomain(t *testing.T) {
+	// The service manages the registry every operation is run from
+	d := NewOperationRegistryDomain().(*operationRegistryDomain)
+	assert.Same(t, matrixlib.DefaultRegistry, d.registry)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+)
+
+// executionPool runs CPU-bound work on a fixed number of worker goroutines.
+// Work submitted while every worker is busy waits for a free one, so a burst of
+// concurrent requests cannot oversubscribe the CPUs and slow every request down.
+//
+// Work waits in a queue per class of service. Interactive work is picked up first by every worker,
+// while batch work is only picked up by half of them, so a flood of batch work leaves the other
+// workers to interactive requests.
+type executionPool struct {
+	interactive chan func()
+	batch       chan func()
+
+	workers int
+	// serviceTime measures how long the workers take per task, to estimate how long queued work will take
+	serviceTime serviceTimer
+}
+
+var (
+	sharedPoolOnce sync.Once
+	sharedPool     *executionPool
+)
+
+// sharedExecutionPool returns the pool shared by every operations domain of the process,
+// sized to the number of CPUs usable by the Go runtime. It is started on first use.
+func sharedExecutionPool() *executionPool {
+	sharedPoolOnce.Do(func() {
+		sharedPool = newExecutionPool(runtime.GOMAXPROCS(0))
+	})
+	return sharedPool
+}
+
+// newExecutionPool starts an execution pool with the given number of workers, of which half,
+// rounded up, also run batch work.
+func newExecutionPool(workers int) *executionPool {
+	p := &executionPool{
+		interactive: make(chan func()),
+		batch:       make(chan func()),
+		workers:     workers,
+	}
+	batchWorkers := (workers + 1) / 2
+	for i := range workers {
+		if i < batchWorkers {
+			go p.workShared()
+		} else {
+			go p.workInteractive()
+		}
+	}
+	return p
+}
+
+// workInteractive runs interactive work only.
+func (p *executionPool) workInteractive() {
+	for task := range p.interactive {
+		task()
+	}
+}
+
+// workShared runs work of both classes, preferring interactive work whenever some is waiting.
+func (p *executionPool) workShared() {
+	for {
+		select {
+		case task := <-p.interactive:
+			task()
+			continue
+		default:
+		}
+
+		select {
+		case task := <-p.interactive:
+			task()
+		case task := <-p.batch:
+			task()
+		}
+	}
+}
+
+// run executes fn on a pool worker and waits for it to return, queued by the class of service carried by ctx.
+// It gives up with the context's error if ctx is done before a worker picks fn up;
+// once started, fn always runs to completion. A panic in fn is recovered and returned as an error, which
+// responds with 500 Internal Server Error, so it fails the request rather than the worker and the process.
+func (p *executionPool) run(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+	var panicErr error
+	task := func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(ctx, "operation panicked",
+					"panic", r,
+					"stack", string(debug.Stack()))
+				panicErr = fmt.Errorf("operation panicked: %v", r)
+			}
+		}()
+		start := time.Now()
+		fn()
+		p.serviceTime.observe(time.Since(start))
+	}
+
+	queue := p.interactive
+	if qos.FromContext(ctx) == qos.Batch {
+		queue = p.batch
+	}
+
+	select {
+	case queue <- task:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	<-done
+	return panicErr
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestExecutionPool_Run(t *testing.T) {
+	t.Run("runs the task and waits for it", func(t *testing.T) {
+		pool := newExecutionPool(1)
+
+		ran := false
+		err := pool.run(context.Background(), func() { ran = true })
+		require.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("bounds concurrent tasks to the number of workers", func(t *testing.T) {
+		const workers = 2
+		pool := newExecutionPool(workers)
+
+		var running, peak atomic.Int32
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := pool.run(context.Background(), func() {
+					n := running.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					running.Add(-1)
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.LessOrEqual(t, peak.Load(), int32(workers))
+		assert.Positive(t, peak.Load())
+	})
+
+	t.Run("recovers a panicking task", func(t *testing.T) {
+		pool := newExecutionPool(1)
+
+		err := pool.run(context.Background(), func() { panic("index out of range") })
+		assert.ErrorContains(t, err, "operation panicked: index out of range")
+		assert.Equal(t, http.StatusInternalServerError, apperrors.GetHTTPStatusCode(err))
+
+		// The worker survives the panic and runs the next task
+		ran := false
+		err = pool.run(context.Background(), func() { ran = true })
+		require.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("gives up when the context is done before a worker is free", func(t *testing.T) {
+		pool := newExecutionPool(1)
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_ = pool.run(context.Background(), func() {
+				close(started)
+				<-release
+			})
+		}()
+		<-started
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		ran := false
+		err := pool.run(ctx, func() { ran = true })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, ran)
+	})
+}
+
+func TestExecutionPool_Classes(t *testing.T) {
+	batch := qos.NewContext(context.Background(), qos.Batch)
+
+	t.Run("batch work leaves workers to interactive work", func(t *testing.T) {
+		pool := newExecutionPool(2)
+
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{}, 2)
+		for range 2 {
+			go func() {
+				_ = pool.run(batch, func() {
+					started <- struct{}{}
+					<-release
+				})
+			}()
+		}
+		<-started
+
+		// The second batch task waits for the only worker running batch work
+		select {
+		case <-started:
+			t.Fatal("batch work ran on every worker")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ran := false
+		err := pool.run(ctx, func() { ran = true })
+		require.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("a single worker runs both classes", func(t *testing.T) {
+		pool := newExecutionPool(1)
+
+		var classes []qos.Class
+		for _, ctx := range []context.Context{batch, context.Background()} {
+			err := pool.run(ctx, func() { classes = append(classes, qos.FromContext(ctx)) })
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []qos.Class{qos.Batch, qos.Interactive}, classes)
+	})
+
+	t.Run("interactive work goes first", func(t *testing.T) {
+		pool := newExecutionPool(1)
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_ = pool.run(context.Background(), func() {
+				close(started)
+				<-release
+			})
+		}()
+		<-started
+
+		var mu sync.Mutex
+		var order []qos.Class
+		var wg sync.WaitGroup
+		for _, ctx := range []context.Context{batch, context.Background()} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, pool.run(ctx, func() {
+					mu.Lock()
+					defer mu.Unlock()
+					order = append(order, qos.FromContext(ctx))
+				}))
+			}()
+		}
+		// Let both tasks queue up behind the busy worker
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, []qos.Class{qos.Interactive, qos.Batch}, order)
+	})
+}
+
+func TestSharedExecutionPool(t *testing.T) {
+	assert.Same(t, sharedExecutionPool(), sharedExecutionPool())
+}
@@ -0,0 +1,373 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// byteOrderMark is the UTF-8 byte order mark spreadsheets write at the start of CSV files.
+const byteOrderMark = "\ufeff"
+
+// ReportDomainInterface defines the business logic contract for reporting the problems of matrix files.
+// Unlike validation, which stops at the first problem, a report lists every problem found with a suggested fix.
+type ReportDomainInterface interface {
+	// ReportFile reads the matrix file at filePath and reports its errors and warnings. The file path is
+	// validated like for the operations; a file that cannot be read fails with the error of the repository,
+	// while a file the service would reject still gives a report.
+	ReportFile(ctx context.Context, filePath string) (*entity.MatrixReport, error)
+}
+
+type reportDomain struct {
+	matrixRepository repository.MatrixRepositoryInterface
+	validatorDomain  MatrixValidatorDomainInterface
+}
+
+// NewReportDomain creates a new instance of ReportDomainInterface with its dependencies.
+// It initializes the domain service with the matrix repository the files are read from
+// and the validator their paths are checked with.
+func NewReportDomain() ReportDomainInterface {
+	return &reportDomain{
+		// Reports read local files only; files in object storage are rejected as an unsupported scheme
+		matrixRepository: repository.NewSchemeMatrixRepository(repository.NewMatrixRepository(), nil),
+		validatorDomain:  NewMatrixValidatorDomain(),
+	}
+}
+
+func (d *reportDomain) ReportFile(ctx context.Context, filePath string) (*entity.MatrixReport, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validatorDomain.ValidateFileRef(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	data, err := d.matrixRepository.ReadFile(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildReport(data)
+	report.FilePath = filePath
+	return report, nil
+}
+
+// reportRow is a row of the file being reported on, with the line it starts on.
+type reportRow struct {
+	line   int
+	values []string
+}
+
+// buildReport reads data as CSV, tolerating rows of different lengths, and reports every problem found.
+func buildReport(data []byte) *entity.MatrixReport {
+	report := &entity.MatrixReport{}
+	if rest, ok := bytes.CutPrefix(data, []byte(byteOrderMark)); ok {
+		data = rest
+		report.Warnings = append(report.Warnings, &entity.ReportIssue{
+			Code:    entity.ReportByteOrderMark,
+			Message: "the file starts with a UTF-8 byte order mark, which is ignored",
+			Fix:     "save the file as UTF-8 without a byte order mark",
+			Lines:   []int{1},
+		})
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	var rows []reportRow
+	var blankLines []int
+	for {
+		start := reader.InputOffset()
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// The rest of the file cannot be split into rows reliably, so the report stops here
+			report.Errors = append(report.Errors, &entity.ReportIssue{
+				Code:    entity.ReportSyntax,
+				Message: fmt.Sprintf("invalid CSV on line %d, column %d: %v", parseErr.Line, parseErr.Column, parseErr.Err),
+				Fix:     "enclose values holding quotes in double quotes, doubling the quotes inside them",
+				Lines:   []int{parseErr.Line},
+			})
+			break
+		}
+
+		// Empty lines are skipped by the CSV reader and consumed with the row that follows them
+		line, _ := reader.FieldPos(0)
+		skipped := countBlankLines(data[start:reader.InputOffset()])
+		for i := skipped; i > 0; i-- {
+			blankLines = append(blankLines, line-i)
+		}
+		rows = append(rows, reportRow{line: line, values: values})
+	}
+
+	if len(blankLines) > 0 {
+		report.Warnings = append(report.Warnings, &entity.ReportIssue{
+			Code:    entity.ReportBlankLines,
+			Message: "blank lines are skipped, so the line numbers of the file differ from its row numbers",
+			Fix:     "remove the blank lines between the rows",
+			Lines:   blankLines,
+		})
+	}
+	// After a syntax error the end of the file was not read as rows
+	if trailing := trailingBlankLines(data); len(trailing) > 0 && len(report.Errors) == 0 {
+		report.Warnings = append(report.Warnings, &entity.ReportIssue{
+			Code:    entity.ReportTrailingBlankLines,
+			Message: "the file ends with blank lines",
+			Fix:     "remove the blank lines at the end of the file, keeping a single line break after the last row",
+			Lines:   trailing,
+		})
+	}
+
+	report.Rows = len(rows)
+	if len(rows) == 0 {
+		if len(report.Errors) == 0 {
+			report.Errors = append(report.Errors, &entity.ReportIssue{
+				Code:    entity.ReportEmptyFile,
+				Message: "the file holds no rows",
+				Fix:     "add at least one row of comma-separated numbers",
+			})
+		}
+		return report
+	}
+
+	report.Cols = commonRowLength(rows)
+	report.Errors = append(report.Errors, checkDimensions(rows, report.Cols)...)
+	report.Errors = append(report.Errors, checkValues(rows)...)
+	return report
+}
+
+// countBlankLines returns the number of empty lines at the start of chunk.
+func countBlankLines(chunk []byte) int {
+	n := 0
+	for {
+		switch {
+		case bytes.HasPrefix(chunk, []byte("\n")):
+			chunk = chunk[1:]
+		case bytes.HasPrefix(chunk, []byte("\r\n")):
+			chunk = chunk[2:]
+		default:
+			return n
+		}
+		n++
+	}
+}
+
+// trailingBlankLines returns the numbers of the empty lines data ends with, after the line break of its last row.
+func trailingBlankLines(data []byte) []int {
+	lines := bytes.Count(data, []byte("\n"))
+	trimmed := bytes.TrimRight(data, "\r\n")
+	if len(trimmed) == 0 {
+		return nil
+	}
+
+	// The last row ends on the line its final line break terminates
+	lastRow := bytes.Count(trimmed, []byte("\n")) + 1
+	var blank []int
+	for line := lastRow + 1; line <= lines; line++ {
+		blank = append(blank, line)
+	}
+	return blank
+}
+
+// commonRowLength returns the number of values most rows hold, preferring the earliest rows on a tie.
+func commonRowLength(rows []reportRow) int {
+	counts := make(map[int]int)
+	common := len(rows[0].values)
+	for _, row := range rows {
+		counts[len(row.values)]++
+		if counts[len(row.values)] > counts[common] {
+			common = len(row.values)
+		}
+	}
+	return common
+}
+
+// checkDimensions reports rows whose length differs from cols and matrices beyond the dimension limits.
+func checkDimensions(rows []reportRow, cols int) []*entity.ReportIssue {
+	var issues []*entity.ReportIssue
+
+	var offending, shortRows, longRows, lines []int
+	var lengths []string
+	for i, row := range rows {
+		switch {
+		case len(row.values) < cols:
+			shortRows = append(shortRows, i+1)
+		case len(row.values) > cols:
+			longRows = append(longRows, i+1)
+		default:
+			continue
+		}
+		offending = append(offending, i+1)
+		lines = append(lines, row.line)
+		lengths = append(lengths, fmt.Sprintf("%d in row %d", len(row.values), i+1))
+	}
+	if len(offending) > 0 {
+		var fixes []string
+		if len(shortRows) > 0 {
+			fixes = append(fixes, "add the missing values to "+listRows(shortRows))
+		}
+		if len(longRows) > 0 {
+			fixes = append(fixes, "remove the extra values from "+listRows(longRows))
+		}
+		issues = append(issues, &entity.ReportIssue{
+			Code:    entity.ReportRowLength,
+			Message: fmt.Sprintf("rows hold different numbers of values: most hold %d, but got %s", cols, strings.Join(lengths, ", ")),
+			Fix:     fmt.Sprintf("make every row hold %d values: %s", cols, strings.Join(fixes, " and ")),
+			Lines:   lines,
+			Rows:    offending,
+		})
+	}
+
+	limits := config.CurrentLimits()
+	if len(rows) > limits.MaxRows {
+		issues = append(issues, &entity.ReportIssue{
+			Code:    entity.ReportTooManyRows,
+			Message: fmt.Sprintf("the matrix has %d rows, maximum is %d", len(rows), limits.MaxRows),
+			Fix:     fmt.Sprintf("split the matrix into files of at most %d rows", limits.MaxRows),
+			Lines:   []int{rows[limits.MaxRows].line},
+			Rows:    []int{limits.MaxRows + 1},
+		})
+	}
+	if cols > limits.MaxCols {
+		issues = append(issues, &entity.ReportIssue{
+			Code:    entity.ReportTooManyCols,
+			Message: fmt.Sprintf("the matrix has %d columns, maximum is %d", cols, limits.MaxCols),
+			Fix:     fmt.Sprintf("split the matrix into files of at most %d columns", limits.MaxCols),
+		})
+	}
+	return issues
+}
+
+// checkValues reports every value that is not a valid number, with a fix suited to what it holds.
+func checkValues(rows []reportRow) []*entity.ReportIssue {
+	var issues []*entity.ReportIssue
+	for i, row := range rows {
+		for j, value := range row.values {
+			err := checkValue(value)
+			if err == nil {
+				continue
+			}
+
+			issue := &entity.ReportIssue{
+				Code:    entity.ReportNonNumericCell,
+				Message: fmt.Sprintf("row %d, column %d holds %q, which is not a number", i+1, j+1, value),
+				Fix:     suggestValueFix(value),
+				Lines:   []int{row.line},
+				Row:     i + 1,
+				Col:     j + 1,
+				Value:   value,
+			}
+			if errors.Is(err, apperrors.ErrOverflow) {
+				issue.Code = entity.ReportOutOfRange
+				issue.Message = fmt.Sprintf("row %d, column %d holds %s, which does not fit in a 64-bit integer", i+1, j+1, value)
+				if _, ok := roundDecimal(decimalDigits(value)); ok {
+					issue.Message = fmt.Sprintf("row %d, column %d holds %s, which has too many digits to fit in 64 bits",
+						i+1, j+1, value)
+				}
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// checkValue checks value with the engine's own parser, so the report agrees with validation.
+func checkValue(value string) error {
+	return matrixlib.AppendRow(&entity.Matrix[int64]{}, []string{value})
+}
+
+// suggestValueFix suggests how to turn value, which is not a valid number, into one.
+func suggestValueFix(value string) string {
+	trimmed := strings.Trim(value, " \t")
+	if trimmed == "" {
+		return "fill in the missing value, such as with 0"
+	}
+	if errors.Is(checkValue(trimmed), apperrors.ErrOverflow) {
+		if rounded, ok := roundDecimal(decimalDigits(trimmed)); ok {
+			return fmt.Sprintf("round the value to fewer decimal places, such as %s", rounded)
+		}
+		return fmt.Sprintf("use a value between %d and %d", int64(math.MinInt64), int64(math.MaxInt64))
+	}
+	// Base 0 accepts base prefixes and digit separators, which the engine rejects
+	if n, err := strconv.ParseInt(trimmed, 0, 64); err == nil {
+		if strings.Contains(trimmed, "_") && !strings.ContainsAny(strings.ToLower(trimmed), "bxo") {
+			return fmt.Sprintf("remove the digit separators: %d", n)
+		}
+		return fmt.Sprintf("write the value in base 10: %d", n)
+	}
+	// Hexadecimal floats are the only finite numbers strconv.ParseFloat accepts that the engine does not
+	if f, err := strconv.ParseFloat(trimmed, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return fmt.Sprintf("write the value in base 10: %s", strconv.FormatFloat(f, 'f', -1, 64))
+	}
+	return "replace the value with a number"
+}
+
+// maxDecimalDigits is the number of digits of a decimal that always fit in a 64-bit integer once scaled
+// to its decimal places.
+const maxDecimalDigits = 18
+
+// decimalDigits returns value as a rational number when it is a base-10 number with a fraction that is not
+// whole, and nil otherwise.
+func decimalDigits(value string) *big.Rat {
+	n, ok := new(big.Rat).SetString(strings.Trim(value, " \t"))
+	if !ok || n.IsInt() {
+		return nil
+	}
+	return n
+}
+
+// roundDecimal rounds n to as many decimal places as fit in maxDecimalDigits digits together with
+// its whole part, without trailing zeros. It reports false when n is nil or its whole part does not fit
+// in a 64-bit integer, so no rounding helps.
+func roundDecimal(n *big.Rat) (string, bool) {
+	if n == nil {
+		return "", false
+	}
+	whole := new(big.Int).Quo(n.Num(), n.Denom())
+	if !whole.IsInt64() {
+		return "", false
+	}
+	wholeDigits := 0
+	if whole.Sign() != 0 {
+		wholeDigits = len(whole.Abs(whole).String())
+	}
+
+	rounded := n.FloatString(max(0, maxDecimalDigits-wholeDigits))
+	if strings.Contains(rounded, ".") {
+		rounded = strings.TrimRight(strings.TrimRight(rounded, "0"), ".")
+	}
+	if rounded == "-0" {
+		rounded = "0"
+	}
+	return rounded, true
+}
+
+// listRows names rows in a message, such as "row 2" or "rows 2, 5".
+func listRows(rows []int) string {
+	numbers := make([]string, len(rows))
+	for i, row := range rows {
+		numbers[i] = strconv.Itoa(row)
+	}
+	if len(rows) == 1 {
+		return "row " + numbers[0]
+	}
+	return "rows " + strings.Join(numbers, ", ")
+}
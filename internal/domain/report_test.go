@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestReportDomain_ReportFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantRows     int
+		wantCols     int
+		wantErrors   []*entity.ReportIssue
+		wantWarnings []*entity.ReportIssue
+	}{
+		{
+			name:     "valid matrix",
+			content:  "1,2,3\n4,5,6\n",
+			wantRows: 2,
+			wantCols: 3,
+		},
+		{
+			name:     "trailing blank lines",
+			content:  "1,2\n3,4\n\n\n",
+			wantRows: 2,
+			wantCols: 2,
+			wantWarnings: []*entity.ReportIssue{{
+				Code:    entity.ReportTrailingBlankLines,
+				Message: "the file ends with blank lines",
+				Fix:     "remove the blank lines at the end of the file, keeping a single line break after the last row",
+				Lines:   []int{3, 4},
+			}},
+		},
+		{
+			name:     "blank lines between rows",
+			content:  "\n1,2\r\n\r\n3,4",
+			wantRows: 2,
+			wantCols: 2,
+			wantWarnings: []*entity.ReportIssue{{
+				Code:    entity.ReportBlankLines,
+				Message: "blank lines are skipped, so the line numbers of the file differ from its row numbers",
+				Fix:     "remove the blank lines between the rows",
+				Lines:   []int{1, 3},
+			}},
+		},
+		{
+			name:     "byte order mark",
+			content:  "\ufeff1,2\n",
+			wantRows: 1,
+			wantCols: 2,
+			wantWarnings: []*entity.ReportIssue{{
+				Code:    entity.ReportByteOrderMark,
+				Message: "the file starts with a UTF-8 byte order mark, which is ignored",
+				Fix:     "save the file as UTF-8 without a byte order mark",
+				Lines:   []int{1},
+			}},
+		},
+		{
+			name:     "inconsistent row lengths",
+			content:  "1,2,3\n4,5\n\n6,7,8\n9,10,11,12\n",
+			wantRows: 4,
+			wantCols: 3,
+			wantErrors: []*entity.ReportIssue{{
+				Code:    entity.ReportRowLength,
+				Message: "rows hold different numbers of values: most hold 3, but got 2 in row 2, 4 in row 4",
+				Fix:     "make every row hold 3 values: add the missing values to row 2 and remove the extra values from row 4",
+				Lines:   []int{2, 5},
+				Rows:    []int{2, 4},
+			}},
+			wantWarnings: []*entity.ReportIssue{{
+				Code:    entity.ReportBlankLines,
+				Message: "blank lines are skipped, so the line numbers of the file differ from its row numbers",
+				Fix:     "remove the blank lines between the rows",
+				Lines:   []int{3},
+			}},
+		},
+		{
+			name:     "first row is the odd one out",
+			content:  "1\n2,3\n4,5\n",
+			wantRows: 3,
+			wantCols: 2,
+			wantErrors: []*entity.ReportIssue{{
+				Code:    entity.ReportRowLength,
+				Message: "rows hold different numbers of values: most hold 2, but got 1 in row 1",
+				Fix:     "make every row hold 2 values: add the missing values to row 1",
+				Lines:   []int{1},
+				Rows:    []int{1},
+			}},
+		},
+		{
+			name:     "non-numeric cells",
+			content:  "1,abc\n0x1p-2,9223372036854775808\n",
+			wantRows: 2,
+			wantCols: 2,
+			wantErrors: []*entity.ReportIssue{
+				{
+					Code:    entity.ReportNonNumericCell,
+					Message: `row 1, column 2 holds "abc", which is not a number`,
+					Fix:     "replace the value with a number",
+					Lines:   []int{1},
+					Row:     1,
+					Col:     2,
+					Value:   "abc",
+				},
+				{
+					Code:    entity.ReportNonNumericCell,
+					Message: `row 2, column 1 holds "0x1p-2", which is not a number`,
+					Fix:     "write the value in base 10: 0.25",
+					Lines:   []int{2},
+					Row:     2,
+					Col:     1,
+					Value:   "0x1p-2",
+				},
+				{
+					Code:    entity.ReportOutOfRange,
+					Message: "row 2, column 2 holds 9223372036854775808, which does not fit in a 64-bit integer",
+					Fix:     "use a value between -9223372036854775808 and 9223372036854775807",
+					Lines:   []int{2},
+					Row:     2,
+					Col:     2,
+					Value:   "9223372036854775808",
+				},
+			},
+		},
+		{
+			name:     "too many rows and columns",
+			content:  strings.Repeat("1,2,3,4,5,6,7,8,9,10,11\n", 12),
+			wantRows: 12,
+			wantCols: 11,
+			wantErrors: []*entity.ReportIssue{
+				{
+					Code:    entity.ReportTooManyRows,
+					Message: "the matrix has 12 rows, maximum is 10",
+					Fix:     "split the matrix into files of at most 10 rows",
+					Lines:   []int{11},
+					Rows:    []int{11},
+				},
+				{
+					Code:    entity.ReportTooManyCols,
+					Message: "the matrix has 11 columns, maximum is 10",
+					Fix:     "split the matrix into files of at most 10 columns",
+				},
+			},
+		},
+		{
+			name:    "invalid CSV",
+			content: "1,2\n3,\"4\n\n",
+			wantErrors: []*entity.ReportIssue{{
+				Code:    entity.ReportSyntax,
+				Message: `invalid CSV on line 3, column 2: extraneous or missing " in quoted-field`,
+				Fix:     "enclose values holding quotes in double quotes, doubling the quotes inside them",
+				Lines:   []int{3},
+			}},
+			wantRows: 1,
+			wantCols: 2,
+		},
+		{
+			name:    "empty file",
+			content: "\n\n",
+			wantErrors: []*entity.ReportIssue{{
+				Code:    entity.ReportEmptyFile,
+				Message: "the file holds no rows",
+				Fix:     "add at least one row of comma-separated numbers",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+			mockRepo.On("ReadFile", mock.Anything, "testdata/report.csv").Return([]byte(tt.content), nil)
+
+			domain := &reportDomain{matrixRepository: mockRepo, validatorDomain: NewMatrixValidatorDomain()}
+			got, err := domain.ReportFile(context.Background(), "testdata/report.csv")
+
+			assert.NoError(t, err)
+			assert.Equal(t, "testdata/report.csv", got.FilePath)
+			assert.Equal(t, tt.wantRows, got.Rows)
+			assert.Equal(t, tt.wantCols, got.Cols)
+			assert.Equal(t, tt.wantErrors, got.Errors)
+			assert.Equal(t, tt.wantWarnings, got.Warnings)
+			assert.Equal(t, len(tt.wantErrors) == 0, got.Valid())
+		})
+	}
+}
+
+func TestReportDomain_ReportFile_Errors(t *testing.T) {
+	t.Run("invalid file path", func(t *testing.T) {
+		domain := &reportDomain{
+			matrixRepository: mocks.NewMockMatrixRepositoryInterface(t),
+			validatorDomain:  NewMatrixValidatorDomain(),
+		}
+		_, err := domain.ReportFile(context.Background(), "../secrets.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+		mockRepo.On("ReadFile", mock.Anything, "testdata/missing.csv").Return(nil, apperrors.ErrNotFound)
+
+		domain := &reportDomain{matrixRepository: mockRepo, validatorDomain: NewMatrixValidatorDomain()}
+		_, err := domain.ReportFile(context.Background(), "testdata/missing.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		domain := &reportDomain{matrixRepository: mocks.NewMockMatrixRepositoryInterface(t)}
+		_, err := domain.ReportFile(ctx, "testdata/matrix1.csv")
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestReportDomain_ReportFile_Samples(t *testing.T) {
+	// The report agrees with validation on every sample matrix
+	for _, filePath := range []string{
+		"testdata/matrix0.csv", "testdata/matrix1.csv", "testdata/matrix2.csv", "testdata/matrix3.csv",
+		"testdata/matrix4.csv", "testdata/matrix5.csv", "testdata/matrix6.csv",
+	} {
+		t.Run(filePath, func(t *testing.T) {
+			report, err := NewReportDomain().ReportFile(context.Background(), filePath)
+			assert.NoError(t, err)
+
+			_, validateErr := NewMatrixDomain(StreamLimits{}, MemoConfig{}, FileSourcesConfig{}).ProcessMatrix(context.Background(), "echo", filePath, nil)
+			assert.Equal(t, validateErr == nil, report.Valid(), "validation error: %v", validateErr)
+		})
+	}
+}
+
+func TestSuggestValueFix(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: " ", want: "fill in the missing value, such as with 0"},
+		{value: "-9223372036854775809", want: "use a value between -9223372036854775808 and 9223372036854775807"},
+		{value: "1_000", want: "remove the digit separators: 1000"},
+		{value: "0x1F", want: "write the value in base 10: 31"},
+		{value: "0b1_0", want: "write the value in base 10: 2"},
+		{value: "0x1p-2", want: "write the value in base 10: 0.25"},
+		{value: "NaN", want: "replace the value with a number"},
+		{value: "1e30", want: "use a value between -9223372036854775808 and 9223372036854775807"},
+		{value: "0.1e-1024", want: "round the value to fewer decimal places, such as 0"},
+		{value: "12345678901234567890.5", want: "use a value between -9223372036854775808 and 9223372036854775807"},
+		{value: "-0.12345678901234567891", want: "round the value to fewer decimal places, such as -0.123456789012345679"},
+		{value: "1234.00000000000000001", want: "round the value to fewer decimal places, such as 1234"},
+		{value: "12abc", want: "replace the value with a number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggestValueFix(tt.value))
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// RetentionConfig bounds how long stored matrix files are kept. DefaultTTL is the time to live of the matrices
+// stored by uploads and save_as without one of their own; zero keeps them until they are deleted. Deleted and
+// expired matrices can be restored for RestoreWindow before they are purged, and a cleanup looks for expired
+// and purgeable matrices every SweepInterval.
+type RetentionConfig struct {
+	DefaultTTL    time.Duration
+	RestoreWindow time.Duration
+	SweepInterval time.Duration
+}
+
+// RetentionDomainInterface defines the business logic contract for the retention of stored matrix files,
+// so uploaded and saved matrices do not accumulate forever. Matrices expire once their time to live has elapsed,
+// and deleted or expired matrices can be restored until the restore window has elapsed.
+type RetentionDomainInterface interface {
+	// Retain applies a time to live to a matrix file that was just stored, counted from now.
+	// A zero ttl applies the default time to live, if any.
+	Retain(ctx context.Context, filePath string, ttl time.Duration) error
+
+	// SetTTL changes the time to live of a stored matrix file, counted from now, and returns when it expires.
+	// A zero ttl keeps the file until it is deleted and returns the zero time.
+	SetTTL(ctx context.Context, filePath string, ttl time.Duration) (time.Time, error)
+
+	// Delete moves a stored matrix file to the trash, from which it can be restored until the restore window
+	// has elapsed. It returns ErrNotFound when no such file is stored.
+	Delete(ctx context.Context, filePath string) (*entity.DeletedMatrix, error)
+
+	// Restore moves a deleted matrix file back in place. It returns ErrNotFound when no such file was deleted
+	// or it was purged, and ErrConflict when another file was stored at its path since.
+	Restore(ctx context.Context, filePath string) error
+
+	// ListDeleted returns the deleted matrix files the tenant of ctx may restore, the most recently deleted first.
+	ListDeleted(ctx context.Context) ([]*entity.DeletedMatrix, error)
+
+	// Sweep deletes the expired matrix files and purges the deleted ones whose restore window has elapsed,
+	// then removes the uploaded content no stored file shares anymore.
+	// It runs every sweep interval in the background, whichever tenant the files belong to.
+	Sweep(ctx context.Context) error
+
+	// Stop stops the background cleanup and waits for a sweep in progress to finish.
+	Stop(ctx context.Context) error
+}
+
+type retentionDomain struct {
+	retentionRepository repository.RetentionRepositoryInterface
+	objectRepository    repository.ObjectRepositoryInterface
+	validatorDomain     MatrixValidatorDomainInterface
+	config              RetentionConfig
+	// now returns the current time; tests replace it to expire files
+	now func() time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	loop     sync.WaitGroup
+}
+
+// NewRetentionDomain creates a new instance of RetentionDomainInterface.
+// It initializes the domain service with the retention state kept on disk and the bounds of config,
+// and starts the cleanup that runs every sweep interval until Stop is called.
+func NewRetentionDomain(config RetentionConfig) (RetentionDomainInterface, error) {
+	retentionRepository, err := repository.NewRetentionRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &retentionDomain{
+		retentionRepository: retentionRepository,
+		objectRepository:    repository.NewObjectRepository(),
+		validatorDomain:     NewMatrixValidatorDomain(),
+		config:              config,
+		now:                 time.Now,
+		stop:                make(chan struct{}),
+	}
+	if config.SweepInterval > 0 {
+		d.loop.Add(1)
+		go d.run()
+	}
+	return d, nil
+}
+
+func (d *retentionDomain) Retain(ctx context.Context, filePath string, ttl time.Duration) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if ttl == 0 {
+		ttl = d.config.DefaultTTL
+	}
+	// Without a time to live, any expiry left by a file previously stored at the same path is cleared
+	_, err := d.SetTTL(ctx, filePath, ttl)
+	return err
+}
+
+func (d *retentionDomain) SetTTL(ctx context.Context, filePath string, ttl time.Duration) (time.Time, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	if ttl < 0 {
+		return time.Time{}, fmt.Errorf("%w: ttl must not be negative", apperrors.ErrInvalidInput)
+	}
+	if err := d.validatorDomain.ValidateFilePath(ctx, filePath); err != nil {
+		return time.Time{}, err
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = d.now().Add(ttl)
+	}
+	if err := d.retentionRepository.SetExpiry(ctx, filePath, expiresAt); err != nil {
+		return time.Time{}, err
+	}
+	return expiresAt, nil
+}
+
+func (d *retentionDomain) Delete(ctx context.Context, filePath string) (*entity.DeletedMatrix, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validatorDomain.ValidateFilePath(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	deletedAt := d.now()
+	if err := d.retentionRepository.Trash(ctx, filePath, deletedAt); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "matrix file deleted", "file_path", filePath)
+	return d.deletedMatrix(filePath, deletedAt), nil
+}
+
+func (d *retentionDomain) Restore(ctx context.Context, filePath string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := d.validatorDomain.ValidateFilePath(ctx, filePath); err != nil {
+		return err
+	}
+
+	if err := d.retentionRepository.Restore(ctx, filePath); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "matrix file restored", "file_path", filePath)
+	return nil
+}
+
+func (d *retentionDomain) ListDeleted(ctx context.Context) ([]*entity.DeletedMatrix, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	records, err := d.retentionRepository.ListTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make([]*entity.DeletedMatrix, 0, len(records))
+	for _, record := range records {
+		if tenant.CanAccess(ctx, record.FilePath) {
+			deleted = append(deleted, d.deletedMatrix(record.FilePath, record.DeletedAt))
+		}
+	}
+	return deleted, nil
+}
+
+func (d *retentionDomain) Sweep(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := d.now()
+	var errs []error
+
+	expired, err := d.retentionRepository.ListExpired(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, filePath := range expired {
+		// Files are deleted on behalf of the tenant that owns them
+		ownerCtx := tenant.WithID(ctx, tenant.Owner(filePath))
+		err := d.retentionRepository.Trash(ownerCtx, filePath, now)
+		if errors.Is(err, apperrors.ErrNotFound) {
+			// Deleted by other means; forget its expiry
+			err = d.retentionRepository.SetExpiry(ownerCtx, filePath, time.Time{})
+		} else if err == nil {
+			slog.InfoContext(ctx, "expired matrix file deleted", "file_path", filePath)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	trash, err := d.retentionRepository.ListTrash(ctx)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	for _, record := range trash {
+		if now.Before(record.DeletedAt.Add(d.config.RestoreWindow)) {
+			continue
+		}
+		ownerCtx := tenant.WithID(ctx, tenant.Owner(record.FilePath))
+		if err := d.retentionRepository.Purge(ownerCtx, record.FilePath); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		slog.InfoContext(ctx, "deleted matrix file purged", "file_path", record.FilePath)
+	}
+
+	// Uploads deleted for good may leave content that no stored file shares anymore
+	removed, err := d.objectRepository.Collect(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	} else if removed > 0 {
+		slog.InfoContext(ctx, "unshared upload content removed", "objects", removed)
+	}
+	return errors.Join(errs...)
+}
+
+func (d *retentionDomain) Stop(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		d.loop.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("matrix cleanup still running: %w", ctx.Err())
+	}
+}
+
+// run sweeps at each sweep interval until the domain is stopped.
+func (d *retentionDomain) run() {
+	defer d.loop.Done()
+
+	ticker := time.NewTicker(d.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			if err := d.Sweep(context.Background()); err != nil {
+				slog.Error("matrix cleanup failed", "error", err)
+			}
+		}
+	}
+}
+
+func (d *retentionDomain) deletedMatrix(filePath string, deletedAt time.Time) *entity.DeletedMatrix {
+	return &entity.DeletedMatrix{
+		FilePath:  filePath,
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.Add(d.config.RestoreWindow),
+	}
+}
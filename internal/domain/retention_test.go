@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newTestRetentionDomain returns a retention domain working in a temporary directory, whose clock
+// is read from now, without the background cleanup.
+func newTestRetentionDomain(t *testing.T, config RetentionConfig, now *time.Time) *retentionDomain {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	config.SweepInterval = 0
+
+	d, err := NewRetentionDomain(config)
+	assert.NoError(t, err)
+	domain := d.(*retentionDomain)
+	domain.now = func() time.Time { return *now }
+	return domain
+}
+
+// storeMatrixFile writes a small matrix file at filePath, relative to the working directory.
+func storeMatrixFile(t *testing.T, filePath string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	assert.NoError(t, os.WriteFile(filePath, []byte("1,2\n3,4\n"), 0o644))
+}
+
+func TestRetentionDomain_ExpiryAndSweep(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := newTestRetentionDomain(t, RetentionConfig{DefaultTTL: 2 * time.Hour, RestoreWindow: 24 * time.Hour}, &now)
+	ctx := context.Background()
+	storeMatrixFile(t, "testdata/default.csv")
+	storeMatrixFile(t, "testdata/short.csv")
+	storeMatrixFile(t, "testdata/kept.csv")
+
+	assert.NoError(t, domain.Retain(ctx, "testdata/default.csv", 0))
+	assert.NoError(t, domain.Retain(ctx, "testdata/short.csv", time.Hour))
+	expiresAt, err := domain.SetTTL(ctx, "testdata/kept.csv", 0)
+	assert.NoError(t, err)
+	assert.True(t, expiresAt.IsZero())
+
+	now = now.Add(time.Hour)
+	assert.NoError(t, domain.Sweep(ctx))
+	assert.NoFileExists(t, "testdata/short.csv")
+	assert.FileExists(t, "testdata/default.csv")
+
+	now = now.Add(time.Hour)
+	assert.NoError(t, domain.Sweep(ctx))
+	assert.NoFileExists(t, "testdata/default.csv")
+	assert.FileExists(t, "testdata/kept.csv")
+
+	// Expired files can be restored until the restore window has elapsed
+	deleted, err := domain.ListDeleted(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, deleted, 2) {
+		assert.Equal(t, "testdata/default.csv", deleted[0].FilePath)
+		assert.True(t, now.Add(24*time.Hour).Equal(deleted[0].PurgeAt))
+	}
+	assert.NoError(t, domain.Restore(ctx, "testdata/short.csv"))
+	assert.FileExists(t, "testdata/short.csv")
+
+	now = now.Add(24 * time.Hour)
+	assert.NoError(t, domain.Sweep(ctx))
+	deleted, err = domain.ListDeleted(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+	assert.ErrorIs(t, domain.Restore(ctx, "testdata/default.csv"), apperrors.ErrNotFound)
+
+	// A restored file no longer expires
+	assert.FileExists(t, "testdata/short.csv")
+}
+
+func TestRetentionDomain_SweepForgetsMissingFiles(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := newTestRetentionDomain(t, RetentionConfig{RestoreWindow: time.Hour}, &now)
+	ctx := context.Background()
+	storeMatrixFile(t, "testdata/gone.csv")
+	assert.NoError(t, domain.Retain(ctx, "testdata/gone.csv", time.Minute))
+	assert.NoError(t, os.Remove("testdata/gone.csv"))
+
+	now = now.Add(time.Hour)
+	assert.NoError(t, domain.Sweep(ctx))
+
+	expired, err := domain.retentionRepository.ListExpired(ctx, now)
+	assert.NoError(t, err)
+	assert.Empty(t, expired)
+}
+
+func TestRetentionDomain_Delete(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := newTestRetentionDomain(t, RetentionConfig{RestoreWindow: time.Hour}, &now)
+	ctx := context.Background()
+	storeMatrixFile(t, "testdata/out.csv")
+
+	deleted, err := domain.Delete(ctx, "testdata/out.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, "testdata/out.csv", deleted.FilePath)
+	assert.Equal(t, now, deleted.DeletedAt)
+	assert.Equal(t, now.Add(time.Hour), deleted.PurgeAt)
+	assert.NoFileExists(t, "testdata/out.csv")
+
+	_, err = domain.Delete(ctx, "testdata/out.csv")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	for _, filePath := range []string{"", "../secret.csv", "config.json", "testdata/tenants/acme/matrix.csv"} {
+		_, err := domain.Delete(ctx, filePath)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput, filePath)
+	}
+}
+
+func TestRetentionDomain_TenantIsolation(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := newTestRetentionDomain(t, RetentionConfig{RestoreWindow: time.Hour}, &now)
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	globex := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex"})
+	storeMatrixFile(t, "testdata/tenants/acme/matrix.csv")
+	storeMatrixFile(t, "testdata/shared.csv")
+
+	_, err := domain.Delete(acme, "testdata/tenants/acme/matrix.csv")
+	assert.NoError(t, err)
+	_, err = domain.Delete(context.Background(), "testdata/shared.csv")
+	assert.NoError(t, err)
+
+	deleted, err := domain.ListDeleted(acme)
+	assert.NoError(t, err)
+	if assert.Len(t, deleted, 1) {
+		assert.Equal(t, "testdata/tenants/acme/matrix.csv", deleted[0].FilePath)
+	}
+	deleted, err = domain.ListDeleted(globex)
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	// Another tenant cannot tell the file exists
+	assert.ErrorIs(t, domain.Restore(globex, "testdata/tenants/acme/matrix.csv"), apperrors.ErrNotFound)
+
+	// The cleanup purges the files of every tenant
+	now = now.Add(time.Hour)
+	assert.NoError(t, domain.Sweep(context.Background()))
+	deleted, err = domain.ListDeleted(acme)
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+}
+
+func TestRetentionDomain_Stop(t *testing.T) {
+	t.Chdir(t.TempDir())
+	domain, err := NewRetentionDomain(RetentionConfig{SweepInterval: time.Millisecond})
+	assert.NoError(t, err)
+
+	assert.NoError(t, domain.Stop(context.Background()))
+	// Stopping twice is harmless
+	assert.NoError(t, domain.Stop(context.Background()))
+}
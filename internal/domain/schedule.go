@@ -0,0 +1,345 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// maxSchedules bounds how many schedules each tenant can have at once.
+	maxSchedules = 100
+
+	// minScheduleInterval is the shortest time allowed between two runs of a schedule.
+	minScheduleInterval = time.Minute
+)
+
+// ScheduleDomainInterface defines the business logic contract for recurring operations.
+// Each schedule runs an operation against a matrix file on a cron schedule, submitting every run
+// as a background job so its result is stored and can be retrieved like any other job.
+type ScheduleDomainInterface interface {
+	// CreateSchedule validates and registers a new schedule. spec is a standard five-field cron expression
+	// or a descriptor such as @hourly; runs may not be less than a minute apart. Each tenant may have up to
+	// maxSchedules schedules, beyond which ErrConflict is returned. Schedules are kept in memory only, so the
+	// ones created through the API are lost on restart; only those of the schedules file are registered again.
+	CreateSchedule(ctx context.Context, spec string, operation string, filePath string) (*entity.Schedule, error)
+
+	// GetSchedule returns a schedule with the outcome of its latest run, or ErrNotFound for unknown IDs.
+	// Schedules belong to the tenant carried by ctx when they are created, and other tenants cannot see them.
+	GetSchedule(ctx context.Context, id string) (*entity.Schedule, error)
+
+	// ListSchedules returns every schedule of the tenant carried by ctx, oldest first.
+	ListSchedules(ctx context.Context) ([]*entity.Schedule, error)
+
+	// DeleteSchedule stops and removes a schedule. Jobs it already submitted are kept.
+	DeleteSchedule(ctx context.Context, id string) error
+
+	// Stop stops the scheduler, so no run starts afterwards, and waits for the runs in progress to submit
+	// their jobs. It returns the error of ctx when it expires first.
+	Stop(ctx context.Context) error
+}
+
+// scheduleFileEntry is a schedule declared in the schedules configuration file.
+type scheduleFileEntry struct {
+	Schedule  string `json:"schedule"`
+	Operation string `json:"operation"`
+	File      string `json:"file"`
+	Tenant    string `json:"tenant,omitempty"`
+}
+
+// scheduleEntry pairs a schedule with its registration in the cron runner.
+type scheduleEntry struct {
+	schedule entity.Schedule
+	entryID  cron.EntryID
+}
+
+type scheduleDomain struct {
+	jobDomain        JobDomainInterface
+	operationsDomain MatrixOperationsDomainInterface
+	validatorDomain  MatrixValidatorDomainInterface
+
+	cron *cron.Cron
+
+	mu        sync.Mutex
+	schedules map[string]*scheduleEntry
+}
+
+// NewScheduleDomain creates a new instance of ScheduleDomainInterface with all required dependencies.
+// It initializes the domain service with the job domain that executes each run, loads the schedules
+// declared in schedulesFile (a JSON array; ignored when empty) and starts the scheduler.
+func NewScheduleDomain(jobDomain JobDomainInterface, schedulesFile string) (ScheduleDomainInterface, error) {
+	d := newScheduleDomain(jobDomain, NewMatrixOperationsDomain(), NewMatrixValidatorDomain())
+
+	if schedulesFile != "" {
+		if err := d.loadFile(schedulesFile); err != nil {
+			return nil, err
+		}
+	}
+
+	d.cron.Start()
+	return d, nil
+}
+
+// newScheduleDomain builds a schedule domain whose scheduler has not been started.
+func newScheduleDomain(jobDomain JobDomainInterface, operationsDomain MatrixOperationsDomainInterface,
+	validatorDomain MatrixValidatorDomainInterface) *scheduleDomain {
+	return &scheduleDomain{
+		jobDomain:        jobDomain,
+		operationsDomain: operationsDomain,
+		validatorDomain:  validatorDomain,
+		cron:             cron.New(),
+		schedules:        make(map[string]*scheduleEntry),
+	}
+}
+
+func (d *scheduleDomain) CreateSchedule(ctx context.Context, spec string, operation string, filePath string) (*entity.Schedule, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if operation == "" {
+		return nil, fmt.Errorf("%w: operation parameter is required", apperrors.ErrInvalidInput)
+	}
+
+	err := d.operationsDomain.IsValidOperation(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.validatorDomain.ValidateFileRef(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	cronSchedule, err := parseSchedule(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newID("schedule")
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tenantID := tenant.ID(ctx)
+	if d.tenantSchedules(tenantID) >= maxSchedules {
+		return nil, fmt.Errorf("%w: schedule limit of %d reached", apperrors.ErrConflict, maxSchedules)
+	}
+
+	entry := &scheduleEntry{
+		schedule: entity.Schedule{
+			ID:        id,
+			Spec:      spec,
+			Operation: operation,
+			FilePath:  filePath,
+			CreatedAt: time.Now(),
+			TenantID:  tenantID,
+		},
+	}
+	entry.entryID = d.cron.Schedule(cronSchedule, cron.FuncJob(func() { d.run(id) }))
+	d.schedules[id] = entry
+
+	slog.InfoContext(ctx, "schedule created",
+		"schedule_id", id,
+		"schedule", spec,
+		"operation", operation,
+		"file", filePath)
+
+	return d.snapshot(entry), nil
+}
+
+func (d *scheduleDomain) GetSchedule(ctx context.Context, id string) (*entity.Schedule, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, err := d.lookup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return d.snapshot(entry), nil
+}
+
+func (d *scheduleDomain) ListSchedules(ctx context.Context) ([]*entity.Schedule, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tenantID := tenant.ID(ctx)
+	schedules := make([]*entity.Schedule, 0, len(d.schedules))
+	for _, entry := range d.schedules {
+		if entry.schedule.TenantID == tenantID {
+			schedules = append(schedules, d.snapshot(entry))
+		}
+	}
+
+	slices.SortFunc(schedules, func(a, b *entity.Schedule) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return schedules, nil
+}
+
+func (d *scheduleDomain) DeleteSchedule(ctx context.Context, id string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, err := d.lookup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	d.cron.Remove(entry.entryID)
+	delete(d.schedules, id)
+
+	slog.InfoContext(ctx, "schedule deleted", "schedule_id", id)
+	return nil
+}
+
+func (d *scheduleDomain) Stop(ctx context.Context) error {
+	select {
+	case <-d.cron.Stop().Done():
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduled runs still in progress: %w", ctx.Err())
+	}
+}
+
+// run submits one run of a schedule as a background job and records the outcome.
+func (d *scheduleDomain) run(id string) {
+	d.mu.Lock()
+	entry, ok := d.schedules[id]
+	if !ok {
+		// Deleted while the run was being started
+		d.mu.Unlock()
+		return
+	}
+	operation, filePath, tenantID := entry.schedule.Operation, entry.schedule.FilePath, entry.schedule.TenantID
+	d.mu.Unlock()
+
+	// Runs are submitted on behalf of the tenant that created the schedule
+	job, err := d.jobDomain.SubmitJob(tenant.WithID(context.Background(), tenantID), operation, filePath, nil, "")
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry.schedule.LastRunAt = time.Now()
+	entry.schedule.LastErr = err
+	if err != nil {
+		slog.Error("scheduled run failed",
+			"schedule_id", id,
+			"operation", operation,
+			"error", err)
+		return
+	}
+	entry.schedule.LastJobID = job.ID
+
+	slog.Info("scheduled run submitted",
+		"schedule_id", id,
+		"job_id", job.ID)
+}
+
+// lookup returns the schedule registered under id, reporting schedules of other tenants as unknown.
+// The caller must hold d.mu.
+func (d *scheduleDomain) lookup(ctx context.Context, id string) (*scheduleEntry, error) {
+	entry, ok := d.schedules[id]
+	if !ok || entry.schedule.TenantID != tenant.ID(ctx) {
+		return nil, fmt.Errorf("%w: schedule not found: %s", apperrors.ErrNotFound, id)
+	}
+	return entry, nil
+}
+
+// tenantSchedules counts the schedules of a tenant. The caller must hold d.mu.
+func (d *scheduleDomain) tenantSchedules(tenantID string) int {
+	n := 0
+	for _, entry := range d.schedules {
+		if entry.schedule.TenantID == tenantID {
+			n++
+		}
+	}
+	return n
+}
+
+// snapshot copies a schedule, filling in its next run time from the scheduler. The caller must hold d.mu.
+func (d *scheduleDomain) snapshot(entry *scheduleEntry) *entity.Schedule {
+	schedule := entry.schedule
+	schedule.NextRunAt = d.cron.Entry(entry.entryID).Next
+	return &schedule
+}
+
+// loadFile registers the schedules declared in a JSON configuration file.
+func (d *scheduleDomain) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read schedules file: %w", err)
+	}
+
+	var entries []scheduleFileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("%w: invalid schedules file %s: %v", apperrors.ErrInvalidInput, path, err)
+	}
+
+	for i, entry := range entries {
+		if entry.Tenant != "" && !tenant.ValidID(entry.Tenant) {
+			return fmt.Errorf("schedule %d in %s: %w: invalid tenant ID %q", i+1, path, apperrors.ErrInvalidInput, entry.Tenant)
+		}
+
+		_, err := d.CreateSchedule(tenant.WithID(context.Background(), entry.Tenant), entry.Schedule, entry.Operation, entry.File)
+		if err != nil {
+			return fmt.Errorf("schedule %d in %s: %w", i+1, path, err)
+		}
+	}
+
+	slog.Info("schedules loaded",
+		"file", path,
+		"count", len(entries))
+	return nil
+}
+
+// parseSchedule parses a cron expression and rejects schedules that would run more often than minScheduleInterval.
+func parseSchedule(spec string) (cron.Schedule, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("%w: schedule is required", apperrors.ErrInvalidInput)
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid schedule %q: %v", apperrors.ErrInvalidInput, spec, err)
+	}
+
+	// Standard expressions cannot go below a minute, but @every can
+	next := schedule.Next(time.Now())
+	if schedule.Next(next).Sub(next) < minScheduleInterval {
+		return nil, fmt.Errorf("%w: schedule %q runs more often than every %s",
+			apperrors.ErrInvalidInput, spec, minScheduleInterval)
+	}
+	return schedule, nil
+}
@@ -0,0 +1,301 @@
+package domain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func newTestScheduleDomain(t *testing.T) (*scheduleDomain, *mocks.MockJobDomainInterface, *mocks.MockMatrixOperationsDomainInterface, *mocks.MockMatrixValidatorDomainInterface) {
+	mockJobs := mocks.NewMockJobDomainInterface(t)
+	mockOperations := mocks.NewMockMatrixOperationsDomainInterface(t)
+	mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+
+	return newScheduleDomain(mockJobs, mockOperations, mockValidator), mockJobs, mockOperations, mockValidator
+}
+
+func TestScheduleDomain_CreateSchedule(t *testing.T) {
+	t.Run("creates a schedule", func(t *testing.T) {
+		domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix1.csv").Return(nil)
+
+		got, err := domain.CreateSchedule(context.Background(), "0 * * * *", "sum", "testdata/matrix1.csv")
+
+		assert.NoError(t, err)
+		assert.Len(t, got.ID, 32)
+		assert.Equal(t, "0 * * * *", got.Spec)
+		assert.Equal(t, "sum", got.Operation)
+		assert.Equal(t, "testdata/matrix1.csv", got.FilePath)
+		assert.Len(t, domain.cron.Entries(), 1)
+	})
+
+	tests := []struct {
+		name    string
+		spec    string
+		errType error
+	}{
+		{name: "missing schedule", spec: "", errType: apperrors.ErrInvalidInput},
+		{name: "malformed schedule", spec: "every hour", errType: apperrors.ErrInvalidInput},
+		{name: "schedule with seconds field", spec: "0 0 * * * *", errType: apperrors.ErrInvalidInput},
+		{name: "schedule runs too often", spec: "@every 10s", errType: apperrors.ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+			mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+			mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix1.csv").Return(nil)
+
+			got, err := domain.CreateSchedule(context.Background(), tt.spec, "sum", "testdata/matrix1.csv")
+
+			assert.ErrorIs(t, err, tt.errType)
+			assert.Nil(t, got)
+			assert.Empty(t, domain.cron.Entries())
+		})
+	}
+
+	t.Run("invalid operation", func(t *testing.T) {
+		domain, _, mockOperations, _ := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "divide").Return(apperrors.ErrInvalidInput)
+
+		got, err := domain.CreateSchedule(context.Background(), "@hourly", "divide", "testdata/matrix1.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+
+	t.Run("invalid file path", func(t *testing.T) {
+		domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, "../etc/passwd").Return(apperrors.ErrInvalidInput)
+
+		got, err := domain.CreateSchedule(context.Background(), "@hourly", "sum", "../etc/passwd")
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+
+	t.Run("schedule limit reached", func(t *testing.T) {
+		domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix1.csv").Return(nil)
+
+		for range maxSchedules {
+			_, err := domain.CreateSchedule(context.Background(), "@hourly", "sum", "testdata/matrix1.csv")
+			assert.NoError(t, err)
+		}
+
+		got, err := domain.CreateSchedule(context.Background(), "@hourly", "sum", "testdata/matrix1.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Nil(t, got)
+
+		// The limit is counted per tenant, so another tenant can still create schedules
+		got, err = domain.CreateSchedule(tenant.WithID(context.Background(), "acme"), "@hourly", "sum", "testdata/matrix1.csv")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "acme", got.TenantID)
+	})
+}
+
+func TestScheduleDomain_Run(t *testing.T) {
+	t.Run("submits a job and records it", func(t *testing.T) {
+		domain, mockJobs, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix1.csv").Return(nil)
+		mockJobs.On("SubmitJob", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Matrix[int64])(nil), "").
+			Return(&entity.Job{ID: "job-1", Status: entity.JobQueued}, nil)
+
+		schedule, err := domain.CreateSchedule(context.Background(), "@hourly", "sum", "testdata/matrix1.csv")
+		assert.NoError(t, err)
+
+		domain.run(schedule.ID)
+
+		got, err := domain.GetSchedule(context.Background(), schedule.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "job-1", got.LastJobID)
+		assert.False(t, got.LastRunAt.IsZero())
+		assert.NoError(t, got.LastErr)
+	})
+
+	t.Run("records a rejected submission", func(t *testing.T) {
+		domain, mockJobs, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, "sum").Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, "testdata/matrix1.csv").Return(nil)
+		mockJobs.On("SubmitJob", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Matrix[int64])(nil), "").
+			Return(nil, apperrors.ErrServiceUnavailable)
+
+		schedule, err := domain.CreateSchedule(context.Background(), "@hourly", "sum", "testdata/matrix1.csv")
+		assert.NoError(t, err)
+
+		domain.run(schedule.ID)
+
+		got, err := domain.GetSchedule(context.Background(), schedule.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, got.LastJobID)
+		assert.ErrorIs(t, got.LastErr, apperrors.ErrServiceUnavailable)
+	})
+
+	t.Run("deleted schedule does not run", func(t *testing.T) {
+		domain, _, _, _ := newTestScheduleDomain(t)
+
+		// The job mock has no expectations, so submitting would fail the test
+		domain.run("missing")
+	})
+}
+
+func TestScheduleDomain_Stop(t *testing.T) {
+	domain, _, _, _ := newTestScheduleDomain(t)
+	domain.cron.Start()
+
+	assert.NoError(t, domain.Stop(context.Background()))
+}
+
+func TestScheduleDomain_ListAndDelete(t *testing.T) {
+	domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+	mockOperations.On("IsValidOperation", mock.Anything, mock.Anything).Return(nil)
+	mockValidator.On("ValidateFileRef", mock.Anything, mock.Anything).Return(nil)
+
+	first, err := domain.CreateSchedule(context.Background(), "@hourly", "sum", "testdata/matrix1.csv")
+	assert.NoError(t, err)
+	second, err := domain.CreateSchedule(context.Background(), "@daily", "flatten", "testdata/matrix5.csv")
+	assert.NoError(t, err)
+
+	got, err := domain.ListSchedules(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, first.ID, got[0].ID)
+		assert.Equal(t, second.ID, got[1].ID)
+	}
+
+	err = domain.DeleteSchedule(context.Background(), first.ID)
+	assert.NoError(t, err)
+	assert.Len(t, domain.cron.Entries(), 1)
+
+	_, err = domain.GetSchedule(context.Background(), first.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	err = domain.DeleteSchedule(context.Background(), first.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestScheduleDomain_Tenants(t *testing.T) {
+	domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+	mockOperations.On("IsValidOperation", mock.Anything, mock.Anything).Return(nil)
+	mockValidator.On("ValidateFileRef", mock.Anything, mock.Anything).Return(nil)
+
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	globex := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex"})
+
+	owned, err := domain.CreateSchedule(acme, "@hourly", "sum", "testdata/tenants/acme/matrix.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", owned.TenantID)
+	shared, err := domain.CreateSchedule(context.Background(), "@daily", "sum", "testdata/matrix1.csv")
+	assert.NoError(t, err)
+
+	// Each caller only lists its own schedules
+	got, err := domain.ListSchedules(acme)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, owned.ID, got[0].ID)
+	}
+	got, err = domain.ListSchedules(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, shared.ID, got[0].ID)
+	}
+
+	// Schedules of another tenant are reported as missing
+	_, err = domain.GetSchedule(globex, owned.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	err = domain.DeleteSchedule(globex, owned.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	_, err = domain.GetSchedule(acme, shared.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	assert.NoError(t, domain.DeleteSchedule(acme, owned.ID))
+	assert.Len(t, domain.cron.Entries(), 1)
+}
+
+func TestScheduleDomain_LoadFile(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "schedules.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("loads every schedule", func(t *testing.T) {
+		domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, mock.Anything).Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, mock.Anything).Return(nil)
+
+		err := domain.loadFile(writeFile(t, `[
+			{"schedule": "0 6 * * *", "operation": "sum", "file": "testdata/matrix1.csv"},
+			{"schedule": "@weekly", "operation": "flatten", "file": "testdata/matrix5.csv"}
+		]`))
+
+		assert.NoError(t, err)
+		got, err := domain.ListSchedules(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("invalid schedule fails the load", func(t *testing.T) {
+		domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, mock.Anything).Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, mock.Anything).Return(nil)
+
+		err := domain.loadFile(writeFile(t, `[{"schedule": "soon", "operation": "sum", "file": "testdata/matrix1.csv"}]`))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.ErrorContains(t, err, "schedule 1")
+	})
+
+	t.Run("assigns schedules to their tenant", func(t *testing.T) {
+		domain, _, mockOperations, mockValidator := newTestScheduleDomain(t)
+		mockOperations.On("IsValidOperation", mock.Anything, mock.Anything).Return(nil)
+		mockValidator.On("ValidateFileRef", mock.Anything, mock.Anything).Return(nil)
+
+		err := domain.loadFile(writeFile(t, `[{"schedule": "@daily", "operation": "sum", "file": "testdata/tenants/acme/matrix.csv", "tenant": "acme"}]`))
+
+		assert.NoError(t, err)
+		got, err := domain.ListSchedules(tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"}))
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, "acme", got[0].TenantID)
+		}
+	})
+
+	t.Run("invalid tenant fails the load", func(t *testing.T) {
+		domain, _, _, _ := newTestScheduleDomain(t)
+
+		err := domain.loadFile(writeFile(t, `[{"schedule": "@daily", "operation": "sum", "file": "testdata/matrix1.csv", "tenant": "../acme"}]`))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("malformed file", func(t *testing.T) {
+		domain, _, _, _ := newTestScheduleDomain(t)
+
+		err := domain.loadFile(writeFile(t, `{"schedule":`))
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		domain, _, _, _ := newTestScheduleDomain(t)
+
+		err := domain.loadFile(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Names of the secrets read by the server.
+const (
+	// AdminTokenSecret is the token administrators authenticate with on the /admin/ endpoints.
+	AdminTokenSecret = "ADMIN_TOKEN"
+
+	// WebhookSecret is the key job webhooks are signed with.
+	WebhookSecret = "WEBHOOK_SECRET"
+
+	// JobDatabaseURLSecret is the PostgreSQL connection URL of the job store, credentials included.
+	JobDatabaseURLSecret = "JOB_DATABASE_URL"
+
+	// S3AccessKeyIDSecret is the access key ID results are exported to, and matrix files read from, object storage with.
+	S3AccessKeyIDSecret = "S3_ACCESS_KEY_ID"
+
+	// S3SecretAccessKeySecret is the secret access key results are exported to, and matrix files read from,
+	// object storage with.
+	S3SecretAccessKeySecret = "S3_SECRET_ACCESS_KEY"
+
+	// SignedURLKeySecret is the key download URLs shared without an API key are signed with.
+	SignedURLKeySecret = "SIGNED_URL_KEY"
+)
+
+const (
+	// defaultSecretsDir is where secret files are mounted by Docker and Kubernetes.
+	defaultSecretsDir = "/run/secrets"
+
+	// defaultSecretsRefreshInterval is how long a secret is used before it is read again.
+	defaultSecretsRefreshInterval = 5 * time.Minute
+)
+
+// SecretsConfig selects where secrets are read from, given as strings such as environment variables.
+// Provider is "env" (the default), "file" or "vault". Dir is the directory of the secret files, /run/secrets
+// by default. The Vault fields locate the KV secret holding the secrets, as described by repository.VaultConfig.
+// RefreshInterval is a duration such as "1m" after which secrets are read again, 5 minutes by default.
+type SecretsConfig struct {
+	Provider        string
+	Dir             string
+	VaultAddress    string
+	VaultToken      string
+	VaultTokenFile  string
+	VaultPath       string
+	RefreshInterval string
+}
+
+// SecretDomainInterface defines the business logic contract for secrets, such as API keys,
+// signing keys and storage credentials, which may be rotated while the server runs.
+type SecretDomainInterface interface {
+	// Secret returns the current value of the named secret, or an empty string when it is not set.
+	// Callers should ask again whenever they use a secret instead of keeping it, so rotations take effect.
+	Secret(ctx context.Context, name string) (string, error)
+
+	// CheckHealth reports whether the secret store can be reached, for the health endpoint.
+	CheckHealth(ctx context.Context) error
+}
+
+type secretDomain struct {
+	secretRepository repository.SecretRepositoryInterface
+}
+
+// NewSecretDomain creates a new instance of SecretDomainInterface.
+// It initializes the domain service with the secret store described by config, whose secrets are cached
+// and read again once the refresh interval has elapsed.
+func NewSecretDomain(config SecretsConfig) (SecretDomainInterface, error) {
+	interval := defaultSecretsRefreshInterval
+	if config.RefreshInterval != "" {
+		var err error
+		interval, err = time.ParseDuration(config.RefreshInterval)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%w: invalid secrets refresh interval %q: expected a positive duration such as 1m",
+				apperrors.ErrInvalidInput, config.RefreshInterval)
+		}
+	}
+
+	var secretRepository repository.SecretRepositoryInterface
+	switch config.Provider {
+	case "", "env":
+		secretRepository = repository.NewEnvSecretRepository()
+	case "file":
+		dir := config.Dir
+		if dir == "" {
+			dir = defaultSecretsDir
+		}
+		secretRepository = repository.NewFileSecretRepository(dir)
+	case "vault":
+		if config.VaultAddress == "" || config.VaultPath == "" || (config.VaultToken == "" && config.VaultTokenFile == "") {
+			return nil, fmt.Errorf("%w: the vault secrets provider needs an address, a secret path and a token",
+				apperrors.ErrInvalidInput)
+		}
+		secretRepository = repository.NewVaultSecretRepository(repository.VaultConfig{
+			Address:   config.VaultAddress,
+			Token:     config.VaultToken,
+			TokenFile: config.VaultTokenFile,
+			Path:      config.VaultPath,
+		})
+	default:
+		return nil, fmt.Errorf("%w: unknown secrets provider %q: expected env, file or vault",
+			apperrors.ErrInvalidInput, config.Provider)
+	}
+
+	return &secretDomain{
+		secretRepository: repository.NewRotatingSecretRepository(secretRepository, interval),
+	}, nil
+}
+
+func (d *secretDomain) Secret(ctx context.Context, name string) (string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	value, err := d.secretRepository.GetSecret(ctx, name)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return "", nil
+	}
+	return value, err
+}
+
+func (d *secretDomain) CheckHealth(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return d.secretRepository.CheckHealth(ctx)
+}
+
+// readS3Credentials reads the object storage access keys from the secrets of secretDomain. Missing keys are
+// reported as invalid input, naming uses, such as "exports to object storage", as not configured.
+func readS3Credentials(ctx context.Context, secretDomain SecretDomainInterface, uses string) (repository.S3Credentials, error) {
+	accessKeyID, err := secretDomain.Secret(ctx, S3AccessKeyIDSecret)
+	if err != nil {
+		return repository.S3Credentials{}, err
+	}
+	secretAccessKey, err := secretDomain.Secret(ctx, S3SecretAccessKeySecret)
+	if err != nil {
+		return repository.S3Credentials{}, err
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return repository.S3Credentials{}, fmt.Errorf("%w: %s are not configured", apperrors.ErrInvalidInput, uses)
+	}
+	return repository.S3Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey}, nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestNewSecretDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SecretsConfig
+		wantErr error
+	}{
+		{name: "defaults", config: SecretsConfig{}},
+		{name: "file", config: SecretsConfig{Provider: "file", Dir: t.TempDir()}},
+		{name: "vault", config: SecretsConfig{Provider: "vault", VaultAddress: "http://vault:8200", VaultToken: "token", VaultPath: "secret/data/app"}},
+		{name: "vault token file", config: SecretsConfig{Provider: "vault", VaultAddress: "http://vault:8200", VaultTokenFile: "/vault/token", VaultPath: "secret/data/app"}},
+		{name: "vault without token", config: SecretsConfig{Provider: "vault", VaultAddress: "http://vault:8200", VaultPath: "secret/data/app"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "vault without path", config: SecretsConfig{Provider: "vault", VaultAddress: "http://vault:8200", VaultToken: "token"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "unknown provider", config: SecretsConfig{Provider: "aws"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "refresh interval", config: SecretsConfig{RefreshInterval: "30s"}},
+		{name: "invalid refresh interval", config: SecretsConfig{RefreshInterval: "soon"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "negative refresh interval", config: SecretsConfig{RefreshInterval: "-1m"}, wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSecretDomain(tt.config)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func TestSecretDomain_Secret(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, AdminTokenSecret), []byte("secret\n"), 0o600))
+
+	domain, err := NewSecretDomain(SecretsConfig{Provider: "file", Dir: dir})
+	assert.NoError(t, err)
+
+	got, err := domain.Secret(context.Background(), AdminTokenSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", got)
+
+	got, err = domain.Secret(context.Background(), WebhookSecret)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = domain.Secret(ctx, AdminTokenSecret)
+	assert.ErrorIs(t, err, context.Canceled)
+}
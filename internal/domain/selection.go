@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// selectSubmatrix returns the submatrix of a validated matrix described by selection.
+// It runs between validation and the operation, so every operation works on the selected cells only.
+// Indices outside the matrix are reported as invalid input.
+func selectSubmatrix(matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Matrix[int64], error) {
+	if selection.IsEmpty() {
+		return matrix, nil
+	}
+
+	rows, err := expandRanges(selection.Rows, matrix.Rows(), "row")
+	if err != nil {
+		return nil, err
+	}
+	cols, err := expandRanges(selection.Cols, matrix.Cols(), "column")
+	if err != nil {
+		return nil, err
+	}
+
+	submatrix := &entity.Matrix[int64]{Data: make([][]int64, 0, len(rows)), Scale: matrix.Scale}
+	for _, i := range rows {
+		row := make([]int64, 0, len(cols))
+		for _, j := range cols {
+			row = append(row, matrix.At(i, j))
+		}
+		submatrix.Data = append(submatrix.Data, row)
+	}
+
+	return submatrix, nil
+}
+
+// expandRanges converts ranges into the list of indices they cover, checking them against size.
+// No ranges means every index. Selecting an index twice is rejected, which also bounds the
+// submatrix to the size of the original matrix.
+func expandRanges(ranges []entity.IndexRange, size int, dimension string) ([]int, error) {
+	if len(ranges) == 0 {
+		indices := make([]int, size)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+
+	var indices []int
+	seen := make([]bool, size)
+	for _, r := range ranges {
+		if r.Start < 0 || r.End < r.Start {
+			return nil, fmt.Errorf("%w: invalid %s range %d-%d", apperrors.ErrInvalidInput, dimension, r.Start+1, r.End+1)
+		}
+		if r.End >= size {
+			return nil, fmt.Errorf("%w: %s %d is out of range (matrix has %d %ss)",
+				apperrors.ErrInvalidInput, dimension, r.End+1, size, dimension)
+		}
+		for i := r.Start; i <= r.End; i++ {
+			if seen[i] {
+				return nil, fmt.Errorf("%w: %s %d is selected more than once", apperrors.ErrInvalidInput, dimension, i+1)
+			}
+			seen[i] = true
+			indices = append(indices, i)
+		}
+	}
+
+	return indices, nil
+}
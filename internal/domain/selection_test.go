@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestSelectSubmatrix(t *testing.T) {
+	matrix := &entity.Matrix[int64]{Data: [][]int64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	}}
+
+	tests := []struct {
+		name      string
+		selection *entity.Selection
+		want      [][]int64
+		wantErr   error
+	}{
+		{
+			name: "nil selection keeps the whole matrix",
+			want: matrix.Data,
+		},
+		{
+			name:      "row range",
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 0, End: 1}}},
+			want:      [][]int64{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:      "column list",
+			selection: &entity.Selection{Cols: []entity.IndexRange{{Start: 1, End: 1}, {Start: 2, End: 2}}},
+			want:      [][]int64{{2, 3}, {5, 6}, {8, 9}},
+		},
+		{
+			name: "rows and columns in requested order",
+			selection: &entity.Selection{
+				Rows: []entity.IndexRange{{Start: 2, End: 2}, {Start: 0, End: 0}},
+				Cols: []entity.IndexRange{{Start: 0, End: 1}},
+			},
+			want: [][]int64{{7, 8}, {1, 2}},
+		},
+		{
+			name:      "row out of range",
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 1, End: 3}}},
+			wantErr:   apperrors.ErrInvalidInput,
+		},
+		{
+			name:      "column out of range",
+			selection: &entity.Selection{Cols: []entity.IndexRange{{Start: 5, End: 5}}},
+			wantErr:   apperrors.ErrInvalidInput,
+		},
+		{
+			name:      "index selected twice",
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 0, End: 1}, {Start: 1, End: 1}}},
+			wantErr:   apperrors.ErrInvalidInput,
+		},
+		{
+			name:      "reversed range",
+			selection: &entity.Selection{Rows: []entity.IndexRange{{Start: 2, End: 1}}},
+			wantErr:   apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectSubmatrix(matrix, tt.selection)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+// serviceTimeWeight is the weight of the latest measurement in the moving average of a serviceTimer,
+// so the average follows a change of load within a few dozen items.
+const serviceTimeWeight = 0.1
+
+// serviceTimer keeps an exponentially weighted moving average of how long workers take per item.
+// The zero value is ready to use and reports no service time until the first measurement.
+type serviceTimer struct {
+	mu      sync.Mutex
+	average time.Duration
+}
+
+// observe adds the time a worker took for an item to the average.
+func (t *serviceTimer) observe(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.average == 0 {
+		t.average = d
+		return
+	}
+	t.average += time.Duration(serviceTimeWeight * float64(d-t.average))
+}
+
+// get returns the average time per item, or zero before any was measured.
+func (t *serviceTimer) get() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.average
+}
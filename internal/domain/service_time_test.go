@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceTimer(t *testing.T) {
+	var timer serviceTimer
+	assert.Zero(t, timer.get())
+
+	// The first measurement is taken as it is
+	timer.observe(time.Second)
+	assert.Equal(t, time.Second, timer.get())
+
+	// Later ones move the average by a tenth of their difference
+	timer.observe(2 * time.Second)
+	assert.Equal(t, 1100*time.Millisecond, timer.get())
+
+	timer.observe(100 * time.Millisecond)
+	assert.Equal(t, 1000*time.Millisecond, timer.get())
+}
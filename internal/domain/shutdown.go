@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// defaultShutdownHookTimeout bounds a shutdown hook registered without a timeout of its own.
+const defaultShutdownHookTimeout = 10 * time.Second
+
+// ShutdownDomainInterface defines the business logic contract for stopping the service gracefully.
+// Subsystems that run in the background, such as the job workers or the scheduler, register a hook
+// that releases what they hold, and the hooks run once the HTTP server has stopped accepting requests.
+type ShutdownDomainInterface interface {
+	// Register adds a cleanup hook under name. Hooks run in the order they were registered, so a subsystem
+	// should be registered before the ones it depends on. Each hook is given up to timeout, or 10 seconds
+	// when timeout is zero, and never more than what is left of the shutdown.
+	Register(name string, timeout time.Duration, hook func(ctx context.Context) error)
+
+	// Shutdown runs every hook in order, each within its own timeout and the deadline of ctx.
+	// A failing hook does not prevent the next ones from running; the failures are returned together.
+	// Only the first call runs the hooks, later calls return its outcome.
+	Shutdown(ctx context.Context) error
+}
+
+// shutdownHook is a cleanup hook registered by a subsystem.
+type shutdownHook struct {
+	name    string
+	timeout time.Duration
+	hook    func(ctx context.Context) error
+}
+
+type shutdownDomain struct {
+	mu    sync.Mutex
+	hooks []shutdownHook
+
+	once sync.Once
+	err  error
+}
+
+// NewShutdownDomain creates a new instance of ShutdownDomainInterface.
+// It initializes the domain service without hooks.
+func NewShutdownDomain() ShutdownDomainInterface {
+	return &shutdownDomain{}
+}
+
+func (d *shutdownDomain) Register(name string, timeout time.Duration, hook func(ctx context.Context) error) {
+	if timeout <= 0 {
+		timeout = defaultShutdownHookTimeout
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.hooks = append(d.hooks, shutdownHook{name: name, timeout: timeout, hook: hook})
+}
+
+func (d *shutdownDomain) Shutdown(ctx context.Context) error {
+	d.once.Do(func() {
+		d.mu.Lock()
+		hooks := append([]shutdownHook(nil), d.hooks...)
+		d.mu.Unlock()
+
+		var errs []error
+		for _, hook := range hooks {
+			if err := d.runHook(ctx, hook); err != nil {
+				errs = append(errs, fmt.Errorf("shutdown hook %s failed: %w", hook.name, err))
+			}
+		}
+		d.err = errors.Join(errs...)
+	})
+	return d.err
+}
+
+// runHook runs a single hook within its timeout and logs the outcome.
+func (d *shutdownDomain) runHook(ctx context.Context, hook shutdownHook) error {
+	ctx, cancel := context.WithTimeout(ctx, hook.timeout)
+	defer cancel()
+
+	slog.InfoContext(ctx, "running shutdown hook",
+		"hook", hook.name,
+		"timeout", hook.timeout)
+
+	start := time.Now()
+	err := hook.hook(ctx)
+	if err == nil {
+		// A hook that ignores its context may outlive it without reporting so
+		err = ctx.Err()
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "shutdown hook failed",
+			"hook", hook.name,
+			"duration", time.Since(start),
+			"error", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "shutdown hook completed",
+		"hook", hook.name,
+		"duration", time.Since(start))
+	return nil
+}
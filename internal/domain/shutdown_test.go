@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownDomain_Shutdown(t *testing.T) {
+	t.Run("hooks run in registration order", func(t *testing.T) {
+		d := NewShutdownDomain()
+		var ran []string
+		for _, name := range []string{"scheduler", "job_workers", "cache"} {
+			d.Register(name, time.Second, func(ctx context.Context) error {
+				ran = append(ran, name)
+				return nil
+			})
+		}
+
+		err := d.Shutdown(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"scheduler", "job_workers", "cache"}, ran)
+	})
+
+	t.Run("a failing hook does not stop the next ones", func(t *testing.T) {
+		d := NewShutdownDomain()
+		flushErr := errors.New("metrics backend unreachable")
+		ran := false
+		d.Register("metrics", time.Second, func(ctx context.Context) error { return flushErr })
+		d.Register("job_workers", time.Second, func(ctx context.Context) error {
+			ran = true
+			return nil
+		})
+
+		err := d.Shutdown(context.Background())
+
+		assert.ErrorIs(t, err, flushErr)
+		assert.ErrorContains(t, err, "shutdown hook metrics failed")
+		assert.True(t, ran)
+	})
+
+	t.Run("each hook is bounded by its own timeout", func(t *testing.T) {
+		d := NewShutdownDomain()
+		var remaining time.Duration
+		d.Register("consumer", 10*time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		d.Register("job_workers", time.Second, func(ctx context.Context) error {
+			deadline, _ := ctx.Deadline()
+			remaining = time.Until(deadline)
+			return nil
+		})
+
+		err := d.Shutdown(context.Background())
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "shutdown hook consumer failed")
+		assert.Greater(t, remaining, 500*time.Millisecond)
+	})
+
+	t.Run("hooks never outlive the shutdown", func(t *testing.T) {
+		d := NewShutdownDomain()
+		var remaining time.Duration
+		d.Register("job_workers", 0, func(ctx context.Context) error {
+			deadline, _ := ctx.Deadline()
+			remaining = time.Until(deadline)
+			return nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		assert.NoError(t, d.Shutdown(ctx))
+		assert.LessOrEqual(t, remaining, time.Second)
+	})
+
+	t.Run("hooks that ignore their context are reported", func(t *testing.T) {
+		d := NewShutdownDomain()
+		d.Register("cache", time.Millisecond, func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+
+		assert.ErrorIs(t, d.Shutdown(context.Background()), context.DeadlineExceeded)
+	})
+
+	t.Run("hooks run only once", func(t *testing.T) {
+		d := NewShutdownDomain()
+		runs := 0
+		d.Register("job_workers", time.Second, func(ctx context.Context) error {
+			runs++
+			return nil
+		})
+
+		assert.NoError(t, d.Shutdown(context.Background()))
+		assert.NoError(t, d.Shutdown(context.Background()))
+		assert.Equal(t, 1, runs)
+	})
+}
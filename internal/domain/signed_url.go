@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// defaultSignedURLTTL is how long a signed URL is valid when no time to live is given.
+	defaultSignedURLTTL = 24 * time.Hour
+
+	// maxSignedURLTTL bounds how long a signed URL may be valid, since it cannot be revoked
+	// short of rotating the signing key.
+	maxSignedURLTTL = 7 * 24 * time.Hour
+
+	// echoOperation returns the matrix unchanged; signed URLs without an operation download the file itself.
+	echoOperation = "echo"
+)
+
+// SignedURLDomainInterface defines the business logic contract for signed URLs, which let the stored matrix files
+// and their results be downloaded without an API key, such as from a link in an email, until they expire.
+type SignedURLDomainInterface interface {
+	// Sign returns a signed URL for the result of operation on the matrix file at filePath, valid for ttl from now.
+	// An empty operation downloads the file itself, and a zero ttl defaults to 24 hours; ttl may be at most 7 days.
+	// The file must exist and be readable by the tenant carried by ctx. It returns ErrServiceUnavailable
+	// when no signing key is configured.
+	Sign(ctx context.Context, filePath string, operation string, ttl time.Duration) (*entity.SignedURL, error)
+
+	// Verify checks that signed was issued by Sign and has not expired, returning ErrForbidden otherwise.
+	// It returns a context derived from ctx carrying the tenant owning the file, for the download to run as.
+	Verify(ctx context.Context, signed *entity.SignedURL) (context.Context, error)
+}
+
+type signedURLDomain struct {
+	matrixDomain MatrixDomainInterface
+	secretDomain SecretDomainInterface
+	tenantDomain TenantDomainInterface
+	// now returns the current time; tests replace it to expire URLs
+	now func() time.Time
+}
+
+// NewSignedURLDomain creates a new instance of SignedURLDomainInterface with its dependencies.
+// It initializes the domain service with the matrix domain the signed files are checked against, the secret domain
+// the signing key is read from on every use, so a rotated key invalidates the URLs signed before, and the tenant
+// domain downloads run under the limits of.
+func NewSignedURLDomain(matrixDomain MatrixDomainInterface, secretDomain SecretDomainInterface,
+	tenantDomain TenantDomainInterface) SignedURLDomainInterface {
+	return &signedURLDomain{
+		matrixDomain: matrixDomain,
+		secretDomain: secretDomain,
+		tenantDomain: tenantDomain,
+		now:          time.Now,
+	}
+}
+
+func (d *signedURLDomain) Sign(ctx context.Context, filePath string, operation string, ttl time.Duration) (*entity.SignedURL, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if ttl < 0 || ttl > maxSignedURLTTL {
+		return nil, fmt.Errorf("%w: ttl must be between 0 and %s", apperrors.ErrInvalidInput, maxSignedURLTTL)
+	}
+	if ttl == 0 {
+		ttl = defaultSignedURLTTL
+	}
+	if operation == "" {
+		operation = echoOperation
+	}
+	if !slices.Contains(d.matrixDomain.ListOperations(), operation) {
+		return nil, fmt.Errorf("%w: unsupported operation: %s", apperrors.ErrInvalidOperation, operation)
+	}
+	// Links to files that do not exist, or that the tenant may not read, are never handed out
+	if _, err := d.matrixDomain.FileModTime(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	key, err := d.secretDomain.Secret(ctx, SignedURLKeySecret)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, fmt.Errorf("%w: signed URLs are disabled: %s is not set", apperrors.ErrServiceUnavailable, SignedURLKeySecret)
+	}
+
+	// URLs carry the expiry in whole seconds
+	signed := &entity.SignedURL{
+		FilePath:  filePath,
+		Operation: operation,
+		ExpiresAt: d.now().Add(ttl).Truncate(time.Second),
+	}
+	signed.Signature = signURL(key, signed)
+	return signed, nil
+}
+
+func (d *signedURLDomain) Verify(ctx context.Context, signed *entity.SignedURL) (context.Context, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key, err := d.secretDomain.Secret(ctx, SignedURLKeySecret)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, fmt.Errorf("%w: signed URLs are disabled", apperrors.ErrForbidden)
+	}
+	if !hmac.Equal([]byte(signed.Signature), []byte(signURL(key, signed))) {
+		return nil, fmt.Errorf("%w: invalid signature", apperrors.ErrForbidden)
+	}
+	if !d.now().Before(signed.ExpiresAt) {
+		return nil, fmt.Errorf("%w: signed URL expired at %s", apperrors.ErrForbidden, signed.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	// Downloads act for the tenant that owns the file, under its current limits
+	ownerID := tenant.Owner(signed.FilePath)
+	if ownerID == "" || !d.tenantDomain.Enabled() {
+		return tenant.WithID(ctx, ownerID), nil
+	}
+	t, err := d.tenantDomain.GetTenant(ctx, ownerID)
+	if err != nil {
+		// Links of a tenant that is no longer configured stop working
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrForbidden, err)
+	}
+	return tenant.NewContext(ctx, t), nil
+}
+
+// signURL returns the hex-encoded HMAC-SHA256 of the fields of signed, keyed with key.
+func signURL(key string, signed *entity.SignedURL) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(signed.Operation + "\n" + signed.FilePath + "\n" + strconv.FormatInt(signed.ExpiresAt.Unix(), 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
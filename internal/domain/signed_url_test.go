@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newTestSignedURLDomain returns a signed URL domain signing with key, whose clock is read from now.
+func newTestSignedURLDomain(t *testing.T, key string, tenantDomain TenantDomainInterface, now *time.Time) *signedURLDomain {
+	t.Helper()
+	matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+	matrixDomain.On("ListOperations").Return([]string{"echo", "invert", "sum"}).Maybe()
+	matrixDomain.On("FileModTime", mock.Anything, "testdata/missing.csv").Return(time.Time{}, apperrors.ErrNotFound).Maybe()
+	matrixDomain.On("FileModTime", mock.Anything, mock.Anything).Return(time.Time{}, nil).Maybe()
+	secretDomain := mocks.NewMockSecretDomainInterface(t)
+	secretDomain.On("Secret", mock.Anything, SignedURLKeySecret).Return(key, nil).Maybe()
+
+	domain := NewSignedURLDomain(matrixDomain, secretDomain, tenantDomain).(*signedURLDomain)
+	domain.now = func() time.Time { return *now }
+	return domain
+}
+
+func TestSignedURLDomain_Sign(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name          string
+		key           string
+		filePath      string
+		operation     string
+		ttl           time.Duration
+		wantOperation string
+		wantExpiresAt time.Time
+		wantErr       error
+	}{
+		{
+			name:          "file download valid for a day by default",
+			key:           "s3cret",
+			filePath:      "testdata/matrix1.csv",
+			wantOperation: "echo",
+			wantExpiresAt: time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "operation result",
+			key:           "s3cret",
+			filePath:      "testdata/matrix1.csv",
+			operation:     "invert",
+			ttl:           time.Hour,
+			wantOperation: "invert",
+			wantExpiresAt: time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{name: "unknown operation", key: "s3cret", filePath: "testdata/matrix1.csv", operation: "explode", wantErr: apperrors.ErrInvalidInput},
+		{name: "negative ttl", key: "s3cret", filePath: "testdata/matrix1.csv", ttl: -time.Hour, wantErr: apperrors.ErrInvalidInput},
+		{name: "ttl over a week", key: "s3cret", filePath: "testdata/matrix1.csv", ttl: 8 * 24 * time.Hour, wantErr: apperrors.ErrInvalidInput},
+		{name: "missing file", key: "s3cret", filePath: "testdata/missing.csv", wantErr: apperrors.ErrNotFound},
+		{name: "no signing key", filePath: "testdata/matrix1.csv", wantErr: apperrors.ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := newTestSignedURLDomain(t, tt.key, mocks.NewMockTenantDomainInterface(t), &now)
+
+			signed, err := domain.Sign(context.Background(), tt.filePath, tt.operation, tt.ttl)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.filePath, signed.FilePath)
+			assert.Equal(t, tt.wantOperation, signed.Operation)
+			assert.True(t, tt.wantExpiresAt.Equal(signed.ExpiresAt))
+			assert.Len(t, signed.Signature, 64)
+		})
+	}
+}
+
+func TestSignedURLDomain_Verify(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tenantDomain := mocks.NewMockTenantDomainInterface(t)
+	tenantDomain.On("Enabled").Return(false).Maybe()
+	domain := newTestSignedURLDomain(t, "s3cret", tenantDomain, &now)
+
+	signed, err := domain.Sign(context.Background(), "testdata/matrix1.csv", "sum", time.Hour)
+	assert.NoError(t, err)
+
+	_, err = domain.Verify(context.Background(), signed)
+	assert.NoError(t, err)
+
+	for name, tampered := range map[string]*entity.SignedURL{
+		"other file":      {FilePath: "testdata/matrix2.csv", Operation: "sum", ExpiresAt: signed.ExpiresAt, Signature: signed.Signature},
+		"other operation": {FilePath: "testdata/matrix1.csv", Operation: "invert", ExpiresAt: signed.ExpiresAt, Signature: signed.Signature},
+		"later expiry":    {FilePath: "testdata/matrix1.csv", Operation: "sum", ExpiresAt: signed.ExpiresAt.Add(time.Hour), Signature: signed.Signature},
+		"no signature":    {FilePath: "testdata/matrix1.csv", Operation: "sum", ExpiresAt: signed.ExpiresAt},
+	} {
+		_, err := domain.Verify(context.Background(), tampered)
+		assert.ErrorIs(t, err, apperrors.ErrForbidden, name)
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		later := now.Add(time.Hour)
+		expired := newTestSignedURLDomain(t, "s3cret", tenantDomain, &later)
+		_, err := expired.Verify(context.Background(), signed)
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+	})
+
+	t.Run("rotated key", func(t *testing.T) {
+		rotated := newTestSignedURLDomain(t, "n3w", tenantDomain, &now)
+		_, err := rotated.Verify(context.Background(), signed)
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+	})
+
+	t.Run("signing key removed", func(t *testing.T) {
+		disabled := newTestSignedURLDomain(t, "", tenantDomain, &now)
+		_, err := disabled.Verify(context.Background(), signed)
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+	})
+}
+
+func TestSignedURLDomain_Verify_Tenant(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	acme := &tenant.Tenant{ID: "acme", Limits: tenant.Limits{MaxStorageBytes: 1024}}
+	tenantDomain := mocks.NewMockTenantDomainInterface(t)
+	tenantDomain.On("Enabled").Return(true)
+	tenantDomain.On("GetTenant", mock.Anything, "acme").Return(acme, nil)
+	tenantDomain.On("GetTenant", mock.Anything, "globex").Return(nil, apperrors.ErrNotFound)
+	domain := newTestSignedURLDomain(t, "s3cret", tenantDomain, &now)
+	ctx := tenant.NewContext(context.Background(), acme)
+
+	signed, err := domain.Sign(ctx, "testdata/tenants/acme/matrix.csv", "", time.Hour)
+	assert.NoError(t, err)
+
+	// The download runs as the tenant owning the file, with its limits
+	downloadCtx, err := domain.Verify(context.Background(), signed)
+	assert.NoError(t, err)
+	owner, ok := tenant.FromContext(downloadCtx)
+	assert.True(t, ok)
+	assert.Equal(t, acme, owner)
+
+	// The links of a tenant that is no longer configured stop working
+	signed, err = domain.Sign(tenant.WithID(context.Background(), "globex"), "testdata/tenants/globex/matrix.csv", "", time.Hour)
+	assert.NoError(t, err)
+	_, err = domain.Verify(context.Background(), signed)
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+}
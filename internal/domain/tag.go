@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// maxTags caps the number of tags a stored matrix file may carry.
+	maxTags = 20
+
+	// maxTagValueLength bounds the length in bytes of a tag value.
+	maxTagValueLength = 256
+)
+
+// tagKeyPattern matches valid tag keys: lowercase letters, digits, and . _ -, starting with a letter or digit.
+var tagKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,62}$`)
+
+// TagDomainInterface defines the business logic contract for the key/value tags users organize
+// their stored matrix files with. Tags belong to the file rather than to one of its versions.
+type TagDomainInterface interface {
+	// GetTags returns the tags of the matrix file at filePath. It returns ErrNotFound when no such file is stored.
+	GetTags(ctx context.Context, filePath string) (map[string]string, error)
+
+	// SetTags replaces the tags of the matrix file at filePath with tags; no tags clear them.
+	// Keys are up to 63 lowercase letters, digits, dots, underscores and hyphens, and values are non-empty
+	// and up to 256 bytes; a file carries at most 20 tags. Other tags are rejected with ErrInvalidInput.
+	SetTags(ctx context.Context, filePath string, tags map[string]string) error
+}
+
+type tagDomain struct {
+	tagRepository    repository.TagRepositoryInterface
+	matrixRepository repository.MatrixRepositoryInterface
+	validatorDomain  MatrixValidatorDomainInterface
+}
+
+// NewTagDomain creates a new instance of TagDomainInterface with its dependencies.
+// It initializes the domain service with the tag repository, the matrix repository the files
+// are checked in, and the validator their paths are checked with.
+func NewTagDomain() TagDomainInterface {
+	return &tagDomain{
+		tagRepository:    repository.NewTagRepository(),
+		matrixRepository: repository.NewMatrixRepository(),
+		validatorDomain:  NewMatrixValidatorDomain(),
+	}
+}
+
+func (d *tagDomain) GetTags(ctx context.Context, filePath string) (map[string]string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validatorDomain.ValidateFilePath(ctx, filePath); err != nil {
+		return nil, err
+	}
+	// Files never tagged have no tags file, so the file itself tells a missing file apart
+	if _, err := d.matrixRepository.FileModTime(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	return d.tagRepository.GetTags(ctx, filePath)
+}
+
+func (d *tagDomain) SetTags(ctx context.Context, filePath string, tags map[string]string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := d.validatorDomain.ValidateFilePath(ctx, filePath); err != nil {
+		return err
+	}
+	if err := validateTags(tags); err != nil {
+		return err
+	}
+
+	if err := d.tagRepository.SetTags(ctx, filePath, tags); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "matrix tagged",
+		"file_path", filePath,
+		"tags", len(tags))
+	return nil
+}
+
+// validateTags checks the keys and values of tags and their number.
+func validateTags(tags map[string]string) error {
+	if len(tags) > maxTags {
+		return fmt.Errorf("%w: a file carries at most %d tags, got %d", apperrors.ErrInvalidInput, maxTags, len(tags))
+	}
+	for key, value := range tags {
+		if !tagKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: invalid tag key: %q: expected up to 63 lowercase letters, digits, dots, "+
+				"underscores and hyphens", apperrors.ErrInvalidInput, key)
+		}
+		if value == "" || len(value) > maxTagValueLength {
+			return fmt.Errorf("%w: value of tag %s must be between 1 and %d bytes",
+				apperrors.ErrInvalidInput, key, maxTagValueLength)
+		}
+		if !utf8.ValidString(value) || strings.ContainsFunc(value, unicode.IsControl) {
+			return fmt.Errorf("%w: value of tag %s must be printable UTF-8 text", apperrors.ErrInvalidInput, key)
+		}
+	}
+	return nil
+}
+
+// fileTags returns the tags of the matrix file ref refers to, looked up on behalf of the tenant owning it,
+// for the records that note them, such as jobs and audit entries. Tags are informative there, so a file
+// whose tags cannot be read is recorded without them.
+func fileTags(ctx context.Context, tagRepository repository.TagRepositoryInterface, ref string) map[string]string {
+	filePath, _, err := repository.SplitVersion(ref)
+	// Records note the path they were given, which was not necessarily valid
+	if err != nil || !strings.HasSuffix(filePath, ".csv") || !filepath.IsLocal(filePath) {
+		return nil
+	}
+
+	tags, err := tagRepository.GetTags(tenant.WithID(ctx, tenant.Owner(filePath)), filePath)
+	if err != nil {
+		slog.DebugContext(ctx, "failed to read tags",
+			"file_path", filePath,
+			"error", err)
+		return nil
+	}
+	return tags
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestTagDomain_GetTags(t *testing.T) {
+	mockTags := mocks.NewMockTagRepositoryInterface(t)
+	mockTags.On("GetTags", mock.Anything, "testdata/league.csv").Return(map[string]string{"season": "2026"}, nil)
+	mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+	mockRepo.On("FileModTime", mock.Anything, "testdata/league.csv").Return(time.Now(), nil)
+	mockRepo.On("FileModTime", mock.Anything, "testdata/missing.csv").Return(time.Time{}, apperrors.ErrNotFound)
+	domain := &tagDomain{tagRepository: mockTags, matrixRepository: mockRepo, validatorDomain: NewMatrixValidatorDomain()}
+
+	tags, err := domain.GetTags(context.Background(), "testdata/league.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"season": "2026"}, tags)
+
+	_, err = domain.GetTags(context.Background(), "testdata/missing.csv")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	_, err = domain.GetTags(context.Background(), "testdata/league.csv@v1")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestTagDomain_SetTags(t *testing.T) {
+	manyTags := make(map[string]string)
+	for _, key := range strings.Split("a b c d e f g h i j k l m n o p q r s t u", " ") {
+		manyTags[key] = "1"
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		tags     map[string]string
+		repoCall bool
+		repoErr  error
+		wantErr  error
+	}{
+		{name: "tagged", filePath: "testdata/league.csv", tags: map[string]string{"season": "2026", "league.tier": "premier north"}, repoCall: true},
+		{name: "cleared", filePath: "testdata/league.csv", repoCall: true},
+		{name: "missing file", filePath: "testdata/missing.csv", tags: map[string]string{"season": "2026"}, repoCall: true, repoErr: apperrors.ErrNotFound, wantErr: apperrors.ErrNotFound},
+		{name: "uppercase key", filePath: "testdata/league.csv", tags: map[string]string{"Season": "2026"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "key with a colon", filePath: "testdata/league.csv", tags: map[string]string{"season:2026": "yes"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "empty value", filePath: "testdata/league.csv", tags: map[string]string{"season": ""}, wantErr: apperrors.ErrInvalidInput},
+		{name: "long value", filePath: "testdata/league.csv", tags: map[string]string{"notes": strings.Repeat("x", 257)}, wantErr: apperrors.ErrInvalidInput},
+		{name: "control character", filePath: "testdata/league.csv", tags: map[string]string{"notes": "a\nb"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "too many tags", filePath: "testdata/league.csv", tags: manyTags, wantErr: apperrors.ErrInvalidInput},
+		{name: "pinned version", filePath: "testdata/league.csv@v1", tags: map[string]string{"season": "2026"}, wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTags := mocks.NewMockTagRepositoryInterface(t)
+			if tt.repoCall {
+				mockTags.On("SetTags", mock.Anything, tt.filePath, tt.tags).Return(tt.repoErr)
+			}
+			domain := &tagDomain{tagRepository: mockTags, validatorDomain: NewMatrixValidatorDomain()}
+
+			err := domain.SetTags(context.Background(), tt.filePath, tt.tags)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFileTags(t *testing.T) {
+	mockTags := mocks.NewMockTagRepositoryInterface(t)
+	mockTags.On("GetTags", mock.Anything, "testdata/tenants/acme/league.csv").
+		Run(func(args mock.Arguments) {
+			// Tags are read on behalf of the tenant owning the file
+			assert.Equal(t, "acme", tenant.ID(args.Get(0).(context.Context)))
+		}).
+		Return(map[string]string{"season": "2026"}, nil)
+	mockTags.On("GetTags", mock.Anything, "testdata/broken.csv").Return(nil, apperrors.ErrNotFound)
+
+	assert.Equal(t, map[string]string{"season": "2026"}, fileTags(context.Background(), mockTags, "testdata/tenants/acme/league.csv@v2"))
+	assert.Nil(t, fileTags(context.Background(), mockTags, "testdata/broken.csv"))
+	// Paths that could not name a matrix file are never looked up
+	assert.Nil(t, fileTags(context.Background(), mockTags, "../secrets.csv"))
+	assert.Nil(t, fileTags(context.Background(), mockTags, "testdata/league.txt"))
+	assert.Nil(t, fileTags(context.Background(), mockTags, "testdata/league.csv@vX"))
+}
@@ -0,0 +1,482 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// defaultServiceName is the service.name resource attribute unless OTEL_SERVICE_NAME is set.
+	defaultServiceName = "league-matrix"
+
+	// defaultOTLPTimeout bounds each export request unless OTEL_EXPORTER_OTLP_TIMEOUT is set.
+	defaultOTLPTimeout = 10 * time.Second
+
+	// defaultMetricExportInterval is how often metrics are exported unless OTEL_METRIC_EXPORT_INTERVAL is set.
+	defaultMetricExportInterval = time.Minute
+
+	// logExportDelay is how often the queued log records are exported.
+	logExportDelay = time.Second
+
+	// maxQueuedLogRecords bounds the log records waiting to be exported; further records are dropped
+	// rather than holding up the code that logs them.
+	maxQueuedLogRecords = 2048
+
+	// maxLogExportBatch bounds the log records sent in a single export request.
+	maxLogExportBatch = 512
+)
+
+// TelemetryConfig holds the standard OpenTelemetry environment variables that configure the OTLP exporters,
+// given as strings. Logs and metrics are each exported when an endpoint is set for them, either through the
+// base Endpoint, to which /v1/logs and /v1/metrics are appended over HTTP, or through their own complete endpoint.
+// LogsExporter and MetricsExporter may be "otlp" (the default) or "none", and SDKDisabled set to "true"
+// disables both. Protocol is http/protobuf (the default), http/json or grpc. Timeout and MetricExportInterval
+// are milliseconds.
+type TelemetryConfig struct {
+	SDKDisabled          string // OTEL_SDK_DISABLED
+	Endpoint             string // OTEL_EXPORTER_OTLP_ENDPOINT
+	LogsEndpoint         string // OTEL_EXPORTER_OTLP_LOGS_ENDPOINT
+	MetricsEndpoint      string // OTEL_EXPORTER_OTLP_METRICS_ENDPOINT
+	Headers              string // OTEL_EXPORTER_OTLP_HEADERS
+	Protocol             string // OTEL_EXPORTER_OTLP_PROTOCOL
+	Timeout              string // OTEL_EXPORTER_OTLP_TIMEOUT
+	LogsExporter         string // OTEL_LOGS_EXPORTER
+	MetricsExporter      string // OTEL_METRICS_EXPORTER
+	MetricExportInterval string // OTEL_METRIC_EXPORT_INTERVAL
+	ServiceName          string // OTEL_SERVICE_NAME
+	ResourceAttributes   string // OTEL_RESOURCE_ATTRIBUTES
+}
+
+// TelemetryDomainInterface defines the business logic contract for shipping logs and metrics to
+// an OpenTelemetry collector. Exporting runs in the background, so logging never waits for the collector.
+type TelemetryDomainInterface interface {
+	// ExportsLogs reports whether log records are exported, in which case the logger must use LogHandler.
+	ExportsLogs() bool
+
+	// LogHandler wraps base so every record it handles is also queued for export.
+	// Export failures are reported through base only, so they are not exported in turn.
+	LogHandler(base slog.Handler) slog.Handler
+
+	// Shutdown stops exporting in the background, then exports the queued log records and the metrics
+	// one last time within ctx and closes the connections to the collector.
+	Shutdown(ctx context.Context) error
+}
+
+type telemetryDomain struct {
+	otlpRepository repository.OTLPRepositoryInterface
+	// metricsDomain is nil when metrics are not exported
+	metricsDomain  MetricsDomainInterface
+	exportLogs     bool
+	metricInterval time.Duration
+
+	// logger reports export failures; LogHandler replaces it with a logger that skips the export
+	logger  atomic.Pointer[slog.Logger]
+	records chan *repository.LogRecord
+	dropped atomic.Int64
+
+	stop     chan struct{}
+	loops    sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewTelemetryDomain creates a new instance of TelemetryDomainInterface with its dependencies.
+// It initializes the domain service with the OTLP exporters described by config, exporting the metrics
+// collected by metricsDomain, and starts exporting in the background. Nothing is exported when config
+// sets no endpoint.
+func NewTelemetryDomain(config TelemetryConfig, metricsDomain MetricsDomainInterface) (TelemetryDomainInterface, error) {
+	if config.SDKDisabled == "true" {
+		return newTelemetryDomain(nil, nil, false, 0), nil
+	}
+
+	protocol := config.Protocol
+	switch protocol {
+	case "":
+		protocol = repository.OTLPProtocolHTTPProtobuf
+	case repository.OTLPProtocolHTTPProtobuf, repository.OTLPProtocolHTTPJSON, repository.OTLPProtocolGRPC:
+	default:
+		return nil, fmt.Errorf("%w: unsupported OTLP protocol %q: expected %s, %s or %s", apperrors.ErrInvalidInput,
+			config.Protocol, repository.OTLPProtocolHTTPProtobuf, repository.OTLPProtocolHTTPJSON, repository.OTLPProtocolGRPC)
+	}
+
+	logsEndpoint, err := signalEndpoint("logs", protocol, config.LogsExporter, config.LogsEndpoint, config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	metricsEndpoint, err := signalEndpoint("metrics", protocol, config.MetricsExporter, config.MetricsEndpoint, config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if logsEndpoint == "" && metricsEndpoint == "" {
+		return newTelemetryDomain(nil, nil, false, 0), nil
+	}
+
+	headers, err := parseKeyValueList("OTLP headers", config.Headers)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := parseKeyValueList("resource attributes", config.ResourceAttributes)
+	if err != nil {
+		return nil, err
+	}
+	if config.ServiceName != "" {
+		resource["service.name"] = config.ServiceName
+	} else if resource["service.name"] == "" {
+		resource["service.name"] = defaultServiceName
+	}
+
+	timeout, err := parseMilliseconds("OTLP timeout", config.Timeout, defaultOTLPTimeout)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := parseMilliseconds("metric export interval", config.MetricExportInterval, defaultMetricExportInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	otlpRepository, err := repository.NewOTLPRepository(repository.OTLPConfig{
+		Protocol:        protocol,
+		LogsEndpoint:    logsEndpoint,
+		MetricsEndpoint: metricsEndpoint,
+		Headers:         headers,
+		Timeout:         timeout,
+		Resource:        resource,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if metricsEndpoint == "" {
+		metricsDomain = nil
+	}
+
+	d := newTelemetryDomain(otlpRepository, metricsDomain, logsEndpoint != "", interval)
+	d.start()
+
+	slog.Info("OpenTelemetry export enabled",
+		"protocol", protocol,
+		"logs_endpoint", logsEndpoint,
+		"metrics_endpoint", metricsEndpoint,
+		"metric_export_interval", interval)
+	return d, nil
+}
+
+// newTelemetryDomain builds a telemetry domain whose background exports have not been started.
+// otlpRepository may be nil when nothing is exported.
+func newTelemetryDomain(otlpRepository repository.OTLPRepositoryInterface, metricsDomain MetricsDomainInterface,
+	exportLogs bool, metricInterval time.Duration) *telemetryDomain {
+	d := &telemetryDomain{
+		otlpRepository: otlpRepository,
+		metricsDomain:  metricsDomain,
+		exportLogs:     exportLogs,
+		metricInterval: metricInterval,
+		records:        make(chan *repository.LogRecord, maxQueuedLogRecords),
+		stop:           make(chan struct{}),
+	}
+	d.logger.Store(slog.Default())
+	return d
+}
+
+// start launches the background exports.
+func (d *telemetryDomain) start() {
+	if d.exportLogs {
+		d.loops.Go(func() { d.every(logExportDelay, d.exportQueuedLogs) })
+	}
+	if d.metricsDomain != nil {
+		d.loops.Go(func() { d.every(d.metricInterval, d.exportMetrics) })
+	}
+}
+
+// every runs export at each interval until the domain is shut down.
+func (d *telemetryDomain) every(interval time.Duration, export func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			if err := export(context.Background()); err != nil {
+				d.logger.Load().Warn("OpenTelemetry export failed", "error", err)
+			}
+		}
+	}
+}
+
+func (d *telemetryDomain) ExportsLogs() bool {
+	return d.exportLogs
+}
+
+func (d *telemetryDomain) LogHandler(base slog.Handler) slog.Handler {
+	d.logger.Store(slog.New(base))
+	return &otlpLogHandler{base: base, domain: d}
+}
+
+func (d *telemetryDomain) Shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		d.loops.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("telemetry export still running: %w", ctx.Err())
+	}
+
+	var errs []error
+	if d.exportLogs {
+		errs = append(errs, d.exportQueuedLogs(ctx))
+	}
+	if d.metricsDomain != nil {
+		errs = append(errs, d.exportMetrics(ctx))
+	}
+	if d.otlpRepository != nil {
+		errs = append(errs, d.otlpRepository.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// enqueue queues a log record for export, dropping it when the queue is full.
+func (d *telemetryDomain) enqueue(record *repository.LogRecord) {
+	select {
+	case d.records <- record:
+	default:
+		d.dropped.Add(1)
+	}
+}
+
+// exportQueuedLogs exports the queued log records in batches until the queue is empty.
+func (d *telemetryDomain) exportQueuedLogs(ctx context.Context) error {
+	if dropped := d.dropped.Swap(0); dropped > 0 {
+		d.logger.Load().Warn("log records dropped before export", "dropped", dropped)
+	}
+
+	for {
+		batch := make([]*repository.LogRecord, 0, min(len(d.records), maxLogExportBatch))
+	collect:
+		for len(batch) < maxLogExportBatch {
+			select {
+			case record := <-d.records:
+				batch = append(batch, record)
+			default:
+				break collect
+			}
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := d.otlpRepository.ExportLogs(ctx, batch); err != nil {
+			return fmt.Errorf("failed to export %d log records: %w", len(batch), err)
+		}
+	}
+}
+
+// exportMetrics exports the current value of every metric.
+func (d *telemetryDomain) exportMetrics(ctx context.Context) error {
+	snapshot := d.metricsDomain.Snapshot()
+	records := make([]*repository.MetricRecord, 0, len(snapshot.Metrics))
+	for _, metric := range snapshot.Metrics {
+		records = append(records, toMetricRecord(snapshot, metric))
+	}
+
+	if err := d.otlpRepository.ExportMetrics(ctx, records); err != nil {
+		return fmt.Errorf("failed to export metrics: %w", err)
+	}
+	return nil
+}
+
+// toMetricRecord converts a metric of snapshot into its stored form.
+func toMetricRecord(snapshot *entity.MetricsSnapshot, metric *entity.Metric) *repository.MetricRecord {
+	record := &repository.MetricRecord{
+		Name:        metric.Name,
+		Description: metric.Description,
+		Unit:        metric.Unit,
+		Kind:        string(metric.Kind),
+		Bounds:      metric.Bounds,
+		StartTime:   snapshot.StartTime,
+		Time:        snapshot.Time,
+		Points:      make([]repository.MetricPointRecord, 0, len(metric.Points)),
+	}
+	for _, point := range metric.Points {
+		record.Points = append(record.Points, repository.MetricPointRecord{
+			Attributes:   point.Attributes,
+			Value:        point.Value,
+			Count:        point.Count,
+			Sum:          point.Sum,
+			BucketCounts: point.BucketCounts,
+		})
+	}
+	return record
+}
+
+// otlpLogHandler passes log records on to base and queues them for export.
+// Attributes added with WithAttrs and WithGroup are kept flattened, with group names joined by dots.
+type otlpLogHandler struct {
+	base   slog.Handler
+	domain *telemetryDomain
+	attrs  []repository.LogAttribute
+	prefix string
+}
+
+func (h *otlpLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *otlpLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := &repository.LogRecord{
+		Time:           r.Time,
+		SeverityNumber: severityNumber(r.Level),
+		SeverityText:   r.Level.String(),
+		Body:           r.Message,
+		Attributes:     slices.Clone(h.attrs),
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		record.Attributes = appendLogAttribute(record.Attributes, h.prefix, attr)
+		return true
+	})
+	h.domain.enqueue(record)
+
+	return h.base.Handle(ctx, r)
+}
+
+func (h *otlpLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.base = h.base.WithAttrs(attrs)
+	clone.attrs = slices.Clone(h.attrs)
+	for _, attr := range attrs {
+		clone.attrs = appendLogAttribute(clone.attrs, h.prefix, attr)
+	}
+	return &clone
+}
+
+func (h *otlpLogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.base = h.base.WithGroup(name)
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// appendLogAttribute appends attr to attrs, flattening groups and converting values to the types OTLP supports.
+func appendLogAttribute(attrs []repository.LogAttribute, prefix string, attr slog.Attr) []repository.LogAttribute {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return attrs
+	}
+
+	key := prefix + attr.Key
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = key + "."
+		}
+		for _, member := range attr.Value.Group() {
+			attrs = appendLogAttribute(attrs, groupPrefix, member)
+		}
+		return attrs
+	case slog.KindString:
+		return append(attrs, repository.LogAttribute{Key: key, Value: attr.Value.String()})
+	case slog.KindInt64:
+		return append(attrs, repository.LogAttribute{Key: key, Value: attr.Value.Int64()})
+	case slog.KindFloat64:
+		return append(attrs, repository.LogAttribute{Key: key, Value: attr.Value.Float64()})
+	case slog.KindBool:
+		return append(attrs, repository.LogAttribute{Key: key, Value: attr.Value.Bool()})
+	case slog.KindTime:
+		return append(attrs, repository.LogAttribute{Key: key, Value: attr.Value.Time().Format(time.RFC3339Nano)})
+	default:
+		// Durations, unsigned integers and any other value are exported as they are printed
+		return append(attrs, repository.LogAttribute{Key: key, Value: attr.Value.String()})
+	}
+}
+
+// severityNumber maps a slog level to the OpenTelemetry severity number, where INFO is 9
+// and each step of 4 between slog levels moves to the next severity, such as WARN at 13.
+func severityNumber(level slog.Level) int {
+	return min(max(9+int(level), 1), 24)
+}
+
+// signalEndpoint resolves the export endpoint of a signal sent over protocol, or returns an empty endpoint when
+// the signal is not exported. gRPC serves every signal at the base endpoint, while HTTP gives each its own path.
+func signalEndpoint(signal, protocol, exporter, endpoint, baseEndpoint string) (string, error) {
+	switch exporter {
+	case "", "otlp":
+	case "none":
+		return "", nil
+	default:
+		return "", fmt.Errorf("%w: unsupported %s exporter %q: expected otlp or none",
+			apperrors.ErrInvalidInput, signal, exporter)
+	}
+
+	if endpoint == "" && baseEndpoint != "" {
+		endpoint = strings.TrimRight(baseEndpoint, "/") + "/v1/" + signal
+		if protocol == repository.OTLPProtocolGRPC {
+			endpoint = baseEndpoint
+		}
+	}
+	if endpoint == "" {
+		return "", nil
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("%w: invalid OTLP %s endpoint %q: expected an http or https URL",
+			apperrors.ErrInvalidInput, signal, endpoint)
+	}
+	return endpoint, nil
+}
+
+// parseKeyValueList parses a comma-separated list of key=value pairs with percent-encoded values,
+// as used by OTEL_EXPORTER_OTLP_HEADERS and OTEL_RESOURCE_ATTRIBUTES.
+func parseKeyValueList(what, list string) (map[string]string, error) {
+	values := make(map[string]string)
+	for pair := range strings.SplitSeq(list, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		decoded, err := url.PathUnescape(strings.TrimSpace(value))
+		if !ok || key == "" || err != nil {
+			return nil, fmt.Errorf("%w: invalid %s %q: expected comma-separated key=value pairs",
+				apperrors.ErrInvalidInput, what, list)
+		}
+		values[key] = decoded
+	}
+	return values, nil
+}
+
+// parseMilliseconds parses a positive number of milliseconds, returning fallback when value is empty.
+func parseMilliseconds(what, value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	ms, err := strconv.Atoi(value)
+	if err != nil || ms <= 0 {
+		return 0, fmt.Errorf("%w: invalid %s %q: expected a positive number of milliseconds",
+			apperrors.ErrInvalidInput, what, value)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
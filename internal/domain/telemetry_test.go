@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestNewTelemetryDomain(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      TelemetryConfig
+		exportsLogs bool
+		wantErr     string
+	}{
+		{name: "no endpoint", config: TelemetryConfig{}},
+		{name: "base endpoint", config: TelemetryConfig{Endpoint: "http://collector:4318/"}, exportsLogs: true},
+		{name: "logs endpoint", config: TelemetryConfig{LogsEndpoint: "https://collector/logs"}, exportsLogs: true},
+		{name: "metrics only", config: TelemetryConfig{Endpoint: "http://collector:4318", LogsExporter: "none"}},
+		{name: "disabled", config: TelemetryConfig{SDKDisabled: "true", Endpoint: "http://collector:4318"}},
+		{
+			name: "headers, resource attributes and timings",
+			config: TelemetryConfig{
+				Endpoint:             "http://collector:4318",
+				Protocol:             "http/json",
+				Headers:              "Authorization=Bearer%20token, X-Tenant=acme",
+				ResourceAttributes:   "deployment.environment=production",
+				Timeout:              "2500",
+				MetricExportInterval: "30000",
+			},
+			exportsLogs: true,
+		},
+		{name: "protobuf protocol", config: TelemetryConfig{Endpoint: "http://collector:4318", Protocol: "http/protobuf"}, exportsLogs: true},
+		{name: "grpc protocol", config: TelemetryConfig{Endpoint: "https://collector", Protocol: "grpc"}, exportsLogs: true},
+		{name: "unknown protocol", config: TelemetryConfig{Endpoint: "http://collector:4318", Protocol: "http/xml"}, wantErr: "unsupported OTLP protocol"},
+		{name: "unknown exporter", config: TelemetryConfig{Endpoint: "http://collector:4318", MetricsExporter: "prometheus"}, wantErr: "unsupported metrics exporter"},
+		{name: "endpoint without a scheme", config: TelemetryConfig{Endpoint: "collector:4318"}, wantErr: "invalid OTLP logs endpoint"},
+		{name: "malformed headers", config: TelemetryConfig{Endpoint: "http://collector:4318", Headers: "Authorization"}, wantErr: "invalid OTLP headers"},
+		{name: "malformed timeout", config: TelemetryConfig{Endpoint: "http://collector:4318", Timeout: "10s"}, wantErr: "invalid OTLP timeout"},
+		{name: "zero interval", config: TelemetryConfig{Endpoint: "http://collector:4318", MetricExportInterval: "0"}, wantErr: "invalid metric export interval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain, err := NewTelemetryDomain(tt.config, NewMetricsDomain())
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.exportsLogs, domain.ExportsLogs())
+
+			// Nothing is queued, and the collector is never reached before the first interval elapses
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err = domain.Shutdown(ctx)
+			if err != nil {
+				assert.ErrorIs(t, err, context.Canceled)
+			}
+		})
+	}
+}
+
+func TestSignalEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		endpoint string
+		base     string
+		want     string
+	}{
+		{name: "signal path appended over HTTP", protocol: "http/protobuf", base: "http://collector:4318/", want: "http://collector:4318/v1/logs"},
+		{name: "base endpoint over gRPC", protocol: "grpc", base: "http://collector:4317", want: "http://collector:4317"},
+		{name: "signal endpoint", protocol: "http/json", endpoint: "https://collector/logs", base: "http://collector:4318", want: "https://collector/logs"},
+		{name: "no endpoint", protocol: "grpc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signalEndpoint("logs", tt.protocol, "", tt.endpoint, tt.base)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTelemetryDomain_LogHandler(t *testing.T) {
+	mockRepo := mocks.NewMockOTLPRepositoryInterface(t)
+	mockRepo.EXPECT().Close().Return(nil).Once()
+	domain := newTelemetryDomain(mockRepo, nil, true, 0)
+
+	var output bytes.Buffer
+	logger := slog.New(domain.LogHandler(slog.NewTextHandler(&output, nil)))
+	logger.With("job_id", "abc").WithGroup("request").Warn("job failed",
+		"attempt", 3,
+		slog.Group("matrix", "rows", 2, "file", "matrix1.csv"),
+		"retried", true)
+	logger.Debug("not enabled")
+
+	var got []*repository.LogRecord
+	mockRepo.EXPECT().ExportLogs(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, records []*repository.LogRecord) { got = records }).
+		Return(nil).Once()
+
+	assert.NoError(t, domain.Shutdown(context.Background()))
+
+	// Records are still written by the wrapped handler
+	assert.Contains(t, output.String(), "msg=\"job failed\" job_id=abc request.attempt=3")
+	assert.NotContains(t, output.String(), "not enabled")
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "job failed", got[0].Body)
+		assert.Equal(t, 13, got[0].SeverityNumber)
+		assert.Equal(t, "WARN", got[0].SeverityText)
+		assert.False(t, got[0].Time.IsZero())
+		assert.Equal(t, []repository.LogAttribute{
+			{Key: "job_id", Value: "abc"},
+			{Key: "request.attempt", Value: int64(3)},
+			{Key: "request.matrix.rows", Value: int64(2)},
+			{Key: "request.matrix.file", Value: "matrix1.csv"},
+			{Key: "request.retried", Value: true},
+		}, got[0].Attributes)
+	}
+}
+
+func TestTelemetryDomain_Shutdown(t *testing.T) {
+	t.Run("exports the queued log records in batches", func(t *testing.T) {
+		mockRepo := mocks.NewMockOTLPRepositoryInterface(t)
+		mockRepo.EXPECT().Close().Return(nil).Once()
+		domain := newTelemetryDomain(mockRepo, nil, true, 0)
+		logger := slog.New(domain.LogHandler(slog.NewTextHandler(io.Discard, nil)))
+		// One record more than the queue holds is dropped
+		for range maxQueuedLogRecords + 1 {
+			logger.Info("request served")
+		}
+
+		var exported atomic.Int64
+		mockRepo.EXPECT().ExportLogs(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, records []*repository.LogRecord) {
+				assert.LessOrEqual(t, len(records), maxLogExportBatch)
+				exported.Add(int64(len(records)))
+			}).
+			Return(nil).Times(maxQueuedLogRecords / maxLogExportBatch)
+
+		assert.NoError(t, domain.Shutdown(context.Background()))
+		assert.Equal(t, int64(maxQueuedLogRecords), exported.Load())
+	})
+
+	t.Run("exports the metrics", func(t *testing.T) {
+		start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		mockMetrics := mocks.NewMockMetricsDomainInterface(t)
+		mockMetrics.EXPECT().Snapshot().Return(&entity.MetricsSnapshot{
+			StartTime: start,
+			Time:      start.Add(time.Minute),
+			Metrics: []*entity.Metric{{
+				Name:   "http.server.request.duration",
+				Kind:   entity.MetricHistogram,
+				Bounds: []float64{0.1},
+				Points: []*entity.MetricPoint{{
+					Attributes:   map[string]string{"http.route": "/health"},
+					Count:        2,
+					Sum:          0.3,
+					BucketCounts: []uint64{1, 1},
+				}},
+			}},
+		}).Once()
+		mockRepo := mocks.NewMockOTLPRepositoryInterface(t)
+		mockRepo.EXPECT().Close().Return(nil).Once()
+		mockRepo.EXPECT().ExportMetrics(mock.Anything, []*repository.MetricRecord{{
+			Name:      "http.server.request.duration",
+			Kind:      "histogram",
+			Bounds:    []float64{0.1},
+			StartTime: start,
+			Time:      start.Add(time.Minute),
+			Points: []repository.MetricPointRecord{{
+				Attributes:   map[string]string{"http.route": "/health"},
+				Count:        2,
+				Sum:          0.3,
+				BucketCounts: []uint64{1, 1},
+			}},
+		}}).Return(nil).Once()
+		domain := newTelemetryDomain(mockRepo, mockMetrics, false, time.Hour)
+		domain.start()
+
+		assert.NoError(t, domain.Shutdown(context.Background()))
+	})
+
+	t.Run("reports a failing export", func(t *testing.T) {
+		mockRepo := mocks.NewMockOTLPRepositoryInterface(t)
+		mockRepo.EXPECT().Close().Return(nil).Once()
+		mockRepo.EXPECT().ExportLogs(mock.Anything, mock.Anything).
+			Return(errors.New("collector unavailable")).Once()
+		domain := newTelemetryDomain(mockRepo, nil, true, 0)
+		slog.New(domain.LogHandler(slog.NewTextHandler(io.Discard, nil))).Info("request served")
+
+		err := domain.Shutdown(context.Background())
+
+		assert.ErrorContains(t, err, "failed to export 1 log records: collector unavailable")
+	})
+
+	t.Run("exports to the collector in the background", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/metrics" {
+				requests.Add(1)
+			}
+		}))
+		defer server.Close()
+
+		domain, err := NewTelemetryDomain(TelemetryConfig{
+			Endpoint:             server.URL,
+			LogsExporter:         "none",
+			MetricExportInterval: "10",
+		}, NewMetricsDomain())
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool { return requests.Load() > 0 }, time.Second, 5*time.Millisecond)
+		assert.NoError(t, domain.Shutdown(context.Background()))
+	})
+}
@@ -0,0 +1,433 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// TenantDomainInterface defines the business logic contract for identifying tenants by API key.
+// Every API key belongs to exactly one tenant, whose requests are confined to its own data directory.
+type TenantDomainInterface interface {
+	// Enabled reports whether tenants are configured. Without tenants, requests need no API key
+	// and may use every matrix file outside the tenants' data directories.
+	Enabled() bool
+
+	// Authenticate returns the tenant an API key belongs to, or ErrUnauthorized for a missing or unknown key.
+	Authenticate(ctx context.Context, apiKey string) (*tenant.Tenant, error)
+
+	// GetTenant returns the tenant with the given ID, so work recorded for a tenant, such as a background job,
+	// runs under its current limits. It returns ErrNotFound for unknown tenants.
+	GetTenant(ctx context.Context, id string) (*tenant.Tenant, error)
+
+	// AllowRequest counts a request made with apiKey against the tenant's requests per minute limit
+	// and the key's request and compute quotas. It returns ErrTooManyRequests once a limit is reached,
+	// until its window or quota period ends. The returned rate limit is the allowance closest to running out,
+	// rejected requests included, or nil when the tenant has no request limit.
+	AllowRequest(ctx context.Context, t *tenant.Tenant, apiKey string) (*entity.RateLimit, error)
+
+	// RecordUsage adds the time spent serving a request made with apiKey to the key's compute usage.
+	RecordUsage(ctx context.Context, t *tenant.Tenant, apiKey string, computeTime time.Duration) error
+
+	// ListUsage returns the usage of every API key that made a request during its current quota period,
+	// sorted by tenant and key.
+	ListUsage(ctx context.Context) ([]*entity.ClientUsage, error)
+}
+
+const (
+	// requestWindow is the period the requests per minute limit of a tenant is counted over.
+	requestWindow = time.Minute
+
+	// defaultQuotaPeriod is the period quotas are counted over when a tenant sets none.
+	defaultQuotaPeriod = 24 * time.Hour
+
+	// keyIDBytes is the number of bytes of an API key digest that identify the key in usage reports.
+	keyIDBytes = 8
+)
+
+// tenantFileEntry is a tenant declared in the tenants configuration file.
+type tenantFileEntry struct {
+	ID      string            `json:"id"`
+	APIKeys []string          `json:"api_keys"`
+	Limits  tenantLimitsEntry `json:"limits"`
+
+	// QoSClass is the class of service of the tenant's requests, interactive unless set to batch.
+	QoSClass string `json:"qos_class"`
+}
+
+// tenantLimitsEntry holds the optional limits of a tenant declared in the tenants configuration file.
+type tenantLimitsEntry struct {
+	RequestsPerMinute int   `json:"requests_per_minute"`
+	MaxFileSize       int64 `json:"max_file_size"`
+	MaxRows           int   `json:"max_rows"`
+	MaxCols           int   `json:"max_cols"`
+	MaxStorageBytes   int64 `json:"max_storage_bytes"`
+
+	RequestQuota        int    `json:"request_quota"`
+	ComputeQuotaSeconds int64  `json:"compute_quota_seconds"`
+	QuotaPeriod         string `json:"quota_period"`
+}
+
+// requestCounter counts the requests a tenant made in the current window.
+type requestCounter struct {
+	start time.Time
+	count int
+}
+
+// keyUsage is what an API key used in the current quota period.
+type keyUsage struct {
+	tenant      *tenant.Tenant
+	keyID       string
+	periodStart time.Time
+	requests    int
+	computeTime time.Duration
+}
+
+type tenantDomain struct {
+	// tenants maps the SHA-256 digest of each API key to its tenant, so keys are never compared directly
+	tenants map[[sha256.Size]byte]*tenant.Tenant
+
+	// byID maps each tenant ID to its tenant
+	byID map[string]*tenant.Tenant
+
+	mu       sync.Mutex
+	requests map[string]*requestCounter
+	usage    map[[sha256.Size]byte]*keyUsage
+
+	// now returns the current time; tests replace it to control the request windows
+	now func() time.Time
+}
+
+// NewTenantDomain creates a new instance of TenantDomainInterface.
+// It loads the tenants and their API keys declared in tenantsFile (a JSON array);
+// when tenantsFile is empty, multi-tenancy is disabled.
+func NewTenantDomain(tenantsFile string) (TenantDomainInterface, error) {
+	d := &tenantDomain{
+		tenants:  make(map[[sha256.Size]byte]*tenant.Tenant),
+		byID:     make(map[string]*tenant.Tenant),
+		requests: make(map[string]*requestCounter),
+		usage:    make(map[[sha256.Size]byte]*keyUsage),
+		now:      time.Now,
+	}
+
+	if tenantsFile != "" {
+		if err := d.loadFile(tenantsFile); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *tenantDomain) Enabled() bool {
+	return len(d.tenants) > 0
+}
+
+func (d *tenantDomain) Authenticate(ctx context.Context, apiKey string) (*tenant.Tenant, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if apiKey == "" {
+		return nil, fmt.Errorf("%w: an API key is required", apperrors.ErrUnauthorized)
+	}
+
+	t, ok := d.tenants[sha256.Sum256([]byte(apiKey))]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown API key", apperrors.ErrUnauthorized)
+	}
+	return t, nil
+}
+
+func (d *tenantDomain) GetTenant(ctx context.Context, id string) (*tenant.Tenant, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	t, ok := d.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: tenant not found: %s", apperrors.ErrNotFound, id)
+	}
+	return t, nil
+}
+
+func (d *tenantDomain) AllowRequest(ctx context.Context, t *tenant.Tenant, apiKey string) (*entity.RateLimit, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	now := d.now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var allowances []*entity.RateLimit
+	var err error
+
+	var counter *requestCounter
+	if limit := t.Limits.RequestsPerMinute; limit > 0 {
+		var ok bool
+		counter, ok = d.requests[t.ID]
+		if !ok || now.Sub(counter.start) >= requestWindow {
+			counter = &requestCounter{start: now}
+			d.requests[t.ID] = counter
+		}
+		allowance := &entity.RateLimit{Limit: limit, Remaining: limit - counter.count, Reset: counter.start.Add(requestWindow)}
+		if allowance.Remaining <= 0 {
+			err = fmt.Errorf("%w: tenant %q exceeded %d requests per minute, retry in %s",
+				apperrors.ErrTooManyRequests, t.ID, limit, allowance.Reset.Sub(now).Round(time.Second))
+		}
+		allowances = append(allowances, allowance)
+	}
+
+	usage := d.keyUsage(t, apiKey, now)
+	periodEnd := usage.periodStart.Add(quotaPeriod(t))
+	quotaAllowance := &entity.RateLimit{Limit: usage.requests, Reset: periodEnd}
+	if quota := t.Limits.RequestQuota; quota > 0 {
+		quotaAllowance = &entity.RateLimit{Limit: quota, Remaining: quota - usage.requests, Reset: periodEnd}
+		if err == nil && quotaAllowance.Remaining <= 0 {
+			err = fmt.Errorf("%w: API key %s exhausted its quota of %d requests, retry after %s",
+				apperrors.ErrTooManyRequests, usage.keyID, quota, periodEnd.Format(time.RFC3339))
+		}
+		allowances = append(allowances, quotaAllowance)
+	}
+	if quota := t.Limits.ComputeQuota; quota > 0 && usage.computeTime >= quota {
+		// No request is served once the compute time is used up, whatever the request quota says
+		quotaAllowance.Remaining = 0
+		if err == nil {
+			err = fmt.Errorf("%w: API key %s exhausted its quota of %s of compute time, retry after %s",
+				apperrors.ErrTooManyRequests, usage.keyID, quota, periodEnd.Format(time.RFC3339))
+		}
+		if t.Limits.RequestQuota == 0 {
+			allowances = append(allowances, quotaAllowance)
+		}
+	}
+
+	if err == nil {
+		if counter != nil {
+			counter.count++
+		}
+		usage.requests++
+		for _, allowance := range allowances {
+			allowance.Remaining--
+		}
+	}
+	return mostRestrictive(allowances), err
+}
+
+func (d *tenantDomain) RecordUsage(ctx context.Context, t *tenant.Tenant, apiKey string, computeTime time.Duration) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := d.now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.keyUsage(t, apiKey, now).computeTime += computeTime
+	return nil
+}
+
+func (d *tenantDomain) ListUsage(ctx context.Context) ([]*entity.ClientUsage, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	now := d.now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	clients := make([]*entity.ClientUsage, 0, len(d.usage))
+	for _, usage := range d.usage {
+		period := quotaPeriod(usage.tenant)
+		periodStart := now.Truncate(period)
+		client := &entity.ClientUsage{
+			TenantID:     usage.tenant.ID,
+			KeyID:        usage.keyID,
+			PeriodStart:  periodStart,
+			PeriodEnd:    periodStart.Add(period),
+			RequestQuota: usage.tenant.Limits.RequestQuota,
+			ComputeQuota: usage.tenant.Limits.ComputeQuota,
+		}
+		// Keys idle since an earlier period have used nothing in the current one
+		if usage.periodStart.Equal(periodStart) {
+			client.Requests = usage.requests
+			client.ComputeTime = usage.computeTime
+		}
+		clients = append(clients, client)
+	}
+
+	slices.SortFunc(clients, func(a, b *entity.ClientUsage) int {
+		if c := strings.Compare(a.TenantID, b.TenantID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.KeyID, b.KeyID)
+	})
+	return clients, nil
+}
+
+// keyUsage returns the usage of apiKey in the quota period of t that includes now,
+// starting a new one when the previous period has ended. The caller must hold d.mu.
+func (d *tenantDomain) keyUsage(t *tenant.Tenant, apiKey string, now time.Time) *keyUsage {
+	digest := sha256.Sum256([]byte(apiKey))
+	periodStart := now.Truncate(quotaPeriod(t))
+
+	usage, ok := d.usage[digest]
+	if !ok {
+		usage = &keyUsage{keyID: hex.EncodeToString(digest[:keyIDBytes])}
+		d.usage[digest] = usage
+	}
+	if !usage.periodStart.Equal(periodStart) {
+		usage.periodStart = periodStart
+		usage.requests = 0
+		usage.computeTime = 0
+	}
+	usage.tenant = t
+	return usage
+}
+
+// quotaPeriod returns the period the quotas of t are counted over.
+func quotaPeriod(t *tenant.Tenant) time.Duration {
+	if t.Limits.QuotaPeriod > 0 {
+		return t.Limits.QuotaPeriod
+	}
+	return defaultQuotaPeriod
+}
+
+// mostRestrictive returns the allowance with the fewest remaining requests, preferring the one
+// that resets last, or nil without allowances.
+func mostRestrictive(allowances []*entity.RateLimit) *entity.RateLimit {
+	var most *entity.RateLimit
+	for _, allowance := range allowances {
+		allowance.Remaining = max(allowance.Remaining, 0)
+		if most == nil || allowance.Remaining < most.Remaining ||
+			(allowance.Remaining == most.Remaining && allowance.Reset.After(most.Reset)) {
+			most = allowance
+		}
+	}
+	return most
+}
+
+// loadFile registers the tenants declared in a JSON configuration file.
+func (d *tenantDomain) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read tenants file: %w", err)
+	}
+
+	var entries []tenantFileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("%w: invalid tenants file %s: %v", apperrors.ErrInvalidInput, path, err)
+	}
+
+	ids := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		if err := d.addTenant(entry, ids); err != nil {
+			return fmt.Errorf("tenant %d in %s: %w", i+1, path, err)
+		}
+	}
+
+	slog.Info("tenants loaded",
+		"file", path,
+		"count", len(entries))
+	return nil
+}
+
+// addTenant registers a tenant and its API keys, rejecting duplicate IDs and keys.
+// ids holds the IDs registered so far.
+func (d *tenantDomain) addTenant(entry tenantFileEntry, ids map[string]bool) error {
+	if !tenant.ValidID(entry.ID) {
+		return fmt.Errorf("%w: invalid tenant ID %q: use lowercase letters, digits, '-' and '_'",
+			apperrors.ErrInvalidInput, entry.ID)
+	}
+	if ids[entry.ID] {
+		return fmt.Errorf("%w: duplicate tenant ID %q", apperrors.ErrInvalidInput, entry.ID)
+	}
+	if len(entry.APIKeys) == 0 {
+		return fmt.Errorf("%w: tenant %q has no API keys", apperrors.ErrInvalidInput, entry.ID)
+	}
+	limits, err := entry.Limits.limits()
+	if err != nil {
+		return fmt.Errorf("tenant %q: %w", entry.ID, err)
+	}
+	class, err := qos.Parse(entry.QoSClass)
+	if err != nil {
+		return fmt.Errorf("tenant %q: %w", entry.ID, err)
+	}
+	ids[entry.ID] = true
+
+	t := &tenant.Tenant{ID: entry.ID, Limits: limits, QoS: class}
+	d.byID[entry.ID] = t
+	for _, key := range entry.APIKeys {
+		if key == "" {
+			return fmt.Errorf("%w: tenant %q has an empty API key", apperrors.ErrInvalidInput, entry.ID)
+		}
+
+		digest := sha256.Sum256([]byte(key))
+		if _, ok := d.tenants[digest]; ok {
+			return fmt.Errorf("%w: API key of tenant %q is already in use", apperrors.ErrInvalidInput, entry.ID)
+		}
+		d.tenants[digest] = t
+	}
+	return nil
+}
+
+// limits converts the limits declared for a tenant, rejecting negative values and invalid quota periods.
+func (e tenantLimitsEntry) limits() (tenant.Limits, error) {
+	for _, limit := range []struct {
+		name  string
+		value int64
+	}{
+		{name: "requests_per_minute", value: int64(e.RequestsPerMinute)},
+		{name: "max_file_size", value: e.MaxFileSize},
+		{name: "max_rows", value: int64(e.MaxRows)},
+		{name: "max_cols", value: int64(e.MaxCols)},
+		{name: "max_storage_bytes", value: e.MaxStorageBytes},
+		{name: "request_quota", value: int64(e.RequestQuota)},
+		{name: "compute_quota_seconds", value: e.ComputeQuotaSeconds},
+	} {
+		if limit.value < 0 {
+			return tenant.Limits{}, fmt.Errorf("%w: limit %s cannot be negative", apperrors.ErrInvalidInput, limit.name)
+		}
+	}
+
+	var quotaPeriod time.Duration
+	if e.QuotaPeriod != "" {
+		var err error
+		quotaPeriod, err = time.ParseDuration(e.QuotaPeriod)
+		if err != nil || quotaPeriod <= 0 {
+			return tenant.Limits{}, fmt.Errorf("%w: invalid quota_period %q: expected a positive duration such as 24h",
+				apperrors.ErrInvalidInput, e.QuotaPeriod)
+		}
+	}
+
+	return tenant.Limits{
+		RequestsPerMinute: e.RequestsPerMinute,
+		MaxFileSize:       e.MaxFileSize,
+		MaxRows:           e.MaxRows,
+		MaxCols:           e.MaxCols,
+		MaxStorageBytes:   e.MaxStorageBytes,
+		RequestQuota:      e.RequestQuota,
+		ComputeQuota:      time.Duration(e.ComputeQuotaSeconds) * time.Second,
+		QuotaPeriod:       quotaPeriod,
+	}, nil
+}
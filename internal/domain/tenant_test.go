@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// writeTenantsFile writes a tenants configuration file and returns its path.
+func writeTenantsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNewTenantDomain(t *testing.T) {
+	t.Run("disabled without a tenants file", func(t *testing.T) {
+		domain, err := NewTenantDomain("")
+
+		assert.NoError(t, err)
+		assert.False(t, domain.Enabled())
+	})
+
+	t.Run("loads every tenant", func(t *testing.T) {
+		domain, err := NewTenantDomain(writeTenantsFile(t, `[
+			{"id": "acme", "api_keys": ["acme-key", "acme-key-2"]},
+			{"id": "globex", "api_keys": ["globex-key"]}
+		]`))
+
+		assert.NoError(t, err)
+		assert.True(t, domain.Enabled())
+	})
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "malformed file", content: `{"id":`},
+		{name: "invalid tenant ID", content: `[{"id": "../acme", "api_keys": ["key"]}]`},
+		{name: "duplicate tenant ID", content: `[{"id": "acme", "api_keys": ["a"]}, {"id": "acme", "api_keys": ["b"]}]`},
+		{name: "tenant without API keys", content: `[{"id": "acme", "api_keys": []}]`},
+		{name: "empty API key", content: `[{"id": "acme", "api_keys": [""]}]`},
+		{name: "API key shared by tenants", content: `[{"id": "acme", "api_keys": ["key"]}, {"id": "globex", "api_keys": ["key"]}]`},
+		{name: "negative limit", content: `[{"id": "acme", "api_keys": ["key"], "limits": {"max_rows": -1}}]`},
+		{name: "negative quota", content: `[{"id": "acme", "api_keys": ["key"], "limits": {"request_quota": -1}}]`},
+		{name: "invalid quota period", content: `[{"id": "acme", "api_keys": ["key"], "limits": {"quota_period": "daily"}}]`},
+		{name: "invalid class of service", content: `[{"id": "acme", "api_keys": ["key"], "qos_class": "realtime"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain, err := NewTenantDomain(writeTenantsFile(t, tt.content))
+
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+			assert.Nil(t, domain)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		domain, err := NewTenantDomain(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Nil(t, domain)
+	})
+}
+
+func TestTenantDomain_Authenticate(t *testing.T) {
+	domain, err := NewTenantDomain(writeTenantsFile(t, `[
+		{"id": "acme", "api_keys": ["acme-key", "acme-key-2"]},
+		{"id": "globex", "api_keys": ["globex-key"]}
+	]`))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		apiKey  string
+		wantID  string
+		errType error
+	}{
+		{name: "first key", apiKey: "acme-key", wantID: "acme"},
+		{name: "second key", apiKey: "acme-key-2", wantID: "acme"},
+		{name: "another tenant", apiKey: "globex-key", wantID: "globex"},
+		{name: "missing key", apiKey: "", errType: apperrors.ErrUnauthorized},
+		{name: "unknown key", apiKey: "initech-key", errType: apperrors.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.Authenticate(context.Background(), tt.apiKey)
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantID, got.ID)
+			}
+		})
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		got, err := domain.Authenticate(ctx, "acme-key")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTenantDomain_GetTenant(t *testing.T) {
+	domain, err := NewTenantDomain(writeTenantsFile(t, `[{"id": "acme", "api_keys": ["acme-key"], "limits": {
+		"requests_per_minute": 60, "max_file_size": 512, "max_rows": 5, "max_cols": 4, "max_storage_bytes": 4096,
+		"request_quota": 1000, "compute_quota_seconds": 3600, "quota_period": "168h"
+	}, "qos_class": "batch"}]`))
+	assert.NoError(t, err)
+
+	got, err := domain.GetTenant(context.Background(), "acme")
+	assert.NoError(t, err)
+	assert.Equal(t, &tenant.Tenant{ID: "acme", Limits: tenant.Limits{
+		RequestsPerMinute: 60,
+		MaxFileSize:       512,
+		MaxRows:           5,
+		MaxCols:           4,
+		MaxStorageBytes:   4096,
+		RequestQuota:      1000,
+		ComputeQuota:      time.Hour,
+		QuotaPeriod:       7 * 24 * time.Hour,
+	}, QoS: qos.Batch}, got)
+
+	// Authenticated requests carry the same tenant, limits included
+	authenticated, err := domain.Authenticate(context.Background(), "acme-key")
+	assert.NoError(t, err)
+	assert.Same(t, got, authenticated)
+
+	got, err = domain.GetTenant(context.Background(), "globex")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Nil(t, got)
+}
+
+func TestTenantDomain_AllowRequest(t *testing.T) {
+	domain, err := NewTenantDomain("")
+	assert.NoError(t, err)
+	d := domain.(*tenantDomain)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	acme := &tenant.Tenant{ID: "acme", Limits: tenant.Limits{RequestsPerMinute: 2}}
+	globex := &tenant.Tenant{ID: "globex", Limits: tenant.Limits{RequestsPerMinute: 2}}
+
+	rateLimit, err := d.AllowRequest(context.Background(), acme, "acme-key")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.RateLimit{Limit: 2, Remaining: 1, Reset: now.Add(time.Minute)}, rateLimit)
+	_, err = d.AllowRequest(context.Background(), acme, "acme-key-2")
+	assert.NoError(t, err)
+	rateLimit, err = d.AllowRequest(context.Background(), acme, "acme-key")
+	assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+	assert.Equal(t, &entity.RateLimit{Limit: 2, Remaining: 0, Reset: now.Add(time.Minute)}, rateLimit)
+
+	// Each tenant has its own count
+	_, err = d.AllowRequest(context.Background(), globex, "globex-key")
+	assert.NoError(t, err)
+
+	// A new window starts a minute after the first request of the previous one
+	now = now.Add(59 * time.Second)
+	_, err = d.AllowRequest(context.Background(), acme, "acme-key")
+	assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+	now = now.Add(time.Second)
+	_, err = d.AllowRequest(context.Background(), acme, "acme-key")
+	assert.NoError(t, err)
+
+	// Tenants without a limit are never rejected, and have no allowance to report
+	unlimited := &tenant.Tenant{ID: "initech"}
+	for range 100 {
+		rateLimit, err = d.AllowRequest(context.Background(), unlimited, "initech-key")
+		assert.NoError(t, err)
+		assert.Nil(t, rateLimit)
+	}
+}
+
+func TestTenantDomain_Quotas(t *testing.T) {
+	newDomain := func(t *testing.T) (*tenantDomain, *time.Time) {
+		domain, err := NewTenantDomain("")
+		assert.NoError(t, err)
+		d := domain.(*tenantDomain)
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		d.now = func() time.Time { return now }
+		return d, &now
+	}
+	midnight := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("request quota is counted per API key until the period ends", func(t *testing.T) {
+		d, now := newDomain(t)
+		acme := &tenant.Tenant{ID: "acme", Limits: tenant.Limits{RequestQuota: 2}}
+
+		rateLimit, err := d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.RateLimit{Limit: 2, Remaining: 1, Reset: midnight}, rateLimit)
+		_, err = d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.NoError(t, err)
+		_, err = d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+
+		// Another key of the same tenant has its own quota
+		_, err = d.AllowRequest(context.Background(), acme, "acme-key-2")
+		assert.NoError(t, err)
+
+		// Daily quotas reset at midnight UTC
+		*now = midnight
+		rateLimit, err = d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.RateLimit{Limit: 2, Remaining: 1, Reset: midnight.Add(24 * time.Hour)}, rateLimit)
+	})
+
+	t.Run("the allowance closest to running out is reported", func(t *testing.T) {
+		d, _ := newDomain(t)
+		acme := &tenant.Tenant{ID: "acme", Limits: tenant.Limits{RequestsPerMinute: 10, RequestQuota: 3, QuotaPeriod: time.Hour}}
+
+		rateLimit, err := d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, rateLimit.Limit)
+		assert.Equal(t, 2, rateLimit.Remaining)
+		assert.Equal(t, time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC), rateLimit.Reset)
+	})
+
+	t.Run("compute quota rejects requests once used up", func(t *testing.T) {
+		d, now := newDomain(t)
+		acme := &tenant.Tenant{ID: "acme", Limits: tenant.Limits{ComputeQuota: time.Second}}
+
+		_, err := d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.NoError(t, err)
+		assert.NoError(t, d.RecordUsage(context.Background(), acme, "acme-key", 600*time.Millisecond))
+		_, err = d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.NoError(t, err)
+		assert.NoError(t, d.RecordUsage(context.Background(), acme, "acme-key", 600*time.Millisecond))
+
+		rateLimit, err := d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+		assert.Equal(t, &entity.RateLimit{Limit: 2, Remaining: 0, Reset: midnight}, rateLimit)
+
+		*now = midnight
+		_, err = d.AllowRequest(context.Background(), acme, "acme-key")
+		assert.NoError(t, err)
+	})
+}
+
+func TestTenantDomain_ListUsage(t *testing.T) {
+	domain, err := NewTenantDomain("")
+	assert.NoError(t, err)
+	d := domain.(*tenantDomain)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	acme := &tenant.Tenant{ID: "acme", Limits: tenant.Limits{RequestQuota: 100, ComputeQuota: time.Minute}}
+	globex := &tenant.Tenant{ID: "globex"}
+	for _, request := range []struct {
+		tenant *tenant.Tenant
+		key    string
+	}{
+		{tenant: globex, key: "globex-key"},
+		{tenant: acme, key: "acme-key"},
+		{tenant: acme, key: "acme-key"},
+	} {
+		_, err := d.AllowRequest(context.Background(), request.tenant, request.key)
+		assert.NoError(t, err)
+		assert.NoError(t, d.RecordUsage(context.Background(), request.tenant, request.key, time.Second))
+	}
+
+	got, err := d.ListUsage(context.Background())
+	assert.NoError(t, err)
+	acmeDigest := sha256.Sum256([]byte("acme-key"))
+	assert.Equal(t, []*entity.ClientUsage{
+		{
+			TenantID:     "acme",
+			KeyID:        hex.EncodeToString(acmeDigest[:8]),
+			PeriodStart:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:    time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			Requests:     2,
+			RequestQuota: 100,
+			ComputeTime:  2 * time.Second,
+			ComputeQuota: time.Minute,
+		},
+	}, got[:1])
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "globex", got[1].TenantID)
+		assert.Equal(t, 1, got[1].Requests)
+	}
+
+	// Keys idle since an earlier period have used nothing in the current one
+	now = now.Add(24 * time.Hour)
+	got, err = d.ListUsage(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, got[0].Requests)
+	assert.Zero(t, got[0].ComputeTime)
+}
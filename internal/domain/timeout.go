@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// TimeoutConfig bounds how long the server works on a request before answering 504 Gateway Timeout.
+// Request bounds the cheap routes, such as listings, lookups and the health check, so they stay responsive,
+// and Operation the routes running matrix operations, which may take longer on large matrices.
+// Both stay below the write timeout of the server, so the 504 still reaches the client.
+type TimeoutConfig struct {
+	Request   time.Duration
+	Operation time.Duration
+}
@@ -0,0 +1,384 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// uploadIdleTimeout is how long an upload is kept after its last request. Incomplete uploads idle for longer
+	// are abandoned and their data deleted; completed ones are only forgotten, their matrix file is kept.
+	uploadIdleTimeout = 24 * time.Hour
+
+	// uploadSweepInterval is how often idle uploads are looked for.
+	uploadSweepInterval = 10 * time.Minute
+
+	// maxOpenUploads bounds the incomplete uploads a tenant may have at once.
+	maxOpenUploads = 10
+)
+
+// UploadDomainInterface defines the business logic contract for resumable, offset-based matrix uploads.
+// Clients create an upload announcing its total size, then send chunks at the offset reported by the server,
+// so an interrupted transfer can resume from the last received byte instead of starting over.
+type UploadDomainInterface interface {
+	// CreateUpload starts a new upload of length bytes and returns its initial state.
+	// The matrix file it completes into expires after ttl, or after the default time to live when ttl is zero,
+	// and carries tags, which are validated like those set on stored files.
+	CreateUpload(ctx context.Context, length int64, ttl time.Duration, tags map[string]string) (*entity.Upload, error)
+
+	// GetUpload returns the current state of an upload so clients can resume from its offset.
+	GetUpload(ctx context.Context, id string) (*entity.Upload, error)
+
+	// AppendChunk stores a chunk at the given offset, which must match the bytes received so far.
+	// When the last chunk arrives, the assembled file is validated as a matrix and, if valid,
+	// made available as a matrix file; invalid uploads are discarded.
+	AppendChunk(ctx context.Context, id string, offset int64, chunk io.Reader) (*entity.Upload, error)
+
+	// Sweep forgets the uploads without a request for 24 hours, deleting the data of the incomplete ones.
+	// It runs every 10 minutes in the background, whichever tenant the uploads belong to.
+	Sweep(ctx context.Context) error
+
+	// Stop stops the background sweep and waits for a sweep in progress to finish.
+	Stop(ctx context.Context) error
+}
+
+// uploadSession tracks an in-progress upload. Its mutex serializes chunks of the same upload.
+// tenantID identifies the tenant that created the upload, which is the only one allowed to continue it.
+// ttl is the time to live of the completed matrix file, and tags the tags it carries.
+// lastActive is the time of its latest request, guarded by mu; complete is guarded by the mutex of the domain,
+// so open uploads can be counted without waiting for the chunks in progress.
+type uploadSession struct {
+	mu         sync.Mutex
+	upload     entity.Upload
+	tenantID   string
+	ttl        time.Duration
+	tags       map[string]string
+	lastActive time.Time
+	complete   bool
+}
+
+type uploadDomain struct {
+	uploadRepository repository.UploadRepositoryInterface
+	matrixRepository repository.MatrixRepositoryInterface
+	tagRepository    repository.TagRepositoryInterface
+	validatorDomain  MatrixValidatorDomainInterface
+	retentionDomain  RetentionDomainInterface
+
+	// now returns the current time; tests replace it to expire uploads
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*uploadSession
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	loop     sync.WaitGroup
+}
+
+// NewUploadDomain creates a new instance of UploadDomainInterface with all required dependencies.
+// It initializes the domain service with upload storage, matrix reading, tagging, and validation components,
+// and the retention domain completed uploads expire with. It starts the sweep of idle uploads, which runs
+// until Stop is called.
+func NewUploadDomain(retentionDomain RetentionDomainInterface) UploadDomainInterface {
+	d := &uploadDomain{
+		uploadRepository: repository.NewUploadRepository(),
+		matrixRepository: repository.NewMatrixRepository(),
+		tagRepository:    repository.NewTagRepository(),
+		validatorDomain:  NewMatrixValidatorDomain(),
+		retentionDomain:  retentionDomain,
+		now:              time.Now,
+		sessions:         make(map[string]*uploadSession),
+		stop:             make(chan struct{}),
+	}
+	d.loop.Add(1)
+	go d.run()
+	return d
+}
+
+func (d *uploadDomain) CreateUpload(ctx context.Context, length int64, ttl time.Duration, tags map[string]string) (*entity.Upload, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if length <= 0 {
+		return nil, fmt.Errorf("%w: upload length must be greater than zero", apperrors.ErrInvalidInput)
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("%w: ttl must not be negative", apperrors.ErrInvalidInput)
+	}
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+
+	id, err := newID("upload")
+	if err != nil {
+		return nil, err
+	}
+
+	session := &uploadSession{
+		upload:     entity.Upload{ID: id, Length: length},
+		tenantID:   tenant.ID(ctx),
+		ttl:        ttl,
+		tags:       tags,
+		lastActive: d.now(),
+	}
+
+	// The session is registered before its data is created, so concurrent uploads cannot exceed the limit
+	d.mu.Lock()
+	if open := d.openUploads(session.tenantID); open >= maxOpenUploads {
+		d.mu.Unlock()
+		return nil, fmt.Errorf("%w: too many open uploads: %d (maximum: %d)", apperrors.ErrTooManyRequests, open, maxOpenUploads)
+	}
+	d.sessions[id] = session
+	d.mu.Unlock()
+
+	err = d.uploadRepository.CreateUpload(ctx, id, length)
+	if err != nil {
+		d.mu.Lock()
+		delete(d.sessions, id)
+		d.mu.Unlock()
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "upload created",
+		"upload_id", id,
+		"length", length)
+
+	upload := session.upload
+	return &upload, nil
+}
+
+func (d *uploadDomain) GetUpload(ctx context.Context, id string) (*entity.Upload, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	session, err := d.session(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	// Clients look up the offset to resume from, so the upload is still in use
+	session.lastActive = d.now()
+	upload := session.upload
+	return &upload, nil
+}
+
+func (d *uploadDomain) AppendChunk(ctx context.Context, id string, offset int64, chunk io.Reader) (*entity.Upload, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	session, err := d.session(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	session.lastActive = d.now()
+	if session.upload.Complete() {
+		return nil, fmt.Errorf("%w: upload %s is already complete", apperrors.ErrConflict, id)
+	}
+	if offset != session.upload.Offset {
+		return nil, fmt.Errorf("%w: upload offset mismatch: got %d, expected %d",
+			apperrors.ErrConflict, offset, session.upload.Offset)
+	}
+
+	written, err := d.uploadRepository.WriteChunk(ctx, id, offset, session.upload.Length, chunk)
+	if err != nil {
+		return nil, err
+	}
+	session.upload.Offset += written
+
+	if session.upload.Offset == session.upload.Length {
+		completed, err := d.completeUpload(ctx, id, session.ttl, session.tags)
+		if err != nil {
+			d.discard(ctx, id)
+			return nil, err
+		}
+		session.upload.FilePath = completed.FilePath
+		session.upload.ContentHash = completed.ContentHash
+		session.upload.Deduplicated = completed.Deduplicated
+
+		d.mu.Lock()
+		session.complete = true
+		d.mu.Unlock()
+
+		slog.InfoContext(ctx, "upload completed",
+			"upload_id", id,
+			"file_path", completed.FilePath,
+			"content_hash", completed.ContentHash,
+			"deduplicated", completed.Deduplicated)
+	}
+
+	upload := session.upload
+	return &upload, nil
+}
+
+// completeUpload moves the assembled upload into place, validates it as a matrix file and sets its time to live
+// and tags.
+func (d *uploadDomain) completeUpload(ctx context.Context, id string, ttl time.Duration, tags map[string]string) (*repository.CompletedUpload, error) {
+	completed, err := d.uploadRepository.CompleteUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &entity.Matrix[int64]{}
+	err = d.matrixRepository.StreamFileContent(ctx, completed.FilePath, func(row []string) error {
+		return d.validatorDomain.ValidateRow(ctx, matrix, row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.validatorDomain.ValidateMatrix(ctx, matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.retentionDomain.Retain(ctx, completed.FilePath, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) > 0 {
+		err = d.tagRepository.SetTags(ctx, completed.FilePath, tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return completed, nil
+}
+
+// discard forgets an upload and removes its stored data after a failed completion.
+func (d *uploadDomain) discard(ctx context.Context, id string) {
+	d.mu.Lock()
+	delete(d.sessions, id)
+	d.mu.Unlock()
+
+	// Detach from cancellation so cleanup still happens when the request was cancelled,
+	// while keeping the tenant that locates the upload
+	if err := d.uploadRepository.DeleteUpload(context.WithoutCancel(ctx), id); err != nil {
+		slog.ErrorContext(ctx, "failed to delete discarded upload",
+			"upload_id", id,
+			"error", err)
+	}
+}
+
+// session returns the upload registered under id, reporting uploads of other tenants as unknown.
+func (d *uploadDomain) session(ctx context.Context, id string) (*uploadSession, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	session, ok := d.sessions[id]
+	if !ok || session.tenantID != tenant.ID(ctx) {
+		return nil, fmt.Errorf("%w: upload not found: %s", apperrors.ErrNotFound, id)
+	}
+	return session, nil
+}
+
+func (d *uploadDomain) Sweep(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	deadline := d.now().Add(-uploadIdleTimeout)
+	var abandoned []*uploadSession
+	d.mu.Lock()
+	for id, session := range d.sessions {
+		// An upload receiving a chunk is in use, whenever its previous request was
+		if !session.mu.TryLock() {
+			continue
+		}
+		idle := session.lastActive.Before(deadline)
+		session.mu.Unlock()
+		if !idle {
+			continue
+		}
+
+		delete(d.sessions, id)
+		if !session.complete {
+			abandoned = append(abandoned, session)
+		}
+	}
+	d.mu.Unlock()
+
+	var errs []error
+	for _, session := range abandoned {
+		id := session.upload.ID
+		if err := d.uploadRepository.DeleteUpload(tenant.WithID(ctx, session.tenantID), id); err != nil {
+			errs = append(errs, fmt.Errorf("upload %s: %w", id, err))
+			continue
+		}
+		slog.InfoContext(ctx, "abandoned upload deleted",
+			"upload_id", id,
+			"tenant", session.tenantID)
+	}
+	return errors.Join(errs...)
+}
+
+func (d *uploadDomain) Stop(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		d.loop.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("upload sweep still running: %w", ctx.Err())
+	}
+}
+
+// run sweeps idle uploads at each sweep interval until the domain is stopped.
+func (d *uploadDomain) run() {
+	defer d.loop.Done()
+
+	ticker := time.NewTicker(uploadSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			if err := d.Sweep(context.Background()); err != nil {
+				slog.Error("upload sweep failed", "error", err)
+			}
+		}
+	}
+}
+
+// openUploads counts the incomplete uploads of a tenant. The caller holds the mutex of the domain.
+func (d *uploadDomain) openUploads(tenantID string) int {
+	open := 0
+	for _, session := range d.sessions {
+		if session.tenantID == tenantID && !session.complete {
+			open++
+		}
+	}
+	return open
+}
@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func newTestUploadDomain(t *testing.T) (*uploadDomain, *mocks.MockUploadRepositoryInterface, *mocks.MockMatrixRepositoryInterface, *mocks.MockMatrixValidatorDomainInterface) {
+	mockUploads := mocks.NewMockUploadRepositoryInterface(t)
+	mockRepo := mocks.NewMockMatrixRepositoryInterface(t)
+	mockValidator := mocks.NewMockMatrixValidatorDomainInterface(t)
+	mockRetention := mocks.NewMockRetentionDomainInterface(t)
+	mockRetention.On("Retain", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return &uploadDomain{
+		uploadRepository: mockUploads,
+		matrixRepository: mockRepo,
+		tagRepository:    mocks.NewMockTagRepositoryInterface(t),
+		validatorDomain:  mockValidator,
+		retentionDomain:  mockRetention,
+		now:              time.Now,
+		sessions:         make(map[string]*uploadSession),
+		stop:             make(chan struct{}),
+	}, mockUploads, mockRepo, mockValidator
+}
+
+func TestUploadDomain_CreateUpload(t *testing.T) {
+	t.Run("creates upload at offset zero", func(t *testing.T) {
+		domain, mockUploads, _, _ := newTestUploadDomain(t)
+		mockUploads.On("CreateUpload", mock.Anything, mock.AnythingOfType("string"), int64(12)).Return(nil)
+
+		got, err := domain.CreateUpload(context.Background(), 12, 0, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, got.ID, 32)
+		assert.Equal(t, int64(12), got.Length)
+		assert.Equal(t, int64(0), got.Offset)
+		assert.False(t, got.Complete())
+	})
+
+	t.Run("rejects empty upload", func(t *testing.T) {
+		domain, _, _, _ := newTestUploadDomain(t)
+
+		got, err := domain.CreateUpload(context.Background(), 0, 0, nil)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+
+	t.Run("rejects negative ttl", func(t *testing.T) {
+		domain, _, _, _ := newTestUploadDomain(t)
+
+		got, err := domain.CreateUpload(context.Background(), 12, -time.Hour, nil)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+
+	t.Run("rejects invalid tags", func(t *testing.T) {
+		domain, _, _, _ := newTestUploadDomain(t)
+
+		got, err := domain.CreateUpload(context.Background(), 12, 0, map[string]string{"Season": "2026"})
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+
+	t.Run("propagates repository size limit", func(t *testing.T) {
+		domain, mockUploads, _, _ := newTestUploadDomain(t)
+		mockUploads.On("CreateUpload", mock.Anything, mock.AnythingOfType("string"), int64(4096)).
+			Return(apperrors.ErrPayloadTooLarge)
+
+		got, err := domain.CreateUpload(context.Background(), 4096, 0, nil)
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+		assert.Nil(t, got)
+		assert.Empty(t, domain.sessions)
+	})
+
+	t.Run("rejects uploads beyond the open uploads of the tenant", func(t *testing.T) {
+		domain, mockUploads, _, _ := newTestUploadDomain(t)
+		for i := range maxOpenUploads {
+			domain.sessions[fmt.Sprint(i)] = &uploadSession{tenantID: "acme"}
+		}
+		// Completed uploads and the uploads of other tenants do not count
+		domain.sessions["done"] = &uploadSession{tenantID: "globex", complete: true}
+		mockUploads.On("CreateUpload", mock.Anything, mock.AnythingOfType("string"), int64(12)).Return(nil)
+
+		got, err := domain.CreateUpload(tenant.WithID(context.Background(), "acme"), 12, 0, nil)
+		assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+		assert.Nil(t, got)
+
+		_, err = domain.CreateUpload(tenant.WithID(context.Background(), "globex"), 12, 0, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUploadDomain_GetUpload(t *testing.T) {
+	domain, _, _, _ := newTestUploadDomain(t)
+	domain.sessions["abc"] = &uploadSession{upload: entity.Upload{ID: "abc", Length: 12, Offset: 6}}
+
+	got, err := domain.GetUpload(context.Background(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Upload{ID: "abc", Length: 12, Offset: 6}, got)
+
+	got, err = domain.GetUpload(context.Background(), "missing")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Nil(t, got)
+
+	// Uploads of another tenant are reported as missing
+	domain.sessions["def"] = &uploadSession{upload: entity.Upload{ID: "def", Length: 12}, tenantID: "acme"}
+	got, err = domain.GetUpload(tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex"}), "def")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Nil(t, got)
+	_, err = domain.GetUpload(tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"}), "def")
+	assert.NoError(t, err)
+}
+
+func TestUploadDomain_AppendChunk(t *testing.T) {
+	t.Run("partial chunk advances the offset", func(t *testing.T) {
+		domain, mockUploads, _, _ := newTestUploadDomain(t)
+		domain.sessions["abc"] = &uploadSession{upload: entity.Upload{ID: "abc", Length: 12}}
+		mockUploads.On("WriteChunk", mock.Anything, "abc", int64(0), int64(12), mock.Anything).Return(int64(6), nil)
+
+		got, err := domain.AppendChunk(context.Background(), "abc", 0, strings.NewReader("1,2,3\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), got.Offset)
+		assert.False(t, got.Complete())
+	})
+
+	t.Run("offset mismatch is a conflict", func(t *testing.T) {
+		domain, _, _, _ := newTestUploadDomain(t)
+		domain.sessions["abc"] = &uploadSession{upload: entity.Upload{ID: "abc", Length: 12, Offset: 6}}
+
+		got, err := domain.AppendChunk(context.Background(), "abc", 0, strings.NewReader("1,2,3\n"))
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Nil(t, got)
+	})
+
+	t.Run("completed upload is a conflict", func(t *testing.T) {
+		domain, _, _, _ := newTestUploadDomain(t)
+		domain.sessions["abc"] = &uploadSession{upload: entity.Upload{ID: "abc", Length: 6, Offset: 6, FilePath: "testdata/uploads/abc.csv"}}
+
+		_, err := domain.AppendChunk(context.Background(), "abc", 6, strings.NewReader(""))
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+	})
+
+	t.Run("unknown upload", func(t *testing.T) {
+		domain, _, _, _ := newTestUploadDomain(t)
+
+		_, err := domain.AppendChunk(context.Background(), "missing", 0, strings.NewReader("1"))
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("last chunk completes and validates the matrix", func(t *testing.T) {
+		domain, mockUploads, mockRepo, mockValidator := newTestUploadDomain(t)
+		domain.sessions["abc"] = &uploadSession{
+			upload: entity.Upload{ID: "abc", Length: 12, Offset: 6},
+			ttl:    time.Hour,
+			tags:   map[string]string{"season": "2026"},
+		}
+		mockTags := mocks.NewMockTagRepositoryInterface(t)
+		mockTags.On("SetTags", mock.Anything, "testdata/uploads/abc.csv", map[string]string{"season": "2026"}).Return(nil)
+		domain.tagRepository = mockTags
+		mockUploads.On("WriteChunk", mock.Anything, "abc", int64(6), int64(12), mock.Anything).Return(int64(6), nil)
+		mockUploads.On("CompleteUpload", mock.Anything, "abc").
+			Return(&repository.CompletedUpload{FilePath: "testdata/uploads/abc.csv", ContentHash: "c0ffee", Deduplicated: true}, nil)
+		mockRepo.EXPECT().StreamFileContent(mock.Anything, "testdata/uploads/abc.csv", mock.Anything).
+			RunAndReturn(streamRows(&repository.MatrixFileContent{Content: [][]string{{"1", "2", "3"}, {"4", "5", "6"}}}, nil))
+		mockValidator.EXPECT().ValidateRow(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(appendRows(&entity.Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}))
+		mockValidator.On("ValidateMatrix", mock.Anything, mock.Anything).Return(nil)
+
+		got, err := domain.AppendChunk(context.Background(), "abc", 6, strings.NewReader("4,5,6\n"))
+
+		assert.NoError(t, err)
+		assert.True(t, got.Complete())
+		assert.Equal(t, "testdata/uploads/abc.csv", got.FilePath)
+		assert.Equal(t, "c0ffee", got.ContentHash)
+		assert.True(t, got.Deduplicated)
+		// The completed file expires after the ttl given when the upload was created, and carries its tags
+		domain.retentionDomain.(*mocks.MockRetentionDomainInterface).
+			AssertCalled(t, "Retain", mock.Anything, "testdata/uploads/abc.csv", time.Hour)
+	})
+
+	t.Run("invalid matrix is discarded on completion", func(t *testing.T) {
+		domain, mockUploads, mockRepo, mockValidator := newTestUploadDomain(t)
+		domain.sessions["abc"] = &uploadSession{upload: entity.Upload{ID: "abc", Length: 4}}
+		mockUploads.On("WriteChunk", mock.Anything, "abc", int64(0), int64(4), mock.Anything).Return(int64(4), nil)
+		mockUploads.On("CompleteUpload", mock.Anything, "abc").
+			Return(&repository.CompletedUpload{FilePath: "testdata/uploads/abc.csv", ContentHash: "c0ffee"}, nil)
+		mockUploads.On("DeleteUpload", mock.Anything, "abc").Return(nil)
+		mockRepo.EXPECT().StreamFileContent(mock.Anything, "testdata/uploads/abc.csv", mock.Anything).
+			RunAndReturn(streamRows(&repository.MatrixFileContent{Content: [][]string{{"a", "b"}}}, nil))
+		mockValidator.EXPECT().ValidateRow(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(appendRows(nil))
+
+		got, err := domain.AppendChunk(context.Background(), "abc", 0, io.LimitReader(strings.NewReader("a,b\n"), 4))
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, got)
+		assert.NotContains(t, domain.sessions, "abc")
+	})
+}
+
+func TestUploadDomain_Sweep(t *testing.T) {
+	domain, mockUploads, _, _ := newTestUploadDomain(t)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	domain.now = func() time.Time { return now }
+	idle := now.Add(-uploadIdleTimeout - time.Minute)
+	domain.sessions["abandoned"] = &uploadSession{upload: entity.Upload{ID: "abandoned", Length: 12}, tenantID: "acme", lastActive: idle}
+	domain.sessions["completed"] = &uploadSession{upload: entity.Upload{ID: "completed", Length: 6, Offset: 6}, lastActive: idle, complete: true}
+	domain.sessions["active"] = &uploadSession{upload: entity.Upload{ID: "active", Length: 12}, lastActive: now.Add(-time.Hour)}
+	busy := &uploadSession{upload: entity.Upload{ID: "busy", Length: 12}, lastActive: idle}
+	busy.mu.Lock()
+	defer busy.mu.Unlock()
+	domain.sessions["busy"] = busy
+
+	// The data of an abandoned upload is deleted from the directory of its tenant
+	mockUploads.On("DeleteUpload", mock.MatchedBy(func(ctx context.Context) bool {
+		return tenant.ID(ctx) == "acme"
+	}), "abandoned").Return(nil).Once()
+
+	err := domain.Sweep(context.Background())
+
+	assert.NoError(t, err)
+	assert.NotContains(t, domain.sessions, "abandoned")
+	assert.NotContains(t, domain.sessions, "completed")
+	assert.Contains(t, domain.sessions, "active")
+	assert.Contains(t, domain.sessions, "busy")
+}
+
+func TestUploadDomain_Stop(t *testing.T) {
+	domain := NewUploadDomain(mocks.NewMockRetentionDomainInterface(t))
+
+	assert.NoError(t, domain.Stop(context.Background()))
+	// Stopping twice is harmless
+	assert.NoError(t, domain.Stop(context.Background()))
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// VersionDomainInterface defines the business logic contract for the versions of stored matrix files.
+// Updating a stored matrix keeps the matrix it replaces, so pipelines can pin the exact input they read
+// with a reference such as testdata/league.csv@v3, wherever a file is read.
+type VersionDomainInterface interface {
+	// Update replaces the matrix stored at filePath with matrix, keeping the matrix it replaces as a prior version,
+	// and returns the new current version. Updates are conditional, so two writers never silently overwrite each
+	// other: ifMatch holds the entity tags of the If-Match header, one of which must be the ETag of the current
+	// version, or *. It returns ErrPreconditionRequired without any, ErrPreconditionFailed when none matches,
+	// and ErrNotFound when no matrix is stored at filePath.
+	Update(ctx context.Context, filePath string, ifMatch []string, matrix *entity.Matrix[int64]) (*entity.MatrixVersion, error)
+
+	// ListVersions returns the versions of the matrix stored at filePath, the oldest first and the current one last.
+	ListVersions(ctx context.Context, filePath string) ([]*entity.MatrixVersion, error)
+}
+
+type versionDomain struct {
+	versionRepository repository.VersionRepositoryInterface
+	validatorDomain   MatrixValidatorDomainInterface
+}
+
+// NewVersionDomain creates a new instance of VersionDomainInterface with its dependencies.
+// It initializes the domain service with a repository keeping the prior versions of the stored matrix files
+// and a validator for the paths and matrices it is given.
+func NewVersionDomain() VersionDomainInterface {
+	return &versionDomain{
+		versionRepository: repository.NewVersionRepository(),
+		validatorDomain:   NewMatrixValidatorDomain(),
+	}
+}
+
+func (d *versionDomain) Update(ctx context.Context, filePath string, ifMatch []string, matrix *entity.Matrix[int64]) (*entity.MatrixVersion, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Only the current version can be updated, so the path is never pinned
+	if err := d.validatorDomain.ValidateFilePath(ctx, filePath); err != nil {
+		return nil, err
+	}
+	if len(ifMatch) == 0 {
+		return nil, fmt.Errorf("%w: If-Match header with the ETag of the current version is required",
+			apperrors.ErrPreconditionRequired)
+	}
+	if matrix == nil {
+		return nil, fmt.Errorf("%w: no matrix to store", apperrors.ErrUnprocessableEntity)
+	}
+	if err := d.validatorDomain.ValidateMatrix(ctx, matrix); err != nil {
+		return nil, err
+	}
+
+	record, err := d.versionRepository.Update(ctx, filePath, ifMatch, resultContent(&entity.Result{Matrix: matrix}))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update matrix",
+			"file_path", filePath,
+			"error", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "matrix updated",
+		"file_path", filePath,
+		"version", record.Version)
+	return toMatrixVersion(filePath, record, true), nil
+}
+
+func (d *versionDomain) ListVersions(ctx context.Context, filePath string) ([]*entity.MatrixVersion, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validatorDomain.ValidateFilePath(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	records, err := d.versionRepository.ListVersions(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*entity.MatrixVersion, 0, len(records))
+	for i, record := range records {
+		versions = append(versions, toMatrixVersion(filePath, record, i == len(records)-1))
+	}
+	return versions, nil
+}
+
+// toMatrixVersion converts a version record of the matrix file at filePath to its entity.
+func toMatrixVersion(filePath string, record *repository.VersionRecord, current bool) *entity.MatrixVersion {
+	return &entity.MatrixVersion{
+		FilePath:  filePath,
+		Version:   record.Version,
+		Ref:       repository.VersionRef(filePath, record.Version),
+		ETag:      record.ETag,
+		Size:      record.Size,
+		CreatedAt: record.CreatedAt,
+		Current:   current,
+	}
+}
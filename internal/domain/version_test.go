@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestVersionDomain_Update(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		filePath    string
+		ifMatch     []string
+		matrix      *entity.Matrix[int64]
+		wantContent *repository.MatrixFileContent
+		repoErr     error
+		wantErr     error
+	}{
+		{
+			name:        "updated",
+			filePath:    "testdata/league.csv",
+			ifMatch:     []string{`"abc123"`},
+			matrix:      &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}},
+			wantContent: &repository.MatrixFileContent{Content: [][]string{{"1", "2"}, {"3", "4"}}},
+		},
+		{
+			name:        "updated by another writer since",
+			filePath:    "testdata/league.csv",
+			ifMatch:     []string{`"stale"`},
+			matrix:      &entity.Matrix[int64]{Data: [][]int64{{1}}},
+			wantContent: &repository.MatrixFileContent{Content: [][]string{{"1"}}},
+			repoErr:     apperrors.ErrPreconditionFailed,
+			wantErr:     apperrors.ErrPreconditionFailed,
+		},
+		{
+			name:        "no file stored",
+			filePath:    "testdata/missing.csv",
+			ifMatch:     []string{"*"},
+			matrix:      &entity.Matrix[int64]{Data: [][]int64{{1}}},
+			wantContent: &repository.MatrixFileContent{Content: [][]string{{"1"}}},
+			repoErr:     apperrors.ErrNotFound,
+			wantErr:     apperrors.ErrNotFound,
+		},
+		{name: "no If-Match", filePath: "testdata/league.csv", matrix: &entity.Matrix[int64]{Data: [][]int64{{1}}}, wantErr: apperrors.ErrPreconditionRequired},
+		{name: "pinned version", filePath: "testdata/league.csv@v1", ifMatch: []string{"*"}, matrix: &entity.Matrix[int64]{Data: [][]int64{{1}}}, wantErr: apperrors.ErrInvalidInput},
+		{name: "path traversal", filePath: "testdata/../secret.csv", ifMatch: []string{"*"}, matrix: &entity.Matrix[int64]{Data: [][]int64{{1}}}, wantErr: apperrors.ErrInvalidInput},
+		{name: "no matrix", filePath: "testdata/league.csv", ifMatch: []string{"*"}, wantErr: apperrors.ErrUnprocessableEntity},
+		{name: "ragged matrix", filePath: "testdata/league.csv", ifMatch: []string{"*"}, matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3}}}, wantErr: apperrors.ErrUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockVersionRepositoryInterface(t)
+			if tt.wantContent != nil {
+				var record *repository.VersionRecord
+				if tt.repoErr == nil {
+					record = &repository.VersionRecord{Version: 2, ETag: `"def456"`, Size: 8, CreatedAt: createdAt}
+				}
+				mockRepo.On("Update", mock.Anything, tt.filePath, tt.ifMatch, tt.wantContent).Return(record, tt.repoErr)
+			}
+			domain := &versionDomain{versionRepository: mockRepo, validatorDomain: NewMatrixValidatorDomain()}
+
+			version, err := domain.Update(context.Background(), tt.filePath, tt.ifMatch, tt.matrix)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &entity.MatrixVersion{
+				FilePath:  "testdata/league.csv",
+				Version:   2,
+				Ref:       "testdata/league.csv@v2",
+				ETag:      `"def456"`,
+				Size:      8,
+				CreatedAt: createdAt,
+				Current:   true,
+			}, version)
+		})
+	}
+}
+
+func TestVersionDomain_ListVersions(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := mocks.NewMockVersionRepositoryInterface(t)
+	mockRepo.On("ListVersions", mock.Anything, "testdata/league.csv").Return([]*repository.VersionRecord{
+		{Version: 1, ETag: `"abc123"`, Size: 8, CreatedAt: createdAt},
+		{Version: 2, ETag: `"def456"`, Size: 4, CreatedAt: createdAt.Add(time.Hour)},
+	}, nil)
+	mockRepo.On("ListVersions", mock.Anything, "testdata/missing.csv").Return(nil, errors.New("file not found"))
+	domain := &versionDomain{versionRepository: mockRepo, validatorDomain: NewMatrixValidatorDomain()}
+
+	versions, err := domain.ListVersions(context.Background(), "testdata/league.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MatrixVersion{
+		{FilePath: "testdata/league.csv", Version: 1, Ref: "testdata/league.csv@v1", ETag: `"abc123"`, Size: 8, CreatedAt: createdAt},
+		{FilePath: "testdata/league.csv", Version: 2, Ref: "testdata/league.csv@v2", ETag: `"def456"`, Size: 4, CreatedAt: createdAt.Add(time.Hour), Current: true},
+	}, versions)
+
+	_, err = domain.ListVersions(context.Background(), "testdata/missing.csv")
+	assert.Error(t, err)
+	_, err = domain.ListVersions(context.Background(), "secrets.csv")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
@@ -0,0 +1,11 @@
+package entity
+
+// Aggregate represents the outcome of an operation run on several matrix files, such as every file of a directory.
+// Files holds one result per file, so invalid files are reported without failing the others.
+// Total combines the results of the files that succeeded for aggregate operations (sum, multiply), as if the
+// operation ran on the values of all of them; it is empty for the other operations and when every file failed.
+type Aggregate struct {
+	Operation string
+	Files     []*FileResult
+	Total     string
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// AuditEvent classifies an audit log entry.
+type AuditEvent string
+
+const (
+	// AuditRequest records a request that was served, whatever its outcome.
+	AuditRequest AuditEvent = "request"
+
+	// AuditDenied records a request refused for lack of access, such as a rejected API key
+	// or a file path outside the caller's reach.
+	AuditDenied AuditEvent = "denied"
+)
+
+// AuditEntry records a single API request in the audit log.
+// Actor identifies who made the request, such as "tenant:acme" or "admin", and is empty for anonymous requests.
+// Operation and FilePath are set for requests that run a matrix operation, and Reason for denied requests.
+// Tags are the tags the file carried when the request was served, if any.
+type AuditEntry struct {
+	ID         string
+	Time       time.Time
+	Event      AuditEvent
+	Actor      string
+	RemoteAddr string
+	Method     string
+	Path       string
+	Operation  string
+	FilePath   string
+	Status     int
+	Duration   time.Duration
+	Reason     string
+	Tags       map[string]string
+}
+
+// AuditFilter selects audit log entries. Empty fields match every entry, and Limit caps
+// the number of entries returned, newest first.
+type AuditFilter struct {
+	Actor     string
+	Event     AuditEvent
+	Operation string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
@@ -0,0 +1,23 @@
+package entity
+
+// FileResult represents the outcome of a matrix operation on one file of a batch.
+// Exactly one of Result or Err is set, so a single invalid file does not fail the whole batch.
+type FileResult struct {
+	File   string
+	Result *Result
+	Err    error
+}
+
+// BatchItem is one operation of a batch request, run on one matrix file.
+type BatchItem struct {
+	Operation string
+	File      string
+}
+
+// BatchItemResult represents the outcome of one item of a batch request.
+// Exactly one of Result or Err is set, so a single failing item does not fail the whole batch.
+type BatchItemResult struct {
+	Item   BatchItem
+	Result *Result
+	Err    error
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// StoredMatrix describes a stored matrix file in the catalog. Rows and Cols are zero for files that are not valid CSV.
+// CreatedAt is when the file was first stored and UpdatedAt when its current version was, as far as the storage
+// records them. Tags are the key/value tags the file was organized with, if any.
+type StoredMatrix struct {
+	FilePath  string
+	Name      string
+	Rows      int
+	Cols      int
+	Size      int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Tags      map[string]string
+}
+
+// MatrixFilter selects stored matrix files. Name matches part of the file name regardless of case, the bounds on
+// rows and columns are inclusive and ignored when zero, and Tags matches files carrying every tag it holds,
+// with any value for tags whose value is empty. Empty fields match every file. Sort names the field
+// files are ordered by, with a leading - for descending order; Offset and Limit select a page of the files matched.
+type MatrixFilter struct {
+	Name    string
+	MinRows int
+	MaxRows int
+	MinCols int
+	MaxCols int
+	Tags    map[string]string
+	Sort    string
+	Offset  int
+	Limit   int
+}
+
+// MatrixPage holds a page of the stored matrix files matched by a filter. Total counts every file matched,
+// and Offset and Limit are those the page was selected with.
+type MatrixPage struct {
+	Matrices []*StoredMatrix
+	Total    int
+	Offset   int
+	Limit    int
+}
@@ -0,0 +1,16 @@
+package entity
+
+// DashboardSummary gathers what the web dashboard shows about the caller's workspace:
+// the matrix files it may read, how often each operation was requested lately, and its latest jobs.
+type DashboardSummary struct {
+	Files      []string
+	Operations []OperationUsage
+	RecentJobs []*Job
+}
+
+// OperationUsage counts the recent audited requests that ran an operation, and how many of them failed.
+type OperationUsage struct {
+	Operation string
+	Requests  int
+	Failures  int
+}
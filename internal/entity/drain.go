@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// DrainStatus tells whether the server is draining ahead of a shutdown: once draining, it reports itself
+// unhealthy so load balancers stop routing new traffic, while the requests in flight complete.
+// Since is zero while the server is not draining.
+type DrainStatus struct {
+	Draining         bool
+	Since            time.Time
+	InFlightRequests int
+}
@@ -0,0 +1,46 @@
+package entity
+
+// FixtureDefect is a deliberate flaw of a generated test matrix, which the service must reject.
+type FixtureDefect string
+
+const (
+	// FixtureRagged makes a row after the first one shorter than the others, or longer when rows hold
+	// a single value. Matrices of a single row get a second one.
+	FixtureRagged FixtureDefect = "ragged"
+
+	// FixtureBadCell replaces one value with one the validator rejects, such as 12abc, 2.5 or an int64 overflow.
+	FixtureBadCell FixtureDefect = "bad-cell"
+
+	// FixtureOversize adds rows until the matrix exceeds the row limit of the matrix engine.
+	FixtureOversize FixtureDefect = "oversize"
+)
+
+// FixtureDefects lists every defect a fixture can be generated with.
+var FixtureDefects = []FixtureDefect{FixtureRagged, FixtureBadCell, FixtureOversize}
+
+// FixtureSize is the dimensions of a generated matrix.
+type FixtureSize struct {
+	Rows int
+	Cols int
+}
+
+// FixtureSpec describes the test matrices to generate into Dir: a valid matrix of each size, and
+// one matrix of each size for each defect. Values are drawn between Min and Max inclusive, from
+// a sequence determined by Seed, so the same spec always produces the same files.
+type FixtureSpec struct {
+	Dir     string
+	Sizes   []FixtureSize
+	Min     int64
+	Max     int64
+	Defects []FixtureDefect
+	Seed    uint64
+}
+
+// Fixture is a generated test matrix file. Defect is empty for valid matrices.
+// Rows and Cols are the dimensions of the matrix before its defect makes a row shorter or longer.
+type Fixture struct {
+	Path   string
+	Rows   int
+	Cols   int
+	Defect FixtureDefect
+}
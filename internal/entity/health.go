@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// HealthStatus tells whether the service or one of its components works.
+type HealthStatus string
+
+const (
+	// HealthUp means every check passed.
+	HealthUp HealthStatus = "up"
+
+	// HealthDown means a check failed.
+	HealthDown HealthStatus = "down"
+)
+
+// HealthReport is the detailed state of the service: it is up when every component is,
+// and Uptime is how long it has been running.
+type HealthReport struct {
+	Status     HealthStatus
+	Uptime     time.Duration
+	Components []*ComponentHealth
+}
+
+// ComponentHealth is the outcome of the latest check of a component, such as the data directory or the job store.
+// Error is set when that check failed. LastError and LastErrorAt keep the most recent failure even after
+// the component has recovered, and are empty when it never failed.
+type ComponentHealth struct {
+	Name        string
+	Status      HealthStatus
+	Duration    time.Duration
+	Error       string
+	LastError   string
+	LastErrorAt time.Time
+}
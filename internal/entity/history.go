@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// HistoryEntry records a completed matrix operation, so its result can be retrieved later without running it again.
+// FilePath is empty for matrices sent in request bodies, and Checksum identifies the matrix the operation ran on.
+// SavedAs and ExportedTo point to the copies of the result stored with save_as or export, when there are any.
+type HistoryEntry struct {
+	ID          string
+	CompletedAt time.Time
+	Operation   string
+	FilePath    string
+	Checksum    string
+	Result      *Result
+	SavedAs     string
+	ExportedTo  string
+}
+
+// HistoryFilter selects history entries. Empty fields match every entry, and Limit caps
+// the number of entries returned, newest first.
+type HistoryFilter struct {
+	Operation string
+	FilePath  string
+	Limit     int
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// JobStatus is the lifecycle state of a background job.
+type JobStatus string
+
+const (
+	// JobQueued means the job is waiting for a free worker.
+	JobQueued JobStatus = "queued"
+
+	// JobRunning means a worker is executing the job.
+	JobRunning JobStatus = "running"
+
+	// JobSucceeded means the job finished and Result holds its output.
+	JobSucceeded JobStatus = "succeeded"
+
+	// JobFailed means the job finished and Err holds the reason.
+	JobFailed JobStatus = "failed"
+)
+
+// Job represents a matrix operation executed in the background instead of on the request path.
+// The input is either FilePath or Matrix; Result and Err are set once the job has finished.
+// CallbackURL, when set, is notified with the job's final state once it has finished.
+// TenantID identifies the tenant that submitted the job and is empty without multi-tenancy.
+// Tags are the tags the input file carried when the job was submitted, if any.
+type Job struct {
+	ID          string
+	Operation   string
+	FilePath    string
+	Matrix      *Matrix[int64]
+	Status      JobStatus
+	Result      *Result
+	Err         error
+	CreatedAt   time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	CallbackURL string
+	TenantID    string
+	Tags        map[string]string
+}
+
+// Finished reports whether the job has reached a terminal state.
+func (j *Job) Finished() bool {
+	return j.Status == JobSucceeded || j.Status == JobFailed
+}
@@ -1,7 +1,15 @@
 package entity
 
-// Matrix represents a two-dimensional matrix of integer values.
-// The Data field contains rows of integer columns, where each row must have the same length.
-type Matrix struct {
-	Data [][]int64
-}
+import "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+
+// Matrix represents a two-dimensional matrix of numeric values of type T.
+// It is the matrix type of the exported matrix engine, so matrices are shared with it without conversion.
+type Matrix[T matrix.Number] = matrix.Matrix[T]
+
+// OperationInfo describes a registered matrix operation: its name, a one-line description of what it returns,
+// the steps of composite operations and whether it is enabled.
+type OperationInfo = matrix.OperationInfo
+
+// OperationPlan describes how an operation would run on a matrix of given dimensions: the steps it runs,
+// the dimensions of its result and an estimate of its cost.
+type OperationPlan = matrix.Plan
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// MetricKind tells how the points of a metric are aggregated.
+type MetricKind string
+
+const (
+	// MetricCounter is a monotonic sum accumulated since the service started.
+	MetricCounter MetricKind = "counter"
+
+	// MetricGauge is a value measured when the metrics are collected.
+	MetricGauge MetricKind = "gauge"
+
+	// MetricHistogram counts measurements in buckets, accumulated since the service started.
+	MetricHistogram MetricKind = "histogram"
+)
+
+// MetricsSnapshot holds the value of every metric at Time. Counters and histograms accumulate since StartTime.
+type MetricsSnapshot struct {
+	StartTime time.Time
+	Time      time.Time
+	Metrics   []*Metric
+}
+
+// Metric is a named measurement, with one point per combination of attributes.
+// Names and units follow the OpenTelemetry semantic conventions, such as http.server.request.duration in s.
+// Bounds are the upper bounds of the buckets of a histogram; the last bucket has no upper bound.
+type Metric struct {
+	Name        string
+	Description string
+	Unit        string
+	Kind        MetricKind
+	Bounds      []float64
+	Points      []*MetricPoint
+}
+
+// MetricPoint is the value of a metric for a combination of attributes. Value is set for counters and gauges,
+// while Count, Sum and BucketCounts, which has one more entry than the bounds, are set for histograms.
+type MetricPoint struct {
+	Attributes   map[string]string
+	Value        float64
+	Count        uint64
+	Sum          float64
+	BucketCounts []uint64
+}
@@ -0,0 +1,11 @@
+package entity
+
+// Plan is the outcome of a dry run: the operation a request would run and the matrix it would run on,
+// resolved and validated without running the operation.
+// Input describes the matrix after any row or column selection, like Result.Input.
+// Streamed is set when the matrix would be aggregated as it is read rather than held in memory.
+type Plan struct {
+	Operation *OperationPlan
+	Input     *MatrixInfo
+	Streamed  bool
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// QueueStatus describes the work waiting for a bounded pool of workers. Queued counts the items waiting,
+// out of the Capacity the queue holds, and ServiceTime is how long a worker recently took per item,
+// zero before the first one is done.
+type QueueStatus struct {
+	Queued      int
+	Capacity    int
+	Workers     int
+	ServiceTime time.Duration
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// RateLimit describes how many more requests a client may make before being throttled,
+// as reported in the X-RateLimit-* response headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ClientUsage is what an API key used during the current quota period, against the quotas of its tenant.
+// KeyID identifies the key without revealing it, and a zero quota means the usage is not capped.
+type ClientUsage struct {
+	TenantID     string
+	KeyID        string
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	Requests     int
+	RequestQuota int
+	ComputeTime  time.Duration
+	ComputeQuota time.Duration
+}
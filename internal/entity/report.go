@@ -0,0 +1,51 @@
+package entity
+
+// ReportCode identifies the kind of problem a validation report issue describes.
+type ReportCode string
+
+// Codes of the errors, which make the service reject a matrix file.
+const (
+	ReportEmptyFile      ReportCode = "empty_file"
+	ReportSyntax         ReportCode = "csv_syntax"
+	ReportRowLength      ReportCode = "inconsistent_row_length"
+	ReportNonNumericCell ReportCode = "non_numeric_cell"
+	ReportOutOfRange     ReportCode = "value_out_of_range"
+	ReportTooManyRows    ReportCode = "too_many_rows"
+	ReportTooManyCols    ReportCode = "too_many_columns"
+)
+
+// Codes of the warnings, which the service tolerates but are likely mistakes or may confuse other tools.
+const (
+	ReportByteOrderMark      ReportCode = "byte_order_mark"
+	ReportBlankLines         ReportCode = "blank_lines"
+	ReportTrailingBlankLines ReportCode = "trailing_blank_lines"
+)
+
+// MatrixReport describes the problems found in a matrix file, split into errors, which make the service reject it,
+// and warnings. Rows is the number of rows read and Cols the number of values most rows hold.
+type MatrixReport struct {
+	FilePath string
+	Rows     int
+	Cols     int
+	Errors   []*ReportIssue
+	Warnings []*ReportIssue
+}
+
+// Valid reports whether the service accepts the file, warnings notwithstanding.
+func (r *MatrixReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// ReportIssue is a single problem of a matrix file with a suggested fix. Lines, rows and columns are numbered
+// from 1; Lines holds the lines of the file the issue is about, which differ from the rows when the file has
+// blank lines. Issues about a single value set Row, Col and Value, while issues about several rows set Rows.
+type ReportIssue struct {
+	Code    ReportCode
+	Message string
+	Fix     string
+	Lines   []int
+	Rows    []int
+	Row     int
+	Col     int
+	Value   string
+}
@@ -0,0 +1,40 @@
+package entity
+
+// Result represents the outcome of a matrix operation.
+// Operations that produce a matrix (echo, transpose, inverse, flatten) populate Matrix,
+// while aggregate operations (sum, multiply) populate Scalar with an arbitrary-precision decimal string.
+// Input describes the matrix the operation ran on, after any row or column selection.
+// ColumnLabels and RowLabels name the columns and rows of Matrix when the input was labelled
+// and the operation kept its rows and columns.
+// Cached reports whether the result was remembered from an earlier request on the same unchanged file,
+// which was neither read nor parsed again.
+type Result struct {
+	Matrix       *Matrix[int64]
+	Scalar       string
+	Input        *MatrixInfo
+	ColumnLabels []string
+	RowLabels    []string
+	Cached       bool
+}
+
+// MatrixInfo summarizes a matrix for auditing without carrying its values.
+// Checksum is the hex-encoded SHA-256 of the matrix in canonical CSV form, so the same values
+// produce the same checksum whatever format or file they were read from.
+type MatrixInfo struct {
+	Rows     int
+	Cols     int
+	Checksum string
+}
+
+// String renders the result in the plain text format used by the HTTP API.
+// Matrices are rendered as comma-separated rows joined by newlines.
+func (r *Result) String() string {
+	if r == nil {
+		return ""
+	}
+	if r.Matrix == nil {
+		return r.Scalar
+	}
+
+	return r.Matrix.String()
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// DeletedMatrix describes a stored matrix file that was deleted, by a user or once it expired.
+// It can be restored to FilePath until PurgeAt, when it is removed for good.
+type DeletedMatrix struct {
+	FilePath  string
+	DeletedAt time.Time
+	PurgeAt   time.Time
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Schedule represents an operation that runs against a matrix file on a recurring cron schedule.
+// Every run is submitted as a background job; LastJobID refers to the job of the latest run,
+// and LastErr is set when that run could not be submitted. TenantID identifies the tenant that owns the schedule.
+type Schedule struct {
+	ID        string
+	Spec      string
+	Operation string
+	FilePath  string
+	CreatedAt time.Time
+	NextRunAt time.Time
+	LastRunAt time.Time
+	LastJobID string
+	LastErr   error
+	TenantID  string
+}
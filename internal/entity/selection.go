@@ -0,0 +1,19 @@
+package entity
+
+// IndexRange is an inclusive range of zero-based row or column indices.
+type IndexRange struct {
+	Start int
+	End   int
+}
+
+// Selection restricts an operation to a submatrix made of the selected rows and columns,
+// taken in the order they were requested. A nil Rows or Cols slice selects every row or column.
+type Selection struct {
+	Rows []IndexRange
+	Cols []IndexRange
+}
+
+// IsEmpty reports whether the selection keeps the whole matrix.
+func (s *Selection) IsEmpty() bool {
+	return s == nil || (len(s.Rows) == 0 && len(s.Cols) == 0)
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// SignedURL grants whoever holds it the download of the result of Operation on the matrix file at FilePath,
+// without an API key, until ExpiresAt. Signature authenticates the other fields, so none can be changed.
+type SignedURL struct {
+	FilePath  string
+	Operation string
+	ExpiresAt time.Time
+	Signature string
+}
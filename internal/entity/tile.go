@@ -0,0 +1,11 @@
+package entity
+
+// Tile is one of the equal tiles a matrix is split into. Row and Col give its one-based position in the grid
+// of tiles, while Rows and Cols give the rows and columns of the source matrix it holds.
+type Tile struct {
+	Row    int
+	Col    int
+	Rows   IndexRange
+	Cols   IndexRange
+	Matrix *Matrix[int64]
+}
@@ -0,0 +1,20 @@
+package entity
+
+// Upload represents the state of a matrix file being received in chunks.
+// Offset is the number of bytes received so far out of Length; FilePath is set once
+// the upload is complete and the assembled file has been validated as a matrix.
+// ContentHash is then the SHA-256 of the file, and Deduplicated reports that the same content
+// had been uploaded before, so the file shares its storage.
+type Upload struct {
+	ID           string
+	Length       int64
+	Offset       int64
+	FilePath     string
+	ContentHash  string
+	Deduplicated bool
+}
+
+// Complete reports whether every byte of the upload has been received and validated.
+func (u *Upload) Complete() bool {
+	return u.FilePath != ""
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// MatrixVersion describes a version of a stored matrix file. Versions are numbered from 1 as the file is updated,
+// and Ref pins operations to the version, as in testdata/league.csv@v3, however the file is updated later.
+// ETag identifies the content of the version; updates are made conditional on the ETag of the current version.
+type MatrixVersion struct {
+	FilePath  string
+	Version   int
+	Ref       string
+	ETag      string
+	Size      int64
+	CreatedAt time.Time
+	Current   bool
+}
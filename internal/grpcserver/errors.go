@@ -0,0 +1,110 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// errorDomain is the domain of the ErrorInfo details of the errors returned to clients.
+const errorDomain = "league-matrix-app"
+
+// statusError converts an application error into a gRPC status error. The status carries the message of err and
+// an ErrorInfo detail whose reason is the machine-readable code of err, as reported by the HTTP error envelope.
+func statusError(err error) error {
+	st := status.New(statusCode(err), err.Error())
+	if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(apperrors.GetErrorCode(err)),
+		Domain: errorDomain,
+	}); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// retryStatusError converts an application error into a gRPC status error like statusError, with a RetryInfo
+// detail telling the client to retry after retryAfter when the service is unavailable.
+func retryStatusError(err error, retryAfter time.Duration) error {
+	st := status.Convert(statusError(err))
+	if st.Code() != codes.Unavailable {
+		return st.Err()
+	}
+	if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// statusCode maps application errors to gRPC status codes, like apperrors.GetHTTPStatusCode does for HTTP.
+// If no sentinel error is found, it defaults to codes.Internal.
+func statusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidInput), errors.Is(err, apperrors.ErrUnsupportedMediaType):
+		return codes.InvalidArgument
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		return codes.Unauthenticated
+	case errors.Is(err, apperrors.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, apperrors.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, apperrors.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, apperrors.ErrPreconditionFailed), errors.Is(err, apperrors.ErrPreconditionRequired),
+		errors.Is(err, apperrors.ErrUnprocessableEntity):
+		return codes.FailedPrecondition
+	case errors.Is(err, apperrors.ErrOverflow):
+		return codes.OutOfRange
+	case errors.Is(err, apperrors.ErrPayloadTooLarge), errors.Is(err, apperrors.ErrTooManyRequests):
+		return codes.ResourceExhausted
+	case errors.Is(err, apperrors.ErrServiceUnavailable):
+		return codes.Unavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	default:
+		return codes.Internal
+	}
+}
+
+// httpStatus maps gRPC status codes to the HTTP status codes the HTTP server answers the same errors with,
+// so calls are recorded in the audit log like requests.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusUnprocessableEntity
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		// The status nginx records for clients that closed the connection, as net/http has none
+		return 499
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,290 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/logging"
+	"github.com/matsuboshi/league-matrix-app/internal/metrics"
+	"github.com/matsuboshi/league-matrix-app/internal/pb"
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+const (
+	// apiKeyMetadata is the metadata key API keys are read from, the gRPC counterpart of the X-API-Key header.
+	apiKeyMetadata = "x-api-key"
+
+	// requestIDMetadata is the metadata key request IDs are read from and returned with in the header metadata,
+	// the gRPC counterpart of the X-Request-ID header.
+	requestIDMetadata = "x-request-id"
+
+	// qosMetadata is the metadata key selecting the class of service of a call, the gRPC counterpart of the
+	// X-QoS-Class header, echoed in the header metadata.
+	qosMetadata = "x-qos-class"
+
+	// usageRecordTimeout bounds recording the compute time of a call once it has been served.
+	usageRecordTimeout = 5 * time.Second
+
+	// auditRecordTimeout bounds recording a call in the audit log once it has been served.
+	auditRecordTimeout = 5 * time.Second
+
+	// memoryRetryAfter is how long clients of calls shed under memory pressure are told to wait, like the
+	// Retry-After of the HTTP server.
+	memoryRetryAfter = 5 * time.Second
+)
+
+// scopeCalls gives every call a request ID like the RequestID HTTP middleware: the x-request-id metadata sent by
+// the client, or a new random ID when it is missing or unusable. The ID is set on the context of the call for
+// logging.Handler to add to every record logged with it and returned in the x-request-id header metadata, then
+// the call is logged with its outcome.
+func scopeCalls(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+	scope := logging.FromContext(ctx)
+	scope.RequestID = logging.NewRequestID(firstMetadata(ctx, requestIDMetadata))
+	ctx = logging.NewContext(ctx, scope)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, scope.RequestID))
+
+	start := time.Now()
+	resp, err := next(ctx, req)
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	slog.Log(ctx, level, "grpc call",
+		"method", info.FullMethod,
+		"code", code.String(),
+		"duration_ms", float64(time.Since(start).Microseconds())/1000,
+		"error", err)
+	return resp, err
+}
+
+// measureCalls counts every call with its duration by method and status code in metricsDomain, and the calls
+// that run a matrix operation by operation, with the sizes of the matrices they read, like HTTP requests.
+func measureCalls(metricsDomain domain.MetricsDomainInterface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		// The layers serving the call describe the operation it runs
+		request := metrics.NewRequest()
+
+		resp, err := next(metrics.NewContext(ctx, request), req)
+
+		duration := time.Since(start)
+		metricsDomain.RecordCall(info.FullMethod, int(status.Code(err)), duration)
+		if operation := request.Operation(); operation != "" {
+			metricsDomain.RecordOperation(operation, duration, request.Sizes())
+		}
+		return resp, err
+	}
+}
+
+// auditCalls records every call in the audit log of auditDomain once it has been served, like the Audit HTTP
+// middleware, with the full method as its path and the HTTP status matching its status code. Health checks are
+// left out, like the HTTP health check.
+func auditCalls(auditDomain domain.AuditDomainInterface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		if healthCheck(info) {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		trail := &audit.Trail{}
+
+		resp, err := next(audit.NewContext(ctx, trail), req)
+
+		details := trail.Details()
+		entry := &entity.AuditEntry{
+			Time:       start,
+			Event:      entity.AuditRequest,
+			Actor:      details.Actor,
+			RemoteAddr: remoteAddr(ctx),
+			// Calls are HTTP/2 POST requests to the full method
+			Method:    http.MethodPost,
+			Path:      info.FullMethod,
+			Operation: details.Operation,
+			FilePath:  details.FilePath,
+			Status:    httpStatus(status.Code(err)),
+			Duration:  time.Since(start),
+			Reason:    details.Denial,
+		}
+		if details.Denial != "" {
+			entry.Event = entity.AuditDenied
+		}
+
+		// The entry is recorded even when the client has gone away
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditRecordTimeout)
+		defer cancel()
+		if recordErr := auditDomain.Record(recordCtx, entry); recordErr != nil {
+			slog.ErrorContext(ctx, "failed to record audit entry",
+				"method", info.FullMethod,
+				"status_code", entry.Status,
+				"error", recordErr)
+		}
+		return resp, err
+	}
+}
+
+// recoverCalls fails a call that panics with Internal, logging the panic with its stack, rather than letting it
+// take the whole server down, as gRPC leaves panics unrecovered.
+func recoverCalls(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "grpc call panicked",
+				"method", info.FullMethod,
+				"panic", r,
+				"stack", string(debug.Stack()))
+			resp, err = nil, status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return next(ctx, req)
+}
+
+// requireTenant authenticates calls like the RequireTenant HTTP middleware: when tenants are configured, calls
+// must carry an API key, which is rate limited and charged with the compute time of the call, and are served
+// on behalf of its tenant. Without tenants, calls are served as they come, and so are health checks, which load
+// balancers send without an API key like they check the HTTP health check.
+func requireTenant(tenantDomain domain.TenantDomainInterface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		if !tenantDomain.Enabled() || healthCheck(info) {
+			return next(ctx, req)
+		}
+
+		key := apiKey(ctx)
+		t, err := tenantDomain.Authenticate(ctx, key)
+		if err == nil {
+			_, err = tenantDomain.AllowRequest(ctx, t, key)
+		}
+		if t != nil {
+			audit.SetActor(ctx, "tenant:"+t.ID)
+		}
+		if err != nil {
+			audit.Deny(ctx, err.Error())
+			return nil, statusError(err)
+		}
+
+		start := time.Now()
+		resp, err := next(tenant.NewContext(ctx, t), req)
+
+		// The compute time is recorded even when the client has gone away
+		usageCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), usageRecordTimeout)
+		defer cancel()
+		if usageErr := tenantDomain.RecordUsage(usageCtx, t, key, time.Since(start)); usageErr != nil {
+			slog.ErrorContext(ctx, "failed to record usage",
+				"tenant_id", t.ID,
+				"error", usageErr)
+		}
+		return resp, err
+	}
+}
+
+// classifyCalls schedules calls with the class of service of their tenant, lowered to batch by the x-qos-class
+// metadata when asked, like the Classify HTTP middleware. Metadata can never raise a batch tenant to interactive.
+// Calls with an invalid class are rejected with InvalidArgument.
+func classifyCalls(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+	requested, err := qos.Parse(firstMetadata(ctx, qosMetadata))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	var tier qos.Class
+	if t, ok := tenant.FromContext(ctx); ok {
+		tier = t.QoS
+	}
+	class := qos.Min(tier, requested)
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(qosMetadata, string(class)))
+	return next(qos.NewContext(ctx, class), req)
+}
+
+// limitCalls gives every call the deadline of the HTTP routes alike: ProcessMatrix calls the operation timeout of
+// config and the other calls its request timeout, so a call sent without a deadline cannot run indefinitely.
+// A client deadline that comes first is kept.
+func limitCalls(config domain.TimeoutConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		timeout := config.Request
+		if operationCall(info) {
+			timeout = config.Operation
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return next(ctx, req)
+	}
+}
+
+// guardCalls sheds the ProcessMatrix calls with Unavailable while memoryGuardDomain reports memory pressure
+// and while backpressureDomain reports the workers saturated, like the operation routes of the HTTP server.
+// The status tells clients when to retry in a RetryInfo detail.
+func guardCalls(memoryGuardDomain domain.MemoryGuardDomainInterface,
+	backpressureDomain domain.BackpressureDomainInterface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		if !operationCall(info) {
+			return next(ctx, req)
+		}
+
+		if err := memoryGuardDomain.Admit(ctx); err != nil {
+			return nil, shedCall(ctx, info, memoryRetryAfter, err)
+		}
+		release, retryAfter, err := backpressureDomain.AdmitOperation(ctx)
+		if err != nil {
+			return nil, shedCall(ctx, info, retryAfter, err)
+		}
+		defer release()
+
+		return next(ctx, req)
+	}
+}
+
+// shedCall logs a call that was not admitted and returns its status error, telling when to retry.
+func shedCall(ctx context.Context, info *grpc.UnaryServerInfo, retryAfter time.Duration, err error) error {
+	slog.WarnContext(ctx, "grpc call shed",
+		"method", info.FullMethod,
+		"error", err,
+		"retry_after", retryAfter)
+	return retryStatusError(err, retryAfter)
+}
+
+// operationCall reports whether info describes a call running a matrix operation.
+func operationCall(info *grpc.UnaryServerInfo) bool {
+	return info.FullMethod == pb.MatrixService_ProcessMatrix_FullMethodName
+}
+
+// healthCheck reports whether info describes a call to the health service.
+func healthCheck(info *grpc.UnaryServerInfo) bool {
+	return strings.HasPrefix(info.FullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+// apiKey returns the API key sent in the metadata of the call, or an empty string without one.
+func apiKey(ctx context.Context) string {
+	return firstMetadata(ctx, apiKeyMetadata)
+}
+
+// firstMetadata returns the first value of key in the metadata of the call, or an empty string without one.
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// remoteAddr returns the address of the client of the call, or an empty string when it is unknown.
+func remoteAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+// Package grpcserver exposes the matrix operations over gRPC, as the MatrixService of proto/matrix_service.proto.
+// It serves the same domain layer as the HTTP handlers, so files, tenants and limits behave alike on both servers.
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/pb"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+type matrixServer struct {
+	pb.UnimplementedMatrixServiceServer
+	matrixDomain domain.MatrixDomainInterface
+}
+
+// Server is the gRPC server exposing MatrixService, along with the standard grpc.health.v1.Health service
+// load balancers check it with and the reflection service clients such as grpcurl discover it with.
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+// NewServer creates a gRPC server exposing MatrixService with its dependencies.
+// Every call is given a request ID and logged, measured in metricsDomain and recorded in the audit log of
+// auditDomain; a call that panics fails alone. When tenants are configured, calls must carry an API key in the
+// x-api-key metadata, which is authenticated and rate limited by tenantDomain like the X-API-Key header.
+// Health checks need no API key. Calls are scheduled with the class of service of their tenant, lowered to
+// batch by the x-qos-class metadata. Calls are bounded by the timeouts of timeoutConfig like HTTP requests, and
+// ProcessMatrix calls are shed by memoryGuardDomain and backpressureDomain like the operation routes.
+// The server reports itself as not serving until SetServing is called.
+func NewServer(matrixDomain domain.MatrixDomainInterface, tenantDomain domain.TenantDomainInterface,
+	auditDomain domain.AuditDomainInterface, metricsDomain domain.MetricsDomainInterface,
+	memoryGuardDomain domain.MemoryGuardDomainInterface, backpressureDomain domain.BackpressureDomainInterface,
+	timeoutConfig domain.TimeoutConfig) *Server {
+	server := &Server{
+		Server: grpc.NewServer(grpc.ChainUnaryInterceptor(
+			scopeCalls,
+			measureCalls(metricsDomain),
+			auditCalls(auditDomain),
+			// Panics are recovered inside the audit log and the metrics, so failed calls are recorded like the others
+			recoverCalls,
+			requireTenant(tenantDomain),
+			classifyCalls,
+			limitCalls(timeoutConfig),
+			guardCalls(memoryGuardDomain, backpressureDomain),
+		)),
+		health: health.NewServer(),
+	}
+	pb.RegisterMatrixServiceServer(server, &matrixServer{matrixDomain: matrixDomain})
+	healthpb.RegisterHealthServer(server, server.health)
+	reflection.Register(server)
+	server.SetServing(false)
+	return server
+}
+
+// SetServing reports the server, and MatrixService, as serving or not serving to health checks. The server is
+// set serving once it accepts calls, and not serving when it starts shutting down, so load balancers stop
+// sending it calls before it stops.
+func (s *Server) SetServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(pb.MatrixService_ServiceDesc.ServiceName, status)
+}
+
+func (s *matrixServer) ListOperations(_ context.Context, _ *pb.ListOperationsRequest) (*pb.ListOperationsResponse, error) {
+	infos := s.matrixDomain.DescribeOperations()
+
+	response := &pb.ListOperationsResponse{Operations: make([]*pb.Operation, 0, len(infos))}
+	for _, info := range infos {
+		operation := &pb.Operation{Name: string(info.Name), Description: info.Description}
+		for _, step := range info.Steps {
+			operation.Steps = append(operation.Steps, string(step))
+		}
+		response.Operations = append(response.Operations, operation)
+	}
+	return response, nil
+}
+
+func (s *matrixServer) ProcessMatrix(ctx context.Context, req *pb.ProcessMatrixRequest) (*pb.OperationResult, error) {
+	if req.GetOperation() == "" {
+		return nil, statusError(fmt.Errorf("%w: operation is required", apperrors.ErrInvalidInput))
+	}
+
+	var result *entity.Result
+	var err error
+	switch input := req.GetInput().(type) {
+	case *pb.ProcessMatrixRequest_File:
+		audit.Describe(ctx, req.GetOperation(), input.File)
+		result, err = s.matrixDomain.ProcessMatrix(ctx, req.GetOperation(), input.File, nil)
+	case *pb.ProcessMatrixRequest_Matrix:
+		audit.Describe(ctx, req.GetOperation(), "")
+		result, err = s.matrixDomain.ProcessMatrixData(ctx, req.GetOperation(), codec.MatrixFromProto(input.Matrix), nil)
+	default:
+		err = fmt.Errorf("%w: file or matrix is required", apperrors.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	message := &pb.OperationResult{Operation: req.GetOperation()}
+	if result.Matrix != nil {
+		message.Value = &pb.OperationResult_Matrix{Matrix: codec.MatrixToProto(result.Matrix)}
+	} else {
+		message.Value = &pb.OperationResult_Scalar{Scalar: result.String()}
+	}
+	return message, nil
+}
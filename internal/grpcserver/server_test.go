@@ -0,0 +1,512 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/logging"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/pb"
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+// newTestClient serves a server created with NewServer over an in-memory connection and returns a client of it.
+func newTestClient(t *testing.T, matrixDomain *mocks.MockMatrixDomainInterface,
+	tenantDomain *mocks.MockTenantDomainInterface) pb.MatrixServiceClient {
+	_, conn := newTestConn(t, matrixDomain, tenantDomain)
+	return pb.NewMatrixServiceClient(conn)
+}
+
+// newTestConn serves a server created with NewServer over an in-memory connection and returns it with
+// a connection to it.
+func newTestConn(t *testing.T, matrixDomain *mocks.MockMatrixDomainInterface,
+	tenantDomain *mocks.MockTenantDomainInterface) (*Server, *grpc.ClientConn) {
+	return newServerDeps(t, matrixDomain, tenantDomain).serve(t)
+}
+
+// serverDeps holds the dependencies a server is created with, for tests to replace before serving it.
+type serverDeps struct {
+	matrixDomain       domain.MatrixDomainInterface
+	tenantDomain       domain.TenantDomainInterface
+	auditDomain        domain.AuditDomainInterface
+	metricsDomain      domain.MetricsDomainInterface
+	memoryGuardDomain  domain.MemoryGuardDomainInterface
+	backpressureDomain domain.BackpressureDomainInterface
+	timeoutConfig      domain.TimeoutConfig
+}
+
+// newServerDeps returns the dependencies of a server with matrixDomain and tenantDomain, an in-memory audit log,
+// metrics, a memory limit far away, idle workers and the default timeouts.
+func newServerDeps(t *testing.T, matrixDomain *mocks.MockMatrixDomainInterface,
+	tenantDomain *mocks.MockTenantDomainInterface) *serverDeps {
+	auditDomain, err := domain.NewAuditDomain("")
+	require.NoError(t, err)
+	return &serverDeps{
+		matrixDomain:      matrixDomain,
+		tenantDomain:      tenantDomain,
+		auditDomain:       auditDomain,
+		metricsDomain:     domain.NewMetricsDomain(),
+		memoryGuardDomain: domain.NewMemoryGuardDomain(1 << 50),
+		backpressureDomain: domain.NewBackpressureDomain(func() *entity.QueueStatus {
+			return &entity.QueueStatus{}
+		}),
+		timeoutConfig: domain.TimeoutConfig{Request: 5 * time.Second, Operation: 25 * time.Second},
+	}
+}
+
+// serve serves a server created with the dependencies over an in-memory connection and returns it with
+// a connection to it.
+func (d *serverDeps) serve(t *testing.T) (*Server, *grpc.ClientConn) {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(d.matrixDomain, d.tenantDomain, d.auditDomain, d.metricsDomain, d.memoryGuardDomain,
+		d.backpressureDomain, d.timeoutConfig)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return server, conn
+}
+
+// newTenantDomain returns a tenant domain mock with tenants enabled or disabled.
+func newTenantDomain(t *testing.T, enabled bool) *mocks.MockTenantDomainInterface {
+	tenantDomain := mocks.NewMockTenantDomainInterface(t)
+	tenantDomain.On("Enabled").Return(enabled)
+	return tenantDomain
+}
+
+func TestMatrixServer_ListOperations(t *testing.T) {
+	matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+	matrixDomain.On("DescribeOperations").Return([]entity.OperationInfo{
+		{Name: "column-sums", Steps: []matrixlib.Operation{matrixlib.Invert, matrixlib.Sum}, Enabled: true},
+		{Name: matrixlib.Sum, Description: "Adds every value of the matrix.", BuiltIn: true, Enabled: true},
+	})
+	client := newTestClient(t, matrixDomain, newTenantDomain(t, false))
+
+	var header metadata.MD
+	got, err := client.ListOperations(context.Background(), &pb.ListOperationsRequest{}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	want := &pb.ListOperationsResponse{Operations: []*pb.Operation{
+		{Name: "column-sums", Steps: []string{"invert", "sum"}},
+		{Name: "sum", Description: "Adds every value of the matrix."},
+	}}
+	assert.True(t, proto.Equal(want, got), got)
+	assert.Len(t, header.Get(requestIDMetadata), 1)
+}
+
+func TestMatrixServer_ProcessMatrix(t *testing.T) {
+	matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+	tests := []struct {
+		name     string
+		request  *pb.ProcessMatrixRequest
+		setup    func(matrixDomain *mocks.MockMatrixDomainInterface)
+		want     *pb.OperationResult
+		wantCode codes.Code
+		// wantReason is the machine-readable error code reported in the ErrorInfo details
+		wantReason apperrors.Code
+	}{
+		{
+			name: "file with a scalar result",
+			request: &pb.ProcessMatrixRequest{Operation: "sum",
+				Input: &pb.ProcessMatrixRequest_File{File: "testdata/matrix1.csv"}},
+			setup: func(matrixDomain *mocks.MockMatrixDomainInterface) {
+				matrixDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+					Return(&entity.Result{Scalar: "378"}, nil)
+			},
+			want: &pb.OperationResult{Operation: "sum", Value: &pb.OperationResult_Scalar{Scalar: "378"}},
+		},
+		{
+			name: "matrix with a matrix result",
+			request: &pb.ProcessMatrixRequest{Operation: "transpose", Input: &pb.ProcessMatrixRequest_Matrix{
+				Matrix: &pb.Matrix{Rows: []*pb.Row{{Values: []int64{1, 2}}, {Values: []int64{3, 4}}}}}},
+			setup: func(matrixDomain *mocks.MockMatrixDomainInterface) {
+				matrixDomain.On("ProcessMatrixData", mock.Anything, "transpose", matrix, (*entity.Selection)(nil)).
+					Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}}, nil)
+			},
+			want: &pb.OperationResult{Operation: "transpose", Value: &pb.OperationResult_Matrix{
+				Matrix: &pb.Matrix{Rows: []*pb.Row{{Values: []int64{1, 3}}, {Values: []int64{2, 4}}}}}},
+		},
+		{
+			name: "invalid operation",
+			request: &pb.ProcessMatrixRequest{Operation: "divide",
+				Input: &pb.ProcessMatrixRequest_File{File: "testdata/matrix1.csv"}},
+			setup: func(matrixDomain *mocks.MockMatrixDomainInterface) {
+				matrixDomain.On("ProcessMatrix", mock.Anything, "divide", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+					Return(nil, fmt.Errorf("%w: invalid operation: divide", apperrors.ErrInvalidOperation))
+			},
+			wantCode:   codes.InvalidArgument,
+			wantReason: apperrors.CodeInvalidOperation,
+		},
+		{
+			name: "missing file",
+			request: &pb.ProcessMatrixRequest{Operation: "sum",
+				Input: &pb.ProcessMatrixRequest_File{File: "testdata/missing.csv"}},
+			setup: func(matrixDomain *mocks.MockMatrixDomainInterface) {
+				matrixDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/missing.csv", (*entity.Selection)(nil)).
+					Return(nil, fmt.Errorf("%w: failed to open file", apperrors.ErrNotFound))
+			},
+			wantCode:   codes.NotFound,
+			wantReason: apperrors.CodeNotFound,
+		},
+		{
+			name:       "missing operation",
+			request:    &pb.ProcessMatrixRequest{Input: &pb.ProcessMatrixRequest_File{File: "testdata/matrix1.csv"}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: apperrors.CodeInvalidInput,
+		},
+		{
+			name:       "missing input",
+			request:    &pb.ProcessMatrixRequest{Operation: "sum"},
+			wantCode:   codes.InvalidArgument,
+			wantReason: apperrors.CodeInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+			if tt.setup != nil {
+				tt.setup(matrixDomain)
+			}
+			client := newTestClient(t, matrixDomain, newTenantDomain(t, false))
+
+			got, err := client.ProcessMatrix(context.Background(), tt.request)
+
+			if tt.wantCode != codes.OK {
+				st := status.Convert(err)
+				assert.Equal(t, tt.wantCode, st.Code())
+				require.Len(t, st.Details(), 1)
+				assert.Equal(t, string(tt.wantReason), st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(tt.want, got), got)
+		})
+	}
+}
+
+func TestMatrixServer_Tenants(t *testing.T) {
+	acme := &tenant.Tenant{ID: "acme"}
+	matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+	// The operation runs on behalf of the tenant of the API key
+	matrixDomain.On("ProcessMatrix", mock.MatchedBy(func(ctx context.Context) bool { return tenant.ID(ctx) == "acme" }),
+		"sum", "testdata/tenants/acme/matrix.csv", (*entity.Selection)(nil)).Return(&entity.Result{Scalar: "10"}, nil)
+	tenantDomain := newTenantDomain(t, true)
+	tenantDomain.On("Authenticate", mock.Anything, "acme-key").Return(acme, nil)
+	tenantDomain.On("Authenticate", mock.Anything, "").Return(nil, apperrors.ErrUnauthorized)
+	tenantDomain.On("AllowRequest", mock.Anything, acme, "acme-key").Return(nil, nil)
+	tenantDomain.On("RecordUsage", mock.Anything, acme, "acme-key", mock.Anything).Return(nil)
+	client := newTestClient(t, matrixDomain, tenantDomain)
+	request := &pb.ProcessMatrixRequest{Operation: "sum",
+		Input: &pb.ProcessMatrixRequest_File{File: "testdata/tenants/acme/matrix.csv"}}
+
+	_, err := client.ProcessMatrix(context.Background(), request)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), apiKeyMetadata, "acme-key")
+	got, err := client.ProcessMatrix(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, "10", got.GetScalar())
+}
+
+func TestServer_QoS(t *testing.T) {
+	tests := []struct {
+		name      string
+		tier      qos.Class
+		requested string
+		want      qos.Class
+		wantCode  codes.Code
+	}{
+		{name: "interactive tenant", tier: qos.Interactive, want: qos.Interactive},
+		{name: "batch tenant", tier: qos.Batch, want: qos.Batch},
+		{name: "batch tenant asking for interactive", tier: qos.Batch, requested: "interactive", want: qos.Batch},
+		{name: "interactive tenant asking for batch", tier: qos.Interactive, requested: "batch", want: qos.Batch},
+		{name: "invalid class", tier: qos.Interactive, requested: "bulk", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acme := &tenant.Tenant{ID: "acme", QoS: tt.tier}
+			matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+			if tt.wantCode == codes.OK {
+				// The execution pool queues the operation by the class of service of its context
+				matrixDomain.On("ProcessMatrix", mock.MatchedBy(func(ctx context.Context) bool {
+					return qos.FromContext(ctx) == tt.want
+				}), "sum", "testdata/tenants/acme/matrix.csv", (*entity.Selection)(nil)).
+					Return(&entity.Result{Scalar: "10"}, nil)
+			}
+			tenantDomain := newTenantDomain(t, true)
+			tenantDomain.On("Authenticate", mock.Anything, "acme-key").Return(acme, nil)
+			tenantDomain.On("AllowRequest", mock.Anything, acme, "acme-key").Return(nil, nil)
+			tenantDomain.On("RecordUsage", mock.Anything, acme, "acme-key", mock.Anything).Return(nil)
+			client := newTestClient(t, matrixDomain, tenantDomain)
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), apiKeyMetadata, "acme-key")
+			if tt.requested != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, qosMetadata, tt.requested)
+			}
+			var header metadata.MD
+			_, err := client.ProcessMatrix(ctx, &pb.ProcessMatrixRequest{Operation: "sum",
+				Input: &pb.ProcessMatrixRequest_File{File: "testdata/tenants/acme/matrix.csv"}}, grpc.Header(&header))
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{string(tt.want)}, header.Get(qosMetadata))
+		})
+	}
+}
+
+func TestServer_RequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		want      string
+	}{
+		{name: "client request ID", requestID: "job-42", want: "job-42"},
+		{name: "no request ID"},
+		{name: "unusable request ID", requestID: "job 42"},
+		{name: "request ID too long", requestID: strings.Repeat("a", logging.MaxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+			matrixDomain.On("ProcessMatrix", mock.MatchedBy(func(ctx context.Context) bool {
+				got = logging.FromContext(ctx).RequestID
+				return true
+			}), "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(&entity.Result{Scalar: "45"}, nil)
+			client := newTestClient(t, matrixDomain, newTenantDomain(t, false))
+
+			ctx := context.Background()
+			if tt.requestID != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadata, tt.requestID)
+			}
+			var header metadata.MD
+			_, err := client.ProcessMatrix(ctx, &pb.ProcessMatrixRequest{Operation: "sum",
+				Input: &pb.ProcessMatrixRequest_File{File: "testdata/matrix1.csv"}}, grpc.Header(&header))
+
+			require.NoError(t, err)
+			if tt.want != "" {
+				assert.Equal(t, tt.want, got)
+			} else {
+				// A random ID is generated instead
+				assert.Regexp(t, "^[0-9a-f]{32}$", got)
+			}
+			assert.Equal(t, []string{got}, header.Get(requestIDMetadata))
+		})
+	}
+}
+
+func TestServer_Deadline(t *testing.T) {
+	// A call sent without a deadline runs under the operation timeout
+	matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+	matrixDomain.On("ProcessMatrix", mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && time.Until(deadline) <= 25*time.Second
+	}), "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(&entity.Result{Scalar: "378"}, nil)
+	client := newTestClient(t, matrixDomain, newTenantDomain(t, false))
+
+	_, err := client.ProcessMatrix(context.Background(), &pb.ProcessMatrixRequest{Operation: "sum",
+		Input: &pb.ProcessMatrixRequest_File{File: "testdata/matrix1.csv"}})
+	require.NoError(t, err)
+}
+
+func TestServer_Recover(t *testing.T) {
+	matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+	matrixDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+		Run(func(mock.Arguments) { panic("boom") })
+	matrixDomain.On("DescribeOperations").Return([]entity.OperationInfo{})
+	client := newTestClient(t, matrixDomain, newTenantDomain(t, false))
+
+	_, err := client.ProcessMatrix(context.Background(), &pb.ProcessMatrixRequest{Operation: "sum",
+		Input: &pb.ProcessMatrixRequest_File{File: "testdata/matrix1.csv"}})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// The server keeps serving
+	_, err = client.ListOperations(context.Background(), &pb.ListOperationsRequest{})
+	require.NoError(t, err)
+}
+
+func TestServer_Shed(t *testing.T) {
+	matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+	matrixDomain.On("DescribeOperations").Return([]entity.OperationInfo{})
+	memoryGuardDomain := mocks.NewMockMemoryGuardDomainInterface(t)
+	memoryGuardDomain.On("Admit", mock.Anything).
+		Return(fmt.Errorf("%w: memory pressure", apperrors.ErrServiceUnavailable))
+	deps := newServerDeps(t, matrixDomain, newTenantDomain(t, false))
+	deps.memoryGuardDomain = memoryGuardDomain
+	_, conn := deps.serve(t)
+	client := pb.NewMatrixServiceClient(conn)
+
+	_, err := client.ProcessMatrix(context.Background(), &pb.ProcessMatrixRequest{Operation: "sum",
+		Input: &pb.ProcessMatrixRequest_File{File: "testdata/matrix1.csv"}})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	require.NotNil(t, retryInfo)
+	assert.Equal(t, memoryRetryAfter, retryInfo.GetRetryDelay().AsDuration())
+
+	// Calls running no operation are not shed
+	_, err = client.ListOperations(context.Background(), &pb.ListOperationsRequest{})
+	require.NoError(t, err)
+}
+
+func TestServer_AuditAndMetrics(t *testing.T) {
+	acme := &tenant.Tenant{ID: "acme"}
+	matrixDomain := mocks.NewMockMatrixDomainInterface(t)
+	matrixDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/tenants/acme/matrix.csv", (*entity.Selection)(nil)).
+		Return(&entity.Result{Scalar: "10"}, nil)
+	tenantDomain := newTenantDomain(t, true)
+	tenantDomain.On("Authenticate", mock.Anything, "acme-key").Return(acme, nil)
+	tenantDomain.On("Authenticate", mock.Anything, "").Return(nil, apperrors.ErrUnauthorized)
+	tenantDomain.On("AllowRequest", mock.Anything, acme, "acme-key").Return(nil, nil)
+	tenantDomain.On("RecordUsage", mock.Anything, acme, "acme-key", mock.Anything).Return(nil)
+	deps := newServerDeps(t, matrixDomain, tenantDomain)
+	_, conn := deps.serve(t)
+	client := pb.NewMatrixServiceClient(conn)
+	request := &pb.ProcessMatrixRequest{Operation: "sum",
+		Input: &pb.ProcessMatrixRequest_File{File: "testdata/tenants/acme/matrix.csv"}}
+
+	_, err := client.ProcessMatrix(context.Background(), request)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), apiKeyMetadata, "acme-key")
+	_, err = client.ProcessMatrix(ctx, request)
+	require.NoError(t, err)
+
+	entries, err := deps.auditDomain.ListEntries(context.Background(), &entity.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	served, denied := entries[0], entries[1]
+	assert.Equal(t, entity.AuditRequest, served.Event)
+	assert.Equal(t, "tenant:acme", served.Actor)
+	assert.Equal(t, pb.MatrixService_ProcessMatrix_FullMethodName, served.Path)
+	assert.Equal(t, "sum", served.Operation)
+	assert.Equal(t, "testdata/tenants/acme/matrix.csv", served.FilePath)
+	assert.Equal(t, http.StatusOK, served.Status)
+	assert.Equal(t, entity.AuditDenied, denied.Event)
+	assert.Equal(t, http.StatusUnauthorized, denied.Status)
+
+	var calls uint64
+	for _, metric := range deps.metricsDomain.Snapshot().Metrics {
+		if metric.Name != "rpc.server.call.duration" {
+			continue
+		}
+		for _, point := range metric.Points {
+			assert.Equal(t, pb.MatrixService_ProcessMatrix_FullMethodName, point.Attributes["rpc.method"])
+			calls += point.Count
+		}
+	}
+	assert.Equal(t, uint64(2), calls)
+}
+
+func TestServer_Health(t *testing.T) {
+	// Health checks carry no API key, even when tenants are configured
+	server, conn := newTestConn(t, mocks.NewMockMatrixDomainInterface(t), newTenantDomain(t, true))
+	client := healthpb.NewHealthClient(conn)
+
+	for _, serving := range []bool{false, true, false} {
+		server.SetServing(serving)
+		want := healthpb.HealthCheckResponse_NOT_SERVING
+		if serving {
+			want = healthpb.HealthCheckResponse_SERVING
+		}
+		for _, service := range []string{"", pb.MatrixService_ServiceDesc.ServiceName} {
+			got, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			assert.Equal(t, want, got.GetStatus(), service)
+		}
+	}
+}
+
+func TestServer_Reflection(t *testing.T) {
+	// Reflection is a streaming service, which needs no API key either
+	_, conn := newTestConn(t, mocks.NewMockMatrixDomainInterface(t), mocks.NewMockTenantDomainInterface(t))
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	response, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range response.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, pb.MatrixService_ServiceDesc.ServiceName)
+	assert.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+}
+
+func TestStatusCode(t *testing.T) {
+	for err, want := range map[error]codes.Code{
+		apperrors.ErrInvalidInput:                                      codes.InvalidArgument,
+		apperrors.ErrForbidden:                                         codes.PermissionDenied,
+		apperrors.ErrFileTooLarge:                                      codes.ResourceExhausted,
+		apperrors.ErrTooManyRequests:                                   codes.ResourceExhausted,
+		fmt.Errorf("%w: 2 and 3 rows", apperrors.ErrDimensionMismatch): codes.FailedPrecondition,
+		apperrors.ErrOverflow:                                          codes.OutOfRange,
+		apperrors.ErrServiceUnavailable:                                codes.Unavailable,
+		fmt.Errorf("reading matrix: %w", context.DeadlineExceeded):     codes.DeadlineExceeded,
+		context.Canceled:                                               codes.Canceled,
+		fmt.Errorf("boom"):                                             codes.Internal,
+	} {
+		assert.Equal(t, want, statusCode(err), err.Error())
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	for code, want := range map[codes.Code]int{
+		codes.OK:                http.StatusOK,
+		codes.InvalidArgument:   http.StatusBadRequest,
+		codes.Unauthenticated:   http.StatusUnauthorized,
+		codes.OutOfRange:        http.StatusUnprocessableEntity,
+		codes.ResourceExhausted: http.StatusTooManyRequests,
+		codes.Unavailable:       http.StatusServiceUnavailable,
+		codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+		codes.Canceled:          499,
+		codes.DataLoss:          http.StatusInternalServerError,
+	} {
+		assert.Equal(t, want, httpStatus(code), code.String())
+	}
+}
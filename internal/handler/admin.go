@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// adminActor identifies administrators in the audit log.
+const adminActor = "admin"
+
+// AdminHandlerInterface defines the contract for the middleware that guards the operator endpoints under /admin/.
+type AdminHandlerInterface interface {
+	// RequireAdmin wraps next so every request must present the admin token as an "Authorization: Bearer" token.
+	// Requests without it are rejected with 401 Unauthorized. When no admin token is configured,
+	// the admin endpoints are disabled and respond with 404 Not Found. The token is looked up
+	// on every request, so a rotated token takes effect without a restart.
+	RequireAdmin(next http.Handler) http.Handler
+}
+
+type adminHandler struct {
+	secretDomain domain.SecretDomainInterface
+}
+
+// NewAdminHandler creates a new instance of AdminHandlerInterface.
+// It initializes the middleware with the secret domain holding the ADMIN_TOKEN administrators
+// authenticate with; the admin endpoints are disabled while it is not set.
+func NewAdminHandler(secretDomain domain.SecretDomainInterface) AdminHandlerInterface {
+	return &adminHandler{
+		secretDomain: secretDomain,
+	}
+}
+
+func (h *adminHandler) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adminToken, err := h.secretDomain.Secret(r.Context(), domain.AdminTokenSecret)
+		if err != nil {
+			statusCode := apperrors.GetHTTPStatusCode(err)
+			slog.ErrorContext(r.Context(), "failed to read admin token",
+				"error", err,
+				"status_code", statusCode)
+			httpErrorFrom(w, r, err, statusCode)
+			return
+		}
+		if adminToken == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(adminToken)) != 1 {
+			slog.WarnContext(r.Context(), "admin request rejected",
+				"path", r.URL.Path,
+				"remote_addr", r.RemoteAddr)
+			audit.Deny(r.Context(), "missing or invalid admin token")
+			w.Header().Set("WWW-Authenticate", `Bearer realm="league-matrix-admin"`)
+			httpError(w, r, "unauthorized: missing or invalid admin token", http.StatusUnauthorized)
+			return
+		}
+
+		audit.SetActor(r.Context(), adminActor)
+		next.ServeHTTP(w, r)
+	})
+}
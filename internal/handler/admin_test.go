@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestAdminHandler_RequireAdmin(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name          string
+		token         string
+		tokenErr      error
+		authorization string
+		wantStatus    int
+		wantActor     string
+		wantDenial    bool
+	}{
+		{name: "valid token", token: "secret", authorization: "Bearer secret", wantStatus: http.StatusNoContent, wantActor: "admin"},
+		{name: "lowercase scheme", token: "secret", authorization: "bearer secret", wantStatus: http.StatusNoContent, wantActor: "admin"},
+		{name: "wrong token", token: "secret", authorization: "Bearer guess", wantStatus: http.StatusUnauthorized, wantDenial: true},
+		{name: "missing token", token: "secret", wantStatus: http.StatusUnauthorized, wantDenial: true},
+		{name: "other scheme", token: "secret", authorization: "Basic secret", wantStatus: http.StatusUnauthorized, wantDenial: true},
+		{name: "admin endpoints disabled", token: "", authorization: "Bearer ", wantStatus: http.StatusNotFound},
+		{
+			name: "secret store unavailable", tokenErr: apperrors.ErrServiceUnavailable, authorization: "Bearer secret",
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trail := &audit.Trail{}
+			req := httptest.NewRequest(http.MethodGet, "/admin/audit", nil)
+			req = req.WithContext(audit.NewContext(req.Context(), trail))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			mockSecrets := mocks.NewMockSecretDomainInterface(t)
+			mockSecrets.On("Secret", mock.Anything, domain.AdminTokenSecret).Return(tt.token, tt.tokenErr)
+
+			NewAdminHandler(mockSecrets).RequireAdmin(next).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantActor, trail.Details().Actor)
+			assert.Equal(t, tt.wantDenial, trail.Details().Denial != "")
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="league-matrix-admin"`, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// AggregateHandlerInterface defines the contract for HTTP handlers that run an operation on several matrix files.
+type AggregateHandlerInterface interface {
+	// Aggregate handles GET /matrix/aggregate?dir=...&op=... requests. It runs the operation on every
+	// matrix file directly inside the directory and responds with a JSON body holding one entry per file, each
+	// with its own status code and either the result or the error message, and the combined result of sum and multiply.
+	// With repeated file parameters instead of dir (file=...&file=...&op=...), the operation runs on the listed files.
+	Aggregate(w http.ResponseWriter, r *http.Request)
+}
+
+// aggregateResponse is the JSON body of an aggregate. Dir is omitted for an aggregate of listed files.
+// Result combines the results of the files that succeeded and is omitted for operations producing matrices.
+// Files is never null.
+type aggregateResponse struct {
+	Operation string                 `json:"operation"`
+	Dir       string                 `json:"dir,omitempty"`
+	Result    string                 `json:"result,omitempty"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Files     []archiveManifestEntry `json:"files"`
+}
+
+type aggregateHandler struct {
+	aggregateDomain domain.AggregateDomainInterface
+}
+
+// NewAggregateHandler creates a new instance of AggregateHandlerInterface with its dependencies.
+// It initializes the handler with the domain service that runs operations on several files.
+func NewAggregateHandler(aggregateDomain domain.AggregateDomainInterface) AggregateHandlerInterface {
+	return &aggregateHandler{
+		aggregateDomain: aggregateDomain,
+	}
+}
+
+func (h *aggregateHandler) Aggregate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	operation := r.URL.Query().Get("op")
+	dir := r.URL.Query().Get("dir")
+	files := r.URL.Query()["file"]
+	audit.Describe(r.Context(), operation, cmp.Or(dir, strings.Join(files, ",")))
+
+	var aggregate *entity.Aggregate
+	var err error
+	switch {
+	case dir != "" && len(files) > 0:
+		err = fmt.Errorf("%w: dir and file parameters cannot be combined", apperrors.ErrInvalidInput)
+	case len(files) > 0:
+		aggregate, err = h.aggregateDomain.AggregateFiles(r.Context(), operation, files)
+	default:
+		aggregate, err = h.aggregateDomain.AggregateDirectory(r.Context(), operation, dir)
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			slog.InfoContext(r.Context(), "request cancelled by client", "dir", dir)
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.ErrorContext(r.Context(), "request timeout", "dir", dir)
+			httpError(w, r, "request timeout", http.StatusGatewayTimeout)
+			return
+		}
+
+		statusCode := apperrors.GetHTTPStatusCode(err)
+		slog.ErrorContext(r.Context(), "aggregate failed",
+			"dir", dir,
+			"error", err,
+			"status_code", statusCode)
+		httpErrorFrom(w, r, err, statusCode)
+		return
+	}
+
+	response := aggregateResponse{
+		Operation: aggregate.Operation,
+		Dir:       dir,
+		Result:    aggregate.Total,
+		Files:     make([]archiveManifestEntry, 0, len(aggregate.Files)),
+	}
+	for _, result := range aggregate.Files {
+		if result.Err != nil {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		response.Files = append(response.Files, toManifestEntry(result))
+	}
+
+	slog.InfoContext(r.Context(), "aggregate completed",
+		"dir", dir,
+		"files", len(response.Files),
+		"failed", response.Failed)
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// toManifestEntry reports the outcome of an operation on one file, with the status code of its error.
+func toManifestEntry(result *entity.FileResult) archiveManifestEntry {
+	entry := archiveManifestEntry{File: result.File, Status: http.StatusOK}
+	if result.Err != nil {
+		entry.Status = apperrors.GetHTTPStatusCode(result.Err)
+		entry.Error = result.Err.Error()
+	} else {
+		entry.Result = result.Result.String()
+	}
+	return entry
+}
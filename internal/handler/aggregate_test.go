@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestAggregateHandler_Aggregate(t *testing.T) {
+	t.Run("per-file results and total", func(t *testing.T) {
+		mockDomain := mocks.NewMockAggregateDomainInterface(t)
+		mockDomain.On("AggregateDirectory", mock.Anything, "sum", "testdata").Return(&entity.Aggregate{
+			Operation: "sum",
+			Files: []*entity.FileResult{
+				{File: "testdata/matrix1.csv", Result: &entity.Result{Scalar: "378"}},
+				{File: "testdata/matrix3.csv", Err: apperrors.ErrUnprocessableEntity},
+				{File: "testdata/matrix4.csv", Result: &entity.Result{Scalar: "10"}},
+			},
+			Total: "388",
+		}, nil)
+
+		handler := &aggregateHandler{aggregateDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.Aggregate(w, httptest.NewRequest(http.MethodGet, "/matrix/aggregate?dir=testdata&op=sum", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"operation":"sum","dir":"testdata","result":"388","succeeded":2,"failed":1,"files":[
+			{"file":"testdata/matrix1.csv","status":200,"result":"378"},
+			{"file":"testdata/matrix3.csv","status":422,"error":"unprocessable entity"},
+			{"file":"testdata/matrix4.csv","status":200,"result":"10"}]}`, w.Body.String())
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		mockDomain := mocks.NewMockAggregateDomainInterface(t)
+		mockDomain.On("AggregateDirectory", mock.Anything, "echo", "testdata/empty").
+			Return(&entity.Aggregate{Operation: "echo"}, nil)
+
+		handler := &aggregateHandler{aggregateDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.Aggregate(w, httptest.NewRequest(http.MethodGet, "/matrix/aggregate?dir=testdata/empty&op=echo", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"operation":"echo","dir":"testdata/empty","succeeded":0,"failed":0,"files":[]}`, w.Body.String())
+	})
+
+	t.Run("listed files", func(t *testing.T) {
+		mockDomain := mocks.NewMockAggregateDomainInterface(t)
+		mockDomain.On("AggregateFiles", mock.Anything, "sum", []string{"testdata/matrix1.csv", "testdata/matrix4.csv"}).
+			Return(&entity.Aggregate{
+				Operation: "sum",
+				Files: []*entity.FileResult{
+					{File: "testdata/matrix1.csv", Result: &entity.Result{Scalar: "378"}},
+					{File: "testdata/matrix4.csv", Result: &entity.Result{Scalar: "10"}},
+				},
+				Total: "388",
+			}, nil)
+
+		handler := &aggregateHandler{aggregateDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.Aggregate(w, httptest.NewRequest(http.MethodGet,
+			"/matrix/aggregate?op=sum&file=testdata/matrix1.csv&file=testdata/matrix4.csv", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"operation":"sum","result":"388","succeeded":2,"failed":0,"files":[
+			{"file":"testdata/matrix1.csv","status":200,"result":"378"},
+			{"file":"testdata/matrix4.csv","status":200,"result":"10"}]}`, w.Body.String())
+	})
+
+	t.Run("directory and listed files", func(t *testing.T) {
+		handler := &aggregateHandler{aggregateDomain: mocks.NewMockAggregateDomainInterface(t)}
+		w := httptest.NewRecorder()
+		handler.Aggregate(w, httptest.NewRequest(http.MethodGet,
+			"/matrix/aggregate?op=sum&dir=testdata&file=testdata/matrix1.csv", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		mockDomain := mocks.NewMockAggregateDomainInterface(t)
+		mockDomain.On("AggregateDirectory", mock.Anything, "divide", "testdata").Return(nil, apperrors.ErrInvalidInput)
+
+		handler := &aggregateHandler{aggregateDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.Aggregate(w, httptest.NewRequest(http.MethodGet, "/matrix/aggregate?dir=testdata&op=divide", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("cancelled request", func(t *testing.T) {
+		mockDomain := mocks.NewMockAggregateDomainInterface(t)
+		mockDomain.On("AggregateDirectory", mock.Anything, "sum", "testdata").Return(nil, context.Canceled)
+
+		handler := &aggregateHandler{aggregateDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.Aggregate(w, httptest.NewRequest(http.MethodGet, "/matrix/aggregate?dir=testdata&op=sum", nil))
+
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		handler := &aggregateHandler{aggregateDomain: mocks.NewMockAggregateDomainInterface(t)}
+		w := httptest.NewRecorder()
+		handler.Aggregate(w, httptest.NewRequest(http.MethodPost, "/matrix/aggregate?dir=testdata&op=sum", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
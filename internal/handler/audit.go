@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// auditRecordTimeout bounds how long recording a request may take once it has been served.
+const auditRecordTimeout = 5 * time.Second
+
+// AuditHandlerInterface defines the contract for the HTTP side of the audit log:
+// the middleware that records every request and the admin endpoint that retrieves the entries.
+type AuditHandlerInterface interface {
+	// Audit wraps next so every request is recorded in the audit log once it has been served,
+	// with who made it, the operation it ran, its status and duration, and why it was denied, if it was.
+	Audit(next http.Handler) http.Handler
+
+	// ListAuditEntries handles GET /admin/audit requests, returning the latest entries as JSON, newest first.
+	// The actor, event, operation, since, until and limit query parameters narrow the entries returned;
+	// since and until are RFC 3339 times.
+	ListAuditEntries(w http.ResponseWriter, r *http.Request)
+}
+
+// auditEntryResponse describes an audit log entry.
+type auditEntryResponse struct {
+	ID         string            `json:"id"`
+	Time       time.Time         `json:"time"`
+	Event      string            `json:"event"`
+	Actor      string            `json:"actor,omitempty"`
+	RemoteAddr string            `json:"remote_addr,omitempty"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Operation  string            `json:"operation,omitempty"`
+	File       string            `json:"file,omitempty"`
+	Status     int               `json:"status"`
+	DurationMS float64           `json:"duration_ms"`
+	Reason     string            `json:"reason,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+}
+
+type auditListResponse struct {
+	Entries []auditEntryResponse `json:"entries"`
+}
+
+type auditHandler struct {
+	auditDomain domain.AuditDomainInterface
+}
+
+// NewAuditHandler creates a new instance of AuditHandlerInterface with its dependencies.
+// It initializes the handler with the audit domain service that stores and queries the audit log.
+func NewAuditHandler(auditDomain domain.AuditDomainInterface) AuditHandlerInterface {
+	return &auditHandler{
+		auditDomain: auditDomain,
+	}
+}
+
+func (h *auditHandler) Audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		trail := &audit.Trail{}
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r.WithContext(audit.NewContext(r.Context(), trail)))
+
+		details := trail.Details()
+		entry := &entity.AuditEntry{
+			Time:       start,
+			Event:      entity.AuditRequest,
+			Actor:      details.Actor,
+			RemoteAddr: r.RemoteAddr,
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Operation:  details.Operation,
+			FilePath:   details.FilePath,
+			Status:     recorder.status(),
+			Duration:   time.Since(start),
+			Reason:     details.Denial,
+		}
+		if details.Denial != "" {
+			entry.Event = entity.AuditDenied
+		}
+
+		// The entry is recorded even when the client has gone away
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), auditRecordTimeout)
+		defer cancel()
+		if err := h.auditDomain.Record(ctx, entry); err != nil {
+			slog.ErrorContext(r.Context(), "failed to record audit entry",
+				"path", entry.Path,
+				"status_code", entry.Status,
+				"error", err)
+		}
+	})
+}
+
+func (h *auditHandler) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	entries, err := h.auditDomain.ListEntries(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := auditListResponse{Entries: make([]auditEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, auditEntryResponse{
+			ID:         entry.ID,
+			Time:       entry.Time,
+			Event:      string(entry.Event),
+			Actor:      entry.Actor,
+			RemoteAddr: entry.RemoteAddr,
+			Method:     entry.Method,
+			Path:       entry.Path,
+			Operation:  entry.Operation,
+			File:       entry.FilePath,
+			Status:     entry.Status,
+			DurationMS: float64(entry.Duration) / float64(time.Millisecond),
+			Reason:     entry.Reason,
+			Tags:       entry.Tags,
+		})
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func (h *auditHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "audit request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// parseAuditFilter reads the audit log filter from the query parameters of r.
+func parseAuditFilter(r *http.Request) (*entity.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := &entity.AuditFilter{
+		Actor:     query.Get("actor"),
+		Event:     entity.AuditEvent(query.Get("event")),
+		Operation: query.Get("operation"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{
+		{name: "since", target: &filter.Since},
+		{name: "until", target: &filter.Until},
+	} {
+		if value := query.Get(param.name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid %s parameter: %q", apperrors.ErrInvalidInput, param.name, value)
+			}
+			*param.target = parsed
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("%w: invalid limit parameter: %q", apperrors.ErrInvalidInput, value)
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
+// statusRecorder remembers the status code written through it, so the audit log can record it.
+// It keeps the streaming and WebSocket endpoints working by passing flushes and hijacks through.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if s.code == 0 {
+		s.code = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(p []byte) (int, error) {
+	if s.code == 0 {
+		s.code = http.StatusOK
+	}
+	return s.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, so streamed responses are still flushed.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// Hijack hands the connection over to the WebSocket handler, which needs an http.Hijacker.
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(s.ResponseWriter).Hijack()
+	if err == nil {
+		s.code = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// status returns the status code of the response, which is 200 when the handler wrote nothing.
+func (s *statusRecorder) status() int {
+	if s.code == 0 {
+		return http.StatusOK
+	}
+	return s.code
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestAuditHandler_Audit(t *testing.T) {
+	tests := []struct {
+		name      string
+		next      http.HandlerFunc
+		wantEntry entity.AuditEntry
+	}{
+		{
+			name: "operation request",
+			next: func(w http.ResponseWriter, r *http.Request) {
+				audit.SetActor(r.Context(), "tenant:acme")
+				audit.Describe(r.Context(), "sum", "testdata/matrix1.csv")
+				w.Write([]byte("45"))
+			},
+			wantEntry: entity.AuditEntry{
+				Event:     entity.AuditRequest,
+				Actor:     "tenant:acme",
+				Operation: "sum",
+				FilePath:  "testdata/matrix1.csv",
+				Status:    http.StatusOK,
+			},
+		},
+		{
+			name: "denied request",
+			next: func(w http.ResponseWriter, r *http.Request) {
+				audit.Deny(r.Context(), "unknown API key")
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			},
+			wantEntry: entity.AuditEntry{
+				Event:  entity.AuditDenied,
+				Status: http.StatusUnauthorized,
+				Reason: "unknown API key",
+			},
+		},
+		{
+			name:      "handler writing nothing",
+			next:      func(w http.ResponseWriter, r *http.Request) {},
+			wantEntry: entity.AuditEntry{Event: entity.AuditRequest, Status: http.StatusOK},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockAuditDomainInterface(t)
+			var recorded *entity.AuditEntry
+			mockDomain.On("Record", mock.Anything, mock.AnythingOfType("*entity.AuditEntry")).
+				Run(func(args mock.Arguments) { recorded = args.Get(1).(*entity.AuditEntry) }).
+				Return(nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil)
+			w := httptest.NewRecorder()
+
+			NewAuditHandler(mockDomain).Audit(tt.next).ServeHTTP(w, req)
+
+			if assert.NotNil(t, recorded) {
+				assert.Equal(t, tt.wantEntry.Event, recorded.Event)
+				assert.Equal(t, tt.wantEntry.Actor, recorded.Actor)
+				assert.Equal(t, tt.wantEntry.Operation, recorded.Operation)
+				assert.Equal(t, tt.wantEntry.FilePath, recorded.FilePath)
+				assert.Equal(t, tt.wantEntry.Status, recorded.Status)
+				assert.Equal(t, tt.wantEntry.Reason, recorded.Reason)
+				assert.Equal(t, http.MethodGet, recorded.Method)
+				assert.Equal(t, "/matrix/sum", recorded.Path)
+				assert.Equal(t, req.RemoteAddr, recorded.RemoteAddr)
+				assert.False(t, recorded.Time.IsZero())
+			}
+		})
+	}
+
+	t.Run("recording failure does not affect the response", func(t *testing.T) {
+		mockDomain := mocks.NewMockAuditDomainInterface(t)
+		mockDomain.On("Record", mock.Anything, mock.Anything).Return(errors.New("disk full"))
+
+		req := httptest.NewRequest(http.MethodGet, "/matrix", nil)
+		w := httptest.NewRecorder()
+
+		NewAuditHandler(mockDomain).Audit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		})).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+}
+
+func TestAuditHandler_ListAuditEntries(t *testing.T) {
+	entryTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		wantFilter *entity.AuditFilter
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "every entry",
+			method:     http.MethodGet,
+			wantFilter: &entity.AuditFilter{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "filtered entries",
+			method: http.MethodGet,
+			query:  "?actor=tenant:acme&event=denied&operation=sum&since=2026-01-01T00:00:00Z&until=2026-01-02T00:00:00Z&limit=10",
+			wantFilter: &entity.AuditFilter{
+				Actor:     "tenant:acme",
+				Event:     entity.AuditDenied,
+				Operation: "sum",
+				Since:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:     time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				Limit:     10,
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid since",
+			method:     http.MethodGet,
+			query:      "?since=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid limit",
+			method:     http.MethodGet,
+			query:      "?limit=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "domain rejects the filter",
+			method:     http.MethodGet,
+			query:      "?event=login",
+			wantFilter: &entity.AuditFilter{Event: "login"},
+			mockError:  apperrors.ErrInvalidInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockAuditDomainInterface(t)
+			if tt.wantFilter != nil {
+				var entries []*entity.AuditEntry
+				if tt.mockError == nil {
+					entries = []*entity.AuditEntry{{
+						ID:        "abc",
+						Time:      entryTime,
+						Event:     entity.AuditRequest,
+						Actor:     "tenant:acme",
+						Method:    http.MethodGet,
+						Path:      "/matrix/sum",
+						Operation: "sum",
+						Status:    http.StatusOK,
+						Duration:  1500 * time.Microsecond,
+					}}
+				}
+				mockDomain.On("ListEntries", mock.Anything, tt.wantFilter).Return(entries, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/admin/audit"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewAuditHandler(mockDomain).ListAuditEntries(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var got auditListResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, []auditEntryResponse{{
+					ID:         "abc",
+					Time:       entryTime,
+					Event:      "request",
+					Actor:      "tenant:acme",
+					Method:     http.MethodGet,
+					Path:       "/matrix/sum",
+					Operation:  "sum",
+					Status:     http.StatusOK,
+					DurationMS: 1.5,
+				}}, got.Entries)
+			}
+		})
+	}
+}
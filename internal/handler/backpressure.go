@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// BackpressureHandlerInterface defines the contract for the middleware that sheds load while the workers are saturated.
+// Rejected requests are answered 503 Service Unavailable with a Retry-After header telling when the work ahead
+// of them should be done, instead of waiting until they time out.
+type BackpressureHandlerInterface interface {
+	// ShedOperations wraps next, a route running matrix operations, so its requests are rejected
+	// while as many operations are in progress as the execution pool can serve in time.
+	ShedOperations(next http.Handler) http.Handler
+
+	// ShedJobs wraps next, the route submitting jobs, so submissions are rejected while the job queue is full.
+	ShedJobs(next http.Handler) http.Handler
+}
+
+type backpressureHandler struct {
+	backpressureDomain domain.BackpressureDomainInterface
+}
+
+// NewBackpressureHandler creates a new instance of BackpressureHandlerInterface with its dependencies.
+// It initializes the middleware with the domain service that tracks the work queued for the workers.
+func NewBackpressureHandler(backpressureDomain domain.BackpressureDomainInterface) BackpressureHandlerInterface {
+	return &backpressureHandler{
+		backpressureDomain: backpressureDomain,
+	}
+}
+
+func (h *backpressureHandler) ShedOperations(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		release, retryAfter, err := h.backpressureDomain.AdmitOperation(r.Context())
+		if err != nil {
+			h.writeError(w, r, retryAfter, err)
+			return
+		}
+		defer release()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *backpressureHandler) ShedJobs(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only submissions are queued; other methods are left to next to reject
+		if r.Method == http.MethodPost {
+			if retryAfter, err := h.backpressureDomain.AdmitJob(r.Context()); err != nil {
+				h.writeError(w, r, retryAfter, err)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeError answers a request that was not admitted, telling when to retry when it was rejected under overload.
+func (h *backpressureHandler) writeError(w http.ResponseWriter, r *http.Request, retryAfter time.Duration, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.WarnContext(r.Context(), "request shed",
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+		"error", err,
+		"retry_after", retryAfter,
+		"status_code", statusCode)
+
+	if statusCode == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter/time.Second)))
+	}
+	httpErrorFrom(w, r, err, statusCode)
+}
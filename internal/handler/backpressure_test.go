@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestBackpressureHandler_ShedOperations(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockRetryAfter time.Duration
+		mockError      error
+		wantStatus     int
+		wantBody       string
+		wantRetryAfter string
+	}{
+		{
+			name:       "admitted",
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:           "shed under overload",
+			mockRetryAfter: 12 * time.Second,
+			mockError:      fmt.Errorf("%w: too many operations in progress, retry in 12s", apperrors.ErrServiceUnavailable),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantBody:       "service unavailable: too many operations in progress, retry in 12s\n",
+			wantRetryAfter: "12",
+		},
+		{
+			name:       "cancelled request",
+			mockError:  context.Canceled,
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "context canceled\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			served, released := false, false
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				// The place of the request is held while it is served
+				assert.False(t, released)
+				served = true
+				fmt.Fprint(w, "ok")
+			})
+
+			release := func() { released = true }
+			if tt.mockError != nil {
+				release = nil
+			}
+			mockDomain := mocks.NewMockBackpressureDomainInterface(t)
+			mockDomain.On("AdmitOperation", mock.Anything).Return(release, tt.mockRetryAfter, tt.mockError)
+
+			w := httptest.NewRecorder()
+			NewBackpressureHandler(mockDomain).ShedOperations(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+			assert.Equal(t, tt.wantRetryAfter, w.Header().Get("Retry-After"))
+			assert.Equal(t, tt.mockError == nil, served)
+			assert.Equal(t, tt.mockError == nil, released)
+		})
+	}
+}
+
+func TestBackpressureHandler_ShedJobs(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		mockRetryAfter time.Duration
+		mockError      error
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{name: "admitted", method: http.MethodPost, wantStatus: http.StatusOK},
+		{
+			name:           "full queue",
+			method:         http.MethodPost,
+			mockRetryAfter: 30 * time.Second,
+			mockError:      fmt.Errorf("%w: job queue is full, retry in 30s", apperrors.ErrServiceUnavailable),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantRetryAfter: "30",
+		},
+		{name: "other methods are not checked", method: http.MethodGet, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockBackpressureDomainInterface(t)
+			if tt.method == http.MethodPost {
+				mockDomain.On("AdmitJob", mock.Anything).Return(tt.mockRetryAfter, tt.mockError)
+			}
+
+			w := httptest.NewRecorder()
+			NewBackpressureHandler(mockDomain).ShedJobs(next).ServeHTTP(w, httptest.NewRequest(tt.method, "/jobs", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// batchContentType is the media type required by the batch operations endpoint.
+const batchContentType = "application/json"
+
+// maxBatchBodyBytes limits the size of the JSON body of a batch request, which lists up to 100 items.
+const maxBatchBodyBytes = 64 << 10
+
+// batchOperation names batch requests in the audit log.
+const batchOperation = "batch"
+
+// BatchHandlerInterface defines the contract for HTTP handlers that run several operations in one request.
+type BatchHandlerInterface interface {
+	// ProcessBatch handles POST /matrix/batch requests. The JSON body lists the items to run, such as
+	// {"items":[{"operation":"sum","file":"testdata/matrix1.csv"}]}, and the response holds one entry per item,
+	// in the order they are listed, each with its own status code and either the result or the error message.
+	ProcessBatch(w http.ResponseWriter, r *http.Request)
+}
+
+// batchRequest is the JSON body of a batch request.
+type batchRequest struct {
+	Items []batchRequestItem `json:"items"`
+}
+
+// batchRequestItem is one item of a batch request.
+type batchRequestItem struct {
+	Operation string `json:"operation"`
+	File      string `json:"file"`
+}
+
+// batchResponse is the JSON body of a batch response. Items is never null.
+type batchResponse struct {
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Items     []batchResponseItem `json:"items"`
+}
+
+// batchResponseItem reports the outcome of one item of a batch request. Failed items carry the error code
+// the HTTP API would have returned along with the error message.
+type batchResponseItem struct {
+	Operation string         `json:"operation"`
+	File      string         `json:"file"`
+	Status    int            `json:"status"`
+	Result    string         `json:"result,omitempty"`
+	Code      apperrors.Code `json:"code,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+type batchHandler struct {
+	batchDomain domain.BatchDomainInterface
+}
+
+// NewBatchHandler creates a new instance of BatchHandlerInterface with its dependencies.
+// It initializes the handler with the domain service that runs the items of a batch.
+func NewBatchHandler(batchDomain domain.BatchDomainInterface) BatchHandlerInterface {
+	return &batchHandler{
+		batchDomain: batchDomain,
+	}
+}
+
+func (h *batchHandler) ProcessBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	items, err := decodeBatchRequest(r)
+	if err != nil {
+		h.writeError(w, r, items, err)
+		return
+	}
+	audit.Describe(r.Context(), batchOperation, describeBatchFiles(items))
+
+	results, err := h.batchDomain.RunBatch(r.Context(), items)
+	if err != nil {
+		h.writeError(w, r, items, err)
+		return
+	}
+	h.writeBatch(w, r, results)
+}
+
+func (h *batchHandler) writeError(w http.ResponseWriter, r *http.Request, items []entity.BatchItem, err error) {
+	if errors.Is(err, context.Canceled) {
+		slog.InfoContext(r.Context(), "request cancelled by client", "items", len(items))
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		slog.ErrorContext(r.Context(), "request timeout", "items", len(items))
+		httpError(w, r, "request timeout", http.StatusGatewayTimeout)
+		return
+	}
+
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "batch failed",
+		"items", len(items),
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// writeBatch writes the results of a batch, counting the items that succeeded and failed.
+func (h *batchHandler) writeBatch(w http.ResponseWriter, r *http.Request, results []*entity.BatchItemResult) {
+	response := batchResponse{
+		Items: make([]batchResponseItem, 0, len(results)),
+	}
+	for _, result := range results {
+		item := batchResponseItem{
+			Operation: result.Item.Operation,
+			File:      result.Item.File,
+			Status:    http.StatusOK,
+		}
+		if result.Err != nil {
+			item.Status = apperrors.GetHTTPStatusCode(result.Err)
+			item.Code = errorCode(result.Err, item.Status)
+			item.Error = result.Err.Error()
+			response.Failed++
+		} else {
+			item.Result = result.Result.String()
+			response.Succeeded++
+		}
+		response.Items = append(response.Items, item)
+	}
+
+	slog.InfoContext(r.Context(), "batch completed",
+		"items", len(response.Items),
+		"failed", response.Failed)
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// decodeBatchRequest reads the items of the JSON batch request sent in the request body.
+func decodeBatchRequest(r *http.Request) ([]entity.BatchItem, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != batchContentType {
+		return nil, fmt.Errorf("%w: batches must be sent as %s", apperrors.ErrUnsupportedMediaType, batchContentType)
+	}
+
+	// Limit the body size BEFORE reading to prevent DoS attacks
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBatchBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("%w: request body too large (maximum: %d bytes)",
+				apperrors.ErrPayloadTooLarge, maxBatchBodyBytes)
+		}
+		return nil, fmt.Errorf("%w: failed to read request body: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	request := &batchRequest{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("%w: invalid batch request: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	items := make([]entity.BatchItem, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, entity.BatchItem{Operation: item.Operation, File: item.File})
+	}
+	return items, nil
+}
+
+// describeBatchFiles lists the distinct files of items for the audit log, in the order they are first listed.
+func describeBatchFiles(items []entity.BatchItem) string {
+	seen := make(map[string]bool, len(items))
+	files := make([]string, 0, len(items))
+	for _, item := range items {
+		if !seen[item.File] {
+			seen[item.File] = true
+			files = append(files, item.File)
+		}
+	}
+	return strings.Join(files, ",")
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newBatchRequest returns a POST /matrix/batch request sending body as JSON.
+func newBatchRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/matrix/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestBatchHandler_ProcessBatch(t *testing.T) {
+	t.Run("per-item results", func(t *testing.T) {
+		items := []entity.BatchItem{
+			{Operation: "sum", File: "testdata/matrix1.csv"},
+			{Operation: "flatten", File: "testdata/matrix4.csv"},
+			{Operation: "sum", File: "testdata/matrix3.csv"},
+		}
+		mockDomain := mocks.NewMockBatchDomainInterface(t)
+		mockDomain.On("RunBatch", mock.Anything, items).Return([]*entity.BatchItemResult{
+			{Item: items[0], Result: &entity.Result{Scalar: "378"}},
+			{Item: items[1], Result: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2, 3, 4}}}}},
+			{Item: items[2], Err: apperrors.ErrUnprocessableEntity},
+		}, nil)
+
+		handler := &batchHandler{batchDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.ProcessBatch(w, newBatchRequest(`{"items":[
+			{"operation":"sum","file":"testdata/matrix1.csv"},
+			{"operation":"flatten","file":"testdata/matrix4.csv"},
+			{"operation":"sum","file":"testdata/matrix3.csv"}]}`))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"succeeded":2,"failed":1,"items":[
+			{"operation":"sum","file":"testdata/matrix1.csv","status":200,"result":"378"},
+			{"operation":"flatten","file":"testdata/matrix4.csv","status":200,"result":"1,2,3,4"},
+			{"operation":"sum","file":"testdata/matrix3.csv","status":422,"code":"UNPROCESSABLE_ENTITY","error":"unprocessable entity"}]}`, w.Body.String())
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		mockDomain := mocks.NewMockBatchDomainInterface(t)
+		mockDomain.On("RunBatch", mock.Anything, []entity.BatchItem{}).
+			Return(nil, apperrors.ErrInvalidInput)
+
+		handler := &batchHandler{batchDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.ProcessBatch(w, newBatchRequest(`{"items":[]}`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		mockDomain := mocks.NewMockBatchDomainInterface(t)
+		mockDomain.On("RunBatch", mock.Anything, mock.Anything).Return(nil, context.DeadlineExceeded)
+
+		handler := &batchHandler{batchDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.ProcessBatch(w, newBatchRequest(`{"items":[{"operation":"sum","file":"testdata/matrix1.csv"}]}`))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	})
+}
+
+func TestBatchHandler_ProcessBatch_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "GET", method: http.MethodGet, contentType: "application/json", wantStatus: http.StatusMethodNotAllowed},
+		{name: "not JSON", method: http.MethodPost, contentType: "text/csv", body: "1,2\n", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed JSON", method: http.MethodPost, contentType: "application/json", body: `{"items":`, wantStatus: http.StatusBadRequest},
+		{name: "body too large", method: http.MethodPost, contentType: "application/json",
+			body: `{"items":[` + strings.Repeat(" ", maxBatchBodyBytes) + `]}`, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Invalid requests never reach the domain
+			handler := &batchHandler{batchDomain: mocks.NewMockBatchDomainInterface(t)}
+			req := httptest.NewRequest(tt.method, "/matrix/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			handler.ProcessBatch(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// CacheHandlerInterface defines the contract for the admin HTTP handlers that drop remembered operation results,
+// so operators can force operations to run again without a restart.
+type CacheHandlerInterface interface {
+	// FlushCache handles POST /admin/cache/flush requests, forgetting every memoized and cached result.
+	// It responds with the number of results removed.
+	FlushCache(w http.ResponseWriter, r *http.Request)
+
+	// InvalidateCache handles POST /admin/cache/invalidate requests, forgetting the results remembered for the
+	// key query parameter: the checksum of a matrix, or the path of a matrix file of the tenant named by the
+	// tenant query parameter, the default tenant when it is omitted. It responds with the number of results removed.
+	InvalidateCache(w http.ResponseWriter, r *http.Request)
+}
+
+// cacheResponse reports how many results a flush or an invalidation removed.
+type cacheResponse struct {
+	Removed int `json:"removed"`
+}
+
+type cacheHandler struct {
+	matrixDomain domain.MatrixDomainInterface
+}
+
+// NewCacheHandler creates a new instance of CacheHandlerInterface with its dependencies.
+// It initializes the handler with the matrix domain service holding the memo and the file cache.
+func NewCacheHandler(matrixDomain domain.MatrixDomainInterface) CacheHandlerInterface {
+	return &cacheHandler{
+		matrixDomain: matrixDomain,
+	}
+}
+
+func (h *cacheHandler) FlushCache(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	removed := h.matrixDomain.FlushCache()
+	slog.InfoContext(r.Context(), "cache flushed", "removed", removed)
+	writeJSON(w, r, http.StatusOK, cacheResponse{Removed: removed})
+}
+
+func (h *cacheHandler) InvalidateCache(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		h.writeError(w, r, fmt.Errorf("%w: key parameter is required", apperrors.ErrInvalidInput))
+		return
+	}
+	tenantID := r.URL.Query().Get("tenant")
+	if tenantID != "" && !tenant.ValidID(tenantID) {
+		h.writeError(w, r, fmt.Errorf("%w: invalid tenant parameter: %q", apperrors.ErrInvalidInput, tenantID))
+		return
+	}
+
+	removed := h.matrixDomain.InvalidateCache(tenant.WithID(r.Context(), tenantID), key)
+	slog.InfoContext(r.Context(), "cache invalidated",
+		"key", key,
+		"tenant_id", tenantID,
+		"removed", removed)
+	writeJSON(w, r, http.StatusOK, cacheResponse{Removed: removed})
+}
+
+func (h *cacheHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "cache request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
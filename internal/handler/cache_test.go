@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+func TestCacheHandler_FlushCache(t *testing.T) {
+	t.Run("flushes the cache", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("FlushCache").Return(12)
+
+		w := httptest.NewRecorder()
+		NewCacheHandler(mockDomain).FlushCache(w, httptest.NewRequest(http.MethodPost, "/admin/cache/flush", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"removed":12}`, w.Body.String())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewCacheHandler(mocks.NewMockMatrixDomainInterface(t)).
+			FlushCache(w, httptest.NewRequest(http.MethodGet, "/admin/cache/flush", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestCacheHandler_InvalidateCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantTenant string
+		wantKey    string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "checksum", method: http.MethodPost, target: "/admin/cache/invalidate?key=3f1c",
+			wantKey: "3f1c", wantStatus: http.StatusOK, wantBody: `{"removed":2}`,
+		},
+		{
+			name: "file of a tenant", method: http.MethodPost, target: "/admin/cache/invalidate?key=league.csv&tenant=acme",
+			wantTenant: "acme", wantKey: "league.csv", wantStatus: http.StatusOK, wantBody: `{"removed":2}`,
+		},
+		{name: "missing key", method: http.MethodPost, target: "/admin/cache/invalidate", wantStatus: http.StatusBadRequest},
+		{
+			name: "invalid tenant", method: http.MethodPost, target: "/admin/cache/invalidate?key=league.csv&tenant=../acme",
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "method not allowed", method: http.MethodGet, target: "/admin/cache/invalidate?key=3f1c", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			if tt.wantKey != "" {
+				mockDomain.On("InvalidateCache", mock.MatchedBy(func(ctx context.Context) bool {
+					return tenant.ID(ctx) == tt.wantTenant
+				}), tt.wantKey).Return(2)
+			}
+
+			w := httptest.NewRecorder()
+			NewCacheHandler(mockDomain).InvalidateCache(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// CatalogHandlerInterface defines the contract for HTTP handlers that find stored matrix files by their metadata.
+type CatalogHandlerInterface interface {
+	// SearchMatrices handles GET /matrices requests, returning the stored matrix files of the caller as JSON.
+	// The name, min_rows, max_rows, min_cols, max_cols and tag query parameters narrow the files returned, sort orders
+	// them by name, created_at, updated_at, size, rows or cols, descending with a leading -, and offset and limit
+	// select a page, whose total and next page are given in the X-Total-Count and Link headers. Each tag parameter,
+	// such as tag=season:2026, or tag=season for any value, selects the files carrying that tag.
+	SearchMatrices(w http.ResponseWriter, r *http.Request)
+
+	// ExportMatrices handles GET /matrices/export requests, streaming a zip archive of the stored matrix files
+	// of the caller, each under its path, with a manifest.json describing them. The filter and sort parameters
+	// of SearchMatrices select and order the files exported; every file matched is exported, without pages.
+	ExportMatrices(w http.ResponseWriter, r *http.Request)
+}
+
+// exportManifestName is the name of the archive entry describing the files of an export.
+const exportManifestName = "manifest.json"
+
+// matrixInfoResponse describes a stored matrix file; Rows and Cols are zero for files that are not valid CSV.
+type matrixInfoResponse struct {
+	File      string            `json:"file"`
+	Name      string            `json:"name"`
+	Rows      int               `json:"rows"`
+	Cols      int               `json:"cols"`
+	Size      int64             `json:"size"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// exportedMatrix describes a file of an export in its manifest, with the SHA-256 of its content
+// so the file can be checked once restored.
+type exportedMatrix struct {
+	matrixInfoResponse
+	SHA256 string `json:"sha256"`
+}
+
+// exportManifest describes the files of an export, in the order they were archived.
+type exportManifest struct {
+	ExportedAt time.Time        `json:"exported_at"`
+	Matrices   []exportedMatrix `json:"matrices"`
+	Total      int              `json:"total"`
+}
+
+type matrixListResponse struct {
+	Matrices []matrixInfoResponse `json:"matrices"`
+	Total    int                  `json:"total"`
+}
+
+type catalogHandler struct {
+	catalogDomain domain.CatalogDomainInterface
+}
+
+// NewCatalogHandler creates a new instance of CatalogHandlerInterface with its dependencies.
+// It initializes the handler with the catalog domain service that searches the stored matrix files.
+func NewCatalogHandler(catalogDomain domain.CatalogDomainInterface) CatalogHandlerInterface {
+	return &catalogHandler{
+		catalogDomain: catalogDomain,
+	}
+}
+
+func (h *catalogHandler) SearchMatrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseMatrixFilter(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	requested, err := parsePage(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if requested != nil {
+		filter.Offset, filter.Limit = requested.offset, requested.limit
+	}
+
+	page, err := h.catalogDomain.Search(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := matrixListResponse{Matrices: make([]matrixInfoResponse, 0, len(page.Matrices)), Total: page.Total}
+	for _, info := range page.Matrices {
+		response.Matrices = append(response.Matrices, newMatrixInfoResponse(info))
+	}
+	setPageHeaders(w, r, &resultPage{offset: page.Offset, limit: page.Limit}, page.Total)
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func (h *catalogHandler) ExportMatrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	audit.Describe(r.Context(), "export", "")
+
+	filter, err := parseMatrixFilter(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	// The archive is streamed as the files are read, so the response starts with the first file
+	// and errors past that point can only cut it short
+	manifest := &exportManifest{ExportedAt: time.Now().UTC(), Matrices: []exportedMatrix{}}
+	archive := zip.NewWriter(w)
+	controller := http.NewResponseController(w)
+	started := false
+	start := func() {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", archiveContentType)
+			w.Header().Set("Content-Disposition",
+				mime.FormatMediaType("attachment", map[string]string{"filename": "matrices-export.zip"}))
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+
+	err = h.catalogDomain.Export(r.Context(), filter, func(info *entity.StoredMatrix, content []byte) error {
+		start()
+		if err := writeArchiveEntry(archive, info.FilePath, info.UpdatedAt, content); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		manifest.Matrices = append(manifest.Matrices, exportedMatrix{
+			matrixInfoResponse: newMatrixInfoResponse(info),
+			SHA256:             hex.EncodeToString(sum[:]),
+		})
+		// Writers that cannot flush still receive the files, just without the early delivery
+		if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
+	if err == nil {
+		start()
+		manifest.Total = len(manifest.Matrices)
+		var encoded []byte
+		encoded, err = json.MarshalIndent(manifest, "", "  ")
+		if err == nil {
+			err = writeArchiveEntry(archive, exportManifestName, manifest.ExportedAt, encoded)
+		}
+		if err == nil {
+			err = archive.Close()
+		}
+	}
+	if err != nil {
+		if !started {
+			h.writeError(w, r, err)
+			return
+		}
+		// Without the central directory written on close, the client sees an invalid archive
+		slog.ErrorContext(r.Context(), "matrix export interrupted",
+			"files", len(manifest.Matrices),
+			"error", err)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "matrices exported",
+		"files", manifest.Total)
+}
+
+func (h *catalogHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "catalog request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// newMatrixInfoResponse describes the stored matrix file info.
+func newMatrixInfoResponse(info *entity.StoredMatrix) matrixInfoResponse {
+	return matrixInfoResponse{
+		File:      info.FilePath,
+		Name:      info.Name,
+		Rows:      info.Rows,
+		Cols:      info.Cols,
+		Size:      info.Size,
+		CreatedAt: info.CreatedAt,
+		UpdatedAt: info.UpdatedAt,
+		Tags:      info.Tags,
+	}
+}
+
+// writeArchiveEntry adds a compressed file named name to archive, modified at modTime unless it is zero.
+func writeArchiveEntry(archive *zip.Writer, name string, modTime time.Time, content []byte) error {
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime})
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(content)
+	return err
+}
+
+// parseMatrixFilter reads the catalog filter, without its page, from the query parameters of r.
+func parseMatrixFilter(r *http.Request) (*entity.MatrixFilter, error) {
+	query := r.URL.Query()
+	filter := &entity.MatrixFilter{
+		Name: query.Get("name"),
+		Sort: query.Get("sort"),
+	}
+
+	tags, err := parseTagQuery(r, false)
+	if err != nil {
+		return nil, err
+	}
+	filter.Tags = tags
+
+	bounds := []struct {
+		name  string
+		value *int
+	}{
+		{"min_rows", &filter.MinRows},
+		{"max_rows", &filter.MaxRows},
+		{"min_cols", &filter.MinCols},
+		{"max_cols", &filter.MaxCols},
+	}
+	for _, bound := range bounds {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: invalid %s parameter: %q", apperrors.ErrInvalidInput, bound.name, value)
+		}
+		*bound.value = n
+	}
+	return filter, nil
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestCatalogHandler_SearchMatrices(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	matrix := &entity.StoredMatrix{
+		FilePath:  "testdata/league.csv",
+		Name:      "league.csv",
+		Rows:      2,
+		Cols:      2,
+		Size:      8,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt.Add(time.Hour),
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		wantFilter *entity.MatrixFilter
+		mockError  error
+		wantStatus int
+		wantLink   string
+	}{
+		{
+			name:       "all matrices",
+			method:     http.MethodGet,
+			wantFilter: &entity.MatrixFilter{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "filtered page",
+			method: http.MethodGet,
+			query:  "?name=league&min_rows=2&max_rows=10&min_cols=1&max_cols=5&sort=-created_at&offset=0&limit=1",
+			wantFilter: &entity.MatrixFilter{
+				Name: "league", MinRows: 2, MaxRows: 10, MinCols: 1, MaxCols: 5, Sort: "-created_at", Limit: 1,
+			},
+			wantStatus: http.StatusOK,
+			wantLink:   `</matrices?limit=1&max_cols=5&max_rows=10&min_cols=1&min_rows=2&name=league&offset=1&sort=-created_at>; rel="next"`,
+		},
+		{
+			name:       "tag filter",
+			method:     http.MethodGet,
+			query:      "?tag=season:2026&tag=league",
+			wantFilter: &entity.MatrixFilter{Tags: map[string]string{"season": "2026", "league": ""}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "repeated tag",
+			method:     http.MethodGet,
+			query:      "?tag=season:2026&tag=season:2025",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown sort field",
+			method:     http.MethodGet,
+			query:      "?sort=owner",
+			wantFilter: &entity.MatrixFilter{Sort: "owner"},
+			mockError:  apperrors.ErrInvalidInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid bound",
+			method:     http.MethodGet,
+			query:      "?min_rows=-1",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid limit",
+			method:     http.MethodGet,
+			query:      "?limit=none",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockCatalogDomainInterface(t)
+			if tt.wantFilter != nil {
+				var page *entity.MatrixPage
+				if tt.mockError == nil {
+					page = &entity.MatrixPage{Matrices: []*entity.StoredMatrix{matrix}, Total: 2, Offset: tt.wantFilter.Offset, Limit: 50}
+					if tt.wantFilter.Limit > 0 {
+						page.Limit = tt.wantFilter.Limit
+					}
+				}
+				mockDomain.On("Search", mock.Anything, tt.wantFilter).Return(page, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/matrices"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewCatalogHandler(mockDomain).SearchMatrices(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.JSONEq(t, `{"matrices":[{"file":"testdata/league.csv","name":"league.csv","rows":2,"cols":2,"size":8,`+
+					`"created_at":"2026-01-01T12:00:00Z","updated_at":"2026-01-01T13:00:00Z"}],"total":2}`, w.Body.String())
+				assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+				assert.Equal(t, tt.wantLink, w.Header().Get("Link"))
+			}
+		})
+	}
+}
+
+func TestCatalogHandler_ExportMatrices(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	matrix := &entity.StoredMatrix{
+		FilePath:  "testdata/tenants/acme/league.csv",
+		Name:      "league.csv",
+		Rows:      2,
+		Cols:      2,
+		Size:      8,
+		CreatedAt: updatedAt,
+		UpdatedAt: updatedAt,
+		Tags:      map[string]string{"season": "2026"},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		wantFilter *entity.MatrixFilter
+		mockFiles  []*entity.StoredMatrix
+		mockError  error
+		wantStatus int
+		wantFiles  []string
+	}{
+		{
+			name:       "every file",
+			method:     http.MethodGet,
+			wantFilter: &entity.MatrixFilter{},
+			mockFiles:  []*entity.StoredMatrix{matrix},
+			wantStatus: http.StatusOK,
+			wantFiles:  []string{"testdata/tenants/acme/league.csv", "manifest.json"},
+		},
+		{
+			name:       "no file matched",
+			method:     http.MethodGet,
+			query:      "?tag=season:2025&sort=-size",
+			wantFilter: &entity.MatrixFilter{Tags: map[string]string{"season": "2025"}, Sort: "-size"},
+			wantStatus: http.StatusOK,
+			wantFiles:  []string{"manifest.json"},
+		},
+		{
+			name:       "unknown sort field",
+			method:     http.MethodGet,
+			query:      "?sort=owner",
+			wantFilter: &entity.MatrixFilter{Sort: "owner"},
+			mockError:  apperrors.ErrInvalidInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid bound",
+			method:     http.MethodGet,
+			query:      "?max_cols=none",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockCatalogDomainInterface(t)
+			if tt.wantFilter != nil {
+				mockDomain.On("Export", mock.Anything, tt.wantFilter, mock.Anything).
+					Run(func(args mock.Arguments) {
+						handleFile := args.Get(2).(func(*entity.StoredMatrix, []byte) error)
+						for _, file := range tt.mockFiles {
+							assert.NoError(t, handleFile(file, []byte("1,2\n3,4\n")))
+						}
+					}).
+					Return(tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/matrices/export"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewCatalogHandler(mockDomain).ExportMatrices(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename=matrices-export.zip`, w.Header().Get("Content-Disposition"))
+
+			archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+			if !assert.NoError(t, err) {
+				return
+			}
+			contents := map[string][]byte{}
+			var names []string
+			for _, file := range archive.File {
+				names = append(names, file.Name)
+				rc, err := file.Open()
+				assert.NoError(t, err)
+				contents[file.Name], err = io.ReadAll(rc)
+				assert.NoError(t, err)
+				assert.NoError(t, rc.Close())
+			}
+			assert.Equal(t, tt.wantFiles, names)
+
+			var manifest exportManifest
+			assert.NoError(t, json.Unmarshal(contents["manifest.json"], &manifest))
+			assert.Equal(t, len(tt.mockFiles), manifest.Total)
+			assert.False(t, manifest.ExportedAt.IsZero())
+			for i, file := range tt.mockFiles {
+				assert.Equal(t, "1,2\n3,4\n", string(contents[file.FilePath]))
+				assert.Equal(t, newMatrixInfoResponse(file), manifest.Matrices[i].matrixInfoResponse)
+				assert.Len(t, manifest.Matrices[i].SHA256, 64)
+			}
+		})
+	}
+}
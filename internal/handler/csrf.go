@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// csrfCookie holds the CSRF token issued to a browser. Pages read it to echo the token back,
+	// so it is not HttpOnly; SameSite keeps other sites from sending it along.
+	csrfCookie = "csrf_token"
+
+	// csrfHeader carries the CSRF token on requests sent by scripts, such as the dashboard.
+	csrfHeader = "X-CSRF-Token"
+
+	// csrfField carries the CSRF token on HTML form submissions, such as the landing page form.
+	csrfField = "csrf_token"
+
+	// csrfTokenBytes is the number of random bytes in a CSRF token.
+	csrfTokenBytes = 32
+)
+
+// CSRFHandlerInterface defines the contract for the middleware that protects state-changing requests
+// against cross-site request forgery, using a token kept in a cookie that must be echoed by the request.
+type CSRFHandlerInterface interface {
+	// Protect wraps next so browser-originated POST, PUT, PATCH and DELETE requests, and GET requests saving or
+	// exporting a result with save_as or export, must echo the CSRF cookie in the X-CSRF-Token header or, for
+	// HTML forms, in the csrf_token field. Requests without it
+	// are rejected with 403 Forbidden. Requests authenticated with an API key or bearer token, which
+	// browsers never attach on their own, are exempt, and so are clients that send no cookies and no
+	// Origin or Sec-Fetch-Site header, such as curl. Safe requests are issued a token when they lack one.
+	Protect(next http.Handler) http.Handler
+}
+
+type csrfHandler struct{}
+
+// NewCSRFHandler creates a new instance of CSRFHandlerInterface.
+func NewCSRFHandler() CSRFHandlerInterface {
+	return &csrfHandler{}
+}
+
+type csrfContextKey struct{}
+
+// csrfToken returns the CSRF token of the request carrying ctx, for pages to embed in their forms.
+// It is empty outside the CSRF middleware and for token-authenticated requests.
+func csrfToken(ctx context.Context) string {
+	token, _ := ctx.Value(csrfContextKey{}).(string)
+	return token
+}
+
+func (h *csrfHandler) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiKey(r) != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := ""
+		if cookie, err := r.Cookie(csrfCookie); err == nil && validCSRFToken(cookie.Value) {
+			token = cookie.Value
+		}
+
+		switch {
+		case !stateChanging(r):
+			if token == "" {
+				var err error
+				token, err = newCSRFToken()
+				if err != nil {
+					h.writeError(w, r, err)
+					return
+				}
+				http.SetCookie(w, &http.Cookie{
+					Name:     csrfCookie,
+					Value:    token,
+					Path:     "/",
+					Secure:   r.TLS != nil,
+					SameSite: http.SameSiteStrictMode,
+				})
+			}
+		default:
+			if browserOriginated(r) {
+				submitted, err := submittedCSRFToken(w, r)
+				if err == nil && (token == "" || subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) != 1) {
+					err = fmt.Errorf("%w: missing or invalid CSRF token", apperrors.ErrForbidden)
+				}
+				if err != nil {
+					if r.MultipartForm != nil {
+						_ = r.MultipartForm.RemoveAll()
+					}
+					h.writeError(w, r, err)
+					return
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), csrfContextKey{}, token)))
+	})
+}
+
+// stateChanging reports whether r changes state on the server. Besides POST, PUT, PATCH and DELETE requests,
+// GET requests to matrix operations write files when they carry save_as or export, so a link or image on another
+// site must not trigger them.
+func stateChanging(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		query := r.URL.Query()
+		return query.Has("save_as") || query.Has("export")
+	}
+	return true
+}
+
+// browserOriginated reports whether r may have been sent by a browser, possibly on behalf of another site.
+// Browsers attach cookies on their own and label requests with the Origin and Sec-Fetch-Site headers,
+// while scripts and command line clients send none of them unless told to.
+func browserOriginated(r *http.Request) bool {
+	return r.Header.Get("Origin") != "" || r.Header.Get("Sec-Fetch-Site") != "" || len(r.Cookies()) > 0
+}
+
+// submittedCSRFToken returns the token echoed by r, from the X-CSRF-Token header or the csrf_token form field.
+// Forms are parsed within the size limit of the landing page form, and stay parsed for the handler.
+func submittedCSRFToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	if token := r.Header.Get(csrfHeader); token != "" {
+		return token, nil
+	}
+
+	var parseForm func() error
+	switch mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType {
+	case "multipart/form-data":
+		parseForm = func() error { return r.ParseMultipartForm(maxLandingFormBytes) }
+	case "application/x-www-form-urlencoded":
+		parseForm = r.ParseForm
+	default:
+		return "", nil
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxLandingFormBytes)
+	if err := parseForm(); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return "", fmt.Errorf("%w: form exceeds %d bytes", apperrors.ErrPayloadTooLarge, maxLandingFormBytes)
+		}
+		return "", fmt.Errorf("%w: invalid form: %v", apperrors.ErrInvalidInput, err)
+	}
+	return r.PostFormValue(csrfField), nil
+}
+
+// newCSRFToken returns a new random CSRF token.
+func newCSRFToken() (string, error) {
+	b := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate CSRF token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validCSRFToken reports whether token looks like a token issued by newCSRFToken,
+// so a malformed cookie is replaced instead of being echoed back.
+func validCSRFToken(token string) bool {
+	b, err := hex.DecodeString(token)
+	return err == nil && len(b) == csrfTokenBytes
+}
+
+func (h *csrfHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.WarnContext(r.Context(), "request rejected",
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+		"error", err,
+		"status_code", statusCode)
+	if statusCode == http.StatusForbidden {
+		audit.Deny(r.Context(), "missing or invalid CSRF token")
+	}
+	httpErrorFrom(w, r, err, statusCode)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSRFHandler_Protect(t *testing.T) {
+	token := strings.Repeat("ab", csrfTokenBytes)
+	otherToken := strings.Repeat("cd", csrfTokenBytes)
+
+	multipartForm := func(fields map[string]string) (string, string) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for name, value := range fields {
+			assert.NoError(t, writer.WriteField(name, value))
+		}
+		assert.NoError(t, writer.Close())
+		return body.String(), writer.FormDataContentType()
+	}
+	formBody, formContentType := multipartForm(map[string]string{csrfField: token, "operation": "sum"})
+	badFormBody, badFormContentType := multipartForm(map[string]string{csrfField: otherToken, "operation": "sum"})
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		cookie      string
+		headers     map[string]string
+		body        string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "safe request", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "GET saving a result without a token", method: http.MethodGet, target: "/matrix/transpose?file=a.csv&save_as=out.csv", cookie: token, wantStatus: http.StatusForbidden},
+		{name: "GET exporting a result without a token", method: http.MethodGet, target: "/matrix/sum?file=a.csv&export=s3://bucket/sum.csv", headers: map[string]string{"Sec-Fetch-Site": "cross-site"}, wantStatus: http.StatusForbidden},
+		{name: "GET saving a result with a token", method: http.MethodGet, target: "/matrix/transpose?file=a.csv&save_as=out.csv", cookie: token, headers: map[string]string{csrfHeader: token}, wantStatus: http.StatusOK},
+		{name: "GET saving a result from the command line", method: http.MethodGet, target: "/matrix/transpose?file=a.csv&save_as=out.csv", wantStatus: http.StatusOK},
+		{name: "command line client", method: http.MethodPost, wantStatus: http.StatusOK},
+		{name: "API key", method: http.MethodPost, cookie: token, headers: map[string]string{"X-API-Key": "acme-key"}, wantStatus: http.StatusOK},
+		{name: "bearer token", method: http.MethodDelete, headers: map[string]string{"Origin": "https://evil.example", "Authorization": "Bearer change-me"}, wantStatus: http.StatusOK},
+		{name: "token in header", method: http.MethodPost, cookie: token, headers: map[string]string{csrfHeader: token}, wantStatus: http.StatusOK},
+		{name: "token in multipart form", method: http.MethodPost, cookie: token, body: formBody, contentType: formContentType, wantStatus: http.StatusOK},
+		{
+			name: "token in urlencoded form", method: http.MethodPost, cookie: token,
+			body: url.Values{csrfField: {token}}.Encode(), contentType: "application/x-www-form-urlencoded", wantStatus: http.StatusOK,
+		},
+		{name: "missing token", method: http.MethodPost, cookie: token, wantStatus: http.StatusForbidden},
+		{name: "wrong token in header", method: http.MethodPut, cookie: token, headers: map[string]string{csrfHeader: otherToken}, wantStatus: http.StatusForbidden},
+		{name: "wrong token in form", method: http.MethodPost, cookie: token, body: badFormBody, contentType: badFormContentType, wantStatus: http.StatusForbidden},
+		{name: "cross-site request without a cookie", method: http.MethodPost, headers: map[string]string{"Sec-Fetch-Site": "cross-site", csrfHeader: token}, wantStatus: http.StatusForbidden},
+		{name: "malformed cookie", method: http.MethodPost, cookie: "guessable", headers: map[string]string{csrfHeader: "guessable"}, wantStatus: http.StatusForbidden},
+		{
+			name: "form too large", method: http.MethodPost, cookie: token,
+			body: strings.Repeat("a", maxLandingFormBytes+1), contentType: "application/x-www-form-urlencoded", wantStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			target := tt.target
+			if target == "" {
+				target = "/"
+			}
+			req := httptest.NewRequest(tt.method, target, strings.NewReader(tt.body))
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: csrfCookie, Value: tt.cookie})
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+
+			NewCSRFHandler().Protect(next).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	t.Run("issues a token to safe requests without one", func(t *testing.T) {
+		var seen string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = csrfToken(r.Context())
+		})
+		w := httptest.NewRecorder()
+
+		NewCSRFHandler().Protect(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		cookies := w.Result().Cookies()
+		if assert.Len(t, cookies, 1) {
+			assert.Equal(t, csrfCookie, cookies[0].Name)
+			assert.True(t, validCSRFToken(cookies[0].Value))
+			assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+			assert.Equal(t, cookies[0].Value, seen)
+		}
+	})
+
+	t.Run("keeps the token of safe requests with one", func(t *testing.T) {
+		var seen string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = csrfToken(r.Context())
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: csrfCookie, Value: token})
+		w := httptest.NewRecorder()
+
+		NewCSRFHandler().Protect(next).ServeHTTP(w, req)
+
+		assert.Empty(t, w.Result().Cookies())
+		assert.Equal(t, token, seen)
+	})
+
+	t.Run("form stays readable by the handler", func(t *testing.T) {
+		var operation string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, r.ParseMultipartForm(maxLandingFormBytes))
+			operation = r.FormValue("operation")
+		})
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(formBody))
+		req.Header.Set("Content-Type", formContentType)
+		req.AddCookie(&http.Cookie{Name: csrfCookie, Value: token})
+		w := httptest.NewRecorder()
+
+		NewCSRFHandler().Protect(next).ServeHTTP(w, req)
+
+		assert.Equal(t, "sum", operation)
+	})
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// dashboardPrefix is the path the dashboard is served under.
+const dashboardPrefix = "/ui/"
+
+// dashboardPolicy only lets the dashboard load its own scripts and styles and call the API it was served by.
+const dashboardPolicy = "default-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// DashboardHandlerInterface defines the contract for the web dashboard served under /ui.
+// The dashboard is a single page built from assets embedded in the binary; it reads its data
+// from the summary endpoint and runs operations through the regular /matrix endpoints.
+type DashboardHandlerInterface interface {
+	// ServeAssets handles GET requests under /ui/, serving the dashboard page, script and stylesheet.
+	// The assets hold no data, so browsers can load them without an API key.
+	ServeAssets(w http.ResponseWriter, r *http.Request)
+
+	// GetSummary handles GET /ui/api/summary requests, returning as JSON the matrix files,
+	// operation usage and recent jobs shown on the dashboard.
+	GetSummary(w http.ResponseWriter, r *http.Request)
+}
+
+// dashboardResponse is the data shown on the dashboard. Jobs are described like on the job status endpoint.
+type dashboardResponse struct {
+	Files      []string                 `json:"files"`
+	Operations []operationUsageResponse `json:"operations"`
+	RecentJobs []json.RawMessage        `json:"recent_jobs"`
+}
+
+// operationUsageResponse counts the recent requests that ran an operation.
+type operationUsageResponse struct {
+	Operation string `json:"operation"`
+	Requests  int    `json:"requests"`
+	Failures  int    `json:"failures"`
+}
+
+type dashboardHandler struct {
+	dashboardDomain domain.DashboardDomainInterface
+}
+
+// NewDashboardHandler creates a new instance of DashboardHandlerInterface with its dependencies.
+// It initializes the handler with the dashboard domain service that gathers the summary.
+func NewDashboardHandler(dashboardDomain domain.DashboardDomainInterface) DashboardHandlerInterface {
+	return &dashboardHandler{
+		dashboardDomain: dashboardDomain,
+	}
+}
+
+func (h *dashboardHandler) ServeAssets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, dashboardPrefix)
+	if name == "" {
+		name = "index.html"
+	}
+	assetPath := path.Join("dashboard", name)
+	info, err := fs.Stat(dashboardAssets, assetPath)
+	if !fs.ValidPath(name) || err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Security-Policy", dashboardPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeFileFS(w, r, dashboardAssets, assetPath)
+}
+
+func (h *dashboardHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	summary, err := h.dashboardDomain.Summary(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := dashboardResponse{
+		Files:      append([]string{}, summary.Files...),
+		Operations: make([]operationUsageResponse, 0, len(summary.Operations)),
+		RecentJobs: make([]json.RawMessage, 0, len(summary.RecentJobs)),
+	}
+	for _, usage := range summary.Operations {
+		response.Operations = append(response.Operations, operationUsageResponse{
+			Operation: usage.Operation,
+			Requests:  usage.Requests,
+			Failures:  usage.Failures,
+		})
+	}
+	for _, job := range summary.RecentJobs {
+		body, err := codec.EncodeJob(job)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		response.RecentJobs = append(response.RecentJobs, body)
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func (h *dashboardHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "dashboard request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
@@ -0,0 +1,177 @@
+"use strict";
+
+// The API key, when the server requires one, is kept for the lifetime of the tab only.
+const keyStorage = "league-matrix-api-key";
+
+function byId(id) {
+  return document.getElementById(id);
+}
+
+function apiHeaders() {
+  const key = sessionStorage.getItem(keyStorage);
+  return key ? { "X-API-Key": key } : {};
+}
+
+// csrfToken returns the token the server issued to this browser, which must be echoed by POST requests.
+function csrfToken() {
+  const cookie = document.cookie.split("; ").find((c) => c.startsWith("csrf_token="));
+  return cookie ? cookie.slice("csrf_token=".length) : "";
+}
+
+// request calls the API, asking for an API key when the server rejects the request for lack of one.
+async function request(url, options = {}) {
+  const response = await fetch(url, {
+    ...options,
+    headers: { ...apiHeaders(), "X-CSRF-Token": csrfToken(), ...options.headers },
+  });
+  if (response.status === 401) {
+    sessionStorage.removeItem(keyStorage);
+    byId("key-form").hidden = false;
+  }
+  return response;
+}
+
+function showStatus(message) {
+  const status = byId("status");
+  status.textContent = message;
+  status.hidden = !message;
+}
+
+function cell(text, className) {
+  const td = document.createElement("td");
+  td.textContent = text;
+  if (className) {
+    td.className = className;
+  }
+  return td;
+}
+
+function renderFiles(files) {
+  const list = byId("files");
+  const select = byId("file");
+  list.replaceChildren();
+  select.replaceChildren(select.options[0]);
+
+  for (const file of files) {
+    const button = document.createElement("button");
+    button.type = "button";
+    button.textContent = file;
+    button.addEventListener("click", () => {
+      select.value = file;
+    });
+    const item = document.createElement("li");
+    item.append(button);
+    list.append(item);
+    select.append(new Option(file, file));
+  }
+  byId("files-empty").hidden = files.length > 0;
+}
+
+function renderOperations(operations) {
+  const body = byId("operations");
+  const select = byId("operation");
+  const selected = select.value;
+  body.replaceChildren();
+  select.replaceChildren();
+
+  for (const usage of operations) {
+    const row = document.createElement("tr");
+    row.append(
+      cell(usage.operation),
+      cell(usage.requests, "number"),
+      cell(usage.failures, "number"),
+    );
+    body.append(row);
+    select.append(new Option(usage.operation, usage.operation));
+  }
+  if (selected) {
+    select.value = selected;
+  }
+}
+
+function renderJobs(jobs) {
+  const body = byId("jobs");
+  body.replaceChildren();
+
+  for (const job of jobs) {
+    const row = document.createElement("tr");
+    row.append(
+      cell(job.id),
+      cell(job.operation),
+      cell(job.status, "status-" + job.status),
+      cell(new Date(job.created_at).toLocaleString()),
+      cell(job.error ? job.error.message : ""),
+    );
+    body.append(row);
+  }
+  byId("jobs-empty").hidden = jobs.length > 0;
+}
+
+async function refresh() {
+  try {
+    const response = await request("api/summary");
+    if (!response.ok) {
+      showStatus("Failed to load the dashboard: " + (await response.text()));
+      return;
+    }
+    const summary = await response.json();
+    showStatus("");
+    byId("key-form").hidden = true;
+    renderFiles(summary.files);
+    renderOperations(summary.operations);
+    renderJobs(summary.recent_jobs);
+  } catch (err) {
+    showStatus("Failed to load the dashboard: " + err.message);
+  }
+}
+
+// parseMatrix turns comma-separated rows into the JSON matrix accepted by the matrix endpoints.
+function parseMatrix(text) {
+  const rows = text
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line !== "")
+    .map((line) => line.split(",").map((value) => Number(value.trim())));
+  return JSON.stringify({ rows });
+}
+
+async function runOperation(event) {
+  event.preventDefault();
+  const output = byId("output");
+  const operation = encodeURIComponent(byId("operation").value);
+  const file = byId("file").value;
+
+  let response;
+  try {
+    if (file) {
+      const params = new URLSearchParams({ file, format: "text" });
+      response = await request(`/matrix/${operation}?${params}`);
+    } else {
+      response = await request(`/matrix/${operation}?format=text`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: parseMatrix(byId("matrix").value),
+      });
+    }
+  } catch (err) {
+    output.textContent = err.message;
+    output.className = "error";
+    return;
+  }
+
+  output.textContent = await response.text();
+  output.className = response.ok ? "" : "error";
+  refresh();
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  byId("refresh").addEventListener("click", refresh);
+  byId("playground").addEventListener("submit", runOperation);
+  byId("key-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    sessionStorage.setItem(keyStorage, byId("api-key").value);
+    byId("api-key").value = "";
+    refresh();
+  });
+  refresh();
+});
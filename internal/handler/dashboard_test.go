@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestDashboardHandler_ServeAssets(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		wantStatus      int
+		wantContentType string
+	}{
+		{name: "page", method: http.MethodGet, path: "/ui/", wantStatus: http.StatusOK, wantContentType: "text/html; charset=utf-8"},
+		{name: "script", method: http.MethodGet, path: "/ui/app.js", wantStatus: http.StatusOK, wantContentType: "text/javascript; charset=utf-8"},
+		{name: "stylesheet", method: http.MethodHead, path: "/ui/style.css", wantStatus: http.StatusOK, wantContentType: "text/css; charset=utf-8"},
+		{name: "unknown asset", method: http.MethodGet, path: "/ui/missing.js", wantStatus: http.StatusNotFound},
+		{name: "path traversal", method: http.MethodGet, path: "/ui/../dashboard.go", wantStatus: http.StatusNotFound},
+		{name: "directory", method: http.MethodGet, path: "/ui/.", wantStatus: http.StatusNotFound},
+		{name: "wrong method", method: http.MethodPost, path: "/ui/", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewDashboardHandler(mocks.NewMockDashboardDomainInterface(t))
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.URL.Path = tt.path
+			w := httptest.NewRecorder()
+
+			handler.ServeAssets(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, dashboardPolicy, w.Header().Get("Content-Security-Policy"))
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_GetSummary(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("summary", func(t *testing.T) {
+		mockDomain := mocks.NewMockDashboardDomainInterface(t)
+		mockDomain.On("Summary", mock.Anything).Return(&entity.DashboardSummary{
+			Files:      []string{"testdata/matrix1.csv"},
+			Operations: []entity.OperationUsage{{Operation: "sum", Requests: 3, Failures: 1}},
+			RecentJobs: []*entity.Job{{ID: "abc", Operation: "sum", Status: entity.JobQueued, CreatedAt: createdAt}},
+		}, nil)
+
+		handler := NewDashboardHandler(mockDomain)
+		w := httptest.NewRecorder()
+
+		handler.GetSummary(w, httptest.NewRequest(http.MethodGet, "/ui/api/summary", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{
+			"files": ["testdata/matrix1.csv"],
+			"operations": [{"operation": "sum", "requests": 3, "failures": 1}],
+			"recent_jobs": [{"id": "abc", "operation": "sum", "status": "queued", "created_at": "2026-01-02T03:04:05Z"}]
+		}`, w.Body.String())
+	})
+
+	t.Run("empty workspace", func(t *testing.T) {
+		mockDomain := mocks.NewMockDashboardDomainInterface(t)
+		mockDomain.On("Summary", mock.Anything).Return(&entity.DashboardSummary{}, nil)
+
+		handler := NewDashboardHandler(mockDomain)
+		w := httptest.NewRecorder()
+
+		handler.GetSummary(w, httptest.NewRequest(http.MethodGet, "/ui/api/summary", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"files": [], "operations": [], "recent_jobs": []}`, w.Body.String())
+	})
+
+	t.Run("domain error", func(t *testing.T) {
+		mockDomain := mocks.NewMockDashboardDomainInterface(t)
+		mockDomain.On("Summary", mock.Anything).Return(nil, apperrors.ErrServiceUnavailable)
+
+		handler := NewDashboardHandler(mockDomain)
+		w := httptest.NewRecorder()
+
+		handler.GetSummary(w, httptest.NewRequest(http.MethodGet, "/ui/api/summary", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		handler := NewDashboardHandler(mocks.NewMockDashboardDomainInterface(t))
+		w := httptest.NewRecorder()
+
+		handler.GetSummary(w, httptest.NewRequest(http.MethodPost, "/ui/api/summary", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+)
+
+// DrainHandlerInterface defines the contract for the HTTP handlers that report the drain state of the server.
+type DrainHandlerInterface interface {
+	// Track wraps next so its requests are counted as in flight until they complete.
+	Track(next http.Handler) http.Handler
+
+	// DrainStatus handles GET /drain requests, reporting as JSON whether the server is draining, since when,
+	// and how many requests are still in flight. It responds with 503 Service Unavailable while draining,
+	// so it can serve as a readiness probe as well.
+	DrainStatus(w http.ResponseWriter, r *http.Request)
+}
+
+// drainStatusResponse describes the drain state of the server.
+type drainStatusResponse struct {
+	Status           string     `json:"status"`
+	DrainingSince    *time.Time `json:"draining_since,omitempty"`
+	InFlightRequests int        `json:"in_flight_requests"`
+}
+
+type drainHandler struct {
+	drainDomain domain.DrainDomainInterface
+}
+
+// NewDrainHandler creates a new instance of DrainHandlerInterface with its dependencies.
+// It initializes the handler with the drain domain service that keeps the drain state.
+func NewDrainHandler(drainDomain domain.DrainDomainInterface) DrainHandlerInterface {
+	return &drainHandler{
+		drainDomain: drainDomain,
+	}
+}
+
+func (h *drainHandler) Track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		done := h.drainDomain.TrackRequest()
+		defer done()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *drainHandler) DrainStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := h.drainDomain.Status()
+	response := drainStatusResponse{
+		Status:           "serving",
+		InFlightRequests: status.InFlightRequests,
+	}
+	statusCode := http.StatusOK
+	if status.Draining {
+		response.Status = "draining"
+		response.DrainingSince = &status.Since
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, r, statusCode, response)
+}
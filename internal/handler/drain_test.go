@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+)
+
+func TestDrainHandler_DrainStatus(t *testing.T) {
+	since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		status     *entity.DrainStatus
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "serving",
+			method:     http.MethodGet,
+			status:     &entity.DrainStatus{InFlightRequests: 3},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"serving","in_flight_requests":3}`,
+		},
+		{
+			name:       "draining",
+			method:     http.MethodGet,
+			status:     &entity.DrainStatus{Draining: true, Since: since, InFlightRequests: 1},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `{"status":"draining","draining_since":"2026-01-02T03:04:05Z","in_flight_requests":1}`,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDrain := mocks.NewMockDrainDomainInterface(t)
+			if tt.status != nil {
+				mockDrain.On("Status").Return(tt.status)
+			}
+
+			w := httptest.NewRecorder()
+			NewDrainHandler(mockDrain).DrainStatus(w, httptest.NewRequest(tt.method, "/drain", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDrainHandler_Track(t *testing.T) {
+	done := false
+	mockDrain := mocks.NewMockDrainDomainInterface(t)
+	mockDrain.On("TrackRequest").Return(func() { done = true })
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, done, "the request is in flight while it is served")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	w := httptest.NewRecorder()
+	NewDrainHandler(mockDrain).Track(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.True(t, done)
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/middleware"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// errorResponse is the JSON error envelope sent to clients that accept JSON. Code is machine-readable, so
+// clients can branch on it instead of parsing Message, and RequestID is the ID the request is logged with.
+type errorResponse struct {
+	Code      apperrors.Code    `json:"code"`
+	Message   string            `json:"message"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"request_id"`
+}
+
+// httpError replies to r with message and statusCode, like http.Error, for errors that are not caused by an
+// application error, such as a method the route does not allow. Their code follows from statusCode.
+func httpError(w http.ResponseWriter, r *http.Request, message string, statusCode int) {
+	writeErrorResponse(w, r, statusCode, apperrors.GetStatusErrorCode(statusCode), message, nil)
+}
+
+// httpErrorFrom replies to r with err and statusCode, like http.Error with err.Error(). The code of err is
+// reported along, or the code following from statusCode when err wraps no sentinel error.
+func httpErrorFrom(w http.ResponseWriter, r *http.Request, err error, statusCode int) {
+	writeErrorResponse(w, r, statusCode, errorCode(err, statusCode), err.Error(), nil)
+}
+
+// writeErrorResponse replies to r with an error. Clients accepting JSON get an errorResponse with code, message,
+// details and the request ID; other clients get message as plain text, as before error codes were introduced.
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, code apperrors.Code, message string,
+	details map[string]string) {
+	if !acceptsJSON(r) {
+		http.Error(w, message, statusCode)
+		return
+	}
+
+	writeJSON(w, r, statusCode, errorResponse{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: middleware.RequestIDFrom(r),
+	})
+}
+
+// errorCode returns the code of err, or the code following from statusCode when err wraps no sentinel error.
+func errorCode(err error, statusCode int) apperrors.Code {
+	if code := apperrors.GetErrorCode(err); code != apperrors.CodeInternal {
+		return code
+	}
+	return apperrors.GetStatusErrorCode(statusCode)
+}
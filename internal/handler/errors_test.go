@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/middleware"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestHTTPErrorFrom(t *testing.T) {
+	tests := []struct {
+		name       string
+		accept     string
+		err        error
+		statusCode int
+		wantType   string
+		wantBody   string
+	}{
+		{
+			name:       "plain text by default",
+			err:        fmt.Errorf("%w: file parameter is required", apperrors.ErrInvalidInput),
+			statusCode: http.StatusBadRequest,
+			wantType:   "text/plain; charset=utf-8",
+			wantBody:   "invalid input: file parameter is required\n",
+		},
+		{
+			name:       "JSON envelope",
+			accept:     "application/json",
+			err:        fmt.Errorf("%w: file parameter is required", apperrors.ErrInvalidInput),
+			statusCode: http.StatusBadRequest,
+			wantType:   "application/json",
+			wantBody:   `{"code":"INVALID_INPUT","message":"invalid input: file parameter is required","request_id":"req-1"}`,
+		},
+		{
+			name:       "refined code",
+			accept:     "text/html, application/json;q=0.9",
+			err:        fmt.Errorf("%w: file too large: 2048 bytes", apperrors.ErrFileTooLarge),
+			statusCode: http.StatusRequestEntityTooLarge,
+			wantType:   "application/json",
+			wantBody:   `{"code":"FILE_TOO_LARGE","message":"payload too large: file too large: 2048 bytes","request_id":"req-1"}`,
+		},
+		{
+			name:       "code of the status without a sentinel",
+			accept:     "application/json",
+			err:        errors.New("bad gateway"),
+			statusCode: http.StatusServiceUnavailable,
+			wantType:   "application/json",
+			wantBody:   `{"code":"SERVICE_UNAVAILABLE","message":"bad gateway","request_id":"req-1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+			req.Header.Set("Accept", tt.accept)
+			req.Header.Set(middleware.RequestIDHeader, "req-1")
+			w := httptest.NewRecorder()
+
+			httpErrorFrom(w, req, tt.err, tt.statusCode)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+			if tt.wantType == "application/json" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			} else {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodDelete, "/matrix/sum", nil)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+
+	httpError(w, req, "method not allowed", http.StatusMethodNotAllowed)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.JSONEq(t, `{"code":"METHOD_NOT_ALLOWED","message":"method not allowed","request_id":"req-1"}`, w.Body.String())
+}
+
+func TestMatrixHandler_ProcessMatrix_JSONError(t *testing.T) {
+	mockDomain := mocks.NewMockMatrixDomainInterface(t)
+	mockDomain.On("FileModTime", mock.Anything, "testdata/matrix1.csv").Return(time.Time{}, nil)
+	mockDomain.On("ProcessMatrix", mock.Anything, "divide", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+		Return(nil, fmt.Errorf("%w: invalid operation: divide", apperrors.ErrInvalidOperation))
+
+	handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+	req := httptest.NewRequest(http.MethodGet, "/matrix/divide?file=testdata/matrix1.csv", nil)
+	req.SetPathValue("operation", "divide")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+
+	handler.ProcessMatrix(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"code":"INVALID_OPERATION",
+		"message":"invalid input: invalid operation: divide",
+		"details":{"operation":"divide","file":"testdata/matrix1.csv"},
+		"request_id":"req-1"}`, w.Body.String())
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// HealthHandlerInterface defines the contract for the HTTP handler that reports the health of the service.
+type HealthHandlerInterface interface {
+	// HealthCheck handles health check requests.
+	// It returns HTTP 200 OK with "OK" message if the service is running, which is all load balancers
+	// and container orchestration systems need. With details=true, or when the Accept header asks for
+	// application/json, every component is checked and the report is returned as JSON, with
+	// 503 Service Unavailable when any component is down.
+	// While the server drains ahead of a shutdown, both forms respond with 503 Service Unavailable,
+	// so load balancers stop routing new traffic to it.
+	HealthCheck(w http.ResponseWriter, r *http.Request)
+}
+
+// componentHealthResponse describes the outcome of the check of a component.
+type componentHealthResponse struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	DurationMS  float64    `json:"duration_ms"`
+	Error       string     `json:"error,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+type healthResponse struct {
+	Status        string                    `json:"status"`
+	Draining      bool                      `json:"draining,omitempty"`
+	UptimeSeconds float64                   `json:"uptime_seconds"`
+	Components    []componentHealthResponse `json:"components"`
+}
+
+type healthHandler struct {
+	healthDomain domain.HealthDomainInterface
+	drainDomain  domain.DrainDomainInterface
+}
+
+// NewHealthHandler creates a new instance of HealthHandlerInterface with its dependencies.
+// It initializes the handler with the health domain service that checks every registered component
+// and the drain domain service that tells whether the server is draining.
+func NewHealthHandler(healthDomain domain.HealthDomainInterface, drainDomain domain.DrainDomainInterface) HealthHandlerInterface {
+	return &healthHandler{
+		healthDomain: healthDomain,
+		drainDomain:  drainDomain,
+	}
+}
+
+func (h *healthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	details, err := parseBoolQuery(r, "details")
+	if err != nil {
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	draining := h.drainDomain.Draining()
+	if !details && !acceptsJSON(r) {
+		slog.DebugContext(r.Context(), "health check request received", "draining", draining)
+
+		statusCode, body := http.StatusOK, "OK"
+		if draining {
+			statusCode, body = http.StatusServiceUnavailable, "DRAINING"
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(statusCode)
+		_, err = w.Write([]byte(body))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to write health check response", "error", err)
+		}
+		return
+	}
+
+	report, err := h.healthDomain.Check(r.Context())
+	if err != nil {
+		statusCode := apperrors.GetHTTPStatusCode(err)
+		slog.ErrorContext(r.Context(), "health check failed",
+			"error", err,
+			"status_code", statusCode)
+		httpErrorFrom(w, r, err, statusCode)
+		return
+	}
+
+	response := toHealthResponse(report)
+	response.Draining = draining
+
+	statusCode := http.StatusOK
+	if report.Status != entity.HealthUp || draining {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, r, statusCode, response)
+}
+
+// toHealthResponse converts a health report into its JSON representation.
+func toHealthResponse(report *entity.HealthReport) healthResponse {
+	response := healthResponse{
+		Status:        string(report.Status),
+		UptimeSeconds: report.Uptime.Seconds(),
+		Components:    make([]componentHealthResponse, 0, len(report.Components)),
+	}
+	for _, component := range report.Components {
+		componentResponse := componentHealthResponse{
+			Name:       component.Name,
+			Status:     string(component.Status),
+			DurationMS: float64(component.Duration.Microseconds()) / 1000,
+			Error:      component.Error,
+			LastError:  component.LastError,
+		}
+		if !component.LastErrorAt.IsZero() {
+			componentResponse.LastErrorAt = &component.LastErrorAt
+		}
+		response.Components = append(response.Components, componentResponse)
+	}
+	return response
+}
+
+// acceptsJSON reports whether the Accept header of r lists application/json.
+// Probes that send no Accept header, or */*, keep receiving the plain "OK".
+func acceptsJSON(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == "application/json" && params["q"] != "0" {
+			return true
+		}
+	}
+	return false
+}
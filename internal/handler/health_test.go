@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestHealthHandler_HealthCheck(t *testing.T) {
+	failedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	upReport := &entity.HealthReport{
+		Status: entity.HealthUp,
+		Uptime: 90 * time.Second,
+		Components: []*entity.ComponentHealth{
+			{Name: "data_directory", Status: entity.HealthUp, Duration: 1500 * time.Microsecond},
+			{Name: "job_store", Status: entity.HealthUp, LastError: "connection refused", LastErrorAt: failedAt},
+		},
+	}
+	downReport := &entity.HealthReport{
+		Status: entity.HealthDown,
+		Uptime: time.Second,
+		Components: []*entity.ComponentHealth{
+			{Name: "secrets", Status: entity.HealthDown, Error: "Vault is sealed", LastError: "Vault is sealed", LastErrorAt: failedAt},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		accept          string
+		report          *entity.HealthReport
+		reportErr       error
+		draining        bool
+		wantStatus      int
+		wantBody        string
+		wantContentType string
+	}{
+		{
+			name:            "successful health check",
+			method:          http.MethodGet,
+			target:          "/health",
+			wantStatus:      http.StatusOK,
+			wantBody:        "OK",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "probes accepting anything get the plain check",
+			method:          http.MethodGet,
+			target:          "/health",
+			accept:          "*/*",
+			wantStatus:      http.StatusOK,
+			wantBody:        "OK",
+			wantContentType: "text/plain",
+		},
+		{
+			name:       "details requested with the query",
+			method:     http.MethodGet,
+			target:     "/health?details=true",
+			report:     upReport,
+			wantStatus: http.StatusOK,
+			wantBody: `{"status":"up","uptime_seconds":90,"components":[` +
+				`{"name":"data_directory","status":"up","duration_ms":1.5},` +
+				`{"name":"job_store","status":"up","duration_ms":0,"last_error":"connection refused","last_error_at":"2026-01-02T03:04:05Z"}]}`,
+			wantContentType: "application/json",
+		},
+		{
+			name:       "details requested with the Accept header",
+			method:     http.MethodGet,
+			target:     "/health",
+			accept:     "application/json",
+			report:     downReport,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: `{"status":"down","uptime_seconds":1,"components":[` +
+				`{"name":"secrets","status":"down","duration_ms":0,"error":"Vault is sealed","last_error":"Vault is sealed","last_error_at":"2026-01-02T03:04:05Z"}]}`,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "draining",
+			method:          http.MethodGet,
+			target:          "/health",
+			draining:        true,
+			wantStatus:      http.StatusServiceUnavailable,
+			wantBody:        "DRAINING",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "details while draining",
+			method:          http.MethodGet,
+			target:          "/health?details=true",
+			report:          &entity.HealthReport{Status: entity.HealthUp, Uptime: time.Second},
+			draining:        true,
+			wantStatus:      http.StatusServiceUnavailable,
+			wantBody:        `{"status":"up","draining":true,"uptime_seconds":1,"components":[]}`,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "details disabled",
+			method:          http.MethodGet,
+			target:          "/health?details=false",
+			wantStatus:      http.StatusOK,
+			wantBody:        "OK",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "invalid details parameter",
+			method:          http.MethodGet,
+			target:          "/health?details=maybe",
+			wantStatus:      http.StatusBadRequest,
+			wantBody:        "invalid details parameter",
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "report failure",
+			method:          http.MethodGet,
+			target:          "/health?details=true",
+			reportErr:       apperrors.ErrServiceUnavailable,
+			wantStatus:      http.StatusServiceUnavailable,
+			wantBody:        "service unavailable",
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "method not allowed - POST",
+			method:          http.MethodPost,
+			target:          "/health",
+			wantStatus:      http.StatusMethodNotAllowed,
+			wantBody:        "method not allowed",
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:            "method not allowed - DELETE",
+			method:          http.MethodDelete,
+			target:          "/health",
+			wantStatus:      http.StatusMethodNotAllowed,
+			wantBody:        "method not allowed",
+			wantContentType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHealth := mocks.NewMockHealthDomainInterface(t)
+			if tt.report != nil || tt.reportErr != nil {
+				mockHealth.On("Check", mock.Anything).Return(tt.report, tt.reportErr)
+			}
+			mockDrain := mocks.NewMockDrainDomainInterface(t)
+			mockDrain.On("Draining").Return(tt.draining).Maybe()
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			NewHealthHandler(mockHealth, mockDrain).HealthCheck(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), tt.wantContentType)
+			if tt.wantContentType == "application/json" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			} else {
+				assert.Contains(t, w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
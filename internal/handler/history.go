@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/presenter"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// HistoryHandlerInterface defines the contract for HTTP handlers that retrieve the history of completed operations.
+type HistoryHandlerInterface interface {
+	// ListRecentResults handles GET /results/recent requests, returning the latest operations of the caller
+	// as JSON, newest first, each with its result. The operation, file and limit query parameters narrow
+	// the entries returned.
+	ListRecentResults(w http.ResponseWriter, r *http.Request)
+}
+
+// historyEntryResponse describes a completed operation. Result uses the plain text format of the matrix endpoints,
+// and Rows and Cols give the dimensions of the matrix the operation ran on.
+type historyEntryResponse struct {
+	ID          string    `json:"id"`
+	CompletedAt time.Time `json:"completed_at"`
+	Operation   string    `json:"operation"`
+	File        string    `json:"file,omitempty"`
+	Checksum    string    `json:"checksum,omitempty"`
+	Rows        int       `json:"rows,omitempty"`
+	Cols        int       `json:"cols,omitempty"`
+	Result      string    `json:"result"`
+	SavedAs     string    `json:"saved_as,omitempty"`
+	ExportedTo  string    `json:"exported_to,omitempty"`
+}
+
+type historyListResponse struct {
+	Results []historyEntryResponse `json:"results"`
+}
+
+type historyHandler struct {
+	historyDomain domain.HistoryDomainInterface
+}
+
+// NewHistoryHandler creates a new instance of HistoryHandlerInterface with its dependencies.
+// It initializes the handler with the history domain service the matrix handler records completed operations in.
+func NewHistoryHandler(historyDomain domain.HistoryDomainInterface) HistoryHandlerInterface {
+	return &historyHandler{
+		historyDomain: historyDomain,
+	}
+}
+
+func (h *historyHandler) ListRecentResults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseHistoryFilter(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	entries, err := h.historyDomain.Recent(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := historyListResponse{Results: make([]historyEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		item := historyEntryResponse{
+			ID:          entry.ID,
+			CompletedAt: entry.CompletedAt,
+			Operation:   entry.Operation,
+			File:        entry.FilePath,
+			Checksum:    presenter.Checksum(entry.Checksum),
+			Result:      entry.Result.String(),
+			SavedAs:     entry.SavedAs,
+			ExportedTo:  entry.ExportedTo,
+		}
+		if entry.Result != nil && entry.Result.Input != nil {
+			item.Rows, item.Cols = entry.Result.Input.Rows, entry.Result.Input.Cols
+		}
+		response.Results = append(response.Results, item)
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func (h *historyHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "history request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// parseHistoryFilter reads the history filter from the query parameters of r.
+func parseHistoryFilter(r *http.Request) (*entity.HistoryFilter, error) {
+	query := r.URL.Query()
+	filter := &entity.HistoryFilter{
+		Operation: query.Get("operation"),
+		FilePath:  query.Get("file"),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("%w: invalid limit parameter: %q", apperrors.ErrInvalidInput, value)
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
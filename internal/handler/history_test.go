@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestHistoryHandler_ListRecentResults(t *testing.T) {
+	completedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		wantFilter *entity.HistoryFilter
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "latest results",
+			method:     http.MethodGet,
+			wantFilter: &entity.HistoryFilter{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "filtered results",
+			method:     http.MethodGet,
+			query:      "?operation=invert&file=testdata/matrix1.csv&limit=5",
+			wantFilter: &entity.HistoryFilter{Operation: "invert", FilePath: "testdata/matrix1.csv", Limit: 5},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid limit",
+			method:     http.MethodGet,
+			query:      "?limit=many",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "domain rejects the filter",
+			method:     http.MethodGet,
+			query:      "?limit=500",
+			wantFilter: &entity.HistoryFilter{Limit: 500},
+			mockError:  apperrors.ErrInvalidInput,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockHistoryDomainInterface(t)
+			if tt.wantFilter != nil {
+				var entries []*entity.HistoryEntry
+				if tt.mockError == nil {
+					entries = []*entity.HistoryEntry{{
+						ID:          "abc",
+						CompletedAt: completedAt,
+						Operation:   "invert",
+						FilePath:    "testdata/matrix1.csv",
+						Checksum:    "c0ffee",
+						Result: &entity.Result{
+							Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}},
+							Input:  &entity.MatrixInfo{Rows: 2, Cols: 2, Checksum: "c0ffee"},
+						},
+						SavedAs: "testdata/inverted.csv",
+					}}
+				}
+				mockDomain.On("Recent", mock.Anything, tt.wantFilter).Return(entries, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/results/recent"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewHistoryHandler(mockDomain).ListRecentResults(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var got historyListResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, []historyEntryResponse{{
+					ID:          "abc",
+					CompletedAt: completedAt,
+					Operation:   "invert",
+					File:        "testdata/matrix1.csv",
+					Checksum:    "sha256:c0ffee",
+					Rows:        2,
+					Cols:        2,
+					Result:      "1,3\n2,4",
+					SavedAs:     "testdata/inverted.csv",
+				}}, got.Results)
+			}
+		})
+	}
+}
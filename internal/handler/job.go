@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// jobContentType is the media type required for job requests.
+const jobContentType = "application/json"
+
+// JobHandlerInterface defines the contract for HTTP handlers that run matrix operations in the background.
+// Submitting a job returns immediately, so slow operations do not hold the request open.
+type JobHandlerInterface interface {
+	// CreateJob handles POST /jobs requests with a JSON body naming an operation and either a file or a matrix,
+	// plus an optional callback_url that receives the job status as a signed POST when the job finishes.
+	// It responds with 202 Accepted, the job URL in the Location header and the queued job in the body.
+	CreateJob(w http.ResponseWriter, r *http.Request)
+
+	// HandleJob handles GET requests for a single job under /jobs/{id}.
+	// GET /jobs/{id} reports the job status (queued, running, succeeded or failed, with the error of a failed job).
+	// GET /jobs/{id}/result returns the output of a succeeded job in the same formats as the matrix endpoints;
+	// it responds with 409 Conflict while the job has not finished and with the job's own error if it failed.
+	HandleJob(w http.ResponseWriter, r *http.Request)
+}
+
+// jobRequest is the body of a job submission.
+type jobRequest struct {
+	Operation   string          `json:"operation"`
+	File        string          `json:"file,omitempty"`
+	Matrix      [][]json.Number `json:"matrix,omitempty"`
+	CallbackURL string          `json:"callback_url,omitempty"`
+}
+
+type jobHandler struct {
+	jobDomain domain.JobDomainInterface
+}
+
+// NewJobHandler creates a new instance of JobHandlerInterface with its dependencies.
+// It initializes the handler with the job domain service, which is shared with the scheduler
+// so scheduled runs can be looked up like any other job.
+func NewJobHandler(jobDomain domain.JobDomainInterface) JobHandlerInterface {
+	return &jobHandler{
+		jobDomain: jobDomain,
+	}
+}
+
+func (h *jobHandler) CreateJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	request, err := decodeJobRequest(r)
+	if err != nil {
+		h.writeError(w, r, "", err)
+		return
+	}
+
+	audit.Describe(r.Context(), request.Operation, request.File)
+
+	var matrix *entity.Matrix[int64]
+	if request.Matrix != nil {
+		matrix, err = codec.MatrixFromJSON(request.Matrix)
+		if err != nil {
+			h.writeError(w, r, "", err)
+			return
+		}
+	}
+
+	job, err := h.jobDomain.SubmitJob(r.Context(), request.Operation, request.File, matrix, request.CallbackURL)
+	if err != nil {
+		h.writeError(w, r, "", err)
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	h.writeJob(w, r, http.StatusAccepted, job)
+}
+
+func (h *jobHandler) HandleJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, resource, _ := strings.Cut(r.URL.Path[len("/jobs/"):], "/")
+	if resource != "" && resource != "result" {
+		http.NotFound(w, r)
+		return
+	}
+
+	job, err := h.jobDomain.GetJob(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, id, err)
+		return
+	}
+
+	if resource == "" {
+		h.writeJob(w, r, http.StatusOK, job)
+		return
+	}
+	h.writeResult(w, r, job)
+}
+
+// writeResult sends the output of a finished job, encoded like a synchronous matrix response.
+func (h *jobHandler) writeResult(w http.ResponseWriter, r *http.Request, job *entity.Job) {
+	if !job.Finished() {
+		h.writeError(w, r, job.ID, fmt.Errorf("%w: job %s is %s", apperrors.ErrConflict, job.ID, job.Status))
+		return
+	}
+	if job.Status == entity.JobFailed {
+		statusCode, message := codec.JobErrorStatus(job.Err)
+		writeErrorResponse(w, r, statusCode, errorCode(job.Err, statusCode), message, nil)
+		return
+	}
+
+	responseCodec, err := selectResponseCodec(r, false)
+	if err != nil {
+		h.writeError(w, r, job.ID, err)
+		return
+	}
+
+	body, err := responseCodec.EncodeResult(job.Operation, job.Result)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response",
+			"job_id", job.ID,
+			"content_type", responseCodec.ContentType(),
+			"error", err)
+		httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	setResultHeaders(w, responseCodec, job.FilePath, job.Operation, "", false)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(body)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
+	}
+}
+
+func (h *jobHandler) writeJob(w http.ResponseWriter, r *http.Request, statusCode int, job *entity.Job) {
+	body, err := codec.EncodeJob(job)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "job_id", job.ID, "error", err)
+		httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, r, statusCode, json.RawMessage(body))
+}
+
+func (h *jobHandler) writeError(w http.ResponseWriter, r *http.Request, id string, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "job request failed",
+		"job_id", id,
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// decodeJobRequest reads the JSON job submission sent in the request body.
+func decodeJobRequest(r *http.Request) (*jobRequest, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jobContentType {
+		return nil, fmt.Errorf("%w: jobs must be sent as %s", apperrors.ErrUnsupportedMediaType, jobContentType)
+	}
+
+	// Limit the body size BEFORE reading to prevent DoS attacks
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("%w: request body too large (maximum: %d bytes)",
+				apperrors.ErrPayloadTooLarge, maxRequestBodyBytes)
+		}
+		return nil, fmt.Errorf("%w: failed to read request body: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	request := &jobRequest{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("%w: invalid job request: %v", apperrors.ErrInvalidInput, err)
+	}
+	return request, nil
+}
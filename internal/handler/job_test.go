@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestJobHandler_CreateJob(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		method       string
+		contentType  string
+		body         string
+		mockFile     string
+		mockMatrix   *entity.Matrix[int64]
+		mockCallback string
+		mockJob      *entity.Job
+		mockError    error
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name:         "queue a file job",
+			method:       http.MethodPost,
+			contentType:  "application/json",
+			body:         `{"operation":"sum","file":"testdata/matrix1.csv"}`,
+			mockFile:     "testdata/matrix1.csv",
+			mockJob:      &entity.Job{ID: "abc", Operation: "sum", Status: entity.JobQueued, CreatedAt: createdAt},
+			wantStatus:   http.StatusAccepted,
+			wantLocation: "/jobs/abc",
+		},
+		{
+			name:         "queue a matrix job",
+			method:       http.MethodPost,
+			contentType:  "application/json; charset=utf-8",
+			body:         `{"operation":"invert","matrix":[[1,2],[3,4]]}`,
+			mockMatrix:   &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}},
+			mockJob:      &entity.Job{ID: "def", Operation: "invert", Status: entity.JobQueued, CreatedAt: createdAt},
+			wantStatus:   http.StatusAccepted,
+			wantLocation: "/jobs/def",
+		},
+		{
+			name:         "queue a job with a callback",
+			method:       http.MethodPost,
+			contentType:  "application/json",
+			body:         `{"operation":"sum","file":"testdata/matrix1.csv","callback_url":"https://example.com/hook"}`,
+			mockFile:     "testdata/matrix1.csv",
+			mockCallback: "https://example.com/hook",
+			mockJob:      &entity.Job{ID: "ghi", Operation: "sum", Status: entity.JobQueued, CreatedAt: createdAt},
+			wantStatus:   http.StatusAccepted,
+			wantLocation: "/jobs/ghi",
+		},
+		{
+			name:         "invalid callback",
+			method:       http.MethodPost,
+			contentType:  "application/json",
+			body:         `{"operation":"sum","file":"testdata/matrix1.csv","callback_url":"ftp://example.com"}`,
+			mockFile:     "testdata/matrix1.csv",
+			mockCallback: "ftp://example.com",
+			mockError:    apperrors.ErrInvalidInput,
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:        "queue is full",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"operation":"sum","file":"testdata/matrix1.csv"}`,
+			mockFile:    "testdata/matrix1.csv",
+			mockError:   apperrors.ErrServiceUnavailable,
+			wantStatus:  http.StatusServiceUnavailable,
+		},
+		{
+			name:        "malformed body",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"operation":`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "wrong content type",
+			method:      http.MethodPost,
+			contentType: "text/csv",
+			body:        "1,2\n3,4\n",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "body too large",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"operation":"sum","file":"` + strings.Repeat("a", maxRequestBodyBytes) + `"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "method not allowed - GET",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockJobDomainInterface(t)
+			if tt.mockJob != nil || tt.mockError != nil {
+				var request jobRequest
+				assert.NoError(t, json.Unmarshal([]byte(tt.body), &request))
+				mockDomain.On("SubmitJob", mock.Anything, request.Operation, tt.mockFile, tt.mockMatrix, tt.mockCallback).
+					Return(tt.mockJob, tt.mockError)
+			}
+
+			handler := &jobHandler{jobDomain: mockDomain}
+
+			req := httptest.NewRequest(tt.method, "/jobs", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			handler.CreateJob(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			if tt.mockJob != nil {
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+				assert.JSONEq(t,
+					`{"id":"`+tt.mockJob.ID+`","operation":"`+tt.mockJob.Operation+`","status":"queued","created_at":"2026-01-02T03:04:05Z"}`,
+					w.Body.String())
+			}
+		})
+	}
+}
+
+func TestJobHandler_HandleJob(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	startedAt := createdAt.Add(time.Second)
+	finishedAt := createdAt.Add(2 * time.Second)
+
+	succeeded := &entity.Job{
+		ID: "abc", Operation: "invert", FilePath: "testdata/matrix1.csv", Status: entity.JobSucceeded,
+		Result:    &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}},
+		CreatedAt: createdAt, StartedAt: startedAt, FinishedAt: finishedAt,
+	}
+	failed := &entity.Job{
+		ID: "abc", Operation: "sum", Status: entity.JobFailed,
+		Err:       fmt.Errorf("%w: invalid matrix", apperrors.ErrUnprocessableEntity),
+		CreatedAt: createdAt, StartedAt: startedAt, FinishedAt: finishedAt,
+	}
+	timedOut := &entity.Job{
+		ID: "abc", Operation: "sum", Status: entity.JobFailed, Err: context.DeadlineExceeded,
+		CreatedAt: createdAt, StartedAt: startedAt, FinishedAt: finishedAt,
+	}
+	running := &entity.Job{ID: "abc", Operation: "sum", Status: entity.JobRunning, CreatedAt: createdAt, StartedAt: startedAt}
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		accept          string
+		mockJob         *entity.Job
+		mockError       error
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+		wantJSON        string
+	}{
+		{
+			name:       "status of a running job",
+			method:     http.MethodGet,
+			path:       "/jobs/abc",
+			mockJob:    running,
+			wantStatus: http.StatusOK,
+			wantJSON:   `{"id":"abc","operation":"sum","status":"running","created_at":"2026-01-02T03:04:05Z","started_at":"2026-01-02T03:04:06Z"}`,
+		},
+		{
+			name:       "status of a failed job",
+			method:     http.MethodGet,
+			path:       "/jobs/abc",
+			mockJob:    failed,
+			wantStatus: http.StatusOK,
+			wantJSON: `{"id":"abc","operation":"sum","status":"failed","created_at":"2026-01-02T03:04:05Z",` +
+				`"started_at":"2026-01-02T03:04:06Z","finished_at":"2026-01-02T03:04:07Z",` +
+				`"error":{"status":422,"message":"unprocessable entity: invalid matrix"}}`,
+		},
+		{
+			name:       "status of a timed out job",
+			method:     http.MethodGet,
+			path:       "/jobs/abc",
+			mockJob:    timedOut,
+			wantStatus: http.StatusOK,
+			wantJSON: `{"id":"abc","operation":"sum","status":"failed","created_at":"2026-01-02T03:04:05Z",` +
+				`"started_at":"2026-01-02T03:04:06Z","finished_at":"2026-01-02T03:04:07Z",` +
+				`"error":{"status":504,"message":"job timeout"}}`,
+		},
+		{
+			name:       "unknown job",
+			method:     http.MethodGet,
+			path:       "/jobs/missing",
+			mockError:  apperrors.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:            "result of a succeeded job",
+			method:          http.MethodGet,
+			path:            "/jobs/abc/result",
+			mockJob:         succeeded,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain",
+			wantBody:        "1,3\n2,4",
+		},
+		{
+			name:            "result in a negotiated format",
+			method:          http.MethodGet,
+			path:            "/jobs/abc/result",
+			accept:          "text/csv",
+			mockJob:         succeeded,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody:        "1,3\n2,4\n",
+		},
+		{
+			name:       "result of an unfinished job",
+			method:     http.MethodGet,
+			path:       "/jobs/abc/result",
+			mockJob:    running,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "result of a failed job",
+			method:     http.MethodGet,
+			path:       "/jobs/abc/result",
+			mockJob:    failed,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "result of an unknown job",
+			method:     http.MethodGet,
+			path:       "/jobs/missing/result",
+			mockError:  apperrors.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown job resource",
+			method:     http.MethodGet,
+			path:       "/jobs/abc/logs",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "method not allowed - DELETE",
+			method:     http.MethodDelete,
+			path:       "/jobs/abc",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockJobDomainInterface(t)
+			if tt.mockJob != nil || tt.mockError != nil {
+				id := strings.Split(tt.path, "/")[2]
+				mockDomain.On("GetJob", mock.Anything, id).Return(tt.mockJob, tt.mockError)
+			}
+
+			handler := &jobHandler{jobDomain: mockDomain}
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			handler.HandleJob(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			if tt.wantJSON != "" {
+				assert.JSONEq(t, tt.wantJSON, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/i18n"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// maxLandingFormBytes limits the size of the landing page form, uploaded file included.
+// The form is parsed in memory up to this size, so uploads never spill to temporary files.
+const maxLandingFormBytes = 1 << 20
+
+//go:embed templates/landing.html
+var templates embed.FS
+
+// landingTemplate renders the landing page served to browsers.
+var landingTemplate = template.Must(template.ParseFS(templates, "templates/landing.html"))
+
+// landingPage holds the data rendered by the landing page template.
+// Operation and File echo the submitted form, and at most one of Result or Error is set.
+// CSRFToken is submitted with the form so the CSRF middleware accepts it, and Lang renders
+// the instructional text in the language the browser prefers.
+type landingPage struct {
+	Lang       *i18n.Localizer
+	Operations []string
+	CSRFToken  string
+	Operation  string
+	File       string
+	Result     string
+	Error      string
+}
+
+// localizerFor returns the localizer for the language r prefers among those the instructional text is
+// translated to, English otherwise. Responses rendered with it must be marked with setLanguageHeaders.
+func localizerFor(r *http.Request) *i18n.Localizer {
+	return i18n.Match(r.Header.Get("Accept-Language"))
+}
+
+// setLanguageHeaders marks a response as written in the language of localizer, and as depending on
+// the Accept-Language header so caches keep one copy per language.
+func setLanguageHeaders(w http.ResponseWriter, localizer *i18n.Localizer) {
+	w.Header().Set("Content-Language", localizer.Language())
+	w.Header().Add("Vary", "Accept-Language")
+}
+
+// acceptsHTML reports whether the Accept header of r lists text/html, as browsers do when loading a page.
+// Other clients, such as curl, keep receiving plain text.
+func acceptsHTML(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == "text/html" && params["q"] != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *matrixHandler) ProcessForm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	page := &landingPage{
+		Lang:       localizerFor(r),
+		Operations: h.matrixDomain.ListOperations(),
+		CSRFToken:  csrfToken(r.Context()),
+	}
+
+	if err := parseMultipartForm(w, r); err != nil {
+		h.renderFormError(w, r, page, err)
+		return
+	}
+	defer func() {
+		if err := r.MultipartForm.RemoveAll(); err != nil {
+			slog.ErrorContext(r.Context(), "failed to remove form files", "error", err)
+		}
+	}()
+
+	page.Operation = r.FormValue("operation")
+	page.File = r.FormValue("file")
+	audit.Describe(r.Context(), page.Operation, page.File)
+
+	result, err := h.processFormInput(r, page)
+	if err != nil {
+		h.renderFormError(w, r, page, err)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "form operation completed")
+
+	page.Result = result.String()
+	renderLanding(w, r, http.StatusOK, page)
+}
+
+// parseMultipartForm parses the multipart form in the body of r within the size limit of the landing page form.
+// w is told to close the connection when the form is too large, and may be nil.
+func parseMultipartForm(w http.ResponseWriter, r *http.Request) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxLandingFormBytes)
+	if err := r.ParseMultipartForm(maxLandingFormBytes); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return fmt.Errorf("%w: form exceeds %d bytes", apperrors.ErrPayloadTooLarge, maxLandingFormBytes)
+		}
+		return fmt.Errorf("%w: invalid form: %v", apperrors.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// processFormInput runs the submitted operation on the uploaded file or, without one, on the server file.
+func (h *matrixHandler) processFormInput(r *http.Request, page *landingPage) (*entity.Result, error) {
+	upload, _, err := r.FormFile(csvUploadField)
+	if err == nil {
+		defer upload.Close()
+		return h.matrixDomain.ProcessMatrixReader(r.Context(), page.Operation, upload, nil)
+	}
+	if !errors.Is(err, http.ErrMissingFile) {
+		return nil, fmt.Errorf("%w: invalid upload: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	if page.File == "" {
+		return nil, fmt.Errorf("%w: choose a server file or upload a CSV file", apperrors.ErrInvalidInput)
+	}
+	return h.matrixDomain.ProcessMatrix(r.Context(), page.Operation, page.File, nil)
+}
+
+// renderFormError renders the landing page with err in the results panel and the status code err maps to.
+func (h *matrixHandler) renderFormError(w http.ResponseWriter, r *http.Request, page *landingPage, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "failed to process form",
+		"error", err,
+		"status_code", statusCode)
+
+	page.Error = err.Error()
+	renderLanding(w, r, statusCode, page)
+}
+
+// renderLanding renders the landing page. The page is rendered in full before anything is written,
+// so a template failure can still be reported with a proper status code.
+func renderLanding(w http.ResponseWriter, r *http.Request, statusCode int, page *landingPage) {
+	var buf bytes.Buffer
+	if err := landingTemplate.Execute(&buf, page); err != nil {
+		slog.ErrorContext(r.Context(), "failed to render landing page", "error", err)
+		httpError(w, r, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	setLanguageHeaders(w, page.Lang)
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
+	}
+}
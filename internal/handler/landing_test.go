@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/i18n"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// landingForm builds a multipart landing page form, with an uploaded file when upload is not empty.
+func landingForm(t *testing.T, fields map[string]string, upload string) (io.Reader, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		assert.NoError(t, writer.WriteField(name, value))
+	}
+	if upload != "" {
+		part, err := writer.CreateFormFile("upload", "matrix.csv")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte(upload))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+	return &body, writer.FormDataContentType()
+}
+
+func TestAcceptsHTML(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: true},
+		{accept: "text/html", want: true},
+		{accept: "text/plain, text/html;q=0.5", want: true},
+		{accept: "text/html;q=0", want: false},
+		{accept: "*/*", want: false},
+		{accept: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+
+			assert.Equal(t, tt.want, acceptsHTML(req))
+		})
+	}
+}
+
+func TestMatrixHandler_ListMatrixOperations_HTML(t *testing.T) {
+	mockDomain := mocks.NewMockMatrixDomainInterface(t)
+	mockDomain.On("ListOperations").Return([]string{"echo", "sum"})
+	handler := &matrixHandler{matrixDomain: mockDomain}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/html,*/*;q=0.8")
+	w := httptest.NewRecorder()
+
+	// The CSRF middleware issues the token the form is submitted with
+	NewCSRFHandler().Protect(http.HandlerFunc(handler.ListMatrixOperations)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<option value="echo">echo</option>`)
+	assert.Contains(t, w.Body.String(), `<option value="sum">sum</option>`)
+	assert.Contains(t, w.Body.String(), `enctype="multipart/form-data"`)
+	if cookies := w.Result().Cookies(); assert.Len(t, cookies, 1) {
+		assert.Contains(t, w.Body.String(), `name="csrf_token" value="`+cookies[0].Value+`"`)
+	}
+}
+
+func TestMatrixHandler_ListMatrixOperations_Localized(t *testing.T) {
+	t.Run("landing page", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ListOperations").Return([]string{"sum"})
+		handler := &matrixHandler{matrixDomain: mockDomain}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", "text/html")
+		req.Header.Set("Accept-Language", "ja-JP,ja;q=0.9,en;q=0.8")
+		w := httptest.NewRecorder()
+
+		handler.ListMatrixOperations(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ja", w.Header().Get("Content-Language"))
+		assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+		assert.Contains(t, w.Body.String(), `<html lang="ja">`)
+		assert.Contains(t, w.Body.String(), `<button type="submit">実行</button>`)
+		assert.Contains(t, w.Body.String(), `<option value="sum">sum</option>`)
+	})
+
+	t.Run("listing", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ListMatrixOperations", mock.MatchedBy(func(l *i18n.Localizer) bool {
+			return l.Language() == "pt"
+		})).Return("Está perdido?", nil)
+		handler := &matrixHandler{matrixDomain: mockDomain}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "pt-BR")
+		w := httptest.NewRecorder()
+
+		handler.ListMatrixOperations(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "pt", w.Header().Get("Content-Language"))
+		assert.Equal(t, "Está perdido?", w.Body.String())
+	})
+
+	t.Run("unsupported languages fall back to English", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ListOperations").Return([]string{"sum"})
+		handler := &matrixHandler{matrixDomain: mockDomain}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", "text/html")
+		req.Header.Set("Accept-Language", "fr-FR")
+		w := httptest.NewRecorder()
+
+		handler.ListMatrixOperations(w, req)
+
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		assert.Contains(t, w.Body.String(), `<button type="submit">Run</button>`)
+	})
+}
+
+func TestMatrixHandler_ProcessForm(t *testing.T) {
+	tests := []struct {
+		name             string
+		fields           map[string]string
+		upload           string
+		setupMock        func(*mocks.MockMatrixDomainInterface)
+		wantStatus       int
+		wantBodyContains []string
+	}{
+		{
+			name:   "server file",
+			fields: map[string]string{"operation": "sum", "file": "testdata/matrix1.csv"},
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+					Return(&entity.Result{Scalar: "45"}, nil)
+			},
+			wantStatus:       http.StatusOK,
+			wantBodyContains: []string{"<pre>45</pre>", `<option value="sum" selected>sum</option>`, `value="testdata/matrix1.csv"`},
+		},
+		{
+			name:   "upload takes precedence over the server file",
+			fields: map[string]string{"operation": "invert", "file": "testdata/matrix1.csv"},
+			upload: "1,2\n3,4\n",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrixReader", mock.Anything, "invert", mock.Anything, (*entity.Selection)(nil)).
+					Run(func(args mock.Arguments) {
+						data, err := io.ReadAll(args.Get(2).(io.Reader))
+						assert.NoError(t, err)
+						assert.Equal(t, "1,2\n3,4\n", string(data))
+					}).
+					Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}}, nil)
+			},
+			wantStatus:       http.StatusOK,
+			wantBodyContains: []string{"<pre>1,3\n2,4</pre>"},
+		},
+		{
+			name:             "no input",
+			fields:           map[string]string{"operation": "sum"},
+			setupMock:        func(m *mocks.MockMatrixDomainInterface) {},
+			wantStatus:       http.StatusBadRequest,
+			wantBodyContains: []string{`<p class="error">invalid input: choose a server file or upload a CSV file</p>`},
+		},
+		{
+			name:   "operation error is shown with its status",
+			fields: map[string]string{"operation": "sum", "file": "testdata/missing.csv"},
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrix", mock.Anything, "sum", "testdata/missing.csv", (*entity.Selection)(nil)).
+					Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatus:       http.StatusNotFound,
+			wantBodyContains: []string{`<p class="error">not found</p>`},
+		},
+		{
+			name:   "submitted values are escaped",
+			fields: map[string]string{"operation": "sum", "file": `"><script>alert(1)</script>`},
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("ProcessMatrix", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+					Return(nil, apperrors.ErrInvalidInput)
+			},
+			wantStatus:       http.StatusBadRequest,
+			wantBodyContains: []string{`value="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			mockDomain.On("ListOperations").Return([]string{"invert", "sum"})
+			tt.setupMock(mockDomain)
+			handler := &matrixHandler{matrixDomain: mockDomain}
+
+			body, contentType := landingForm(t, tt.fields, tt.upload)
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			handler.ProcessForm(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+			for _, want := range tt.wantBodyContains {
+				assert.Contains(t, w.Body.String(), want)
+			}
+			assert.NotContains(t, w.Body.String(), "<script>")
+		})
+	}
+
+	t.Run("form too large", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ListOperations").Return([]string{"sum"})
+		handler := &matrixHandler{matrixDomain: mockDomain}
+
+		body, contentType := landingForm(t, map[string]string{"operation": "sum"}, strings.Repeat("1,", maxLandingFormBytes))
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+
+		handler.ProcessForm(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		handler := &matrixHandler{matrixDomain: mocks.NewMockMatrixDomainInterface(t)}
+		w := httptest.NewRecorder()
+
+		handler.ProcessForm(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
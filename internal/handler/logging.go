@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/logging"
+)
+
+// traceParentHeader is the W3C Trace Context header naming the trace a request belongs to.
+const traceParentHeader = "traceparent"
+
+// validTraceParent matches a traceparent header of a known version, capturing its trace ID.
+// Later versions may append fields, which are ignored.
+var validTraceParent = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}(-.*)?$`)
+
+// LoggingHandlerInterface defines the contract for the middleware that scopes log records to the request
+// they are written for.
+type LoggingHandlerInterface interface {
+	// Scope wraps next so the request context carries the trace ID of the traceparent header of the request,
+	// which every record logged with the context then carries along with the request ID set by
+	// middleware.RequestID.
+	Scope(next http.Handler) http.Handler
+}
+
+type loggingHandler struct{}
+
+// NewLoggingHandler creates a new instance of LoggingHandlerInterface.
+// It initializes the middleware, which needs no configuration since requests carry their own identifiers.
+func NewLoggingHandler() LoggingHandlerInterface {
+	return &loggingHandler{}
+}
+
+func (h *loggingHandler) Scope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := logging.FromContext(r.Context())
+		scope.TraceID = traceID(r)
+		next.ServeHTTP(w, r.WithContext(logging.NewContext(r.Context(), scope)))
+	})
+}
+
+// traceID returns the trace ID of the traceparent header, or an empty string when the header is missing
+// or invalid, as the W3C Trace Context specification has invalid headers ignored.
+func traceID(r *http.Request) string {
+	match := validTraceParent.FindStringSubmatch(strings.TrimSpace(r.Header.Get(traceParentHeader)))
+	// Version ff and the all-zero trace ID are invalid
+	if match == nil || strings.HasPrefix(match[0], "ff") || strings.Trim(match[1], "0") == "" {
+		return ""
+	}
+	return match[1]
+}
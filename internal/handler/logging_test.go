@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/logging"
+)
+
+func TestLoggingHandler_Scope(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		wantTraceID string
+	}{
+		{
+			name:        "client trace",
+			traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:        "future traceparent version",
+			traceParent: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{name: "no traceparent"},
+		{name: "invalid version", traceParent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "all-zero trace ID", traceParent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "uppercase trace ID", traceParent: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{name: "malformed traceparent", traceParent: "4bf92f3577b34da6a3ce929d0e0e4736"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got logging.Scope
+			next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = logging.FromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+			if tt.traceParent != "" {
+				req.Header.Set("traceparent", tt.traceParent)
+			}
+			// The request ID set by the middleware before is kept
+			req = req.WithContext(logging.NewContext(req.Context(), logging.Scope{RequestID: "job-42"}))
+			NewLoggingHandler().Scope(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, logging.Scope{RequestID: "job-42", TraceID: tt.wantTraceID}, got)
+		})
+	}
+}
@@ -2,11 +2,25 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
 	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/middleware"
+	"github.com/matsuboshi/league-matrix-app/internal/presenter"
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
 )
 
@@ -14,118 +28,900 @@ import (
 // It provides endpoints for listing available operations and processing matrices.
 type MatrixHandlerInterface interface {
 	// ListMatrixOperations handles requests to list all available matrix operations.
-	// It responds with a text message showing available operations and a sample URL.
+	// It responds with a text message showing available operations and a sample URL, with
+	// an HTML page holding a form to run them when the Accept header asks for text/html, or with
+	// the operations and their descriptions as JSON when it asks for application/json.
 	ListMatrixOperations(w http.ResponseWriter, r *http.Request)
 
-	// ProcessMatrix handles requests to perform specific matrix operations.
-	// It extracts the operation from the URL path and the file path from query parameters,
-	// then processes the matrix and returns the result.
+	// ProcessForm handles POST submissions of the landing page form, a multipart form with the operation,
+	// a server file path and an optional uploaded CSV file, which takes precedence over the path.
+	// It responds with the landing page showing the result or the error.
+	ProcessForm(w http.ResponseWriter, r *http.Request)
+
+	// ProcessMatrix handles GET and POST /matrix/{operation} requests, running the operation on the matrix of
+	// a file or of the request body and responding with the result in the requested format. Its query parameters
+	// and responses are described in internal/openapi/openapi.json.
 	ProcessMatrix(w http.ResponseWriter, r *http.Request)
 
-	// HealthCheck handles health check requests.
-	// It returns HTTP 200 OK with "OK" message if the service is running and healthy.
-	// This endpoint is intended for use with load balancers and container orchestration systems.
-	HealthCheck(w http.ResponseWriter, r *http.Request)
+	// ProcessArchive handles POST /batch/{operation} requests carrying a zip archive of CSV files.
+	// It runs the operation on every file and responds with a JSON manifest holding one entry per file,
+	// each with its own status code and either the result or the error message.
+	ProcessArchive(w http.ResponseWriter, r *http.Request)
+
+	// SplitMatrix handles GET /matrix/split?file=...&rows=2&cols=2 requests, dividing the matrix into a grid
+	// of rows by cols tiles of equal size; either count defaults to 1. It responds with the tiles as labeled
+	// sections of plain text or, with download=true, as a zip archive holding a CSV file per tile.
+	SplitMatrix(w http.ResponseWriter, r *http.Request)
+}
+
+// concatOperation is the operation joining the matrix files given by the file and file2 query parameters,
+// which unlike the operations of the matrix engine reads two matrices.
+const concatOperation = "concat"
+
+// matmulOperation is the operation multiplying the matrix files given by the file and file2 query parameters,
+// the linear-algebra product rather than the product of every value computed by multiply.
+const matmulOperation = "matmul"
+
+// csvContentType is the media type of matrices sent as raw CSV request bodies.
+const csvContentType = "text/csv"
+
+// jsonContentType is the media type of JSON responses, which present results with their metadata.
+const jsonContentType = "application/json"
+
+// csvUploadField is the multipart form field holding the CSV file of a matrix request, as in the landing page form.
+const csvUploadField = "upload"
+
+// maxRequestBodyBytes limits the size of matrices sent in request bodies, other than CSV bodies.
+// A 10x10 matrix of int64 values encoded as protobuf stays well below this limit.
+const maxRequestBodyBytes = 4096
+
+// archiveContentType is the media type required by the batch endpoint.
+const archiveContentType = "application/zip"
+
+// maxArchiveBodyBytes limits the size of zip archives sent to the batch endpoint.
+const maxArchiveBodyBytes = 1 << 20
+
+// archiveManifest is the JSON response of the batch endpoint.
+type archiveManifest struct {
+	Operation string                 `json:"operation"`
+	Total     int                    `json:"total"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Files     []archiveManifestEntry `json:"files"`
+}
+
+// archiveManifestEntry reports the outcome for a single file of the archive.
+// Result uses the plain text format of the matrix endpoints.
+type archiveManifestEntry struct {
+	File   string `json:"file"`
+	Status int    `json:"status"`
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// planResponse is the JSON response of a dry run.
+// Parameters holds the query parameters of the request, except dry_run itself.
+type planResponse struct {
+	DryRun        bool              `json:"dry_run"`
+	Operation     string            `json:"operation"`
+	Steps         []string          `json:"steps"`
+	Parameters    map[string]string `json:"parameters"`
+	Input         planInput         `json:"input"`
+	Output        planOutput        `json:"output"`
+	Streamed      bool              `json:"streamed"`
+	EstimatedCost int64             `json:"estimated_cost"`
+}
+
+// planInput describes the matrix a planned operation would run on, after any selection.
+type planInput struct {
+	Rows     int    `json:"rows"`
+	Cols     int    `json:"cols"`
+	Checksum string `json:"checksum"`
+}
+
+// planOutput describes the result of a planned operation: the dimensions of a matrix, or a scalar.
+type planOutput struct {
+	Rows   int  `json:"rows,omitempty"`
+	Cols   int  `json:"cols,omitempty"`
+	Scalar bool `json:"scalar"`
 }
 
 type matrixHandler struct {
-	matrixDomain domain.MatrixDomainInterface
+	matrixDomain    domain.MatrixDomainInterface
+	exportDomain    domain.ExportDomainInterface
+	historyDomain   domain.HistoryDomainInterface
+	retentionDomain domain.RetentionDomainInterface
 }
 
 // NewMatrixHandler creates a new instance of MatrixHandlerInterface with its dependencies.
-// It initializes the handler with a matrix domain service for business logic processing.
-func NewMatrixHandler() MatrixHandlerInterface {
+// It initializes the handler with a matrix domain service for business logic processing,
+// the export domain results are written to storage targets with, the history domain
+// completed operations are recorded in and the retention domain saved results expire with.
+func NewMatrixHandler(matrixDomain domain.MatrixDomainInterface, exportDomain domain.ExportDomainInterface,
+	historyDomain domain.HistoryDomainInterface, retentionDomain domain.RetentionDomainInterface) MatrixHandlerInterface {
 	return &matrixHandler{
-		matrixDomain: domain.NewMatrixDomain(),
+		matrixDomain:    matrixDomain,
+		exportDomain:    exportDomain,
+		historyDomain:   historyDomain,
+		retentionDomain: retentionDomain,
 	}
 }
 
 func (h *matrixHandler) ListMatrixOperations(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	result, err := h.matrixDomain.ListMatrixOperations()
+	localizer := localizerFor(r)
+	if acceptsHTML(r) {
+		renderLanding(w, r, http.StatusOK, &landingPage{
+			Lang:       localizer,
+			Operations: h.matrixDomain.ListOperations(),
+			CSRFToken:  csrfToken(r.Context()),
+		})
+		return
+	}
+
+	if acceptsJSON(r) {
+		body, err := presenter.EncodeOperations(h.matrixDomain.DescribeOperations())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode operations", "error", err)
+			httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, json.RawMessage(body))
+		return
+	}
+
+	result, err := h.matrixDomain.ListMatrixOperations(localizer)
 	if err != nil {
 		statusCode := apperrors.GetHTTPStatusCode(err)
-		slog.Error("failed to list operations",
+		slog.ErrorContext(r.Context(), "failed to list operations",
 			"error", err,
 			"status_code", statusCode)
-		http.Error(w, err.Error(), statusCode)
+		httpErrorFrom(w, r, err, statusCode)
 		return
 	}
 
 	w.Header().Set("Content-Type", "text/plain")
+	setLanguageHeaders(w, localizer)
 	w.WriteHeader(http.StatusOK)
 	_, err = w.Write([]byte(result))
 	if err != nil {
-		slog.Error("failed to write response", "error", err)
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
 	}
 }
 
 func (h *matrixHandler) ProcessMatrix(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
 	operation := r.URL.Path[len("/matrix/"):]
 	filePath := r.URL.Query().Get("file")
+	filePath2 := r.URL.Query().Get("file2")
+	saveAs := r.URL.Query().Get("save_as")
+	export := r.URL.Query().Get("export")
+	if readsTwoFiles(operation) && filePath2 != "" {
+		audit.Describe(r.Context(), operation, filePath+","+filePath2)
+	} else {
+		audit.Describe(r.Context(), operation, filePath)
+	}
+
+	download, err := parseBoolQuery(r, "download")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid download parameter", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	envelope, err := parseBoolQuery(r, "envelope")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid envelope parameter", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	dryRun, err := parseBoolQuery(r, "dry_run")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid dry_run parameter", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	responseCodec, err := selectResponseCodec(r, envelope)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid format parameter", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	selection, err := parseSelection(r)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid selection parameters", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	page, err := parsePage(r)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid pagination parameters", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	if value := r.URL.Query().Get("precision"); value != "" {
+		r, err = withPrecision(r, value)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid precision parameter", "error", err)
+			httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+			return
+		}
+	}
+
+	var ttl time.Duration
+	if value := r.URL.Query().Get("ttl"); value != "" {
+		if saveAs == "" {
+			err = fmt.Errorf("%w: ttl parameter requires save_as", apperrors.ErrInvalidInput)
+		} else {
+			ttl, err = parseTTL(value)
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid ttl parameter", "error", err)
+			httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+			return
+		}
+	}
+
+	if dryRun {
+		h.planMatrix(w, r, operation, filePath, filePath2, selection)
+		return
+	}
+
+	// Clients polling a file that has not changed since their last request get no new result,
+	// so it is not computed again; saving or exporting a result must run whatever the client holds
+	var modTime time.Time
+	if r.Method == http.MethodGet && saveAs == "" && export == "" {
+		modTime = h.sourceModTime(r, filePath)
+		if readsTwoFiles(operation) {
+			modTime = laterModTime(modTime, h.sourceModTime(r, filePath2))
+		}
+		if notModified(r, modTime) {
+			slog.InfoContext(r.Context(), "matrix result not modified")
+			w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+			w.Header().Set("Vary", "Accept")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	start := time.Now()
+	var result *entity.Result
+	if r.Method == http.MethodPost {
+		result, err = h.processMatrixBody(r, operation, selection)
+	} else if operation == concatOperation {
+		result, err = h.matrixDomain.ConcatMatrices(r.Context(), filePath, filePath2, r.URL.Query().Get("axis"), selection)
+	} else if operation == matmulOperation {
+		result, err = h.matrixDomain.MultiplyMatrices(r.Context(), filePath, filePath2, selection)
+	} else {
+		result, err = h.matrixDomain.ProcessMatrix(r.Context(), operation, filePath, selection)
+		if err == nil {
+			w.Header().Set("X-Cache", cacheStatus(result))
+		}
+	}
+	if err == nil && saveAs != "" {
+		err = h.matrixDomain.SaveResult(r.Context(), saveAs, result)
+		if err == nil {
+			err = h.retentionDomain.Retain(r.Context(), saveAs, ttl)
+		}
+	}
+	if err == nil && export != "" {
+		err = h.exportDomain.ExportResult(r.Context(), export, result)
+	}
+	if err != nil {
+		writeOperationError(w, r, operation, filePath, err)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "matrix operation completed",
+		"save_as", saveAs,
+		"export", export)
+	h.recordHistory(r, operation, filePath, result, saveAs, export)
+
+	if !modTime.IsZero() {
+		w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+	}
+	if export != "" {
+		w.Header().Set("Matrix-Export", export)
+	}
+
+	// Results are saved in full; only the response is paginated
+	if page != nil && result.Matrix != nil {
+		var total int
+		result, total = page.apply(result)
+		setPageHeaders(w, r, page, total)
+	}
+
+	// Row-oriented formats are written as they are encoded so large results never sit in memory
+	// as a single buffer and clients start receiving rows immediately
+	if streamingCodec, ok := responseCodec.(codec.StreamingCodec); ok && !envelope {
+		setResultHeaders(w, responseCodec, filePath, operation, saveAs, download)
+		w.WriteHeader(http.StatusOK)
+		stopSerialize := profile.Start(r.Context(), profile.Serialize)
+		err = streamingCodec.WriteResult(&flushWriter{w: w, controller: http.NewResponseController(w)}, operation, result)
+		stopSerialize()
+		if err != nil {
+			// Headers are already sent, so the status can no longer be changed
+			slog.ErrorContext(r.Context(), "failed to stream response",
+				"content_type", responseCodec.ContentType(),
+				"error", err)
+		}
+		return
+	}
+
+	var body []byte
+	stopSerialize := profile.Start(r.Context(), profile.Serialize)
+	if envelope {
+		body, err = presenter.EncodeEnvelope(&presenter.Envelope{
+			RequestID: middleware.RequestIDFrom(r),
+			Operation: operation,
+			Source:    filePath,
+			Duration:  time.Since(start),
+			Result:    result,
+		})
+	} else if responseCodec.ContentType() == jsonContentType {
+		// JSON clients also get the dimensions of the matrices and the time the operation took
+		body, err = presenter.EncodeResult(operation, result, time.Since(start))
+	} else {
+		body, err = responseCodec.EncodeResult(operation, result)
+	}
+	stopSerialize()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response",
+			"content_type", responseCodec.ContentType(),
+			"error", err)
+		httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	setResultHeaders(w, responseCodec, filePath, operation, saveAs, download)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(body)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
+	}
+}
+
+// writeOperationError responds to a matrix request that failed with err.
+func writeOperationError(w http.ResponseWriter, r *http.Request, operation, filePath string, err error) {
+	// Handle context errors specially
+	if errors.Is(err, context.Canceled) {
+		slog.InfoContext(r.Context(), "request cancelled by client")
+		// Client already disconnected, no need to write response
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		slog.ErrorContext(r.Context(), "request timeout")
+		writeErrorResponse(w, r, http.StatusGatewayTimeout, apperrors.CodeTimeout, "request timeout",
+			operationErrorDetails(operation, filePath))
+		return
+	}
+
+	// Handle other errors
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "matrix operation failed",
+		"error", err,
+		"status_code", statusCode)
+	writeErrorResponse(w, r, statusCode, errorCode(err, statusCode), err.Error(), operationErrorDetails(operation, filePath))
+}
+
+// operationErrorDetails returns the details of the JSON error response of a failed matrix request,
+// naming the operation and file it was sent for when they are known.
+func operationErrorDetails(operation, filePath string) map[string]string {
+	details := make(map[string]string, 2)
+	if operation != "" {
+		details["operation"] = operation
+	}
+	if filePath != "" {
+		details["file"] = filePath
+	}
+	return details
+}
+
+// planMatrix responds to a dry run with the plan of the operation, read and validated like the request
+// would be but without running it.
+func (h *matrixHandler) planMatrix(w http.ResponseWriter, r *http.Request, operation, filePath, filePath2 string, selection *entity.Selection) {
+	var plan *entity.Plan
+	var err error
+	if r.Method == http.MethodPost {
+		plan, err = h.planMatrixBody(r, operation, selection)
+	} else if operation == concatOperation {
+		plan, err = h.matrixDomain.PlanConcat(r.Context(), filePath, filePath2, r.URL.Query().Get("axis"), selection)
+	} else if operation == matmulOperation {
+		plan, err = h.matrixDomain.PlanMultiplyMatrices(r.Context(), filePath, filePath2, selection)
+	} else {
+		plan, err = h.matrixDomain.PlanMatrix(r.Context(), operation, filePath, selection)
+	}
+	if err != nil {
+		writeOperationError(w, r, operation, filePath, err)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "matrix operation planned",
+		"estimated_cost", plan.Operation.Cost)
+
+	response := planResponse{
+		DryRun:     true,
+		Operation:  operation,
+		Steps:      make([]string, 0, len(plan.Operation.Steps)),
+		Parameters: make(map[string]string),
+		Input: planInput{
+			Rows:     plan.Input.Rows,
+			Cols:     plan.Input.Cols,
+			Checksum: presenter.Checksum(plan.Input.Checksum),
+		},
+		Output: planOutput{
+			Rows:   plan.Operation.Rows,
+			Cols:   plan.Operation.Cols,
+			Scalar: plan.Operation.Scalar,
+		},
+		Streamed:      plan.Streamed,
+		EstimatedCost: plan.Operation.Cost,
+	}
+	for _, step := range plan.Operation.Steps {
+		response.Steps = append(response.Steps, string(step))
+	}
+	for name := range r.URL.Query() {
+		if name != "dry_run" {
+			response.Parameters[name] = r.URL.Query().Get(name)
+		}
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// recordHistory adds a completed operation to the history of the caller, pointing to the copies
+// of its result stored with save_as or export. Failing to record it leaves the response unaffected.
+func (h *matrixHandler) recordHistory(r *http.Request, operation, filePath string, result *entity.Result, saveAs, export string) {
+	entry := &entity.HistoryEntry{
+		CompletedAt: time.Now(),
+		Operation:   operation,
+		FilePath:    filePath,
+		Result:      result,
+		SavedAs:     saveAs,
+		ExportedTo:  export,
+	}
+	if r.Method == http.MethodPost {
+		entry.FilePath = ""
+	} else if readsTwoFiles(operation) {
+		entry.FilePath = filePath + "," + r.URL.Query().Get("file2")
+	}
+	if result.Input != nil {
+		entry.Checksum = result.Input.Checksum
+	}
+
+	if err := h.historyDomain.Record(r.Context(), entry); err != nil {
+		slog.ErrorContext(r.Context(), "failed to record operation history",
+			"error", err)
+	}
+}
+
+// cacheStatus reports in the X-Cache header whether result was remembered from an earlier request on the same
+// unchanged file (HIT) or computed from the file (MISS).
+func cacheStatus(result *entity.Result) string {
+	if result.Cached {
+		return "HIT"
+	}
+	return "MISS"
+}
+
+// readsTwoFiles reports whether operation reads the matrix files of both the file and file2 query parameters.
+func readsTwoFiles(operation string) bool {
+	return operation == concatOperation || operation == matmulOperation
+}
+
+// sourceModTime returns the time the file read by a request was last modified, truncated to the second
+// resolution of HTTP dates, or the zero time when it is unknown.
+func (h *matrixHandler) sourceModTime(r *http.Request, filePath string) time.Time {
+	modTime, err := h.matrixDomain.FileModTime(r.Context(), filePath)
+	if err != nil {
+		// The operation reports the error once it reads the file
+		return time.Time{}
+	}
+	return modTime.Truncate(time.Second)
+}
+
+// laterModTime returns the later of the modification times of two files a result is computed from,
+// or the zero time when either is unknown.
+func laterModTime(a, b time.Time) time.Time {
+	if a.IsZero() || b.IsZero() {
+		return time.Time{}
+	}
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// notModified reports whether the If-Modified-Since header of r shows that the client already holds
+// the result computed from a file last modified at modTime.
+func notModified(r *http.Request, modTime time.Time) bool {
+	if modTime.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !modTime.After(since)
+}
+
+// setResultHeaders sets the headers describing a successful operation result.
+func setResultHeaders(w http.ResponseWriter, responseCodec codec.Codec, filePath, operation, saveAs string, download bool) {
+	w.Header().Set("Content-Type", responseCodec.ContentType())
+	w.Header().Set("Vary", "Accept")
+	if saveAs != "" {
+		w.Header().Set("Matrix-File", saveAs)
+	}
+	if download {
+		filename := attachmentFilename(filePath, operation, responseCodec.FileExtension())
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+}
+
+// flushWriter flushes every write through to the client. Codecs buffer rows internally,
+// so each write carries a chunk of rows rather than a single value.
+type flushWriter struct {
+	w          io.Writer
+	controller *http.ResponseController
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	// Writers that cannot flush still receive the data, just without the early delivery
+	if flushErr := f.controller.Flush(); flushErr != nil && !errors.Is(flushErr, http.ErrNotSupported) {
+		return n, flushErr
+	}
+	return n, nil
+}
+
+// processMatrixBody runs the operation on the matrix sent in the request body. CSV matrices are read
+// like files as the operation runs, while matrices in other formats are decoded first.
+func (h *matrixHandler) processMatrixBody(r *http.Request, operation string, selection *entity.Selection) (*entity.Result, error) {
+	upload, err := openCSVBody(r)
+	if err != nil {
+		return nil, err
+	}
+	if upload != nil {
+		defer upload.Close()
+		return h.matrixDomain.ProcessMatrixReader(r.Context(), operation, upload, selection)
+	}
+
+	matrix, err := decodeMatrixBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.matrixDomain.ProcessMatrixData(r.Context(), operation, matrix, selection)
+}
+
+// planMatrixBody is the dry run of processMatrixBody.
+func (h *matrixHandler) planMatrixBody(r *http.Request, operation string, selection *entity.Selection) (*entity.Plan, error) {
+	upload, err := openCSVBody(r)
+	if err != nil {
+		return nil, err
+	}
+	if upload != nil {
+		defer upload.Close()
+		return h.matrixDomain.PlanMatrixReader(r.Context(), operation, upload, selection)
+	}
+
+	matrix, err := decodeMatrixBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.matrixDomain.PlanMatrixData(r.Context(), operation, matrix, selection)
+}
+
+// openCSVBody returns the CSV matrix sent in the request body, either as the whole body of a text/csv request
+// or as the upload file of a multipart form, or nil for bodies in other formats. The caller must close it.
+// Raw CSV bodies are left to the file size limits of the repository reading them, so they can be streamed,
+// while forms are parsed within the size limit of the landing page form.
+func openCSVBody(r *http.Request) (io.ReadCloser, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		// Invalid content types are reported by decodeMatrixBody
+		return nil, nil
+	}
+
+	switch mediaType {
+	case csvContentType:
+		return r.Body, nil
+	case "multipart/form-data":
+		// Browsers' forms were already parsed by the CSRF protection
+		if r.MultipartForm == nil {
+			if err := parseMultipartForm(nil, r); err != nil {
+				return nil, err
+			}
+		}
+		upload, _, err := r.FormFile(csvUploadField)
+		if errors.Is(err, http.ErrMissingFile) {
+			return nil, fmt.Errorf("%w: form has no %s file", apperrors.ErrInvalidInput, csvUploadField)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid upload: %v", apperrors.ErrInvalidInput, err)
+		}
+		return upload, nil
+	default:
+		return nil, nil
+	}
+}
+
+// decodeMatrixBody decodes the matrix sent in the request body using the codec selected by the Content-Type header.
+func decodeMatrixBody(r *http.Request) (*entity.Matrix[int64], error) {
+	requestCodec, err := codec.ForContentType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit the body size BEFORE reading to prevent DoS attacks
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("%w: request body too large (maximum: %d bytes)",
+				apperrors.ErrPayloadTooLarge, maxRequestBodyBytes)
+		}
+		return nil, fmt.Errorf("%w: failed to read request body: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	return requestCodec.DecodeMatrix(data)
+}
+
+// selectResponseCodec picks the codec for the response. An explicit format query parameter
+// (text, csv, json, ndjson, protobuf, msgpack or cbor) takes precedence over the Accept header.
+// Envelopes are always JSON, so asking for an envelope in another format is rejected.
+func selectResponseCodec(r *http.Request, envelope bool) (codec.Codec, error) {
+	format := r.URL.Query().Get("format")
+	if envelope {
+		if format != "" && !strings.EqualFold(format, "json") {
+			return nil, fmt.Errorf("%w: envelopes are only available in json format", apperrors.ErrInvalidInput)
+		}
+		return codec.ForFormat("json")
+	}
+
+	if format != "" {
+		return codec.ForFormat(format)
+	}
+	return codec.Negotiate(r.Header.Get("Accept")), nil
+}
+
+// parseSelection reads the optional rows and cols query parameters into a submatrix selection.
+// Both take comma-separated one-based indices or inclusive ranges, such as "1-5" or "2,4-6".
+func parseSelection(r *http.Request) (*entity.Selection, error) {
+	rows, err := parseIndexRanges(r.URL.Query().Get("rows"), "rows")
+	if err != nil {
+		return nil, err
+	}
+	cols, err := parseIndexRanges(r.URL.Query().Get("cols"), "cols")
+	if err != nil {
+		return nil, err
+	}
+
+	if rows == nil && cols == nil {
+		return nil, nil
+	}
+	return &entity.Selection{Rows: rows, Cols: cols}, nil
+}
+
+// parseIndexRanges converts a list such as "1-3,5" into zero-based index ranges.
+func parseIndexRanges(value, name string) ([]entity.IndexRange, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var ranges []entity.IndexRange
+	for _, part := range strings.Split(value, ",") {
+		startStr, endStr, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		if !isRange {
+			endStr = startStr
+		}
+
+		start, startErr := strconv.Atoi(startStr)
+		end, endErr := strconv.Atoi(endStr)
+		if startErr != nil || endErr != nil || start < 1 || end < start {
+			return nil, fmt.Errorf("%w: invalid %s parameter: %q", apperrors.ErrInvalidInput, name, value)
+		}
+
+		ranges = append(ranges, entity.IndexRange{Start: start - 1, End: end - 1})
+	}
+
+	return ranges, nil
+}
+
+// resultPage is a window of result rows selected with the offset and limit query parameters.
+// A zero limit means every row from offset onwards.
+type resultPage struct {
+	offset int
+	limit  int
+}
+
+// parsePage reads the optional offset and limit query parameters. It returns nil when neither is set.
+func parsePage(r *http.Request) (*resultPage, error) {
+	query := r.URL.Query()
+	if !query.Has("offset") && !query.Has("limit") {
+		return nil, nil
+	}
+
+	page := &resultPage{}
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("%w: invalid offset parameter: %q", apperrors.ErrInvalidInput, value)
+		}
+		page.offset = offset
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("%w: invalid limit parameter: %q", apperrors.ErrInvalidInput, value)
+		}
+		page.limit = limit
+	}
+
+	return page, nil
+}
+
+// apply returns a copy of a matrix result holding only the rows in the page, along with the total row count.
+func (p *resultPage) apply(result *entity.Result) (*entity.Result, int) {
+	rows := result.Matrix.Data
+	total := len(rows)
+
+	start := min(p.offset, total)
+	end := total
+	// Compare against the remaining rows rather than adding to offset, which could overflow
+	if p.limit > 0 && p.limit < total-start {
+		end = start + p.limit
+	}
+
+	paged := *result
+	paged.Matrix = &entity.Matrix[int64]{Data: rows[start:end], Scale: result.Matrix.Scale}
+	if result.RowLabels != nil {
+		paged.RowLabels = result.RowLabels[start:end]
+	}
+	return &paged, total
+}
+
+// setPageHeaders reports the total row count and, when more rows follow, a Link header to the next page.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, page *resultPage, total int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+
+	if page.limit > 0 && page.offset < total && page.limit < total-page.offset {
+		next := *r.URL
+		query := next.Query()
+		query.Set("offset", strconv.Itoa(page.offset+page.limit))
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.RequestURI()))
+	}
+}
+
+// withPrecision returns a copy of r whose operation results are rounded to the decimal places in value,
+// the precision query parameter, or r itself with an error for an invalid precision.
+func withPrecision(r *http.Request, value string) (*http.Request, error) {
+	precision, err := strconv.Atoi(value)
+	if err != nil {
+		return r, fmt.Errorf("%w: invalid precision parameter: %q", apperrors.ErrInvalidInput, value)
+	}
+	ctx, err := domain.WithPrecision(r.Context(), precision)
+	if err != nil {
+		return r, err
+	}
+	return r.WithContext(ctx), nil
+}
+
+// parseBoolQuery reads an optional boolean query parameter, which defaults to false.
+func parseBoolQuery(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid %s parameter: %q", apperrors.ErrInvalidInput, name, value)
+	}
+	return enabled, nil
+}
+
+// attachmentFilename builds the name of a downloaded result from the input file and the operation,
+// e.g. matrix1-invert.csv. Matrices sent in the request body have no file name and are called "matrix".
+func attachmentFilename(filePath, operation, extension string) string {
+	base := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	if filePath == "" || base == "" {
+		base = "matrix"
+	}
+	return base + "-" + operation + "." + extension
+}
+
+func (h *matrixHandler) ProcessArchive(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	operation := r.URL.Path[len("/batch/"):]
+	audit.Describe(r.Context(), operation, "")
 
-	result, err := h.matrixDomain.ProcessMatrix(r.Context(), operation, filePath)
+	results, err := h.processArchiveBody(r, operation)
 	if err != nil {
-		// Handle context errors specially
 		if errors.Is(err, context.Canceled) {
-			slog.Info("request cancelled by client",
-				"operation", operation,
-				"file_path", filePath)
-			// Client already disconnected, no need to write response
+			slog.InfoContext(r.Context(), "request cancelled by client")
 			return
 		}
 		if errors.Is(err, context.DeadlineExceeded) {
-			slog.Error("request timeout",
-				"operation", operation,
-				"file_path", filePath)
-			http.Error(w, "request timeout", http.StatusGatewayTimeout)
+			slog.ErrorContext(r.Context(), "request timeout")
+			httpError(w, r, "request timeout", http.StatusGatewayTimeout)
 			return
 		}
 
-		// Handle other errors
 		statusCode := apperrors.GetHTTPStatusCode(err)
-		slog.Error("matrix operation failed",
-			"operation", operation,
-			"file_path", filePath,
+		slog.ErrorContext(r.Context(), "archive processing failed",
 			"error", err,
 			"status_code", statusCode)
-		http.Error(w, err.Error(), statusCode)
+		httpErrorFrom(w, r, err, statusCode)
 		return
 	}
 
-	slog.Info("matrix operation completed",
-		"operation", operation,
-		"file_path", filePath)
+	manifest := archiveManifest{
+		Operation: operation,
+		Total:     len(results),
+		Files:     make([]archiveManifestEntry, 0, len(results)),
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			manifest.Failed++
+		} else {
+			manifest.Succeeded++
+		}
+		manifest.Files = append(manifest.Files, toManifestEntry(result))
+	}
 
-	w.Header().Set("Content-Type", "text/plain")
+	slog.InfoContext(r.Context(), "archive processing completed",
+		"total", manifest.Total,
+		"failed", manifest.Failed)
+
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode manifest", "error", err)
+		httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	_, err = w.Write([]byte(result))
+	_, err = w.Write(body)
 	if err != nil {
-		slog.Error("failed to write response", "error", err)
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
 	}
 }
 
-func (h *matrixHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-		return
+// processArchiveBody reads the zip archive sent in the request body and runs the operation on each of its files.
+func (h *matrixHandler) processArchiveBody(r *http.Request, operation string) ([]*entity.FileResult, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != archiveContentType {
+		return nil, fmt.Errorf("%w: archives must be sent as %s", apperrors.ErrUnsupportedMediaType, archiveContentType)
 	}
 
-	slog.Debug("health check request received")
-
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(http.StatusOK)
-	_, err := w.Write([]byte("OK"))
+	// Limit the body size BEFORE reading to prevent DoS attacks
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxArchiveBodyBytes))
 	if err != nil {
-		slog.Error("failed to write health check response", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("%w: archive too large (maximum: %d bytes)",
+				apperrors.ErrPayloadTooLarge, maxArchiveBodyBytes)
+		}
+		return nil, fmt.Errorf("%w: failed to read request body: %v", apperrors.ErrInvalidInput, err)
 	}
+
+	return h.matrixDomain.ProcessArchive(r.Context(), operation, data)
 }
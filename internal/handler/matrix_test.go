@@ -1,17 +1,31 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
 	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/pb"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
 )
 
 func TestMatrixHandler_ListMatrixOperations(t *testing.T) {
@@ -56,16 +70,17 @@ func TestMatrixHandler_ListMatrixOperations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create mock domain
-			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			mockDomain := newMockMatrixDomain(t)
 
 			// Setup expectations only for GET requests
 			if tt.method == http.MethodGet {
-				mockDomain.On("ListMatrixOperations").Return(tt.mockResponse, tt.mockError)
+				mockDomain.On("ListMatrixOperations", mock.Anything).Return(tt.mockResponse, tt.mockError)
 			}
 
 			// Create handler with mock
 			handler := &matrixHandler{
-				matrixDomain: mockDomain,
+				matrixDomain:  mockDomain,
+				historyDomain: newMockHistoryDomain(t),
 			}
 
 			// Create request
@@ -88,13 +103,35 @@ func TestMatrixHandler_ListMatrixOperations(t *testing.T) {
 	}
 }
 
+func TestMatrixHandler_ListMatrixOperations_JSON(t *testing.T) {
+	mockDomain := newMockMatrixDomain(t)
+	mockDomain.On("DescribeOperations").Return([]entity.OperationInfo{
+		{Name: "echo", Description: "Returns the matrix unchanged.", BuiltIn: true, Enabled: true},
+		{Name: "column-sums", Description: "Adds up every column.", Steps: []matrixlib.Operation{"invert", "sum"}, Enabled: true},
+	})
+
+	handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ListMatrixOperations(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"operations": [
+		{"name": "echo", "description": "Returns the matrix unchanged."},
+		{"name": "column-sums", "description": "Adds up every column.", "steps": ["invert", "sum"]}
+	]}`, w.Body.String())
+}
+
 func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 	tests := []struct {
 		name             string
 		method           string
 		path             string
 		query            string
-		mockResponse     string
+		mockResponse     *entity.Result
 		mockError        error
 		wantStatus       int
 		wantBodyContains string
@@ -105,7 +142,7 @@ func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 			method:           http.MethodGet,
 			path:             "/matrix/sum",
 			query:            "file=testdata/matrix1.csv",
-			mockResponse:     "45",
+			mockResponse:     &entity.Result{Scalar: "45"},
 			mockError:        nil,
 			wantStatus:       http.StatusOK,
 			wantBodyContains: "45",
@@ -116,7 +153,7 @@ func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 			method:           http.MethodGet,
 			path:             "/matrix/multiply",
 			query:            "file=testdata/matrix1.csv",
-			mockResponse:     "362880",
+			mockResponse:     &entity.Result{Scalar: "362880"},
 			mockError:        nil,
 			wantStatus:       http.StatusOK,
 			wantBodyContains: "362880",
@@ -127,7 +164,7 @@ func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 			method:           http.MethodGet,
 			path:             "/matrix/echo",
 			query:            "file=testdata/matrix1.csv",
-			mockResponse:     "1,2,3\n4,5,6",
+			mockResponse:     &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}},
 			mockError:        nil,
 			wantStatus:       http.StatusOK,
 			wantBodyContains: "1,2,3",
@@ -174,8 +211,8 @@ func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 			wantContentType:  "text/plain; charset=utf-8",
 		},
 		{
-			name:             "method not allowed - POST",
-			method:           http.MethodPost,
+			name:             "method not allowed - PUT",
+			method:           http.MethodPut,
 			path:             "/matrix/sum",
 			query:            "file=testdata/matrix1.csv",
 			wantStatus:       http.StatusMethodNotAllowed,
@@ -187,7 +224,7 @@ func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create mock domain
-			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			mockDomain := newMockMatrixDomain(t)
 
 			// Setup expectations only for GET requests
 			if tt.method == http.MethodGet {
@@ -196,13 +233,14 @@ func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 				if tt.query != "" {
 					filePath = tt.query[len("file="):]
 				}
-				mockDomain.On("ProcessMatrix", mock.Anything, operation, filePath).
+				mockDomain.On("ProcessMatrix", mock.Anything, operation, filePath, (*entity.Selection)(nil)).
 					Return(tt.mockResponse, tt.mockError)
 			}
 
 			// Create handler with mock
 			handler := &matrixHandler{
-				matrixDomain: mockDomain,
+				matrixDomain:  mockDomain,
+				historyDomain: newMockHistoryDomain(t),
 			}
 
 			// Create request
@@ -226,12 +264,13 @@ func TestMatrixHandler_ProcessMatrix(t *testing.T) {
 
 func TestMatrixHandler_ProcessMatrix_ContextHandling(t *testing.T) {
 	t.Run("context cancelled by client", func(t *testing.T) {
-		mockDomain := mocks.NewMockMatrixDomainInterface(t)
-		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv").
-			Return("", context.Canceled)
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(nil, context.Canceled)
 
 		handler := &matrixHandler{
-			matrixDomain: mockDomain,
+			matrixDomain:  mockDomain,
+			historyDomain: newMockHistoryDomain(t),
 		}
 
 		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil)
@@ -245,12 +284,13 @@ func TestMatrixHandler_ProcessMatrix_ContextHandling(t *testing.T) {
 	})
 
 	t.Run("context deadline exceeded", func(t *testing.T) {
-		mockDomain := mocks.NewMockMatrixDomainInterface(t)
-		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv").
-			Return("", context.DeadlineExceeded)
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(nil, context.DeadlineExceeded)
 
 		handler := &matrixHandler{
-			matrixDomain: mockDomain,
+			matrixDomain:  mockDomain,
+			historyDomain: newMockHistoryDomain(t),
 		}
 
 		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil)
@@ -263,68 +303,203 @@ func TestMatrixHandler_ProcessMatrix_ContextHandling(t *testing.T) {
 	})
 }
 
-func TestMatrixHandler_HealthCheck(t *testing.T) {
-	tests := []struct {
-		name            string
-		method          string
-		wantStatus      int
-		wantBody        string
-		wantContentType string
-	}{
-		{
-			name:            "successful health check",
-			method:          http.MethodGet,
-			wantStatus:      http.StatusOK,
-			wantBody:        "OK",
-			wantContentType: "text/plain",
-		},
-		{
-			name:            "method not allowed - POST",
-			method:          http.MethodPost,
-			wantStatus:      http.StatusMethodNotAllowed,
-			wantBody:        "method not allowed",
-			wantContentType: "text/plain; charset=utf-8",
-		},
-		{
-			name:            "method not allowed - PUT",
-			method:          http.MethodPut,
-			wantStatus:      http.StatusMethodNotAllowed,
-			wantBody:        "method not allowed",
-			wantContentType: "text/plain; charset=utf-8",
-		},
-		{
-			name:            "method not allowed - DELETE",
-			method:          http.MethodDelete,
-			wantStatus:      http.StatusMethodNotAllowed,
-			wantBody:        "method not allowed",
-			wantContentType: "text/plain; charset=utf-8",
-		},
-	}
+func TestMatrixHandler_ProcessMatrix_Protobuf(t *testing.T) {
+	t.Run("encodes result as protobuf when accepted", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "invert", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}}, nil)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			handler := &matrixHandler{}
+		handler := &matrixHandler{
+			matrixDomain:  mockDomain,
+			historyDomain: newMockHistoryDomain(t),
+		}
 
-			req := httptest.NewRequest(tt.method, "/health", nil)
-			w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/matrix/invert?file=testdata/matrix1.csv", nil)
+		req.Header.Set("Accept", "application/x-protobuf")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
 
-			handler.HealthCheck(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
 
-			assert.Equal(t, tt.wantStatus, w.Code)
-			assert.Contains(t, w.Header().Get("Content-Type"), tt.wantContentType)
-			assert.Contains(t, w.Body.String(), tt.wantBody)
-		})
+		got := &pb.OperationResult{}
+		assert.NoError(t, proto.Unmarshal(w.Body.Bytes(), got))
+		assert.Equal(t, "invert", got.GetOperation())
+		assert.Len(t, got.GetMatrix().GetRows(), 2)
+		assert.Equal(t, []int64{1, 3}, got.GetMatrix().GetRows()[0].GetValues())
+	})
+
+	t.Run("decodes protobuf matrix from request body", func(t *testing.T) {
+		matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+		body, err := proto.Marshal(codec.MatrixToProto(matrix))
+		assert.NoError(t, err)
+
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrixData", mock.Anything, "sum", matrix, (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "10"}, nil)
+
+		handler := &matrixHandler{
+			matrixDomain:  mockDomain,
+			historyDomain: newMockHistoryDomain(t),
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+		assert.Equal(t, "10", w.Body.String())
+	})
+
+	t.Run("rejects unsupported request content type", func(t *testing.T) {
+		handler := &matrixHandler{
+			matrixDomain:  newMockMatrixDomain(t),
+			historyDomain: newMockHistoryDomain(t),
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", strings.NewReader("<matrix/>"))
+		req.Header.Set("Content-Type", "application/xml")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("rejects request body over the size limit", func(t *testing.T) {
+		handler := &matrixHandler{
+			matrixDomain:  newMockMatrixDomain(t),
+			historyDomain: newMockHistoryDomain(t),
+		}
+
+		body := bytes.Repeat([]byte{0}, maxRequestBodyBytes+1)
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+func TestMatrixHandler_ProcessMatrix_CSVBody(t *testing.T) {
+	// multipartBody returns a multipart form holding a file in field and its content type
+	multipartBody := func(t *testing.T, field, content string) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile(field, "matrix.csv")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+		return body, writer.FormDataContentType()
+	}
+	// readsMatrix asserts that the reader passed to the domain holds the CSV matrix sent
+	readsMatrix := func(t *testing.T) func(mock.Arguments) {
+		return func(args mock.Arguments) {
+			data, err := io.ReadAll(args.Get(2).(io.Reader))
+			assert.NoError(t, err)
+			assert.Equal(t, "1,2\n3,4\n", string(data))
+		}
 	}
+
+	t.Run("raw text/csv body", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrixReader", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Run(readsMatrix(t)).Return(&entity.Result{Scalar: "10"}, nil)
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", strings.NewReader("1,2\n3,4\n"))
+		req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "10", w.Body.String())
+	})
+
+	t.Run("multipart form upload", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrixReader", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Run(readsMatrix(t)).Return(&entity.Result{Scalar: "10"}, nil)
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+
+		body, contentType := multipartBody(t, "upload", "1,2\n3,4\n")
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "10", w.Body.String())
+	})
+
+	t.Run("multipart form without upload", func(t *testing.T) {
+		handler := &matrixHandler{matrixDomain: newMockMatrixDomain(t), historyDomain: newMockHistoryDomain(t)}
+
+		body, contentType := multipartBody(t, "matrix", "1,2\n3,4\n")
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "form has no upload file")
+	})
+
+	t.Run("invalid matrix", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrixReader", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Return(nil, fmt.Errorf("%w: invalid integer value", apperrors.ErrUnprocessableEntity))
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum", strings.NewReader("1,x\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("PlanMatrixReader", mock.Anything, "sum", mock.Anything, (*entity.Selection)(nil)).
+			Run(readsMatrix(t)).
+			Return(&entity.Plan{
+				Operation: &entity.OperationPlan{Operation: "sum", Steps: []matrixlib.Operation{"sum"}, Scalar: true, Cost: 4},
+				Input:     &entity.MatrixInfo{Rows: 2, Cols: 2, Checksum: "5d1c"},
+			}, nil)
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: mocks.NewMockHistoryDomainInterface(t)}
+
+		req := httptest.NewRequest(http.MethodPost, "/matrix/sum?dry_run=true", strings.NewReader("1,2\n3,4\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"estimated_cost":4`)
+	})
 }
 
 func TestMatrixHandler_ErrorHandling(t *testing.T) {
 	t.Run("domain error is properly mapped to HTTP status", func(t *testing.T) {
-		mockDomain := mocks.NewMockMatrixDomainInterface(t)
-		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "invalid").
-			Return("", errors.New("some domain error"))
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "invalid", (*entity.Selection)(nil)).
+			Return(nil, errors.New("some domain error"))
 
 		handler := &matrixHandler{
-			matrixDomain: mockDomain,
+			matrixDomain:  mockDomain,
+			historyDomain: newMockHistoryDomain(t),
 		}
 
 		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=invalid", nil)
@@ -336,12 +511,13 @@ func TestMatrixHandler_ErrorHandling(t *testing.T) {
 	})
 
 	t.Run("list operations error handling", func(t *testing.T) {
-		mockDomain := mocks.NewMockMatrixDomainInterface(t)
-		mockDomain.On("ListMatrixOperations").
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ListMatrixOperations", mock.Anything).
 			Return("", errors.New("internal error"))
 
 		handler := &matrixHandler{
-			matrixDomain: mockDomain,
+			matrixDomain:  mockDomain,
+			historyDomain: newMockHistoryDomain(t),
 		}
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -353,12 +529,1080 @@ func TestMatrixHandler_ErrorHandling(t *testing.T) {
 	})
 }
 
+func TestMatrixHandler_ProcessMatrix_OtherTenantFile(t *testing.T) {
+	handler := &matrixHandler{
+		matrixDomain:  domain.NewMatrixDomain(domain.DefaultStreamLimits, domain.MemoConfig{}, domain.FileSourcesConfig{}),
+		historyDomain: newMockHistoryDomain(t),
+	}
+	ctx := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+
+	// Another tenant's file is reported like a missing file, without naming the caller's data directory
+	for _, filePath := range []string{"testdata/tenants/globex/matrix.csv", "testdata/tenants/acme/missing.csv"} {
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file="+filePath, nil).WithContext(ctx)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, filePath)
+		assert.Contains(t, w.Body.String(), `"code":"NOT_FOUND"`, filePath)
+		assert.Contains(t, w.Body.String(), "failed to open file: open "+filePath+": no such file or directory", filePath)
+	}
+}
+
 func TestNewMatrixHandler(t *testing.T) {
 	t.Run("creates handler with dependencies", func(t *testing.T) {
-		handler := NewMatrixHandler()
+		handler := NewMatrixHandler(newMockMatrixDomain(t), mocks.NewMockExportDomainInterface(t), newMockHistoryDomain(t),
+			mocks.NewMockRetentionDomainInterface(t))
 
 		assert.NotNil(t, handler)
 		// Verify it implements the interface
 		var _ MatrixHandlerInterface = handler
 	})
 }
+
+func TestMatrixHandler_ProcessArchive(t *testing.T) {
+	t.Run("responds with a per-file manifest", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessArchive", mock.Anything, "sum", []byte("zip")).Return([]*entity.FileResult{
+			{File: "a.csv", Result: &entity.Result{Scalar: "10"}},
+			{File: "b.csv", Err: fmt.Errorf("%w: invalid integer", apperrors.ErrUnprocessableEntity)},
+		}, nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodPost, "/batch/sum", strings.NewReader("zip"))
+		req.Header.Set("Content-Type", "application/zip")
+		w := httptest.NewRecorder()
+
+		handler.ProcessArchive(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"operation": "sum",
+			"total": 2,
+			"succeeded": 1,
+			"failed": 1,
+			"files": [
+				{"file": "a.csv", "status": 200, "result": "10"},
+				{"file": "b.csv", "status": 422, "error": "unprocessable entity: invalid integer"}
+			]
+		}`, w.Body.String())
+	})
+
+	t.Run("archive errors fail the request", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessArchive", mock.Anything, "sum", []byte("bad")).
+			Return(nil, apperrors.ErrUnprocessableEntity)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodPost, "/batch/sum", strings.NewReader("bad"))
+		req.Header.Set("Content-Type", "application/zip")
+		w := httptest.NewRecorder()
+
+		handler.ProcessArchive(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("wrong content type", func(t *testing.T) {
+		handler := &matrixHandler{matrixDomain: newMockMatrixDomain(t), historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodPost, "/batch/sum", strings.NewReader("1,2\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+
+		handler.ProcessArchive(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("archive too large", func(t *testing.T) {
+		handler := &matrixHandler{matrixDomain: newMockMatrixDomain(t), historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodPost, "/batch/sum", bytes.NewReader(make([]byte, maxArchiveBodyBytes+1)))
+		req.Header.Set("Content-Type", "application/zip")
+		w := httptest.NewRecorder()
+
+		handler.ProcessArchive(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("method not allowed - GET", func(t *testing.T) {
+		handler := &matrixHandler{matrixDomain: newMockMatrixDomain(t), historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/batch/sum", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessArchive(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestMatrixHandler_ProcessMatrix_SaveAs(t *testing.T) {
+	t.Run("result is saved and reported in the Matrix-File header", func(t *testing.T) {
+		result := &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}}}}
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "invert", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(result, nil)
+		mockDomain.On("SaveResult", mock.Anything, "testdata/out.csv", result).Return(nil)
+		mockRetention := mocks.NewMockRetentionDomainInterface(t)
+		mockRetention.On("Retain", mock.Anything, "testdata/out.csv", time.Duration(0)).Return(nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t), retentionDomain: mockRetention}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/invert?file=testdata/matrix1.csv&save_as=testdata/out.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "testdata/out.csv", w.Header().Get("Matrix-File"))
+		assert.Equal(t, "1,2", w.Body.String())
+	})
+
+	t.Run("saved result expires after the ttl", func(t *testing.T) {
+		result := &entity.Result{Scalar: "45"}
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(result, nil)
+		mockDomain.On("SaveResult", mock.Anything, "testdata/out.csv", result).Return(nil)
+		mockRetention := mocks.NewMockRetentionDomainInterface(t)
+		mockRetention.On("Retain", mock.Anything, "testdata/out.csv", 24*time.Hour).Return(nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t), retentionDomain: mockRetention}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&save_as=testdata/out.csv&ttl=24h", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ttl without save_as is rejected", func(t *testing.T) {
+		handler := &matrixHandler{matrixDomain: newMockMatrixDomain(t), historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&ttl=24h", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("save failure fails the request", func(t *testing.T) {
+		result := &entity.Result{Scalar: "45"}
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(result, nil)
+		mockDomain.On("SaveResult", mock.Anything, "testdata/matrix1.csv", result).
+			Return(fmt.Errorf("%w: file already exists", apperrors.ErrConflict))
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&save_as=testdata/matrix1.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Empty(t, w.Header().Get("Matrix-File"))
+	})
+
+	t.Run("nothing is saved when the operation fails", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix2.csv", (*entity.Selection)(nil)).
+			Return(nil, apperrors.ErrUnprocessableEntity)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix2.csv&save_as=testdata/out.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestMatrixHandler_ProcessMatrix_Export(t *testing.T) {
+	t.Run("result is exported and reported in the Matrix-Export header", func(t *testing.T) {
+		result := &entity.Result{Scalar: "45"}
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(result, nil)
+		mockExport := mocks.NewMockExportDomainInterface(t)
+		mockExport.On("ExportResult", mock.Anything, "s3://results/sum.csv", result).Return(nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, exportDomain: mockExport, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&export=s3://results/sum.csv", nil)
+		// Exporting runs the operation whatever the client holds
+		req.Header.Set("If-Modified-Since", "Thu, 01 Jan 2099 00:00:00 GMT")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "s3://results/sum.csv", w.Header().Get("Matrix-Export"))
+		assert.Equal(t, "45", w.Body.String())
+	})
+
+	t.Run("export failure fails the request", func(t *testing.T) {
+		result := &entity.Result{Scalar: "45"}
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(result, nil)
+		mockExport := mocks.NewMockExportDomainInterface(t)
+		mockExport.On("ExportResult", mock.Anything, "s3://results/sum.csv", result).
+			Return(fmt.Errorf("%w: failed to reach object storage", apperrors.ErrServiceUnavailable))
+
+		handler := &matrixHandler{matrixDomain: mockDomain, exportDomain: mockExport, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&export=s3://results/sum.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Empty(t, w.Header().Get("Matrix-Export"))
+	})
+
+	t.Run("nothing is exported when saving fails", func(t *testing.T) {
+		result := &entity.Result{Scalar: "45"}
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(result, nil)
+		mockDomain.On("SaveResult", mock.Anything, "testdata/matrix1.csv", result).Return(apperrors.ErrConflict)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, exportDomain: mocks.NewMockExportDomainInterface(t), historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet,
+			"/matrix/sum?file=testdata/matrix1.csv&save_as=testdata/matrix1.csv&export=s3://results/sum.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestMatrixHandler_ProcessMatrix_History(t *testing.T) {
+	t.Run("completed operation is recorded with its result and copies", func(t *testing.T) {
+		result := &entity.Result{Scalar: "45", Input: &entity.MatrixInfo{Rows: 3, Cols: 3, Checksum: "abc123"}}
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(result, nil)
+		mockDomain.On("SaveResult", mock.Anything, "testdata/out.csv", result).Return(nil)
+		mockHistory := mocks.NewMockHistoryDomainInterface(t)
+		mockHistory.On("Record", mock.Anything, mock.MatchedBy(func(entry *entity.HistoryEntry) bool {
+			return entry.Operation == "sum" && entry.FilePath == "testdata/matrix1.csv" &&
+				entry.Checksum == "abc123" && entry.Result == result && entry.SavedAs == "testdata/out.csv" &&
+				!entry.CompletedAt.IsZero()
+		})).Return(nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: mockHistory, retentionDomain: newMockRetentionDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&save_as=testdata/out.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("failing to record leaves the response unaffected", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "45"}, nil)
+		mockHistory := mocks.NewMockHistoryDomainInterface(t)
+		mockHistory.On("Record", mock.Anything, mock.Anything).Return(errors.New("out of entropy"))
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: mockHistory}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "45", w.Body.String())
+	})
+
+	t.Run("failed operation is not recorded", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/missing.csv", (*entity.Selection)(nil)).
+			Return(nil, apperrors.ErrNotFound)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: mocks.NewMockHistoryDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/missing.csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestMatrixHandler_ProcessMatrix_Download(t *testing.T) {
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		wantStatus      int
+		wantDisposition string
+	}{
+		{
+			name:            "text result is downloaded as CSV",
+			url:             "/matrix/invert?file=testdata/matrix1.csv&download=true",
+			wantStatus:      http.StatusOK,
+			wantDisposition: `attachment; filename=matrix1-invert.csv`,
+		},
+		{
+			name:            "extension follows the negotiated format",
+			url:             "/matrix/invert?file=testdata/matrix1.csv&download=1",
+			accept:          "application/cbor",
+			wantStatus:      http.StatusOK,
+			wantDisposition: `attachment; filename=matrix1-invert.cbor`,
+		},
+		{
+			name:       "download=false is served inline",
+			url:        "/matrix/invert?file=testdata/matrix1.csv&download=false",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid download parameter",
+			url:        "/matrix/invert?file=testdata/matrix1.csv&download=maybe",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := newMockMatrixDomain(t)
+			if tt.wantStatus == http.StatusOK {
+				mockDomain.On("ProcessMatrix", mock.Anything, "invert", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+					Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}}}}, nil)
+			}
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantDisposition, w.Header().Get("Content-Disposition"))
+		})
+	}
+}
+
+func TestAttachmentFilename(t *testing.T) {
+	assert.Equal(t, "matrix1-sum.csv", attachmentFilename("testdata/matrix1.csv", "sum", "csv"))
+	assert.Equal(t, "out-flatten.binpb", attachmentFilename("testdata/results/out.csv", "flatten", "binpb"))
+	assert.Equal(t, "matrix-echo.msgpack", attachmentFilename("", "echo", "msgpack"))
+}
+
+func TestMatrixHandler_ProcessMatrix_Format(t *testing.T) {
+	tests := []struct {
+		name            string
+		format          string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "json format",
+			format:          "json",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `{"operation":"echo","matrix":[[1,2],[3,4]],"output":{"rows":2,"cols":2}}`,
+		},
+		{
+			name:            "json negotiated through Accept",
+			accept:          "application/json",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `{"operation":"echo","matrix":[[1,2],[3,4]],"output":{"rows":2,"cols":2}}`,
+		},
+		{
+			name:            "ndjson format",
+			format:          "ndjson",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantBody:        "[1,2]\n[3,4]\n",
+		},
+		{
+			name:            "csv format",
+			format:          "csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody:        "1,2\n3,4\n",
+		},
+		{
+			name:            "format takes precedence over Accept",
+			format:          "text",
+			accept:          "application/json",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain",
+			wantBody:        "1,2\n3,4",
+		},
+		{
+			name:       "unknown format",
+			format:     "xml",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := newMockMatrixDomain(t)
+			if tt.wantStatus == http.StatusOK {
+				mockDomain.On("ProcessMatrix", mock.Anything, "echo", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+					Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}}, nil)
+			}
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+			req := httptest.NewRequest(http.MethodGet, "/matrix/echo?file=testdata/matrix1.csv&format="+tt.format, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+				if tt.wantContentType != "application/json" {
+					assert.Equal(t, tt.wantBody, w.Body.String())
+					return
+				}
+				// The duration varies from run to run, so only its presence is checked.
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Contains(t, body, "duration_ms")
+				delete(body, "duration_ms")
+				got, err := json.Marshal(body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(got))
+			}
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_Selection(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantSelection *entity.Selection
+		wantStatus    int
+	}{
+		{
+			name:  "rows range and cols list",
+			query: "rows=1-5&cols=2,3",
+			wantSelection: &entity.Selection{
+				Rows: []entity.IndexRange{{Start: 0, End: 4}},
+				Cols: []entity.IndexRange{{Start: 1, End: 1}, {Start: 2, End: 2}},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "rows only",
+			query: "rows=2,4-5",
+			wantSelection: &entity.Selection{
+				Rows: []entity.IndexRange{{Start: 1, End: 1}, {Start: 3, End: 4}},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "zero is not a valid index",
+			query:      "rows=0-2",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "reversed range",
+			query:      "cols=3-1",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "not a number",
+			query:      "cols=a",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := newMockMatrixDomain(t)
+			if tt.wantStatus == http.StatusOK {
+				mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", tt.wantSelection).
+					Return(&entity.Result{Scalar: "10"}, nil)
+			}
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+			req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_Envelope(t *testing.T) {
+	result := &entity.Result{
+		Scalar: "10",
+		Input:  &entity.MatrixInfo{Rows: 2, Cols: 2, Checksum: "abc"},
+	}
+
+	t.Run("result is wrapped with execution metadata", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(result, nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&envelope=true", nil)
+		req.Header.Set("X-Request-ID", "req-42")
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var got map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, "req-42", got["request_id"])
+		assert.Equal(t, "sum", got["operation"])
+		assert.Equal(t, "testdata/matrix1.csv", got["source"])
+		assert.Equal(t, map[string]any{"rows": 2.0, "cols": 2.0, "checksum": "sha256:abc"}, got["input"])
+		assert.Equal(t, map[string]any{"scalar": "10"}, got["result"])
+		assert.Contains(t, got, "duration_ms")
+	})
+
+	t.Run("request ID is generated when missing", func(t *testing.T) {
+		mockDomain := newMockMatrixDomain(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(result, nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&envelope=true&format=json", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		var got map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Len(t, got["request_id"], 32)
+	})
+
+	t.Run("envelope in a non-JSON format is rejected", func(t *testing.T) {
+		handler := &matrixHandler{matrixDomain: newMockMatrixDomain(t), historyDomain: newMockHistoryDomain(t)}
+		req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv&envelope=true&format=cbor", nil)
+		w := httptest.NewRecorder()
+
+		handler.ProcessMatrix(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMatrixHandler_ProcessMatrix_Pagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		scalar     bool
+		wantStatus int
+		wantBody   string
+		wantTotal  string
+		wantLink   string
+	}{
+		{
+			name:       "first page links to the next one",
+			query:      "limit=2",
+			wantStatus: http.StatusOK,
+			wantBody:   "1,2\n3,4",
+			wantTotal:  "5",
+			wantLink:   `</matrix/echo?file=testdata%2Fmatrix1.csv&limit=2&offset=2>; rel="next"`,
+		},
+		{
+			name:       "last page has no next link",
+			query:      "offset=4&limit=2",
+			wantStatus: http.StatusOK,
+			wantBody:   "9,10",
+			wantTotal:  "5",
+		},
+		{
+			name:       "offset without limit returns the remaining rows",
+			query:      "offset=3",
+			wantStatus: http.StatusOK,
+			wantBody:   "7,8\n9,10",
+			wantTotal:  "5",
+		},
+		{
+			name:       "offset past the end returns an empty page",
+			query:      "offset=10&limit=2",
+			wantStatus: http.StatusOK,
+			wantBody:   "",
+			wantTotal:  "5",
+		},
+		{
+			name:       "scalar results are not paginated",
+			query:      "limit=2",
+			scalar:     true,
+			wantStatus: http.StatusOK,
+			wantBody:   "55",
+		},
+		{
+			name:       "zero limit",
+			query:      "limit=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "negative offset",
+			query:      "offset=-1",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9, 10}}}}
+			if tt.scalar {
+				result = &entity.Result{Scalar: "55"}
+			}
+
+			mockDomain := newMockMatrixDomain(t)
+			if tt.wantStatus == http.StatusOK {
+				mockDomain.On("ProcessMatrix", mock.Anything, "echo", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+					Return(result, nil)
+			}
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+			req := httptest.NewRequest(http.MethodGet, "/matrix/echo?file=testdata/matrix1.csv&"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+				assert.Equal(t, tt.wantTotal, w.Header().Get("X-Total-Count"))
+				assert.Equal(t, tt.wantLink, w.Header().Get("Link"))
+			}
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_PaginationLabels(t *testing.T) {
+	mockDomain := newMockMatrixDomain(t)
+	mockDomain.On("ProcessMatrix", mock.Anything, "echo", "testdata/scores.csv", (*entity.Selection)(nil)).
+		Return(&entity.Result{
+			Matrix:       &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}, {5, 6}}},
+			ColumnLabels: []string{"home", "away"},
+			RowLabels:    []string{"lions", "tigers", "bears"},
+		}, nil)
+
+	handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+	req := httptest.NewRequest(http.MethodGet, "/matrix/echo?file=testdata/scores.csv&format=csv&offset=1&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.ProcessMatrix(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ",home,away\ntigers,3,4\n", w.Body.String())
+}
+
+func TestMatrixHandler_ProcessMatrix_Streaming(t *testing.T) {
+	data := make([][]int64, 2000)
+	for i := range data {
+		data[i] = []int64{int64(i), int64(i)}
+	}
+
+	mockDomain := newMockMatrixDomain(t)
+	mockDomain.On("ProcessMatrix", mock.Anything, "echo", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+		Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: data}}, nil)
+
+	handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+	req := httptest.NewRequest(http.MethodGet, "/matrix/echo?file=testdata/matrix1.csv&format=ndjson", nil)
+	w := httptest.NewRecorder()
+
+	handler.ProcessMatrix(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.True(t, w.Flushed)
+	assert.Equal(t, 2000, strings.Count(w.Body.String(), "\n"))
+}
+
+func TestMatrixHandler_ProcessMatrix_NotModified(t *testing.T) {
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 500_000_000, time.UTC)
+	lastModified := "Thu, 01 Jan 2026 12:00:00 GMT"
+
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		ifModifiedSince string
+		modTime         time.Time
+		wantStatus      int
+		wantHeader      string
+	}{
+		{name: "first request", method: http.MethodGet, target: "/matrix/sum?file=testdata/matrix1.csv",
+			modTime: modTime, wantStatus: http.StatusOK, wantHeader: lastModified},
+		{name: "unchanged file", method: http.MethodGet, target: "/matrix/sum?file=testdata/matrix1.csv",
+			ifModifiedSince: lastModified, modTime: modTime, wantStatus: http.StatusNotModified, wantHeader: lastModified},
+		{name: "changed file", method: http.MethodGet, target: "/matrix/sum?file=testdata/matrix1.csv",
+			ifModifiedSince: "Thu, 01 Jan 2026 11:59:59 GMT", modTime: modTime, wantStatus: http.StatusOK, wantHeader: lastModified},
+		{name: "invalid date", method: http.MethodGet, target: "/matrix/sum?file=testdata/matrix1.csv",
+			ifModifiedSince: "yesterday", modTime: modTime, wantStatus: http.StatusOK, wantHeader: lastModified},
+		{name: "file without modification time", method: http.MethodGet, target: "/matrix/sum?file=testdata/matrix1.csv",
+			ifModifiedSince: lastModified, wantStatus: http.StatusOK},
+		{name: "saving the result", method: http.MethodGet, target: "/matrix/sum?file=testdata/matrix1.csv&save_as=testdata/out.csv",
+			ifModifiedSince: lastModified, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &entity.Result{Scalar: "45"}
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			if !strings.Contains(tt.target, "save_as") {
+				mockDomain.On("FileModTime", mock.Anything, "testdata/matrix1.csv").Return(tt.modTime, nil)
+			} else {
+				mockDomain.On("SaveResult", mock.Anything, "testdata/out.csv", result).Return(nil)
+			}
+			if tt.wantStatus == http.StatusOK {
+				mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+					Return(result, nil)
+			}
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t), retentionDomain: newMockRetentionDomain(t)}
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantHeader, w.Header().Get("Last-Modified"))
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_NotModifiedUnknownFile(t *testing.T) {
+	// A file that cannot be found fails when the operation reads it, as without conditional requests
+	mockDomain := mocks.NewMockMatrixDomainInterface(t)
+	mockDomain.On("FileModTime", mock.Anything, "testdata/missing.csv").Return(time.Time{}, apperrors.ErrNotFound)
+	mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/missing.csv", (*entity.Selection)(nil)).
+		Return(nil, apperrors.ErrNotFound)
+
+	handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+	req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/missing.csv", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 01 Jan 2026 12:00:00 GMT")
+	w := httptest.NewRecorder()
+
+	handler.ProcessMatrix(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+}
+
+func TestMatrixHandler_ProcessMatrix_Cache(t *testing.T) {
+	tests := []struct {
+		name   string
+		cached bool
+		want   string
+	}{
+		{name: "computed", cached: false, want: "MISS"},
+		{name: "cached", cached: true, want: "HIT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			mockDomain.On("FileModTime", mock.Anything, "testdata/matrix1.csv").Return(time.Time{}, nil)
+			mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+				Return(&entity.Result{Scalar: "378", Cached: tt.cached}, nil)
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+			w := httptest.NewRecorder()
+			handler.ProcessMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "378", w.Body.String())
+			assert.Equal(t, tt.want, w.Header().Get("X-Cache"))
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_Precision(t *testing.T) {
+	t.Run("results rounded to the precision", func(t *testing.T) {
+		// The operation runs with the precision of the request
+		rounded := mock.MatchedBy(func(ctx context.Context) bool {
+			result, err := domain.NewMatrixOperationsDomain().
+				RunOperation(ctx, &entity.Matrix[int64]{Data: [][]int64{{7, -2, 5}}}, "mean")
+			return err == nil && result.Scalar == "3.33"
+		})
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("FileModTime", mock.Anything, "testdata/matrix1.csv").Return(time.Time{}, nil)
+		mockDomain.On("ProcessMatrix", rounded, "mean", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "3.33"}, nil)
+
+		handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+		w := httptest.NewRecorder()
+		handler.ProcessMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/mean?file=testdata/matrix1.csv&precision=2", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "3.33", w.Body.String())
+	})
+
+	for _, precision := range []string{"two", "-1", "19"} {
+		t.Run("invalid precision "+precision, func(t *testing.T) {
+			handler := &matrixHandler{matrixDomain: mocks.NewMockMatrixDomainInterface(t)}
+			w := httptest.NewRecorder()
+			handler.ProcessMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/mean?file=testdata/matrix1.csv&precision="+precision, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_Concat(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		axis       string
+		mockResult *entity.Result
+		mockError  error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "horizontal", target: "/matrix/concat?file=testdata/a.csv&file2=testdata/b.csv&axis=horizontal", axis: "horizontal",
+			mockResult: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2, 5}, {3, 4, 6}}}},
+			wantStatus: http.StatusOK, wantBody: "1,2,5\n3,4,6"},
+		{name: "default axis", target: "/matrix/concat?file=testdata/a.csv&file2=testdata/b.csv",
+			mockResult: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 5}}}},
+			wantStatus: http.StatusOK, wantBody: "1,5"},
+		{name: "matrices do not line up", target: "/matrix/concat?file=testdata/a.csv&file2=testdata/b.csv&axis=vertical", axis: "vertical",
+			mockError:  fmt.Errorf("%w: cannot concatenate vertically: matrices have 2 and 1 columns", apperrors.ErrUnprocessableEntity),
+			wantStatus: http.StatusUnprocessableEntity, wantBody: "cannot concatenate vertically"},
+		{name: "invalid axis", target: "/matrix/concat?file=testdata/a.csv&file2=testdata/b.csv&axis=diagonal", axis: "diagonal",
+			mockError:  fmt.Errorf("%w: invalid axis", apperrors.ErrInvalidInput),
+			wantStatus: http.StatusBadRequest, wantBody: "invalid axis"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := newMockMatrixDomain(t)
+			mockDomain.On("ConcatMatrices", mock.Anything, "testdata/a.csv", "testdata/b.csv", tt.axis, (*entity.Selection)(nil)).
+				Return(tt.mockResult, tt.mockError)
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_MatMul(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		mockResult *entity.Result
+		mockError  error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "product", target: "/matrix/matmul?file=testdata/a.csv&file2=testdata/b.csv",
+			mockResult: &entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{17}, {39}}}},
+			wantStatus: http.StatusOK, wantBody: "17\n39"},
+		{name: "matrices do not line up", target: "/matrix/matmul?file=testdata/a.csv&file2=testdata/b.csv",
+			mockError:  fmt.Errorf("%w: cannot multiply matrices: first has 2 columns, second has 1 rows", apperrors.ErrUnprocessableEntity),
+			wantStatus: http.StatusUnprocessableEntity, wantBody: "cannot multiply matrices"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := newMockMatrixDomain(t)
+			mockDomain.On("MultiplyMatrices", mock.Anything, "testdata/a.csv", "testdata/b.csv", (*entity.Selection)(nil)).
+				Return(tt.mockResult, tt.mockError)
+
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestMatrixHandler_ProcessMatrix_ConcatNotModified(t *testing.T) {
+	older := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	mockDomain := mocks.NewMockMatrixDomainInterface(t)
+	mockDomain.On("FileModTime", mock.Anything, "testdata/a.csv").Return(older, nil)
+	mockDomain.On("FileModTime", mock.Anything, "testdata/b.csv").Return(newer, nil)
+	mockDomain.On("ConcatMatrices", mock.Anything, "testdata/a.csv", "testdata/b.csv", "", (*entity.Selection)(nil)).
+		Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}}}}, nil).
+		Once()
+
+	handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: newMockHistoryDomain(t)}
+
+	// Holding the result computed before the second file changed, the client gets the new result
+	req := httptest.NewRequest(http.MethodGet, "/matrix/concat?file=testdata/a.csv&file2=testdata/b.csv", nil)
+	req.Header.Set("If-Modified-Since", older.Format(http.TimeFormat))
+	w := httptest.NewRecorder()
+	handler.ProcessMatrix(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, newer.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+
+	req = httptest.NewRequest(http.MethodGet, "/matrix/concat?file=testdata/a.csv&file2=testdata/b.csv", nil)
+	req.Header.Set("If-Modified-Since", newer.Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	handler.ProcessMatrix(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
+
+func TestMatrixHandler_ProcessMatrix_DryRun(t *testing.T) {
+	sumPlan := &entity.Plan{
+		Operation: &entity.OperationPlan{Operation: "sum", Steps: []matrixlib.Operation{"sum"}, Scalar: true, Cost: 27},
+		Input:     &entity.MatrixInfo{Rows: 9, Cols: 3, Checksum: "9c1e"},
+	}
+	rowPlan := &entity.Plan{
+		Operation: &entity.OperationPlan{Operation: "row", Steps: []matrixlib.Operation{"invert", "flatten"}, Rows: 1, Cols: 4, Cost: 8},
+		Input:     &entity.MatrixInfo{Rows: 2, Cols: 2, Checksum: "3f0a"},
+	}
+	selection := &entity.Selection{Rows: []entity.IndexRange{{Start: 0, End: 1}}}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setupMock  func(*mocks.MockMatrixDomainInterface)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "file",
+			method: http.MethodGet,
+			target: "/matrix/sum?file=testdata/matrix1.csv&dry_run=true&save_as=testdata/sum.csv",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("PlanMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).Return(sumPlan, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"dry_run":true,"operation":"sum","steps":["sum"],` +
+				`"parameters":{"file":"testdata/matrix1.csv","save_as":"testdata/sum.csv"},` +
+				`"input":{"rows":9,"cols":3,"checksum":"sha256:9c1e"},"output":{"scalar":true},"streamed":false,"estimated_cost":27}`,
+		},
+		{
+			name:   "request body and selection",
+			method: http.MethodPost,
+			target: "/matrix/row?dry_run=1&rows=1-2",
+			body:   `{"rows":[[1,2],[3,4],[5,6]]}`,
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("PlanMatrixData", mock.Anything, "row", &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}, {5, 6}}}, selection).
+					Return(rowPlan, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"dry_run":true,"operation":"row","steps":["invert","flatten"],"parameters":{"rows":"1-2"},` +
+				`"input":{"rows":2,"cols":2,"checksum":"sha256:3f0a"},"output":{"rows":1,"cols":4,"scalar":false},"streamed":false,"estimated_cost":8}`,
+		},
+		{
+			name:   "concat",
+			method: http.MethodGet,
+			target: "/matrix/concat?file=testdata/a.csv&file2=testdata/b.csv&axis=vertical&dry_run=true",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("PlanConcat", mock.Anything, "testdata/a.csv", "testdata/b.csv", "vertical", (*entity.Selection)(nil)).
+					Return(rowPlan, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "matmul",
+			method: http.MethodGet,
+			target: "/matrix/matmul?file=testdata/a.csv&file2=testdata/b.csv&dry_run=true",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("PlanMultiplyMatrices", mock.Anything, "testdata/a.csv", "testdata/b.csv", (*entity.Selection)(nil)).
+					Return(rowPlan, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "invalid matrix",
+			method: http.MethodGet,
+			target: "/matrix/sum?file=testdata/bad.csv&dry_run=true",
+			setupMock: func(m *mocks.MockMatrixDomainInterface) {
+				m.On("PlanMatrix", mock.Anything, "sum", "testdata/bad.csv", (*entity.Selection)(nil)).
+					Return(nil, fmt.Errorf("%w: invalid integer value", apperrors.ErrUnprocessableEntity))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid dry_run parameter",
+			method:     http.MethodGet,
+			target:     "/matrix/sum?file=testdata/matrix1.csv&dry_run=maybe",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Dry runs neither run the operation nor record it, so the domains have no other expectations
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockDomain)
+			}
+			handler := &matrixHandler{matrixDomain: mockDomain, historyDomain: mocks.NewMockHistoryDomainInterface(t)}
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.method == http.MethodPost {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+
+			handler.ProcessMatrix(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestLaterModTime(t *testing.T) {
+	older := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Second)
+
+	assert.Equal(t, newer, laterModTime(older, newer))
+	assert.Equal(t, newer, laterModTime(newer, older))
+	assert.True(t, laterModTime(time.Time{}, newer).IsZero())
+	assert.True(t, laterModTime(older, time.Time{}).IsZero())
+}
+
+// newMockMatrixDomain returns a matrix domain mock whose files carry no modification time,
+// for the tests that do not exercise conditional requests.
+func newMockMatrixDomain(t *testing.T) *mocks.MockMatrixDomainInterface {
+	mockDomain := mocks.NewMockMatrixDomainInterface(t)
+	mockDomain.On("FileModTime", mock.Anything, mock.Anything).Return(time.Time{}, nil).Maybe()
+	return mockDomain
+}
+
+// newMockRetentionDomain returns a retention domain mock accepting any saved result.
+func newMockRetentionDomain(t *testing.T) *mocks.MockRetentionDomainInterface {
+	retentionDomain := mocks.NewMockRetentionDomainInterface(t)
+	retentionDomain.On("Retain", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return retentionDomain
+}
+
+// newMockHistoryDomain returns a history domain mock accepting any completed operation.
+func newMockHistoryDomain(t *testing.T) *mocks.MockHistoryDomainInterface {
+	historyDomain := mocks.NewMockHistoryDomainInterface(t)
+	historyDomain.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return historyDomain
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// memoryRetryAfter is the Retry-After value, in seconds, of requests rejected under memory pressure.
+// It gives the garbage collector and the requests still running time to release memory.
+const memoryRetryAfter = "5"
+
+// MemoryGuardHandlerInterface defines the contract for the middleware that sheds load under memory pressure.
+type MemoryGuardHandlerInterface interface {
+	// Guard wraps next so requests are rejected with 503 Service Unavailable and a Retry-After header
+	// while memory is tight. When no memory limit is configured, requests are passed through unchanged.
+	Guard(next http.Handler) http.Handler
+}
+
+type memoryGuardHandler struct {
+	memoryGuardDomain domain.MemoryGuardDomainInterface
+}
+
+// NewMemoryGuardHandler creates a new instance of MemoryGuardHandlerInterface with its dependencies.
+// It initializes the middleware with the domain service that tracks memory usage against the limit.
+func NewMemoryGuardHandler(memoryGuardDomain domain.MemoryGuardDomainInterface) MemoryGuardHandlerInterface {
+	return &memoryGuardHandler{
+		memoryGuardDomain: memoryGuardDomain,
+	}
+}
+
+func (h *memoryGuardHandler) Guard(next http.Handler) http.Handler {
+	if !h.memoryGuardDomain.Enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := h.memoryGuardDomain.Admit(r.Context()); err != nil {
+			statusCode := apperrors.GetHTTPStatusCode(err)
+			slog.WarnContext(r.Context(), "request shed",
+				"path", r.URL.Path,
+				"remote_addr", r.RemoteAddr,
+				"error", err,
+				"status_code", statusCode)
+
+			if statusCode == http.StatusServiceUnavailable {
+				w.Header().Set("Retry-After", memoryRetryAfter)
+			}
+			httpErrorFrom(w, r, err, statusCode)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
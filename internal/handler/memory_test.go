@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestMemoryGuardHandler_Guard(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+
+	t.Run("passes requests through without a memory limit", func(t *testing.T) {
+		mockDomain := mocks.NewMockMemoryGuardDomainInterface(t)
+		mockDomain.On("Enabled").Return(false)
+
+		w := httptest.NewRecorder()
+		NewMemoryGuardHandler(mockDomain).Guard(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+	})
+
+	tests := []struct {
+		name           string
+		mockError      error
+		wantStatus     int
+		wantBody       string
+		wantRetryAfter string
+	}{
+		{
+			name:       "admitted",
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:           "shed under memory pressure",
+			mockError:      fmt.Errorf("%w: server is low on memory, retry later", apperrors.ErrServiceUnavailable),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantBody:       "service unavailable: server is low on memory, retry later\n",
+			wantRetryAfter: "5",
+		},
+		{
+			name:       "cancelled request",
+			mockError:  context.Canceled,
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "context canceled\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMemoryGuardDomainInterface(t)
+			mockDomain.On("Enabled").Return(true)
+			mockDomain.On("Admit", mock.Anything).Return(tt.mockError)
+
+			w := httptest.NewRecorder()
+			NewMemoryGuardHandler(mockDomain).Guard(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+			assert.Equal(t, tt.wantRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
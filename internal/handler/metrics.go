@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/metrics"
+)
+
+// metricsRoutes are the route templates reported in request metrics, by first path segment.
+// Requests to other paths are reported under the "other" route, so clients cannot create series at will.
+var metricsRoutes = map[string]string{
+	"":          "/",
+	"admin":     "/admin/{endpoint}",
+	"batch":     "/batch/{operation}",
+	"drain":     "/drain",
+	"health":    "/health",
+	"jobs":      "/jobs/{id}",
+	"matrix":    "/matrix/{operation}",
+	"metrics":   "/metrics",
+	"schedules": "/schedules/{id}",
+	"ui":        "/ui/{path...}",
+	"uploads":   "/uploads/{id}",
+	"ws":        "/ws",
+}
+
+// MetricsHandlerInterface defines the contract for the middleware that measures the HTTP requests.
+type MetricsHandlerInterface interface {
+	// Record wraps next so every request is counted with its duration by method, route and status code,
+	// and the requests that run a matrix operation by operation, with the sizes of the matrices they read.
+	Record(next http.Handler) http.Handler
+
+	// ServeMetrics handles GET /metrics, writing the current metrics in the Prometheus text exposition format.
+	ServeMetrics(w http.ResponseWriter, r *http.Request)
+}
+
+type metricsHandler struct {
+	metricsDomain domain.MetricsDomainInterface
+}
+
+// NewMetricsHandler creates a new instance of MetricsHandlerInterface with its dependencies.
+// It initializes the middleware with the metrics domain service that accumulates the measurements.
+func NewMetricsHandler(metricsDomain domain.MetricsDomainInterface) MetricsHandlerInterface {
+	return &metricsHandler{
+		metricsDomain: metricsDomain,
+	}
+}
+
+func (h *metricsHandler) Record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+		// The layers serving the request describe the operation it runs, so handlers need not report it
+		request := metrics.NewRequest()
+
+		next.ServeHTTP(recorder, r.WithContext(metrics.NewContext(r.Context(), request)))
+
+		duration := time.Since(start)
+		h.metricsDomain.RecordRequest(r.Method, metricsRoute(r.URL.Path), recorder.status(), duration)
+		if operation := request.Operation(); operation != "" {
+			h.metricsDomain.RecordOperation(operation, duration, request.Sizes())
+		}
+	})
+}
+
+func (h *metricsHandler) ServeMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", metrics.ContentType)
+	if err := metrics.WritePrometheus(w, h.metricsDomain.Snapshot()); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write metrics", "error", err)
+	}
+}
+
+// metricsRoute returns the route template of path, such as /matrix/{operation} for /matrix/sum.
+// Collection paths without an ID, such as /jobs, keep their own route.
+func metricsRoute(path string) string {
+	segment, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	route, ok := metricsRoutes[segment]
+	if !ok {
+		return "other"
+	}
+	if rest == "" && segment != "" && segment != "ui" {
+		return "/" + segment
+	}
+	return route
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/metrics"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+)
+
+func TestMetricsHandler_Record(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		status     int
+		wantRoute  string
+		wantStatus int
+	}{
+		{name: "operation", method: http.MethodGet, path: "/matrix/sum", wantRoute: "/matrix/{operation}", wantStatus: http.StatusOK},
+		{name: "operation listing", method: http.MethodGet, path: "/matrix", wantRoute: "/matrix", wantStatus: http.StatusOK},
+		{name: "job", method: http.MethodDelete, path: "/jobs/abc", status: http.StatusNoContent, wantRoute: "/jobs/{id}", wantStatus: http.StatusNoContent},
+		{name: "job submission", method: http.MethodPost, path: "/jobs", status: http.StatusAccepted, wantRoute: "/jobs", wantStatus: http.StatusAccepted},
+		{name: "landing page", method: http.MethodGet, path: "/", wantRoute: "/", wantStatus: http.StatusOK},
+		{name: "dashboard", method: http.MethodGet, path: "/ui/api/summary", wantRoute: "/ui/{path...}", wantStatus: http.StatusOK},
+		{name: "dashboard without a trailing slash", method: http.MethodGet, path: "/ui", wantRoute: "/ui/{path...}", wantStatus: http.StatusOK},
+		{name: "unknown path", method: http.MethodGet, path: "/wp-admin/setup.php", status: http.StatusNotFound, wantRoute: "other", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMetricsDomainInterface(t)
+			mockDomain.On("RecordRequest", tt.method, tt.wantRoute, tt.wantStatus, mock.AnythingOfType("time.Duration")).Return().Once()
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+			})
+
+			w := httptest.NewRecorder()
+			NewMetricsHandler(mockDomain).Record(next).ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMetricsHandler_Record_Operation(t *testing.T) {
+	mockDomain := mocks.NewMockMetricsDomainInterface(t)
+	mockDomain.On("RecordRequest", http.MethodGet, "/matrix/{operation}", http.StatusOK, mock.AnythingOfType("time.Duration")).Return().Once()
+	mockDomain.On("RecordOperation", "sum", mock.AnythingOfType("time.Duration"), []int{6, 4}).Return().Once()
+	// The layers serving the request describe it, without the handler reporting anything
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		metrics.SetOperation(r.Context(), "sum")
+		metrics.ObserveMatrix(r.Context(), 2, 3)
+		metrics.ObserveMatrix(r.Context(), 2, 2)
+	})
+
+	w := httptest.NewRecorder()
+	NewMetricsHandler(mockDomain).Record(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMetricsHandler_ServeMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		wantStatus int
+	}{
+		{name: "scrape", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "method not allowed", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMetricsDomainInterface(t)
+			if tt.wantStatus == http.StatusOK {
+				mockDomain.On("Snapshot").Return(&entity.MetricsSnapshot{Metrics: []*entity.Metric{{
+					Name:        "http.server.requests",
+					Description: "Count of HTTP server requests.",
+					Unit:        "{request}",
+					Kind:        entity.MetricCounter,
+					Points:      []*entity.MetricPoint{{Attributes: map[string]string{"http.route": "/health"}, Value: 3}},
+				}}}).Once()
+			}
+
+			w := httptest.NewRecorder()
+			NewMetricsHandler(mockDomain).ServeMetrics(w, httptest.NewRequest(tt.method, "/metrics", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, metrics.ContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, "# HELP http_server_requests_total Count of HTTP server requests.\n"+
+					"# TYPE http_server_requests_total counter\n"+
+					`http_server_requests_total{http_route="/health"} 3`+"\n", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/openapi"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// OpenAPIHandlerInterface defines the contract for serving the OpenAPI document of the API and enforcing it.
+type OpenAPIHandlerInterface interface {
+	// ServeDocument handles GET /openapi.json requests, returning the OpenAPI 3 document describing every route
+	// with its parameters and error codes, with the operations enabled when it is requested.
+	ServeDocument(w http.ResponseWriter, r *http.Request)
+
+	// Validate wraps next so requests are checked against the OpenAPI document before they are served.
+	// Requests with a query parameter their route does not list are rejected with 400 Bad Request, as are
+	// requests naming an unknown operation, and requests naming a disabled one with 403 Forbidden. JSON error
+	// responses name the parameter at fault in their details.
+	Validate(next http.Handler) http.Handler
+}
+
+type openAPIHandler struct {
+	operationsDomain domain.MatrixOperationsDomainInterface
+}
+
+// NewOpenAPIHandler creates a new instance of OpenAPIHandlerInterface with its dependencies.
+// It initializes the handler with the operations domain service that lists and validates the operation names.
+func NewOpenAPIHandler(operationsDomain domain.MatrixOperationsDomainInterface) OpenAPIHandlerInterface {
+	return &openAPIHandler{
+		operationsDomain: operationsDomain,
+	}
+}
+
+func (h *openAPIHandler) ServeDocument(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := openapi.Document(h.operationsDomain.ListOperations())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode OpenAPI document", "error", err)
+		httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, r, http.StatusOK, json.RawMessage(body))
+}
+
+func (h *openAPIHandler) Validate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := openapi.Validate(r.Method, r.URL.Path, r.URL.Query(), func(operation string) error {
+			return h.operationsDomain.IsValidOperation(r.Context(), operation)
+		})
+		if err != nil {
+			statusCode := apperrors.GetHTTPStatusCode(err)
+			slog.ErrorContext(r.Context(), "request does not follow the OpenAPI document",
+				"error", err,
+				"status_code", statusCode)
+
+			var details map[string]string
+			var paramErr *openapi.Error
+			if errors.As(err, &paramErr) {
+				details = map[string]string{"parameter": paramErr.Parameter}
+			}
+			writeErrorResponse(w, r, statusCode, errorCode(err, statusCode), err.Error(), details)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matsuboshi/league-matrix-app/internal/middleware"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestOpenAPIHandler_ServeDocument(t *testing.T) {
+	t.Run("document with the enabled operations", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixOperationsDomainInterface(t)
+		mockDomain.On("ListOperations").Return([]string{"sum", "transpose"})
+
+		w := httptest.NewRecorder()
+		NewOpenAPIHandler(mockDomain).ServeDocument(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var doc struct {
+			Components struct {
+				Schemas struct {
+					OperationName struct {
+						Enum []string `json:"enum"`
+					} `json:"OperationName"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, []string{"sum", "transpose"}, doc.Components.Schemas.OperationName.Enum)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewOpenAPIHandler(mocks.NewMockMatrixOperationsDomainInterface(t)).
+			ServeDocument(w, httptest.NewRequest(http.MethodPost, "/openapi.json", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestOpenAPIHandler_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		operation    string
+		operationErr error
+		wantStatus   int
+		wantBody     string
+	}{
+		{name: "valid request", method: http.MethodGet, target: "/matrix/sum?file=a.csv&format=json", operation: "sum", wantStatus: http.StatusOK},
+		{name: "route without operation", method: http.MethodGet, target: "/results/recent?limit=5", wantStatus: http.StatusOK},
+		{
+			name: "unknown query parameter", method: http.MethodGet, target: "/results/recent?limt=5",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code":"INVALID_INPUT","message":"invalid input: unknown query parameter: \"limt\"","details":{"parameter":"limt"},"request_id":"req-1"}`,
+		},
+		{
+			name: "unknown operation", method: http.MethodGet, target: "/matrix/sqrt?file=a.csv", operation: "sqrt",
+			operationErr: fmt.Errorf("%w: invalid operation: sqrt", apperrors.ErrInvalidOperation),
+			wantStatus:   http.StatusBadRequest,
+			wantBody:     `{"code":"INVALID_OPERATION","message":"invalid input: invalid operation: sqrt","details":{"parameter":"operation"},"request_id":"req-1"}`,
+		},
+		{
+			name: "disabled operation", method: http.MethodPost, target: "/batch/sum", operation: "sum",
+			operationErr: fmt.Errorf("%w: operation sum is disabled", apperrors.ErrForbidden),
+			wantStatus:   http.StatusForbidden,
+			wantBody:     `{"code":"FORBIDDEN","message":"forbidden: operation sum is disabled","details":{"parameter":"operation"},"request_id":"req-1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMatrixOperationsDomainInterface(t)
+			if tt.operation != "" {
+				mockDomain.On("IsValidOperation", mock.Anything, tt.operation).Return(tt.operationErr)
+			}
+			served := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				served = true
+			})
+
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			r.Header.Set("Accept", "application/json")
+			r.Header.Set(middleware.RequestIDHeader, "req-1")
+			w := httptest.NewRecorder()
+			NewOpenAPIHandler(mockDomain).Validate(next).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantStatus == http.StatusOK, served)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// OperationHandlerInterface defines the contract for the admin HTTP handlers that manage the matrix operations
+// the service runs, without a restart.
+type OperationHandlerInterface interface {
+	// HandleOperations handles requests to /admin/operations.
+	// GET lists every operation, enabled or not; POST registers a composite operation from a JSON body with
+	// a name, a description and the steps it runs, responding with 201 Created and its URL in the Location header.
+	HandleOperations(w http.ResponseWriter, r *http.Request)
+
+	// HandleOperation handles requests for a single operation under /admin/operations/{name}.
+	// GET describes the operation; PATCH enables or disables it from a JSON body with an enabled field.
+	HandleOperation(w http.ResponseWriter, r *http.Request)
+}
+
+// operationRequest is the body of an operation registration request.
+type operationRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Steps       []string `json:"steps"`
+}
+
+// operationUpdateRequest is the body of a request enabling or disabling an operation.
+// Enabled is a pointer so a body without it is rejected rather than disabling the operation.
+type operationUpdateRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// operationResponse describes an operation. Steps are omitted for built-in operations.
+type operationResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Steps       []string `json:"steps,omitempty"`
+	BuiltIn     bool     `json:"built_in"`
+	Enabled     bool     `json:"enabled"`
+}
+
+type operationListResponse struct {
+	Operations []operationResponse `json:"operations"`
+}
+
+type operationHandler struct {
+	operationRegistryDomain domain.OperationRegistryDomainInterface
+}
+
+// NewOperationHandler creates a new instance of OperationHandlerInterface with its dependencies.
+// It initializes the handler with the domain service managing the registry of operations.
+func NewOperationHandler(operationRegistryDomain domain.OperationRegistryDomainInterface) OperationHandlerInterface {
+	return &operationHandler{
+		operationRegistryDomain: operationRegistryDomain,
+	}
+}
+
+func (h *operationHandler) HandleOperations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		operations, err := h.operationRegistryDomain.ListOperations(r.Context())
+		if err != nil {
+			h.writeError(w, r, "", err)
+			return
+		}
+
+		response := operationListResponse{Operations: make([]operationResponse, 0, len(operations))}
+		for _, operation := range operations {
+			response.Operations = append(response.Operations, toOperationResponse(&operation))
+		}
+		writeJSON(w, r, http.StatusOK, response)
+
+	case http.MethodPost:
+		request := &operationRequest{}
+		if err := decodeOperationRequest(r, request); err != nil {
+			h.writeError(w, r, "", err)
+			return
+		}
+		audit.Describe(r.Context(), request.Name, "")
+
+		operation, err := h.operationRegistryDomain.RegisterOperation(r.Context(), request.Name, request.Description, request.Steps)
+		if err != nil {
+			h.writeError(w, r, request.Name, err)
+			return
+		}
+
+		w.Header().Set("Location", "/admin/operations/"+string(operation.Name))
+		writeJSON(w, r, http.StatusCreated, toOperationResponse(operation))
+
+	default:
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *operationHandler) HandleOperation(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Path[len("/admin/operations/"):]
+
+	switch r.Method {
+	case http.MethodGet:
+		operation, err := h.operationRegistryDomain.GetOperation(r.Context(), name)
+		if err != nil {
+			h.writeError(w, r, name, err)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, toOperationResponse(operation))
+
+	case http.MethodPatch:
+		request := &operationUpdateRequest{}
+		if err := decodeOperationRequest(r, request); err != nil {
+			h.writeError(w, r, name, err)
+			return
+		}
+		if request.Enabled == nil {
+			h.writeError(w, r, name, fmt.Errorf("%w: missing enabled field", apperrors.ErrInvalidInput))
+			return
+		}
+		audit.Describe(r.Context(), name, "")
+
+		operation, err := h.operationRegistryDomain.SetOperationEnabled(r.Context(), name, *request.Enabled)
+		if err != nil {
+			h.writeError(w, r, name, err)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, toOperationResponse(operation))
+
+	default:
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *operationHandler) writeError(w http.ResponseWriter, r *http.Request, name string, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "operation request failed",
+		"operation", name,
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// decodeOperationRequest reads the JSON body of an operation request into request.
+func decodeOperationRequest(r *http.Request, request any) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jobContentType {
+		return fmt.Errorf("%w: operations must be sent as %s", apperrors.ErrUnsupportedMediaType, jobContentType)
+	}
+
+	// Limit the body size BEFORE reading to prevent DoS attacks
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return fmt.Errorf("%w: request body too large (maximum: %d bytes)",
+				apperrors.ErrPayloadTooLarge, maxRequestBodyBytes)
+		}
+		return fmt.Errorf("%w: failed to read request body: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	if err := json.Unmarshal(data, request); err != nil {
+		return fmt.Errorf("%w: invalid operation request: %v", apperrors.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// toOperationResponse converts an operation to its JSON representation.
+func toOperationResponse(operation *entity.OperationInfo) operationResponse {
+	response := operationResponse{
+		Name:        string(operation.Name),
+		Description: operation.Description,
+		BuiltIn:     operation.BuiltIn,
+		Enabled:     operation.Enabled,
+	}
+	for _, step := range operation.Steps {
+		response.Steps = append(response.Steps, string(step))
+	}
+	return response
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+func TestOperationHandler_HandleOperations(t *testing.T) {
+	columnSums := &entity.OperationInfo{
+		Name:        "column-sums",
+		Description: "Adds every column.",
+		Steps:       []matrixlib.Operation{matrixlib.Invert, matrixlib.Sum},
+		Enabled:     true,
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		contentType  string
+		body         string
+		setupMock    func(*mocks.MockOperationRegistryDomainInterface)
+		wantStatus   int
+		wantBody     string
+		wantLocation string
+	}{
+		{
+			name:   "list operations",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockOperationRegistryDomainInterface) {
+				m.On("ListOperations", mock.Anything).Return([]entity.OperationInfo{
+					*columnSums,
+					{Name: "sum", Description: "Adds every value of the matrix.", BuiltIn: true},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"operations":[` +
+				`{"name":"column-sums","description":"Adds every column.","steps":["invert","sum"],"built_in":false,"enabled":true},` +
+				`{"name":"sum","description":"Adds every value of the matrix.","built_in":true,"enabled":false}]}`,
+		},
+		{
+			name:        "register an operation",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"name":"column-sums","description":"Adds every column.","steps":["invert","sum"]}`,
+			setupMock: func(m *mocks.MockOperationRegistryDomainInterface) {
+				m.On("RegisterOperation", mock.Anything, "column-sums", "Adds every column.", []string{"invert", "sum"}).
+					Return(columnSums, nil)
+			},
+			wantStatus:   http.StatusCreated,
+			wantBody:     `{"name":"column-sums","description":"Adds every column.","steps":["invert","sum"],"built_in":false,"enabled":true}`,
+			wantLocation: "/admin/operations/column-sums",
+		},
+		{
+			name:        "name taken",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"name":"sum","steps":["echo"]}`,
+			setupMock: func(m *mocks.MockOperationRegistryDomainInterface) {
+				m.On("RegisterOperation", mock.Anything, "sum", "", []string{"echo"}).Return(nil, apperrors.ErrConflict)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:        "malformed body",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"steps":"sum"}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "unsupported media type",
+			method:      http.MethodPost,
+			contentType: "text/plain",
+			body:        "column-sums",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockOperationRegistryDomainInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockDomain)
+			}
+
+			req := httptest.NewRequest(tt.method, "/admin/operations", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			NewOperationHandler(mockDomain).HandleOperations(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestOperationHandler_HandleOperation(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		setupMock   func(*mocks.MockOperationRegistryDomainInterface)
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:   "describe an operation",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockOperationRegistryDomainInterface) {
+				m.On("GetOperation", mock.Anything, "sum").
+					Return(&entity.OperationInfo{Name: "sum", Description: "Adds every value of the matrix.", BuiltIn: true, Enabled: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"name":"sum","description":"Adds every value of the matrix.","built_in":true,"enabled":true}`,
+		},
+		{
+			name:   "unknown operation",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockOperationRegistryDomainInterface) {
+				m.On("GetOperation", mock.Anything, "sum").Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:        "disable an operation",
+			method:      http.MethodPatch,
+			contentType: "application/json",
+			body:        `{"enabled":false}`,
+			setupMock: func(m *mocks.MockOperationRegistryDomainInterface) {
+				m.On("SetOperationEnabled", mock.Anything, "sum", false).
+					Return(&entity.OperationInfo{Name: "sum", Description: "Adds every value of the matrix.", BuiltIn: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"name":"sum","description":"Adds every value of the matrix.","built_in":true,"enabled":false}`,
+		},
+		{
+			name:        "missing enabled field",
+			method:      http.MethodPatch,
+			contentType: "application/json",
+			body:        `{}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockOperationRegistryDomainInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockDomain)
+			}
+
+			req := httptest.NewRequest(tt.method, "/admin/operations/sum", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			NewOperationHandler(mockDomain).HandleOperation(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
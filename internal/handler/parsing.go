@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// ParsingHandlerInterface defines the contract for the middleware that selects how matrix CSV input is read.
+type ParsingHandlerInterface interface {
+	// Parse wraps next so the request carries the parsing options of the server, overridden by the
+	// lenient, header and row_labels query parameters when they are given. Requests with an invalid value are rejected with 400.
+	Parse(next http.Handler) http.Handler
+}
+
+type parsingHandler struct {
+	defaults parsing.Options
+}
+
+// NewParsingHandler creates a new instance of ParsingHandlerInterface.
+// It initializes the middleware with the parsing options requests get unless they ask for others.
+func NewParsingHandler(defaults parsing.Options) ParsingHandlerInterface {
+	return &parsingHandler{
+		defaults: defaults,
+	}
+}
+
+func (h *parsingHandler) Parse(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		options := h.defaults
+		for _, param := range []struct {
+			name  string
+			value *bool
+		}{
+			{name: "lenient", value: &options.Lenient},
+			{name: "header", value: &options.Header},
+			{name: "row_labels", value: &options.RowLabels},
+		} {
+			value := r.URL.Query().Get(param.name)
+			if value == "" {
+				continue
+			}
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				err = fmt.Errorf("%w: invalid %s parameter: %q", apperrors.ErrInvalidInput, param.name, value)
+				slog.ErrorContext(r.Context(), "invalid parsing parameter", "error", err)
+				httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+				return
+			}
+			*param.value = enabled
+		}
+
+		next.ServeHTTP(w, r.WithContext(parsing.NewContext(r.Context(), options)))
+	})
+}
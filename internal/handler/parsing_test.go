@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+)
+
+func TestParsingHandler_Parse(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaults    parsing.Options
+		query       string
+		wantOptions parsing.Options
+		wantStatus  int
+	}{
+		{name: "strict by default", query: "", wantStatus: http.StatusOK},
+		{name: "lenient on request", query: "?lenient=true", wantOptions: parsing.Options{Lenient: true}, wantStatus: http.StatusOK},
+		{name: "lenient server default", defaults: parsing.Options{Lenient: true}, wantOptions: parsing.Options{Lenient: true}, wantStatus: http.StatusOK},
+		{name: "strict on request", defaults: parsing.Options{Lenient: true}, query: "?lenient=false", wantStatus: http.StatusOK},
+		{name: "header row", query: "?header=true", wantOptions: parsing.Options{Header: true}, wantStatus: http.StatusOK},
+		{name: "lenient with header row", query: "?lenient=1&header=1", wantOptions: parsing.Options{Lenient: true, Header: true}, wantStatus: http.StatusOK},
+		{name: "row labels", query: "?row_labels=true", wantOptions: parsing.Options{RowLabels: true}, wantStatus: http.StatusOK},
+		{name: "invalid lenient value", query: "?lenient=maybe", wantStatus: http.StatusBadRequest},
+		{name: "invalid header value", query: "?header=first", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *parsing.Options
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				options := parsing.FromContext(r.Context())
+				got = &options
+			})
+
+			w := httptest.NewRecorder()
+			NewParsingHandler(tt.defaults).Parse(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrix/sum"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NotNil(t, got) {
+				assert.Equal(t, tt.wantOptions, *got)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
+)
+
+const (
+	// debugProfileHeader is the request header asking for the timing breakdown of the request.
+	debugProfileHeader = "X-Debug-Profile"
+
+	// serverTimingHeader is the response header the timing breakdown is reported in.
+	serverTimingHeader = "Server-Timing"
+)
+
+// ProfileHandlerInterface defines the contract for the middleware reporting how long each stage
+// of serving a request took, so slow requests can be diagnosed by the clients making them.
+type ProfileHandlerInterface interface {
+	// Profile wraps next so requests sent with an "X-Debug-Profile: true" header are answered with
+	// a Server-Timing header breaking down the time spent validating the path, reading, parsing and
+	// validating the matrix, running the operation and serializing the result, plus the total.
+	// Responses streamed as they are serialized repeat the header as a trailer once they are complete.
+	// The header is ignored unless profiling is enabled.
+	Profile(next http.Handler) http.Handler
+}
+
+type profileHandler struct {
+	enabled bool
+}
+
+// NewProfileHandler creates a new instance of ProfileHandlerInterface.
+// It initializes the middleware with whether clients may ask for profiles, which are off by default
+// as they reveal how the service spends its time.
+func NewProfileHandler(enabled bool) ProfileHandlerInterface {
+	return &profileHandler{
+		enabled: enabled,
+	}
+}
+
+func (h *profileHandler) Profile(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requested, _ := strconv.ParseBool(r.Header.Get(debugProfileHeader)); !h.enabled || !requested {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		requestProfile := profile.New()
+		writer := &profileWriter{ResponseWriter: w, profile: requestProfile}
+		next.ServeHTTP(writer, r.WithContext(profile.NewContext(r.Context(), requestProfile)))
+
+		// Only sent for chunked responses, whose serialization may have outlasted the header
+		if writer.wroteHeader {
+			w.Header().Set(http.TrailerPrefix+serverTimingHeader, serverTiming(requestProfile))
+		}
+	})
+}
+
+// serverTiming formats the timings of p as a Server-Timing header value, in milliseconds.
+func serverTiming(p *profile.Profile) string {
+	var metrics []string
+	for _, timing := range p.Timings() {
+		metrics = append(metrics, serverTimingMetric(string(timing.Stage), timing.Duration))
+	}
+	metrics = append(metrics, serverTimingMetric("total", p.Total()))
+	return strings.Join(metrics, ", ")
+}
+
+func serverTimingMetric(name string, duration time.Duration) string {
+	return name + ";dur=" + strconv.FormatFloat(float64(duration)/float64(time.Millisecond), 'f', 3, 64)
+}
+
+// profileWriter adds the Server-Timing header of a profiled request when its headers are written.
+// It keeps the streaming and WebSocket endpoints working by passing flushes and hijacks through.
+type profileWriter struct {
+	http.ResponseWriter
+	profile     *profile.Profile
+	wroteHeader bool
+}
+
+func (p *profileWriter) WriteHeader(code int) {
+	if !p.wroteHeader {
+		p.wroteHeader = true
+		p.Header().Set(serverTimingHeader, serverTiming(p.profile))
+	}
+	p.ResponseWriter.WriteHeader(code)
+}
+
+func (p *profileWriter) Write(b []byte) (int, error) {
+	if !p.wroteHeader {
+		p.WriteHeader(http.StatusOK)
+	}
+	return p.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, so streamed responses are still flushed.
+func (p *profileWriter) Unwrap() http.ResponseWriter {
+	return p.ResponseWriter
+}
+
+// Hijack hands the connection over to the WebSocket handler, which needs an http.Hijacker.
+func (p *profileWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(p.ResponseWriter).Hijack()
+}
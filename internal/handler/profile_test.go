@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
+)
+
+func TestProfileHandler_Profile(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		header      string
+		wantProfile bool
+	}{
+		{name: "requested", enabled: true, header: "true", wantProfile: true},
+		{name: "requested with 1", enabled: true, header: "1", wantProfile: true},
+		{name: "not requested", enabled: true},
+		{name: "declined", enabled: true, header: "false"},
+		{name: "invalid header", enabled: true, header: "please"},
+		{name: "disabled", header: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantProfile, profile.FromContext(r.Context()) != nil)
+
+				stopValidate := profile.Start(r.Context(), profile.Validate)
+				stopOperation := profile.Start(r.Context(), profile.Operation)
+				stopOperation()
+				stopValidate()
+				_, _ = w.Write([]byte("3"))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=testdata/matrix1.csv", nil)
+			if tt.header != "" {
+				req.Header.Set(debugProfileHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			NewProfileHandler(tt.enabled).Profile(next).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "3", w.Body.String())
+			if !tt.wantProfile {
+				assert.Empty(t, w.Header().Get(serverTimingHeader))
+				return
+			}
+			// Stages are reported in pipeline order, in milliseconds
+			wantTiming := `^validate;dur=\d+\.\d{3}, operation;dur=\d+\.\d{3}, total;dur=\d+\.\d{3}$`
+			assert.Regexp(t, wantTiming, w.Result().Header.Get(serverTimingHeader))
+			assert.Regexp(t, wantTiming, w.Result().Trailer.Get(serverTimingHeader))
+		})
+	}
+}
+
+func TestProfileHandler_Profile_Error(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer profile.Start(r.Context(), profile.Path)()
+		http.Error(w, "invalid input: path traversal not allowed", http.StatusBadRequest)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/matrix/sum?file=../secret.csv", nil)
+	req.Header.Set(debugProfileHeader, "true")
+	w := httptest.NewRecorder()
+	NewProfileHandler(true).Profile(next).ServeHTTP(w, req)
+
+	// Failed requests are profiled up to the stage they failed in
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Regexp(t, `^path;dur=\d+\.\d{3}, total;dur=\d+\.\d{3}$`, w.Header().Get(serverTimingHeader))
+}
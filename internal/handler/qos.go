@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// qosHeader is the request header selecting the class of service of a request, echoed on the response.
+const qosHeader = "X-QoS-Class"
+
+// QoSHandlerInterface defines the contract for the middleware that selects the class of service requests are scheduled with.
+type QoSHandlerInterface interface {
+	// Classify wraps next so the request carries the class of service of its tenant, lowered to batch by the
+	// X-QoS-Class header when asked. A header can never raise a batch tenant to interactive.
+	// Requests with an invalid class are rejected with 400.
+	Classify(next http.Handler) http.Handler
+}
+
+type qosHandler struct{}
+
+// NewQoSHandler creates a new instance of QoSHandlerInterface.
+// It initializes the middleware, which needs no configuration since tenants carry their own class.
+func NewQoSHandler() QoSHandlerInterface {
+	return &qosHandler{}
+}
+
+func (h *qosHandler) Classify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested, err := qos.Parse(r.Header.Get(qosHeader))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid class of service", "error", err)
+			httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+			return
+		}
+
+		var tier qos.Class
+		if t, ok := tenant.FromContext(r.Context()); ok {
+			tier = t.QoS
+		}
+		class := qos.Min(tier, requested)
+
+		w.Header().Set(qosHeader, string(class))
+		next.ServeHTTP(w, r.WithContext(qos.NewContext(r.Context(), class)))
+	})
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+func TestQoSHandler_Classify(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenant     *tenant.Tenant
+		header     string
+		wantClass  qos.Class
+		wantStatus int
+	}{
+		{name: "interactive by default", wantClass: qos.Interactive, wantStatus: http.StatusOK},
+		{name: "batch on request", header: "batch", wantClass: qos.Batch, wantStatus: http.StatusOK},
+		{name: "interactive on request", header: "interactive", wantClass: qos.Interactive, wantStatus: http.StatusOK},
+		{name: "interactive tenant", tenant: &tenant.Tenant{ID: "acme"}, wantClass: qos.Interactive, wantStatus: http.StatusOK},
+		{name: "batch tenant", tenant: &tenant.Tenant{ID: "acme", QoS: qos.Batch}, wantClass: qos.Batch, wantStatus: http.StatusOK},
+		{
+			name:       "header cannot raise a batch tenant",
+			tenant:     &tenant.Tenant{ID: "acme", QoS: qos.Batch},
+			header:     "interactive",
+			wantClass:  qos.Batch,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "header lowers an interactive tenant",
+			tenant:     &tenant.Tenant{ID: "acme", QoS: qos.Interactive},
+			header:     "batch",
+			wantClass:  qos.Batch,
+			wantStatus: http.StatusOK,
+		},
+		{name: "invalid class", header: "realtime", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got qos.Class
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = qos.FromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+			if tt.tenant != nil {
+				req = req.WithContext(tenant.NewContext(req.Context(), tt.tenant))
+			}
+			if tt.header != "" {
+				req.Header.Set(qosHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			NewQoSHandler().Classify(next).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantClass, got)
+			assert.Equal(t, string(tt.wantClass), w.Header().Get(qosHeader))
+		})
+	}
+}
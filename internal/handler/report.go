@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// ReportHandlerInterface defines the contract for HTTP handlers that report the problems of matrix files.
+type ReportHandlerInterface interface {
+	// ReportMatrix handles GET /matrix/report?file=... requests. It responds with a JSON report of the
+	// errors, which make the service reject the file, and warnings found in it, each with a suggested fix.
+	// A file with errors still gives 200 OK, with valid set to false.
+	ReportMatrix(w http.ResponseWriter, r *http.Request)
+}
+
+// reportResponse is the JSON body of a validation report. Errors and warnings are never null.
+type reportResponse struct {
+	File     string                `json:"file"`
+	Valid    bool                  `json:"valid"`
+	Rows     int                   `json:"rows"`
+	Cols     int                   `json:"cols"`
+	Errors   []reportIssueResponse `json:"errors"`
+	Warnings []reportIssueResponse `json:"warnings"`
+}
+
+// reportIssueResponse describes a single problem of the file. Row, column and value are set for
+// problems of a single value, while rows are set for problems spanning several rows.
+type reportIssueResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Lines   []int  `json:"lines,omitempty"`
+	Rows    []int  `json:"rows,omitempty"`
+	Row     int    `json:"row,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Fix     string `json:"fix"`
+}
+
+type reportHandler struct {
+	reportDomain domain.ReportDomainInterface
+}
+
+// NewReportHandler creates a new instance of ReportHandlerInterface with its dependencies.
+// It initializes the handler with the domain service that inspects matrix files.
+func NewReportHandler(reportDomain domain.ReportDomainInterface) ReportHandlerInterface {
+	return &reportHandler{
+		reportDomain: reportDomain,
+	}
+}
+
+func (h *reportHandler) ReportMatrix(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	audit.Describe(r.Context(), "report", filePath)
+
+	report, err := h.reportDomain.ReportFile(r.Context(), filePath)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			slog.InfoContext(r.Context(), "request cancelled by client")
+			return
+		}
+
+		statusCode := apperrors.GetHTTPStatusCode(err)
+		slog.ErrorContext(r.Context(), "matrix report failed",
+			"error", err,
+			"status_code", statusCode)
+		httpErrorFrom(w, r, err, statusCode)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "matrix report completed",
+		"errors", len(report.Errors),
+		"warnings", len(report.Warnings))
+	writeJSON(w, r, http.StatusOK, reportResponse{
+		File:     report.FilePath,
+		Valid:    report.Valid(),
+		Rows:     report.Rows,
+		Cols:     report.Cols,
+		Errors:   toReportIssueResponses(report.Errors),
+		Warnings: toReportIssueResponses(report.Warnings),
+	})
+}
+
+func toReportIssueResponses(issues []*entity.ReportIssue) []reportIssueResponse {
+	responses := make([]reportIssueResponse, 0, len(issues))
+	for _, issue := range issues {
+		responses = append(responses, reportIssueResponse{
+			Code:    string(issue.Code),
+			Message: issue.Message,
+			Lines:   issue.Lines,
+			Rows:    issue.Rows,
+			Row:     issue.Row,
+			Column:  issue.Col,
+			Value:   issue.Value,
+			Fix:     issue.Fix,
+		})
+	}
+	return responses
+}
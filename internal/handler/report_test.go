@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestReportHandler_ReportMatrix(t *testing.T) {
+	t.Run("report with errors and warnings", func(t *testing.T) {
+		mockDomain := mocks.NewMockReportDomainInterface(t)
+		mockDomain.On("ReportFile", mock.Anything, "testdata/matrix3.csv").Return(&entity.MatrixReport{
+			FilePath: "testdata/matrix3.csv",
+			Rows:     3,
+			Cols:     3,
+			Errors: []*entity.ReportIssue{
+				{Code: entity.ReportRowLength, Message: "uneven", Fix: "even out", Lines: []int{2}, Rows: []int{2}},
+				{Code: entity.ReportNonNumericCell, Message: "bad", Fix: "replace", Lines: []int{3}, Row: 3, Col: 1, Value: "x"},
+			},
+			Warnings: []*entity.ReportIssue{
+				{Code: entity.ReportTrailingBlankLines, Message: "blank", Fix: "remove", Lines: []int{4}},
+			},
+		}, nil)
+
+		handler := &reportHandler{reportDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.ReportMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/report?file=testdata/matrix3.csv", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"file":"testdata/matrix3.csv","valid":false,"rows":3,"cols":3,
+			"errors":[
+				{"code":"inconsistent_row_length","message":"uneven","lines":[2],"rows":[2],"fix":"even out"},
+				{"code":"non_numeric_cell","message":"bad","lines":[3],"row":3,"column":1,"value":"x","fix":"replace"}],
+			"warnings":[{"code":"trailing_blank_lines","message":"blank","lines":[4],"fix":"remove"}]}`, w.Body.String())
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		mockDomain := mocks.NewMockReportDomainInterface(t)
+		mockDomain.On("ReportFile", mock.Anything, "testdata/matrix1.csv").
+			Return(&entity.MatrixReport{FilePath: "testdata/matrix1.csv", Rows: 9, Cols: 3}, nil)
+
+		handler := &reportHandler{reportDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.ReportMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/report?file=testdata/matrix1.csv", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"file":"testdata/matrix1.csv","valid":true,"rows":9,"cols":3,"errors":[],"warnings":[]}`,
+			w.Body.String())
+	})
+
+	errorTests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "invalid file path", err: apperrors.ErrInvalidInput, wantStatus: http.StatusBadRequest},
+		{name: "missing file", err: apperrors.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "file too large", err: apperrors.ErrPayloadTooLarge, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockReportDomainInterface(t)
+			mockDomain.On("ReportFile", mock.Anything, "testdata/file.csv").Return(nil, tt.err)
+
+			handler := &reportHandler{reportDomain: mockDomain}
+			w := httptest.NewRecorder()
+			handler.ReportMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/report?file=testdata/file.csv", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	t.Run("cancelled request", func(t *testing.T) {
+		mockDomain := mocks.NewMockReportDomainInterface(t)
+		mockDomain.On("ReportFile", mock.Anything, "testdata/matrix1.csv").Return(nil, context.Canceled)
+
+		handler := &reportHandler{reportDomain: mockDomain}
+		w := httptest.NewRecorder()
+		handler.ReportMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/report?file=testdata/matrix1.csv", nil))
+
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		handler := &reportHandler{reportDomain: mocks.NewMockReportDomainInterface(t)}
+		w := httptest.NewRecorder()
+		handler.ReportMatrix(w, httptest.NewRequest(http.MethodPost, "/matrix/report?file=testdata/matrix1.csv", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
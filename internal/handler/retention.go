@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// RetentionHandlerInterface defines the contract for HTTP handlers that manage how long stored matrix files are kept.
+// Deleted files go to a trash, from which they can be restored until the restore window has elapsed.
+type RetentionHandlerInterface interface {
+	// DeleteFile handles DELETE /files?file=... requests, moving the file to the trash.
+	// It responds with the deleted file as JSON, including when it will be purged.
+	DeleteFile(w http.ResponseWriter, r *http.Request)
+
+	// RestoreFile handles POST /files/restore?file=... requests, moving a deleted file back in place.
+	RestoreFile(w http.ResponseWriter, r *http.Request)
+
+	// ListDeletedFiles handles GET /files/trash requests, returning the deleted files that can still be restored
+	// as JSON, the most recently deleted first.
+	ListDeletedFiles(w http.ResponseWriter, r *http.Request)
+
+	// SetRetention handles PUT /files/retention?file=...&ttl=720h requests, setting the time to live
+	// of a stored file from now; ttl=0 keeps the file until it is deleted.
+	SetRetention(w http.ResponseWriter, r *http.Request)
+}
+
+// deletedFileResponse describes a deleted matrix file.
+type deletedFileResponse struct {
+	File      string    `json:"file"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+type deletedFileListResponse struct {
+	Files []deletedFileResponse `json:"files"`
+}
+
+// retentionResponse describes the retention of a stored matrix file; ExpiresAt is omitted for files kept until deleted.
+type retentionResponse struct {
+	File      string     `json:"file"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type retentionHandler struct {
+	retentionDomain domain.RetentionDomainInterface
+}
+
+// NewRetentionHandler creates a new instance of RetentionHandlerInterface with its dependencies.
+// It initializes the handler with the retention domain service that expires, deletes and restores stored files.
+func NewRetentionHandler(retentionDomain domain.RetentionDomainInterface) RetentionHandlerInterface {
+	return &retentionHandler{
+		retentionDomain: retentionDomain,
+	}
+}
+
+func (h *retentionHandler) DeleteFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	audit.Describe(r.Context(), "delete", filePath)
+
+	deleted, err := h.retentionDomain.Delete(r.Context(), filePath)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, http.StatusOK, newDeletedFileResponse(deleted))
+}
+
+func (h *retentionHandler) RestoreFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	audit.Describe(r.Context(), "restore", filePath)
+
+	if err := h.retentionDomain.Restore(r.Context(), filePath); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Matrix-File", filePath)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *retentionHandler) ListDeletedFiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	deleted, err := h.retentionDomain.ListDeleted(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := deletedFileListResponse{Files: make([]deletedFileResponse, 0, len(deleted))}
+	for _, file := range deleted {
+		response.Files = append(response.Files, newDeletedFileResponse(file))
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func (h *retentionHandler) SetRetention(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	audit.Describe(r.Context(), "retention", filePath)
+
+	value := r.URL.Query().Get("ttl")
+	if value == "" {
+		h.writeError(w, r, fmt.Errorf("%w: ttl parameter is required", apperrors.ErrInvalidInput))
+		return
+	}
+	ttl, err := parseTTL(value)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	expiresAt, err := h.retentionDomain.SetTTL(r.Context(), filePath, ttl)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := retentionResponse{File: filePath}
+	if !expiresAt.IsZero() {
+		response.ExpiresAt = &expiresAt
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func (h *retentionHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "retention request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+func newDeletedFileResponse(deleted *entity.DeletedMatrix) deletedFileResponse {
+	return deletedFileResponse{
+		File:      deleted.FilePath,
+		DeletedAt: deleted.DeletedAt,
+		PurgeAt:   deleted.PurgeAt,
+	}
+}
+
+// parseTTL parses the time to live of a stored matrix file, a duration such as 720h, where 0 keeps the file.
+func parseTTL(value string) (time.Duration, error) {
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("%w: invalid ttl parameter: %q: expected a duration such as 720h", apperrors.ErrInvalidInput, value)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestRetentionHandler_DeleteFile(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		mockCall   bool
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "file deleted",
+			method:     http.MethodDelete,
+			query:      "?file=testdata/out.csv",
+			mockCall:   true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "file not found",
+			method:     http.MethodDelete,
+			query:      "?file=testdata/out.csv",
+			mockCall:   true,
+			mockError:  apperrors.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			query:      "?file=testdata/out.csv",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockRetentionDomainInterface(t)
+			if tt.mockCall {
+				var deleted *entity.DeletedMatrix
+				if tt.mockError == nil {
+					deleted = &entity.DeletedMatrix{FilePath: "testdata/out.csv", DeletedAt: deletedAt, PurgeAt: deletedAt.Add(time.Hour)}
+				}
+				mockDomain.On("Delete", mock.Anything, "testdata/out.csv").Return(deleted, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/files"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewRetentionHandler(mockDomain).DeleteFile(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var got deletedFileResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, deletedFileResponse{File: "testdata/out.csv", DeletedAt: deletedAt, PurgeAt: deletedAt.Add(time.Hour)}, got)
+			}
+		})
+	}
+}
+
+func TestRetentionHandler_RestoreFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		mockCall   bool
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "file restored",
+			method:     http.MethodPost,
+			mockCall:   true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "another file stored since",
+			method:     http.MethodPost,
+			mockCall:   true,
+			mockError:  apperrors.ErrConflict,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "purged",
+			method:     http.MethodPost,
+			mockCall:   true,
+			mockError:  apperrors.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockRetentionDomainInterface(t)
+			if tt.mockCall {
+				mockDomain.On("Restore", mock.Anything, "testdata/out.csv").Return(tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/files/restore?file=testdata/out.csv", nil)
+			w := httptest.NewRecorder()
+
+			NewRetentionHandler(mockDomain).RestoreFile(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusNoContent {
+				assert.Equal(t, "testdata/out.csv", w.Header().Get("Matrix-File"))
+			}
+		})
+	}
+}
+
+func TestRetentionHandler_ListDeletedFiles(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("deleted files", func(t *testing.T) {
+		mockDomain := mocks.NewMockRetentionDomainInterface(t)
+		mockDomain.On("ListDeleted", mock.Anything).Return([]*entity.DeletedMatrix{
+			{FilePath: "testdata/out.csv", DeletedAt: deletedAt, PurgeAt: deletedAt.Add(time.Hour)},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/files/trash", nil)
+		w := httptest.NewRecorder()
+
+		NewRetentionHandler(mockDomain).ListDeletedFiles(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var got deletedFileListResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, []deletedFileResponse{{File: "testdata/out.csv", DeletedAt: deletedAt, PurgeAt: deletedAt.Add(time.Hour)}}, got.Files)
+	})
+
+	t.Run("empty trash", func(t *testing.T) {
+		mockDomain := mocks.NewMockRetentionDomainInterface(t)
+		mockDomain.On("ListDeleted", mock.Anything).Return([]*entity.DeletedMatrix{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/files/trash", nil)
+		w := httptest.NewRecorder()
+
+		NewRetentionHandler(mockDomain).ListDeletedFiles(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"files":[]}`, w.Body.String())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/files/trash", nil)
+		w := httptest.NewRecorder()
+
+		NewRetentionHandler(mocks.NewMockRetentionDomainInterface(t)).ListDeletedFiles(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestRetentionHandler_SetRetention(t *testing.T) {
+	expiresAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		mockCall   bool
+		wantTTL    time.Duration
+		mockResult time.Time
+		mockError  error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "ttl set",
+			method:     http.MethodPut,
+			query:      "?file=testdata/out.csv&ttl=720h",
+			mockCall:   true,
+			wantTTL:    720 * time.Hour,
+			mockResult: expiresAt,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"file":"testdata/out.csv","expires_at":"2026-02-01T12:00:00Z"}`,
+		},
+		{
+			name:       "kept until deleted",
+			method:     http.MethodPut,
+			query:      "?file=testdata/out.csv&ttl=0",
+			mockCall:   true,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"file":"testdata/out.csv"}`,
+		},
+		{
+			name:       "file not found",
+			method:     http.MethodPut,
+			query:      "?file=testdata/out.csv&ttl=1h",
+			mockCall:   true,
+			wantTTL:    time.Hour,
+			mockError:  apperrors.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "missing ttl",
+			method:     http.MethodPut,
+			query:      "?file=testdata/out.csv",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "negative ttl",
+			method:     http.MethodPut,
+			query:      "?file=testdata/out.csv&ttl=-1h",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid ttl",
+			method:     http.MethodPut,
+			query:      "?file=testdata/out.csv&ttl=forever",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			query:      "?file=testdata/out.csv&ttl=1h",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockRetentionDomainInterface(t)
+			if tt.mockCall {
+				mockDomain.On("SetTTL", mock.Anything, "testdata/out.csv", tt.wantTTL).Return(tt.mockResult, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/files/retention"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewRetentionHandler(mockDomain).SetRetention(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
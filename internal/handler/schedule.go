@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// ScheduleHandlerInterface defines the contract for HTTP handlers that manage recurring operations.
+// Every run of a schedule is submitted as a background job, whose result is available under /jobs.
+type ScheduleHandlerInterface interface {
+	// HandleSchedules handles requests to /schedules.
+	// GET lists every schedule; POST creates one from a JSON body with a cron schedule, an operation and a file,
+	// responding with 201 Created and the schedule URL in the Location header.
+	HandleSchedules(w http.ResponseWriter, r *http.Request)
+
+	// HandleSchedule handles requests for a single schedule under /schedules/{id}.
+	// GET reports the schedule with its next run and the job of its latest run; DELETE removes it.
+	HandleSchedule(w http.ResponseWriter, r *http.Request)
+}
+
+// scheduleRequest is the body of a schedule creation request.
+type scheduleRequest struct {
+	Schedule  string `json:"schedule"`
+	Operation string `json:"operation"`
+	File      string `json:"file"`
+}
+
+// scheduleResponse describes a schedule. Run fields are omitted until they are known.
+type scheduleResponse struct {
+	ID        string     `json:"id"`
+	Schedule  string     `json:"schedule"`
+	Operation string     `json:"operation"`
+	File      string     `json:"file"`
+	CreatedAt time.Time  `json:"created_at"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	LastJobID string     `json:"last_job_id,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+type scheduleListResponse struct {
+	Schedules []scheduleResponse `json:"schedules"`
+}
+
+type scheduleHandler struct {
+	scheduleDomain domain.ScheduleDomainInterface
+}
+
+// NewScheduleHandler creates a new instance of ScheduleHandlerInterface with its dependencies.
+// It initializes the handler with the schedule domain service that submits each run as a job.
+func NewScheduleHandler(scheduleDomain domain.ScheduleDomainInterface) ScheduleHandlerInterface {
+	return &scheduleHandler{
+		scheduleDomain: scheduleDomain,
+	}
+}
+
+func (h *scheduleHandler) HandleSchedules(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		schedules, err := h.scheduleDomain.ListSchedules(r.Context())
+		if err != nil {
+			h.writeError(w, r, "", err)
+			return
+		}
+
+		response := scheduleListResponse{Schedules: make([]scheduleResponse, 0, len(schedules))}
+		for _, schedule := range schedules {
+			response.Schedules = append(response.Schedules, toScheduleResponse(schedule))
+		}
+		writeJSON(w, r, http.StatusOK, response)
+
+	case http.MethodPost:
+		request, err := decodeScheduleRequest(r)
+		if err != nil {
+			h.writeError(w, r, "", err)
+			return
+		}
+
+		schedule, err := h.scheduleDomain.CreateSchedule(r.Context(), request.Schedule, request.Operation, request.File)
+		if err != nil {
+			h.writeError(w, r, "", err)
+			return
+		}
+
+		w.Header().Set("Location", "/schedules/"+schedule.ID)
+		writeJSON(w, r, http.StatusCreated, toScheduleResponse(schedule))
+
+	default:
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *scheduleHandler) HandleSchedule(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/schedules/"):]
+
+	switch r.Method {
+	case http.MethodGet:
+		schedule, err := h.scheduleDomain.GetSchedule(r.Context(), id)
+		if err != nil {
+			h.writeError(w, r, id, err)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, toScheduleResponse(schedule))
+
+	case http.MethodDelete:
+		err := h.scheduleDomain.DeleteSchedule(r.Context(), id)
+		if err != nil {
+			h.writeError(w, r, id, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *scheduleHandler) writeError(w http.ResponseWriter, r *http.Request, id string, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "schedule request failed",
+		"schedule_id", id,
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+func toScheduleResponse(schedule *entity.Schedule) scheduleResponse {
+	response := scheduleResponse{
+		ID:        schedule.ID,
+		Schedule:  schedule.Spec,
+		Operation: schedule.Operation,
+		File:      schedule.FilePath,
+		CreatedAt: schedule.CreatedAt,
+		LastJobID: schedule.LastJobID,
+	}
+	if !schedule.NextRunAt.IsZero() {
+		response.NextRunAt = &schedule.NextRunAt
+	}
+	if !schedule.LastRunAt.IsZero() {
+		response.LastRunAt = &schedule.LastRunAt
+	}
+	if schedule.LastErr != nil {
+		response.LastError = schedule.LastErr.Error()
+	}
+	return response
+}
+
+// writeJSON encodes value as the JSON response body of r.
+func writeJSON(w http.ResponseWriter, r *http.Request, statusCode int, value any) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(body)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
+	}
+}
+
+// decodeScheduleRequest reads the JSON schedule sent in the request body.
+func decodeScheduleRequest(r *http.Request) (*scheduleRequest, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jobContentType {
+		return nil, fmt.Errorf("%w: schedules must be sent as %s", apperrors.ErrUnsupportedMediaType, jobContentType)
+	}
+
+	// Limit the body size BEFORE reading to prevent DoS attacks
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("%w: request body too large (maximum: %d bytes)",
+				apperrors.ErrPayloadTooLarge, maxRequestBodyBytes)
+		}
+		return nil, fmt.Errorf("%w: failed to read request body: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	request := &scheduleRequest{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("%w: invalid schedule request: %v", apperrors.ErrInvalidInput, err)
+	}
+	return request, nil
+}
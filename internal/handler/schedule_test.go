@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestScheduleHandler_HandleSchedules(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	nextRunAt := time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)
+	schedule := &entity.Schedule{
+		ID: "abc", Spec: "@hourly", Operation: "sum", FilePath: "testdata/matrix1.csv",
+		CreatedAt: createdAt, NextRunAt: nextRunAt,
+	}
+
+	t.Run("create a schedule", func(t *testing.T) {
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("CreateSchedule", mock.Anything, "@hourly", "sum", "testdata/matrix1.csv").Return(schedule, nil)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodPost, "/schedules",
+			strings.NewReader(`{"schedule":"@hourly","operation":"sum","file":"testdata/matrix1.csv"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedules(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "/schedules/abc", w.Header().Get("Location"))
+		assert.JSONEq(t, `{"id":"abc","schedule":"@hourly","operation":"sum","file":"testdata/matrix1.csv",`+
+			`"created_at":"2026-01-02T03:04:05Z","next_run_at":"2026-01-02T04:00:00Z"}`, w.Body.String())
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("CreateSchedule", mock.Anything, "soon", "sum", "testdata/matrix1.csv").
+			Return(nil, apperrors.ErrInvalidInput)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodPost, "/schedules",
+			strings.NewReader(`{"schedule":"soon","operation":"sum","file":"testdata/matrix1.csv"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedules(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("wrong content type", func(t *testing.T) {
+		handler := &scheduleHandler{scheduleDomain: mocks.NewMockScheduleDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodPost, "/schedules", strings.NewReader("@hourly sum"))
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedules(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("list schedules", func(t *testing.T) {
+		ran := *schedule
+		ran.LastRunAt = nextRunAt
+		ran.LastErr = apperrors.ErrServiceUnavailable
+
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("ListSchedules", mock.Anything).Return([]*entity.Schedule{&ran}, nil)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodGet, "/schedules", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedules(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"schedules":[{"id":"abc","schedule":"@hourly","operation":"sum","file":"testdata/matrix1.csv",`+
+			`"created_at":"2026-01-02T03:04:05Z","next_run_at":"2026-01-02T04:00:00Z","last_run_at":"2026-01-02T04:00:00Z",`+
+			`"last_error":"service unavailable"}]}`, w.Body.String())
+	})
+
+	t.Run("list without schedules", func(t *testing.T) {
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("ListSchedules", mock.Anything).Return([]*entity.Schedule{}, nil)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodGet, "/schedules", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedules(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"schedules":[]}`, w.Body.String())
+	})
+
+	t.Run("method not allowed - PUT", func(t *testing.T) {
+		handler := &scheduleHandler{scheduleDomain: mocks.NewMockScheduleDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodPut, "/schedules", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedules(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestScheduleHandler_HandleSchedule(t *testing.T) {
+	t.Run("get a schedule with its latest job", func(t *testing.T) {
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("GetSchedule", mock.Anything, "abc").Return(&entity.Schedule{
+			ID: "abc", Spec: "0 6 * * *", Operation: "sum", FilePath: "testdata/matrix1.csv",
+			CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			LastRunAt: time.Date(2026, 1, 3, 6, 0, 0, 0, time.UTC), LastJobID: "job-1",
+		}, nil)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodGet, "/schedules/abc", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":"abc","schedule":"0 6 * * *","operation":"sum","file":"testdata/matrix1.csv",`+
+			`"created_at":"2026-01-02T03:04:05Z","last_run_at":"2026-01-03T06:00:00Z","last_job_id":"job-1"}`, w.Body.String())
+	})
+
+	t.Run("get unknown schedule", func(t *testing.T) {
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("GetSchedule", mock.Anything, "missing").Return(nil, apperrors.ErrNotFound)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodGet, "/schedules/missing", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete a schedule", func(t *testing.T) {
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("DeleteSchedule", mock.Anything, "abc").Return(nil)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodDelete, "/schedules/abc", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("delete unknown schedule", func(t *testing.T) {
+		mockDomain := mocks.NewMockScheduleDomainInterface(t)
+		mockDomain.On("DeleteSchedule", mock.Anything, "missing").Return(apperrors.ErrNotFound)
+
+		handler := &scheduleHandler{scheduleDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodDelete, "/schedules/missing", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("method not allowed - POST", func(t *testing.T) {
+		handler := &scheduleHandler{scheduleDomain: mocks.NewMockScheduleDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodPost, "/schedules/abc", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// sharedPath is the path signed URLs are served under, followed by the operation.
+	sharedPath = "/shared/"
+
+	// signedURLActor identifies downloads made with a signed URL in the audit log.
+	signedURLActor = "signed_url"
+)
+
+// SignedURLHandlerInterface defines the contract for HTTP handlers that share stored matrix files and their results
+// through signed URLs, which can be downloaded without an API key until they expire.
+type SignedURLHandlerInterface interface {
+	// CreateSignedURL handles POST /share?file=...&operation=invert&ttl=24h requests, responding with 201 Created
+	// and a signed URL for the result of the operation on the file as JSON. Without an operation the URL downloads
+	// the file itself; ttl defaults to 24 hours and may be at most 7 days.
+	CreateSignedURL(w http.ResponseWriter, r *http.Request)
+
+	// RequireSignature wraps next, the handler of /matrix/{operation} requests, so GET /shared/{operation}?file=...
+	// &expires=...&signature=... requests are served as a download of the signed result, as the tenant owning
+	// the file. Requests whose signature is invalid or expired are rejected with 403 Forbidden. Parameters other
+	// than the signed ones are ignored, so the request cannot be changed.
+	RequireSignature(next http.Handler) http.Handler
+}
+
+// signedURLResponse describes a signed URL.
+type signedURLResponse struct {
+	URL       string    `json:"url"`
+	File      string    `json:"file"`
+	Operation string    `json:"operation"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type signedURLHandler struct {
+	signedURLDomain domain.SignedURLDomainInterface
+}
+
+// NewSignedURLHandler creates a new instance of SignedURLHandlerInterface with its dependencies.
+// It initializes the handler with the signed URL domain service that signs and verifies the URLs.
+func NewSignedURLHandler(signedURLDomain domain.SignedURLDomainInterface) SignedURLHandlerInterface {
+	return &signedURLHandler{
+		signedURLDomain: signedURLDomain,
+	}
+}
+
+func (h *signedURLHandler) CreateSignedURL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	operation := r.URL.Query().Get("operation")
+	audit.Describe(r.Context(), "share", filePath)
+
+	var ttl time.Duration
+	if value := r.URL.Query().Get("ttl"); value != "" {
+		var err error
+		ttl, err = time.ParseDuration(value)
+		if err != nil {
+			h.writeError(w, r, fmt.Errorf("%w: invalid ttl parameter: %q: expected a duration such as 24h",
+				apperrors.ErrInvalidInput, value))
+			return
+		}
+	}
+
+	signed, err := h.signedURLDomain.Sign(r.Context(), filePath, operation, ttl)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "signed URL created",
+		"operation", signed.Operation,
+		"expires_at", signed.ExpiresAt)
+	writeJSON(w, r, http.StatusCreated, signedURLResponse{
+		URL:       signedURL(r, signed),
+		File:      signed.FilePath,
+		Operation: signed.Operation,
+		ExpiresAt: signed.ExpiresAt,
+	})
+}
+
+func (h *signedURLHandler) RequireSignature(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		signed := &entity.SignedURL{
+			FilePath:  query.Get("file"),
+			Operation: strings.TrimPrefix(r.URL.Path, sharedPath),
+			Signature: query.Get("signature"),
+		}
+		audit.SetActor(r.Context(), signedURLActor)
+		audit.Describe(r.Context(), signed.Operation, signed.FilePath)
+
+		expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+		if err != nil {
+			h.reject(w, r, fmt.Errorf("%w: invalid expires parameter", apperrors.ErrForbidden))
+			return
+		}
+		signed.ExpiresAt = time.Unix(expires, 0)
+
+		ctx, err := h.signedURLDomain.Verify(r.Context(), signed)
+		if err != nil {
+			h.reject(w, r, err)
+			return
+		}
+
+		// The download is served by the matrix endpoint, with only the signed parameters
+		download := r.Clone(ctx)
+		download.URL.Path = "/matrix/" + signed.Operation
+		download.URL.RawPath = ""
+		download.URL.RawQuery = url.Values{"file": {signed.FilePath}, "download": {"true"}}.Encode()
+		next.ServeHTTP(w, download)
+	})
+}
+
+// reject answers a request whose signed URL could not be verified.
+func (h *signedURLHandler) reject(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	audit.Deny(r.Context(), err.Error())
+	slog.WarnContext(r.Context(), "signed URL rejected",
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+func (h *signedURLHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "signed URL request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// signedURL returns the absolute URL downloading signed from the server r was sent to.
+func signedURL(r *http.Request, signed *entity.SignedURL) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   r.Host,
+		Path:   sharedPath + signed.Operation,
+		RawQuery: url.Values{
+			"file":      {signed.FilePath},
+			"expires":   {strconv.FormatInt(signed.ExpiresAt.Unix(), 10)},
+			"signature": {signed.Signature},
+		}.Encode(),
+	}
+	return u.String()
+}
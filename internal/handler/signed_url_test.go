@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestSignedURLHandler_CreateSignedURL(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		method        string
+		query         string
+		mockCall      bool
+		wantOperation string
+		wantTTL       time.Duration
+		mockError     error
+		wantStatus    int
+		wantURL       string
+	}{
+		{
+			name:       "file download",
+			method:     http.MethodPost,
+			query:      "?file=testdata/matrix1.csv",
+			mockCall:   true,
+			wantStatus: http.StatusCreated,
+			wantURL:    "http://example.com/shared/echo?expires=1767355200&file=testdata%2Fmatrix1.csv&signature=abc123",
+		},
+		{
+			name:          "operation result",
+			method:        http.MethodPost,
+			query:         "?file=testdata/matrix1.csv&operation=invert&ttl=1h",
+			mockCall:      true,
+			wantOperation: "invert",
+			wantTTL:       time.Hour,
+			wantStatus:    http.StatusCreated,
+			wantURL:       "http://example.com/shared/invert?expires=1767355200&file=testdata%2Fmatrix1.csv&signature=abc123",
+		},
+		{
+			name:       "signing disabled",
+			method:     http.MethodPost,
+			query:      "?file=testdata/matrix1.csv",
+			mockCall:   true,
+			mockError:  apperrors.ErrServiceUnavailable,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "invalid ttl",
+			method:     http.MethodPost,
+			query:      "?file=testdata/matrix1.csv&ttl=tomorrow",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			query:      "?file=testdata/matrix1.csv",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockSignedURLDomainInterface(t)
+			if tt.mockCall {
+				var signed *entity.SignedURL
+				if tt.mockError == nil {
+					operation := tt.wantOperation
+					if operation == "" {
+						operation = "echo"
+					}
+					signed = &entity.SignedURL{FilePath: "testdata/matrix1.csv", Operation: operation, ExpiresAt: expiresAt, Signature: "abc123"}
+				}
+				mockDomain.On("Sign", mock.Anything, "testdata/matrix1.csv", tt.wantOperation, tt.wantTTL).Return(signed, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/share"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewSignedURLHandler(mockDomain).CreateSignedURL(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusCreated {
+				var got signedURLResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, tt.wantURL, got.URL)
+				assert.Equal(t, "testdata/matrix1.csv", got.File)
+				assert.True(t, expiresAt.Equal(got.ExpiresAt))
+			}
+		})
+	}
+}
+
+func TestSignedURLHandler_RequireSignature(t *testing.T) {
+	signed := &entity.SignedURL{
+		FilePath:  "testdata/tenants/acme/matrix.csv",
+		Operation: "invert",
+		ExpiresAt: time.Unix(1767355200, 0),
+		Signature: "abc123",
+	}
+	validURL := "/shared/invert?file=testdata/tenants/acme/matrix.csv&expires=1767355200&signature=abc123"
+
+	t.Run("valid signature", func(t *testing.T) {
+		mockDomain := mocks.NewMockSignedURLDomainInterface(t)
+		mockDomain.On("Verify", mock.Anything, signed).
+			Return(tenant.WithID(context.Background(), "acme"), nil)
+
+		var got *http.Request
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+		})
+		// Parameters other than the signed ones are not passed on
+		req := httptest.NewRequest(http.MethodGet, validURL+"&save_as=testdata/copy.csv", nil)
+		w := httptest.NewRecorder()
+
+		NewSignedURLHandler(mockDomain).RequireSignature(next).ServeHTTP(w, req)
+
+		if assert.NotNil(t, got) {
+			assert.Equal(t, "/matrix/invert", got.URL.Path)
+			assert.Equal(t, url.Values{"file": {"testdata/tenants/acme/matrix.csv"}, "download": {"true"}}, got.URL.Query())
+			assert.Equal(t, "acme", tenant.ID(got.Context()))
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		mockDomain := mocks.NewMockSignedURLDomainInterface(t)
+		mockDomain.On("Verify", mock.Anything, signed).Return(nil, apperrors.ErrForbidden)
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request with an invalid signature was served")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, validURL, nil)
+		w := httptest.NewRecorder()
+
+		NewSignedURLHandler(mockDomain).RequireSignature(next).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("invalid expiry", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request with an invalid expiry was served")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/shared/invert?file=testdata/matrix1.csv&expires=soon&signature=abc123", nil)
+		w := httptest.NewRecorder()
+
+		NewSignedURLHandler(mocks.NewMockSignedURLDomainInterface(t)).RequireSignature(next).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, validURL, nil)
+		w := httptest.NewRecorder()
+
+		NewSignedURLHandler(mocks.NewMockSignedURLDomainInterface(t)).RequireSignature(http.NotFoundHandler()).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func (h *matrixHandler) SplitMatrix(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	audit.Describe(r.Context(), "split", filePath)
+
+	rows, err := parseTileCount(r, "rows")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid rows parameter", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+	cols, err := parseTileCount(r, "cols")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid cols parameter", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+	download, err := parseBoolQuery(r, "download")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid download parameter", "error", err)
+		httpErrorFrom(w, r, err, apperrors.GetHTTPStatusCode(err))
+		return
+	}
+
+	tiles, err := h.matrixDomain.SplitMatrix(r.Context(), filePath, rows, cols)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			slog.InfoContext(r.Context(), "request cancelled by client")
+			return
+		}
+
+		statusCode := apperrors.GetHTTPStatusCode(err)
+		slog.ErrorContext(r.Context(), "matrix split failed",
+			"error", err,
+			"status_code", statusCode)
+		httpErrorFrom(w, r, err, statusCode)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "matrix split completed",
+		"tiles", len(tiles))
+
+	if download {
+		body, err := zipTiles(filePath, tiles)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode tiles", "error", err)
+			httpError(w, r, "failed to encode response", http.StatusInternalServerError)
+			return
+		}
+		filename := attachmentFilename(filePath, "split", "zip")
+		w.Header().Set("Content-Type", archiveContentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			slog.ErrorContext(r.Context(), "failed to write response", "error", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(formatTiles(tiles))); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
+	}
+}
+
+// parseTileCount reads the optional number of tiles a matrix is split into along the rows or the columns,
+// which defaults to 1 so a matrix can be split along a single dimension.
+func parseTileCount(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 1, nil
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("%w: invalid %s parameter: %q", apperrors.ErrInvalidInput, name, value)
+	}
+	return count, nil
+}
+
+// formatTiles renders tiles as sections of plain text, each headed by a line naming its position in the grid
+// and the rows and columns of the source matrix it holds, with one-based indices like the selection parameters.
+func formatTiles(tiles []*entity.Tile) string {
+	var sb strings.Builder
+	for i, tile := range tiles {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "# tile %d,%d: rows %d-%d, cols %d-%d\n%s\n",
+			tile.Row, tile.Col, tile.Rows.Start+1, tile.Rows.End+1, tile.Cols.Start+1, tile.Cols.End+1, tile.Matrix)
+	}
+	return sb.String()
+}
+
+// zipTiles builds a zip archive holding a CSV file per tile, named after the split file and the position
+// of the tile in the grid, e.g. matrix1-r1c2.csv.
+func zipTiles(filePath string, tiles []*entity.Tile) ([]byte, error) {
+	base := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, tile := range tiles {
+		entry, err := archive.Create(fmt.Sprintf("%s-r%dc%d.csv", base, tile.Row, tile.Col))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Fprintf(entry, "%s\n", tile.Matrix); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
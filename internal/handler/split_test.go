@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// splitTiles are the tiles of the 2x4 matrix 1,2,3,4 / 5,6,7,8 split into a 1x2 grid.
+var splitTiles = []*entity.Tile{
+	{Row: 1, Col: 1, Rows: entity.IndexRange{Start: 0, End: 1}, Cols: entity.IndexRange{Start: 0, End: 1},
+		Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {5, 6}}}},
+	{Row: 1, Col: 2, Rows: entity.IndexRange{Start: 0, End: 1}, Cols: entity.IndexRange{Start: 2, End: 3},
+		Matrix: &entity.Matrix[int64]{Data: [][]int64{{3, 4}, {7, 8}}}},
+}
+
+func TestMatrixHandler_SplitMatrix(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		rows, cols int
+		mockTiles  []*entity.Tile
+		mockError  error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "labeled sections", method: http.MethodGet, target: "/matrix/split?file=testdata/m.csv&cols=2",
+			rows: 1, cols: 2, mockTiles: splitTiles, wantStatus: http.StatusOK,
+			wantBody: "# tile 1,1: rows 1-2, cols 1-2\n1,2\n5,6\n\n# tile 1,2: rows 1-2, cols 3-4\n3,4\n7,8\n"},
+		{name: "tiles do not divide evenly", method: http.MethodGet, target: "/matrix/split?file=testdata/m.csv&rows=3&cols=1",
+			rows: 3, cols: 1, mockError: fmt.Errorf("%w: cannot split 2 rows into 3 equal parts", apperrors.ErrUnprocessableEntity),
+			wantStatus: http.StatusUnprocessableEntity, wantBody: "cannot split 2 rows into 3 equal parts\n"},
+		{name: "invalid rows", method: http.MethodGet, target: "/matrix/split?file=testdata/m.csv&rows=0",
+			wantStatus: http.StatusBadRequest, wantBody: "invalid input: invalid rows parameter: \"0\"\n"},
+		{name: "invalid cols", method: http.MethodGet, target: "/matrix/split?file=testdata/m.csv&cols=two",
+			wantStatus: http.StatusBadRequest, wantBody: "invalid input: invalid cols parameter: \"two\"\n"},
+		{name: "invalid download", method: http.MethodGet, target: "/matrix/split?file=testdata/m.csv&download=maybe",
+			wantStatus: http.StatusBadRequest, wantBody: "invalid input: invalid download parameter: \"maybe\"\n"},
+		{name: "method not allowed", method: http.MethodPost, target: "/matrix/split?file=testdata/m.csv",
+			wantStatus: http.StatusMethodNotAllowed, wantBody: "method not allowed\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockMatrixDomainInterface(t)
+			if tt.mockTiles != nil || tt.mockError != nil {
+				mockDomain.On("SplitMatrix", mock.Anything, "testdata/m.csv", tt.rows, tt.cols).Return(tt.mockTiles, tt.mockError)
+			}
+
+			handler := &matrixHandler{matrixDomain: mockDomain}
+			w := httptest.NewRecorder()
+			handler.SplitMatrix(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestMatrixHandler_SplitMatrix_Download(t *testing.T) {
+	mockDomain := mocks.NewMockMatrixDomainInterface(t)
+	mockDomain.On("SplitMatrix", mock.Anything, "testdata/m.csv", 1, 2).Return(splitTiles, nil)
+
+	handler := &matrixHandler{matrixDomain: mockDomain}
+	w := httptest.NewRecorder()
+	handler.SplitMatrix(w, httptest.NewRequest(http.MethodGet, "/matrix/split?file=testdata/m.csv&cols=2&download=true", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=m-split.zip", w.Header().Get("Content-Disposition"))
+
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	assert.NoError(t, err)
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		f, err := file.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(f)
+		assert.NoError(t, err)
+		files[file.Name] = string(content)
+	}
+	assert.Equal(t, map[string]string{"m-r1c1.csv": "1,2\n5,6\n", "m-r1c2.csv": "3,4\n7,8\n"}, files)
+}
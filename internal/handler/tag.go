@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// TagHandlerInterface defines the contract for HTTP handlers that organize stored matrix files with key/value tags.
+type TagHandlerInterface interface {
+	// HandleTags handles requests for the tags of a stored matrix file under /files/tags?file=....
+	// GET returns the tags of the file as JSON; PUT replaces them with the tags of a JSON body
+	// such as {"tags":{"season":"2026"}}, where no tags clear them, and responds with the tags set.
+	HandleTags(w http.ResponseWriter, r *http.Request)
+}
+
+// tagRequest is the body of a request setting the tags of a file.
+type tagRequest struct {
+	Tags map[string]string `json:"tags"`
+}
+
+type tagResponse struct {
+	File string            `json:"file"`
+	Tags map[string]string `json:"tags"`
+}
+
+type tagHandler struct {
+	tagDomain domain.TagDomainInterface
+}
+
+// NewTagHandler creates a new instance of TagHandlerInterface with its dependencies.
+// It initializes the handler with the tag domain service that reads and sets the tags of stored files.
+func NewTagHandler(tagDomain domain.TagDomainInterface) TagHandlerInterface {
+	return &tagHandler{
+		tagDomain: tagDomain,
+	}
+}
+
+func (h *tagHandler) HandleTags(w http.ResponseWriter, r *http.Request) {
+	filePath := r.URL.Query().Get("file")
+
+	switch r.Method {
+	case http.MethodGet:
+		audit.Describe(r.Context(), "tags", filePath)
+
+		tags, err := h.tagDomain.GetTags(r.Context(), filePath)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, newTagResponse(filePath, tags))
+
+	case http.MethodPut:
+		audit.Describe(r.Context(), "tag", filePath)
+
+		request, err := decodeTagRequest(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		err = h.tagDomain.SetTags(r.Context(), filePath, request.Tags)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, newTagResponse(filePath, request.Tags))
+
+	default:
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *tagHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "tag request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// newTagResponse describes the tags of the file at filePath, with an empty object for a file without tags.
+func newTagResponse(filePath string, tags map[string]string) tagResponse {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return tagResponse{File: filePath, Tags: tags}
+}
+
+// decodeTagRequest reads the JSON tags sent in the request body.
+func decodeTagRequest(r *http.Request) (*tagRequest, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jobContentType {
+		return nil, fmt.Errorf("%w: tags must be sent as %s", apperrors.ErrUnsupportedMediaType, jobContentType)
+	}
+
+	// Limit the body size BEFORE reading to prevent DoS attacks
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("%w: request body too large (maximum: %d bytes)",
+				apperrors.ErrPayloadTooLarge, maxRequestBodyBytes)
+		}
+		return nil, fmt.Errorf("%w: failed to read request body: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	request := &tagRequest{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("%w: invalid tag request: %v", apperrors.ErrInvalidInput, err)
+	}
+	return request, nil
+}
+
+// parseTagQuery reads the tag query parameters of r, each a key and a value separated by a colon,
+// as in tag=season:2026. When requireValue is false, a bare key such as tag=season stands for
+// the tag with any value.
+func parseTagQuery(r *http.Request, requireValue bool) (map[string]string, error) {
+	values := r.URL.Query()["tag"]
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	tags := make(map[string]string, len(values))
+	for _, value := range values {
+		key, tagValue, found := strings.Cut(value, ":")
+		if key == "" || (requireValue && (!found || tagValue == "")) {
+			return nil, fmt.Errorf("%w: invalid tag parameter: %q: expected key:value", apperrors.ErrInvalidInput, value)
+		}
+		if _, ok := tags[key]; ok {
+			return nil, fmt.Errorf("%w: tag %s is given more than once", apperrors.ErrInvalidInput, key)
+		}
+		tags[key] = tagValue
+	}
+	return tags, nil
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestTagHandler_HandleTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		setupMock   func(*mocks.MockTagDomainInterface)
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:   "get tags",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockTagDomainInterface) {
+				m.On("GetTags", mock.Anything, "testdata/league.csv").Return(map[string]string{"season": "2026"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"file":"testdata/league.csv","tags":{"season":"2026"}}`,
+		},
+		{
+			name:   "file without tags",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockTagDomainInterface) {
+				m.On("GetTags", mock.Anything, "testdata/league.csv").Return(nil, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"file":"testdata/league.csv","tags":{}}`,
+		},
+		{
+			name:   "file not found",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockTagDomainInterface) {
+				m.On("GetTags", mock.Anything, "testdata/league.csv").Return(nil, apperrors.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:        "set tags",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			body:        `{"tags":{"season":"2026","league":"premier"}}`,
+			setupMock: func(m *mocks.MockTagDomainInterface) {
+				m.On("SetTags", mock.Anything, "testdata/league.csv", map[string]string{"season": "2026", "league": "premier"}).
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"file":"testdata/league.csv","tags":{"season":"2026","league":"premier"}}`,
+		},
+		{
+			name:        "clear tags",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			body:        `{"tags":{}}`,
+			setupMock: func(m *mocks.MockTagDomainInterface) {
+				m.On("SetTags", mock.Anything, "testdata/league.csv", map[string]string{}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"file":"testdata/league.csv","tags":{}}`,
+		},
+		{
+			name:        "invalid tags",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			body:        `{"tags":{"Season":"2026"}}`,
+			setupMock: func(m *mocks.MockTagDomainInterface) {
+				m.On("SetTags", mock.Anything, "testdata/league.csv", map[string]string{"Season": "2026"}).
+					Return(apperrors.ErrInvalidInput)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "malformed body",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			body:        `{"tags":["season"]}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "unsupported media type",
+			method:      http.MethodPut,
+			contentType: "text/csv",
+			body:        "season,2026\n",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockTagDomainInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockDomain)
+			}
+
+			req := httptest.NewRequest(tt.method, "/files/tags?file=testdata/league.csv", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			NewTagHandler(mockDomain).HandleTags(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// apiKeyHeader is the request header carrying the API key, as an alternative to a bearer token.
+	apiKeyHeader = "X-API-Key"
+
+	// usageRecordTimeout bounds how long recording the compute time of a request may take once it has been served.
+	usageRecordTimeout = 5 * time.Second
+)
+
+// TenantHandlerInterface defines the contract for the middleware that identifies the tenant of each request.
+type TenantHandlerInterface interface {
+	// RequireTenant wraps next so every request must present an API key, either in the X-API-Key header
+	// or as an "Authorization: Bearer" token, and runs with the tenant it belongs to in its context.
+	// Requests without a valid key are rejected with 401 Unauthorized, and requests over the tenant's
+	// requests per minute limit or the key's quotas with 429 Too Many Requests. Responses report the
+	// allowance closest to running out in the X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset
+	// headers, and the time spent serving each request counts against the key's compute quota.
+	// When multi-tenancy is disabled, requests are passed through unchanged.
+	RequireTenant(next http.Handler) http.Handler
+
+	// ListUsage handles GET /admin/usage requests, returning the usage of every API key during its
+	// current quota period as JSON.
+	ListUsage(w http.ResponseWriter, r *http.Request)
+}
+
+// clientUsageResponse describes the usage of an API key during its current quota period.
+type clientUsageResponse struct {
+	TenantID            string    `json:"tenant_id"`
+	KeyID               string    `json:"key_id"`
+	PeriodStart         time.Time `json:"period_start"`
+	PeriodEnd           time.Time `json:"period_end"`
+	Requests            int       `json:"requests"`
+	RequestQuota        int       `json:"request_quota,omitempty"`
+	ComputeSeconds      float64   `json:"compute_seconds"`
+	ComputeQuotaSeconds float64   `json:"compute_quota_seconds,omitempty"`
+}
+
+type usageListResponse struct {
+	Clients []clientUsageResponse `json:"clients"`
+}
+
+type tenantHandler struct {
+	tenantDomain domain.TenantDomainInterface
+}
+
+// NewTenantHandler creates a new instance of TenantHandlerInterface with its dependencies.
+// It initializes the middleware with the tenant domain service that maps API keys to tenants.
+func NewTenantHandler(tenantDomain domain.TenantDomainInterface) TenantHandlerInterface {
+	return &tenantHandler{
+		tenantDomain: tenantDomain,
+	}
+}
+
+func (h *tenantHandler) RequireTenant(next http.Handler) http.Handler {
+	if !h.tenantDomain.Enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := apiKey(r)
+		t, err := h.tenantDomain.Authenticate(r.Context(), key)
+		if err == nil {
+			var rateLimit *entity.RateLimit
+			rateLimit, err = h.tenantDomain.AllowRequest(r.Context(), t, key)
+			setRateLimitHeaders(w, rateLimit)
+		}
+		if err != nil {
+			rejectRequest(w, r, t, err)
+			return
+		}
+
+		audit.SetActor(r.Context(), tenantActor(t))
+		start := time.Now()
+		next.ServeHTTP(w, r.WithContext(tenant.NewContext(r.Context(), t)))
+
+		// The compute time is recorded even when the client has gone away
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), usageRecordTimeout)
+		defer cancel()
+		if err := h.tenantDomain.RecordUsage(ctx, t, key, time.Since(start)); err != nil {
+			slog.ErrorContext(r.Context(), "failed to record usage",
+				"tenant_id", t.ID,
+				"error", err)
+		}
+	})
+}
+
+func (h *tenantHandler) ListUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	clients, err := h.tenantDomain.ListUsage(r.Context())
+	if err != nil {
+		statusCode := apperrors.GetHTTPStatusCode(err)
+		slog.ErrorContext(r.Context(), "usage request failed",
+			"error", err,
+			"status_code", statusCode)
+		httpErrorFrom(w, r, err, statusCode)
+		return
+	}
+
+	response := usageListResponse{Clients: make([]clientUsageResponse, 0, len(clients))}
+	for _, client := range clients {
+		response.Clients = append(response.Clients, clientUsageResponse{
+			TenantID:            client.TenantID,
+			KeyID:               client.KeyID,
+			PeriodStart:         client.PeriodStart,
+			PeriodEnd:           client.PeriodEnd,
+			Requests:            client.Requests,
+			RequestQuota:        client.RequestQuota,
+			ComputeSeconds:      client.ComputeTime.Seconds(),
+			ComputeQuotaSeconds: client.ComputeQuota.Seconds(),
+		})
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// setRateLimitHeaders reports the request allowance of the client in the X-RateLimit-* headers,
+// with the reset given in seconds from now. Nothing is reported without an allowance.
+func setRateLimitHeaders(w http.ResponseWriter, rateLimit *entity.RateLimit) {
+	if rateLimit == nil {
+		return
+	}
+
+	reset := math.Ceil(max(time.Until(rateLimit.Reset).Seconds(), 0))
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(rateLimit.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(rateLimit.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatFloat(reset, 'f', 0, 64))
+}
+
+// rejectRequest answers a request that failed authentication or exceeded its tenant's request limits.
+// t is nil when the request could not be authenticated.
+func rejectRequest(w http.ResponseWriter, r *http.Request, t *tenant.Tenant, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	tenantID := ""
+	if t != nil {
+		tenantID = t.ID
+		audit.SetActor(r.Context(), tenantActor(t))
+	}
+	audit.Deny(r.Context(), err.Error())
+	slog.WarnContext(r.Context(), "request rejected",
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+		"tenant_id", tenantID,
+		"error", err,
+		"status_code", statusCode)
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		w.Header().Set("WWW-Authenticate", `Bearer realm="league-matrix"`)
+	case http.StatusTooManyRequests:
+		// The allowance that ran out resets when the client may retry
+		w.Header().Set("Retry-After", w.Header().Get("X-RateLimit-Reset"))
+	}
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// tenantActor identifies a tenant in the audit log.
+func tenantActor(t *tenant.Tenant) string {
+	return "tenant:" + t.ID
+}
+
+// apiKey returns the API key sent with a request, preferring the X-API-Key header over a bearer token.
+func apiKey(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestTenantHandler_RequireTenant(t *testing.T) {
+	// next echoes the tenant the request runs for
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, tenant.ID(r.Context()))
+	})
+
+	t.Run("passes requests through when tenancy is disabled", func(t *testing.T) {
+		mockDomain := mocks.NewMockTenantDomainInterface(t)
+		mockDomain.On("Enabled").Return(false)
+
+		req := httptest.NewRequest(http.MethodGet, "/matrix", nil)
+		w := httptest.NewRecorder()
+
+		NewTenantHandler(mockDomain).RequireTenant(next).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		wantKey       string
+		mockError     error
+		limitError    error
+		rateLimit     *entity.RateLimit
+		wantStatus    int
+		wantBody      string
+		wantChallenge bool
+	}{
+		{
+			name:       "API key header",
+			headers:    map[string]string{"X-API-Key": "acme-key"},
+			wantKey:    "acme-key",
+			rateLimit:  &entity.RateLimit{Limit: 100, Remaining: 42, Reset: time.Now().Add(30 * time.Second)},
+			wantStatus: http.StatusOK,
+			wantBody:   "acme",
+		},
+		{
+			name:       "bearer token",
+			headers:    map[string]string{"Authorization": "Bearer acme-key"},
+			wantKey:    "acme-key",
+			wantStatus: http.StatusOK,
+			wantBody:   "acme",
+		},
+		{
+			name:       "API key header wins over bearer token",
+			headers:    map[string]string{"X-API-Key": "acme-key", "Authorization": "Bearer other-key"},
+			wantKey:    "acme-key",
+			wantStatus: http.StatusOK,
+			wantBody:   "acme",
+		},
+		{
+			name:       "request limit reached",
+			headers:    map[string]string{"X-API-Key": "acme-key"},
+			wantKey:    "acme-key",
+			limitError: apperrors.ErrTooManyRequests,
+			rateLimit:  &entity.RateLimit{Limit: 100, Remaining: 0, Reset: time.Now().Add(30 * time.Second)},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:          "other authorization scheme",
+			headers:       map[string]string{"Authorization": "Basic YWNtZTprZXk="},
+			wantKey:       "",
+			mockError:     apperrors.ErrUnauthorized,
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: true,
+		},
+		{
+			name:          "missing API key",
+			wantKey:       "",
+			mockError:     apperrors.ErrUnauthorized,
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: true,
+		},
+		{
+			name:          "unknown API key",
+			headers:       map[string]string{"X-API-Key": "unknown"},
+			wantKey:       "unknown",
+			mockError:     apperrors.ErrUnauthorized,
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockTenantDomainInterface(t)
+			mockDomain.On("Enabled").Return(true)
+			if tt.mockError != nil {
+				mockDomain.On("Authenticate", mock.Anything, tt.wantKey).Return(nil, tt.mockError)
+			} else {
+				acme := &tenant.Tenant{ID: "acme"}
+				mockDomain.On("Authenticate", mock.Anything, tt.wantKey).Return(acme, nil)
+				mockDomain.On("AllowRequest", mock.Anything, acme, tt.wantKey).Return(tt.rateLimit, tt.limitError)
+				if tt.limitError == nil {
+					mockDomain.On("RecordUsage", mock.Anything, acme, tt.wantKey, mock.Anything).Return(nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/matrix", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+
+			NewTenantHandler(mockDomain).RequireTenant(next).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			if tt.wantChallenge {
+				assert.Equal(t, `Bearer realm="league-matrix"`, w.Header().Get("WWW-Authenticate"))
+			} else {
+				assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+			}
+			if tt.rateLimit != nil {
+				assert.Equal(t, strconv.Itoa(tt.rateLimit.Limit), w.Header().Get("X-RateLimit-Limit"))
+				assert.Equal(t, strconv.Itoa(tt.rateLimit.Remaining), w.Header().Get("X-RateLimit-Remaining"))
+				assert.Equal(t, "30", w.Header().Get("X-RateLimit-Reset"))
+			} else {
+				assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+			}
+			if tt.wantStatus == http.StatusTooManyRequests {
+				assert.Equal(t, "30", w.Header().Get("Retry-After"))
+			} else {
+				assert.Empty(t, w.Header().Get("Retry-After"))
+			}
+		})
+	}
+
+	t.Run("cancelled request", func(t *testing.T) {
+		mockDomain := mocks.NewMockTenantDomainInterface(t)
+		mockDomain.On("Enabled").Return(true)
+		mockDomain.On("Authenticate", mock.Anything, "acme-key").Return(nil, context.Canceled)
+
+		req := httptest.NewRequest(http.MethodGet, "/matrix", nil)
+		req.Header.Set("X-API-Key", "acme-key")
+		w := httptest.NewRecorder()
+
+		NewTenantHandler(mockDomain).RequireTenant(next).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestTenantHandler_RequireTenant_RecordsComputeTime(t *testing.T) {
+	acme := &tenant.Tenant{ID: "acme"}
+	mockDomain := mocks.NewMockTenantDomainInterface(t)
+	mockDomain.On("Enabled").Return(true)
+	mockDomain.On("Authenticate", mock.Anything, "acme-key").Return(acme, nil)
+	mockDomain.On("AllowRequest", mock.Anything, acme, "acme-key").Return(nil, nil)
+	mockDomain.On("RecordUsage", mock.Anything, acme, "acme-key", mock.MatchedBy(func(d time.Duration) bool {
+		return d >= 10*time.Millisecond
+	})).Return(nil)
+
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+	req.Header.Set("X-API-Key", "acme-key")
+	w := httptest.NewRecorder()
+
+	NewTenantHandler(mockDomain).RequireTenant(slow).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestTenantHandler_ListUsage(t *testing.T) {
+	periodStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("lists the usage of every key", func(t *testing.T) {
+		mockDomain := mocks.NewMockTenantDomainInterface(t)
+		mockDomain.On("ListUsage", mock.Anything).Return([]*entity.ClientUsage{{
+			TenantID:     "acme",
+			KeyID:        "0123456789abcdef",
+			PeriodStart:  periodStart,
+			PeriodEnd:    periodStart.Add(24 * time.Hour),
+			Requests:     12,
+			RequestQuota: 1000,
+			ComputeTime:  1500 * time.Millisecond,
+		}}, nil)
+
+		w := httptest.NewRecorder()
+		NewTenantHandler(mockDomain).ListUsage(w, httptest.NewRequest(http.MethodGet, "/admin/usage", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var got usageListResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, usageListResponse{Clients: []clientUsageResponse{{
+			TenantID:       "acme",
+			KeyID:          "0123456789abcdef",
+			PeriodStart:    periodStart,
+			PeriodEnd:      periodStart.Add(24 * time.Hour),
+			Requests:       12,
+			RequestQuota:   1000,
+			ComputeSeconds: 1.5,
+		}}}, got)
+		assert.NotContains(t, w.Body.String(), "compute_quota_seconds")
+	})
+
+	t.Run("domain error", func(t *testing.T) {
+		mockDomain := mocks.NewMockTenantDomainInterface(t)
+		mockDomain.On("ListUsage", mock.Anything).Return(nil, context.Canceled)
+
+		w := httptest.NewRecorder()
+		NewTenantHandler(mockDomain).ListUsage(w, httptest.NewRequest(http.MethodGet, "/admin/usage", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewTenantHandler(mocks.NewMockTenantDomainInterface(t)).ListUsage(w, httptest.NewRequest(http.MethodPost, "/admin/usage", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+)
+
+// TimeoutHandlerInterface defines the contract for the middleware that bounds how long a route works on a request.
+// Unlike the write timeout of the server, which cuts the connection, the deadline is set on the request context,
+// so handlers stop at their next context check and the client is answered 504 Gateway Timeout.
+type TimeoutHandlerInterface interface {
+	// Limit wraps next, a cheap route such as a listing or a lookup, in the request timeout.
+	Limit(next http.Handler) http.Handler
+
+	// LimitOperation wraps next, a route running matrix operations, in the longer operation timeout.
+	LimitOperation(next http.Handler) http.Handler
+}
+
+type timeoutHandler struct {
+	config domain.TimeoutConfig
+}
+
+// NewTimeoutHandler creates a new instance of TimeoutHandlerInterface with its dependencies.
+// It initializes the middleware with the timeouts of the cheap routes and of the operation routes.
+func NewTimeoutHandler(config domain.TimeoutConfig) TimeoutHandlerInterface {
+	return &timeoutHandler{
+		config: config,
+	}
+}
+
+func (h *timeoutHandler) Limit(next http.Handler) http.Handler {
+	return h.limit(h.config.Request, next)
+}
+
+func (h *timeoutHandler) LimitOperation(next http.Handler) http.Handler {
+	return h.limit(h.config.Operation, next)
+}
+
+// limit wraps next so its requests are given up once timeout has elapsed.
+func (h *timeoutHandler) limit(timeout time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		// Handlers that return on the expired deadline without answering get the 504 the others answer with.
+		// A client that disconnected first cancels the context instead, and is not answered.
+		if recorder.code == 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			slog.ErrorContext(r.Context(), "request timeout",
+				"path", r.URL.Path,
+				"timeout", timeout)
+			httpError(w, r, "request timeout", http.StatusGatewayTimeout)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestTimeoutHandler_Limit(t *testing.T) {
+	timeouts := NewTimeoutHandler(domain.TimeoutConfig{Request: 10 * time.Millisecond, Operation: time.Minute})
+
+	// slow waits for the deadline of the request, then answers like handlers mapping errors do when answer is set
+	slow := func(answer bool) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			if answer {
+				err := fmt.Errorf("failed to read file: %w", r.Context().Err())
+				http.Error(w, err.Error(), apperrors.GetHTTPStatusCode(err))
+			}
+		})
+	}
+
+	tests := []struct {
+		name       string
+		limit      func(http.Handler) http.Handler
+		next       http.Handler
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "answered in time",
+			limit: timeouts.Limit,
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok := r.Context().Deadline()
+				assert.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(10*time.Millisecond), deadline, 10*time.Millisecond)
+				fmt.Fprint(w, "ok")
+			}),
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "handler answers the timeout",
+			limit:      timeouts.Limit,
+			next:       slow(true),
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   "failed to read file: context deadline exceeded\n",
+		},
+		{
+			name:       "handler returns without answering",
+			limit:      timeouts.Limit,
+			next:       slow(false),
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   "request timeout\n",
+		},
+		{
+			name:  "operations are given longer",
+			limit: timeouts.LimitOperation,
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok := r.Context().Deadline()
+				assert.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+				fmt.Fprint(w, "ok")
+			}),
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.limit(tt.next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+
+	t.Run("client disconnected", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		w := httptest.NewRecorder()
+		timeouts.Limit(slow(false)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/matrices", nil).WithContext(ctx))
+
+		// Nothing is written for a client that is gone
+		assert.Empty(t, w.Body.String())
+	})
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// chunkContentType is the media type required for upload chunks, following the tus protocol.
+const chunkContentType = "application/offset+octet-stream"
+
+// UploadHandlerInterface defines the contract for HTTP handlers that receive matrix files in resumable chunks.
+// The flow is offset based: create an upload announcing its size, then PATCH chunks at the current offset,
+// using HEAD to find where to resume after a failure.
+type UploadHandlerInterface interface {
+	// CreateUpload handles POST /uploads requests with an Upload-Length header.
+	// It responds with 201 Created and the upload URL in the Location header.
+	// The ttl query parameter (e.g. ttl=24h) sets the time to live of the completed matrix file,
+	// and each tag query parameter (e.g. tag=season:2026) a tag it carries.
+	CreateUpload(w http.ResponseWriter, r *http.Request)
+
+	// HandleUpload handles requests for a single upload under /uploads/{id}.
+	// HEAD reports the current Upload-Offset; PATCH appends the body at the Upload-Offset header.
+	// Once the last chunk is received and validated, the Matrix-File header holds the file path
+	// to use with the matrix endpoints and Matrix-Content-SHA256 the hash of its content, with
+	// Matrix-Deduplicated: true when the same content had been uploaded before.
+	HandleUpload(w http.ResponseWriter, r *http.Request)
+}
+
+type uploadHandler struct {
+	uploadDomain domain.UploadDomainInterface
+}
+
+// NewUploadHandler creates a new instance of UploadHandlerInterface with its dependencies.
+// It initializes the handler with the upload domain service for chunk assembly and validation,
+// whose background sweep of abandoned uploads the caller stops on shutdown.
+func NewUploadHandler(uploadDomain domain.UploadDomainInterface) UploadHandlerInterface {
+	return &uploadHandler{
+		uploadDomain: uploadDomain,
+	}
+}
+
+func (h *uploadHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	length, err := parseUploadHeader(r, "Upload-Length")
+	if err != nil {
+		h.writeError(w, r, "", err)
+		return
+	}
+
+	var ttl time.Duration
+	if value := r.URL.Query().Get("ttl"); value != "" {
+		ttl, err = parseTTL(value)
+		if err != nil {
+			h.writeError(w, r, "", err)
+			return
+		}
+	}
+
+	tags, err := parseTagQuery(r, true)
+	if err != nil {
+		h.writeError(w, r, "", err)
+		return
+	}
+
+	upload, err := h.uploadDomain.CreateUpload(r.Context(), length, ttl, tags)
+	if err != nil {
+		h.writeError(w, r, "", err)
+		return
+	}
+
+	w.Header().Set("Location", "/uploads/"+upload.ID)
+	setUploadHeaders(w, upload)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *uploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/uploads/"):]
+
+	switch r.Method {
+	case http.MethodHead:
+		upload, err := h.uploadDomain.GetUpload(r.Context(), id)
+		if err != nil {
+			h.writeError(w, r, id, err)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		setUploadHeaders(w, upload)
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodPatch:
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != chunkContentType {
+			h.writeError(w, r, id, fmt.Errorf("%w: chunks must be sent as %s", apperrors.ErrUnsupportedMediaType, chunkContentType))
+			return
+		}
+
+		offset, err := parseUploadHeader(r, "Upload-Offset")
+		if err != nil {
+			h.writeError(w, r, id, err)
+			return
+		}
+
+		upload, err := h.uploadDomain.AppendChunk(r.Context(), id, offset, r.Body)
+		if err != nil {
+			h.writeError(w, r, id, err)
+			return
+		}
+
+		setUploadHeaders(w, upload)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *uploadHandler) writeError(w http.ResponseWriter, r *http.Request, id string, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "upload request failed",
+		"upload_id", id,
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+// parseUploadHeader reads a non-negative byte count from the named request header.
+func parseUploadHeader(r *http.Request, name string) (int64, error) {
+	value := r.Header.Get(name)
+	if value == "" {
+		return 0, fmt.Errorf("%w: %s header is required", apperrors.ErrInvalidInput, name)
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: invalid %s header: %q", apperrors.ErrInvalidInput, name, value)
+	}
+	return n, nil
+}
+
+func setUploadHeaders(w http.ResponseWriter, upload *entity.Upload) {
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	if upload.Complete() {
+		w.Header().Set("Matrix-File", upload.FilePath)
+		w.Header().Set("Matrix-Content-SHA256", upload.ContentHash)
+		if upload.Deduplicated {
+			w.Header().Set("Matrix-Deduplicated", "true")
+		}
+	}
+}
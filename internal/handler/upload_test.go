@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestUploadHandler_CreateUpload(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		query        string
+		uploadLength string
+		wantTTL      time.Duration
+		wantTags     map[string]string
+		mockUpload   *entity.Upload
+		mockError    error
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name:         "successfully create upload",
+			method:       http.MethodPost,
+			uploadLength: "12",
+			mockUpload:   &entity.Upload{ID: "abc", Length: 12},
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/uploads/abc",
+		},
+		{
+			name:         "upload with a time to live",
+			method:       http.MethodPost,
+			query:        "?ttl=24h",
+			uploadLength: "12",
+			wantTTL:      24 * time.Hour,
+			mockUpload:   &entity.Upload{ID: "abc", Length: 12},
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/uploads/abc",
+		},
+		{
+			name:         "upload with tags",
+			method:       http.MethodPost,
+			query:        "?tag=season:2026&tag=league:premier:north",
+			uploadLength: "12",
+			wantTags:     map[string]string{"season": "2026", "league": "premier:north"},
+			mockUpload:   &entity.Upload{ID: "abc", Length: 12},
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/uploads/abc",
+		},
+		{
+			name:         "tag without value",
+			method:       http.MethodPost,
+			query:        "?tag=season",
+			uploadLength: "12",
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:         "invalid ttl",
+			method:       http.MethodPost,
+			query:        "?ttl=tomorrow",
+			uploadLength: "12",
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:       "missing Upload-Length header",
+			method:     http.MethodPost,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid Upload-Length header",
+			method:       http.MethodPost,
+			uploadLength: "-1",
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:         "upload too large",
+			method:       http.MethodPost,
+			uploadLength: "4096",
+			mockError:    apperrors.ErrPayloadTooLarge,
+			wantStatus:   http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "method not allowed - GET",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockUploadDomainInterface(t)
+			if tt.mockUpload != nil || tt.mockError != nil {
+				mockDomain.On("CreateUpload", mock.Anything, mock.AnythingOfType("int64"), tt.wantTTL, tt.wantTags).
+					Return(tt.mockUpload, tt.mockError)
+			}
+
+			handler := &uploadHandler{
+				uploadDomain: mockDomain,
+			}
+
+			req := httptest.NewRequest(tt.method, "/uploads"+tt.query, nil)
+			if tt.uploadLength != "" {
+				req.Header.Set("Upload-Length", tt.uploadLength)
+			}
+			w := httptest.NewRecorder()
+
+			handler.CreateUpload(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			if tt.wantStatus == http.StatusCreated {
+				assert.Equal(t, "0", w.Header().Get("Upload-Offset"))
+				assert.Equal(t, "12", w.Header().Get("Upload-Length"))
+			}
+		})
+	}
+}
+
+func TestUploadHandler_HandleUpload(t *testing.T) {
+	t.Run("HEAD reports the current offset", func(t *testing.T) {
+		mockDomain := mocks.NewMockUploadDomainInterface(t)
+		mockDomain.On("GetUpload", mock.Anything, "abc").
+			Return(&entity.Upload{ID: "abc", Length: 12, Offset: 6}, nil)
+
+		handler := &uploadHandler{uploadDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodHead, "/uploads/abc", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "6", w.Header().Get("Upload-Offset"))
+		assert.Equal(t, "12", w.Header().Get("Upload-Length"))
+		assert.Empty(t, w.Header().Get("Matrix-File"))
+	})
+
+	t.Run("HEAD for unknown upload", func(t *testing.T) {
+		mockDomain := mocks.NewMockUploadDomainInterface(t)
+		mockDomain.On("GetUpload", mock.Anything, "missing").Return(nil, apperrors.ErrNotFound)
+
+		handler := &uploadHandler{uploadDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodHead, "/uploads/missing", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("PATCH with final chunk reports the matrix file", func(t *testing.T) {
+		mockDomain := mocks.NewMockUploadDomainInterface(t)
+		mockDomain.On("AppendChunk", mock.Anything, "abc", int64(6), mock.Anything).
+			Return(&entity.Upload{ID: "abc", Length: 12, Offset: 12, FilePath: "testdata/uploads/abc.csv", ContentHash: "c0ffee"}, nil)
+
+		handler := &uploadHandler{uploadDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodPatch, "/uploads/abc", strings.NewReader("4,5,6\n"))
+		req.Header.Set("Content-Type", "application/offset+octet-stream")
+		req.Header.Set("Upload-Offset", "6")
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "12", w.Header().Get("Upload-Offset"))
+		assert.Equal(t, "testdata/uploads/abc.csv", w.Header().Get("Matrix-File"))
+		assert.Equal(t, "c0ffee", w.Header().Get("Matrix-Content-SHA256"))
+		assert.Empty(t, w.Header().Get("Matrix-Deduplicated"))
+	})
+
+	t.Run("PATCH with final chunk reports deduplicated content", func(t *testing.T) {
+		mockDomain := mocks.NewMockUploadDomainInterface(t)
+		mockDomain.On("AppendChunk", mock.Anything, "abc", int64(0), mock.Anything).
+			Return(&entity.Upload{ID: "abc", Length: 6, Offset: 6, FilePath: "testdata/uploads/abc.csv", ContentHash: "c0ffee", Deduplicated: true}, nil)
+
+		handler := &uploadHandler{uploadDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodPatch, "/uploads/abc", strings.NewReader("1,2,3\n"))
+		req.Header.Set("Content-Type", "application/offset+octet-stream")
+		req.Header.Set("Upload-Offset", "0")
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Matrix-Deduplicated"))
+	})
+
+	t.Run("PATCH with stale offset", func(t *testing.T) {
+		mockDomain := mocks.NewMockUploadDomainInterface(t)
+		mockDomain.On("AppendChunk", mock.Anything, "abc", int64(0), mock.Anything).
+			Return(nil, apperrors.ErrConflict)
+
+		handler := &uploadHandler{uploadDomain: mockDomain}
+		req := httptest.NewRequest(http.MethodPatch, "/uploads/abc", strings.NewReader("1,2,3\n"))
+		req.Header.Set("Content-Type", "application/offset+octet-stream")
+		req.Header.Set("Upload-Offset", "0")
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("PATCH with wrong content type", func(t *testing.T) {
+		handler := &uploadHandler{uploadDomain: mocks.NewMockUploadDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodPatch, "/uploads/abc", strings.NewReader("1,2,3\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		req.Header.Set("Upload-Offset", "0")
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("PATCH without offset", func(t *testing.T) {
+		handler := &uploadHandler{uploadDomain: mocks.NewMockUploadDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodPatch, "/uploads/abc", strings.NewReader("1,2,3\n"))
+		req.Header.Set("Content-Type", "application/offset+octet-stream")
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("method not allowed - DELETE", func(t *testing.T) {
+		handler := &uploadHandler{uploadDomain: mocks.NewMockUploadDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodDelete, "/uploads/abc", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleUpload(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
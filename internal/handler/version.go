@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// VersionHandlerInterface defines the contract for HTTP handlers that update stored matrix files while keeping
+// their prior versions, which operations read with a reference such as file=testdata/league.csv@v3.
+type VersionHandlerInterface interface {
+	// UpdateFile handles PUT /files?file=... requests, replacing the matrix stored in the file with the one
+	// in the body, encoded as described by the Content-Type header, and keeping the matrix it replaces as a prior
+	// version. The If-Match header must carry the ETag of the current version, so an update based on a version
+	// replaced since fails with 412 Precondition Failed; without it the update fails with 428 Precondition Required.
+	// It responds with the new version as JSON and its ETag.
+	UpdateFile(w http.ResponseWriter, r *http.Request)
+
+	// ListVersions handles GET /files/versions?file=... requests, returning the versions of the file as JSON,
+	// the oldest first and the current one last, with the ETag of the current version.
+	ListVersions(w http.ResponseWriter, r *http.Request)
+}
+
+// versionResponse describes a version of a stored matrix file; Ref is the file parameter reading it.
+type versionResponse struct {
+	Version   int       `json:"version"`
+	Ref       string    `json:"ref"`
+	ETag      string    `json:"etag"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	Current   bool      `json:"current"`
+}
+
+type versionListResponse struct {
+	File     string            `json:"file"`
+	Versions []versionResponse `json:"versions"`
+}
+
+type versionHandler struct {
+	versionDomain domain.VersionDomainInterface
+}
+
+// NewVersionHandler creates a new instance of VersionHandlerInterface with its dependencies.
+// It initializes the handler with the version domain service that updates stored files and lists their versions.
+func NewVersionHandler(versionDomain domain.VersionDomainInterface) VersionHandlerInterface {
+	return &versionHandler{
+		versionDomain: versionDomain,
+	}
+}
+
+func (h *versionHandler) UpdateFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	audit.Describe(r.Context(), "update", filePath)
+
+	matrix, err := decodeMatrixBody(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	version, err := h.versionDomain.Update(r.Context(), filePath, parseIfMatch(r), matrix)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", version.ETag)
+	w.Header().Set("Matrix-File", version.Ref)
+	writeJSON(w, r, http.StatusOK, toVersionResponse(version))
+}
+
+func (h *versionHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath := r.URL.Query().Get("file")
+	audit.Describe(r.Context(), "versions", filePath)
+
+	versions, err := h.versionDomain.ListVersions(r.Context(), filePath)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := versionListResponse{File: filePath, Versions: make([]versionResponse, 0, len(versions))}
+	for _, version := range versions {
+		response.Versions = append(response.Versions, toVersionResponse(version))
+		if version.Current {
+			w.Header().Set("ETag", version.ETag)
+		}
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func (h *versionHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := apperrors.GetHTTPStatusCode(err)
+	slog.ErrorContext(r.Context(), "version request failed",
+		"error", err,
+		"status_code", statusCode)
+	httpErrorFrom(w, r, err, statusCode)
+}
+
+func toVersionResponse(version *entity.MatrixVersion) versionResponse {
+	return versionResponse{
+		Version:   version.Version,
+		Ref:       version.Ref,
+		ETag:      version.ETag,
+		Size:      version.Size,
+		CreatedAt: version.CreatedAt,
+		Current:   version.Current,
+	}
+}
+
+// parseIfMatch returns the entity tags of the If-Match headers of r, which may list several separated by commas.
+func parseIfMatch(r *http.Request) []string {
+	var tags []string
+	for _, header := range r.Header.Values("If-Match") {
+		for tag := range strings.SplitSeq(header, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
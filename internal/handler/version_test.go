@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestVersionHandler_UpdateFile(t *testing.T) {
+	current := &entity.MatrixVersion{
+		FilePath:  "testdata/league.csv",
+		Version:   2,
+		Ref:       "testdata/league.csv@v2",
+		ETag:      `"def456"`,
+		Size:      8,
+		CreatedAt: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		Current:   true,
+	}
+	matrix := &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		ifMatch     []string
+		mockCall    bool
+		wantIfMatch []string
+		mockError   error
+		wantStatus  int
+	}{
+		{
+			name:        "updated",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			ifMatch:     []string{`"abc123"`},
+			mockCall:    true,
+			wantIfMatch: []string{`"abc123"`},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "several entity tags",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			ifMatch:     []string{`"abc123", W/"weak"`, `"older"`},
+			mockCall:    true,
+			wantIfMatch: []string{`"abc123"`, `W/"weak"`, `"older"`},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "updated by another writer since",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			ifMatch:     []string{`"stale"`},
+			mockCall:    true,
+			wantIfMatch: []string{`"stale"`},
+			mockError:   apperrors.ErrPreconditionFailed,
+			wantStatus:  http.StatusPreconditionFailed,
+		},
+		{
+			name:        "no If-Match",
+			method:      http.MethodPut,
+			contentType: "application/json",
+			mockCall:    true,
+			mockError:   apperrors.ErrPreconditionRequired,
+			wantStatus:  http.StatusPreconditionRequired,
+		},
+		{
+			name:        "unsupported body",
+			method:      http.MethodPut,
+			contentType: "text/csv",
+			ifMatch:     []string{"*"},
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockVersionDomainInterface(t)
+			if tt.mockCall {
+				var version *entity.MatrixVersion
+				if tt.mockError == nil {
+					version = current
+				}
+				mockDomain.On("Update", mock.Anything, "testdata/league.csv", tt.wantIfMatch, matrix).Return(version, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/files?file=testdata/league.csv", strings.NewReader(`{"rows":[[1,2],[3,4]]}`))
+			req.Header.Set("Content-Type", tt.contentType)
+			for _, value := range tt.ifMatch {
+				req.Header.Add("If-Match", value)
+			}
+			w := httptest.NewRecorder()
+
+			NewVersionHandler(mockDomain).UpdateFile(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, `"def456"`, w.Header().Get("ETag"))
+				assert.Equal(t, "testdata/league.csv@v2", w.Header().Get("Matrix-File"))
+				assert.JSONEq(t, `{"version":2,"ref":"testdata/league.csv@v2","etag":"\"def456\"","size":8,`+
+					`"created_at":"2026-01-01T12:00:00Z","current":true}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestVersionHandler_ListVersions(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		filePath   string
+		mockCall   bool
+		mockError  error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "versions",
+			method:     http.MethodGet,
+			filePath:   "testdata/league.csv",
+			mockCall:   true,
+			wantStatus: http.StatusOK,
+			wantBody: `{"file":"testdata/league.csv","versions":[` +
+				`{"version":1,"ref":"testdata/league.csv@v1","etag":"\"abc123\"","size":8,"created_at":"2026-01-01T12:00:00Z","current":false},` +
+				`{"version":2,"ref":"testdata/league.csv@v2","etag":"\"def456\"","size":8,"created_at":"2026-01-01T12:00:00Z","current":true}]}`,
+		},
+		{
+			name:       "missing file",
+			method:     http.MethodGet,
+			filePath:   "testdata/missing.csv",
+			mockCall:   true,
+			mockError:  apperrors.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			filePath:   "testdata/league.csv",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomain := mocks.NewMockVersionDomainInterface(t)
+			if tt.mockCall {
+				var versions []*entity.MatrixVersion
+				if tt.mockError == nil {
+					versions = []*entity.MatrixVersion{
+						{FilePath: tt.filePath, Version: 1, Ref: "testdata/league.csv@v1", ETag: `"abc123"`, Size: 8, CreatedAt: createdAt},
+						{FilePath: tt.filePath, Version: 2, Ref: "testdata/league.csv@v2", ETag: `"def456"`, Size: 8, CreatedAt: createdAt, Current: true},
+					}
+				}
+				mockDomain.On("ListVersions", mock.Anything, tt.filePath).Return(versions, tt.mockError)
+			}
+
+			req := httptest.NewRequest(tt.method, "/files/versions?file="+tt.filePath, nil)
+			w := httptest.NewRecorder()
+
+			NewVersionHandler(mockDomain).ListVersions(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+				assert.Equal(t, `"def456"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/domain"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// wsIdleTimeout closes connections that send no message for this long.
+	wsIdleTimeout = 60 * time.Second
+
+	// wsOperationTimeout bounds the processing of a single message, like the HTTP write timeout does for requests.
+	wsOperationTimeout = 10 * time.Second
+
+	// wsWriteTimeout bounds the time spent sending a single response.
+	wsWriteTimeout = 5 * time.Second
+)
+
+// WebSocketHandlerInterface defines the contract for the WebSocket API, which lets interactive clients
+// issue many small operations over one persistent connection instead of one HTTP request each.
+type WebSocketHandlerInterface interface {
+	// ServeWebSocket handles GET /ws requests by upgrading the connection to a WebSocket.
+	// Each text message is a JSON request naming an operation and either a file or an inline matrix;
+	// each request receives exactly one JSON response carrying the same id, in order.
+	ServeWebSocket(w http.ResponseWriter, r *http.Request)
+}
+
+// wsRequest is a single operation request received over the WebSocket.
+type wsRequest struct {
+	ID        string          `json:"id,omitempty"`
+	Operation string          `json:"operation"`
+	File      string          `json:"file,omitempty"`
+	Matrix    [][]json.Number `json:"matrix,omitempty"`
+}
+
+// wsResponse answers a wsRequest. Exactly one of Matrix, Scalar or Error is set.
+type wsResponse struct {
+	ID        string          `json:"id,omitempty"`
+	Operation string          `json:"operation,omitempty"`
+	Matrix    [][]json.Number `json:"matrix,omitempty"`
+	Scalar    string          `json:"scalar,omitempty"`
+	Error     *wsError        `json:"error,omitempty"`
+}
+
+// wsError reports a failed request with the status code and error code the HTTP API would have returned.
+type wsError struct {
+	Status  int            `json:"status"`
+	Code    apperrors.Code `json:"code"`
+	Message string         `json:"message"`
+}
+
+type webSocketHandler struct {
+	matrixDomain domain.MatrixDomainInterface
+	upgrader     websocket.Upgrader
+}
+
+// NewWebSocketHandler creates a new instance of WebSocketHandlerInterface with its dependencies.
+// It initializes the handler with a matrix domain service and an upgrader that only accepts same-origin browser connections.
+func NewWebSocketHandler(matrixDomain domain.MatrixDomainInterface) WebSocketHandlerInterface {
+	return &webSocketHandler{
+		matrixDomain: matrixDomain,
+	}
+}
+
+func (h *webSocketHandler) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The upgrader writes the error response itself when the handshake fails
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "websocket upgrade failed", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	// Requests carry at most a small matrix, like HTTP request bodies
+	conn.SetReadLimit(maxRequestBodyBytes)
+
+	slog.InfoContext(r.Context(), "websocket connection opened", "remote_addr", r.RemoteAddr)
+	served := 0
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(wsIdleTimeout)); err != nil {
+			break
+		}
+
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				slog.ErrorContext(r.Context(), "websocket read failed", "error", err)
+			}
+			break
+		}
+
+		response := h.handleMessage(r.Context(), message)
+		if err := conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+			break
+		}
+		if err := conn.WriteJSON(response); err != nil {
+			slog.ErrorContext(r.Context(), "websocket write failed", "error", err)
+			break
+		}
+		served++
+	}
+
+	slog.InfoContext(r.Context(), "websocket connection closed",
+		"remote_addr", r.RemoteAddr,
+		"requests", served)
+}
+
+// handleMessage decodes a single message and runs the request it carries.
+// Malformed messages are answered with an error instead of closing the connection.
+func (h *webSocketHandler) handleMessage(ctx context.Context, message []byte) *wsResponse {
+	var request wsRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		slog.ErrorContext(ctx, "invalid websocket message", "error", err)
+		return &wsResponse{Error: &wsError{
+			Status:  http.StatusBadRequest,
+			Code:    apperrors.CodeInvalidInput,
+			Message: fmt.Errorf("%w: invalid message: %v", apperrors.ErrInvalidInput, err).Error(),
+		}}
+	}
+
+	return h.process(ctx, &request)
+}
+
+// process runs a single request with its own timeout so one slow operation cannot hold the connection forever.
+func (h *webSocketHandler) process(ctx context.Context, request *wsRequest) *wsResponse {
+	ctx, cancel := context.WithTimeout(ctx, wsOperationTimeout)
+	defer cancel()
+
+	var result *entity.Result
+	var err error
+	if request.Matrix != nil {
+		var matrix *entity.Matrix[int64]
+		matrix, err = codec.MatrixFromJSON(request.Matrix)
+		if err == nil {
+			result, err = h.matrixDomain.ProcessMatrixData(ctx, request.Operation, matrix, nil)
+		}
+	} else {
+		result, err = h.matrixDomain.ProcessMatrix(ctx, request.Operation, request.File, nil)
+	}
+
+	response := &wsResponse{ID: request.ID, Operation: request.Operation}
+	if err != nil {
+		statusCode := apperrors.GetHTTPStatusCode(err)
+		message := err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			statusCode, message = http.StatusGatewayTimeout, "request timeout"
+		}
+		slog.ErrorContext(ctx, "websocket operation failed",
+			"request_id", request.ID,
+			"operation", request.Operation,
+			"file_path", request.File,
+			"error", err,
+			"status_code", statusCode)
+		response.Error = &wsError{Status: statusCode, Code: errorCode(err, statusCode), Message: message}
+		return response
+	}
+
+	if result.Matrix != nil {
+		response.Matrix = codec.MatrixToJSON(result.Matrix)
+	} else {
+		response.Scalar = result.Scalar
+	}
+	return response
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/mocks"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// dialWebSocket starts a test server for handler and opens a WebSocket connection to it.
+func dialWebSocket(t *testing.T, handler *webSocketHandler) *websocket.Conn {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(handler.ServeWebSocket))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestWebSocketHandler_ServeWebSocket(t *testing.T) {
+	t.Run("many requests over one connection", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "378"}, nil)
+		mockDomain.On("ProcessMatrixData", mock.Anything, "invert", &entity.Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}, (*entity.Selection)(nil)).
+			Return(&entity.Result{Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}}}}, nil)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix2.csv", (*entity.Selection)(nil)).
+			Return(nil, apperrors.ErrUnprocessableEntity)
+
+		conn := dialWebSocket(t, &webSocketHandler{matrixDomain: mockDomain})
+
+		requests := []wsRequest{
+			{ID: "1", Operation: "sum", File: "testdata/matrix1.csv"},
+			{ID: "2", Operation: "invert", Matrix: [][]json.Number{{"1", "2"}, {"3", "4"}}},
+			{ID: "3", Operation: "sum", File: "testdata/matrix2.csv"},
+		}
+		want := []wsResponse{
+			{ID: "1", Operation: "sum", Scalar: "378"},
+			{ID: "2", Operation: "invert", Matrix: [][]json.Number{{"1", "3"}, {"2", "4"}}},
+			{ID: "3", Operation: "sum", Error: &wsError{Status: http.StatusUnprocessableEntity, Code: apperrors.CodeUnprocessableEntity, Message: "unprocessable entity"}},
+		}
+
+		for i, request := range requests {
+			assert.NoError(t, conn.WriteJSON(request))
+
+			var got wsResponse
+			assert.NoError(t, conn.ReadJSON(&got))
+			assert.Equal(t, want[i], got)
+		}
+	})
+
+	t.Run("malformed message keeps the connection open", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(&entity.Result{Scalar: "378"}, nil)
+
+		conn := dialWebSocket(t, &webSocketHandler{matrixDomain: mockDomain})
+
+		assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("{not json")))
+		var got wsResponse
+		assert.NoError(t, conn.ReadJSON(&got))
+		assert.Equal(t, http.StatusBadRequest, got.Error.Status)
+		assert.Equal(t, apperrors.CodeInvalidInput, got.Error.Code)
+
+		assert.NoError(t, conn.WriteJSON(wsRequest{Operation: "sum", File: "testdata/matrix1.csv"}))
+		got = wsResponse{}
+		assert.NoError(t, conn.ReadJSON(&got))
+		assert.Equal(t, "378", got.Scalar)
+	})
+
+	t.Run("operation timeout", func(t *testing.T) {
+		mockDomain := mocks.NewMockMatrixDomainInterface(t)
+		mockDomain.On("ProcessMatrix", mock.Anything, "sum", "testdata/matrix1.csv", (*entity.Selection)(nil)).
+			Return(nil, context.DeadlineExceeded)
+
+		conn := dialWebSocket(t, &webSocketHandler{matrixDomain: mockDomain})
+
+		assert.NoError(t, conn.WriteJSON(wsRequest{Operation: "sum", File: "testdata/matrix1.csv"}))
+		var got wsResponse
+		assert.NoError(t, conn.ReadJSON(&got))
+		assert.Equal(t, &wsError{Status: http.StatusGatewayTimeout, Code: apperrors.CodeTimeout, Message: "request timeout"}, got.Error)
+	})
+
+	t.Run("plain HTTP request is rejected", func(t *testing.T) {
+		handler := &webSocketHandler{matrixDomain: mocks.NewMockMatrixDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeWebSocket(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("method not allowed - POST", func(t *testing.T) {
+		handler := &webSocketHandler{matrixDomain: mocks.NewMockMatrixDomainInterface(t)}
+		req := httptest.NewRequest(http.MethodPost, "/ws", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeWebSocket(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
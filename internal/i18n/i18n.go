@@ -0,0 +1,137 @@
+// Package i18n translates the instructional text shown to people, such as the landing page and the
+// operations listing, using the message catalogs embedded under locales/. Each catalog is a JSON object
+// mapping message keys to their text in one language; English is the fallback for missing languages and keys.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// fallback is the language of the catalog used when no supported language is acceptable,
+// and for keys missing from another catalog. It must be the first supported language.
+var fallback = language.English
+
+var (
+	// catalogs maps each supported language to its messages
+	catalogs map[language.Tag]map[string]string
+
+	// supported lists the languages with a catalog, the fallback first
+	supported []language.Tag
+
+	// matcher picks the supported language closest to those a client accepts
+	matcher language.Matcher
+)
+
+func init() {
+	var err error
+	catalogs, supported, err = loadCatalogs()
+	if err != nil {
+		panic(err)
+	}
+	matcher = language.NewMatcher(supported)
+}
+
+// Localizer renders messages in a single language.
+type Localizer struct {
+	tag      language.Tag
+	messages map[string]string
+}
+
+// Match returns the localizer for the supported language that best matches an Accept-Language header value,
+// falling back to English when the header is empty, malformed or lists no supported language.
+func Match(acceptLanguage string) *Localizer {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return English()
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return English()
+	}
+	tag := supported[index]
+	return &Localizer{tag: tag, messages: catalogs[tag]}
+}
+
+// English returns the localizer for the fallback language.
+func English() *Localizer {
+	return &Localizer{tag: fallback, messages: catalogs[fallback]}
+}
+
+// Languages returns the BCP 47 tags of the supported languages, English first.
+func Languages() []string {
+	tags := make([]string, 0, len(supported))
+	for _, tag := range supported {
+		tags = append(tags, tag.String())
+	}
+	return tags
+}
+
+// Language returns the BCP 47 tag of the language l renders, for Content-Language headers and lang attributes.
+func (l *Localizer) Language() string {
+	return l.tag.String()
+}
+
+// T returns the message for key in the language of l, formatted with args as by fmt.Sprintf.
+// Keys missing from the catalog fall back to English, and unknown keys are returned unchanged
+// so a missing translation is visible rather than blank.
+func (l *Localizer) T(key string, args ...any) string {
+	message, ok := l.messages[key]
+	if !ok {
+		message, ok = catalogs[fallback][key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// loadCatalogs reads the embedded message catalogs, named after the language they hold.
+func loadCatalogs() (map[language.Tag]map[string]string, []language.Tag, error) {
+	entries, err := locales.ReadDir("locales")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read message catalogs: %w", err)
+	}
+
+	loaded := make(map[language.Tag]map[string]string, len(entries))
+	tags := []language.Tag{fallback}
+	for _, entry := range entries {
+		name := entry.Name()
+		tag, err := language.Parse(strings.TrimSuffix(name, path.Ext(name)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid message catalog name %s: %w", name, err)
+		}
+
+		data, err := locales.ReadFile("locales/" + name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read message catalog %s: %w", name, err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, nil, fmt.Errorf("invalid message catalog %s: %w", name, err)
+		}
+
+		loaded[tag] = messages
+		if tag != fallback {
+			tags = append(tags, tag)
+		}
+	}
+
+	if _, ok := loaded[fallback]; !ok {
+		return nil, nil, fmt.Errorf("missing message catalog for %s", fallback)
+	}
+	return loaded, tags, nil
+}
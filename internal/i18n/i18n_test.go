@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{acceptLanguage: "ja", want: "ja"},
+		{acceptLanguage: "pt-BR,pt;q=0.9,en;q=0.8", want: "pt"},
+		{acceptLanguage: "es-MX", want: "es"},
+		{acceptLanguage: "fr-CA,fr;q=0.9,es;q=0.5", want: "es"},
+		{acceptLanguage: "de,ja;q=0.1", want: "ja"},
+		{acceptLanguage: "en-GB", want: "en"},
+		{acceptLanguage: "fr", want: "en"},
+		{acceptLanguage: "", want: "en"},
+		{acceptLanguage: "not a language;;", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.acceptLanguage).Language())
+		})
+	}
+}
+
+func TestLocalizer_T(t *testing.T) {
+	assert.Equal(t, "Are you lost?", English().T("listing.lost"))
+	assert.Equal(t, "お困りですか?", Match("ja").T("listing.lost"))
+
+	// Keys missing from a catalog fall back to English, and unknown keys are shown as is
+	spanish := &Localizer{tag: Match("es").tag, messages: map[string]string{}}
+	assert.Equal(t, "Are you lost?", spanish.T("listing.lost"))
+	assert.Equal(t, "missing.key", spanish.T("missing.key"))
+
+	formatted := &Localizer{messages: map[string]string{"greeting": "Hello, %s"}}
+	assert.Equal(t, "Hello, acme", formatted.T("greeting", "acme"))
+}
+
+func TestCatalogs(t *testing.T) {
+	assert.Equal(t, []string{"en", "es", "ja", "pt"}, Languages())
+
+	// Every catalog translates every English message
+	for tag, messages := range catalogs {
+		for key := range catalogs[fallback] {
+			assert.NotEmpty(t, messages[key], "%s is missing %s", tag, key)
+		}
+		for key := range messages {
+			assert.Contains(t, catalogs[fallback], key, "%s has unknown key %s", tag, key)
+		}
+	}
+}
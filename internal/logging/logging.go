@@ -0,0 +1,140 @@
+// Package logging scopes log records to the request they are written for. It carries the identifiers of
+// a request, from the middleware that reads them to every layer serving it, and its Handler adds them to
+// each record logged with the request context, along with the tenant, operation and file of the request.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+// Scope identifies the request a log record is written for.
+type Scope struct {
+	// RequestID identifies the request, as sent by the client or generated for it.
+	RequestID string
+
+	// TraceID is the W3C trace ID the request belongs to, empty when the client sent none.
+	TraceID string
+}
+
+// MaxRequestIDLength limits the request IDs sent by clients, which are logged and echoed in responses.
+const MaxRequestIDLength = 128
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying scope.
+func NewContext(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, contextKey{}, scope)
+}
+
+// FromContext returns the scope carried by ctx, or an empty scope without one.
+func FromContext(ctx context.Context) Scope {
+	scope, _ := ctx.Value(contextKey{}).(Scope)
+	return scope
+}
+
+// NewRequestID returns sent, the request ID a client sent with a request, when it is usable: at most
+// MaxRequestIDLength printable ASCII characters. It returns a new random ID when sent is missing or unusable.
+func NewRequestID(sent string) string {
+	if sent != "" && len(sent) <= MaxRequestIDLength && isPrintableASCII(sent) {
+		return sent
+	}
+
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Handler is a slog.Handler that adds the request-scoped attributes carried by the context of a record
+// before passing it on to the handler it wraps: request_id, trace_id, tenant_id, and the operation and
+// file_path described to the audit trail. Attributes without a value are left out, and so are those the
+// record or the logger already set, so explicit values win. Records must be logged with the request context,
+// as with slog.InfoContext, to be scoped.
+type Handler struct {
+	base slog.Handler
+	// keys are the keys of the attributes added with WithAttrs
+	keys map[string]bool
+}
+
+// NewHandler returns a handler adding the request-scoped attributes to the records it passes on to base.
+func NewHandler(base slog.Handler) *Handler {
+	return &Handler{base: base}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := scopeAttrs(ctx)
+	if len(attrs) == 0 {
+		return h.base.Handle(ctx, r)
+	}
+
+	r.Attrs(func(attr slog.Attr) bool {
+		attrs = slices.DeleteFunc(attrs, func(scoped slog.Attr) bool { return scoped.Key == attr.Key })
+		return len(attrs) > 0
+	})
+	attrs = slices.DeleteFunc(attrs, func(scoped slog.Attr) bool { return h.keys[scoped.Key] })
+	if len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.base.Handle(ctx, r)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	keys := maps.Clone(h.keys)
+	if keys == nil {
+		keys = make(map[string]bool, len(attrs))
+	}
+	for _, attr := range attrs {
+		keys[attr.Key] = true
+	}
+	return &Handler{base: h.base.WithAttrs(attrs), keys: keys}
+}
+
+// WithGroup opens a group on the handler it wraps. The request-scoped attributes of later records
+// are added inside the group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{base: h.base.WithGroup(name), keys: h.keys}
+}
+
+// scopeAttrs returns the request-scoped attributes carried by ctx that have a value.
+func scopeAttrs(ctx context.Context) []slog.Attr {
+	scope := FromContext(ctx)
+	details := audit.DetailsFromContext(ctx)
+
+	attrs := make([]slog.Attr, 0, 5)
+	for _, attr := range []slog.Attr{
+		slog.String("request_id", scope.RequestID),
+		slog.String("trace_id", scope.TraceID),
+		slog.String("tenant_id", tenant.ID(ctx)),
+		slog.String("operation", details.Operation),
+		slog.String("file_path", details.FilePath),
+	} {
+		if attr.Value.String() != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+)
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, Scope{}, FromContext(context.Background()))
+
+	scope := Scope{RequestID: "job-42", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"}
+	assert.Equal(t, scope, FromContext(NewContext(context.Background(), scope)))
+}
+
+func TestHandler(t *testing.T) {
+	trail := &audit.Trail{}
+	ctx := NewContext(context.Background(), Scope{RequestID: "job-42", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"})
+	ctx = tenant.NewContext(audit.NewContext(ctx, trail), &tenant.Tenant{ID: "acme"})
+
+	tests := []struct {
+		name string
+		log  func(logger *slog.Logger)
+		want string
+	}{
+		{
+			name: "scoped attributes",
+			log: func(logger *slog.Logger) {
+				audit.Describe(ctx, "sum", "testdata/tenants/acme/matrix1.csv")
+				logger.InfoContext(ctx, "matrix operation completed")
+			},
+			want: `level=INFO msg="matrix operation completed" request_id=job-42 trace_id=4bf92f3577b34da6a3ce929d0e0e4736 ` +
+				"tenant_id=acme operation=sum file_path=testdata/tenants/acme/matrix1.csv\n",
+		},
+		{
+			name: "explicit values win",
+			log: func(logger *slog.Logger) {
+				audit.Describe(ctx, "sum", "")
+				logger.With("tenant_id", "globex").InfoContext(ctx, "archive file failed", "operation", "echo")
+			},
+			want: `level=INFO msg="archive file failed" tenant_id=globex operation=echo request_id=job-42 ` +
+				"trace_id=4bf92f3577b34da6a3ce929d0e0e4736\n",
+		},
+		{
+			name: "records without the request context",
+			log: func(logger *slog.Logger) {
+				logger.Info("server stopped gracefully")
+			},
+			want: "level=INFO msg=\"server stopped gracefully\"\n",
+		},
+		{
+			name: "groups",
+			log: func(logger *slog.Logger) {
+				audit.Describe(ctx, "", "")
+				logger.WithGroup("job").InfoContext(ctx, "job started", "id", "7f3a")
+			},
+			want: `level=INFO msg="job started" job.id=7f3a job.request_id=job-42 ` +
+				"job.trace_id=4bf92f3577b34da6a3ce929d0e0e4736 job.tenant_id=acme\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			base := slog.NewTextHandler(&out, &slog.HandlerOptions{
+				// Leave the time out so the output is predictable
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if len(groups) == 0 && attr.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return attr
+				},
+			})
+
+			tt.log(slog.New(NewHandler(base)))
+
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	handler := NewHandler(slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelError))
+}
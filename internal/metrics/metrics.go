@@ -0,0 +1,70 @@
+// Package metrics follows the matrix operations a request runs, so the metrics middleware can measure them
+// without the handlers reporting anything, and writes the metrics of the service in the Prometheus text
+// exposition format. The middleware attaches a Request to the context of every request, and the layers serving
+// it describe the operation and the matrices it reads with SetOperation and ObserveMatrix. Without a Request,
+// such as in the CLI or in background jobs, both are no-ops.
+package metrics
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Request holds what a single request did, as described by the layers serving it.
+type Request struct {
+	mu        sync.Mutex
+	operation string
+	sizes     []int
+}
+
+// NewRequest returns a request that ran no operation yet.
+func NewRequest() *Request {
+	return &Request{}
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying request.
+func NewContext(ctx context.Context, request *Request) context.Context {
+	return context.WithValue(ctx, contextKey{}, request)
+}
+
+// FromContext returns the request carried by ctx, or nil without one.
+func FromContext(ctx context.Context) *Request {
+	request, _ := ctx.Value(contextKey{}).(*Request)
+	return request
+}
+
+// SetOperation records that the request carried by ctx runs operation, replacing any operation set before.
+func SetOperation(ctx context.Context, operation string) {
+	if request := FromContext(ctx); request != nil {
+		request.mu.Lock()
+		defer request.mu.Unlock()
+		request.operation = operation
+	}
+}
+
+// ObserveMatrix records that the request carried by ctx read a matrix of rows by cols values.
+// A request may read several matrices, such as the files of a batch.
+func ObserveMatrix(ctx context.Context, rows, cols int) {
+	if request := FromContext(ctx); request != nil {
+		request.mu.Lock()
+		defer request.mu.Unlock()
+		request.sizes = append(request.sizes, rows*cols)
+	}
+}
+
+// Operation returns the operation the request ran, or an empty string when it ran none.
+func (r *Request) Operation() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.operation
+}
+
+// Sizes returns the number of values of every matrix the request read, in the order they were read.
+func (r *Request) Sizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.sizes)
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest(t *testing.T) {
+	request := NewRequest()
+	ctx := NewContext(context.Background(), request)
+	assert.Same(t, request, FromContext(ctx))
+
+	assert.Empty(t, request.Operation())
+	assert.Empty(t, request.Sizes())
+
+	SetOperation(ctx, "echo")
+	SetOperation(ctx, "concat")
+	ObserveMatrix(ctx, 2, 3)
+	ObserveMatrix(ctx, 4, 1)
+
+	// The last operation set is the one the request ran
+	assert.Equal(t, "concat", request.Operation())
+	assert.Equal(t, []int{6, 4}, request.Sizes())
+
+	// Sizes returns a copy
+	request.Sizes()[0] = 0
+	assert.Equal(t, []int{6, 4}, request.Sizes())
+}
+
+func TestRequest_WithoutRequest(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, FromContext(ctx))
+
+	// Outside a request, such as in the CLI, describing the operation does nothing
+	assert.NotPanics(t, func() {
+		SetOperation(ctx, "sum")
+		ObserveMatrix(ctx, 3, 3)
+	})
+}
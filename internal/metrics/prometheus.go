@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// ContentType is the media type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// unitSuffixes are the Prometheus suffixes of the units used by the metrics, which follow the OpenTelemetry
+// semantic conventions. Annotations such as {request} have no suffix.
+var unitSuffixes = map[string]string{
+	"s":  "seconds",
+	"By": "bytes",
+	"1":  "ratio",
+}
+
+// WritePrometheus writes the metrics of snapshot to w in the Prometheus text exposition format, naming them
+// as the OpenTelemetry compatibility rules do: dots become underscores, the unit is appended as a suffix,
+// such as _seconds, and counters end in _total. Histogram buckets are cumulative, as Prometheus expects.
+func WritePrometheus(w io.Writer, snapshot *entity.MetricsSnapshot) error {
+	bw := bufio.NewWriter(w)
+	for _, metric := range snapshot.Metrics {
+		writeMetric(bw, metric)
+	}
+	return bw.Flush()
+}
+
+// writeMetric writes the HELP and TYPE lines of metric, followed by a sample per point.
+func writeMetric(w *bufio.Writer, metric *entity.Metric) {
+	name := PrometheusName(metric)
+	w.WriteString("# HELP " + name + " " + escapeHelp(metric.Description) + "\n")
+	w.WriteString("# TYPE " + name + " " + prometheusType(metric.Kind) + "\n")
+
+	for _, point := range metric.Points {
+		labels := sortedLabels(point.Attributes)
+		if metric.Kind != entity.MetricHistogram {
+			writeSample(w, name, labels, "", "", point.Value)
+			continue
+		}
+
+		var cumulative uint64
+		for i, count := range point.BucketCounts {
+			cumulative += count
+			bound := math.Inf(1)
+			if i < len(metric.Bounds) {
+				bound = metric.Bounds[i]
+			}
+			writeSample(w, name+"_bucket", labels, "le", formatFloat(bound), float64(cumulative))
+		}
+		writeSample(w, name+"_sum", labels, "", "", point.Sum)
+		writeSample(w, name+"_count", labels, "", "", float64(point.Count))
+	}
+}
+
+// PrometheusName returns the name metric is exposed under, such as http_server_request_duration_seconds
+// for http.server.request.duration in seconds.
+func PrometheusName(metric *entity.Metric) string {
+	name := sanitizeName(metric.Name)
+	if suffix, ok := unitSuffixes[metric.Unit]; ok && !strings.HasSuffix(name, "_"+suffix) {
+		name += "_" + suffix
+	}
+	if metric.Kind == entity.MetricCounter {
+		name += "_total"
+	}
+	return name
+}
+
+func prometheusType(kind entity.MetricKind) string {
+	switch kind {
+	case entity.MetricCounter:
+		return "counter"
+	case entity.MetricHistogram:
+		return "histogram"
+	default:
+		return "gauge"
+	}
+}
+
+// label is a label of a sample, with its name already sanitized.
+type label struct {
+	name  string
+	value string
+}
+
+// sortedLabels returns attributes as labels sorted by name, so samples are written the same way every time.
+func sortedLabels(attributes map[string]string) []label {
+	labels := make([]label, 0, len(attributes))
+	for key, value := range attributes {
+		labels = append(labels, label{name: sanitizeName(key), value: value})
+	}
+	slices.SortFunc(labels, func(a, b label) int { return strings.Compare(a.name, b.name) })
+	return labels
+}
+
+// writeSample writes a sample line of name with labels, and an extra label such as the le bound of a bucket
+// when extraName is set.
+func writeSample(w *bufio.Writer, name string, labels []label, extraName, extraValue string, value float64) {
+	w.WriteString(name)
+	if len(labels) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(l.name + `="` + escapeLabelValue(l.value) + `"`)
+		}
+		if extraName != "" {
+			if len(labels) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extraName + `="` + extraValue + `"`)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteString(" " + formatFloat(value) + "\n")
+}
+
+// sanitizeName replaces the characters that are not valid in Prometheus metric and label names with underscores.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// formatFloat formats value as Prometheus expects, with +Inf, -Inf and NaN spelled out.
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
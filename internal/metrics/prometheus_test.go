@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	snapshot := &entity.MetricsSnapshot{Metrics: []*entity.Metric{
+		{
+			Name:        "go.memory.used",
+			Description: "Memory used by the Go runtime.",
+			Unit:        "By",
+			Kind:        entity.MetricGauge,
+			Points:      []*entity.MetricPoint{{Value: 1.5e6}},
+		},
+		{
+			Name:        "http.server.requests",
+			Description: "Count of HTTP server requests.",
+			Unit:        "{request}",
+			Kind:        entity.MetricCounter,
+			Points: []*entity.MetricPoint{
+				{Attributes: map[string]string{"http.route": "/matrix/{operation}", "http.request.method": "GET"}, Value: 7},
+			},
+		},
+		{
+			Name:        "matrix.operation.duration",
+			Description: "Duration of matrix operations.",
+			Unit:        "s",
+			Kind:        entity.MetricHistogram,
+			Bounds:      []float64{0.1, 1},
+			Points: []*entity.MetricPoint{
+				{
+					Attributes:   map[string]string{"matrix.operation": "sum"},
+					Count:        4,
+					Sum:          3.25,
+					BucketCounts: []uint64{1, 2, 1},
+				},
+			},
+		},
+	}}
+
+	var b strings.Builder
+	assert.NoError(t, WritePrometheus(&b, snapshot))
+
+	assert.Equal(t, `# HELP go_memory_used_bytes Memory used by the Go runtime.
+# TYPE go_memory_used_bytes gauge
+go_memory_used_bytes 1.5e+06
+# HELP http_server_requests_total Count of HTTP server requests.
+# TYPE http_server_requests_total counter
+http_server_requests_total{http_request_method="GET",http_route="/matrix/{operation}"} 7
+# HELP matrix_operation_duration_seconds Duration of matrix operations.
+# TYPE matrix_operation_duration_seconds histogram
+matrix_operation_duration_seconds_bucket{matrix_operation="sum",le="0.1"} 1
+matrix_operation_duration_seconds_bucket{matrix_operation="sum",le="1"} 3
+matrix_operation_duration_seconds_bucket{matrix_operation="sum",le="+Inf"} 4
+matrix_operation_duration_seconds_sum{matrix_operation="sum"} 3.25
+matrix_operation_duration_seconds_count{matrix_operation="sum"} 4
+`, b.String())
+}
+
+func TestWritePrometheus_Escaping(t *testing.T) {
+	snapshot := &entity.MetricsSnapshot{Metrics: []*entity.Metric{{
+		Name:        "app.files",
+		Description: "Files read,\nby path \\ name.",
+		Kind:        entity.MetricGauge,
+		Points:      []*entity.MetricPoint{{Attributes: map[string]string{"file-path": "a \"b\"\\c\n"}, Value: 1}},
+	}}}
+
+	var b strings.Builder
+	assert.NoError(t, WritePrometheus(&b, snapshot))
+
+	assert.Equal(t, `# HELP app_files Files read,\nby path \\ name.
+# TYPE app_files gauge
+app_files{file_path="a \"b\"\\c\n"} 1
+`, b.String())
+}
+
+func TestPrometheusName(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric *entity.Metric
+		want   string
+	}{
+		{name: "seconds", metric: &entity.Metric{Name: "http.server.request.duration", Unit: "s", Kind: entity.MetricHistogram}, want: "http_server_request_duration_seconds"},
+		{name: "bytes", metric: &entity.Metric{Name: "go.memory.used", Unit: "By", Kind: entity.MetricGauge}, want: "go_memory_used_bytes"},
+		{name: "ratio", metric: &entity.Metric{Name: "matrix.memo.hit_ratio", Unit: "1", Kind: entity.MetricGauge}, want: "matrix_memo_hit_ratio"},
+		{name: "annotation", metric: &entity.Metric{Name: "go.goroutine.count", Unit: "{goroutine}", Kind: entity.MetricGauge}, want: "go_goroutine_count"},
+		{name: "counter", metric: &entity.Metric{Name: "matrix.memo.lookups", Unit: "{lookup}", Kind: entity.MetricCounter}, want: "matrix_memo_lookups_total"},
+		{name: "invalid characters", metric: &entity.Metric{Name: "app.queue-size/jobs", Kind: entity.MetricGauge}, want: "app_queue_size_jobs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PrometheusName(tt.metric))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package middleware holds the HTTP middleware wrapping every route of the server, which runs before
+// the handlers of the routes and sees every request, whatever its outcome.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/matsuboshi/league-matrix-app/internal/logging"
+)
+
+// RequestIDHeader is the header request IDs are read from and returned in.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID wraps next so every request carries an ID: the X-Request-ID header sent by the client, or a new random
+// ID when it is missing or unusable. The ID is set on the request context, where logging.Handler adds it to every
+// record logged with the context, from the handlers down to the repositories, and returned in the X-Request-ID
+// response header, so users can report issues with a reference to the request that is found in the logs.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := logging.FromContext(r.Context())
+		scope.RequestID = newRequestID(r)
+
+		w.Header().Set(RequestIDHeader, scope.RequestID)
+		next.ServeHTTP(w, r.WithContext(logging.NewContext(r.Context(), scope)))
+	})
+}
+
+// RequestIDFrom returns the ID RequestID gave the request, or the ID it would give the request
+// when the request did not go through it.
+func RequestIDFrom(r *http.Request) string {
+	if id := logging.FromContext(r.Context()).RequestID; id != "" {
+		return id
+	}
+	return newRequestID(r)
+}
+
+// newRequestID returns the client-supplied X-Request-ID header, or a new random ID when it is missing or unusable.
+func newRequestID(r *http.Request) string {
+	return logging.NewRequestID(r.Header.Get(RequestIDHeader))
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/logging"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		want      string
+	}{
+		{name: "client request ID", requestID: "job-42", want: "job-42"},
+		{name: "no request ID"},
+		{name: "unusable request ID", requestID: "job 42"},
+		{name: "request ID too long", requestID: strings.Repeat("a", logging.MaxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = logging.FromContext(r.Context()).RequestID
+				assert.Equal(t, got, RequestIDFrom(r))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			w := httptest.NewRecorder()
+			RequestID(next).ServeHTTP(w, req)
+
+			if tt.want != "" {
+				assert.Equal(t, tt.want, got)
+			} else {
+				// A random ID is generated instead
+				assert.Regexp(t, "^[0-9a-f]{32}$", got)
+			}
+			assert.Equal(t, got, w.Header().Get(RequestIDHeader))
+		})
+	}
+}
+
+func TestRequestID_Logging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(logging.NewHandler(slog.NewTextHandler(&buf, nil)))
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		logger.InfoContext(r.Context(), "file read")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+	req.Header.Set(RequestIDHeader, "job-42")
+	RequestID(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, buf.String(), "request_id=job-42")
+}
+
+func TestRequestIDFrom(t *testing.T) {
+	// Requests that did not go through RequestID get the ID it would give them
+	req := httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+	req.Header.Set(RequestIDHeader, "job-42")
+	assert.Equal(t, "job-42", RequestIDFrom(req))
+
+	req = httptest.NewRequest(http.MethodGet, "/matrix/sum", nil)
+	assert.Regexp(t, "^[0-9a-f]{32}$", RequestIDFrom(req))
+}
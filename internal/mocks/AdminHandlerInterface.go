@@ -0,0 +1,91 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAdminHandlerInterface creates a new instance of MockAdminHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAdminHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAdminHandlerInterface {
+	mock := &MockAdminHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAdminHandlerInterface is an autogenerated mock type for the AdminHandlerInterface type
+type MockAdminHandlerInterface struct {
+	mock.Mock
+}
+
+type MockAdminHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAdminHandlerInterface) EXPECT() *MockAdminHandlerInterface_Expecter {
+	return &MockAdminHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// RequireAdmin provides a mock function for the type MockAdminHandlerInterface
+func (_mock *MockAdminHandlerInterface) RequireAdmin(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireAdmin")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockAdminHandlerInterface_RequireAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequireAdmin'
+type MockAdminHandlerInterface_RequireAdmin_Call struct {
+	*mock.Call
+}
+
+// RequireAdmin is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockAdminHandlerInterface_Expecter) RequireAdmin(next interface{}) *MockAdminHandlerInterface_RequireAdmin_Call {
+	return &MockAdminHandlerInterface_RequireAdmin_Call{Call: _e.mock.On("RequireAdmin", next)}
+}
+
+func (_c *MockAdminHandlerInterface_RequireAdmin_Call) Run(run func(next http.Handler)) *MockAdminHandlerInterface_RequireAdmin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminHandlerInterface_RequireAdmin_Call) Return(handler http.Handler) *MockAdminHandlerInterface_RequireAdmin_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockAdminHandlerInterface_RequireAdmin_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockAdminHandlerInterface_RequireAdmin_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,187 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAggregateDomainInterface creates a new instance of MockAggregateDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAggregateDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAggregateDomainInterface {
+	mock := &MockAggregateDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAggregateDomainInterface is an autogenerated mock type for the AggregateDomainInterface type
+type MockAggregateDomainInterface struct {
+	mock.Mock
+}
+
+type MockAggregateDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAggregateDomainInterface) EXPECT() *MockAggregateDomainInterface_Expecter {
+	return &MockAggregateDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// AggregateDirectory provides a mock function for the type MockAggregateDomainInterface
+func (_mock *MockAggregateDomainInterface) AggregateDirectory(ctx context.Context, operation string, dir string) (*entity.Aggregate, error) {
+	ret := _mock.Called(ctx, operation, dir)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateDirectory")
+	}
+
+	var r0 *entity.Aggregate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Aggregate, error)); ok {
+		return returnFunc(ctx, operation, dir)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entity.Aggregate); ok {
+		r0 = returnFunc(ctx, operation, dir)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Aggregate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, operation, dir)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAggregateDomainInterface_AggregateDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateDirectory'
+type MockAggregateDomainInterface_AggregateDirectory_Call struct {
+	*mock.Call
+}
+
+// AggregateDirectory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - dir string
+func (_e *MockAggregateDomainInterface_Expecter) AggregateDirectory(ctx interface{}, operation interface{}, dir interface{}) *MockAggregateDomainInterface_AggregateDirectory_Call {
+	return &MockAggregateDomainInterface_AggregateDirectory_Call{Call: _e.mock.On("AggregateDirectory", ctx, operation, dir)}
+}
+
+func (_c *MockAggregateDomainInterface_AggregateDirectory_Call) Run(run func(ctx context.Context, operation string, dir string)) *MockAggregateDomainInterface_AggregateDirectory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAggregateDomainInterface_AggregateDirectory_Call) Return(aggregate *entity.Aggregate, err error) *MockAggregateDomainInterface_AggregateDirectory_Call {
+	_c.Call.Return(aggregate, err)
+	return _c
+}
+
+func (_c *MockAggregateDomainInterface_AggregateDirectory_Call) RunAndReturn(run func(ctx context.Context, operation string, dir string) (*entity.Aggregate, error)) *MockAggregateDomainInterface_AggregateDirectory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AggregateFiles provides a mock function for the type MockAggregateDomainInterface
+func (_mock *MockAggregateDomainInterface) AggregateFiles(ctx context.Context, operation string, files []string) (*entity.Aggregate, error) {
+	ret := _mock.Called(ctx, operation, files)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateFiles")
+	}
+
+	var r0 *entity.Aggregate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (*entity.Aggregate, error)); ok {
+		return returnFunc(ctx, operation, files)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) *entity.Aggregate); ok {
+		r0 = returnFunc(ctx, operation, files)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Aggregate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, operation, files)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAggregateDomainInterface_AggregateFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateFiles'
+type MockAggregateDomainInterface_AggregateFiles_Call struct {
+	*mock.Call
+}
+
+// AggregateFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - files []string
+func (_e *MockAggregateDomainInterface_Expecter) AggregateFiles(ctx interface{}, operation interface{}, files interface{}) *MockAggregateDomainInterface_AggregateFiles_Call {
+	return &MockAggregateDomainInterface_AggregateFiles_Call{Call: _e.mock.On("AggregateFiles", ctx, operation, files)}
+}
+
+func (_c *MockAggregateDomainInterface_AggregateFiles_Call) Run(run func(ctx context.Context, operation string, files []string)) *MockAggregateDomainInterface_AggregateFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAggregateDomainInterface_AggregateFiles_Call) Return(aggregate *entity.Aggregate, err error) *MockAggregateDomainInterface_AggregateFiles_Call {
+	_c.Call.Return(aggregate, err)
+	return _c
+}
+
+func (_c *MockAggregateDomainInterface_AggregateFiles_Call) RunAndReturn(run func(ctx context.Context, operation string, files []string) (*entity.Aggregate, error)) *MockAggregateDomainInterface_AggregateFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAggregateHandlerInterface creates a new instance of MockAggregateHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAggregateHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAggregateHandlerInterface {
+	mock := &MockAggregateHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAggregateHandlerInterface is an autogenerated mock type for the AggregateHandlerInterface type
+type MockAggregateHandlerInterface struct {
+	mock.Mock
+}
+
+type MockAggregateHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAggregateHandlerInterface) EXPECT() *MockAggregateHandlerInterface_Expecter {
+	return &MockAggregateHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Aggregate provides a mock function for the type MockAggregateHandlerInterface
+func (_mock *MockAggregateHandlerInterface) Aggregate(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockAggregateHandlerInterface_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type MockAggregateHandlerInterface_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockAggregateHandlerInterface_Expecter) Aggregate(w interface{}, r interface{}) *MockAggregateHandlerInterface_Aggregate_Call {
+	return &MockAggregateHandlerInterface_Aggregate_Call{Call: _e.mock.On("Aggregate", w, r)}
+}
+
+func (_c *MockAggregateHandlerInterface_Aggregate_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockAggregateHandlerInterface_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAggregateHandlerInterface_Aggregate_Call) Return() *MockAggregateHandlerInterface_Aggregate_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAggregateHandlerInterface_Aggregate_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockAggregateHandlerInterface_Aggregate_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockArchiveRepositoryInterface creates a new instance of MockArchiveRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockArchiveRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockArchiveRepositoryInterface {
+	mock := &MockArchiveRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockArchiveRepositoryInterface is an autogenerated mock type for the ArchiveRepositoryInterface type
+type MockArchiveRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockArchiveRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockArchiveRepositoryInterface) EXPECT() *MockArchiveRepositoryInterface_Expecter {
+	return &MockArchiveRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// ReadArchive provides a mock function for the type MockArchiveRepositoryInterface
+func (_mock *MockArchiveRepositoryInterface) ReadArchive(ctx context.Context, data []byte) ([]*repository.ArchiveEntry, error) {
+	ret := _mock.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadArchive")
+	}
+
+	var r0 []*repository.ArchiveEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) ([]*repository.ArchiveEntry, error)); ok {
+		return returnFunc(ctx, data)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) []*repository.ArchiveEntry); ok {
+		r0 = returnFunc(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.ArchiveEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = returnFunc(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockArchiveRepositoryInterface_ReadArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadArchive'
+type MockArchiveRepositoryInterface_ReadArchive_Call struct {
+	*mock.Call
+}
+
+// ReadArchive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data []byte
+func (_e *MockArchiveRepositoryInterface_Expecter) ReadArchive(ctx interface{}, data interface{}) *MockArchiveRepositoryInterface_ReadArchive_Call {
+	return &MockArchiveRepositoryInterface_ReadArchive_Call{Call: _e.mock.On("ReadArchive", ctx, data)}
+}
+
+func (_c *MockArchiveRepositoryInterface_ReadArchive_Call) Run(run func(ctx context.Context, data []byte)) *MockArchiveRepositoryInterface_ReadArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArchiveRepositoryInterface_ReadArchive_Call) Return(archiveEntrys []*repository.ArchiveEntry, err error) *MockArchiveRepositoryInterface_ReadArchive_Call {
+	_c.Call.Return(archiveEntrys, err)
+	return _c
+}
+
+func (_c *MockArchiveRepositoryInterface_ReadArchive_Call) RunAndReturn(run func(ctx context.Context, data []byte) ([]*repository.ArchiveEntry, error)) *MockArchiveRepositoryInterface_ReadArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
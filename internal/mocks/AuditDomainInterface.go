@@ -0,0 +1,164 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAuditDomainInterface creates a new instance of MockAuditDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditDomainInterface {
+	mock := &MockAuditDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditDomainInterface is an autogenerated mock type for the AuditDomainInterface type
+type MockAuditDomainInterface struct {
+	mock.Mock
+}
+
+type MockAuditDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditDomainInterface) EXPECT() *MockAuditDomainInterface_Expecter {
+	return &MockAuditDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListEntries provides a mock function for the type MockAuditDomainInterface
+func (_mock *MockAuditDomainInterface) ListEntries(ctx context.Context, filter *entity.AuditFilter) ([]*entity.AuditEntry, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []*entity.AuditEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.AuditFilter) ([]*entity.AuditEntry, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.AuditFilter) []*entity.AuditEntry); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.AuditEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.AuditFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditDomainInterface_ListEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntries'
+type MockAuditDomainInterface_ListEntries_Call struct {
+	*mock.Call
+}
+
+// ListEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *entity.AuditFilter
+func (_e *MockAuditDomainInterface_Expecter) ListEntries(ctx interface{}, filter interface{}) *MockAuditDomainInterface_ListEntries_Call {
+	return &MockAuditDomainInterface_ListEntries_Call{Call: _e.mock.On("ListEntries", ctx, filter)}
+}
+
+func (_c *MockAuditDomainInterface_ListEntries_Call) Run(run func(ctx context.Context, filter *entity.AuditFilter)) *MockAuditDomainInterface_ListEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.AuditFilter
+		if args[1] != nil {
+			arg1 = args[1].(*entity.AuditFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditDomainInterface_ListEntries_Call) Return(auditEntrys []*entity.AuditEntry, err error) *MockAuditDomainInterface_ListEntries_Call {
+	_c.Call.Return(auditEntrys, err)
+	return _c
+}
+
+func (_c *MockAuditDomainInterface_ListEntries_Call) RunAndReturn(run func(ctx context.Context, filter *entity.AuditFilter) ([]*entity.AuditEntry, error)) *MockAuditDomainInterface_ListEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockAuditDomainInterface
+func (_mock *MockAuditDomainInterface) Record(ctx context.Context, entry *entity.AuditEntry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.AuditEntry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditDomainInterface_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockAuditDomainInterface_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *entity.AuditEntry
+func (_e *MockAuditDomainInterface_Expecter) Record(ctx interface{}, entry interface{}) *MockAuditDomainInterface_Record_Call {
+	return &MockAuditDomainInterface_Record_Call{Call: _e.mock.On("Record", ctx, entry)}
+}
+
+func (_c *MockAuditDomainInterface_Record_Call) Run(run func(ctx context.Context, entry *entity.AuditEntry)) *MockAuditDomainInterface_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.AuditEntry
+		if args[1] != nil {
+			arg1 = args[1].(*entity.AuditEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditDomainInterface_Record_Call) Return(err error) *MockAuditDomainInterface_Record_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditDomainInterface_Record_Call) RunAndReturn(run func(ctx context.Context, entry *entity.AuditEntry) error) *MockAuditDomainInterface_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
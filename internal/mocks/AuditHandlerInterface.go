@@ -0,0 +1,137 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAuditHandlerInterface creates a new instance of MockAuditHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditHandlerInterface {
+	mock := &MockAuditHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditHandlerInterface is an autogenerated mock type for the AuditHandlerInterface type
+type MockAuditHandlerInterface struct {
+	mock.Mock
+}
+
+type MockAuditHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditHandlerInterface) EXPECT() *MockAuditHandlerInterface_Expecter {
+	return &MockAuditHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Audit provides a mock function for the type MockAuditHandlerInterface
+func (_mock *MockAuditHandlerInterface) Audit(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Audit")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockAuditHandlerInterface_Audit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Audit'
+type MockAuditHandlerInterface_Audit_Call struct {
+	*mock.Call
+}
+
+// Audit is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockAuditHandlerInterface_Expecter) Audit(next interface{}) *MockAuditHandlerInterface_Audit_Call {
+	return &MockAuditHandlerInterface_Audit_Call{Call: _e.mock.On("Audit", next)}
+}
+
+func (_c *MockAuditHandlerInterface_Audit_Call) Run(run func(next http.Handler)) *MockAuditHandlerInterface_Audit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditHandlerInterface_Audit_Call) Return(handler http.Handler) *MockAuditHandlerInterface_Audit_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockAuditHandlerInterface_Audit_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockAuditHandlerInterface_Audit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditEntries provides a mock function for the type MockAuditHandlerInterface
+func (_mock *MockAuditHandlerInterface) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockAuditHandlerInterface_ListAuditEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEntries'
+type MockAuditHandlerInterface_ListAuditEntries_Call struct {
+	*mock.Call
+}
+
+// ListAuditEntries is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockAuditHandlerInterface_Expecter) ListAuditEntries(w interface{}, r interface{}) *MockAuditHandlerInterface_ListAuditEntries_Call {
+	return &MockAuditHandlerInterface_ListAuditEntries_Call{Call: _e.mock.On("ListAuditEntries", w, r)}
+}
+
+func (_c *MockAuditHandlerInterface_ListAuditEntries_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockAuditHandlerInterface_ListAuditEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditHandlerInterface_ListAuditEntries_Call) Return() *MockAuditHandlerInterface_ListAuditEntries_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuditHandlerInterface_ListAuditEntries_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockAuditHandlerInterface_ListAuditEntries_Call {
+	_c.Run(run)
+	return _c
+}
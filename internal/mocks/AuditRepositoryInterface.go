@@ -0,0 +1,164 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAuditRepositoryInterface creates a new instance of MockAuditRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditRepositoryInterface {
+	mock := &MockAuditRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditRepositoryInterface is an autogenerated mock type for the AuditRepositoryInterface type
+type MockAuditRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockAuditRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditRepositoryInterface) EXPECT() *MockAuditRepositoryInterface_Expecter {
+	return &MockAuditRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// AppendEntry provides a mock function for the type MockAuditRepositoryInterface
+func (_mock *MockAuditRepositoryInterface) AppendEntry(ctx context.Context, entry *repository.AuditRecord) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendEntry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.AuditRecord) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditRepositoryInterface_AppendEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendEntry'
+type MockAuditRepositoryInterface_AppendEntry_Call struct {
+	*mock.Call
+}
+
+// AppendEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *repository.AuditRecord
+func (_e *MockAuditRepositoryInterface_Expecter) AppendEntry(ctx interface{}, entry interface{}) *MockAuditRepositoryInterface_AppendEntry_Call {
+	return &MockAuditRepositoryInterface_AppendEntry_Call{Call: _e.mock.On("AppendEntry", ctx, entry)}
+}
+
+func (_c *MockAuditRepositoryInterface_AppendEntry_Call) Run(run func(ctx context.Context, entry *repository.AuditRecord)) *MockAuditRepositoryInterface_AppendEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *repository.AuditRecord
+		if args[1] != nil {
+			arg1 = args[1].(*repository.AuditRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditRepositoryInterface_AppendEntry_Call) Return(err error) *MockAuditRepositoryInterface_AppendEntry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditRepositoryInterface_AppendEntry_Call) RunAndReturn(run func(ctx context.Context, entry *repository.AuditRecord) error) *MockAuditRepositoryInterface_AppendEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEntries provides a mock function for the type MockAuditRepositoryInterface
+func (_mock *MockAuditRepositoryInterface) ListEntries(ctx context.Context, query *repository.AuditQuery) ([]*repository.AuditRecord, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []*repository.AuditRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.AuditQuery) ([]*repository.AuditRecord, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.AuditQuery) []*repository.AuditRecord); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.AuditRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *repository.AuditQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditRepositoryInterface_ListEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntries'
+type MockAuditRepositoryInterface_ListEntries_Call struct {
+	*mock.Call
+}
+
+// ListEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *repository.AuditQuery
+func (_e *MockAuditRepositoryInterface_Expecter) ListEntries(ctx interface{}, query interface{}) *MockAuditRepositoryInterface_ListEntries_Call {
+	return &MockAuditRepositoryInterface_ListEntries_Call{Call: _e.mock.On("ListEntries", ctx, query)}
+}
+
+func (_c *MockAuditRepositoryInterface_ListEntries_Call) Run(run func(ctx context.Context, query *repository.AuditQuery)) *MockAuditRepositoryInterface_ListEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *repository.AuditQuery
+		if args[1] != nil {
+			arg1 = args[1].(*repository.AuditQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditRepositoryInterface_ListEntries_Call) Return(auditRecords []*repository.AuditRecord, err error) *MockAuditRepositoryInterface_ListEntries_Call {
+	_c.Call.Return(auditRecords, err)
+	return _c
+}
+
+func (_c *MockAuditRepositoryInterface_ListEntries_Call) RunAndReturn(run func(ctx context.Context, query *repository.AuditQuery) ([]*repository.AuditRecord, error)) *MockAuditRepositoryInterface_ListEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,214 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBackpressureDomainInterface creates a new instance of MockBackpressureDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBackpressureDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBackpressureDomainInterface {
+	mock := &MockBackpressureDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBackpressureDomainInterface is an autogenerated mock type for the BackpressureDomainInterface type
+type MockBackpressureDomainInterface struct {
+	mock.Mock
+}
+
+type MockBackpressureDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBackpressureDomainInterface) EXPECT() *MockBackpressureDomainInterface_Expecter {
+	return &MockBackpressureDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// AdmitJob provides a mock function for the type MockBackpressureDomainInterface
+func (_mock *MockBackpressureDomainInterface) AdmitJob(ctx context.Context) (time.Duration, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdmitJob")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (time.Duration, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) time.Duration); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBackpressureDomainInterface_AdmitJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdmitJob'
+type MockBackpressureDomainInterface_AdmitJob_Call struct {
+	*mock.Call
+}
+
+// AdmitJob is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBackpressureDomainInterface_Expecter) AdmitJob(ctx interface{}) *MockBackpressureDomainInterface_AdmitJob_Call {
+	return &MockBackpressureDomainInterface_AdmitJob_Call{Call: _e.mock.On("AdmitJob", ctx)}
+}
+
+func (_c *MockBackpressureDomainInterface_AdmitJob_Call) Run(run func(ctx context.Context)) *MockBackpressureDomainInterface_AdmitJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBackpressureDomainInterface_AdmitJob_Call) Return(retryAfter time.Duration, err error) *MockBackpressureDomainInterface_AdmitJob_Call {
+	_c.Call.Return(retryAfter, err)
+	return _c
+}
+
+func (_c *MockBackpressureDomainInterface_AdmitJob_Call) RunAndReturn(run func(ctx context.Context) (time.Duration, error)) *MockBackpressureDomainInterface_AdmitJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdmitOperation provides a mock function for the type MockBackpressureDomainInterface
+func (_mock *MockBackpressureDomainInterface) AdmitOperation(ctx context.Context) (func(), time.Duration, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdmitOperation")
+	}
+
+	var r0 func()
+	var r1 time.Duration
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (func(), time.Duration, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) func()); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) time.Duration); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = returnFunc(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockBackpressureDomainInterface_AdmitOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdmitOperation'
+type MockBackpressureDomainInterface_AdmitOperation_Call struct {
+	*mock.Call
+}
+
+// AdmitOperation is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBackpressureDomainInterface_Expecter) AdmitOperation(ctx interface{}) *MockBackpressureDomainInterface_AdmitOperation_Call {
+	return &MockBackpressureDomainInterface_AdmitOperation_Call{Call: _e.mock.On("AdmitOperation", ctx)}
+}
+
+func (_c *MockBackpressureDomainInterface_AdmitOperation_Call) Run(run func(ctx context.Context)) *MockBackpressureDomainInterface_AdmitOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBackpressureDomainInterface_AdmitOperation_Call) Return(release func(), retryAfter time.Duration, err error) *MockBackpressureDomainInterface_AdmitOperation_Call {
+	_c.Call.Return(release, retryAfter, err)
+	return _c
+}
+
+func (_c *MockBackpressureDomainInterface_AdmitOperation_Call) RunAndReturn(run func(ctx context.Context) (func(), time.Duration, error)) *MockBackpressureDomainInterface_AdmitOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Metrics provides a mock function for the type MockBackpressureDomainInterface
+func (_mock *MockBackpressureDomainInterface) Metrics() []*entity.Metric {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Metrics")
+	}
+
+	var r0 []*entity.Metric
+	if returnFunc, ok := ret.Get(0).(func() []*entity.Metric); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Metric)
+		}
+	}
+	return r0
+}
+
+// MockBackpressureDomainInterface_Metrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Metrics'
+type MockBackpressureDomainInterface_Metrics_Call struct {
+	*mock.Call
+}
+
+// Metrics is a helper method to define mock.On call
+func (_e *MockBackpressureDomainInterface_Expecter) Metrics() *MockBackpressureDomainInterface_Metrics_Call {
+	return &MockBackpressureDomainInterface_Metrics_Call{Call: _e.mock.On("Metrics")}
+}
+
+func (_c *MockBackpressureDomainInterface_Metrics_Call) Run(run func()) *MockBackpressureDomainInterface_Metrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBackpressureDomainInterface_Metrics_Call) Return(metrics []*entity.Metric) *MockBackpressureDomainInterface_Metrics_Call {
+	_c.Call.Return(metrics)
+	return _c
+}
+
+func (_c *MockBackpressureDomainInterface_Metrics_Call) RunAndReturn(run func() []*entity.Metric) *MockBackpressureDomainInterface_Metrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBackpressureHandlerInterface creates a new instance of MockBackpressureHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBackpressureHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBackpressureHandlerInterface {
+	mock := &MockBackpressureHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBackpressureHandlerInterface is an autogenerated mock type for the BackpressureHandlerInterface type
+type MockBackpressureHandlerInterface struct {
+	mock.Mock
+}
+
+type MockBackpressureHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBackpressureHandlerInterface) EXPECT() *MockBackpressureHandlerInterface_Expecter {
+	return &MockBackpressureHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ShedJobs provides a mock function for the type MockBackpressureHandlerInterface
+func (_mock *MockBackpressureHandlerInterface) ShedJobs(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShedJobs")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockBackpressureHandlerInterface_ShedJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShedJobs'
+type MockBackpressureHandlerInterface_ShedJobs_Call struct {
+	*mock.Call
+}
+
+// ShedJobs is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockBackpressureHandlerInterface_Expecter) ShedJobs(next interface{}) *MockBackpressureHandlerInterface_ShedJobs_Call {
+	return &MockBackpressureHandlerInterface_ShedJobs_Call{Call: _e.mock.On("ShedJobs", next)}
+}
+
+func (_c *MockBackpressureHandlerInterface_ShedJobs_Call) Run(run func(next http.Handler)) *MockBackpressureHandlerInterface_ShedJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBackpressureHandlerInterface_ShedJobs_Call) Return(handler http.Handler) *MockBackpressureHandlerInterface_ShedJobs_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockBackpressureHandlerInterface_ShedJobs_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockBackpressureHandlerInterface_ShedJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ShedOperations provides a mock function for the type MockBackpressureHandlerInterface
+func (_mock *MockBackpressureHandlerInterface) ShedOperations(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShedOperations")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockBackpressureHandlerInterface_ShedOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShedOperations'
+type MockBackpressureHandlerInterface_ShedOperations_Call struct {
+	*mock.Call
+}
+
+// ShedOperations is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockBackpressureHandlerInterface_Expecter) ShedOperations(next interface{}) *MockBackpressureHandlerInterface_ShedOperations_Call {
+	return &MockBackpressureHandlerInterface_ShedOperations_Call{Call: _e.mock.On("ShedOperations", next)}
+}
+
+func (_c *MockBackpressureHandlerInterface_ShedOperations_Call) Run(run func(next http.Handler)) *MockBackpressureHandlerInterface_ShedOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBackpressureHandlerInterface_ShedOperations_Call) Return(handler http.Handler) *MockBackpressureHandlerInterface_ShedOperations_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockBackpressureHandlerInterface_ShedOperations_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockBackpressureHandlerInterface_ShedOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBatchDomainInterface creates a new instance of MockBatchDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBatchDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBatchDomainInterface {
+	mock := &MockBatchDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBatchDomainInterface is an autogenerated mock type for the BatchDomainInterface type
+type MockBatchDomainInterface struct {
+	mock.Mock
+}
+
+type MockBatchDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBatchDomainInterface) EXPECT() *MockBatchDomainInterface_Expecter {
+	return &MockBatchDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// RunBatch provides a mock function for the type MockBatchDomainInterface
+func (_mock *MockBatchDomainInterface) RunBatch(ctx context.Context, items []entity.BatchItem) ([]*entity.BatchItemResult, error) {
+	ret := _mock.Called(ctx, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunBatch")
+	}
+
+	var r0 []*entity.BatchItemResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []entity.BatchItem) ([]*entity.BatchItemResult, error)); ok {
+		return returnFunc(ctx, items)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []entity.BatchItem) []*entity.BatchItemResult); ok {
+		r0 = returnFunc(ctx, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BatchItemResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []entity.BatchItem) error); ok {
+		r1 = returnFunc(ctx, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchDomainInterface_RunBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunBatch'
+type MockBatchDomainInterface_RunBatch_Call struct {
+	*mock.Call
+}
+
+// RunBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []entity.BatchItem
+func (_e *MockBatchDomainInterface_Expecter) RunBatch(ctx interface{}, items interface{}) *MockBatchDomainInterface_RunBatch_Call {
+	return &MockBatchDomainInterface_RunBatch_Call{Call: _e.mock.On("RunBatch", ctx, items)}
+}
+
+func (_c *MockBatchDomainInterface_RunBatch_Call) Run(run func(ctx context.Context, items []entity.BatchItem)) *MockBatchDomainInterface_RunBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []entity.BatchItem
+		if args[1] != nil {
+			arg1 = args[1].([]entity.BatchItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchDomainInterface_RunBatch_Call) Return(batchItemResults []*entity.BatchItemResult, err error) *MockBatchDomainInterface_RunBatch_Call {
+	_c.Call.Return(batchItemResults, err)
+	return _c
+}
+
+func (_c *MockBatchDomainInterface_RunBatch_Call) RunAndReturn(run func(ctx context.Context, items []entity.BatchItem) ([]*entity.BatchItemResult, error)) *MockBatchDomainInterface_RunBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBatchHandlerInterface creates a new instance of MockBatchHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBatchHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBatchHandlerInterface {
+	mock := &MockBatchHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBatchHandlerInterface is an autogenerated mock type for the BatchHandlerInterface type
+type MockBatchHandlerInterface struct {
+	mock.Mock
+}
+
+type MockBatchHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBatchHandlerInterface) EXPECT() *MockBatchHandlerInterface_Expecter {
+	return &MockBatchHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ProcessBatch provides a mock function for the type MockBatchHandlerInterface
+func (_mock *MockBatchHandlerInterface) ProcessBatch(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockBatchHandlerInterface_ProcessBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBatch'
+type MockBatchHandlerInterface_ProcessBatch_Call struct {
+	*mock.Call
+}
+
+// ProcessBatch is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockBatchHandlerInterface_Expecter) ProcessBatch(w interface{}, r interface{}) *MockBatchHandlerInterface_ProcessBatch_Call {
+	return &MockBatchHandlerInterface_ProcessBatch_Call{Call: _e.mock.On("ProcessBatch", w, r)}
+}
+
+func (_c *MockBatchHandlerInterface_ProcessBatch_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockBatchHandlerInterface_ProcessBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchHandlerInterface_ProcessBatch_Call) Return() *MockBatchHandlerInterface_ProcessBatch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBatchHandlerInterface_ProcessBatch_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockBatchHandlerInterface_ProcessBatch_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCSRFHandlerInterface creates a new instance of MockCSRFHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCSRFHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCSRFHandlerInterface {
+	mock := &MockCSRFHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCSRFHandlerInterface is an autogenerated mock type for the CSRFHandlerInterface type
+type MockCSRFHandlerInterface struct {
+	mock.Mock
+}
+
+type MockCSRFHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCSRFHandlerInterface) EXPECT() *MockCSRFHandlerInterface_Expecter {
+	return &MockCSRFHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Protect provides a mock function for the type MockCSRFHandlerInterface
+func (_mock *MockCSRFHandlerInterface) Protect(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Protect")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockCSRFHandlerInterface_Protect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Protect'
+type MockCSRFHandlerInterface_Protect_Call struct {
+	*mock.Call
+}
+
+// Protect is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockCSRFHandlerInterface_Expecter) Protect(next interface{}) *MockCSRFHandlerInterface_Protect_Call {
+	return &MockCSRFHandlerInterface_Protect_Call{Call: _e.mock.On("Protect", next)}
+}
+
+func (_c *MockCSRFHandlerInterface_Protect_Call) Run(run func(next http.Handler)) *MockCSRFHandlerInterface_Protect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCSRFHandlerInterface_Protect_Call) Return(handler http.Handler) *MockCSRFHandlerInterface_Protect_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockCSRFHandlerInterface_Protect_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockCSRFHandlerInterface_Protect_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCacheHandlerInterface creates a new instance of MockCacheHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCacheHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCacheHandlerInterface {
+	mock := &MockCacheHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCacheHandlerInterface is an autogenerated mock type for the CacheHandlerInterface type
+type MockCacheHandlerInterface struct {
+	mock.Mock
+}
+
+type MockCacheHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCacheHandlerInterface) EXPECT() *MockCacheHandlerInterface_Expecter {
+	return &MockCacheHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// FlushCache provides a mock function for the type MockCacheHandlerInterface
+func (_mock *MockCacheHandlerInterface) FlushCache(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockCacheHandlerInterface_FlushCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushCache'
+type MockCacheHandlerInterface_FlushCache_Call struct {
+	*mock.Call
+}
+
+// FlushCache is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockCacheHandlerInterface_Expecter) FlushCache(w interface{}, r interface{}) *MockCacheHandlerInterface_FlushCache_Call {
+	return &MockCacheHandlerInterface_FlushCache_Call{Call: _e.mock.On("FlushCache", w, r)}
+}
+
+func (_c *MockCacheHandlerInterface_FlushCache_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockCacheHandlerInterface_FlushCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCacheHandlerInterface_FlushCache_Call) Return() *MockCacheHandlerInterface_FlushCache_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCacheHandlerInterface_FlushCache_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockCacheHandlerInterface_FlushCache_Call {
+	_c.Run(run)
+	return _c
+}
+
+// InvalidateCache provides a mock function for the type MockCacheHandlerInterface
+func (_mock *MockCacheHandlerInterface) InvalidateCache(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockCacheHandlerInterface_InvalidateCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateCache'
+type MockCacheHandlerInterface_InvalidateCache_Call struct {
+	*mock.Call
+}
+
+// InvalidateCache is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockCacheHandlerInterface_Expecter) InvalidateCache(w interface{}, r interface{}) *MockCacheHandlerInterface_InvalidateCache_Call {
+	return &MockCacheHandlerInterface_InvalidateCache_Call{Call: _e.mock.On("InvalidateCache", w, r)}
+}
+
+func (_c *MockCacheHandlerInterface_InvalidateCache_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockCacheHandlerInterface_InvalidateCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCacheHandlerInterface_InvalidateCache_Call) Return() *MockCacheHandlerInterface_InvalidateCache_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCacheHandlerInterface_InvalidateCache_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockCacheHandlerInterface_InvalidateCache_Call {
+	_c.Run(run)
+	return _c
+}
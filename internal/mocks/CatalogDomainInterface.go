@@ -0,0 +1,170 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCatalogDomainInterface creates a new instance of MockCatalogDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalogDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalogDomainInterface {
+	mock := &MockCatalogDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCatalogDomainInterface is an autogenerated mock type for the CatalogDomainInterface type
+type MockCatalogDomainInterface struct {
+	mock.Mock
+}
+
+type MockCatalogDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalogDomainInterface) EXPECT() *MockCatalogDomainInterface_Expecter {
+	return &MockCatalogDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function for the type MockCatalogDomainInterface
+func (_mock *MockCatalogDomainInterface) Export(ctx context.Context, filter *entity.MatrixFilter, handleFile func(matrix *entity.StoredMatrix, content []byte) error) error {
+	ret := _mock.Called(ctx, filter, handleFile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.MatrixFilter, func(matrix *entity.StoredMatrix, content []byte) error) error); ok {
+		r0 = returnFunc(ctx, filter, handleFile)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCatalogDomainInterface_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockCatalogDomainInterface_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *entity.MatrixFilter
+//   - handleFile func(matrix *entity.StoredMatrix, content []byte) error
+func (_e *MockCatalogDomainInterface_Expecter) Export(ctx interface{}, filter interface{}, handleFile interface{}) *MockCatalogDomainInterface_Export_Call {
+	return &MockCatalogDomainInterface_Export_Call{Call: _e.mock.On("Export", ctx, filter, handleFile)}
+}
+
+func (_c *MockCatalogDomainInterface_Export_Call) Run(run func(ctx context.Context, filter *entity.MatrixFilter, handleFile func(matrix *entity.StoredMatrix, content []byte) error)) *MockCatalogDomainInterface_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.MatrixFilter
+		if args[1] != nil {
+			arg1 = args[1].(*entity.MatrixFilter)
+		}
+		var arg2 func(matrix *entity.StoredMatrix, content []byte) error
+		if args[2] != nil {
+			arg2 = args[2].(func(matrix *entity.StoredMatrix, content []byte) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalogDomainInterface_Export_Call) Return(err error) *MockCatalogDomainInterface_Export_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCatalogDomainInterface_Export_Call) RunAndReturn(run func(ctx context.Context, filter *entity.MatrixFilter, handleFile func(matrix *entity.StoredMatrix, content []byte) error) error) *MockCatalogDomainInterface_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type MockCatalogDomainInterface
+func (_mock *MockCatalogDomainInterface) Search(ctx context.Context, filter *entity.MatrixFilter) (*entity.MatrixPage, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *entity.MatrixPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.MatrixFilter) (*entity.MatrixPage, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.MatrixFilter) *entity.MatrixPage); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.MatrixPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.MatrixFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalogDomainInterface_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockCatalogDomainInterface_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *entity.MatrixFilter
+func (_e *MockCatalogDomainInterface_Expecter) Search(ctx interface{}, filter interface{}) *MockCatalogDomainInterface_Search_Call {
+	return &MockCatalogDomainInterface_Search_Call{Call: _e.mock.On("Search", ctx, filter)}
+}
+
+func (_c *MockCatalogDomainInterface_Search_Call) Run(run func(ctx context.Context, filter *entity.MatrixFilter)) *MockCatalogDomainInterface_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.MatrixFilter
+		if args[1] != nil {
+			arg1 = args[1].(*entity.MatrixFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalogDomainInterface_Search_Call) Return(matrixPage *entity.MatrixPage, err error) *MockCatalogDomainInterface_Search_Call {
+	_c.Call.Return(matrixPage, err)
+	return _c
+}
+
+func (_c *MockCatalogDomainInterface_Search_Call) RunAndReturn(run func(ctx context.Context, filter *entity.MatrixFilter) (*entity.MatrixPage, error)) *MockCatalogDomainInterface_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCatalogHandlerInterface creates a new instance of MockCatalogHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalogHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalogHandlerInterface {
+	mock := &MockCatalogHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCatalogHandlerInterface is an autogenerated mock type for the CatalogHandlerInterface type
+type MockCatalogHandlerInterface struct {
+	mock.Mock
+}
+
+type MockCatalogHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalogHandlerInterface) EXPECT() *MockCatalogHandlerInterface_Expecter {
+	return &MockCatalogHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ExportMatrices provides a mock function for the type MockCatalogHandlerInterface
+func (_mock *MockCatalogHandlerInterface) ExportMatrices(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockCatalogHandlerInterface_ExportMatrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportMatrices'
+type MockCatalogHandlerInterface_ExportMatrices_Call struct {
+	*mock.Call
+}
+
+// ExportMatrices is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockCatalogHandlerInterface_Expecter) ExportMatrices(w interface{}, r interface{}) *MockCatalogHandlerInterface_ExportMatrices_Call {
+	return &MockCatalogHandlerInterface_ExportMatrices_Call{Call: _e.mock.On("ExportMatrices", w, r)}
+}
+
+func (_c *MockCatalogHandlerInterface_ExportMatrices_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockCatalogHandlerInterface_ExportMatrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalogHandlerInterface_ExportMatrices_Call) Return() *MockCatalogHandlerInterface_ExportMatrices_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCatalogHandlerInterface_ExportMatrices_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockCatalogHandlerInterface_ExportMatrices_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SearchMatrices provides a mock function for the type MockCatalogHandlerInterface
+func (_mock *MockCatalogHandlerInterface) SearchMatrices(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockCatalogHandlerInterface_SearchMatrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchMatrices'
+type MockCatalogHandlerInterface_SearchMatrices_Call struct {
+	*mock.Call
+}
+
+// SearchMatrices is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockCatalogHandlerInterface_Expecter) SearchMatrices(w interface{}, r interface{}) *MockCatalogHandlerInterface_SearchMatrices_Call {
+	return &MockCatalogHandlerInterface_SearchMatrices_Call{Call: _e.mock.On("SearchMatrices", w, r)}
+}
+
+func (_c *MockCatalogHandlerInterface_SearchMatrices_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockCatalogHandlerInterface_SearchMatrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalogHandlerInterface_SearchMatrices_Call) Return() *MockCatalogHandlerInterface_SearchMatrices_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCatalogHandlerInterface_SearchMatrices_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockCatalogHandlerInterface_SearchMatrices_Call {
+	_c.Run(run)
+	return _c
+}
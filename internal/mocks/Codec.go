@@ -0,0 +1,255 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCodec creates a new instance of MockCodec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCodec(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCodec {
+	mock := &MockCodec{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCodec is an autogenerated mock type for the Codec type
+type MockCodec struct {
+	mock.Mock
+}
+
+type MockCodec_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCodec) EXPECT() *MockCodec_Expecter {
+	return &MockCodec_Expecter{mock: &_m.Mock}
+}
+
+// ContentType provides a mock function for the type MockCodec
+func (_mock *MockCodec) ContentType() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContentType")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockCodec_ContentType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContentType'
+type MockCodec_ContentType_Call struct {
+	*mock.Call
+}
+
+// ContentType is a helper method to define mock.On call
+func (_e *MockCodec_Expecter) ContentType() *MockCodec_ContentType_Call {
+	return &MockCodec_ContentType_Call{Call: _e.mock.On("ContentType")}
+}
+
+func (_c *MockCodec_ContentType_Call) Run(run func()) *MockCodec_ContentType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCodec_ContentType_Call) Return(s string) *MockCodec_ContentType_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockCodec_ContentType_Call) RunAndReturn(run func() string) *MockCodec_ContentType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DecodeMatrix provides a mock function for the type MockCodec
+func (_mock *MockCodec) DecodeMatrix(data []byte) (*entity.Matrix[int64], error) {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecodeMatrix")
+	}
+
+	var r0 *entity.Matrix[int64]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) (*entity.Matrix[int64], error)); ok {
+		return returnFunc(data)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) *entity.Matrix[int64]); ok {
+		r0 = returnFunc(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Matrix[int64])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCodec_DecodeMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecodeMatrix'
+type MockCodec_DecodeMatrix_Call struct {
+	*mock.Call
+}
+
+// DecodeMatrix is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockCodec_Expecter) DecodeMatrix(data interface{}) *MockCodec_DecodeMatrix_Call {
+	return &MockCodec_DecodeMatrix_Call{Call: _e.mock.On("DecodeMatrix", data)}
+}
+
+func (_c *MockCodec_DecodeMatrix_Call) Run(run func(data []byte)) *MockCodec_DecodeMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCodec_DecodeMatrix_Call) Return(v *entity.Matrix[int64], err error) *MockCodec_DecodeMatrix_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockCodec_DecodeMatrix_Call) RunAndReturn(run func(data []byte) (*entity.Matrix[int64], error)) *MockCodec_DecodeMatrix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EncodeResult provides a mock function for the type MockCodec
+func (_mock *MockCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	ret := _mock.Called(operation, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EncodeResult")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, *entity.Result) ([]byte, error)); ok {
+		return returnFunc(operation, result)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, *entity.Result) []byte); ok {
+		r0 = returnFunc(operation, result)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, *entity.Result) error); ok {
+		r1 = returnFunc(operation, result)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCodec_EncodeResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EncodeResult'
+type MockCodec_EncodeResult_Call struct {
+	*mock.Call
+}
+
+// EncodeResult is a helper method to define mock.On call
+//   - operation string
+//   - result *entity.Result
+func (_e *MockCodec_Expecter) EncodeResult(operation interface{}, result interface{}) *MockCodec_EncodeResult_Call {
+	return &MockCodec_EncodeResult_Call{Call: _e.mock.On("EncodeResult", operation, result)}
+}
+
+func (_c *MockCodec_EncodeResult_Call) Run(run func(operation string, result *entity.Result)) *MockCodec_EncodeResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *entity.Result
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Result)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCodec_EncodeResult_Call) Return(bytes []byte, err error) *MockCodec_EncodeResult_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockCodec_EncodeResult_Call) RunAndReturn(run func(operation string, result *entity.Result) ([]byte, error)) *MockCodec_EncodeResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileExtension provides a mock function for the type MockCodec
+func (_mock *MockCodec) FileExtension() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileExtension")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockCodec_FileExtension_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileExtension'
+type MockCodec_FileExtension_Call struct {
+	*mock.Call
+}
+
+// FileExtension is a helper method to define mock.On call
+func (_e *MockCodec_Expecter) FileExtension() *MockCodec_FileExtension_Call {
+	return &MockCodec_FileExtension_Call{Call: _e.mock.On("FileExtension")}
+}
+
+func (_c *MockCodec_FileExtension_Call) Run(run func()) *MockCodec_FileExtension_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCodec_FileExtension_Call) Return(s string) *MockCodec_FileExtension_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockCodec_FileExtension_Call) RunAndReturn(run func() string) *MockCodec_FileExtension_Call {
+	_c.Call.Return(run)
+	return _c
+}
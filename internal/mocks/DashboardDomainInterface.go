@@ -0,0 +1,101 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDashboardDomainInterface creates a new instance of MockDashboardDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDashboardDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDashboardDomainInterface {
+	mock := &MockDashboardDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDashboardDomainInterface is an autogenerated mock type for the DashboardDomainInterface type
+type MockDashboardDomainInterface struct {
+	mock.Mock
+}
+
+type MockDashboardDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDashboardDomainInterface) EXPECT() *MockDashboardDomainInterface_Expecter {
+	return &MockDashboardDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Summary provides a mock function for the type MockDashboardDomainInterface
+func (_mock *MockDashboardDomainInterface) Summary(ctx context.Context) (*entity.DashboardSummary, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Summary")
+	}
+
+	var r0 *entity.DashboardSummary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*entity.DashboardSummary, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *entity.DashboardSummary); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DashboardSummary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDashboardDomainInterface_Summary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Summary'
+type MockDashboardDomainInterface_Summary_Call struct {
+	*mock.Call
+}
+
+// Summary is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDashboardDomainInterface_Expecter) Summary(ctx interface{}) *MockDashboardDomainInterface_Summary_Call {
+	return &MockDashboardDomainInterface_Summary_Call{Call: _e.mock.On("Summary", ctx)}
+}
+
+func (_c *MockDashboardDomainInterface_Summary_Call) Run(run func(ctx context.Context)) *MockDashboardDomainInterface_Summary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDashboardDomainInterface_Summary_Call) Return(dashboardSummary *entity.DashboardSummary, err error) *MockDashboardDomainInterface_Summary_Call {
+	_c.Call.Return(dashboardSummary, err)
+	return _c
+}
+
+func (_c *MockDashboardDomainInterface_Summary_Call) RunAndReturn(run func(ctx context.Context) (*entity.DashboardSummary, error)) *MockDashboardDomainInterface_Summary_Call {
+	_c.Call.Return(run)
+	return _c
+}
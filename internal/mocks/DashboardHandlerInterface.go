@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDashboardHandlerInterface creates a new instance of MockDashboardHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDashboardHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDashboardHandlerInterface {
+	mock := &MockDashboardHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDashboardHandlerInterface is an autogenerated mock type for the DashboardHandlerInterface type
+type MockDashboardHandlerInterface struct {
+	mock.Mock
+}
+
+type MockDashboardHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDashboardHandlerInterface) EXPECT() *MockDashboardHandlerInterface_Expecter {
+	return &MockDashboardHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetSummary provides a mock function for the type MockDashboardHandlerInterface
+func (_mock *MockDashboardHandlerInterface) GetSummary(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockDashboardHandlerInterface_GetSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummary'
+type MockDashboardHandlerInterface_GetSummary_Call struct {
+	*mock.Call
+}
+
+// GetSummary is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockDashboardHandlerInterface_Expecter) GetSummary(w interface{}, r interface{}) *MockDashboardHandlerInterface_GetSummary_Call {
+	return &MockDashboardHandlerInterface_GetSummary_Call{Call: _e.mock.On("GetSummary", w, r)}
+}
+
+func (_c *MockDashboardHandlerInterface_GetSummary_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockDashboardHandlerInterface_GetSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDashboardHandlerInterface_GetSummary_Call) Return() *MockDashboardHandlerInterface_GetSummary_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDashboardHandlerInterface_GetSummary_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockDashboardHandlerInterface_GetSummary_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ServeAssets provides a mock function for the type MockDashboardHandlerInterface
+func (_mock *MockDashboardHandlerInterface) ServeAssets(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockDashboardHandlerInterface_ServeAssets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServeAssets'
+type MockDashboardHandlerInterface_ServeAssets_Call struct {
+	*mock.Call
+}
+
+// ServeAssets is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockDashboardHandlerInterface_Expecter) ServeAssets(w interface{}, r interface{}) *MockDashboardHandlerInterface_ServeAssets_Call {
+	return &MockDashboardHandlerInterface_ServeAssets_Call{Call: _e.mock.On("ServeAssets", w, r)}
+}
+
+func (_c *MockDashboardHandlerInterface_ServeAssets_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockDashboardHandlerInterface_ServeAssets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDashboardHandlerInterface_ServeAssets_Call) Return() *MockDashboardHandlerInterface_ServeAssets_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDashboardHandlerInterface_ServeAssets_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockDashboardHandlerInterface_ServeAssets_Call {
+	_c.Run(run)
+	return _c
+}
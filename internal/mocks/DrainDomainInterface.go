@@ -0,0 +1,206 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDrainDomainInterface creates a new instance of MockDrainDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDrainDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDrainDomainInterface {
+	mock := &MockDrainDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDrainDomainInterface is an autogenerated mock type for the DrainDomainInterface type
+type MockDrainDomainInterface struct {
+	mock.Mock
+}
+
+type MockDrainDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDrainDomainInterface) EXPECT() *MockDrainDomainInterface_Expecter {
+	return &MockDrainDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Draining provides a mock function for the type MockDrainDomainInterface
+func (_mock *MockDrainDomainInterface) Draining() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Draining")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockDrainDomainInterface_Draining_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Draining'
+type MockDrainDomainInterface_Draining_Call struct {
+	*mock.Call
+}
+
+// Draining is a helper method to define mock.On call
+func (_e *MockDrainDomainInterface_Expecter) Draining() *MockDrainDomainInterface_Draining_Call {
+	return &MockDrainDomainInterface_Draining_Call{Call: _e.mock.On("Draining")}
+}
+
+func (_c *MockDrainDomainInterface_Draining_Call) Run(run func()) *MockDrainDomainInterface_Draining_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_Draining_Call) Return(b bool) *MockDrainDomainInterface_Draining_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_Draining_Call) RunAndReturn(run func() bool) *MockDrainDomainInterface_Draining_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartDrain provides a mock function for the type MockDrainDomainInterface
+func (_mock *MockDrainDomainInterface) StartDrain() {
+	_mock.Called()
+	return
+}
+
+// MockDrainDomainInterface_StartDrain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartDrain'
+type MockDrainDomainInterface_StartDrain_Call struct {
+	*mock.Call
+}
+
+// StartDrain is a helper method to define mock.On call
+func (_e *MockDrainDomainInterface_Expecter) StartDrain() *MockDrainDomainInterface_StartDrain_Call {
+	return &MockDrainDomainInterface_StartDrain_Call{Call: _e.mock.On("StartDrain")}
+}
+
+func (_c *MockDrainDomainInterface_StartDrain_Call) Run(run func()) *MockDrainDomainInterface_StartDrain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_StartDrain_Call) Return() *MockDrainDomainInterface_StartDrain_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_StartDrain_Call) RunAndReturn(run func()) *MockDrainDomainInterface_StartDrain_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Status provides a mock function for the type MockDrainDomainInterface
+func (_mock *MockDrainDomainInterface) Status() *entity.DrainStatus {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 *entity.DrainStatus
+	if returnFunc, ok := ret.Get(0).(func() *entity.DrainStatus); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DrainStatus)
+		}
+	}
+	return r0
+}
+
+// MockDrainDomainInterface_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockDrainDomainInterface_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *MockDrainDomainInterface_Expecter) Status() *MockDrainDomainInterface_Status_Call {
+	return &MockDrainDomainInterface_Status_Call{Call: _e.mock.On("Status")}
+}
+
+func (_c *MockDrainDomainInterface_Status_Call) Run(run func()) *MockDrainDomainInterface_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_Status_Call) Return(drainStatus *entity.DrainStatus) *MockDrainDomainInterface_Status_Call {
+	_c.Call.Return(drainStatus)
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_Status_Call) RunAndReturn(run func() *entity.DrainStatus) *MockDrainDomainInterface_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TrackRequest provides a mock function for the type MockDrainDomainInterface
+func (_mock *MockDrainDomainInterface) TrackRequest() func() {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrackRequest")
+	}
+
+	var r0 func()
+	if returnFunc, ok := ret.Get(0).(func() func()); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+	return r0
+}
+
+// MockDrainDomainInterface_TrackRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrackRequest'
+type MockDrainDomainInterface_TrackRequest_Call struct {
+	*mock.Call
+}
+
+// TrackRequest is a helper method to define mock.On call
+func (_e *MockDrainDomainInterface_Expecter) TrackRequest() *MockDrainDomainInterface_TrackRequest_Call {
+	return &MockDrainDomainInterface_TrackRequest_Call{Call: _e.mock.On("TrackRequest")}
+}
+
+func (_c *MockDrainDomainInterface_TrackRequest_Call) Run(run func()) *MockDrainDomainInterface_TrackRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_TrackRequest_Call) Return(fn func()) *MockDrainDomainInterface_TrackRequest_Call {
+	_c.Call.Return(fn)
+	return _c
+}
+
+func (_c *MockDrainDomainInterface_TrackRequest_Call) RunAndReturn(run func() func()) *MockDrainDomainInterface_TrackRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDrainHandlerInterface creates a new instance of MockDrainHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDrainHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDrainHandlerInterface {
+	mock := &MockDrainHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDrainHandlerInterface is an autogenerated mock type for the DrainHandlerInterface type
+type MockDrainHandlerInterface struct {
+	mock.Mock
+}
+
+type MockDrainHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDrainHandlerInterface) EXPECT() *MockDrainHandlerInterface_Expecter {
+	return &MockDrainHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// DrainStatus provides a mock function for the type MockDrainHandlerInterface
+func (_mock *MockDrainHandlerInterface) DrainStatus(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockDrainHandlerInterface_DrainStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DrainStatus'
+type MockDrainHandlerInterface_DrainStatus_Call struct {
+	*mock.Call
+}
+
+// DrainStatus is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockDrainHandlerInterface_Expecter) DrainStatus(w interface{}, r interface{}) *MockDrainHandlerInterface_DrainStatus_Call {
+	return &MockDrainHandlerInterface_DrainStatus_Call{Call: _e.mock.On("DrainStatus", w, r)}
+}
+
+func (_c *MockDrainHandlerInterface_DrainStatus_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockDrainHandlerInterface_DrainStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDrainHandlerInterface_DrainStatus_Call) Return() *MockDrainHandlerInterface_DrainStatus_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDrainHandlerInterface_DrainStatus_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockDrainHandlerInterface_DrainStatus_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Track provides a mock function for the type MockDrainHandlerInterface
+func (_mock *MockDrainHandlerInterface) Track(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Track")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockDrainHandlerInterface_Track_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Track'
+type MockDrainHandlerInterface_Track_Call struct {
+	*mock.Call
+}
+
+// Track is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockDrainHandlerInterface_Expecter) Track(next interface{}) *MockDrainHandlerInterface_Track_Call {
+	return &MockDrainHandlerInterface_Track_Call{Call: _e.mock.On("Track", next)}
+}
+
+func (_c *MockDrainHandlerInterface_Track_Call) Run(run func(next http.Handler)) *MockDrainHandlerInterface_Track_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDrainHandlerInterface_Track_Call) Return(handler http.Handler) *MockDrainHandlerInterface_Track_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockDrainHandlerInterface_Track_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockDrainHandlerInterface_Track_Call {
+	_c.Call.Return(run)
+	return _c
+}
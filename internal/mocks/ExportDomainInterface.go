@@ -0,0 +1,102 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExportDomainInterface creates a new instance of MockExportDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportDomainInterface {
+	mock := &MockExportDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportDomainInterface is an autogenerated mock type for the ExportDomainInterface type
+type MockExportDomainInterface struct {
+	mock.Mock
+}
+
+type MockExportDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportDomainInterface) EXPECT() *MockExportDomainInterface_Expecter {
+	return &MockExportDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// ExportResult provides a mock function for the type MockExportDomainInterface
+func (_mock *MockExportDomainInterface) ExportResult(ctx context.Context, target string, result *entity.Result) error {
+	ret := _mock.Called(ctx, target, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportResult")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *entity.Result) error); ok {
+		r0 = returnFunc(ctx, target, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportDomainInterface_ExportResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportResult'
+type MockExportDomainInterface_ExportResult_Call struct {
+	*mock.Call
+}
+
+// ExportResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target string
+//   - result *entity.Result
+func (_e *MockExportDomainInterface_Expecter) ExportResult(ctx interface{}, target interface{}, result interface{}) *MockExportDomainInterface_ExportResult_Call {
+	return &MockExportDomainInterface_ExportResult_Call{Call: _e.mock.On("ExportResult", ctx, target, result)}
+}
+
+func (_c *MockExportDomainInterface_ExportResult_Call) Run(run func(ctx context.Context, target string, result *entity.Result)) *MockExportDomainInterface_ExportResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *entity.Result
+		if args[2] != nil {
+			arg2 = args[2].(*entity.Result)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportDomainInterface_ExportResult_Call) Return(err error) *MockExportDomainInterface_ExportResult_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportDomainInterface_ExportResult_Call) RunAndReturn(run func(ctx context.Context, target string, result *entity.Result) error) *MockExportDomainInterface_ExportResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
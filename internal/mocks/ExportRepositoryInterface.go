@@ -0,0 +1,102 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExportRepositoryInterface creates a new instance of MockExportRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportRepositoryInterface {
+	mock := &MockExportRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportRepositoryInterface is an autogenerated mock type for the ExportRepositoryInterface type
+type MockExportRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockExportRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportRepositoryInterface) EXPECT() *MockExportRepositoryInterface_Expecter {
+	return &MockExportRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// WriteFile provides a mock function for the type MockExportRepositoryInterface
+func (_mock *MockExportRepositoryInterface) WriteFile(ctx context.Context, filePath string, content *repository.MatrixFileContent) error {
+	ret := _mock.Called(ctx, filePath, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *repository.MatrixFileContent) error); ok {
+		r0 = returnFunc(ctx, filePath, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportRepositoryInterface_WriteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteFile'
+type MockExportRepositoryInterface_WriteFile_Call struct {
+	*mock.Call
+}
+
+// WriteFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - content *repository.MatrixFileContent
+func (_e *MockExportRepositoryInterface_Expecter) WriteFile(ctx interface{}, filePath interface{}, content interface{}) *MockExportRepositoryInterface_WriteFile_Call {
+	return &MockExportRepositoryInterface_WriteFile_Call{Call: _e.mock.On("WriteFile", ctx, filePath, content)}
+}
+
+func (_c *MockExportRepositoryInterface_WriteFile_Call) Run(run func(ctx context.Context, filePath string, content *repository.MatrixFileContent)) *MockExportRepositoryInterface_WriteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *repository.MatrixFileContent
+		if args[2] != nil {
+			arg2 = args[2].(*repository.MatrixFileContent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportRepositoryInterface_WriteFile_Call) Return(err error) *MockExportRepositoryInterface_WriteFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportRepositoryInterface_WriteFile_Call) RunAndReturn(run func(ctx context.Context, filePath string, content *repository.MatrixFileContent) error) *MockExportRepositoryInterface_WriteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
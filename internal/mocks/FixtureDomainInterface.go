@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockFixtureDomainInterface creates a new instance of MockFixtureDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFixtureDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFixtureDomainInterface {
+	mock := &MockFixtureDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFixtureDomainInterface is an autogenerated mock type for the FixtureDomainInterface type
+type MockFixtureDomainInterface struct {
+	mock.Mock
+}
+
+type MockFixtureDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFixtureDomainInterface) EXPECT() *MockFixtureDomainInterface_Expecter {
+	return &MockFixtureDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// GenerateFixtures provides a mock function for the type MockFixtureDomainInterface
+func (_mock *MockFixtureDomainInterface) GenerateFixtures(ctx context.Context, spec *entity.FixtureSpec) ([]*entity.Fixture, error) {
+	ret := _mock.Called(ctx, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateFixtures")
+	}
+
+	var r0 []*entity.Fixture
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.FixtureSpec) ([]*entity.Fixture, error)); ok {
+		return returnFunc(ctx, spec)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.FixtureSpec) []*entity.Fixture); ok {
+		r0 = returnFunc(ctx, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Fixture)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.FixtureSpec) error); ok {
+		r1 = returnFunc(ctx, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFixtureDomainInterface_GenerateFixtures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateFixtures'
+type MockFixtureDomainInterface_GenerateFixtures_Call struct {
+	*mock.Call
+}
+
+// GenerateFixtures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - spec *entity.FixtureSpec
+func (_e *MockFixtureDomainInterface_Expecter) GenerateFixtures(ctx interface{}, spec interface{}) *MockFixtureDomainInterface_GenerateFixtures_Call {
+	return &MockFixtureDomainInterface_GenerateFixtures_Call{Call: _e.mock.On("GenerateFixtures", ctx, spec)}
+}
+
+func (_c *MockFixtureDomainInterface_GenerateFixtures_Call) Run(run func(ctx context.Context, spec *entity.FixtureSpec)) *MockFixtureDomainInterface_GenerateFixtures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.FixtureSpec
+		if args[1] != nil {
+			arg1 = args[1].(*entity.FixtureSpec)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFixtureDomainInterface_GenerateFixtures_Call) Return(fixtures []*entity.Fixture, err error) *MockFixtureDomainInterface_GenerateFixtures_Call {
+	_c.Call.Return(fixtures, err)
+	return _c
+}
+
+func (_c *MockFixtureDomainInterface_GenerateFixtures_Call) RunAndReturn(run func(ctx context.Context, spec *entity.FixtureSpec) ([]*entity.Fixture, error)) *MockFixtureDomainInterface_GenerateFixtures_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,147 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHealthDomainInterface creates a new instance of MockHealthDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHealthDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHealthDomainInterface {
+	mock := &MockHealthDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHealthDomainInterface is an autogenerated mock type for the HealthDomainInterface type
+type MockHealthDomainInterface struct {
+	mock.Mock
+}
+
+type MockHealthDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHealthDomainInterface) EXPECT() *MockHealthDomainInterface_Expecter {
+	return &MockHealthDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function for the type MockHealthDomainInterface
+func (_mock *MockHealthDomainInterface) Check(ctx context.Context) (*entity.HealthReport, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 *entity.HealthReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*entity.HealthReport, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *entity.HealthReport); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.HealthReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHealthDomainInterface_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type MockHealthDomainInterface_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHealthDomainInterface_Expecter) Check(ctx interface{}) *MockHealthDomainInterface_Check_Call {
+	return &MockHealthDomainInterface_Check_Call{Call: _e.mock.On("Check", ctx)}
+}
+
+func (_c *MockHealthDomainInterface_Check_Call) Run(run func(ctx context.Context)) *MockHealthDomainInterface_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthDomainInterface_Check_Call) Return(healthReport *entity.HealthReport, err error) *MockHealthDomainInterface_Check_Call {
+	_c.Call.Return(healthReport, err)
+	return _c
+}
+
+func (_c *MockHealthDomainInterface_Check_Call) RunAndReturn(run func(ctx context.Context) (*entity.HealthReport, error)) *MockHealthDomainInterface_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function for the type MockHealthDomainInterface
+func (_mock *MockHealthDomainInterface) Register(name string, check func(ctx context.Context) error) {
+	_mock.Called(name, check)
+	return
+}
+
+// MockHealthDomainInterface_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockHealthDomainInterface_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - name string
+//   - check func(ctx context.Context) error
+func (_e *MockHealthDomainInterface_Expecter) Register(name interface{}, check interface{}) *MockHealthDomainInterface_Register_Call {
+	return &MockHealthDomainInterface_Register_Call{Call: _e.mock.On("Register", name, check)}
+}
+
+func (_c *MockHealthDomainInterface_Register_Call) Run(run func(name string, check func(ctx context.Context) error)) *MockHealthDomainInterface_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 func(ctx context.Context) error
+		if args[1] != nil {
+			arg1 = args[1].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthDomainInterface_Register_Call) Return() *MockHealthDomainInterface_Register_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHealthDomainInterface_Register_Call) RunAndReturn(run func(name string, check func(ctx context.Context) error)) *MockHealthDomainInterface_Register_Call {
+	_c.Run(run)
+	return _c
+}
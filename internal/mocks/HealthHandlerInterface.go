@@ -0,0 +1,84 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHealthHandlerInterface creates a new instance of MockHealthHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHealthHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHealthHandlerInterface {
+	mock := &MockHealthHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHealthHandlerInterface is an autogenerated mock type for the HealthHandlerInterface type
+type MockHealthHandlerInterface struct {
+	mock.Mock
+}
+
+type MockHealthHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHealthHandlerInterface) EXPECT() *MockHealthHandlerInterface_Expecter {
+	return &MockHealthHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// HealthCheck provides a mock function for the type MockHealthHandlerInterface
+func (_mock *MockHealthHandlerInterface) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockHealthHandlerInterface_HealthCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HealthCheck'
+type MockHealthHandlerInterface_HealthCheck_Call struct {
+	*mock.Call
+}
+
+// HealthCheck is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockHealthHandlerInterface_Expecter) HealthCheck(w interface{}, r interface{}) *MockHealthHandlerInterface_HealthCheck_Call {
+	return &MockHealthHandlerInterface_HealthCheck_Call{Call: _e.mock.On("HealthCheck", w, r)}
+}
+
+func (_c *MockHealthHandlerInterface_HealthCheck_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockHealthHandlerInterface_HealthCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthHandlerInterface_HealthCheck_Call) Return() *MockHealthHandlerInterface_HealthCheck_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHealthHandlerInterface_HealthCheck_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockHealthHandlerInterface_HealthCheck_Call {
+	_c.Run(run)
+	return _c
+}
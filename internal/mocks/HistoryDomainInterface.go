@@ -0,0 +1,164 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHistoryDomainInterface creates a new instance of MockHistoryDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHistoryDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHistoryDomainInterface {
+	mock := &MockHistoryDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHistoryDomainInterface is an autogenerated mock type for the HistoryDomainInterface type
+type MockHistoryDomainInterface struct {
+	mock.Mock
+}
+
+type MockHistoryDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHistoryDomainInterface) EXPECT() *MockHistoryDomainInterface_Expecter {
+	return &MockHistoryDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Recent provides a mock function for the type MockHistoryDomainInterface
+func (_mock *MockHistoryDomainInterface) Recent(ctx context.Context, filter *entity.HistoryFilter) ([]*entity.HistoryEntry, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recent")
+	}
+
+	var r0 []*entity.HistoryEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.HistoryFilter) ([]*entity.HistoryEntry, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.HistoryFilter) []*entity.HistoryEntry); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.HistoryEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.HistoryFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHistoryDomainInterface_Recent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recent'
+type MockHistoryDomainInterface_Recent_Call struct {
+	*mock.Call
+}
+
+// Recent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *entity.HistoryFilter
+func (_e *MockHistoryDomainInterface_Expecter) Recent(ctx interface{}, filter interface{}) *MockHistoryDomainInterface_Recent_Call {
+	return &MockHistoryDomainInterface_Recent_Call{Call: _e.mock.On("Recent", ctx, filter)}
+}
+
+func (_c *MockHistoryDomainInterface_Recent_Call) Run(run func(ctx context.Context, filter *entity.HistoryFilter)) *MockHistoryDomainInterface_Recent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.HistoryFilter
+		if args[1] != nil {
+			arg1 = args[1].(*entity.HistoryFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHistoryDomainInterface_Recent_Call) Return(historyEntrys []*entity.HistoryEntry, err error) *MockHistoryDomainInterface_Recent_Call {
+	_c.Call.Return(historyEntrys, err)
+	return _c
+}
+
+func (_c *MockHistoryDomainInterface_Recent_Call) RunAndReturn(run func(ctx context.Context, filter *entity.HistoryFilter) ([]*entity.HistoryEntry, error)) *MockHistoryDomainInterface_Recent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockHistoryDomainInterface
+func (_mock *MockHistoryDomainInterface) Record(ctx context.Context, entry *entity.HistoryEntry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.HistoryEntry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHistoryDomainInterface_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockHistoryDomainInterface_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *entity.HistoryEntry
+func (_e *MockHistoryDomainInterface_Expecter) Record(ctx interface{}, entry interface{}) *MockHistoryDomainInterface_Record_Call {
+	return &MockHistoryDomainInterface_Record_Call{Call: _e.mock.On("Record", ctx, entry)}
+}
+
+func (_c *MockHistoryDomainInterface_Record_Call) Run(run func(ctx context.Context, entry *entity.HistoryEntry)) *MockHistoryDomainInterface_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.HistoryEntry
+		if args[1] != nil {
+			arg1 = args[1].(*entity.HistoryEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHistoryDomainInterface_Record_Call) Return(err error) *MockHistoryDomainInterface_Record_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHistoryDomainInterface_Record_Call) RunAndReturn(run func(ctx context.Context, entry *entity.HistoryEntry) error) *MockHistoryDomainInterface_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHistoryHandlerInterface creates a new instance of MockHistoryHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHistoryHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHistoryHandlerInterface {
+	mock := &MockHistoryHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHistoryHandlerInterface is an autogenerated mock type for the HistoryHandlerInterface type
+type MockHistoryHandlerInterface struct {
+	mock.Mock
+}
+
+type MockHistoryHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHistoryHandlerInterface) EXPECT() *MockHistoryHandlerInterface_Expecter {
+	return &MockHistoryHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListRecentResults provides a mock function for the type MockHistoryHandlerInterface
+func (_mock *MockHistoryHandlerInterface) ListRecentResults(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockHistoryHandlerInterface_ListRecentResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecentResults'
+type MockHistoryHandlerInterface_ListRecentResults_Call struct {
+	*mock.Call
+}
+
+// ListRecentResults is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockHistoryHandlerInterface_Expecter) ListRecentResults(w interface{}, r interface{}) *MockHistoryHandlerInterface_ListRecentResults_Call {
+	return &MockHistoryHandlerInterface_ListRecentResults_Call{Call: _e.mock.On("ListRecentResults", w, r)}
+}
+
+func (_c *MockHistoryHandlerInterface_ListRecentResults_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockHistoryHandlerInterface_ListRecentResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHistoryHandlerInterface_ListRecentResults_Call) Return() *MockHistoryHandlerInterface_ListRecentResults_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHistoryHandlerInterface_ListRecentResults_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockHistoryHandlerInterface_ListRecentResults_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,409 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockJobDomainInterface creates a new instance of MockJobDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobDomainInterface {
+	mock := &MockJobDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobDomainInterface is an autogenerated mock type for the JobDomainInterface type
+type MockJobDomainInterface struct {
+	mock.Mock
+}
+
+type MockJobDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobDomainInterface) EXPECT() *MockJobDomainInterface_Expecter {
+	return &MockJobDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// CheckHealth provides a mock function for the type MockJobDomainInterface
+func (_mock *MockJobDomainInterface) CheckHealth(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobDomainInterface_CheckHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckHealth'
+type MockJobDomainInterface_CheckHealth_Call struct {
+	*mock.Call
+}
+
+// CheckHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobDomainInterface_Expecter) CheckHealth(ctx interface{}) *MockJobDomainInterface_CheckHealth_Call {
+	return &MockJobDomainInterface_CheckHealth_Call{Call: _e.mock.On("CheckHealth", ctx)}
+}
+
+func (_c *MockJobDomainInterface_CheckHealth_Call) Run(run func(ctx context.Context)) *MockJobDomainInterface_CheckHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobDomainInterface_CheckHealth_Call) Return(err error) *MockJobDomainInterface_CheckHealth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobDomainInterface_CheckHealth_Call) RunAndReturn(run func(ctx context.Context) error) *MockJobDomainInterface_CheckHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJob provides a mock function for the type MockJobDomainInterface
+func (_mock *MockJobDomainInterface) GetJob(ctx context.Context, id string) (*entity.Job, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *entity.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.Job, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.Job); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobDomainInterface_GetJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJob'
+type MockJobDomainInterface_GetJob_Call struct {
+	*mock.Call
+}
+
+// GetJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockJobDomainInterface_Expecter) GetJob(ctx interface{}, id interface{}) *MockJobDomainInterface_GetJob_Call {
+	return &MockJobDomainInterface_GetJob_Call{Call: _e.mock.On("GetJob", ctx, id)}
+}
+
+func (_c *MockJobDomainInterface_GetJob_Call) Run(run func(ctx context.Context, id string)) *MockJobDomainInterface_GetJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobDomainInterface_GetJob_Call) Return(job *entity.Job, err error) *MockJobDomainInterface_GetJob_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobDomainInterface_GetJob_Call) RunAndReturn(run func(ctx context.Context, id string) (*entity.Job, error)) *MockJobDomainInterface_GetJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecentJobs provides a mock function for the type MockJobDomainInterface
+func (_mock *MockJobDomainInterface) ListRecentJobs(ctx context.Context, limit int) ([]*entity.Job, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecentJobs")
+	}
+
+	var r0 []*entity.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]*entity.Job, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []*entity.Job); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobDomainInterface_ListRecentJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecentJobs'
+type MockJobDomainInterface_ListRecentJobs_Call struct {
+	*mock.Call
+}
+
+// ListRecentJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockJobDomainInterface_Expecter) ListRecentJobs(ctx interface{}, limit interface{}) *MockJobDomainInterface_ListRecentJobs_Call {
+	return &MockJobDomainInterface_ListRecentJobs_Call{Call: _e.mock.On("ListRecentJobs", ctx, limit)}
+}
+
+func (_c *MockJobDomainInterface_ListRecentJobs_Call) Run(run func(ctx context.Context, limit int)) *MockJobDomainInterface_ListRecentJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobDomainInterface_ListRecentJobs_Call) Return(jobs []*entity.Job, err error) *MockJobDomainInterface_ListRecentJobs_Call {
+	_c.Call.Return(jobs, err)
+	return _c
+}
+
+func (_c *MockJobDomainInterface_ListRecentJobs_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]*entity.Job, error)) *MockJobDomainInterface_ListRecentJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueueStatus provides a mock function for the type MockJobDomainInterface
+func (_mock *MockJobDomainInterface) QueueStatus() *entity.QueueStatus {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueueStatus")
+	}
+
+	var r0 *entity.QueueStatus
+	if returnFunc, ok := ret.Get(0).(func() *entity.QueueStatus); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.QueueStatus)
+		}
+	}
+	return r0
+}
+
+// MockJobDomainInterface_QueueStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueueStatus'
+type MockJobDomainInterface_QueueStatus_Call struct {
+	*mock.Call
+}
+
+// QueueStatus is a helper method to define mock.On call
+func (_e *MockJobDomainInterface_Expecter) QueueStatus() *MockJobDomainInterface_QueueStatus_Call {
+	return &MockJobDomainInterface_QueueStatus_Call{Call: _e.mock.On("QueueStatus")}
+}
+
+func (_c *MockJobDomainInterface_QueueStatus_Call) Run(run func()) *MockJobDomainInterface_QueueStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJobDomainInterface_QueueStatus_Call) Return(queueStatus *entity.QueueStatus) *MockJobDomainInterface_QueueStatus_Call {
+	_c.Call.Return(queueStatus)
+	return _c
+}
+
+func (_c *MockJobDomainInterface_QueueStatus_Call) RunAndReturn(run func() *entity.QueueStatus) *MockJobDomainInterface_QueueStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function for the type MockJobDomainInterface
+func (_mock *MockJobDomainInterface) Stop(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobDomainInterface_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockJobDomainInterface_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobDomainInterface_Expecter) Stop(ctx interface{}) *MockJobDomainInterface_Stop_Call {
+	return &MockJobDomainInterface_Stop_Call{Call: _e.mock.On("Stop", ctx)}
+}
+
+func (_c *MockJobDomainInterface_Stop_Call) Run(run func(ctx context.Context)) *MockJobDomainInterface_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobDomainInterface_Stop_Call) Return(err error) *MockJobDomainInterface_Stop_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobDomainInterface_Stop_Call) RunAndReturn(run func(ctx context.Context) error) *MockJobDomainInterface_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubmitJob provides a mock function for the type MockJobDomainInterface
+func (_mock *MockJobDomainInterface) SubmitJob(ctx context.Context, operation string, filePath string, matrix *entity.Matrix[int64], callbackURL string) (*entity.Job, error) {
+	ret := _mock.Called(ctx, operation, filePath, matrix, callbackURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitJob")
+	}
+
+	var r0 *entity.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Matrix[int64], string) (*entity.Job, error)); ok {
+		return returnFunc(ctx, operation, filePath, matrix, callbackURL)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Matrix[int64], string) *entity.Job); ok {
+		r0 = returnFunc(ctx, operation, filePath, matrix, callbackURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *entity.Matrix[int64], string) error); ok {
+		r1 = returnFunc(ctx, operation, filePath, matrix, callbackURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobDomainInterface_SubmitJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubmitJob'
+type MockJobDomainInterface_SubmitJob_Call struct {
+	*mock.Call
+}
+
+// SubmitJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - filePath string
+//   - matrix *entity.Matrix[int64]
+//   - callbackURL string
+func (_e *MockJobDomainInterface_Expecter) SubmitJob(ctx interface{}, operation interface{}, filePath interface{}, matrix interface{}, callbackURL interface{}) *MockJobDomainInterface_SubmitJob_Call {
+	return &MockJobDomainInterface_SubmitJob_Call{Call: _e.mock.On("SubmitJob", ctx, operation, filePath, matrix, callbackURL)}
+}
+
+func (_c *MockJobDomainInterface_SubmitJob_Call) Run(run func(ctx context.Context, operation string, filePath string, matrix *entity.Matrix[int64], callbackURL string)) *MockJobDomainInterface_SubmitJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *entity.Matrix[int64]
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Matrix[int64])
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobDomainInterface_SubmitJob_Call) Return(job *entity.Job, err error) *MockJobDomainInterface_SubmitJob_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobDomainInterface_SubmitJob_Call) RunAndReturn(run func(ctx context.Context, operation string, filePath string, matrix *entity.Matrix[int64], callbackURL string) (*entity.Job, error)) *MockJobDomainInterface_SubmitJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockJobHandlerInterface creates a new instance of MockJobHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobHandlerInterface {
+	mock := &MockJobHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobHandlerInterface is an autogenerated mock type for the JobHandlerInterface type
+type MockJobHandlerInterface struct {
+	mock.Mock
+}
+
+type MockJobHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobHandlerInterface) EXPECT() *MockJobHandlerInterface_Expecter {
+	return &MockJobHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateJob provides a mock function for the type MockJobHandlerInterface
+func (_mock *MockJobHandlerInterface) CreateJob(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockJobHandlerInterface_CreateJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateJob'
+type MockJobHandlerInterface_CreateJob_Call struct {
+	*mock.Call
+}
+
+// CreateJob is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockJobHandlerInterface_Expecter) CreateJob(w interface{}, r interface{}) *MockJobHandlerInterface_CreateJob_Call {
+	return &MockJobHandlerInterface_CreateJob_Call{Call: _e.mock.On("CreateJob", w, r)}
+}
+
+func (_c *MockJobHandlerInterface_CreateJob_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockJobHandlerInterface_CreateJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobHandlerInterface_CreateJob_Call) Return() *MockJobHandlerInterface_CreateJob_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJobHandlerInterface_CreateJob_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockJobHandlerInterface_CreateJob_Call {
+	_c.Run(run)
+	return _c
+}
+
+// HandleJob provides a mock function for the type MockJobHandlerInterface
+func (_mock *MockJobHandlerInterface) HandleJob(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockJobHandlerInterface_HandleJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleJob'
+type MockJobHandlerInterface_HandleJob_Call struct {
+	*mock.Call
+}
+
+// HandleJob is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockJobHandlerInterface_Expecter) HandleJob(w interface{}, r interface{}) *MockJobHandlerInterface_HandleJob_Call {
+	return &MockJobHandlerInterface_HandleJob_Call{Call: _e.mock.On("HandleJob", w, r)}
+}
+
+func (_c *MockJobHandlerInterface_HandleJob_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockJobHandlerInterface_HandleJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobHandlerInterface_HandleJob_Call) Return() *MockJobHandlerInterface_HandleJob_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJobHandlerInterface_HandleJob_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockJobHandlerInterface_HandleJob_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,462 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockJobRepositoryInterface creates a new instance of MockJobRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobRepositoryInterface {
+	mock := &MockJobRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobRepositoryInterface is an autogenerated mock type for the JobRepositoryInterface type
+type MockJobRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockJobRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobRepositoryInterface) EXPECT() *MockJobRepositoryInterface_Expecter {
+	return &MockJobRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// CheckHealth provides a mock function for the type MockJobRepositoryInterface
+func (_mock *MockJobRepositoryInterface) CheckHealth(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepositoryInterface_CheckHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckHealth'
+type MockJobRepositoryInterface_CheckHealth_Call struct {
+	*mock.Call
+}
+
+// CheckHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobRepositoryInterface_Expecter) CheckHealth(ctx interface{}) *MockJobRepositoryInterface_CheckHealth_Call {
+	return &MockJobRepositoryInterface_CheckHealth_Call{Call: _e.mock.On("CheckHealth", ctx)}
+}
+
+func (_c *MockJobRepositoryInterface_CheckHealth_Call) Run(run func(ctx context.Context)) *MockJobRepositoryInterface_CheckHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_CheckHealth_Call) Return(err error) *MockJobRepositoryInterface_CheckHealth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_CheckHealth_Call) RunAndReturn(run func(ctx context.Context) error) *MockJobRepositoryInterface_CheckHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Close provides a mock function for the type MockJobRepositoryInterface
+func (_mock *MockJobRepositoryInterface) Close() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepositoryInterface_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockJobRepositoryInterface_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockJobRepositoryInterface_Expecter) Close() *MockJobRepositoryInterface_Close_Call {
+	return &MockJobRepositoryInterface_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockJobRepositoryInterface_Close_Call) Run(run func()) *MockJobRepositoryInterface_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_Close_Call) Return(err error) *MockJobRepositoryInterface_Close_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_Close_Call) RunAndReturn(run func() error) *MockJobRepositoryInterface_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFinishedJobs provides a mock function for the type MockJobRepositoryInterface
+func (_mock *MockJobRepositoryInterface) DeleteFinishedJobs(ctx context.Context, before time.Time) (int, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFinishedJobs")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepositoryInterface_DeleteFinishedJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFinishedJobs'
+type MockJobRepositoryInterface_DeleteFinishedJobs_Call struct {
+	*mock.Call
+}
+
+// DeleteFinishedJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockJobRepositoryInterface_Expecter) DeleteFinishedJobs(ctx interface{}, before interface{}) *MockJobRepositoryInterface_DeleteFinishedJobs_Call {
+	return &MockJobRepositoryInterface_DeleteFinishedJobs_Call{Call: _e.mock.On("DeleteFinishedJobs", ctx, before)}
+}
+
+func (_c *MockJobRepositoryInterface_DeleteFinishedJobs_Call) Run(run func(ctx context.Context, before time.Time)) *MockJobRepositoryInterface_DeleteFinishedJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_DeleteFinishedJobs_Call) Return(n int, err error) *MockJobRepositoryInterface_DeleteFinishedJobs_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_DeleteFinishedJobs_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int, error)) *MockJobRepositoryInterface_DeleteFinishedJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJob provides a mock function for the type MockJobRepositoryInterface
+func (_mock *MockJobRepositoryInterface) GetJob(ctx context.Context, id string) (*repository.JobRecord, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *repository.JobRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*repository.JobRecord, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *repository.JobRecord); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.JobRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepositoryInterface_GetJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJob'
+type MockJobRepositoryInterface_GetJob_Call struct {
+	*mock.Call
+}
+
+// GetJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockJobRepositoryInterface_Expecter) GetJob(ctx interface{}, id interface{}) *MockJobRepositoryInterface_GetJob_Call {
+	return &MockJobRepositoryInterface_GetJob_Call{Call: _e.mock.On("GetJob", ctx, id)}
+}
+
+func (_c *MockJobRepositoryInterface_GetJob_Call) Run(run func(ctx context.Context, id string)) *MockJobRepositoryInterface_GetJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_GetJob_Call) Return(jobRecord *repository.JobRecord, err error) *MockJobRepositoryInterface_GetJob_Call {
+	_c.Call.Return(jobRecord, err)
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_GetJob_Call) RunAndReturn(run func(ctx context.Context, id string) (*repository.JobRecord, error)) *MockJobRepositoryInterface_GetJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecentJobs provides a mock function for the type MockJobRepositoryInterface
+func (_mock *MockJobRepositoryInterface) ListRecentJobs(ctx context.Context, tenantID string, limit int) ([]*repository.JobRecord, error) {
+	ret := _mock.Called(ctx, tenantID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecentJobs")
+	}
+
+	var r0 []*repository.JobRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*repository.JobRecord, error)); ok {
+		return returnFunc(ctx, tenantID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*repository.JobRecord); ok {
+		r0 = returnFunc(ctx, tenantID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.JobRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, tenantID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepositoryInterface_ListRecentJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecentJobs'
+type MockJobRepositoryInterface_ListRecentJobs_Call struct {
+	*mock.Call
+}
+
+// ListRecentJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+//   - limit int
+func (_e *MockJobRepositoryInterface_Expecter) ListRecentJobs(ctx interface{}, tenantID interface{}, limit interface{}) *MockJobRepositoryInterface_ListRecentJobs_Call {
+	return &MockJobRepositoryInterface_ListRecentJobs_Call{Call: _e.mock.On("ListRecentJobs", ctx, tenantID, limit)}
+}
+
+func (_c *MockJobRepositoryInterface_ListRecentJobs_Call) Run(run func(ctx context.Context, tenantID string, limit int)) *MockJobRepositoryInterface_ListRecentJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_ListRecentJobs_Call) Return(jobRecords []*repository.JobRecord, err error) *MockJobRepositoryInterface_ListRecentJobs_Call {
+	_c.Call.Return(jobRecords, err)
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_ListRecentJobs_Call) RunAndReturn(run func(ctx context.Context, tenantID string, limit int) ([]*repository.JobRecord, error)) *MockJobRepositoryInterface_ListRecentJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnfinishedJobs provides a mock function for the type MockJobRepositoryInterface
+func (_mock *MockJobRepositoryInterface) ListUnfinishedJobs(ctx context.Context) ([]*repository.JobRecord, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnfinishedJobs")
+	}
+
+	var r0 []*repository.JobRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*repository.JobRecord, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*repository.JobRecord); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.JobRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepositoryInterface_ListUnfinishedJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnfinishedJobs'
+type MockJobRepositoryInterface_ListUnfinishedJobs_Call struct {
+	*mock.Call
+}
+
+// ListUnfinishedJobs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobRepositoryInterface_Expecter) ListUnfinishedJobs(ctx interface{}) *MockJobRepositoryInterface_ListUnfinishedJobs_Call {
+	return &MockJobRepositoryInterface_ListUnfinishedJobs_Call{Call: _e.mock.On("ListUnfinishedJobs", ctx)}
+}
+
+func (_c *MockJobRepositoryInterface_ListUnfinishedJobs_Call) Run(run func(ctx context.Context)) *MockJobRepositoryInterface_ListUnfinishedJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_ListUnfinishedJobs_Call) Return(jobRecords []*repository.JobRecord, err error) *MockJobRepositoryInterface_ListUnfinishedJobs_Call {
+	_c.Call.Return(jobRecords, err)
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_ListUnfinishedJobs_Call) RunAndReturn(run func(ctx context.Context) ([]*repository.JobRecord, error)) *MockJobRepositoryInterface_ListUnfinishedJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveJob provides a mock function for the type MockJobRepositoryInterface
+func (_mock *MockJobRepositoryInterface) SaveJob(ctx context.Context, job *repository.JobRecord) error {
+	ret := _mock.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveJob")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.JobRecord) error); ok {
+		r0 = returnFunc(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepositoryInterface_SaveJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveJob'
+type MockJobRepositoryInterface_SaveJob_Call struct {
+	*mock.Call
+}
+
+// SaveJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *repository.JobRecord
+func (_e *MockJobRepositoryInterface_Expecter) SaveJob(ctx interface{}, job interface{}) *MockJobRepositoryInterface_SaveJob_Call {
+	return &MockJobRepositoryInterface_SaveJob_Call{Call: _e.mock.On("SaveJob", ctx, job)}
+}
+
+func (_c *MockJobRepositoryInterface_SaveJob_Call) Run(run func(ctx context.Context, job *repository.JobRecord)) *MockJobRepositoryInterface_SaveJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *repository.JobRecord
+		if args[1] != nil {
+			arg1 = args[1].(*repository.JobRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_SaveJob_Call) Return(err error) *MockJobRepositoryInterface_SaveJob_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepositoryInterface_SaveJob_Call) RunAndReturn(run func(ctx context.Context, job *repository.JobRecord) error) *MockJobRepositoryInterface_SaveJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockLoggingHandlerInterface creates a new instance of MockLoggingHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLoggingHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLoggingHandlerInterface {
+	mock := &MockLoggingHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLoggingHandlerInterface is an autogenerated mock type for the LoggingHandlerInterface type
+type MockLoggingHandlerInterface struct {
+	mock.Mock
+}
+
+type MockLoggingHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLoggingHandlerInterface) EXPECT() *MockLoggingHandlerInterface_Expecter {
+	return &MockLoggingHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Scope provides a mock function for the type MockLoggingHandlerInterface
+func (_mock *MockLoggingHandlerInterface) Scope(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scope")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockLoggingHandlerInterface_Scope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scope'
+type MockLoggingHandlerInterface_Scope_Call struct {
+	*mock.Call
+}
+
+// Scope is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockLoggingHandlerInterface_Expecter) Scope(next interface{}) *MockLoggingHandlerInterface_Scope_Call {
+	return &MockLoggingHandlerInterface_Scope_Call{Call: _e.mock.On("Scope", next)}
+}
+
+func (_c *MockLoggingHandlerInterface_Scope_Call) Run(run func(next http.Handler)) *MockLoggingHandlerInterface_Scope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoggingHandlerInterface_Scope_Call) Return(handler http.Handler) *MockLoggingHandlerInterface_Scope_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockLoggingHandlerInterface_Scope_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockLoggingHandlerInterface_Scope_Call {
+	_c.Call.Return(run)
+	return _c
+}
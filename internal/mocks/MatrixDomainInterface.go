@@ -6,7 +6,11 @@ package mocks
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/i18n"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,79 +41,1198 @@ func (_m *MockMatrixDomainInterface) EXPECT() *MockMatrixDomainInterface_Expecte
 	return &MockMatrixDomainInterface_Expecter{mock: &_m.Mock}
 }
 
-// ListMatrixOperations provides a mock function for the type MockMatrixDomainInterface
-func (_mock *MockMatrixDomainInterface) ListMatrixOperations() (string, error) {
+// CacheMetrics provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) CacheMetrics() []*entity.Metric {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CacheMetrics")
+	}
+
+	var r0 []*entity.Metric
+	if returnFunc, ok := ret.Get(0).(func() []*entity.Metric); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Metric)
+		}
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_CacheMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CacheMetrics'
+type MockMatrixDomainInterface_CacheMetrics_Call struct {
+	*mock.Call
+}
+
+// CacheMetrics is a helper method to define mock.On call
+func (_e *MockMatrixDomainInterface_Expecter) CacheMetrics() *MockMatrixDomainInterface_CacheMetrics_Call {
+	return &MockMatrixDomainInterface_CacheMetrics_Call{Call: _e.mock.On("CacheMetrics")}
+}
+
+func (_c *MockMatrixDomainInterface_CacheMetrics_Call) Run(run func()) *MockMatrixDomainInterface_CacheMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_CacheMetrics_Call) Return(metrics []*entity.Metric) *MockMatrixDomainInterface_CacheMetrics_Call {
+	_c.Call.Return(metrics)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_CacheMetrics_Call) RunAndReturn(run func() []*entity.Metric) *MockMatrixDomainInterface_CacheMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckHealth provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) CheckHealth(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_CheckHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckHealth'
+type MockMatrixDomainInterface_CheckHealth_Call struct {
+	*mock.Call
+}
+
+// CheckHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMatrixDomainInterface_Expecter) CheckHealth(ctx interface{}) *MockMatrixDomainInterface_CheckHealth_Call {
+	return &MockMatrixDomainInterface_CheckHealth_Call{Call: _e.mock.On("CheckHealth", ctx)}
+}
+
+func (_c *MockMatrixDomainInterface_CheckHealth_Call) Run(run func(ctx context.Context)) *MockMatrixDomainInterface_CheckHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_CheckHealth_Call) Return(err error) *MockMatrixDomainInterface_CheckHealth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_CheckHealth_Call) RunAndReturn(run func(ctx context.Context) error) *MockMatrixDomainInterface_CheckHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConcatMatrices provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) ConcatMatrices(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Result, error) {
+	ret := _mock.Called(ctx, filePath, filePath2, axis, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConcatMatrices")
+	}
+
+	var r0 *entity.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *entity.Selection) (*entity.Result, error)); ok {
+		return returnFunc(ctx, filePath, filePath2, axis, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *entity.Selection) *entity.Result); ok {
+		r0 = returnFunc(ctx, filePath, filePath2, axis, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, filePath, filePath2, axis, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_ConcatMatrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConcatMatrices'
+type MockMatrixDomainInterface_ConcatMatrices_Call struct {
+	*mock.Call
+}
+
+// ConcatMatrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - filePath2 string
+//   - axis string
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) ConcatMatrices(ctx interface{}, filePath interface{}, filePath2 interface{}, axis interface{}, selection interface{}) *MockMatrixDomainInterface_ConcatMatrices_Call {
+	return &MockMatrixDomainInterface_ConcatMatrices_Call{Call: _e.mock.On("ConcatMatrices", ctx, filePath, filePath2, axis, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_ConcatMatrices_Call) Run(run func(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection)) *MockMatrixDomainInterface_ConcatMatrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 *entity.Selection
+		if args[4] != nil {
+			arg4 = args[4].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ConcatMatrices_Call) Return(result *entity.Result, err error) *MockMatrixDomainInterface_ConcatMatrices_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ConcatMatrices_Call) RunAndReturn(run func(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Result, error)) *MockMatrixDomainInterface_ConcatMatrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DescribeOperations provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) DescribeOperations() []entity.OperationInfo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeOperations")
+	}
+
+	var r0 []entity.OperationInfo
+	if returnFunc, ok := ret.Get(0).(func() []entity.OperationInfo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OperationInfo)
+		}
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_DescribeOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeOperations'
+type MockMatrixDomainInterface_DescribeOperations_Call struct {
+	*mock.Call
+}
+
+// DescribeOperations is a helper method to define mock.On call
+func (_e *MockMatrixDomainInterface_Expecter) DescribeOperations() *MockMatrixDomainInterface_DescribeOperations_Call {
+	return &MockMatrixDomainInterface_DescribeOperations_Call{Call: _e.mock.On("DescribeOperations")}
+}
+
+func (_c *MockMatrixDomainInterface_DescribeOperations_Call) Run(run func()) *MockMatrixDomainInterface_DescribeOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_DescribeOperations_Call) Return(vs []entity.OperationInfo) *MockMatrixDomainInterface_DescribeOperations_Call {
+	_c.Call.Return(vs)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_DescribeOperations_Call) RunAndReturn(run func() []entity.OperationInfo) *MockMatrixDomainInterface_DescribeOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileModTime provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileModTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_FileModTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileModTime'
+type MockMatrixDomainInterface_FileModTime_Call struct {
+	*mock.Call
+}
+
+// FileModTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockMatrixDomainInterface_Expecter) FileModTime(ctx interface{}, filePath interface{}) *MockMatrixDomainInterface_FileModTime_Call {
+	return &MockMatrixDomainInterface_FileModTime_Call{Call: _e.mock.On("FileModTime", ctx, filePath)}
+}
+
+func (_c *MockMatrixDomainInterface_FileModTime_Call) Run(run func(ctx context.Context, filePath string)) *MockMatrixDomainInterface_FileModTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_FileModTime_Call) Return(time1 time.Time, err error) *MockMatrixDomainInterface_FileModTime_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_FileModTime_Call) RunAndReturn(run func(ctx context.Context, filePath string) (time.Time, error)) *MockMatrixDomainInterface_FileModTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushCache provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) FlushCache() int {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListMatrixOperations")
+		panic("no return value specified for FlushCache")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_FlushCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushCache'
+type MockMatrixDomainInterface_FlushCache_Call struct {
+	*mock.Call
+}
+
+// FlushCache is a helper method to define mock.On call
+func (_e *MockMatrixDomainInterface_Expecter) FlushCache() *MockMatrixDomainInterface_FlushCache_Call {
+	return &MockMatrixDomainInterface_FlushCache_Call{Call: _e.mock.On("FlushCache")}
+}
+
+func (_c *MockMatrixDomainInterface_FlushCache_Call) Run(run func()) *MockMatrixDomainInterface_FlushCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_FlushCache_Call) Return(n int) *MockMatrixDomainInterface_FlushCache_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_FlushCache_Call) RunAndReturn(run func() int) *MockMatrixDomainInterface_FlushCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateCache provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) InvalidateCache(ctx context.Context, key string) int {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateCache")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_InvalidateCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateCache'
+type MockMatrixDomainInterface_InvalidateCache_Call struct {
+	*mock.Call
+}
+
+// InvalidateCache is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockMatrixDomainInterface_Expecter) InvalidateCache(ctx interface{}, key interface{}) *MockMatrixDomainInterface_InvalidateCache_Call {
+	return &MockMatrixDomainInterface_InvalidateCache_Call{Call: _e.mock.On("InvalidateCache", ctx, key)}
+}
+
+func (_c *MockMatrixDomainInterface_InvalidateCache_Call) Run(run func(ctx context.Context, key string)) *MockMatrixDomainInterface_InvalidateCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_InvalidateCache_Call) Return(n int) *MockMatrixDomainInterface_InvalidateCache_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_InvalidateCache_Call) RunAndReturn(run func(ctx context.Context, key string) int) *MockMatrixDomainInterface_InvalidateCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFiles provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) ListFiles(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFiles")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
+type MockMatrixDomainInterface_ListFiles_Call struct {
+	*mock.Call
+}
+
+// ListFiles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMatrixDomainInterface_Expecter) ListFiles(ctx interface{}) *MockMatrixDomainInterface_ListFiles_Call {
+	return &MockMatrixDomainInterface_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx)}
+}
+
+func (_c *MockMatrixDomainInterface_ListFiles_Call) Run(run func(ctx context.Context)) *MockMatrixDomainInterface_ListFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ListFiles_Call) Return(strings []string, err error) *MockMatrixDomainInterface_ListFiles_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ListFiles_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockMatrixDomainInterface_ListFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMatrixOperations provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) ListMatrixOperations(localizer *i18n.Localizer) (string, error) {
+	ret := _mock.Called(localizer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMatrixOperations")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*i18n.Localizer) (string, error)); ok {
+		return returnFunc(localizer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*i18n.Localizer) string); ok {
+		r0 = returnFunc(localizer)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*i18n.Localizer) error); ok {
+		r1 = returnFunc(localizer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_ListMatrixOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatrixOperations'
+type MockMatrixDomainInterface_ListMatrixOperations_Call struct {
+	*mock.Call
+}
+
+// ListMatrixOperations is a helper method to define mock.On call
+//   - localizer *i18n.Localizer
+func (_e *MockMatrixDomainInterface_Expecter) ListMatrixOperations(localizer interface{}) *MockMatrixDomainInterface_ListMatrixOperations_Call {
+	return &MockMatrixDomainInterface_ListMatrixOperations_Call{Call: _e.mock.On("ListMatrixOperations", localizer)}
+}
+
+func (_c *MockMatrixDomainInterface_ListMatrixOperations_Call) Run(run func(localizer *i18n.Localizer)) *MockMatrixDomainInterface_ListMatrixOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *i18n.Localizer
+		if args[0] != nil {
+			arg0 = args[0].(*i18n.Localizer)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ListMatrixOperations_Call) Return(s string, err error) *MockMatrixDomainInterface_ListMatrixOperations_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ListMatrixOperations_Call) RunAndReturn(run func(localizer *i18n.Localizer) (string, error)) *MockMatrixDomainInterface_ListMatrixOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOperations provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) ListOperations() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOperations")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_ListOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOperations'
+type MockMatrixDomainInterface_ListOperations_Call struct {
+	*mock.Call
+}
+
+// ListOperations is a helper method to define mock.On call
+func (_e *MockMatrixDomainInterface_Expecter) ListOperations() *MockMatrixDomainInterface_ListOperations_Call {
+	return &MockMatrixDomainInterface_ListOperations_Call{Call: _e.mock.On("ListOperations")}
+}
+
+func (_c *MockMatrixDomainInterface_ListOperations_Call) Run(run func()) *MockMatrixDomainInterface_ListOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ListOperations_Call) Return(strings []string) *MockMatrixDomainInterface_ListOperations_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ListOperations_Call) RunAndReturn(run func() []string) *MockMatrixDomainInterface_ListOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MemoMetrics provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) MemoMetrics() []*entity.Metric {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MemoMetrics")
+	}
+
+	var r0 []*entity.Metric
+	if returnFunc, ok := ret.Get(0).(func() []*entity.Metric); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Metric)
+		}
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_MemoMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MemoMetrics'
+type MockMatrixDomainInterface_MemoMetrics_Call struct {
+	*mock.Call
+}
+
+// MemoMetrics is a helper method to define mock.On call
+func (_e *MockMatrixDomainInterface_Expecter) MemoMetrics() *MockMatrixDomainInterface_MemoMetrics_Call {
+	return &MockMatrixDomainInterface_MemoMetrics_Call{Call: _e.mock.On("MemoMetrics")}
+}
+
+func (_c *MockMatrixDomainInterface_MemoMetrics_Call) Run(run func()) *MockMatrixDomainInterface_MemoMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_MemoMetrics_Call) Return(metrics []*entity.Metric) *MockMatrixDomainInterface_MemoMetrics_Call {
+	_c.Call.Return(metrics)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_MemoMetrics_Call) RunAndReturn(run func() []*entity.Metric) *MockMatrixDomainInterface_MemoMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MultiplyMatrices provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) MultiplyMatrices(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Result, error) {
+	ret := _mock.Called(ctx, filePath, filePath2, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MultiplyMatrices")
+	}
+
+	var r0 *entity.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) (*entity.Result, error)); ok {
+		return returnFunc(ctx, filePath, filePath2, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) *entity.Result); ok {
+		r0 = returnFunc(ctx, filePath, filePath2, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, filePath, filePath2, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_MultiplyMatrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MultiplyMatrices'
+type MockMatrixDomainInterface_MultiplyMatrices_Call struct {
+	*mock.Call
+}
+
+// MultiplyMatrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - filePath2 string
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) MultiplyMatrices(ctx interface{}, filePath interface{}, filePath2 interface{}, selection interface{}) *MockMatrixDomainInterface_MultiplyMatrices_Call {
+	return &MockMatrixDomainInterface_MultiplyMatrices_Call{Call: _e.mock.On("MultiplyMatrices", ctx, filePath, filePath2, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_MultiplyMatrices_Call) Run(run func(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection)) *MockMatrixDomainInterface_MultiplyMatrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_MultiplyMatrices_Call) Return(result *entity.Result, err error) *MockMatrixDomainInterface_MultiplyMatrices_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_MultiplyMatrices_Call) RunAndReturn(run func(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Result, error)) *MockMatrixDomainInterface_MultiplyMatrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanConcat provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) PlanConcat(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Plan, error) {
+	ret := _mock.Called(ctx, filePath, filePath2, axis, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanConcat")
+	}
+
+	var r0 *entity.Plan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *entity.Selection) (*entity.Plan, error)); ok {
+		return returnFunc(ctx, filePath, filePath2, axis, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *entity.Selection) *entity.Plan); ok {
+		r0 = returnFunc(ctx, filePath, filePath2, axis, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Plan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, filePath, filePath2, axis, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_PlanConcat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanConcat'
+type MockMatrixDomainInterface_PlanConcat_Call struct {
+	*mock.Call
+}
+
+// PlanConcat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - filePath2 string
+//   - axis string
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) PlanConcat(ctx interface{}, filePath interface{}, filePath2 interface{}, axis interface{}, selection interface{}) *MockMatrixDomainInterface_PlanConcat_Call {
+	return &MockMatrixDomainInterface_PlanConcat_Call{Call: _e.mock.On("PlanConcat", ctx, filePath, filePath2, axis, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_PlanConcat_Call) Run(run func(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection)) *MockMatrixDomainInterface_PlanConcat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 *entity.Selection
+		if args[4] != nil {
+			arg4 = args[4].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanConcat_Call) Return(plan *entity.Plan, err error) *MockMatrixDomainInterface_PlanConcat_Call {
+	_c.Call.Return(plan, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanConcat_Call) RunAndReturn(run func(ctx context.Context, filePath string, filePath2 string, axis string, selection *entity.Selection) (*entity.Plan, error)) *MockMatrixDomainInterface_PlanConcat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanMatrix provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) PlanMatrix(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Plan, error) {
+	ret := _mock.Called(ctx, operation, filePath, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanMatrix")
+	}
+
+	var r0 *entity.Plan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) (*entity.Plan, error)); ok {
+		return returnFunc(ctx, operation, filePath, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) *entity.Plan); ok {
+		r0 = returnFunc(ctx, operation, filePath, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Plan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, operation, filePath, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_PlanMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanMatrix'
+type MockMatrixDomainInterface_PlanMatrix_Call struct {
+	*mock.Call
+}
+
+// PlanMatrix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - filePath string
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) PlanMatrix(ctx interface{}, operation interface{}, filePath interface{}, selection interface{}) *MockMatrixDomainInterface_PlanMatrix_Call {
+	return &MockMatrixDomainInterface_PlanMatrix_Call{Call: _e.mock.On("PlanMatrix", ctx, operation, filePath, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrix_Call) Run(run func(ctx context.Context, operation string, filePath string, selection *entity.Selection)) *MockMatrixDomainInterface_PlanMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrix_Call) Return(plan *entity.Plan, err error) *MockMatrixDomainInterface_PlanMatrix_Call {
+	_c.Call.Return(plan, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrix_Call) RunAndReturn(run func(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Plan, error)) *MockMatrixDomainInterface_PlanMatrix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanMatrixData provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) PlanMatrixData(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Plan, error) {
+	ret := _mock.Called(ctx, operation, matrix, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanMatrixData")
+	}
+
+	var r0 *entity.Plan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *entity.Matrix[int64], *entity.Selection) (*entity.Plan, error)); ok {
+		return returnFunc(ctx, operation, matrix, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *entity.Matrix[int64], *entity.Selection) *entity.Plan); ok {
+		r0 = returnFunc(ctx, operation, matrix, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Plan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *entity.Matrix[int64], *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, operation, matrix, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_PlanMatrixData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanMatrixData'
+type MockMatrixDomainInterface_PlanMatrixData_Call struct {
+	*mock.Call
+}
+
+// PlanMatrixData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - matrix *entity.Matrix[int64]
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) PlanMatrixData(ctx interface{}, operation interface{}, matrix interface{}, selection interface{}) *MockMatrixDomainInterface_PlanMatrixData_Call {
+	return &MockMatrixDomainInterface_PlanMatrixData_Call{Call: _e.mock.On("PlanMatrixData", ctx, operation, matrix, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrixData_Call) Run(run func(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection)) *MockMatrixDomainInterface_PlanMatrixData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *entity.Matrix[int64]
+		if args[2] != nil {
+			arg2 = args[2].(*entity.Matrix[int64])
+		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrixData_Call) Return(plan *entity.Plan, err error) *MockMatrixDomainInterface_PlanMatrixData_Call {
+	_c.Call.Return(plan, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrixData_Call) RunAndReturn(run func(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Plan, error)) *MockMatrixDomainInterface_PlanMatrixData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanMatrixReader provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) PlanMatrixReader(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Plan, error) {
+	ret := _mock.Called(ctx, operation, r, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanMatrixReader")
+	}
+
+	var r0 *entity.Plan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, io.Reader, *entity.Selection) (*entity.Plan, error)); ok {
+		return returnFunc(ctx, operation, r, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, io.Reader, *entity.Selection) *entity.Plan); ok {
+		r0 = returnFunc(ctx, operation, r, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Plan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, io.Reader, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, operation, r, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_PlanMatrixReader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanMatrixReader'
+type MockMatrixDomainInterface_PlanMatrixReader_Call struct {
+	*mock.Call
+}
+
+// PlanMatrixReader is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - r io.Reader
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) PlanMatrixReader(ctx interface{}, operation interface{}, r interface{}, selection interface{}) *MockMatrixDomainInterface_PlanMatrixReader_Call {
+	return &MockMatrixDomainInterface_PlanMatrixReader_Call{Call: _e.mock.On("PlanMatrixReader", ctx, operation, r, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrixReader_Call) Run(run func(ctx context.Context, operation string, r io.Reader, selection *entity.Selection)) *MockMatrixDomainInterface_PlanMatrixReader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 io.Reader
+		if args[2] != nil {
+			arg2 = args[2].(io.Reader)
+		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrixReader_Call) Return(plan *entity.Plan, err error) *MockMatrixDomainInterface_PlanMatrixReader_Call {
+	_c.Call.Return(plan, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMatrixReader_Call) RunAndReturn(run func(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Plan, error)) *MockMatrixDomainInterface_PlanMatrixReader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanMultiplyMatrices provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) PlanMultiplyMatrices(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Plan, error) {
+	ret := _mock.Called(ctx, filePath, filePath2, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanMultiplyMatrices")
+	}
+
+	var r0 *entity.Plan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) (*entity.Plan, error)); ok {
+		return returnFunc(ctx, filePath, filePath2, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) *entity.Plan); ok {
+		r0 = returnFunc(ctx, filePath, filePath2, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Plan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, filePath, filePath2, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_PlanMultiplyMatrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanMultiplyMatrices'
+type MockMatrixDomainInterface_PlanMultiplyMatrices_Call struct {
+	*mock.Call
+}
+
+// PlanMultiplyMatrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - filePath2 string
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) PlanMultiplyMatrices(ctx interface{}, filePath interface{}, filePath2 interface{}, selection interface{}) *MockMatrixDomainInterface_PlanMultiplyMatrices_Call {
+	return &MockMatrixDomainInterface_PlanMultiplyMatrices_Call{Call: _e.mock.On("PlanMultiplyMatrices", ctx, filePath, filePath2, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_PlanMultiplyMatrices_Call) Run(run func(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection)) *MockMatrixDomainInterface_PlanMultiplyMatrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMultiplyMatrices_Call) Return(plan *entity.Plan, err error) *MockMatrixDomainInterface_PlanMultiplyMatrices_Call {
+	_c.Call.Return(plan, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_PlanMultiplyMatrices_Call) RunAndReturn(run func(ctx context.Context, filePath string, filePath2 string, selection *entity.Selection) (*entity.Plan, error)) *MockMatrixDomainInterface_PlanMultiplyMatrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessArchive provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) ProcessArchive(ctx context.Context, operation string, archive []byte) ([]*entity.FileResult, error) {
+	ret := _mock.Called(ctx, operation, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessArchive")
 	}
 
-	var r0 string
+	var r0 []*entity.FileResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() (string, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) ([]*entity.FileResult, error)); ok {
+		return returnFunc(ctx, operation, archive)
 	}
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) []*entity.FileResult); ok {
+		r0 = returnFunc(ctx, operation, archive)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.FileResult)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = returnFunc(ctx, operation, archive)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMatrixDomainInterface_ListMatrixOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatrixOperations'
-type MockMatrixDomainInterface_ListMatrixOperations_Call struct {
+// MockMatrixDomainInterface_ProcessArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessArchive'
+type MockMatrixDomainInterface_ProcessArchive_Call struct {
 	*mock.Call
 }
 
-// ListMatrixOperations is a helper method to define mock.On call
-func (_e *MockMatrixDomainInterface_Expecter) ListMatrixOperations() *MockMatrixDomainInterface_ListMatrixOperations_Call {
-	return &MockMatrixDomainInterface_ListMatrixOperations_Call{Call: _e.mock.On("ListMatrixOperations")}
+// ProcessArchive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - archive []byte
+func (_e *MockMatrixDomainInterface_Expecter) ProcessArchive(ctx interface{}, operation interface{}, archive interface{}) *MockMatrixDomainInterface_ProcessArchive_Call {
+	return &MockMatrixDomainInterface_ProcessArchive_Call{Call: _e.mock.On("ProcessArchive", ctx, operation, archive)}
 }
 
-func (_c *MockMatrixDomainInterface_ListMatrixOperations_Call) Run(run func()) *MockMatrixDomainInterface_ListMatrixOperations_Call {
+func (_c *MockMatrixDomainInterface_ProcessArchive_Call) Run(run func(ctx context.Context, operation string, archive []byte)) *MockMatrixDomainInterface_ProcessArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *MockMatrixDomainInterface_ListMatrixOperations_Call) Return(s string, err error) *MockMatrixDomainInterface_ListMatrixOperations_Call {
-	_c.Call.Return(s, err)
+func (_c *MockMatrixDomainInterface_ProcessArchive_Call) Return(fileResults []*entity.FileResult, err error) *MockMatrixDomainInterface_ProcessArchive_Call {
+	_c.Call.Return(fileResults, err)
 	return _c
 }
 
-func (_c *MockMatrixDomainInterface_ListMatrixOperations_Call) RunAndReturn(run func() (string, error)) *MockMatrixDomainInterface_ListMatrixOperations_Call {
+func (_c *MockMatrixDomainInterface_ProcessArchive_Call) RunAndReturn(run func(ctx context.Context, operation string, archive []byte) ([]*entity.FileResult, error)) *MockMatrixDomainInterface_ProcessArchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ProcessMatrix provides a mock function for the type MockMatrixDomainInterface
-func (_mock *MockMatrixDomainInterface) ProcessMatrix(ctx context.Context, operation string, filePath string) (string, error) {
-	ret := _mock.Called(ctx, operation, filePath)
+func (_mock *MockMatrixDomainInterface) ProcessMatrix(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Result, error) {
+	ret := _mock.Called(ctx, operation, filePath, selection)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessMatrix")
 	}
 
-	var r0 string
+	var r0 *entity.Result
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, operation, filePath)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) (*entity.Result, error)); ok {
+		return returnFunc(ctx, operation, filePath, selection)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, operation, filePath)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *entity.Selection) *entity.Result); ok {
+		r0 = returnFunc(ctx, operation, filePath, selection)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Result)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, operation, filePath)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, operation, filePath, selection)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -125,11 +1248,12 @@ type MockMatrixDomainInterface_ProcessMatrix_Call struct {
 //   - ctx context.Context
 //   - operation string
 //   - filePath string
-func (_e *MockMatrixDomainInterface_Expecter) ProcessMatrix(ctx interface{}, operation interface{}, filePath interface{}) *MockMatrixDomainInterface_ProcessMatrix_Call {
-	return &MockMatrixDomainInterface_ProcessMatrix_Call{Call: _e.mock.On("ProcessMatrix", ctx, operation, filePath)}
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) ProcessMatrix(ctx interface{}, operation interface{}, filePath interface{}, selection interface{}) *MockMatrixDomainInterface_ProcessMatrix_Call {
+	return &MockMatrixDomainInterface_ProcessMatrix_Call{Call: _e.mock.On("ProcessMatrix", ctx, operation, filePath, selection)}
 }
 
-func (_c *MockMatrixDomainInterface_ProcessMatrix_Call) Run(run func(ctx context.Context, operation string, filePath string)) *MockMatrixDomainInterface_ProcessMatrix_Call {
+func (_c *MockMatrixDomainInterface_ProcessMatrix_Call) Run(run func(ctx context.Context, operation string, filePath string, selection *entity.Selection)) *MockMatrixDomainInterface_ProcessMatrix_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -143,21 +1267,329 @@ func (_c *MockMatrixDomainInterface_ProcessMatrix_Call) Run(run func(ctx context
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMatrixDomainInterface_ProcessMatrix_Call) Return(s string, err error) *MockMatrixDomainInterface_ProcessMatrix_Call {
-	_c.Call.Return(s, err)
+func (_c *MockMatrixDomainInterface_ProcessMatrix_Call) Return(result *entity.Result, err error) *MockMatrixDomainInterface_ProcessMatrix_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ProcessMatrix_Call) RunAndReturn(run func(ctx context.Context, operation string, filePath string, selection *entity.Selection) (*entity.Result, error)) *MockMatrixDomainInterface_ProcessMatrix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessMatrixData provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) ProcessMatrixData(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Result, error) {
+	ret := _mock.Called(ctx, operation, matrix, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessMatrixData")
+	}
+
+	var r0 *entity.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *entity.Matrix[int64], *entity.Selection) (*entity.Result, error)); ok {
+		return returnFunc(ctx, operation, matrix, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *entity.Matrix[int64], *entity.Selection) *entity.Result); ok {
+		r0 = returnFunc(ctx, operation, matrix, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *entity.Matrix[int64], *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, operation, matrix, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_ProcessMatrixData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessMatrixData'
+type MockMatrixDomainInterface_ProcessMatrixData_Call struct {
+	*mock.Call
+}
+
+// ProcessMatrixData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - matrix *entity.Matrix[int64]
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) ProcessMatrixData(ctx interface{}, operation interface{}, matrix interface{}, selection interface{}) *MockMatrixDomainInterface_ProcessMatrixData_Call {
+	return &MockMatrixDomainInterface_ProcessMatrixData_Call{Call: _e.mock.On("ProcessMatrixData", ctx, operation, matrix, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_ProcessMatrixData_Call) Run(run func(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection)) *MockMatrixDomainInterface_ProcessMatrixData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *entity.Matrix[int64]
+		if args[2] != nil {
+			arg2 = args[2].(*entity.Matrix[int64])
+		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ProcessMatrixData_Call) Return(result *entity.Result, err error) *MockMatrixDomainInterface_ProcessMatrixData_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ProcessMatrixData_Call) RunAndReturn(run func(ctx context.Context, operation string, matrix *entity.Matrix[int64], selection *entity.Selection) (*entity.Result, error)) *MockMatrixDomainInterface_ProcessMatrixData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessMatrixReader provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) ProcessMatrixReader(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Result, error) {
+	ret := _mock.Called(ctx, operation, r, selection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessMatrixReader")
+	}
+
+	var r0 *entity.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, io.Reader, *entity.Selection) (*entity.Result, error)); ok {
+		return returnFunc(ctx, operation, r, selection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, io.Reader, *entity.Selection) *entity.Result); ok {
+		r0 = returnFunc(ctx, operation, r, selection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, io.Reader, *entity.Selection) error); ok {
+		r1 = returnFunc(ctx, operation, r, selection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_ProcessMatrixReader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessMatrixReader'
+type MockMatrixDomainInterface_ProcessMatrixReader_Call struct {
+	*mock.Call
+}
+
+// ProcessMatrixReader is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - r io.Reader
+//   - selection *entity.Selection
+func (_e *MockMatrixDomainInterface_Expecter) ProcessMatrixReader(ctx interface{}, operation interface{}, r interface{}, selection interface{}) *MockMatrixDomainInterface_ProcessMatrixReader_Call {
+	return &MockMatrixDomainInterface_ProcessMatrixReader_Call{Call: _e.mock.On("ProcessMatrixReader", ctx, operation, r, selection)}
+}
+
+func (_c *MockMatrixDomainInterface_ProcessMatrixReader_Call) Run(run func(ctx context.Context, operation string, r io.Reader, selection *entity.Selection)) *MockMatrixDomainInterface_ProcessMatrixReader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 io.Reader
+		if args[2] != nil {
+			arg2 = args[2].(io.Reader)
+		}
+		var arg3 *entity.Selection
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Selection)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ProcessMatrixReader_Call) Return(result *entity.Result, err error) *MockMatrixDomainInterface_ProcessMatrixReader_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_ProcessMatrixReader_Call) RunAndReturn(run func(ctx context.Context, operation string, r io.Reader, selection *entity.Selection) (*entity.Result, error)) *MockMatrixDomainInterface_ProcessMatrixReader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveResult provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) SaveResult(ctx context.Context, filePath string, result *entity.Result) error {
+	ret := _mock.Called(ctx, filePath, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResult")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *entity.Result) error); ok {
+		r0 = returnFunc(ctx, filePath, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixDomainInterface_SaveResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveResult'
+type MockMatrixDomainInterface_SaveResult_Call struct {
+	*mock.Call
+}
+
+// SaveResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - result *entity.Result
+func (_e *MockMatrixDomainInterface_Expecter) SaveResult(ctx interface{}, filePath interface{}, result interface{}) *MockMatrixDomainInterface_SaveResult_Call {
+	return &MockMatrixDomainInterface_SaveResult_Call{Call: _e.mock.On("SaveResult", ctx, filePath, result)}
+}
+
+func (_c *MockMatrixDomainInterface_SaveResult_Call) Run(run func(ctx context.Context, filePath string, result *entity.Result)) *MockMatrixDomainInterface_SaveResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *entity.Result
+		if args[2] != nil {
+			arg2 = args[2].(*entity.Result)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_SaveResult_Call) Return(err error) *MockMatrixDomainInterface_SaveResult_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_SaveResult_Call) RunAndReturn(run func(ctx context.Context, filePath string, result *entity.Result) error) *MockMatrixDomainInterface_SaveResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SplitMatrix provides a mock function for the type MockMatrixDomainInterface
+func (_mock *MockMatrixDomainInterface) SplitMatrix(ctx context.Context, filePath string, rows int, cols int) ([]*entity.Tile, error) {
+	ret := _mock.Called(ctx, filePath, rows, cols)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitMatrix")
+	}
+
+	var r0 []*entity.Tile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*entity.Tile, error)); ok {
+		return returnFunc(ctx, filePath, rows, cols)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) []*entity.Tile); ok {
+		r0 = returnFunc(ctx, filePath, rows, cols)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Tile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, filePath, rows, cols)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixDomainInterface_SplitMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitMatrix'
+type MockMatrixDomainInterface_SplitMatrix_Call struct {
+	*mock.Call
+}
+
+// SplitMatrix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - rows int
+//   - cols int
+func (_e *MockMatrixDomainInterface_Expecter) SplitMatrix(ctx interface{}, filePath interface{}, rows interface{}, cols interface{}) *MockMatrixDomainInterface_SplitMatrix_Call {
+	return &MockMatrixDomainInterface_SplitMatrix_Call{Call: _e.mock.On("SplitMatrix", ctx, filePath, rows, cols)}
+}
+
+func (_c *MockMatrixDomainInterface_SplitMatrix_Call) Run(run func(ctx context.Context, filePath string, rows int, cols int)) *MockMatrixDomainInterface_SplitMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixDomainInterface_SplitMatrix_Call) Return(tiles []*entity.Tile, err error) *MockMatrixDomainInterface_SplitMatrix_Call {
+	_c.Call.Return(tiles, err)
 	return _c
 }
 
-func (_c *MockMatrixDomainInterface_ProcessMatrix_Call) RunAndReturn(run func(ctx context.Context, operation string, filePath string) (string, error)) *MockMatrixDomainInterface_ProcessMatrix_Call {
+func (_c *MockMatrixDomainInterface_SplitMatrix_Call) RunAndReturn(run func(ctx context.Context, filePath string, rows int, cols int) ([]*entity.Tile, error)) *MockMatrixDomainInterface_SplitMatrix_Call {
 	_c.Call.Return(run)
 	return _c
 }
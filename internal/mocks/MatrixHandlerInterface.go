@@ -37,25 +37,25 @@ func (_m *MockMatrixHandlerInterface) EXPECT() *MockMatrixHandlerInterface_Expec
 	return &MockMatrixHandlerInterface_Expecter{mock: &_m.Mock}
 }
 
-// HealthCheck provides a mock function for the type MockMatrixHandlerInterface
-func (_mock *MockMatrixHandlerInterface) HealthCheck(w http.ResponseWriter, r *http.Request) {
+// ListMatrixOperations provides a mock function for the type MockMatrixHandlerInterface
+func (_mock *MockMatrixHandlerInterface) ListMatrixOperations(w http.ResponseWriter, r *http.Request) {
 	_mock.Called(w, r)
 	return
 }
 
-// MockMatrixHandlerInterface_HealthCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HealthCheck'
-type MockMatrixHandlerInterface_HealthCheck_Call struct {
+// MockMatrixHandlerInterface_ListMatrixOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatrixOperations'
+type MockMatrixHandlerInterface_ListMatrixOperations_Call struct {
 	*mock.Call
 }
 
-// HealthCheck is a helper method to define mock.On call
+// ListMatrixOperations is a helper method to define mock.On call
 //   - w http.ResponseWriter
 //   - r *http.Request
-func (_e *MockMatrixHandlerInterface_Expecter) HealthCheck(w interface{}, r interface{}) *MockMatrixHandlerInterface_HealthCheck_Call {
-	return &MockMatrixHandlerInterface_HealthCheck_Call{Call: _e.mock.On("HealthCheck", w, r)}
+func (_e *MockMatrixHandlerInterface_Expecter) ListMatrixOperations(w interface{}, r interface{}) *MockMatrixHandlerInterface_ListMatrixOperations_Call {
+	return &MockMatrixHandlerInterface_ListMatrixOperations_Call{Call: _e.mock.On("ListMatrixOperations", w, r)}
 }
 
-func (_c *MockMatrixHandlerInterface_HealthCheck_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_HealthCheck_Call {
+func (_c *MockMatrixHandlerInterface_ListMatrixOperations_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ListMatrixOperations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 http.ResponseWriter
 		if args[0] != nil {
@@ -73,35 +73,35 @@ func (_c *MockMatrixHandlerInterface_HealthCheck_Call) Run(run func(w http.Respo
 	return _c
 }
 
-func (_c *MockMatrixHandlerInterface_HealthCheck_Call) Return() *MockMatrixHandlerInterface_HealthCheck_Call {
+func (_c *MockMatrixHandlerInterface_ListMatrixOperations_Call) Return() *MockMatrixHandlerInterface_ListMatrixOperations_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockMatrixHandlerInterface_HealthCheck_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_HealthCheck_Call {
+func (_c *MockMatrixHandlerInterface_ListMatrixOperations_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ListMatrixOperations_Call {
 	_c.Run(run)
 	return _c
 }
 
-// ListMatrixOperations provides a mock function for the type MockMatrixHandlerInterface
-func (_mock *MockMatrixHandlerInterface) ListMatrixOperations(w http.ResponseWriter, r *http.Request) {
+// ProcessArchive provides a mock function for the type MockMatrixHandlerInterface
+func (_mock *MockMatrixHandlerInterface) ProcessArchive(w http.ResponseWriter, r *http.Request) {
 	_mock.Called(w, r)
 	return
 }
 
-// MockMatrixHandlerInterface_ListMatrixOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatrixOperations'
-type MockMatrixHandlerInterface_ListMatrixOperations_Call struct {
+// MockMatrixHandlerInterface_ProcessArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessArchive'
+type MockMatrixHandlerInterface_ProcessArchive_Call struct {
 	*mock.Call
 }
 
-// ListMatrixOperations is a helper method to define mock.On call
+// ProcessArchive is a helper method to define mock.On call
 //   - w http.ResponseWriter
 //   - r *http.Request
-func (_e *MockMatrixHandlerInterface_Expecter) ListMatrixOperations(w interface{}, r interface{}) *MockMatrixHandlerInterface_ListMatrixOperations_Call {
-	return &MockMatrixHandlerInterface_ListMatrixOperations_Call{Call: _e.mock.On("ListMatrixOperations", w, r)}
+func (_e *MockMatrixHandlerInterface_Expecter) ProcessArchive(w interface{}, r interface{}) *MockMatrixHandlerInterface_ProcessArchive_Call {
+	return &MockMatrixHandlerInterface_ProcessArchive_Call{Call: _e.mock.On("ProcessArchive", w, r)}
 }
 
-func (_c *MockMatrixHandlerInterface_ListMatrixOperations_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ListMatrixOperations_Call {
+func (_c *MockMatrixHandlerInterface_ProcessArchive_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ProcessArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 http.ResponseWriter
 		if args[0] != nil {
@@ -119,12 +119,58 @@ func (_c *MockMatrixHandlerInterface_ListMatrixOperations_Call) Run(run func(w h
 	return _c
 }
 
-func (_c *MockMatrixHandlerInterface_ListMatrixOperations_Call) Return() *MockMatrixHandlerInterface_ListMatrixOperations_Call {
+func (_c *MockMatrixHandlerInterface_ProcessArchive_Call) Return() *MockMatrixHandlerInterface_ProcessArchive_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockMatrixHandlerInterface_ListMatrixOperations_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ListMatrixOperations_Call {
+func (_c *MockMatrixHandlerInterface_ProcessArchive_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ProcessArchive_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ProcessForm provides a mock function for the type MockMatrixHandlerInterface
+func (_mock *MockMatrixHandlerInterface) ProcessForm(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockMatrixHandlerInterface_ProcessForm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessForm'
+type MockMatrixHandlerInterface_ProcessForm_Call struct {
+	*mock.Call
+}
+
+// ProcessForm is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockMatrixHandlerInterface_Expecter) ProcessForm(w interface{}, r interface{}) *MockMatrixHandlerInterface_ProcessForm_Call {
+	return &MockMatrixHandlerInterface_ProcessForm_Call{Call: _e.mock.On("ProcessForm", w, r)}
+}
+
+func (_c *MockMatrixHandlerInterface_ProcessForm_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ProcessForm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixHandlerInterface_ProcessForm_Call) Return() *MockMatrixHandlerInterface_ProcessForm_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMatrixHandlerInterface_ProcessForm_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_ProcessForm_Call {
 	_c.Run(run)
 	return _c
 }
@@ -174,3 +220,49 @@ func (_c *MockMatrixHandlerInterface_ProcessMatrix_Call) RunAndReturn(run func(w
 	_c.Run(run)
 	return _c
 }
+
+// SplitMatrix provides a mock function for the type MockMatrixHandlerInterface
+func (_mock *MockMatrixHandlerInterface) SplitMatrix(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockMatrixHandlerInterface_SplitMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitMatrix'
+type MockMatrixHandlerInterface_SplitMatrix_Call struct {
+	*mock.Call
+}
+
+// SplitMatrix is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockMatrixHandlerInterface_Expecter) SplitMatrix(w interface{}, r interface{}) *MockMatrixHandlerInterface_SplitMatrix_Call {
+	return &MockMatrixHandlerInterface_SplitMatrix_Call{Call: _e.mock.On("SplitMatrix", w, r)}
+}
+
+func (_c *MockMatrixHandlerInterface_SplitMatrix_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_SplitMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixHandlerInterface_SplitMatrix_Call) Return() *MockMatrixHandlerInterface_SplitMatrix_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMatrixHandlerInterface_SplitMatrix_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockMatrixHandlerInterface_SplitMatrix_Call {
+	_c.Run(run)
+	return _c
+}
@@ -38,6 +38,52 @@ func (_m *MockMatrixOperationsDomainInterface) EXPECT() *MockMatrixOperationsDom
 	return &MockMatrixOperationsDomainInterface_Expecter{mock: &_m.Mock}
 }
 
+// DescribeOperations provides a mock function for the type MockMatrixOperationsDomainInterface
+func (_mock *MockMatrixOperationsDomainInterface) DescribeOperations() []entity.OperationInfo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeOperations")
+	}
+
+	var r0 []entity.OperationInfo
+	if returnFunc, ok := ret.Get(0).(func() []entity.OperationInfo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OperationInfo)
+		}
+	}
+	return r0
+}
+
+// MockMatrixOperationsDomainInterface_DescribeOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeOperations'
+type MockMatrixOperationsDomainInterface_DescribeOperations_Call struct {
+	*mock.Call
+}
+
+// DescribeOperations is a helper method to define mock.On call
+func (_e *MockMatrixOperationsDomainInterface_Expecter) DescribeOperations() *MockMatrixOperationsDomainInterface_DescribeOperations_Call {
+	return &MockMatrixOperationsDomainInterface_DescribeOperations_Call{Call: _e.mock.On("DescribeOperations")}
+}
+
+func (_c *MockMatrixOperationsDomainInterface_DescribeOperations_Call) Run(run func()) *MockMatrixOperationsDomainInterface_DescribeOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMatrixOperationsDomainInterface_DescribeOperations_Call) Return(vs []entity.OperationInfo) *MockMatrixOperationsDomainInterface_DescribeOperations_Call {
+	_c.Call.Return(vs)
+	return _c
+}
+
+func (_c *MockMatrixOperationsDomainInterface_DescribeOperations_Call) RunAndReturn(run func() []entity.OperationInfo) *MockMatrixOperationsDomainInterface_DescribeOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsValidOperation provides a mock function for the type MockMatrixOperationsDomainInterface
 func (_mock *MockMatrixOperationsDomainInterface) IsValidOperation(ctx context.Context, operation string) error {
 	ret := _mock.Called(ctx, operation)
@@ -141,25 +187,107 @@ func (_c *MockMatrixOperationsDomainInterface_ListOperations_Call) RunAndReturn(
 	return _c
 }
 
+// PlanOperation provides a mock function for the type MockMatrixOperationsDomainInterface
+func (_mock *MockMatrixOperationsDomainInterface) PlanOperation(ctx context.Context, operation string, rows int, cols int) (*entity.OperationPlan, error) {
+	ret := _mock.Called(ctx, operation, rows, cols)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanOperation")
+	}
+
+	var r0 *entity.OperationPlan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) (*entity.OperationPlan, error)); ok {
+		return returnFunc(ctx, operation, rows, cols)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) *entity.OperationPlan); ok {
+		r0 = returnFunc(ctx, operation, rows, cols)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OperationPlan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, operation, rows, cols)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixOperationsDomainInterface_PlanOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanOperation'
+type MockMatrixOperationsDomainInterface_PlanOperation_Call struct {
+	*mock.Call
+}
+
+// PlanOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - rows int
+//   - cols int
+func (_e *MockMatrixOperationsDomainInterface_Expecter) PlanOperation(ctx interface{}, operation interface{}, rows interface{}, cols interface{}) *MockMatrixOperationsDomainInterface_PlanOperation_Call {
+	return &MockMatrixOperationsDomainInterface_PlanOperation_Call{Call: _e.mock.On("PlanOperation", ctx, operation, rows, cols)}
+}
+
+func (_c *MockMatrixOperationsDomainInterface_PlanOperation_Call) Run(run func(ctx context.Context, operation string, rows int, cols int)) *MockMatrixOperationsDomainInterface_PlanOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixOperationsDomainInterface_PlanOperation_Call) Return(v *entity.OperationPlan, err error) *MockMatrixOperationsDomainInterface_PlanOperation_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockMatrixOperationsDomainInterface_PlanOperation_Call) RunAndReturn(run func(ctx context.Context, operation string, rows int, cols int) (*entity.OperationPlan, error)) *MockMatrixOperationsDomainInterface_PlanOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RunOperation provides a mock function for the type MockMatrixOperationsDomainInterface
-func (_mock *MockMatrixOperationsDomainInterface) RunOperation(ctx context.Context, matrix *entity.Matrix, operation string) (string, error) {
+func (_mock *MockMatrixOperationsDomainInterface) RunOperation(ctx context.Context, matrix *entity.Matrix[int64], operation string) (*entity.Result, error) {
 	ret := _mock.Called(ctx, matrix, operation)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RunOperation")
 	}
 
-	var r0 string
+	var r0 *entity.Result
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Matrix, string) (string, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Matrix[int64], string) (*entity.Result, error)); ok {
 		return returnFunc(ctx, matrix, operation)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Matrix, string) string); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Matrix[int64], string) *entity.Result); ok {
 		r0 = returnFunc(ctx, matrix, operation)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Result)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.Matrix, string) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.Matrix[int64], string) error); ok {
 		r1 = returnFunc(ctx, matrix, operation)
 	} else {
 		r1 = ret.Error(1)
@@ -174,21 +302,21 @@ type MockMatrixOperationsDomainInterface_RunOperation_Call struct {
 
 // RunOperation is a helper method to define mock.On call
 //   - ctx context.Context
-//   - matrix *entity.Matrix
+//   - matrix *entity.Matrix[int64]
 //   - operation string
 func (_e *MockMatrixOperationsDomainInterface_Expecter) RunOperation(ctx interface{}, matrix interface{}, operation interface{}) *MockMatrixOperationsDomainInterface_RunOperation_Call {
 	return &MockMatrixOperationsDomainInterface_RunOperation_Call{Call: _e.mock.On("RunOperation", ctx, matrix, operation)}
 }
 
-func (_c *MockMatrixOperationsDomainInterface_RunOperation_Call) Run(run func(ctx context.Context, matrix *entity.Matrix, operation string)) *MockMatrixOperationsDomainInterface_RunOperation_Call {
+func (_c *MockMatrixOperationsDomainInterface_RunOperation_Call) Run(run func(ctx context.Context, matrix *entity.Matrix[int64], operation string)) *MockMatrixOperationsDomainInterface_RunOperation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *entity.Matrix
+		var arg1 *entity.Matrix[int64]
 		if args[1] != nil {
-			arg1 = args[1].(*entity.Matrix)
+			arg1 = args[1].(*entity.Matrix[int64])
 		}
 		var arg2 string
 		if args[2] != nil {
@@ -203,12 +331,12 @@ func (_c *MockMatrixOperationsDomainInterface_RunOperation_Call) Run(run func(ct
 	return _c
 }
 
-func (_c *MockMatrixOperationsDomainInterface_RunOperation_Call) Return(s string, err error) *MockMatrixOperationsDomainInterface_RunOperation_Call {
-	_c.Call.Return(s, err)
+func (_c *MockMatrixOperationsDomainInterface_RunOperation_Call) Return(result *entity.Result, err error) *MockMatrixOperationsDomainInterface_RunOperation_Call {
+	_c.Call.Return(result, err)
 	return _c
 }
 
-func (_c *MockMatrixOperationsDomainInterface_RunOperation_Call) RunAndReturn(run func(ctx context.Context, matrix *entity.Matrix, operation string) (string, error)) *MockMatrixOperationsDomainInterface_RunOperation_Call {
+func (_c *MockMatrixOperationsDomainInterface_RunOperation_Call) RunAndReturn(run func(ctx context.Context, matrix *entity.Matrix[int64], operation string) (*entity.Result, error)) *MockMatrixOperationsDomainInterface_RunOperation_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,6 +6,8 @@ package mocks
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/matsuboshi/league-matrix-app/internal/repository"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +40,123 @@ func (_m *MockMatrixRepositoryInterface) EXPECT() *MockMatrixRepositoryInterface
 	return &MockMatrixRepositoryInterface_Expecter{mock: &_m.Mock}
 }
 
+// CheckHealth provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) CheckHealth(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixRepositoryInterface_CheckHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckHealth'
+type MockMatrixRepositoryInterface_CheckHealth_Call struct {
+	*mock.Call
+}
+
+// CheckHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMatrixRepositoryInterface_Expecter) CheckHealth(ctx interface{}) *MockMatrixRepositoryInterface_CheckHealth_Call {
+	return &MockMatrixRepositoryInterface_CheckHealth_Call{Call: _e.mock.On("CheckHealth", ctx)}
+}
+
+func (_c *MockMatrixRepositoryInterface_CheckHealth_Call) Run(run func(ctx context.Context)) *MockMatrixRepositoryInterface_CheckHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_CheckHealth_Call) Return(err error) *MockMatrixRepositoryInterface_CheckHealth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_CheckHealth_Call) RunAndReturn(run func(ctx context.Context) error) *MockMatrixRepositoryInterface_CheckHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileModTime provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileModTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixRepositoryInterface_FileModTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileModTime'
+type MockMatrixRepositoryInterface_FileModTime_Call struct {
+	*mock.Call
+}
+
+// FileModTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockMatrixRepositoryInterface_Expecter) FileModTime(ctx interface{}, filePath interface{}) *MockMatrixRepositoryInterface_FileModTime_Call {
+	return &MockMatrixRepositoryInterface_FileModTime_Call{Call: _e.mock.On("FileModTime", ctx, filePath)}
+}
+
+func (_c *MockMatrixRepositoryInterface_FileModTime_Call) Run(run func(ctx context.Context, filePath string)) *MockMatrixRepositoryInterface_FileModTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_FileModTime_Call) Return(time1 time.Time, err error) *MockMatrixRepositoryInterface_FileModTime_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_FileModTime_Call) RunAndReturn(run func(ctx context.Context, filePath string) (time.Time, error)) *MockMatrixRepositoryInterface_FileModTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFileContent provides a mock function for the type MockMatrixRepositoryInterface
 func (_mock *MockMatrixRepositoryInterface) GetFileContent(ctx context.Context, filePath string) (*repository.MatrixFileContent, error) {
 	ret := _mock.Called(ctx, filePath)
@@ -105,3 +224,388 @@ func (_c *MockMatrixRepositoryInterface_GetFileContent_Call) RunAndReturn(run fu
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListFiles provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) ListFiles(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFiles")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixRepositoryInterface_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
+type MockMatrixRepositoryInterface_ListFiles_Call struct {
+	*mock.Call
+}
+
+// ListFiles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMatrixRepositoryInterface_Expecter) ListFiles(ctx interface{}) *MockMatrixRepositoryInterface_ListFiles_Call {
+	return &MockMatrixRepositoryInterface_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx)}
+}
+
+func (_c *MockMatrixRepositoryInterface_ListFiles_Call) Run(run func(ctx context.Context)) *MockMatrixRepositoryInterface_ListFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_ListFiles_Call) Return(strings []string, err error) *MockMatrixRepositoryInterface_ListFiles_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_ListFiles_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockMatrixRepositoryInterface_ListFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadFile provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadFile")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixRepositoryInterface_ReadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadFile'
+type MockMatrixRepositoryInterface_ReadFile_Call struct {
+	*mock.Call
+}
+
+// ReadFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockMatrixRepositoryInterface_Expecter) ReadFile(ctx interface{}, filePath interface{}) *MockMatrixRepositoryInterface_ReadFile_Call {
+	return &MockMatrixRepositoryInterface_ReadFile_Call{Call: _e.mock.On("ReadFile", ctx, filePath)}
+}
+
+func (_c *MockMatrixRepositoryInterface_ReadFile_Call) Run(run func(ctx context.Context, filePath string)) *MockMatrixRepositoryInterface_ReadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_ReadFile_Call) Return(bytes []byte, err error) *MockMatrixRepositoryInterface_ReadFile_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_ReadFile_Call) RunAndReturn(run func(ctx context.Context, filePath string) ([]byte, error)) *MockMatrixRepositoryInterface_ReadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveFileContent provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) SaveFileContent(ctx context.Context, filePath string, content *repository.MatrixFileContent) error {
+	ret := _mock.Called(ctx, filePath, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFileContent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *repository.MatrixFileContent) error); ok {
+		r0 = returnFunc(ctx, filePath, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixRepositoryInterface_SaveFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveFileContent'
+type MockMatrixRepositoryInterface_SaveFileContent_Call struct {
+	*mock.Call
+}
+
+// SaveFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - content *repository.MatrixFileContent
+func (_e *MockMatrixRepositoryInterface_Expecter) SaveFileContent(ctx interface{}, filePath interface{}, content interface{}) *MockMatrixRepositoryInterface_SaveFileContent_Call {
+	return &MockMatrixRepositoryInterface_SaveFileContent_Call{Call: _e.mock.On("SaveFileContent", ctx, filePath, content)}
+}
+
+func (_c *MockMatrixRepositoryInterface_SaveFileContent_Call) Run(run func(ctx context.Context, filePath string, content *repository.MatrixFileContent)) *MockMatrixRepositoryInterface_SaveFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *repository.MatrixFileContent
+		if args[2] != nil {
+			arg2 = args[2].(*repository.MatrixFileContent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_SaveFileContent_Call) Return(err error) *MockMatrixRepositoryInterface_SaveFileContent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_SaveFileContent_Call) RunAndReturn(run func(ctx context.Context, filePath string, content *repository.MatrixFileContent) error) *MockMatrixRepositoryInterface_SaveFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StatFile provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) StatFile(ctx context.Context, filePath string) (repository.FileStat, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatFile")
+	}
+
+	var r0 repository.FileStat
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.FileStat, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.FileStat); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		r0 = ret.Get(0).(repository.FileStat)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixRepositoryInterface_StatFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StatFile'
+type MockMatrixRepositoryInterface_StatFile_Call struct {
+	*mock.Call
+}
+
+// StatFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockMatrixRepositoryInterface_Expecter) StatFile(ctx interface{}, filePath interface{}) *MockMatrixRepositoryInterface_StatFile_Call {
+	return &MockMatrixRepositoryInterface_StatFile_Call{Call: _e.mock.On("StatFile", ctx, filePath)}
+}
+
+func (_c *MockMatrixRepositoryInterface_StatFile_Call) Run(run func(ctx context.Context, filePath string)) *MockMatrixRepositoryInterface_StatFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_StatFile_Call) Return(fileStat repository.FileStat, err error) *MockMatrixRepositoryInterface_StatFile_Call {
+	_c.Call.Return(fileStat, err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_StatFile_Call) RunAndReturn(run func(ctx context.Context, filePath string) (repository.FileStat, error)) *MockMatrixRepositoryInterface_StatFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamContent provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) StreamContent(ctx context.Context, r io.Reader, handleRow repository.RowHandler) error {
+	ret := _mock.Called(ctx, r, handleRow)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamContent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader, repository.RowHandler) error); ok {
+		r0 = returnFunc(ctx, r, handleRow)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixRepositoryInterface_StreamContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamContent'
+type MockMatrixRepositoryInterface_StreamContent_Call struct {
+	*mock.Call
+}
+
+// StreamContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r io.Reader
+//   - handleRow repository.RowHandler
+func (_e *MockMatrixRepositoryInterface_Expecter) StreamContent(ctx interface{}, r interface{}, handleRow interface{}) *MockMatrixRepositoryInterface_StreamContent_Call {
+	return &MockMatrixRepositoryInterface_StreamContent_Call{Call: _e.mock.On("StreamContent", ctx, r, handleRow)}
+}
+
+func (_c *MockMatrixRepositoryInterface_StreamContent_Call) Run(run func(ctx context.Context, r io.Reader, handleRow repository.RowHandler)) *MockMatrixRepositoryInterface_StreamContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Reader
+		if args[1] != nil {
+			arg1 = args[1].(io.Reader)
+		}
+		var arg2 repository.RowHandler
+		if args[2] != nil {
+			arg2 = args[2].(repository.RowHandler)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_StreamContent_Call) Return(err error) *MockMatrixRepositoryInterface_StreamContent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_StreamContent_Call) RunAndReturn(run func(ctx context.Context, r io.Reader, handleRow repository.RowHandler) error) *MockMatrixRepositoryInterface_StreamContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamFileContent provides a mock function for the type MockMatrixRepositoryInterface
+func (_mock *MockMatrixRepositoryInterface) StreamFileContent(ctx context.Context, filePath string, handleRow repository.RowHandler) error {
+	ret := _mock.Called(ctx, filePath, handleRow)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamFileContent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, repository.RowHandler) error); ok {
+		r0 = returnFunc(ctx, filePath, handleRow)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixRepositoryInterface_StreamFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamFileContent'
+type MockMatrixRepositoryInterface_StreamFileContent_Call struct {
+	*mock.Call
+}
+
+// StreamFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - handleRow repository.RowHandler
+func (_e *MockMatrixRepositoryInterface_Expecter) StreamFileContent(ctx interface{}, filePath interface{}, handleRow interface{}) *MockMatrixRepositoryInterface_StreamFileContent_Call {
+	return &MockMatrixRepositoryInterface_StreamFileContent_Call{Call: _e.mock.On("StreamFileContent", ctx, filePath, handleRow)}
+}
+
+func (_c *MockMatrixRepositoryInterface_StreamFileContent_Call) Run(run func(ctx context.Context, filePath string, handleRow repository.RowHandler)) *MockMatrixRepositoryInterface_StreamFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 repository.RowHandler
+		if args[2] != nil {
+			arg2 = args[2].(repository.RowHandler)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_StreamFileContent_Call) Return(err error) *MockMatrixRepositoryInterface_StreamFileContent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixRepositoryInterface_StreamFileContent_Call) RunAndReturn(run func(ctx context.Context, filePath string, handleRow repository.RowHandler) error) *MockMatrixRepositoryInterface_StreamFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
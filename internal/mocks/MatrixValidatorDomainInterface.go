@@ -9,6 +9,7 @@ import (
 
 	"github.com/matsuboshi/league-matrix-app/internal/entity"
 	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	"github.com/matsuboshi/league-matrix-app/pkg/matrix"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -40,23 +41,23 @@ func (_m *MockMatrixValidatorDomainInterface) EXPECT() *MockMatrixValidatorDomai
 }
 
 // Validate provides a mock function for the type MockMatrixValidatorDomainInterface
-func (_mock *MockMatrixValidatorDomainInterface) Validate(ctx context.Context, matrix *repository.MatrixFileContent) (*entity.Matrix, error) {
+func (_mock *MockMatrixValidatorDomainInterface) Validate(ctx context.Context, matrix *repository.MatrixFileContent) (*entity.Matrix[int64], error) {
 	ret := _mock.Called(ctx, matrix)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Validate")
 	}
 
-	var r0 *entity.Matrix
+	var r0 *entity.Matrix[int64]
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.MatrixFileContent) (*entity.Matrix, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.MatrixFileContent) (*entity.Matrix[int64], error)); ok {
 		return returnFunc(ctx, matrix)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.MatrixFileContent) *entity.Matrix); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *repository.MatrixFileContent) *entity.Matrix[int64]); ok {
 		r0 = returnFunc(ctx, matrix)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Matrix)
+			r0 = ret.Get(0).(*entity.Matrix[int64])
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, *repository.MatrixFileContent) error); ok {
@@ -97,12 +98,12 @@ func (_c *MockMatrixValidatorDomainInterface_Validate_Call) Run(run func(ctx con
 	return _c
 }
 
-func (_c *MockMatrixValidatorDomainInterface_Validate_Call) Return(matrix1 *entity.Matrix, err error) *MockMatrixValidatorDomainInterface_Validate_Call {
-	_c.Call.Return(matrix1, err)
+func (_c *MockMatrixValidatorDomainInterface_Validate_Call) Return(v *entity.Matrix[int64], err error) *MockMatrixValidatorDomainInterface_Validate_Call {
+	_c.Call.Return(v, err)
 	return _c
 }
 
-func (_c *MockMatrixValidatorDomainInterface_Validate_Call) RunAndReturn(run func(ctx context.Context, matrix *repository.MatrixFileContent) (*entity.Matrix, error)) *MockMatrixValidatorDomainInterface_Validate_Call {
+func (_c *MockMatrixValidatorDomainInterface_Validate_Call) RunAndReturn(run func(ctx context.Context, matrix *repository.MatrixFileContent) (*entity.Matrix[int64], error)) *MockMatrixValidatorDomainInterface_Validate_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -163,3 +164,254 @@ func (_c *MockMatrixValidatorDomainInterface_ValidateFilePath_Call) RunAndReturn
 	_c.Call.Return(run)
 	return _c
 }
+
+// ValidateFileRef provides a mock function for the type MockMatrixValidatorDomainInterface
+func (_mock *MockMatrixValidatorDomainInterface) ValidateFileRef(ctx context.Context, ref string) error {
+	ret := _mock.Called(ctx, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateFileRef")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, ref)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixValidatorDomainInterface_ValidateFileRef_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateFileRef'
+type MockMatrixValidatorDomainInterface_ValidateFileRef_Call struct {
+	*mock.Call
+}
+
+// ValidateFileRef is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ref string
+func (_e *MockMatrixValidatorDomainInterface_Expecter) ValidateFileRef(ctx interface{}, ref interface{}) *MockMatrixValidatorDomainInterface_ValidateFileRef_Call {
+	return &MockMatrixValidatorDomainInterface_ValidateFileRef_Call{Call: _e.mock.On("ValidateFileRef", ctx, ref)}
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateFileRef_Call) Run(run func(ctx context.Context, ref string)) *MockMatrixValidatorDomainInterface_ValidateFileRef_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateFileRef_Call) Return(err error) *MockMatrixValidatorDomainInterface_ValidateFileRef_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateFileRef_Call) RunAndReturn(run func(ctx context.Context, ref string) error) *MockMatrixValidatorDomainInterface_ValidateFileRef_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateMatrix provides a mock function for the type MockMatrixValidatorDomainInterface
+func (_mock *MockMatrixValidatorDomainInterface) ValidateMatrix(ctx context.Context, matrix *entity.Matrix[int64]) error {
+	ret := _mock.Called(ctx, matrix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateMatrix")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Matrix[int64]) error); ok {
+		r0 = returnFunc(ctx, matrix)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixValidatorDomainInterface_ValidateMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateMatrix'
+type MockMatrixValidatorDomainInterface_ValidateMatrix_Call struct {
+	*mock.Call
+}
+
+// ValidateMatrix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - matrix *entity.Matrix[int64]
+func (_e *MockMatrixValidatorDomainInterface_Expecter) ValidateMatrix(ctx interface{}, matrix interface{}) *MockMatrixValidatorDomainInterface_ValidateMatrix_Call {
+	return &MockMatrixValidatorDomainInterface_ValidateMatrix_Call{Call: _e.mock.On("ValidateMatrix", ctx, matrix)}
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateMatrix_Call) Run(run func(ctx context.Context, matrix *entity.Matrix[int64])) *MockMatrixValidatorDomainInterface_ValidateMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Matrix[int64]
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Matrix[int64])
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateMatrix_Call) Return(err error) *MockMatrixValidatorDomainInterface_ValidateMatrix_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateMatrix_Call) RunAndReturn(run func(ctx context.Context, matrix *entity.Matrix[int64]) error) *MockMatrixValidatorDomainInterface_ValidateMatrix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateRow provides a mock function for the type MockMatrixValidatorDomainInterface
+func (_mock *MockMatrixValidatorDomainInterface) ValidateRow(ctx context.Context, matrix *entity.Matrix[int64], row []string) error {
+	ret := _mock.Called(ctx, matrix, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateRow")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Matrix[int64], []string) error); ok {
+		r0 = returnFunc(ctx, matrix, row)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMatrixValidatorDomainInterface_ValidateRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateRow'
+type MockMatrixValidatorDomainInterface_ValidateRow_Call struct {
+	*mock.Call
+}
+
+// ValidateRow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - matrix *entity.Matrix[int64]
+//   - row []string
+func (_e *MockMatrixValidatorDomainInterface_Expecter) ValidateRow(ctx interface{}, matrix interface{}, row interface{}) *MockMatrixValidatorDomainInterface_ValidateRow_Call {
+	return &MockMatrixValidatorDomainInterface_ValidateRow_Call{Call: _e.mock.On("ValidateRow", ctx, matrix, row)}
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateRow_Call) Run(run func(ctx context.Context, matrix *entity.Matrix[int64], row []string)) *MockMatrixValidatorDomainInterface_ValidateRow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Matrix[int64]
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Matrix[int64])
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateRow_Call) Return(err error) *MockMatrixValidatorDomainInterface_ValidateRow_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateRow_Call) RunAndReturn(run func(ctx context.Context, matrix *entity.Matrix[int64], row []string) error) *MockMatrixValidatorDomainInterface_ValidateRow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateStreamRow provides a mock function for the type MockMatrixValidatorDomainInterface
+func (_mock *MockMatrixValidatorDomainInterface) ValidateStreamRow(ctx context.Context, stream *matrix.Stream[int64], row []string) ([]int64, error) {
+	ret := _mock.Called(ctx, stream, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateStreamRow")
+	}
+
+	var r0 []int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *matrix.Stream[int64], []string) ([]int64, error)); ok {
+		return returnFunc(ctx, stream, row)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *matrix.Stream[int64], []string) []int64); ok {
+		r0 = returnFunc(ctx, stream, row)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *matrix.Stream[int64], []string) error); ok {
+		r1 = returnFunc(ctx, stream, row)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMatrixValidatorDomainInterface_ValidateStreamRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateStreamRow'
+type MockMatrixValidatorDomainInterface_ValidateStreamRow_Call struct {
+	*mock.Call
+}
+
+// ValidateStreamRow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stream *matrix.Stream[int64]
+//   - row []string
+func (_e *MockMatrixValidatorDomainInterface_Expecter) ValidateStreamRow(ctx interface{}, stream interface{}, row interface{}) *MockMatrixValidatorDomainInterface_ValidateStreamRow_Call {
+	return &MockMatrixValidatorDomainInterface_ValidateStreamRow_Call{Call: _e.mock.On("ValidateStreamRow", ctx, stream, row)}
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateStreamRow_Call) Run(run func(ctx context.Context, stream *matrix.Stream[int64], row []string)) *MockMatrixValidatorDomainInterface_ValidateStreamRow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *matrix.Stream[int64]
+		if args[1] != nil {
+			arg1 = args[1].(*matrix.Stream[int64])
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateStreamRow_Call) Return(int64s []int64, err error) *MockMatrixValidatorDomainInterface_ValidateStreamRow_Call {
+	_c.Call.Return(int64s, err)
+	return _c
+}
+
+func (_c *MockMatrixValidatorDomainInterface_ValidateStreamRow_Call) RunAndReturn(run func(ctx context.Context, stream *matrix.Stream[int64], row []string) ([]int64, error)) *MockMatrixValidatorDomainInterface_ValidateStreamRow_Call {
+	_c.Call.Return(run)
+	return _c
+}
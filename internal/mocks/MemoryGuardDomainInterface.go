@@ -0,0 +1,133 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMemoryGuardDomainInterface creates a new instance of MockMemoryGuardDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMemoryGuardDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMemoryGuardDomainInterface {
+	mock := &MockMemoryGuardDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMemoryGuardDomainInterface is an autogenerated mock type for the MemoryGuardDomainInterface type
+type MockMemoryGuardDomainInterface struct {
+	mock.Mock
+}
+
+type MockMemoryGuardDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMemoryGuardDomainInterface) EXPECT() *MockMemoryGuardDomainInterface_Expecter {
+	return &MockMemoryGuardDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Admit provides a mock function for the type MockMemoryGuardDomainInterface
+func (_mock *MockMemoryGuardDomainInterface) Admit(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Admit")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMemoryGuardDomainInterface_Admit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Admit'
+type MockMemoryGuardDomainInterface_Admit_Call struct {
+	*mock.Call
+}
+
+// Admit is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMemoryGuardDomainInterface_Expecter) Admit(ctx interface{}) *MockMemoryGuardDomainInterface_Admit_Call {
+	return &MockMemoryGuardDomainInterface_Admit_Call{Call: _e.mock.On("Admit", ctx)}
+}
+
+func (_c *MockMemoryGuardDomainInterface_Admit_Call) Run(run func(ctx context.Context)) *MockMemoryGuardDomainInterface_Admit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemoryGuardDomainInterface_Admit_Call) Return(err error) *MockMemoryGuardDomainInterface_Admit_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMemoryGuardDomainInterface_Admit_Call) RunAndReturn(run func(ctx context.Context) error) *MockMemoryGuardDomainInterface_Admit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enabled provides a mock function for the type MockMemoryGuardDomainInterface
+func (_mock *MockMemoryGuardDomainInterface) Enabled() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enabled")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockMemoryGuardDomainInterface_Enabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enabled'
+type MockMemoryGuardDomainInterface_Enabled_Call struct {
+	*mock.Call
+}
+
+// Enabled is a helper method to define mock.On call
+func (_e *MockMemoryGuardDomainInterface_Expecter) Enabled() *MockMemoryGuardDomainInterface_Enabled_Call {
+	return &MockMemoryGuardDomainInterface_Enabled_Call{Call: _e.mock.On("Enabled")}
+}
+
+func (_c *MockMemoryGuardDomainInterface_Enabled_Call) Run(run func()) *MockMemoryGuardDomainInterface_Enabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMemoryGuardDomainInterface_Enabled_Call) Return(b bool) *MockMemoryGuardDomainInterface_Enabled_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockMemoryGuardDomainInterface_Enabled_Call) RunAndReturn(run func() bool) *MockMemoryGuardDomainInterface_Enabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
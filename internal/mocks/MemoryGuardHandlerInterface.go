@@ -0,0 +1,91 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMemoryGuardHandlerInterface creates a new instance of MockMemoryGuardHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMemoryGuardHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMemoryGuardHandlerInterface {
+	mock := &MockMemoryGuardHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMemoryGuardHandlerInterface is an autogenerated mock type for the MemoryGuardHandlerInterface type
+type MockMemoryGuardHandlerInterface struct {
+	mock.Mock
+}
+
+type MockMemoryGuardHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMemoryGuardHandlerInterface) EXPECT() *MockMemoryGuardHandlerInterface_Expecter {
+	return &MockMemoryGuardHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Guard provides a mock function for the type MockMemoryGuardHandlerInterface
+func (_mock *MockMemoryGuardHandlerInterface) Guard(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Guard")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockMemoryGuardHandlerInterface_Guard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Guard'
+type MockMemoryGuardHandlerInterface_Guard_Call struct {
+	*mock.Call
+}
+
+// Guard is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockMemoryGuardHandlerInterface_Expecter) Guard(next interface{}) *MockMemoryGuardHandlerInterface_Guard_Call {
+	return &MockMemoryGuardHandlerInterface_Guard_Call{Call: _e.mock.On("Guard", next)}
+}
+
+func (_c *MockMemoryGuardHandlerInterface_Guard_Call) Run(run func(next http.Handler)) *MockMemoryGuardHandlerInterface_Guard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemoryGuardHandlerInterface_Guard_Call) Return(handler http.Handler) *MockMemoryGuardHandlerInterface_Guard_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockMemoryGuardHandlerInterface_Guard_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockMemoryGuardHandlerInterface_Guard_Call {
+	_c.Call.Return(run)
+	return _c
+}
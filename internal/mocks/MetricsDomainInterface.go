@@ -0,0 +1,287 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMetricsDomainInterface creates a new instance of MockMetricsDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMetricsDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMetricsDomainInterface {
+	mock := &MockMetricsDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMetricsDomainInterface is an autogenerated mock type for the MetricsDomainInterface type
+type MockMetricsDomainInterface struct {
+	mock.Mock
+}
+
+type MockMetricsDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMetricsDomainInterface) EXPECT() *MockMetricsDomainInterface_Expecter {
+	return &MockMetricsDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// RecordCall provides a mock function for the type MockMetricsDomainInterface
+func (_mock *MockMetricsDomainInterface) RecordCall(method string, statusCode int, duration time.Duration) {
+	_mock.Called(method, statusCode, duration)
+	return
+}
+
+// MockMetricsDomainInterface_RecordCall_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordCall'
+type MockMetricsDomainInterface_RecordCall_Call struct {
+	*mock.Call
+}
+
+// RecordCall is a helper method to define mock.On call
+//   - method string
+//   - statusCode int
+//   - duration time.Duration
+func (_e *MockMetricsDomainInterface_Expecter) RecordCall(method interface{}, statusCode interface{}, duration interface{}) *MockMetricsDomainInterface_RecordCall_Call {
+	return &MockMetricsDomainInterface_RecordCall_Call{Call: _e.mock.On("RecordCall", method, statusCode, duration)}
+}
+
+func (_c *MockMetricsDomainInterface_RecordCall_Call) Run(run func(method string, statusCode int, duration time.Duration)) *MockMetricsDomainInterface_RecordCall_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_RecordCall_Call) Return() *MockMetricsDomainInterface_RecordCall_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_RecordCall_Call) RunAndReturn(run func(method string, statusCode int, duration time.Duration)) *MockMetricsDomainInterface_RecordCall_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordOperation provides a mock function for the type MockMetricsDomainInterface
+func (_mock *MockMetricsDomainInterface) RecordOperation(operation string, duration time.Duration, sizes []int) {
+	_mock.Called(operation, duration, sizes)
+	return
+}
+
+// MockMetricsDomainInterface_RecordOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordOperation'
+type MockMetricsDomainInterface_RecordOperation_Call struct {
+	*mock.Call
+}
+
+// RecordOperation is a helper method to define mock.On call
+//   - operation string
+//   - duration time.Duration
+//   - sizes []int
+func (_e *MockMetricsDomainInterface_Expecter) RecordOperation(operation interface{}, duration interface{}, sizes interface{}) *MockMetricsDomainInterface_RecordOperation_Call {
+	return &MockMetricsDomainInterface_RecordOperation_Call{Call: _e.mock.On("RecordOperation", operation, duration, sizes)}
+}
+
+func (_c *MockMetricsDomainInterface_RecordOperation_Call) Run(run func(operation string, duration time.Duration, sizes []int)) *MockMetricsDomainInterface_RecordOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		var arg2 []int
+		if args[2] != nil {
+			arg2 = args[2].([]int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_RecordOperation_Call) Return() *MockMetricsDomainInterface_RecordOperation_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_RecordOperation_Call) RunAndReturn(run func(operation string, duration time.Duration, sizes []int)) *MockMetricsDomainInterface_RecordOperation_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordRequest provides a mock function for the type MockMetricsDomainInterface
+func (_mock *MockMetricsDomainInterface) RecordRequest(method string, route string, statusCode int, duration time.Duration) {
+	_mock.Called(method, route, statusCode, duration)
+	return
+}
+
+// MockMetricsDomainInterface_RecordRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordRequest'
+type MockMetricsDomainInterface_RecordRequest_Call struct {
+	*mock.Call
+}
+
+// RecordRequest is a helper method to define mock.On call
+//   - method string
+//   - route string
+//   - statusCode int
+//   - duration time.Duration
+func (_e *MockMetricsDomainInterface_Expecter) RecordRequest(method interface{}, route interface{}, statusCode interface{}, duration interface{}) *MockMetricsDomainInterface_RecordRequest_Call {
+	return &MockMetricsDomainInterface_RecordRequest_Call{Call: _e.mock.On("RecordRequest", method, route, statusCode, duration)}
+}
+
+func (_c *MockMetricsDomainInterface_RecordRequest_Call) Run(run func(method string, route string, statusCode int, duration time.Duration)) *MockMetricsDomainInterface_RecordRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_RecordRequest_Call) Return() *MockMetricsDomainInterface_RecordRequest_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_RecordRequest_Call) RunAndReturn(run func(method string, route string, statusCode int, duration time.Duration)) *MockMetricsDomainInterface_RecordRequest_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Register provides a mock function for the type MockMetricsDomainInterface
+func (_mock *MockMetricsDomainInterface) Register(collect func() []*entity.Metric) {
+	_mock.Called(collect)
+	return
+}
+
+// MockMetricsDomainInterface_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockMetricsDomainInterface_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - collect func() []*entity.Metric
+func (_e *MockMetricsDomainInterface_Expecter) Register(collect interface{}) *MockMetricsDomainInterface_Register_Call {
+	return &MockMetricsDomainInterface_Register_Call{Call: _e.mock.On("Register", collect)}
+}
+
+func (_c *MockMetricsDomainInterface_Register_Call) Run(run func(collect func() []*entity.Metric)) *MockMetricsDomainInterface_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func() []*entity.Metric
+		if args[0] != nil {
+			arg0 = args[0].(func() []*entity.Metric)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_Register_Call) Return() *MockMetricsDomainInterface_Register_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_Register_Call) RunAndReturn(run func(collect func() []*entity.Metric)) *MockMetricsDomainInterface_Register_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Snapshot provides a mock function for the type MockMetricsDomainInterface
+func (_mock *MockMetricsDomainInterface) Snapshot() *entity.MetricsSnapshot {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Snapshot")
+	}
+
+	var r0 *entity.MetricsSnapshot
+	if returnFunc, ok := ret.Get(0).(func() *entity.MetricsSnapshot); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.MetricsSnapshot)
+		}
+	}
+	return r0
+}
+
+// MockMetricsDomainInterface_Snapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Snapshot'
+type MockMetricsDomainInterface_Snapshot_Call struct {
+	*mock.Call
+}
+
+// Snapshot is a helper method to define mock.On call
+func (_e *MockMetricsDomainInterface_Expecter) Snapshot() *MockMetricsDomainInterface_Snapshot_Call {
+	return &MockMetricsDomainInterface_Snapshot_Call{Call: _e.mock.On("Snapshot")}
+}
+
+func (_c *MockMetricsDomainInterface_Snapshot_Call) Run(run func()) *MockMetricsDomainInterface_Snapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_Snapshot_Call) Return(metricsSnapshot *entity.MetricsSnapshot) *MockMetricsDomainInterface_Snapshot_Call {
+	_c.Call.Return(metricsSnapshot)
+	return _c
+}
+
+func (_c *MockMetricsDomainInterface_Snapshot_Call) RunAndReturn(run func() *entity.MetricsSnapshot) *MockMetricsDomainInterface_Snapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
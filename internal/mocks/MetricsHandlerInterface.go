@@ -0,0 +1,137 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMetricsHandlerInterface creates a new instance of MockMetricsHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMetricsHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMetricsHandlerInterface {
+	mock := &MockMetricsHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMetricsHandlerInterface is an autogenerated mock type for the MetricsHandlerInterface type
+type MockMetricsHandlerInterface struct {
+	mock.Mock
+}
+
+type MockMetricsHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMetricsHandlerInterface) EXPECT() *MockMetricsHandlerInterface_Expecter {
+	return &MockMetricsHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function for the type MockMetricsHandlerInterface
+func (_mock *MockMetricsHandlerInterface) Record(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockMetricsHandlerInterface_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockMetricsHandlerInterface_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockMetricsHandlerInterface_Expecter) Record(next interface{}) *MockMetricsHandlerInterface_Record_Call {
+	return &MockMetricsHandlerInterface_Record_Call{Call: _e.mock.On("Record", next)}
+}
+
+func (_c *MockMetricsHandlerInterface_Record_Call) Run(run func(next http.Handler)) *MockMetricsHandlerInterface_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsHandlerInterface_Record_Call) Return(handler http.Handler) *MockMetricsHandlerInterface_Record_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockMetricsHandlerInterface_Record_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockMetricsHandlerInterface_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ServeMetrics provides a mock function for the type MockMetricsHandlerInterface
+func (_mock *MockMetricsHandlerInterface) ServeMetrics(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockMetricsHandlerInterface_ServeMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServeMetrics'
+type MockMetricsHandlerInterface_ServeMetrics_Call struct {
+	*mock.Call
+}
+
+// ServeMetrics is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockMetricsHandlerInterface_Expecter) ServeMetrics(w interface{}, r interface{}) *MockMetricsHandlerInterface_ServeMetrics_Call {
+	return &MockMetricsHandlerInterface_ServeMetrics_Call{Call: _e.mock.On("ServeMetrics", w, r)}
+}
+
+func (_c *MockMetricsHandlerInterface_ServeMetrics_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockMetricsHandlerInterface_ServeMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsHandlerInterface_ServeMetrics_Call) Return() *MockMetricsHandlerInterface_ServeMetrics_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetricsHandlerInterface_ServeMetrics_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockMetricsHandlerInterface_ServeMetrics_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,197 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOTLPRepositoryInterface creates a new instance of MockOTLPRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOTLPRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOTLPRepositoryInterface {
+	mock := &MockOTLPRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOTLPRepositoryInterface is an autogenerated mock type for the OTLPRepositoryInterface type
+type MockOTLPRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockOTLPRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOTLPRepositoryInterface) EXPECT() *MockOTLPRepositoryInterface_Expecter {
+	return &MockOTLPRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function for the type MockOTLPRepositoryInterface
+func (_mock *MockOTLPRepositoryInterface) Close() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOTLPRepositoryInterface_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockOTLPRepositoryInterface_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockOTLPRepositoryInterface_Expecter) Close() *MockOTLPRepositoryInterface_Close_Call {
+	return &MockOTLPRepositoryInterface_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockOTLPRepositoryInterface_Close_Call) Run(run func()) *MockOTLPRepositoryInterface_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockOTLPRepositoryInterface_Close_Call) Return(err error) *MockOTLPRepositoryInterface_Close_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOTLPRepositoryInterface_Close_Call) RunAndReturn(run func() error) *MockOTLPRepositoryInterface_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportLogs provides a mock function for the type MockOTLPRepositoryInterface
+func (_mock *MockOTLPRepositoryInterface) ExportLogs(ctx context.Context, records []*repository.LogRecord) error {
+	ret := _mock.Called(ctx, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportLogs")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*repository.LogRecord) error); ok {
+		r0 = returnFunc(ctx, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOTLPRepositoryInterface_ExportLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportLogs'
+type MockOTLPRepositoryInterface_ExportLogs_Call struct {
+	*mock.Call
+}
+
+// ExportLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - records []*repository.LogRecord
+func (_e *MockOTLPRepositoryInterface_Expecter) ExportLogs(ctx interface{}, records interface{}) *MockOTLPRepositoryInterface_ExportLogs_Call {
+	return &MockOTLPRepositoryInterface_ExportLogs_Call{Call: _e.mock.On("ExportLogs", ctx, records)}
+}
+
+func (_c *MockOTLPRepositoryInterface_ExportLogs_Call) Run(run func(ctx context.Context, records []*repository.LogRecord)) *MockOTLPRepositoryInterface_ExportLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*repository.LogRecord
+		if args[1] != nil {
+			arg1 = args[1].([]*repository.LogRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOTLPRepositoryInterface_ExportLogs_Call) Return(err error) *MockOTLPRepositoryInterface_ExportLogs_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOTLPRepositoryInterface_ExportLogs_Call) RunAndReturn(run func(ctx context.Context, records []*repository.LogRecord) error) *MockOTLPRepositoryInterface_ExportLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportMetrics provides a mock function for the type MockOTLPRepositoryInterface
+func (_mock *MockOTLPRepositoryInterface) ExportMetrics(ctx context.Context, records []*repository.MetricRecord) error {
+	ret := _mock.Called(ctx, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportMetrics")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*repository.MetricRecord) error); ok {
+		r0 = returnFunc(ctx, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOTLPRepositoryInterface_ExportMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportMetrics'
+type MockOTLPRepositoryInterface_ExportMetrics_Call struct {
+	*mock.Call
+}
+
+// ExportMetrics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - records []*repository.MetricRecord
+func (_e *MockOTLPRepositoryInterface_Expecter) ExportMetrics(ctx interface{}, records interface{}) *MockOTLPRepositoryInterface_ExportMetrics_Call {
+	return &MockOTLPRepositoryInterface_ExportMetrics_Call{Call: _e.mock.On("ExportMetrics", ctx, records)}
+}
+
+func (_c *MockOTLPRepositoryInterface_ExportMetrics_Call) Run(run func(ctx context.Context, records []*repository.MetricRecord)) *MockOTLPRepositoryInterface_ExportMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*repository.MetricRecord
+		if args[1] != nil {
+			arg1 = args[1].([]*repository.MetricRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOTLPRepositoryInterface_ExportMetrics_Call) Return(err error) *MockOTLPRepositoryInterface_ExportMetrics_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOTLPRepositoryInterface_ExportMetrics_Call) RunAndReturn(run func(ctx context.Context, records []*repository.MetricRecord) error) *MockOTLPRepositoryInterface_ExportMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
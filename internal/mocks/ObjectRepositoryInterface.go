@@ -0,0 +1,173 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockObjectRepositoryInterface creates a new instance of MockObjectRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockObjectRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockObjectRepositoryInterface {
+	mock := &MockObjectRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockObjectRepositoryInterface is an autogenerated mock type for the ObjectRepositoryInterface type
+type MockObjectRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockObjectRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockObjectRepositoryInterface) EXPECT() *MockObjectRepositoryInterface_Expecter {
+	return &MockObjectRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Collect provides a mock function for the type MockObjectRepositoryInterface
+func (_mock *MockObjectRepositoryInterface) Collect(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Collect")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockObjectRepositoryInterface_Collect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Collect'
+type MockObjectRepositoryInterface_Collect_Call struct {
+	*mock.Call
+}
+
+// Collect is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockObjectRepositoryInterface_Expecter) Collect(ctx interface{}) *MockObjectRepositoryInterface_Collect_Call {
+	return &MockObjectRepositoryInterface_Collect_Call{Call: _e.mock.On("Collect", ctx)}
+}
+
+func (_c *MockObjectRepositoryInterface_Collect_Call) Run(run func(ctx context.Context)) *MockObjectRepositoryInterface_Collect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockObjectRepositoryInterface_Collect_Call) Return(n int, err error) *MockObjectRepositoryInterface_Collect_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockObjectRepositoryInterface_Collect_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockObjectRepositoryInterface_Collect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Store provides a mock function for the type MockObjectRepositoryInterface
+func (_mock *MockObjectRepositoryInterface) Store(ctx context.Context, src string, dst string) (*repository.StoredObject, error) {
+	ret := _mock.Called(ctx, src, dst)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Store")
+	}
+
+	var r0 *repository.StoredObject
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*repository.StoredObject, error)); ok {
+		return returnFunc(ctx, src, dst)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *repository.StoredObject); ok {
+		r0 = returnFunc(ctx, src, dst)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.StoredObject)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, src, dst)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockObjectRepositoryInterface_Store_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Store'
+type MockObjectRepositoryInterface_Store_Call struct {
+	*mock.Call
+}
+
+// Store is a helper method to define mock.On call
+//   - ctx context.Context
+//   - src string
+//   - dst string
+func (_e *MockObjectRepositoryInterface_Expecter) Store(ctx interface{}, src interface{}, dst interface{}) *MockObjectRepositoryInterface_Store_Call {
+	return &MockObjectRepositoryInterface_Store_Call{Call: _e.mock.On("Store", ctx, src, dst)}
+}
+
+func (_c *MockObjectRepositoryInterface_Store_Call) Run(run func(ctx context.Context, src string, dst string)) *MockObjectRepositoryInterface_Store_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockObjectRepositoryInterface_Store_Call) Return(storedObject *repository.StoredObject, err error) *MockObjectRepositoryInterface_Store_Call {
+	_c.Call.Return(storedObject, err)
+	return _c
+}
+
+func (_c *MockObjectRepositoryInterface_Store_Call) RunAndReturn(run func(ctx context.Context, src string, dst string) (*repository.StoredObject, error)) *MockObjectRepositoryInterface_Store_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOpenAPIHandlerInterface creates a new instance of MockOpenAPIHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOpenAPIHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOpenAPIHandlerInterface {
+	mock := &MockOpenAPIHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOpenAPIHandlerInterface is an autogenerated mock type for the OpenAPIHandlerInterface type
+type MockOpenAPIHandlerInterface struct {
+	mock.Mock
+}
+
+type MockOpenAPIHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOpenAPIHandlerInterface) EXPECT() *MockOpenAPIHandlerInterface_Expecter {
+	return &MockOpenAPIHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ServeDocument provides a mock function for the type MockOpenAPIHandlerInterface
+func (_mock *MockOpenAPIHandlerInterface) ServeDocument(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockOpenAPIHandlerInterface_ServeDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServeDocument'
+type MockOpenAPIHandlerInterface_ServeDocument_Call struct {
+	*mock.Call
+}
+
+// ServeDocument is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockOpenAPIHandlerInterface_Expecter) ServeDocument(w interface{}, r interface{}) *MockOpenAPIHandlerInterface_ServeDocument_Call {
+	return &MockOpenAPIHandlerInterface_ServeDocument_Call{Call: _e.mock.On("ServeDocument", w, r)}
+}
+
+func (_c *MockOpenAPIHandlerInterface_ServeDocument_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockOpenAPIHandlerInterface_ServeDocument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOpenAPIHandlerInterface_ServeDocument_Call) Return() *MockOpenAPIHandlerInterface_ServeDocument_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockOpenAPIHandlerInterface_ServeDocument_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockOpenAPIHandlerInterface_ServeDocument_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Validate provides a mock function for the type MockOpenAPIHandlerInterface
+func (_mock *MockOpenAPIHandlerInterface) Validate(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockOpenAPIHandlerInterface_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockOpenAPIHandlerInterface_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockOpenAPIHandlerInterface_Expecter) Validate(next interface{}) *MockOpenAPIHandlerInterface_Validate_Call {
+	return &MockOpenAPIHandlerInterface_Validate_Call{Call: _e.mock.On("Validate", next)}
+}
+
+func (_c *MockOpenAPIHandlerInterface_Validate_Call) Run(run func(next http.Handler)) *MockOpenAPIHandlerInterface_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOpenAPIHandlerInterface_Validate_Call) Return(handler http.Handler) *MockOpenAPIHandlerInterface_Validate_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockOpenAPIHandlerInterface_Validate_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockOpenAPIHandlerInterface_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
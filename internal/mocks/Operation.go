@@ -0,0 +1,194 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOperation creates a new instance of MockOperation. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOperation(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOperation {
+	mock := &MockOperation{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOperation is an autogenerated mock type for the Operation type
+type MockOperation struct {
+	mock.Mock
+}
+
+type MockOperation_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOperation) EXPECT() *MockOperation_Expecter {
+	return &MockOperation_Expecter{mock: &_m.Mock}
+}
+
+// Name provides a mock function for the type MockOperation
+func (_mock *MockOperation) Name() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockOperation_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockOperation_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *MockOperation_Expecter) Name() *MockOperation_Name_Call {
+	return &MockOperation_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *MockOperation_Name_Call) Run(run func()) *MockOperation_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockOperation_Name_Call) Return(s string) *MockOperation_Name_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockOperation_Name_Call) RunAndReturn(run func() string) *MockOperation_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockOperation
+func (_mock *MockOperation) Run(matrix *entity.Matrix[int64]) (*entity.Result, error) {
+	ret := _mock.Called(matrix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 *entity.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*entity.Matrix[int64]) (*entity.Result, error)); ok {
+		return returnFunc(matrix)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*entity.Matrix[int64]) *entity.Result); ok {
+		r0 = returnFunc(matrix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*entity.Matrix[int64]) error); ok {
+		r1 = returnFunc(matrix)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOperation_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockOperation_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - matrix *entity.Matrix[int64]
+func (_e *MockOperation_Expecter) Run(matrix interface{}) *MockOperation_Run_Call {
+	return &MockOperation_Run_Call{Call: _e.mock.On("Run", matrix)}
+}
+
+func (_c *MockOperation_Run_Call) Run(run func(matrix *entity.Matrix[int64])) *MockOperation_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *entity.Matrix[int64]
+		if args[0] != nil {
+			arg0 = args[0].(*entity.Matrix[int64])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperation_Run_Call) Return(result *entity.Result, err error) *MockOperation_Run_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockOperation_Run_Call) RunAndReturn(run func(matrix *entity.Matrix[int64]) (*entity.Result, error)) *MockOperation_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function for the type MockOperation
+func (_mock *MockOperation) Validate(matrix *entity.Matrix[int64]) error {
+	ret := _mock.Called(matrix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*entity.Matrix[int64]) error); ok {
+		r0 = returnFunc(matrix)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOperation_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockOperation_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - matrix *entity.Matrix[int64]
+func (_e *MockOperation_Expecter) Validate(matrix interface{}) *MockOperation_Validate_Call {
+	return &MockOperation_Validate_Call{Call: _e.mock.On("Validate", matrix)}
+}
+
+func (_c *MockOperation_Validate_Call) Run(run func(matrix *entity.Matrix[int64])) *MockOperation_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *entity.Matrix[int64]
+		if args[0] != nil {
+			arg0 = args[0].(*entity.Matrix[int64])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperation_Validate_Call) Return(err error) *MockOperation_Validate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOperation_Validate_Call) RunAndReturn(run func(matrix *entity.Matrix[int64]) error) *MockOperation_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
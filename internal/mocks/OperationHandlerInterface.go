@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOperationHandlerInterface creates a new instance of MockOperationHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOperationHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOperationHandlerInterface {
+	mock := &MockOperationHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOperationHandlerInterface is an autogenerated mock type for the OperationHandlerInterface type
+type MockOperationHandlerInterface struct {
+	mock.Mock
+}
+
+type MockOperationHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOperationHandlerInterface) EXPECT() *MockOperationHandlerInterface_Expecter {
+	return &MockOperationHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// HandleOperation provides a mock function for the type MockOperationHandlerInterface
+func (_mock *MockOperationHandlerInterface) HandleOperation(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockOperationHandlerInterface_HandleOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleOperation'
+type MockOperationHandlerInterface_HandleOperation_Call struct {
+	*mock.Call
+}
+
+// HandleOperation is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockOperationHandlerInterface_Expecter) HandleOperation(w interface{}, r interface{}) *MockOperationHandlerInterface_HandleOperation_Call {
+	return &MockOperationHandlerInterface_HandleOperation_Call{Call: _e.mock.On("HandleOperation", w, r)}
+}
+
+func (_c *MockOperationHandlerInterface_HandleOperation_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockOperationHandlerInterface_HandleOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationHandlerInterface_HandleOperation_Call) Return() *MockOperationHandlerInterface_HandleOperation_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockOperationHandlerInterface_HandleOperation_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockOperationHandlerInterface_HandleOperation_Call {
+	_c.Run(run)
+	return _c
+}
+
+// HandleOperations provides a mock function for the type MockOperationHandlerInterface
+func (_mock *MockOperationHandlerInterface) HandleOperations(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockOperationHandlerInterface_HandleOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleOperations'
+type MockOperationHandlerInterface_HandleOperations_Call struct {
+	*mock.Call
+}
+
+// HandleOperations is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockOperationHandlerInterface_Expecter) HandleOperations(w interface{}, r interface{}) *MockOperationHandlerInterface_HandleOperations_Call {
+	return &MockOperationHandlerInterface_HandleOperations_Call{Call: _e.mock.On("HandleOperations", w, r)}
+}
+
+func (_c *MockOperationHandlerInterface_HandleOperations_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockOperationHandlerInterface_HandleOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationHandlerInterface_HandleOperations_Call) Return() *MockOperationHandlerInterface_HandleOperations_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockOperationHandlerInterface_HandleOperations_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockOperationHandlerInterface_HandleOperations_Call {
+	_c.Run(run)
+	return _c
+}
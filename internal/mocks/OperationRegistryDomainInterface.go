@@ -0,0 +1,323 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOperationRegistryDomainInterface creates a new instance of MockOperationRegistryDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOperationRegistryDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOperationRegistryDomainInterface {
+	mock := &MockOperationRegistryDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOperationRegistryDomainInterface is an autogenerated mock type for the OperationRegistryDomainInterface type
+type MockOperationRegistryDomainInterface struct {
+	mock.Mock
+}
+
+type MockOperationRegistryDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOperationRegistryDomainInterface) EXPECT() *MockOperationRegistryDomainInterface_Expecter {
+	return &MockOperationRegistryDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetOperation provides a mock function for the type MockOperationRegistryDomainInterface
+func (_mock *MockOperationRegistryDomainInterface) GetOperation(ctx context.Context, name string) (*entity.OperationInfo, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperation")
+	}
+
+	var r0 *entity.OperationInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.OperationInfo, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.OperationInfo); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OperationInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOperationRegistryDomainInterface_GetOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOperation'
+type MockOperationRegistryDomainInterface_GetOperation_Call struct {
+	*mock.Call
+}
+
+// GetOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockOperationRegistryDomainInterface_Expecter) GetOperation(ctx interface{}, name interface{}) *MockOperationRegistryDomainInterface_GetOperation_Call {
+	return &MockOperationRegistryDomainInterface_GetOperation_Call{Call: _e.mock.On("GetOperation", ctx, name)}
+}
+
+func (_c *MockOperationRegistryDomainInterface_GetOperation_Call) Run(run func(ctx context.Context, name string)) *MockOperationRegistryDomainInterface_GetOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_GetOperation_Call) Return(v *entity.OperationInfo, err error) *MockOperationRegistryDomainInterface_GetOperation_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_GetOperation_Call) RunAndReturn(run func(ctx context.Context, name string) (*entity.OperationInfo, error)) *MockOperationRegistryDomainInterface_GetOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOperations provides a mock function for the type MockOperationRegistryDomainInterface
+func (_mock *MockOperationRegistryDomainInterface) ListOperations(ctx context.Context) ([]entity.OperationInfo, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOperations")
+	}
+
+	var r0 []entity.OperationInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]entity.OperationInfo, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []entity.OperationInfo); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OperationInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOperationRegistryDomainInterface_ListOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOperations'
+type MockOperationRegistryDomainInterface_ListOperations_Call struct {
+	*mock.Call
+}
+
+// ListOperations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOperationRegistryDomainInterface_Expecter) ListOperations(ctx interface{}) *MockOperationRegistryDomainInterface_ListOperations_Call {
+	return &MockOperationRegistryDomainInterface_ListOperations_Call{Call: _e.mock.On("ListOperations", ctx)}
+}
+
+func (_c *MockOperationRegistryDomainInterface_ListOperations_Call) Run(run func(ctx context.Context)) *MockOperationRegistryDomainInterface_ListOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_ListOperations_Call) Return(vs []entity.OperationInfo, err error) *MockOperationRegistryDomainInterface_ListOperations_Call {
+	_c.Call.Return(vs, err)
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_ListOperations_Call) RunAndReturn(run func(ctx context.Context) ([]entity.OperationInfo, error)) *MockOperationRegistryDomainInterface_ListOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterOperation provides a mock function for the type MockOperationRegistryDomainInterface
+func (_mock *MockOperationRegistryDomainInterface) RegisterOperation(ctx context.Context, name string, description string, steps []string) (*entity.OperationInfo, error) {
+	ret := _mock.Called(ctx, name, description, steps)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterOperation")
+	}
+
+	var r0 *entity.OperationInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string) (*entity.OperationInfo, error)); ok {
+		return returnFunc(ctx, name, description, steps)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string) *entity.OperationInfo); ok {
+		r0 = returnFunc(ctx, name, description, steps)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OperationInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = returnFunc(ctx, name, description, steps)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOperationRegistryDomainInterface_RegisterOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterOperation'
+type MockOperationRegistryDomainInterface_RegisterOperation_Call struct {
+	*mock.Call
+}
+
+// RegisterOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - description string
+//   - steps []string
+func (_e *MockOperationRegistryDomainInterface_Expecter) RegisterOperation(ctx interface{}, name interface{}, description interface{}, steps interface{}) *MockOperationRegistryDomainInterface_RegisterOperation_Call {
+	return &MockOperationRegistryDomainInterface_RegisterOperation_Call{Call: _e.mock.On("RegisterOperation", ctx, name, description, steps)}
+}
+
+func (_c *MockOperationRegistryDomainInterface_RegisterOperation_Call) Run(run func(ctx context.Context, name string, description string, steps []string)) *MockOperationRegistryDomainInterface_RegisterOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_RegisterOperation_Call) Return(v *entity.OperationInfo, err error) *MockOperationRegistryDomainInterface_RegisterOperation_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_RegisterOperation_Call) RunAndReturn(run func(ctx context.Context, name string, description string, steps []string) (*entity.OperationInfo, error)) *MockOperationRegistryDomainInterface_RegisterOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOperationEnabled provides a mock function for the type MockOperationRegistryDomainInterface
+func (_mock *MockOperationRegistryDomainInterface) SetOperationEnabled(ctx context.Context, name string, enabled bool) (*entity.OperationInfo, error) {
+	ret := _mock.Called(ctx, name, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOperationEnabled")
+	}
+
+	var r0 *entity.OperationInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (*entity.OperationInfo, error)); ok {
+		return returnFunc(ctx, name, enabled)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) *entity.OperationInfo); ok {
+		r0 = returnFunc(ctx, name, enabled)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OperationInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, name, enabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOperationRegistryDomainInterface_SetOperationEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOperationEnabled'
+type MockOperationRegistryDomainInterface_SetOperationEnabled_Call struct {
+	*mock.Call
+}
+
+// SetOperationEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - enabled bool
+func (_e *MockOperationRegistryDomainInterface_Expecter) SetOperationEnabled(ctx interface{}, name interface{}, enabled interface{}) *MockOperationRegistryDomainInterface_SetOperationEnabled_Call {
+	return &MockOperationRegistryDomainInterface_SetOperationEnabled_Call{Call: _e.mock.On("SetOperationEnabled", ctx, name, enabled)}
+}
+
+func (_c *MockOperationRegistryDomainInterface_SetOperationEnabled_Call) Run(run func(ctx context.Context, name string, enabled bool)) *MockOperationRegistryDomainInterface_SetOperationEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_SetOperationEnabled_Call) Return(v *entity.OperationInfo, err error) *MockOperationRegistryDomainInterface_SetOperationEnabled_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockOperationRegistryDomainInterface_SetOperationEnabled_Call) RunAndReturn(run func(ctx context.Context, name string, enabled bool) (*entity.OperationInfo, error)) *MockOperationRegistryDomainInterface_SetOperationEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
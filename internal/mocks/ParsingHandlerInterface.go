@@ -0,0 +1,91 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockParsingHandlerInterface creates a new instance of MockParsingHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockParsingHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockParsingHandlerInterface {
+	mock := &MockParsingHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockParsingHandlerInterface is an autogenerated mock type for the ParsingHandlerInterface type
+type MockParsingHandlerInterface struct {
+	mock.Mock
+}
+
+type MockParsingHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockParsingHandlerInterface) EXPECT() *MockParsingHandlerInterface_Expecter {
+	return &MockParsingHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Parse provides a mock function for the type MockParsingHandlerInterface
+func (_mock *MockParsingHandlerInterface) Parse(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Parse")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockParsingHandlerInterface_Parse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Parse'
+type MockParsingHandlerInterface_Parse_Call struct {
+	*mock.Call
+}
+
+// Parse is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockParsingHandlerInterface_Expecter) Parse(next interface{}) *MockParsingHandlerInterface_Parse_Call {
+	return &MockParsingHandlerInterface_Parse_Call{Call: _e.mock.On("Parse", next)}
+}
+
+func (_c *MockParsingHandlerInterface_Parse_Call) Run(run func(next http.Handler)) *MockParsingHandlerInterface_Parse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockParsingHandlerInterface_Parse_Call) Return(handler http.Handler) *MockParsingHandlerInterface_Parse_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockParsingHandlerInterface_Parse_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockParsingHandlerInterface_Parse_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockProfileHandlerInterface creates a new instance of MockProfileHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfileHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfileHandlerInterface {
+	mock := &MockProfileHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockProfileHandlerInterface is an autogenerated mock type for the ProfileHandlerInterface type
+type MockProfileHandlerInterface struct {
+	mock.Mock
+}
+
+type MockProfileHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfileHandlerInterface) EXPECT() *MockProfileHandlerInterface_Expecter {
+	return &MockProfileHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Profile provides a mock function for the type MockProfileHandlerInterface
+func (_mock *MockProfileHandlerInterface) Profile(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Profile")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockProfileHandlerInterface_Profile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Profile'
+type MockProfileHandlerInterface_Profile_Call struct {
+	*mock.Call
+}
+
+// Profile is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockProfileHandlerInterface_Expecter) Profile(next interface{}) *MockProfileHandlerInterface_Profile_Call {
+	return &MockProfileHandlerInterface_Profile_Call{Call: _e.mock.On("Profile", next)}
+}
+
+func (_c *MockProfileHandlerInterface_Profile_Call) Run(run func(next http.Handler)) *MockProfileHandlerInterface_Profile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileHandlerInterface_Profile_Call) Return(handler http.Handler) *MockProfileHandlerInterface_Profile_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockProfileHandlerInterface_Profile_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockProfileHandlerInterface_Profile_Call {
+	_c.Call.Return(run)
+	return _c
+}
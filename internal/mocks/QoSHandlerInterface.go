@@ -0,0 +1,91 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockQoSHandlerInterface creates a new instance of MockQoSHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQoSHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQoSHandlerInterface {
+	mock := &MockQoSHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockQoSHandlerInterface is an autogenerated mock type for the QoSHandlerInterface type
+type MockQoSHandlerInterface struct {
+	mock.Mock
+}
+
+type MockQoSHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQoSHandlerInterface) EXPECT() *MockQoSHandlerInterface_Expecter {
+	return &MockQoSHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Classify provides a mock function for the type MockQoSHandlerInterface
+func (_mock *MockQoSHandlerInterface) Classify(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Classify")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockQoSHandlerInterface_Classify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Classify'
+type MockQoSHandlerInterface_Classify_Call struct {
+	*mock.Call
+}
+
+// Classify is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockQoSHandlerInterface_Expecter) Classify(next interface{}) *MockQoSHandlerInterface_Classify_Call {
+	return &MockQoSHandlerInterface_Classify_Call{Call: _e.mock.On("Classify", next)}
+}
+
+func (_c *MockQoSHandlerInterface_Classify_Call) Run(run func(next http.Handler)) *MockQoSHandlerInterface_Classify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQoSHandlerInterface_Classify_Call) Return(handler http.Handler) *MockQoSHandlerInterface_Classify_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockQoSHandlerInterface_Classify_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockQoSHandlerInterface_Classify_Call {
+	_c.Call.Return(run)
+	return _c
+}
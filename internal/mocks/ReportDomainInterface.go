@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockReportDomainInterface creates a new instance of MockReportDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReportDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReportDomainInterface {
+	mock := &MockReportDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReportDomainInterface is an autogenerated mock type for the ReportDomainInterface type
+type MockReportDomainInterface struct {
+	mock.Mock
+}
+
+type MockReportDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReportDomainInterface) EXPECT() *MockReportDomainInterface_Expecter {
+	return &MockReportDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// ReportFile provides a mock function for the type MockReportDomainInterface
+func (_mock *MockReportDomainInterface) ReportFile(ctx context.Context, filePath string) (*entity.MatrixReport, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportFile")
+	}
+
+	var r0 *entity.MatrixReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.MatrixReport, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.MatrixReport); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.MatrixReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReportDomainInterface_ReportFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportFile'
+type MockReportDomainInterface_ReportFile_Call struct {
+	*mock.Call
+}
+
+// ReportFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockReportDomainInterface_Expecter) ReportFile(ctx interface{}, filePath interface{}) *MockReportDomainInterface_ReportFile_Call {
+	return &MockReportDomainInterface_ReportFile_Call{Call: _e.mock.On("ReportFile", ctx, filePath)}
+}
+
+func (_c *MockReportDomainInterface_ReportFile_Call) Run(run func(ctx context.Context, filePath string)) *MockReportDomainInterface_ReportFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReportDomainInterface_ReportFile_Call) Return(matrixReport *entity.MatrixReport, err error) *MockReportDomainInterface_ReportFile_Call {
+	_c.Call.Return(matrixReport, err)
+	return _c
+}
+
+func (_c *MockReportDomainInterface_ReportFile_Call) RunAndReturn(run func(ctx context.Context, filePath string) (*entity.MatrixReport, error)) *MockReportDomainInterface_ReportFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockReportHandlerInterface creates a new instance of MockReportHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReportHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReportHandlerInterface {
+	mock := &MockReportHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReportHandlerInterface is an autogenerated mock type for the ReportHandlerInterface type
+type MockReportHandlerInterface struct {
+	mock.Mock
+}
+
+type MockReportHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReportHandlerInterface) EXPECT() *MockReportHandlerInterface_Expecter {
+	return &MockReportHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ReportMatrix provides a mock function for the type MockReportHandlerInterface
+func (_mock *MockReportHandlerInterface) ReportMatrix(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockReportHandlerInterface_ReportMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportMatrix'
+type MockReportHandlerInterface_ReportMatrix_Call struct {
+	*mock.Call
+}
+
+// ReportMatrix is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockReportHandlerInterface_Expecter) ReportMatrix(w interface{}, r interface{}) *MockReportHandlerInterface_ReportMatrix_Call {
+	return &MockReportHandlerInterface_ReportMatrix_Call{Call: _e.mock.On("ReportMatrix", w, r)}
+}
+
+func (_c *MockReportHandlerInterface_ReportMatrix_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockReportHandlerInterface_ReportMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReportHandlerInterface_ReportMatrix_Call) Return() *MockReportHandlerInterface_ReportMatrix_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockReportHandlerInterface_ReportMatrix_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockReportHandlerInterface_ReportMatrix_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,464 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRetentionDomainInterface creates a new instance of MockRetentionDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRetentionDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRetentionDomainInterface {
+	mock := &MockRetentionDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRetentionDomainInterface is an autogenerated mock type for the RetentionDomainInterface type
+type MockRetentionDomainInterface struct {
+	mock.Mock
+}
+
+type MockRetentionDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRetentionDomainInterface) EXPECT() *MockRetentionDomainInterface_Expecter {
+	return &MockRetentionDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockRetentionDomainInterface
+func (_mock *MockRetentionDomainInterface) Delete(ctx context.Context, filePath string) (*entity.DeletedMatrix, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *entity.DeletedMatrix
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.DeletedMatrix, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.DeletedMatrix); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DeletedMatrix)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetentionDomainInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRetentionDomainInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockRetentionDomainInterface_Expecter) Delete(ctx interface{}, filePath interface{}) *MockRetentionDomainInterface_Delete_Call {
+	return &MockRetentionDomainInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, filePath)}
+}
+
+func (_c *MockRetentionDomainInterface_Delete_Call) Run(run func(ctx context.Context, filePath string)) *MockRetentionDomainInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Delete_Call) Return(deletedMatrix *entity.DeletedMatrix, err error) *MockRetentionDomainInterface_Delete_Call {
+	_c.Call.Return(deletedMatrix, err)
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Delete_Call) RunAndReturn(run func(ctx context.Context, filePath string) (*entity.DeletedMatrix, error)) *MockRetentionDomainInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeleted provides a mock function for the type MockRetentionDomainInterface
+func (_mock *MockRetentionDomainInterface) ListDeleted(ctx context.Context) ([]*entity.DeletedMatrix, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeleted")
+	}
+
+	var r0 []*entity.DeletedMatrix
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entity.DeletedMatrix, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entity.DeletedMatrix); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.DeletedMatrix)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetentionDomainInterface_ListDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeleted'
+type MockRetentionDomainInterface_ListDeleted_Call struct {
+	*mock.Call
+}
+
+// ListDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRetentionDomainInterface_Expecter) ListDeleted(ctx interface{}) *MockRetentionDomainInterface_ListDeleted_Call {
+	return &MockRetentionDomainInterface_ListDeleted_Call{Call: _e.mock.On("ListDeleted", ctx)}
+}
+
+func (_c *MockRetentionDomainInterface_ListDeleted_Call) Run(run func(ctx context.Context)) *MockRetentionDomainInterface_ListDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_ListDeleted_Call) Return(deletedMatrixs []*entity.DeletedMatrix, err error) *MockRetentionDomainInterface_ListDeleted_Call {
+	_c.Call.Return(deletedMatrixs, err)
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_ListDeleted_Call) RunAndReturn(run func(ctx context.Context) ([]*entity.DeletedMatrix, error)) *MockRetentionDomainInterface_ListDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockRetentionDomainInterface
+func (_mock *MockRetentionDomainInterface) Restore(ctx context.Context, filePath string) error {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionDomainInterface_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockRetentionDomainInterface_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockRetentionDomainInterface_Expecter) Restore(ctx interface{}, filePath interface{}) *MockRetentionDomainInterface_Restore_Call {
+	return &MockRetentionDomainInterface_Restore_Call{Call: _e.mock.On("Restore", ctx, filePath)}
+}
+
+func (_c *MockRetentionDomainInterface_Restore_Call) Run(run func(ctx context.Context, filePath string)) *MockRetentionDomainInterface_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Restore_Call) Return(err error) *MockRetentionDomainInterface_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Restore_Call) RunAndReturn(run func(ctx context.Context, filePath string) error) *MockRetentionDomainInterface_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Retain provides a mock function for the type MockRetentionDomainInterface
+func (_mock *MockRetentionDomainInterface) Retain(ctx context.Context, filePath string, ttl time.Duration) error {
+	ret := _mock.Called(ctx, filePath, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retain")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, filePath, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionDomainInterface_Retain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Retain'
+type MockRetentionDomainInterface_Retain_Call struct {
+	*mock.Call
+}
+
+// Retain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - ttl time.Duration
+func (_e *MockRetentionDomainInterface_Expecter) Retain(ctx interface{}, filePath interface{}, ttl interface{}) *MockRetentionDomainInterface_Retain_Call {
+	return &MockRetentionDomainInterface_Retain_Call{Call: _e.mock.On("Retain", ctx, filePath, ttl)}
+}
+
+func (_c *MockRetentionDomainInterface_Retain_Call) Run(run func(ctx context.Context, filePath string, ttl time.Duration)) *MockRetentionDomainInterface_Retain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Retain_Call) Return(err error) *MockRetentionDomainInterface_Retain_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Retain_Call) RunAndReturn(run func(ctx context.Context, filePath string, ttl time.Duration) error) *MockRetentionDomainInterface_Retain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTTL provides a mock function for the type MockRetentionDomainInterface
+func (_mock *MockRetentionDomainInterface) SetTTL(ctx context.Context, filePath string, ttl time.Duration) (time.Time, error) {
+	ret := _mock.Called(ctx, filePath, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTTL")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) (time.Time, error)); ok {
+		return returnFunc(ctx, filePath, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) time.Time); ok {
+		r0 = returnFunc(ctx, filePath, ttl)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = returnFunc(ctx, filePath, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetentionDomainInterface_SetTTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTTL'
+type MockRetentionDomainInterface_SetTTL_Call struct {
+	*mock.Call
+}
+
+// SetTTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - ttl time.Duration
+func (_e *MockRetentionDomainInterface_Expecter) SetTTL(ctx interface{}, filePath interface{}, ttl interface{}) *MockRetentionDomainInterface_SetTTL_Call {
+	return &MockRetentionDomainInterface_SetTTL_Call{Call: _e.mock.On("SetTTL", ctx, filePath, ttl)}
+}
+
+func (_c *MockRetentionDomainInterface_SetTTL_Call) Run(run func(ctx context.Context, filePath string, ttl time.Duration)) *MockRetentionDomainInterface_SetTTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_SetTTL_Call) Return(time1 time.Time, err error) *MockRetentionDomainInterface_SetTTL_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_SetTTL_Call) RunAndReturn(run func(ctx context.Context, filePath string, ttl time.Duration) (time.Time, error)) *MockRetentionDomainInterface_SetTTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function for the type MockRetentionDomainInterface
+func (_mock *MockRetentionDomainInterface) Stop(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionDomainInterface_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockRetentionDomainInterface_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRetentionDomainInterface_Expecter) Stop(ctx interface{}) *MockRetentionDomainInterface_Stop_Call {
+	return &MockRetentionDomainInterface_Stop_Call{Call: _e.mock.On("Stop", ctx)}
+}
+
+func (_c *MockRetentionDomainInterface_Stop_Call) Run(run func(ctx context.Context)) *MockRetentionDomainInterface_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Stop_Call) Return(err error) *MockRetentionDomainInterface_Stop_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Stop_Call) RunAndReturn(run func(ctx context.Context) error) *MockRetentionDomainInterface_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sweep provides a mock function for the type MockRetentionDomainInterface
+func (_mock *MockRetentionDomainInterface) Sweep(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sweep")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionDomainInterface_Sweep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sweep'
+type MockRetentionDomainInterface_Sweep_Call struct {
+	*mock.Call
+}
+
+// Sweep is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRetentionDomainInterface_Expecter) Sweep(ctx interface{}) *MockRetentionDomainInterface_Sweep_Call {
+	return &MockRetentionDomainInterface_Sweep_Call{Call: _e.mock.On("Sweep", ctx)}
+}
+
+func (_c *MockRetentionDomainInterface_Sweep_Call) Run(run func(ctx context.Context)) *MockRetentionDomainInterface_Sweep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Sweep_Call) Return(err error) *MockRetentionDomainInterface_Sweep_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionDomainInterface_Sweep_Call) RunAndReturn(run func(ctx context.Context) error) *MockRetentionDomainInterface_Sweep_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,222 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRetentionHandlerInterface creates a new instance of MockRetentionHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRetentionHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRetentionHandlerInterface {
+	mock := &MockRetentionHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRetentionHandlerInterface is an autogenerated mock type for the RetentionHandlerInterface type
+type MockRetentionHandlerInterface struct {
+	mock.Mock
+}
+
+type MockRetentionHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRetentionHandlerInterface) EXPECT() *MockRetentionHandlerInterface_Expecter {
+	return &MockRetentionHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// DeleteFile provides a mock function for the type MockRetentionHandlerInterface
+func (_mock *MockRetentionHandlerInterface) DeleteFile(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockRetentionHandlerInterface_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
+type MockRetentionHandlerInterface_DeleteFile_Call struct {
+	*mock.Call
+}
+
+// DeleteFile is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockRetentionHandlerInterface_Expecter) DeleteFile(w interface{}, r interface{}) *MockRetentionHandlerInterface_DeleteFile_Call {
+	return &MockRetentionHandlerInterface_DeleteFile_Call{Call: _e.mock.On("DeleteFile", w, r)}
+}
+
+func (_c *MockRetentionHandlerInterface_DeleteFile_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_DeleteFile_Call) Return() *MockRetentionHandlerInterface_DeleteFile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_DeleteFile_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_DeleteFile_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ListDeletedFiles provides a mock function for the type MockRetentionHandlerInterface
+func (_mock *MockRetentionHandlerInterface) ListDeletedFiles(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockRetentionHandlerInterface_ListDeletedFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeletedFiles'
+type MockRetentionHandlerInterface_ListDeletedFiles_Call struct {
+	*mock.Call
+}
+
+// ListDeletedFiles is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockRetentionHandlerInterface_Expecter) ListDeletedFiles(w interface{}, r interface{}) *MockRetentionHandlerInterface_ListDeletedFiles_Call {
+	return &MockRetentionHandlerInterface_ListDeletedFiles_Call{Call: _e.mock.On("ListDeletedFiles", w, r)}
+}
+
+func (_c *MockRetentionHandlerInterface_ListDeletedFiles_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_ListDeletedFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_ListDeletedFiles_Call) Return() *MockRetentionHandlerInterface_ListDeletedFiles_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_ListDeletedFiles_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_ListDeletedFiles_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RestoreFile provides a mock function for the type MockRetentionHandlerInterface
+func (_mock *MockRetentionHandlerInterface) RestoreFile(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockRetentionHandlerInterface_RestoreFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreFile'
+type MockRetentionHandlerInterface_RestoreFile_Call struct {
+	*mock.Call
+}
+
+// RestoreFile is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockRetentionHandlerInterface_Expecter) RestoreFile(w interface{}, r interface{}) *MockRetentionHandlerInterface_RestoreFile_Call {
+	return &MockRetentionHandlerInterface_RestoreFile_Call{Call: _e.mock.On("RestoreFile", w, r)}
+}
+
+func (_c *MockRetentionHandlerInterface_RestoreFile_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_RestoreFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_RestoreFile_Call) Return() *MockRetentionHandlerInterface_RestoreFile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_RestoreFile_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_RestoreFile_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetRetention provides a mock function for the type MockRetentionHandlerInterface
+func (_mock *MockRetentionHandlerInterface) SetRetention(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockRetentionHandlerInterface_SetRetention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRetention'
+type MockRetentionHandlerInterface_SetRetention_Call struct {
+	*mock.Call
+}
+
+// SetRetention is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockRetentionHandlerInterface_Expecter) SetRetention(w interface{}, r interface{}) *MockRetentionHandlerInterface_SetRetention_Call {
+	return &MockRetentionHandlerInterface_SetRetention_Call{Call: _e.mock.On("SetRetention", w, r)}
+}
+
+func (_c *MockRetentionHandlerInterface_SetRetention_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_SetRetention_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_SetRetention_Call) Return() *MockRetentionHandlerInterface_SetRetention_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRetentionHandlerInterface_SetRetention_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockRetentionHandlerInterface_SetRetention_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,410 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRetentionRepositoryInterface creates a new instance of MockRetentionRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRetentionRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRetentionRepositoryInterface {
+	mock := &MockRetentionRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRetentionRepositoryInterface is an autogenerated mock type for the RetentionRepositoryInterface type
+type MockRetentionRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockRetentionRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRetentionRepositoryInterface) EXPECT() *MockRetentionRepositoryInterface_Expecter {
+	return &MockRetentionRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListExpired provides a mock function for the type MockRetentionRepositoryInterface
+func (_mock *MockRetentionRepositoryInterface) ListExpired(ctx context.Context, now time.Time) ([]string, error) {
+	ret := _mock.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpired")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]string, error)); ok {
+		return returnFunc(ctx, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []string); ok {
+		r0 = returnFunc(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetentionRepositoryInterface_ListExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpired'
+type MockRetentionRepositoryInterface_ListExpired_Call struct {
+	*mock.Call
+}
+
+// ListExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockRetentionRepositoryInterface_Expecter) ListExpired(ctx interface{}, now interface{}) *MockRetentionRepositoryInterface_ListExpired_Call {
+	return &MockRetentionRepositoryInterface_ListExpired_Call{Call: _e.mock.On("ListExpired", ctx, now)}
+}
+
+func (_c *MockRetentionRepositoryInterface_ListExpired_Call) Run(run func(ctx context.Context, now time.Time)) *MockRetentionRepositoryInterface_ListExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_ListExpired_Call) Return(strings []string, err error) *MockRetentionRepositoryInterface_ListExpired_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_ListExpired_Call) RunAndReturn(run func(ctx context.Context, now time.Time) ([]string, error)) *MockRetentionRepositoryInterface_ListExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTrash provides a mock function for the type MockRetentionRepositoryInterface
+func (_mock *MockRetentionRepositoryInterface) ListTrash(ctx context.Context) ([]*repository.TrashRecord, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrash")
+	}
+
+	var r0 []*repository.TrashRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*repository.TrashRecord, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*repository.TrashRecord); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.TrashRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetentionRepositoryInterface_ListTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrash'
+type MockRetentionRepositoryInterface_ListTrash_Call struct {
+	*mock.Call
+}
+
+// ListTrash is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRetentionRepositoryInterface_Expecter) ListTrash(ctx interface{}) *MockRetentionRepositoryInterface_ListTrash_Call {
+	return &MockRetentionRepositoryInterface_ListTrash_Call{Call: _e.mock.On("ListTrash", ctx)}
+}
+
+func (_c *MockRetentionRepositoryInterface_ListTrash_Call) Run(run func(ctx context.Context)) *MockRetentionRepositoryInterface_ListTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_ListTrash_Call) Return(trashRecords []*repository.TrashRecord, err error) *MockRetentionRepositoryInterface_ListTrash_Call {
+	_c.Call.Return(trashRecords, err)
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_ListTrash_Call) RunAndReturn(run func(ctx context.Context) ([]*repository.TrashRecord, error)) *MockRetentionRepositoryInterface_ListTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockRetentionRepositoryInterface
+func (_mock *MockRetentionRepositoryInterface) Purge(ctx context.Context, filePath string) error {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionRepositoryInterface_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockRetentionRepositoryInterface_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockRetentionRepositoryInterface_Expecter) Purge(ctx interface{}, filePath interface{}) *MockRetentionRepositoryInterface_Purge_Call {
+	return &MockRetentionRepositoryInterface_Purge_Call{Call: _e.mock.On("Purge", ctx, filePath)}
+}
+
+func (_c *MockRetentionRepositoryInterface_Purge_Call) Run(run func(ctx context.Context, filePath string)) *MockRetentionRepositoryInterface_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_Purge_Call) Return(err error) *MockRetentionRepositoryInterface_Purge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_Purge_Call) RunAndReturn(run func(ctx context.Context, filePath string) error) *MockRetentionRepositoryInterface_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockRetentionRepositoryInterface
+func (_mock *MockRetentionRepositoryInterface) Restore(ctx context.Context, filePath string) error {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionRepositoryInterface_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockRetentionRepositoryInterface_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockRetentionRepositoryInterface_Expecter) Restore(ctx interface{}, filePath interface{}) *MockRetentionRepositoryInterface_Restore_Call {
+	return &MockRetentionRepositoryInterface_Restore_Call{Call: _e.mock.On("Restore", ctx, filePath)}
+}
+
+func (_c *MockRetentionRepositoryInterface_Restore_Call) Run(run func(ctx context.Context, filePath string)) *MockRetentionRepositoryInterface_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_Restore_Call) Return(err error) *MockRetentionRepositoryInterface_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_Restore_Call) RunAndReturn(run func(ctx context.Context, filePath string) error) *MockRetentionRepositoryInterface_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetExpiry provides a mock function for the type MockRetentionRepositoryInterface
+func (_mock *MockRetentionRepositoryInterface) SetExpiry(ctx context.Context, filePath string, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, filePath, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetExpiry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, filePath, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionRepositoryInterface_SetExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetExpiry'
+type MockRetentionRepositoryInterface_SetExpiry_Call struct {
+	*mock.Call
+}
+
+// SetExpiry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - expiresAt time.Time
+func (_e *MockRetentionRepositoryInterface_Expecter) SetExpiry(ctx interface{}, filePath interface{}, expiresAt interface{}) *MockRetentionRepositoryInterface_SetExpiry_Call {
+	return &MockRetentionRepositoryInterface_SetExpiry_Call{Call: _e.mock.On("SetExpiry", ctx, filePath, expiresAt)}
+}
+
+func (_c *MockRetentionRepositoryInterface_SetExpiry_Call) Run(run func(ctx context.Context, filePath string, expiresAt time.Time)) *MockRetentionRepositoryInterface_SetExpiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_SetExpiry_Call) Return(err error) *MockRetentionRepositoryInterface_SetExpiry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_SetExpiry_Call) RunAndReturn(run func(ctx context.Context, filePath string, expiresAt time.Time) error) *MockRetentionRepositoryInterface_SetExpiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trash provides a mock function for the type MockRetentionRepositoryInterface
+func (_mock *MockRetentionRepositoryInterface) Trash(ctx context.Context, filePath string, deletedAt time.Time) error {
+	ret := _mock.Called(ctx, filePath, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, filePath, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRetentionRepositoryInterface_Trash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trash'
+type MockRetentionRepositoryInterface_Trash_Call struct {
+	*mock.Call
+}
+
+// Trash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - deletedAt time.Time
+func (_e *MockRetentionRepositoryInterface_Expecter) Trash(ctx interface{}, filePath interface{}, deletedAt interface{}) *MockRetentionRepositoryInterface_Trash_Call {
+	return &MockRetentionRepositoryInterface_Trash_Call{Call: _e.mock.On("Trash", ctx, filePath, deletedAt)}
+}
+
+func (_c *MockRetentionRepositoryInterface_Trash_Call) Run(run func(ctx context.Context, filePath string, deletedAt time.Time)) *MockRetentionRepositoryInterface_Trash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_Trash_Call) Return(err error) *MockRetentionRepositoryInterface_Trash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRetentionRepositoryInterface_Trash_Call) RunAndReturn(run func(ctx context.Context, filePath string, deletedAt time.Time) error) *MockRetentionRepositoryInterface_Trash_Call {
+	_c.Call.Return(run)
+	return _c
+}
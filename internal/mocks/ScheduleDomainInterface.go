@@ -0,0 +1,357 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockScheduleDomainInterface creates a new instance of MockScheduleDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockScheduleDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockScheduleDomainInterface {
+	mock := &MockScheduleDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockScheduleDomainInterface is an autogenerated mock type for the ScheduleDomainInterface type
+type MockScheduleDomainInterface struct {
+	mock.Mock
+}
+
+type MockScheduleDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockScheduleDomainInterface) EXPECT() *MockScheduleDomainInterface_Expecter {
+	return &MockScheduleDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateSchedule provides a mock function for the type MockScheduleDomainInterface
+func (_mock *MockScheduleDomainInterface) CreateSchedule(ctx context.Context, spec string, operation string, filePath string) (*entity.Schedule, error) {
+	ret := _mock.Called(ctx, spec, operation, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSchedule")
+	}
+
+	var r0 *entity.Schedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*entity.Schedule, error)); ok {
+		return returnFunc(ctx, spec, operation, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *entity.Schedule); ok {
+		r0 = returnFunc(ctx, spec, operation, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Schedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, spec, operation, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScheduleDomainInterface_CreateSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSchedule'
+type MockScheduleDomainInterface_CreateSchedule_Call struct {
+	*mock.Call
+}
+
+// CreateSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - spec string
+//   - operation string
+//   - filePath string
+func (_e *MockScheduleDomainInterface_Expecter) CreateSchedule(ctx interface{}, spec interface{}, operation interface{}, filePath interface{}) *MockScheduleDomainInterface_CreateSchedule_Call {
+	return &MockScheduleDomainInterface_CreateSchedule_Call{Call: _e.mock.On("CreateSchedule", ctx, spec, operation, filePath)}
+}
+
+func (_c *MockScheduleDomainInterface_CreateSchedule_Call) Run(run func(ctx context.Context, spec string, operation string, filePath string)) *MockScheduleDomainInterface_CreateSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_CreateSchedule_Call) Return(schedule *entity.Schedule, err error) *MockScheduleDomainInterface_CreateSchedule_Call {
+	_c.Call.Return(schedule, err)
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_CreateSchedule_Call) RunAndReturn(run func(ctx context.Context, spec string, operation string, filePath string) (*entity.Schedule, error)) *MockScheduleDomainInterface_CreateSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSchedule provides a mock function for the type MockScheduleDomainInterface
+func (_mock *MockScheduleDomainInterface) DeleteSchedule(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchedule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockScheduleDomainInterface_DeleteSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSchedule'
+type MockScheduleDomainInterface_DeleteSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockScheduleDomainInterface_Expecter) DeleteSchedule(ctx interface{}, id interface{}) *MockScheduleDomainInterface_DeleteSchedule_Call {
+	return &MockScheduleDomainInterface_DeleteSchedule_Call{Call: _e.mock.On("DeleteSchedule", ctx, id)}
+}
+
+func (_c *MockScheduleDomainInterface_DeleteSchedule_Call) Run(run func(ctx context.Context, id string)) *MockScheduleDomainInterface_DeleteSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_DeleteSchedule_Call) Return(err error) *MockScheduleDomainInterface_DeleteSchedule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_DeleteSchedule_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockScheduleDomainInterface_DeleteSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSchedule provides a mock function for the type MockScheduleDomainInterface
+func (_mock *MockScheduleDomainInterface) GetSchedule(ctx context.Context, id string) (*entity.Schedule, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchedule")
+	}
+
+	var r0 *entity.Schedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.Schedule, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.Schedule); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Schedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScheduleDomainInterface_GetSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSchedule'
+type MockScheduleDomainInterface_GetSchedule_Call struct {
+	*mock.Call
+}
+
+// GetSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockScheduleDomainInterface_Expecter) GetSchedule(ctx interface{}, id interface{}) *MockScheduleDomainInterface_GetSchedule_Call {
+	return &MockScheduleDomainInterface_GetSchedule_Call{Call: _e.mock.On("GetSchedule", ctx, id)}
+}
+
+func (_c *MockScheduleDomainInterface_GetSchedule_Call) Run(run func(ctx context.Context, id string)) *MockScheduleDomainInterface_GetSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_GetSchedule_Call) Return(schedule *entity.Schedule, err error) *MockScheduleDomainInterface_GetSchedule_Call {
+	_c.Call.Return(schedule, err)
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_GetSchedule_Call) RunAndReturn(run func(ctx context.Context, id string) (*entity.Schedule, error)) *MockScheduleDomainInterface_GetSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSchedules provides a mock function for the type MockScheduleDomainInterface
+func (_mock *MockScheduleDomainInterface) ListSchedules(ctx context.Context) ([]*entity.Schedule, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSchedules")
+	}
+
+	var r0 []*entity.Schedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entity.Schedule, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entity.Schedule); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Schedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScheduleDomainInterface_ListSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSchedules'
+type MockScheduleDomainInterface_ListSchedules_Call struct {
+	*mock.Call
+}
+
+// ListSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockScheduleDomainInterface_Expecter) ListSchedules(ctx interface{}) *MockScheduleDomainInterface_ListSchedules_Call {
+	return &MockScheduleDomainInterface_ListSchedules_Call{Call: _e.mock.On("ListSchedules", ctx)}
+}
+
+func (_c *MockScheduleDomainInterface_ListSchedules_Call) Run(run func(ctx context.Context)) *MockScheduleDomainInterface_ListSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_ListSchedules_Call) Return(schedules []*entity.Schedule, err error) *MockScheduleDomainInterface_ListSchedules_Call {
+	_c.Call.Return(schedules, err)
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_ListSchedules_Call) RunAndReturn(run func(ctx context.Context) ([]*entity.Schedule, error)) *MockScheduleDomainInterface_ListSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function for the type MockScheduleDomainInterface
+func (_mock *MockScheduleDomainInterface) Stop(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockScheduleDomainInterface_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockScheduleDomainInterface_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockScheduleDomainInterface_Expecter) Stop(ctx interface{}) *MockScheduleDomainInterface_Stop_Call {
+	return &MockScheduleDomainInterface_Stop_Call{Call: _e.mock.On("Stop", ctx)}
+}
+
+func (_c *MockScheduleDomainInterface_Stop_Call) Run(run func(ctx context.Context)) *MockScheduleDomainInterface_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_Stop_Call) Return(err error) *MockScheduleDomainInterface_Stop_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockScheduleDomainInterface_Stop_Call) RunAndReturn(run func(ctx context.Context) error) *MockScheduleDomainInterface_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
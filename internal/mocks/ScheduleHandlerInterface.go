@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockScheduleHandlerInterface creates a new instance of MockScheduleHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockScheduleHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockScheduleHandlerInterface {
+	mock := &MockScheduleHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockScheduleHandlerInterface is an autogenerated mock type for the ScheduleHandlerInterface type
+type MockScheduleHandlerInterface struct {
+	mock.Mock
+}
+
+type MockScheduleHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockScheduleHandlerInterface) EXPECT() *MockScheduleHandlerInterface_Expecter {
+	return &MockScheduleHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// HandleSchedule provides a mock function for the type MockScheduleHandlerInterface
+func (_mock *MockScheduleHandlerInterface) HandleSchedule(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockScheduleHandlerInterface_HandleSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleSchedule'
+type MockScheduleHandlerInterface_HandleSchedule_Call struct {
+	*mock.Call
+}
+
+// HandleSchedule is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockScheduleHandlerInterface_Expecter) HandleSchedule(w interface{}, r interface{}) *MockScheduleHandlerInterface_HandleSchedule_Call {
+	return &MockScheduleHandlerInterface_HandleSchedule_Call{Call: _e.mock.On("HandleSchedule", w, r)}
+}
+
+func (_c *MockScheduleHandlerInterface_HandleSchedule_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockScheduleHandlerInterface_HandleSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduleHandlerInterface_HandleSchedule_Call) Return() *MockScheduleHandlerInterface_HandleSchedule_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockScheduleHandlerInterface_HandleSchedule_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockScheduleHandlerInterface_HandleSchedule_Call {
+	_c.Run(run)
+	return _c
+}
+
+// HandleSchedules provides a mock function for the type MockScheduleHandlerInterface
+func (_mock *MockScheduleHandlerInterface) HandleSchedules(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockScheduleHandlerInterface_HandleSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleSchedules'
+type MockScheduleHandlerInterface_HandleSchedules_Call struct {
+	*mock.Call
+}
+
+// HandleSchedules is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockScheduleHandlerInterface_Expecter) HandleSchedules(w interface{}, r interface{}) *MockScheduleHandlerInterface_HandleSchedules_Call {
+	return &MockScheduleHandlerInterface_HandleSchedules_Call{Call: _e.mock.On("HandleSchedules", w, r)}
+}
+
+func (_c *MockScheduleHandlerInterface_HandleSchedules_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockScheduleHandlerInterface_HandleSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduleHandlerInterface_HandleSchedules_Call) Return() *MockScheduleHandlerInterface_HandleSchedules_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockScheduleHandlerInterface_HandleSchedules_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockScheduleHandlerInterface_HandleSchedules_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSecretDomainInterface creates a new instance of MockSecretDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretDomainInterface {
+	mock := &MockSecretDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretDomainInterface is an autogenerated mock type for the SecretDomainInterface type
+type MockSecretDomainInterface struct {
+	mock.Mock
+}
+
+type MockSecretDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretDomainInterface) EXPECT() *MockSecretDomainInterface_Expecter {
+	return &MockSecretDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// CheckHealth provides a mock function for the type MockSecretDomainInterface
+func (_mock *MockSecretDomainInterface) CheckHealth(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretDomainInterface_CheckHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckHealth'
+type MockSecretDomainInterface_CheckHealth_Call struct {
+	*mock.Call
+}
+
+// CheckHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSecretDomainInterface_Expecter) CheckHealth(ctx interface{}) *MockSecretDomainInterface_CheckHealth_Call {
+	return &MockSecretDomainInterface_CheckHealth_Call{Call: _e.mock.On("CheckHealth", ctx)}
+}
+
+func (_c *MockSecretDomainInterface_CheckHealth_Call) Run(run func(ctx context.Context)) *MockSecretDomainInterface_CheckHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretDomainInterface_CheckHealth_Call) Return(err error) *MockSecretDomainInterface_CheckHealth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretDomainInterface_CheckHealth_Call) RunAndReturn(run func(ctx context.Context) error) *MockSecretDomainInterface_CheckHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Secret provides a mock function for the type MockSecretDomainInterface
+func (_mock *MockSecretDomainInterface) Secret(ctx context.Context, name string) (string, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Secret")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretDomainInterface_Secret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Secret'
+type MockSecretDomainInterface_Secret_Call struct {
+	*mock.Call
+}
+
+// Secret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockSecretDomainInterface_Expecter) Secret(ctx interface{}, name interface{}) *MockSecretDomainInterface_Secret_Call {
+	return &MockSecretDomainInterface_Secret_Call{Call: _e.mock.On("Secret", ctx, name)}
+}
+
+func (_c *MockSecretDomainInterface_Secret_Call) Run(run func(ctx context.Context, name string)) *MockSecretDomainInterface_Secret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretDomainInterface_Secret_Call) Return(s string, err error) *MockSecretDomainInterface_Secret_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSecretDomainInterface_Secret_Call) RunAndReturn(run func(ctx context.Context, name string) (string, error)) *MockSecretDomainInterface_Secret_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSecretRepositoryInterface creates a new instance of MockSecretRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretRepositoryInterface {
+	mock := &MockSecretRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretRepositoryInterface is an autogenerated mock type for the SecretRepositoryInterface type
+type MockSecretRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockSecretRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretRepositoryInterface) EXPECT() *MockSecretRepositoryInterface_Expecter {
+	return &MockSecretRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// CheckHealth provides a mock function for the type MockSecretRepositoryInterface
+func (_mock *MockSecretRepositoryInterface) CheckHealth(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretRepositoryInterface_CheckHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckHealth'
+type MockSecretRepositoryInterface_CheckHealth_Call struct {
+	*mock.Call
+}
+
+// CheckHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSecretRepositoryInterface_Expecter) CheckHealth(ctx interface{}) *MockSecretRepositoryInterface_CheckHealth_Call {
+	return &MockSecretRepositoryInterface_CheckHealth_Call{Call: _e.mock.On("CheckHealth", ctx)}
+}
+
+func (_c *MockSecretRepositoryInterface_CheckHealth_Call) Run(run func(ctx context.Context)) *MockSecretRepositoryInterface_CheckHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretRepositoryInterface_CheckHealth_Call) Return(err error) *MockSecretRepositoryInterface_CheckHealth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretRepositoryInterface_CheckHealth_Call) RunAndReturn(run func(ctx context.Context) error) *MockSecretRepositoryInterface_CheckHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSecret provides a mock function for the type MockSecretRepositoryInterface
+func (_mock *MockSecretRepositoryInterface) GetSecret(ctx context.Context, name string) (string, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecret")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretRepositoryInterface_GetSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecret'
+type MockSecretRepositoryInterface_GetSecret_Call struct {
+	*mock.Call
+}
+
+// GetSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockSecretRepositoryInterface_Expecter) GetSecret(ctx interface{}, name interface{}) *MockSecretRepositoryInterface_GetSecret_Call {
+	return &MockSecretRepositoryInterface_GetSecret_Call{Call: _e.mock.On("GetSecret", ctx, name)}
+}
+
+func (_c *MockSecretRepositoryInterface_GetSecret_Call) Run(run func(ctx context.Context, name string)) *MockSecretRepositoryInterface_GetSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretRepositoryInterface_GetSecret_Call) Return(s string, err error) *MockSecretRepositoryInterface_GetSecret_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSecretRepositoryInterface_GetSecret_Call) RunAndReturn(run func(ctx context.Context, name string) (string, error)) *MockSecretRepositoryInterface_GetSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockShutdownDomainInterface creates a new instance of MockShutdownDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockShutdownDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockShutdownDomainInterface {
+	mock := &MockShutdownDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockShutdownDomainInterface is an autogenerated mock type for the ShutdownDomainInterface type
+type MockShutdownDomainInterface struct {
+	mock.Mock
+}
+
+type MockShutdownDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockShutdownDomainInterface) EXPECT() *MockShutdownDomainInterface_Expecter {
+	return &MockShutdownDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Register provides a mock function for the type MockShutdownDomainInterface
+func (_mock *MockShutdownDomainInterface) Register(name string, timeout time.Duration, hook func(ctx context.Context) error) {
+	_mock.Called(name, timeout, hook)
+	return
+}
+
+// MockShutdownDomainInterface_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockShutdownDomainInterface_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - name string
+//   - timeout time.Duration
+//   - hook func(ctx context.Context) error
+func (_e *MockShutdownDomainInterface_Expecter) Register(name interface{}, timeout interface{}, hook interface{}) *MockShutdownDomainInterface_Register_Call {
+	return &MockShutdownDomainInterface_Register_Call{Call: _e.mock.On("Register", name, timeout, hook)}
+}
+
+func (_c *MockShutdownDomainInterface_Register_Call) Run(run func(name string, timeout time.Duration, hook func(ctx context.Context) error)) *MockShutdownDomainInterface_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		var arg2 func(ctx context.Context) error
+		if args[2] != nil {
+			arg2 = args[2].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShutdownDomainInterface_Register_Call) Return() *MockShutdownDomainInterface_Register_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShutdownDomainInterface_Register_Call) RunAndReturn(run func(name string, timeout time.Duration, hook func(ctx context.Context) error)) *MockShutdownDomainInterface_Register_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Shutdown provides a mock function for the type MockShutdownDomainInterface
+func (_mock *MockShutdownDomainInterface) Shutdown(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockShutdownDomainInterface_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MockShutdownDomainInterface_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockShutdownDomainInterface_Expecter) Shutdown(ctx interface{}) *MockShutdownDomainInterface_Shutdown_Call {
+	return &MockShutdownDomainInterface_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *MockShutdownDomainInterface_Shutdown_Call) Run(run func(ctx context.Context)) *MockShutdownDomainInterface_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShutdownDomainInterface_Shutdown_Call) Return(err error) *MockShutdownDomainInterface_Shutdown_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockShutdownDomainInterface_Shutdown_Call) RunAndReturn(run func(ctx context.Context) error) *MockShutdownDomainInterface_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,188 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSignedURLDomainInterface creates a new instance of MockSignedURLDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignedURLDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignedURLDomainInterface {
+	mock := &MockSignedURLDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSignedURLDomainInterface is an autogenerated mock type for the SignedURLDomainInterface type
+type MockSignedURLDomainInterface struct {
+	mock.Mock
+}
+
+type MockSignedURLDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignedURLDomainInterface) EXPECT() *MockSignedURLDomainInterface_Expecter {
+	return &MockSignedURLDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// Sign provides a mock function for the type MockSignedURLDomainInterface
+func (_mock *MockSignedURLDomainInterface) Sign(ctx context.Context, filePath string, operation string, ttl time.Duration) (*entity.SignedURL, error) {
+	ret := _mock.Called(ctx, filePath, operation, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 *entity.SignedURL
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (*entity.SignedURL, error)); ok {
+		return returnFunc(ctx, filePath, operation, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) *entity.SignedURL); ok {
+		r0 = returnFunc(ctx, filePath, operation, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.SignedURL)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = returnFunc(ctx, filePath, operation, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSignedURLDomainInterface_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type MockSignedURLDomainInterface_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - operation string
+//   - ttl time.Duration
+func (_e *MockSignedURLDomainInterface_Expecter) Sign(ctx interface{}, filePath interface{}, operation interface{}, ttl interface{}) *MockSignedURLDomainInterface_Sign_Call {
+	return &MockSignedURLDomainInterface_Sign_Call{Call: _e.mock.On("Sign", ctx, filePath, operation, ttl)}
+}
+
+func (_c *MockSignedURLDomainInterface_Sign_Call) Run(run func(ctx context.Context, filePath string, operation string, ttl time.Duration)) *MockSignedURLDomainInterface_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignedURLDomainInterface_Sign_Call) Return(signedURL *entity.SignedURL, err error) *MockSignedURLDomainInterface_Sign_Call {
+	_c.Call.Return(signedURL, err)
+	return _c
+}
+
+func (_c *MockSignedURLDomainInterface_Sign_Call) RunAndReturn(run func(ctx context.Context, filePath string, operation string, ttl time.Duration) (*entity.SignedURL, error)) *MockSignedURLDomainInterface_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockSignedURLDomainInterface
+func (_mock *MockSignedURLDomainInterface) Verify(ctx context.Context, signed *entity.SignedURL) (context.Context, error) {
+	ret := _mock.Called(ctx, signed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 context.Context
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.SignedURL) (context.Context, error)); ok {
+		return returnFunc(ctx, signed)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.SignedURL) context.Context); ok {
+		r0 = returnFunc(ctx, signed)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.SignedURL) error); ok {
+		r1 = returnFunc(ctx, signed)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSignedURLDomainInterface_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockSignedURLDomainInterface_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signed *entity.SignedURL
+func (_e *MockSignedURLDomainInterface_Expecter) Verify(ctx interface{}, signed interface{}) *MockSignedURLDomainInterface_Verify_Call {
+	return &MockSignedURLDomainInterface_Verify_Call{Call: _e.mock.On("Verify", ctx, signed)}
+}
+
+func (_c *MockSignedURLDomainInterface_Verify_Call) Run(run func(ctx context.Context, signed *entity.SignedURL)) *MockSignedURLDomainInterface_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.SignedURL
+		if args[1] != nil {
+			arg1 = args[1].(*entity.SignedURL)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignedURLDomainInterface_Verify_Call) Return(context1 context.Context, err error) *MockSignedURLDomainInterface_Verify_Call {
+	_c.Call.Return(context1, err)
+	return _c
+}
+
+func (_c *MockSignedURLDomainInterface_Verify_Call) RunAndReturn(run func(ctx context.Context, signed *entity.SignedURL) (context.Context, error)) *MockSignedURLDomainInterface_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSignedURLHandlerInterface creates a new instance of MockSignedURLHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignedURLHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignedURLHandlerInterface {
+	mock := &MockSignedURLHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSignedURLHandlerInterface is an autogenerated mock type for the SignedURLHandlerInterface type
+type MockSignedURLHandlerInterface struct {
+	mock.Mock
+}
+
+type MockSignedURLHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignedURLHandlerInterface) EXPECT() *MockSignedURLHandlerInterface_Expecter {
+	return &MockSignedURLHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateSignedURL provides a mock function for the type MockSignedURLHandlerInterface
+func (_mock *MockSignedURLHandlerInterface) CreateSignedURL(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockSignedURLHandlerInterface_CreateSignedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSignedURL'
+type MockSignedURLHandlerInterface_CreateSignedURL_Call struct {
+	*mock.Call
+}
+
+// CreateSignedURL is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockSignedURLHandlerInterface_Expecter) CreateSignedURL(w interface{}, r interface{}) *MockSignedURLHandlerInterface_CreateSignedURL_Call {
+	return &MockSignedURLHandlerInterface_CreateSignedURL_Call{Call: _e.mock.On("CreateSignedURL", w, r)}
+}
+
+func (_c *MockSignedURLHandlerInterface_CreateSignedURL_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockSignedURLHandlerInterface_CreateSignedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignedURLHandlerInterface_CreateSignedURL_Call) Return() *MockSignedURLHandlerInterface_CreateSignedURL_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSignedURLHandlerInterface_CreateSignedURL_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockSignedURLHandlerInterface_CreateSignedURL_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RequireSignature provides a mock function for the type MockSignedURLHandlerInterface
+func (_mock *MockSignedURLHandlerInterface) RequireSignature(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireSignature")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockSignedURLHandlerInterface_RequireSignature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequireSignature'
+type MockSignedURLHandlerInterface_RequireSignature_Call struct {
+	*mock.Call
+}
+
+// RequireSignature is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockSignedURLHandlerInterface_Expecter) RequireSignature(next interface{}) *MockSignedURLHandlerInterface_RequireSignature_Call {
+	return &MockSignedURLHandlerInterface_RequireSignature_Call{Call: _e.mock.On("RequireSignature", next)}
+}
+
+func (_c *MockSignedURLHandlerInterface_RequireSignature_Call) Run(run func(next http.Handler)) *MockSignedURLHandlerInterface_RequireSignature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignedURLHandlerInterface_RequireSignature_Call) Return(handler http.Handler) *MockSignedURLHandlerInterface_RequireSignature_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockSignedURLHandlerInterface_RequireSignature_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockSignedURLHandlerInterface_RequireSignature_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,320 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"io"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockStreamingCodec creates a new instance of MockStreamingCodec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStreamingCodec(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStreamingCodec {
+	mock := &MockStreamingCodec{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStreamingCodec is an autogenerated mock type for the StreamingCodec type
+type MockStreamingCodec struct {
+	mock.Mock
+}
+
+type MockStreamingCodec_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStreamingCodec) EXPECT() *MockStreamingCodec_Expecter {
+	return &MockStreamingCodec_Expecter{mock: &_m.Mock}
+}
+
+// ContentType provides a mock function for the type MockStreamingCodec
+func (_mock *MockStreamingCodec) ContentType() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContentType")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockStreamingCodec_ContentType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContentType'
+type MockStreamingCodec_ContentType_Call struct {
+	*mock.Call
+}
+
+// ContentType is a helper method to define mock.On call
+func (_e *MockStreamingCodec_Expecter) ContentType() *MockStreamingCodec_ContentType_Call {
+	return &MockStreamingCodec_ContentType_Call{Call: _e.mock.On("ContentType")}
+}
+
+func (_c *MockStreamingCodec_ContentType_Call) Run(run func()) *MockStreamingCodec_ContentType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStreamingCodec_ContentType_Call) Return(s string) *MockStreamingCodec_ContentType_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockStreamingCodec_ContentType_Call) RunAndReturn(run func() string) *MockStreamingCodec_ContentType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DecodeMatrix provides a mock function for the type MockStreamingCodec
+func (_mock *MockStreamingCodec) DecodeMatrix(data []byte) (*entity.Matrix[int64], error) {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecodeMatrix")
+	}
+
+	var r0 *entity.Matrix[int64]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) (*entity.Matrix[int64], error)); ok {
+		return returnFunc(data)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) *entity.Matrix[int64]); ok {
+		r0 = returnFunc(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Matrix[int64])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStreamingCodec_DecodeMatrix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecodeMatrix'
+type MockStreamingCodec_DecodeMatrix_Call struct {
+	*mock.Call
+}
+
+// DecodeMatrix is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockStreamingCodec_Expecter) DecodeMatrix(data interface{}) *MockStreamingCodec_DecodeMatrix_Call {
+	return &MockStreamingCodec_DecodeMatrix_Call{Call: _e.mock.On("DecodeMatrix", data)}
+}
+
+func (_c *MockStreamingCodec_DecodeMatrix_Call) Run(run func(data []byte)) *MockStreamingCodec_DecodeMatrix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStreamingCodec_DecodeMatrix_Call) Return(v *entity.Matrix[int64], err error) *MockStreamingCodec_DecodeMatrix_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockStreamingCodec_DecodeMatrix_Call) RunAndReturn(run func(data []byte) (*entity.Matrix[int64], error)) *MockStreamingCodec_DecodeMatrix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EncodeResult provides a mock function for the type MockStreamingCodec
+func (_mock *MockStreamingCodec) EncodeResult(operation string, result *entity.Result) ([]byte, error) {
+	ret := _mock.Called(operation, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EncodeResult")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, *entity.Result) ([]byte, error)); ok {
+		return returnFunc(operation, result)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, *entity.Result) []byte); ok {
+		r0 = returnFunc(operation, result)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, *entity.Result) error); ok {
+		r1 = returnFunc(operation, result)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStreamingCodec_EncodeResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EncodeResult'
+type MockStreamingCodec_EncodeResult_Call struct {
+	*mock.Call
+}
+
+// EncodeResult is a helper method to define mock.On call
+//   - operation string
+//   - result *entity.Result
+func (_e *MockStreamingCodec_Expecter) EncodeResult(operation interface{}, result interface{}) *MockStreamingCodec_EncodeResult_Call {
+	return &MockStreamingCodec_EncodeResult_Call{Call: _e.mock.On("EncodeResult", operation, result)}
+}
+
+func (_c *MockStreamingCodec_EncodeResult_Call) Run(run func(operation string, result *entity.Result)) *MockStreamingCodec_EncodeResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *entity.Result
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Result)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStreamingCodec_EncodeResult_Call) Return(bytes []byte, err error) *MockStreamingCodec_EncodeResult_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockStreamingCodec_EncodeResult_Call) RunAndReturn(run func(operation string, result *entity.Result) ([]byte, error)) *MockStreamingCodec_EncodeResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileExtension provides a mock function for the type MockStreamingCodec
+func (_mock *MockStreamingCodec) FileExtension() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileExtension")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockStreamingCodec_FileExtension_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileExtension'
+type MockStreamingCodec_FileExtension_Call struct {
+	*mock.Call
+}
+
+// FileExtension is a helper method to define mock.On call
+func (_e *MockStreamingCodec_Expecter) FileExtension() *MockStreamingCodec_FileExtension_Call {
+	return &MockStreamingCodec_FileExtension_Call{Call: _e.mock.On("FileExtension")}
+}
+
+func (_c *MockStreamingCodec_FileExtension_Call) Run(run func()) *MockStreamingCodec_FileExtension_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStreamingCodec_FileExtension_Call) Return(s string) *MockStreamingCodec_FileExtension_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockStreamingCodec_FileExtension_Call) RunAndReturn(run func() string) *MockStreamingCodec_FileExtension_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteResult provides a mock function for the type MockStreamingCodec
+func (_mock *MockStreamingCodec) WriteResult(w io.Writer, operation string, result *entity.Result) error {
+	ret := _mock.Called(w, operation, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteResult")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(io.Writer, string, *entity.Result) error); ok {
+		r0 = returnFunc(w, operation, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStreamingCodec_WriteResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteResult'
+type MockStreamingCodec_WriteResult_Call struct {
+	*mock.Call
+}
+
+// WriteResult is a helper method to define mock.On call
+//   - w io.Writer
+//   - operation string
+//   - result *entity.Result
+func (_e *MockStreamingCodec_Expecter) WriteResult(w interface{}, operation interface{}, result interface{}) *MockStreamingCodec_WriteResult_Call {
+	return &MockStreamingCodec_WriteResult_Call{Call: _e.mock.On("WriteResult", w, operation, result)}
+}
+
+func (_c *MockStreamingCodec_WriteResult_Call) Run(run func(w io.Writer, operation string, result *entity.Result)) *MockStreamingCodec_WriteResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 io.Writer
+		if args[0] != nil {
+			arg0 = args[0].(io.Writer)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *entity.Result
+		if args[2] != nil {
+			arg2 = args[2].(*entity.Result)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStreamingCodec_WriteResult_Call) Return(err error) *MockStreamingCodec_WriteResult_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStreamingCodec_WriteResult_Call) RunAndReturn(run func(w io.Writer, operation string, result *entity.Result) error) *MockStreamingCodec_WriteResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTagHandlerInterface creates a new instance of MockTagHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTagHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTagHandlerInterface {
+	mock := &MockTagHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTagHandlerInterface is an autogenerated mock type for the TagHandlerInterface type
+type MockTagHandlerInterface struct {
+	mock.Mock
+}
+
+type MockTagHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTagHandlerInterface) EXPECT() *MockTagHandlerInterface_Expecter {
+	return &MockTagHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// HandleTags provides a mock function for the type MockTagHandlerInterface
+func (_mock *MockTagHandlerInterface) HandleTags(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockTagHandlerInterface_HandleTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleTags'
+type MockTagHandlerInterface_HandleTags_Call struct {
+	*mock.Call
+}
+
+// HandleTags is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockTagHandlerInterface_Expecter) HandleTags(w interface{}, r interface{}) *MockTagHandlerInterface_HandleTags_Call {
+	return &MockTagHandlerInterface_HandleTags_Call{Call: _e.mock.On("HandleTags", w, r)}
+}
+
+func (_c *MockTagHandlerInterface_HandleTags_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockTagHandlerInterface_HandleTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTagHandlerInterface_HandleTags_Call) Return() *MockTagHandlerInterface_HandleTags_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTagHandlerInterface_HandleTags_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockTagHandlerInterface_HandleTags_Call {
+	_c.Run(run)
+	return _c
+}
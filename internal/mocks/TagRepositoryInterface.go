@@ -0,0 +1,169 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTagRepositoryInterface creates a new instance of MockTagRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTagRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTagRepositoryInterface {
+	mock := &MockTagRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTagRepositoryInterface is an autogenerated mock type for the TagRepositoryInterface type
+type MockTagRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockTagRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTagRepositoryInterface) EXPECT() *MockTagRepositoryInterface_Expecter {
+	return &MockTagRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetTags provides a mock function for the type MockTagRepositoryInterface
+func (_mock *MockTagRepositoryInterface) GetTags(ctx context.Context, filePath string) (map[string]string, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTagRepositoryInterface_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
+type MockTagRepositoryInterface_GetTags_Call struct {
+	*mock.Call
+}
+
+// GetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockTagRepositoryInterface_Expecter) GetTags(ctx interface{}, filePath interface{}) *MockTagRepositoryInterface_GetTags_Call {
+	return &MockTagRepositoryInterface_GetTags_Call{Call: _e.mock.On("GetTags", ctx, filePath)}
+}
+
+func (_c *MockTagRepositoryInterface_GetTags_Call) Run(run func(ctx context.Context, filePath string)) *MockTagRepositoryInterface_GetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTagRepositoryInterface_GetTags_Call) Return(stringToString map[string]string, err error) *MockTagRepositoryInterface_GetTags_Call {
+	_c.Call.Return(stringToString, err)
+	return _c
+}
+
+func (_c *MockTagRepositoryInterface_GetTags_Call) RunAndReturn(run func(ctx context.Context, filePath string) (map[string]string, error)) *MockTagRepositoryInterface_GetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTags provides a mock function for the type MockTagRepositoryInterface
+func (_mock *MockTagRepositoryInterface) SetTags(ctx context.Context, filePath string, tags map[string]string) error {
+	ret := _mock.Called(ctx, filePath, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]string) error); ok {
+		r0 = returnFunc(ctx, filePath, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTagRepositoryInterface_SetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTags'
+type MockTagRepositoryInterface_SetTags_Call struct {
+	*mock.Call
+}
+
+// SetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - tags map[string]string
+func (_e *MockTagRepositoryInterface_Expecter) SetTags(ctx interface{}, filePath interface{}, tags interface{}) *MockTagRepositoryInterface_SetTags_Call {
+	return &MockTagRepositoryInterface_SetTags_Call{Call: _e.mock.On("SetTags", ctx, filePath, tags)}
+}
+
+func (_c *MockTagRepositoryInterface_SetTags_Call) Run(run func(ctx context.Context, filePath string, tags map[string]string)) *MockTagRepositoryInterface_SetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 map[string]string
+		if args[2] != nil {
+			arg2 = args[2].(map[string]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTagRepositoryInterface_SetTags_Call) Return(err error) *MockTagRepositoryInterface_SetTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTagRepositoryInterface_SetTags_Call) RunAndReturn(run func(ctx context.Context, filePath string, tags map[string]string) error) *MockTagRepositoryInterface_SetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,187 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"log/slog"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTelemetryDomainInterface creates a new instance of MockTelemetryDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTelemetryDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTelemetryDomainInterface {
+	mock := &MockTelemetryDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTelemetryDomainInterface is an autogenerated mock type for the TelemetryDomainInterface type
+type MockTelemetryDomainInterface struct {
+	mock.Mock
+}
+
+type MockTelemetryDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTelemetryDomainInterface) EXPECT() *MockTelemetryDomainInterface_Expecter {
+	return &MockTelemetryDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// ExportsLogs provides a mock function for the type MockTelemetryDomainInterface
+func (_mock *MockTelemetryDomainInterface) ExportsLogs() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportsLogs")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockTelemetryDomainInterface_ExportsLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportsLogs'
+type MockTelemetryDomainInterface_ExportsLogs_Call struct {
+	*mock.Call
+}
+
+// ExportsLogs is a helper method to define mock.On call
+func (_e *MockTelemetryDomainInterface_Expecter) ExportsLogs() *MockTelemetryDomainInterface_ExportsLogs_Call {
+	return &MockTelemetryDomainInterface_ExportsLogs_Call{Call: _e.mock.On("ExportsLogs")}
+}
+
+func (_c *MockTelemetryDomainInterface_ExportsLogs_Call) Run(run func()) *MockTelemetryDomainInterface_ExportsLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTelemetryDomainInterface_ExportsLogs_Call) Return(b bool) *MockTelemetryDomainInterface_ExportsLogs_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockTelemetryDomainInterface_ExportsLogs_Call) RunAndReturn(run func() bool) *MockTelemetryDomainInterface_ExportsLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogHandler provides a mock function for the type MockTelemetryDomainInterface
+func (_mock *MockTelemetryDomainInterface) LogHandler(base slog.Handler) slog.Handler {
+	ret := _mock.Called(base)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogHandler")
+	}
+
+	var r0 slog.Handler
+	if returnFunc, ok := ret.Get(0).(func(slog.Handler) slog.Handler); ok {
+		r0 = returnFunc(base)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(slog.Handler)
+		}
+	}
+	return r0
+}
+
+// MockTelemetryDomainInterface_LogHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogHandler'
+type MockTelemetryDomainInterface_LogHandler_Call struct {
+	*mock.Call
+}
+
+// LogHandler is a helper method to define mock.On call
+//   - base slog.Handler
+func (_e *MockTelemetryDomainInterface_Expecter) LogHandler(base interface{}) *MockTelemetryDomainInterface_LogHandler_Call {
+	return &MockTelemetryDomainInterface_LogHandler_Call{Call: _e.mock.On("LogHandler", base)}
+}
+
+func (_c *MockTelemetryDomainInterface_LogHandler_Call) Run(run func(base slog.Handler)) *MockTelemetryDomainInterface_LogHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 slog.Handler
+		if args[0] != nil {
+			arg0 = args[0].(slog.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryDomainInterface_LogHandler_Call) Return(handler slog.Handler) *MockTelemetryDomainInterface_LogHandler_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockTelemetryDomainInterface_LogHandler_Call) RunAndReturn(run func(base slog.Handler) slog.Handler) *MockTelemetryDomainInterface_LogHandler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Shutdown provides a mock function for the type MockTelemetryDomainInterface
+func (_mock *MockTelemetryDomainInterface) Shutdown(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTelemetryDomainInterface_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MockTelemetryDomainInterface_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTelemetryDomainInterface_Expecter) Shutdown(ctx interface{}) *MockTelemetryDomainInterface_Shutdown_Call {
+	return &MockTelemetryDomainInterface_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *MockTelemetryDomainInterface_Shutdown_Call) Run(run func(ctx context.Context)) *MockTelemetryDomainInterface_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryDomainInterface_Shutdown_Call) Return(err error) *MockTelemetryDomainInterface_Shutdown_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTelemetryDomainInterface_Shutdown_Call) RunAndReturn(run func(ctx context.Context) error) *MockTelemetryDomainInterface_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,426 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTenantDomainInterface creates a new instance of MockTenantDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTenantDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTenantDomainInterface {
+	mock := &MockTenantDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTenantDomainInterface is an autogenerated mock type for the TenantDomainInterface type
+type MockTenantDomainInterface struct {
+	mock.Mock
+}
+
+type MockTenantDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTenantDomainInterface) EXPECT() *MockTenantDomainInterface_Expecter {
+	return &MockTenantDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// AllowRequest provides a mock function for the type MockTenantDomainInterface
+func (_mock *MockTenantDomainInterface) AllowRequest(ctx context.Context, t *tenant.Tenant, apiKey string) (*entity.RateLimit, error) {
+	ret := _mock.Called(ctx, t, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllowRequest")
+	}
+
+	var r0 *entity.RateLimit
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tenant.Tenant, string) (*entity.RateLimit, error)); ok {
+		return returnFunc(ctx, t, apiKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tenant.Tenant, string) *entity.RateLimit); ok {
+		r0 = returnFunc(ctx, t, apiKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RateLimit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tenant.Tenant, string) error); ok {
+		r1 = returnFunc(ctx, t, apiKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTenantDomainInterface_AllowRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllowRequest'
+type MockTenantDomainInterface_AllowRequest_Call struct {
+	*mock.Call
+}
+
+// AllowRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t *tenant.Tenant
+//   - apiKey string
+func (_e *MockTenantDomainInterface_Expecter) AllowRequest(ctx interface{}, t interface{}, apiKey interface{}) *MockTenantDomainInterface_AllowRequest_Call {
+	return &MockTenantDomainInterface_AllowRequest_Call{Call: _e.mock.On("AllowRequest", ctx, t, apiKey)}
+}
+
+func (_c *MockTenantDomainInterface_AllowRequest_Call) Run(run func(ctx context.Context, t *tenant.Tenant, apiKey string)) *MockTenantDomainInterface_AllowRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tenant.Tenant
+		if args[1] != nil {
+			arg1 = args[1].(*tenant.Tenant)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_AllowRequest_Call) Return(rateLimit *entity.RateLimit, err error) *MockTenantDomainInterface_AllowRequest_Call {
+	_c.Call.Return(rateLimit, err)
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_AllowRequest_Call) RunAndReturn(run func(ctx context.Context, t *tenant.Tenant, apiKey string) (*entity.RateLimit, error)) *MockTenantDomainInterface_AllowRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Authenticate provides a mock function for the type MockTenantDomainInterface
+func (_mock *MockTenantDomainInterface) Authenticate(ctx context.Context, apiKey string) (*tenant.Tenant, error) {
+	ret := _mock.Called(ctx, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *tenant.Tenant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*tenant.Tenant, error)); ok {
+		return returnFunc(ctx, apiKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *tenant.Tenant); ok {
+		r0 = returnFunc(ctx, apiKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tenant.Tenant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, apiKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTenantDomainInterface_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockTenantDomainInterface_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey string
+func (_e *MockTenantDomainInterface_Expecter) Authenticate(ctx interface{}, apiKey interface{}) *MockTenantDomainInterface_Authenticate_Call {
+	return &MockTenantDomainInterface_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, apiKey)}
+}
+
+func (_c *MockTenantDomainInterface_Authenticate_Call) Run(run func(ctx context.Context, apiKey string)) *MockTenantDomainInterface_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_Authenticate_Call) Return(tenant1 *tenant.Tenant, err error) *MockTenantDomainInterface_Authenticate_Call {
+	_c.Call.Return(tenant1, err)
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_Authenticate_Call) RunAndReturn(run func(ctx context.Context, apiKey string) (*tenant.Tenant, error)) *MockTenantDomainInterface_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enabled provides a mock function for the type MockTenantDomainInterface
+func (_mock *MockTenantDomainInterface) Enabled() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enabled")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockTenantDomainInterface_Enabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enabled'
+type MockTenantDomainInterface_Enabled_Call struct {
+	*mock.Call
+}
+
+// Enabled is a helper method to define mock.On call
+func (_e *MockTenantDomainInterface_Expecter) Enabled() *MockTenantDomainInterface_Enabled_Call {
+	return &MockTenantDomainInterface_Enabled_Call{Call: _e.mock.On("Enabled")}
+}
+
+func (_c *MockTenantDomainInterface_Enabled_Call) Run(run func()) *MockTenantDomainInterface_Enabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_Enabled_Call) Return(b bool) *MockTenantDomainInterface_Enabled_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_Enabled_Call) RunAndReturn(run func() bool) *MockTenantDomainInterface_Enabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenant provides a mock function for the type MockTenantDomainInterface
+func (_mock *MockTenantDomainInterface) GetTenant(ctx context.Context, id string) (*tenant.Tenant, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenant")
+	}
+
+	var r0 *tenant.Tenant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*tenant.Tenant, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *tenant.Tenant); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tenant.Tenant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTenantDomainInterface_GetTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenant'
+type MockTenantDomainInterface_GetTenant_Call struct {
+	*mock.Call
+}
+
+// GetTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTenantDomainInterface_Expecter) GetTenant(ctx interface{}, id interface{}) *MockTenantDomainInterface_GetTenant_Call {
+	return &MockTenantDomainInterface_GetTenant_Call{Call: _e.mock.On("GetTenant", ctx, id)}
+}
+
+func (_c *MockTenantDomainInterface_GetTenant_Call) Run(run func(ctx context.Context, id string)) *MockTenantDomainInterface_GetTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_GetTenant_Call) Return(tenant1 *tenant.Tenant, err error) *MockTenantDomainInterface_GetTenant_Call {
+	_c.Call.Return(tenant1, err)
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_GetTenant_Call) RunAndReturn(run func(ctx context.Context, id string) (*tenant.Tenant, error)) *MockTenantDomainInterface_GetTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsage provides a mock function for the type MockTenantDomainInterface
+func (_mock *MockTenantDomainInterface) ListUsage(ctx context.Context) ([]*entity.ClientUsage, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsage")
+	}
+
+	var r0 []*entity.ClientUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entity.ClientUsage, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entity.ClientUsage); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.ClientUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTenantDomainInterface_ListUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsage'
+type MockTenantDomainInterface_ListUsage_Call struct {
+	*mock.Call
+}
+
+// ListUsage is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTenantDomainInterface_Expecter) ListUsage(ctx interface{}) *MockTenantDomainInterface_ListUsage_Call {
+	return &MockTenantDomainInterface_ListUsage_Call{Call: _e.mock.On("ListUsage", ctx)}
+}
+
+func (_c *MockTenantDomainInterface_ListUsage_Call) Run(run func(ctx context.Context)) *MockTenantDomainInterface_ListUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_ListUsage_Call) Return(clientUsages []*entity.ClientUsage, err error) *MockTenantDomainInterface_ListUsage_Call {
+	_c.Call.Return(clientUsages, err)
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_ListUsage_Call) RunAndReturn(run func(ctx context.Context) ([]*entity.ClientUsage, error)) *MockTenantDomainInterface_ListUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordUsage provides a mock function for the type MockTenantDomainInterface
+func (_mock *MockTenantDomainInterface) RecordUsage(ctx context.Context, t *tenant.Tenant, apiKey string, computeTime time.Duration) error {
+	ret := _mock.Called(ctx, t, apiKey, computeTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tenant.Tenant, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, t, apiKey, computeTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTenantDomainInterface_RecordUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordUsage'
+type MockTenantDomainInterface_RecordUsage_Call struct {
+	*mock.Call
+}
+
+// RecordUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t *tenant.Tenant
+//   - apiKey string
+//   - computeTime time.Duration
+func (_e *MockTenantDomainInterface_Expecter) RecordUsage(ctx interface{}, t interface{}, apiKey interface{}, computeTime interface{}) *MockTenantDomainInterface_RecordUsage_Call {
+	return &MockTenantDomainInterface_RecordUsage_Call{Call: _e.mock.On("RecordUsage", ctx, t, apiKey, computeTime)}
+}
+
+func (_c *MockTenantDomainInterface_RecordUsage_Call) Run(run func(ctx context.Context, t *tenant.Tenant, apiKey string, computeTime time.Duration)) *MockTenantDomainInterface_RecordUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tenant.Tenant
+		if args[1] != nil {
+			arg1 = args[1].(*tenant.Tenant)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_RecordUsage_Call) Return(err error) *MockTenantDomainInterface_RecordUsage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTenantDomainInterface_RecordUsage_Call) RunAndReturn(run func(ctx context.Context, t *tenant.Tenant, apiKey string, computeTime time.Duration) error) *MockTenantDomainInterface_RecordUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTenantHandlerInterface creates a new instance of MockTenantHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTenantHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTenantHandlerInterface {
+	mock := &MockTenantHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTenantHandlerInterface is an autogenerated mock type for the TenantHandlerInterface type
+type MockTenantHandlerInterface struct {
+	mock.Mock
+}
+
+type MockTenantHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTenantHandlerInterface) EXPECT() *MockTenantHandlerInterface_Expecter {
+	return &MockTenantHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListUsage provides a mock function for the type MockTenantHandlerInterface
+func (_mock *MockTenantHandlerInterface) ListUsage(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockTenantHandlerInterface_ListUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsage'
+type MockTenantHandlerInterface_ListUsage_Call struct {
+	*mock.Call
+}
+
+// ListUsage is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockTenantHandlerInterface_Expecter) ListUsage(w interface{}, r interface{}) *MockTenantHandlerInterface_ListUsage_Call {
+	return &MockTenantHandlerInterface_ListUsage_Call{Call: _e.mock.On("ListUsage", w, r)}
+}
+
+func (_c *MockTenantHandlerInterface_ListUsage_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockTenantHandlerInterface_ListUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTenantHandlerInterface_ListUsage_Call) Return() *MockTenantHandlerInterface_ListUsage_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTenantHandlerInterface_ListUsage_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockTenantHandlerInterface_ListUsage_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RequireTenant provides a mock function for the type MockTenantHandlerInterface
+func (_mock *MockTenantHandlerInterface) RequireTenant(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireTenant")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockTenantHandlerInterface_RequireTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequireTenant'
+type MockTenantHandlerInterface_RequireTenant_Call struct {
+	*mock.Call
+}
+
+// RequireTenant is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockTenantHandlerInterface_Expecter) RequireTenant(next interface{}) *MockTenantHandlerInterface_RequireTenant_Call {
+	return &MockTenantHandlerInterface_RequireTenant_Call{Call: _e.mock.On("RequireTenant", next)}
+}
+
+func (_c *MockTenantHandlerInterface_RequireTenant_Call) Run(run func(next http.Handler)) *MockTenantHandlerInterface_RequireTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTenantHandlerInterface_RequireTenant_Call) Return(handler http.Handler) *MockTenantHandlerInterface_RequireTenant_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockTenantHandlerInterface_RequireTenant_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockTenantHandlerInterface_RequireTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTimeoutHandlerInterface creates a new instance of MockTimeoutHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTimeoutHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTimeoutHandlerInterface {
+	mock := &MockTimeoutHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTimeoutHandlerInterface is an autogenerated mock type for the TimeoutHandlerInterface type
+type MockTimeoutHandlerInterface struct {
+	mock.Mock
+}
+
+type MockTimeoutHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTimeoutHandlerInterface) EXPECT() *MockTimeoutHandlerInterface_Expecter {
+	return &MockTimeoutHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// Limit provides a mock function for the type MockTimeoutHandlerInterface
+func (_mock *MockTimeoutHandlerInterface) Limit(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Limit")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockTimeoutHandlerInterface_Limit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Limit'
+type MockTimeoutHandlerInterface_Limit_Call struct {
+	*mock.Call
+}
+
+// Limit is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockTimeoutHandlerInterface_Expecter) Limit(next interface{}) *MockTimeoutHandlerInterface_Limit_Call {
+	return &MockTimeoutHandlerInterface_Limit_Call{Call: _e.mock.On("Limit", next)}
+}
+
+func (_c *MockTimeoutHandlerInterface_Limit_Call) Run(run func(next http.Handler)) *MockTimeoutHandlerInterface_Limit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTimeoutHandlerInterface_Limit_Call) Return(handler http.Handler) *MockTimeoutHandlerInterface_Limit_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockTimeoutHandlerInterface_Limit_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockTimeoutHandlerInterface_Limit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LimitOperation provides a mock function for the type MockTimeoutHandlerInterface
+func (_mock *MockTimeoutHandlerInterface) LimitOperation(next http.Handler) http.Handler {
+	ret := _mock.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LimitOperation")
+	}
+
+	var r0 http.Handler
+	if returnFunc, ok := ret.Get(0).(func(http.Handler) http.Handler); ok {
+		r0 = returnFunc(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.Handler)
+		}
+	}
+	return r0
+}
+
+// MockTimeoutHandlerInterface_LimitOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LimitOperation'
+type MockTimeoutHandlerInterface_LimitOperation_Call struct {
+	*mock.Call
+}
+
+// LimitOperation is a helper method to define mock.On call
+//   - next http.Handler
+func (_e *MockTimeoutHandlerInterface_Expecter) LimitOperation(next interface{}) *MockTimeoutHandlerInterface_LimitOperation_Call {
+	return &MockTimeoutHandlerInterface_LimitOperation_Call{Call: _e.mock.On("LimitOperation", next)}
+}
+
+func (_c *MockTimeoutHandlerInterface_LimitOperation_Call) Run(run func(next http.Handler)) *MockTimeoutHandlerInterface_LimitOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.Handler
+		if args[0] != nil {
+			arg0 = args[0].(http.Handler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTimeoutHandlerInterface_LimitOperation_Call) Return(handler http.Handler) *MockTimeoutHandlerInterface_LimitOperation_Call {
+	_c.Call.Return(handler)
+	return _c
+}
+
+func (_c *MockTimeoutHandlerInterface_LimitOperation_Call) RunAndReturn(run func(next http.Handler) http.Handler) *MockTimeoutHandlerInterface_LimitOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,371 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUploadDomainInterface creates a new instance of MockUploadDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUploadDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUploadDomainInterface {
+	mock := &MockUploadDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUploadDomainInterface is an autogenerated mock type for the UploadDomainInterface type
+type MockUploadDomainInterface struct {
+	mock.Mock
+}
+
+type MockUploadDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUploadDomainInterface) EXPECT() *MockUploadDomainInterface_Expecter {
+	return &MockUploadDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// AppendChunk provides a mock function for the type MockUploadDomainInterface
+func (_mock *MockUploadDomainInterface) AppendChunk(ctx context.Context, id string, offset int64, chunk io.Reader) (*entity.Upload, error) {
+	ret := _mock.Called(ctx, id, offset, chunk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendChunk")
+	}
+
+	var r0 *entity.Upload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, io.Reader) (*entity.Upload, error)); ok {
+		return returnFunc(ctx, id, offset, chunk)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, io.Reader) *entity.Upload); ok {
+		r0 = returnFunc(ctx, id, offset, chunk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Upload)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, io.Reader) error); ok {
+		r1 = returnFunc(ctx, id, offset, chunk)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadDomainInterface_AppendChunk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendChunk'
+type MockUploadDomainInterface_AppendChunk_Call struct {
+	*mock.Call
+}
+
+// AppendChunk is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - offset int64
+//   - chunk io.Reader
+func (_e *MockUploadDomainInterface_Expecter) AppendChunk(ctx interface{}, id interface{}, offset interface{}, chunk interface{}) *MockUploadDomainInterface_AppendChunk_Call {
+	return &MockUploadDomainInterface_AppendChunk_Call{Call: _e.mock.On("AppendChunk", ctx, id, offset, chunk)}
+}
+
+func (_c *MockUploadDomainInterface_AppendChunk_Call) Run(run func(ctx context.Context, id string, offset int64, chunk io.Reader)) *MockUploadDomainInterface_AppendChunk_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 io.Reader
+		if args[3] != nil {
+			arg3 = args[3].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_AppendChunk_Call) Return(upload *entity.Upload, err error) *MockUploadDomainInterface_AppendChunk_Call {
+	_c.Call.Return(upload, err)
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_AppendChunk_Call) RunAndReturn(run func(ctx context.Context, id string, offset int64, chunk io.Reader) (*entity.Upload, error)) *MockUploadDomainInterface_AppendChunk_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUpload provides a mock function for the type MockUploadDomainInterface
+func (_mock *MockUploadDomainInterface) CreateUpload(ctx context.Context, length int64, ttl time.Duration, tags map[string]string) (*entity.Upload, error) {
+	ret := _mock.Called(ctx, length, ttl, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUpload")
+	}
+
+	var r0 *entity.Upload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, time.Duration, map[string]string) (*entity.Upload, error)); ok {
+		return returnFunc(ctx, length, ttl, tags)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, time.Duration, map[string]string) *entity.Upload); ok {
+		r0 = returnFunc(ctx, length, ttl, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Upload)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, time.Duration, map[string]string) error); ok {
+		r1 = returnFunc(ctx, length, ttl, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadDomainInterface_CreateUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUpload'
+type MockUploadDomainInterface_CreateUpload_Call struct {
+	*mock.Call
+}
+
+// CreateUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - length int64
+//   - ttl time.Duration
+//   - tags map[string]string
+func (_e *MockUploadDomainInterface_Expecter) CreateUpload(ctx interface{}, length interface{}, ttl interface{}, tags interface{}) *MockUploadDomainInterface_CreateUpload_Call {
+	return &MockUploadDomainInterface_CreateUpload_Call{Call: _e.mock.On("CreateUpload", ctx, length, ttl, tags)}
+}
+
+func (_c *MockUploadDomainInterface_CreateUpload_Call) Run(run func(ctx context.Context, length int64, ttl time.Duration, tags map[string]string)) *MockUploadDomainInterface_CreateUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		var arg3 map[string]string
+		if args[3] != nil {
+			arg3 = args[3].(map[string]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_CreateUpload_Call) Return(upload *entity.Upload, err error) *MockUploadDomainInterface_CreateUpload_Call {
+	_c.Call.Return(upload, err)
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_CreateUpload_Call) RunAndReturn(run func(ctx context.Context, length int64, ttl time.Duration, tags map[string]string) (*entity.Upload, error)) *MockUploadDomainInterface_CreateUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUpload provides a mock function for the type MockUploadDomainInterface
+func (_mock *MockUploadDomainInterface) GetUpload(ctx context.Context, id string) (*entity.Upload, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpload")
+	}
+
+	var r0 *entity.Upload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.Upload, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.Upload); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Upload)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadDomainInterface_GetUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpload'
+type MockUploadDomainInterface_GetUpload_Call struct {
+	*mock.Call
+}
+
+// GetUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUploadDomainInterface_Expecter) GetUpload(ctx interface{}, id interface{}) *MockUploadDomainInterface_GetUpload_Call {
+	return &MockUploadDomainInterface_GetUpload_Call{Call: _e.mock.On("GetUpload", ctx, id)}
+}
+
+func (_c *MockUploadDomainInterface_GetUpload_Call) Run(run func(ctx context.Context, id string)) *MockUploadDomainInterface_GetUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_GetUpload_Call) Return(upload *entity.Upload, err error) *MockUploadDomainInterface_GetUpload_Call {
+	_c.Call.Return(upload, err)
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_GetUpload_Call) RunAndReturn(run func(ctx context.Context, id string) (*entity.Upload, error)) *MockUploadDomainInterface_GetUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function for the type MockUploadDomainInterface
+func (_mock *MockUploadDomainInterface) Stop(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUploadDomainInterface_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockUploadDomainInterface_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUploadDomainInterface_Expecter) Stop(ctx interface{}) *MockUploadDomainInterface_Stop_Call {
+	return &MockUploadDomainInterface_Stop_Call{Call: _e.mock.On("Stop", ctx)}
+}
+
+func (_c *MockUploadDomainInterface_Stop_Call) Run(run func(ctx context.Context)) *MockUploadDomainInterface_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_Stop_Call) Return(err error) *MockUploadDomainInterface_Stop_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_Stop_Call) RunAndReturn(run func(ctx context.Context) error) *MockUploadDomainInterface_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sweep provides a mock function for the type MockUploadDomainInterface
+func (_mock *MockUploadDomainInterface) Sweep(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sweep")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUploadDomainInterface_Sweep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sweep'
+type MockUploadDomainInterface_Sweep_Call struct {
+	*mock.Call
+}
+
+// Sweep is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUploadDomainInterface_Expecter) Sweep(ctx interface{}) *MockUploadDomainInterface_Sweep_Call {
+	return &MockUploadDomainInterface_Sweep_Call{Call: _e.mock.On("Sweep", ctx)}
+}
+
+func (_c *MockUploadDomainInterface_Sweep_Call) Run(run func(ctx context.Context)) *MockUploadDomainInterface_Sweep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_Sweep_Call) Return(err error) *MockUploadDomainInterface_Sweep_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUploadDomainInterface_Sweep_Call) RunAndReturn(run func(ctx context.Context) error) *MockUploadDomainInterface_Sweep_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUploadHandlerInterface creates a new instance of MockUploadHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUploadHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUploadHandlerInterface {
+	mock := &MockUploadHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUploadHandlerInterface is an autogenerated mock type for the UploadHandlerInterface type
+type MockUploadHandlerInterface struct {
+	mock.Mock
+}
+
+type MockUploadHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUploadHandlerInterface) EXPECT() *MockUploadHandlerInterface_Expecter {
+	return &MockUploadHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateUpload provides a mock function for the type MockUploadHandlerInterface
+func (_mock *MockUploadHandlerInterface) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockUploadHandlerInterface_CreateUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUpload'
+type MockUploadHandlerInterface_CreateUpload_Call struct {
+	*mock.Call
+}
+
+// CreateUpload is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockUploadHandlerInterface_Expecter) CreateUpload(w interface{}, r interface{}) *MockUploadHandlerInterface_CreateUpload_Call {
+	return &MockUploadHandlerInterface_CreateUpload_Call{Call: _e.mock.On("CreateUpload", w, r)}
+}
+
+func (_c *MockUploadHandlerInterface_CreateUpload_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockUploadHandlerInterface_CreateUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadHandlerInterface_CreateUpload_Call) Return() *MockUploadHandlerInterface_CreateUpload_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUploadHandlerInterface_CreateUpload_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockUploadHandlerInterface_CreateUpload_Call {
+	_c.Run(run)
+	return _c
+}
+
+// HandleUpload provides a mock function for the type MockUploadHandlerInterface
+func (_mock *MockUploadHandlerInterface) HandleUpload(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockUploadHandlerInterface_HandleUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleUpload'
+type MockUploadHandlerInterface_HandleUpload_Call struct {
+	*mock.Call
+}
+
+// HandleUpload is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockUploadHandlerInterface_Expecter) HandleUpload(w interface{}, r interface{}) *MockUploadHandlerInterface_HandleUpload_Call {
+	return &MockUploadHandlerInterface_HandleUpload_Call{Call: _e.mock.On("HandleUpload", w, r)}
+}
+
+func (_c *MockUploadHandlerInterface_HandleUpload_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockUploadHandlerInterface_HandleUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadHandlerInterface_HandleUpload_Call) Return() *MockUploadHandlerInterface_HandleUpload_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUploadHandlerInterface_HandleUpload_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockUploadHandlerInterface_HandleUpload_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,312 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUploadRepositoryInterface creates a new instance of MockUploadRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUploadRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUploadRepositoryInterface {
+	mock := &MockUploadRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUploadRepositoryInterface is an autogenerated mock type for the UploadRepositoryInterface type
+type MockUploadRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockUploadRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUploadRepositoryInterface) EXPECT() *MockUploadRepositoryInterface_Expecter {
+	return &MockUploadRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// CompleteUpload provides a mock function for the type MockUploadRepositoryInterface
+func (_mock *MockUploadRepositoryInterface) CompleteUpload(ctx context.Context, id string) (*repository.CompletedUpload, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteUpload")
+	}
+
+	var r0 *repository.CompletedUpload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*repository.CompletedUpload, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *repository.CompletedUpload); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.CompletedUpload)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadRepositoryInterface_CompleteUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteUpload'
+type MockUploadRepositoryInterface_CompleteUpload_Call struct {
+	*mock.Call
+}
+
+// CompleteUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUploadRepositoryInterface_Expecter) CompleteUpload(ctx interface{}, id interface{}) *MockUploadRepositoryInterface_CompleteUpload_Call {
+	return &MockUploadRepositoryInterface_CompleteUpload_Call{Call: _e.mock.On("CompleteUpload", ctx, id)}
+}
+
+func (_c *MockUploadRepositoryInterface_CompleteUpload_Call) Run(run func(ctx context.Context, id string)) *MockUploadRepositoryInterface_CompleteUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_CompleteUpload_Call) Return(completedUpload *repository.CompletedUpload, err error) *MockUploadRepositoryInterface_CompleteUpload_Call {
+	_c.Call.Return(completedUpload, err)
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_CompleteUpload_Call) RunAndReturn(run func(ctx context.Context, id string) (*repository.CompletedUpload, error)) *MockUploadRepositoryInterface_CompleteUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUpload provides a mock function for the type MockUploadRepositoryInterface
+func (_mock *MockUploadRepositoryInterface) CreateUpload(ctx context.Context, id string, length int64) error {
+	ret := _mock.Called(ctx, id, length)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUpload")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, id, length)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUploadRepositoryInterface_CreateUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUpload'
+type MockUploadRepositoryInterface_CreateUpload_Call struct {
+	*mock.Call
+}
+
+// CreateUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - length int64
+func (_e *MockUploadRepositoryInterface_Expecter) CreateUpload(ctx interface{}, id interface{}, length interface{}) *MockUploadRepositoryInterface_CreateUpload_Call {
+	return &MockUploadRepositoryInterface_CreateUpload_Call{Call: _e.mock.On("CreateUpload", ctx, id, length)}
+}
+
+func (_c *MockUploadRepositoryInterface_CreateUpload_Call) Run(run func(ctx context.Context, id string, length int64)) *MockUploadRepositoryInterface_CreateUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_CreateUpload_Call) Return(err error) *MockUploadRepositoryInterface_CreateUpload_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_CreateUpload_Call) RunAndReturn(run func(ctx context.Context, id string, length int64) error) *MockUploadRepositoryInterface_CreateUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUpload provides a mock function for the type MockUploadRepositoryInterface
+func (_mock *MockUploadRepositoryInterface) DeleteUpload(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUpload")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUploadRepositoryInterface_DeleteUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUpload'
+type MockUploadRepositoryInterface_DeleteUpload_Call struct {
+	*mock.Call
+}
+
+// DeleteUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUploadRepositoryInterface_Expecter) DeleteUpload(ctx interface{}, id interface{}) *MockUploadRepositoryInterface_DeleteUpload_Call {
+	return &MockUploadRepositoryInterface_DeleteUpload_Call{Call: _e.mock.On("DeleteUpload", ctx, id)}
+}
+
+func (_c *MockUploadRepositoryInterface_DeleteUpload_Call) Run(run func(ctx context.Context, id string)) *MockUploadRepositoryInterface_DeleteUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_DeleteUpload_Call) Return(err error) *MockUploadRepositoryInterface_DeleteUpload_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_DeleteUpload_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockUploadRepositoryInterface_DeleteUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteChunk provides a mock function for the type MockUploadRepositoryInterface
+func (_mock *MockUploadRepositoryInterface) WriteChunk(ctx context.Context, id string, offset int64, length int64, chunk io.Reader) (int64, error) {
+	ret := _mock.Called(ctx, id, offset, length, chunk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteChunk")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int64, io.Reader) (int64, error)); ok {
+		return returnFunc(ctx, id, offset, length, chunk)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int64, io.Reader) int64); ok {
+		r0 = returnFunc(ctx, id, offset, length, chunk)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, int64, io.Reader) error); ok {
+		r1 = returnFunc(ctx, id, offset, length, chunk)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadRepositoryInterface_WriteChunk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteChunk'
+type MockUploadRepositoryInterface_WriteChunk_Call struct {
+	*mock.Call
+}
+
+// WriteChunk is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - offset int64
+//   - length int64
+//   - chunk io.Reader
+func (_e *MockUploadRepositoryInterface_Expecter) WriteChunk(ctx interface{}, id interface{}, offset interface{}, length interface{}, chunk interface{}) *MockUploadRepositoryInterface_WriteChunk_Call {
+	return &MockUploadRepositoryInterface_WriteChunk_Call{Call: _e.mock.On("WriteChunk", ctx, id, offset, length, chunk)}
+}
+
+func (_c *MockUploadRepositoryInterface_WriteChunk_Call) Run(run func(ctx context.Context, id string, offset int64, length int64, chunk io.Reader)) *MockUploadRepositoryInterface_WriteChunk_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		var arg4 io.Reader
+		if args[4] != nil {
+			arg4 = args[4].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_WriteChunk_Call) Return(n int64, err error) *MockUploadRepositoryInterface_WriteChunk_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_WriteChunk_Call) RunAndReturn(run func(ctx context.Context, id string, offset int64, length int64, chunk io.Reader) (int64, error)) *MockUploadRepositoryInterface_WriteChunk_Call {
+	_c.Call.Return(run)
+	return _c
+}
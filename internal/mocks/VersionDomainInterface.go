@@ -0,0 +1,187 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockVersionDomainInterface creates a new instance of MockVersionDomainInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVersionDomainInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVersionDomainInterface {
+	mock := &MockVersionDomainInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockVersionDomainInterface is an autogenerated mock type for the VersionDomainInterface type
+type MockVersionDomainInterface struct {
+	mock.Mock
+}
+
+type MockVersionDomainInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVersionDomainInterface) EXPECT() *MockVersionDomainInterface_Expecter {
+	return &MockVersionDomainInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListVersions provides a mock function for the type MockVersionDomainInterface
+func (_mock *MockVersionDomainInterface) ListVersions(ctx context.Context, filePath string) ([]*entity.MatrixVersion, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVersions")
+	}
+
+	var r0 []*entity.MatrixVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entity.MatrixVersion, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entity.MatrixVersion); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.MatrixVersion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVersionDomainInterface_ListVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVersions'
+type MockVersionDomainInterface_ListVersions_Call struct {
+	*mock.Call
+}
+
+// ListVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockVersionDomainInterface_Expecter) ListVersions(ctx interface{}, filePath interface{}) *MockVersionDomainInterface_ListVersions_Call {
+	return &MockVersionDomainInterface_ListVersions_Call{Call: _e.mock.On("ListVersions", ctx, filePath)}
+}
+
+func (_c *MockVersionDomainInterface_ListVersions_Call) Run(run func(ctx context.Context, filePath string)) *MockVersionDomainInterface_ListVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVersionDomainInterface_ListVersions_Call) Return(matrixVersions []*entity.MatrixVersion, err error) *MockVersionDomainInterface_ListVersions_Call {
+	_c.Call.Return(matrixVersions, err)
+	return _c
+}
+
+func (_c *MockVersionDomainInterface_ListVersions_Call) RunAndReturn(run func(ctx context.Context, filePath string) ([]*entity.MatrixVersion, error)) *MockVersionDomainInterface_ListVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockVersionDomainInterface
+func (_mock *MockVersionDomainInterface) Update(ctx context.Context, filePath string, ifMatch []string, matrix *entity.Matrix[int64]) (*entity.MatrixVersion, error) {
+	ret := _mock.Called(ctx, filePath, ifMatch, matrix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *entity.MatrixVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *entity.Matrix[int64]) (*entity.MatrixVersion, error)); ok {
+		return returnFunc(ctx, filePath, ifMatch, matrix)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *entity.Matrix[int64]) *entity.MatrixVersion); ok {
+		r0 = returnFunc(ctx, filePath, ifMatch, matrix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.MatrixVersion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, *entity.Matrix[int64]) error); ok {
+		r1 = returnFunc(ctx, filePath, ifMatch, matrix)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVersionDomainInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockVersionDomainInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - ifMatch []string
+//   - matrix *entity.Matrix[int64]
+func (_e *MockVersionDomainInterface_Expecter) Update(ctx interface{}, filePath interface{}, ifMatch interface{}, matrix interface{}) *MockVersionDomainInterface_Update_Call {
+	return &MockVersionDomainInterface_Update_Call{Call: _e.mock.On("Update", ctx, filePath, ifMatch, matrix)}
+}
+
+func (_c *MockVersionDomainInterface_Update_Call) Run(run func(ctx context.Context, filePath string, ifMatch []string, matrix *entity.Matrix[int64])) *MockVersionDomainInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 *entity.Matrix[int64]
+		if args[3] != nil {
+			arg3 = args[3].(*entity.Matrix[int64])
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVersionDomainInterface_Update_Call) Return(matrixVersion *entity.MatrixVersion, err error) *MockVersionDomainInterface_Update_Call {
+	_c.Call.Return(matrixVersion, err)
+	return _c
+}
+
+func (_c *MockVersionDomainInterface_Update_Call) RunAndReturn(run func(ctx context.Context, filePath string, ifMatch []string, matrix *entity.Matrix[int64]) (*entity.MatrixVersion, error)) *MockVersionDomainInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
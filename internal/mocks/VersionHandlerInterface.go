@@ -0,0 +1,130 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockVersionHandlerInterface creates a new instance of MockVersionHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVersionHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVersionHandlerInterface {
+	mock := &MockVersionHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockVersionHandlerInterface is an autogenerated mock type for the VersionHandlerInterface type
+type MockVersionHandlerInterface struct {
+	mock.Mock
+}
+
+type MockVersionHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVersionHandlerInterface) EXPECT() *MockVersionHandlerInterface_Expecter {
+	return &MockVersionHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListVersions provides a mock function for the type MockVersionHandlerInterface
+func (_mock *MockVersionHandlerInterface) ListVersions(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockVersionHandlerInterface_ListVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVersions'
+type MockVersionHandlerInterface_ListVersions_Call struct {
+	*mock.Call
+}
+
+// ListVersions is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockVersionHandlerInterface_Expecter) ListVersions(w interface{}, r interface{}) *MockVersionHandlerInterface_ListVersions_Call {
+	return &MockVersionHandlerInterface_ListVersions_Call{Call: _e.mock.On("ListVersions", w, r)}
+}
+
+func (_c *MockVersionHandlerInterface_ListVersions_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockVersionHandlerInterface_ListVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVersionHandlerInterface_ListVersions_Call) Return() *MockVersionHandlerInterface_ListVersions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockVersionHandlerInterface_ListVersions_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockVersionHandlerInterface_ListVersions_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateFile provides a mock function for the type MockVersionHandlerInterface
+func (_mock *MockVersionHandlerInterface) UpdateFile(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockVersionHandlerInterface_UpdateFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFile'
+type MockVersionHandlerInterface_UpdateFile_Call struct {
+	*mock.Call
+}
+
+// UpdateFile is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockVersionHandlerInterface_Expecter) UpdateFile(w interface{}, r interface{}) *MockVersionHandlerInterface_UpdateFile_Call {
+	return &MockVersionHandlerInterface_UpdateFile_Call{Call: _e.mock.On("UpdateFile", w, r)}
+}
+
+func (_c *MockVersionHandlerInterface_UpdateFile_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockVersionHandlerInterface_UpdateFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVersionHandlerInterface_UpdateFile_Call) Return() *MockVersionHandlerInterface_UpdateFile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockVersionHandlerInterface_UpdateFile_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockVersionHandlerInterface_UpdateFile_Call {
+	_c.Run(run)
+	return _c
+}
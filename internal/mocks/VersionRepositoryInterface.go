@@ -0,0 +1,187 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/matsuboshi/league-matrix-app/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockVersionRepositoryInterface creates a new instance of MockVersionRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVersionRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVersionRepositoryInterface {
+	mock := &MockVersionRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockVersionRepositoryInterface is an autogenerated mock type for the VersionRepositoryInterface type
+type MockVersionRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockVersionRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVersionRepositoryInterface) EXPECT() *MockVersionRepositoryInterface_Expecter {
+	return &MockVersionRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListVersions provides a mock function for the type MockVersionRepositoryInterface
+func (_mock *MockVersionRepositoryInterface) ListVersions(ctx context.Context, filePath string) ([]*repository.VersionRecord, error) {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVersions")
+	}
+
+	var r0 []*repository.VersionRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*repository.VersionRecord, error)); ok {
+		return returnFunc(ctx, filePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*repository.VersionRecord); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.VersionRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVersionRepositoryInterface_ListVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVersions'
+type MockVersionRepositoryInterface_ListVersions_Call struct {
+	*mock.Call
+}
+
+// ListVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+func (_e *MockVersionRepositoryInterface_Expecter) ListVersions(ctx interface{}, filePath interface{}) *MockVersionRepositoryInterface_ListVersions_Call {
+	return &MockVersionRepositoryInterface_ListVersions_Call{Call: _e.mock.On("ListVersions", ctx, filePath)}
+}
+
+func (_c *MockVersionRepositoryInterface_ListVersions_Call) Run(run func(ctx context.Context, filePath string)) *MockVersionRepositoryInterface_ListVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVersionRepositoryInterface_ListVersions_Call) Return(versionRecords []*repository.VersionRecord, err error) *MockVersionRepositoryInterface_ListVersions_Call {
+	_c.Call.Return(versionRecords, err)
+	return _c
+}
+
+func (_c *MockVersionRepositoryInterface_ListVersions_Call) RunAndReturn(run func(ctx context.Context, filePath string) ([]*repository.VersionRecord, error)) *MockVersionRepositoryInterface_ListVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockVersionRepositoryInterface
+func (_mock *MockVersionRepositoryInterface) Update(ctx context.Context, filePath string, ifMatch []string, content *repository.MatrixFileContent) (*repository.VersionRecord, error) {
+	ret := _mock.Called(ctx, filePath, ifMatch, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *repository.VersionRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *repository.MatrixFileContent) (*repository.VersionRecord, error)); ok {
+		return returnFunc(ctx, filePath, ifMatch, content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *repository.MatrixFileContent) *repository.VersionRecord); ok {
+		r0 = returnFunc(ctx, filePath, ifMatch, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.VersionRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, *repository.MatrixFileContent) error); ok {
+		r1 = returnFunc(ctx, filePath, ifMatch, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVersionRepositoryInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockVersionRepositoryInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePath string
+//   - ifMatch []string
+//   - content *repository.MatrixFileContent
+func (_e *MockVersionRepositoryInterface_Expecter) Update(ctx interface{}, filePath interface{}, ifMatch interface{}, content interface{}) *MockVersionRepositoryInterface_Update_Call {
+	return &MockVersionRepositoryInterface_Update_Call{Call: _e.mock.On("Update", ctx, filePath, ifMatch, content)}
+}
+
+func (_c *MockVersionRepositoryInterface_Update_Call) Run(run func(ctx context.Context, filePath string, ifMatch []string, content *repository.MatrixFileContent)) *MockVersionRepositoryInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 *repository.MatrixFileContent
+		if args[3] != nil {
+			arg3 = args[3].(*repository.MatrixFileContent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVersionRepositoryInterface_Update_Call) Return(versionRecord *repository.VersionRecord, err error) *MockVersionRepositoryInterface_Update_Call {
+	_c.Call.Return(versionRecord, err)
+	return _c
+}
+
+func (_c *MockVersionRepositoryInterface_Update_Call) RunAndReturn(run func(ctx context.Context, filePath string, ifMatch []string, content *repository.MatrixFileContent) (*repository.VersionRecord, error)) *MockVersionRepositoryInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebSocketHandlerInterface creates a new instance of MockWebSocketHandlerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebSocketHandlerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebSocketHandlerInterface {
+	mock := &MockWebSocketHandlerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebSocketHandlerInterface is an autogenerated mock type for the WebSocketHandlerInterface type
+type MockWebSocketHandlerInterface struct {
+	mock.Mock
+}
+
+type MockWebSocketHandlerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebSocketHandlerInterface) EXPECT() *MockWebSocketHandlerInterface_Expecter {
+	return &MockWebSocketHandlerInterface_Expecter{mock: &_m.Mock}
+}
+
+// ServeWebSocket provides a mock function for the type MockWebSocketHandlerInterface
+func (_mock *MockWebSocketHandlerInterface) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	_mock.Called(w, r)
+	return
+}
+
+// MockWebSocketHandlerInterface_ServeWebSocket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServeWebSocket'
+type MockWebSocketHandlerInterface_ServeWebSocket_Call struct {
+	*mock.Call
+}
+
+// ServeWebSocket is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *MockWebSocketHandlerInterface_Expecter) ServeWebSocket(w interface{}, r interface{}) *MockWebSocketHandlerInterface_ServeWebSocket_Call {
+	return &MockWebSocketHandlerInterface_ServeWebSocket_Call{Call: _e.mock.On("ServeWebSocket", w, r)}
+}
+
+func (_c *MockWebSocketHandlerInterface_ServeWebSocket_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *MockWebSocketHandlerInterface_ServeWebSocket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 http.ResponseWriter
+		if args[0] != nil {
+			arg0 = args[0].(http.ResponseWriter)
+		}
+		var arg1 *http.Request
+		if args[1] != nil {
+			arg1 = args[1].(*http.Request)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebSocketHandlerInterface_ServeWebSocket_Call) Return() *MockWebSocketHandlerInterface_ServeWebSocket_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebSocketHandlerInterface_ServeWebSocket_Call) RunAndReturn(run func(w http.ResponseWriter, r *http.Request)) *MockWebSocketHandlerInterface_ServeWebSocket_Call {
+	_c.Run(run)
+	return _c
+}
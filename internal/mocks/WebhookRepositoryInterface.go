@@ -0,0 +1,113 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebhookRepositoryInterface creates a new instance of MockWebhookRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookRepositoryInterface {
+	mock := &MockWebhookRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookRepositoryInterface is an autogenerated mock type for the WebhookRepositoryInterface type
+type MockWebhookRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockWebhookRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookRepositoryInterface) EXPECT() *MockWebhookRepositoryInterface_Expecter {
+	return &MockWebhookRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Deliver provides a mock function for the type MockWebhookRepositoryInterface
+func (_mock *MockWebhookRepositoryInterface) Deliver(ctx context.Context, callbackURL string, event string, payload []byte, secret string) error {
+	ret := _mock.Called(ctx, callbackURL, event, payload, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deliver")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte, string) error); ok {
+		r0 = returnFunc(ctx, callbackURL, event, payload, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepositoryInterface_Deliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deliver'
+type MockWebhookRepositoryInterface_Deliver_Call struct {
+	*mock.Call
+}
+
+// Deliver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callbackURL string
+//   - event string
+//   - payload []byte
+//   - secret string
+func (_e *MockWebhookRepositoryInterface_Expecter) Deliver(ctx interface{}, callbackURL interface{}, event interface{}, payload interface{}, secret interface{}) *MockWebhookRepositoryInterface_Deliver_Call {
+	return &MockWebhookRepositoryInterface_Deliver_Call{Call: _e.mock.On("Deliver", ctx, callbackURL, event, payload, secret)}
+}
+
+func (_c *MockWebhookRepositoryInterface_Deliver_Call) Run(run func(ctx context.Context, callbackURL string, event string, payload []byte, secret string)) *MockWebhookRepositoryInterface_Deliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepositoryInterface_Deliver_Call) Return(err error) *MockWebhookRepositoryInterface_Deliver_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepositoryInterface_Deliver_Call) RunAndReturn(run func(ctx context.Context, callbackURL string, event string, payload []byte, secret string) error) *MockWebhookRepositoryInterface_Deliver_Call {
+	_c.Call.Return(run)
+	return _c
+}
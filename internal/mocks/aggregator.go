@@ -0,0 +1,176 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/matsuboshi/league-matrix-app/pkg/matrix"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockaggregator creates a new instance of Mockaggregator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockaggregator[T matrix.Number](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Mockaggregator[T] {
+	mock := &Mockaggregator[T]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Mockaggregator is an autogenerated mock type for the aggregator type
+type Mockaggregator[T matrix.Number] struct {
+	mock.Mock
+}
+
+type Mockaggregator_Expecter[T matrix.Number] struct {
+	mock *mock.Mock
+}
+
+func (_m *Mockaggregator[T]) EXPECT() *Mockaggregator_Expecter[T] {
+	return &Mockaggregator_Expecter[T]{mock: &_m.Mock}
+}
+
+// add provides a mock function for the type Mockaggregator
+func (_mock *Mockaggregator[T]) add(row []T) {
+	_mock.Called(row)
+	return
+}
+
+// Mockaggregator_add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'add'
+type Mockaggregator_add_Call[T matrix.Number] struct {
+	*mock.Call
+}
+
+// add is a helper method to define mock.On call
+//   - row []T
+func (_e *Mockaggregator_Expecter[T]) add(row interface{}) *Mockaggregator_add_Call[T] {
+	return &Mockaggregator_add_Call[T]{Call: _e.mock.On("add", row)}
+}
+
+func (_c *Mockaggregator_add_Call[T]) Run(run func(row []T)) *Mockaggregator_add_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []T
+		if args[0] != nil {
+			arg0 = args[0].([]T)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Mockaggregator_add_Call[T]) Return() *Mockaggregator_add_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Mockaggregator_add_Call[T]) RunAndReturn(run func(row []T)) *Mockaggregator_add_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
+// rescale provides a mock function for the type Mockaggregator
+func (_mock *Mockaggregator[T]) rescale(places int) {
+	_mock.Called(places)
+	return
+}
+
+// Mockaggregator_rescale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'rescale'
+type Mockaggregator_rescale_Call[T matrix.Number] struct {
+	*mock.Call
+}
+
+// rescale is a helper method to define mock.On call
+//   - places int
+func (_e *Mockaggregator_Expecter[T]) rescale(places interface{}) *Mockaggregator_rescale_Call[T] {
+	return &Mockaggregator_rescale_Call[T]{Call: _e.mock.On("rescale", places)}
+}
+
+func (_c *Mockaggregator_rescale_Call[T]) Run(run func(places int)) *Mockaggregator_rescale_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Mockaggregator_rescale_Call[T]) Return() *Mockaggregator_rescale_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Mockaggregator_rescale_Call[T]) RunAndReturn(run func(places int)) *Mockaggregator_rescale_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
+// result provides a mock function for the type Mockaggregator
+func (_mock *Mockaggregator[T]) result(scale int, precision int) *matrix.Result[T] {
+	ret := _mock.Called(scale, precision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for result")
+	}
+
+	var r0 *matrix.Result[T]
+	if returnFunc, ok := ret.Get(0).(func(int, int) *matrix.Result[T]); ok {
+		r0 = returnFunc(scale, precision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*matrix.Result[T])
+		}
+	}
+	return r0
+}
+
+// Mockaggregator_result_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'result'
+type Mockaggregator_result_Call[T matrix.Number] struct {
+	*mock.Call
+}
+
+// result is a helper method to define mock.On call
+//   - scale int
+//   - precision int
+func (_e *Mockaggregator_Expecter[T]) result(scale interface{}, precision interface{}) *Mockaggregator_result_Call[T] {
+	return &Mockaggregator_result_Call[T]{Call: _e.mock.On("result", scale, precision)}
+}
+
+func (_c *Mockaggregator_result_Call[T]) Run(run func(scale int, precision int)) *Mockaggregator_result_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Mockaggregator_result_Call[T]) Return(result *matrix.Result[T]) *Mockaggregator_result_Call[T] {
+	_c.Call.Return(result)
+	return _c
+}
+
+func (_c *Mockaggregator_result_Call[T]) RunAndReturn(run func(scale int, precision int) *matrix.Result[T]) *Mockaggregator_result_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
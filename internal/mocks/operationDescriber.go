@@ -0,0 +1,80 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockoperationDescriber creates a new instance of MockoperationDescriber. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockoperationDescriber(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockoperationDescriber {
+	mock := &MockoperationDescriber{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockoperationDescriber is an autogenerated mock type for the operationDescriber type
+type MockoperationDescriber struct {
+	mock.Mock
+}
+
+type MockoperationDescriber_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockoperationDescriber) EXPECT() *MockoperationDescriber_Expecter {
+	return &MockoperationDescriber_Expecter{mock: &_m.Mock}
+}
+
+// Description provides a mock function for the type MockoperationDescriber
+func (_mock *MockoperationDescriber) Description() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Description")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockoperationDescriber_Description_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Description'
+type MockoperationDescriber_Description_Call struct {
+	*mock.Call
+}
+
+// Description is a helper method to define mock.On call
+func (_e *MockoperationDescriber_Expecter) Description() *MockoperationDescriber_Description_Call {
+	return &MockoperationDescriber_Description_Call{Call: _e.mock.On("Description")}
+}
+
+func (_c *MockoperationDescriber_Description_Call) Run(run func()) *MockoperationDescriber_Description_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockoperationDescriber_Description_Call) Return(s string) *MockoperationDescriber_Description_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockoperationDescriber_Description_Call) RunAndReturn(run func() string) *MockoperationDescriber_Description_Call {
+	_c.Call.Return(run)
+	return _c
+}
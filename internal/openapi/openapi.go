@@ -0,0 +1,340 @@
+// Package openapi holds the OpenAPI 3 document describing the HTTP API, embedded from openapi.json, and checks
+// requests against it. The routes of the document are read once, so every request can be checked for query
+// parameters its route does not list and path parameters outside their schema, keeping the API to its contract.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+//go:embed openapi.json
+var document []byte
+
+// operationNameSchema is the schema of the operation names. Operations are registered and switched on and off
+// at runtime, so its enum is filled in when the document is served and names are checked by the caller.
+const operationNameSchema = "#/components/schemas/OperationName"
+
+const (
+	parametersPrefix = "#/components/parameters/"
+	schemasPrefix    = "#/components/schemas/"
+)
+
+// methods lists the operations of a path item, by the key they are described under.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Error is a request parameter that does not follow the document. It wraps the error the request is rejected
+// with, which wraps ErrInvalidInput unless the caller rejected an operation name with another.
+type Error struct {
+	// Parameter is the name of the parameter at fault.
+	Parameter string
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// spec is the part of the document requests are checked against.
+type spec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Parameters map[string]*parameter `json:"parameters"`
+		Schemas    map[string]*schema    `json:"schemas"`
+	} `json:"components"`
+}
+
+type parameter struct {
+	Ref    string  `json:"$ref"`
+	Name   string  `json:"name"`
+	In     string  `json:"in"`
+	Schema *schema `json:"schema"`
+}
+
+// schema is the part of a schema path parameters are checked against: a reference, a list of alternatives or an enum.
+type schema struct {
+	Ref   string    `json:"$ref"`
+	OneOf []*schema `json:"oneOf"`
+	Enum  []string  `json:"enum"`
+}
+
+// route is an operation of the document: a method on a path template.
+type route struct {
+	method string
+
+	// segments holds the segments of the path template, where {name} stands for a path parameter
+	segments []string
+
+	// query holds the names of the query parameters of the route
+	query map[string]bool
+
+	// path holds the schemas of the path parameters of the route by name
+	path map[string]*schema
+}
+
+var (
+	// routes holds every route of the document, the ones without path parameters first,
+	// so a literal path such as /matrix/report is matched before /matrix/{operation}
+	routes []*route
+
+	// schemas holds the schemas of the document by reference
+	schemas map[string]*schema
+)
+
+func init() {
+	var err error
+	routes, schemas, err = loadRoutes()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Document returns the OpenAPI document as JSON, with the enum of OperationName listing operations.
+func Document(operations []string) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+
+	name := strings.TrimPrefix(operationNameSchema, schemasPrefix)
+	operationName := doc["components"].(map[string]any)["schemas"].(map[string]any)[name].(map[string]any)
+	operationName["enum"] = operations
+	return json.Marshal(doc)
+}
+
+// Validate checks a request with method, path and query against the route of the document it is sent to.
+// Query parameters the route does not list, and path parameters missing from the enum of their schema, are rejected
+// with an *Error wrapping ErrInvalidInput. Operation names are checked with validateOperation, whose error is
+// returned as the *Error. Requests matching no route of the document are left to the router and not checked.
+func Validate(method, path string, query url.Values, validateOperation func(operation string) error) error {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	var template []string
+	for _, r := range routes {
+		// A path is served by its most specific template only, so a method it does not allow is left to the
+		// router rather than matched by a template, such as GET /matrix/batch by /matrix/{operation}
+		if template != nil && !slices.Equal(r.segments, template) {
+			break
+		}
+		params, ok := r.match(segments)
+		if !ok {
+			continue
+		}
+		template = r.segments
+		if r.method != method {
+			continue
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(query)) {
+			if !r.query[name] {
+				return &Error{
+					Parameter: name,
+					err:       fmt.Errorf("%w: unknown query parameter: %q", apperrors.ErrInvalidInput, name),
+				}
+			}
+		}
+
+		for name, value := range params {
+			if err := r.path[name].check(name, value, validateOperation); err != nil {
+				return &Error{Parameter: name, err: err}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// match reports whether the path template of the route matches a path split into segments, with its path
+// parameters by name.
+func (r *route) match(segments []string) (map[string]string, bool) {
+	if len(r.segments) != len(segments) {
+		return nil, false
+	}
+
+	var params map[string]string
+	for i, segment := range r.segments {
+		name, ok := templateParameter(segment)
+		if !ok {
+			if segment != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+		if segments[i] == "" {
+			return nil, false
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[name] = segments[i]
+	}
+	return params, true
+}
+
+// check checks value, the value of the path parameter name, against s.
+func (s *schema) check(name, value string, validateOperation func(operation string) error) error {
+	switch {
+	case s == nil:
+		return nil
+	case s.Ref == operationNameSchema:
+		return validateOperation(value)
+	case s.Ref != "":
+		return schemas[s.Ref].check(name, value, validateOperation)
+	case len(s.OneOf) > 0:
+		// The error of the first alternative is reported, so alternatives extending a schema come after it
+		var first error
+		for _, alternative := range s.OneOf {
+			err := alternative.check(name, value, validateOperation)
+			if err == nil {
+				return nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		return first
+	case len(s.Enum) > 0 && !slices.Contains(s.Enum, value):
+		return fmt.Errorf("%w: invalid %s parameter: %q: expected one of %s",
+			apperrors.ErrInvalidInput, name, value, strings.Join(s.Enum, ", "))
+	}
+	return nil
+}
+
+// loadRoutes reads the routes and schemas of the embedded document, checking that every reference resolves.
+func loadRoutes() ([]*route, map[string]*schema, error) {
+	var doc spec
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+
+	schemas := make(map[string]*schema, len(doc.Components.Schemas))
+	for name, s := range doc.Components.Schemas {
+		schemas[schemasPrefix+name] = s
+	}
+	for ref, s := range schemas {
+		if err := resolveSchema(s, schemas); err != nil {
+			return nil, nil, fmt.Errorf("invalid schema %s: %w", ref, err)
+		}
+	}
+
+	var routes []*route
+	for template, item := range doc.Paths {
+		var shared []*parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &shared); err != nil {
+				return nil, nil, fmt.Errorf("invalid parameters of %s: %w", template, err)
+			}
+		}
+
+		for _, method := range methods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			var operation struct {
+				Parameters []*parameter `json:"parameters"`
+			}
+			if err := json.Unmarshal(raw, &operation); err != nil {
+				return nil, nil, fmt.Errorf("invalid operation %s %s: %w", method, template, err)
+			}
+
+			r := &route{
+				method:   strings.ToUpper(method),
+				segments: strings.Split(strings.TrimPrefix(template, "/"), "/"),
+				query:    make(map[string]bool),
+				path:     make(map[string]*schema),
+			}
+			for _, param := range slices.Concat(shared, operation.Parameters) {
+				param, err := resolveParameter(param, doc.Components.Parameters, schemas)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid parameter of %s %s: %w", method, template, err)
+				}
+				switch param.In {
+				case "query":
+					r.query[param.Name] = true
+				case "path":
+					r.path[param.Name] = param.Schema
+				}
+			}
+			for _, segment := range r.segments {
+				if name, ok := templateParameter(segment); ok && r.path[name] == nil {
+					return nil, nil, fmt.Errorf("path parameter %s of %s %s is not described", name, method, template)
+				}
+			}
+			routes = append(routes, r)
+		}
+	}
+
+	// Paths are unordered in the document, so routes are sorted to be matched the same way every time
+	slices.SortStableFunc(routes, func(a, b *route) int {
+		if n := a.templateParameters() - b.templateParameters(); n != 0 {
+			return n
+		}
+		return strings.Compare(strings.Join(a.segments, "/"), strings.Join(b.segments, "/"))
+	})
+	return routes, schemas, nil
+}
+
+// resolveParameter returns the parameter param refers to, or param itself when it is not a reference.
+func resolveParameter(param *parameter, parameters map[string]*parameter, schemas map[string]*schema) (*parameter, error) {
+	if param.Ref != "" {
+		resolved, ok := parameters[strings.TrimPrefix(param.Ref, parametersPrefix)]
+		if !ok || !strings.HasPrefix(param.Ref, parametersPrefix) {
+			return nil, fmt.Errorf("unknown reference %s", param.Ref)
+		}
+		param = resolved
+	}
+	if param.Name == "" || (param.In != "query" && param.In != "path" && param.In != "header" && param.In != "cookie") {
+		return nil, fmt.Errorf("parameter %q in %q is not valid", param.Name, param.In)
+	}
+	if err := resolveSchema(param.Schema, schemas); err != nil {
+		return nil, err
+	}
+	return param, nil
+}
+
+// resolveSchema checks that every reference of s, an alternative included, names a schema of the document.
+func resolveSchema(s *schema, schemas map[string]*schema) error {
+	if s == nil {
+		return nil
+	}
+	if _, ok := schemas[s.Ref]; s.Ref != "" && !ok {
+		return fmt.Errorf("unknown reference %s", s.Ref)
+	}
+	for _, alternative := range s.OneOf {
+		if err := resolveSchema(alternative, schemas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateParameters counts the path parameters of the route.
+func (r *route) templateParameters() int {
+	n := 0
+	for _, segment := range r.segments {
+		if _, ok := templateParameter(segment); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// templateParameter returns the name of the path parameter segment stands for, such as operation for {operation}.
+func templateParameter(segment string) (string, bool) {
+	if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+		return "", false
+	}
+	return segment[1 : len(segment)-1], true
+}
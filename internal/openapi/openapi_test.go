@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// validateOperation accepts sum and rejects disabled like a disabled operation of the registry.
+func validateOperation(operation string) error {
+	switch operation {
+	case "sum":
+		return nil
+	case "disabled":
+		return fmt.Errorf("%w: operation %s is disabled", apperrors.ErrForbidden, operation)
+	default:
+		return fmt.Errorf("%w: invalid operation: %s", apperrors.ErrInvalidOperation, operation)
+	}
+}
+
+func TestDocument(t *testing.T) {
+	body, err := Document([]string{"flatten", "sum"})
+	require.NoError(t, err)
+
+	var doc struct {
+		OpenAPI    string                     `json:"openapi"`
+		Paths      map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas struct {
+				OperationName struct {
+					Enum []string `json:"enum"`
+				} `json:"OperationName"`
+				Error struct {
+					Properties struct {
+						Code struct {
+							Enum []apperrors.Code `json:"enum"`
+						} `json:"code"`
+					} `json:"properties"`
+				} `json:"Error"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(body, &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Equal(t, []string{"flatten", "sum"}, doc.Components.Schemas.OperationName.Enum)
+	assert.Contains(t, doc.Paths, "/matrix/{operation}")
+	assert.Contains(t, doc.Paths, "/openapi.json")
+
+	// Every code an error response can carry is documented
+	assert.ElementsMatch(t, []apperrors.Code{
+		apperrors.CodeInvalidInput,
+		apperrors.CodeUnauthorized,
+		apperrors.CodeForbidden,
+		apperrors.CodeNotFound,
+		apperrors.CodeMethodNotAllowed,
+		apperrors.CodeConflict,
+		apperrors.CodePreconditionFailed,
+		apperrors.CodePayloadTooLarge,
+		apperrors.CodeUnsupportedMediaType,
+		apperrors.CodeUnprocessableEntity,
+		apperrors.CodeOverflow,
+		apperrors.CodePreconditionRequired,
+		apperrors.CodeTooManyRequests,
+		apperrors.CodeServiceUnavailable,
+		apperrors.CodeTimeout,
+		apperrors.CodeInternal,
+		apperrors.CodeInvalidOperation,
+		apperrors.CodeFileTooLarge,
+		apperrors.CodeMatrixTooLarge,
+		apperrors.CodeDimensionMismatch,
+	}, doc.Components.Schemas.Error.Properties.Code.Enum)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		query     string
+		wantErr   error
+		wantParam string
+	}{
+		{name: "operation with its parameters", method: "GET", path: "/matrix/sum", query: "file=a.csv&format=json&lenient=true"},
+		{name: "concat", method: "GET", path: "/matrix/concat", query: "file=a.csv&file2=b.csv&axis=vertical"},
+		{name: "literal route before template", method: "GET", path: "/matrix/report", query: "file=a.csv"},
+		{name: "repeated parameter", method: "GET", path: "/matrix/aggregate", query: "op=sum&file=a.csv&file=b.csv"},
+		{name: "path level parameter", method: "DELETE", path: "/files", query: "file=a.csv"},
+		{name: "nested path parameter", method: "GET", path: "/jobs/abc/result", query: "format=csv"},
+		{name: "admin route", method: "GET", path: "/admin/audit", query: "actor=acme&limit=5"},
+		{name: "unknown path", method: "GET", path: "/unknown", query: "foo=bar"},
+		{name: "method not in the document", method: "PUT", path: "/matrix/sum", query: "foo=bar"},
+		{name: "empty path parameter", method: "GET", path: "/matrix/", query: "foo=bar"},
+		{name: "method of a literal path not in the document", method: "GET", path: "/matrix/batch", query: "foo=bar"},
+		{
+			name: "unknown query parameter", method: "GET", path: "/matrix/sum", query: "file=a.csv&fromat=json",
+			wantErr: apperrors.ErrInvalidInput, wantParam: "fromat",
+		},
+		{
+			name: "parameter of another route", method: "GET", path: "/matrix/report", query: "file=a.csv&format=json",
+			wantErr: apperrors.ErrInvalidInput, wantParam: "format",
+		},
+		{
+			name: "parameter of another method", method: "POST", path: "/matrix/sum", query: "file=a.csv",
+			wantErr: apperrors.ErrInvalidInput, wantParam: "file",
+		},
+		{
+			name: "unknown operation", method: "GET", path: "/matrix/sqrt", query: "file=a.csv",
+			wantErr: apperrors.ErrInvalidOperation, wantParam: "operation",
+		},
+		{
+			name: "disabled operation", method: "GET", path: "/matrix/disabled", query: "file=a.csv",
+			wantErr: apperrors.ErrForbidden, wantParam: "operation",
+		},
+		{
+			name: "operation reading two files in an archive", method: "POST", path: "/batch/concat",
+			wantErr: apperrors.ErrInvalidOperation, wantParam: "operation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			err = Validate(tt.method, tt.path, query, validateOperation)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			var paramErr *Error
+			require.True(t, errors.As(err, &paramErr))
+			assert.Equal(t, tt.wantParam, paramErr.Parameter)
+		})
+	}
+}
+
+func TestSchemaCheck(t *testing.T) {
+	s := &schema{Enum: []string{"horizontal", "vertical"}}
+	assert.NoError(t, s.check("axis", "vertical", validateOperation))
+
+	err := s.check("axis", "diagonal", validateOperation)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), `invalid axis parameter: "diagonal": expected one of horizontal, vertical`)
+}
@@ -0,0 +1,51 @@
+// Package parsing carries the options of how matrix CSV input is read, from the request or command
+// that chooses them to the repository that reads the input.
+package parsing
+
+import (
+	"context"
+	"strings"
+)
+
+// Options describes how matrix CSV input is read. The zero value is the strict mode, in which
+// the input must be a well-formed matrix as it is.
+type Options struct {
+	// Lenient tolerates the quirks of CSV files exported by spreadsheets: whitespace around values,
+	// non-breaking spaces included, is trimmed and rows holding nothing but blank values are skipped.
+	// Rows of different lengths are left for validation to report rather than rejected by the CSV reader.
+	Lenient bool
+
+	// Header takes the first row of the input as column labels rather than values.
+	Header bool
+
+	// RowLabels takes the first value of every row as the label of the row rather than a value.
+	RowLabels bool
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying options.
+func NewContext(ctx context.Context, options Options) context.Context {
+	return context.WithValue(ctx, contextKey{}, options)
+}
+
+// FromContext returns the options carried by ctx, or the strict mode without any.
+func FromContext(ctx context.Context) Options {
+	options, _ := ctx.Value(contextKey{}).(Options)
+	return options
+}
+
+// CleanRow applies options to a row read from the input, trimming its values in place in lenient mode.
+// It reports whether the row should be kept, which it always is in strict mode.
+func (o Options) CleanRow(row []string) bool {
+	if !o.Lenient {
+		return true
+	}
+
+	blank := true
+	for i, val := range row {
+		row[i] = strings.TrimSpace(val)
+		blank = blank && row[i] == ""
+	}
+	return !blank
+}
@@ -0,0 +1,52 @@
+package parsing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, Options{}, FromContext(context.Background()))
+
+	ctx := NewContext(context.Background(), Options{Lenient: true})
+	assert.Equal(t, Options{Lenient: true}, FromContext(ctx))
+}
+
+func TestOptions_CleanRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  Options
+		row      []string
+		wantRow  []string
+		wantKeep bool
+	}{
+		{name: "strict keeps values as they are", row: []string{" 1", "2 "}, wantRow: []string{" 1", "2 "}, wantKeep: true},
+		{name: "strict keeps blank rows", row: []string{"", " "}, wantRow: []string{"", " "}, wantKeep: true},
+		{
+			name:     "lenient trims whitespace",
+			options:  Options{Lenient: true},
+			row:      []string{" 1", "\t2 ", "3"},
+			wantRow:  []string{"1", "2", "3"},
+			wantKeep: true,
+		},
+		{
+			name:     "lenient keeps rows with missing values",
+			options:  Options{Lenient: true},
+			row:      []string{"1", " "},
+			wantRow:  []string{"1", ""},
+			wantKeep: true,
+		},
+		{name: "lenient skips blank rows", options: Options{Lenient: true}, row: []string{" ", "", " "}, wantRow: []string{"", "", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep := tt.options.CleanRow(tt.row)
+
+			assert.Equal(t, tt.wantKeep, keep)
+			assert.Equal(t, tt.wantRow, tt.row)
+		})
+	}
+}
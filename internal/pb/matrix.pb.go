@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: matrix.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Row holds the values of a single matrix row.
+type Row struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []int64                `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	mi := &file_matrix_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_matrix_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Row) GetValues() []int64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Matrix is a two-dimensional matrix of integer or decimal values.
+// Every row must have the same number of values.
+type Matrix struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rows  []*Row                 `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Number of decimal places of the values, which are scaled by 10^scale:
+	// with a scale of 2, the value 150 stands for 1.5. Zero for matrices of integers.
+	Scale         int32 `protobuf:"varint,2,opt,name=scale,proto3" json:"scale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Matrix) Reset() {
+	*x = Matrix{}
+	mi := &file_matrix_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Matrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Matrix) ProtoMessage() {}
+
+func (x *Matrix) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Matrix.ProtoReflect.Descriptor instead.
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return file_matrix_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Matrix) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Matrix) GetScale() int32 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+// OperationResult is the outcome of a matrix operation.
+// Operations that produce a matrix (echo, transpose, inverse, flatten) set matrix,
+// while aggregate operations (sum, multiply) set scalar.
+type OperationResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Operation string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*OperationResult_Matrix
+	//	*OperationResult_Scalar
+	Value         isOperationResult_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationResult) Reset() {
+	*x = OperationResult{}
+	mi := &file_matrix_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationResult) ProtoMessage() {}
+
+func (x *OperationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationResult.ProtoReflect.Descriptor instead.
+func (*OperationResult) Descriptor() ([]byte, []int) {
+	return file_matrix_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OperationResult) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *OperationResult) GetValue() isOperationResult_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *OperationResult) GetMatrix() *Matrix {
+	if x != nil {
+		if x, ok := x.Value.(*OperationResult_Matrix); ok {
+			return x.Matrix
+		}
+	}
+	return nil
+}
+
+func (x *OperationResult) GetScalar() string {
+	if x != nil {
+		if x, ok := x.Value.(*OperationResult_Scalar); ok {
+			return x.Scalar
+		}
+	}
+	return ""
+}
+
+type isOperationResult_Value interface {
+	isOperationResult_Value()
+}
+
+type OperationResult_Matrix struct {
+	Matrix *Matrix `protobuf:"bytes,2,opt,name=matrix,proto3,oneof"`
+}
+
+type OperationResult_Scalar struct {
+	// Arbitrary-precision decimal, since aggregates can exceed int64.
+	Scalar string `protobuf:"bytes,3,opt,name=scalar,proto3,oneof"`
+}
+
+func (*OperationResult_Matrix) isOperationResult_Value() {}
+
+func (*OperationResult_Scalar) isOperationResult_Value() {}
+
+var File_matrix_proto protoreflect.FileDescriptor
+
+const file_matrix_proto_rawDesc = "" +
+	"\n" +
+	"\fmatrix.proto\x12\x0fleaguematrix.v1\"\x1d\n" +
+	"\x03Row\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x03R\x06values\"H\n" +
+	"\x06Matrix\x12(\n" +
+	"\x04rows\x18\x01 \x03(\v2\x14.leaguematrix.v1.RowR\x04rows\x12\x14\n" +
+	"\x05scale\x18\x02 \x01(\x05R\x05scale\"\x85\x01\n" +
+	"\x0fOperationResult\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x121\n" +
+	"\x06matrix\x18\x02 \x01(\v2\x17.leaguematrix.v1.MatrixH\x00R\x06matrix\x12\x18\n" +
+	"\x06scalar\x18\x03 \x01(\tH\x00R\x06scalarB\a\n" +
+	"\x05valueB8Z6github.com/matsuboshi/league-matrix-app/internal/pb;pbb\x06proto3"
+
+var (
+	file_matrix_proto_rawDescOnce sync.Once
+	file_matrix_proto_rawDescData []byte
+)
+
+func file_matrix_proto_rawDescGZIP() []byte {
+	file_matrix_proto_rawDescOnce.Do(func() {
+		file_matrix_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_matrix_proto_rawDesc), len(file_matrix_proto_rawDesc)))
+	})
+	return file_matrix_proto_rawDescData
+}
+
+var file_matrix_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_matrix_proto_goTypes = []any{
+	(*Row)(nil),             // 0: leaguematrix.v1.Row
+	(*Matrix)(nil),          // 1: leaguematrix.v1.Matrix
+	(*OperationResult)(nil), // 2: leaguematrix.v1.OperationResult
+}
+var file_matrix_proto_depIdxs = []int32{
+	0, // 0: leaguematrix.v1.Matrix.rows:type_name -> leaguematrix.v1.Row
+	1, // 1: leaguematrix.v1.OperationResult.matrix:type_name -> leaguematrix.v1.Matrix
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_matrix_proto_init() }
+func file_matrix_proto_init() {
+	if File_matrix_proto != nil {
+		return
+	}
+	file_matrix_proto_msgTypes[2].OneofWrappers = []any{
+		(*OperationResult_Matrix)(nil),
+		(*OperationResult_Scalar)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_matrix_proto_rawDesc), len(file_matrix_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_matrix_proto_goTypes,
+		DependencyIndexes: file_matrix_proto_depIdxs,
+		MessageInfos:      file_matrix_proto_msgTypes,
+	}.Build()
+	File_matrix_proto = out.File
+	file_matrix_proto_goTypes = nil
+	file_matrix_proto_depIdxs = nil
+}
@@ -0,0 +1,342 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: matrix_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
+	mi := &file_matrix_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsRequest) ProtoMessage() {}
+
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_matrix_service_proto_rawDescGZIP(), []int{0}
+}
+
+// Operation describes an enabled operation. Steps are the built-in operations a
+// registered operation runs in turn, and are empty for the built-in operations.
+type Operation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Steps         []string               `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_matrix_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_matrix_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Operation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Operation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Operation) GetSteps() []string {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type ListOperationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsResponse) Reset() {
+	*x = ListOperationsResponse{}
+	mi := &file_matrix_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsResponse) ProtoMessage() {}
+
+func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_matrix_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListOperationsResponse) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type ProcessMatrixRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Operation string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Types that are valid to be assigned to Input:
+	//
+	//	*ProcessMatrixRequest_File
+	//	*ProcessMatrixRequest_Matrix
+	Input         isProcessMatrixRequest_Input `protobuf_oneof:"input"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessMatrixRequest) Reset() {
+	*x = ProcessMatrixRequest{}
+	mi := &file_matrix_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessMatrixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessMatrixRequest) ProtoMessage() {}
+
+func (x *ProcessMatrixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessMatrixRequest.ProtoReflect.Descriptor instead.
+func (*ProcessMatrixRequest) Descriptor() ([]byte, []int) {
+	return file_matrix_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProcessMatrixRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ProcessMatrixRequest) GetInput() isProcessMatrixRequest_Input {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *ProcessMatrixRequest) GetFile() string {
+	if x != nil {
+		if x, ok := x.Input.(*ProcessMatrixRequest_File); ok {
+			return x.File
+		}
+	}
+	return ""
+}
+
+func (x *ProcessMatrixRequest) GetMatrix() *Matrix {
+	if x != nil {
+		if x, ok := x.Input.(*ProcessMatrixRequest_Matrix); ok {
+			return x.Matrix
+		}
+	}
+	return nil
+}
+
+type isProcessMatrixRequest_Input interface {
+	isProcessMatrixRequest_Input()
+}
+
+type ProcessMatrixRequest_File struct {
+	// Path of a matrix file, validated like the file parameter of the HTTP API.
+	File string `protobuf:"bytes,2,opt,name=file,proto3,oneof"`
+}
+
+type ProcessMatrixRequest_Matrix struct {
+	Matrix *Matrix `protobuf:"bytes,3,opt,name=matrix,proto3,oneof"`
+}
+
+func (*ProcessMatrixRequest_File) isProcessMatrixRequest_Input() {}
+
+func (*ProcessMatrixRequest_Matrix) isProcessMatrixRequest_Input() {}
+
+var File_matrix_service_proto protoreflect.FileDescriptor
+
+const file_matrix_service_proto_rawDesc = "" +
+	"\n" +
+	"\x14matrix_service.proto\x12\x0fleaguematrix.v1\x1a\fmatrix.proto\"\x17\n" +
+	"\x15ListOperationsRequest\"W\n" +
+	"\tOperation\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05steps\x18\x03 \x03(\tR\x05steps\"T\n" +
+	"\x16ListOperationsResponse\x12:\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x1a.leaguematrix.v1.OperationR\n" +
+	"operations\"\x86\x01\n" +
+	"\x14ProcessMatrixRequest\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x14\n" +
+	"\x04file\x18\x02 \x01(\tH\x00R\x04file\x121\n" +
+	"\x06matrix\x18\x03 \x01(\v2\x17.leaguematrix.v1.MatrixH\x00R\x06matrixB\a\n" +
+	"\x05input2\xcc\x01\n" +
+	"\rMatrixService\x12a\n" +
+	"\x0eListOperations\x12&.leaguematrix.v1.ListOperationsRequest\x1a'.leaguematrix.v1.ListOperationsResponse\x12X\n" +
+	"\rProcessMatrix\x12%.leaguematrix.v1.ProcessMatrixRequest\x1a .leaguematrix.v1.OperationResultB8Z6github.com/matsuboshi/league-matrix-app/internal/pb;pbb\x06proto3"
+
+var (
+	file_matrix_service_proto_rawDescOnce sync.Once
+	file_matrix_service_proto_rawDescData []byte
+)
+
+func file_matrix_service_proto_rawDescGZIP() []byte {
+	file_matrix_service_proto_rawDescOnce.Do(func() {
+		file_matrix_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_matrix_service_proto_rawDesc), len(file_matrix_service_proto_rawDesc)))
+	})
+	return file_matrix_service_proto_rawDescData
+}
+
+var file_matrix_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_matrix_service_proto_goTypes = []any{
+	(*ListOperationsRequest)(nil),  // 0: leaguematrix.v1.ListOperationsRequest
+	(*Operation)(nil),              // 1: leaguematrix.v1.Operation
+	(*ListOperationsResponse)(nil), // 2: leaguematrix.v1.ListOperationsResponse
+	(*ProcessMatrixRequest)(nil),   // 3: leaguematrix.v1.ProcessMatrixRequest
+	(*Matrix)(nil),                 // 4: leaguematrix.v1.Matrix
+	(*OperationResult)(nil),        // 5: leaguematrix.v1.OperationResult
+}
+var file_matrix_service_proto_depIdxs = []int32{
+	1, // 0: leaguematrix.v1.ListOperationsResponse.operations:type_name -> leaguematrix.v1.Operation
+	4, // 1: leaguematrix.v1.ProcessMatrixRequest.matrix:type_name -> leaguematrix.v1.Matrix
+	0, // 2: leaguematrix.v1.MatrixService.ListOperations:input_type -> leaguematrix.v1.ListOperationsRequest
+	3, // 3: leaguematrix.v1.MatrixService.ProcessMatrix:input_type -> leaguematrix.v1.ProcessMatrixRequest
+	2, // 4: leaguematrix.v1.MatrixService.ListOperations:output_type -> leaguematrix.v1.ListOperationsResponse
+	5, // 5: leaguematrix.v1.MatrixService.ProcessMatrix:output_type -> leaguematrix.v1.OperationResult
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_matrix_service_proto_init() }
+func file_matrix_service_proto_init() {
+	if File_matrix_service_proto != nil {
+		return
+	}
+	file_matrix_proto_init()
+	file_matrix_service_proto_msgTypes[3].OneofWrappers = []any{
+		(*ProcessMatrixRequest_File)(nil),
+		(*ProcessMatrixRequest_Matrix)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_matrix_service_proto_rawDesc), len(file_matrix_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_matrix_service_proto_goTypes,
+		DependencyIndexes: file_matrix_service_proto_depIdxs,
+		MessageInfos:      file_matrix_service_proto_msgTypes,
+	}.Build()
+	File_matrix_service_proto = out.File
+	file_matrix_service_proto_goTypes = nil
+	file_matrix_service_proto_depIdxs = nil
+}
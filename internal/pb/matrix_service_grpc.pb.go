@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: matrix_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MatrixService_ListOperations_FullMethodName = "/leaguematrix.v1.MatrixService/ListOperations"
+	MatrixService_ProcessMatrix_FullMethodName  = "/leaguematrix.v1.MatrixService/ProcessMatrix"
+)
+
+// MatrixServiceClient is the client API for MatrixService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MatrixService runs the matrix operations of the HTTP API over gRPC.
+// When tenants are configured, every call must carry an API key in the x-api-key metadata.
+type MatrixServiceClient interface {
+	// ListOperations returns the enabled operations in alphabetical order.
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	// ProcessMatrix runs an operation on a matrix file or on a matrix sent in the request.
+	ProcessMatrix(ctx context.Context, in *ProcessMatrixRequest, opts ...grpc.CallOption) (*OperationResult, error)
+}
+
+type matrixServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatrixServiceClient(cc grpc.ClientConnInterface) MatrixServiceClient {
+	return &matrixServiceClient{cc}
+}
+
+func (c *matrixServiceClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, MatrixService_ListOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) ProcessMatrix(ctx context.Context, in *ProcessMatrixRequest, opts ...grpc.CallOption) (*OperationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OperationResult)
+	err := c.cc.Invoke(ctx, MatrixService_ProcessMatrix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MatrixServiceServer is the server API for MatrixService service.
+// All implementations must embed UnimplementedMatrixServiceServer
+// for forward compatibility.
+//
+// MatrixService runs the matrix operations of the HTTP API over gRPC.
+// When tenants are configured, every call must carry an API key in the x-api-key metadata.
+type MatrixServiceServer interface {
+	// ListOperations returns the enabled operations in alphabetical order.
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	// ProcessMatrix runs an operation on a matrix file or on a matrix sent in the request.
+	ProcessMatrix(context.Context, *ProcessMatrixRequest) (*OperationResult, error)
+	mustEmbedUnimplementedMatrixServiceServer()
+}
+
+// UnimplementedMatrixServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMatrixServiceServer struct{}
+
+func (UnimplementedMatrixServiceServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (UnimplementedMatrixServiceServer) ProcessMatrix(context.Context, *ProcessMatrixRequest) (*OperationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessMatrix not implemented")
+}
+func (UnimplementedMatrixServiceServer) mustEmbedUnimplementedMatrixServiceServer() {}
+func (UnimplementedMatrixServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeMatrixServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatrixServiceServer will
+// result in compilation errors.
+type UnsafeMatrixServiceServer interface {
+	mustEmbedUnimplementedMatrixServiceServer()
+}
+
+func RegisterMatrixServiceServer(s grpc.ServiceRegistrar, srv MatrixServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMatrixServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MatrixService_ServiceDesc, srv)
+}
+
+func _MatrixService_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatrixService_ListOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_ProcessMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).ProcessMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatrixService_ProcessMatrix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).ProcessMatrix(ctx, req.(*ProcessMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MatrixService_ServiceDesc is the grpc.ServiceDesc for MatrixService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MatrixService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaguematrix.v1.MatrixService",
+	HandlerType: (*MatrixServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOperations",
+			Handler:    _MatrixService_ListOperations_Handler,
+		},
+		{
+			MethodName: "ProcessMatrix",
+			Handler:    _MatrixService_ProcessMatrix_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matrix_service.proto",
+}
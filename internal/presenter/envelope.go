@@ -0,0 +1,49 @@
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// Envelope wraps an operation result with the execution metadata needed by auditable pipelines.
+type Envelope struct {
+	RequestID string
+	Operation string
+	// Source is the file the matrix was read from; empty for matrices sent in the request body.
+	Source   string
+	Duration time.Duration
+	Result   *entity.Result
+}
+
+type envelopePayload struct {
+	RequestID  string          `json:"request_id"`
+	Operation  string          `json:"operation"`
+	Source     string          `json:"source,omitempty"`
+	Input      *dimensionsJSON `json:"input,omitempty"`
+	Output     *dimensionsJSON `json:"output,omitempty"`
+	Result     valueJSON       `json:"result"`
+	DurationMs float64         `json:"duration_ms"`
+}
+
+// EncodeEnvelope serializes an envelope as a JSON object, with the result reported under the result field.
+// Envelopes are always JSON, whichever format the bare result would have been encoded in.
+func EncodeEnvelope(envelope *Envelope) ([]byte, error) {
+	payload := &envelopePayload{
+		RequestID:  envelope.RequestID,
+		Operation:  envelope.Operation,
+		Source:     envelope.Source,
+		Input:      inputDimensions(envelope.Result),
+		Output:     outputDimensions(envelope.Result),
+		Result:     newValue(envelope.Result),
+		DurationMs: milliseconds(envelope.Duration),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+	return data, nil
+}
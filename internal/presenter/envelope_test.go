@@ -0,0 +1,90 @@
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+func TestEncodeEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		envelope *Envelope
+		want     string
+	}{
+		{
+			name: "matrix result from a file",
+			envelope: &Envelope{
+				RequestID: "req-1",
+				Operation: "invert",
+				Source:    "testdata/matrix1.csv",
+				Duration:  1500 * time.Microsecond,
+				Result: &entity.Result{
+					Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}, {5, 6}}},
+					Input:  &entity.MatrixInfo{Rows: 2, Cols: 3, Checksum: "abc"},
+				},
+			},
+			want: `{
+				"request_id": "req-1",
+				"operation": "invert",
+				"source": "testdata/matrix1.csv",
+				"input": {"rows": 2, "cols": 3, "checksum": "sha256:abc"},
+				"output": {"rows": 3, "cols": 2},
+				"result": {"matrix": [[1, 3], [2, 4], [5, 6]]},
+				"duration_ms": 1.5
+			}`,
+		},
+		{
+			name: "matrix result with column labels",
+			envelope: &Envelope{
+				RequestID: "req-3",
+				Operation: "echo",
+				Source:    "testdata/scores.csv",
+				Result: &entity.Result{
+					Matrix:       &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+					Input:        &entity.MatrixInfo{Rows: 1, Cols: 2, Checksum: "abc"},
+					ColumnLabels: []string{"home", "away"},
+				},
+			},
+			want: `{
+				"request_id": "req-3",
+				"operation": "echo",
+				"source": "testdata/scores.csv",
+				"input": {"rows": 1, "cols": 2, "checksum": "sha256:abc"},
+				"output": {"rows": 1, "cols": 2},
+				"result": {"column_labels": ["home", "away"], "matrix": [[1, 2]]},
+				"duration_ms": 0
+			}`,
+		},
+		{
+			name: "scalar result from a request body",
+			envelope: &Envelope{
+				RequestID: "req-2",
+				Operation: "sum",
+				Result: &entity.Result{
+					Scalar: "10",
+					Input:  &entity.MatrixInfo{Rows: 2, Cols: 2, Checksum: "def"},
+				},
+			},
+			want: `{
+				"request_id": "req-2",
+				"operation": "sum",
+				"input": {"rows": 2, "cols": 2, "checksum": "sha256:def"},
+				"result": {"scalar": "10"},
+				"duration_ms": 0
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeEnvelope(tt.envelope)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+type operationListPayload struct {
+	Operations []operationPayload `json:"operations"`
+}
+
+// operationPayload describes an operation clients can run. Steps are omitted for built-in operations.
+type operationPayload struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Steps       []string `json:"steps,omitempty"`
+}
+
+// EncodeOperations serializes the operations clients can run as a JSON object, in the order given.
+func EncodeOperations(operations []entity.OperationInfo) ([]byte, error) {
+	payload := &operationListPayload{Operations: make([]operationPayload, 0, len(operations))}
+	for _, operation := range operations {
+		entry := operationPayload{
+			Name:        string(operation.Name),
+			Description: operation.Description,
+		}
+		for _, step := range operation.Steps {
+			entry.Steps = append(entry.Steps, string(step))
+		}
+		payload.Operations = append(payload.Operations, entry)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal operations: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+	matrixlib "github.com/matsuboshi/league-matrix-app/pkg/matrix"
+)
+
+func TestEncodeOperations(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations []entity.OperationInfo
+		want       string
+	}{
+		{
+			name: "built-in and composite operations",
+			operations: []entity.OperationInfo{
+				{
+					Name:        "column-sums",
+					Description: "Adds up every column.",
+					Steps:       []matrixlib.Operation{matrixlib.Invert, matrixlib.Sum},
+					Enabled:     true,
+				},
+				{Name: matrixlib.Echo, Description: "Returns the matrix unchanged.", BuiltIn: true, Enabled: true},
+			},
+			want: `{"operations": [
+				{"name": "column-sums", "description": "Adds up every column.", "steps": ["invert", "sum"]},
+				{"name": "echo", "description": "Returns the matrix unchanged."}
+			]}`,
+		},
+		{
+			name: "no operations",
+			want: `{"operations": []}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeOperations(tt.operations)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package presenter shapes the JSON documents the HTTP API responds with from the entities the domain returns.
+// Unlike the wire formats of the codec package, which encode a result as it is, its documents add what
+// clients want to know around the result, such as the dimensions of the matrices and how long it took.
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/codec"
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// valueJSON holds the value of a result: a matrix, with the labels of a matrix read with labels, or a scalar.
+// Matrix values are JSON numbers, decimals included.
+type valueJSON struct {
+	ColumnLabels []string        `json:"column_labels,omitempty"`
+	RowLabels    []string        `json:"row_labels,omitempty"`
+	Matrix       [][]json.Number `json:"matrix,omitempty"`
+	Scalar       string          `json:"scalar,omitempty"`
+}
+
+// dimensionsJSON describes a matrix: its dimensions and, for input matrices, the checksum identifying it.
+type dimensionsJSON struct {
+	Rows     int    `json:"rows"`
+	Cols     int    `json:"cols"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+func newValue(result *entity.Result) valueJSON {
+	if result != nil && result.Matrix != nil {
+		return valueJSON{
+			ColumnLabels: result.ColumnLabels,
+			RowLabels:    result.RowLabels,
+			Matrix:       codec.MatrixToJSON(result.Matrix),
+		}
+	}
+	return valueJSON{Scalar: result.String()}
+}
+
+// inputDimensions describes the matrix result was computed from, or returns nil when it is unknown.
+func inputDimensions(result *entity.Result) *dimensionsJSON {
+	if result == nil || result.Input == nil {
+		return nil
+	}
+	return &dimensionsJSON{Rows: result.Input.Rows, Cols: result.Input.Cols, Checksum: Checksum(result.Input.Checksum)}
+}
+
+// Checksum returns the hex-encoded SHA-256 checksum of a matrix in the "sha256:<hex>" form every response
+// identifies matrices with, or an empty string for an unknown checksum.
+func Checksum(checksum string) string {
+	if checksum == "" {
+		return ""
+	}
+	return "sha256:" + checksum
+}
+
+// outputDimensions describes the matrix of result, or returns nil for scalar results.
+func outputDimensions(result *entity.Result) *dimensionsJSON {
+	if result == nil || result.Matrix == nil {
+		return nil
+	}
+	return &dimensionsJSON{Rows: result.Matrix.Rows(), Cols: result.Matrix.Cols()}
+}
+
+// milliseconds returns duration in milliseconds, to the microsecond.
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
@@ -0,0 +1,24 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		name     string
+		checksum string
+		want     string
+	}{
+		{name: "checksum", checksum: "9c1e", want: "sha256:9c1e"},
+		{name: "unknown checksum", checksum: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Checksum(tt.checksum))
+		})
+	}
+}
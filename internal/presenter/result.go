@@ -0,0 +1,38 @@
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+// resultPayload is the JSON response of an operation. It holds the fields of the json format of the codec
+// package, so clients reading those keep working, along with the dimensions of the input and of the
+// resulting matrix and the time the operation took.
+type resultPayload struct {
+	Operation string `json:"operation"`
+	valueJSON
+	Input      *dimensionsJSON `json:"input,omitempty"`
+	Output     *dimensionsJSON `json:"output,omitempty"`
+	DurationMs float64         `json:"duration_ms"`
+}
+
+// EncodeResult serializes the result of operation, computed in duration, as a JSON object.
+// Input is omitted when the input matrix is unknown, and output for scalar results.
+func EncodeResult(operation string, result *entity.Result, duration time.Duration) ([]byte, error) {
+	payload := &resultPayload{
+		Operation:  operation,
+		valueJSON:  newValue(result),
+		Input:      inputDimensions(result),
+		Output:     outputDimensions(result),
+		DurationMs: milliseconds(duration),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json result: %w", err)
+	}
+	return data, nil
+}
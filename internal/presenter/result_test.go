@@ -0,0 +1,86 @@
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/entity"
+)
+
+func TestEncodeResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		result    *entity.Result
+		duration  time.Duration
+		want      string
+	}{
+		{
+			name:      "matrix result",
+			operation: "invert",
+			result: &entity.Result{
+				Matrix: &entity.Matrix[int64]{Data: [][]int64{{1, 3}, {2, 4}, {5, 6}}},
+				Input:  &entity.MatrixInfo{Rows: 2, Cols: 3, Checksum: "abc"},
+			},
+			duration: 1500 * time.Microsecond,
+			want: `{
+				"operation": "invert",
+				"matrix": [[1, 3], [2, 4], [5, 6]],
+				"input": {"rows": 2, "cols": 3, "checksum": "sha256:abc"},
+				"output": {"rows": 3, "cols": 2},
+				"duration_ms": 1.5
+			}`,
+		},
+		{
+			name:      "matrix result with labels",
+			operation: "echo",
+			result: &entity.Result{
+				Matrix:       &entity.Matrix[int64]{Data: [][]int64{{1, 2}}},
+				Input:        &entity.MatrixInfo{Rows: 1, Cols: 2, Checksum: "abc"},
+				ColumnLabels: []string{"home", "away"},
+				RowLabels:    []string{"round 1"},
+			},
+			want: `{
+				"operation": "echo",
+				"column_labels": ["home", "away"],
+				"row_labels": ["round 1"],
+				"matrix": [[1, 2]],
+				"input": {"rows": 1, "cols": 2, "checksum": "sha256:abc"},
+				"output": {"rows": 1, "cols": 2},
+				"duration_ms": 0
+			}`,
+		},
+		{
+			name:      "scalar result",
+			operation: "sum",
+			result: &entity.Result{
+				Scalar: "10",
+				Input:  &entity.MatrixInfo{Rows: 2, Cols: 2, Checksum: "def"},
+			},
+			duration: 250 * time.Microsecond,
+			want: `{
+				"operation": "sum",
+				"scalar": "10",
+				"input": {"rows": 2, "cols": 2, "checksum": "sha256:def"},
+				"duration_ms": 0.25
+			}`,
+		},
+		{
+			name:      "unknown input",
+			operation: "multiply",
+			result:    &entity.Result{Scalar: "24"},
+			want:      `{"operation": "multiply", "scalar": "24", "duration_ms": 0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeResult(tt.operation, tt.result, tt.duration)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Package profile times the stages of serving a request, so the breakdown of a slow request can be
+// reported back to the client that asked for it. The profile middleware attaches a Profile to the context
+// of the requests that ask for one, and the layers serving it time their stages with Start.
+// Without a profile, such as for every other request, in the CLI or in background jobs, Start is a no-op.
+package profile
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Stage names a stage of the pipeline serving a matrix request.
+type Stage string
+
+const (
+	// Path is the validation of the file path the request reads.
+	Path Stage = "path"
+	// Read is the time spent opening the input and reading its bytes.
+	Read Stage = "read"
+	// Parse is the decoding of CSV records from the bytes read.
+	Parse Stage = "parse"
+	// Validate is the conversion and validation of the values of the matrix.
+	Validate Stage = "validate"
+	// Operation is the time spent running the operation. Streamed operations aggregate values as they
+	// are validated, so only producing their result is counted here.
+	Operation Stage = "operation"
+	// Serialize is the encoding of the response body.
+	Serialize Stage = "serialize"
+)
+
+// stages lists the stages in pipeline order, the order Timings reports them in.
+var stages = []Stage{Path, Read, Parse, Validate, Operation, Serialize}
+
+// Timing is the time a request spent in a stage.
+type Timing struct {
+	Stage    Stage
+	Duration time.Duration
+}
+
+// Profile accumulates the time a single request spends in each stage. Stages may be nested, as reading
+// happens while records are parsed, and the time of a nested stage is only counted for that stage,
+// so the timings of a profile add up to no more than the time it ran for. The stages of a request are
+// expected to run one at a time.
+type Profile struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	start     time.Time
+	since     time.Time
+	running   []Stage
+	durations map[Stage]time.Duration
+}
+
+// New returns a profile started now.
+func New() *Profile {
+	return newProfile(time.Now)
+}
+
+func newProfile(now func() time.Time) *Profile {
+	start := now()
+	return &Profile{
+		now:       now,
+		start:     start,
+		since:     start,
+		durations: make(map[Stage]time.Duration),
+	}
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying profile.
+func NewContext(ctx context.Context, profile *Profile) context.Context {
+	return context.WithValue(ctx, contextKey{}, profile)
+}
+
+// FromContext returns the profile carried by ctx, or nil without one.
+func FromContext(ctx context.Context) *Profile {
+	profile, _ := ctx.Value(contextKey{}).(*Profile)
+	return profile
+}
+
+// Start starts timing stage for the profile carried by ctx and returns the function that stops it,
+// meant to be deferred. Without a profile, nothing is timed.
+func Start(ctx context.Context, stage Stage) (stop func()) {
+	profile := FromContext(ctx)
+	if profile == nil {
+		return func() {}
+	}
+	profile.enter(stage)
+	return func() { profile.leave(stage) }
+}
+
+// enter pauses the running stage, if any, and starts stage.
+func (p *Profile) enter(stage Stage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.charge()
+	p.running = append(p.running, stage)
+}
+
+// leave stops stage and resumes the stage it paused.
+func (p *Profile) leave(stage Stage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.charge()
+	// Search from the innermost stage, as the same stage may be running more than once
+	for i := len(p.running) - 1; i >= 0; i-- {
+		if p.running[i] == stage {
+			p.running = slices.Delete(p.running, i, i+1)
+			return
+		}
+	}
+}
+
+// charge adds the time elapsed since the last change to the running stage.
+func (p *Profile) charge() {
+	now := p.now()
+	if len(p.running) > 0 {
+		p.durations[p.running[len(p.running)-1]] += now.Sub(p.since)
+	}
+	p.since = now
+}
+
+// Timings returns the time spent so far in each stage the request went through, in pipeline order.
+func (p *Profile) Timings() []Timing {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.charge()
+	timings := make([]Timing, 0, len(stages))
+	for _, stage := range stages {
+		if duration, ok := p.durations[stage]; ok {
+			timings = append(timings, Timing{Stage: stage, Duration: duration})
+		}
+	}
+	return timings
+}
+
+// Total returns the time elapsed since the profile was started.
+func (p *Profile) Total() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.now().Sub(p.start)
+}
+
+// Reader returns a reader of r whose reads are timed as stage for the profile carried by ctx,
+// or r itself without a profile.
+func Reader(ctx context.Context, stage Stage, r io.Reader) io.Reader {
+	profile := FromContext(ctx)
+	if profile == nil {
+		return r
+	}
+	return &timedReader{reader: r, profile: profile, stage: stage}
+}
+
+// timedReader times the reads of a reader as a stage of a profile.
+type timedReader struct {
+	reader  io.Reader
+	profile *Profile
+	stage   Stage
+}
+
+func (r *timedReader) Read(p []byte) (int, error) {
+	r.profile.enter(r.stage)
+	defer r.profile.leave(r.stage)
+	return r.reader.Read(p)
+}
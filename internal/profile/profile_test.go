@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a clock that only moves when it is advanced.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	profile := New()
+	assert.Same(t, profile, FromContext(NewContext(context.Background(), profile)))
+}
+
+func TestStart(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	profile := newProfile(clock.Now)
+	ctx := NewContext(context.Background(), profile)
+
+	stopPath := Start(ctx, Path)
+	clock.advance(1 * time.Millisecond)
+	stopPath()
+
+	// Time spent outside any stage is only counted in the total
+	clock.advance(5 * time.Millisecond)
+
+	// Reads nested in parsing are only counted as reads
+	for range 2 {
+		stopParse := Start(ctx, Parse)
+		clock.advance(2 * time.Millisecond)
+		stopRead := Start(ctx, Read)
+		clock.advance(3 * time.Millisecond)
+		stopRead()
+		clock.advance(1 * time.Millisecond)
+		stopParse()
+
+		stopValidate := Start(ctx, Validate)
+		clock.advance(4 * time.Millisecond)
+		stopValidate()
+	}
+
+	stopSerialize := Start(ctx, Serialize)
+	clock.advance(7 * time.Millisecond)
+
+	// A stage still running is counted up to now, and stages are reported in pipeline order
+	assert.Equal(t, []Timing{
+		{Stage: Path, Duration: 1 * time.Millisecond},
+		{Stage: Read, Duration: 6 * time.Millisecond},
+		{Stage: Parse, Duration: 6 * time.Millisecond},
+		{Stage: Validate, Duration: 8 * time.Millisecond},
+		{Stage: Serialize, Duration: 7 * time.Millisecond},
+	}, profile.Timings())
+	assert.Equal(t, 33*time.Millisecond, profile.Total())
+
+	clock.advance(1 * time.Millisecond)
+	stopSerialize()
+	assert.Equal(t, Timing{Stage: Serialize, Duration: 8 * time.Millisecond}, profile.Timings()[4])
+}
+
+func TestStart_WithoutProfile(t *testing.T) {
+	// Stopping a stage that was not timed does nothing
+	stop := Start(context.Background(), Operation)
+	assert.NotPanics(t, stop)
+}
@@ -0,0 +1,56 @@
+// Package qos carries the class of service of a request, from the API key tier or header that selects it
+// to the execution pool that schedules its operations, so batch work cannot starve interactive requests.
+package qos
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Class is the class of service work is scheduled with.
+type Class string
+
+const (
+	// Interactive is the class of requests a client waits on. It is the default, and its work goes first.
+	Interactive Class = "interactive"
+
+	// Batch is the class of bulk work, such as background jobs, which only gets a share of the workers.
+	Batch Class = "batch"
+)
+
+// Parse parses a class given as a string, such as a request header. An empty value returns an empty class,
+// which leaves the class to the default.
+func Parse(value string) (Class, error) {
+	switch class := Class(value); class {
+	case "", Interactive, Batch:
+		return class, nil
+	default:
+		return "", fmt.Errorf("%w: invalid class of service %q: expected %s or %s",
+			apperrors.ErrInvalidInput, value, Interactive, Batch)
+	}
+}
+
+// Min returns the lower of a and b, where an empty class stands for Interactive.
+func Min(a, b Class) Class {
+	if a == Batch || b == Batch {
+		return Batch
+	}
+	return Interactive
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying class.
+func NewContext(ctx context.Context, class Class) context.Context {
+	return context.WithValue(ctx, contextKey{}, class)
+}
+
+// FromContext returns the class carried by ctx, or Interactive without one.
+func FromContext(ctx context.Context) Class {
+	if class, ok := ctx.Value(contextKey{}).(Class); ok && class != "" {
+		return class
+	}
+	return Interactive
+}
@@ -0,0 +1,35 @@
+package qos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestParse(t *testing.T) {
+	for _, value := range []string{"", "interactive", "batch"} {
+		class, err := Parse(value)
+		assert.NoError(t, err)
+		assert.Equal(t, Class(value), class)
+	}
+
+	for _, value := range []string{"Batch", "bulk", " interactive"} {
+		_, err := Parse(value)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput, value)
+	}
+}
+
+func TestMin(t *testing.T) {
+	assert.Equal(t, Interactive, Min("", Interactive))
+	assert.Equal(t, Batch, Min(Interactive, Batch))
+	assert.Equal(t, Batch, Min(Batch, ""))
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, Interactive, FromContext(context.Background()))
+	assert.Equal(t, Batch, FromContext(NewContext(context.Background(), Batch)))
+	assert.Equal(t, Interactive, FromContext(NewContext(context.Background(), "")))
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// maxArchiveEntries limits how many files a single zip archive may contain.
+const maxArchiveEntries = 100
+
+// ArchiveRepositoryInterface defines the contract for reading matrix files bundled in a zip archive.
+type ArchiveRepositoryInterface interface {
+	// ReadArchive parses a zip archive and returns the CSV content of every file it contains, in archive order.
+	// Problems with individual files are reported on the entry so the remaining files can still be processed;
+	// an error is returned only when the archive itself cannot be read.
+	ReadArchive(ctx context.Context, data []byte) ([]*ArchiveEntry, error)
+}
+
+// ArchiveEntry represents a single file read from a zip archive.
+// Exactly one of Content or Err is set.
+type ArchiveEntry struct {
+	Name    string
+	Content *MatrixFileContent
+	Err     error
+}
+
+type archiveRepository struct{}
+
+// NewArchiveRepository creates a new instance of ArchiveRepositoryInterface.
+// It returns a repository that reads matrix CSV files from in-memory zip archives.
+func NewArchiveRepository() ArchiveRepositoryInterface {
+	return &archiveRepository{}
+}
+
+func (r *archiveRepository) ReadArchive(ctx context.Context, data []byte) ([]*ArchiveEntry, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to open zip archive", "error", err)
+		return nil, fmt.Errorf("%w: failed to read zip archive: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+
+	limit := maxFileSize(ctx)
+	options := parsing.FromContext(ctx)
+	entries := make([]*ArchiveEntry, 0, len(reader.File))
+	for _, file := range reader.File {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Directories carry no data; skip them
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		if len(entries) == maxArchiveEntries {
+			return nil, fmt.Errorf("%w: archive contains more than %d files",
+				apperrors.ErrPayloadTooLarge, maxArchiveEntries)
+		}
+
+		entry := &ArchiveEntry{Name: file.Name}
+		entry.Content, entry.Err = r.readEntry(file, limit, options)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// readEntry reads the CSV content of an archived file as described by options, rejecting files larger than limit bytes.
+func (r *archiveRepository) readEntry(file *zip.File, limit int64, options parsing.Options) (*MatrixFileContent, error) {
+	if !strings.EqualFold(path.Ext(file.Name), ".csv") {
+		return nil, fmt.Errorf("%w: only .csv files are supported", apperrors.ErrInvalidInput)
+	}
+
+	// Check the declared size BEFORE decompressing to prevent DoS attacks
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%w: file too large: %d bytes (maximum: %d bytes)",
+			apperrors.ErrPayloadTooLarge, file.UncompressedSize64, limit)
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open archive entry: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+	defer rc.Close()
+
+	// The declared size can lie, so never decompress more than the limit allows
+	raw, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress archive entry: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+	if int64(len(raw)) > limit {
+		return nil, fmt.Errorf("%w: file too large (maximum: %d bytes)",
+			apperrors.ErrPayloadTooLarge, limit)
+	}
+
+	records, err := newCSVReader(bytes.NewReader(raw), options).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV file: %v", apperrors.ErrUnprocessableEntity, err)
+	}
+
+	content := &MatrixFileContent{Content: records[:0]}
+	for _, record := range records {
+		if options.CleanRow(record) {
+			content.Content = append(content.Content, record)
+		}
+	}
+	return content, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// buildArchive zips the given name/content pairs in order; names ending in "/" become directory entries.
+func buildArchive(t *testing.T, files [][2]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := writer.Create(file[0])
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(file[1]))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestArchiveRepository_ReadArchive(t *testing.T) {
+	t.Run("reads every CSV file in archive order", func(t *testing.T) {
+		data := buildArchive(t, [][2]string{
+			{"batch/", ""},
+			{"batch/a.csv", "1,2\n3,4\n"},
+			{"batch/b.CSV", "5\n"},
+		})
+
+		entries, err := NewArchiveRepository().ReadArchive(context.Background(), data)
+
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "batch/a.csv", entries[0].Name)
+		assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, entries[0].Content.Content)
+		assert.Equal(t, "batch/b.CSV", entries[1].Name)
+		assert.Equal(t, [][]string{{"5"}}, entries[1].Content.Content)
+	})
+
+	t.Run("invalid files are reported per entry", func(t *testing.T) {
+		data := buildArchive(t, [][2]string{
+			{"notes.txt", "hello"},
+			{"large.csv", strings.Repeat("1,", 600)},
+			{"broken.csv", "1,\"2\n"},
+			{"ok.csv", "1\n"},
+		})
+
+		entries, err := NewArchiveRepository().ReadArchive(context.Background(), data)
+
+		assert.NoError(t, err)
+		assert.Len(t, entries, 4)
+		assert.ErrorIs(t, entries[0].Err, apperrors.ErrInvalidInput)
+		assert.ErrorIs(t, entries[1].Err, apperrors.ErrPayloadTooLarge)
+		assert.ErrorIs(t, entries[2].Err, apperrors.ErrUnprocessableEntity)
+		assert.NoError(t, entries[3].Err)
+		assert.Equal(t, [][]string{{"1"}}, entries[3].Content.Content)
+	})
+
+	t.Run("lenient parsing", func(t *testing.T) {
+		data := buildArchive(t, [][2]string{{"export.csv", "\ufeff 1 , 2\n,\n3, \"4\"\n"}})
+		ctx := parsing.NewContext(context.Background(), parsing.Options{Lenient: true})
+
+		entries, err := NewArchiveRepository().ReadArchive(ctx, data)
+
+		assert.NoError(t, err)
+		assert.NoError(t, entries[0].Err)
+		assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, entries[0].Content.Content)
+	})
+
+	t.Run("not a zip archive", func(t *testing.T) {
+		entries, err := NewArchiveRepository().ReadArchive(context.Background(), []byte("1,2\n3,4\n"))
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		assert.Nil(t, entries)
+	})
+
+	t.Run("too many files", func(t *testing.T) {
+		files := make([][2]string, maxArchiveEntries+1)
+		for i := range files {
+			files[i] = [2]string{fmt.Sprintf("m%d.csv", i), "1\n"}
+		}
+
+		entries, err := NewArchiveRepository().ReadArchive(context.Background(), buildArchive(t, files))
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+		assert.Nil(t, entries)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := NewArchiveRepository().ReadArchive(ctx, buildArchive(t, [][2]string{{"a.csv", "1\n"}}))
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxMemoryAuditEntries caps the entries kept by the in-memory audit log; the oldest are dropped first.
+const maxMemoryAuditEntries = 10000
+
+// AuditRepositoryInterface defines the contract for storing the audit log.
+type AuditRepositoryInterface interface {
+	// AppendEntry adds an entry to the audit log. Entries are never changed once appended.
+	AppendEntry(ctx context.Context, entry *AuditRecord) error
+
+	// ListEntries returns the entries matching query, newest first.
+	ListEntries(ctx context.Context, query *AuditQuery) ([]*AuditRecord, error)
+}
+
+// AuditRecord is the stored form of an audit log entry, one JSON object per entry in audit log files.
+type AuditRecord struct {
+	ID         string            `json:"id"`
+	Time       time.Time         `json:"time"`
+	Event      string            `json:"event"`
+	Actor      string            `json:"actor,omitempty"`
+	RemoteAddr string            `json:"remote_addr,omitempty"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Operation  string            `json:"operation,omitempty"`
+	FilePath   string            `json:"file,omitempty"`
+	Status     int               `json:"status"`
+	Duration   time.Duration     `json:"duration"`
+	Reason     string            `json:"reason,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+}
+
+// AuditQuery selects audit log entries. Empty fields match every entry;
+// a zero Limit returns every matching entry.
+type AuditQuery struct {
+	Actor     string
+	Event     string
+	Operation string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// Matches reports whether entry is selected by the query, ignoring its limit.
+func (q *AuditQuery) Matches(entry *AuditRecord) bool {
+	return (q.Actor == "" || entry.Actor == q.Actor) &&
+		(q.Event == "" || entry.Event == q.Event) &&
+		(q.Operation == "" || entry.Operation == q.Operation) &&
+		(q.Since.IsZero() || !entry.Time.Before(q.Since)) &&
+		(q.Until.IsZero() || entry.Time.Before(q.Until))
+}
+
+type memoryAuditRepository struct {
+	mu      sync.Mutex
+	entries []AuditRecord
+}
+
+// NewAuditRepository creates a new instance of AuditRepositoryInterface.
+// It returns a repository that keeps the latest audit log entries in memory,
+// so the log is lost when the process exits.
+func NewAuditRepository() AuditRepositoryInterface {
+	return &memoryAuditRepository{}
+}
+
+func (r *memoryAuditRepository) AppendEntry(ctx context.Context, entry *AuditRecord) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) == maxMemoryAuditEntries {
+		r.entries = slices.Delete(r.entries, 0, 1)
+	}
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *memoryAuditRepository) ListEntries(ctx context.Context, query *AuditQuery) ([]*AuditRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []*AuditRecord
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if query.Limit > 0 && len(entries) == query.Limit {
+			break
+		}
+		if entry := r.entries[i]; query.Matches(&entry) {
+			entries = append(entries, &entry)
+		}
+	}
+	return entries, nil
+}
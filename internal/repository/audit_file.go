@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+)
+
+type fileAuditRepository struct {
+	// mu serializes appends so concurrent entries are never interleaved
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileAuditRepository creates a new instance of AuditRepositoryInterface backed by a file.
+// It returns a repository that appends each entry to path as a line of JSON, so the log
+// survives restarts and can be processed with standard tools. The file is created if it does not exist.
+func NewFileAuditRepository(path string) (AuditRepositoryInterface, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return &fileAuditRepository{path: path}, nil
+}
+
+func (r *fileAuditRepository) AppendEntry(ctx context.Context, entry *AuditRecord) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	_, err = file.Write(line)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListEntries scans the whole log file, so queries get slower as the log grows;
+// rotate the file with external tools to keep it small.
+func (r *fileAuditRepository) ListEntries(ctx context.Context, query *AuditQuery) ([]*AuditRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(r.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []*AuditRecord
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		entry := &AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			// A line cut short by a crash must not hide the rest of the log
+			slog.WarnContext(ctx, "skipping malformed audit entry",
+				"file", r.path,
+				"line", line,
+				"error", err)
+			continue
+		}
+		if query.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	// The file is in append order, oldest first
+	slices.Reverse(entries)
+	if query.Limit > 0 && len(entries) > query.Limit {
+		entries = entries[:query.Limit]
+	}
+	return entries, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileAuditRepository(t *testing.T) {
+	t.Run("queries", func(t *testing.T) {
+		repo, err := NewFileAuditRepository(filepath.Join(t.TempDir(), "audit.log"))
+		assert.NoError(t, err)
+
+		testAuditQueries(t, repo)
+	})
+
+	t.Run("entries survive a restart", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		repo, err := NewFileAuditRepository(path)
+		assert.NoError(t, err)
+		assert.NoError(t, repo.AppendEntry(context.Background(), auditFixture()[0]))
+
+		reopened, err := NewFileAuditRepository(path)
+		assert.NoError(t, err)
+		got, err := reopened.ListEntries(context.Background(), &AuditQuery{})
+
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, auditFixture()[0], got[0])
+		}
+	})
+
+	t.Run("skips malformed lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		repo, err := NewFileAuditRepository(path)
+		assert.NoError(t, err)
+		assert.NoError(t, repo.AppendEntry(context.Background(), auditFixture()[0]))
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+		assert.NoError(t, err)
+		_, err = file.WriteString(`{"id": "trunc`)
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+
+		got, err := repo.ListEntries(context.Background(), &AuditQuery{})
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+
+	t.Run("unwritable location", func(t *testing.T) {
+		repo, err := NewFileAuditRepository(filepath.Join(t.TempDir(), "missing", "audit.log"))
+
+		assert.Error(t, err)
+		assert.Nil(t, repo)
+	})
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// auditFixture returns entries appended one minute apart, oldest first.
+func auditFixture() []*AuditRecord {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	return []*AuditRecord{
+		{ID: "1", Time: start, Event: "request", Actor: "tenant:acme", Method: "GET", Path: "/matrix/sum", Operation: "sum", Status: 200},
+		{ID: "2", Time: start.Add(time.Minute), Event: "denied", Method: "GET", Path: "/matrix/sum", Status: 401, Reason: "unknown API key"},
+		{ID: "3", Time: start.Add(2 * time.Minute), Event: "request", Actor: "tenant:globex", Method: "GET", Path: "/matrix/flatten", Operation: "flatten", Status: 200},
+		{ID: "4", Time: start.Add(3 * time.Minute), Event: "request", Actor: "tenant:acme", Method: "GET", Path: "/jobs/abc", Status: 404},
+	}
+}
+
+// testAuditQueries checks the queries every audit repository must answer the same way.
+func testAuditQueries(t *testing.T, repo AuditRepositoryInterface) {
+	for _, entry := range auditFixture() {
+		assert.NoError(t, repo.AppendEntry(context.Background(), entry))
+	}
+	start := auditFixture()[0].Time
+
+	tests := []struct {
+		name    string
+		query   *AuditQuery
+		wantIDs []string
+	}{
+		{name: "every entry, newest first", query: &AuditQuery{}, wantIDs: []string{"4", "3", "2", "1"}},
+		{name: "limit", query: &AuditQuery{Limit: 2}, wantIDs: []string{"4", "3"}},
+		{name: "actor", query: &AuditQuery{Actor: "tenant:acme"}, wantIDs: []string{"4", "1"}},
+		{name: "event", query: &AuditQuery{Event: "denied"}, wantIDs: []string{"2"}},
+		{name: "operation", query: &AuditQuery{Operation: "flatten"}, wantIDs: []string{"3"}},
+		{name: "time range", query: &AuditQuery{Since: start.Add(time.Minute), Until: start.Add(3 * time.Minute)}, wantIDs: []string{"3", "2"}},
+		{name: "no match", query: &AuditQuery{Actor: "admin"}, wantIDs: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListEntries(context.Background(), tt.query)
+
+			assert.NoError(t, err)
+			var ids []string
+			for _, entry := range got {
+				ids = append(ids, entry.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestMemoryAuditRepository(t *testing.T) {
+	t.Run("queries", func(t *testing.T) {
+		testAuditQueries(t, NewAuditRepository())
+	})
+
+	t.Run("drops the oldest entries", func(t *testing.T) {
+		repo := NewAuditRepository()
+		for i := range maxMemoryAuditEntries + 1 {
+			assert.NoError(t, repo.AppendEntry(context.Background(), &AuditRecord{Status: i}))
+		}
+
+		got, err := repo.ListEntries(context.Background(), &AuditQuery{})
+
+		assert.NoError(t, err)
+		assert.Len(t, got, maxMemoryAuditEntries)
+		assert.Equal(t, 1, got[len(got)-1].Status)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, NewAuditRepository().AppendEntry(ctx, &AuditRecord{}), context.Canceled)
+	})
+}
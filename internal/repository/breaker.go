@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// BreakerConfig controls when the circuit around a remote repository backend opens and recovers.
+// The circuit opens after FailureThreshold consecutive backend failures and rejects calls for
+// OpenTimeout, after which a single trial call decides whether it closes again.
+type BreakerConfig struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+// DefaultBreakerConfig returns the circuit breaker policy used for remote backends:
+// open after 5 consecutive failures and probe the backend again after 30s.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type breakerMatrixRepository struct {
+	next   MatrixRepositoryInterface
+	config BreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// NewBreakerMatrixRepository creates a new instance of MatrixRepositoryInterface that guards next with a circuit breaker.
+// While the circuit is open, calls fail immediately with ErrServiceUnavailable instead of waiting on a misbehaving backend.
+// It should wrap the retrying repository, so that a whole sequence of retries counts as a single failure.
+func NewBreakerMatrixRepository(next MatrixRepositoryInterface, config BreakerConfig) MatrixRepositoryInterface {
+	if config.FailureThreshold < 1 {
+		config.FailureThreshold = 1
+	}
+
+	return &breakerMatrixRepository{
+		next:   next,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+func (r *breakerMatrixRepository) GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error) {
+	if err := r.allow(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	content, err := r.next.GetFileContent(ctx, filePath)
+	r.record(ctx, filePath, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func (r *breakerMatrixRepository) StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error {
+	if err := r.allow(ctx, filePath); err != nil {
+		return err
+	}
+
+	err := r.next.StreamFileContent(ctx, filePath, handleRow)
+	r.record(ctx, filePath, err)
+	return err
+}
+
+func (r *breakerMatrixRepository) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	if err := r.allow(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	data, err := r.next.ReadFile(ctx, filePath)
+	r.record(ctx, filePath, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *breakerMatrixRepository) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	if err := r.allow(ctx, filePath); err != nil {
+		return time.Time{}, err
+	}
+
+	modTime, err := r.next.FileModTime(ctx, filePath)
+	r.record(ctx, filePath, err)
+	return modTime, err
+}
+
+func (r *breakerMatrixRepository) StatFile(ctx context.Context, filePath string) (FileStat, error) {
+	if err := r.allow(ctx, filePath); err != nil {
+		return FileStat{}, err
+	}
+
+	stat, err := r.next.StatFile(ctx, filePath)
+	r.record(ctx, filePath, err)
+	return stat, err
+}
+
+// StreamContent reads a local stream, which involves no backend, so it bypasses the breaker.
+func (r *breakerMatrixRepository) StreamContent(ctx context.Context, reader io.Reader, handleRow RowHandler) error {
+	return r.next.StreamContent(ctx, reader, handleRow)
+}
+
+func (r *breakerMatrixRepository) SaveFileContent(ctx context.Context, filePath string, content *MatrixFileContent) error {
+	if err := r.allow(ctx, filePath); err != nil {
+		return err
+	}
+
+	err := r.next.SaveFileContent(ctx, filePath, content)
+	r.record(ctx, filePath, err)
+	return err
+}
+
+// allow reports whether a call may reach the backend. Once the open timeout has elapsed,
+// exactly one caller is let through as a trial while the others keep failing fast.
+func (r *breakerMatrixRepository) allow(ctx context.Context, filePath string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.state {
+	case breakerOpen:
+		if r.now().Sub(r.openedAt) < r.config.OpenTimeout {
+			return r.openError(filePath)
+		}
+		r.setState(ctx, breakerHalfOpen)
+		return nil
+	case breakerHalfOpen:
+		// A trial call is already in flight
+		return r.openError(filePath)
+	default:
+		return nil
+	}
+}
+
+// record updates the circuit with the outcome of a backend call.
+// Only backend failures count; invalid input or missing files say nothing about the backend's health.
+func (r *breakerMatrixRepository) record(ctx context.Context, filePath string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !isBackendFailure(err) {
+		r.failures = 0
+		if r.state != breakerClosed {
+			r.setState(ctx, breakerClosed)
+		}
+		return
+	}
+
+	r.failures++
+	if r.state == breakerHalfOpen || r.failures >= r.config.FailureThreshold {
+		slog.ErrorContext(ctx, "remote backend circuit opened",
+			"file_path", filePath,
+			"consecutive_failures", r.failures,
+			"open_timeout", r.config.OpenTimeout,
+			"error", err)
+		r.openedAt = r.now()
+		r.setState(ctx, breakerOpen)
+	}
+}
+
+func (r *breakerMatrixRepository) setState(ctx context.Context, state breakerState) {
+	if r.state != state {
+		slog.InfoContext(ctx, "remote backend circuit state changed",
+			"from", r.state.String(),
+			"to", state.String())
+	}
+	r.state = state
+}
+
+func (r *breakerMatrixRepository) openError(filePath string) error {
+	return fmt.Errorf("%w: remote backend circuit is open for %s", apperrors.ErrServiceUnavailable, filePath)
+}
+
+// isBackendFailure reports whether err indicates an unhealthy backend: a transient error
+// or a call that ran out of time. Cancellation by the client is not the backend's fault.
+func isBackendFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	return errors.Is(err, context.DeadlineExceeded) || isTransient(err)
+}
+
+func (r *breakerMatrixRepository) ListFiles(ctx context.Context) ([]string, error) {
+	if err := r.allow(ctx, config.CurrentLimits().DataDir); err != nil {
+		return nil, err
+	}
+
+	files, err := r.next.ListFiles(ctx)
+	r.record(ctx, config.CurrentLimits().DataDir, err)
+	return files, err
+}
+
+// CheckHealth fails while the circuit is open, without counting as a call: the breaker has already
+// found the backend unhealthy, and a health check must not use up the trial call.
+func (r *breakerMatrixRepository) CheckHealth(ctx context.Context) error {
+	r.mu.Lock()
+	open := r.state != breakerClosed
+	r.mu.Unlock()
+	if open {
+		return r.openError(config.CurrentLimits().DataDir)
+	}
+
+	return r.next.CheckHealth(ctx)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func newTestBreaker(next MatrixRepositoryInterface, now *time.Time) *breakerMatrixRepository {
+	repo := NewBreakerMatrixRepository(next, BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute}).(*breakerMatrixRepository)
+	repo.now = func() time.Time { return *now }
+	return repo
+}
+
+func TestBreakerMatrixRepository(t *testing.T) {
+	t.Run("opens after consecutive failures and fails fast", func(t *testing.T) {
+		now := time.Now()
+		next := &flakyRepository{errs: []error{apperrors.ErrServiceUnavailable, apperrors.ErrServiceUnavailable}}
+		repo := newTestBreaker(next, &now)
+		ctx := context.Background()
+
+		for range 2 {
+			_, err := repo.GetFileContent(ctx, "remote/matrix.csv")
+			assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		}
+		assert.Equal(t, breakerOpen, repo.state)
+
+		_, err := repo.GetFileContent(ctx, "remote/matrix.csv")
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.Contains(t, err.Error(), "circuit is open")
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("successful trial call closes the circuit", func(t *testing.T) {
+		now := time.Now()
+		next := &flakyRepository{errs: []error{apperrors.ErrServiceUnavailable, context.DeadlineExceeded}, rows: [][]string{{"1"}}}
+		repo := newTestBreaker(next, &now)
+		ctx := context.Background()
+
+		_, _ = repo.GetFileContent(ctx, "remote/matrix.csv")
+		_, _ = repo.GetFileContent(ctx, "remote/matrix.csv")
+		assert.Equal(t, breakerOpen, repo.state)
+
+		now = now.Add(time.Minute)
+		got, err := repo.GetFileContent(ctx, "remote/matrix.csv")
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"1"}}, got.Content)
+		assert.Equal(t, breakerClosed, repo.state)
+		assert.Equal(t, 3, next.calls)
+	})
+
+	t.Run("failed trial call reopens the circuit", func(t *testing.T) {
+		now := time.Now()
+		next := &flakyRepository{errs: []error{
+			apperrors.ErrServiceUnavailable, apperrors.ErrServiceUnavailable, apperrors.ErrServiceUnavailable,
+		}}
+		repo := newTestBreaker(next, &now)
+		ctx := context.Background()
+
+		_ = repo.StreamFileContent(ctx, "remote/matrix.csv", func([]string) error { return nil })
+		_ = repo.StreamFileContent(ctx, "remote/matrix.csv", func([]string) error { return nil })
+
+		now = now.Add(time.Minute)
+		err := repo.StreamFileContent(ctx, "remote/matrix.csv", func([]string) error { return nil })
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.Equal(t, breakerOpen, repo.state)
+		assert.Equal(t, now, repo.openedAt)
+		assert.Equal(t, 3, next.calls)
+	})
+
+	t.Run("permanent errors do not trip the circuit", func(t *testing.T) {
+		now := time.Now()
+		next := &flakyRepository{errs: []error{
+			apperrors.ErrNotFound, apperrors.ErrUnprocessableEntity, context.Canceled,
+		}}
+		repo := newTestBreaker(next, &now)
+		ctx := context.Background()
+
+		for range 3 {
+			_, err := repo.GetFileContent(ctx, "remote/matrix.csv")
+			assert.Error(t, err)
+		}
+
+		assert.Equal(t, breakerClosed, repo.state)
+		assert.Equal(t, 0, repo.failures)
+	})
+
+	t.Run("health check fails while the circuit is open", func(t *testing.T) {
+		now := time.Now()
+		next := &flakyRepository{errs: []error{apperrors.ErrServiceUnavailable, apperrors.ErrServiceUnavailable}}
+		repo := newTestBreaker(next, &now)
+		ctx := context.Background()
+
+		for range 2 {
+			_, _ = repo.GetFileContent(ctx, "remote/matrix.csv")
+		}
+
+		err := repo.CheckHealth(ctx)
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.Contains(t, err.Error(), "circuit is open")
+		assert.Equal(t, 2, next.calls)
+
+		// The health check leaves the trial call to the next request
+		now = now.Add(time.Minute)
+		assert.ErrorIs(t, repo.CheckHealth(ctx), apperrors.ErrServiceUnavailable)
+		assert.Equal(t, breakerOpen, repo.state)
+	})
+}
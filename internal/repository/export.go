@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// csvContentType is the media type exported CSV files are stored with.
+const csvContentType = "text/csv"
+
+// ExportRepositoryInterface defines the data access contract for writing operation results to a storage target
+// outside the matrix files, such as a local directory or an object storage bucket.
+type ExportRepositoryInterface interface {
+	// WriteFile writes content as a CSV file at filePath within the target, replacing any file of the same path.
+	// Object storage targets take the bucket as the first segment of filePath.
+	WriteFile(ctx context.Context, filePath string, content *MatrixFileContent) error
+}
+
+type dirExportRepository struct {
+	dir string
+}
+
+// NewDirExportRepository creates a new instance of ExportRepositoryInterface that writes files below dir,
+// creating the directories they are in as needed.
+func NewDirExportRepository(dir string) ExportRepositoryInterface {
+	return &dirExportRepository{
+		dir: dir,
+	}
+}
+
+func (r *dirExportRepository) WriteFile(ctx context.Context, filePath string, content *MatrixFileContent) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	encoded, err := encodeCSV(content)
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(r.dir, filepath.FromSlash(filePath))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create export directory",
+			"file_path", target,
+			"error", err)
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	// The file is written aside and renamed over the target, so readers never see a partial export
+	temp, err := os.CreateTemp(filepath.Dir(target), ".export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	_, err = temp.Write(encoded)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), target)
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+		slog.ErrorContext(ctx, "failed to write export file",
+			"file_path", target,
+			"error", err)
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+type s3ExportRepository struct {
+	client *s3Client
+}
+
+// NewS3ExportRepository creates a new instance of ExportRepositoryInterface that writes files as objects
+// of the S3-compatible object storage described by config.
+func NewS3ExportRepository(config S3Config) ExportRepositoryInterface {
+	return &s3ExportRepository{
+		client: newS3Client(config),
+	}
+}
+
+func (r *s3ExportRepository) WriteFile(ctx context.Context, filePath string, content *MatrixFileContent) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	bucket, key, _ := strings.Cut(filePath, "/")
+	encoded, err := encodeCSV(content)
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.putObject(ctx, bucket, key, encoded, csvContentType); err != nil {
+		slog.ErrorContext(ctx, "failed to write export object",
+			"bucket", bucket,
+			"key", key,
+			"error", err)
+		return err
+	}
+	return nil
+}
+
+// encodeCSV encodes the rows of content as CSV.
+func encodeCSV(content *MatrixFileContent) ([]byte, error) {
+	var encoded bytes.Buffer
+	if err := csv.NewWriter(&encoded).WriteAll(content.Content); err != nil {
+		return nil, fmt.Errorf("failed to encode CSV: %w", err)
+	}
+	return encoded.Bytes(), nil
+}
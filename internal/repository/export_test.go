@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirExportRepository_WriteFile(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewDirExportRepository(dir)
+
+	err := repo.WriteFile(context.Background(), "daily/sum.csv", &MatrixFileContent{Content: [][]string{{"45"}}})
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "daily", "sum.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "45\n", string(data))
+
+	// Exporting again replaces the file, leaving no temporary file behind
+	err = repo.WriteFile(context.Background(), "daily/sum.csv", &MatrixFileContent{Content: [][]string{{"1", "2"}, {"3", "4"}}})
+	assert.NoError(t, err)
+
+	data, err = os.ReadFile(filepath.Join(dir, "daily", "sum.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2\n3,4\n", string(data))
+
+	entries, err := os.ReadDir(filepath.Join(dir, "daily"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestDirExportRepository_WriteFile_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := NewDirExportRepository(t.TempDir()).WriteFile(ctx, "sum.csv", &MatrixFileContent{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestS3ExportRepository_WriteFile(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotBody = r.URL.Path, string(body)
+	}))
+	defer server.Close()
+
+	repo := NewS3ExportRepository(S3Config{
+		Endpoint: server.URL,
+		Credentials: func(context.Context) (S3Credentials, error) {
+			return exampleCredentials, nil
+		},
+	})
+	err := repo.WriteFile(context.Background(), "results/daily/echo.csv", &MatrixFileContent{Content: [][]string{{"1", "2"}}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/results/daily/echo.csv", gotPath)
+	assert.Equal(t, "1,2\n", gotBody)
+}
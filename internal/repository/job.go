@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Job status values as stored by job repositories.
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+// JobRepositoryInterface defines the contract for storing the state of background jobs.
+type JobRepositoryInterface interface {
+	// SaveJob stores a job, replacing any previous state saved under the same ID.
+	SaveJob(ctx context.Context, job *JobRecord) error
+
+	// GetJob returns the stored state of a job, or ErrNotFound for unknown IDs.
+	GetJob(ctx context.Context, id string) (*JobRecord, error)
+
+	// ListUnfinishedJobs returns every queued or running job, oldest first,
+	// so work interrupted by a restart can be resumed or marked as failed.
+	ListUnfinishedJobs(ctx context.Context) ([]*JobRecord, error)
+
+	// ListRecentJobs returns up to limit jobs submitted by tenantID, newest first.
+	// An empty tenantID selects the jobs submitted without a tenant.
+	ListRecentJobs(ctx context.Context, tenantID string, limit int) ([]*JobRecord, error)
+
+	// DeleteFinishedJobs deletes the jobs that finished before the given time, whatever tenant submitted them,
+	// and returns how many were deleted. Queued and running jobs are kept.
+	DeleteFinishedJobs(ctx context.Context, before time.Time) (int, error)
+
+	// CheckHealth reports whether the job store can be reached, returning ErrServiceUnavailable when it cannot.
+	CheckHealth(ctx context.Context) error
+
+	// Close releases the connections to the job store. The repository must not be used afterwards.
+	Close() error
+}
+
+// JobRecord is the stored form of a background job.
+// Input is the matrix supplied with the job, if any; it is dropped once the job has finished.
+// InputScale and ResultScale are the scales the values of Input and ResultMatrix are held at, which are
+// scaled by 10^scale for decimal matrices.
+// The result fields are set for succeeded jobs and the error fields for failed ones, where ErrorKind
+// names the error class so it can be restored with the same meaning. CallbackURL is the webhook
+// notified when the job finishes, if any, and TenantID the tenant that submitted the job, if any.
+type JobRecord struct {
+	ID            string
+	Operation     string
+	FilePath      string
+	Input         [][]int64
+	InputScale    int
+	Status        string
+	ResultMatrix  [][]int64
+	ResultScale   int
+	ResultScalar  string
+	InputRows     int
+	InputCols     int
+	InputChecksum string
+	ErrorKind     string
+	ErrorMessage  string
+	CreatedAt     time.Time
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	CallbackURL   string
+	TenantID      string
+	Tags          map[string]string
+}
+
+// Unfinished reports whether the job is still queued or running.
+func (j *JobRecord) Unfinished() bool {
+	return j.Status == JobStatusQueued || j.Status == JobStatusRunning
+}
+
+type memoryJobRepository struct {
+	mu   sync.Mutex
+	jobs map[string]JobRecord
+}
+
+// NewJobRepository creates a new instance of JobRepositoryInterface.
+// It returns a repository that keeps jobs in memory, so their state is lost when the process exits.
+func NewJobRepository() JobRepositoryInterface {
+	return &memoryJobRepository{
+		jobs: make(map[string]JobRecord),
+	}
+}
+
+func (r *memoryJobRepository) SaveJob(ctx context.Context, job *JobRecord) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *memoryJobRepository) GetJob(ctx context.Context, id string) (*JobRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: job not found: %s", apperrors.ErrNotFound, id)
+	}
+	return &job, nil
+}
+
+func (r *memoryJobRepository) ListUnfinishedJobs(ctx context.Context) ([]*JobRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var jobs []*JobRecord
+	for _, job := range r.jobs {
+		if job.Unfinished() {
+			jobs = append(jobs, &job)
+		}
+	}
+
+	slices.SortFunc(jobs, func(a, b *JobRecord) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return jobs, nil
+}
+
+func (r *memoryJobRepository) DeleteFinishedJobs(ctx context.Context, before time.Time) (int, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, job := range r.jobs {
+		if !job.Unfinished() && job.FinishedAt.Before(before) {
+			delete(r.jobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CheckHealth always succeeds, since the jobs are kept in memory.
+func (r *memoryJobRepository) CheckHealth(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Close does nothing, since the jobs are kept in memory.
+func (r *memoryJobRepository) Close() error {
+	return nil
+}
+
+func (r *memoryJobRepository) ListRecentJobs(ctx context.Context, tenantID string, limit int) ([]*JobRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var jobs []*JobRecord
+	for _, job := range r.jobs {
+		if job.TenantID == tenantID {
+			jobs = append(jobs, &job)
+		}
+	}
+
+	slices.SortFunc(jobs, func(a, b *JobRecord) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return jobs[:min(len(jobs), max(limit, 0))], nil
+}
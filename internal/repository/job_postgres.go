@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	// Registers the "pgx" database/sql driver
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// postgresDriver is the database/sql driver name registered by pgx.
+	postgresDriver = "pgx"
+
+	// jobStoreConnectTimeout bounds connecting to the database and preparing its schema at startup.
+	jobStoreConnectTimeout = 10 * time.Second
+)
+
+// jobSchema creates the jobs table on first use. Matrices are stored as JSON arrays of rows, and tags as JSON objects.
+const jobSchema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id             TEXT PRIMARY KEY,
+	operation      TEXT NOT NULL,
+	file_path      TEXT NOT NULL,
+	input          TEXT,
+	status         TEXT NOT NULL,
+	result_matrix  TEXT,
+	result_scalar  TEXT NOT NULL,
+	input_rows     INTEGER NOT NULL,
+	input_cols     INTEGER NOT NULL,
+	input_checksum TEXT NOT NULL,
+	error_kind     TEXT NOT NULL,
+	error_message  TEXT NOT NULL,
+	created_at     TIMESTAMPTZ NOT NULL,
+	started_at     TIMESTAMPTZ,
+	finished_at    TIMESTAMPTZ,
+	callback_url   TEXT NOT NULL DEFAULT '',
+	tenant_id      TEXT NOT NULL DEFAULT '',
+	tags           TEXT,
+	input_scale    INTEGER NOT NULL DEFAULT 0,
+	result_scale   INTEGER NOT NULL DEFAULT 0
+);
+-- Tables created before webhooks, tenants, tags and decimal matrices were supported lack their columns
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS callback_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS tags TEXT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS input_scale INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS result_scale INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS jobs_unfinished ON jobs (created_at) WHERE status IN ('queued', 'running');
+CREATE INDEX IF NOT EXISTS jobs_tenant_created ON jobs (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS jobs_finished ON jobs (finished_at) WHERE finished_at IS NOT NULL`
+
+const jobColumns = `id, operation, file_path, input, status, result_matrix, result_scalar,
+	input_rows, input_cols, input_checksum, error_kind, error_message, created_at, started_at, finished_at, callback_url, tenant_id, tags,
+	input_scale, result_scale`
+
+const saveJobQuery = `
+INSERT INTO jobs (` + jobColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+ON CONFLICT (id) DO UPDATE SET
+	input = EXCLUDED.input,
+	input_scale = EXCLUDED.input_scale,
+	status = EXCLUDED.status,
+	result_matrix = EXCLUDED.result_matrix,
+	result_scale = EXCLUDED.result_scale,
+	result_scalar = EXCLUDED.result_scalar,
+	input_rows = EXCLUDED.input_rows,
+	input_cols = EXCLUDED.input_cols,
+	input_checksum = EXCLUDED.input_checksum,
+	error_kind = EXCLUDED.error_kind,
+	error_message = EXCLUDED.error_message,
+	started_at = EXCLUDED.started_at,
+	finished_at = EXCLUDED.finished_at`
+
+const getJobQuery = `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+const listUnfinishedJobsQuery = `SELECT ` + jobColumns + ` FROM jobs
+WHERE status IN ('queued', 'running') ORDER BY created_at`
+
+const listRecentJobsQuery = `SELECT ` + jobColumns + ` FROM jobs
+WHERE tenant_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+// deleteFinishedJobsQuery deletes the jobs finished before $1; unfinished jobs have no finished_at, so they are kept.
+const deleteFinishedJobsQuery = `DELETE FROM jobs WHERE finished_at < $1`
+
+type postgresJobRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresJobRepository creates a new instance of JobRepositoryInterface backed by PostgreSQL.
+// It connects to databaseURL and creates the jobs table if needed, so job state survives restarts.
+func NewPostgresJobRepository(databaseURL string) (JobRepositoryInterface, error) {
+	db, err := sql.Open(postgresDriver, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job database: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jobStoreConnectTimeout)
+	defer cancel()
+
+	repo, err := newPostgresJobRepository(ctx, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return repo, nil
+}
+
+// newPostgresJobRepository prepares the schema on an open database.
+func newPostgresJobRepository(ctx context.Context, db *sql.DB) (*postgresJobRepository, error) {
+	if _, err := db.ExecContext(ctx, jobSchema); err != nil {
+		return nil, fmt.Errorf("failed to create jobs table: %w", err)
+	}
+	return &postgresJobRepository{db: db}, nil
+}
+
+func (r *postgresJobRepository) SaveJob(ctx context.Context, job *JobRecord) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	input, err := marshalMatrix(job.Input)
+	if err != nil {
+		return err
+	}
+	resultMatrix, err := marshalMatrix(job.ResultMatrix)
+	if err != nil {
+		return err
+	}
+	tags, err := marshalTags(job.Tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, saveJobQuery,
+		job.ID, job.Operation, job.FilePath, input, job.Status, resultMatrix, job.ResultScalar,
+		job.InputRows, job.InputCols, job.InputChecksum, job.ErrorKind, job.ErrorMessage,
+		job.CreatedAt, nullTime(job.StartedAt), nullTime(job.FinishedAt), job.CallbackURL, job.TenantID, tags,
+		job.InputScale, job.ResultScale)
+	if err != nil {
+		return jobStoreError(ctx, "failed to save job", err)
+	}
+	return nil
+}
+
+func (r *postgresJobRepository) GetJob(ctx context.Context, id string) (*JobRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, getJobQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: job not found: %s", apperrors.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, jobStoreError(ctx, "failed to load job", err)
+	}
+	return job, nil
+}
+
+func (r *postgresJobRepository) ListUnfinishedJobs(ctx context.Context) ([]*JobRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.queryJobs(ctx, listUnfinishedJobsQuery)
+}
+
+func (r *postgresJobRepository) CheckHealth(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := r.db.PingContext(ctx); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("%w: job database is unreachable: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	return nil
+}
+
+func (r *postgresJobRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *postgresJobRepository) ListRecentJobs(ctx context.Context, tenantID string, limit int) ([]*JobRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.queryJobs(ctx, listRecentJobsQuery, tenantID, max(limit, 0))
+}
+
+func (r *postgresJobRepository) DeleteFinishedJobs(ctx context.Context, before time.Time) (int, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.ExecContext(ctx, deleteFinishedJobsQuery, before)
+	if err != nil {
+		return 0, jobStoreError(ctx, "failed to delete finished jobs", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, jobStoreError(ctx, "failed to delete finished jobs", err)
+	}
+	return int(deleted), nil
+}
+
+// queryJobs runs a query selecting jobColumns and loads every job it returns.
+func (r *postgresJobRepository) queryJobs(ctx context.Context, query string, args ...any) ([]*JobRecord, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, jobStoreError(ctx, "failed to list jobs", err)
+	}
+	defer rows.Close()
+
+	var jobs []*JobRecord
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, jobStoreError(ctx, "failed to load job", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, jobStoreError(ctx, "failed to list jobs", err)
+	}
+	return jobs, nil
+}
+
+// rowScanner is the common part of *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (*JobRecord, error) {
+	job := &JobRecord{}
+	var input, resultMatrix, tags sql.NullString
+	var startedAt, finishedAt sql.NullTime
+
+	err := row.Scan(&job.ID, &job.Operation, &job.FilePath, &input, &job.Status, &resultMatrix, &job.ResultScalar,
+		&job.InputRows, &job.InputCols, &job.InputChecksum, &job.ErrorKind, &job.ErrorMessage,
+		&job.CreatedAt, &startedAt, &finishedAt, &job.CallbackURL, &job.TenantID, &tags,
+		&job.InputScale, &job.ResultScale)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Input, err = unmarshalMatrix(input); err != nil {
+		return nil, err
+	}
+	if job.ResultMatrix, err = unmarshalMatrix(resultMatrix); err != nil {
+		return nil, err
+	}
+	if job.Tags, err = unmarshalTags(tags); err != nil {
+		return nil, err
+	}
+	job.StartedAt = startedAt.Time
+	job.FinishedAt = finishedAt.Time
+	return job, nil
+}
+
+// marshalMatrix encodes a matrix as a JSON column value; nil matrices are stored as NULL.
+func marshalMatrix(data [][]int64) (sql.NullString, error) {
+	if data == nil {
+		return sql.NullString{}, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode matrix: %w", err)
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+func unmarshalMatrix(column sql.NullString) ([][]int64, error) {
+	if !column.Valid {
+		return nil, nil
+	}
+
+	var data [][]int64
+	if err := json.Unmarshal([]byte(column.String), &data); err != nil {
+		return nil, fmt.Errorf("failed to decode stored matrix: %w", err)
+	}
+	return data, nil
+}
+
+// marshalTags encodes tags as a JSON column value; jobs without tags store NULL.
+func marshalTags(tags map[string]string) (sql.NullString, error) {
+	if len(tags) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode tags: %w", err)
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+func unmarshalTags(column sql.NullString) (map[string]string, error) {
+	if !column.Valid {
+		return nil, nil
+	}
+
+	var tags map[string]string
+	if err := json.Unmarshal([]byte(column.String), &tags); err != nil {
+		return nil, fmt.Errorf("failed to decode stored tags: %w", err)
+	}
+	return tags, nil
+}
+
+// nullTime stores the zero time, which marks a job step that has not happened yet, as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// jobStoreError classifies a database failure. Cancellation is passed through unchanged,
+// while other failures mean the store is unavailable and the request may be retried.
+func jobStoreError(ctx context.Context, action string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return fmt.Errorf("%w: %s: %v", apperrors.ErrServiceUnavailable, action, err)
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newTestPostgresJobRepository returns a repository on a mocked database whose schema has been prepared.
+func newTestPostgresJobRepository(t *testing.T) (*postgresJobRepository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS jobs")).WillReturnResult(sqlmock.NewResult(0, 0))
+	repo, err := newPostgresJobRepository(context.Background(), db)
+	assert.NoError(t, err)
+	return repo, mock
+}
+
+func jobRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "operation", "file_path", "input", "status", "result_matrix", "result_scalar",
+		"input_rows", "input_cols", "input_checksum", "error_kind", "error_message", "created_at", "started_at", "finished_at", "callback_url", "tenant_id", "tags",
+		"input_scale", "result_scale"})
+}
+
+func TestPostgresJobRepository_SaveJob(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("upserts the job with matrices as JSON", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs")).
+			WithArgs("abc", "invert", "", sql.NullString{String: "[[1,2],[3,4]]", Valid: true}, JobStatusQueued,
+				sql.NullString{}, "", 0, 0, "", "", "", createdAt, sql.NullTime{}, sql.NullTime{}, "https://example.com/hook", "acme",
+				sql.NullString{String: `{"season":"2026"}`, Valid: true}, 0, 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SaveJob(context.Background(), &JobRecord{
+			ID: "abc", Operation: "invert", Input: [][]int64{{1, 2}, {3, 4}}, Status: JobStatusQueued, CreatedAt: createdAt,
+			CallbackURL: "https://example.com/hook", TenantID: "acme", Tags: map[string]string{"season": "2026"},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("stores the scale of decimal matrices", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs")).
+			WithArgs("abc", "echo", "", sql.NullString{String: "[[15,2]]", Valid: true}, JobStatusSucceeded,
+				sql.NullString{String: "[[15,2]]", Valid: true}, "", 0, 0, "", "", "", createdAt, sql.NullTime{}, sql.NullTime{},
+				"", "", sql.NullString{}, 1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SaveJob(context.Background(), &JobRecord{
+			ID: "abc", Operation: "echo", Input: [][]int64{{15, 2}}, InputScale: 1, Status: JobStatusSucceeded,
+			ResultMatrix: [][]int64{{15, 2}}, ResultScale: 1, CreatedAt: createdAt,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("database failure is reported as unavailable", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs")).WillReturnError(errors.New("connection refused"))
+
+		err := repo.SaveJob(context.Background(), &JobRecord{ID: "abc", Status: JobStatusQueued, CreatedAt: createdAt})
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+}
+
+func TestPostgresJobRepository_GetJob(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	finishedAt := createdAt.Add(time.Second)
+
+	t.Run("loads a finished job", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM jobs WHERE id = $1")).WithArgs("abc").
+			WillReturnRows(jobRows().AddRow("abc", "invert", "testdata/matrix1.csv", nil, JobStatusSucceeded,
+				"[[1,3],[2,4]]", "", 2, 2, "sum", "", "", createdAt, createdAt, finishedAt, "", "acme", `{"season":"2026"}`, 0, 0))
+
+		got, err := repo.GetJob(context.Background(), "abc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &JobRecord{
+			ID: "abc", Operation: "invert", FilePath: "testdata/matrix1.csv", Status: JobStatusSucceeded,
+			ResultMatrix: [][]int64{{1, 3}, {2, 4}}, InputRows: 2, InputCols: 2, InputChecksum: "sum",
+			CreatedAt: createdAt, StartedAt: createdAt, FinishedAt: finishedAt, TenantID: "acme",
+			Tags: map[string]string{"season": "2026"},
+		}, got)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM jobs WHERE id = $1")).WithArgs("missing").WillReturnRows(jobRows())
+
+		got, err := repo.GetJob(context.Background(), "missing")
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, got)
+	})
+
+	t.Run("corrupt matrix column", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM jobs WHERE id = $1")).WithArgs("abc").
+			WillReturnRows(jobRows().AddRow("abc", "invert", "", "[[1,", JobStatusQueued,
+				nil, "", 0, 0, "", "", "", createdAt, nil, nil, "", "", nil, 0, 0))
+
+		got, err := repo.GetJob(context.Background(), "abc")
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.Nil(t, got)
+	})
+}
+
+func TestPostgresJobRepository_ListUnfinishedJobs(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	repo, mock := newTestPostgresJobRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE status IN ('queued', 'running') ORDER BY created_at")).
+		WillReturnRows(jobRows().
+			AddRow("a", "sum", "testdata/matrix1.csv", nil, JobStatusQueued, nil, "", 0, 0, "", "", "", createdAt, nil, nil, "", "", nil, 0, 0).
+			AddRow("b", "invert", "", "[[1]]", JobStatusRunning, nil, "", 0, 0, "", "", "", createdAt, createdAt, nil, "https://example.com/hook", "", nil, 0, 0))
+
+	got, err := repo.ListUnfinishedJobs(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*JobRecord{
+		{ID: "a", Operation: "sum", FilePath: "testdata/matrix1.csv", Status: JobStatusQueued, CreatedAt: createdAt},
+		{ID: "b", Operation: "invert", Input: [][]int64{{1}}, Status: JobStatusRunning, CreatedAt: createdAt, StartedAt: createdAt,
+			CallbackURL: "https://example.com/hook"},
+	}, got)
+}
+
+func TestPostgresJobRepository_ListRecentJobs(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("newest jobs of the tenant", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE tenant_id = $1 ORDER BY created_at DESC LIMIT $2")).
+			WithArgs("acme", 20).
+			WillReturnRows(jobRows().
+				AddRow("a", "sum", "testdata/matrix1.csv", nil, JobStatusSucceeded, nil, "10", 2, 2, "abc", "", "", createdAt, createdAt, createdAt, "", "acme", nil, 0, 0))
+
+		got, err := repo.ListRecentJobs(context.Background(), "acme", 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*JobRecord{
+			{ID: "a", Operation: "sum", FilePath: "testdata/matrix1.csv", Status: JobStatusSucceeded, ResultScalar: "10",
+				InputRows: 2, InputCols: 2, InputChecksum: "abc", CreatedAt: createdAt, StartedAt: createdAt, FinishedAt: createdAt,
+				TenantID: "acme"},
+		}, got)
+	})
+
+	t.Run("query failure", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM jobs")).WillReturnError(errors.New("connection refused"))
+
+		_, err := repo.ListRecentJobs(context.Background(), "", 20)
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+}
+
+func TestPostgresJobRepository_DeleteFinishedJobs(t *testing.T) {
+	before := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("jobs finished before the time", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM jobs WHERE finished_at < $1")).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		got, err := repo.DeleteFinishedJobs(context.Background(), before)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, got)
+	})
+
+	t.Run("query failure", func(t *testing.T) {
+		repo, mock := newTestPostgresJobRepository(t)
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM jobs")).WillReturnError(errors.New("connection refused"))
+
+		_, err := repo.DeleteFinishedJobs(context.Background(), before)
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+}
+
+func TestPostgresJobRepository_CheckHealth(t *testing.T) {
+	tests := []struct {
+		name    string
+		pingErr error
+		wantErr error
+	}{
+		{name: "reachable"},
+		{name: "unreachable", pingErr: errors.New("connection refused"), wantErr: apperrors.ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			assert.NoError(t, err)
+			defer db.Close()
+			mock.ExpectPing().WillReturnError(tt.pingErr)
+
+			err = (&postgresJobRepository{db: db}).CheckHealth(context.Background())
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresJobRepository_Close(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	mock.ExpectClose()
+
+	assert.NoError(t, (&postgresJobRepository{db: db}).Close())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
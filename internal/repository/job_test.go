@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestMemoryJobRepository_SaveJob(t *testing.T) {
+	repo := NewJobRepository()
+	job := &JobRecord{ID: "abc", Operation: "sum", Status: JobStatusQueued, CreatedAt: time.Now()}
+
+	err := repo.SaveJob(context.Background(), job)
+	assert.NoError(t, err)
+
+	// Changes to the saved record are not visible until it is saved again
+	job.Status = JobStatusRunning
+	got, err := repo.GetJob(context.Background(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, JobStatusQueued, got.Status)
+
+	err = repo.SaveJob(context.Background(), job)
+	assert.NoError(t, err)
+	got, err = repo.GetJob(context.Background(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, job, got)
+}
+
+func TestMemoryJobRepository_GetJob(t *testing.T) {
+	t.Run("unknown job", func(t *testing.T) {
+		got, err := NewJobRepository().GetJob(context.Background(), "missing")
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, got)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		got, err := NewJobRepository().GetJob(ctx, "abc")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, got)
+	})
+}
+
+func TestMemoryJobRepository_ListUnfinishedJobs(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := NewJobRepository()
+	for _, job := range []*JobRecord{
+		{ID: "running", Status: JobStatusRunning, CreatedAt: createdAt.Add(2 * time.Second)},
+		{ID: "succeeded", Status: JobStatusSucceeded, CreatedAt: createdAt},
+		{ID: "queued", Status: JobStatusQueued, CreatedAt: createdAt.Add(time.Second)},
+		{ID: "failed", Status: JobStatusFailed, CreatedAt: createdAt},
+	} {
+		assert.NoError(t, repo.SaveJob(context.Background(), job))
+	}
+
+	got, err := repo.ListUnfinishedJobs(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "queued", got[0].ID)
+		assert.Equal(t, "running", got[1].ID)
+	}
+}
+
+func TestMemoryJobRepository_ListRecentJobs(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := NewJobRepository()
+	for _, job := range []*JobRecord{
+		{ID: "old", Status: JobStatusSucceeded, CreatedAt: createdAt},
+		{ID: "new", Status: JobStatusQueued, CreatedAt: createdAt.Add(2 * time.Second)},
+		{ID: "middle", Status: JobStatusFailed, CreatedAt: createdAt.Add(time.Second)},
+		{ID: "acme", Status: JobStatusSucceeded, CreatedAt: createdAt, TenantID: "acme"},
+	} {
+		assert.NoError(t, repo.SaveJob(context.Background(), job))
+	}
+
+	tests := []struct {
+		name     string
+		tenantID string
+		limit    int
+		want     []string
+	}{
+		{name: "newest first", limit: 10, want: []string{"new", "middle", "old"}},
+		{name: "limited", limit: 2, want: []string{"new", "middle"}},
+		{name: "tenant jobs", tenantID: "acme", limit: 10, want: []string{"acme"}},
+		{name: "unknown tenant", tenantID: "globex", limit: 10, want: []string{}},
+		{name: "zero limit", limit: 0, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListRecentJobs(context.Background(), tt.tenantID, tt.limit)
+
+			assert.NoError(t, err)
+			ids := []string{}
+			for _, job := range got {
+				ids = append(ids, job.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestMemoryJobRepository_DeleteFinishedJobs(t *testing.T) {
+	finishedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := NewJobRepository()
+	for _, job := range []*JobRecord{
+		{ID: "old", Status: JobStatusSucceeded, FinishedAt: finishedAt},
+		{ID: "old-failed", Status: JobStatusFailed, FinishedAt: finishedAt.Add(time.Minute)},
+		{ID: "recent", Status: JobStatusSucceeded, FinishedAt: finishedAt.Add(time.Hour)},
+		{ID: "queued", Status: JobStatusQueued},
+		{ID: "running", Status: JobStatusRunning},
+	} {
+		assert.NoError(t, repo.SaveJob(context.Background(), job))
+	}
+
+	got, err := repo.DeleteFinishedJobs(context.Background(), finishedAt.Add(time.Hour))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got)
+	for _, id := range []string{"old", "old-failed"} {
+		_, err = repo.GetJob(context.Background(), id)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound, id)
+	}
+	for _, id := range []string{"recent", "queued", "running"} {
+		_, err = repo.GetJob(context.Background(), id)
+		assert.NoError(t, err, id)
+	}
+}
+
+func TestMemoryJobRepository_CheckHealth(t *testing.T) {
+	assert.NoError(t, NewJobRepository().CheckHealth(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, NewJobRepository().CheckHealth(ctx), context.Canceled)
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package repository
+
+import "io/fs"
+
+// linkCount returns the number of hard links to the file described by info, or 0 when it is unknown,
+// as it always is on platforms without Unix file metadata.
+func linkCount(info fs.FileInfo) uint64 {
+	return 0
+}
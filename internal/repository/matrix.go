@@ -1,88 +1,506 @@
 package repository
 
 import (
+	"bufio"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
+	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	leaguematrix "github.com/matsuboshi/league-matrix-app"
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
 )
 
 const (
-	// maxFileSizeBytes defines the maximum allowed file size in bytes (1KB).
-	// This prevents denial of service attacks from extremely large files.
-	// Maximum theoretical size for 10x10 matrix with 7-digit numbers is ~800 bytes.
-	maxFileSizeBytes = 1024 // 1KB
+	// maxListedFiles bounds the number of files returned by ListFiles.
+	maxListedFiles = 1000
+
+	// byteOrderMark is the UTF-8 byte order mark ignored at the start of CSV files.
+	byteOrderMark = "\ufeff"
 )
 
 // MatrixRepositoryInterface defines the contract for accessing matrix data from external sources.
+// Files are read at their current version unless the path is pinned to a prior one, as in testdata/league.csv@v3.
 type MatrixRepositoryInterface interface {
 	// GetFileContent reads and parses a CSV file containing matrix data.
 	// It returns the raw string content of the file organized as a 2D slice.
 	GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error)
+
+	// StreamFileContent reads a CSV file containing matrix data row by row, passing each record
+	// to handleRow as soon as it is parsed instead of buffering the whole file.
+	// Reading stops at the first error returned by handleRow or when the context is cancelled.
+	StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error
+
+	// ReadFile returns the raw bytes of a matrix file, under the same access and size checks as GetFileContent.
+	// It serves callers that inspect the layout of the file, such as blank lines, rather than its records.
+	ReadFile(ctx context.Context, filePath string) ([]byte, error)
+
+	// FileModTime returns the time the matrix file at filePath was last modified, under the same access check
+	// as GetFileContent. It returns the zero time for files that carry none, such as the embedded samples.
+	FileModTime(ctx context.Context, filePath string) (time.Time, error)
+
+	// StatFile returns the modification time and size of the matrix file at filePath, under the same access check
+	// as GetFileContent, so callers can tell whether it changed without reading it. The modification time is zero
+	// for files that carry none, such as the embedded samples, and the size is -1 when it is unknown.
+	StatFile(ctx context.Context, filePath string) (FileStat, error)
+
+	// StreamContent reads matrix data in CSV format from r row by row, like StreamFileContent does for files.
+	// It serves matrices that do not come from a file path, such as local files and pipes given to the CLI,
+	// and enforces the same size limit as matrix files.
+	// The size limit of the tenant carried by ctx applies when it is lower than the service-wide one.
+	StreamContent(ctx context.Context, r io.Reader, handleRow RowHandler) error
+
+	// SaveFileContent writes matrix data to a new CSV file, creating missing parent directories.
+	// Existing files are never overwritten; saving to a path that already exists fails with ErrConflict.
+	// Files that would take the tenant carried by ctx over its storage limit fail with ErrPayloadTooLarge.
+	SaveFileContent(ctx context.Context, filePath string, content *MatrixFileContent) error
+
+	// ListFiles returns the paths of the CSV matrix files the tenant carried by ctx may read, sorted.
+	// Without a tenant, the files of the data directory outside the tenants' directories are listed,
+	// together with the embedded samples missing on disk. At most 1000 files are returned.
+	ListFiles(ctx context.Context) ([]string, error)
+
+	// CheckHealth reports whether matrix files can be read, returning ErrServiceUnavailable when they cannot.
+	CheckHealth(ctx context.Context) error
 }
 
+// maxFileSizeKey is the context key of the file size limit set with WithMaxFileSize.
+type maxFileSizeKey struct{}
+
+// WithMaxFileSize returns a copy of ctx in which matrix files and streams are read under limit bytes
+// instead of the service-wide limit, such as for operations that stream rows without holding the matrix.
+// Lower limits of the tenant carried by ctx still apply.
+func WithMaxFileSize(ctx context.Context, limit int64) context.Context {
+	return context.WithValue(ctx, maxFileSizeKey{}, limit)
+}
+
+// RowHandler processes a single record read from a matrix file.
+// The row slice is reused between calls, so handlers must copy it if they need to retain it.
+type RowHandler func(row []string) error
+
 // MatrixFileContent represents the raw content read from a matrix file.
 // Content contains the parsed CSV data as rows and columns of strings.
 type MatrixFileContent struct {
 	Content [][]string
 }
 
-type matrixRepository struct{}
+// FileStat describes a version of a matrix file without its content.
+type FileStat struct {
+	ModTime time.Time
+	Size    int64
+}
+
+type matrixRepository struct {
+	// fallback serves files that are missing on disk; nil disables the fallback.
+	fallback fs.FS
+}
 
 // NewMatrixRepository creates a new instance of MatrixRepositoryInterface.
-// It returns a repository implementation that can read matrix data from CSV files.
+// It returns a repository implementation that can read matrix data from CSV files,
+// falling back to the sample matrices embedded in the binary when a file is missing on disk.
 func NewMatrixRepository() MatrixRepositoryInterface {
-	return &matrixRepository{}
+	return &matrixRepository{
+		fallback: leaguematrix.Samples,
+	}
 }
 
 func (r *matrixRepository) GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error) {
+	content := &MatrixFileContent{}
+	err := r.StreamFileContent(ctx, filePath, func(row []string) error {
+		content.Content = append(content.Content, append([]string(nil), row...))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func (r *matrixRepository) StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	file, err := r.openChecked(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return streamCSV(ctx, file, filePath, handleRow)
+}
+
+func (r *matrixRepository) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	file, err := r.openChecked(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// The file may grow after its size was checked, so never read past the limit
+	data, err := io.ReadAll(profile.Reader(ctx, profile.Read, io.LimitReader(file, maxFileSize(ctx))))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read file",
+			"error", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+func (r *matrixRepository) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	stat, err := r.StatFile(ctx, filePath)
+	return stat.ModTime, err
+}
+
+func (r *matrixRepository) StatFile(ctx context.Context, filePath string) (FileStat, error) {
 	// Check if context is already cancelled
 	if err := ctx.Err(); err != nil {
+		return FileStat{}, err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return FileStat{}, err
+	}
+
+	file, err := r.openVersion(ctx, filePath)
+	if isVersionError(err) {
+		return FileStat{}, err
+	}
+	if err != nil {
+		return FileStat{}, fmt.Errorf("%w: failed to open file: %v", apperrors.ErrNotFound, err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return FileStat{}, fmt.Errorf("%w: failed to get file info: %v", apperrors.ErrNotFound, err)
+	}
+	return FileStat{ModTime: fileInfo.ModTime(), Size: fileInfo.Size()}, nil
+}
+
+// openChecked opens the matrix file at filePath once it has checked that the tenant carried by ctx
+// may read it and that it is within the size limit.
+func (r *matrixRepository) openChecked(ctx context.Context, filePath string) (fs.File, error) {
+	defer profile.Start(ctx, profile.Read)()
+
+	// Never open another tenant's file, whatever the caller validated
+	if err := checkTenantAccess(ctx, filePath); err != nil {
 		return nil, err
 	}
 
 	// Open the CSV file
-	file, err := os.Open(filePath)
+	file, err := r.openVersion(ctx, filePath)
+	if isVersionError(err) {
+		return nil, err
+	}
 	if err != nil {
-		slog.Error("failed to open file",
-			"file_path", filePath,
+		slog.ErrorContext(ctx, "failed to open file",
 			"error", err)
 		return nil, fmt.Errorf("%w: failed to open file: %v", apperrors.ErrNotFound, err)
 	}
-	defer file.Close()
 
 	// Get file info to check size
 	fileInfo, err := file.Stat()
 	if err != nil {
-		slog.Error("failed to get file info",
-			"file_path", filePath,
+		file.Close()
+		slog.ErrorContext(ctx, "failed to get file info",
 			"error", err)
 		return nil, fmt.Errorf("%w: failed to get file info: %v", apperrors.ErrNotFound, err)
 	}
 
 	// Check file size BEFORE reading to prevent DoS attacks
-	if fileInfo.Size() > maxFileSizeBytes {
+	if limit := maxFileSize(ctx); fileInfo.Size() > limit {
+		file.Close()
 		return nil, fmt.Errorf("%w: file too large: %d bytes (maximum: %d bytes)",
-			apperrors.ErrPayloadTooLarge, fileInfo.Size(), maxFileSizeBytes)
+			apperrors.ErrFileTooLarge, fileInfo.Size(), limit)
+	}
+	return file, nil
+}
+
+func (r *matrixRepository) StreamContent(ctx context.Context, reader io.Reader, handleRow RowHandler) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return streamLimited(ctx, reader, "", handleRow)
+}
+
+// streamLimited parses CSV records from r like streamCSV, failing with ErrPayloadTooLarge once r holds more
+// than the size limit. source names the input in log messages and is empty for streams that are not files.
+func streamLimited(ctx context.Context, r io.Reader, source string, handleRow RowHandler) error {
+	// The size of a stream is unknown up front, so read one byte past the limit to detect oversized input
+	limit := maxFileSize(ctx)
+	limited := &io.LimitedReader{R: r, N: limit + 1}
+	err := streamCSV(ctx, limited, source, handleRow)
+	// A truncated final row may also fail to parse, so the size error takes precedence
+	if limited.N == 0 && ctx.Err() == nil {
+		return fmt.Errorf("%w: input too large (maximum: %d bytes)", apperrors.ErrFileTooLarge, limit)
+	}
+	return err
+}
+
+// readLimited reads r whole, failing with ErrPayloadTooLarge once r holds more than the size limit.
+// It serves remote files, whose announced size may be missing.
+func readLimited(ctx context.Context, r io.Reader) ([]byte, error) {
+	// Files sent without a length are only known to be too large once the limit has been read past
+	limit := maxFileSize(ctx)
+	data, err := io.ReadAll(profile.Reader(ctx, profile.Read, io.LimitReader(r, limit+1)))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: file too large (maximum: %d bytes)", apperrors.ErrFileTooLarge, limit)
+	}
+	return data, nil
+}
+
+// checkAnnouncedSize fails with ErrPayloadTooLarge when size, the length a remote file is announced with,
+// is past the size limit. A negative size is unknown and passes.
+func checkAnnouncedSize(ctx context.Context, size int64) error {
+	if limit := maxFileSize(ctx); size > limit {
+		return fmt.Errorf("%w: file too large: %d bytes (maximum: %d bytes)", apperrors.ErrFileTooLarge, size, limit)
+	}
+	return nil
+}
+
+// responseStat describes the remote file resp, a response to a HEAD request, by its Last-Modified header and
+// its announced length. Files served without a valid Last-Modified header carry no modification time.
+func responseStat(resp *http.Response) FileStat {
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return FileStat{ModTime: modTime, Size: resp.ContentLength}
+}
+
+// streamCSV parses CSV records from r and passes them to handleRow one at a time.
+// source names the input in log messages and is empty for streams that are not files.
+func streamCSV(ctx context.Context, r io.Reader, source string, handleRow RowHandler) error {
+	// Create a new CSV reader that reuses its record slice between rows
+	options := parsing.FromContext(ctx)
+	reader := newCSVReader(profile.Reader(ctx, profile.Read, r), options)
+	reader.ReuseRecord = true
+
+	// Read records one at a time so callers can reject the matrix without reading the rest of the file
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		stopParse := profile.Start(ctx, profile.Parse)
+		record, err := reader.Read()
+		stopParse()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to parse CSV",
+				"file_path", source,
+				"error", err)
+			return fmt.Errorf("%w: failed to read CSV file: %v", apperrors.ErrUnprocessableEntity, err)
+		}
+
+		if !options.CleanRow(record) {
+			continue
+		}
+		if err := handleRow(record); err != nil {
+			return err
+		}
+	}
+}
+
+// newCSVReader returns a CSV reader of the matrix data in r, read as described by options.
+func newCSVReader(r io.Reader, options parsing.Options) *csv.Reader {
+	reader := csv.NewReader(skipBOM(r))
+	if options.Lenient {
+		// Quoted values preceded by spaces are accepted, and rows of different lengths are left to validation,
+		// as blank rows are only skipped after they are read
+		reader.TrimLeadingSpace = true
+		reader.FieldsPerRecord = -1
+	}
+	return reader
+}
+
+// skipBOM returns a reader of r without the leading UTF-8 byte order mark that spreadsheets
+// write at the start of CSV files, which would otherwise end up in the first value.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if prefix, err := br.Peek(len(byteOrderMark)); err == nil && string(prefix) == byteOrderMark {
+		_, _ = br.Discard(len(byteOrderMark))
+	}
+	return br
+}
+
+// open opens filePath on disk, or in the embedded fallback FS when it does not exist on disk.
+func (r *matrixRepository) open(ctx context.Context, filePath string) (fs.File, error) {
+	file, err := os.Open(filePath)
+	if err == nil {
+		return file, nil
+	}
+	if r.fallback == nil || !errors.Is(err, fs.ErrNotExist) || !fs.ValidPath(filePath) {
+		return nil, err
+	}
+
+	embedded, fallbackErr := r.fallback.Open(filePath)
+	if fallbackErr != nil {
+		// Report the on-disk error, which names the path the caller asked for
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "serving embedded sample file", "file_path", filePath)
+	return embedded, nil
+}
+
+// openVersion opens the content referred to by ref, the path of a matrix file optionally pinned to one of its
+// versions, as in testdata/league.csv@v3.
+func (r *matrixRepository) openVersion(ctx context.Context, ref string) (fs.File, error) {
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+
+	filePath, err := resolveVersion(ref)
+	if err != nil {
+		return nil, err
+	}
+	return r.open(ctx, filePath)
+}
+
+// isVersionError reports whether err was returned by openVersion for a version reference it could not resolve,
+// rather than by opening the file.
+func isVersionError(err error) bool {
+	return errors.Is(err, apperrors.ErrInvalidInput) || errors.Is(err, apperrors.ErrNotFound)
+}
+
+func (r *matrixRepository) SaveFileContent(ctx context.Context, filePath string, content *MatrixFileContent) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	// Create a new CSV reader
-	reader := csv.NewReader(file)
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return err
+	}
 
-	// Read all records from the CSV file
-	records, err := reader.ReadAll()
+	// Encode the matrix first, so its size is known before it counts against the tenant's storage
+	encoded, err := encodeCSV(content)
 	if err != nil {
-		slog.Error("failed to parse CSV",
-			"file_path", filePath,
+		return err
+	}
+	if err := checkStorageQuota(ctx, int64(len(encoded))); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create directory",
 			"error", err)
-		return nil, fmt.Errorf("%w: failed to read CSV file: %v", apperrors.ErrUnprocessableEntity, err)
+		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Return the matrix file content
-	return &MatrixFileContent{
-		Content: records,
-	}, nil
+	// O_EXCL makes the existence check and the creation atomic, so sample and uploaded files cannot be clobbered
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%w: file already exists: %s", apperrors.ErrConflict, filePath)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create file",
+			"error", err)
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	_, err = file.Write(encoded)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to write CSV",
+			"error", err)
+		// Do not leave a truncated matrix behind
+		_ = os.Remove(filePath)
+		return fmt.Errorf("failed to write CSV file: %w", err)
+	}
+
+	return nil
+}
+
+func (r *matrixRepository) ListFiles(ctx context.Context) ([]string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	root := config.CurrentLimits().DataDir
+	if t, ok := tenant.FromContext(ctx); ok {
+		root = t.DataDir()
+	}
+
+	seen := make(map[string]bool)
+	collect := func(path string, entry fs.DirEntry, err error) error {
+		// Unreadable entries and a missing root are skipped: the listing is best effort
+		if err != nil {
+			return nil
+		}
+		if len(seen) == maxListedFiles {
+			return fs.SkipAll
+		}
+		path = filepath.ToSlash(path)
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(path), ".csv") && tenant.CanAccess(ctx, path) {
+			seen[path] = true
+		}
+		return nil
+	}
+
+	if err := filepath.WalkDir(root, collect); err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	if r.fallback != nil {
+		if err := fs.WalkDir(r.fallback, root, collect); err != nil {
+			return nil, fmt.Errorf("failed to list embedded files: %w", err)
+		}
+	}
+
+	files := make([]string, 0, len(seen))
+	for path := range seen {
+		files = append(files, path)
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+func (r *matrixRepository) CheckHealth(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(config.CurrentLimits().DataDir)
+	if errors.Is(err, fs.ErrNotExist) && r.fallback != nil {
+		// Without a data directory on disk, the embedded samples are served
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: data directory is not readable: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	defer dir.Close()
+
+	if _, err := dir.ReadDir(1); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: data directory is not readable: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	return nil
 }
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+type s3MatrixRepository struct {
+	client *s3Client
+}
+
+// NewS3MatrixRepository creates a new instance of MatrixRepositoryInterface that reads matrix files from
+// the S3-compatible object storage described by config. Files are addressed as s3://bucket/key.csv, and tenants
+// may only read the objects below a prefix named after their ID. Object storage is read-only: matrix files
+// cannot be saved to it, and it is not listed.
+func NewS3MatrixRepository(config S3Config) MatrixRepositoryInterface {
+	return &s3MatrixRepository{
+		client: newS3Client(config),
+	}
+}
+
+func (r *s3MatrixRepository) GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error) {
+	content := &MatrixFileContent{}
+	err := r.StreamFileContent(ctx, filePath, func(row []string) error {
+		content.Content = append(content.Content, append([]string(nil), row...))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func (r *s3MatrixRepository) StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := r.openChecked(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return streamLimited(ctx, body, filePath, handleRow)
+}
+
+func (r *s3MatrixRepository) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	body, err := r.openChecked(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := readLimited(ctx, body)
+	if err != nil && !errors.Is(err, apperrors.ErrPayloadTooLarge) {
+		slog.ErrorContext(ctx, "failed to read object",
+			"error", err)
+		return nil, fmt.Errorf("%w: failed to read object: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	return data, err
+}
+
+func (r *s3MatrixRepository) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	stat, err := r.StatFile(ctx, filePath)
+	return stat.ModTime, err
+}
+
+func (r *s3MatrixRepository) StatFile(ctx context.Context, filePath string) (FileStat, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return FileStat{}, err
+	}
+
+	bucket, key, err := r.locate(ctx, filePath)
+	if err != nil {
+		return FileStat{}, err
+	}
+	resp, err := r.client.getObject(ctx, bucket, key, true)
+	if err != nil {
+		return FileStat{}, err
+	}
+	resp.Body.Close()
+
+	return responseStat(resp), nil
+}
+
+// openChecked returns the body of the object filePath addresses once it has checked that the tenant carried by ctx
+// may read it and that its announced size is within the size limit. The caller must close the body.
+func (r *s3MatrixRepository) openChecked(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	defer profile.Start(ctx, profile.Read)()
+
+	bucket, key, err := r.locate(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.getObject(ctx, bucket, key, false)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to open object",
+			"error", err)
+		return nil, err
+	}
+
+	// Check the announced size BEFORE reading to prevent DoS attacks; objects sent without one are limited as they are read
+	if err := checkAnnouncedSize(ctx, resp.ContentLength); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// locate returns the bucket and key of the object filePath addresses, once it has checked that the tenant
+// carried by ctx may read it.
+func (r *s3MatrixRepository) locate(ctx context.Context, filePath string) (string, string, error) {
+	bucket, key, ok := SplitS3URI(filePath)
+	if !ok || bucket == "" || key == "" {
+		return "", "", fmt.Errorf("%w: invalid object storage address: %q: expected s3://bucket/key.csv",
+			apperrors.ErrInvalidInput, filePath)
+	}
+
+	// Never read another tenant's object, whatever the caller validated
+	if !tenant.CanAccessObject(ctx, key) {
+		slog.WarnContext(ctx, "tenant object access denied")
+		audit.Deny(ctx, "object outside the tenant's prefix: "+filePath)
+		return "", "", fmt.Errorf("%w: file not found: %s", apperrors.ErrNotFound, filePath)
+	}
+	return bucket, key, nil
+}
+
+func (r *s3MatrixRepository) StreamContent(ctx context.Context, reader io.Reader, handleRow RowHandler) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return streamLimited(ctx, reader, "", handleRow)
+}
+
+// SaveFileContent always fails: results are written to object storage by exporting them, which replaces objects,
+// rather than saved as matrix files, which never replace one.
+func (r *s3MatrixRepository) SaveFileContent(ctx context.Context, filePath string, _ *MatrixFileContent) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: matrix files cannot be saved to object storage: %s: export the result instead",
+		apperrors.ErrInvalidInput, filePath)
+}
+
+// ListFiles returns no files: buckets are named by the paths of the files read from them, so there are none to list.
+func (r *s3MatrixRepository) ListFiles(ctx context.Context) ([]string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return []string{}, nil
+}
+
+// CheckHealth reports object storage as healthy: buckets are named by the paths of the files read from them,
+// so there is no bucket to check up front, and failing reads are reported by the requests making them.
+func (r *s3MatrixRepository) CheckHealth(ctx context.Context) error {
+	return ctx.Err()
+}
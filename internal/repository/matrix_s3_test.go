@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newObjectServer returns object storage serving objects by path, such as /reports/league.csv,
+// with their length unless chunked is set. Each object was last modified at modTime.
+func newObjectServer(t *testing.T, objects map[string]string, modTime time.Time, chunked bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+		if !chunked {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(content))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestS3MatrixRepository returns a repository reading the objects of server.
+func newTestS3MatrixRepository(server *httptest.Server) MatrixRepositoryInterface {
+	return NewS3MatrixRepository(S3Config{
+		Endpoint: server.URL,
+		Credentials: func(context.Context) (S3Credentials, error) {
+			return exampleCredentials, nil
+		},
+	})
+}
+
+func TestS3MatrixRepository_GetFileContent(t *testing.T) {
+	server := newObjectServer(t, map[string]string{
+		"/reports/league.csv":      "1,2\n3,4\n",
+		"/reports/acme/league.csv": "5,6\n",
+	}, time.Now(), false)
+	repo := newTestS3MatrixRepository(server)
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		filePath string
+		want     [][]string
+		wantErr  error
+	}{
+		{name: "object", ctx: context.Background(), filePath: "s3://reports/league.csv", want: [][]string{{"1", "2"}, {"3", "4"}}},
+		{name: "tenant object", ctx: acme, filePath: "s3://reports/acme/league.csv", want: [][]string{{"5", "6"}}},
+		{name: "object outside the tenant's prefix", ctx: acme, filePath: "s3://reports/league.csv", wantErr: apperrors.ErrNotFound},
+		{name: "missing object", ctx: context.Background(), filePath: "s3://reports/missing.csv", wantErr: apperrors.ErrNotFound},
+		{name: "missing key", ctx: context.Background(), filePath: "s3://reports", wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetFileContent(tt.ctx, tt.filePath)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Content)
+		})
+	}
+}
+
+func TestS3MatrixRepository_SizeLimit(t *testing.T) {
+	content := strings.Repeat("1,2,3\n", 10)
+	for _, chunked := range []bool{false, true} {
+		t.Run("chunked="+strconv.FormatBool(chunked), func(t *testing.T) {
+			server := newObjectServer(t, map[string]string{"/reports/large.csv": content}, time.Now(), chunked)
+			repo := newTestS3MatrixRepository(server)
+			// Objects are rejected by their announced size, or once read past the limit when they announce none
+			ctx := WithMaxFileSize(context.Background(), int64(len(content)-1))
+
+			err := repo.StreamFileContent(ctx, "s3://reports/large.csv", func([]string) error { return nil })
+			assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+
+			_, err = repo.ReadFile(ctx, "s3://reports/large.csv")
+			assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+
+			data, err := repo.ReadFile(context.Background(), "s3://reports/large.csv")
+			assert.NoError(t, err)
+			assert.Equal(t, content, string(data))
+		})
+	}
+}
+
+func TestS3MatrixRepository_FileModTime(t *testing.T) {
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := newObjectServer(t, map[string]string{"/reports/league.csv": "1,2\n"}, modTime, false)
+	repo := newTestS3MatrixRepository(server)
+
+	got, err := repo.FileModTime(context.Background(), "s3://reports/league.csv")
+	assert.NoError(t, err)
+	assert.True(t, modTime.Equal(got))
+
+	_, err = repo.FileModTime(context.Background(), "s3://reports/missing.csv")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestS3MatrixRepository_ReadOnly(t *testing.T) {
+	server := newObjectServer(t, map[string]string{}, time.Now(), false)
+	repo := newTestS3MatrixRepository(server)
+
+	err := repo.SaveFileContent(context.Background(), "s3://reports/result.csv", &MatrixFileContent{Content: [][]string{{"1"}}})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+	files, err := repo.ListFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	assert.NoError(t, repo.CheckHealth(context.Background()))
+}
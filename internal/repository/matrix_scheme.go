@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+type schemeMatrixRepository struct {
+	local   MatrixRepositoryInterface
+	schemes map[string]MatrixRepositoryInterface
+}
+
+// NewSchemeMatrixRepository creates a new instance of MatrixRepositoryInterface that serves each matrix file
+// from the backend of the scheme of its path: paths such as s3://bucket/league.csv are served by the repository
+// registered for their scheme in schemes, and plain paths by local. Paths with a scheme missing from schemes
+// are rejected as invalid input. Files are listed, streams read and health checked by local alone.
+func NewSchemeMatrixRepository(local MatrixRepositoryInterface, schemes map[string]MatrixRepositoryInterface) MatrixRepositoryInterface {
+	return &schemeMatrixRepository{
+		local:   local,
+		schemes: schemes,
+	}
+}
+
+func (r *schemeMatrixRepository) GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error) {
+	backend, err := r.backend(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return backend.GetFileContent(ctx, filePath)
+}
+
+func (r *schemeMatrixRepository) StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error {
+	backend, err := r.backend(filePath)
+	if err != nil {
+		return err
+	}
+	return backend.StreamFileContent(ctx, filePath, handleRow)
+}
+
+func (r *schemeMatrixRepository) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	backend, err := r.backend(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return backend.ReadFile(ctx, filePath)
+}
+
+func (r *schemeMatrixRepository) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	backend, err := r.backend(filePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return backend.FileModTime(ctx, filePath)
+}
+
+func (r *schemeMatrixRepository) StatFile(ctx context.Context, filePath string) (FileStat, error) {
+	backend, err := r.backend(filePath)
+	if err != nil {
+		return FileStat{}, err
+	}
+	return backend.StatFile(ctx, filePath)
+}
+
+func (r *schemeMatrixRepository) StreamContent(ctx context.Context, reader io.Reader, handleRow RowHandler) error {
+	return r.local.StreamContent(ctx, reader, handleRow)
+}
+
+func (r *schemeMatrixRepository) SaveFileContent(ctx context.Context, filePath string, content *MatrixFileContent) error {
+	backend, err := r.backend(filePath)
+	if err != nil {
+		return err
+	}
+	return backend.SaveFileContent(ctx, filePath, content)
+}
+
+func (r *schemeMatrixRepository) ListFiles(ctx context.Context) ([]string, error) {
+	return r.local.ListFiles(ctx)
+}
+
+func (r *schemeMatrixRepository) CheckHealth(ctx context.Context) error {
+	return r.local.CheckHealth(ctx)
+}
+
+// backend returns the repository serving filePath.
+func (r *schemeMatrixRepository) backend(filePath string) (MatrixRepositoryInterface, error) {
+	scheme, ok := FileScheme(filePath)
+	if !ok {
+		return r.local, nil
+	}
+	backend, ok := r.schemes[scheme]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported file scheme %q", apperrors.ErrInvalidInput, scheme)
+	}
+	return backend, nil
+}
+
+// FileScheme returns the scheme of filePath, such as s3 for s3://bucket/league.csv, and false for plain paths.
+func FileScheme(filePath string) (string, bool) {
+	scheme, _, ok := strings.Cut(filePath, "://")
+	// A plain path may hold :// past its first segment
+	if !ok || scheme == "" || strings.Contains(scheme, "/") {
+		return "", false
+	}
+	return scheme, true
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestSchemeMatrixRepository(t *testing.T) {
+	local := &flakyRepository{rows: [][]string{{"1"}}}
+	s3 := &flakyRepository{rows: [][]string{{"2"}}}
+	repo := NewSchemeMatrixRepository(local, map[string]MatrixRepositoryInterface{S3Scheme: s3})
+
+	tests := []struct {
+		name     string
+		filePath string
+		want     [][]string
+		wantErr  error
+	}{
+		{name: "plain path", filePath: "testdata/matrix1.csv", want: [][]string{{"1"}}},
+		{name: "plain path holding a scheme separator", filePath: "testdata/a://b.csv", want: [][]string{{"1"}}},
+		{name: "object storage", filePath: "s3://reports/league.csv", want: [][]string{{"2"}}},
+		{name: "unsupported scheme", filePath: "ftp://example.com/league.csv", wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetFileContent(context.Background(), tt.filePath)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Content)
+		})
+	}
+
+	// Listing and health checks only concern local files
+	callsBefore := s3.calls
+	_, _ = repo.ListFiles(context.Background())
+	_ = repo.CheckHealth(context.Background())
+	assert.Equal(t, callsBefore, s3.calls)
+}
+
+func TestFileScheme(t *testing.T) {
+	tests := []struct {
+		filePath   string
+		wantScheme string
+		wantOK     bool
+	}{
+		{filePath: "s3://reports/league.csv", wantScheme: "s3", wantOK: true},
+		{filePath: "https://example.com/league.csv", wantScheme: "https", wantOK: true},
+		{filePath: "testdata/matrix1.csv"},
+		{filePath: "testdata/a://b.csv"},
+		{filePath: "://league.csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			scheme, ok := FileScheme(tt.filePath)
+			assert.Equal(t, tt.wantScheme, scheme)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
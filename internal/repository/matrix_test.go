@@ -2,15 +2,25 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/parsing"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
 	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
 )
 
+// maxFileSizeBytes is the matrix file size limit unless configured otherwise.
+var maxFileSizeBytes = int(config.DefaultLimits.MaxFileSize)
+
 func TestMatrixRepository_GetFileContent(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -225,3 +235,485 @@ func TestMatrixRepository_GetFileContent_EdgeCases(t *testing.T) {
 		assert.Equal(t, 2, len(got.Content))
 	})
 }
+
+func TestMatrixRepository_StreamFileContent(t *testing.T) {
+	t.Run("streams every row in order", func(t *testing.T) {
+		repo := NewMatrixRepository()
+
+		var got [][]string
+		err := repo.StreamFileContent(context.Background(), "testdata/matrix2.csv", func(row []string) error {
+			got = append(got, append([]string(nil), row...))
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"a", "2", "3"}, {"4", "b", "6"}, {"7", "8", "9"}}, got)
+	})
+
+	t.Run("stops reading when the handler fails", func(t *testing.T) {
+		repo := NewMatrixRepository()
+		handlerErr := errors.New("row rejected")
+
+		calls := 0
+		err := repo.StreamFileContent(context.Background(), "testdata/matrix1.csv", func(row []string) error {
+			calls++
+			if calls == 2 {
+				return handlerErr
+			}
+			return nil
+		})
+
+		assert.ErrorIs(t, err, handlerErr)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("stops reading when the context is cancelled", func(t *testing.T) {
+		repo := NewMatrixRepository()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calls := 0
+		err := repo.StreamFileContent(ctx, "testdata/matrix1.csv", func(row []string) error {
+			calls++
+			cancel()
+			return nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		repo := NewMatrixRepository()
+
+		err := repo.StreamFileContent(context.Background(), "testdata/nonexistent.csv", func(row []string) error {
+			t.Fatal("handler must not be called")
+			return nil
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestMatrixRepository_ReadFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	t.Run("returns the raw bytes", func(t *testing.T) {
+		// Blank lines and the byte order mark are kept, unlike the parsed content
+		path := write("raw.csv", "\ufeff1,2\n\n3,4\n\n")
+
+		got, err := NewMatrixRepository().ReadFile(context.Background(), path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "\ufeff1,2\n\n3,4\n\n", string(got))
+	})
+
+	t.Run("reads embedded samples", func(t *testing.T) {
+		got, err := NewMatrixRepository().ReadFile(context.Background(), "testdata/matrix1.csv")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewMatrixRepository().ReadFile(context.Background(), filepath.Join(dir, "missing.csv"))
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		path := write("large.csv", strings.Repeat("1\n", 513))
+
+		_, err := NewMatrixRepository().ReadFile(context.Background(), path)
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+}
+
+func TestMatrixRepository_FileModTime(t *testing.T) {
+	t.Run("returns the modification time", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "matrix.csv")
+		assert.NoError(t, os.WriteFile(path, []byte("1,2\n"), 0o644))
+		modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+
+		got, err := NewMatrixRepository().FileModTime(context.Background(), path)
+
+		assert.NoError(t, err)
+		assert.True(t, modTime.Equal(got), got)
+	})
+
+	t.Run("embedded samples carry no modification time", func(t *testing.T) {
+		got, err := NewMatrixRepository().FileModTime(context.Background(), "testdata/matrix5.csv")
+
+		assert.NoError(t, err)
+		assert.True(t, got.IsZero())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewMatrixRepository().FileModTime(context.Background(), filepath.Join(t.TempDir(), "missing.csv"))
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestMatrixRepository_StatFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matrix.csv")
+	assert.NoError(t, os.WriteFile(path, []byte("1,2\n"), 0o644))
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	got, err := NewMatrixRepository().StatFile(context.Background(), path)
+
+	assert.NoError(t, err)
+	assert.True(t, modTime.Equal(got.ModTime), got.ModTime)
+	assert.Equal(t, int64(4), got.Size)
+
+	_, err = NewMatrixRepository().StatFile(context.Background(), filepath.Join(t.TempDir(), "missing.csv"))
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestMatrixRepository_StreamContent(t *testing.T) {
+	t.Run("streams every row in order", func(t *testing.T) {
+		repo := NewMatrixRepository()
+
+		var got [][]string
+		err := repo.StreamContent(context.Background(), strings.NewReader("1,2\n3,4\n"), func(row []string) error {
+			got = append(got, append([]string(nil), row...))
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, got)
+	})
+
+	t.Run("skips a leading byte order mark", func(t *testing.T) {
+		repo := NewMatrixRepository()
+
+		var got [][]string
+		err := repo.StreamContent(context.Background(), strings.NewReader("\ufeff\"1\",2\n3,4\n"), func(row []string) error {
+			got = append(got, append([]string(nil), row...))
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, got)
+	})
+
+	t.Run("lenient parsing", func(t *testing.T) {
+		repo := NewMatrixRepository()
+		ctx := parsing.NewContext(context.Background(), parsing.Options{Lenient: true})
+
+		var got [][]string
+		err := repo.StreamContent(ctx, strings.NewReader("\ufeff 1 ,\u00a02\n\n , \n3, \"4\"\n,\n"), func(row []string) error {
+			got = append(got, append([]string(nil), row...))
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, got)
+	})
+
+	t.Run("strict parsing keeps blank rows and padded values", func(t *testing.T) {
+		repo := NewMatrixRepository()
+
+		var got [][]string
+		err := repo.StreamContent(context.Background(), strings.NewReader(" 1,2\n,\n"), func(row []string) error {
+			got = append(got, append([]string(nil), row...))
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{" 1", "2"}, {"", ""}}, got)
+	})
+
+	t.Run("input at the size limit", func(t *testing.T) {
+		repo := NewMatrixRepository()
+		input := strings.Repeat("1", maxFileSizeBytes-1) + "\n"
+
+		err := repo.StreamContent(context.Background(), strings.NewReader(input), func(row []string) error {
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("input too large", func(t *testing.T) {
+		repo := NewMatrixRepository()
+		input := strings.Repeat("1,", maxFileSizeBytes)
+
+		err := repo.StreamContent(context.Background(), strings.NewReader(input), func(row []string) error {
+			return nil
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+
+	t.Run("input under a raised size limit", func(t *testing.T) {
+		repo := NewMatrixRepository()
+		input := strings.Repeat("1,2,3,4\n", maxFileSizeBytes)
+		ctx := WithMaxFileSize(context.Background(), int64(len(input)))
+
+		rows := 0
+		err := repo.StreamContent(ctx, strings.NewReader(input), func(row []string) error {
+			rows++
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, maxFileSizeBytes, rows)
+
+		err = repo.StreamContent(ctx, strings.NewReader(input+"1\n"), func(row []string) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+
+	t.Run("malformed CSV", func(t *testing.T) {
+		repo := NewMatrixRepository()
+
+		err := repo.StreamContent(context.Background(), strings.NewReader("1,\"2\n"), func(row []string) error {
+			return nil
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	})
+}
+
+func TestMatrixRepository_EmbeddedFallback(t *testing.T) {
+	fallback := fstest.MapFS{
+		"testdata/embedded.csv": {Data: []byte("1,2\n3,4\n")},
+		"testdata/matrix1.csv":  {Data: []byte("9\n")},
+		"testdata/large.csv":    {Data: make([]byte, maxFileSizeBytes+1)},
+	}
+	repo := &matrixRepository{fallback: fallback}
+	ctx := context.Background()
+
+	t.Run("missing file is served from the fallback", func(t *testing.T) {
+		got, err := repo.GetFileContent(ctx, "testdata/embedded.csv")
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, got.Content)
+	})
+
+	t.Run("file on disk takes precedence", func(t *testing.T) {
+		got, err := repo.GetFileContent(ctx, "testdata/matrix1.csv")
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, [][]string{{"9"}}, got.Content)
+	})
+
+	t.Run("size limit applies to embedded files", func(t *testing.T) {
+		_, err := repo.GetFileContent(ctx, "testdata/large.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+
+	t.Run("missing from both", func(t *testing.T) {
+		_, err := repo.GetFileContent(ctx, "testdata/nonexistent.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestMatrixRepository_EmbeddedSamples(t *testing.T) {
+	// Sample paths are relative to the module root, which is not the working directory of this test
+	_, err := os.Stat("testdata/matrix5.csv")
+	assert.True(t, os.IsNotExist(err))
+
+	got, err := NewMatrixRepository().GetFileContent(context.Background(), "testdata/matrix5.csv")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, got.Content)
+}
+
+func TestMatrixRepository_SaveFileContent(t *testing.T) {
+	t.Run("writes a new CSV file that can be read back", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "out", "result.csv")
+		repo := NewMatrixRepository()
+		content := &MatrixFileContent{Content: [][]string{{"1", "4"}, {"2", "5"}}}
+
+		err := repo.SaveFileContent(context.Background(), filePath, content)
+		assert.NoError(t, err)
+
+		got, err := repo.GetFileContent(context.Background(), filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, content, got)
+	})
+
+	t.Run("existing files are not overwritten", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "result.csv")
+		assert.NoError(t, os.WriteFile(filePath, []byte("9\n"), 0o644))
+
+		err := NewMatrixRepository().SaveFileContent(context.Background(), filePath,
+			&MatrixFileContent{Content: [][]string{{"1"}}})
+
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		data, readErr := os.ReadFile(filePath)
+		assert.NoError(t, readErr)
+		assert.Equal(t, "9\n", string(data))
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		filePath := filepath.Join(t.TempDir(), "result.csv")
+
+		err := NewMatrixRepository().SaveFileContent(ctx, filePath, &MatrixFileContent{Content: [][]string{{"1"}}})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoFileExists(t, filePath)
+	})
+}
+
+func TestMatrixRepository_TenantAccess(t *testing.T) {
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	content := &MatrixFileContent{Content: [][]string{{"1"}}}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		filePath string
+	}{
+		{name: "shared file for a tenant", ctx: acme, filePath: "testdata/matrix1.csv"},
+		{name: "another tenant's file", ctx: acme, filePath: "testdata/tenants/globex/matrix.csv"},
+		{name: "tenant file without a tenant", ctx: context.Background(), filePath: "testdata/tenants/acme/matrix.csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMatrixRepository()
+
+			_, err := repo.GetFileContent(tt.ctx, tt.filePath)
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+			_, err = repo.ReadFile(tt.ctx, tt.filePath)
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+			_, err = repo.FileModTime(tt.ctx, tt.filePath)
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+			err = repo.SaveFileContent(tt.ctx, tt.filePath, content)
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		})
+	}
+}
+
+func TestMatrixRepository_ListFiles(t *testing.T) {
+	fallback := fstest.MapFS{
+		"testdata/embedded.csv":             {Data: []byte("1\n")},
+		"testdata/matrix1.csv":              {Data: []byte("9\n")},
+		"testdata/notes.txt":                {Data: []byte("not a matrix\n")},
+		"testdata/tenants/acme/matrix.csv":  {Data: []byte("1\n")},
+		"testdata/tenants/globex/other.csv": {Data: []byte("1\n")},
+	}
+	repo := &matrixRepository{fallback: fallback}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{
+			name: "without a tenant",
+			ctx:  context.Background(),
+			want: []string{
+				"testdata/embedded.csv",
+				"testdata/gopher.jpg.csv",
+				"testdata/matrix0.csv",
+				"testdata/matrix1.csv",
+				"testdata/matrix2.csv",
+			},
+		},
+		{
+			name: "tenant only sees its own files",
+			ctx:  tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"}),
+			want: []string{"testdata/tenants/acme/matrix.csv"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListFiles(tt.ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := repo.ListFiles(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestMatrixRepository_CheckHealth(t *testing.T) {
+	t.Run("readable data directory", func(t *testing.T) {
+		assert.NoError(t, (&matrixRepository{}).CheckHealth(context.Background()))
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, (&matrixRepository{}).CheckHealth(ctx), context.Canceled)
+	})
+}
+
+func FuzzMatrixRepository_StreamContent(f *testing.F) {
+	for _, seed := range []string{
+		"1,2\n3,4\n",
+		"1, 2\r\n3 ,4\r\n",
+		"\ufeff1,2\n3,4\n",
+		"\ufeff\"1\",\"2\"\n",
+		"1,\"2\n",
+		"1,\"2\"x\n",
+		"1,2\n3\n",
+		"99999999999999999999999999999999999999,1\n",
+		"١,٢\n",
+		"\xff\xfe1,2\n",
+		strings.Repeat("1,", maxFileSizeBytes),
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	repo := NewMatrixRepository()
+	f.Fuzz(func(t *testing.T, input string) {
+		var rows [][]string
+		err := repo.StreamContent(context.Background(), strings.NewReader(input), func(row []string) error {
+			rows = append(rows, append([]string(nil), row...))
+			return nil
+		})
+
+		if len(input) > maxFileSizeBytes {
+			assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+			return
+		}
+		if err != nil {
+			assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+			return
+		}
+		for _, row := range rows {
+			assert.Len(t, row, len(rows[0]))
+		}
+		// A leading byte order mark never ends up in the values
+		if withoutBOM, ok := strings.CutPrefix(input, "\ufeff"); ok {
+			var want [][]string
+			err := repo.StreamContent(context.Background(), strings.NewReader(withoutBOM), func(row []string) error {
+				want = append(want, append([]string(nil), row...))
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, want, rows)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/profile"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// HTTPScheme and HTTPSScheme are the schemes of the URLs of remote matrix files, such as
+	// https://example.com/league.csv.
+	HTTPScheme  = "http"
+	HTTPSScheme = "https"
+
+	// urlRequestTimeout bounds a single request for a remote file, redirects included.
+	urlRequestTimeout = 30 * time.Second
+
+	// urlMaxRedirects bounds the redirects followed for a remote file.
+	urlMaxRedirects = 5
+)
+
+// urlContentTypes are the media types remote matrix files may be served with.
+var urlContentTypes = []string{"text/csv", "application/csv", "text/plain"}
+
+type urlMatrixRepository struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+// NewURLMatrixRepository creates a new instance of MatrixRepositoryInterface that downloads matrix files from
+// http:// and https:// URLs. Only the hosts of allowedHosts are ever requested, redirects included, so that
+// requests cannot reach the internal network through it. An entry such as example.com allows the host on any
+// port, and one such as example.com:8443 on that port alone. Remote files are read-only and not listed.
+func NewURLMatrixRepository(allowedHosts []string) MatrixRepositoryInterface {
+	r := &urlMatrixRepository{
+		allowedHosts: allowedHosts,
+	}
+	r.client = &http.Client{
+		Timeout:       urlRequestTimeout,
+		CheckRedirect: r.checkRedirect,
+	}
+	return r
+}
+
+func (r *urlMatrixRepository) GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error) {
+	content := &MatrixFileContent{}
+	err := r.StreamFileContent(ctx, filePath, func(row []string) error {
+		content.Content = append(content.Content, append([]string(nil), row...))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func (r *urlMatrixRepository) StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := r.openChecked(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return streamLimited(ctx, body, filePath, handleRow)
+}
+
+func (r *urlMatrixRepository) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	body, err := r.openChecked(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := readLimited(ctx, body)
+	if err != nil && !errors.Is(err, apperrors.ErrPayloadTooLarge) {
+		slog.ErrorContext(ctx, "failed to read remote file",
+			"file_path", filePath,
+			"error", err)
+		return nil, fmt.Errorf("%w: failed to read remote file: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	return data, err
+}
+
+func (r *urlMatrixRepository) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	stat, err := r.StatFile(ctx, filePath)
+	return stat.ModTime, err
+}
+
+func (r *urlMatrixRepository) StatFile(ctx context.Context, filePath string) (FileStat, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return FileStat{}, err
+	}
+
+	resp, err := r.fetch(ctx, http.MethodHead, filePath)
+	if err != nil {
+		return FileStat{}, err
+	}
+	resp.Body.Close()
+
+	return responseStat(resp), nil
+}
+
+// openChecked returns the body of the file filePath addresses once it has checked that it is served as CSV
+// and that its announced size is within the size limit. The caller must close the body.
+func (r *urlMatrixRepository) openChecked(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	defer profile.Start(ctx, profile.Read)()
+
+	resp, err := r.fetch(ctx, http.MethodGet, filePath)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to download remote file",
+			"file_path", filePath,
+			"error", err)
+		return nil, err
+	}
+
+	if err := checkContentType(resp.Header.Get("Content-Type")); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	// Check the announced size BEFORE reading to prevent DoS attacks; files sent without one are limited as they are read
+	if err := checkAnnouncedSize(ctx, resp.ContentLength); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// fetch sends a request for the file filePath addresses and returns the successful response, whose body
+// the caller must close.
+func (r *urlMatrixRepository) fetch(ctx context.Context, method, filePath string) (*http.Response, error) {
+	target, err := url.Parse(filePath)
+	if err != nil || (target.Scheme != HTTPScheme && target.Scheme != HTTPSScheme) || target.Host == "" {
+		return nil, fmt.Errorf("%w: invalid remote file address: %q: expected https://host/path",
+			apperrors.ErrInvalidInput, filePath)
+	}
+	if !r.allowedHost(target) {
+		slog.WarnContext(ctx, "remote file host denied",
+			"host", target.Host,
+			"file_path", filePath)
+		audit.Deny(ctx, "remote file host not allowed: "+target.Host)
+		return nil, fmt.Errorf("%w: host %q is not allowed for remote files", apperrors.ErrInvalidInput, target.Host)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid remote file address: %v", apperrors.ErrInvalidInput, err)
+	}
+	req.Header.Set("Accept", strings.Join(urlContentTypes, ", "))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		// The redirect check reports the hosts it denies as invalid input
+		if errors.Is(err, apperrors.ErrInvalidInput) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: failed to reach remote file: %v", apperrors.ErrServiceUnavailable, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return resp, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: remote file not found: %s", apperrors.ErrNotFound, filePath)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: remote host denied access to %s", apperrors.ErrForbidden, filePath)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: remote host responded with status %d", apperrors.ErrServiceUnavailable, resp.StatusCode)
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("remote host responded with status %d", resp.StatusCode)
+	}
+}
+
+// checkRedirect follows the redirects of a request for a remote file while they stay on allowed hosts.
+func (r *urlMatrixRepository) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= urlMaxRedirects {
+		return fmt.Errorf("%w: stopped after %d redirects", apperrors.ErrInvalidInput, urlMaxRedirects)
+	}
+	if req.URL.Scheme != HTTPScheme && req.URL.Scheme != HTTPSScheme {
+		return fmt.Errorf("%w: redirect to unsupported scheme %q", apperrors.ErrInvalidInput, req.URL.Scheme)
+	}
+	if !r.allowedHost(req.URL) {
+		audit.Deny(req.Context(), "remote file redirect to a host not allowed: "+req.URL.Host)
+		return fmt.Errorf("%w: redirect to host %q is not allowed for remote files", apperrors.ErrInvalidInput, req.URL.Host)
+	}
+	return nil
+}
+
+// allowedHost reports whether the host of target is allowed: an entry naming the host alone allows it on any
+// port, and one naming a port on that port alone. Hosts are compared ignoring case.
+func (r *urlMatrixRepository) allowedHost(target *url.URL) bool {
+	host := strings.ToLower(target.Host)
+	hostname := strings.ToLower(target.Hostname())
+	return slices.ContainsFunc(r.allowedHosts, func(allowed string) bool {
+		allowed = strings.ToLower(allowed)
+		return allowed == host || allowed == hostname
+	})
+}
+
+// checkContentType fails with ErrUnsupportedMediaType unless contentType is one of urlContentTypes.
+func checkContentType(contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(urlContentTypes, mediaType) {
+		return fmt.Errorf("%w: remote file served as %q: expected one of %s",
+			apperrors.ErrUnsupportedMediaType, contentType, strings.Join(urlContentTypes, ", "))
+	}
+	return nil
+}
+
+func (r *urlMatrixRepository) StreamContent(ctx context.Context, reader io.Reader, handleRow RowHandler) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return streamLimited(ctx, reader, "", handleRow)
+}
+
+// SaveFileContent always fails: remote files belong to the hosts serving them.
+func (r *urlMatrixRepository) SaveFileContent(ctx context.Context, filePath string, _ *MatrixFileContent) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: matrix files cannot be saved to a URL: %s", apperrors.ErrInvalidInput, filePath)
+}
+
+// ListFiles returns no files: remote files are named by the URLs they are read from, so there are none to list.
+func (r *urlMatrixRepository) ListFiles(ctx context.Context) ([]string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return []string{}, nil
+}
+
+// CheckHealth reports remote files as healthy: failing downloads are reported by the requests making them.
+func (r *urlMatrixRepository) CheckHealth(ctx context.Context) error {
+	return ctx.Err()
+}
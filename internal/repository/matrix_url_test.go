@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newRemoteFileServer returns a host serving the files of a remote matrix file test, each last modified
+// at modTime, and counts the requests it receives in hits.
+func newRemoteFileServer(t *testing.T, modTime time.Time, hits *atomic.Int64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+		switch r.URL.Path {
+		case "/league.csv":
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			_, _ = w.Write([]byte("1,2\n3,4\n"))
+		case "/league.txt":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("5,6\n"))
+		case "/league.json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[[1,2]]`))
+		case "/large.csv":
+			w.Header().Set("Content-Type", "text/csv")
+			if r.URL.Query().Get("chunked") == "" {
+				w.Header().Set("Content-Length", "60")
+			}
+			_, _ = w.Write([]byte(strings.Repeat("1,2,3\n", 10)))
+			w.(http.Flusher).Flush()
+		case "/moved.csv":
+			http.Redirect(w, r, "/league.csv", http.StatusFound)
+		case "/escape.csv":
+			http.Redirect(w, r, "http://metadata.internal/latest.csv", http.StatusFound)
+		case "/unavailable.csv":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/private.csv":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestURLMatrixRepository_GetFileContent(t *testing.T) {
+	var hits atomic.Int64
+	server := newRemoteFileServer(t, time.Now(), &hits)
+	repo := NewURLMatrixRepository([]string{"127.0.0.1"})
+
+	tests := []struct {
+		name    string
+		path    string
+		want    [][]string
+		wantErr error
+	}{
+		{name: "csv file", path: "/league.csv", want: [][]string{{"1", "2"}, {"3", "4"}}},
+		{name: "plain text file", path: "/league.txt", want: [][]string{{"5", "6"}}},
+		{name: "redirect to an allowed host", path: "/moved.csv", want: [][]string{{"1", "2"}, {"3", "4"}}},
+		{name: "redirect to a host not allowed", path: "/escape.csv", wantErr: apperrors.ErrInvalidInput},
+		{name: "file not served as csv", path: "/league.json", wantErr: apperrors.ErrUnsupportedMediaType},
+		{name: "missing file", path: "/missing.csv", wantErr: apperrors.ErrNotFound},
+		{name: "denied file", path: "/private.csv", wantErr: apperrors.ErrForbidden},
+		{name: "unavailable host", path: "/unavailable.csv", wantErr: apperrors.ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetFileContent(context.Background(), server.URL+tt.path)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Content)
+		})
+	}
+}
+
+func TestURLMatrixRepository_AllowedHosts(t *testing.T) {
+	var hits atomic.Int64
+	server := newRemoteFileServer(t, time.Now(), &hits)
+	port := strings.TrimPrefix(server.URL, "http://127.0.0.1:")
+
+	tests := []struct {
+		name         string
+		allowedHosts []string
+		wantErr      error
+	}{
+		{name: "host on any port", allowedHosts: []string{"127.0.0.1"}},
+		{name: "host on its port", allowedHosts: []string{"example.com", "127.0.0.1:" + port}},
+		{name: "host on another port", allowedHosts: []string{"127.0.0.1:1"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "other host", allowedHosts: []string{"example.com"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "no host", wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits.Store(0)
+			repo := NewURLMatrixRepository(tt.allowedHosts)
+
+			_, err := repo.ReadFile(context.Background(), server.URL+"/league.csv")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				// Hosts that are not allowed are never requested
+				assert.Zero(t, hits.Load())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestURLMatrixRepository_SizeLimit(t *testing.T) {
+	var hits atomic.Int64
+	server := newRemoteFileServer(t, time.Now(), &hits)
+	repo := NewURLMatrixRepository([]string{"127.0.0.1"})
+
+	for _, chunked := range []bool{false, true} {
+		t.Run("chunked="+strconv.FormatBool(chunked), func(t *testing.T) {
+			fileURL := server.URL + "/large.csv"
+			if chunked {
+				fileURL += "?chunked=1"
+			}
+			// Files are rejected by their announced size, or once read past the limit when they announce none
+			ctx := WithMaxFileSize(context.Background(), 59)
+
+			err := repo.StreamFileContent(ctx, fileURL, func([]string) error { return nil })
+			assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+
+			_, err = repo.ReadFile(ctx, fileURL)
+			assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+
+			data, err := repo.ReadFile(context.Background(), fileURL)
+			assert.NoError(t, err)
+			assert.Len(t, data, 60)
+		})
+	}
+}
+
+func TestURLMatrixRepository_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	repo := NewURLMatrixRepository([]string{"127.0.0.1"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := repo.ReadFile(ctx, server.URL+"/slow.csv")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestURLMatrixRepository_FileModTime(t *testing.T) {
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var hits atomic.Int64
+	server := newRemoteFileServer(t, modTime, &hits)
+	repo := NewURLMatrixRepository([]string{"127.0.0.1"})
+
+	got, err := repo.FileModTime(context.Background(), server.URL+"/league.csv")
+	assert.NoError(t, err)
+	assert.True(t, modTime.Equal(got))
+
+	_, err = repo.FileModTime(context.Background(), server.URL+"/missing.csv")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestURLMatrixRepository_StatFile(t *testing.T) {
+	modTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var hits atomic.Int64
+	server := newRemoteFileServer(t, modTime, &hits)
+	repo := NewURLMatrixRepository([]string{"127.0.0.1"})
+
+	got, err := repo.StatFile(context.Background(), server.URL+"/league.csv")
+	assert.NoError(t, err)
+	assert.True(t, modTime.Equal(got.ModTime))
+	assert.Equal(t, int64(8), got.Size)
+
+	// Files streamed without a length have an unknown size
+	got, err = repo.StatFile(context.Background(), server.URL+"/large.csv?chunked=1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), got.Size)
+}
+
+func TestURLMatrixRepository_ReadOnly(t *testing.T) {
+	repo := NewURLMatrixRepository([]string{"example.com"})
+
+	err := repo.SaveFileContent(context.Background(), "https://example.com/result.csv", &MatrixFileContent{Content: [][]string{{"1"}}})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+	files, err := repo.ListFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	assert.NoError(t, repo.CheckHealth(context.Background()))
+}
+
+func TestCheckContentType(t *testing.T) {
+	for contentType, ok := range map[string]bool{
+		"text/csv":                  true,
+		"text/csv; charset=utf-8":   true,
+		"Application/CSV":           true,
+		"text/plain; charset=utf-8": true,
+		"application/json":          false,
+		"text/html":                 false,
+		"":                          false,
+	} {
+		err := checkContentType(contentType)
+		if ok {
+			assert.NoError(t, err, contentType)
+		} else {
+			assert.ErrorIs(t, err, apperrors.ErrUnsupportedMediaType, contentType)
+		}
+	}
+}
+
+func TestURLMatrixRepository_InvalidAddress(t *testing.T) {
+	repo := NewURLMatrixRepository([]string{"example.com"})
+
+	for _, filePath := range []string{"https://", "ftp://example.com/league.csv", (&url.URL{Scheme: "https", Path: "/league.csv"}).String()} {
+		_, err := repo.ReadFile(context.Background(), filePath)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput, filePath)
+	}
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+)
+
+// objectDir holds the content of completed uploads, each stored once under its SHA-256 however many uploads share it.
+// It lives inside the upload directory so uploads can be hard links to its objects on the same file system, and
+// objects have no .csv extension so they cannot be read through the matrix endpoints.
+const objectDir = ".objects"
+
+// objectsMu serializes changes to the object store, which every repository instance shares on disk,
+// so an object is never collected while a new upload is being linked to it.
+var objectsMu sync.Mutex
+
+// ObjectRepositoryInterface defines the data access contract for the content-addressable store of uploaded matrices.
+// Stored files are hard links to the object holding their content, so the number of files referring to an object
+// is counted by the file system, and deleting a file, whichever way, releases its reference.
+type ObjectRepositoryInterface interface {
+	// Store moves the file at src into the store under the SHA-256 of its content and makes dst a link to it.
+	// When the same content is stored already, src is removed and dst shares the stored object instead.
+	// Where the store cannot link to it, such as across file systems, src is moved to dst without being shared.
+	Store(ctx context.Context, src string, dst string) (*StoredObject, error)
+
+	// Collect removes the objects no stored file refers to anymore, returning how many were removed.
+	Collect(ctx context.Context) (int, error)
+}
+
+// StoredObject describes the object holding the content of a stored file.
+// Deduplicated reports that the content was stored already, so the file takes no additional space.
+type StoredObject struct {
+	Hash         string
+	Deduplicated bool
+}
+
+type objectRepository struct {
+	dir string
+}
+
+// NewObjectRepository creates a new instance of ObjectRepositoryInterface.
+// It returns a repository that stores objects on the local file system, next to the uploads linking to them.
+func NewObjectRepository() ObjectRepositoryInterface {
+	return &objectRepository{
+		dir: filepath.Join(config.CurrentLimits().DataDir, uploadDir, objectDir),
+	}
+}
+
+func (r *objectRepository) Store(ctx context.Context, src string, dst string) (*StoredObject, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	hash, err := hashFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash file: %w", err)
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create object directory",
+			"dir", r.dir,
+			"error", err)
+		return nil, fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	objectsMu.Lock()
+	defer objectsMu.Unlock()
+
+	object := filepath.Join(r.dir, hash)
+	_, err = os.Stat(object)
+	deduplicated := err == nil
+	if !deduplicated {
+		if err := os.Rename(src, object); err != nil {
+			return storeUnshared(ctx, hash, src, dst, err)
+		}
+		src = object
+	}
+
+	if err := os.Link(object, dst); err != nil {
+		// The file keeps its own copy of the content, as if the store did not exist
+		return storeUnshared(ctx, hash, src, dst, err)
+	}
+	if deduplicated {
+		if err := os.Remove(src); err != nil {
+			slog.WarnContext(ctx, "failed to remove deduplicated file",
+				"path", src,
+				"error", err)
+		}
+	}
+	return &StoredObject{Hash: hash, Deduplicated: deduplicated}, nil
+}
+
+func (r *objectRepository) Collect(ctx context.Context) (int, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	objectsMu.Lock()
+	defer objectsMu.Unlock()
+
+	entries, err := os.ReadDir(r.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		// The only link left is the object itself
+		if linkCount(info) != 1 {
+			continue
+		}
+		if err := os.Remove(filepath.Join(r.dir, entry.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove object: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// hashFile returns the hex-encoded SHA-256 of the content of the file at path.
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// storeUnshared moves the file at src, whose content hashes to hash, to dst without sharing its content, after
+// the object store failed with cause, such as when the upload directory spans several file systems.
+func storeUnshared(ctx context.Context, hash string, src string, dst string, cause error) (*StoredObject, error) {
+	slog.WarnContext(ctx, "storing file without deduplication",
+		"path", dst,
+		"error", cause)
+	if err := os.Rename(src, dst); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	return &StoredObject{Hash: hash}, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeObjectSource writes content to a new file in dir, to be stored as an object.
+func writeObjectSource(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestObjectRepository_Store(t *testing.T) {
+	dir := t.TempDir()
+	repo := &objectRepository{dir: filepath.Join(dir, ".objects")}
+	ctx := context.Background()
+
+	first, err := repo.Store(ctx, writeObjectSource(t, dir, "a.part", "1,2\n"), filepath.Join(dir, "a.csv"))
+	assert.NoError(t, err)
+	assert.False(t, first.Deduplicated)
+
+	second, err := repo.Store(ctx, writeObjectSource(t, dir, "b.part", "1,2\n"), filepath.Join(dir, "b.csv"))
+	assert.NoError(t, err)
+	assert.True(t, second.Deduplicated)
+	assert.Equal(t, first.Hash, second.Hash)
+	assert.NoFileExists(t, filepath.Join(dir, "b.part"))
+
+	object, err := os.Stat(filepath.Join(repo.dir, first.Hash))
+	assert.NoError(t, err)
+	for _, name := range []string{"a.csv", "b.csv"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		assert.True(t, os.SameFile(object, info), name)
+	}
+}
+
+func TestObjectRepository_Store_NoObjectDirectory(t *testing.T) {
+	dir := t.TempDir()
+	// A file where the object directory should be keeps the store from being created
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".objects"), nil, 0o644))
+	repo := &objectRepository{dir: filepath.Join(dir, ".objects", "store")}
+
+	_, err := repo.Store(context.Background(), writeObjectSource(t, dir, "a.part", "1,2\n"), filepath.Join(dir, "a.csv"))
+
+	assert.Error(t, err)
+	assert.FileExists(t, filepath.Join(dir, "a.part"))
+}
+
+func TestObjectRepository_Collect(t *testing.T) {
+	dir := t.TempDir()
+	repo := &objectRepository{dir: filepath.Join(dir, ".objects")}
+	ctx := context.Background()
+
+	removed, err := repo.Collect(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, removed)
+
+	kept, err := repo.Store(ctx, writeObjectSource(t, dir, "a.part", "1,2\n"), filepath.Join(dir, "a.csv"))
+	assert.NoError(t, err)
+	_, err = repo.Store(ctx, writeObjectSource(t, dir, "b.part", "1,2\n"), filepath.Join(dir, "b.csv"))
+	assert.NoError(t, err)
+	released, err := repo.Store(ctx, writeObjectSource(t, dir, "c.part", "3,4\n"), filepath.Join(dir, "c.csv"))
+	assert.NoError(t, err)
+
+	// Content stays stored while any file shares it
+	assert.NoError(t, os.Remove(filepath.Join(dir, "a.csv")))
+	assert.NoError(t, os.Remove(filepath.Join(dir, "c.csv")))
+
+	removed, err = repo.Collect(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.FileExists(t, filepath.Join(repo.dir, kept.Hash))
+	assert.NoFileExists(t, filepath.Join(repo.dir, released.Hash))
+}
@@ -0,0 +1,411 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// OTLP protocols, as named by OTEL_EXPORTER_OTLP_PROTOCOL.
+const (
+	OTLPProtocolHTTPProtobuf = "http/protobuf"
+	OTLPProtocolHTTPJSON     = "http/json"
+	OTLPProtocolGRPC         = "grpc"
+)
+
+const (
+	// otlpScopeName is the instrumentation scope of every exported log record and metric.
+	otlpScopeName = "github.com/matsuboshi/league-matrix-app"
+
+	// maxOTLPResponseBytes bounds how much of a collector response is read before the connection is reused.
+	maxOTLPResponseBytes = 64 << 10
+
+	// otlpCumulative is the aggregation temporality of values accumulated since the service started.
+	otlpCumulative = metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+
+	// defaultOTLPGRPCPort is the port of gRPC endpoints that name none.
+	defaultOTLPGRPCPort = "4317"
+)
+
+// OTLPConfig locates the OpenTelemetry collector receiving the logs and metrics. Protocol is one of
+// OTLPProtocolHTTPProtobuf, OTLPProtocolHTTPJSON and OTLPProtocolGRPC; an empty protocol is http/protobuf.
+// LogsEndpoint and MetricsEndpoint are complete URLs, such as http://collector:4318/v1/logs over HTTP or
+// http://collector:4317 over gRPC, where https connects with TLS; an empty endpoint disables exporting that signal.
+// Headers are sent with every request, and Resource holds the attributes describing the service, such as service.name.
+type OTLPConfig struct {
+	Protocol        string
+	LogsEndpoint    string
+	MetricsEndpoint string
+	Headers         map[string]string
+	Timeout         time.Duration
+	Resource        map[string]string
+}
+
+// LogRecord is a log record to export. SeverityNumber follows the OpenTelemetry log data model,
+// where 9 is INFO and 17 is ERROR.
+type LogRecord struct {
+	Time           time.Time
+	SeverityNumber int
+	SeverityText   string
+	Body           string
+	Attributes     []LogAttribute
+}
+
+// LogAttribute is an attribute of a log record. Value is a string, int64, float64 or bool.
+type LogAttribute struct {
+	Key   string
+	Value any
+}
+
+// MetricRecord is a metric to export, accumulated since StartTime and measured at Time.
+// Kind is "counter", "gauge" or "histogram"; Bounds only apply to histograms.
+type MetricRecord struct {
+	Name        string
+	Description string
+	Unit        string
+	Kind        string
+	Bounds      []float64
+	StartTime   time.Time
+	Time        time.Time
+	Points      []MetricPointRecord
+}
+
+// MetricPointRecord is the value of a metric for a combination of attributes.
+// Value is set for counters and gauges; Count, Sum and BucketCounts for histograms.
+type MetricPointRecord struct {
+	Attributes   map[string]string
+	Value        float64
+	Count        uint64
+	Sum          float64
+	BucketCounts []uint64
+}
+
+// OTLPRepositoryInterface defines the contract for shipping telemetry to an OpenTelemetry collector.
+type OTLPRepositoryInterface interface {
+	// ExportLogs sends log records to the logs endpoint. Network errors, 429 and 5xx responses, and their
+	// gRPC equivalents such as UNAVAILABLE, fail with ErrServiceUnavailable; records are not retried,
+	// since newer ones keep coming.
+	ExportLogs(ctx context.Context, records []*LogRecord) error
+
+	// ExportMetrics sends metrics to the metrics endpoint, failing like ExportLogs.
+	ExportMetrics(ctx context.Context, records []*MetricRecord) error
+
+	// Close closes the connections to the collector. Nothing can be exported afterwards.
+	Close() error
+}
+
+type otlpRepository struct {
+	client *http.Client
+	config OTLPConfig
+
+	// logsConn and metricsConn connect to the endpoints over gRPC; they are nil over HTTP
+	// and for the signals not exported
+	logsConn    *grpc.ClientConn
+	metricsConn *grpc.ClientConn
+}
+
+// NewOTLPRepository creates a new instance of OTLPRepositoryInterface.
+// It sends telemetry to the endpoints of config over OTLP/HTTP with protobuf or JSON encoding, or over OTLP/gRPC.
+// gRPC connections are established on the first export, so the collector need not be up yet.
+func NewOTLPRepository(config OTLPConfig) (OTLPRepositoryInterface, error) {
+	r := &otlpRepository{
+		client: &http.Client{Timeout: config.Timeout},
+		config: config,
+	}
+
+	switch config.Protocol {
+	case "", OTLPProtocolHTTPProtobuf, OTLPProtocolHTTPJSON:
+		return r, nil
+	case OTLPProtocolGRPC:
+	default:
+		return nil, fmt.Errorf("%w: unsupported OTLP protocol %q: expected %s, %s or %s", apperrors.ErrInvalidInput,
+			config.Protocol, OTLPProtocolHTTPProtobuf, OTLPProtocolHTTPJSON, OTLPProtocolGRPC)
+	}
+
+	var err error
+	if r.logsConn, err = dialOTLP(config.LogsEndpoint); err != nil {
+		return nil, err
+	}
+	if r.metricsConn, err = dialOTLP(config.MetricsEndpoint); err != nil {
+		return nil, errors.Join(err, r.Close())
+	}
+	return r, nil
+}
+
+// dialOTLP creates the gRPC connection to endpoint, or returns nil when endpoint is empty.
+func dialOTLP(endpoint string) (*grpc.ClientConn, error) {
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, fmt.Errorf("%w: invalid OTLP endpoint %q: expected an http or https URL", apperrors.ErrInvalidInput, endpoint)
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = defaultOTLPGRPCPort
+	}
+	creds := insecure.NewCredentials()
+	if parsed.Scheme == "https" {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	conn, err := grpc.NewClient(net.JoinHostPort(parsed.Hostname(), port), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid OTLP endpoint %q: %v", apperrors.ErrInvalidInput, endpoint, err)
+	}
+	return conn, nil
+}
+
+func (r *otlpRepository) ExportLogs(ctx context.Context, records []*LogRecord) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	logRecords := make([]*logspb.LogRecord, 0, len(records))
+	observed := unixNano(time.Now())
+	for _, record := range records {
+		attributes := make([]*commonpb.KeyValue, 0, len(record.Attributes))
+		for _, attribute := range record.Attributes {
+			attributes = append(attributes, &commonpb.KeyValue{Key: attribute.Key, Value: otlpValue(attribute.Value)})
+		}
+		logRecords = append(logRecords, &logspb.LogRecord{
+			TimeUnixNano:         unixNano(record.Time),
+			ObservedTimeUnixNano: observed,
+			SeverityNumber:       logspb.SeverityNumber(record.SeverityNumber),
+			SeverityText:         record.SeverityText,
+			Body:                 otlpValue(record.Body),
+			Attributes:           attributes,
+		})
+	}
+
+	request := &collogspb.ExportLogsServiceRequest{ResourceLogs: []*logspb.ResourceLogs{{
+		Resource:  r.resource(),
+		ScopeLogs: []*logspb.ScopeLogs{{Scope: &commonpb.InstrumentationScope{Name: otlpScopeName}, LogRecords: logRecords}},
+	}}}
+	if r.config.Protocol == OTLPProtocolGRPC {
+		return r.call(ctx, func(ctx context.Context) error {
+			_, err := collogspb.NewLogsServiceClient(r.logsConn).Export(ctx, request)
+			return err
+		})
+	}
+	return r.post(ctx, r.config.LogsEndpoint, request)
+}
+
+func (r *otlpRepository) ExportMetrics(ctx context.Context, records []*MetricRecord) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	metrics := make([]*metricspb.Metric, 0, len(records))
+	for _, record := range records {
+		metric := &metricspb.Metric{Name: record.Name, Description: record.Description, Unit: record.Unit}
+		start, now := unixNano(record.StartTime), unixNano(record.Time)
+
+		switch record.Kind {
+		case "histogram":
+			histogram := &metricspb.Histogram{AggregationTemporality: otlpCumulative}
+			for _, point := range record.Points {
+				histogram.DataPoints = append(histogram.DataPoints, &metricspb.HistogramDataPoint{
+					Attributes:        otlpAttributes(point.Attributes),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      now,
+					Count:             point.Count,
+					Sum:               proto.Float64(point.Sum),
+					BucketCounts:      point.BucketCounts,
+					ExplicitBounds:    record.Bounds,
+				})
+			}
+			metric.Data = &metricspb.Metric_Histogram{Histogram: histogram}
+		case "counter":
+			sum := &metricspb.Sum{AggregationTemporality: otlpCumulative, IsMonotonic: true}
+			for _, point := range record.Points {
+				sum.DataPoints = append(sum.DataPoints, &metricspb.NumberDataPoint{
+					Attributes:        otlpAttributes(point.Attributes),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      now,
+					Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: point.Value},
+				})
+			}
+			metric.Data = &metricspb.Metric_Sum{Sum: sum}
+		default:
+			gauge := &metricspb.Gauge{}
+			for _, point := range record.Points {
+				gauge.DataPoints = append(gauge.DataPoints, &metricspb.NumberDataPoint{
+					Attributes:   otlpAttributes(point.Attributes),
+					TimeUnixNano: now,
+					Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: point.Value},
+				})
+			}
+			metric.Data = &metricspb.Metric_Gauge{Gauge: gauge}
+		}
+		metrics = append(metrics, metric)
+	}
+
+	request := &colmetricspb.ExportMetricsServiceRequest{ResourceMetrics: []*metricspb.ResourceMetrics{{
+		Resource:     r.resource(),
+		ScopeMetrics: []*metricspb.ScopeMetrics{{Scope: &commonpb.InstrumentationScope{Name: otlpScopeName}, Metrics: metrics}},
+	}}}
+	if r.config.Protocol == OTLPProtocolGRPC {
+		return r.call(ctx, func(ctx context.Context) error {
+			_, err := colmetricspb.NewMetricsServiceClient(r.metricsConn).Export(ctx, request)
+			return err
+		})
+	}
+	return r.post(ctx, r.config.MetricsEndpoint, request)
+}
+
+func (r *otlpRepository) Close() error {
+	var errs []error
+	for _, conn := range []*grpc.ClientConn{r.logsConn, r.metricsConn} {
+		if conn != nil {
+			errs = append(errs, conn.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// post sends an OTLP/HTTP export request to endpoint, encoded as JSON or protobuf depending on the protocol.
+func (r *otlpRepository) post(ctx context.Context, endpoint string, request proto.Message) error {
+	var body []byte
+	var err error
+	contentType := "application/x-protobuf"
+	if r.config.Protocol == OTLPProtocolHTTPJSON {
+		// OTLP/JSON encodes enums as their numbers
+		body, err = protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(request)
+		contentType = "application/json"
+	} else {
+		body, err = proto.Marshal(request)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode OTLP request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: invalid OTLP endpoint: %v", apperrors.ErrInvalidInput, err)
+	}
+	for name, value := range r.config.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("%w: failed to reach the OpenTelemetry collector: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxOTLPResponseBytes))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("%w: OpenTelemetry collector responded with status %d",
+			apperrors.ErrServiceUnavailable, resp.StatusCode)
+	default:
+		return fmt.Errorf("OpenTelemetry collector responded with status %d", resp.StatusCode)
+	}
+}
+
+// call makes an OTLP/gRPC export call within the timeout of the configuration, sending the headers as metadata.
+func (r *otlpRepository) call(ctx context.Context, export func(ctx context.Context) error) error {
+	callCtx := ctx
+	if r.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, r.config.Timeout)
+		defer cancel()
+	}
+	for name, value := range r.config.Headers {
+		callCtx = metadata.AppendToOutgoingContext(callCtx, strings.ToLower(name), value)
+	}
+
+	err := export(callCtx)
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	// The codes the OTLP specification lets clients retry are reported as transient
+	code := status.Code(err)
+	switch code {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.OutOfRange,
+		codes.Unavailable, codes.DataLoss:
+		return fmt.Errorf("%w: OpenTelemetry collector responded with status %s", apperrors.ErrServiceUnavailable, code)
+	default:
+		return fmt.Errorf("OpenTelemetry collector responded with status %s", code)
+	}
+}
+
+// resource describes the service in export requests.
+func (r *otlpRepository) resource() *resourcepb.Resource {
+	return &resourcepb.Resource{Attributes: otlpAttributes(r.config.Resource)}
+}
+
+// otlpAttributes converts string attributes, sorted by key so requests are stable.
+func otlpAttributes(attributes map[string]string) []*commonpb.KeyValue {
+	keyValues := make([]*commonpb.KeyValue, 0, len(attributes))
+	for _, key := range slices.Sorted(maps.Keys(attributes)) {
+		keyValues = append(keyValues, &commonpb.KeyValue{Key: key, Value: otlpValue(attributes[key])})
+	}
+	return keyValues
+}
+
+// otlpValue converts an attribute value; values of other types are formatted as strings.
+func otlpValue(value any) *commonpb.AnyValue {
+	switch v := value.(type) {
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	case int64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+	case float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(v)}}
+	}
+}
+
+// unixNano returns t as nanoseconds since the Unix epoch; the zero time is left unset.
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// newTestOTLPRepository creates an OTLP repository closed when the test ends.
+func newTestOTLPRepository(t *testing.T, config OTLPConfig) OTLPRepositoryInterface {
+	t.Helper()
+
+	repo, err := NewOTLPRepository(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, repo.Close()) })
+	return repo
+}
+
+// collect starts a collector that records the path, headers and JSON body of the last request.
+func collect(t *testing.T, status int) (*httptest.Server, *http.Request, *map[string]any) {
+	t.Helper()
+
+	got := &http.Request{}
+	body := map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = *r
+		data, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(data, &body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, got, &body
+}
+
+func TestOTLPRepository_ExportLogs(t *testing.T) {
+	server, got, body := collect(t, http.StatusOK)
+	repo := newTestOTLPRepository(t, OTLPConfig{
+		Protocol:     OTLPProtocolHTTPJSON,
+		LogsEndpoint: server.URL + "/v1/logs",
+		Headers:      map[string]string{"Authorization": "Bearer token"},
+		Timeout:      time.Second,
+		Resource:     map[string]string{"service.name": "league-matrix"},
+	})
+
+	err := repo.ExportLogs(context.Background(), []*LogRecord{{
+		Time:           time.Unix(1700000000, 5),
+		SeverityNumber: 13,
+		SeverityText:   "WARN",
+		Body:           "job failed",
+		Attributes: []LogAttribute{
+			{Key: "job_id", Value: "abc"},
+			{Key: "attempt", Value: int64(3)},
+			{Key: "ratio", Value: 0.5},
+			{Key: "retried", Value: true},
+		},
+	}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "/v1/logs", got.URL.Path)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", got.Header.Get("Authorization"))
+
+	resourceLogs := (*body)["resourceLogs"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "league-matrix"}}},
+		resourceLogs["resource"].(map[string]any)["attributes"])
+	scopeLogs := resourceLogs["scopeLogs"].([]any)[0].(map[string]any)
+	assert.Equal(t, otlpScopeName, scopeLogs["scope"].(map[string]any)["name"])
+
+	record := scopeLogs["logRecords"].([]any)[0].(map[string]any)
+	assert.Equal(t, "1700000000000000005", record["timeUnixNano"])
+	assert.NotEmpty(t, record["observedTimeUnixNano"])
+	assert.Equal(t, float64(13), record["severityNumber"])
+	assert.Equal(t, "WARN", record["severityText"])
+	assert.Equal(t, map[string]any{"stringValue": "job failed"}, record["body"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "job_id", "value": map[string]any{"stringValue": "abc"}},
+		map[string]any{"key": "attempt", "value": map[string]any{"intValue": "3"}},
+		map[string]any{"key": "ratio", "value": map[string]any{"doubleValue": 0.5}},
+		map[string]any{"key": "retried", "value": map[string]any{"boolValue": true}},
+	}, record["attributes"])
+}
+
+func TestOTLPRepository_ExportMetrics(t *testing.T) {
+	server, got, body := collect(t, http.StatusOK)
+	repo := newTestOTLPRepository(t, OTLPConfig{Protocol: OTLPProtocolHTTPJSON, MetricsEndpoint: server.URL + "/v1/metrics", Timeout: time.Second})
+	start, now := time.Unix(1700000000, 0), time.Unix(1700000060, 0)
+
+	err := repo.ExportMetrics(context.Background(), []*MetricRecord{
+		{
+			Name: "requests", Kind: "counter", StartTime: start, Time: now,
+			Points: []MetricPointRecord{{Attributes: map[string]string{"route": "/matrix"}, Value: 4}},
+		},
+		{
+			Name: "go.goroutine.count", Unit: "{goroutine}", Kind: "gauge", StartTime: start, Time: now,
+			Points: []MetricPointRecord{{Value: 12}},
+		},
+		{
+			Name: "http.server.request.duration", Unit: "s", Kind: "histogram", Bounds: []float64{0.1, 1},
+			StartTime: start, Time: now,
+			Points: []MetricPointRecord{{Count: 3, Sum: 1.5, BucketCounts: []uint64{1, 1, 1}}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/metrics", got.URL.Path)
+
+	resourceMetrics := (*body)["resourceMetrics"].([]any)[0].(map[string]any)
+	metrics := resourceMetrics["scopeMetrics"].([]any)[0].(map[string]any)["metrics"].([]any)
+	if !assert.Len(t, metrics, 3) {
+		return
+	}
+
+	assert.Equal(t, map[string]any{
+		"aggregationTemporality": float64(otlpCumulative),
+		"isMonotonic":            true,
+		"dataPoints": []any{map[string]any{
+			"attributes":        []any{map[string]any{"key": "route", "value": map[string]any{"stringValue": "/matrix"}}},
+			"startTimeUnixNano": "1700000000000000000",
+			"timeUnixNano":      "1700000060000000000",
+			"asDouble":          float64(4),
+		}},
+	}, metrics[0].(map[string]any)["sum"])
+
+	gauge := metrics[1].(map[string]any)
+	assert.Equal(t, "{goroutine}", gauge["unit"])
+	assert.Equal(t, map[string]any{"dataPoints": []any{map[string]any{
+		"timeUnixNano": "1700000060000000000",
+		"asDouble":     float64(12),
+	}}}, gauge["gauge"])
+
+	assert.Equal(t, map[string]any{
+		"aggregationTemporality": float64(otlpCumulative),
+		"dataPoints": []any{map[string]any{
+			"startTimeUnixNano": "1700000000000000000",
+			"timeUnixNano":      "1700000060000000000",
+			"count":             "3",
+			"sum":               1.5,
+			"bucketCounts":      []any{"1", "1", "1"},
+			"explicitBounds":    []any{0.1, float64(1)},
+		}},
+	}, metrics[2].(map[string]any)["histogram"])
+}
+
+func TestOTLPRepository_Protobuf(t *testing.T) {
+	var contentType string
+	request := &collogspb.ExportLogsServiceRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		assert.NoError(t, proto.Unmarshal(data, request))
+	}))
+	defer server.Close()
+	// http/protobuf is the default protocol
+	repo := newTestOTLPRepository(t, OTLPConfig{LogsEndpoint: server.URL + "/v1/logs", Timeout: time.Second})
+
+	err := repo.ExportLogs(context.Background(), []*LogRecord{{
+		Time: time.Unix(1700000000, 5), SeverityNumber: 9, SeverityText: "INFO", Body: "job done",
+		Attributes: []LogAttribute{{Key: "attempt", Value: int64(3)}},
+	}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-protobuf", contentType)
+	record := request.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()[0]
+	assert.Equal(t, uint64(1700000000000000005), record.GetTimeUnixNano())
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, record.GetSeverityNumber())
+	assert.Equal(t, "job done", record.GetBody().GetStringValue())
+	assert.Equal(t, int64(3), record.GetAttributes()[0].GetValue().GetIntValue())
+}
+
+// grpcCollector records the export requests it receives over gRPC and responds with err.
+type grpcCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+	colmetricspb.UnimplementedMetricsServiceServer
+
+	err      error
+	logs     chan *collogspb.ExportLogsServiceRequest
+	metrics  chan *colmetricspb.ExportMetricsServiceRequest
+	metadata chan metadata.MD
+}
+
+func (c *grpcCollector) Export(ctx context.Context, request *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c.metadata <- md
+	c.logs <- request
+	return &collogspb.ExportLogsServiceResponse{}, c.err
+}
+
+// metricsService serves the metrics of the collector, whose Export method serves the logs.
+type metricsService struct {
+	*grpcCollector
+}
+
+func (s metricsService) Export(_ context.Context, request *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	s.metrics <- request
+	return &colmetricspb.ExportMetricsServiceResponse{}, s.err
+}
+
+// startGRPCCollector starts a gRPC collector responding with err and returns its endpoint.
+func startGRPCCollector(t *testing.T, err error) (*grpcCollector, string) {
+	t.Helper()
+
+	listener, listenErr := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, listenErr)
+	collector := &grpcCollector{
+		err:      err,
+		logs:     make(chan *collogspb.ExportLogsServiceRequest, 1),
+		metrics:  make(chan *colmetricspb.ExportMetricsServiceRequest, 1),
+		metadata: make(chan metadata.MD, 1),
+	}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, collector)
+	colmetricspb.RegisterMetricsServiceServer(server, metricsService{collector})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return collector, "http://" + listener.Addr().String()
+}
+
+func TestOTLPRepository_GRPC(t *testing.T) {
+	t.Run("exports logs and metrics", func(t *testing.T) {
+		collector, endpoint := startGRPCCollector(t, nil)
+		repo := newTestOTLPRepository(t, OTLPConfig{
+			Protocol:        OTLPProtocolGRPC,
+			LogsEndpoint:    endpoint,
+			MetricsEndpoint: endpoint,
+			Headers:         map[string]string{"Authorization": "Bearer token"},
+			Timeout:         5 * time.Second,
+			Resource:        map[string]string{"service.name": "league-matrix"},
+		})
+
+		err := repo.ExportLogs(context.Background(), []*LogRecord{{Body: "job failed", SeverityNumber: 17}})
+		assert.NoError(t, err)
+		logs := <-collector.logs
+		assert.Equal(t, "job failed", logs.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()[0].GetBody().GetStringValue())
+		assert.Equal(t, "league-matrix", logs.GetResourceLogs()[0].GetResource().GetAttributes()[0].GetValue().GetStringValue())
+		assert.Equal(t, []string{"Bearer token"}, (<-collector.metadata).Get("authorization"))
+
+		err = repo.ExportMetrics(context.Background(), []*MetricRecord{{Name: "go.goroutine.count", Kind: "gauge", Points: []MetricPointRecord{{Value: 12}}}})
+		assert.NoError(t, err)
+		metric := (<-collector.metrics).GetResourceMetrics()[0].GetScopeMetrics()[0].GetMetrics()[0]
+		assert.Equal(t, "go.goroutine.count", metric.GetName())
+		assert.Equal(t, 12.0, metric.GetGauge().GetDataPoints()[0].GetAsDouble())
+	})
+
+	tests := []struct {
+		name    string
+		err     error
+		errType error
+	}{
+		{name: "collector unavailable", err: status.Error(codes.Unavailable, "overloaded"), errType: apperrors.ErrServiceUnavailable},
+		{name: "rate limited", err: status.Error(codes.ResourceExhausted, "slow down"), errType: apperrors.ErrServiceUnavailable},
+		{name: "rejected", err: status.Error(codes.InvalidArgument, "bad request")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector, endpoint := startGRPCCollector(t, tt.err)
+			repo := newTestOTLPRepository(t, OTLPConfig{Protocol: OTLPProtocolGRPC, LogsEndpoint: endpoint, Timeout: 5 * time.Second})
+
+			err := repo.ExportLogs(context.Background(), []*LogRecord{{Body: "hello"}})
+			<-collector.logs
+
+			assert.ErrorContains(t, err, "responded with status")
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				assert.NotErrorIs(t, err, apperrors.ErrServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestNewOTLPRepository(t *testing.T) {
+	_, err := NewOTLPRepository(OTLPConfig{Protocol: "grpc/json"})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+	_, err = NewOTLPRepository(OTLPConfig{Protocol: OTLPProtocolGRPC, LogsEndpoint: "collector:4317"})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestOTLPRepository_Status(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+		errType error
+	}{
+		{name: "accepted", status: http.StatusAccepted},
+		{name: "rate limited", status: http.StatusTooManyRequests, wantErr: true, errType: apperrors.ErrServiceUnavailable},
+		{name: "collector unavailable", status: http.StatusServiceUnavailable, wantErr: true, errType: apperrors.ErrServiceUnavailable},
+		{name: "rejected", status: http.StatusBadRequest, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _, _ := collect(t, tt.status)
+			repo := newTestOTLPRepository(t, OTLPConfig{Protocol: OTLPProtocolHTTPJSON, LogsEndpoint: server.URL, Timeout: time.Second})
+
+			err := repo.ExportLogs(context.Background(), []*LogRecord{{Body: "hello"}})
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, "responded with status")
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				assert.NotErrorIs(t, err, apperrors.ErrServiceUnavailable)
+			}
+		})
+	}
+
+	t.Run("unreachable collector", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		repo := newTestOTLPRepository(t, OTLPConfig{MetricsEndpoint: server.URL, Timeout: time.Second})
+
+		err := repo.ExportMetrics(context.Background(), nil)
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		repo := newTestOTLPRepository(t, OTLPConfig{LogsEndpoint: "http://127.0.0.1:1", Timeout: time.Second})
+
+		err := repo.ExportLogs(ctx, nil)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,390 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// retentionDir holds the expiry of stored matrix files and the trash of deleted ones.
+// It lives outside testdata/ so deleted files cannot be read through the matrix endpoints.
+const retentionDir = ".retention"
+
+const (
+	// expiryFile is the file, inside the retention directory, mapping matrix files to the time they expire.
+	expiryFile = "expiry.json"
+
+	// trashDir is the directory, inside the retention directory, holding deleted matrix files under their original path.
+	trashDir = "trash"
+)
+
+// RetentionRepositoryInterface defines the data access contract for the retention of stored matrix files:
+// when they expire, and the trash deleted files wait in until they are restored or purged.
+type RetentionRepositoryInterface interface {
+	// SetExpiry records that the matrix file at filePath expires at expiresAt; the zero time clears its expiry.
+	// Setting an expiry returns ErrNotFound when no such file is stored.
+	SetExpiry(ctx context.Context, filePath string, expiresAt time.Time) error
+
+	// ListExpired returns the matrix files that expire at now or earlier, whichever tenant they belong to.
+	ListExpired(ctx context.Context, now time.Time) ([]string, error)
+
+	// Trash moves the matrix file at filePath to the trash, recording that it was deleted at deletedAt,
+	// and clears its expiry. A file deleted earlier under the same path is replaced.
+	// It returns ErrNotFound when no such file is stored.
+	Trash(ctx context.Context, filePath string, deletedAt time.Time) error
+
+	// Restore moves the matrix file deleted from filePath back in place. It returns ErrNotFound when no such file
+	// is in the trash, ErrConflict when another file was stored at filePath since, and ErrPayloadTooLarge when
+	// the file would take the tenant carried by ctx over its storage limit.
+	Restore(ctx context.Context, filePath string) error
+
+	// ListTrash returns the files in the trash, whichever tenant they belong to, the most recently deleted first.
+	ListTrash(ctx context.Context) ([]*TrashRecord, error)
+
+	// Purge removes the file deleted from filePath from the trash for good, together with its prior versions
+	// and tags unless another file was stored at filePath since.
+	Purge(ctx context.Context, filePath string) error
+}
+
+// TrashRecord describes a matrix file in the trash.
+type TrashRecord struct {
+	FilePath  string
+	DeletedAt time.Time
+}
+
+type retentionRepository struct {
+	dir string
+
+	// mu serializes moves in and out of the trash, and guards expiry and the expiry file it is saved to
+	mu     sync.Mutex
+	expiry map[string]time.Time
+}
+
+// NewRetentionRepository creates a new instance of RetentionRepositoryInterface.
+// It returns a repository that keeps the retention state on the local file system, loading
+// the expiry of the stored matrix files recorded before a restart.
+func NewRetentionRepository() (RetentionRepositoryInterface, error) {
+	r := &retentionRepository{
+		dir:    retentionDir,
+		expiry: make(map[string]time.Time),
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.dir, expiryFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read matrix expiry: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.expiry); err != nil {
+		return nil, fmt.Errorf("failed to decode matrix expiry: %w", err)
+	}
+	return r, nil
+}
+
+func (r *retentionRepository) SetExpiry(ctx context.Context, filePath string, expiresAt time.Time) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filePath); err != nil && !expiresAt.IsZero() {
+		return fmt.Errorf("%w: file not found: %s", apperrors.ErrNotFound, filePath)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateExpiry(ctx, filePath, expiresAt)
+}
+
+func (r *retentionRepository) ListExpired(ctx context.Context, now time.Time) ([]string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var expired []string
+	for filePath, expiresAt := range r.expiry {
+		if !expiresAt.After(now) {
+			expired = append(expired, filePath)
+		}
+	}
+	slices.Sort(expired)
+	return expired, nil
+}
+
+func (r *retentionRepository) Trash(ctx context.Context, filePath string, deletedAt time.Time) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trashPath := r.trashPath(filePath)
+	if err := os.MkdirAll(filepath.Dir(trashPath), 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create trash directory",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	if err := moveToTrash(filePath, trashPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: file not found: %s", apperrors.ErrNotFound, filePath)
+		}
+		slog.ErrorContext(ctx, "failed to move file to trash",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	// The modification time of a file in the trash is when it was deleted
+	if err := os.Chtimes(trashPath, deletedAt, deletedAt); err != nil {
+		slog.WarnContext(ctx, "failed to record deletion time",
+			"file_path", filePath,
+			"error", err)
+	}
+
+	// The file is deleted whether or not its expiry is cleared; an expiry left behind is cleared by the next cleanup
+	if _, ok := r.expiry[filePath]; ok {
+		_ = r.updateExpiry(ctx, filePath, time.Time{})
+	}
+	return nil
+}
+
+func (r *retentionRepository) Restore(ctx context.Context, filePath string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trashPath := r.trashPath(filePath)
+	info, err := os.Stat(trashPath)
+	if err != nil {
+		return fmt.Errorf("%w: no deleted file to restore: %s", apperrors.ErrNotFound, filePath)
+	}
+	if err := checkStorageQuota(ctx, info.Size()); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create directory",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	// Linking fails when a file exists at the path, so a file stored since the deletion is never replaced
+	if err := os.Link(trashPath, filePath); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%w: file already exists: %s", apperrors.ErrConflict, filePath)
+		}
+		slog.ErrorContext(ctx, "failed to restore file",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+	if err := os.Remove(trashPath); err != nil {
+		slog.WarnContext(ctx, "failed to remove restored file from trash",
+			"file_path", filePath,
+			"error", err)
+	}
+	return nil
+}
+
+func (r *retentionRepository) ListTrash(ctx context.Context) ([]*TrashRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	root := filepath.Join(r.dir, trashDir)
+	var records []*TrashRecord
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		filePath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		records = append(records, &TrashRecord{FilePath: filepath.ToSlash(filePath), DeletedAt: info.ModTime()})
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	slices.SortFunc(records, func(a, b *TrashRecord) int {
+		if c := b.DeletedAt.Compare(a.DeletedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.FilePath, b.FilePath)
+	})
+	return records, nil
+}
+
+func (r *retentionRepository) Purge(ctx context.Context, filePath string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.Remove(r.trashPath(filePath)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: no deleted file to purge: %s", apperrors.ErrNotFound, filePath)
+		}
+		slog.ErrorContext(ctx, "failed to purge file",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to purge file: %w", err)
+	}
+	if err := removeVersions(filePath); err != nil {
+		slog.WarnContext(ctx, "failed to remove prior versions of purged file",
+			"file_path", filePath,
+			"error", err)
+	}
+	if err := removeTags(filePath); err != nil {
+		slog.WarnContext(ctx, "failed to remove tags of purged file",
+			"file_path", filePath,
+			"error", err)
+	}
+	return nil
+}
+
+// moveToTrash moves the file at filePath to trashPath. Files sharing their content with other stored files,
+// such as deduplicated uploads, are copied instead, since the modification time recording their deletion
+// would change for every file sharing it.
+func moveToTrash(filePath string, trashPath string) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	if linkCount(info) == 1 {
+		return os.Rename(filePath, trashPath)
+	}
+
+	src, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(trashPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Remove(filePath)
+	}
+	if err != nil {
+		_ = os.Remove(trashPath)
+	}
+	return err
+}
+
+// trashPath returns where the file deleted from filePath is kept in the trash.
+func (r *retentionRepository) trashPath(filePath string) string {
+	return filepath.Join(r.dir, trashDir, filepath.FromSlash(filePath))
+}
+
+// updateExpiry sets or, for the zero time, clears the expiry of filePath and saves every expiry.
+// The file is written aside and renamed over the previous one, so a crash never leaves it truncated.
+// The caller must hold mu.
+func (r *retentionRepository) updateExpiry(ctx context.Context, filePath string, expiresAt time.Time) error {
+	previous, hadExpiry := r.expiry[filePath]
+	if expiresAt.IsZero() {
+		delete(r.expiry, filePath)
+	} else {
+		r.expiry[filePath] = expiresAt
+	}
+
+	err := r.saveExpiry()
+	if err != nil {
+		// Keep the expiry in memory as it is on disk
+		if hadExpiry {
+			r.expiry[filePath] = previous
+		} else {
+			delete(r.expiry, filePath)
+		}
+		slog.ErrorContext(ctx, "failed to save matrix expiry",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to save matrix expiry: %w", err)
+	}
+	return nil
+}
+
+func (r *retentionRepository) saveExpiry() error {
+	data, err := json.Marshal(r.expiry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(r.dir, ".expiry-*")
+	if err != nil {
+		return err
+	}
+	_, err = temp.Write(data)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), filepath.Join(r.dir, expiryFile))
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+	}
+	return err
+}
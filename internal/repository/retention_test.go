@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// writeMatrixFile stores a small matrix file at filePath, relative to the working directory.
+func writeMatrixFile(t *testing.T, filePath string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	assert.NoError(t, os.WriteFile(filePath, []byte("1,2\n3,4\n"), 0o644))
+}
+
+func TestRetentionRepository_Expiry(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	writeMatrixFile(t, "testdata/a.csv")
+	writeMatrixFile(t, "testdata/b.csv")
+
+	repo, err := NewRetentionRepository()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.SetExpiry(ctx, "testdata/a.csv", now))
+	assert.NoError(t, repo.SetExpiry(ctx, "testdata/b.csv", now.Add(time.Hour)))
+	assert.ErrorIs(t, repo.SetExpiry(ctx, "testdata/missing.csv", now), apperrors.ErrNotFound)
+
+	expired, err := repo.ListExpired(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testdata/a.csv"}, expired)
+
+	// The expiry survives a restart
+	repo, err = NewRetentionRepository()
+	assert.NoError(t, err)
+	expired, err = repo.ListExpired(ctx, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testdata/a.csv", "testdata/b.csv"}, expired)
+
+	// Clearing an expiry keeps the file
+	assert.NoError(t, repo.SetExpiry(ctx, "testdata/a.csv", time.Time{}))
+	expired, err = repo.ListExpired(ctx, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testdata/b.csv"}, expired)
+	assert.FileExists(t, "testdata/a.csv")
+}
+
+func TestRetentionRepository_TrashAndRestore(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	deletedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	writeMatrixFile(t, "testdata/out.csv")
+
+	repo, err := NewRetentionRepository()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.SetExpiry(ctx, "testdata/out.csv", deletedAt.Add(time.Hour)))
+
+	assert.NoError(t, repo.Trash(ctx, "testdata/out.csv", deletedAt))
+	assert.NoFileExists(t, "testdata/out.csv")
+	assert.ErrorIs(t, repo.Trash(ctx, "testdata/out.csv", deletedAt), apperrors.ErrNotFound)
+
+	// A deleted file no longer expires
+	expired, err := repo.ListExpired(ctx, deletedAt.Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, expired)
+
+	trash, err := repo.ListTrash(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, trash, 1) {
+		assert.Equal(t, "testdata/out.csv", trash[0].FilePath)
+		assert.True(t, deletedAt.Equal(trash[0].DeletedAt))
+	}
+
+	// A file stored at the same path since the deletion is never replaced
+	writeMatrixFile(t, "testdata/out.csv")
+	assert.ErrorIs(t, repo.Restore(ctx, "testdata/out.csv"), apperrors.ErrConflict)
+	assert.NoError(t, os.Remove("testdata/out.csv"))
+
+	assert.NoError(t, repo.Restore(ctx, "testdata/out.csv"))
+	data, err := os.ReadFile("testdata/out.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2\n3,4\n", string(data))
+	assert.ErrorIs(t, repo.Restore(ctx, "testdata/out.csv"), apperrors.ErrNotFound)
+
+	trash, err = repo.ListTrash(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+}
+
+func TestRetentionRepository_TrashSharedFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	deletedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	writeMatrixFile(t, "testdata/a.csv")
+	assert.NoError(t, os.Link("testdata/a.csv", "testdata/b.csv"))
+	before, err := os.Stat("testdata/b.csv")
+	assert.NoError(t, err)
+
+	repo, err := NewRetentionRepository()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Trash(ctx, "testdata/a.csv", deletedAt))
+
+	// The file sharing the content is left as it was
+	after, err := os.Stat("testdata/b.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, before.ModTime(), after.ModTime())
+	trash, err := repo.ListTrash(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, trash, 1) {
+		assert.True(t, deletedAt.Equal(trash[0].DeletedAt))
+	}
+
+	assert.NoError(t, repo.Restore(ctx, "testdata/a.csv"))
+	data, err := os.ReadFile("testdata/a.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2\n3,4\n", string(data))
+}
+
+func TestRetentionRepository_Purge(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	writeMatrixFile(t, "testdata/out.csv")
+
+	repo, err := NewRetentionRepository()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Trash(ctx, "testdata/out.csv", time.Now()))
+
+	assert.NoError(t, repo.Purge(ctx, "testdata/out.csv"))
+	assert.ErrorIs(t, repo.Purge(ctx, "testdata/out.csv"), apperrors.ErrNotFound)
+	assert.ErrorIs(t, repo.Restore(ctx, "testdata/out.csv"), apperrors.ErrNotFound)
+}
+
+func TestRetentionRepository_PurgeVersions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	writeMatrixFile(t, "testdata/a.csv")
+	writeMatrixFile(t, "testdata/b.csv")
+	versions := NewVersionRepository()
+	for _, filePath := range []string{"testdata/a.csv", "testdata/b.csv"} {
+		_, err := versions.Update(ctx, filePath, []string{"*"}, &MatrixFileContent{Content: [][]string{{"1"}}})
+		assert.NoError(t, err)
+	}
+
+	repo, err := NewRetentionRepository()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Trash(ctx, "testdata/a.csv", time.Now()))
+	assert.NoError(t, repo.Trash(ctx, "testdata/b.csv", time.Now()))
+	writeMatrixFile(t, "testdata/b.csv")
+
+	assert.NoError(t, repo.Purge(ctx, "testdata/a.csv"))
+	assert.NoError(t, repo.Purge(ctx, "testdata/b.csv"))
+
+	_, err = os.Stat(versionsPath("testdata/a.csv"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	// The file stored at the path since carries on the versions
+	assert.FileExists(t, versionsPath("testdata/b.csv")+"/v1")
+}
+
+func TestRetentionRepository_TenantAccess(t *testing.T) {
+	t.Chdir(t.TempDir())
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	globex := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex"})
+	writeMatrixFile(t, "testdata/tenants/acme/matrix.csv")
+
+	repo, err := NewRetentionRepository()
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, repo.Trash(globex, "testdata/tenants/acme/matrix.csv", time.Now()), apperrors.ErrNotFound)
+	assert.ErrorIs(t, repo.Trash(context.Background(), "testdata/tenants/acme/matrix.csv", time.Now()), apperrors.ErrNotFound)
+	assert.NoError(t, repo.Trash(acme, "testdata/tenants/acme/matrix.csv", time.Now()))
+	assert.ErrorIs(t, repo.Restore(globex, "testdata/tenants/acme/matrix.csv"), apperrors.ErrNotFound)
+
+	// Restoring counts against the tenant's storage limit
+	tooSmall := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme", Limits: tenant.Limits{MaxStorageBytes: 4}})
+	assert.ErrorIs(t, repo.Restore(tooSmall, "testdata/tenants/acme/matrix.csv"), apperrors.ErrPayloadTooLarge)
+	assert.NoError(t, repo.Restore(acme, "testdata/tenants/acme/matrix.csv"))
+}
+
+func TestNewRetentionRepository_CorruptExpiry(t *testing.T) {
+	t.Chdir(t.TempDir())
+	assert.NoError(t, os.MkdirAll(retentionDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(retentionDir, expiryFile), []byte("{"), 0o644))
+
+	_, err := NewRetentionRepository()
+
+	assert.Error(t, err)
+}
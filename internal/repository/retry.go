@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// RetryConfig controls how a remote repository backend is retried on transient errors.
+// The delay before attempt n+1 is InitialBackoff*Multiplier^(n-1), capped at MaxBackoff,
+// with full jitter applied so concurrent requests do not retry in lockstep.
+type RetryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultRetryConfig returns the retry policy used for remote backends:
+// up to 3 attempts starting at 100ms and never waiting more than 2s between attempts.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+type retryMatrixRepository struct {
+	next   MatrixRepositoryInterface
+	config RetryConfig
+}
+
+// NewRetryMatrixRepository creates a new instance of MatrixRepositoryInterface that retries next on transient errors.
+// It is meant to wrap remote backends; errors that are not transient, such as invalid or missing files,
+// are returned immediately. Retries stop early when the next backoff would overrun the request context deadline.
+func NewRetryMatrixRepository(next MatrixRepositoryInterface, config RetryConfig) MatrixRepositoryInterface {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	if config.Multiplier < 1 {
+		config.Multiplier = 1
+	}
+
+	return &retryMatrixRepository{
+		next:   next,
+		config: config,
+	}
+}
+
+func (r *retryMatrixRepository) GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error) {
+	var content *MatrixFileContent
+	err := retry(ctx, r.config, slog.Attr{}, func() (bool, error) {
+		var err error
+		content, err = r.next.GetFileContent(ctx, filePath)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func (r *retryMatrixRepository) StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error {
+	return retry(ctx, r.config, slog.Attr{}, func() (bool, error) {
+		// Rows already passed to handleRow cannot be taken back, so only failures
+		// that happen before the first row are safe to retry
+		delivered := false
+		err := r.next.StreamFileContent(ctx, filePath, func(row []string) error {
+			delivered = true
+			return handleRow(row)
+		})
+		return !delivered, err
+	})
+}
+
+func (r *retryMatrixRepository) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	var data []byte
+	err := retry(ctx, r.config, slog.Attr{}, func() (bool, error) {
+		var err error
+		data, err = r.next.ReadFile(ctx, filePath)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *retryMatrixRepository) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	var modTime time.Time
+	err := retry(ctx, r.config, slog.Attr{}, func() (bool, error) {
+		var err error
+		modTime, err = r.next.FileModTime(ctx, filePath)
+		return true, err
+	})
+	return modTime, err
+}
+
+func (r *retryMatrixRepository) StatFile(ctx context.Context, filePath string) (FileStat, error) {
+	var stat FileStat
+	err := retry(ctx, r.config, slog.Attr{}, func() (bool, error) {
+		var err error
+		stat, err = r.next.StatFile(ctx, filePath)
+		return true, err
+	})
+	return stat, err
+}
+
+// StreamContent is not retried: a stream cannot be read again once it has been consumed.
+func (r *retryMatrixRepository) StreamContent(ctx context.Context, reader io.Reader, handleRow RowHandler) error {
+	return r.next.StreamContent(ctx, reader, handleRow)
+}
+
+func (r *retryMatrixRepository) SaveFileContent(ctx context.Context, filePath string, content *MatrixFileContent) error {
+	return retry(ctx, r.config, slog.Attr{}, func() (bool, error) {
+		return true, r.next.SaveFileContent(ctx, filePath, content)
+	})
+}
+
+func (r *retryMatrixRepository) ListFiles(ctx context.Context) ([]string, error) {
+	var files []string
+	err := retry(ctx, r.config, slog.String("file_path", config.CurrentLimits().DataDir), func() (bool, error) {
+		var err error
+		files, err = r.next.ListFiles(ctx)
+		return true, err
+	})
+	return files, err
+}
+
+// CheckHealth is not retried, so a health check reports the state of the backend as it is.
+func (r *retryMatrixRepository) CheckHealth(ctx context.Context) error {
+	return r.next.CheckHealth(ctx)
+}
+
+// retry runs attempt until it succeeds, fails with a permanent error, reports that it must not be
+// repeated, or the attempts or context deadline run out. It returns the last error seen.
+// target identifies what is being retried in log messages. It is left empty for the files of a request,
+// which the request scope of ctx already identifies, and slog leaves empty attributes out.
+func retry(ctx context.Context, config RetryConfig, target slog.Attr, attempt func() (retryable bool, err error)) error {
+	backoff := config.InitialBackoff
+	for n := 1; ; n++ {
+		retryable, err := attempt()
+		if err == nil || !retryable || !isTransient(err) || n >= config.MaxAttempts {
+			return err
+		}
+
+		delay := jitter(backoff)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			slog.WarnContext(ctx, "not retrying remote backend, deadline too close",
+				target,
+				"attempt", n,
+				"error", err)
+			return err
+		}
+
+		slog.WarnContext(ctx, "retrying remote backend after transient error",
+			target,
+			"attempt", n,
+			"delay", delay,
+			"error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff = min(time.Duration(float64(backoff)*config.Multiplier), config.MaxBackoff)
+	}
+}
+
+// isTransient reports whether err is worth retrying: failures marked as ErrServiceUnavailable
+// by a backend and network timeouts. Context cancellation is never retried.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, apperrors.ErrServiceUnavailable) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// jitter returns a random duration in [0, d] ("full jitter").
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1)
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// flakyRepository fails with the queued errors, one per call, before serving rows.
+type flakyRepository struct {
+	errs  []error
+	rows  [][]string
+	calls int
+	// failAfterRows makes StreamFileContent fail after delivering its rows instead of before.
+	failAfterRows bool
+}
+
+func (f *flakyRepository) nextErr() error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func (f *flakyRepository) GetFileContent(ctx context.Context, filePath string) (*MatrixFileContent, error) {
+	if err := f.nextErr(); err != nil {
+		return nil, err
+	}
+	return &MatrixFileContent{Content: f.rows}, nil
+}
+
+func (f *flakyRepository) StreamFileContent(ctx context.Context, filePath string, handleRow RowHandler) error {
+	err := f.nextErr()
+	if err != nil && !f.failAfterRows {
+		return err
+	}
+	for _, row := range f.rows {
+		if err := handleRow(row); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (f *flakyRepository) ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	if err := f.nextErr(); err != nil {
+		return nil, err
+	}
+	return []byte("1,2\n"), nil
+}
+
+func (f *flakyRepository) FileModTime(ctx context.Context, filePath string) (time.Time, error) {
+	if err := f.nextErr(); err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), nil
+}
+
+func (f *flakyRepository) StatFile(ctx context.Context, filePath string) (FileStat, error) {
+	if err := f.nextErr(); err != nil {
+		return FileStat{}, err
+	}
+	return FileStat{ModTime: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), Size: 4}, nil
+}
+
+func (f *flakyRepository) StreamContent(ctx context.Context, r io.Reader, handleRow RowHandler) error {
+	return f.StreamFileContent(ctx, "", handleRow)
+}
+
+func (f *flakyRepository) SaveFileContent(ctx context.Context, filePath string, content *MatrixFileContent) error {
+	return f.nextErr()
+}
+
+func (f *flakyRepository) ListFiles(ctx context.Context) ([]string, error) {
+	if err := f.nextErr(); err != nil {
+		return nil, err
+	}
+	return []string{"testdata/matrix1.csv"}, nil
+}
+
+func (f *flakyRepository) CheckHealth(ctx context.Context) error {
+	return f.nextErr()
+}
+
+func testRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryMatrixRepository_GetFileContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "succeeds without retrying",
+			wantCalls: 1,
+		},
+		{
+			name:      "retries transient errors until success",
+			errs:      []error{apperrors.ErrServiceUnavailable, timeoutError{}},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after max attempts",
+			errs:      []error{apperrors.ErrServiceUnavailable, apperrors.ErrServiceUnavailable, apperrors.ErrServiceUnavailable, nil},
+			wantCalls: 3,
+			wantErr:   apperrors.ErrServiceUnavailable,
+		},
+		{
+			name:      "permanent errors are not retried",
+			errs:      []error{apperrors.ErrNotFound},
+			wantCalls: 1,
+			wantErr:   apperrors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &flakyRepository{errs: tt.errs, rows: [][]string{{"1", "2"}}}
+			repo := NewRetryMatrixRepository(next, testRetryConfig())
+
+			got, err := repo.GetFileContent(context.Background(), "remote/matrix.csv")
+
+			assert.Equal(t, tt.wantCalls, next.calls)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, [][]string{{"1", "2"}}, got.Content)
+			}
+		})
+	}
+}
+
+func TestRetryMatrixRepository_StreamFileContent(t *testing.T) {
+	t.Run("retries failures before the first row", func(t *testing.T) {
+		next := &flakyRepository{errs: []error{apperrors.ErrServiceUnavailable}, rows: [][]string{{"1"}, {"2"}}}
+		repo := NewRetryMatrixRepository(next, testRetryConfig())
+
+		var rows [][]string
+		err := repo.StreamFileContent(context.Background(), "remote/matrix.csv", func(row []string) error {
+			rows = append(rows, row)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, next.calls)
+		assert.Equal(t, [][]string{{"1"}, {"2"}}, rows)
+	})
+
+	t.Run("does not retry once rows were delivered", func(t *testing.T) {
+		next := &flakyRepository{errs: []error{apperrors.ErrServiceUnavailable}, rows: [][]string{{"1"}}, failAfterRows: true}
+		repo := NewRetryMatrixRepository(next, testRetryConfig())
+
+		var rows int
+		err := repo.StreamFileContent(context.Background(), "remote/matrix.csv", func(row []string) error {
+			rows++
+			return nil
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.Equal(t, 1, next.calls)
+		assert.Equal(t, 1, rows)
+	})
+}
+
+func TestRetryMatrixRepository_ContextDeadline(t *testing.T) {
+	t.Run("stops when the backoff would overrun the deadline", func(t *testing.T) {
+		next := &flakyRepository{errs: []error{apperrors.ErrServiceUnavailable, apperrors.ErrServiceUnavailable}}
+		config := testRetryConfig()
+		config.InitialBackoff = time.Hour
+		config.MaxBackoff = time.Hour
+		repo := NewRetryMatrixRepository(next, config)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// The jittered delay can be anywhere in [0, 1h]; with a 1s deadline a retry is all but impossible
+		_, err := repo.GetFileContent(ctx, "remote/matrix.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+		assert.LessOrEqual(t, next.calls, 2)
+	})
+
+	t.Run("cancellation is not retried", func(t *testing.T) {
+		next := &flakyRepository{errs: []error{context.Canceled}}
+		repo := NewRetryMatrixRepository(next, testRetryConfig())
+
+		_, err := repo.GetFileContent(context.Background(), "remote/matrix.csv")
+
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 1, next.calls)
+	})
+}
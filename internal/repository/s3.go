@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// S3Scheme is the scheme of the addresses of objects in object storage, such as s3://bucket/league.csv.
+	S3Scheme = "s3"
+
+	// s3RequestTimeout bounds a single request to object storage.
+	s3RequestTimeout = 30 * time.Second
+
+	// s3DefaultRegion is the region requests are signed for unless configured otherwise.
+	s3DefaultRegion = "us-east-1"
+
+	// sigV4Algorithm names the AWS Signature Version 4 signing algorithm.
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+)
+
+// S3Credentials are the access keys requests to object storage are signed with.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Config locates S3-compatible object storage. Endpoint is the base URL of the service, such as
+// http://localhost:9000 for MinIO, and defaults to the AWS endpoint of Region, which defaults to us-east-1.
+// Objects are addressed in path style, as {endpoint}/{bucket}/{key}, which every S3-compatible service supports.
+// Credentials is called before every request, so rotated keys take effect without a restart.
+type S3Config struct {
+	Endpoint    string
+	Region      string
+	Credentials func(ctx context.Context) (S3Credentials, error)
+}
+
+// s3Client sends requests to S3-compatible object storage, signed with AWS Signature Version 4.
+type s3Client struct {
+	client   *http.Client
+	endpoint string
+	region   string
+	config   S3Config
+	// now returns the current time; tests replace it to sign at a fixed time
+	now func() time.Time
+}
+
+// newS3Client creates a client of the object storage described by config.
+func newS3Client(config S3Config) *s3Client {
+	region := config.Region
+	if region == "" {
+		region = s3DefaultRegion
+	}
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	return &s3Client{
+		client:   &http.Client{Timeout: s3RequestTimeout},
+		endpoint: strings.TrimRight(endpoint, "/"),
+		region:   region,
+		config:   config,
+		now:      time.Now,
+	}
+}
+
+// putObject stores body as the object key of bucket, replacing any object of the same key.
+func (c *s3Client) putObject(ctx context.Context, bucket, key string, body []byte, contentType string) error {
+	resp, err := c.send(ctx, http.MethodPut, bucket, key, body, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: bucket not found: %s", apperrors.ErrNotFound, bucket)
+	case resp.StatusCode != http.StatusOK:
+		return statusError(resp, bucket, key)
+	}
+	return nil
+}
+
+// getObject returns the response holding the object key of bucket, whose body the caller must close.
+// The size of the object is the ContentLength of the response, and its modification time the Last-Modified header.
+// With head set, only the headers of the object are requested.
+func (c *s3Client) getObject(ctx context.Context, bucket, key string, head bool) (*http.Response, error) {
+	method := http.MethodGet
+	if head {
+		method = http.MethodHead
+	}
+	resp, err := c.send(ctx, method, bucket, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: object not found: %s://%s/%s", apperrors.ErrNotFound, S3Scheme, bucket, key)
+	case resp.StatusCode != http.StatusOK:
+		resp.Body.Close()
+		return nil, statusError(resp, bucket, key)
+	}
+	return resp, nil
+}
+
+// send sends a signed request for the object key of bucket, with body as its payload. It returns the response
+// whatever its status, and an error only when the request could not be sent.
+func (c *s3Client) send(ctx context.Context, method, bucket, key string, body []byte, contentType string) (*http.Response, error) {
+	credentials, err := c.config.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+"/"+bucket+"/"+key, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid object storage address: %v", apperrors.ErrInvalidInput, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	signRequest(req, body, credentials, c.region, c.now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("%w: failed to reach object storage: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	return resp, nil
+}
+
+// statusError returns the error reported for resp, a response to a request for the object key of bucket
+// with a status the caller does not handle itself.
+func statusError(resp *http.Response, bucket, key string) error {
+	switch {
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("%w: object storage denied access to %s/%s", apperrors.ErrForbidden, bucket, key)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("%w: object storage responded with status %d", apperrors.ErrServiceUnavailable, resp.StatusCode)
+	default:
+		return fmt.Errorf("object storage responded with status %d", resp.StatusCode)
+	}
+}
+
+// SplitS3URI returns the bucket and key of uri, the address of an object such as s3://bucket/league.csv,
+// and false when uri is not an object storage address.
+func SplitS3URI(uri string) (bucket, key string, ok bool) {
+	location, ok := strings.CutPrefix(uri, S3Scheme+"://")
+	if !ok {
+		return "", "", false
+	}
+	bucket, key, _ = strings.Cut(location, "/")
+	return bucket, key, true
+}
+
+// signRequest signs req, whose body is payload, with AWS Signature Version 4 for the S3 service of region.
+// Every header set on req is signed along with the host.
+func signRequest(req *http.Request, payload []byte, credentials S3Credentials, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// Headers are signed by lowercase name, sorted, with their values trimmed
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := slices.Sorted(maps.Keys(headers))
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := now.Format("20060102") + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{sigV4Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := []byte("AWS4" + credentials.SecretAccessKey)
+	for _, part := range []string{now.Format("20060102"), region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalURI returns the path of u with each segment escaped as Signature Version 4 expects.
+// S3 paths are escaped once, keeping the slashes between segments.
+func canonicalURI(u *url.URL) string {
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.QueryEscape(segment), "+", "%20")
+	}
+	if uri := strings.Join(segments, "/"); uri != "" {
+		return uri
+	}
+	return "/"
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
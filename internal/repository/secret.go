@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// vaultRequestTimeout bounds a single request to Vault.
+	vaultRequestTimeout = 10 * time.Second
+
+	// maxVaultResponseBytes bounds the size of a Vault response.
+	maxVaultResponseBytes = 1 << 20
+
+	// maxSecretFileBytes bounds the size of a mounted secret file.
+	maxSecretFileBytes = 64 << 10
+)
+
+// SecretRepositoryInterface defines the contract for reading secrets, such as API keys, signing keys
+// and storage credentials, from wherever they are kept.
+type SecretRepositoryInterface interface {
+	// GetSecret returns the current value of the named secret, or ErrNotFound when it is not set.
+	// Backends that cannot be reached fail with ErrServiceUnavailable.
+	GetSecret(ctx context.Context, name string) (string, error)
+
+	// CheckHealth reports whether secrets can be read, returning ErrServiceUnavailable when the store cannot be reached.
+	CheckHealth(ctx context.Context) error
+}
+
+type envSecretRepository struct {
+	lookup func(string) (string, bool)
+}
+
+// NewEnvSecretRepository creates a new instance of SecretRepositoryInterface that reads each secret
+// from the environment variable of the same name. An empty variable counts as not set.
+func NewEnvSecretRepository() SecretRepositoryInterface {
+	return &envSecretRepository{
+		lookup: os.LookupEnv,
+	}
+}
+
+func (r *envSecretRepository) GetSecret(ctx context.Context, name string) (string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	value, ok := r.lookup(name)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: secret not set: %s", apperrors.ErrNotFound, name)
+	}
+	return value, nil
+}
+
+// CheckHealth always succeeds, since the environment is always available.
+func (r *envSecretRepository) CheckHealth(ctx context.Context) error {
+	return ctx.Err()
+}
+
+type fileSecretRepository struct {
+	dir string
+}
+
+// NewFileSecretRepository creates a new instance of SecretRepositoryInterface that reads each secret
+// from the file of the same name in dir, as mounted by Docker and Kubernetes secrets.
+// Files are read on every call, so secrets rotated on disk are picked up without a restart.
+func NewFileSecretRepository(dir string) SecretRepositoryInterface {
+	return &fileSecretRepository{
+		dir: dir,
+	}
+}
+
+func (r *fileSecretRepository) GetSecret(ctx context.Context, name string) (string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("%w: invalid secret name: %q", apperrors.ErrInvalidInput, name)
+	}
+
+	file, err := os.Open(filepath.Join(r.dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: secret not set: %s", apperrors.ErrNotFound, name)
+		}
+		return "", fmt.Errorf("failed to read secret %s: %w", name, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSecretFileBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", name, err)
+	}
+	if len(data) > maxSecretFileBytes {
+		return "", fmt.Errorf("%w: secret %s exceeds %d bytes", apperrors.ErrPayloadTooLarge, name, maxSecretFileBytes)
+	}
+
+	// Editors and echo add a trailing newline that is not part of the secret
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("%w: secret not set: %s", apperrors.ErrNotFound, name)
+	}
+	return value, nil
+}
+
+func (r *fileSecretRepository) CheckHealth(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(r.dir)
+	if err != nil {
+		return fmt.Errorf("%w: secrets directory is not readable: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	defer dir.Close()
+
+	if _, err := dir.ReadDir(1); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: secrets directory is not readable: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	return nil
+}
+
+// VaultConfig locates the secrets kept in HashiCorp Vault.
+// Path is the API path of a KV secret below /v1/, such as "secret/data/league-matrix" for the KV version 2
+// engine mounted at secret/; each secret is a key of that KV secret. The token is read from TokenFile,
+// as written by Vault Agent, on every request when it is set, and taken from Token otherwise.
+type VaultConfig struct {
+	Address   string
+	Token     string
+	TokenFile string
+	Path      string
+}
+
+type vaultSecretRepository struct {
+	client *http.Client
+	config VaultConfig
+}
+
+// vaultResponse is the part of a Vault KV read response holding the secret data.
+type vaultResponse struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// vaultKVv2Data is the secret data of the KV version 2 engine, which nests the data alongside its metadata.
+// Version 1 returns the data directly instead.
+type vaultKVv2Data struct {
+	Data     map[string]any `json:"data"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+// NewVaultSecretRepository creates a new instance of SecretRepositoryInterface that reads secrets from
+// a KV secret in HashiCorp Vault through its HTTP API. Every call reads the latest version of the secret.
+func NewVaultSecretRepository(config VaultConfig) SecretRepositoryInterface {
+	return &vaultSecretRepository{
+		client: &http.Client{Timeout: vaultRequestTimeout},
+		config: config,
+	}
+}
+
+func (r *vaultSecretRepository) GetSecret(ctx context.Context, name string) (string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	token, err := r.token()
+	if err != nil {
+		return "", err
+	}
+
+	url := strings.TrimRight(r.config.Address, "/") + "/v1/" + strings.Trim(r.config.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid Vault address: %v", apperrors.ErrInvalidInput, err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+		return "", fmt.Errorf("%w: failed to reach Vault: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("%w: Vault secret not found: %s", apperrors.ErrNotFound, r.config.Path)
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized:
+		return "", fmt.Errorf("%w: Vault denied access to %s", apperrors.ErrUnauthorized, r.config.Path)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return "", fmt.Errorf("%w: Vault responded with status %d", apperrors.ErrServiceUnavailable, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("Vault responded with status %d", resp.StatusCode)
+	}
+
+	var body vaultResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxVaultResponseBytes)).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+
+	var v2 vaultKVv2Data
+	data := map[string]any{}
+	if err := json.Unmarshal(body.Data, &v2); err == nil && v2.Metadata != nil {
+		data = v2.Data
+	} else if err := json.Unmarshal(body.Data, &data); err != nil {
+		return "", fmt.Errorf("failed to decode Vault secret data: %w", err)
+	}
+
+	value, ok := data[name].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: secret not set: %s", apperrors.ErrNotFound, name)
+	}
+	return value, nil
+}
+
+// CheckHealth queries the health endpoint of Vault, which needs no token. Standby nodes are healthy,
+// since they forward reads to the active node, while sealed or uninitialized servers are not.
+func (r *vaultSecretRepository) CheckHealth(ctx context.Context) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	url := strings.TrimRight(r.config.Address, "/") + "/v1/sys/health?standbyok=true&perfstandbyok=true"
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("%w: invalid Vault address: %v", apperrors.ErrInvalidInput, err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("%w: failed to reach Vault: %v", apperrors.ErrServiceUnavailable, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: Vault health check responded with status %d", apperrors.ErrServiceUnavailable, resp.StatusCode)
+	}
+	return nil
+}
+
+// token returns the Vault token, reading it from the token file when one is configured so a token
+// renewed by Vault Agent is picked up.
+func (r *vaultSecretRepository) token() (string, error) {
+	if r.config.TokenFile == "" {
+		return r.config.Token, nil
+	}
+
+	data, err := os.ReadFile(r.config.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault token: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+type cachedSecret struct {
+	value     string
+	err       error
+	fetchedAt time.Time
+}
+
+type rotatingSecretRepository struct {
+	next     SecretRepositoryInterface
+	interval time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedSecret
+}
+
+// NewRotatingSecretRepository creates a new instance of SecretRepositoryInterface that caches the secrets
+// read from next for interval, then reads them again so rotated secrets are picked up without a restart.
+// When next fails while refreshing a secret, the last value read keeps being served until the next refresh,
+// so a backend outage does not lock callers out.
+func NewRotatingSecretRepository(next SecretRepositoryInterface, interval time.Duration) SecretRepositoryInterface {
+	return &rotatingSecretRepository{
+		next:     next,
+		interval: interval,
+		now:      time.Now,
+		cache:    make(map[string]cachedSecret),
+	}
+}
+
+func (r *rotatingSecretRepository) GetSecret(ctx context.Context, name string) (string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	cached, ok := r.cache[name]
+	r.mu.Unlock()
+	if ok && r.now().Sub(cached.fetchedAt) < r.interval {
+		return cached.value, cached.err
+	}
+
+	// The backend is read without holding the lock, so a slow backend does not block cached secrets
+	value, err := r.next.GetSecret(ctx, name)
+	if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+		if !ok || ctx.Err() != nil {
+			return "", err
+		}
+		slog.WarnContext(ctx, "failed to refresh secret, serving the last value read",
+			"secret", name,
+			"error", err)
+		value, err = cached.value, cached.err
+	}
+
+	r.mu.Lock()
+	r.cache[name] = cachedSecret{value: value, err: err, fetchedAt: r.now()}
+	r.mu.Unlock()
+	return value, err
+}
+
+// CheckHealth is not cached, so a health check reports the state of the store as it is.
+func (r *rotatingSecretRepository) CheckHealth(ctx context.Context) error {
+	return r.next.CheckHealth(ctx)
+}
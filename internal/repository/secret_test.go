@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestEnvSecretRepository_GetSecret(t *testing.T) {
+	env := map[string]string{"ADMIN_TOKEN": "secret", "EMPTY": ""}
+	repo := &envSecretRepository{lookup: func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}}
+
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr error
+	}{
+		{name: "set", secret: "ADMIN_TOKEN", want: "secret"},
+		{name: "empty", secret: "EMPTY", wantErr: apperrors.ErrNotFound},
+		{name: "unset", secret: "WEBHOOK_SECRET", wantErr: apperrors.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetSecret(context.Background(), tt.secret)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFileSecretRepository_GetSecret(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ADMIN_TOKEN"), []byte("secret\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "EMPTY"), []byte("\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "LARGE"), []byte(strings.Repeat("x", maxSecretFileBytes+1)), 0o600))
+
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr error
+	}{
+		{name: "trailing newline is trimmed", secret: "ADMIN_TOKEN", want: "secret"},
+		{name: "empty file", secret: "EMPTY", wantErr: apperrors.ErrNotFound},
+		{name: "missing file", secret: "WEBHOOK_SECRET", wantErr: apperrors.ErrNotFound},
+		{name: "file too large", secret: "LARGE", wantErr: apperrors.ErrPayloadTooLarge},
+		{name: "path traversal", secret: "../ADMIN_TOKEN", wantErr: apperrors.ErrInvalidInput},
+		{name: "hidden file", secret: ".ADMIN_TOKEN", wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFileSecretRepository(dir).GetSecret(context.Background(), tt.secret)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVaultSecretRepository_GetSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		secret  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "KV version 2",
+			status: http.StatusOK,
+			body:   `{"data":{"data":{"ADMIN_TOKEN":"secret"},"metadata":{"version":3}}}`,
+			secret: "ADMIN_TOKEN",
+			want:   "secret",
+		},
+		{
+			name:   "KV version 1",
+			status: http.StatusOK,
+			body:   `{"data":{"ADMIN_TOKEN":"secret"}}`,
+			secret: "ADMIN_TOKEN",
+			want:   "secret",
+		},
+		{
+			name:    "missing key",
+			status:  http.StatusOK,
+			body:    `{"data":{"data":{"ADMIN_TOKEN":"secret"},"metadata":{"version":3}}}`,
+			secret:  "WEBHOOK_SECRET",
+			wantErr: apperrors.ErrNotFound,
+		},
+		{name: "missing secret", status: http.StatusNotFound, body: `{"errors":[]}`, secret: "ADMIN_TOKEN", wantErr: apperrors.ErrNotFound},
+		{name: "permission denied", status: http.StatusForbidden, body: `{"errors":["permission denied"]}`, secret: "ADMIN_TOKEN", wantErr: apperrors.ErrUnauthorized},
+		{name: "sealed", status: http.StatusServiceUnavailable, body: `{"errors":["Vault is sealed"]}`, secret: "ADMIN_TOKEN", wantErr: apperrors.ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/secret/data/league-matrix", r.URL.Path)
+				assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			repo := NewVaultSecretRepository(VaultConfig{
+				Address: server.URL + "/",
+				Token:   "vault-token",
+				Path:    "/secret/data/league-matrix",
+			})
+			got, err := repo.GetSecret(context.Background(), tt.secret)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("token file is read on every request", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"TOKEN":"` + r.Header.Get("X-Vault-Token") + `"}}`))
+		}))
+		defer server.Close()
+
+		repo := NewVaultSecretRepository(VaultConfig{Address: server.URL, Token: "ignored", TokenFile: tokenFile, Path: "kv/app"})
+		for _, token := range []string{"first", "renewed"} {
+			assert.NoError(t, os.WriteFile(tokenFile, []byte(token+"\n"), 0o600))
+
+			got, err := repo.GetSecret(context.Background(), "TOKEN")
+
+			assert.NoError(t, err)
+			assert.Equal(t, token, got)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		repo := NewVaultSecretRepository(VaultConfig{Address: server.URL, Token: "vault-token", Path: "kv/app"})
+		_, err := repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+}
+
+// fakeSecretRepository serves the value and error it is set to, counting the reads.
+type fakeSecretRepository struct {
+	value string
+	err   error
+	reads int
+}
+
+func (r *fakeSecretRepository) GetSecret(ctx context.Context, name string) (string, error) {
+	r.reads++
+	return r.value, r.err
+}
+
+func (r *fakeSecretRepository) CheckHealth(ctx context.Context) error {
+	return r.err
+}
+
+func TestRotatingSecretRepository_GetSecret(t *testing.T) {
+	newRepo := func(next SecretRepositoryInterface) (*rotatingSecretRepository, *time.Time) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo := NewRotatingSecretRepository(next, time.Minute).(*rotatingSecretRepository)
+		repo.now = func() time.Time { return now }
+		return repo, &now
+	}
+
+	t.Run("secrets are cached until the interval elapses", func(t *testing.T) {
+		next := &fakeSecretRepository{value: "first"}
+		repo, now := newRepo(next)
+
+		got, err := repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+		assert.NoError(t, err)
+		assert.Equal(t, "first", got)
+
+		next.value = "rotated"
+		*now = now.Add(30 * time.Second)
+		got, _ = repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+		assert.Equal(t, "first", got)
+		assert.Equal(t, 1, next.reads)
+
+		*now = now.Add(time.Minute)
+		got, _ = repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+		assert.Equal(t, "rotated", got)
+		assert.Equal(t, 2, next.reads)
+	})
+
+	t.Run("missing secrets are cached too", func(t *testing.T) {
+		next := &fakeSecretRepository{err: apperrors.ErrNotFound}
+		repo, _ := newRepo(next)
+
+		for range 2 {
+			_, err := repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		}
+		assert.Equal(t, 1, next.reads)
+	})
+
+	t.Run("the last value is served while the backend fails", func(t *testing.T) {
+		next := &fakeSecretRepository{value: "first"}
+		repo, now := newRepo(next)
+		_, _ = repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+
+		next.err = apperrors.ErrServiceUnavailable
+		*now = now.Add(2 * time.Minute)
+		got, err := repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "first", got)
+	})
+
+	t.Run("backend failures are returned when nothing was read before", func(t *testing.T) {
+		repo, _ := newRepo(&fakeSecretRepository{err: apperrors.ErrServiceUnavailable})
+
+		_, err := repo.GetSecret(context.Background(), "ADMIN_TOKEN")
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+}
+
+func TestSecretRepository_CheckHealth(t *testing.T) {
+	t.Run("environment", func(t *testing.T) {
+		assert.NoError(t, NewEnvSecretRepository().CheckHealth(context.Background()))
+	})
+
+	t.Run("readable directory", func(t *testing.T) {
+		assert.NoError(t, NewFileSecretRepository(t.TempDir()).CheckHealth(context.Background()))
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		err := NewFileSecretRepository(filepath.Join(t.TempDir(), "missing")).CheckHealth(context.Background())
+
+		assert.ErrorIs(t, err, apperrors.ErrServiceUnavailable)
+	})
+
+	vaultTests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "Vault active", status: http.StatusOK},
+		{name: "Vault sealed", status: http.StatusServiceUnavailable, wantErr: apperrors.ErrServiceUnavailable},
+		{name: "Vault not initialized", status: http.StatusNotImplemented, wantErr: apperrors.ErrServiceUnavailable},
+	}
+
+	for _, tt := range vaultTests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodHead, r.Method)
+				assert.Equal(t, "/v1/sys/health", r.URL.Path)
+				assert.Equal(t, "true", r.URL.Query().Get("standbyok"))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			repo := NewVaultSecretRepository(VaultConfig{Address: server.URL, Token: "vault-token", Path: "kv/app"})
+
+			assert.ErrorIs(t, repo.CheckHealth(context.Background()), tt.wantErr)
+		})
+	}
+
+	t.Run("Vault unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		repo := NewVaultSecretRepository(VaultConfig{Address: server.URL, Token: "vault-token", Path: "kv/app"})
+
+		assert.ErrorIs(t, repo.CheckHealth(context.Background()), apperrors.ErrServiceUnavailable)
+	})
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// tagDir is the directory, next to a stored matrix file, holding its tags in a JSON file named after the file.
+// Like prior versions, tags live with the file, so they are kept while the file is in the trash
+// and count against the storage of the tenant owning it.
+const tagDir = ".tags"
+
+// tagsMu serializes changes to the tags of stored matrix files, which every repository instance shares on disk.
+var tagsMu sync.Mutex
+
+// TagRepositoryInterface defines the data access contract for the key/value tags of stored matrix files.
+type TagRepositoryInterface interface {
+	// GetTags returns the tags of the matrix file at filePath, or none when it has none,
+	// such as a file that is not stored.
+	GetTags(ctx context.Context, filePath string) (map[string]string, error)
+
+	// SetTags replaces the tags of the matrix file at filePath with tags; no tags clear them.
+	// It returns ErrNotFound when no file is stored at filePath, and ErrPayloadTooLarge when the tags
+	// would take the tenant carried by ctx over its storage limit.
+	SetTags(ctx context.Context, filePath string, tags map[string]string) error
+}
+
+type tagRepository struct{}
+
+// NewTagRepository creates a new instance of TagRepositoryInterface.
+// It returns a repository that keeps the tags of matrix files on the local file system, next to the files.
+func NewTagRepository() TagRepositoryInterface {
+	return &tagRepository{}
+}
+
+func (r *tagRepository) GetTags(ctx context.Context, filePath string) (map[string]string, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(tagsPath(filePath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tags: %w", err)
+	}
+
+	var tags map[string]string
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("failed to decode tags: %w", err)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) SetTags(ctx context.Context, filePath string, tags map[string]string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return err
+	}
+
+	tagsMu.Lock()
+	defer tagsMu.Unlock()
+
+	if _, err := os.Stat(filePath); err != nil {
+		return fmt.Errorf("%w: file not found: %s", apperrors.ErrNotFound, filePath)
+	}
+
+	path := tagsPath(filePath)
+	if len(tags) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to clear tags: %w", err)
+		}
+		return nil
+	}
+
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf("failed to encode tags: %w", err)
+	}
+	if err := checkStorageQuota(ctx, int64(len(encoded))); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create tag directory",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to create tag directory: %w", err)
+	}
+
+	// The tags are written aside and renamed into place, so readers never see them partly written
+	temp, err := os.CreateTemp(filepath.Dir(path), ".tags-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	_, err = temp.Write(encoded)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+		slog.ErrorContext(ctx, "failed to write tags",
+			"file_path", filePath,
+			"error", err)
+		return fmt.Errorf("failed to write tags: %w", err)
+	}
+	return nil
+}
+
+// removeTags removes the tags of the matrix file at filePath, once the file is gone for good.
+func removeTags(filePath string) error {
+	tagsMu.Lock()
+	defer tagsMu.Unlock()
+
+	// A file stored at the same path since carries on the tags
+	if _, err := os.Stat(filePath); err == nil {
+		return nil
+	}
+	if err := os.Remove(tagsPath(filePath)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// tagsPath returns the file holding the tags of the matrix file at filePath.
+func tagsPath(filePath string) string {
+	return filepath.Join(filepath.Dir(filePath), tagDir, filepath.Base(filePath)+".json")
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestTagRepository_SetTags(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	writeMatrixFile(t, "testdata/league.csv")
+	repo := NewTagRepository()
+
+	// Files never tagged have no tags
+	tags, err := repo.GetTags(ctx, "testdata/league.csv")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	assert.NoError(t, repo.SetTags(ctx, "testdata/league.csv", map[string]string{"season": "2026", "league": "premier"}))
+	tags, err = repo.GetTags(ctx, "testdata/league.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"season": "2026", "league": "premier"}, tags)
+	assert.FileExists(t, "testdata/.tags/league.csv.json")
+
+	// Setting tags replaces them all
+	assert.NoError(t, repo.SetTags(ctx, "testdata/league.csv", map[string]string{"season": "2027"}))
+	tags, err = repo.GetTags(ctx, "testdata/league.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"season": "2027"}, tags)
+
+	assert.NoError(t, repo.SetTags(ctx, "testdata/league.csv", nil))
+	tags, err = repo.GetTags(ctx, "testdata/league.csv")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+	assert.NoFileExists(t, "testdata/.tags/league.csv.json")
+
+	err = repo.SetTags(ctx, "testdata/missing.csv", map[string]string{"season": "2026"})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestTagRepository_TenantAccess(t *testing.T) {
+	t.Chdir(t.TempDir())
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	globex := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex"})
+	writeMatrixFile(t, "testdata/tenants/acme/matrix.csv")
+	repo := NewTagRepository()
+
+	assert.NoError(t, repo.SetTags(acme, "testdata/tenants/acme/matrix.csv", map[string]string{"season": "2026"}))
+	_, err := repo.GetTags(globex, "testdata/tenants/acme/matrix.csv")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	err = repo.SetTags(globex, "testdata/tenants/acme/matrix.csv", map[string]string{"season": "2027"})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	// Tags count against the tenant's storage limit
+	limited := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme", Limits: tenant.Limits{MaxStorageBytes: 30}})
+	err = repo.SetTags(limited, "testdata/tenants/acme/matrix.csv", map[string]string{"league": "premier"})
+	assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+}
+
+func TestRetentionRepository_PurgeTags(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	tags := NewTagRepository()
+	for _, filePath := range []string{"testdata/a.csv", "testdata/b.csv"} {
+		writeMatrixFile(t, filePath)
+		assert.NoError(t, tags.SetTags(ctx, filePath, map[string]string{"season": "2026"}))
+	}
+
+	repo, err := NewRetentionRepository()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Trash(ctx, "testdata/a.csv", time.Now()))
+	assert.NoError(t, repo.Trash(ctx, "testdata/b.csv", time.Now()))
+	// Tags are kept while the file is in the trash
+	got, err := tags.GetTags(ctx, "testdata/a.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"season": "2026"}, got)
+	writeMatrixFile(t, "testdata/b.csv")
+
+	assert.NoError(t, repo.Purge(ctx, "testdata/a.csv"))
+	assert.NoError(t, repo.Purge(ctx, "testdata/b.csv"))
+
+	_, err = os.Stat(tagsPath("testdata/a.csv"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	// The file stored at the path since carries on the tags
+	assert.FileExists(t, tagsPath("testdata/b.csv"))
+}
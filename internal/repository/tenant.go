@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/matsuboshi/league-matrix-app/internal/audit"
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// checkTenantAccess rejects file paths outside the data directory of the tenant carried by ctx.
+// The file is reported as not found so one tenant cannot learn which files another one has.
+func checkTenantAccess(ctx context.Context, filePath string) error {
+	if !tenant.CanAccess(ctx, filePath) {
+		slog.WarnContext(ctx, "tenant file access denied")
+		audit.Deny(ctx, "path outside the tenant's data directory: "+filePath)
+		return fmt.Errorf("%w: file not found: %s", apperrors.ErrNotFound, filePath)
+	}
+	return nil
+}
+
+// maxFileSize returns the maximum matrix file size for the tenant carried by ctx,
+// which is the service-wide limit, or the one set with WithMaxFileSize, unless the tenant has a lower one.
+func maxFileSize(ctx context.Context) int64 {
+	serviceLimit := config.CurrentLimits().MaxFileSize
+	if limit, ok := ctx.Value(maxFileSizeKey{}).(int64); ok {
+		serviceLimit = limit
+	}
+
+	if limit := tenant.LimitsFromContext(ctx).MaxFileSize; limit > 0 && limit < serviceLimit {
+		return limit
+	}
+	return serviceLimit
+}
+
+// checkStorageQuota rejects storing size more bytes when they would take the tenant carried by ctx
+// over its storage limit. Callers without a tenant, or tenants without a limit, are never rejected.
+func checkStorageQuota(ctx context.Context, size int64) error {
+	t, ok := tenant.FromContext(ctx)
+	if !ok || t.Limits.MaxStorageBytes == 0 {
+		return nil
+	}
+
+	used, err := storageUsed(t.DataDir())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to measure tenant storage",
+			"tenant_id", t.ID,
+			"error", err)
+		return fmt.Errorf("failed to measure tenant storage: %w", err)
+	}
+
+	if used+size > t.Limits.MaxStorageBytes {
+		return fmt.Errorf("%w: storage quota exceeded: %d bytes used, %d more requested (maximum: %d bytes)",
+			apperrors.ErrPayloadTooLarge, used, size, t.Limits.MaxStorageBytes)
+	}
+	return nil
+}
+
+// storageUsed returns the total size of the files in dir and its subdirectories.
+// A directory that does not exist yet holds no data.
+func storageUsed(dir string) (int64, error) {
+	var used int64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		used += info.Size()
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	return used, err
+}
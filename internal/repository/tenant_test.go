@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// tenantContext returns a context carrying the acme tenant with the given limits.
+func tenantContext(limits tenant.Limits) context.Context {
+	return tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme", Limits: limits})
+}
+
+func TestMaxFileSize(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want int64
+	}{
+		{name: "without a tenant", ctx: context.Background(), want: config.DefaultLimits.MaxFileSize},
+		{name: "tenant without a limit", ctx: tenantContext(tenant.Limits{}), want: config.DefaultLimits.MaxFileSize},
+		{name: "lower tenant limit", ctx: tenantContext(tenant.Limits{MaxFileSize: 100}), want: 100},
+		{name: "higher tenant limit", ctx: tenantContext(tenant.Limits{MaxFileSize: 4096}), want: config.DefaultLimits.MaxFileSize},
+		{name: "raised service limit", ctx: WithMaxFileSize(context.Background(), 1<<20), want: 1 << 20},
+		{name: "tenant limit under a raised service limit", ctx: WithMaxFileSize(tenantContext(tenant.Limits{MaxFileSize: 4096}), 1<<20), want: 4096},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, maxFileSize(tt.ctx))
+		})
+	}
+
+	t.Run("configured service limit", func(t *testing.T) {
+		config.SetLimits(config.Limits{DataDir: "testdata", MaxFileSize: 2048, MaxRows: 10, MaxCols: 10})
+		t.Cleanup(func() { config.SetLimits(config.DefaultLimits) })
+
+		assert.Equal(t, int64(2048), maxFileSize(context.Background()))
+		assert.Equal(t, int64(2048), maxFileSize(tenantContext(tenant.Limits{MaxFileSize: 4096})))
+	})
+}
+
+func TestCheckStorageQuota(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dataDir := filepath.Join(tenant.Root(), "acme")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dataDir, "uploads"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, "matrix.csv"), make([]byte, 60), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, "uploads", "abc.part"), make([]byte, 30), 0o644))
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		size    int64
+		wantErr bool
+	}{
+		{name: "within the quota", ctx: tenantContext(tenant.Limits{MaxStorageBytes: 100}), size: 10},
+		{name: "over the quota", ctx: tenantContext(tenant.Limits{MaxStorageBytes: 100}), size: 11, wantErr: true},
+		{name: "tenant without a quota", ctx: tenantContext(tenant.Limits{}), size: 1 << 20},
+		{name: "without a tenant", ctx: context.Background(), size: 1 << 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStorageQuota(tt.ctx, tt.size)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("tenant without files yet", func(t *testing.T) {
+		ctx := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex", Limits: tenant.Limits{MaxStorageBytes: 100}})
+
+		assert.NoError(t, checkStorageQuota(ctx, 100))
+	})
+}
+
+func TestMatrixRepository_TenantLimits(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dataDir := filepath.Join(tenant.Root(), "acme")
+	assert.NoError(t, os.MkdirAll(dataDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, "matrix.csv"), []byte("1,2\n3,4\n"), 0o644))
+	repo := NewMatrixRepository()
+
+	t.Run("file larger than the tenant's limit", func(t *testing.T) {
+		_, err := repo.GetFileContent(tenantContext(tenant.Limits{MaxFileSize: 4}), "testdata/tenants/acme/matrix.csv")
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+	})
+
+	t.Run("saving over the tenant's storage quota", func(t *testing.T) {
+		filePath := "testdata/tenants/acme/result.csv"
+
+		err := repo.SaveFileContent(tenantContext(tenant.Limits{MaxStorageBytes: 12}), filePath,
+			&MatrixFileContent{Content: [][]string{{"1", "2"}, {"3", "4"}}})
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+		assert.NoFileExists(t, filePath)
+	})
+
+	t.Run("saving within the tenant's storage quota", func(t *testing.T) {
+		err := repo.SaveFileContent(tenantContext(tenant.Limits{MaxStorageBytes: 16}), "testdata/tenants/acme/result.csv",
+			&MatrixFileContent{Content: [][]string{{"1", "2"}, {"3", "4"}}})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("upload over the tenant's storage quota", func(t *testing.T) {
+		err := NewUploadRepository().CreateUpload(tenantContext(tenant.Limits{MaxStorageBytes: 32}), "abc", 20)
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+		assert.NoFileExists(t, filepath.Join(dataDir, "uploads", "abc.part"))
+	})
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// uploadDir is the directory, inside the data directory, where partial uploads are assembled and completed uploads
+// are stored. It lives inside the data directory so completed uploads pass the file path validation rules.
+const uploadDir = "uploads"
+
+// tenantUploadDir is the directory, inside each tenant's data directory, holding the uploads of that tenant.
+const tenantUploadDir = "uploads"
+
+// UploadRepositoryInterface defines the contract for storing matrix files received in chunks.
+// Uploads made on behalf of a tenant are stored in the tenant's data directory.
+type UploadRepositoryInterface interface {
+	// CreateUpload reserves storage for a new upload whose complete size is length bytes.
+	// It rejects uploads larger than the maximum matrix file size, or that would take the tenant
+	// carried by ctx over its storage limit.
+	CreateUpload(ctx context.Context, id string, length int64) error
+
+	// WriteChunk writes the chunk at the given byte offset of an upload, without writing past length.
+	// It returns the number of bytes written.
+	WriteChunk(ctx context.Context, id string, offset int64, length int64, chunk io.Reader) (int64, error)
+
+	// CompleteUpload makes a fully received upload available as a matrix file. Its content is stored once
+	// whichever tenant uploads it, so identical uploads share the same storage.
+	CompleteUpload(ctx context.Context, id string) (*CompletedUpload, error)
+
+	// DeleteUpload removes any partial or completed data stored for an upload.
+	DeleteUpload(ctx context.Context, id string) error
+}
+
+// CompletedUpload describes the matrix file a completed upload is available as, with the SHA-256 of its content.
+// Deduplicated reports that the same content had been uploaded before, so the file takes no additional space.
+type CompletedUpload struct {
+	FilePath     string
+	ContentHash  string
+	Deduplicated bool
+}
+
+type uploadRepository struct {
+	dir              string
+	objectRepository ObjectRepositoryInterface
+}
+
+// NewUploadRepository creates a new instance of UploadRepositoryInterface.
+// It returns a repository that assembles uploads on the local file system and stores completed ones
+// in the content-addressable object store.
+func NewUploadRepository() UploadRepositoryInterface {
+	return &uploadRepository{
+		dir:              filepath.Join(config.CurrentLimits().DataDir, uploadDir),
+		objectRepository: NewObjectRepository(),
+	}
+}
+
+func (r *uploadRepository) CreateUpload(ctx context.Context, id string, length int64) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Check the announced size BEFORE accepting any data to prevent DoS attacks
+	if limit := maxFileSize(ctx); length > limit {
+		return fmt.Errorf("%w: upload too large: %d bytes (maximum: %d bytes)",
+			apperrors.ErrPayloadTooLarge, length, limit)
+	}
+	// Other unfinished uploads of the tenant count with the bytes received so far
+	if err := checkStorageQuota(ctx, length); err != nil {
+		return err
+	}
+
+	dir := r.dirFor(ctx)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create upload directory",
+			"dir", dir,
+			"error", err)
+		return fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.partPath(ctx, id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create upload file",
+			"upload_id", id,
+			"error", err)
+		return fmt.Errorf("failed to create upload file: %w", err)
+	}
+	return file.Close()
+}
+
+func (r *uploadRepository) WriteChunk(ctx context.Context, id string, offset int64, length int64, chunk io.Reader) (int64, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	file, err := os.OpenFile(r.partPath(ctx, id), os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("%w: upload data not found: %v", apperrors.ErrNotFound, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek upload file: %w", err)
+	}
+
+	// Read one byte past the remaining length to detect chunks that overflow the announced size
+	remaining := length - offset
+	written, err := io.Copy(file, io.LimitReader(chunk, remaining+1))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to write upload chunk",
+			"upload_id", id,
+			"offset", offset,
+			"error", err)
+		return 0, fmt.Errorf("failed to write upload chunk: %w", err)
+	}
+	if written > remaining {
+		// Drop the overflowing byte so the stored data never exceeds the announced length
+		if err := file.Truncate(length); err != nil {
+			return 0, fmt.Errorf("failed to truncate upload file: %w", err)
+		}
+		return 0, fmt.Errorf("%w: chunk exceeds upload length of %d bytes",
+			apperrors.ErrPayloadTooLarge, length)
+	}
+
+	return written, nil
+}
+
+func (r *uploadRepository) CompleteUpload(ctx context.Context, id string) (*CompletedUpload, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(r.dirFor(ctx), id+".csv")
+	object, err := r.objectRepository.Store(ctx, r.partPath(ctx, id), filePath)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to complete upload",
+			"upload_id", id,
+			"error", err)
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	return &CompletedUpload{
+		FilePath:     filepath.ToSlash(filePath),
+		ContentHash:  object.Hash,
+		Deduplicated: object.Deduplicated,
+	}, nil
+}
+
+func (r *uploadRepository) DeleteUpload(ctx context.Context, id string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, path := range []string{r.partPath(ctx, id), filepath.Join(r.dirFor(ctx), id+".csv")} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete upload: %w", err)
+		}
+	}
+	return nil
+}
+
+// dirFor returns the upload directory of the tenant carried by ctx, or the shared one without a tenant.
+func (r *uploadRepository) dirFor(ctx context.Context) string {
+	if t, ok := tenant.FromContext(ctx); ok {
+		return filepath.Join(t.DataDir(), tenantUploadDir)
+	}
+	return r.dir
+}
+
+// partPath returns the path where the chunks of an unfinished upload are assembled.
+func (r *uploadRepository) partPath(ctx context.Context, id string) string {
+	return filepath.Join(r.dirFor(ctx), id+".part")
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestUploadRepository_CreateUpload(t *testing.T) {
+	tests := []struct {
+		name    string
+		length  int64
+		wantErr bool
+		errType error
+	}{
+		{
+			name:   "create upload within size limit",
+			length: 512,
+		},
+		{
+			name:   "create upload of exactly 1KB",
+			length: 1024,
+		},
+		{
+			name:    "reject upload larger than 1KB",
+			length:  1025,
+			wantErr: true,
+			errType: apperrors.ErrPayloadTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &uploadRepository{dir: t.TempDir()}
+
+			err := repo.CreateUpload(context.Background(), "abc", tt.length)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, tt.errType)
+				assert.NoFileExists(t, repo.partPath(context.Background(), "abc"))
+			} else {
+				assert.NoError(t, err)
+				assert.FileExists(t, repo.partPath(context.Background(), "abc"))
+			}
+		})
+	}
+}
+
+func TestUploadRepository_WriteChunk(t *testing.T) {
+	t.Run("chunks are assembled at their offsets", func(t *testing.T) {
+		repo := &uploadRepository{dir: t.TempDir()}
+		ctx := context.Background()
+		assert.NoError(t, repo.CreateUpload(ctx, "abc", 12))
+
+		n, err := repo.WriteChunk(ctx, "abc", 0, 12, strings.NewReader("1,2,3\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), n)
+
+		n, err = repo.WriteChunk(ctx, "abc", 6, 12, strings.NewReader("4,5,6\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), n)
+
+		data, err := os.ReadFile(repo.partPath(context.Background(), "abc"))
+		assert.NoError(t, err)
+		assert.Equal(t, "1,2,3\n4,5,6\n", string(data))
+	})
+
+	t.Run("chunk overflowing the upload length is rejected", func(t *testing.T) {
+		repo := &uploadRepository{dir: t.TempDir()}
+		ctx := context.Background()
+		assert.NoError(t, repo.CreateUpload(ctx, "abc", 4))
+
+		_, err := repo.WriteChunk(ctx, "abc", 0, 4, strings.NewReader("1,2,3"))
+
+		assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+		info, statErr := os.Stat(repo.partPath(context.Background(), "abc"))
+		assert.NoError(t, statErr)
+		assert.Equal(t, int64(4), info.Size())
+	})
+
+	t.Run("unknown upload", func(t *testing.T) {
+		repo := &uploadRepository{dir: t.TempDir()}
+
+		_, err := repo.WriteChunk(context.Background(), "missing", 0, 4, strings.NewReader("1"))
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+// newTestUploadRepository returns an upload repository storing uploads and their content in a temporary directory.
+func newTestUploadRepository(t *testing.T) *uploadRepository {
+	t.Helper()
+	dir := t.TempDir()
+	return &uploadRepository{dir: dir, objectRepository: &objectRepository{dir: filepath.Join(dir, ".objects")}}
+}
+
+// completeTestUpload uploads content in a single chunk and completes the upload.
+func completeTestUpload(t *testing.T, repo *uploadRepository, ctx context.Context, id string, content string) *CompletedUpload {
+	t.Helper()
+	assert.NoError(t, repo.CreateUpload(ctx, id, int64(len(content))))
+	_, err := repo.WriteChunk(ctx, id, 0, int64(len(content)), strings.NewReader(content))
+	assert.NoError(t, err)
+
+	completed, err := repo.CompleteUpload(ctx, id)
+	assert.NoError(t, err)
+	return completed
+}
+
+func TestUploadRepository_CompleteAndDeleteUpload(t *testing.T) {
+	repo := newTestUploadRepository(t)
+	ctx := context.Background()
+
+	completed := completeTestUpload(t, repo, ctx, "abc", "1,2")
+
+	assert.Equal(t, filepath.ToSlash(filepath.Join(repo.dir, "abc.csv")), completed.FilePath)
+	assert.Equal(t, "17f8af97ad4a7f7639a4c9171d5185cbafb85462877a4746c21bdb0a4f940ca0", completed.ContentHash)
+	assert.False(t, completed.Deduplicated)
+	assert.FileExists(t, completed.FilePath)
+	assert.NoFileExists(t, repo.partPath(context.Background(), "abc"))
+
+	assert.NoError(t, repo.DeleteUpload(ctx, "abc"))
+	assert.NoFileExists(t, completed.FilePath)
+
+	// Deleting twice is not an error
+	assert.NoError(t, repo.DeleteUpload(ctx, "abc"))
+}
+
+func TestUploadRepository_CompleteUpload_Deduplicates(t *testing.T) {
+	repo := newTestUploadRepository(t)
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	t.Chdir(t.TempDir())
+
+	first := completeTestUpload(t, repo, context.Background(), "abc", "1,2\n3,4\n")
+	second := completeTestUpload(t, repo, acme, "def", "1,2\n3,4\n")
+	other := completeTestUpload(t, repo, context.Background(), "ghi", "5,6\n")
+
+	assert.False(t, first.Deduplicated)
+	assert.True(t, second.Deduplicated)
+	assert.False(t, other.Deduplicated)
+	assert.Equal(t, first.ContentHash, second.ContentHash)
+	assert.NotEqual(t, first.ContentHash, other.ContentHash)
+	assert.Equal(t, "testdata/tenants/acme/uploads/def.csv", second.FilePath)
+
+	// Identical uploads share one stored object
+	firstInfo, err := os.Stat(first.FilePath)
+	assert.NoError(t, err)
+	secondInfo, err := os.Stat(second.FilePath)
+	assert.NoError(t, err)
+	assert.True(t, os.SameFile(firstInfo, secondInfo))
+	objects, err := os.ReadDir(repo.objectRepository.(*objectRepository).dir)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+
+	data, err := os.ReadFile(second.FilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2\n3,4\n", string(data))
+}
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// versionDir is the directory, next to a stored matrix file, holding its prior versions in a directory named
+// after the file. It lives with the file so prior versions count against the storage of the tenant owning it,
+// and prior versions have no .csv extension so they are never listed or read as files of their own.
+const versionDir = ".versions"
+
+// versionSeparator separates the path of a matrix file from the version it is pinned to, as in testdata/league.csv@v3.
+const versionSeparator = "@v"
+
+// versionsMu serializes updates of stored matrix files against reads of their versions, which every repository
+// instance shares on disk, so a version is never read from the file while it is being replaced.
+var versionsMu sync.RWMutex
+
+// VersionRepositoryInterface defines the data access contract for the versions of stored matrix files.
+// Versions are numbered from 1, the content the file was first stored with, and the highest is the current content.
+// A prior version is read through the matrix repository with a reference such as testdata/league.csv@v3.
+type VersionRepositoryInterface interface {
+	// Update replaces the content of the matrix file at filePath with content, keeping the content it replaces
+	// as a prior version, and returns the version stored. The current version must match one of the entity tags
+	// in ifMatch, or ifMatch must hold *, otherwise it returns ErrPreconditionFailed, so an update is never based
+	// on a version another writer has replaced since. It returns ErrNotFound when no file is stored at filePath,
+	// and ErrPayloadTooLarge when the new version would take the tenant carried by ctx over its storage limit.
+	Update(ctx context.Context, filePath string, ifMatch []string, content *MatrixFileContent) (*VersionRecord, error)
+
+	// ListVersions returns the versions of the matrix file at filePath, the oldest first and the current one last.
+	// It returns ErrNotFound when no file is stored at filePath.
+	ListVersions(ctx context.Context, filePath string) ([]*VersionRecord, error)
+}
+
+// VersionRecord describes a version of a stored matrix file. ETag is its strong entity tag, the quoted SHA-256
+// of its content.
+type VersionRecord struct {
+	Version   int
+	ETag      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+type versionRepository struct{}
+
+// NewVersionRepository creates a new instance of VersionRepositoryInterface.
+// It returns a repository that keeps the prior versions of matrix files on the local file system, next to the files.
+func NewVersionRepository() VersionRepositoryInterface {
+	return &versionRepository{}
+}
+
+// SplitVersion splits a reference to a matrix file, optionally pinned to a version as in testdata/league.csv@v3,
+// into the path of the file and the version. References that are not pinned return version 0.
+// It returns ErrInvalidInput when the version is not a positive number.
+func SplitVersion(ref string) (string, int, error) {
+	i := strings.LastIndex(ref, versionSeparator)
+	// A file may have the separator in its name, such as testdata/team@vienna.csv
+	if i < 0 || strings.HasSuffix(ref, ".csv") {
+		return ref, 0, nil
+	}
+
+	version, err := strconv.Atoi(ref[i+len(versionSeparator):])
+	if err != nil || version < 1 {
+		return "", 0, fmt.Errorf("%w: invalid version: %q: expected a reference such as file.csv@v3",
+			apperrors.ErrInvalidInput, ref[i+1:])
+	}
+	return ref[:i], version, nil
+}
+
+// VersionRef returns the reference to version of the matrix file at filePath, such as testdata/league.csv@v3.
+func VersionRef(filePath string, version int) string {
+	return filePath + versionSeparator + strconv.Itoa(version)
+}
+
+func (r *versionRepository) Update(ctx context.Context, filePath string, ifMatch []string, content *MatrixFileContent) (*VersionRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	encoded, err := encodeCSV(content)
+	if err != nil {
+		return nil, err
+	}
+	// The replaced content is kept, so the new version takes additional space
+	if err := checkStorageQuota(ctx, int64(len(encoded))); err != nil {
+		return nil, err
+	}
+
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+
+	if _, err := os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("%w: file not found: %s", apperrors.ErrNotFound, filePath)
+	}
+	current, err := currentVersion(filePath)
+	if err != nil {
+		return nil, err
+	}
+	// The check is made under the lock, so no other update can replace the version it matched
+	etag, err := fileETag(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash file: %w", err)
+	}
+	if !slices.Contains(ifMatch, "*") && !slices.Contains(ifMatch, etag) {
+		return nil, fmt.Errorf("%w: version %d of %s, with entity tag %s, does not match If-Match",
+			apperrors.ErrPreconditionFailed, current, filePath, etag)
+	}
+
+	dir := versionsPath(filePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create version directory",
+			"file_path", filePath,
+			"error", err)
+		return nil, fmt.Errorf("failed to create version directory: %w", err)
+	}
+
+	// The new version is written aside and renamed over the file, so readers never see it partly written
+	temp, err := os.CreateTemp(dir, ".update-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	_, err = temp.Write(encoded)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+		slog.ErrorContext(ctx, "failed to write CSV",
+			"file_path", filePath,
+			"error", err)
+		return nil, fmt.Errorf("failed to write CSV file: %w", err)
+	}
+
+	// The current content is linked rather than copied, so content shared with other files stays shared
+	prior := filepath.Join(dir, versionName(current))
+	if err := os.Link(filePath, prior); err != nil {
+		_ = os.Remove(temp.Name())
+		slog.ErrorContext(ctx, "failed to keep prior version",
+			"file_path", filePath,
+			"version", current,
+			"error", err)
+		return nil, fmt.Errorf("failed to keep prior version: %w", err)
+	}
+	if err := os.Rename(temp.Name(), filePath); err != nil {
+		_ = os.Remove(temp.Name())
+		_ = os.Remove(prior)
+		slog.ErrorContext(ctx, "failed to replace file",
+			"file_path", filePath,
+			"error", err)
+		return nil, fmt.Errorf("failed to update file: %w", err)
+	}
+
+	return versionRecord(current+1, filePath)
+}
+
+func (r *versionRepository) ListVersions(ctx context.Context, filePath string) ([]*VersionRecord, error) {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := checkTenantAccess(ctx, filePath); err != nil {
+		return nil, err
+	}
+
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+
+	if _, err := os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("%w: file not found: %s", apperrors.ErrNotFound, filePath)
+	}
+	current, err := currentVersion(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*VersionRecord, 0, current)
+	for version := 1; version < current; version++ {
+		record, err := versionRecord(version, filepath.Join(versionsPath(filePath), versionName(version)))
+		if err != nil {
+			// Versions are never removed one by one, so a gap is only left by hand
+			slog.WarnContext(ctx, "prior version missing",
+				"file_path", filePath,
+				"version", version,
+				"error", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	record, err := versionRecord(current, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return append(records, record), nil
+}
+
+// versionRecord describes version, whose content is held by the file at path.
+func versionRecord(version int, path string) (*VersionRecord, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+	etag, err := fileETag(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash file: %w", err)
+	}
+	return &VersionRecord{Version: version, ETag: etag, Size: info.Size(), CreatedAt: info.ModTime()}, nil
+}
+
+// fileETag returns the strong entity tag of the content of the file at path.
+func fileETag(path string) (string, error) {
+	hash, err := hashFile(path)
+	if err != nil {
+		return "", err
+	}
+	return `"` + hash + `"`, nil
+}
+
+// resolveVersion returns the path the content referred to by ref is read from: the path of the file
+// for references that are not pinned to a version or are pinned to the current one, and the path of the prior
+// version otherwise. The caller must hold versionsMu for reading until the content is opened.
+func resolveVersion(ref string) (string, error) {
+	filePath, version, err := SplitVersion(ref)
+	if err != nil || version == 0 {
+		return filePath, err
+	}
+
+	current, err := currentVersion(filePath)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case version == current:
+		return filePath, nil
+	case version < current:
+		return filepath.Join(versionsPath(filePath), versionName(version)), nil
+	}
+	return "", fmt.Errorf("%w: version %d of %s not found: the current version is %d",
+		apperrors.ErrNotFound, version, filePath, current)
+}
+
+// currentVersion returns the version of the current content of the matrix file at filePath,
+// one more than its latest prior version.
+func currentVersion(filePath string) (int, error) {
+	entries, err := os.ReadDir(versionsPath(filePath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	latest := 0
+	for _, entry := range entries {
+		name, ok := strings.CutPrefix(entry.Name(), "v")
+		if version, err := strconv.Atoi(name); ok && err == nil {
+			latest = max(latest, version)
+		}
+	}
+	return latest + 1, nil
+}
+
+// removeVersions removes the prior versions of the matrix file at filePath, once the file is gone for good.
+func removeVersions(filePath string) error {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+
+	// A file stored at the same path since carries on the versions
+	if _, err := os.Stat(filePath); err == nil {
+		return nil
+	}
+	return os.RemoveAll(versionsPath(filePath))
+}
+
+// versionsPath returns the directory holding the prior versions of the matrix file at filePath.
+func versionsPath(filePath string) string {
+	return filepath.Join(filepath.Dir(filePath), versionDir, filepath.Base(filePath))
+}
+
+// versionName returns the name of the file holding version in the directory of prior versions.
+func versionName(version int) string {
+	return "v" + strconv.Itoa(version)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matsuboshi/league-matrix-app/internal/tenant"
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestSplitVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		ref          string
+		wantFilePath string
+		wantVersion  int
+		wantErr      bool
+	}{
+		{name: "file", ref: "testdata/league.csv", wantFilePath: "testdata/league.csv"},
+		{name: "pinned", ref: "testdata/league.csv@v3", wantFilePath: "testdata/league.csv", wantVersion: 3},
+		{name: "separator in the file name", ref: "testdata/team@vienna.csv", wantFilePath: "testdata/team@vienna.csv"},
+		{name: "pinned with the separator in the file name", ref: "testdata/team@vienna.csv@v12", wantFilePath: "testdata/team@vienna.csv", wantVersion: 12},
+		{name: "version zero", ref: "testdata/league.csv@v0", wantErr: true},
+		{name: "version not a number", ref: "testdata/league.csv@vlatest", wantErr: true},
+		{name: "missing version", ref: "testdata/league.csv@v", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath, version, err := SplitVersion(tt.ref)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFilePath, filePath)
+			assert.Equal(t, tt.wantVersion, version)
+			if version > 0 {
+				assert.Equal(t, tt.ref, VersionRef(filePath, version))
+			}
+		})
+	}
+}
+
+func TestVersionRepository_Update(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	writeMatrixFile(t, "testdata/league.csv")
+	repo := NewVersionRepository()
+	matrixRepo := &matrixRepository{}
+
+	updated, err := repo.Update(ctx, "testdata/league.csv", []string{"*"}, &MatrixFileContent{Content: [][]string{{"5", "6"}, {"7", "8"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, int64(8), updated.Size)
+	// Updates are based on the current version only
+	_, err = repo.Update(ctx, "testdata/league.csv", []string{`"stale"`}, &MatrixFileContent{Content: [][]string{{"0"}}})
+	assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+	_, err = repo.Update(ctx, "testdata/league.csv", nil, &MatrixFileContent{Content: [][]string{{"0"}}})
+	assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+	updated, err = repo.Update(ctx, "testdata/league.csv", []string{`"stale"`, updated.ETag}, &MatrixFileContent{Content: [][]string{{"9"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, updated.Version)
+
+	versions, err := repo.ListVersions(ctx, "testdata/league.csv")
+	assert.NoError(t, err)
+	if assert.Len(t, versions, 3) {
+		for i, version := range versions {
+			assert.Equal(t, i+1, version.Version)
+		}
+		assert.Equal(t, int64(2), versions[2].Size)
+		assert.Equal(t, updated.ETag, versions[2].ETag)
+		// The entity tag is the hash of the content
+		assert.Equal(t, `"96bbd5de61f36b0e10c5771d180998d066192e8986aa34a8cb7c453f62959274"`, versions[0].ETag)
+	}
+
+	// Every version is read through the matrix repository, the current one with or without pinning it
+	for ref, want := range map[string][][]string{
+		"testdata/league.csv":    {{"9"}},
+		"testdata/league.csv@v3": {{"9"}},
+		"testdata/league.csv@v2": {{"5", "6"}, {"7", "8"}},
+		"testdata/league.csv@v1": {{"1", "2"}, {"3", "4"}},
+	} {
+		content, err := matrixRepo.GetFileContent(ctx, ref)
+		assert.NoError(t, err, ref)
+		assert.Equal(t, want, content.Content, ref)
+	}
+	_, err = matrixRepo.GetFileContent(ctx, "testdata/league.csv@v4")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	_, err = matrixRepo.FileModTime(ctx, "testdata/league.csv@v0")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+	// Prior versions are not listed as files of their own
+	files, err := matrixRepo.ListFiles(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testdata/league.csv"}, files)
+}
+
+func TestVersionRepository_NotFound(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	repo := NewVersionRepository()
+
+	_, err := repo.Update(ctx, "testdata/missing.csv", []string{"*"}, &MatrixFileContent{Content: [][]string{{"1"}}})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	_, err = repo.ListVersions(ctx, "testdata/missing.csv")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoDirExists(t, "testdata/.versions")
+}
+
+func TestVersionRepository_TenantAccess(t *testing.T) {
+	t.Chdir(t.TempDir())
+	acme := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme"})
+	globex := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "globex"})
+	writeMatrixFile(t, "testdata/tenants/acme/matrix.csv")
+	repo := NewVersionRepository()
+	content := &MatrixFileContent{Content: [][]string{{"5", "6"}, {"7", "8"}}}
+
+	_, err := repo.Update(globex, "testdata/tenants/acme/matrix.csv", []string{"*"}, content)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	_, err = repo.ListVersions(globex, "testdata/tenants/acme/matrix.csv")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	// Prior versions keep counting against the tenant's storage limit
+	limited := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "acme", Limits: tenant.Limits{MaxStorageBytes: 20}})
+	_, err = repo.Update(limited, "testdata/tenants/acme/matrix.csv", []string{"*"}, content)
+	assert.NoError(t, err)
+	_, err = repo.Update(limited, "testdata/tenants/acme/matrix.csv", []string{"*"}, content)
+	assert.ErrorIs(t, err, apperrors.ErrPayloadTooLarge)
+
+	versions, err := repo.ListVersions(acme, "testdata/tenants/acme/matrix.csv")
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	_, err = (&matrixRepository{}).GetFileContent(globex, "testdata/tenants/acme/matrix.csv@v1")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// WebhookSignatureHeader carries the HMAC-SHA256 of the request body, as "sha256=<hex digest>".
+	WebhookSignatureHeader = "X-Signature-256"
+
+	// WebhookEventHeader names the event a webhook request reports.
+	WebhookEventHeader = "X-Webhook-Event"
+
+	// webhookAttemptTimeout bounds a single delivery attempt, including reading the response.
+	webhookAttemptTimeout = 10 * time.Second
+
+	// maxWebhookResponseBytes bounds how much of a response body is read before the connection is reused.
+	maxWebhookResponseBytes = 64 << 10
+)
+
+// WebhookRepositoryInterface defines the contract for notifying external systems over HTTP.
+type WebhookRepositoryInterface interface {
+	// Deliver POSTs a JSON payload describing event to callbackURL, signed with secret using HMAC-SHA256 in the
+	// X-Signature-256 header. Network errors, 429 and 5xx responses are retried with backoff; any other
+	// response outside 2xx, including redirects, fails immediately. Callback URLs may carry credentials in
+	// their path or query, so only their scheme and host appear in logs and errors.
+	Deliver(ctx context.Context, callbackURL string, event string, payload []byte, secret string) error
+}
+
+type webhookRepository struct {
+	client *http.Client
+	config RetryConfig
+}
+
+// WebhookRetryConfig returns the retry policy used for webhooks: up to 5 attempts starting at 1s
+// and never waiting more than 30s between attempts, since receivers may take a while to recover.
+func WebhookRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepositoryInterface.
+// It refuses to connect to loopback, private and link-local addresses, so callbacks cannot be used
+// to reach services on the server's own network.
+func NewWebhookRepository() WebhookRepositoryInterface {
+	dialer := &net.Dialer{
+		Timeout: webhookAttemptTimeout,
+		Control: requirePublicAddress,
+	}
+
+	client := &http.Client{
+		Timeout: webhookAttemptTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookAttemptTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// A redirect could point anywhere, so it is reported as a failed delivery instead of followed
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return newWebhookRepository(client, WebhookRetryConfig())
+}
+
+func newWebhookRepository(client *http.Client, config RetryConfig) *webhookRepository {
+	return &webhookRepository{
+		client: client,
+		config: config,
+	}
+}
+
+func (r *webhookRepository) Deliver(ctx context.Context, callbackURL string, event string, payload []byte, secret string) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	signature := SignWebhookPayload([]byte(secret), payload)
+	return retry(ctx, r.config, slog.String("target", redactURL(callbackURL)), func() (bool, error) {
+		return true, r.post(ctx, callbackURL, event, signature, payload)
+	})
+}
+
+// post makes a single delivery attempt. Its errors leave out the URL, which *url.Error would otherwise spell out.
+func (r *webhookRepository) post(ctx context.Context, callbackURL string, event string, signature string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: invalid webhook URL: %v", apperrors.ErrInvalidInput, stripURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookSignatureHeader, signature)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		err = stripURL(err)
+		if errors.Is(err, apperrors.ErrInvalidInput) {
+			return err
+		}
+		return fmt.Errorf("%w: webhook delivery to %s failed: %v", apperrors.ErrServiceUnavailable, redactURL(callbackURL), err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the response so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseBytes))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("%w: webhook responded with status %d", apperrors.ErrServiceUnavailable, resp.StatusCode)
+	default:
+		return fmt.Errorf("webhook rejected with status %d", resp.StatusCode)
+	}
+}
+
+// redactURL returns the scheme and host of a callback URL, leaving out the user info, path and query
+// that may carry credentials.
+func redactURL(callbackURL string) string {
+	u, err := url.Parse(callbackURL)
+	if err != nil || u.Host == "" {
+		return "invalid URL"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// stripURL returns the error a *url.Error wraps, so the URL it carries is not logged or returned.
+func stripURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// SignWebhookPayload returns the value of the signature header for payload: "sha256=" followed by
+// the hex-encoded HMAC-SHA256 of the payload keyed with secret. Receivers recompute it to
+// authenticate a request.
+func SignWebhookPayload(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// requirePublicAddress rejects connections to addresses that are not publicly routable.
+// It runs after name resolution, so host names resolving to internal addresses are rejected too.
+func requirePublicAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: invalid webhook address %s", apperrors.ErrInvalidInput, address)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return fmt.Errorf("%w: webhook address %s is not public", apperrors.ErrInvalidInput, host)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package tenant identifies the tenant a request acts for and scopes the matrix files it may access.
+// Each tenant owns a data directory under Root(); requests that carry a tenant may only use files inside it,
+// and requests without one may not use any tenant's files.
+package tenant
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/matsuboshi/league-matrix-app/internal/config"
+	"github.com/matsuboshi/league-matrix-app/internal/qos"
+)
+
+// rootDir is the directory, inside the data directory of the service, holding the data directory of every tenant.
+const rootDir = "tenants"
+
+// Root returns the directory holding the data directory of every tenant.
+// It lives inside the data directory of the service so tenant files pass the file path validation rules.
+func Root() string {
+	return config.CurrentLimits().DataDir + "/" + rootDir
+}
+
+// validID restricts tenant IDs to names that are safe to use as a directory.
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Tenant is an isolated customer of the service.
+type Tenant struct {
+	ID     string
+	Limits Limits
+
+	// QoS is the class of service of the tenant's requests. Empty schedules them as interactive.
+	QoS qos.Class
+}
+
+// Limits caps the resources a tenant may use. A zero field leaves the service-wide limit in place,
+// and a tenant limit can only lower a service-wide limit, never raise it.
+type Limits struct {
+	// RequestsPerMinute caps the API requests the tenant may make each minute.
+	RequestsPerMinute int
+
+	// MaxFileSize caps the size in bytes of a matrix file read or uploaded by the tenant.
+	MaxFileSize int64
+
+	// MaxRows and MaxCols cap the dimensions of the matrices the tenant may process.
+	MaxRows int
+	MaxCols int
+
+	// MaxStorageBytes caps the total size of the files stored in the tenant's data directory.
+	MaxStorageBytes int64
+
+	// RequestQuota caps the API requests each of the tenant's API keys may make per quota period.
+	RequestQuota int
+
+	// ComputeQuota caps the time the server may spend serving each of the tenant's API keys per quota period.
+	ComputeQuota time.Duration
+
+	// QuotaPeriod is the period quotas are counted over, starting at multiples of it since the zero time,
+	// so daily quotas reset at midnight UTC. Zero counts quotas per day.
+	QuotaPeriod time.Duration
+}
+
+// ValidID reports whether id can name a tenant.
+func ValidID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// DataDir returns the directory holding the tenant's matrix files.
+func (t *Tenant) DataDir() string {
+	return Root() + "/" + t.ID
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying t.
+func NewContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant carried by ctx, if any.
+func FromContext(ctx context.Context) (*Tenant, bool) {
+	t, ok := ctx.Value(contextKey{}).(*Tenant)
+	return t, ok && t != nil
+}
+
+// LimitsFromContext returns the limits of the tenant carried by ctx, or no limits without one.
+func LimitsFromContext(ctx context.Context) Limits {
+	if t, ok := FromContext(ctx); ok {
+		return t.Limits
+	}
+	return Limits{}
+}
+
+// ID returns the ID of the tenant carried by ctx, or an empty string without one.
+// It is what records store to remember the tenant they belong to.
+func ID(ctx context.Context) string {
+	if t, ok := FromContext(ctx); ok {
+		return t.ID
+	}
+	return ""
+}
+
+// WithID returns a copy of ctx carrying the tenant identified by id, as stored by a record.
+// An empty id returns ctx unchanged.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return NewContext(ctx, &Tenant{ID: id})
+}
+
+// CanAccess reports whether the tenant carried by ctx may use filePath.
+// A tenant may only use files inside its data directory, while callers without a tenant
+// may use any file outside Root().
+func CanAccess(ctx context.Context, filePath string) bool {
+	cleaned := path.Clean(filepath.ToSlash(filePath))
+
+	t, ok := FromContext(ctx)
+	if !ok {
+		root := Root()
+		return cleaned != root && !strings.HasPrefix(cleaned, root+"/")
+	}
+	return strings.HasPrefix(cleaned, t.DataDir()+"/")
+}
+
+// CanAccessObject reports whether the tenant carried by ctx may use key, the key of an object in object storage.
+// A tenant may only use the objects below a prefix named after its ID, where its results are exported,
+// while callers without a tenant may use any object.
+func CanAccessObject(ctx context.Context, key string) bool {
+	t, ok := FromContext(ctx)
+	if !ok {
+		return true
+	}
+	return strings.HasPrefix(path.Clean(key), t.ID+"/")
+}
+
+// Owner returns the ID of the tenant whose data directory holds filePath, or an empty string for files
+// outside Root(). Work done on a file outside any request, such as a cleanup, acts for its owner.
+func Owner(filePath string) string {
+	cleaned := path.Clean(filepath.ToSlash(filePath))
+	rest, ok := strings.CutPrefix(cleaned, Root()+"/")
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	return id
+}
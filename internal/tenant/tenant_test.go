@@ -0,0 +1,120 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{id: "acme", want: true},
+		{id: "team-42_eu", want: true},
+		{id: "", want: false},
+		{id: "Acme", want: false},
+		{id: "-acme", want: false},
+		{id: "../acme", want: false},
+		{id: "acme/eu", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidID(tt.id))
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	acme := &Tenant{ID: "acme"}
+
+	got, ok := FromContext(NewContext(context.Background(), acme))
+	assert.True(t, ok)
+	assert.Same(t, acme, got)
+	assert.Equal(t, "acme", ID(NewContext(context.Background(), acme)))
+
+	_, ok = FromContext(context.Background())
+	assert.False(t, ok)
+	assert.Empty(t, ID(context.Background()))
+
+	assert.Equal(t, "acme", ID(WithID(context.Background(), "acme")))
+	assert.Empty(t, ID(WithID(context.Background(), "")))
+
+	limited := &Tenant{ID: "acme", Limits: Limits{MaxRows: 5}}
+	assert.Equal(t, Limits{MaxRows: 5}, LimitsFromContext(NewContext(context.Background(), limited)))
+	assert.Equal(t, Limits{}, LimitsFromContext(context.Background()))
+}
+
+func TestCanAccess(t *testing.T) {
+	acme := NewContext(context.Background(), &Tenant{ID: "acme"})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		filePath string
+		want     bool
+	}{
+		{name: "tenant file", ctx: acme, filePath: "testdata/tenants/acme/matrix.csv", want: true},
+		{name: "nested tenant file", ctx: acme, filePath: "testdata/tenants/acme/uploads/upload.csv", want: true},
+		{name: "another tenant's file", ctx: acme, filePath: "testdata/tenants/globex/matrix.csv", want: false},
+		{name: "tenant with a shared prefix", ctx: acme, filePath: "testdata/tenants/acme-eu/matrix.csv", want: false},
+		{name: "escaping the data directory", ctx: acme, filePath: "testdata/tenants/acme/../globex/matrix.csv", want: false},
+		{name: "shared file for a tenant", ctx: acme, filePath: "testdata/matrix1.csv", want: false},
+		{name: "shared file without a tenant", ctx: context.Background(), filePath: "testdata/matrix1.csv", want: true},
+		{name: "tenant file without a tenant", ctx: context.Background(), filePath: "testdata/tenants/acme/matrix.csv", want: false},
+		{name: "unclean tenant file without a tenant", ctx: context.Background(), filePath: "testdata//tenants/acme/matrix.csv", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CanAccess(tt.ctx, tt.filePath))
+		})
+	}
+}
+
+func TestCanAccessObject(t *testing.T) {
+	acme := NewContext(context.Background(), &Tenant{ID: "acme"})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		key  string
+		want bool
+	}{
+		{name: "tenant object", ctx: acme, key: "acme/matrix.csv", want: true},
+		{name: "nested tenant object", ctx: acme, key: "acme/2026/matrix.csv", want: true},
+		{name: "another tenant's object", ctx: acme, key: "globex/matrix.csv", want: false},
+		{name: "tenant with a shared prefix", ctx: acme, key: "acme-eu/matrix.csv", want: false},
+		{name: "escaping the prefix", ctx: acme, key: "acme/../globex/matrix.csv", want: false},
+		{name: "shared object for a tenant", ctx: acme, key: "matrix.csv", want: false},
+		{name: "any object without a tenant", ctx: context.Background(), key: "acme/matrix.csv", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CanAccessObject(tt.ctx, tt.key))
+		})
+	}
+}
+
+func TestOwner(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     string
+	}{
+		{filePath: "testdata/tenants/acme/matrix.csv", want: "acme"},
+		{filePath: "testdata/tenants/acme/uploads/upload.csv", want: "acme"},
+		{filePath: "testdata//tenants/globex/matrix.csv", want: "globex"},
+		{filePath: "testdata/matrix1.csv", want: ""},
+		{filePath: "testdata/tenants", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			assert.Equal(t, tt.want, Owner(tt.filePath))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Code is a machine-readable error code, reported with error responses so API clients can branch on it
+// instead of parsing the message. Codes are stable: new codes may be added, but existing ones keep their meaning.
+type Code string
+
+// Error codes of the sentinel errors, and of the responses that are not caused by one.
+const (
+	CodeInvalidInput         Code = "INVALID_INPUT"
+	CodeUnauthorized         Code = "UNAUTHORIZED"
+	CodeForbidden            Code = "FORBIDDEN"
+	CodeNotFound             Code = "NOT_FOUND"
+	CodeMethodNotAllowed     Code = "METHOD_NOT_ALLOWED"
+	CodeConflict             Code = "CONFLICT"
+	CodePreconditionFailed   Code = "PRECONDITION_FAILED"
+	CodePayloadTooLarge      Code = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeUnprocessableEntity  Code = "UNPROCESSABLE_ENTITY"
+	CodeOverflow             Code = "OVERFLOW"
+	CodePreconditionRequired Code = "PRECONDITION_REQUIRED"
+	CodeTooManyRequests      Code = "TOO_MANY_REQUESTS"
+	CodeServiceUnavailable   Code = "SERVICE_UNAVAILABLE"
+	CodeTimeout              Code = "TIMEOUT"
+	CodeInternal             Code = "INTERNAL_ERROR"
+)
+
+// Error codes of the refined sentinel errors, which tell apart the most common causes of a broader sentinel.
+const (
+	CodeInvalidOperation  Code = "INVALID_OPERATION"
+	CodeFileTooLarge      Code = "FILE_TOO_LARGE"
+	CodeMatrixTooLarge    Code = "MATRIX_TOO_LARGE"
+	CodeDimensionMismatch Code = "DIMENSION_MISMATCH"
+)
+
+// Refined sentinel errors. Each refines a broader sentinel error with its own code: it reads and matches like
+// the sentinel it refines, so errors wrapping it keep their message, status code and exit code.
+var (
+	// ErrInvalidOperation refines ErrInvalidInput for operations that do not exist or cannot be run.
+	ErrInvalidOperation = &refinedError{sentinel: ErrInvalidInput, code: CodeInvalidOperation}
+
+	// ErrFileTooLarge refines ErrPayloadTooLarge for matrix files and inputs past the size limit.
+	ErrFileTooLarge = &refinedError{sentinel: ErrPayloadTooLarge, code: CodeFileTooLarge}
+
+	// ErrMatrixTooLarge refines ErrUnprocessableEntity for matrices with more rows or columns than allowed.
+	ErrMatrixTooLarge = &refinedError{sentinel: ErrUnprocessableEntity, code: CodeMatrixTooLarge}
+
+	// ErrDimensionMismatch refines ErrUnprocessableEntity for matrices whose dimensions do not fit the operation,
+	// such as rows of different lengths or matrices multiplied with a mismatched inner dimension.
+	ErrDimensionMismatch = &refinedError{sentinel: ErrUnprocessableEntity, code: CodeDimensionMismatch}
+)
+
+// refinedError is a sentinel error refining a broader sentinel error with a more specific code.
+type refinedError struct {
+	sentinel error
+	code     Code
+}
+
+func (e *refinedError) Error() string {
+	return e.sentinel.Error()
+}
+
+func (e *refinedError) Unwrap() error {
+	return e.sentinel
+}
+
+// GetErrorCode maps application errors to machine-readable error codes, like GetHTTPStatusCode does for HTTP.
+// The code of a refined sentinel error found in the error chain wins over the code of the sentinel it refines.
+// If no sentinel error is found, it defaults to CodeInternal.
+func GetErrorCode(err error) Code {
+	var refined *refinedError
+	if errors.As(err, &refined) {
+		return refined.code
+	}
+
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		return CodeInvalidInput
+	case errors.Is(err, ErrUnauthorized):
+		return CodeUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return CodeForbidden
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrConflict):
+		return CodeConflict
+	case errors.Is(err, ErrPreconditionFailed):
+		return CodePreconditionFailed
+	case errors.Is(err, ErrPayloadTooLarge):
+		return CodePayloadTooLarge
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return CodeUnsupportedMediaType
+	case errors.Is(err, ErrUnprocessableEntity):
+		return CodeUnprocessableEntity
+	case errors.Is(err, ErrOverflow):
+		return CodeOverflow
+	case errors.Is(err, ErrPreconditionRequired):
+		return CodePreconditionRequired
+	case errors.Is(err, ErrTooManyRequests):
+		return CodeTooManyRequests
+	case errors.Is(err, ErrServiceUnavailable):
+		return CodeServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	default:
+		return CodeInternal
+	}
+}
+
+// GetStatusErrorCode returns the error code of responses with statusCode that are not caused by a sentinel error,
+// such as requests with a method a route does not allow. Status codes without a code of their own map to
+// CodeInvalidInput for client errors and CodeInternal otherwise.
+func GetStatusErrorCode(statusCode int) Code {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnsupportedMediaType:
+		return CodeUnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return CodeUnprocessableEntity
+	case http.StatusPreconditionRequired:
+		return CodePreconditionRequired
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return CodeInvalidInput
+	}
+	return CodeInternal
+}
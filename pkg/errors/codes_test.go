@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetErrorCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode Code
+	}{
+		{name: "ErrInvalidInput", err: fmt.Errorf("%w: file parameter is required", ErrInvalidInput), wantCode: CodeInvalidInput},
+		{name: "ErrNotFound", err: fmt.Errorf("%w: failed to open file", ErrNotFound), wantCode: CodeNotFound},
+		{name: "ErrPayloadTooLarge", err: ErrPayloadTooLarge, wantCode: CodePayloadTooLarge},
+		{name: "ErrUnprocessableEntity", err: ErrUnprocessableEntity, wantCode: CodeUnprocessableEntity},
+		{name: "ErrOverflow", err: fmt.Errorf("%w: value out of range", ErrOverflow), wantCode: CodeOverflow},
+		{name: "ErrServiceUnavailable", err: ErrServiceUnavailable, wantCode: CodeServiceUnavailable},
+		{name: "deadline exceeded", err: fmt.Errorf("reading matrix: %w", context.DeadlineExceeded), wantCode: CodeTimeout},
+		{name: "ErrInvalidOperation", err: fmt.Errorf("%w: divide", ErrInvalidOperation), wantCode: CodeInvalidOperation},
+		{name: "ErrFileTooLarge", err: fmt.Errorf("%w: 2048 bytes", ErrFileTooLarge), wantCode: CodeFileTooLarge},
+		{name: "ErrMatrixTooLarge", err: fmt.Errorf("%w: 11 rows", ErrMatrixTooLarge), wantCode: CodeMatrixTooLarge},
+		{name: "ErrDimensionMismatch", err: fmt.Errorf("job failed: %w", fmt.Errorf("%w: 2 and 3 rows", ErrDimensionMismatch)), wantCode: CodeDimensionMismatch},
+		{name: "unknown errors are internal", err: errors.New("boom"), wantCode: CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCode, GetErrorCode(tt.err))
+		})
+	}
+}
+
+func TestRefinedError(t *testing.T) {
+	err := fmt.Errorf("%w: cannot multiply matrices: first has 2 columns, second has 3 rows", ErrDimensionMismatch)
+
+	// Refined sentinels read and match like the sentinels they refine
+	assert.Equal(t, "unprocessable entity: cannot multiply matrices: first has 2 columns, second has 3 rows", err.Error())
+	assert.ErrorIs(t, err, ErrDimensionMismatch)
+	assert.ErrorIs(t, err, ErrUnprocessableEntity)
+	assert.NotErrorIs(t, err, ErrMatrixTooLarge)
+	assert.Equal(t, http.StatusUnprocessableEntity, GetHTTPStatusCode(err))
+	assert.Equal(t, ExitDataErr, GetExitCode(err))
+
+	assert.Equal(t, http.StatusBadRequest, GetHTTPStatusCode(ErrInvalidOperation))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, GetHTTPStatusCode(ErrFileTooLarge))
+}
+
+func TestGetStatusErrorCode(t *testing.T) {
+	for statusCode, want := range map[int]Code{
+		http.StatusBadRequest:          CodeInvalidInput,
+		http.StatusUnauthorized:        CodeUnauthorized,
+		http.StatusMethodNotAllowed:    CodeMethodNotAllowed,
+		http.StatusTeapot:              CodeInvalidInput,
+		http.StatusInternalServerError: CodeInternal,
+		http.StatusServiceUnavailable:  CodeServiceUnavailable,
+		http.StatusGatewayTimeout:      CodeTimeout,
+	} {
+		assert.Equal(t, want, GetStatusErrorCode(statusCode), statusCode)
+	}
+}
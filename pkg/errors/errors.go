@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"net/http"
 )
@@ -11,18 +12,75 @@ var (
 	// ErrInvalidInput maps to 400 Bad Request.
 	ErrInvalidInput = errors.New("invalid input")
 
+	// ErrUnauthorized maps to 401 Unauthorized.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden maps to 403 Forbidden.
+	ErrForbidden = errors.New("forbidden")
+
 	// ErrNotFound maps to 404 Not Found.
 	ErrNotFound = errors.New("not found")
 
+	// ErrConflict maps to 409 Conflict.
+	ErrConflict = errors.New("conflict")
+
+	// ErrPreconditionFailed maps to 412 Precondition Failed.
+	// It reports a conditional update whose If-Match header no longer matches the stored data.
+	ErrPreconditionFailed = errors.New("precondition failed")
+
 	// ErrPayloadTooLarge maps to 413 Payload Too Large.
 	ErrPayloadTooLarge = errors.New("payload too large")
 
+	// ErrUnsupportedMediaType maps to 415 Unsupported Media Type.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
 	// ErrUnprocessableEntity maps to 422 Unprocessable Entity.
 	ErrUnprocessableEntity = errors.New("unprocessable entity")
+
+	// ErrOverflow maps to 422 Unprocessable Entity.
+	// It reports a value that does not fit in the numeric type it is read or computed into.
+	ErrOverflow = errors.New("overflow")
+
+	// ErrPreconditionRequired maps to 428 Precondition Required.
+	// It reports an update sent without the If-Match header it must be made conditional with.
+	ErrPreconditionRequired = errors.New("precondition required")
+
+	// ErrTooManyRequests maps to 429 Too Many Requests.
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrServiceUnavailable maps to 503 Service Unavailable.
+	// Remote backends wrap transient failures with it so callers know the request may be retried.
+	ErrServiceUnavailable = errors.New("service unavailable")
+)
+
+// Exit codes returned by the command line interface, following the BSD sysexits.h conventions
+// so scripts can tell bad usage and bad data apart from temporary failures worth retrying.
+const (
+	// ExitOK reports success.
+	ExitOK = 0
+
+	// ExitFailure reports an error that has no more specific code.
+	ExitFailure = 1
+
+	// ExitUsage reports invalid arguments, such as an unknown operation (EX_USAGE).
+	ExitUsage = 64
+
+	// ExitDataErr reports input that is not a valid matrix (EX_DATAERR).
+	ExitDataErr = 65
+
+	// ExitNoInput reports an input file that does not exist or cannot be read (EX_NOINPUT).
+	ExitNoInput = 66
+
+	// ExitTempFail reports a temporary failure; the command may succeed if retried (EX_TEMPFAIL).
+	ExitTempFail = 75
+
+	// ExitInterrupted reports a command cancelled by an interrupt, as shells do for SIGINT.
+	ExitInterrupted = 130
 )
 
 // GetHTTPStatusCode maps application errors to appropriate HTTP status codes.
 // It uses errors.Is to check the error chain for sentinel errors and returns the corresponding status code.
+// A request that ran past its deadline, which wraps context.DeadlineExceeded, maps to 504 Gateway Timeout.
 // If no sentinel error is found, it defaults to 500 Internal Server Error.
 func GetHTTPStatusCode(err error) int {
 	if err == nil {
@@ -32,13 +90,55 @@ func GetHTTPStatusCode(err error) int {
 	switch {
 	case errors.Is(err, ErrInvalidInput):
 		return http.StatusBadRequest // 400
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized // 401
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden // 403
 	case errors.Is(err, ErrNotFound):
 		return http.StatusNotFound // 404
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict // 409
+	case errors.Is(err, ErrPreconditionFailed):
+		return http.StatusPreconditionFailed // 412
 	case errors.Is(err, ErrPayloadTooLarge):
 		return http.StatusRequestEntityTooLarge // 413
-	case errors.Is(err, ErrUnprocessableEntity):
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType // 415
+	case errors.Is(err, ErrUnprocessableEntity), errors.Is(err, ErrOverflow):
 		return http.StatusUnprocessableEntity // 422
+	case errors.Is(err, ErrPreconditionRequired):
+		return http.StatusPreconditionRequired // 428
+	case errors.Is(err, ErrTooManyRequests):
+		return http.StatusTooManyRequests // 429
+	case errors.Is(err, ErrServiceUnavailable):
+		return http.StatusServiceUnavailable // 503
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout // 504
 	default:
 		return http.StatusInternalServerError // 500
 	}
 }
+
+// GetExitCode maps application errors to command line exit codes, like GetHTTPStatusCode does for HTTP.
+// If no sentinel error is found, it defaults to ExitFailure.
+func GetExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted // 130
+	case errors.Is(err, ErrInvalidInput):
+		return ExitUsage // 64
+	case errors.Is(err, ErrNotFound):
+		return ExitNoInput // 66
+	case errors.Is(err, ErrPayloadTooLarge), errors.Is(err, ErrUnsupportedMediaType), errors.Is(err, ErrUnprocessableEntity),
+		errors.Is(err, ErrOverflow):
+		return ExitDataErr // 65
+	case errors.Is(err, ErrTooManyRequests), errors.Is(err, ErrServiceUnavailable):
+		return ExitTempFail // 75
+	default:
+		return ExitFailure // 1
+	}
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -45,6 +46,16 @@ func TestGetHTTPStatusCode(t *testing.T) {
 			err:      fmt.Errorf("%w: matrix not found with id: 123", ErrNotFound),
 			wantCode: http.StatusNotFound,
 		},
+		{
+			name:     "ErrConflict returns 409",
+			err:      ErrConflict,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "fmt.Errorf with %w wrapping 409 ErrConflict",
+			err:      fmt.Errorf("%w: upload offset mismatch", ErrConflict),
+			wantCode: http.StatusConflict,
+		},
 		{
 			name:     "ErrPayloadTooLarge returns 413",
 			err:      ErrPayloadTooLarge,
@@ -55,6 +66,16 @@ func TestGetHTTPStatusCode(t *testing.T) {
 			err:      fmt.Errorf("%w: matrix size exceeds limit", ErrPayloadTooLarge),
 			wantCode: http.StatusRequestEntityTooLarge,
 		},
+		{
+			name:     "ErrUnsupportedMediaType returns 415",
+			err:      ErrUnsupportedMediaType,
+			wantCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:     "fmt.Errorf with %w wrapping 415 ErrUnsupportedMediaType",
+			err:      fmt.Errorf("%w: application/xml", ErrUnsupportedMediaType),
+			wantCode: http.StatusUnsupportedMediaType,
+		},
 		{
 			name:     "ErrUnprocessableEntity returns 422",
 			err:      ErrUnprocessableEntity,
@@ -65,6 +86,46 @@ func TestGetHTTPStatusCode(t *testing.T) {
 			err:      fmt.Errorf("%w: unable to process matrix format", ErrUnprocessableEntity),
 			wantCode: http.StatusUnprocessableEntity,
 		},
+		{
+			name:     "ErrOverflow returns 422",
+			err:      fmt.Errorf("%w: integer value out of range at row 0, column 1", ErrOverflow),
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "ErrUnauthorized returns 401",
+			err:      fmt.Errorf("%w: unknown API key", ErrUnauthorized),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "ErrForbidden returns 403",
+			err:      fmt.Errorf("%w: missing CSRF token", ErrForbidden),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "ErrPreconditionFailed returns 412",
+			err:      fmt.Errorf("%w: the file was changed since", ErrPreconditionFailed),
+			wantCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:     "ErrPreconditionRequired returns 428",
+			err:      fmt.Errorf("%w: If-Match header is required", ErrPreconditionRequired),
+			wantCode: http.StatusPreconditionRequired,
+		},
+		{
+			name:     "ErrTooManyRequests returns 429",
+			err:      fmt.Errorf("%w: request limit reached", ErrTooManyRequests),
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:     "ErrServiceUnavailable returns 503",
+			err:      ErrServiceUnavailable,
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "deadline exceeded returns 504",
+			err:      fmt.Errorf("failed to read file: %w", context.DeadlineExceeded),
+			wantCode: http.StatusGatewayTimeout,
+		},
 		{
 			name:     "unknown error returns 500",
 			err:      errors.New("unknown error"),
@@ -84,3 +145,30 @@ func TestGetHTTPStatusCode(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "nil error returns 0", err: nil, wantCode: ExitOK},
+		{name: "ErrInvalidInput is a usage error", err: fmt.Errorf("%w: invalid operation: divide", ErrInvalidInput), wantCode: ExitUsage},
+		{name: "ErrNotFound means no input", err: fmt.Errorf("%w: failed to open file", ErrNotFound), wantCode: ExitNoInput},
+		{name: "ErrUnprocessableEntity is a data error", err: fmt.Errorf("%w: invalid number", ErrUnprocessableEntity), wantCode: ExitDataErr},
+		{name: "ErrOverflow is a data error", err: fmt.Errorf("%w: value out of range", ErrOverflow), wantCode: ExitDataErr},
+		{name: "ErrPayloadTooLarge is a data error", err: ErrPayloadTooLarge, wantCode: ExitDataErr},
+		{name: "ErrUnsupportedMediaType is a data error", err: ErrUnsupportedMediaType, wantCode: ExitDataErr},
+		{name: "ErrServiceUnavailable is temporary", err: ErrServiceUnavailable, wantCode: ExitTempFail},
+		{name: "ErrTooManyRequests is temporary", err: ErrTooManyRequests, wantCode: ExitTempFail},
+		{name: "cancellation is an interrupt", err: fmt.Errorf("reading matrix: %w", context.Canceled), wantCode: ExitInterrupted},
+		{name: "ErrConflict has no specific code", err: ErrConflict, wantCode: ExitFailure},
+		{name: "unknown errors are generic failures", err: errors.New("boom"), wantCode: ExitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCode, GetExitCode(tt.err))
+		})
+	}
+}
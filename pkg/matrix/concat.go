@@ -0,0 +1,76 @@
+package matrix
+
+import (
+	"fmt"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Axis names the direction two matrices are joined in.
+type Axis string
+
+const (
+	// Horizontal places the columns of the second matrix after those of the first, so both need as many rows.
+	Horizontal Axis = "horizontal"
+
+	// Vertical places the rows of the second matrix below those of the first, so both need as many columns.
+	Vertical Axis = "vertical"
+)
+
+// ParseAxis checks that axis names a supported axis and returns it.
+func ParseAxis(axis string) (Axis, error) {
+	switch Axis(axis) {
+	case Horizontal, Vertical:
+		return Axis(axis), nil
+	default:
+		return "", fmt.Errorf("%w: invalid axis: %q: expected %s or %s", apperrors.ErrInvalidInput, axis, Horizontal, Vertical)
+	}
+}
+
+// Concat joins a and b along axis into a new matrix that shares no memory with them.
+// Matrices whose rows or columns do not line up along axis are rejected as unprocessable.
+// The joined matrix is not checked against the dimension limits, so callers keeping it should validate it.
+// Decimal matrices are joined at the larger scale of a and b, failing with ErrOverflow when a value
+// no longer fits in T.
+func Concat[T Number](a, b *Matrix[T], axis Axis) (*Matrix[T], error) {
+	if a.Rows() == 0 || b.Rows() == 0 {
+		return nil, fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+	}
+
+	// Values are joined at a single scale, so the matrix with fewer decimal places is rescaled
+	scale := max(a.Scale, b.Scale)
+	a, b = a.Clone(), b.Clone()
+	if err := a.rescale(scale); err != nil {
+		return nil, err
+	}
+	if err := b.rescale(scale); err != nil {
+		return nil, err
+	}
+
+	switch axis {
+	case Horizontal:
+		if a.Rows() != b.Rows() {
+			return nil, fmt.Errorf("%w: cannot concatenate horizontally: matrices have %d and %d rows",
+				apperrors.ErrDimensionMismatch, a.Rows(), b.Rows())
+		}
+		data := make([][]T, a.Rows())
+		for i := range data {
+			data[i] = make([]T, 0, a.Cols()+b.Cols())
+			data[i] = append(data[i], a.Data[i]...)
+			data[i] = append(data[i], b.Data[i]...)
+		}
+		return &Matrix[T]{Data: data, Scale: scale}, nil
+	case Vertical:
+		if a.Cols() != b.Cols() {
+			return nil, fmt.Errorf("%w: cannot concatenate vertically: matrices have %d and %d columns",
+				apperrors.ErrDimensionMismatch, a.Cols(), b.Cols())
+		}
+		data := make([][]T, 0, a.Rows()+b.Rows())
+		data = append(data, a.Data...)
+		data = append(data, b.Data...)
+		return &Matrix[T]{Data: data, Scale: scale}, nil
+	default:
+		_, err := ParseAxis(string(axis))
+		return nil, err
+	}
+}
@@ -0,0 +1,125 @@
+package matrix
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestParseAxis(t *testing.T) {
+	for _, axis := range []Axis{Horizontal, Vertical} {
+		got, err := ParseAxis(string(axis))
+		assert.NoError(t, err)
+		assert.Equal(t, axis, got)
+	}
+
+	for _, axis := range []string{"", "diagonal", "Horizontal"} {
+		_, err := ParseAxis(axis)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput, axis)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       [][]int64
+		b       [][]int64
+		axis    Axis
+		want    [][]int64
+		wantErr error
+	}{
+		{
+			name: "horizontal",
+			a:    [][]int64{{1, 2}, {3, 4}},
+			b:    [][]int64{{5}, {6}},
+			axis: Horizontal,
+			want: [][]int64{{1, 2, 5}, {3, 4, 6}},
+		},
+		{
+			name: "vertical",
+			a:    [][]int64{{1, 2}, {3, 4}},
+			b:    [][]int64{{5, 6}},
+			axis: Vertical,
+			want: [][]int64{{1, 2}, {3, 4}, {5, 6}},
+		},
+		{
+			name:    "horizontal with different row counts",
+			a:       [][]int64{{1, 2}, {3, 4}},
+			b:       [][]int64{{5, 6}},
+			axis:    Horizontal,
+			wantErr: apperrors.ErrUnprocessableEntity,
+		},
+		{
+			name:    "vertical with different column counts",
+			a:       [][]int64{{1, 2}, {3, 4}},
+			b:       [][]int64{{5}, {6}},
+			axis:    Vertical,
+			wantErr: apperrors.ErrUnprocessableEntity,
+		},
+		{
+			name:    "empty matrix",
+			a:       [][]int64{{1}},
+			b:       nil,
+			axis:    Vertical,
+			wantErr: apperrors.ErrInvalidInput,
+		},
+		{
+			name:    "invalid axis",
+			a:       [][]int64{{1}},
+			b:       [][]int64{{2}},
+			axis:    "diagonal",
+			wantErr: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := &Matrix[int64]{Data: tt.a}, &Matrix[int64]{Data: tt.b}
+			got, err := Concat(a, b, tt.axis)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Data)
+
+			// The joined matrix shares no memory with its parts
+			got.Data[0][0] = 100
+			assert.Equal(t, int64(1), a.At(0, 0))
+		})
+	}
+}
+
+func TestConcat_BigInt(t *testing.T) {
+	a := &Matrix[*big.Int]{Data: [][]*big.Int{{big.NewInt(1)}}}
+	b := &Matrix[*big.Int]{Data: [][]*big.Int{{big.NewInt(2)}}}
+
+	got, err := Concat(a, b, Horizontal)
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2", got.String())
+
+	// Values are copied, so changing the joined matrix leaves its parts untouched
+	got.Data[0][0].SetInt64(100)
+	assert.Equal(t, int64(1), a.At(0, 0).Int64())
+}
+
+func TestConcat_Decimal(t *testing.T) {
+	a := &Matrix[int64]{Data: [][]int64{{15}, {2}}, Scale: 1}
+	b := &Matrix[int64]{Data: [][]int64{{125}, {3}}, Scale: 2}
+
+	got, err := Concat(a, b, Horizontal)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got.Scale)
+	assert.Equal(t, "1.5,1.25\n0.2,0.03", got.String())
+
+	// The parts keep their own scale
+	assert.Equal(t, "1.5\n0.2", a.String())
+
+	_, err = Concat(&Matrix[int64]{Data: [][]int64{{math.MaxInt64}}}, b, Vertical)
+	assert.ErrorIs(t, err, apperrors.ErrOverflow)
+}
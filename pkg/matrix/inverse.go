@@ -0,0 +1,220 @@
+package matrix
+
+import (
+	"fmt"
+	"math/big"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// InverseScale caps the decimal places of the inverse of an integer matrix. Values with more decimal places,
+// or with no exact decimal form at all such as 1/3, are rounded half away from zero to InverseScale places.
+const InverseScale = 9
+
+// MaxPrecision is the most decimal places RunWithPrecision and MatInverseWithPrecision round results to.
+// An integer matrix holds its values scaled by 10^precision, so the more decimal places, the smaller the values
+// that fit an int64.
+const MaxPrecision = 18
+
+// exactPrecision is the precision of Run: the inverse is rounded to InverseScale decimal places, and the mean
+// and median of integer matrices are written exactly by formatFraction.
+const exactPrecision = -1
+
+// ValidatePrecision checks that precision is a number of decimal places results can be rounded to,
+// between 0 and MaxPrecision, and fails with ErrInvalidInput otherwise.
+func ValidatePrecision(precision int) error {
+	if precision < 0 || precision > MaxPrecision {
+		return fmt.Errorf("%w: invalid precision %d: expected between 0 and %d decimal places",
+			apperrors.ErrInvalidInput, precision, MaxPrecision)
+	}
+	return nil
+}
+
+// MatInverse returns the inverse of the square matrix m, a new matrix that shares no memory with it, such that
+// their matrix product is the identity matrix. Matrices that are not square or are singular have no inverse
+// and are rejected as unprocessable. The inverse is computed exactly with InverseFractions; the values of
+// an integer inverse are decimals with as many decimal places as they need, up to InverseScale, and an inverse
+// with a value that does not fit an int64 is rejected as unprocessable.
+func MatInverse[T Number](m *Matrix[T]) (*Matrix[T], error) {
+	return matInverse(m, InverseScale)
+}
+
+// MatInverseWithPrecision returns the inverse of the square matrix m like MatInverse, with the values of an integer
+// inverse rounded to precision decimal places rather than InverseScale. It fails with ErrInvalidInput when
+// precision is not between 0 and MaxPrecision.
+func MatInverseWithPrecision[T Number](m *Matrix[T], precision int) (*Matrix[T], error) {
+	if err := ValidatePrecision(precision); err != nil {
+		return nil, err
+	}
+	return matInverse(m, precision)
+}
+
+// matInverse returns the inverse of m with the values of an integer inverse rounded to precision decimal places.
+func matInverse[T Number](m *Matrix[T], precision int) (*Matrix[T], error) {
+	inverse, err := InverseFractions(m)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := 0
+	if !isFloat[T]() {
+		for _, row := range inverse {
+			for _, val := range row {
+				scale = max(scale, min(fractionPlaces(val, precision), precision))
+			}
+		}
+	}
+
+	data := make([][]T, len(inverse))
+	for i, row := range inverse {
+		data[i] = make([]T, len(row))
+		for j, val := range row {
+			var ok bool
+			if data[i][j], ok = fromFraction[T](val, scale); !ok {
+				return nil, fmt.Errorf("%w: inverse value at row %d, column %d overflows int64: %s",
+					apperrors.ErrOverflow, i, j, val.FloatString(scale))
+			}
+		}
+	}
+	return &Matrix[T]{Data: data, Scale: scale}, nil
+}
+
+// InverseFractions returns the inverse of the square matrix m as exact fractions, the values MatInverse rounds,
+// computed by Gauss-Jordan elimination on the fractions m holds. Matrices that are not square or are singular
+// are rejected as unprocessable.
+func InverseFractions[T Number](m *Matrix[T]) ([][]*big.Rat, error) {
+	if m.Rows() == 0 {
+		return nil, fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+	}
+	if !m.IsSquare() {
+		return nil, fmt.Errorf("%w: cannot invert a %dx%d matrix: only square matrices have an inverse",
+			apperrors.ErrDimensionMismatch, m.Rows(), m.Cols())
+	}
+
+	// Reducing m to the identity matrix turns the identity matrix next to it into the inverse
+	n := m.Rows()
+	a := make([][]*big.Rat, n)
+	inverse := make([][]*big.Rat, n)
+	for i, row := range m.Data {
+		a[i] = make([]*big.Rat, n)
+		inverse[i] = make([]*big.Rat, n)
+		for j, val := range row {
+			if a[i][j] = toFraction(val, m.Scale); a[i][j] == nil {
+				return nil, fmt.Errorf("%w: value at row %d, column %d is not a finite number",
+					apperrors.ErrUnprocessableEntity, i, j)
+			}
+			inverse[i][j] = new(big.Rat)
+		}
+		inverse[i][i].SetInt64(1)
+	}
+
+	factor := new(big.Rat)
+	for col := range n {
+		pivot := col
+		for pivot < n && a[pivot][col].Sign() == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, fmt.Errorf("%w: matrix is singular, so it has no inverse", apperrors.ErrUnprocessableEntity)
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		inverse[col], inverse[pivot] = inverse[pivot], inverse[col]
+
+		factor.Inv(a[col][col])
+		scaleRow(a[col], factor)
+		scaleRow(inverse[col], factor)
+
+		for i := range n {
+			if i == col || a[i][col].Sign() == 0 {
+				continue
+			}
+			factor.Neg(a[i][col])
+			addScaledRow(a[i], a[col], factor)
+			addScaledRow(inverse[i], inverse[col], factor)
+		}
+	}
+	return inverse, nil
+}
+
+// scaleRow multiplies every value of row by factor.
+func scaleRow(row []*big.Rat, factor *big.Rat) {
+	for _, val := range row {
+		val.Mul(val, factor)
+	}
+}
+
+// addScaledRow adds src multiplied by factor to dst.
+func addScaledRow(dst, src []*big.Rat, factor *big.Rat) {
+	term := new(big.Rat)
+	for j, val := range src {
+		dst[j].Add(dst[j], term.Mul(val, factor))
+	}
+}
+
+// toFraction returns val, a value of a matrix with the given scale, as an exact fraction,
+// or nil for floats that are not finite.
+func toFraction[T Number](val T, scale int) *big.Rat {
+	switch v := any(val).(type) {
+	case int64:
+		return new(big.Rat).SetFrac(big.NewInt(v), pow10(scale))
+	case float64:
+		// Every finite float is a fraction with a power of 2 as denominator
+		return new(big.Rat).SetFloat64(v)
+	default:
+		return new(big.Rat).SetFrac(any(val).(*big.Int), pow10(scale))
+	}
+}
+
+// fromFraction converts frac to T, scaled by 10^scale for integer types and rounded half away from zero.
+// It returns false when the value does not fit an int64.
+func fromFraction[T Number](frac *big.Rat, scale int) (T, bool) {
+	var result T
+	if p, ok := any(&result).(*float64); ok {
+		*p, _ = frac.Float64()
+		return result, true
+	}
+
+	num := new(big.Int).Mul(frac.Num(), pow10(scale))
+	quo, rem := num.QuoRem(num, frac.Denom(), new(big.Int))
+	if rem.Abs(rem).Lsh(rem, 1).Cmp(frac.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(int64(frac.Sign())))
+	}
+
+	switch p := any(&result).(type) {
+	case *int64:
+		if !quo.IsInt64() {
+			return result, false
+		}
+		*p = quo.Int64()
+	case **big.Int:
+		*p = quo
+	}
+	return result, true
+}
+
+// fractionPlaces returns the number of decimal places frac needs to be written exactly, or limit+1 when it
+// needs more than limit or has no exact decimal form, because its denominator has prime factors
+// other than 2 and 5.
+func fractionPlaces(frac *big.Rat, limit int) int {
+	denom := new(big.Int).Set(frac.Denom())
+	twos := int(denom.TrailingZeroBits())
+	denom.Rsh(denom, uint(twos))
+
+	five, rem := big.NewInt(5), new(big.Int)
+	fives := 0
+	for {
+		quo, r := new(big.Int).QuoRem(denom, five, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		denom = quo
+		fives++
+		if fives > limit {
+			return limit + 1
+		}
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return limit + 1
+	}
+	return max(twos, fives)
+}
@@ -0,0 +1,182 @@
+package matrix
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestMatInverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *Matrix[int64]
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "integer inverse",
+			matrix: &Matrix[int64]{Data: [][]int64{{2, 1}, {1, 1}}},
+			want:   "1,-1\n-1,2",
+		},
+		{
+			name:   "decimal inverse",
+			matrix: &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}},
+			want:   "-2,1\n1.5,-0.5",
+		},
+		{
+			name:   "rows swapped to find a pivot",
+			matrix: &Matrix[int64]{Data: [][]int64{{0, 4}, {2, 0}}},
+			want:   "0,0.5\n0.25,0",
+		},
+		{
+			name:   "values rounded to InverseScale decimal places",
+			matrix: &Matrix[int64]{Data: [][]int64{{3, 0}, {0, -6}}},
+			want:   "0.333333333,0\n0,-0.166666667",
+		},
+		{
+			name:   "decimal matrix",
+			matrix: &Matrix[int64]{Data: [][]int64{{5}}, Scale: 1},
+			want:   "2",
+		},
+		{
+			name:    "singular matrix",
+			matrix:  &Matrix[int64]{Data: [][]int64{{1, 2}, {2, 4}}},
+			wantErr: apperrors.ErrUnprocessableEntity,
+		},
+		{
+			name:    "matrix that is not square",
+			matrix:  &Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}},
+			wantErr: apperrors.ErrUnprocessableEntity,
+		},
+		{
+			name:    "inverse beyond int64",
+			matrix:  &Matrix[int64]{Data: [][]int64{{1}}, Scale: 19},
+			wantErr: apperrors.ErrOverflow,
+		},
+		{
+			name:    "empty matrix",
+			matrix:  &Matrix[int64]{},
+			wantErr: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatInverse(tt.matrix)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestMatInverse_Identity(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{2, 1, 0}, {1, 3, 1}, {0, 1, 4}}}
+	original := m.Clone()
+
+	inverse, err := MatInverse(m)
+	require.NoError(t, err)
+	assert.Equal(t, 9, inverse.Scale)
+
+	// The product is the identity matrix but for the rounding of the inverse
+	product, err := MatMul(m, inverse)
+	require.NoError(t, err)
+	for i, row := range product.Data {
+		for j, val := range row {
+			want := int64(0)
+			if i == j {
+				want = 1_000_000_000
+			}
+			assert.InDelta(t, want, val, 5, "row %d, column %d", i, j)
+		}
+	}
+	assert.True(t, original.Equal(m))
+}
+
+func TestMatInverse_Float(t *testing.T) {
+	m := &Matrix[float64]{Data: [][]float64{{4, 7}, {2, 6}}}
+
+	got, err := MatInverse(m)
+	require.NoError(t, err)
+	assert.Equal(t, "0.6,-0.7\n-0.2,0.4", got.String())
+
+	_, err = MatInverse(&Matrix[float64]{Data: [][]float64{{0.5, 1}, {1, 2}}})
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+}
+
+func TestMatInverse_BigInt(t *testing.T) {
+	large, _ := new(big.Int).SetString("100000000000000000000", 10)
+	m := &Matrix[*big.Int]{Data: [][]*big.Int{{large, big.NewInt(0)}, {big.NewInt(0), big.NewInt(8)}}}
+
+	got, err := MatInverse(m)
+	require.NoError(t, err)
+	assert.Equal(t, 9, got.Scale)
+	assert.Equal(t, "0,0\n0,0.125", got.String())
+}
+
+func TestMatInverseWithPrecision(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{3, 0}, {0, -8}}}
+
+	got, err := MatInverseWithPrecision(m, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "0.33,0\n0,-0.13", got.String())
+
+	// Values are written with the decimal places they need, up to the precision
+	got, err = MatInverseWithPrecision(m, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "0.3333,0\n0,-0.125", got.String())
+
+	got, err = MatInverseWithPrecision(m, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0,0\n0,0", got.String())
+
+	for _, precision := range []int{-1, MaxPrecision + 1} {
+		_, err = MatInverseWithPrecision(m, precision)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	}
+}
+
+func TestInverseFractions(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{3, 1}, {5, 2}}, Scale: 1}
+
+	got, err := InverseFractions(m)
+	require.NoError(t, err)
+	assert.Equal(t, "20/1", got[0][0].String())
+	assert.Equal(t, "-10/1", got[0][1].String())
+	assert.Equal(t, "-50/1", got[1][0].String())
+	assert.Equal(t, "30/1", got[1][1].String())
+
+	got, err = InverseFractions(&Matrix[int64]{Data: [][]int64{{3}}})
+	require.NoError(t, err)
+	assert.Equal(t, "1/3", got[0][0].String())
+}
+
+func TestFractionPlaces(t *testing.T) {
+	tests := []struct {
+		frac string
+		want int
+	}{
+		{frac: "3", want: 0},
+		{frac: "1/2", want: 1},
+		{frac: "3/8", want: 3},
+		{frac: "7/20", want: 2},
+		{frac: "1/3", want: InverseScale + 1},
+		{frac: "1/1024", want: 10},
+		{frac: "1/9765625", want: InverseScale + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.frac, func(t *testing.T) {
+			frac, _ := new(big.Rat).SetString(tt.frac)
+			assert.Equal(t, tt.want, fractionPlaces(frac, InverseScale))
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Package matrix is the matrix engine behind the league-matrix service, usable without running the server.
+// It parses CSV matrices of integers, decimals, floats or arbitrary-precision numbers, validates them against
+// the service limits and runs the supported operations:
+//
+//	m, err := matrix.Parse(strings.NewReader("1,2\n3,4\n"))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := matrix.Run(matrix.Sum, m)
+//
+// Errors wrap the sentinels of the pkg/errors package, so callers can classify them with errors.Is.
+package matrix
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+const (
+	// MaxRows is the maximum number of rows of a valid matrix.
+	MaxRows = 10
+
+	// MaxCols is the maximum number of columns of a valid matrix.
+	MaxCols = 10
+
+	// maxPooledTextBuffer bounds the capacity of the buffers kept for reuse by String,
+	// so rendering one unusually large matrix does not pin its buffer in memory.
+	maxPooledTextBuffer = 64 << 10
+)
+
+// DefaultLimits are the dimension limits of a valid matrix: MaxRows by MaxCols.
+var DefaultLimits = Limits{MaxRows: MaxRows, MaxCols: MaxCols}
+
+// textBufferPool holds the buffers String renders matrices into.
+var textBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 256)
+		return &buf
+	},
+}
+
+// Matrix represents a two-dimensional matrix of numeric values of type T.
+// The Data field contains rows of columns, where each row must have the same length.
+//
+// Matrices of integer types hold decimals as fixed-point values: every value of Data is scaled by 10^Scale,
+// so with a Scale of 2 the value 150 stands for 1.5. Scale is 0 for matrices of whole numbers, and is
+// ignored for floats, which hold decimals as they are.
+//
+// Once validated, a matrix is treated as immutable: operations, codecs and the service never modify
+// a matrix they are given, so one parsed instance can be shared by concurrent operations and requests.
+// Callers that need to change values must work on a copy made with Clone.
+type Matrix[T Number] struct {
+	Data  [][]T
+	Scale int
+}
+
+// String renders the matrix as comma-separated rows joined by newlines, without a trailing newline.
+func (m *Matrix[T]) String() string {
+	if m == nil {
+		return ""
+	}
+
+	// Render into a pooled buffer, so the only allocation left is the returned string
+	buf := textBufferPool.Get().(*[]byte)
+	*buf, _ = m.AppendText((*buf)[:0])
+	text := string(*buf)
+	if cap(*buf) <= maxPooledTextBuffer {
+		textBufferPool.Put(buf)
+	}
+	return text
+}
+
+// AppendText appends the text form rendered by String to b and returns the extended buffer.
+// It implements encoding.TextAppender and never fails.
+func (m *Matrix[T]) AppendText(b []byte) ([]byte, error) {
+	if m == nil {
+		return b, nil
+	}
+
+	for i, row := range m.Data {
+		if i > 0 {
+			b = append(b, '\n')
+		}
+		for j, val := range row {
+			if j > 0 {
+				b = append(b, ',')
+			}
+			b = AppendValue(b, val, m.Scale)
+		}
+	}
+	return b, nil
+}
+
+// Rows returns the number of rows of the matrix, which is 0 for a nil matrix.
+func (m *Matrix[T]) Rows() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.Data)
+}
+
+// Cols returns the number of columns of the matrix, taken from its first row, or 0 when it has no rows.
+func (m *Matrix[T]) Cols() int {
+	if m.Rows() == 0 {
+		return 0
+	}
+	return len(m.Data[0])
+}
+
+// At returns the value at row i and column j. Like indexing Data, it panics when either is out of range.
+func (m *Matrix[T]) At(i, j int) T {
+	return m.Data[i][j]
+}
+
+// IsSquare reports whether the matrix has rows and as many rows as columns.
+func (m *Matrix[T]) IsSquare() bool {
+	return m.Rows() > 0 && m.Rows() == m.Cols()
+}
+
+// Equal reports whether m and other have the same dimensions and values.
+// Matrices without rows, including nil ones, are equal to each other, and values are compared
+// whatever the scale they are held at, so 1.5 held at a scale of 1 equals 1.50 held at a scale of 2.
+func (m *Matrix[T]) Equal(other *Matrix[T]) bool {
+	if m.Rows() != other.Rows() {
+		return false
+	}
+	if m.Rows() == 0 {
+		return true
+	}
+
+	// Values held at different scales have a single text form, so they are compared through it
+	if m.Scale != other.Scale && !isFloat[T]() {
+		for i, row := range m.Data {
+			if len(row) != len(other.Data[i]) {
+				return false
+			}
+		}
+		return m.String() == other.String()
+	}
+
+	for i, row := range m.Data {
+		if len(row) != len(other.Data[i]) {
+			return false
+		}
+		for j, val := range row {
+			if !equalValues(val, other.Data[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of m that shares no memory with it, so it can be modified freely.
+// It returns nil for a nil matrix.
+func (m *Matrix[T]) Clone() *Matrix[T] {
+	if m == nil {
+		return nil
+	}
+
+	data := make([][]T, len(m.Data))
+	for i, row := range m.Data {
+		data[i] = make([]T, len(row))
+		for j, val := range row {
+			data[i][j] = cloneValue(val)
+		}
+	}
+	return &Matrix[T]{Data: data, Scale: m.Scale}
+}
+
+// Transpose returns a new matrix whose rows are the columns of m. It shares no memory with m.
+func (m *Matrix[T]) Transpose() *Matrix[T] {
+	rows, cols := m.Rows(), m.Cols()
+
+	transposed := make([][]T, cols)
+	for i := range transposed {
+		transposed[i] = make([]T, rows)
+		for j := range transposed[i] {
+			transposed[i][j] = cloneValue(m.Data[j][i])
+		}
+	}
+	return &Matrix[T]{Data: transposed, Scale: m.Scale}
+}
+
+// Parse reads a CSV matrix of integers or decimals from r and validates it. A leading byte order mark is ignored.
+// Rows are converted as they are read, so input exceeding the dimension limits is rejected
+// without reading the rest of it. Parse does not bound the size of a single row:
+// callers reading untrusted input should limit r.
+func Parse(r io.Reader) (*Matrix[int64], error) {
+	return ParseAs[int64](r)
+}
+
+// ParseAs reads a CSV matrix of values of type T from r and validates it, like Parse.
+func ParseAs[T Number](r io.Reader) (*Matrix[T], error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.ReuseRecord = true
+
+	matrix := &Matrix[T]{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read CSV: %v", apperrors.ErrUnprocessableEntity, err)
+		}
+
+		if err := AppendRow(matrix, record); err != nil {
+			return nil, err
+		}
+	}
+
+	// Empty input produces no rows, so the assembled matrix is checked as a whole
+	if err := Validate(matrix); err != nil {
+		return nil, err
+	}
+	return matrix, nil
+}
+
+// byteOrderMark is the UTF-8 byte order mark spreadsheets write at the start of CSV files.
+const byteOrderMark = "\ufeff"
+
+// skipBOM returns a reader of r without its leading byte order mark, if any.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if prefix, err := br.Peek(len(byteOrderMark)); err == nil && string(prefix) == byteOrderMark {
+		_, _ = br.Discard(len(byteOrderMark))
+	}
+	return br
+}
+
+// AppendRow checks the next row of a matrix being read and appends its converted values to matrix.
+// Dimension limits are enforced as rows arrive, so oversized input is rejected as soon as a limit is crossed.
+// Each value must be a base-10 number with an optional sign and fraction that fits in T; spaces and tabs
+// around it are ignored. For integer types, a row with more decimal places than the rows before it raises
+// the Scale of matrix to its places, rescaling the values already appended, and fails with ErrOverflow when
+// they no longer fit in T. It is the building block of Parse for callers that read rows themselves.
+func AppendRow[T Number](matrix *Matrix[T], row []string) error {
+	return AppendRowWithin(matrix, row, DefaultLimits)
+}
+
+// AppendRowWithin is AppendRow for matrices bounded by limits instead of DefaultLimits.
+func AppendRowWithin[T Number](matrix *Matrix[T], row []string, limits Limits) error {
+	i := matrix.Rows()
+
+	// Validate maximum dimensions before converting anything
+	if i >= limits.MaxRows {
+		return fmt.Errorf("%w: matrix exceeds maximum row limit: got more than %d rows",
+			apperrors.ErrMatrixTooLarge, limits.MaxRows)
+	}
+
+	if len(row) > limits.MaxCols {
+		return fmt.Errorf("%w: matrix exceeds maximum column limit: got %d columns, maximum is %d",
+			apperrors.ErrMatrixTooLarge, len(row), limits.MaxCols)
+	}
+
+	// Validate that the row has the same number of columns as the first one
+	if i > 0 && len(row) != matrix.Cols() {
+		return fmt.Errorf("%w: inconsistent row length at row %d: expected %d columns, got %d",
+			apperrors.ErrDimensionMismatch, i, matrix.Cols(), len(row))
+	}
+
+	// Convert string data to numbers
+	values, scale, err := parseRow(make([]T, 0, len(row)), row, i, matrix.Scale)
+	if err != nil {
+		return err
+	}
+	if err := matrix.rescale(scale); err != nil {
+		return err
+	}
+
+	matrix.Data = append(matrix.Data, values)
+	return nil
+}
+
+// Validate checks that matrix is not empty, fits the dimension limits, has no negative Scale and has rows
+// of equal length without missing values. It is meant for matrices built by the caller, since Parse already returns
+// validated matrices.
+func Validate[T Number](matrix *Matrix[T]) error {
+	return ValidateWithin(matrix, DefaultLimits)
+}
+
+// ValidateWithin is Validate for matrices bounded by limits instead of DefaultLimits.
+func ValidateWithin[T Number](matrix *Matrix[T], limits Limits) error {
+	if matrix.Rows() == 0 {
+		return fmt.Errorf("%w: empty matrix data", apperrors.ErrUnprocessableEntity)
+	}
+
+	rows := matrix.Rows()
+	cols := matrix.Cols()
+
+	// Validate maximum dimensions
+	if rows > limits.MaxRows {
+		return fmt.Errorf("%w: matrix exceeds maximum row limit: got %d rows, maximum is %d",
+			apperrors.ErrMatrixTooLarge, rows, limits.MaxRows)
+	}
+
+	if cols > limits.MaxCols {
+		return fmt.Errorf("%w: matrix exceeds maximum column limit: got %d columns, maximum is %d",
+			apperrors.ErrMatrixTooLarge, cols, limits.MaxCols)
+	}
+
+	if matrix.Scale < 0 {
+		return fmt.Errorf("%w: invalid matrix scale: %d", apperrors.ErrUnprocessableEntity, matrix.Scale)
+	}
+
+	// Validate that all rows have the same number of columns and hold a value in each
+	for i, row := range matrix.Data {
+		if len(row) != cols {
+			return fmt.Errorf("%w: inconsistent row length at row %d: expected %d columns, got %d",
+				apperrors.ErrDimensionMismatch, i, cols, len(row))
+		}
+		for j, val := range row {
+			if missingValue(val) {
+				return fmt.Errorf("%w: missing value at row %d, column %d", apperrors.ErrUnprocessableEntity, i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseRow appends the converted values of row, the i-th row of a matrix, to dst. Values of integer types
+// are scaled to the most decimal places of scale and the values of row, which parseRow returns as the scale
+// of the row.
+func parseRow[T Number](dst []T, row []string, i, scale int) ([]T, int, error) {
+	if !isFloat[T]() {
+		for _, val := range row {
+			scale = max(scale, decimalPlaces(val))
+		}
+	}
+
+	for j, val := range row {
+		num, err := parseValue[T](val, scale)
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, 0, fmt.Errorf("%w: number value out of range at row %d, column %d: %q",
+				apperrors.ErrOverflow, i, j, val)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid number value at row %d, column %d: %q",
+				apperrors.ErrUnprocessableEntity, i, j, val)
+		}
+		dst = append(dst, num)
+	}
+	return dst, scale, nil
+}
+
+// rescale raises the Scale of m to scale, scaling its values in place. It fails with ErrOverflow when
+// a value no longer fits in T, leaving m partly rescaled, so it is only meant for matrices being built.
+func (m *Matrix[T]) rescale(scale int) error {
+	if scale <= m.Scale {
+		return nil
+	}
+
+	for i, row := range m.Data {
+		for j, val := range row {
+			scaled, ok := rescaleValue(val, scale-m.Scale)
+			if !ok {
+				return fmt.Errorf("%w: number value out of range with %d decimal places at row %d, column %d",
+					apperrors.ErrOverflow, scale, i, j)
+			}
+			row[j] = scaled
+		}
+	}
+	m.Scale = scale
+	return nil
+}
@@ -0,0 +1,350 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"math"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *Matrix[int64]
+		errType error
+	}{
+		{name: "valid matrix", input: "1,2\n3,4\n", want: &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}},
+		{name: "negative values", input: "-1,0\n", want: &Matrix[int64]{Data: [][]int64{{-1, 0}}}},
+		{name: "maximum size", input: strings.Repeat("1,2,3,4,5,6,7,8,9,10\n", MaxRows), want: &Matrix[int64]{Data: fill(MaxRows, MaxCols)}},
+		{name: "empty input", input: "", errType: apperrors.ErrUnprocessableEntity},
+		{name: "spaces around values", input: "1, 2\n\t3 ,+4\n", want: &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}},
+		{name: "int64 bounds", input: "9223372036854775807,-9223372036854775808\n", want: &Matrix[int64]{Data: [][]int64{{math.MaxInt64, math.MinInt64}}}},
+		{name: "invalid integer", input: "1,a\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "trailing garbage", input: "1,12abc\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "decimal value", input: "1,2.5\n", want: &Matrix[int64]{Data: [][]int64{{10, 25}}, Scale: 1}},
+		{name: "exponent notation", input: "1e6,2.5E3\n-1.5e+1,120e-1\n", want: &Matrix[int64]{Data: [][]int64{{1000000, 2500}, {-15, 12}}}},
+		{name: "decimal exponent notation", input: "1,2.5e0\n", want: &Matrix[int64]{Data: [][]int64{{10, 25}}, Scale: 1}},
+		{name: "exponent overflow", input: "1,1e19\n", errType: apperrors.ErrOverflow},
+		{name: "base prefix", input: "1,0x1F\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "digit separators", input: "1,1_000\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "empty value", input: "1, \n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "overflow", input: "1,9223372036854775808\n", errType: apperrors.ErrOverflow},
+		{name: "underflow", input: "1,-9223372036854775809\n", errType: apperrors.ErrOverflow},
+		{name: "byte order mark", input: "\ufeff1,2\n3,4\n", want: &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}},
+		{name: "byte order mark before a quoted value", input: "\ufeff\"1\",2\n", want: &Matrix[int64]{Data: [][]int64{{1, 2}}}},
+		{name: "byte order mark inside the data", input: "1,2\n\ufeff3,4\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "unicode digits", input: "١,٢\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "malformed CSV", input: "1,\"2\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "inconsistent rows", input: "1,2\n3\n", errType: apperrors.ErrUnprocessableEntity},
+		{name: "too many rows", input: strings.Repeat("1\n", MaxRows+1), errType: apperrors.ErrUnprocessableEntity},
+		{name: "too many columns", input: "1,2,3,4,5,6,7,8,9,10,11\n", errType: apperrors.ErrUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAppendRow(t *testing.T) {
+	matrix := &Matrix[int64]{}
+
+	assert.NoError(t, AppendRow(matrix, []string{"1", "2"}))
+	assert.NoError(t, AppendRow(matrix, []string{"3", "4"}))
+	assert.ErrorIs(t, AppendRow(matrix, []string{"5"}), apperrors.ErrUnprocessableEntity)
+
+	// A rejected row leaves the matrix unchanged
+	assert.Equal(t, [][]int64{{1, 2}, {3, 4}}, matrix.Data)
+}
+
+func TestAppendRowWithin(t *testing.T) {
+	limits := Limits{MaxRows: 2, MaxCols: 12}
+	row := strings.Split(strings.Repeat("1,", 11)+"1", ",")
+	matrix := &Matrix[int64]{}
+
+	assert.NoError(t, AppendRowWithin(matrix, row, limits))
+	assert.NoError(t, AppendRowWithin(matrix, row, limits))
+	assert.ErrorContains(t, AppendRowWithin(matrix, row, limits), "got more than 2 rows")
+	assert.ErrorContains(t, AppendRowWithin(&Matrix[int64]{}, append(row, "1"), limits), "got 13 columns, maximum is 12")
+}
+
+func TestAppendRow_Errors(t *testing.T) {
+	err := AppendRow(&Matrix[int64]{}, []string{"1", "99999999999999999999"})
+	assert.ErrorIs(t, err, apperrors.ErrOverflow)
+	assert.ErrorContains(t, err, "out of range at row 0, column 1")
+
+	err = AppendRow(&Matrix[int64]{}, []string{"12abc"})
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	assert.ErrorContains(t, err, `invalid number value at row 0, column 0: "12abc"`)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *Matrix[int64]
+		wantErr bool
+	}{
+		{name: "valid matrix", matrix: &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}},
+		{name: "maximum size", matrix: &Matrix[int64]{Data: fill(MaxRows, MaxCols)}},
+		{name: "nil matrix", matrix: nil, wantErr: true},
+		{name: "no rows", matrix: &Matrix[int64]{}, wantErr: true},
+		{name: "inconsistent rows", matrix: &Matrix[int64]{Data: [][]int64{{1, 2}, {3}}}, wantErr: true},
+		{name: "too many rows", matrix: &Matrix[int64]{Data: fill(MaxRows+1, 1)}, wantErr: true},
+		{name: "too many columns", matrix: &Matrix[int64]{Data: fill(1, MaxCols+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.matrix)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateWithin(t *testing.T) {
+	limits := Limits{MaxRows: 20, MaxCols: 2}
+
+	assert.NoError(t, ValidateWithin(&Matrix[int64]{Data: fill(20, 2)}, limits))
+	assert.ErrorIs(t, ValidateWithin(&Matrix[int64]{Data: fill(21, 2)}, limits), apperrors.ErrUnprocessableEntity)
+	assert.ErrorIs(t, ValidateWithin(&Matrix[int64]{Data: fill(1, 3)}, limits), apperrors.ErrUnprocessableEntity)
+}
+
+func TestMatrix_String(t *testing.T) {
+	assert.Equal(t, "1,2\n-3,4", (&Matrix[int64]{Data: [][]int64{{1, 2}, {-3, 4}}}).String())
+	assert.Equal(t, "", (*Matrix[int64])(nil).String())
+
+	// Rendering reuses pooled buffers, leaving the returned string as the only allocation
+	m := &Matrix[int64]{Data: fill(MaxRows, MaxCols)}
+	allocs := testing.AllocsPerRun(100, func() { _ = m.String() })
+	assert.LessOrEqual(t, allocs, 1.0)
+}
+
+func TestMatrix_AppendText(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{1, 2}, {-3, 4}}}
+
+	got, err := m.AppendText([]byte("matrix:\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "matrix:\n1,2\n-3,4", string(got))
+
+	got, err = (*Matrix[int64])(nil).AppendText([]byte("x"))
+	assert.NoError(t, err)
+	assert.Equal(t, "x", string(got))
+}
+
+// fill builds a rows x cols matrix whose rows hold 1 to cols.
+func fill(rows, cols int) [][]int64 {
+	data := make([][]int64, rows)
+	for i := range data {
+		data[i] = make([]int64, cols)
+		for j := range data[i] {
+			data[i][j] = int64(j + 1)
+		}
+	}
+	return data
+}
+
+func BenchmarkParse(b *testing.B) {
+	input := strings.Repeat("-1000000,2,300,4000,5,60000,7,800,9,1000000\n", MaxRows)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Parse(strings.NewReader(input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMatrix_String(b *testing.B) {
+	m := &Matrix[int64]{Data: fill(MaxRows, MaxCols)}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = m.String()
+	}
+}
+
+func TestMatrix_Dimensions(t *testing.T) {
+	tests := []struct {
+		name     string
+		matrix   *Matrix[int64]
+		rows     int
+		cols     int
+		isSquare bool
+	}{
+		{name: "rectangular", matrix: &Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}, rows: 2, cols: 3},
+		{name: "square", matrix: &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}, rows: 2, cols: 2, isSquare: true},
+		{name: "single value", matrix: &Matrix[int64]{Data: [][]int64{{7}}}, rows: 1, cols: 1, isSquare: true},
+		{name: "no rows", matrix: &Matrix[int64]{}},
+		{name: "nil matrix", matrix: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rows, tt.matrix.Rows())
+			assert.Equal(t, tt.cols, tt.matrix.Cols())
+			assert.Equal(t, tt.isSquare, tt.matrix.IsSquare())
+		})
+	}
+}
+
+func TestMatrix_At(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}
+
+	assert.Equal(t, int64(1), m.At(0, 0))
+	assert.Equal(t, int64(6), m.At(1, 2))
+	assert.Panics(t, func() { m.At(2, 0) })
+}
+
+func TestMatrix_Equal(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+	assert.True(t, m.Equal(&Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}))
+	assert.False(t, m.Equal(&Matrix[int64]{Data: [][]int64{{1, 2}, {3, 5}}}))
+	assert.False(t, m.Equal(&Matrix[int64]{Data: [][]int64{{1, 2}}}))
+	assert.False(t, m.Equal(&Matrix[int64]{Data: [][]int64{{1, 2, 0}, {3, 4, 0}}}))
+	assert.False(t, m.Equal(nil))
+	assert.True(t, (*Matrix[int64])(nil).Equal(&Matrix[int64]{}))
+
+	// Arbitrary-precision values are compared by value, not by pointer
+	a := &Matrix[*big.Int]{Data: bigInts([][]int64{{1, 2}})}
+	assert.True(t, a.Equal(&Matrix[*big.Int]{Data: bigInts([][]int64{{1, 2}})}))
+	assert.False(t, a.Equal(&Matrix[*big.Int]{Data: bigInts([][]int64{{1, 3}})}))
+}
+
+func TestMatrix_Transpose(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{1, 2, 3}, {4, 5, 6}}}
+
+	got := m.Transpose()
+	assert.Equal(t, [][]int64{{1, 4}, {2, 5}, {3, 6}}, got.Data)
+	assert.True(t, m.Equal(got.Transpose()))
+
+	// The transpose shares no memory with the original
+	got.Data[0][0] = 100
+	assert.Equal(t, int64(1), m.At(0, 0))
+
+	assert.Equal(t, 0, (&Matrix[int64]{}).Transpose().Rows())
+}
+
+func TestMatrix_Clone(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+	clone := m.Clone()
+	assert.True(t, m.Equal(clone))
+
+	clone.Data[0][0] = 100
+	clone.Data[1] = append(clone.Data[1], 5)
+	assert.Equal(t, [][]int64{{1, 2}, {3, 4}}, m.Data)
+
+	// Arbitrary-precision values are copied too, not only the rows holding them
+	b := &Matrix[*big.Int]{Data: bigInts([][]int64{{1, 2}})}
+	b.Clone().Data[0][0].SetInt64(100)
+	assert.Equal(t, "1,2", b.String())
+
+	assert.Nil(t, (*Matrix[int64])(nil).Clone())
+}
+
+// fuzzSeeds are CSV inputs the parsing fuzz targets start from: malformed rows, huge numbers, unicode and BOMs.
+var fuzzSeeds = []string{
+	"1,2\n3,4\n",
+	"1, 2\n\t3 ,+4\r\n",
+	"9223372036854775807,-9223372036854775808\n",
+	"1,9223372036854775808\n",
+	"99999999999999999999999999999999999999,1\n",
+	"1.5,-2e3\n",
+	"1e6,2.5E3,-12e-1,0e999999\n",
+	"1e400,1\n",
+	"0x1p-2,1\n",
+	"1_0,2\n",
+	"NaN,Inf\n",
+	"1,\"2\n",
+	"\"1\",\"2\"\n",
+	"1,2\n3\n",
+	"1,,2\n",
+	"\ufeff1,2\n3,4\n",
+	"\ufeff\"1\",2\n",
+	"١,٢\n",
+	"１,２\n",
+	"1 ,2\n",
+	"\xff\xfe1,2\n",
+	"",
+	"\n\n",
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		got, err := Parse(strings.NewReader(input))
+		if err != nil {
+			// Invalid values and values out of range are both rejected as unprocessable
+			assert.Equal(t, http.StatusUnprocessableEntity, apperrors.GetHTTPStatusCode(err), err)
+			return
+		}
+		assert.NoError(t, Validate(got))
+
+		// Spreadsheets save CSV files with a byte order mark, which must not change the values
+		withBOM, err := Parse(strings.NewReader("\ufeff" + strings.TrimPrefix(input, "\ufeff")))
+		if assert.NoError(t, err) {
+			assert.True(t, got.Equal(withBOM), "%q parsed as %v, then as %v with a BOM", input, got, withBOM)
+		}
+
+		// A parsed matrix is printed in a form that parses back to the same values
+		again, err := Parse(strings.NewReader(got.String()))
+		if assert.NoError(t, err) {
+			assert.True(t, got.Equal(again), "%q parsed as %v, then as %v", input, got, again)
+		}
+	})
+}
+
+// decimalNumber matches the base-10 numbers accepted as float64 values.
+var decimalNumber = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+func FuzzParseAs_Float(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		got, err := ParseAs[float64](strings.NewReader(input))
+		if err != nil {
+			assert.Equal(t, http.StatusUnprocessableEntity, apperrors.GetHTTPStatusCode(err), err)
+			return
+		}
+
+		records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(input, "\ufeff"))).ReadAll()
+		assert.NoError(t, err)
+		for i, row := range got.Data {
+			for j, val := range row {
+				assert.False(t, math.IsNaN(val) || math.IsInf(val, 0), "%q parsed as %v", input, val)
+				// Only base-10 numbers are accepted, never hexadecimal floats or digit separators
+				assert.Regexp(t, decimalNumber, strings.Trim(records[i][j], " \t"))
+			}
+		}
+		again, err := ParseAs[float64](strings.NewReader(got.String()))
+		if assert.NoError(t, err) {
+			assert.True(t, got.Equal(again), "%q parsed as %v, then as %v", input, got, again)
+		}
+	})
+}
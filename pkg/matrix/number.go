@@ -0,0 +1,278 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Number is the set of element types a Matrix can hold: 64-bit integers, 64-bit floats
+// and arbitrary-precision integers. Every operation is written once for all of them.
+// Integer types also hold decimals, as fixed-point values scaled by 10^Scale of their matrix.
+type Number interface {
+	int64 | float64 | *big.Int
+}
+
+// isFloat reports whether T is float64, whose arithmetic is not exact.
+func isFloat[T Number]() bool {
+	var zero T
+	_, ok := any(zero).(float64)
+	return ok
+}
+
+// maxExpandedDigits bounds the digits of a number written in exponent notation, far beyond any int64
+// while keeping a short value such as 1e999999999 from claiming unbounded memory.
+const maxExpandedDigits = 1024
+
+// parseValue converts a single matrix value to T, rejecting anything but a base-10 number.
+// Unlike scanning with fmt, trailing garbage such as "12abc" is an error rather than ignored.
+// Integer types hold the value scaled by 10^scale, so with a scale of 2, 1.5 is held as 150; values with more
+// decimal places than scale are rejected. Values may be written in exponent notation, such as 2.5E3.
+// Floats must be finite base-10 numbers, so NaN, infinities, hexadecimal floats and digit separators,
+// which strconv.ParseFloat accepts, are rejected as well.
+func parseValue[T Number](val string, scale int) (T, error) {
+	val = strings.Trim(val, " \t")
+
+	// Parse through a pointer to the result, so values are not boxed into an interface
+	var num T
+	var err error
+	switch p := any(&num).(type) {
+	case *int64:
+		if scale == 0 {
+			*p, err = strconv.ParseInt(val, 10, 64)
+			if !errors.Is(err, strconv.ErrSyntax) {
+				break
+			}
+		}
+		var digits string
+		if digits, err = scaleDigits(val, scale); err == nil {
+			*p, err = strconv.ParseInt(digits, 10, 64)
+		}
+	case *float64:
+		// Overflows to infinity fail with strconv.ErrRange
+		if strings.ContainsFunc(val, notDecimal) {
+			return num, strconv.ErrSyntax
+		}
+		*p, err = strconv.ParseFloat(val, 64)
+	case **big.Int:
+		var ok bool
+		if scale == 0 {
+			if *p, ok = new(big.Int).SetString(val, 10); ok {
+				break
+			}
+		}
+		var digits string
+		if digits, err = scaleDigits(val, scale); err == nil {
+			*p, _ = new(big.Int).SetString(digits, 10)
+		}
+	}
+	return num, err
+}
+
+// decimalPlaces returns the number of decimal places of val, a value parseValue accepts, such as 1 for 2.50
+// or 0 for 2.5E3. It returns 0 for values that are not numbers, which parseValue rejects.
+func decimalPlaces(val string) int {
+	val = strings.Trim(val, " \t")
+	if !strings.ContainsAny(val, ".eE") {
+		return 0
+	}
+	_, places, _ := splitDecimal(val)
+	return places
+}
+
+// scaleDigits rewrites val, a number with at most scale decimal places, as the plain digits of val scaled
+// by 10^scale, such as 150 for 1.5 with a scale of 2. It fails like splitDecimal, and with strconv.ErrSyntax
+// when val has more than scale decimal places.
+func scaleDigits(val string, scale int) (string, error) {
+	digits, places, err := splitDecimal(val)
+	if err != nil {
+		return "", err
+	}
+	if places > scale {
+		return "", strconv.ErrSyntax
+	}
+	if len(digits)+scale-places > maxExpandedDigits {
+		return "", strconv.ErrRange
+	}
+	return digits + strings.Repeat("0", scale-places), nil
+}
+
+// splitDecimal splits a base-10 number with an optional fraction and exponent into its signed digits
+// and the number of those digits after the decimal point, such as -2500 and 0 for -2.5E3, or 125 and 3
+// for 0.1250: trailing zeros of the fraction do not change the value, so they are dropped.
+// It fails with strconv.ErrSyntax when val is not such a number, and with strconv.ErrRange when
+// the number would take more than maxExpandedDigits digits.
+func splitDecimal(val string) (string, int, error) {
+	mantissa, exponent, found := strings.Cut(strings.ToLower(val), "e")
+	exp := 0
+	if found {
+		var err error
+		exp, err = strconv.Atoi(exponent)
+		if errors.Is(err, strconv.ErrRange) {
+			return "", 0, strconv.ErrRange
+		}
+		if err != nil {
+			return "", 0, strconv.ErrSyntax
+		}
+	}
+
+	sign := ""
+	if rest, ok := strings.CutPrefix(mantissa, "-"); ok {
+		sign, mantissa = "-", rest
+	} else {
+		mantissa = strings.TrimPrefix(mantissa, "+")
+	}
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+	digits := whole + fraction
+	if digits == "" || strings.ContainsFunc(digits, notDigit) {
+		return "", 0, strconv.ErrSyntax
+	}
+
+	if exp < -maxExpandedDigits || exp > maxExpandedDigits {
+		return "", 0, strconv.ErrRange
+	}
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return "0", 0, nil
+	}
+
+	// places is the number of digits after the decimal point once the exponent moved it
+	places := len(fraction) - exp
+	for places > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		places--
+	}
+	if places < 0 {
+		digits += strings.Repeat("0", -places)
+		places = 0
+	}
+	if len(digits) > maxExpandedDigits || places > maxExpandedDigits {
+		return "", 0, strconv.ErrRange
+	}
+	return sign + digits, places, nil
+}
+
+// notDigit reports whether r is not a base-10 digit.
+func notDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
+
+// notDecimal reports whether r cannot appear in a base-10 number, with an optional fraction and exponent.
+func notDecimal(r rune) bool {
+	return (r < '0' || r > '9') && r != '+' && r != '-' && r != '.' && r != 'e' && r != 'E'
+}
+
+// AppendValue appends the decimal form of val, a value of a matrix held at scale, to b, the way String
+// renders it, and returns the extended buffer. Values of integer types are scaled by 10^scale, and are written
+// with their decimal point in place. Floats use the shortest form that round-trips.
+func AppendValue[T Number](b []byte, val T, scale int) []byte {
+	switch v := any(val).(type) {
+	case int64:
+		if scale == 0 {
+			return strconv.AppendInt(b, v, 10)
+		}
+		var digits [20]byte
+		return appendScaled(b, strconv.AppendInt(digits[:0], v, 10), scale)
+	case float64:
+		return strconv.AppendFloat(b, v, 'g', -1, 64)
+	case *big.Int:
+		if scale == 0 {
+			return v.Append(b, 10)
+		}
+		return appendScaled(b, v.Append(nil, 10), scale)
+	}
+	return b
+}
+
+// appendScaled appends digits, the signed decimal digits of an integer scaled by 10^scale, to b with
+// the decimal point in place, such as 1.5 for 150 with a scale of 2. Trailing zeros of the fraction are
+// dropped, and so is the decimal point when no fraction is left, so a value has one form whatever its scale.
+func appendScaled(b, digits []byte, scale int) []byte {
+	if digits[0] == '-' {
+		b = append(b, '-')
+		digits = digits[1:]
+	}
+	if len(digits) == 1 && digits[0] == '0' {
+		return append(b, '0')
+	}
+	for scale > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		scale--
+	}
+
+	switch {
+	case scale == 0:
+		return append(b, digits...)
+	case len(digits) > scale:
+		b = append(b, digits[:len(digits)-scale]...)
+		b = append(b, '.')
+		return append(b, digits[len(digits)-scale:]...)
+	default:
+		b = append(b, "0."...)
+		for range scale - len(digits) {
+			b = append(b, '0')
+		}
+		return append(b, digits...)
+	}
+}
+
+// formatScaled returns the decimal form of n scaled by 10^scale, like appendScaled.
+func formatScaled(n *big.Int, scale int) string {
+	return string(appendScaled(nil, n.Append(nil, 10), scale))
+}
+
+// pow10 returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// rescaleValue returns a copy of val, an integer scaled by 10^scale, scaled by places more powers of ten,
+// so it holds the same number with places more decimal places. It reports false when the result does not
+// fit in T. Floats are not scaled, so they are returned as they are.
+func rescaleValue[T Number](val T, places int) (T, bool) {
+	var scaled T
+	switch p := any(&scaled).(type) {
+	case *int64:
+		v := any(val).(int64)
+		for range places {
+			if v == 0 {
+				break
+			}
+			if v > math.MaxInt64/10 || v < math.MinInt64/10 {
+				return scaled, false
+			}
+			v *= 10
+		}
+		*p = v
+	case *float64:
+		*p = any(val).(float64)
+	case **big.Int:
+		*p = new(big.Int).Mul(any(val).(*big.Int), pow10(places))
+	}
+	return scaled, true
+}
+
+// cloneValue returns a copy of val that shares no memory with it.
+func cloneValue[T Number](val T) T {
+	if n, ok := any(val).(*big.Int); ok {
+		return any(new(big.Int).Set(n)).(T)
+	}
+	return val
+}
+
+// equalValues reports whether a and b hold the same number.
+func equalValues[T Number](a, b T) bool {
+	if x, ok := any(a).(*big.Int); ok {
+		y := any(b).(*big.Int)
+		return x == y || (x != nil && y != nil && x.Cmp(y) == 0)
+	}
+	return a == b
+}
+
+// missingValue reports whether val holds no value, which only nil arbitrary-precision integers do.
+func missingValue[T Number](val T) bool {
+	n, ok := any(val).(*big.Int)
+	return ok && n == nil
+}
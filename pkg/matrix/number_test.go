@@ -0,0 +1,374 @@
+package matrix
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// bigInts converts rows of int64 values to arbitrary-precision integers.
+func bigInts(rows [][]int64) [][]*big.Int {
+	data := make([][]*big.Int, len(rows))
+	for i, row := range rows {
+		data[i] = make([]*big.Int, len(row))
+		for j, val := range row {
+			data[i][j] = big.NewInt(val)
+		}
+	}
+	return data
+}
+
+func TestParseAs_Float(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    [][]float64
+		errType error
+		errText string
+	}{
+		{name: "decimals", input: "1.5,-2\n3e2, .25\n", want: [][]float64{{1.5, -2}, {300, 0.25}}},
+		{name: "invalid number", input: "1,2.5x\n", errText: `invalid number value at row 0, column 1: "2.5x"`},
+		{name: "not a number", input: "NaN\n", errText: "invalid number value"},
+		{name: "infinity", input: "1,-Inf\n", errText: "invalid number value"},
+		{name: "out of range", input: "1e400\n", errType: apperrors.ErrOverflow, errText: "number value out of range"},
+		{name: "hexadecimal float", input: "0x1p-2\n", errText: "invalid number value"},
+		{name: "digit separators", input: "1_000.5\n", errText: "invalid number value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAs[float64](strings.NewReader(tt.input))
+
+			if tt.errText != "" {
+				errType := tt.errType
+				if errType == nil {
+					errType = apperrors.ErrUnprocessableEntity
+				}
+				assert.ErrorIs(t, err, errType)
+				assert.ErrorContains(t, err, tt.errText)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Data)
+			}
+		})
+	}
+}
+
+func TestParseAs_BigInt(t *testing.T) {
+	got, err := ParseAs[*big.Int](strings.NewReader("99999999999999999999999,+1\n-7, 0\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "99999999999999999999999,1\n-7,0", got.String())
+
+	got, err = ParseAs[*big.Int](strings.NewReader("1e30,-2.5E1\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000000000000,-25", got.String())
+
+	got, err = ParseAs[*big.Int](strings.NewReader("1,2.5\n0.125,99999999999999999999999\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, got.Scale)
+	assert.Equal(t, "1,2.5\n0.125,99999999999999999999999", got.String())
+
+	_, err = ParseAs[*big.Int](strings.NewReader("1,2.5x\n"))
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	assert.ErrorContains(t, err, `invalid number value at row 0, column 1: "2.5x"`)
+}
+
+func TestParse_Decimal(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      [][]int64
+		wantScale int
+		text      string
+		errType   error
+		errText   string
+	}{
+		{
+			name:      "decimals",
+			input:     "1.5,2.5\n-3,0.25\n",
+			want:      [][]int64{{150, 250}, {-300, 25}},
+			wantScale: 2,
+			text:      "1.5,2.5\n-3,0.25",
+		},
+		{
+			name:  "trailing zeros keep whole numbers whole",
+			input: "1.0,2.00\n3e0,4\n",
+			want:  [][]int64{{1, 2}, {3, 4}},
+			text:  "1,2\n3,4",
+		},
+		{
+			name:      "later rows rescale earlier ones",
+			input:     "1,2\n0.001,-.5\n",
+			want:      [][]int64{{1000, 2000}, {1, -500}},
+			wantScale: 3,
+			text:      "1,2\n0.001,-0.5",
+		},
+		{
+			name:      "exponent notation",
+			input:     "2.5e-3,1.25E1\n",
+			want:      [][]int64{{25, 125000}},
+			wantScale: 4,
+			text:      "0.0025,12.5",
+		},
+		{
+			name:    "too many places to fit",
+			input:   "9,0.0000000000000000001\n",
+			errType: apperrors.ErrOverflow,
+			errText: `number value out of range at row 0, column 0: "9"`,
+		},
+		{
+			name:    "rescaling beyond int64",
+			input:   "9000000000000000000\n0.5\n",
+			errType: apperrors.ErrOverflow,
+			errText: "number value out of range with 1 decimal places at row 0, column 0",
+		},
+		{
+			name:    "invalid decimal",
+			input:   "1.2.3\n",
+			errText: `invalid number value at row 0, column 0: "1.2.3"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+
+			if tt.errText != "" {
+				errType := tt.errType
+				if errType == nil {
+					errType = apperrors.ErrUnprocessableEntity
+				}
+				assert.ErrorIs(t, err, errType)
+				assert.ErrorContains(t, err, tt.errText)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Data)
+			assert.Equal(t, tt.wantScale, got.Scale)
+			assert.Equal(t, tt.text, got.String())
+		})
+	}
+}
+
+func TestSplitDecimal(t *testing.T) {
+	tests := []struct {
+		val        string
+		want       string
+		wantPlaces int
+		wantErr    error
+	}{
+		{val: "12", want: "12"},
+		{val: "1e6", want: "1000000"},
+		{val: "2.5E3", want: "2500"},
+		{val: "+1.25e2", want: "125"},
+		{val: "-4e0", want: "-4"},
+		{val: "1200e-2", want: "12"},
+		{val: "01.50e1", want: "15"},
+		{val: ".5e1", want: "5"},
+		{val: "-0.0e5", want: "0"},
+		{val: "1.5e0", want: "15", wantPlaces: 1},
+		{val: "1e-1", want: "1", wantPlaces: 1},
+		{val: "-0.1250", want: "-125", wantPlaces: 3},
+		{val: "1.", want: "1"},
+		{val: ".", wantErr: strconv.ErrSyntax},
+		{val: "1.2.3", wantErr: strconv.ErrSyntax},
+		{val: "e5", wantErr: strconv.ErrSyntax},
+		{val: "1e", wantErr: strconv.ErrSyntax},
+		{val: "1e2e3", wantErr: strconv.ErrSyntax},
+		{val: "0x1e3", wantErr: strconv.ErrSyntax},
+		{val: "+-1e3", wantErr: strconv.ErrSyntax},
+		{val: "1e1025", wantErr: strconv.ErrRange},
+		{val: "0e1025", wantErr: strconv.ErrRange},
+		{val: "1e-1025", wantErr: strconv.ErrRange},
+		{val: "1e99999999999999999999", wantErr: strconv.ErrRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.val, func(t *testing.T) {
+			got, places, err := splitDecimal(tt.val)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPlaces, places)
+		})
+	}
+}
+
+func TestAppendValue_Scaled(t *testing.T) {
+	tests := []struct {
+		val   int64
+		scale int
+		want  string
+	}{
+		{val: 150, scale: 2, want: "1.5"},
+		{val: -150, scale: 2, want: "-1.5"},
+		{val: 5, scale: 3, want: "0.005"},
+		{val: -5, scale: 3, want: "-0.005"},
+		{val: 1200, scale: 2, want: "12"},
+		{val: 0, scale: 4, want: "0"},
+		{val: 42, scale: 0, want: "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(AppendValue(nil, tt.val, tt.scale)))
+			assert.Equal(t, tt.want, string(AppendValue(nil, big.NewInt(tt.val), tt.scale)))
+		})
+	}
+}
+
+func TestValidate_MissingValue(t *testing.T) {
+	err := Validate(&Matrix[*big.Int]{Data: [][]*big.Int{{big.NewInt(1), nil}}})
+
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	assert.ErrorContains(t, err, "missing value at row 0, column 1")
+}
+
+func TestRun_Float(t *testing.T) {
+	m := &Matrix[float64]{Data: [][]float64{{1.5, 2}, {-0.25, 4}}}
+
+	tests := []struct {
+		operation Operation
+		want      string
+	}{
+		{operation: Sum, want: "7.25"},
+		{operation: Multiply, want: "-3"},
+		{operation: Echo, want: "1.5,2\n-0.25,4"},
+		{operation: Invert, want: "1.5,-0.25\n2,4"},
+		{operation: Flatten, want: "1.5,2,-0.25,4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.operation), func(t *testing.T) {
+			got, err := Run(tt.operation, m)
+
+			assert.NoError(t, err)
+			if got.Matrix != nil {
+				assert.Equal(t, tt.want, got.Matrix.String())
+			} else {
+				assert.Equal(t, tt.want, got.Scalar)
+			}
+		})
+	}
+}
+
+func TestRun_BigInt(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	m := &Matrix[*big.Int]{Data: [][]*big.Int{{huge, big.NewInt(2)}, {big.NewInt(-3), big.NewInt(4)}}}
+
+	tests := []struct {
+		operation Operation
+		want      string
+	}{
+		{operation: Sum, want: "123456789012345678901234567893"},
+		{operation: Multiply, want: "-2962962936296296293629629629360"},
+		{operation: Echo, want: "123456789012345678901234567890,2\n-3,4"},
+		{operation: Invert, want: "123456789012345678901234567890,-3\n2,4"},
+		{operation: Flatten, want: "123456789012345678901234567890,2,-3,4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.operation), func(t *testing.T) {
+			got, err := Run(tt.operation, m)
+
+			assert.NoError(t, err)
+			if got.Matrix != nil {
+				assert.Equal(t, tt.want, got.Matrix.String())
+			} else {
+				assert.Equal(t, tt.want, got.Scalar)
+			}
+		})
+	}
+
+	t.Run("matrix results do not share values with the input", func(t *testing.T) {
+		got, err := Run(Echo, m)
+		assert.NoError(t, err)
+
+		got.Matrix.Data[0][0].SetInt64(0)
+		assert.Equal(t, "123456789012345678901234567890", huge.String())
+	})
+
+	t.Run("zero short-circuits the product", func(t *testing.T) {
+		got, err := Run(Multiply, &Matrix[*big.Int]{Data: bigInts([][]int64{{5, 0}, {7, 9}})})
+		assert.NoError(t, err)
+		assert.Equal(t, "0", got.Scalar)
+	})
+}
+
+func TestRun_Decimal(t *testing.T) {
+	m, err := Parse(strings.NewReader("1.5,2\n-0.25,4\n"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		operation Operation
+		want      string
+	}{
+		{operation: Sum, want: "7.25"},
+		{operation: Multiply, want: "-3"},
+		{operation: Echo, want: "1.5,2\n-0.25,4"},
+		{operation: Invert, want: "1.5,-0.25\n2,4"},
+		{operation: Flatten, want: "1.5,2,-0.25,4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.operation), func(t *testing.T) {
+			got, err := Run(tt.operation, m)
+
+			assert.NoError(t, err)
+			if got.Matrix != nil {
+				assert.Equal(t, tt.want, got.Matrix.String())
+				assert.Equal(t, m.Scale, got.Matrix.Scale)
+			} else {
+				assert.Equal(t, tt.want, got.Scalar)
+			}
+		})
+	}
+}
+
+func TestStream_Decimal(t *testing.T) {
+	tests := []struct {
+		operation Operation
+		want      string
+	}{
+		{operation: Sum, want: "11.125"},
+		{operation: Multiply, want: "-0.5859375"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.operation), func(t *testing.T) {
+			stream, err := NewStream[int64](tt.operation, Limits{MaxRows: 3, MaxCols: 2})
+			assert.NoError(t, err)
+
+			// The scale rises with each row, rescaling what was aggregated before
+			for _, row := range [][]string{{"3", "5"}, {"0.5", "2.5"}, {"-0.125", "0.25"}} {
+				_, err := stream.AppendRow(row)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 3, stream.Scale())
+
+			got, err := stream.Result()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Scalar)
+		})
+	}
+}
+
+func TestStream_Float(t *testing.T) {
+	stream, err := NewStream[float64](Sum, Limits{MaxRows: 3, MaxCols: 2})
+	assert.NoError(t, err)
+
+	for _, row := range [][]string{{"0.5", "1"}, {"2", "-0.25"}} {
+		_, err := stream.AppendRow(row)
+		assert.NoError(t, err)
+	}
+
+	got, err := stream.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "3.25", got.Scalar)
+}
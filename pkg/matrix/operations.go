@@ -0,0 +1,180 @@
+package matrix
+
+import (
+	"fmt"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Operation names a matrix operation.
+type Operation string
+
+const (
+	// Sum adds every value of the matrix.
+	Sum Operation = "sum"
+
+	// Multiply multiplies every value of the matrix.
+	Multiply Operation = "multiply"
+
+	// Echo returns a copy of the matrix.
+	Echo Operation = "echo"
+
+	// Transpose transposes the matrix, turning rows into columns.
+	Transpose Operation = "transpose"
+
+	// Invert transposes the matrix like Transpose, whose former name it is, kept for existing clients.
+	Invert Operation = "invert"
+
+	// Inverse returns the inverse of a square matrix.
+	Inverse Operation = "inverse"
+
+	// Flatten returns a single row holding every value in row-major order.
+	Flatten Operation = "flatten"
+
+	// Min returns the smallest value of the matrix.
+	Min Operation = "min"
+
+	// Max returns the largest value of the matrix.
+	Max Operation = "max"
+
+	// Mean returns the arithmetic mean of the values of the matrix.
+	Mean Operation = "mean"
+
+	// Median returns the middle value of the matrix, or the mean of the two middle values.
+	Median Operation = "median"
+)
+
+// OperationInfo describes a registered operation. Steps are the built-in operations a composite operation
+// runs in turn, and are empty for the other operations. BuiltIn is only set for the operations of the engine.
+type OperationInfo struct {
+	Name        Operation
+	Description string
+	Steps       []Operation
+	BuiltIn     bool
+	Enabled     bool
+}
+
+// Result represents the outcome of a matrix operation on a matrix of values of type T.
+// Operations that produce a matrix (echo, transpose, inverse, flatten) populate Matrix,
+// while aggregate operations (sum, multiply, min, max, mean, median) populate Scalar with a decimal string,
+// of arbitrary precision for matrices of integer types, decimal ones included. The mean and median of such
+// matrices are exact, so they are written as a fraction in lowest terms, such as 10/3, when they have no
+// decimal with up to InverseScale decimal places, unless RunWithPrecision rounds them.
+type Result[T Number] struct {
+	Matrix *Matrix[T]
+	Scalar string
+}
+
+// Operations returns the names of the enabled operations of DefaultRegistry in alphabetical order.
+func Operations() []string {
+	return DefaultRegistry.Operations()
+}
+
+// DescribeOperations returns every enabled operation of DefaultRegistry with its description, in alphabetical order.
+func DescribeOperations() []OperationInfo {
+	return DefaultRegistry.DescribeOperations()
+}
+
+// ValidateOperation checks that operation names an enabled operation of DefaultRegistry.
+func ValidateOperation(operation string) error {
+	return DefaultRegistry.Validate(operation)
+}
+
+// Run executes operation, an enabled operation of DefaultRegistry, on matrix.
+// Matrix results never share memory with the input matrix. Matrices the operation cannot run on,
+// such as a singular matrix for inverse, are rejected as unprocessable.
+func Run[T Number](operation Operation, matrix *Matrix[T]) (*Result[T], error) {
+	return runIn(DefaultRegistry, operation, matrix, exactPrecision)
+}
+
+// RunWithPrecision executes operation like Run, rounding the decimals of its result to precision decimal places:
+// the inverse of an integer matrix is rounded to precision places rather than InverseScale, and the mean and median
+// of integer matrices are rounded to precision places rather than written as a fraction when they need more.
+// It fails with ErrInvalidInput when precision is not between 0 and MaxPrecision.
+func RunWithPrecision[T Number](operation Operation, matrix *Matrix[T], precision int) (*Result[T], error) {
+	if err := ValidatePrecision(precision); err != nil {
+		return nil, err
+	}
+	return runIn(DefaultRegistry, operation, matrix, precision)
+}
+
+// runIn executes operation, an enabled operation of registry, on matrix, running the steps of composite
+// operations in turn, with results rounded to precision decimal places or exactPrecision.
+func runIn[T Number](registry *Registry, operation Operation, matrix *Matrix[T], precision int) (*Result[T], error) {
+	steps, err := registry.engineSteps(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result[T]{Matrix: matrix}
+	for _, step := range steps {
+		// Only the last step of a composite operation may return a scalar, so every step gets a matrix
+		if result.Matrix.Rows() == 0 {
+			return nil, fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+		}
+		result, err = runBuiltIn(step, result.Matrix, precision)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// runBuiltIn executes a built-in operation on matrix, with results rounded to precision decimal places
+// or exactPrecision.
+func runBuiltIn[T Number](operation Operation, matrix *Matrix[T], precision int) (*Result[T], error) {
+	switch operation {
+	case Echo:
+		return echo(matrix), nil
+	case Transpose, Invert:
+		return transpose(matrix), nil
+	case Inverse:
+		return inverse(matrix, precision)
+	case Flatten:
+		return flatten(matrix), nil
+	case Median:
+		return median(matrix, precision)
+	default:
+		a, _ := newAggregator[T](operation)
+		return aggregate(a, matrix, precision), nil
+	}
+}
+
+// aggregate feeds every row of matrix to an aggregator and returns its result at precision.
+func aggregate[T Number](a aggregator[T], matrix *Matrix[T], precision int) *Result[T] {
+	for _, row := range matrix.Data {
+		a.add(row)
+	}
+	return a.result(matrix.Scale, precision)
+}
+
+func echo[T Number](matrix *Matrix[T]) *Result[T] {
+	return &Result[T]{Matrix: matrix.Clone()}
+}
+
+func transpose[T Number](matrix *Matrix[T]) *Result[T] {
+	return &Result[T]{Matrix: matrix.Transpose()}
+}
+
+func inverse[T Number](matrix *Matrix[T], precision int) (*Result[T], error) {
+	if precision == exactPrecision {
+		precision = InverseScale
+	}
+	inverted, err := matInverse(matrix, precision)
+	if err != nil {
+		return nil, err
+	}
+	return &Result[T]{Matrix: inverted}, nil
+}
+
+func flatten[T Number](matrix *Matrix[T]) *Result[T] {
+	// A flattened matrix is a single row holding every value in row-major order
+	flattened := make([]T, 0, matrix.Rows()*matrix.Cols())
+	for _, row := range matrix.Data {
+		for _, val := range row {
+			flattened = append(flattened, cloneValue(val))
+		}
+	}
+
+	return &Result[T]{Matrix: &Matrix[T]{Data: [][]T{flattened}, Scale: matrix.Scale}}
+}
@@ -0,0 +1,560 @@
+package matrix
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// resultString renders a result like the plain text responses of the HTTP API.
+func resultString(result *Result[int64]) string {
+	if result.Matrix != nil {
+		return result.Matrix.String()
+	}
+	return result.Scalar
+}
+
+func TestOperations(t *testing.T) {
+	// The order is stable across calls even though the registry is a map
+	for range 10 {
+		assert.Equal(t, []string{"echo", "flatten", "inverse", "invert", "max", "mean", "median", "min", "multiply", "sum", "transpose"}, Operations())
+	}
+}
+
+func TestDescribeOperations(t *testing.T) {
+	infos := DescribeOperations()
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, string(info.Name))
+		assert.NotEmpty(t, info.Description, info.Name)
+	}
+	assert.Equal(t, Operations(), names)
+	assert.Equal(t, OperationInfo{Name: Transpose, Description: "Transposes the matrix, turning rows into columns.", BuiltIn: true, Enabled: true}, infos[len(infos)-1])
+}
+
+func TestValidateOperation(t *testing.T) {
+	assert.NoError(t, ValidateOperation("sum"))
+	assert.ErrorIs(t, ValidateOperation("divide"), apperrors.ErrInvalidInput)
+	assert.ErrorIs(t, ValidateOperation(""), apperrors.ErrInvalidInput)
+}
+
+func TestRun_Sum(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *Matrix[int64]
+		want    string
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "sum of 2x2 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2},
+					{3, 4},
+				},
+			},
+			want:    "10",
+			wantErr: false,
+		},
+		{
+			name: "sum of 3x3 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2, 3},
+					{4, 5, 6},
+					{7, 8, 9},
+				},
+			},
+			want:    "45",
+			wantErr: false,
+		},
+		{
+			name: "sum with negative numbers",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{-1, -2},
+					{-3, -4},
+				},
+			},
+			want:    "-10",
+			wantErr: false,
+		},
+		{
+			name: "sum with large numbers",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1000000, 1000000},
+					{1000000, 1000000},
+				},
+			},
+			want:    "4000000",
+			wantErr: false,
+		},
+		{
+			name: "sum of single element",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{42}},
+			},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name:    "empty matrix",
+			matrix:  &Matrix[int64]{Data: [][]int64{}},
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+		{
+			name:    "nil matrix",
+			matrix:  nil,
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(Sum, tt.matrix)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, resultString(got))
+			}
+		})
+	}
+}
+
+func TestRun_Multiply(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *Matrix[int64]
+		want    string
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "multiply 2x2 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{2, 3},
+					{4, 5},
+				},
+			},
+			want:    "120",
+			wantErr: false,
+		},
+		{
+			name: "multiply 3x3 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2, 3},
+					{4, 5, 6},
+					{7, 8, 9},
+				},
+			},
+			want:    "362880",
+			wantErr: false,
+		},
+		{
+			name: "multiply with zero",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 0, 3},
+					{4, 5, 6},
+				},
+			},
+			want:    "0",
+			wantErr: false,
+		},
+		{
+			name: "multiply with negative numbers",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{-2, 3},
+					{4, -5},
+				},
+			},
+			want:    "120",
+			wantErr: false,
+		},
+		{
+			name: "multiply single element",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{7}},
+			},
+			want:    "7",
+			wantErr: false,
+		},
+		{
+			name:    "empty matrix",
+			matrix:  &Matrix[int64]{Data: [][]int64{}},
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+		{
+			name:    "nil matrix",
+			matrix:  nil,
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(Multiply, tt.matrix)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, resultString(got))
+			}
+		})
+	}
+}
+
+func TestRun_Echo(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *Matrix[int64]
+		want    string
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "echo 2x2 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2},
+					{3, 4},
+				},
+			},
+			want:    "1,2\n3,4",
+			wantErr: false,
+		},
+		{
+			name: "echo 3x3 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2, 3},
+					{4, 5, 6},
+					{7, 8, 9},
+				},
+			},
+			want:    "1,2,3\n4,5,6\n7,8,9",
+			wantErr: false,
+		},
+		{
+			name: "echo single element",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{42}},
+			},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name: "echo with negative numbers",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{-1, -2},
+					{-3, -4},
+				},
+			},
+			want:    "-1,-2\n-3,-4",
+			wantErr: false,
+		},
+		{
+			name:    "empty matrix",
+			matrix:  &Matrix[int64]{Data: [][]int64{}},
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+		{
+			name:    "nil matrix",
+			matrix:  nil,
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(Echo, tt.matrix)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, resultString(got))
+			}
+		})
+	}
+}
+
+func TestRun_Invert(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *Matrix[int64]
+		want    string
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "invert 2x2 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2},
+					{3, 4},
+				},
+			},
+			want:    "1,3\n2,4",
+			wantErr: false,
+		},
+		{
+			name: "invert 3x3 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2, 3},
+					{4, 5, 6},
+					{7, 8, 9},
+				},
+			},
+			want:    "1,4,7\n2,5,8\n3,6,9",
+			wantErr: false,
+		},
+		{
+			name: "invert rectangular matrix 2x3",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2, 3},
+					{4, 5, 6},
+				},
+			},
+			want:    "1,4\n2,5\n3,6",
+			wantErr: false,
+		},
+		{
+			name: "invert rectangular matrix 3x2",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2},
+					{3, 4},
+					{5, 6},
+				},
+			},
+			want:    "1,3,5\n2,4,6",
+			wantErr: false,
+		},
+		{
+			name: "invert single element",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{42}},
+			},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name: "invert single row",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{1, 2, 3, 4}},
+			},
+			want:    "1\n2\n3\n4",
+			wantErr: false,
+		},
+		{
+			name: "invert single column",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{1}, {2}, {3}, {4}},
+			},
+			want:    "1,2,3,4",
+			wantErr: false,
+		},
+		{
+			name:    "empty matrix",
+			matrix:  &Matrix[int64]{Data: [][]int64{}},
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+		{
+			name:    "nil matrix",
+			matrix:  nil,
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(Invert, tt.matrix)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, resultString(got))
+			}
+		})
+	}
+}
+
+func TestRun_Flatten(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *Matrix[int64]
+		want    string
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "flatten 2x2 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2},
+					{3, 4},
+				},
+			},
+			want:    "1,2,3,4",
+			wantErr: false,
+		},
+		{
+			name: "flatten 3x3 matrix",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{1, 2, 3},
+					{4, 5, 6},
+					{7, 8, 9},
+				},
+			},
+			want:    "1,2,3,4,5,6,7,8,9",
+			wantErr: false,
+		},
+		{
+			name: "flatten single element",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{42}},
+			},
+			want:    "42",
+			wantErr: false,
+		},
+		{
+			name: "flatten single row",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{{1, 2, 3, 4, 5}},
+			},
+			want:    "1,2,3,4,5",
+			wantErr: false,
+		},
+		{
+			name: "flatten with negative numbers",
+			matrix: &Matrix[int64]{
+				Data: [][]int64{
+					{-1, -2},
+					{-3, -4},
+				},
+			},
+			want:    "-1,-2,-3,-4",
+			wantErr: false,
+		},
+		{
+			name:    "empty matrix",
+			matrix:  &Matrix[int64]{Data: [][]int64{}},
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+		{
+			name:    "nil matrix",
+			matrix:  nil,
+			want:    "",
+			wantErr: true,
+			errType: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(Flatten, tt.matrix)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, resultString(got))
+			}
+		})
+	}
+}
+
+func TestRun_SharedInput(t *testing.T) {
+	// One parsed matrix is shared by concurrent operations, none of which may modify it
+	m, err := Parse(strings.NewReader("2,1,0\n1,3,1\n0,1,4\n"))
+	assert.NoError(t, err)
+	original := m.Clone()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		for _, operation := range Operations() {
+			wg.Go(func() {
+				result, err := Run(Operation(operation), m)
+				assert.NoError(t, err)
+
+				// Matrix results belong to the caller, who may modify them
+				if result.Matrix != nil {
+					result.Matrix.Data[0][0] = -1
+				}
+			})
+		}
+	}
+	wg.Wait()
+
+	assert.True(t, original.Equal(m))
+}
+
+func BenchmarkRun(b *testing.B) {
+	m := &Matrix[int64]{Data: fill(MaxRows, MaxCols)}
+	// Adding to the diagonal makes the matrix of identical rows invertible
+	for i := range m.Data {
+		m.Data[i][i] += 100
+	}
+	for _, operation := range Operations() {
+		b.Run(operation, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := Run(Operation(operation), m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package matrix
+
+import (
+	"fmt"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Plan describes how an operation would run on a matrix of given dimensions, worked out without running it.
+// Rows and Cols are the dimensions of a matrix result, and both are zero when Scalar is set.
+// Cost estimates the work of the operation as the number of values its steps read, so plans of different
+// operations and matrices can be compared; it is not a measure of time.
+type Plan struct {
+	Operation Operation
+	Steps     []Operation
+	Rows      int
+	Cols      int
+	Scalar    bool
+	Cost      int64
+}
+
+// PlanOperation plans operation, an enabled operation of DefaultRegistry, on a matrix of rows by cols values.
+func PlanOperation(operation Operation, rows, cols int) (*Plan, error) {
+	return planIn(DefaultRegistry, operation, rows, cols)
+}
+
+// planIn plans operation, an enabled operation of registry, following the steps of composite operations
+// the way runIn runs them.
+func planIn(registry *Registry, operation Operation, rows, cols int) (*Plan, error) {
+	steps, err := registry.engineSteps(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Operation: operation, Steps: steps}
+	for _, step := range steps {
+		if rows == 0 {
+			return nil, fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+		}
+		cost := int64(rows) * int64(cols)
+
+		switch step {
+		case Transpose, Invert:
+			rows, cols = cols, rows
+		case Inverse:
+			if rows != cols {
+				return nil, fmt.Errorf("%w: cannot invert a %dx%d matrix: only square matrices have an inverse",
+					apperrors.ErrDimensionMismatch, rows, cols)
+			}
+			// Eliminating each column reads every value of the matrix and of its inverse
+			cost *= 2 * int64(cols)
+		case Flatten:
+			rows, cols = 1, rows*cols
+		case Sum, Multiply, Min, Max, Mean, Median:
+			rows, cols = 0, 0
+			plan.Scalar = true
+		}
+		plan.Cost += cost
+	}
+	plan.Rows, plan.Cols = rows, cols
+	return plan, nil
+}
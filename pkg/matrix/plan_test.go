@@ -0,0 +1,85 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestPlanIn(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register("row", "", []Operation{Invert, Flatten}))
+	require.NoError(t, registry.Register("column-sums", "", []Operation{Invert, Sum}))
+	require.NoError(t, registry.SetEnabled(Multiply, false))
+
+	tests := []struct {
+		name      string
+		operation Operation
+		rows      int
+		cols      int
+		want      *Plan
+		wantErr   error
+	}{
+		{
+			name: "echo", operation: Echo, rows: 9, cols: 3,
+			want: &Plan{Operation: Echo, Steps: []Operation{Echo}, Rows: 9, Cols: 3, Cost: 27},
+		},
+		{
+			name: "invert", operation: Invert, rows: 9, cols: 3,
+			want: &Plan{Operation: Invert, Steps: []Operation{Invert}, Rows: 3, Cols: 9, Cost: 27},
+		},
+		{
+			name: "transpose", operation: Transpose, rows: 9, cols: 3,
+			want: &Plan{Operation: Transpose, Steps: []Operation{Transpose}, Rows: 3, Cols: 9, Cost: 27},
+		},
+		{
+			name: "inverse", operation: Inverse, rows: 3, cols: 3,
+			want: &Plan{Operation: Inverse, Steps: []Operation{Inverse}, Rows: 3, Cols: 3, Cost: 54},
+		},
+		{name: "inverse of a matrix that is not square", operation: Inverse, rows: 2, cols: 3,
+			wantErr: apperrors.ErrUnprocessableEntity},
+		{
+			name: "sum", operation: Sum, rows: 2, cols: 5,
+			want: &Plan{Operation: Sum, Steps: []Operation{Sum}, Scalar: true, Cost: 10},
+		},
+		{
+			name: "median", operation: Median, rows: 2, cols: 5,
+			want: &Plan{Operation: Median, Steps: []Operation{Median}, Scalar: true, Cost: 10},
+		},
+		{
+			name: "composite matrix result", operation: "row", rows: 2, cols: 3,
+			want: &Plan{Operation: "row", Steps: []Operation{Invert, Flatten}, Rows: 1, Cols: 6, Cost: 12},
+		},
+		{
+			name: "composite scalar result", operation: "column-sums", rows: 2, cols: 3,
+			want: &Plan{Operation: "column-sums", Steps: []Operation{Invert, Sum}, Scalar: true, Cost: 12},
+		},
+		{name: "empty matrix", operation: Echo, wantErr: apperrors.ErrInvalidInput},
+		{name: "unknown operation", operation: "divide", rows: 1, cols: 1, wantErr: apperrors.ErrInvalidInput},
+		{name: "disabled operation", operation: Multiply, rows: 1, cols: 1, wantErr: apperrors.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := planIn(registry, tt.operation, tt.rows, tt.cols)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPlanOperation(t *testing.T) {
+	// The cost of an operation does not depend on its values, so a large matrix is planned without one
+	got, err := PlanOperation(Multiply, 1_000_000, 10_000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10_000_000_000), got.Cost)
+	assert.True(t, got.Scalar)
+}
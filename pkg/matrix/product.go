@@ -0,0 +1,65 @@
+package matrix
+
+import (
+	"fmt"
+	"math/big"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// MatMul returns the matrix product of a and b, a new matrix that shares no memory with them.
+// The product has the rows of a and the columns of b, so a needs as many columns as b has rows;
+// matrices that do not line up are rejected as unprocessable. Integer products are computed with
+// arbitrary precision and rejected as unprocessable when a value does not fit an int64.
+// The values of a product of decimal matrices have as many decimal places as those of a and b together.
+func MatMul[T Number](a, b *Matrix[T]) (*Matrix[T], error) {
+	if a.Rows() == 0 || b.Rows() == 0 {
+		return nil, fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+	}
+	if a.Cols() != b.Rows() {
+		return nil, fmt.Errorf("%w: cannot multiply matrices: first has %d columns, second has %d rows",
+			apperrors.ErrDimensionMismatch, a.Cols(), b.Rows())
+	}
+
+	data := make([][]T, a.Rows())
+	for i := range data {
+		data[i] = make([]T, b.Cols())
+		for j := range data[i] {
+			val, err := dotProduct(a, b, i, j)
+			if err != nil {
+				return nil, err
+			}
+			data[i][j] = val
+		}
+	}
+	return &Matrix[T]{Data: data, Scale: a.Scale + b.Scale}, nil
+}
+
+// dotProduct multiplies row i of a by column j of b, the value at row i and column j of their product.
+func dotProduct[T Number](a, b *Matrix[T], i, j int) (T, error) {
+	var result T
+	switch p := any(&result).(type) {
+	case *int64:
+		total, factor := new(big.Int), new(big.Int)
+		for k, val := range any(a.Data[i]).([]int64) {
+			factor.Mul(big.NewInt(val), big.NewInt(any(b.Data[k][j]).(int64)))
+			total.Add(total, factor)
+		}
+		if !total.IsInt64() {
+			return result, fmt.Errorf("%w: product value at row %d, column %d overflows int64: %s",
+				apperrors.ErrOverflow, i, j, total)
+		}
+		*p = total.Int64()
+	case *float64:
+		for k, val := range any(a.Data[i]).([]float64) {
+			*p += val * any(b.Data[k][j]).(float64)
+		}
+	case **big.Int:
+		total, factor := new(big.Int), new(big.Int)
+		for k, val := range any(a.Data[i]).([]*big.Int) {
+			total.Add(total, factor.Mul(val, any(b.Data[k][j]).(*big.Int)))
+		}
+		*p = total
+	}
+	return result, nil
+}
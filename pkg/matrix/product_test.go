@@ -0,0 +1,111 @@
+package matrix
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestMatMul(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       [][]int64
+		b       [][]int64
+		want    [][]int64
+		wantErr error
+	}{
+		{
+			name: "square matrices",
+			a:    [][]int64{{1, 2}, {3, 4}},
+			b:    [][]int64{{5, 6}, {7, 8}},
+			want: [][]int64{{19, 22}, {43, 50}},
+		},
+		{
+			name: "rows by columns",
+			a:    [][]int64{{1, 2, 3}, {4, 5, 6}},
+			b:    [][]int64{{7}, {8}, {9}},
+			want: [][]int64{{50}, {122}},
+		},
+		{
+			name: "column by row",
+			a:    [][]int64{{1}, {-2}},
+			b:    [][]int64{{3, 4}},
+			want: [][]int64{{3, 4}, {-6, -8}},
+		},
+		{
+			name: "intermediate products beyond int64",
+			a:    [][]int64{{math.MaxInt64, math.MaxInt64}},
+			b:    [][]int64{{2}, {-2}},
+			want: [][]int64{{0}},
+		},
+		{
+			name:    "product beyond int64",
+			a:       [][]int64{{math.MaxInt64, 1}},
+			b:       [][]int64{{1}, {1}},
+			wantErr: apperrors.ErrOverflow,
+		},
+		{
+			name:    "matrices that do not line up",
+			a:       [][]int64{{1, 2}, {3, 4}},
+			b:       [][]int64{{5, 6}},
+			wantErr: apperrors.ErrUnprocessableEntity,
+		},
+		{
+			name:    "empty matrix",
+			a:       [][]int64{{1}},
+			b:       nil,
+			wantErr: apperrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := &Matrix[int64]{Data: tt.a}, &Matrix[int64]{Data: tt.b}
+			got, err := MatMul(a, b)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Data)
+		})
+	}
+}
+
+func TestMatMul_Float(t *testing.T) {
+	a := &Matrix[float64]{Data: [][]float64{{0.5, 2}}}
+	b := &Matrix[float64]{Data: [][]float64{{4}, {0.25}}}
+
+	got, err := MatMul(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.5", got.String())
+}
+
+func TestMatMul_BigInt(t *testing.T) {
+	large, _ := new(big.Int).SetString("100000000000000000000", 10)
+	a := &Matrix[*big.Int]{Data: [][]*big.Int{{large, big.NewInt(1)}}}
+	b := &Matrix[*big.Int]{Data: [][]*big.Int{{big.NewInt(3)}, {big.NewInt(4)}}}
+
+	got, err := MatMul(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, "300000000000000000004", got.String())
+
+	// Values are computed afresh, so changing the product leaves its factors untouched
+	got.Data[0][0].SetInt64(0)
+	assert.Equal(t, "100000000000000000000", large.String())
+}
+
+func TestMatMul_Decimal(t *testing.T) {
+	a := &Matrix[int64]{Data: [][]int64{{15, 2}}, Scale: 1}
+	b := &Matrix[int64]{Data: [][]int64{{4}, {25}}, Scale: 2}
+
+	got, err := MatMul(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, got.Scale)
+	assert.Equal(t, "0.11", got.String())
+}
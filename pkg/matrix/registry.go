@@ -0,0 +1,238 @@
+package matrix
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// validOperationName restricts the names of registered operations to names that are safe in a URL path.
+var validOperationName = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// DefaultRegistry is the registry Operations, DescribeOperations, ValidateOperation and Run use.
+// It holds the built-in operations, all enabled.
+var DefaultRegistry = NewRegistry()
+
+// Registry holds the operations that can be run and whether each is enabled. Besides the built-in operations,
+// composite operations running a sequence of registered operations can be added at runtime, and so can
+// operations run outside the engine, which the registry only lists, validates and switches on and off.
+// A Registry is safe for concurrent use, so operations can be registered and switched on and off while others run.
+type Registry struct {
+	mu         sync.RWMutex
+	operations map[Operation]*registeredOperation
+}
+
+// registeredOperation is an operation held by a Registry.
+type registeredOperation struct {
+	description string
+	// steps are the built-in operations a composite operation runs in turn, empty for built-in operations
+	steps []Operation
+	// scalar is set for operations returning a scalar rather than a matrix
+	scalar bool
+	// external is set for operations run outside the engine, registered with RegisterExternal
+	external bool
+	disabled bool
+}
+
+// NewRegistry returns a registry holding the built-in operations, all enabled.
+func NewRegistry() *Registry {
+	return &Registry{
+		operations: map[Operation]*registeredOperation{
+			Sum:       {description: "Adds every value of the matrix.", scalar: true},
+			Multiply:  {description: "Multiplies every value of the matrix.", scalar: true},
+			Echo:      {description: "Returns the matrix unchanged."},
+			Transpose: {description: "Transposes the matrix, turning rows into columns."},
+			Invert:    {description: "Transposes the matrix like transpose, its former name."},
+			Inverse:   {description: "Returns the inverse of a square matrix, with decimals rounded to 9 places."},
+			Flatten:   {description: "Returns a single row holding every value in row-major order."},
+			Min:       {description: "Returns the smallest value of the matrix.", scalar: true},
+			Max:       {description: "Returns the largest value of the matrix.", scalar: true},
+			Mean:      {description: "Returns the arithmetic mean of the values of the matrix.", scalar: true},
+			Median:    {description: "Returns the middle value of the matrix, or the mean of the two middle values.", scalar: true},
+		},
+	}
+}
+
+// Register adds a composite operation running steps in turn, each on the matrix returned by the previous one.
+// Steps may be any registered operations, composite ones included, but only the last may return a scalar.
+// It returns ErrConflict when name is already taken and ErrInvalidInput for an invalid name or steps.
+// A composite operation keeps running its steps when they are later disabled.
+func (r *Registry) Register(name Operation, description string, steps []Operation) error {
+	if !validOperationName.MatchString(string(name)) {
+		return fmt.Errorf("%w: invalid operation name %q: use up to 32 lowercase letters, digits, '-' and '_'",
+			apperrors.ErrInvalidInput, name)
+	}
+	if len(steps) == 0 {
+		return fmt.Errorf("%w: operation %s has no steps", apperrors.ErrInvalidInput, name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[name]; ok {
+		return fmt.Errorf("%w: operation %s already exists", apperrors.ErrConflict, name)
+	}
+
+	operation := &registeredOperation{description: description}
+	for i, step := range steps {
+		registered, ok := r.operations[step]
+		if !ok {
+			return fmt.Errorf("%w: unknown step %s of operation %s", apperrors.ErrInvalidInput, step, name)
+		}
+		if registered.external {
+			return fmt.Errorf("%w: step %s of operation %s is not run by the matrix engine",
+				apperrors.ErrInvalidInput, step, name)
+		}
+		if registered.scalar && i < len(steps)-1 {
+			return fmt.Errorf("%w: step %s of operation %s returns a scalar, so it must be the last step",
+				apperrors.ErrInvalidInput, step, name)
+		}
+		// Composite steps are expanded, so the operation does not change if they are
+		if len(registered.steps) > 0 {
+			operation.steps = append(operation.steps, registered.steps...)
+		} else {
+			operation.steps = append(operation.steps, step)
+		}
+		operation.scalar = registered.scalar
+	}
+
+	r.operations[name] = operation
+	return nil
+}
+
+// RegisterExternal adds an operation run outside the engine, such as one implemented by the service embedding it.
+// The registry lists, validates and switches it on and off like the others, but Run and PlanOperation reject it,
+// and it cannot be a step of a composite operation. It returns ErrConflict when name is already taken
+// and ErrInvalidInput for an invalid name.
+func (r *Registry) RegisterExternal(name Operation, description string) error {
+	if !validOperationName.MatchString(string(name)) {
+		return fmt.Errorf("%w: invalid operation name %q: use up to 32 lowercase letters, digits, '-' and '_'",
+			apperrors.ErrInvalidInput, name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[name]; ok {
+		return fmt.Errorf("%w: operation %s already exists", apperrors.ErrConflict, name)
+	}
+	r.operations[name] = &registeredOperation{description: description, external: true}
+	return nil
+}
+
+// SetEnabled enables or disables the operation with the given name. Disabled operations are left out of
+// Operations and DescribeOperations and rejected by Validate. It returns ErrNotFound for unknown operations.
+func (r *Registry) SetEnabled(name Operation, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	operation, ok := r.operations[name]
+	if !ok {
+		return fmt.Errorf("%w: unknown operation: %s", apperrors.ErrNotFound, name)
+	}
+	operation.disabled = !enabled
+	return nil
+}
+
+// Describe returns the operation with the given name, enabled or not, or ErrNotFound for unknown operations.
+func (r *Registry) Describe(name Operation) (OperationInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	operation, ok := r.operations[name]
+	if !ok {
+		return OperationInfo{}, fmt.Errorf("%w: unknown operation: %s", apperrors.ErrNotFound, name)
+	}
+	return operation.info(name), nil
+}
+
+// DescribeAll returns every registered operation, enabled or not, in alphabetical order.
+func (r *Registry) DescribeAll() []OperationInfo {
+	return r.describe(func(*registeredOperation) bool { return true })
+}
+
+// DescribeOperations returns every enabled operation with its description, in alphabetical order.
+func (r *Registry) DescribeOperations() []OperationInfo {
+	return r.describe(func(operation *registeredOperation) bool { return !operation.disabled })
+}
+
+// Operations returns the names of the enabled operations in alphabetical order.
+func (r *Registry) Operations() []string {
+	infos := r.DescribeOperations()
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, string(info.Name))
+	}
+	return names
+}
+
+// Validate checks that operation names an enabled operation. It returns ErrInvalidInput for unknown operations
+// and ErrForbidden for disabled ones.
+func (r *Registry) Validate(operation string) error {
+	_, err := r.steps(Operation(operation))
+	return err
+}
+
+// describe returns the operations matched by include, in alphabetical order.
+func (r *Registry) describe(include func(*registeredOperation) bool) []OperationInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]OperationInfo, 0, len(r.operations))
+	for _, name := range slices.Sorted(maps.Keys(r.operations)) {
+		if operation := r.operations[name]; include(operation) {
+			infos = append(infos, operation.info(name))
+		}
+	}
+	return infos
+}
+
+// steps returns the built-in operations to run for an enabled operation: the operation itself when it is built in.
+func (r *Registry) steps(name Operation) ([]Operation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	operation, ok := r.operations[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid operation: %s", apperrors.ErrInvalidOperation, name)
+	}
+	if operation.disabled {
+		return nil, fmt.Errorf("%w: operation %s is disabled", apperrors.ErrForbidden, name)
+	}
+	if len(operation.steps) == 0 {
+		return []Operation{name}, nil
+	}
+	return slices.Clone(operation.steps), nil
+}
+
+// engineSteps returns the steps of an enabled operation like steps, rejecting the operations run outside the engine.
+func (r *Registry) engineSteps(name Operation) ([]Operation, error) {
+	steps, err := r.steps(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Operations are never removed, so the operation steps found is still registered
+	if r.operations[name].external {
+		return nil, fmt.Errorf("%w: operation %s is not run by the matrix engine", apperrors.ErrInvalidInput, name)
+	}
+	return steps, nil
+}
+
+// info describes the operation registered under name.
+func (o *registeredOperation) info(name Operation) OperationInfo {
+	return OperationInfo{
+		Name:        name,
+		Description: o.description,
+		Steps:       slices.Clone(o.steps),
+		BuiltIn:     len(o.steps) == 0 && !o.external,
+		Enabled:     !o.disabled,
+	}
+}
@@ -0,0 +1,156 @@
+package matrix
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		opName  Operation
+		steps   []Operation
+		wantErr error
+	}{
+		{name: "pipeline", opName: "column-sums", steps: []Operation{Invert, Sum}},
+		{name: "matrix result", opName: "row", steps: []Operation{Invert, Flatten}},
+		{name: "name taken", opName: Sum, steps: []Operation{Echo}, wantErr: apperrors.ErrConflict},
+		{name: "invalid name", opName: "Column Sums", steps: []Operation{Sum}, wantErr: apperrors.ErrInvalidInput},
+		{name: "no steps", opName: "noop", wantErr: apperrors.ErrInvalidInput},
+		{name: "unknown step", opName: "halve", steps: []Operation{"divide"}, wantErr: apperrors.ErrInvalidInput},
+		{name: "scalar before the last step", opName: "twice", steps: []Operation{Sum, Echo}, wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+
+			err := registry.Register(tt.opName, "A registered operation.", tt.steps)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			info, err := registry.Describe(tt.opName)
+			require.NoError(t, err)
+			assert.Equal(t, OperationInfo{
+				Name:        tt.opName,
+				Description: "A registered operation.",
+				Steps:       tt.steps,
+				Enabled:     true,
+			}, info)
+			assert.Contains(t, registry.Operations(), string(tt.opName))
+		})
+	}
+
+	t.Run("composite steps are expanded", func(t *testing.T) {
+		registry := NewRegistry()
+		require.NoError(t, registry.Register("column", "", []Operation{Invert, Flatten}))
+		require.NoError(t, registry.Register("column-total", "", []Operation{"column", Sum}))
+
+		info, err := registry.Describe("column-total")
+		require.NoError(t, err)
+		assert.Equal(t, []Operation{Invert, Flatten, Sum}, info.Steps)
+
+		// A composite returning a scalar cannot be followed by other steps either
+		err = registry.Register("broken", "", []Operation{"column-total", Echo})
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
+
+func TestRegistry_RegisterExternal(t *testing.T) {
+	registry := NewRegistry()
+
+	require.NoError(t, registry.RegisterExternal("trace", "Adds the values of the main diagonal."))
+	assert.ErrorIs(t, registry.RegisterExternal("trace", ""), apperrors.ErrConflict)
+	assert.ErrorIs(t, registry.RegisterExternal(Sum, ""), apperrors.ErrConflict)
+	assert.ErrorIs(t, registry.RegisterExternal("Trace", ""), apperrors.ErrInvalidInput)
+
+	info, err := registry.Describe("trace")
+	require.NoError(t, err)
+	assert.Equal(t, OperationInfo{Name: "trace", Description: "Adds the values of the main diagonal.", Enabled: true}, info)
+	assert.Contains(t, registry.Operations(), "trace")
+	assert.NoError(t, registry.Validate("trace"))
+
+	// The registry switches it on and off, but the engine does not run it
+	matrix := &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+	_, err = runIn(registry, "trace", matrix, exactPrecision)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	_, err = planIn(registry, "trace", 2, 2)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.ErrorIs(t, registry.Register("trace-twice", "", []Operation{Echo, "trace"}), apperrors.ErrInvalidInput)
+
+	require.NoError(t, registry.SetEnabled("trace", false))
+	assert.ErrorIs(t, registry.Validate("trace"), apperrors.ErrForbidden)
+	assert.NotContains(t, registry.Operations(), "trace")
+}
+
+func TestRegistry_SetEnabled(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register("column-sums", "", []Operation{Invert, Sum}))
+
+	require.NoError(t, registry.SetEnabled(Sum, false))
+	assert.ErrorIs(t, registry.Validate("sum"), apperrors.ErrForbidden)
+	assert.Equal(t, []string{"column-sums", "echo", "flatten", "inverse", "invert", "max", "mean", "median", "min", "multiply", "transpose"}, registry.Operations())
+	assert.Len(t, registry.DescribeAll(), 12)
+
+	info, err := registry.Describe(Sum)
+	require.NoError(t, err)
+	assert.False(t, info.Enabled)
+	assert.True(t, info.BuiltIn)
+
+	// Composite operations keep running their disabled steps
+	assert.NoError(t, registry.Validate("column-sums"))
+	result, err := runIn(registry, "column-sums", &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}, exactPrecision)
+	require.NoError(t, err)
+	assert.Equal(t, "10", result.Scalar)
+
+	_, err = runIn(registry, Sum, &Matrix[int64]{Data: [][]int64{{1}}}, exactPrecision)
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+
+	require.NoError(t, registry.SetEnabled(Sum, true))
+	assert.NoError(t, registry.Validate("sum"))
+
+	assert.ErrorIs(t, registry.SetEnabled("divide", false), apperrors.ErrNotFound)
+	_, err = registry.Describe("divide")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestRunIn_Composite(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register("row", "", []Operation{Invert, Flatten}))
+	input := &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+	result, err := runIn(registry, "row", input, exactPrecision)
+	require.NoError(t, err)
+	assert.Equal(t, [][]int64{{1, 3, 2, 4}}, result.Matrix.Data)
+	// The input is left untouched
+	assert.Equal(t, [][]int64{{1, 2}, {3, 4}}, input.Data)
+
+	_, err = runIn(registry, "row", &Matrix[int64]{}, exactPrecision)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	registry := NewRegistry()
+	matrix := &Matrix[int64]{Data: [][]int64{{1, 2}, {3, 4}}}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			_ = registry.SetEnabled(Echo, i%2 == 0)
+			_ = registry.Register(Operation("op-"+string(rune('a'+i))), "", []Operation{Invert, Sum})
+			_, _ = runIn(registry, Sum, matrix, exactPrecision)
+			_ = registry.DescribeAll()
+		})
+	}
+	wg.Wait()
+
+	assert.Len(t, registry.DescribeAll(), 19)
+}
@@ -0,0 +1,45 @@
+package matrix
+
+import (
+	"fmt"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Split divides matrix into a grid of rows by cols tiles of equal size, the inverse of concatenating them.
+// The grid is returned row by row, so tiles[i][j] holds the i-th band of rows and the j-th band of columns.
+// Tiles share no memory with matrix. Matrices whose dimensions are not multiples of the grid are rejected
+// as unprocessable.
+func Split[T Number](matrix *Matrix[T], rows, cols int) ([][]*Matrix[T], error) {
+	if rows < 1 || cols < 1 {
+		return nil, fmt.Errorf("%w: invalid grid of %dx%d tiles", apperrors.ErrInvalidInput, rows, cols)
+	}
+	if matrix.Rows() == 0 {
+		return nil, fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+	}
+	if matrix.Rows()%rows != 0 {
+		return nil, fmt.Errorf("%w: cannot split %d rows into %d equal parts",
+			apperrors.ErrDimensionMismatch, matrix.Rows(), rows)
+	}
+	if matrix.Cols()%cols != 0 {
+		return nil, fmt.Errorf("%w: cannot split %d columns into %d equal parts",
+			apperrors.ErrDimensionMismatch, matrix.Cols(), cols)
+	}
+
+	tileRows, tileCols := matrix.Rows()/rows, matrix.Cols()/cols
+	tiles := make([][]*Matrix[T], rows)
+	for i := range tiles {
+		tiles[i] = make([]*Matrix[T], cols)
+		for j := range tiles[i] {
+			data := make([][]T, tileRows)
+			for k := range data {
+				data[k] = make([]T, tileCols)
+				for l := range data[k] {
+					data[k][l] = cloneValue(matrix.Data[i*tileRows+k][j*tileCols+l])
+				}
+			}
+			tiles[i][j] = &Matrix[T]{Data: data, Scale: matrix.Scale}
+		}
+	}
+	return tiles, nil
+}
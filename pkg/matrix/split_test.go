@@ -0,0 +1,70 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestSplit(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{1, 2, 3, 4}, {5, 6, 7, 8}}}
+
+	tests := []struct {
+		name    string
+		rows    int
+		cols    int
+		want    [][]string
+		wantErr error
+	}{
+		{name: "single tile", rows: 1, cols: 1, want: [][]string{{"1,2,3,4\n5,6,7,8"}}},
+		{name: "grid", rows: 2, cols: 2, want: [][]string{{"1,2", "3,4"}, {"5,6", "7,8"}}},
+		{name: "columns only", rows: 1, cols: 4, want: [][]string{{"1\n5", "2\n6", "3\n7", "4\n8"}}},
+		{name: "rows do not divide evenly", rows: 3, cols: 1, wantErr: apperrors.ErrUnprocessableEntity},
+		{name: "columns do not divide evenly", rows: 1, cols: 3, wantErr: apperrors.ErrUnprocessableEntity},
+		{name: "no tiles", rows: 0, cols: 1, wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Split(m, tt.rows, tt.cols)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, len(tt.want))
+			for i := range got {
+				assert.Len(t, got[i], len(tt.want[i]))
+				for j := range got[i] {
+					assert.Equal(t, tt.want[i][j], got[i][j].String())
+				}
+			}
+		})
+	}
+}
+
+func TestSplit_InverseOfConcat(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{1, 2, 3, 4}, {5, 6, 7, 8}}}
+
+	tiles, err := Split(m, 2, 2)
+	assert.NoError(t, err)
+
+	// Joining the tiles of each band of rows, then the bands, gives the matrix back
+	top, _ := Concat(tiles[0][0], tiles[0][1], Horizontal)
+	bottom, _ := Concat(tiles[1][0], tiles[1][1], Horizontal)
+	joined, err := Concat(top, bottom, Vertical)
+	assert.NoError(t, err)
+	assert.True(t, m.Equal(joined))
+
+	// Tiles share no memory with the matrix
+	tiles[0][0].Data[0][0] = 100
+	assert.Equal(t, int64(1), m.At(0, 0))
+}
+
+func TestSplit_EmptyMatrix(t *testing.T) {
+	_, err := Split(&Matrix[int64]{}, 1, 1)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
@@ -0,0 +1,153 @@
+package matrix
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// extremeAggregator keeps the smallest or the largest value of a matrix. Integer values are compared as they
+// are held, scaled by the power of ten of their row, and the extreme is kept in a big.Int, so it is rescaled
+// without overflowing when later rows hold more decimal places. Floats are compared in a float64.
+type extremeAggregator[T Number] struct {
+	// sign is -1 to keep the smallest value and 1 to keep the largest
+	sign    int
+	extreme *big.Int
+	value   *big.Int
+	float   float64
+	seen    bool
+}
+
+func newExtremeAggregator[T Number](sign int) aggregator[T] {
+	return &extremeAggregator[T]{sign: sign, extreme: new(big.Int), value: new(big.Int)}
+}
+
+func (a *extremeAggregator[T]) add(row []T) {
+	switch row := any(row).(type) {
+	case []int64:
+		for _, val := range row {
+			a.keep(a.value.SetInt64(val))
+		}
+	case []float64:
+		for _, val := range row {
+			if !a.seen || cmp.Compare(val, a.float) == a.sign {
+				a.float = val
+			}
+			a.seen = true
+		}
+	case []*big.Int:
+		for _, val := range row {
+			a.keep(val)
+		}
+	}
+}
+
+// keep keeps val when it is the first value or beyond the extreme kept so far.
+func (a *extremeAggregator[T]) keep(val *big.Int) {
+	if !a.seen || val.Cmp(a.extreme) == a.sign {
+		a.extreme.Set(val)
+	}
+	a.seen = true
+}
+
+func (a *extremeAggregator[T]) rescale(places int) {
+	a.extreme.Mul(a.extreme, pow10(places))
+}
+
+func (a *extremeAggregator[T]) result(scale int, _ int) *Result[T] {
+	if isFloat[T]() {
+		return &Result[T]{Scalar: strconv.FormatFloat(a.float, 'g', -1, 64)}
+	}
+	return &Result[T]{Scalar: formatScaled(a.extreme, scale)}
+}
+
+// meanAggregator adds the values of a matrix like sumAggregator and counts them. The mean of integer values
+// is divided exactly, so it is written with formatFraction; the mean of floats is divided in a float64.
+type meanAggregator[T Number] struct {
+	sum    sumAggregator[T]
+	values int64
+}
+
+func newMeanAggregator[T Number]() aggregator[T] {
+	return &meanAggregator[T]{sum: sumAggregator[T]{total: new(big.Int)}}
+}
+
+func (a *meanAggregator[T]) add(row []T) {
+	a.sum.add(row)
+	a.values += int64(len(row))
+}
+
+func (a *meanAggregator[T]) rescale(places int) {
+	a.sum.rescale(places)
+}
+
+func (a *meanAggregator[T]) result(scale int, precision int) *Result[T] {
+	if isFloat[T]() {
+		return &Result[T]{Scalar: strconv.FormatFloat(a.sum.float/float64(a.values), 'g', -1, 64)}
+	}
+	if a.values == 0 {
+		return &Result[T]{Scalar: "0"}
+	}
+	denom := new(big.Int).Mul(big.NewInt(a.values), pow10(scale))
+	return &Result[T]{Scalar: formatFraction(new(big.Rat).SetFrac(a.sum.value(), denom), precision)}
+}
+
+// median returns the middle value of matrix once its values are sorted, or the mean of the two middle values
+// when it holds an even number of them, written with formatFraction at precision. Unlike the other aggregates,
+// it needs every value at once, so it cannot run on a stream.
+func median[T Number](matrix *Matrix[T], precision int) (*Result[T], error) {
+	values := make([]T, 0, matrix.Rows()*matrix.Cols())
+	for _, row := range matrix.Data {
+		values = append(values, row...)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: empty matrix", apperrors.ErrInvalidInput)
+	}
+
+	slices.SortFunc(values, compareValues[T])
+
+	low, high := values[(len(values)-1)/2], values[len(values)/2]
+	if f, ok := any(low).(float64); ok {
+		// Halving each value first keeps the sum of two large floats from overflowing
+		mid := f/2 + any(high).(float64)/2
+		return &Result[T]{Scalar: strconv.FormatFloat(mid, 'g', -1, 64)}, nil
+	}
+	mid := new(big.Rat).Add(toFraction(low, matrix.Scale), toFraction(high, matrix.Scale))
+	mid.Quo(mid, big.NewRat(2, 1))
+	return &Result[T]{Scalar: formatFraction(mid, precision)}, nil
+}
+
+// compareValues compares a and b like cmp.Compare, returning -1, 0 or +1.
+func compareValues[T Number](a, b T) int {
+	switch x := any(a).(type) {
+	case int64:
+		return cmp.Compare(x, any(b).(int64))
+	case float64:
+		return cmp.Compare(x, any(b).(float64))
+	default:
+		return x.(*big.Int).Cmp(any(b).(*big.Int))
+	}
+}
+
+// formatFraction writes frac with exactPrecision exactly: as a decimal when it has one with up to InverseScale
+// decimal places, such as 2.5, and as a fraction in lowest terms otherwise, such as 10/3 or 1/1024. With another
+// precision, it writes frac as a decimal with as many decimal places as it needs, rounded half away from zero
+// to precision places, such as 3.33 for 10/3 at precision 2.
+func formatFraction(frac *big.Rat, precision int) string {
+	if precision == exactPrecision {
+		places := fractionPlaces(frac, InverseScale)
+		if places > InverseScale {
+			return frac.String()
+		}
+		value, _ := fromFraction[*big.Int](frac, places)
+		return formatScaled(value, places)
+	}
+
+	places := min(fractionPlaces(frac, precision), precision)
+	value, _ := fromFraction[*big.Int](frac, places)
+	return formatScaled(value, places)
+}
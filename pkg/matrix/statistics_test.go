@@ -0,0 +1,144 @@
+package matrix
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestRun_Statistics(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix *Matrix[int64]
+		want   map[Operation]string
+	}{
+		{
+			name:   "2x2 matrix",
+			matrix: &Matrix[int64]{Data: [][]int64{{4, 1}, {3, 2}}},
+			want:   map[Operation]string{Min: "1", Max: "4", Mean: "2.5", Median: "2.5"},
+		},
+		{
+			name:   "odd number of values",
+			matrix: &Matrix[int64]{Data: [][]int64{{7, -2, 5}}},
+			want:   map[Operation]string{Min: "-2", Max: "7", Mean: "10/3", Median: "5"},
+		},
+		{
+			name:   "negative numbers",
+			matrix: &Matrix[int64]{Data: [][]int64{{-1, -2}, {-3, -4}}},
+			want:   map[Operation]string{Min: "-4", Max: "-1", Mean: "-2.5", Median: "-2.5"},
+		},
+		{
+			name:   "single element",
+			matrix: &Matrix[int64]{Data: [][]int64{{42}}},
+			want:   map[Operation]string{Min: "42", Max: "42", Mean: "42", Median: "42"},
+		},
+		{
+			name:   "decimal matrix",
+			matrix: &Matrix[int64]{Data: [][]int64{{150, 25}, {-75, 1000}}, Scale: 2},
+			want:   map[Operation]string{Min: "-0.75", Max: "10", Mean: "2.75", Median: "0.875"},
+		},
+		{
+			name:   "values beyond int64 when added",
+			matrix: &Matrix[int64]{Data: [][]int64{{9223372036854775807, 9223372036854775807}}},
+			want: map[Operation]string{
+				Min: "9223372036854775807", Max: "9223372036854775807",
+				Mean: "9223372036854775807", Median: "9223372036854775807",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for operation, want := range tt.want {
+				got, err := Run(operation, tt.matrix)
+				require.NoError(t, err, operation)
+				assert.Equal(t, want, resultString(got), operation)
+			}
+		})
+	}
+}
+
+func TestRunWithPrecision(t *testing.T) {
+	m := &Matrix[int64]{Data: [][]int64{{7, -2, 5}, {1, 2, 3}}}
+
+	for operation, want := range map[Operation]string{Mean: "2.67", Median: "2.5", Sum: "16"} {
+		got, err := RunWithPrecision(operation, m, 2)
+		require.NoError(t, err, operation)
+		assert.Equal(t, want, got.Scalar, operation)
+	}
+
+	got, err := RunWithPrecision(Inverse, &Matrix[int64]{Data: [][]int64{{3}}}, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "0.3333", got.Matrix.String())
+
+	_, err = RunWithPrecision(Mean, m, MaxPrecision+1)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestRun_StatisticsOfOtherTypes(t *testing.T) {
+	floats := &Matrix[float64]{Data: [][]float64{{1.5, -0.5}, {4, 2}}}
+	huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+	bigInts := &Matrix[*big.Int]{Data: [][]*big.Int{{huge, big.NewInt(-3)}, {big.NewInt(1), big.NewInt(0)}}}
+
+	for operation, want := range map[Operation][2]string{
+		Min:    {"-0.5", "-3"},
+		Max:    {"4", "100000000000000000000"},
+		Mean:   {"1.75", "24999999999999999999.5"},
+		Median: {"1.75", "0.5"},
+	} {
+		got, err := Run(operation, floats)
+		require.NoError(t, err, operation)
+		assert.Equal(t, want[0], got.Scalar, operation)
+
+		gotBig, err := Run(operation, bigInts)
+		require.NoError(t, err, operation)
+		assert.Equal(t, want[1], gotBig.Scalar, operation)
+	}
+}
+
+func TestRun_StatisticsOfEmptyMatrix(t *testing.T) {
+	for _, operation := range []Operation{Min, Max, Mean, Median} {
+		_, err := Run(operation, &Matrix[int64]{Data: [][]int64{}})
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput, operation)
+	}
+}
+
+func TestFormatFraction(t *testing.T) {
+	tests := []struct {
+		frac *big.Rat
+		want string
+	}{
+		{frac: big.NewRat(5, 2), want: "2.5"},
+		{frac: big.NewRat(-3, 1), want: "-3"},
+		{frac: big.NewRat(1, 1024), want: "1/1024"},
+		{frac: big.NewRat(1, 512), want: "0.001953125"},
+		{frac: big.NewRat(10, 3), want: "10/3"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatFraction(tt.frac, exactPrecision), tt.frac.String())
+	}
+}
+
+func TestFormatFraction_Precision(t *testing.T) {
+	tests := []struct {
+		frac      *big.Rat
+		precision int
+		want      string
+	}{
+		{frac: big.NewRat(10, 3), precision: 2, want: "3.33"},
+		{frac: big.NewRat(-2, 3), precision: 3, want: "-0.667"},
+		{frac: big.NewRat(5, 2), precision: 4, want: "2.5"},
+		{frac: big.NewRat(5, 2), precision: 0, want: "3"},
+		{frac: big.NewRat(1, 1024), precision: 12, want: "0.0009765625"},
+		{frac: big.NewRat(1, 1024), precision: 3, want: "0.001"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatFraction(tt.frac, tt.precision), tt.frac.String())
+	}
+}
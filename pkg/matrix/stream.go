@@ -0,0 +1,276 @@
+package matrix
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+// Limits bounds the dimensions of a matrix, such as one read as a stream.
+type Limits struct {
+	MaxRows int
+	MaxCols int
+}
+
+// aggregator folds the rows of a matrix into a scalar, one row at a time. Rows of integer types hold values
+// scaled by a power of ten: rescale is called when rows start being held at places more decimal places,
+// and result gets the scale of the last rows and the precision results are rounded to, or exactPrecision.
+type aggregator[T Number] interface {
+	add(row []T)
+	rescale(places int)
+	result(scale int, precision int) *Result[T]
+}
+
+// newAggregator builds the aggregator of operation for values of type T.
+// It reports false when operation cannot run on a stream of rows.
+func newAggregator[T Number](operation Operation) (aggregator[T], bool) {
+	switch operation {
+	case Sum:
+		return newSumAggregator[T](), true
+	case Multiply:
+		return newProductAggregator[T](), true
+	case Min:
+		return newExtremeAggregator[T](-1), true
+	case Max:
+		return newExtremeAggregator[T](1), true
+	case Mean:
+		return newMeanAggregator[T](), true
+	default:
+		return nil, false
+	}
+}
+
+// Streamable reports whether operation produces its result from rows one at a time,
+// so it can run on a Stream without holding the whole matrix in memory.
+func Streamable(operation Operation) bool {
+	_, ok := newAggregator[int64](operation)
+	return ok
+}
+
+// Stream runs a streamable operation on the rows of a matrix of values of type T as they are read,
+// without keeping them. It applies the same row validation as AppendRow, under its own dimension limits,
+// so matrices far larger than MaxRows x MaxCols can be aggregated in constant memory.
+type Stream[T Number] struct {
+	limits    Limits
+	rows      int
+	cols      int
+	scale     int
+	values    []T
+	aggregate aggregator[T]
+}
+
+// NewStream starts running operation on a stream of rows of values of type T bounded by limits.
+// It fails with ErrInvalidInput for operations that are not streamable.
+func NewStream[T Number](operation Operation, limits Limits) (*Stream[T], error) {
+	aggregate, ok := newAggregator[T](operation)
+	if !ok {
+		return nil, fmt.Errorf("%w: operation cannot run on a stream: %s", apperrors.ErrInvalidInput, operation)
+	}
+
+	return &Stream[T]{
+		limits:    limits,
+		aggregate: aggregate,
+	}, nil
+}
+
+// NewCheckStream starts checking a stream of rows of values of type T bounded by limits. Rows are validated
+// like on a Stream of NewStream, but no operation runs on them, so matrices too large to assemble can be
+// checked without the cost of aggregating them. Its Result holds no value.
+func NewCheckStream[T Number](limits Limits) *Stream[T] {
+	return &Stream[T]{
+		limits:    limits,
+		aggregate: discardAggregator[T]{},
+	}
+}
+
+// AppendRow checks and converts the next row of the stream and feeds it to the operation.
+// It returns the converted values, which are only valid until the next call and are scaled by 10^Scale
+// like the values of a Matrix. Unlike on a Matrix, the values of earlier rows are not rescaled when a row
+// raises the scale, since they are not kept.
+func (s *Stream[T]) AppendRow(row []string) ([]T, error) {
+	if s.rows >= s.limits.MaxRows {
+		return nil, fmt.Errorf("%w: matrix exceeds maximum row limit: got more than %d rows",
+			apperrors.ErrMatrixTooLarge, s.limits.MaxRows)
+	}
+	if len(row) > s.limits.MaxCols {
+		return nil, fmt.Errorf("%w: matrix exceeds maximum column limit: got %d columns, maximum is %d",
+			apperrors.ErrMatrixTooLarge, len(row), s.limits.MaxCols)
+	}
+	if s.rows > 0 && len(row) != s.cols {
+		return nil, fmt.Errorf("%w: inconsistent row length at row %d: expected %d columns, got %d",
+			apperrors.ErrDimensionMismatch, s.rows, s.cols, len(row))
+	}
+
+	// The values buffer is reused between rows, so streaming allocates nothing per row
+	values, scale, err := parseRow(s.values[:0], row, s.rows, s.scale)
+	if err != nil {
+		return nil, err
+	}
+	s.values = values
+	if scale > s.scale {
+		s.aggregate.rescale(scale - s.scale)
+		s.scale = scale
+	}
+
+	s.aggregate.add(values)
+	s.cols = len(row)
+	s.rows++
+	return values, nil
+}
+
+// Rows returns the number of rows appended so far.
+func (s *Stream[T]) Rows() int {
+	return s.rows
+}
+
+// Cols returns the number of columns of the rows appended so far.
+func (s *Stream[T]) Cols() int {
+	return s.cols
+}
+
+// Scale returns the scale of the values returned by the last call to AppendRow: the most decimal places
+// of the rows appended so far.
+func (s *Stream[T]) Scale() int {
+	return s.scale
+}
+
+// Result returns the result of the operation on every row appended.
+// It fails with ErrUnprocessableEntity when no row was appended.
+func (s *Stream[T]) Result() (*Result[T], error) {
+	if s.rows == 0 {
+		return nil, fmt.Errorf("%w: empty matrix data", apperrors.ErrUnprocessableEntity)
+	}
+	return s.aggregate.result(s.scale, exactPrecision), nil
+}
+
+// ResultWithPrecision returns the result of the operation on every row appended like Result, with the mean
+// of integer values rounded to precision decimal places like RunWithPrecision does.
+// It fails with ErrInvalidInput when precision is not between 0 and MaxPrecision.
+func (s *Stream[T]) ResultWithPrecision(precision int) (*Result[T], error) {
+	if err := ValidatePrecision(precision); err != nil {
+		return nil, err
+	}
+	if s.rows == 0 {
+		return nil, fmt.Errorf("%w: empty matrix data", apperrors.ErrUnprocessableEntity)
+	}
+	return s.aggregate.result(s.scale, precision), nil
+}
+
+// sumAggregator adds integers in an int64 while it cannot overflow, carrying into a big.Int otherwise,
+// so typical integer matrices are summed without allocating. Floats are added in a float64.
+type sumAggregator[T Number] struct {
+	partial int64
+	total   *big.Int
+	float   float64
+}
+
+func newSumAggregator[T Number]() aggregator[T] {
+	return &sumAggregator[T]{total: new(big.Int)}
+}
+
+func (a *sumAggregator[T]) add(row []T) {
+	switch row := any(row).(type) {
+	case []int64:
+		for _, val := range row {
+			next := a.partial + val
+			// Signed overflow happened when both operands share a sign the result does not have
+			if (a.partial >= 0) == (val >= 0) && (next >= 0) != (val >= 0) {
+				a.total.Add(a.total, big.NewInt(a.partial))
+				next = val
+			}
+			a.partial = next
+		}
+	case []float64:
+		for _, val := range row {
+			a.float += val
+		}
+	case []*big.Int:
+		for _, val := range row {
+			a.total.Add(a.total, val)
+		}
+	}
+}
+
+func (a *sumAggregator[T]) rescale(places int) {
+	// The partial sum may not fit an int64 once rescaled, so it is carried first
+	a.total.Add(a.total, big.NewInt(a.partial))
+	a.partial = 0
+	a.total.Mul(a.total, pow10(places))
+}
+
+func (a *sumAggregator[T]) result(scale int, _ int) *Result[T] {
+	if isFloat[T]() {
+		return &Result[T]{Scalar: strconv.FormatFloat(a.float, 'g', -1, 64)}
+	}
+	return &Result[T]{Scalar: formatScaled(a.value(), scale)}
+}
+
+// value returns the integer sum, scaled like the values added.
+func (a *sumAggregator[T]) value() *big.Int {
+	return new(big.Int).Add(a.total, big.NewInt(a.partial))
+}
+
+// productAggregator multiplies integers with arbitrary precision and floats in a float64.
+// Once a zero is seen the integer product cannot change, so the remaining values are skipped
+// rather than multiplied. Every factor carries the scale of its row, so the integer product is
+// scaled by 10^scale once per value multiplied.
+type productAggregator[T Number] struct {
+	product *big.Int
+	factor  *big.Int
+	float   float64
+	values  int
+}
+
+func newProductAggregator[T Number]() aggregator[T] {
+	return &productAggregator[T]{product: big.NewInt(1), factor: new(big.Int), float: 1}
+}
+
+func (a *productAggregator[T]) add(row []T) {
+	a.values += len(row)
+	switch row := any(row).(type) {
+	case []int64:
+		if a.product.Sign() == 0 {
+			return
+		}
+		for _, val := range row {
+			a.product.Mul(a.product, a.factor.SetInt64(val))
+		}
+	case []float64:
+		for _, val := range row {
+			a.float *= val
+		}
+	case []*big.Int:
+		if a.product.Sign() == 0 {
+			return
+		}
+		for _, val := range row {
+			a.product.Mul(a.product, val)
+		}
+	}
+}
+
+func (a *productAggregator[T]) rescale(places int) {
+	if a.product.Sign() != 0 {
+		a.product.Mul(a.product, pow10(places*a.values))
+	}
+}
+
+func (a *productAggregator[T]) result(scale int, _ int) *Result[T] {
+	if isFloat[T]() {
+		return &Result[T]{Scalar: strconv.FormatFloat(a.float, 'g', -1, 64)}
+	}
+	return &Result[T]{Scalar: formatScaled(a.product, scale*a.values)}
+}
+
+// discardAggregator ignores the rows of a stream that is only checked.
+type discardAggregator[T Number] struct{}
+
+func (discardAggregator[T]) add([]T) {}
+
+func (discardAggregator[T]) rescale(int) {}
+
+func (discardAggregator[T]) result(int, int) *Result[T] {
+	return &Result[T]{}
+}
@@ -0,0 +1,187 @@
+package matrix
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/matsuboshi/league-matrix-app/pkg/errors"
+)
+
+func TestStreamable(t *testing.T) {
+	assert.True(t, Streamable(Sum))
+	assert.True(t, Streamable(Multiply))
+	assert.True(t, Streamable(Min))
+	assert.True(t, Streamable(Max))
+	assert.True(t, Streamable(Mean))
+	assert.False(t, Streamable(Median))
+	assert.False(t, Streamable(Echo))
+	assert.False(t, Streamable(Invert))
+	assert.False(t, Streamable(Flatten))
+	assert.False(t, Streamable("divide"))
+}
+
+func TestNewStream(t *testing.T) {
+	_, err := NewStream[int64](Invert, Limits{MaxRows: 10, MaxCols: 10})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestStream(t *testing.T) {
+	t.Run("aggregates matrices beyond the engine limits", func(t *testing.T) {
+		stream, err := NewStream[int64](Sum, Limits{MaxRows: 1000, MaxCols: 50})
+		require.NoError(t, err)
+
+		row := make([]string, 50)
+		for j := range row {
+			row[j] = strconv.Itoa(j + 1)
+		}
+		for range 1000 {
+			values, err := stream.AppendRow(row)
+			require.NoError(t, err)
+			assert.Len(t, values, 50)
+		}
+
+		result, err := stream.Result()
+		require.NoError(t, err)
+		// Every row sums to 1+...+50 = 1275
+		assert.Equal(t, "1275000", result.Scalar)
+		assert.Equal(t, 1000, stream.Rows())
+		assert.Equal(t, 50, stream.Cols())
+	})
+
+	t.Run("matches the materialized operations", func(t *testing.T) {
+		m := &Matrix[int64]{Data: [][]int64{{1, -2, 3}, {4, 5, -6}}}
+		for _, operation := range []Operation{Sum, Multiply, Min, Max, Mean} {
+			stream, err := NewStream[int64](operation, Limits{MaxRows: 10, MaxCols: 10})
+			require.NoError(t, err)
+			for _, row := range [][]string{{"1", "-2", "3"}, {"4", "5", "-6"}} {
+				_, err := stream.AppendRow(row)
+				require.NoError(t, err)
+			}
+
+			want, err := Run(operation, m)
+			require.NoError(t, err)
+			got, err := stream.Result()
+			require.NoError(t, err)
+			assert.Equal(t, want, got, operation)
+		}
+	})
+
+	t.Run("rescales the extremes and the mean", func(t *testing.T) {
+		for operation, want := range map[Operation]string{Min: "-2", Max: "4.125", Mean: "0.590625"} {
+			stream, err := NewStream[int64](operation, Limits{MaxRows: 10, MaxCols: 10})
+			require.NoError(t, err)
+			for _, row := range [][]string{{"-2", "1"}, {"0.5", "-1.5"}, {"4.125", "0.1"}, {"1", "1.5"}} {
+				_, err := stream.AppendRow(row)
+				require.NoError(t, err)
+			}
+
+			result, err := stream.Result()
+			require.NoError(t, err)
+			assert.Equal(t, want, result.Scalar, operation)
+		}
+	})
+
+	tests := []struct {
+		name string
+		rows [][]string
+	}{
+		{name: "too many rows", rows: [][]string{{"1"}, {"2"}, {"3"}}},
+		{name: "too many columns", rows: [][]string{{"1", "2", "3"}}},
+		{name: "inconsistent rows", rows: [][]string{{"1", "2"}, {"3"}}},
+		{name: "invalid value", rows: [][]string{{"1", "x"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := NewStream[int64](Sum, Limits{MaxRows: 2, MaxCols: 2})
+			require.NoError(t, err)
+
+			for _, row := range tt.rows {
+				if _, err = stream.AppendRow(row); err != nil {
+					break
+				}
+			}
+			assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+		})
+	}
+
+	t.Run("empty stream", func(t *testing.T) {
+		stream, err := NewStream[int64](Multiply, Limits{MaxRows: 2, MaxCols: 2})
+		require.NoError(t, err)
+
+		_, err = stream.Result()
+		assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+	})
+}
+
+func TestNewCheckStream(t *testing.T) {
+	stream := NewCheckStream[int64](Limits{MaxRows: 2, MaxCols: 2})
+
+	_, err := stream.Result()
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+
+	for _, row := range [][]string{{"1", "2"}, {"3", "4"}} {
+		_, err := stream.AppendRow(row)
+		require.NoError(t, err)
+	}
+	result, err := stream.Result()
+	require.NoError(t, err)
+	assert.Equal(t, &Result[int64]{}, result)
+	assert.Equal(t, 2, stream.Rows())
+	assert.Equal(t, 2, stream.Cols())
+
+	// Rows are held to the limits like on any other stream
+	_, err = stream.AppendRow([]string{"5", "6"})
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+}
+
+func TestStream_ResultWithPrecision(t *testing.T) {
+	stream, err := NewStream[int64](Mean, Limits{MaxRows: 2, MaxCols: 2})
+	require.NoError(t, err)
+
+	_, err = stream.ResultWithPrecision(2)
+	assert.ErrorIs(t, err, apperrors.ErrUnprocessableEntity)
+
+	for _, row := range [][]string{{"1", "2"}, {"4", "3"}} {
+		_, err := stream.AppendRow(row)
+		require.NoError(t, err)
+	}
+	result, err := stream.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", result.Scalar)
+	result, err = stream.ResultWithPrecision(0)
+	require.NoError(t, err)
+	assert.Equal(t, "3", result.Scalar)
+
+	_, err = stream.ResultWithPrecision(MaxPrecision + 1)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+}
+
+func TestSumAggregator(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]int64
+		want string
+	}{
+		{name: "small values", rows: [][]int64{{1, 2}, {3, -4}}, want: "2"},
+		{name: "positive overflow", rows: [][]int64{{math.MaxInt64, math.MaxInt64, 2}}, want: "18446744073709551616"},
+		{name: "negative overflow", rows: [][]int64{{math.MinInt64, -1}, {math.MinInt64}}, want: "-18446744073709551617"},
+		{name: "overflow then back in range", rows: [][]int64{{math.MaxInt64, 1, math.MinInt64}}, want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, aggregate(newSumAggregator[int64](), &Matrix[int64]{Data: tt.rows}, exactPrecision).Scalar)
+		})
+	}
+}
+
+func TestProductAggregator(t *testing.T) {
+	assert.Equal(t, "-24", aggregate(newProductAggregator[int64](), &Matrix[int64]{Data: [][]int64{{1, 2}, {3, -4}}}, exactPrecision).Scalar)
+	assert.Equal(t, "0", aggregate(newProductAggregator[int64](), &Matrix[int64]{Data: [][]int64{{5, 0}, {math.MaxInt64, 7}}}, exactPrecision).Scalar)
+	assert.Equal(t, "85070591730234615847396907784232501249",
+		aggregate(newProductAggregator[int64](), &Matrix[int64]{Data: [][]int64{{math.MaxInt64, math.MaxInt64}}}, exactPrecision).Scalar)
+}
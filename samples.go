@@ -0,0 +1,11 @@
+// Package leaguematrix holds assets that ship inside the league matrix binary.
+package leaguematrix
+
+import "embed"
+
+// Samples contains the sample matrices from testdata/, embedded at build time so the
+// matrix endpoints keep working when the files are not present on disk (e.g. in a scratch container).
+// Paths inside the FS match the on-disk paths, such as "testdata/matrix1.csv".
+//
+//go:embed testdata/*.csv
+var Samples embed.FS